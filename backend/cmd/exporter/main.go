@@ -184,7 +184,7 @@ func Run() {
 	defer db.AlloyWriter.Close()
 	defer db.BigtableClient.Close()
 
-	context, err := modules.GetModuleContext()
+	context, err := modules.GetModuleContext(context.Background())
 	if err != nil {
 		log.Fatal(err, "error getting module context", 0)
 	}
@@ -202,7 +202,11 @@ func Run() {
 			modules.NewSlotExporter(context),
 			modules.NewExecutionDepositsExporter(context),
 			modules.NewExecutionPayloadsExporter(context),
+			modules.NewPoolOperationsTracker(context),
 		)
+		if utils.Config.SlotDutiesPublisher.Enabled {
+			usedModules = append(usedModules, modules.NewSlotDutiesPublisher(context))
+		}
 	}
 
 	go modules.StartAll(context, usedModules, cfg.JustV2)
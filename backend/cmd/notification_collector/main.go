@@ -18,6 +18,7 @@ import (
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 	"github.com/gobitfly/beaconchain/pkg/commons/version"
 	"github.com/gobitfly/beaconchain/pkg/notification"
+	"github.com/gobitfly/beaconchain/pkg/reports"
 
 	//nolint:gosec
 	_ "net/http/pprof"
@@ -215,6 +216,7 @@ func Run() {
 
 	log.Infof("database connection established")
 
+	notification.SetIncomeReportRenderer(reports.NewService(db.ClickHouseReader, db.ReaderDb).RenderAttachment)
 	notification.InitNotificationCollector(utils.Config.Notifications.PubkeyCachePath)
 
 	utils.WaitForCtrlC()
@@ -32,6 +32,7 @@ import (
 )
 
 var ts *httptest.Server
+var dummyTs *httptest.Server
 var dataAccessor dataaccess.DataAccessor
 var postgres *embeddedpostgres.EmbeddedPostgres
 
@@ -59,6 +60,9 @@ func teardown() {
 	if ts != nil {
 		ts.Close()
 	}
+	if dummyTs != nil {
+		dummyTs.Close()
+	}
 	if postgres != nil {
 		err := postgres.Stop()
 		if err != nil {
@@ -164,6 +168,9 @@ func setup() error {
 	}
 	ts.Client().Jar = jar
 
+	// serve the dummy data accessor for handler tests that don't depend on real data
+	dummyTs = httptest.NewTLSServer(api.NewApiRouter(dummy, dummy, cfg))
+
 	return nil
 }
 
@@ -356,6 +363,88 @@ func TestInternalSearchHandler(t *testing.T) {
 	assert.Greater(t, validatorsByWithdrawalAddress.Count, uint64(0), "returned number of validators should be greater than 0")
 }
 
+func TestPublicNetworkValidators(t *testing.T) {
+	t.Parallel()
+	e := httpexpect.WithConfig(getExpectConfig(t, dummyTs))
+
+	t.Run("get validators", func(t *testing.T) {
+		resp := api_types.GetNetworkValidatorsResponse{}
+		e.GET("/api/v2/networks/{network}/validators", "holesky").
+			WithQuery("validators", "1,5,0x9699af2bad9826694a480cb523cbe545dc41db955356b3b0d4871f1cf3e4924ae4132fa8c374a0505ae2076d3d65b3e0").
+			Expect().Status(http.StatusOK).JSON().Decode(&resp)
+		assert.True(t, sort.SliceIsSorted(resp.Data, func(i, j int) bool {
+			return resp.Data[i].Index < resp.Data[j].Index
+		}), "validators should be sorted by index")
+
+		e.GET("/api/v2/networks/{network}/validators", "holesky").
+			Expect().Status(http.StatusBadRequest)
+		e.GET("/api/v2/networks/{network}/validators", "holesky").
+			WithQuery("validators", "0,1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,20").
+			Expect().Status(http.StatusBadRequest)
+		e.GET("/api/v2/networks/{network}/validators", "invalid").
+			WithQuery("validators", "5").
+			Expect().Status(http.StatusBadRequest)
+	})
+
+	t.Run("get validator", func(t *testing.T) {
+		for _, validator := range []string{"5", "0x9699af2bad9826694a480cb523cbe545dc41db955356b3b0d4871f1cf3e4924ae4132fa8c374a0505ae2076d3d65b3e0"} {
+			resp := api_types.GetNetworkValidatorResponse{}
+			e.GET("/api/v2/networks/{network}/validators/{validator}", "holesky", validator).
+				Expect().Status(http.StatusOK).JSON().Decode(&resp)
+
+			dutiesResp := api_types.GetNetworkValidatorDutiesResponse{}
+			e.GET("/api/v2/networks/{network}/validators/{validator}/duties", 17000, validator).
+				WithQuery("epoch", 100).
+				Expect().Status(http.StatusOK).JSON().Decode(&dutiesResp)
+		}
+
+		e.GET("/api/v2/networks/{network}/validators/{validator}", "holesky", "invalid").
+			Expect().Status(http.StatusBadRequest)
+		e.GET("/api/v2/networks/{network}/validators/{validator}/duties", "holesky", "5").
+			WithQuery("epoch", "invalid").
+			Expect().Status(http.StatusBadRequest)
+	})
+
+	t.Run("get validators by deposit address and withdrawal credential", func(t *testing.T) {
+		resp := api_types.GetNetworkAddressValidatorsResponse{}
+		e.GET("/api/v2/networks/{network}/addresses/{address}/validators", "holesky", "0x0e5dda855eb1de2a212cd1f62b2a3ee49d20c444").
+			WithQuery("limit", 10).
+			Expect().Status(http.StatusOK).JSON().Decode(&resp)
+
+		for _, credential := range []string{"0x0e5dda855eb1de2a212cd1f62b2a3ee49d20c444", "0x0100000000000000000000000e5dda855eb1de2a212cd1f62b2a3ee49d20c444"} {
+			resp := api_types.GetNetworkWithdrawalCredentialValidatorsResponse{}
+			e.GET("/api/v2/networks/{network}/withdrawal-credentials/{credential}/validators", "holesky", credential).
+				Expect().Status(http.StatusOK).JSON().Decode(&resp)
+		}
+
+		e.GET("/api/v2/networks/{network}/addresses/{address}/validators", "holesky", "0x1234").
+			Expect().Status(http.StatusBadRequest)
+		e.GET("/api/v2/networks/{network}/withdrawal-credentials/{credential}/validators", "holesky", "0x1234").
+			Expect().Status(http.StatusBadRequest)
+		e.GET("/api/v2/networks/{network}/addresses/{address}/validators", "holesky", "0x0e5dda855eb1de2a212cd1f62b2a3ee49d20c444").
+			WithQuery("limit", 1000).
+			Expect().Status(http.StatusBadRequest)
+	})
+
+	t.Run("get validator statuses, leaderboard and queue", func(t *testing.T) {
+		statusResp := api_types.GetNetworkValidatorStatusesResponse{}
+		e.GET("/api/v2/networks/{network}/validator-statuses", "holesky").
+			Expect().Status(http.StatusOK).JSON().Decode(&statusResp)
+
+		leaderboardResp := api_types.GetNetworkValidatorLeaderboardResponse{}
+		e.GET("/api/v2/networks/{network}/validator-leaderboard", "holesky").
+			WithQuery("limit", 10).
+			Expect().Status(http.StatusOK).JSON().Decode(&leaderboardResp)
+
+		queueResp := api_types.GetNetworkValidatorQueueResponse{}
+		e.GET("/api/v2/networks/{network}/validator-queue", "holesky").
+			Expect().Status(http.StatusOK).JSON().Decode(&queueResp)
+
+		e.GET("/api/v2/networks/{network}/validator-queue", "invalid").
+			Expect().Status(http.StatusBadRequest)
+	})
+}
+
 func TestPublicAndSharedDashboards(t *testing.T) {
 	t.Parallel()
 	e := httpexpect.WithConfig(getExpectConfig(t, ts))
@@ -0,0 +1,31 @@
+package dataaccess
+
+import (
+	"context"
+
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+)
+
+type AccountDashboardRepository interface {
+	GetAccountDashboardUser(ctx context.Context, dashboardId uint64) (*t.DashboardUser, error)
+	GetUserAccountDashboardCount(ctx context.Context, userId uint64) (uint64, error)
+	CreateAccountDashboard(ctx context.Context, userId uint64, name string) (*t.ADBPostReturnData, error)
+	RemoveAccountDashboard(ctx context.Context, dashboardId uint64) error
+	UpdateAccountDashboardName(ctx context.Context, dashboardId uint64, name string) (*t.ADBPostReturnData, error)
+	GetAccountDashboardOverview(ctx context.Context, dashboardId uint64) (*t.ADBOverviewData, error)
+
+	CreateAccountDashboardGroup(ctx context.Context, dashboardId uint64, name string) (*t.ADBPostCreateGroupData, error)
+	RemoveAccountDashboardGroup(ctx context.Context, dashboardId uint64, groupId uint64) error
+	GetAccountDashboardGroupCount(ctx context.Context, dashboardId uint64) (uint64, error)
+	GetAccountDashboardGroupExists(ctx context.Context, dashboardId uint64, groupId uint64) (bool, error)
+
+	AddAccountDashboardAccounts(ctx context.Context, dashboardId uint64, groupId uint64, addresses [][]byte) ([]t.ADBPostAccountsData, error)
+	UpdateAccountDashboardAccount(ctx context.Context, dashboardId uint64, address []byte, groupId uint64) (*t.ADBPostAccountsData, error)
+	RemoveAccountDashboardAccounts(ctx context.Context, dashboardId uint64, addresses [][]byte) error
+	GetAccountDashboardAccounts(ctx context.Context, dashboardId uint64, groupId int64, cursor string, search string, limit uint64) ([]t.ADBAccountsTableRow, *t.Paging, error)
+	GetAccountDashboardAccountCount(ctx context.Context, dashboardId uint64) (uint64, error)
+
+	GetAccountDashboardTransactions(ctx context.Context, dashboardId uint64, groupId int64, cursor string, limit uint64) ([]t.ADBTransactionsTableRow, *t.Paging, error)
+	GetAccountDashboardTransactionSettings(ctx context.Context, dashboardId uint64) (*t.ADBTransactionSettings, error)
+	UpdateAccountDashboardTransactionSettings(ctx context.Context, dashboardId uint64, settings t.ADBTransactionSettings) (*t.ADBTransactionSettings, error)
+}
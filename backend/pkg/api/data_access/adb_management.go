@@ -0,0 +1,442 @@
+package dataaccess
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+func (d *DataAccessService) GetAccountDashboardUser(ctx context.Context, dashboardId uint64) (*t.DashboardUser, error) {
+	result := &t.DashboardUser{}
+
+	err := d.alloyReader.GetContext(ctx, result, `
+		SELECT
+			id,
+			user_id
+		FROM users_acc_dashboards
+		WHERE id = $1
+	`, dashboardId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: dashboard with id %v not found", ErrNotFound, dashboardId)
+	}
+	return result, err
+}
+
+func (d *DataAccessService) GetUserAccountDashboardCount(ctx context.Context, userId uint64) (uint64, error) {
+	var count uint64
+	err := d.alloyReader.GetContext(ctx, &count, `
+		SELECT COUNT(*) FROM users_acc_dashboards WHERE user_id = $1
+	`, userId)
+	return count, err
+}
+
+func (d *DataAccessService) CreateAccountDashboard(ctx context.Context, userId uint64, name string) (*t.ADBPostReturnData, error) {
+	result := &t.ADBPostReturnData{}
+
+	tx, err := d.alloyWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting db transactions to create an account dashboard: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	// Create account dashboard for user
+	err = tx.GetContext(ctx, result, `
+		INSERT INTO users_acc_dashboards (user_id, name)
+			VALUES ($1, $2)
+		RETURNING id, user_id, name, (EXTRACT(epoch FROM created_at))::BIGINT as created_at
+	`, userId, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a default group for the new dashboard
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO users_acc_dashboards_groups (id, dashboard_id, name)
+			VALUES ($1, $2, $3)
+	`, t.DefaultGroupId, result.Id, t.DefaultGroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("error committing tx to create an account dashboard: %w", err)
+	}
+
+	return result, nil
+}
+
+func (d *DataAccessService) RemoveAccountDashboard(ctx context.Context, dashboardId uint64) error {
+	_, err := d.alloyWriter.ExecContext(ctx, `
+		DELETE FROM users_acc_dashboards WHERE id = $1
+	`, dashboardId)
+	if err != nil {
+		return err
+	}
+
+	prefix := fmt.Sprintf("%s:%d:", AccountDashboardEventPrefix, dashboardId)
+
+	// Remove all events related to the dashboard
+	_, err = d.userWriter.ExecContext(ctx, `
+		DELETE FROM users_subscriptions WHERE event_filter LIKE ($1 || '%')
+	`, prefix)
+	return err
+}
+
+func (d *DataAccessService) UpdateAccountDashboardName(ctx context.Context, dashboardId uint64, name string) (*t.ADBPostReturnData, error) {
+	result := &t.ADBPostReturnData{}
+
+	err := d.alloyWriter.GetContext(ctx, result, `
+		UPDATE users_acc_dashboards SET name = $1 WHERE id = $2
+		RETURNING id, user_id, name, (EXTRACT(epoch FROM created_at))::BIGINT as created_at
+	`, name, dashboardId)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (d *DataAccessService) GetAccountDashboardOverview(ctx context.Context, dashboardId uint64) (*t.ADBOverviewData, error) {
+	data := t.ADBOverviewData{
+		Id:     dashboardId,
+		Groups: []t.ADBGroup{},
+	}
+	eg := errgroup.Group{}
+
+	eg.Go(func() error {
+		err := d.alloyReader.GetContext(ctx, &data.Name, `
+			SELECT name FROM users_acc_dashboards WHERE id = $1
+		`, dashboardId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: dashboard with id %v not found", ErrNotFound, dashboardId)
+		}
+		return err
+	})
+
+	eg.Go(func() error {
+		err := d.alloyReader.SelectContext(ctx, &data.Groups, `
+			SELECT
+				g.id,
+				g.name,
+				COUNT(a.address) AS count
+			FROM users_acc_dashboards_groups g
+			LEFT JOIN users_acc_dashboards_accounts a ON a.dashboard_id = g.dashboard_id AND a.group_id = g.id
+			WHERE g.dashboard_id = $1
+			GROUP BY g.id, g.name
+			ORDER BY g.id
+		`, dashboardId)
+		if err != nil {
+			return fmt.Errorf("error retrieving account dashboard groups: %w", err)
+		}
+		for _, group := range data.Groups {
+			data.AccountCount += group.Count
+		}
+		return nil
+	})
+
+	eg.Go(func() error {
+		settings, err := d.GetAccountDashboardTransactionSettings(ctx, dashboardId)
+		if err != nil {
+			return err
+		}
+		data.TransactionSettings = *settings
+		return nil
+	})
+
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (d *DataAccessService) CreateAccountDashboardGroup(ctx context.Context, dashboardId uint64, name string) (*t.ADBPostCreateGroupData, error) {
+	result := &t.ADBPostCreateGroupData{}
+
+	// Create a new group that has the smallest unique id possible
+	err := d.alloyWriter.GetContext(ctx, result, `
+		WITH NextAvailableId AS (
+		    SELECT COALESCE(MIN(uadg1.id) + 1, 0) AS next_id
+		    FROM users_acc_dashboards_groups uadg1
+		    LEFT JOIN users_acc_dashboards_groups uadg2 ON uadg1.id + 1 = uadg2.id AND uadg1.dashboard_id = uadg2.dashboard_id
+		    WHERE uadg1.dashboard_id = $1 AND uadg2.id IS NULL
+		)
+		INSERT INTO users_acc_dashboards_groups (id, dashboard_id, name)
+			SELECT next_id, $1, $2
+		FROM NextAvailableId
+		RETURNING id, name
+	`, dashboardId, name)
+
+	return result, err
+}
+
+func (d *DataAccessService) RemoveAccountDashboardGroup(ctx context.Context, dashboardId uint64, groupId uint64) error {
+	// Delete the group, its accounts are removed by the foreign key constraint
+	_, err := d.alloyWriter.ExecContext(ctx, `
+		DELETE FROM users_acc_dashboards_groups WHERE dashboard_id = $1 AND id = $2
+	`, dashboardId, groupId)
+	if err != nil {
+		return err
+	}
+
+	prefix := fmt.Sprintf("%s:%d:%d", AccountDashboardEventPrefix, dashboardId, groupId)
+
+	// Remove all events related to the group
+	_, err = d.userWriter.ExecContext(ctx, `
+		DELETE FROM users_subscriptions WHERE event_filter = $1
+	`, prefix)
+	return err
+}
+
+func (d *DataAccessService) GetAccountDashboardGroupCount(ctx context.Context, dashboardId uint64) (uint64, error) {
+	var count uint64
+	err := d.alloyReader.GetContext(ctx, &count, `
+		SELECT COUNT(*) FROM users_acc_dashboards_groups WHERE dashboard_id = $1
+	`, dashboardId)
+	return count, err
+}
+
+func (d *DataAccessService) GetAccountDashboardGroupExists(ctx context.Context, dashboardId uint64, groupId uint64) (bool, error) {
+	groupExists := false
+	err := d.alloyReader.GetContext(ctx, &groupExists, `
+		SELECT EXISTS(
+			SELECT
+				dashboard_id,
+				id
+			FROM users_acc_dashboards_groups
+			WHERE dashboard_id = $1 AND id = $2
+		)
+	`, dashboardId, groupId)
+	return groupExists, err
+}
+
+// adds the accounts to the group, accounts that are already part of the dashboard are moved to the group
+func (d *DataAccessService) AddAccountDashboardAccounts(ctx context.Context, dashboardId uint64, groupId uint64, addresses [][]byte) ([]t.ADBPostAccountsData, error) {
+	result := []t.ADBPostAccountsData{}
+	if len(addresses) == 0 {
+		return result, nil
+	}
+
+	added := [][]byte{}
+	err := d.alloyWriter.SelectContext(ctx, &added, `
+		INSERT INTO users_acc_dashboards_accounts (dashboard_id, group_id, address)
+			SELECT $1, $2, address
+			FROM UNNEST($3::BYTEA[]) AS address
+		ON CONFLICT (dashboard_id, address) DO UPDATE SET group_id = EXCLUDED.group_id
+		RETURNING address
+	`, dashboardId, groupId, pq.ByteaArray(addresses))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, address := range added {
+		result = append(result, t.ADBPostAccountsData{
+			Address: t.Hash(hexutil.Encode(address)),
+			GroupId: groupId,
+		})
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) UpdateAccountDashboardAccount(ctx context.Context, dashboardId uint64, address []byte, groupId uint64) (*t.ADBPostAccountsData, error) {
+	result, err := d.alloyWriter.ExecContext(ctx, `
+		UPDATE users_acc_dashboards_accounts SET group_id = $1 WHERE dashboard_id = $2 AND address = $3
+	`, groupId, dashboardId, address)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, fmt.Errorf("%w: account %#x not found in dashboard %d", ErrNotFound, address, dashboardId)
+	}
+
+	return &t.ADBPostAccountsData{
+		Address: t.Hash(hexutil.Encode(address)),
+		GroupId: groupId,
+	}, nil
+}
+
+func (d *DataAccessService) RemoveAccountDashboardAccounts(ctx context.Context, dashboardId uint64, addresses [][]byte) error {
+	if len(addresses) == 0 {
+		return nil
+	}
+	_, err := d.alloyWriter.ExecContext(ctx, `
+		DELETE FROM users_acc_dashboards_accounts
+		WHERE dashboard_id = $1 AND address = ANY($2)
+	`, dashboardId, pq.ByteaArray(addresses))
+	return err
+}
+
+func (d *DataAccessService) GetAccountDashboardAccounts(ctx context.Context, dashboardId uint64, groupId int64, cursor string, search string, limit uint64) ([]t.ADBAccountsTableRow, *t.Paging, error) {
+	var paging t.Paging
+
+	// Initialize the cursor
+	var currentCursor t.ADBAccountsCursor
+	var err error
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.ADBAccountsCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as ADBAccountsCursor: %w", err)
+		}
+	}
+
+	accounts := []struct {
+		Address []byte `db:"address"`
+		GroupId uint64 `db:"group_id"`
+	}{}
+
+	// accounts are sorted by address, which uniquely identifies a row
+	ds := goqu.Dialect("postgres").
+		Select(
+			goqu.C("address"),
+			goqu.C("group_id")).
+		From("users_acc_dashboards_accounts").
+		Where(goqu.C("dashboard_id").Eq(dashboardId)).
+		Limit(uint(limit + 1))
+
+	if groupId != t.AllGroups {
+		ds = ds.Where(goqu.C("group_id").Eq(groupId))
+	}
+	if search != "" {
+		// search for (a prefix of) the hex encoded address
+		ds = ds.Where(goqu.L("encode(address, 'hex') LIKE (? || '%')", utils.StripPrefix(search)))
+	}
+
+	if currentCursor.IsValid() {
+		if currentCursor.IsReverse() {
+			ds = ds.Where(goqu.C("address").Lt(currentCursor.Address)).Order(goqu.C("address").Desc())
+		} else {
+			ds = ds.Where(goqu.C("address").Gt(currentCursor.Address)).Order(goqu.C("address").Asc())
+		}
+	} else {
+		ds = ds.Order(goqu.C("address").Asc())
+	}
+
+	query, args, err := ds.Prepared(true).ToSQL()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing account dashboard accounts query: %w", err)
+	}
+
+	err = d.alloyReader.SelectContext(ctx, &accounts, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving account dashboard accounts: %w", err)
+	}
+
+	// -------------------------------------
+	// Paging
+
+	// Flag if above limit
+	moreDataFlag := len(accounts) > int(limit)
+	if moreDataFlag {
+		accounts = accounts[:limit]
+	}
+	if currentCursor.IsReverse() {
+		slices.Reverse(accounts)
+	}
+
+	p := &paging
+	if moreDataFlag || currentCursor.IsValid() {
+		p, err = utils.GetPagingFromData(accounts, currentCursor, moreDataFlag)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+		}
+	}
+
+	// -------------------------------------
+	// Names
+
+	addressMapping := make(map[string]*t.Address, len(accounts))
+	for _, account := range accounts {
+		addressMapping[hexutil.Encode(account.Address)] = nil
+	}
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, nil, err
+	}
+
+	result := make([]t.ADBAccountsTableRow, 0, len(accounts))
+	for _, account := range accounts {
+		result = append(result, t.ADBAccountsTableRow{
+			Address: *addressMapping[hexutil.Encode(account.Address)],
+			GroupId: account.GroupId,
+		})
+	}
+	return result, p, nil
+}
+
+func (d *DataAccessService) GetAccountDashboardAccountCount(ctx context.Context, dashboardId uint64) (uint64, error) {
+	var count uint64
+	err := d.alloyReader.GetContext(ctx, &count, `
+		SELECT COUNT(*) FROM users_acc_dashboards_accounts WHERE dashboard_id = $1
+	`, dashboardId)
+	return count, err
+}
+
+// transaction settings are stored in the user settings of the dashboard, settings that have never been set fall back to their defaults
+func (d *DataAccessService) GetAccountDashboardTransactionSettings(ctx context.Context, dashboardId uint64) (*t.ADBTransactionSettings, error) {
+	var rawSettings []byte
+	err := d.alloyReader.GetContext(ctx, &rawSettings, `
+		SELECT COALESCE(user_settings->'transactions', '{}'::jsonb) FROM users_acc_dashboards WHERE id = $1
+	`, dashboardId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: dashboard with id %v not found", ErrNotFound, dashboardId)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving account dashboard transaction settings: %w", err)
+	}
+
+	settings, err := d.getDefaultAccountDashboardTransactionSettings()
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(rawSettings, settings); err != nil {
+		return nil, fmt.Errorf("error parsing account dashboard transaction settings: %w", err)
+	}
+	return settings, nil
+}
+
+func (d *DataAccessService) UpdateAccountDashboardTransactionSettings(ctx context.Context, dashboardId uint64, settings t.ADBTransactionSettings) (*t.ADBTransactionSettings, error) {
+	settings.Networks = slices.Compact(slices.Sorted(slices.Values(settings.Networks)))
+	rawSettings, err := json.Marshal(settings)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling account dashboard transaction settings: %w", err)
+	}
+
+	_, err = d.alloyWriter.ExecContext(ctx, `
+		UPDATE users_acc_dashboards
+		SET user_settings = COALESCE(user_settings, '{}'::jsonb) || jsonb_build_object('transactions', $1::jsonb)
+		WHERE id = $2
+	`, rawSettings, dashboardId)
+	if err != nil {
+		return nil, fmt.Errorf("error updating account dashboard transaction settings: %w", err)
+	}
+	return &settings, nil
+}
+
+// by default the transactions of all networks are listed, including token transfers but without spam
+func (d *DataAccessService) getDefaultAccountDashboardTransactionSettings() (*t.ADBTransactionSettings, error) {
+	networks, err := d.GetAllNetworks()
+	if err != nil {
+		return nil, err
+	}
+	settings := &t.ADBTransactionSettings{
+		IsIncludeTokenTransfersEnabled:  true,
+		IsIgnoreSpamTransactionsEnabled: true,
+	}
+	for _, network := range networks {
+		settings.Networks = append(settings.Networks, network.ChainId)
+	}
+	return settings, nil
+}
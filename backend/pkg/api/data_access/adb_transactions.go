@@ -0,0 +1,435 @@
+package dataaccess
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/shopspring/decimal"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	adbTransactionIndexTx    = "tx"
+	adbTransactionIndexErc20 = "erc20"
+
+	// maximum number of times the indexes are read to fill a page whose entries were mostly filtered out
+	adbTransactionReadRounds = 3
+)
+
+// adbTransactionIndex is a single bigtable index (transactions or token transfers of one account on one network) the transaction history is merged from
+type adbTransactionIndex struct {
+	chainId  uint64
+	kind     string
+	account  []byte
+	groupId  uint64
+	position t.ADBTransactionsIndexPosition
+
+	// entries read in the current round, newest first
+	txs        []*types.Eth1TransactionIndexed
+	txKeys     []string
+	transfers  []*types.Eth1ERC20Indexed
+	nextKey    string
+	isComplete bool // the read returned fewer entries than requested
+}
+
+func (i *adbTransactionIndex) cursorKey() string {
+	return fmt.Sprintf("%d:%s:%x", i.chainId, i.kind, i.account)
+}
+
+func (i *adbTransactionIndex) len() int {
+	if i.kind == adbTransactionIndexTx {
+		return len(i.txs)
+	}
+	return len(i.transfers)
+}
+
+func (i *adbTransactionIndex) time(pos int) int64 {
+	if i.kind == adbTransactionIndexTx {
+		return i.txs[pos].GetTime().AsTime().Unix()
+	}
+	return i.transfers[pos].GetTime().AsTime().Unix()
+}
+
+// read reads the next entries after the current position of the index
+func (i *adbTransactionIndex) read(bt *db.Bigtable, limit uint64) error {
+	key := i.position.Key
+	requested := int(limit + i.position.Skip)
+	switch i.kind {
+	case adbTransactionIndexTx:
+		if key == "" {
+			key = fmt.Sprintf("%d:I:TX:%x:TIME:", i.chainId, i.account)
+		}
+		txs, keys, err := bt.GetEth1TxsForAddress(key, int64(requested))
+		if err != nil {
+			return fmt.Errorf("error retrieving transactions of %#x on network %d: %w", i.account, i.chainId, err)
+		}
+		i.isComplete = len(txs) < requested
+		// the keys can only be used as positions if every indexed transaction could be resolved
+		if len(keys) != len(txs) {
+			keys = nil
+		}
+		skip := min(int(i.position.Skip), len(txs))
+		i.txs = txs[skip:]
+		if keys != nil {
+			i.txKeys = keys[skip:]
+		}
+	case adbTransactionIndexErc20:
+		if key == "" {
+			key = fmt.Sprintf("%d:I:ERC20:%x:TIME:", i.chainId, i.account)
+		}
+		transfers, nextKey, err := bt.GetEth1ERC20ForAddress(key, int64(requested))
+		if err != nil {
+			return fmt.Errorf("error retrieving token transfers of %#x on network %d: %w", i.account, i.chainId, err)
+		}
+		i.isComplete = len(transfers) < requested
+		skip := min(int(i.position.Skip), len(transfers))
+		i.transfers = transfers[skip:]
+		i.nextKey = nextKey
+	}
+	return nil
+}
+
+// advance moves the position of the index past the first n entries of the current round
+func (i *adbTransactionIndex) advance(n int) {
+	switch {
+	case n == i.len() && i.isComplete:
+		i.position = t.ADBTransactionsIndexPosition{Key: i.position.Key, Done: true}
+	case n == 0:
+	case i.kind == adbTransactionIndexTx && i.txKeys != nil:
+		i.position = t.ADBTransactionsIndexPosition{Key: i.txKeys[n-1]}
+	case i.kind == adbTransactionIndexErc20 && n == i.len() && i.nextKey != "":
+		i.position = t.ADBTransactionsIndexPosition{Key: i.nextKey}
+	default:
+		// the index does not expose the key of every entry, so remember how many entries after the key have been returned
+		i.position.Skip += uint64(n)
+	}
+	i.txs, i.txKeys, i.transfers, i.nextKey = nil, nil, nil, ""
+}
+
+// GetAccountDashboardTransactions returns the transactions and (depending on the settings) token transfers of the dashboard accounts
+// on all networks selected in the dashboard settings, newest first. Pages are merged from the per account bigtable indexes,
+// the cursor stores the position within each of them. Since the indexes can only be read backwards in time, only next cursors are returned.
+func (d *DataAccessService) GetAccountDashboardTransactions(ctx context.Context, dashboardId uint64, groupId int64, cursor string, limit uint64) ([]t.ADBTransactionsTableRow, *t.Paging, error) {
+	result := make([]t.ADBTransactionsTableRow, 0)
+	var paging t.Paging
+
+	// Initialize the cursor
+	var currentCursor t.ADBTransactionsCursor
+	var err error
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.ADBTransactionsCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as ADBTransactionsCursor: %w", err)
+		}
+	}
+
+	settings, err := d.GetAccountDashboardTransactionSettings(ctx, dashboardId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	accounts := []struct {
+		Address []byte `db:"address"`
+		GroupId uint64 `db:"group_id"`
+	}{}
+	ds := goqu.Dialect("postgres").
+		Select(
+			goqu.C("address"),
+			goqu.C("group_id")).
+		From("users_acc_dashboards_accounts").
+		Where(goqu.C("dashboard_id").Eq(dashboardId))
+	if groupId != t.AllGroups {
+		ds = ds.Where(goqu.C("group_id").Eq(groupId))
+	}
+	query, args, err := ds.Prepared(true).ToSQL()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing account dashboard accounts query: %w", err)
+	}
+	err = d.alloyReader.SelectContext(ctx, &accounts, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving account dashboard accounts: %w", err)
+	}
+
+	// -------------------------------------
+	// Determine the indexes to read
+
+	kinds := []string{adbTransactionIndexTx}
+	if settings.IsIncludeTokenTransfersEnabled {
+		kinds = append(kinds, adbTransactionIndexErc20)
+	}
+	isDashboardAccount := make(map[string]bool, len(accounts))
+	indexes := make([]*adbTransactionIndex, 0, len(accounts)*len(settings.Networks)*len(kinds))
+	for _, account := range accounts {
+		isDashboardAccount[string(account.Address)] = true
+		for _, chainId := range settings.Networks {
+			for _, kind := range kinds {
+				index := &adbTransactionIndex{
+					chainId: chainId,
+					kind:    kind,
+					account: account.Address,
+					groupId: account.GroupId,
+				}
+				if currentCursor.IsValid() {
+					position, ok := currentCursor.Positions[index.cursorKey()]
+					if !ok {
+						// account, network or token transfers were added after the first page was requested
+						continue
+					}
+					index.position = position
+				}
+				if !index.position.Done {
+					indexes = append(indexes, index)
+				}
+			}
+		}
+	}
+
+	// -------------------------------------
+	// Read and merge the indexes
+
+	type entry struct {
+		index *adbTransactionIndex
+		pos   int
+		time  int64
+	}
+	type pending struct {
+		index    *adbTransactionIndex
+		tx       *types.Eth1TransactionIndexed
+		transfer *types.Eth1ERC20Indexed
+	}
+	rows := make([]pending, 0, limit)
+	seen := make(map[string]bool)
+	hasMoreData := false
+	for round := 0; round < adbTransactionReadRounds && uint64(len(rows)) < limit; round++ {
+		open := make([]*adbTransactionIndex, 0, len(indexes))
+		for _, index := range indexes {
+			if !index.position.Done {
+				open = append(open, index)
+			}
+		}
+		if len(open) == 0 {
+			hasMoreData = false
+			break
+		}
+
+		want := limit - uint64(len(rows))
+		eg := errgroup.Group{}
+		eg.SetLimit(10)
+		for _, index := range open {
+			eg.Go(func() error {
+				return index.read(d.bigtable, want)
+			})
+		}
+		if err := eg.Wait(); err != nil {
+			return nil, nil, err
+		}
+
+		// indexes that have not been read completely may contain further entries as old as their oldest entry read,
+		// so entries that are even older can't be returned yet
+		var horizon int64
+		entries := []entry{}
+		for _, index := range open {
+			for pos := 0; pos < index.len(); pos++ {
+				entries = append(entries, entry{index: index, pos: pos, time: index.time(pos)})
+			}
+			if !index.isComplete && index.len() > 0 {
+				horizon = max(horizon, index.time(index.len()-1))
+			}
+		}
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].time > entries[j].time
+		})
+
+		consumed := make(map[*adbTransactionIndex]int, len(open))
+		stopped := false
+		for _, e := range entries {
+			if e.time < horizon || uint64(len(rows)) >= limit {
+				stopped = true
+				break
+			}
+			consumed[e.index]++
+
+			row := pending{index: e.index}
+			var id string
+			if e.index.kind == adbTransactionIndexTx {
+				row.tx = e.index.txs[e.pos]
+				if settings.IsIgnoreSpamTransactionsEnabled && utils.IsSpamEth1Transaction(row.tx, e.index.account) {
+					continue
+				}
+				id = fmt.Sprintf("%d:tx:%x", e.index.chainId, row.tx.GetHash())
+			} else {
+				row.transfer = e.index.transfers[e.pos]
+				if settings.IsIgnoreSpamTransactionsEnabled && utils.IsSpamERC20Transfer(row.transfer, nil) {
+					continue
+				}
+				id = fmt.Sprintf("%d:erc20:%x:%x:%x:%x:%x", e.index.chainId, row.transfer.GetParentHash(), row.transfer.GetTokenAddress(), row.transfer.GetFrom(), row.transfer.GetTo(), row.transfer.GetValue())
+			}
+			// transfers between two dashboard accounts are part of the indexes of both accounts
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			rows = append(rows, row)
+		}
+
+		hasMoreData = stopped
+		for _, index := range open {
+			index.advance(consumed[index])
+			if !index.position.Done {
+				hasMoreData = true
+			}
+		}
+	}
+
+	// -------------------------------------
+	// Token metadata and names
+
+	// token metadata can only be retrieved for the network of this deployment
+	tokens := make(map[string]bool)
+	for _, row := range rows {
+		if row.transfer != nil && row.index.chainId == utils.Config.Chain.ClConfig.DepositChainID {
+			tokens[string(row.transfer.GetTokenAddress())] = true
+		}
+	}
+	tokenMetadata := make(map[string]*types.ERC20Metadata, len(tokens))
+	mux := sync.Mutex{}
+	eg := errgroup.Group{}
+	eg.SetLimit(10)
+	for token := range tokens {
+		eg.Go(func() error {
+			metadata, err := d.bigtable.GetERC20MetadataForAddress([]byte(token))
+			if err != nil {
+				return fmt.Errorf("error retrieving metadata of token %#x: %w", token, err)
+			}
+			mux.Lock()
+			tokenMetadata[token] = metadata
+			mux.Unlock()
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	addressMapping := make(map[string]*t.Address)
+	for _, row := range rows {
+		if row.tx != nil {
+			addressMapping[hexutil.Encode(row.tx.GetFrom())] = nil
+			addressMapping[hexutil.Encode(row.tx.GetTo())] = nil
+		} else {
+			addressMapping[hexutil.Encode(row.transfer.GetFrom())] = nil
+			addressMapping[hexutil.Encode(row.transfer.GetTo())] = nil
+		}
+	}
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, nil, err
+	}
+
+	// -------------------------------------
+	// Build the result
+
+	for _, row := range rows {
+		resultEntry := t.ADBTransactionsTableRow{
+			Network: row.index.chainId,
+			GroupId: row.index.groupId,
+		}
+		var from, to []byte
+		var isContract bool
+		if row.tx != nil {
+			tx := row.tx
+			from, to = tx.GetFrom(), tx.GetTo()
+			interaction := types.CONTRACT_NONE
+			switch {
+			case tx.GetIsContractCreation():
+				interaction = types.CONTRACT_CREATION
+			case tx.GetInvokesContract():
+				interaction = types.CONTRACT_PRESENT
+			}
+			fee := new(big.Int).SetBytes(tx.GetTxFee())
+			fee.Add(fee, new(big.Int).SetBytes(tx.GetBlobTxFee()))
+
+			resultEntry.Hash = t.Hash(hexutil.Encode(tx.GetHash()))
+			resultEntry.Block = tx.GetBlockNumber()
+			resultEntry.Timestamp = tx.GetTime().AsTime().Unix()
+			resultEntry.Type = "transaction"
+			resultEntry.Method = d.bigtable.GetMethodLabel(tx.GetMethodId(), interaction)
+			resultEntry.Value = decimal.NewFromBigInt(new(big.Int).SetBytes(tx.GetValue()), 0)
+			resultEntry.Fee = decimal.NewFromBigInt(fee, 0)
+			resultEntry.Success = tx.GetErrorMsg() == ""
+			resultEntry.IsSpam = utils.IsSpamEth1Transaction(tx, row.index.account)
+			isContract = tx.GetIsContractCreation() || tx.GetInvokesContract()
+		} else {
+			transfer := row.transfer
+			from, to = transfer.GetFrom(), transfer.GetTo()
+			metadata := tokenMetadata[string(transfer.GetTokenAddress())]
+			token := &t.ADBToken{
+				Address: t.Hash(hexutil.Encode(transfer.GetTokenAddress())),
+			}
+			if metadata != nil {
+				token.Symbol = metadata.Symbol
+				token.Decimals = new(big.Int).SetBytes(metadata.Decimals).Uint64()
+			}
+
+			resultEntry.Hash = t.Hash(hexutil.Encode(transfer.GetParentHash()))
+			resultEntry.Block = transfer.GetBlockNumber()
+			resultEntry.Timestamp = transfer.GetTime().AsTime().Unix()
+			resultEntry.Type = "erc20_transfer"
+			resultEntry.Value = decimal.NewFromBigInt(new(big.Int).SetBytes(transfer.GetValue()), 0)
+			resultEntry.Token = token
+			resultEntry.Success = true
+			resultEntry.IsSpam = utils.IsSpamERC20Transfer(transfer, metadata)
+		}
+		if settings.IsIgnoreSpamTransactionsEnabled && resultEntry.IsSpam {
+			// only detectable with the token metadata
+			continue
+		}
+
+		resultEntry.From = *addressMapping[hexutil.Encode(from)]
+		resultEntry.To = *addressMapping[hexutil.Encode(to)]
+		resultEntry.To.IsContract = isContract
+
+		switch {
+		case isDashboardAccount[string(from)] && isDashboardAccount[string(to)]:
+			resultEntry.Direction = "self"
+		case bytes.Equal(from, row.index.account):
+			resultEntry.Direction = "out"
+		default:
+			resultEntry.Direction = "in"
+		}
+		result = append(result, resultEntry)
+	}
+
+	// -------------------------------------
+	// Paging
+
+	if !hasMoreData {
+		return result, &paging, nil
+	}
+	nextCursor := t.ADBTransactionsCursor{
+		Positions: make(map[string]t.ADBTransactionsIndexPosition, len(indexes)),
+	}
+	if currentCursor.IsValid() {
+		// keep the positions of indexes that have been read completely on earlier pages
+		for key, position := range currentCursor.Positions {
+			nextCursor.Positions[key] = position
+		}
+	}
+	for _, index := range indexes {
+		nextCursor.Positions[index.cursorKey()] = index.position
+	}
+	paging.NextCursor, err = utils.CursorToString(nextCursor)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+	return result, &paging, nil
+}
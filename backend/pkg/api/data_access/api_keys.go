@@ -0,0 +1,248 @@
+package dataaccess
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+type ApiKeyRepository interface {
+	GetApiKeyAccess(ctx context.Context, apiKey string) (*t.ApiKeyAccess, error)
+	GetUserApiKeys(ctx context.Context, userId uint64) ([]t.ApiKey, error)
+	GetUserApiKeyCount(ctx context.Context, userId uint64) (uint64, error)
+	CreateUserApiKey(ctx context.Context, userId uint64, name string, scopes []enums.ApiKeyScope, networks []uint64) (*t.ApiKey, error)
+	UpdateUserApiKey(ctx context.Context, userId uint64, apiKeyId uint64, name string, scopes []enums.ApiKeyScope, networks []uint64) (*t.ApiKey, error)
+	RotateUserApiKey(ctx context.Context, userId uint64, apiKeyId uint64) (*t.ApiKey, error)
+	RemoveUserApiKey(ctx context.Context, userId uint64, apiKeyId uint64) error
+}
+
+// apiKeyRow is a row of api_keys, NULL scopes or networks mean that the key is unrestricted
+type apiKeyRow struct {
+	Id        uint64         `db:"id"`
+	Name      string         `db:"name"`
+	ApiKey    string         `db:"api_key"`
+	UserId    uint64         `db:"user_id"`
+	Scopes    pq.StringArray `db:"scopes"`
+	Networks  pq.Int64Array  `db:"networks"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+func (row apiKeyRow) scopes() []enums.ApiKeyScope {
+	if row.Scopes == nil {
+		return nil
+	}
+	scopes := make([]enums.ApiKeyScope, 0, len(row.Scopes))
+	for _, scope := range row.Scopes {
+		if s := enums.ApiKeyScope(0).NewFromString(scope); !enums.IsInvalidEnum(s) {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+func (row apiKeyRow) networks() []uint64 {
+	if row.Networks == nil {
+		return nil
+	}
+	networks := make([]uint64, len(row.Networks))
+	for i, network := range row.Networks {
+		networks[i] = uint64(network)
+	}
+	return networks
+}
+
+// toApiKey converts the row, the key itself is censored unless it was just created
+func (row apiKeyRow) toApiKey(showKey bool) t.ApiKey {
+	scopes := row.scopes()
+	if scopes == nil {
+		scopes = enums.AllApiKeyScopes
+	}
+	apiKey := t.ApiKey{
+		Id:        row.Id,
+		Name:      row.Name,
+		Key:       row.ApiKey,
+		Scopes:    make([]string, len(scopes)),
+		Networks:  row.networks(),
+		CreatedAt: row.CreatedAt.Unix(),
+	}
+	for i, scope := range scopes {
+		apiKey.Scopes[i] = scope.String()
+	}
+	if apiKey.Networks == nil {
+		apiKey.Networks = []uint64{}
+	}
+	if !showKey {
+		apiKey.Key = censorApiKey(row.ApiKey)
+	}
+	return apiKey
+}
+
+func censorApiKey(apiKey string) string {
+	const visibleChars = 4
+	if len(apiKey) <= visibleChars*2 {
+		return "****"
+	}
+	return apiKey[:visibleChars] + "****" + apiKey[len(apiKey)-visibleChars:]
+}
+
+func apiKeyScopesArray(scopes []enums.ApiKeyScope) pq.StringArray {
+	result := make(pq.StringArray, len(scopes))
+	for i, scope := range scopes {
+		result[i] = scope.String()
+	}
+	return result
+}
+
+// apiKeyNetworksArray returns NULL for an empty list, which allows the key on all networks
+func apiKeyNetworksArray(networks []uint64) interface{} {
+	if len(networks) == 0 {
+		return nil
+	}
+	return pq.Array(networks)
+}
+
+const apiKeyColumns = `id, name, api_key, user_id, scopes, networks, created_at`
+
+func (d *DataAccessService) GetApiKeyAccess(ctx context.Context, apiKey string) (*t.ApiKeyAccess, error) {
+	var row apiKeyRow
+	err := d.userReader.GetContext(ctx, &row, `SELECT `+apiKeyColumns+` FROM api_keys WHERE api_key = $1 AND valid_until > NOW()`, apiKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: api key not found", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t.ApiKeyAccess{
+		UserId:   row.UserId,
+		Scopes:   row.scopes(),
+		Networks: row.networks(),
+	}, nil
+}
+
+func (d *DataAccessService) GetUserApiKeys(ctx context.Context, userId uint64) ([]t.ApiKey, error) {
+	var rows []apiKeyRow
+	err := d.userReader.SelectContext(ctx, &rows, `SELECT `+apiKeyColumns+` FROM api_keys WHERE user_id = $1 AND valid_until > NOW() ORDER BY created_at, id`, userId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]t.ApiKey, len(rows))
+	for i, row := range rows {
+		result[i] = row.toApiKey(false)
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) GetUserApiKeyCount(ctx context.Context, userId uint64) (uint64, error) {
+	var count uint64
+	err := d.userReader.GetContext(ctx, &count, `SELECT COUNT(*) FROM api_keys WHERE user_id = $1 AND valid_until > NOW()`, userId)
+	return count, err
+}
+
+func (d *DataAccessService) CreateUserApiKey(ctx context.Context, userId uint64, name string, scopes []enums.ApiKeyScope, networks []uint64) (*t.ApiKey, error) {
+	apiKey, err := utils.GenerateRandomAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	var row apiKeyRow
+	err = d.userWriter.GetContext(ctx, &row, `
+		INSERT INTO api_keys (api_key, user_id, name, scopes, networks)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+apiKeyColumns,
+		apiKey, userId, name, apiKeyScopesArray(scopes), apiKeyNetworksArray(networks))
+	if err != nil {
+		return nil, err
+	}
+	result := row.toApiKey(true)
+	return &result, nil
+}
+
+func (d *DataAccessService) UpdateUserApiKey(ctx context.Context, userId uint64, apiKeyId uint64, name string, scopes []enums.ApiKeyScope, networks []uint64) (*t.ApiKey, error) {
+	var row apiKeyRow
+	err := d.userWriter.GetContext(ctx, &row, `
+		UPDATE api_keys
+		SET name = $3, scopes = $4, networks = $5, changed_at = NOW()
+		WHERE user_id = $1 AND id = $2 AND valid_until > NOW()
+		RETURNING `+apiKeyColumns,
+		userId, apiKeyId, name, apiKeyScopesArray(scopes), apiKeyNetworksArray(networks))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: api key %v not found", ErrNotFound, apiKeyId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := row.toApiKey(false)
+	return &result, nil
+}
+
+// RotateUserApiKey revokes the key and creates a new one with the same name and restrictions.
+// The old key is kept as revoked row so the rate limiter drops it and its usage stays attributable.
+func (d *DataAccessService) RotateUserApiKey(ctx context.Context, userId uint64, apiKeyId uint64) (*t.ApiKey, error) {
+	apiKey, err := utils.GenerateRandomAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := d.userWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting db transaction: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	var oldRow apiKeyRow
+	err = tx.GetContext(ctx, &oldRow, `
+		UPDATE api_keys
+		SET valid_until = NOW(), changed_at = NOW()
+		WHERE user_id = $1 AND id = $2 AND valid_until > NOW()
+		RETURNING `+apiKeyColumns,
+		userId, apiKeyId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: api key %v not found", ErrNotFound, apiKeyId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var row apiKeyRow
+	err = tx.GetContext(ctx, &row, `
+		INSERT INTO api_keys (api_key, user_id, name, scopes, networks)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+apiKeyColumns,
+		apiKey, userId, oldRow.Name, oldRow.Scopes, oldRow.Networks)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("error committing tx: %w", err)
+	}
+	result := row.toApiKey(true)
+	return &result, nil
+}
+
+// RemoveUserApiKey revokes the key, the row is kept so the rate limiter notices the change
+func (d *DataAccessService) RemoveUserApiKey(ctx context.Context, userId uint64, apiKeyId uint64) error {
+	result, err := d.userWriter.ExecContext(ctx, `
+		UPDATE api_keys
+		SET valid_until = NOW(), changed_at = NOW()
+		WHERE user_id = $1 AND id = $2 AND valid_until > NOW()`,
+		userId, apiKeyId)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: api key %v not found", ErrNotFound, apiKeyId)
+	}
+	return nil
+}
@@ -49,6 +49,7 @@ func (d *DataAccessService) GetValidatorDashboardsCountInfo(ctx context.Context)
 		FROM users_val_dashboards uvd
 		LEFT JOIN dashboards_groups dg ON uvd.id = dg.dashboard_id
 		LEFT JOIN dashboards_validators dv ON uvd.id = dv.dashboard_id
+		WHERE uvd.organization_id IS NULL -- organization dashboards are limited by the pooled perks of the organization, not of their creator
 	`)
 	if err != nil {
 		return nil, err
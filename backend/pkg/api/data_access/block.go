@@ -1,9 +1,24 @@
 package dataaccess
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/blobindexer"
+	"github.com/gobitfly/beaconchain/pkg/commons/cache"
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/lib/pq"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/shopspring/decimal"
+	"golang.org/x/sync/errgroup"
 )
 
 type BlockRepository interface {
@@ -28,119 +43,909 @@ type BlockRepository interface {
 	GetSlotBlobs(ctx context.Context, chainId, block uint64) ([]t.BlockBlobTableRow, error)
 }
 
+// size of a blob in bytes (FIELD_ELEMENTS_PER_BLOB * BYTES_PER_FIELD_ELEMENT)
+const blobSize = 4096 * 32
+
+// blockRow is a row of the blocks table together with the execution layer reward data of the block.
+// Blocks from before the merge are not part of the blocks table, for those only the execution layer fields are set.
+type blockRow struct {
+	Epoch                      uint64              `db:"epoch"`
+	Slot                       uint64              `db:"slot"`
+	BlockRoot                  []byte              `db:"blockroot"`
+	ParentRoot                 []byte              `db:"parentroot"`
+	StateRoot                  []byte              `db:"stateroot"`
+	Signature                  []byte              `db:"signature"`
+	RandaoReveal               []byte              `db:"randaoreveal"`
+	GraffitiText               sql.NullString      `db:"graffiti_text"`
+	Eth1DataDepositRoot        []byte              `db:"eth1data_depositroot"`
+	Eth1DataDepositCount       uint64              `db:"eth1data_depositcount"`
+	Eth1DataBlockHash          []byte              `db:"eth1data_blockhash"`
+	SyncAggregateBits          []byte              `db:"syncaggregate_bits"`
+	SyncAggregateSignature     []byte              `db:"syncaggregate_signature"`
+	SyncAggregateParticipation float64             `db:"syncaggregate_participation"`
+	ProposerSlashingsCount     uint64              `db:"proposerslashingscount"`
+	AttesterSlashingsCount     uint64              `db:"attesterslashingscount"`
+	AttestationsCount          uint64              `db:"attestationscount"`
+	DepositsCount              uint64              `db:"depositscount"`
+	WithdrawalCount            uint64              `db:"withdrawalcount"`
+	VoluntaryExitsCount        uint64              `db:"voluntaryexitscount"`
+	Proposer                   uint64              `db:"proposer"`
+	Status                     string              `db:"status"`
+	ExecParentHash             []byte              `db:"exec_parent_hash"`
+	ExecBlockNumber            sql.NullInt64       `db:"exec_block_number"`
+	ExecGasLimit               sql.NullInt64       `db:"exec_gas_limit"`
+	ExecGasUsed                sql.NullInt64       `db:"exec_gas_used"`
+	ExecExtraData              []byte              `db:"exec_extra_data"`
+	ExecBaseFeePerGas          sql.NullInt64       `db:"exec_base_fee_per_gas"`
+	ExecBlockHash              []byte              `db:"exec_block_hash"`
+	ExecTransactionsCount      uint64              `db:"exec_transactions_count"`
+	FeeRecipient               []byte              `db:"fee_recipient"`
+	ElReward                   decimal.NullDecimal `db:"el_reward"`
+
+	// only set for blocks that were looked up in bigtable already
+	elBlock *types.Eth1Block
+}
+
+func (b *blockRow) hasBeaconBlock() bool {
+	return len(b.BlockRoot) > 0
+}
+
+const blockRowQuery = `
+	SELECT
+		b.epoch,
+		b.slot,
+		b.blockroot,
+		b.parentroot,
+		b.stateroot,
+		b.signature,
+		b.randaoreveal,
+		b.graffiti_text,
+		b.eth1data_depositroot,
+		b.eth1data_depositcount,
+		b.eth1data_blockhash,
+		b.syncaggregate_bits,
+		b.syncaggregate_signature,
+		b.syncaggregate_participation,
+		b.proposerslashingscount,
+		b.attesterslashingscount,
+		b.attestationscount,
+		b.depositscount,
+		b.withdrawalcount,
+		b.voluntaryexitscount,
+		b.proposer,
+		b.status,
+		b.exec_parent_hash,
+		b.exec_block_number,
+		b.exec_gas_limit,
+		b.exec_gas_used,
+		b.exec_extra_data,
+		b.exec_base_fee_per_gas,
+		b.exec_block_hash,
+		b.exec_transactions_count,
+		COALESCE(rb.proposer_fee_recipient, b.exec_fee_recipient) AS fee_recipient,
+		COALESCE(rb.value / 1e18, ep.fee_recipient_reward) AS el_reward
+	FROM blocks b
+	LEFT JOIN execution_payloads ep ON ep.block_hash = b.exec_block_hash
+	LEFT JOIN LATERAL (
+		-- relay bribe deduplication; select most likely (=max) relay bribe value
+		SELECT proposer_fee_recipient, value
+		FROM relays_blocks
+		WHERE relays_blocks.exec_block_hash = b.exec_block_hash
+		ORDER BY value DESC
+		LIMIT 1
+	) rb ON TRUE`
+
+// getBlockRowByNumber returns the canonical block with the given execution layer block number
+func (d *DataAccessService) getBlockRowByNumber(ctx context.Context, block uint64) (*blockRow, error) {
+	row := &blockRow{}
+	err := d.alloyReader.GetContext(ctx, row, blockRowQuery+` WHERE b.exec_block_number = $1 AND b.status = '1'`, block)
+	if err == nil {
+		return row, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("error retrieving block %d: %w", block, err)
+	}
+	// blocks from before the merge only exist on the execution layer
+	elBlock, err := d.bigtable.GetBlockFromBlocksTable(block)
+	if err != nil {
+		if errors.Is(err, db.ErrBlockNotFound) {
+			return nil, fmt.Errorf("%w: block %d", ErrNotFound, block)
+		}
+		return nil, fmt.Errorf("error retrieving block %d from bigtable: %w", block, err)
+	}
+	return &blockRow{
+		Status:                "1",
+		ExecBlockNumber:       sql.NullInt64{Int64: int64(block), Valid: true},
+		ExecTransactionsCount: uint64(len(elBlock.GetTransactions())),
+		elBlock:               elBlock,
+	}, nil
+}
+
+// getBlockRowBySlot returns the canonical block or the missed proposal at the given slot
+func (d *DataAccessService) getBlockRowBySlot(ctx context.Context, slot uint64) (*blockRow, error) {
+	row := &blockRow{}
+	err := d.alloyReader.GetContext(ctx, row, blockRowQuery+` WHERE b.slot = $1 AND b.status IN ('1', '2') ORDER BY b.status LIMIT 1`, slot)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: slot %d", ErrNotFound, slot)
+		}
+		return nil, fmt.Errorf("error retrieving block at slot %d: %w", slot, err)
+	}
+	return row, nil
+}
+
+func (d *DataAccessService) getElBlock(row *blockRow) (*types.Eth1Block, error) {
+	if row.elBlock != nil {
+		return row.elBlock, nil
+	}
+	if !row.ExecBlockNumber.Valid {
+		return nil, nil
+	}
+	elBlock, err := d.bigtable.GetBlockFromBlocksTable(uint64(row.ExecBlockNumber.Int64))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving block %d from bigtable: %w", row.ExecBlockNumber.Int64, err)
+	}
+	row.elBlock = elBlock
+	return elBlock, nil
+}
+
 func (d *DataAccessService) GetBlock(ctx context.Context, chainId, block uint64) (*t.BlockSummary, error) {
-	// @DATA-ACCESS
-	return d.dummy.GetBlock(ctx, chainId, block)
+	row, err := d.getBlockRowByNumber(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	return d.getBlockSummary(ctx, row)
 }
 
 func (d *DataAccessService) GetBlockOverview(ctx context.Context, chainId, block uint64) (*t.BlockOverview, error) {
-	// @DATA-ACCESS
-	return d.dummy.GetBlockOverview(ctx, chainId, block)
+	row, err := d.getBlockRowByNumber(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	return d.getBlockOverview(ctx, row)
 }
 
 func (d *DataAccessService) GetBlockTransactions(ctx context.Context, chainId, block uint64) ([]t.BlockTransactionTableRow, error) {
-	// @DATA-ACCESS
-	return d.dummy.GetBlockTransactions(ctx, chainId, block)
+	row, err := d.getBlockRowByNumber(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	return d.getBlockTransactions(ctx, row)
 }
 
 func (d *DataAccessService) GetBlockVotes(ctx context.Context, chainId, block uint64) ([]t.BlockVoteTableRow, error) {
-	// @DATA-ACCESS
-	return d.dummy.GetBlockVotes(ctx, chainId, block)
+	row, err := d.getBlockRowByNumber(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	return d.getBlockVotes(ctx, row)
 }
 
 func (d *DataAccessService) GetBlockAttestations(ctx context.Context, chainId, block uint64) ([]t.BlockAttestationTableRow, error) {
-	// @DATA-ACCESS
-	return d.dummy.GetBlockAttestations(ctx, chainId, block)
+	row, err := d.getBlockRowByNumber(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	return d.getBlockAttestations(ctx, row)
 }
 
 func (d *DataAccessService) GetBlockWithdrawals(ctx context.Context, chainId, block uint64) ([]t.BlockWithdrawalTableRow, error) {
-	// @DATA-ACCESS
-	return d.dummy.GetBlockWithdrawals(ctx, chainId, block)
+	row, err := d.getBlockRowByNumber(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	return d.getBlockWithdrawals(ctx, row)
 }
 
 func (d *DataAccessService) GetBlockBlsChanges(ctx context.Context, chainId, block uint64) ([]t.BlockBlsChangeTableRow, error) {
-	// @DATA-ACCESS
-	return d.dummy.GetBlockBlsChanges(ctx, chainId, block)
+	row, err := d.getBlockRowByNumber(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	return d.getBlockBlsChanges(ctx, row)
 }
 
 func (d *DataAccessService) GetBlockVoluntaryExits(ctx context.Context, chainId, block uint64) ([]t.BlockVoluntaryExitTableRow, error) {
-	// @DATA-ACCESS
-	return d.dummy.GetBlockVoluntaryExits(ctx, chainId, block)
+	row, err := d.getBlockRowByNumber(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	return d.getBlockVoluntaryExits(ctx, row)
 }
 
 func (d *DataAccessService) GetBlockBlobs(ctx context.Context, chainId, block uint64) ([]t.BlockBlobTableRow, error) {
-	// @DATA-ACCESS
-	return d.dummy.GetBlockBlobs(ctx, chainId, block)
+	row, err := d.getBlockRowByNumber(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	return d.getBlockBlobs(ctx, row)
 }
 
 func (d *DataAccessService) GetSlot(ctx context.Context, chainId, slot uint64) (*t.BlockSummary, error) {
-	block, err := d.GetBlockHeightAt(ctx, slot)
+	row, err := d.getBlockRowBySlot(ctx, slot)
 	if err != nil {
 		return nil, err
 	}
-	return d.GetBlock(ctx, chainId, block)
+	return d.getBlockSummary(ctx, row)
 }
 
 func (d *DataAccessService) GetSlotOverview(ctx context.Context, chainId, slot uint64) (*t.BlockOverview, error) {
-	block, err := d.GetBlockHeightAt(ctx, slot)
+	row, err := d.getBlockRowBySlot(ctx, slot)
 	if err != nil {
 		return nil, err
 	}
-	return d.GetBlockOverview(ctx, chainId, block)
+	return d.getBlockOverview(ctx, row)
 }
 
 func (d *DataAccessService) GetSlotTransactions(ctx context.Context, chainId, slot uint64) ([]t.BlockTransactionTableRow, error) {
-	block, err := d.GetBlockHeightAt(ctx, slot)
+	row, err := d.getBlockRowBySlot(ctx, slot)
 	if err != nil {
 		return nil, err
 	}
-	return d.GetBlockTransactions(ctx, chainId, block)
+	return d.getBlockTransactions(ctx, row)
 }
 
 func (d *DataAccessService) GetSlotVotes(ctx context.Context, chainId, slot uint64) ([]t.BlockVoteTableRow, error) {
-	block, err := d.GetBlockHeightAt(ctx, slot)
+	row, err := d.getBlockRowBySlot(ctx, slot)
 	if err != nil {
 		return nil, err
 	}
-	return d.GetBlockVotes(ctx, chainId, block)
+	return d.getBlockVotes(ctx, row)
 }
 
 func (d *DataAccessService) GetSlotAttestations(ctx context.Context, chainId, slot uint64) ([]t.BlockAttestationTableRow, error) {
-	block, err := d.GetBlockHeightAt(ctx, slot)
+	row, err := d.getBlockRowBySlot(ctx, slot)
 	if err != nil {
 		return nil, err
 	}
-	return d.GetBlockAttestations(ctx, chainId, block)
+	return d.getBlockAttestations(ctx, row)
 }
 
 func (d *DataAccessService) GetSlotWithdrawals(ctx context.Context, chainId, slot uint64) ([]t.BlockWithdrawalTableRow, error) {
-	block, err := d.GetBlockHeightAt(ctx, slot)
+	row, err := d.getBlockRowBySlot(ctx, slot)
 	if err != nil {
 		return nil, err
 	}
-	return d.GetBlockWithdrawals(ctx, chainId, block)
+	return d.getBlockWithdrawals(ctx, row)
 }
 
 func (d *DataAccessService) GetSlotBlsChanges(ctx context.Context, chainId, slot uint64) ([]t.BlockBlsChangeTableRow, error) {
-	block, err := d.GetBlockHeightAt(ctx, slot)
+	row, err := d.getBlockRowBySlot(ctx, slot)
 	if err != nil {
 		return nil, err
 	}
-	return d.GetBlockBlsChanges(ctx, chainId, block)
+	return d.getBlockBlsChanges(ctx, row)
 }
 
 func (d *DataAccessService) GetSlotVoluntaryExits(ctx context.Context, chainId, slot uint64) ([]t.BlockVoluntaryExitTableRow, error) {
-	block, err := d.GetBlockHeightAt(ctx, slot)
+	row, err := d.getBlockRowBySlot(ctx, slot)
 	if err != nil {
 		return nil, err
 	}
-	return d.GetBlockVoluntaryExits(ctx, chainId, block)
+	return d.getBlockVoluntaryExits(ctx, row)
 }
 
 func (d *DataAccessService) GetSlotBlobs(ctx context.Context, chainId, slot uint64) ([]t.BlockBlobTableRow, error) {
-	block, err := d.GetBlockHeightAt(ctx, slot)
+	row, err := d.getBlockRowBySlot(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+	return d.getBlockBlobs(ctx, row)
+}
+
+// ----------------------------------------------------------------------------
+
+type blockCounts struct {
+	Votes            uint64 `db:"votes"`
+	VotingValidators uint64 `db:"voting_validators"`
+	BlsChanges       uint64 `db:"bls_changes"`
+	Blobs            uint64 `db:"blobs"`
+}
+
+// getBlockCounts returns the counts of the block data that is not already tracked in the blocks table
+func (d *DataAccessService) getBlockCounts(ctx context.Context, row *blockRow) (*blockCounts, error) {
+	counts := &blockCounts{}
+	if !row.hasBeaconBlock() {
+		return counts, nil
+	}
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM blocks_attestations WHERE beaconblockroot = $1) AS votes,
+			(SELECT COALESCE(SUM(cardinality(validators)), 0) FROM blocks_attestations WHERE beaconblockroot = $1) AS voting_validators,
+			(SELECT COUNT(*) FROM blocks_bls_change WHERE block_slot = $2 AND block_root = $1) AS bls_changes,
+			(SELECT COUNT(*) FROM blocks_blob_sidecars WHERE block_root = $1) AS blobs`
+	if err := d.alloyReader.GetContext(ctx, counts, query, row.BlockRoot, row.Slot); err != nil {
+		return nil, fmt.Errorf("error retrieving counts of block at slot %d: %w", row.Slot, err)
+	}
+	return counts, nil
+}
+
+func (d *DataAccessService) getBlockSummary(ctx context.Context, row *blockRow) (*t.BlockSummary, error) {
+	counts, err := d.getBlockCounts(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	return &t.BlockSummary{
+		Transactions:   row.ExecTransactionsCount,
+		Votes:          counts.Votes,
+		Attestations:   row.AttestationsCount,
+		Withdrawals:    row.WithdrawalCount,
+		BlsChanges:     counts.BlsChanges,
+		VoluntaryExits: row.VoluntaryExitsCount,
+		Blobs:          counts.Blobs,
+	}, nil
+}
+
+func (d *DataAccessService) getBlockOverview(ctx context.Context, row *blockRow) (*t.BlockOverview, error) {
+	overview := &t.BlockOverview{}
+	addressMapping := make(map[string]*t.Address)
+	contractStatusRequests := make([]db.ContractInteractionAtRequest, 0, 1)
+
+	// execution layer
+	if row.ExecBlockNumber.Valid {
+		blocks, err := d.bigtable.GetBlocksIndexedMultiple([]uint64{uint64(row.ExecBlockNumber.Int64)}, 1)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving indexed block %d from bigtable: %w", row.ExecBlockNumber.Int64, err)
+		}
+		if len(blocks) == 0 {
+			return nil, fmt.Errorf("%w: indexed block %d", ErrNotFound, row.ExecBlockNumber.Int64)
+		}
+		elBlock := blocks[0]
+
+		overview.Block = elBlock.GetNumber()
+		overview.Time = elBlock.GetTime().AsTime().Unix()
+		overview.Hash = t.Hash(hexutil.Encode(elBlock.GetHash()))
+		overview.ParentHash = t.Hash(hexutil.Encode(elBlock.GetParentHash()))
+		overview.Transactions = &t.BlockTransactionCounts{
+			General:  elBlock.GetTransactionCount(),
+			Internal: elBlock.GetInternalTransactionCount(),
+			Blob:     elBlock.GetBlobTransactionCount(),
+		}
+
+		priorityFees := decimal.NewFromBigInt(new(big.Int).SetBytes(elBlock.GetTxReward()), 0)
+		txFees := priorityFees
+		if len(elBlock.GetBaseFee()) > 0 {
+			baseFee := decimal.NewFromBigInt(new(big.Int).SetBytes(elBlock.GetBaseFee()), 0)
+			burnedFees := baseFee.Mul(decimal.NewFromUint64(elBlock.GetGasUsed()))
+			txFees = txFees.Add(burnedFees)
+			overview.BaseFee = &baseFee
+			overview.BurnedFees = &burnedFees
+		}
+
+		if !row.hasBeaconBlock() {
+			// proof of work block
+			miner := hexutil.Encode(elBlock.GetCoinbase())
+			addressMapping[miner] = nil
+			contractStatusRequests = append(contractStatusRequests, db.ContractInteractionAtRequest{
+				Address:  fmt.Sprintf("%x", elBlock.GetCoinbase()),
+				Block:    int64(elBlock.GetNumber()),
+				TxIdx:    -1,
+				TraceIdx: -1,
+			})
+			rewards := decimal.NewFromBigInt(utils.Eth1TotalReward(elBlock), 0)
+			gasUsage := decimal.NewFromUint64(elBlock.GetGasUsed())
+			lowestGasPrice := decimal.NewFromBigInt(new(big.Int).SetBytes(elBlock.GetLowestGasPrice()), 0)
+			difficulty := decimal.NewFromBigInt(new(big.Int).SetBytes(elBlock.GetDifficulty()), 0)
+			overview.Rewards = &rewards
+			overview.TxFees = &txFees
+			overview.GasUsage = &gasUsage
+			overview.LowestGasPrice = &lowestGasPrice
+			overview.Difficulty = &difficulty
+			overview.GasLimit = &t.BlockGasLimit{
+				Value: elBlock.GetGasLimit(),
+			}
+			if elBlock.GetGasLimit() > 0 {
+				overview.GasLimit.Percent = float64(elBlock.GetGasUsed()) / float64(elBlock.GetGasLimit()) * 100
+			}
+			if row.elBlock != nil {
+				overview.Extra = string(row.elBlock.GetExtra())
+			}
+		} else {
+			overview.PriorityFees = &priorityFees
+			overview.Extra = string(row.ExecExtraData)
+			executionPayload := &t.BlockExecutionPayload{
+				BlockHash:     t.Hash(hexutil.Encode(row.ExecBlockHash)),
+				ParentHash:    t.Hash(hexutil.Encode(row.ExecParentHash)),
+				GasUsed:       uint64(row.ExecGasUsed.Int64),
+				GasLimit:      uint64(row.ExecGasLimit.Int64),
+				BaseFeePerGas: decimal.NewFromInt(row.ExecBaseFeePerGas.Int64),
+			}
+			if overview.BurnedFees != nil {
+				executionPayload.BaseFees = *overview.BurnedFees
+			}
+			overview.ExecutionPayload = executionPayload
+		}
+	}
+	if !row.hasBeaconBlock() {
+		if err := d.resolveBlockAddresses(ctx, addressMapping, contractStatusRequests); err != nil {
+			return nil, err
+		}
+		overview.Miner = addressMapping[hexutil.Encode(row.elBlock.GetCoinbase())]
+		return overview, nil
+	}
+
+	// consensus layer
+	overview.Time = d.chain.SlotToTime(row.Slot).Unix()
+	overview.Epoch = row.Epoch
+	overview.Slot = row.Slot
+	overview.Proposer = row.Proposer
+	overview.BlockRoot = t.Hash(hexutil.Encode(row.BlockRoot))
+	overview.ParentRoot = t.Hash(hexutil.Encode(row.ParentRoot))
+	overview.Status = &t.BlockStatus{
+		Proposal:  "scheduled",
+		Finalized: "not_finalized",
+	}
+	switch row.Status {
+	case "1":
+		overview.Status.Proposal = "proposed"
+	case "2":
+		overview.Status.Proposal = "missed"
+	case "3":
+		overview.Status.Proposal = "orphaned"
+	}
+	if row.Epoch <= cache.LatestFinalizedEpoch.OfChain(d.chainId()).Get() {
+		overview.Status.Finalized = "finalized"
+	}
+	if row.Status != "1" {
+		// nothing else to show for missed proposals
+		return overview, nil
+	}
+
+	counts, err := d.getBlockCounts(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	consensusLayer := &t.BlockConsensusLayer{
+		StateRoot:         t.Hash(hexutil.Encode(row.StateRoot)),
+		Signature:         t.Hash(hexutil.Encode(row.Signature)),
+		RandaoReveal:      t.Hash(hexutil.Encode(row.RandaoReveal)),
+		Attestations:      row.AttestationsCount,
+		Votes:             counts.Votes,
+		VotingValidators:  counts.VotingValidators,
+		VoluntaryExits:    row.VoluntaryExitsCount,
+		AttesterSlashings: row.AttesterSlashingsCount,
+		ProposerSlashings: row.ProposerSlashingsCount,
+		Deposits:          row.DepositsCount,
+		Eth1Data: t.BlockEth1Data{
+			BlockHash:    t.Hash(hexutil.Encode(row.Eth1DataBlockHash)),
+			DepositCount: row.Eth1DataDepositCount,
+			DepositRoot:  t.Hash(hexutil.Encode(row.Eth1DataDepositRoot)),
+		},
+		Graffiti: row.GraffitiText.String,
+	}
+	if len(row.SyncAggregateBits) > 0 {
+		syncCommittee := t.BlockSyncCommittee{
+			Participation: row.SyncAggregateParticipation,
+			Bits:          make([]bool, len(row.SyncAggregateBits)*8),
+			Signature:     t.Hash(hexutil.Encode(row.SyncAggregateSignature)),
+		}
+		for i := range syncCommittee.Bits {
+			syncCommittee.Bits[i] = utils.BitAtVector(row.SyncAggregateBits, i)
+		}
+		query := `SELECT validatorindex FROM sync_committees WHERE period = $1 ORDER BY committeeindex`
+		if err := d.alloyReader.SelectContext(ctx, &syncCommittee.SyncCommittee, query, d.chain.SyncPeriodOfEpoch(row.Epoch)); err != nil {
+			return nil, fmt.Errorf("error retrieving sync committee of epoch %d: %w", row.Epoch, err)
+		}
+		consensusLayer.SyncCommittee = syncCommittee
+	}
+	overview.ConsensusLayer = consensusLayer
+
+	query := `
+		SELECT tags.metadata->>'name' AS name, tags.metadata->>'color' AS color
+		FROM blocks_tags
+		INNER JOIN tags ON tags.id = blocks_tags.tag_id
+		WHERE blocks_tags.slot = $1 AND blocks_tags.blockroot = $2`
+	if err := d.alloyReader.SelectContext(ctx, &overview.MevTags, query, row.Slot, row.BlockRoot); err != nil {
+		return nil, fmt.Errorf("error retrieving tags of block at slot %d: %w", row.Slot, err)
+	}
+
+	// proposer reward
+	reward := t.ClElValue[decimal.Decimal]{}
+	var clReward decimal.NullDecimal
+	clRewardQuery := `SELECT attestations_reward + sync_aggregate_reward + slasher_reward AS cl_reward FROM validator_proposal_rewards_slot WHERE slot = $1`
+	if err := d.clickhouseReader.GetContext(ctx, &clReward, clRewardQuery, row.Slot); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("error retrieving consensus layer proposer reward of slot %d: %w", row.Slot, err)
+	}
+	if clReward.Valid {
+		reward.Cl = clReward.Decimal.Mul(decimal.NewFromInt(1e9))
+	}
+	if row.ElReward.Valid {
+		reward.El = row.ElReward.Decimal.Mul(decimal.NewFromInt(1e18))
+	}
+	overview.ProposerReward = &reward
+	if len(row.FeeRecipient) > 0 {
+		feeRecipient := hexutil.Encode(row.FeeRecipient)
+		addressMapping[feeRecipient] = nil
+		contractStatusRequests = append(contractStatusRequests, db.ContractInteractionAtRequest{
+			Address:  fmt.Sprintf("%x", row.FeeRecipient),
+			Block:    row.ExecBlockNumber.Int64,
+			TxIdx:    -1,
+			TraceIdx: -1,
+		})
+		if err := d.resolveBlockAddresses(ctx, addressMapping, contractStatusRequests); err != nil {
+			return nil, err
+		}
+		overview.ProposerRewardRecipient = addressMapping[feeRecipient]
+		if overview.ExecutionPayload != nil {
+			overview.ExecutionPayload.PriorityFeesRecipient = *addressMapping[feeRecipient]
+		}
+	}
+
+	return overview, nil
+}
+
+// resolveBlockAddresses fills in names, ens names and contract statuses of the given addresses
+func (d *DataAccessService) resolveBlockAddresses(ctx context.Context, addressMapping map[string]*t.Address, contractStatusRequests []db.ContractInteractionAtRequest) error {
+	if len(addressMapping) == 0 {
+		return nil
+	}
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return err
+	}
+	contractStatuses, err := d.bigtable.GetAddressContractInteractionsAt(contractStatusRequests)
+	if err != nil {
+		return err
+	}
+	for i, request := range contractStatusRequests {
+		address := addressMapping["0x"+request.Address]
+		address.IsContract = address.IsContract || contractStatuses[i] == types.CONTRACT_CREATION || contractStatuses[i] == types.CONTRACT_PRESENT
+	}
+	return nil
+}
+
+func (d *DataAccessService) getBlockTransactions(ctx context.Context, row *blockRow) ([]t.BlockTransactionTableRow, error) {
+	elBlock, err := d.getElBlock(row)
+	if err != nil || elBlock == nil {
+		return []t.BlockTransactionTableRow{}, err
+	}
+
+	// the receiver of a transaction may or may not be a contract
+	addressMapping := make(map[string]*t.Address)
+	contractStatusRequests := make([]db.ContractInteractionAtRequest, 0, len(elBlock.GetTransactions()))
+	for i, tx := range elBlock.GetTransactions() {
+		addressMapping[hexutil.Encode(tx.GetFrom())] = nil
+		to, _ := getTransactionReceiver(tx)
+		addressMapping[hexutil.Encode(to)] = nil
+		contractStatusRequests = append(contractStatusRequests, db.ContractInteractionAtRequest{
+			Address:  fmt.Sprintf("%x", to),
+			Block:    int64(elBlock.GetNumber()),
+			TxIdx:    int64(i),
+			TraceIdx: -1,
+		})
+	}
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, err
+	}
+	contractStatuses, err := d.bigtable.GetAddressContractInteractionsAt(contractStatusRequests)
 	if err != nil {
 		return nil, err
 	}
-	return d.GetBlockBlobs(ctx, chainId, block)
+
+	blockTime := uint64(elBlock.GetTime().AsTime().Unix())
+	data := make([]t.BlockTransactionTableRow, len(elBlock.GetTransactions()))
+	for i, tx := range elBlock.GetTransactions() {
+		contractStatus := contractStatuses[i]
+		to, isContractCreation := getTransactionReceiver(tx)
+		if isContractCreation {
+			contractStatus = types.CONTRACT_CREATION
+		}
+		gasPrice := new(big.Int).SetBytes(tx.GetGasPrice())
+		txFee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(tx.GetGasUsed()))
+		txFee.Add(txFee, new(big.Int).Mul(new(big.Int).SetBytes(tx.GetBlobGasPrice()), new(big.Int).SetUint64(tx.GetBlobGasUsed())))
+
+		data[i] = t.BlockTransactionTableRow{
+			Success:  tx.GetStatus() == 1,
+			TxHash:   t.Hash(hexutil.Encode(tx.GetHash())),
+			Method:   d.bigtable.GetMethodLabel(tx.GetData(), contractStatus),
+			Block:    elBlock.GetNumber(),
+			Age:      blockTime,
+			From:     *addressMapping[hexutil.Encode(tx.GetFrom())],
+			To:       *addressMapping[hexutil.Encode(to)],
+			Value:    decimal.NewFromBigInt(new(big.Int).SetBytes(tx.GetValue()), 0),
+			GasPrice: decimal.NewFromBigInt(gasPrice, 0),
+			TxFee:    decimal.NewFromBigInt(txFee, 0),
+		}
+		data[i].To.IsContract = contractStatus == types.CONTRACT_CREATION || contractStatus == types.CONTRACT_PRESENT
+		switch {
+		case data[i].To.IsContract:
+			data[i].Type = "contract"
+		case bytes.Equal(tx.GetFrom(), to):
+			data[i].Type = "self"
+		default:
+			data[i].Type = "out"
+		}
+	}
+	return data, nil
+}
+
+// getTransactionReceiver returns the receiver of a transaction, which is the created contract for contract creations
+func getTransactionReceiver(tx *types.Eth1Transaction) ([]byte, bool) {
+	if len(tx.GetContractAddress()) > 0 && !bytes.Equal(tx.GetContractAddress(), db.ZERO_ADDRESS) {
+		return tx.GetContractAddress(), true
+	}
+	return tx.GetTo(), false
+}
+
+func int64ArrayToUint64(arr pq.Int64Array) []uint64 {
+	res := make([]uint64, len(arr))
+	for i, v := range arr {
+		res[i] = uint64(v)
+	}
+	return res
+}
+
+func (d *DataAccessService) getBlockVotes(ctx context.Context, row *blockRow) ([]t.BlockVoteTableRow, error) {
+	data := []t.BlockVoteTableRow{}
+	if !row.hasBeaconBlock() {
+		return data, nil
+	}
+	var votes []struct {
+		Slot       uint64        `db:"slot"`
+		Committee  uint64        `db:"committeeindex"`
+		BlockSlot  uint64        `db:"block_slot"`
+		Validators pq.Int64Array `db:"validators"`
+	}
+	query := `
+		SELECT slot, committeeindex, block_slot, validators
+		FROM blocks_attestations
+		WHERE beaconblockroot = $1
+		ORDER BY block_slot, block_index`
+	if err := d.alloyReader.SelectContext(ctx, &votes, query, row.BlockRoot); err != nil {
+		return nil, fmt.Errorf("error retrieving votes for block at slot %d: %w", row.Slot, err)
+	}
+	for _, vote := range votes {
+		data = append(data, t.BlockVoteTableRow{
+			AllocatedSlot:   vote.Slot,
+			Committee:       vote.Committee,
+			IncludedInBlock: vote.BlockSlot,
+			Validators:      int64ArrayToUint64(vote.Validators),
+		})
+	}
+	return data, nil
+}
+
+func (d *DataAccessService) getBlockAttestations(ctx context.Context, row *blockRow) ([]t.BlockAttestationTableRow, error) {
+	data := []t.BlockAttestationTableRow{}
+	if !row.hasBeaconBlock() {
+		return data, nil
+	}
+	var attestations []struct {
+		Slot            uint64        `db:"slot"`
+		CommitteeIndex  uint64        `db:"committeeindex"`
+		AggregationBits []byte        `db:"aggregationbits"`
+		Validators      pq.Int64Array `db:"validators"`
+		BeaconBlockRoot []byte        `db:"beaconblockroot"`
+		SourceEpoch     uint64        `db:"source_epoch"`
+		SourceRoot      []byte        `db:"source_root"`
+		TargetEpoch     uint64        `db:"target_epoch"`
+		TargetRoot      []byte        `db:"target_root"`
+		Signature       []byte        `db:"signature"`
+	}
+	query := `
+		SELECT slot, committeeindex, aggregationbits, validators, beaconblockroot, source_epoch, source_root, target_epoch, target_root, signature
+		FROM blocks_attestations
+		WHERE block_slot = $1 AND block_root = $2
+		ORDER BY block_index`
+	if err := d.alloyReader.SelectContext(ctx, &attestations, query, row.Slot, row.BlockRoot); err != nil {
+		return nil, fmt.Errorf("error retrieving attestations of block at slot %d: %w", row.Slot, err)
+	}
+	for _, attestation := range attestations {
+		bits := bitfield.Bitlist(attestation.AggregationBits)
+		aggregationBits := make([]bool, bits.Len())
+		for i := range aggregationBits {
+			aggregationBits[i] = bits.BitAt(uint64(i))
+		}
+		data = append(data, t.BlockAttestationTableRow{
+			Slot:            attestation.Slot,
+			CommitteeIndex:  attestation.CommitteeIndex,
+			AggregationBits: aggregationBits,
+			Validators:      int64ArrayToUint64(attestation.Validators),
+			BeaconBlockRoot: t.Hash(hexutil.Encode(attestation.BeaconBlockRoot)),
+			Source: t.EpochInfo{
+				Epoch:     attestation.SourceEpoch,
+				BlockRoot: t.Hash(hexutil.Encode(attestation.SourceRoot)),
+			},
+			Target: t.EpochInfo{
+				Epoch:     attestation.TargetEpoch,
+				BlockRoot: t.Hash(hexutil.Encode(attestation.TargetRoot)),
+			},
+			Signature: t.Hash(hexutil.Encode(attestation.Signature)),
+		})
+	}
+	return data, nil
+}
+
+func (d *DataAccessService) getBlockWithdrawals(ctx context.Context, row *blockRow) ([]t.BlockWithdrawalTableRow, error) {
+	data := []t.BlockWithdrawalTableRow{}
+	if !row.hasBeaconBlock() {
+		return data, nil
+	}
+	var withdrawals []struct {
+		Index   uint64 `db:"withdrawalindex"`
+		Address []byte `db:"address"`
+		Amount  int64  `db:"amount"`
+	}
+	query := `
+		SELECT withdrawalindex, address, amount
+		FROM blocks_withdrawals
+		WHERE block_slot = $1 AND block_root = $2
+		ORDER BY withdrawalindex`
+	if err := d.alloyReader.SelectContext(ctx, &withdrawals, query, row.Slot, row.BlockRoot); err != nil {
+		return nil, fmt.Errorf("error retrieving withdrawals of block at slot %d: %w", row.Slot, err)
+	}
+	if len(withdrawals) == 0 {
+		return data, nil
+	}
+
+	addressMapping := make(map[string]*t.Address)
+	contractStatusRequests := make([]db.ContractInteractionAtRequest, 0, len(withdrawals))
+	for _, withdrawal := range withdrawals {
+		address := hexutil.Encode(withdrawal.Address)
+		if _, ok := addressMapping[address]; ok {
+			continue
+		}
+		addressMapping[address] = nil
+		contractStatusRequests = append(contractStatusRequests, db.ContractInteractionAtRequest{
+			Address:  fmt.Sprintf("%x", withdrawal.Address),
+			Block:    row.ExecBlockNumber.Int64,
+			TxIdx:    -1,
+			TraceIdx: -1,
+		})
+	}
+	if err := d.resolveBlockAddresses(ctx, addressMapping, contractStatusRequests); err != nil {
+		return nil, err
+	}
+
+	age := uint64(d.chain.SlotToTime(row.Slot).Unix())
+	for _, withdrawal := range withdrawals {
+		data = append(data, t.BlockWithdrawalTableRow{
+			Index:     withdrawal.Index,
+			Epoch:     row.Epoch,
+			Slot:      row.Slot,
+			Age:       age,
+			Recipient: *addressMapping[hexutil.Encode(withdrawal.Address)],
+			Amount:    utils.GWeiToWei(big.NewInt(withdrawal.Amount)),
+		})
+	}
+	return data, nil
+}
+
+func (d *DataAccessService) getBlockBlsChanges(ctx context.Context, row *blockRow) ([]t.BlockBlsChangeTableRow, error) {
+	data := []t.BlockBlsChangeTableRow{}
+	if !row.hasBeaconBlock() {
+		return data, nil
+	}
+	var blsChanges []struct {
+		Index     uint64 `db:"validatorindex"`
+		Signature []byte `db:"signature"`
+		Pubkey    []byte `db:"pubkey"`
+		Address   []byte `db:"address"`
+	}
+	query := `
+		SELECT validatorindex, signature, pubkey, address
+		FROM blocks_bls_change
+		WHERE block_slot = $1 AND block_root = $2
+		ORDER BY validatorindex`
+	if err := d.alloyReader.SelectContext(ctx, &blsChanges, query, row.Slot, row.BlockRoot); err != nil {
+		return nil, fmt.Errorf("error retrieving bls changes of block at slot %d: %w", row.Slot, err)
+	}
+	if len(blsChanges) == 0 {
+		return data, nil
+	}
+
+	addressMapping := make(map[string]*t.Address)
+	for _, blsChange := range blsChanges {
+		addressMapping[hexutil.Encode(blsChange.Address)] = nil
+	}
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, err
+	}
+	for _, blsChange := range blsChanges {
+		data = append(data, t.BlockBlsChangeTableRow{
+			Index:                blsChange.Index,
+			Signature:            t.Hash(hexutil.Encode(blsChange.Signature)),
+			BlsPubkey:            t.Hash(hexutil.Encode(blsChange.Pubkey)),
+			NewWithdrawalAddress: *addressMapping[hexutil.Encode(blsChange.Address)],
+		})
+	}
+	return data, nil
+}
+
+func (d *DataAccessService) getBlockVoluntaryExits(ctx context.Context, row *blockRow) ([]t.BlockVoluntaryExitTableRow, error) {
+	data := []t.BlockVoluntaryExitTableRow{}
+	if !row.hasBeaconBlock() {
+		return data, nil
+	}
+	var exits []struct {
+		Validator uint64 `db:"validatorindex"`
+		Signature []byte `db:"signature"`
+	}
+	query := `
+		SELECT validatorindex, signature
+		FROM blocks_voluntaryexits
+		WHERE block_slot = $1 AND block_root = $2
+		ORDER BY block_index`
+	if err := d.alloyReader.SelectContext(ctx, &exits, query, row.Slot, row.BlockRoot); err != nil {
+		return nil, fmt.Errorf("error retrieving voluntary exits of block at slot %d: %w", row.Slot, err)
+	}
+	for _, exit := range exits {
+		data = append(data, t.BlockVoluntaryExitTableRow{
+			Validator: exit.Validator,
+			Signature: t.Hash(hexutil.Encode(exit.Signature)),
+		})
+	}
+	return data, nil
+}
+
+func (d *DataAccessService) getBlockBlobs(ctx context.Context, row *blockRow) ([]t.BlockBlobTableRow, error) {
+	data := []t.BlockBlobTableRow{}
+	if !row.hasBeaconBlock() {
+		return data, nil
+	}
+	var blobs []struct {
+		Commitment    []byte `db:"kzg_commitment"`
+		Proof         []byte `db:"kzg_proof"`
+		VersionedHash []byte `db:"blob_versioned_hash"`
+	}
+	query := `
+		SELECT kzg_commitment, kzg_proof, blob_versioned_hash
+		FROM blocks_blob_sidecars
+		WHERE block_root = $1
+		ORDER BY index`
+	if err := d.alloyReader.SelectContext(ctx, &blobs, query, row.BlockRoot); err != nil {
+		return nil, fmt.Errorf("error retrieving blobs of block at slot %d: %w", row.Slot, err)
+	}
+	if len(blobs) == 0 {
+		return data, nil
+	}
+
+	// map the blobs to the transactions that carried them
+	elBlock, err := d.getElBlock(row)
+	if err != nil {
+		return nil, err
+	}
+	txHashes := make(map[string][]byte)
+	for _, tx := range elBlock.GetTransactions() {
+		for _, versionedHash := range tx.GetBlobVersionedHashes() {
+			txHashes[string(versionedHash)] = tx.GetHash()
+		}
+	}
+
+	data = make([]t.BlockBlobTableRow, len(blobs))
+	for i, blob := range blobs {
+		data[i] = t.BlockBlobTableRow{
+			VersionedHash:   t.Hash(hexutil.Encode(blob.VersionedHash)),
+			Commitment:      t.Hash(hexutil.Encode(blob.Commitment)),
+			Proof:           t.Hash(hexutil.Encode(blob.Proof)),
+			Size:            blobSize,
+			TransactionHash: t.Hash(hexutil.Encode(txHashes[string(blob.VersionedHash)])),
+			Block:           uint64(row.ExecBlockNumber.Int64),
+		}
+	}
+
+	// blob data is only available if the blob indexer stored it and it hasn't been pruned yet
+	if d.blobStore == nil {
+		return data, nil
+	}
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(4)
+	for i, blob := range blobs {
+		i, blob := i, blob
+		g.Go(func() error {
+			blobData, _, err := d.blobStore.GetBlob(gCtx, blob.VersionedHash)
+			if err != nil {
+				if errors.Is(err, blobindexer.ErrBlobNotFound) {
+					return nil
+				}
+				return err
+			}
+			data[i].Data = hexutil.Encode(blobData)
+			data[i].Size = uint64(len(blobData))
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return data, nil
 }
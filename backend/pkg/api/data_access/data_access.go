@@ -3,12 +3,16 @@ package dataaccess
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
 	"github.com/gobitfly/beaconchain/pkg/api/services"
 	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/blobindexer"
 	"github.com/gobitfly/beaconchain/pkg/commons/cache"
 	"github.com/gobitfly/beaconchain/pkg/commons/db"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
@@ -19,7 +23,9 @@ import (
 
 type DataAccessor interface {
 	ValidatorDashboardRepository
+	AccountDashboardRepository
 	SearchRepository
+	ValidatorRepository
 	NetworkRepository
 	ClientRepository
 	UserRepository
@@ -30,8 +36,13 @@ type DataAccessor interface {
 	ArchiverRepository
 	ProtocolRepository
 	RatelimitRepository
+	ApiKeyRepository
+	OAuthRepository
+	LoginSecurityRepository
+	OrganizationRepository
 	HealthzRepository
 	MachineRepository
+	NodeJobRepository
 
 	Close()
 
@@ -39,6 +50,7 @@ type DataAccessor interface {
 	GetLatestSlot(ctx context.Context) (uint64, error)
 	GetLatestBlock(ctx context.Context) (uint64, error)
 	GetLatestExchangeRates(ctx context.Context) ([]t.EthConversionRate, error)
+	GetHistoricalExchangeRates(ctx context.Context, currency enums.Currency, startTs, endTs uint64) (map[int64]float64, error)
 
 	GetProductSummary(ctx context.Context) (*t.ProductSummary, error)
 	GetFreeTierPerks(ctx context.Context) (*t.PremiumPerks, error)
@@ -49,6 +61,10 @@ type DataAccessor interface {
 type DataAccessService struct {
 	dummy *DummyService
 
+	// chain of the data the service reads, the service of the main network holds the services of the additional networks
+	chain    *types.Chain
+	networks map[uint64]*DataAccessService
+
 	readerDb                *sqlx.DB
 	writerDb                *sqlx.DB
 	alloyReader             *sqlx.DB
@@ -57,6 +73,7 @@ type DataAccessService struct {
 	userReader              *sqlx.DB
 	userWriter              *sqlx.DB
 	bigtable                *db.Bigtable
+	blobStore               *blobindexer.BlobStore
 	persistentRedisDbClient *redis.Client
 
 	services *services.Services
@@ -90,6 +107,8 @@ func NewDataAccessService(cfg *types.Config) *DataAccessService {
 func createDataAccessService(cfg *types.Config) *DataAccessService {
 	dataAccessService := DataAccessService{
 		dummy:               NewDummyService(),
+		chain:               &cfg.Chain,
+		networks:            make(map[uint64]*DataAccessService, len(cfg.Networks)),
 		skipServiceInitWait: cfg.SkipDataAccessServiceInitWait,
 	}
 
@@ -216,6 +235,15 @@ func createDataAccessService(cfg *types.Config) *DataAccessService {
 		dataAccessService.bigtable = bt
 	}()
 
+	// Initialize the blob store, blob data is only available if the blob indexer bucket is configured
+	if len(cfg.BlobIndexer.S3.Bucket) != 0 {
+		bs, err := blobindexer.NewBlobStore()
+		if err != nil {
+			log.Fatal(err, "error initializing blob store", 0)
+		}
+		dataAccessService.blobStore = bs
+	}
+
 	// Initialize the tiered cache (redis)
 	if cfg.TieredCacheProvider == "redis" || len(cfg.RedisCacheEndpoint) != 0 {
 		wg.Add(1)
@@ -247,23 +275,113 @@ func createDataAccessService(cfg *types.Config) *DataAccessService {
 		log.Fatal(fmt.Errorf("no cache provider set, please set TierdCacheProvider (example redis)"), "", 0)
 	}
 
+	// Initialize the additional networks, they share the user and redis connections of the main network
+	for i := range cfg.Networks {
+		chainId := cfg.Networks[i].Chain.ClConfig.DepositChainID
+		if _, ok := dataAccessService.networks[chainId]; ok || chainId == cfg.Chain.ClConfig.DepositChainID {
+			log.Fatal(fmt.Errorf("network with chain id %d is configured more than once", chainId), "", 0)
+		}
+		dataAccessService.networks[chainId] = createNetworkDataAccessService(&dataAccessService, cfg, &cfg.Networks[i])
+	}
+
 	// Return the result
 	return &dataAccessService
 }
 
+func createNetworkDataAccessService(primary *DataAccessService, cfg *types.Config, networkCfg *types.NetworkConfig) *DataAccessService {
+	dataAccessService := DataAccessService{
+		dummy:                   primary.dummy,
+		chain:                   &networkCfg.Chain,
+		userReader:              primary.userReader,
+		userWriter:              primary.userWriter,
+		persistentRedisDbClient: primary.persistentRedisDbClient,
+		skipServiceInitWait:     primary.skipServiceInitWait,
+	}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		dataAccessService.writerDb, dataAccessService.readerDb = db.MustInitDB(&networkCfg.WriterDatabase, &networkCfg.ReaderDatabase, "pgx", "postgres")
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		dataAccessService.alloyWriter, dataAccessService.alloyReader = db.MustInitDB(&networkCfg.AlloyWriter, &networkCfg.AlloyReader, "pgx", "postgres")
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		clickhouseCfg := networkCfg.ClickHouseReader
+		clickhouseCfg.SSL = true
+		dataAccessService.clickhouseReader, _ = db.MustInitDB(&clickhouseCfg, &clickhouseCfg, "clickhouse", "clickhouse")
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		bt, err := db.InitBigtable(cfg.Bigtable.Project, cfg.Bigtable.Instance, fmt.Sprintf("%d", networkCfg.Chain.ClConfig.DepositChainID), cfg.RedisCacheEndpoint)
+		if err != nil {
+			log.Fatal(err, "error connecting to bigtable", 0)
+		}
+		dataAccessService.bigtable = bt
+	}()
+
+	wg.Wait()
+	log.Infof("initialized network %s (chain id %d)", networkCfg.Chain.Name, networkCfg.Chain.ClConfig.DepositChainID)
+
+	return &dataAccessService
+}
+
+// chainId returns the deposit chain id of the network the service reads data for
+func (d *DataAccessService) chainId() uint64 {
+	return d.chain.ClConfig.DepositChainID
+}
+
+// network returns the service of the network with the given chain id, or false if the network is not served
+func (d *DataAccessService) network(chainId uint64) (*DataAccessService, bool) {
+	if chainId == d.chainId() {
+		return d, true
+	}
+	network, ok := d.networks[chainId]
+	return network, ok
+}
+
+// additionalNetworks returns the services of the additional networks ordered by chain id, only the main network knows them
+func (d *DataAccessService) additionalNetworks() []*DataAccessService {
+	networks := make([]*DataAccessService, 0, len(d.networks))
+	for _, chainId := range slices.Sorted(maps.Keys(d.networks)) {
+		networks = append(networks, d.networks[chainId])
+	}
+	return networks
+}
+
 func (d *DataAccessService) StartDataAccessServices() {
 	// Create the services
-	d.services = services.NewServices(d.readerDb, d.writerDb, d.alloyReader, d.alloyWriter, d.clickhouseReader, d.bigtable, d.persistentRedisDbClient)
+	d.services = services.NewServices(d.chain, true, d.readerDb, d.writerDb, d.alloyReader, d.alloyWriter, d.clickhouseReader, d.bigtable, d.persistentRedisDbClient)
+	for _, network := range d.networks {
+		network.services = services.NewServices(network.chain, false, network.readerDb, network.writerDb, network.alloyReader, network.alloyWriter, network.clickhouseReader, network.bigtable, network.persistentRedisDbClient)
+	}
 
 	// Initialize repositories
 	d.registerNotificationInterfaceTypes()
 	// Initialize the services
 
-	if d.skipServiceInitWait {
-		go d.services.InitServices()
-	} else {
-		d.services.InitServices()
+	wg := &sync.WaitGroup{}
+	for _, das := range append([]*DataAccessService{d}, slices.Collect(maps.Values(d.networks))...) {
+		if das.skipServiceInitWait {
+			go das.services.InitServices()
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			das.services.InitServices()
+		}()
 	}
+	wg.Wait()
 }
 
 func (d *DataAccessService) Close() {
@@ -285,6 +403,15 @@ func (d *DataAccessService) Close() {
 	if d.bigtable != nil {
 		d.bigtable.Close()
 	}
+	// the connections shared with the main network are closed by the main network
+	for _, network := range d.networks {
+		network.readerDb.Close()
+		network.writerDb.Close()
+		network.alloyReader.Close()
+		network.alloyWriter.Close()
+		network.clickhouseReader.Close()
+		network.bigtable.Close()
+	}
 }
 
 var ErrNotFound = errors.New("not found")
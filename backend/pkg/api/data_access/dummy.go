@@ -18,6 +18,7 @@ import (
 	"github.com/gobitfly/beaconchain/pkg/api/enums"
 	t "github.com/gobitfly/beaconchain/pkg/api/types"
 	commontypes "github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/reports"
 	"github.com/gobitfly/beaconchain/pkg/userservice"
 	"github.com/shopspring/decimal"
 )
@@ -115,6 +116,10 @@ func (d *DummyService) GetLatestExchangeRates(ctx context.Context) ([]t.EthConve
 	return getDummyData[[]t.EthConversionRate](ctx)
 }
 
+func (d *DummyService) GetHistoricalExchangeRates(ctx context.Context, currency enums.Currency, startTs, endTs uint64) (map[int64]float64, error) {
+	return getDummyData[map[int64]float64](ctx)
+}
+
 func (d *DummyService) GetUserByEmail(ctx context.Context, email string) (uint64, error) {
 	return getDummyData[uint64](ctx)
 }
@@ -218,7 +223,7 @@ func (d *DummyService) GetUserDashboards(ctx context.Context, userId uint64) (*t
 	return getDummyStruct[t.UserDashboardsData](ctx)
 }
 
-func (d *DummyService) CreateValidatorDashboard(ctx context.Context, userId uint64, name string, network uint64) (*t.VDBPostReturnData, error) {
+func (d *DummyService) CreateValidatorDashboard(ctx context.Context, userId uint64, name string, network uint64, organizationId *uint64) (*t.VDBPostReturnData, error) {
 	return getDummyStruct[t.VDBPostReturnData](ctx)
 }
 
@@ -246,6 +251,14 @@ func (d *DummyService) UpdateValidatorDashboardName(ctx context.Context, dashboa
 	return getDummyStruct[t.VDBPostReturnData](ctx)
 }
 
+func (d *DummyService) UpdateValidatorDashboardOperatorShare(ctx context.Context, dashboardId t.VDBIdPrimary, protocol enums.StakingProtocol, operatorShare float64) (*t.VDBOperatorShare, error) {
+	return getDummyStruct[t.VDBOperatorShare](ctx)
+}
+
+func (d *DummyService) RemoveValidatorDashboardOperatorShare(ctx context.Context, dashboardId t.VDBIdPrimary, protocol enums.StakingProtocol) error {
+	return nil
+}
+
 func (d *DummyService) CreateValidatorDashboardGroup(ctx context.Context, dashboardId t.VDBIdPrimary, name string) (*t.VDBPostCreateGroupData, error) {
 	return getDummyStruct[t.VDBPostCreateGroupData](ctx)
 }
@@ -314,6 +327,32 @@ func (d *DummyService) GetValidatorDashboardSlotViz(ctx context.Context, dashboa
 	return r.Epochs, err
 }
 
+func (d *DummyService) SubscribeToValidatorDashboardSlotDuties(ctx context.Context, dashboardId t.VDBId) (<-chan t.VDBSlotDutiesEvent, error) {
+	result := make(chan t.VDBSlotDutiesEvent)
+	go func() {
+		defer close(result)
+		ticker := time.NewTicker(12 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			event, err := getDummyStruct[t.VDBSlotDutiesEvent](ctx)
+			if err != nil {
+				return
+			}
+			select {
+			case result <- *event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return result, nil
+}
+
 func (d *DummyService) GetValidatorDashboardSummary(ctx context.Context, dashboardId t.VDBId, period enums.TimePeriod, cursor string, colSort t.Sort[enums.VDBSummaryColumn], search string, limit uint64, protocolModes t.VDBProtocolModes) ([]t.VDBSummaryTableRow, *t.Paging, error) {
 	return getDummyWithPaging[t.VDBSummaryTableRow](ctx)
 }
@@ -342,6 +381,14 @@ func (d *DummyService) GetValidatorDashboardRewards(ctx context.Context, dashboa
 	return getDummyWithPaging[t.VDBRewardsTableRow](ctx)
 }
 
+func (d *DummyService) GetValidatorDashboardIncomeReport(ctx context.Context, dashboardId t.VDBId, groupId int64, currency enums.Currency, period reports.Period) (*reports.IncomeReport, error) {
+	return getDummyStruct[reports.IncomeReport](ctx)
+}
+
+func (d *DummyService) GetValidatorDashboardRewardsRange(ctx context.Context, dashboardId t.VDBId, startEpoch, endEpoch uint64, protocolModes t.VDBProtocolModes) ([]t.VDBRewardsTableRow, error) {
+	return getDummyData[[]t.VDBRewardsTableRow](ctx)
+}
+
 func (d *DummyService) GetValidatorDashboardGroupRewards(ctx context.Context, dashboardId t.VDBId, groupId int64, epoch uint64, protocolModes t.VDBProtocolModes) (*t.VDBGroupRewardsData, error) {
 	return getDummyStruct[t.VDBGroupRewardsData](ctx)
 }
@@ -390,6 +437,10 @@ func (d *DummyService) GetValidatorDashboardTotalWithdrawals(ctx context.Context
 	return getDummyStruct[t.VDBTotalWithdrawalsData](ctx)
 }
 
+func (d *DummyService) GetValidatorDashboardPoolOperations(ctx context.Context, dashboardId t.VDBId) ([]t.VDBPoolOperationsTableRow, error) {
+	return getDummyData[[]t.VDBPoolOperationsTableRow](ctx)
+}
+
 func (d *DummyService) GetValidatorDashboardRocketPool(ctx context.Context, dashboardId t.VDBId, cursor string, colSort t.Sort[enums.VDBRocketPoolColumn], search string, limit uint64) ([]t.VDBRocketPoolTableRow, *t.Paging, error) {
 	return getDummyWithPaging[t.VDBRocketPoolTableRow](ctx)
 }
@@ -422,6 +473,10 @@ func (d *DummyService) GetAllNetworks() ([]t.NetworkInfo, error) {
 	}, nil
 }
 
+func (d *DummyService) ForNetwork(chainId uint64) (DataAccessor, bool) {
+	return d, true
+}
+
 func (d *DummyService) GetAllClients() ([]t.ClientInfo, error) {
 	return []t.ClientInfo{
 		// execution_layer
@@ -530,6 +585,47 @@ func (d *DummyService) GetSearchValidatorsByGraffiti(ctx context.Context, chainI
 	return getDummyStruct[t.SearchValidatorsByGraffiti](ctx)
 }
 
+func (d *DummyService) GetValidators(ctx context.Context, indices []uint64) ([]t.ValidatorData, error) {
+	result := make([]t.ValidatorData, 0, len(indices))
+	for _, index := range indices {
+		validator, err := getDummyData[t.ValidatorData](ctx)
+		if err != nil {
+			return nil, err
+		}
+		validator.Index = index
+		result = append(result, validator)
+	}
+	return result, nil
+}
+
+func (d *DummyService) GetValidatorDuties(ctx context.Context, index, epoch uint64) (*t.ValidatorDuties, error) {
+	return getDummyStruct[t.ValidatorDuties](ctx)
+}
+
+func (d *DummyService) GetValidatorPoolOperations(ctx context.Context, index uint64) ([]t.ValidatorPoolOperation, error) {
+	return getDummyData[[]t.ValidatorPoolOperation](ctx)
+}
+
+func (d *DummyService) GetValidatorsByDepositAddress(ctx context.Context, address []byte, cursor string, limit uint64) ([]t.ValidatorData, *t.Paging, error) {
+	return getDummyWithPaging[t.ValidatorData](ctx)
+}
+
+func (d *DummyService) GetValidatorsByWithdrawalCredentials(ctx context.Context, credentials [][]byte, cursor string, limit uint64) ([]t.ValidatorData, *t.Paging, error) {
+	return getDummyWithPaging[t.ValidatorData](ctx)
+}
+
+func (d *DummyService) GetValidatorStatusCounts(ctx context.Context) ([]t.ValidatorStatusCount, error) {
+	return getDummyData[[]t.ValidatorStatusCount](ctx)
+}
+
+func (d *DummyService) GetValidatorLeaderboard(ctx context.Context, cursor string, limit uint64) ([]t.ValidatorLeaderboardTableRow, *t.Paging, error) {
+	return getDummyWithPaging[t.ValidatorLeaderboardTableRow](ctx)
+}
+
+func (d *DummyService) GetValidatorQueue(ctx context.Context) (*t.ValidatorQueue, error) {
+	return getDummyStruct[t.ValidatorQueue](ctx)
+}
+
 func (d *DummyService) GetUserValidatorDashboardCount(ctx context.Context, userId uint64, active bool) (uint64, error) {
 	return getDummyData[uint64](ctx)
 }
@@ -546,6 +642,78 @@ func (d *DummyService) GetValidatorDashboardPublicIdCount(ctx context.Context, d
 	return getDummyData[uint64](ctx)
 }
 
+func (d *DummyService) GetAccountDashboardUser(ctx context.Context, dashboardId uint64) (*t.DashboardUser, error) {
+	return getDummyStruct[t.DashboardUser](ctx)
+}
+
+func (d *DummyService) GetUserAccountDashboardCount(ctx context.Context, userId uint64) (uint64, error) {
+	return getDummyData[uint64](ctx)
+}
+
+func (d *DummyService) CreateAccountDashboard(ctx context.Context, userId uint64, name string) (*t.ADBPostReturnData, error) {
+	return getDummyStruct[t.ADBPostReturnData](ctx)
+}
+
+func (d *DummyService) RemoveAccountDashboard(ctx context.Context, dashboardId uint64) error {
+	return nil
+}
+
+func (d *DummyService) UpdateAccountDashboardName(ctx context.Context, dashboardId uint64, name string) (*t.ADBPostReturnData, error) {
+	return getDummyStruct[t.ADBPostReturnData](ctx)
+}
+
+func (d *DummyService) GetAccountDashboardOverview(ctx context.Context, dashboardId uint64) (*t.ADBOverviewData, error) {
+	return getDummyStruct[t.ADBOverviewData](ctx)
+}
+
+func (d *DummyService) CreateAccountDashboardGroup(ctx context.Context, dashboardId uint64, name string) (*t.ADBPostCreateGroupData, error) {
+	return getDummyStruct[t.ADBPostCreateGroupData](ctx)
+}
+
+func (d *DummyService) RemoveAccountDashboardGroup(ctx context.Context, dashboardId uint64, groupId uint64) error {
+	return nil
+}
+
+func (d *DummyService) GetAccountDashboardGroupCount(ctx context.Context, dashboardId uint64) (uint64, error) {
+	return getDummyData[uint64](ctx)
+}
+
+func (d *DummyService) GetAccountDashboardGroupExists(ctx context.Context, dashboardId uint64, groupId uint64) (bool, error) {
+	return true, nil
+}
+
+func (d *DummyService) AddAccountDashboardAccounts(ctx context.Context, dashboardId uint64, groupId uint64, addresses [][]byte) ([]t.ADBPostAccountsData, error) {
+	return getDummyData[[]t.ADBPostAccountsData](ctx)
+}
+
+func (d *DummyService) UpdateAccountDashboardAccount(ctx context.Context, dashboardId uint64, address []byte, groupId uint64) (*t.ADBPostAccountsData, error) {
+	return getDummyStruct[t.ADBPostAccountsData](ctx)
+}
+
+func (d *DummyService) RemoveAccountDashboardAccounts(ctx context.Context, dashboardId uint64, addresses [][]byte) error {
+	return nil
+}
+
+func (d *DummyService) GetAccountDashboardAccounts(ctx context.Context, dashboardId uint64, groupId int64, cursor string, search string, limit uint64) ([]t.ADBAccountsTableRow, *t.Paging, error) {
+	return getDummyWithPaging[t.ADBAccountsTableRow](ctx)
+}
+
+func (d *DummyService) GetAccountDashboardAccountCount(ctx context.Context, dashboardId uint64) (uint64, error) {
+	return getDummyData[uint64](ctx)
+}
+
+func (d *DummyService) GetAccountDashboardTransactions(ctx context.Context, dashboardId uint64, groupId int64, cursor string, limit uint64) ([]t.ADBTransactionsTableRow, *t.Paging, error) {
+	return getDummyWithPaging[t.ADBTransactionsTableRow](ctx)
+}
+
+func (d *DummyService) GetAccountDashboardTransactionSettings(ctx context.Context, dashboardId uint64) (*t.ADBTransactionSettings, error) {
+	return getDummyStruct[t.ADBTransactionSettings](ctx)
+}
+
+func (d *DummyService) UpdateAccountDashboardTransactionSettings(ctx context.Context, dashboardId uint64, settings t.ADBTransactionSettings) (*t.ADBTransactionSettings, error) {
+	return getDummyStruct[t.ADBTransactionSettings](ctx)
+}
+
 func (d *DummyService) GetNotificationOverview(ctx context.Context, userId uint64) (*t.NotificationOverviewData, error) {
 	return getDummyStruct[t.NotificationOverviewData](ctx)
 }
@@ -611,7 +779,7 @@ func (d *DummyService) GetNotificationSettingsDashboards(ctx context.Context, us
 func (d *DummyService) UpdateNotificationSettingsValidatorDashboard(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, settings t.NotificationSettingsValidatorDashboard) error {
 	return nil
 }
-func (d *DummyService) UpdateNotificationSettingsAccountDashboard(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, settings t.NotificationSettingsAccountDashboard) error {
+func (d *DummyService) UpdateNotificationSettingsAccountDashboard(ctx context.Context, userId uint64, dashboardId uint64, groupId uint64, settings t.NotificationSettingsAccountDashboard) error {
 	return nil
 }
 func (d *DummyService) CreateAdConfiguration(ctx context.Context, key, jquerySelector string, insertMode enums.AdInsertMode, refreshInterval uint64, forAllUsers bool, bannerId uint64, htmlContent string, enabled bool) error {
@@ -642,10 +810,6 @@ func (d *DummyService) MigrateMobileSession(ctx context.Context, oldHashedRefres
 	return nil
 }
 
-func (d *DummyService) GetAppDataFromRedirectUri(ctx context.Context, callback string) (*t.OAuthAppData, error) {
-	return getDummyStruct[t.OAuthAppData](ctx)
-}
-
 func (d *DummyService) AddUserDevice(ctx context.Context, userID uint64, hashedRefreshToken string, deviceID, deviceName string, appID uint64) error {
 	return nil
 }
@@ -746,11 +910,171 @@ func (d *DummyService) GetApiWeights(ctx context.Context) ([]t.ApiWeightItem, er
 	return getDummyData[[]t.ApiWeightItem](ctx)
 }
 
+func (d *DummyService) GetUserApiUsage(ctx context.Context, userId uint64, aggregation enums.ChartAggregation, afterTs, beforeTs uint64) ([]t.ApiKeyEndpointUsage, error) {
+	return getDummyData[[]t.ApiKeyEndpointUsage](ctx)
+}
+
+func (d *DummyService) GetApiKeyAccess(ctx context.Context, apiKey string) (*t.ApiKeyAccess, error) {
+	return getDummyStruct[t.ApiKeyAccess](ctx)
+}
+
+func (d *DummyService) GetUserApiKeys(ctx context.Context, userId uint64) ([]t.ApiKey, error) {
+	return getDummyData[[]t.ApiKey](ctx)
+}
+
+func (d *DummyService) GetUserApiKeyCount(ctx context.Context, userId uint64) (uint64, error) {
+	return getDummyData[uint64](ctx)
+}
+
+func (d *DummyService) CreateUserApiKey(ctx context.Context, userId uint64, name string, scopes []enums.ApiKeyScope, networks []uint64) (*t.ApiKey, error) {
+	return getDummyStruct[t.ApiKey](ctx)
+}
+
+func (d *DummyService) UpdateUserApiKey(ctx context.Context, userId uint64, apiKeyId uint64, name string, scopes []enums.ApiKeyScope, networks []uint64) (*t.ApiKey, error) {
+	return getDummyStruct[t.ApiKey](ctx)
+}
+
+func (d *DummyService) RotateUserApiKey(ctx context.Context, userId uint64, apiKeyId uint64) (*t.ApiKey, error) {
+	return getDummyStruct[t.ApiKey](ctx)
+}
+
+func (d *DummyService) RemoveUserApiKey(ctx context.Context, userId uint64, apiKeyId uint64) error {
+	return nil
+}
+
+func (d *DummyService) GetOAuthClient(ctx context.Context, clientId string) (*t.OAuthClient, error) {
+	return getDummyStruct[t.OAuthClient](ctx)
+}
+
+func (d *DummyService) GetOAuthClientByRedirectUri(ctx context.Context, redirectUri string) (*t.OAuthClient, error) {
+	return getDummyStruct[t.OAuthClient](ctx)
+}
+
+func (d *DummyService) CreateOAuthAuthorizationCode(ctx context.Context, code t.OAuthAuthorizationCode) (string, error) {
+	return getDummyData[string](ctx)
+}
+
+func (d *DummyService) ConsumeOAuthAuthorizationCode(ctx context.Context, appId uint64, code string) (*t.OAuthAuthorizationCode, error) {
+	return getDummyStruct[t.OAuthAuthorizationCode](ctx)
+}
+
+func (d *DummyService) CreateOAuthTokens(ctx context.Context, appId uint64, userId uint64, scopes []enums.ApiKeyScope) (*t.OAuthTokenGrant, error) {
+	return getDummyStruct[t.OAuthTokenGrant](ctx)
+}
+
+func (d *DummyService) RefreshOAuthTokens(ctx context.Context, appId uint64, refreshToken string) (*t.OAuthTokenGrant, error) {
+	return getDummyStruct[t.OAuthTokenGrant](ctx)
+}
+
+func (d *DummyService) RevokeOAuthToken(ctx context.Context, appId uint64, token string) error {
+	return nil
+}
+
+func (d *DummyService) GetOAuthAccessTokenAccess(ctx context.Context, accessToken string) (*t.ApiKeyAccess, error) {
+	return getDummyStruct[t.ApiKeyAccess](ctx)
+}
+
+func (d *DummyService) GetUserOAuthApps(ctx context.Context, userId uint64) ([]t.OAuthApp, error) {
+	return getDummyData[[]t.OAuthApp](ctx)
+}
+
+func (d *DummyService) GetUserOAuthAppCount(ctx context.Context, userId uint64) (uint64, error) {
+	return getDummyData[uint64](ctx)
+}
+
+func (d *DummyService) CreateUserOAuthApp(ctx context.Context, userId uint64, name string, redirectUri string, scopes []enums.ApiKeyScope, confidential bool) (*t.OAuthApp, error) {
+	return getDummyStruct[t.OAuthApp](ctx)
+}
+
+func (d *DummyService) RemoveUserOAuthApp(ctx context.Context, userId uint64, appId uint64) error {
+	return nil
+}
+
+func (d *DummyService) IsUserLoginLocked(ctx context.Context, userId uint64) (bool, error) {
+	return false, nil
+}
+
+func (d *DummyService) RecordUserLoginFailure(ctx context.Context, userId uint64, window time.Duration) (uint64, error) {
+	return getDummyData[uint64](ctx)
+}
+
+func (d *DummyService) LockUserLogin(ctx context.Context, userId uint64, duration time.Duration) error {
+	return nil
+}
+
+func (d *DummyService) ResetUserLoginFailures(ctx context.Context, userId uint64) error {
+	return nil
+}
+
+func (d *DummyService) GetUserTotp(ctx context.Context, userId uint64) (*t.UserTotp, error) {
+	return getDummyStruct[t.UserTotp](ctx)
+}
+
+func (d *DummyService) SetUserTotpSecret(ctx context.Context, userId uint64, secret string) error {
+	return nil
+}
+
+func (d *DummyService) ConfirmUserTotp(ctx context.Context, userId uint64, step uint64) error {
+	return nil
+}
+
+func (d *DummyService) UpdateUserTotpLastUsedStep(ctx context.Context, userId uint64, step uint64) (bool, error) {
+	return true, nil
+}
+
+func (d *DummyService) RemoveUserTotp(ctx context.Context, userId uint64) error {
+	return nil
+}
+
+func (d *DummyService) CreateUserRecoveryCodes(ctx context.Context, userId uint64) ([]string, error) {
+	return getDummyData[[]string](ctx)
+}
+
+func (d *DummyService) GetUserRecoveryCodeCount(ctx context.Context, userId uint64) (uint64, error) {
+	return getDummyData[uint64](ctx)
+}
+
+func (d *DummyService) ConsumeUserRecoveryCode(ctx context.Context, userId uint64, code string) error {
+	return nil
+}
+
+func (d *DummyService) RemoveUserRecoveryCodes(ctx context.Context, userId uint64) error {
+	return nil
+}
+
+func (d *DummyService) GetUserWebAuthnCredentials(ctx context.Context, userId uint64) ([]t.WebAuthnCredential, error) {
+	return getDummyData[[]t.WebAuthnCredential](ctx)
+}
+
+func (d *DummyService) AddUserWebAuthnCredential(ctx context.Context, userId uint64, credential t.WebAuthnCredential) (*t.WebAuthnCredential, error) {
+	return getDummyStruct[t.WebAuthnCredential](ctx)
+}
+
+func (d *DummyService) UpdateUserWebAuthnCredentialUsage(ctx context.Context, userId uint64, credentialId uint64, signCount uint32) error {
+	return nil
+}
+
+func (d *DummyService) RemoveUserWebAuthnCredential(ctx context.Context, userId uint64, credentialId uint64) error {
+	return nil
+}
+
+func (d *DummyService) GetUserDevices(ctx context.Context, userId uint64) ([]t.UserDevice, error) {
+	return getDummyData[[]t.UserDevice](ctx)
+}
+
+func (d *DummyService) DeactivateUserDevice(ctx context.Context, userId uint64, refreshTokenHash string) error {
+	return nil
+}
+
 func (d *DummyService) GetHealthz(ctx context.Context, showAll bool) t.HealthzData {
 	r, _ := getDummyData[t.HealthzData](ctx)
 	return r
 }
 
+func (d *DummyService) GetMonitoringStatus(ctx context.Context) (*t.MonitoringStatusData, error) {
+	return getDummyStruct[t.MonitoringStatusData](ctx)
+}
+
 func (d *DummyService) GetLatestBundleForNativeVersion(ctx context.Context, nativeVersion uint64) (*t.MobileAppBundleStats, error) {
 	return getDummyStruct[t.MobileAppBundleStats](ctx)
 }
@@ -777,6 +1101,9 @@ func (d *DummyService) GetUserMachineMetrics(ctx context.Context, userID uint64,
 	data.NodeMetrics = slices.SortedFunc(slices.Values(data.NodeMetrics), func(i, j *commontypes.MachineMetricNode) int {
 		return int(i.Timestamp) - int(j.Timestamp)
 	})
+	data.ExecutionNodeMetrics = slices.SortedFunc(slices.Values(data.ExecutionNodeMetrics), func(i, j *commontypes.MachineMetricNode) int {
+		return int(i.Timestamp) - int(j.Timestamp)
+	})
 	return data, nil
 }
 
@@ -784,6 +1111,14 @@ func (d *DummyService) PostUserMachineMetrics(ctx context.Context, userID uint64
 	return nil
 }
 
+func (d *DummyService) GetUserMachineMetricSeries(ctx context.Context, userID uint64, machine, metric string, since time.Time) ([]t.MachineMetricSeries, error) {
+	return getDummyData[[]t.MachineMetricSeries](ctx)
+}
+
+func (d *DummyService) PostUserMachineMetricSeries(ctx context.Context, userID uint64, machine string, series map[string][]commontypes.MachineMetricSeriesPoint) error {
+	return nil
+}
+
 func (d *DummyService) GetValidatorDashboardMobileValidators(ctx context.Context, dashboardId t.VDBId, groupId int64, period enums.TimePeriod, cursor string, colSort t.Sort[enums.VDBManageValidatorsColumn], search string, limit uint64) ([]t.MobileValidatorDashboardValidatorsTableRow, *t.Paging, error) {
 	return getDummyWithPaging[t.MobileValidatorDashboardValidatorsTableRow](ctx)
 }
@@ -797,7 +1132,115 @@ func (d *DummyService) QueueTestPushNotification(ctx context.Context, userId uin
 func (d *DummyService) QueueTestWebhookNotification(ctx context.Context, userId uint64, webhookUrl string, isDiscordWebhook bool) error {
 	return nil
 }
+func (d *DummyService) QueueTestTelegramNotification(ctx context.Context, userId uint64) error {
+	return nil
+}
+func (d *DummyService) QueueTestSlackNotification(ctx context.Context, userId uint64, webhookUrl string) error {
+	return nil
+}
+func (d *DummyService) QueueTestMatrixNotification(ctx context.Context, userId uint64, roomId string) error {
+	return nil
+}
+
+func (d *DummyService) CreateTelegramLinkToken(ctx context.Context, userId uint64, token string, expires time.Time) error {
+	return nil
+}
+func (d *DummyService) GetTelegramChatLinked(ctx context.Context, userId uint64) (bool, error) {
+	return getDummyData[bool](ctx)
+}
+func (d *DummyService) DeleteTelegramChat(ctx context.Context, userId uint64) error {
+	return nil
+}
 
 func (d *DummyService) GetPairedDeviceUserId(ctx context.Context, pairedDeviceId uint64) (uint64, error) {
 	return getDummyData[uint64](ctx)
 }
+
+func (d *DummyService) RotateNotificationSettingsValidatorDashboardWebhookSecret(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64) (string, error) {
+	return getDummyData[string](ctx)
+}
+
+func (d *DummyService) GetWebhookDeadLetters(ctx context.Context, userId uint64, cursor string, limit uint64) ([]t.NotificationWebhookDeadLetter, *t.Paging, error) {
+	return getDummyWithPaging[t.NotificationWebhookDeadLetter](ctx)
+}
+
+func (d *DummyService) RedeliverWebhookDeadLetter(ctx context.Context, userId uint64, deadLetterId uint64) error {
+	return nil
+}
+
+func (d *DummyService) GetUserOrganizations(ctx context.Context, userId uint64) ([]t.Organization, error) {
+	return getDummyData[[]t.Organization](ctx)
+}
+
+func (d *DummyService) GetUserOrganization(ctx context.Context, userId uint64, organizationId uint64) (*t.Organization, error) {
+	return getDummyStruct[t.Organization](ctx)
+}
+
+func (d *DummyService) CreateOrganization(ctx context.Context, userId uint64, name string) (*t.Organization, error) {
+	return getDummyStruct[t.Organization](ctx)
+}
+
+func (d *DummyService) UpdateOrganizationName(ctx context.Context, organizationId uint64, name string) error {
+	return nil
+}
+
+func (d *DummyService) RemoveOrganization(ctx context.Context, organizationId uint64) error {
+	return nil
+}
+
+func (d *DummyService) GetOrganizationMemberRole(ctx context.Context, organizationId uint64, userId uint64) (enums.OrganizationRole, error) {
+	return enums.OrganizationRoles.Owner, nil
+}
+
+func (d *DummyService) GetOrganizationMembers(ctx context.Context, organizationId uint64) ([]t.OrganizationMember, error) {
+	return getDummyData[[]t.OrganizationMember](ctx)
+}
+
+func (d *DummyService) UpdateOrganizationMemberRole(ctx context.Context, organizationId uint64, userId uint64, role enums.OrganizationRole) error {
+	return nil
+}
+
+func (d *DummyService) RemoveOrganizationMember(ctx context.Context, organizationId uint64, userId uint64) error {
+	return nil
+}
+
+func (d *DummyService) GetOrganizationInvitations(ctx context.Context, organizationId uint64) ([]t.OrganizationInvitation, error) {
+	return getDummyData[[]t.OrganizationInvitation](ctx)
+}
+
+func (d *DummyService) CreateOrganizationInvitation(ctx context.Context, organizationId uint64, invitedBy uint64, email string, role enums.OrganizationRole) (*t.OrganizationInvitation, string, error) {
+	r, err := getDummyStruct[t.OrganizationInvitation](ctx)
+	return r, "", err
+}
+
+func (d *DummyService) RemoveOrganizationInvitation(ctx context.Context, organizationId uint64, invitationId uint64) error {
+	return nil
+}
+
+func (d *DummyService) AcceptOrganizationInvitation(ctx context.Context, userId uint64, email string, token string) (uint64, error) {
+	return getDummyData[uint64](ctx)
+}
+
+func (d *DummyService) GetOrganizationPremiumPerks(ctx context.Context, organizationId uint64) (*t.PremiumPerks, error) {
+	return getDummyStruct[t.PremiumPerks](ctx)
+}
+
+func (d *DummyService) GetOrganizationValidatorDashboardCount(ctx context.Context, organizationId uint64, active bool) (uint64, error) {
+	return getDummyData[uint64](ctx)
+}
+
+func (d *DummyService) UpdateValidatorDashboardOwner(ctx context.Context, dashboardId t.VDBIdPrimary, userId uint64, organizationId *uint64) error {
+	return nil
+}
+
+func (d *DummyService) CreateNodeJob(ctx context.Context, userId *uint64, data []byte) (*t.Broadcast, error) {
+	return getDummyStruct[t.Broadcast](ctx)
+}
+
+func (d *DummyService) GetNodeJob(ctx context.Context, id string) (*t.Broadcast, error) {
+	return getDummyStruct[t.Broadcast](ctx)
+}
+
+func (d *DummyService) TriggerNodeJob(ctx context.Context, id string, reason string) (bool, error) {
+	return true, nil
+}
@@ -5,31 +5,39 @@ import (
 	"database/sql"
 	"fmt"
 
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
 	t "github.com/gobitfly/beaconchain/pkg/api/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/cache"
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
 	"github.com/gobitfly/beaconchain/pkg/commons/price"
-	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 )
 
 func (d *DataAccessService) GetLatestSlot(ctx context.Context) (uint64, error) {
-	latestSlot := cache.LatestSlot.Get()
+	latestSlot := cache.LatestSlot.OfChain(d.chainId()).Get()
 	return latestSlot, nil
 }
 
 func (d *DataAccessService) GetLatestFinalizedEpoch(ctx context.Context) (uint64, error) {
-	finalizedEpoch := cache.LatestFinalizedEpoch.Get()
+	finalizedEpoch := cache.LatestFinalizedEpoch.OfChain(d.chainId()).Get()
 	return finalizedEpoch, nil
 }
 
 func (d *DataAccessService) GetLatestBlock(ctx context.Context) (uint64, error) {
-	// @DATA-ACCESS implement
-	return d.dummy.GetLatestBlock(ctx)
+	return d.GetLatestBlockHeightForSlot(ctx, cache.LatestSlot.OfChain(d.chainId()).Get())
 }
 
 func (d *DataAccessService) GetBlockHeightAt(ctx context.Context, slot uint64) (uint64, error) {
-	// @DATA-ACCESS implement; return error if no block at slot
-	return getDummyData[uint64](ctx)
+	query := `SELECT exec_block_number FROM blocks WHERE slot = $1 AND status = '1' AND exec_block_number IS NOT NULL`
+	res := uint64(0)
+	err := d.alloyReader.GetContext(ctx, &res, query, slot)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("%w: no EL block at slot %d", ErrNotFound, slot)
+		}
+		return 0, fmt.Errorf("failed to get block height at slot %d: %w", slot, err)
+	}
+	return res, nil
 }
 
 // returns the block number of the latest existing block at or before the given slot
@@ -62,7 +70,7 @@ func (d *DataAccessService) GetLatestBlockHeightsForEpoch(ctx context.Context, e
 	ORDER BY slot DESC
 	LIMIT $2`
 	res := []uint64{}
-	err := d.alloyReader.SelectContext(ctx, &res, query, (epoch+1)*utils.Config.Chain.ClConfig.SlotsPerEpoch, utils.Config.Chain.ClConfig.SlotsPerEpoch)
+	err := d.alloyReader.SelectContext(ctx, &res, query, (epoch+1)*d.chain.ClConfig.SlotsPerEpoch, d.chain.ClConfig.SlotsPerEpoch)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get latest existing block heights for slots in epoch %d: %w", epoch, err)
 	}
@@ -89,3 +97,13 @@ func (d *DataAccessService) GetLatestExchangeRates(ctx context.Context) ([]t.Eth
 
 	return result, nil
 }
+
+// GetHistoricalExchangeRates returns the daily exchange rates of the native currency to the given fiat currency between startTs and endTs,
+// keyed by the unix timestamp of the start of the (UTC) day
+func (d *DataAccessService) GetHistoricalExchangeRates(ctx context.Context, currency enums.Currency, startTs, endTs uint64) (map[int64]float64, error) {
+	if currency == enums.Currencies.Native {
+		return make(map[int64]float64), nil
+	}
+	// the column name is taken from the currency enum and not from user input
+	return db.GetDailyPrices(ctx, d.readerDb, currency.Code(), startTs, endTs)
+}
@@ -3,17 +3,21 @@ package dataaccess
 import (
 	"context"
 	"slices"
+	"strings"
+	"time"
 
 	ch "github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/gobitfly/beaconchain/pkg/api/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/db"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gobitfly/beaconchain/pkg/monitoring/alerting"
 	"github.com/gobitfly/beaconchain/pkg/monitoring/constants"
 )
 
 type HealthzRepository interface {
 	GetHealthz(ctx context.Context, showAll bool) types.HealthzData
+	GetMonitoringStatus(ctx context.Context) (*types.MonitoringStatusData, error)
 }
 
 func (d *DataAccessService) GetHealthz(ctx context.Context, showAll bool) types.HealthzData {
@@ -125,23 +129,10 @@ func (d *DataAccessService) GetHealthz(ctx context.Context, showAll bool) types.
 		return response
 	}
 
-	mustExist := []string{
-		"ch_rolling_1h",
-		"ch_rolling_24h",
-		"ch_rolling_7d",
-		"ch_rolling_30d",
-		"ch_rolling_90d",
-		"ch_rolling_total",
-		"ch_dashboard_epoch",
-		"api_service_avg_efficiency",
-		"api_service_validator_mapping",
-		"api_service_slot_viz",
-		"monitoring_timeouts",
-	}
 	for _, result := range results {
 		response.Reports[result.EventId] = append(response.Reports[result.EventId], result)
 	}
-	for _, id := range mustExist {
+	for _, id := range constants.ExpectedServices {
 		if _, ok := response.Reports[id]; !ok {
 			response.Reports[id] = []types.HealthzResult{
 				{
@@ -180,3 +171,84 @@ func (d *DataAccessService) GetHealthz(ctx context.Context, showAll bool) types.
 
 	return response
 }
+
+const monitoringStatusHistoryRuns = 50
+
+// GetMonitoringStatus evaluates the alert rules over the latest status reports and returns the health of every service with its recent runs
+func (d *DataAccessService) GetMonitoringStatus(ctx context.Context) (*types.MonitoringStatusData, error) {
+	statuses, err := alerting.GetServiceStatuses(ctx, d.clickhouseReader)
+	if err != nil {
+		return nil, err
+	}
+	history, err := alerting.GetServiceStatusHistory(ctx, d.clickhouseReader, time.Now().Add(-utils.Day), monitoringStatusHistoryRuns)
+	if err != nil {
+		return nil, err
+	}
+	rules := alerting.Rules{
+		ExpectedServices:    constants.ExpectedServices,
+		MaxRollingLagEpochs: utils.Config.Monitoring.Alerting.MaxRollingLagEpochs,
+	}
+	alerts := rules.Evaluate(statuses, time.Now())
+
+	toReport := func(status alerting.ServiceStatus) types.MonitoringServiceReport {
+		return types.MonitoringServiceReport{
+			Emitter:   status.Emitter,
+			Status:    string(status.Status),
+			Timestamp: status.InsertedAt.Unix(),
+			Metadata:  status.Metadata,
+		}
+	}
+	services := make(map[string]*types.MonitoringServiceStatus)
+	getService := func(id string) *types.MonitoringServiceStatus {
+		if service, ok := services[id]; ok {
+			return service
+		}
+		service := &types.MonitoringServiceStatus{
+			Id:      id,
+			Healthy: true,
+			Alerts:  []types.MonitoringServiceAlert{},
+			Latest:  []types.MonitoringServiceReport{},
+			History: []types.MonitoringServiceReport{},
+		}
+		services[id] = service
+		return service
+	}
+	for _, id := range constants.ExpectedServices {
+		getService(id)
+	}
+	for _, status := range statuses {
+		service := getService(status.EventId)
+		service.Latest = append(service.Latest, toReport(status))
+		if status.Status == constants.Failure {
+			service.Healthy = false
+		}
+	}
+	for _, status := range history {
+		service := getService(status.EventId)
+		service.History = append(service.History, toReport(status))
+	}
+	for _, alert := range alerts {
+		service := getService(alert.Service)
+		service.Healthy = false
+		service.Alerts = append(service.Alerts, types.MonitoringServiceAlert{
+			Rule:    string(alert.Rule),
+			Emitter: alert.Emitter,
+			Summary: alert.Summary,
+			StartTs: alert.StartsAt.Unix(),
+		})
+	}
+
+	result := &types.MonitoringStatusData{
+		DeploymentType: utils.Config.DeploymentType,
+		Healthy:        true,
+		Services:       make([]types.MonitoringServiceStatus, 0, len(services)),
+	}
+	for _, service := range services {
+		result.Healthy = result.Healthy && service.Healthy
+		result.Services = append(result.Services, *service)
+	}
+	slices.SortFunc(result.Services, func(a, b types.MonitoringServiceStatus) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+	return result, nil
+}
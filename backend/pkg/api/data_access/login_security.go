@@ -0,0 +1,320 @@
+package dataaccess
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+type LoginSecurityRepository interface {
+	IsUserLoginLocked(ctx context.Context, userId uint64) (bool, error)
+	RecordUserLoginFailure(ctx context.Context, userId uint64, window time.Duration) (uint64, error)
+	LockUserLogin(ctx context.Context, userId uint64, duration time.Duration) error
+	ResetUserLoginFailures(ctx context.Context, userId uint64) error
+
+	GetUserTotp(ctx context.Context, userId uint64) (*t.UserTotp, error)
+	SetUserTotpSecret(ctx context.Context, userId uint64, secret string) error
+	ConfirmUserTotp(ctx context.Context, userId uint64, step uint64) error
+	UpdateUserTotpLastUsedStep(ctx context.Context, userId uint64, step uint64) (bool, error)
+	RemoveUserTotp(ctx context.Context, userId uint64) error
+
+	CreateUserRecoveryCodes(ctx context.Context, userId uint64) ([]string, error)
+	GetUserRecoveryCodeCount(ctx context.Context, userId uint64) (uint64, error)
+	ConsumeUserRecoveryCode(ctx context.Context, userId uint64, code string) error
+	RemoveUserRecoveryCodes(ctx context.Context, userId uint64) error
+
+	GetUserWebAuthnCredentials(ctx context.Context, userId uint64) ([]t.WebAuthnCredential, error)
+	AddUserWebAuthnCredential(ctx context.Context, userId uint64, credential t.WebAuthnCredential) (*t.WebAuthnCredential, error)
+	UpdateUserWebAuthnCredentialUsage(ctx context.Context, userId uint64, credentialId uint64, signCount uint32) error
+	RemoveUserWebAuthnCredential(ctx context.Context, userId uint64, credentialId uint64) error
+
+	GetUserDevices(ctx context.Context, userId uint64) ([]t.UserDevice, error)
+	DeactivateUserDevice(ctx context.Context, userId uint64, refreshTokenHash string) error
+}
+
+const (
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+)
+
+// Login lockout
+
+func (d *DataAccessService) IsUserLoginLocked(ctx context.Context, userId uint64) (bool, error) {
+	var locked bool
+	err := d.userReader.GetContext(ctx, &locked, `SELECT EXISTS (SELECT 1 FROM users_login_lockouts WHERE user_id = $1 AND locked_until > NOW())`, userId)
+	return locked, err
+}
+
+// RecordUserLoginFailure returns the number of failed attempts within the window, the count restarts once the window passed
+func (d *DataAccessService) RecordUserLoginFailure(ctx context.Context, userId uint64, window time.Duration) (uint64, error) {
+	var failedAttempts uint64
+	err := d.userWriter.GetContext(ctx, &failedAttempts, `
+		INSERT INTO users_login_lockouts AS l (user_id, failed_attempts, first_failed_at)
+		VALUES ($1, 1, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			failed_attempts = CASE WHEN l.first_failed_at > NOW() - $2 * INTERVAL '1 second' THEN l.failed_attempts + 1 ELSE 1 END,
+			first_failed_at = CASE WHEN l.first_failed_at > NOW() - $2 * INTERVAL '1 second' THEN l.first_failed_at ELSE NOW() END
+		RETURNING failed_attempts`,
+		userId, window.Seconds())
+	return failedAttempts, err
+}
+
+// LockUserLogin locks the login and restarts the count of failed attempts for the time after the lock
+func (d *DataAccessService) LockUserLogin(ctx context.Context, userId uint64, duration time.Duration) error {
+	_, err := d.userWriter.ExecContext(ctx, `
+		UPDATE users_login_lockouts
+		SET locked_until = NOW() + $2 * INTERVAL '1 second', failed_attempts = 0, first_failed_at = NOW()
+		WHERE user_id = $1`,
+		userId, duration.Seconds())
+	return err
+}
+
+func (d *DataAccessService) ResetUserLoginFailures(ctx context.Context, userId uint64) error {
+	_, err := d.userWriter.ExecContext(ctx, `DELETE FROM users_login_lockouts WHERE user_id = $1 AND (locked_until IS NULL OR locked_until <= NOW())`, userId)
+	return err
+}
+
+// TOTP
+
+func (d *DataAccessService) GetUserTotp(ctx context.Context, userId uint64) (*t.UserTotp, error) {
+	var row struct {
+		Secret       string `db:"secret"`
+		Confirmed    bool   `db:"confirmed"`
+		LastUsedStep uint64 `db:"last_used_step"`
+	}
+	err := d.userReader.GetContext(ctx, &row, `SELECT secret, confirmed_at IS NOT NULL AS confirmed, last_used_step FROM users_totp WHERE user_id = $1`, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: totp not set up", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t.UserTotp{
+		Secret:       row.Secret,
+		Confirmed:    row.Confirmed,
+		LastUsedStep: row.LastUsedStep,
+	}, nil
+}
+
+// SetUserTotpSecret starts a new setup, a confirmed secret is never replaced
+func (d *DataAccessService) SetUserTotpSecret(ctx context.Context, userId uint64, secret string) error {
+	_, err := d.userWriter.ExecContext(ctx, `
+		INSERT INTO users_totp (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, last_used_step = 0, created_at = NOW()
+		WHERE users_totp.confirmed_at IS NULL`,
+		userId, secret)
+	return err
+}
+
+func (d *DataAccessService) ConfirmUserTotp(ctx context.Context, userId uint64, step uint64) error {
+	result, err := d.userWriter.ExecContext(ctx, `UPDATE users_totp SET confirmed_at = NOW(), last_used_step = $2 WHERE user_id = $1 AND confirmed_at IS NULL`, userId, step)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: no pending totp setup", ErrNotFound)
+	}
+	return nil
+}
+
+// UpdateUserTotpLastUsedStep returns false if a code of the same or a later step was already used
+func (d *DataAccessService) UpdateUserTotpLastUsedStep(ctx context.Context, userId uint64, step uint64) (bool, error) {
+	result, err := d.userWriter.ExecContext(ctx, `UPDATE users_totp SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2`, userId, step)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	return rows == 1, err
+}
+
+func (d *DataAccessService) RemoveUserTotp(ctx context.Context, userId uint64) error {
+	_, err := d.userWriter.ExecContext(ctx, `DELETE FROM users_totp WHERE user_id = $1`, userId)
+	return err
+}
+
+// Recovery codes
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+// CreateUserRecoveryCodes replaces all codes of the user, the codes are only returned here and stored hashed
+func (d *DataAccessService) CreateUserRecoveryCodes(ctx context.Context, userId uint64) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make(pq.StringArray, recoveryCodeCount)
+	for i := range codes {
+		code := utils.RandomString(recoveryCodeLength)
+		codes[i] = code[:recoveryCodeLength/2] + "-" + code[recoveryCodeLength/2:]
+		hashes[i] = utils.HashAndEncode(code)
+	}
+
+	tx, err := d.userWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting db transaction: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM users_recovery_codes WHERE user_id = $1`, userId)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO users_recovery_codes (user_id, code_hash) SELECT $1, UNNEST($2::TEXT[])`, userId, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("error committing tx: %w", err)
+	}
+	return codes, nil
+}
+
+func (d *DataAccessService) GetUserRecoveryCodeCount(ctx context.Context, userId uint64) (uint64, error) {
+	var count uint64
+	err := d.userReader.GetContext(ctx, &count, `SELECT COUNT(*) FROM users_recovery_codes WHERE user_id = $1 AND used_at IS NULL`, userId)
+	return count, err
+}
+
+func (d *DataAccessService) ConsumeUserRecoveryCode(ctx context.Context, userId uint64, code string) error {
+	result, err := d.userWriter.ExecContext(ctx, `
+		UPDATE users_recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`,
+		userId, utils.HashAndEncode(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: recovery code not found", ErrNotFound)
+	}
+	return nil
+}
+
+func (d *DataAccessService) RemoveUserRecoveryCodes(ctx context.Context, userId uint64) error {
+	_, err := d.userWriter.ExecContext(ctx, `DELETE FROM users_recovery_codes WHERE user_id = $1`, userId)
+	return err
+}
+
+// WebAuthn
+
+type webAuthnCredentialRow struct {
+	Id           uint64       `db:"id"`
+	Name         string       `db:"name"`
+	CredentialId []byte       `db:"credential_id"`
+	PublicKey    []byte       `db:"public_key"`
+	Algorithm    int64        `db:"algorithm"`
+	SignCount    uint32       `db:"sign_count"`
+	CreatedAt    time.Time    `db:"created_at"`
+	LastUsedAt   sql.NullTime `db:"last_used_at"`
+}
+
+const webAuthnCredentialColumns = `id, name, credential_id, public_key, algorithm, sign_count, created_at, last_used_at`
+
+func (row webAuthnCredentialRow) toWebAuthnCredential() t.WebAuthnCredential {
+	credential := t.WebAuthnCredential{
+		Id:           row.Id,
+		Name:         row.Name,
+		CredentialId: row.CredentialId,
+		PublicKey:    row.PublicKey,
+		Algorithm:    row.Algorithm,
+		SignCount:    row.SignCount,
+		CreatedAt:    row.CreatedAt,
+	}
+	if row.LastUsedAt.Valid {
+		credential.LastUsedAt = &row.LastUsedAt.Time
+	}
+	return credential
+}
+
+func (d *DataAccessService) GetUserWebAuthnCredentials(ctx context.Context, userId uint64) ([]t.WebAuthnCredential, error) {
+	var rows []webAuthnCredentialRow
+	err := d.userReader.SelectContext(ctx, &rows, `SELECT `+webAuthnCredentialColumns+` FROM users_webauthn_credentials WHERE user_id = $1 ORDER BY created_at, id`, userId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]t.WebAuthnCredential, len(rows))
+	for i, row := range rows {
+		result[i] = row.toWebAuthnCredential()
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) AddUserWebAuthnCredential(ctx context.Context, userId uint64, credential t.WebAuthnCredential) (*t.WebAuthnCredential, error) {
+	var row webAuthnCredentialRow
+	err := d.userWriter.GetContext(ctx, &row, `
+		INSERT INTO users_webauthn_credentials (user_id, name, credential_id, public_key, algorithm, sign_count)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+webAuthnCredentialColumns,
+		userId, credential.Name, credential.CredentialId, credential.PublicKey, credential.Algorithm, credential.SignCount)
+	if err != nil {
+		return nil, err
+	}
+	result := row.toWebAuthnCredential()
+	return &result, nil
+}
+
+func (d *DataAccessService) UpdateUserWebAuthnCredentialUsage(ctx context.Context, userId uint64, credentialId uint64, signCount uint32) error {
+	_, err := d.userWriter.ExecContext(ctx, `UPDATE users_webauthn_credentials SET sign_count = $3, last_used_at = NOW() WHERE user_id = $1 AND id = $2`, userId, credentialId, signCount)
+	return err
+}
+
+func (d *DataAccessService) RemoveUserWebAuthnCredential(ctx context.Context, userId uint64, credentialId uint64) error {
+	result, err := d.userWriter.ExecContext(ctx, `DELETE FROM users_webauthn_credentials WHERE user_id = $1 AND id = $2`, userId, credentialId)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: webauthn credential %v not found", ErrNotFound, credentialId)
+	}
+	return nil
+}
+
+// Mobile devices
+
+func (d *DataAccessService) GetUserDevices(ctx context.Context, userId uint64) ([]t.UserDevice, error) {
+	var rows []struct {
+		Id               uint64 `db:"id"`
+		DeviceName       string `db:"device_name"`
+		RefreshTokenHash string `db:"refresh_token"`
+	}
+	err := d.userReader.SelectContext(ctx, &rows, `SELECT id, device_name, refresh_token FROM users_devices WHERE user_id = $1 AND active = true`, userId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]t.UserDevice, len(rows))
+	for i, row := range rows {
+		result[i] = t.UserDevice{
+			Id:               row.Id,
+			DeviceName:       row.DeviceName,
+			RefreshTokenHash: row.RefreshTokenHash,
+		}
+	}
+	return result, nil
+}
+
+// DeactivateUserDevice invalidates the refresh token of a mobile login, the device doesn't receive notifications anymore
+func (d *DataAccessService) DeactivateUserDevice(ctx context.Context, userId uint64, refreshTokenHash string) error {
+	_, err := d.userWriter.ExecContext(ctx, `UPDATE users_devices SET active = false WHERE user_id = $1 AND refresh_token = $2`, userId, refreshTokenHash)
+	return err
+}
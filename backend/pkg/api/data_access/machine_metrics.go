@@ -2,7 +2,9 @@ package dataaccess
 
 import (
 	"context"
+	"sort"
 	"strings"
+	"time"
 
 	apiTypes "github.com/gobitfly/beaconchain/pkg/api/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/db"
@@ -14,6 +16,8 @@ import (
 type MachineRepository interface {
 	GetUserMachineMetrics(context context.Context, userID uint64, limit int, offset int) (*apiTypes.MachineMetricsData, error)
 	PostUserMachineMetrics(context context.Context, userID uint64, machine, process string, data []byte) error
+	GetUserMachineMetricSeries(ctx context.Context, userID uint64, machine, metric string, since time.Time) ([]apiTypes.MachineMetricSeries, error)
+	PostUserMachineMetricSeries(ctx context.Context, userID uint64, machine string, series map[string][]types.MachineMetricSeriesPoint) error
 }
 
 func (d *DataAccessService) GetUserMachineMetrics(ctx context.Context, userID uint64, limit int, offset int) (*apiTypes.MachineMetricsData, error) {
@@ -39,6 +43,12 @@ func (d *DataAccessService) GetUserMachineMetrics(ctx context.Context, userID ui
 		return err
 	})
 
+	g.Go(func() error {
+		var err error
+		data.ExecutionNodeMetrics, err = d.bigtable.GetMachineMetricsExecutionNode(types.UserId(userID), limit, offset)
+		return err
+	})
+
 	if err := g.Wait(); err != nil {
 		return nil, errors.Wrap(err, "could not get stats")
 	}
@@ -56,3 +66,29 @@ func (d *DataAccessService) PostUserMachineMetrics(ctx context.Context, userID u
 	}
 	return nil
 }
+
+func (d *DataAccessService) GetUserMachineMetricSeries(ctx context.Context, userID uint64, machine, metric string, since time.Time) ([]apiTypes.MachineMetricSeries, error) {
+	series, err := d.bigtable.GetMachineMetricSeries(types.UserId(userID), machine, metric, since)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get series")
+	}
+	result := make([]apiTypes.MachineMetricSeries, 0, len(series))
+	for id, points := range series {
+		result = append(result, apiTypes.MachineMetricSeries{
+			Series: id,
+			Points: points,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Series < result[j].Series
+	})
+	return result, nil
+}
+
+func (d *DataAccessService) PostUserMachineMetricSeries(ctx context.Context, userID uint64, machine string, series map[string][]types.MachineMetricSeriesPoint) error {
+	err := d.bigtable.SaveMachineMetricSeries(types.UserId(userID), machine, series)
+	if err != nil {
+		return errors.Wrap(err, "could not save series")
+	}
+	return nil
+}
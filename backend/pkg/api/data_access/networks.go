@@ -4,6 +4,16 @@ import "github.com/gobitfly/beaconchain/pkg/api/types"
 
 type NetworkRepository interface {
 	GetAllNetworks() ([]types.NetworkInfo, error)
+	ForNetwork(chainId uint64) (DataAccessor, bool)
+}
+
+// ForNetwork returns the data accessor of the network with the given chain id, or false if the network is not served by this api
+func (d *DataAccessService) ForNetwork(chainId uint64) (DataAccessor, bool) {
+	network, ok := d.network(chainId)
+	if !ok {
+		return nil, false
+	}
+	return network, true
 }
 
 func (d *DataAccessService) GetAllNetworks() ([]types.NetworkInfo, error) {
@@ -0,0 +1,52 @@
+package dataaccess
+
+import (
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+)
+
+func newTestNetworkService(chainId uint64) *DataAccessService {
+	chain := &types.Chain{}
+	chain.ClConfig.DepositChainID = chainId
+	return &DataAccessService{chain: chain}
+}
+
+func TestForNetwork(t *testing.T) {
+	mainnet := newTestNetworkService(1)
+	holesky := newTestNetworkService(17000)
+	mainnet.networks = map[uint64]*DataAccessService{17000: holesky}
+
+	tests := []struct {
+		chainId uint64
+		want    *DataAccessService
+	}{
+		{chainId: 1, want: mainnet},
+		{chainId: 17000, want: holesky},
+		{chainId: 11155111},
+	}
+	for _, tt := range tests {
+		network, ok := mainnet.ForNetwork(tt.chainId)
+		if ok != (tt.want != nil) {
+			t.Fatalf("ForNetwork(%d) returned ok = %v", tt.chainId, ok)
+		}
+		if ok && network != tt.want {
+			t.Errorf("ForNetwork(%d) returned the service of chain %d", tt.chainId, network.(*DataAccessService).chainId())
+		}
+	}
+}
+
+func TestAdditionalNetworks(t *testing.T) {
+	mainnet := newTestNetworkService(1)
+	gnosis := newTestNetworkService(100)
+	holesky := newTestNetworkService(17000)
+	mainnet.networks = map[uint64]*DataAccessService{17000: holesky, 100: gnosis}
+
+	networks := mainnet.additionalNetworks()
+	if len(networks) != 2 || networks[0] != gnosis || networks[1] != holesky {
+		t.Errorf("expected the additional networks ordered by chain id")
+	}
+	if len(holesky.additionalNetworks()) != 0 {
+		t.Errorf("expected additional networks to not know the other networks")
+	}
+}
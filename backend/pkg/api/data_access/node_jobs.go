@@ -0,0 +1,150 @@
+package dataaccess
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gobitfly/beaconchain/pkg/nodejobs"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+type NodeJobRepository interface {
+	CreateNodeJob(ctx context.Context, userId *uint64, data []byte) (*t.Broadcast, error)
+	GetNodeJob(ctx context.Context, id string) (*t.Broadcast, error)
+	TriggerNodeJob(ctx context.Context, id string, reason string) (bool, error)
+}
+
+// CreateNodeJob creates a job from the signed operations in data, jobs of logged in users are owned by them.
+// Invalid data results in a types.CreateNodeJobUserError.
+func (d *DataAccessService) CreateNodeJob(ctx context.Context, userId *uint64, data []byte) (*t.Broadcast, error) {
+	job, err := types.NewNodeJob(data)
+	if err != nil {
+		return nil, err
+	}
+	if userId != nil {
+		job.UserID = sql.NullInt64{Int64: int64(*userId), Valid: true}
+	}
+
+	if jobData, ok := job.GetConditionalVoluntaryExitsNodeJobData(); ok {
+		// conditional exits are bound to the dashboard group whose siblings they watch
+		var groupValidators []uint64
+		err = d.alloyReader.SelectContext(ctx, &groupValidators, `
+			SELECT validator_index
+			FROM users_val_dashboards_validators
+			WHERE dashboard_id = $1 AND group_id = $2`, jobData.DashboardId, jobData.GroupId)
+		if err != nil {
+			return nil, err
+		}
+		groupValidatorSet := utils.SliceToMap(groupValidators)
+		for _, exit := range jobData.Exits {
+			if !groupValidatorSet[uint64(exit.Message.ValidatorIndex)] {
+				return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("validator with index %v is not part of the dashboard group", exit.Message.ValidatorIndex)}
+			}
+		}
+	}
+
+	job, err = nodejobs.CreateParsedNodeJob(job)
+	if err != nil {
+		return nil, err
+	}
+	// reload the job to get the timestamps set by the db
+	return d.GetNodeJob(ctx, job.ID)
+}
+
+func (d *DataAccessService) GetNodeJob(ctx context.Context, id string) (*t.Broadcast, error) {
+	job, err := nodejobs.GetNodeJob(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: broadcast with id %s", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return d.getBroadcast(ctx, job)
+}
+
+// TriggerNodeJob releases a job that waits for its trigger, it returns false if the job wasn't waiting anymore
+func (d *DataAccessService) TriggerNodeJob(ctx context.Context, id string, reason string) (bool, error) {
+	return nodejobs.TriggerNodeJob(id, reason)
+}
+
+func (d *DataAccessService) getBroadcast(ctx context.Context, job *types.NodeJob) (*t.Broadcast, error) {
+	result := &t.Broadcast{
+		Id:        job.ID,
+		Type:      strings.ToLower(string(job.Type)),
+		Status:    strings.ToLower(string(job.Status)),
+		CreatedTs: job.CreatedTime.Unix(),
+	}
+	if job.UserID.Valid {
+		userId := uint64(job.UserID.Int64)
+		result.UserId = &userId
+	}
+	if job.SubmittedToNodeTime.Valid {
+		ts := job.SubmittedToNodeTime.Time.Unix()
+		result.SubmittedTs = &ts
+	}
+	if job.CompletedTime.Valid {
+		ts := job.CompletedTime.Time.Unix()
+		result.CompletedTs = &ts
+	}
+	if jobData, ok := job.GetConditionalVoluntaryExitsNodeJobData(); ok {
+		result.DashboardId = &jobData.DashboardId
+		result.GroupId = &jobData.GroupId
+		result.Trigger = &t.BroadcastTrigger{
+			Type:   string(jobData.Trigger.Type),
+			Epoch:  jobData.Trigger.Epoch,
+			Epochs: jobData.Trigger.Epochs,
+		}
+	}
+
+	indices, err := nodejobs.GetNodeJobValidatorIndices(job)
+	if err != nil {
+		return nil, err
+	}
+	var validators []struct {
+		Index  uint64 `db:"validatorindex"`
+		Pubkey []byte `db:"pubkey"`
+		Status string `db:"status"`
+	}
+	err = d.readerDb.SelectContext(ctx, &validators, `
+		SELECT validatorindex, pubkey, status
+		FROM validators
+		WHERE validatorindex = ANY($1)
+		ORDER BY validatorindex`, pq.Array(indices))
+	if err != nil {
+		return nil, err
+	}
+	result.Validators = make([]t.BroadcastValidator, 0, len(validators))
+	for _, validator := range validators {
+		result.Validators = append(result.Validators, t.BroadcastValidator{
+			Index:     validator.Index,
+			PublicKey: t.PubKey(hexutil.Encode(validator.Pubkey)),
+			Status:    validator.Status,
+		})
+	}
+
+	auditLog, err := nodejobs.GetNodeJobAuditLog(job.ID)
+	if err != nil {
+		return nil, err
+	}
+	result.AuditLog = make([]t.BroadcastAuditLogEntry, 0, len(auditLog))
+	for _, entry := range auditLog {
+		resultEntry := t.BroadcastAuditLogEntry{
+			Timestamp: entry.Ts.Unix(),
+			Action:    strings.ToLower(string(entry.Action)),
+			Details:   entry.Details,
+		}
+		if entry.ValidatorIndex.Valid {
+			index := uint64(entry.ValidatorIndex.Int64)
+			resultEntry.Index = &index
+		}
+		result.AuditLog = append(result.AuditLog, resultEntry)
+	}
+	return result, nil
+}
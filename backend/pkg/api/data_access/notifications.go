@@ -6,8 +6,11 @@ import (
 	"context"
 	"database/sql"
 	"encoding/gob"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"regexp"
 	"slices"
 	"sort"
@@ -56,11 +59,22 @@ type NotificationsRepository interface {
 	UpdateNotificationSettingsClients(ctx context.Context, userId uint64, clientId uint64, IsSubscribed bool) (*t.NotificationSettingsClient, error)
 	GetNotificationSettingsDashboards(ctx context.Context, userId uint64, cursor string, colSort t.Sort[enums.NotificationSettingsDashboardColumn], search string, limit uint64) ([]t.NotificationSettingsDashboardsTableRow, *t.Paging, error)
 	UpdateNotificationSettingsValidatorDashboard(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, settings t.NotificationSettingsValidatorDashboard) error
-	UpdateNotificationSettingsAccountDashboard(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, settings t.NotificationSettingsAccountDashboard) error
+	UpdateNotificationSettingsAccountDashboard(ctx context.Context, userId uint64, dashboardId uint64, groupId uint64, settings t.NotificationSettingsAccountDashboard) error
 
 	QueueTestEmailNotification(ctx context.Context, userId uint64) error
 	QueueTestPushNotification(ctx context.Context, userId uint64) error
 	QueueTestWebhookNotification(ctx context.Context, userId uint64, webhookUrl string, isDiscordWebhook bool) error
+	QueueTestTelegramNotification(ctx context.Context, userId uint64) error
+	QueueTestSlackNotification(ctx context.Context, userId uint64, webhookUrl string) error
+	QueueTestMatrixNotification(ctx context.Context, userId uint64, roomId string) error
+
+	CreateTelegramLinkToken(ctx context.Context, userId uint64, token string, expires time.Time) error
+	GetTelegramChatLinked(ctx context.Context, userId uint64) (bool, error)
+	DeleteTelegramChat(ctx context.Context, userId uint64) error
+
+	RotateNotificationSettingsValidatorDashboardWebhookSecret(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64) (string, error)
+	GetWebhookDeadLetters(ctx context.Context, userId uint64, cursor string, limit uint64) ([]t.NotificationWebhookDeadLetter, *t.Paging, error)
+	RedeliverWebhookDeadLetter(ctx context.Context, userId uint64, deadLetterId uint64) error
 }
 
 func (*DataAccessService) registerNotificationInterfaceTypes() {
@@ -74,6 +88,7 @@ func (*DataAccessService) registerNotificationInterfaceTypes() {
 		gob.Register(&n.ValidatorIsOnlineNotification{})
 		gob.Register(&n.ValidatorGotSlashedNotification{})
 		gob.Register(&n.ValidatorWithdrawalNotification{})
+		gob.Register(&n.ValidatorPoolOperationNotIncludedNotification{})
 		gob.Register(&n.NetworkNotification{})
 		gob.Register(&n.RocketpoolNotification{})
 		gob.Register(&n.MonitorMachineNotification{})
@@ -82,6 +97,8 @@ func (*DataAccessService) registerNotificationInterfaceTypes() {
 		gob.Register(&n.SyncCommitteeSoonNotification{})
 		gob.Register(&n.GasAboveThresholdNotification{})
 		gob.Register(&n.GasBelowThresholdNotification{})
+		gob.Register(&n.AccountTransactionNotification{})
+		gob.Register(&n.AccountTokenTransferNotification{})
 	})
 }
 
@@ -93,6 +110,10 @@ const (
 	MaxCollateralThresholdDefault            float64 = 1.0
 	MinCollateralThresholdDefault            float64 = 0.2
 	ERC20TokenTransfersValueThresholdDefault float64 = 0.1
+	PoolOperationNotIncludedThresholdDefault uint64  = 4
+
+	IncomeReportCurrencyDefault string = "USD"
+	IncomeReportFormatDefault   string = "pdf"
 
 	MachineStorageUsageThresholdDefault float64 = 0.9
 	MachineCpuUsageThresholdDefault     float64 = 0.6
@@ -184,12 +205,11 @@ func (d *DataAccessService) GetNotificationOverview(ctx context.Context, userId
 		response.VDBMostNotifiedGroups, err = getMostNotifiedGroups("users_val_dashboards_notifications_history", "users_val_dashboards_groups")
 		return err
 	})
-	// TODO account dashboards
-	/*eg.Go(func() error {
+	eg.Go(func() error {
 		var err error
-		response.VDBMostNotifiedGroups, err = getMostNotifiedGroups("users_acc_dashboards_notifications_history", "users_acc_dashboards_groups")
+		response.ADBMostNotifiedGroups, err = getMostNotifiedGroups("users_acc_dashboards_notifications_history", "users_acc_dashboards_groups")
 		return err
-	})*/
+	})
 
 	// 24h counts
 	eg.Go(func() error {
@@ -308,41 +328,44 @@ func (d *DataAccessService) GetDashboardNotifications(ctx context.Context, userI
 		)
 	}
 
-	// TODO account dashboards
-	/*adbQuery := goqu.Dialect("postgres").
-		From(goqu.T("adb_notifications_history").As("anh")).
+	// account query
+	adbQuery := goqu.Dialect("postgres").
+		From(goqu.T("users_acc_dashboards_notifications_history").As("uadnh")).
 		Select(
 			goqu.L("true").As("is_account_dashboard"),
-			goqu.I("anh.network").As("chain_id"),
-			goqu.I("anh.epoch"),
+			goqu.I("uadnh.network").As("chain_id"),
+			goqu.I("uadnh.epoch"),
 			goqu.I("uad.id").As("dashboard_id"),
 			goqu.I("uad.name").As("dashboard_name"),
 			goqu.I("uadg.id").As("group_id"),
 			goqu.I("uadg.name").As("group_name"),
-			goqu.SUM("anh.event_count").As("entity_count"),
+			goqu.SUM("uadnh.event_count").As("entity_count"),
 			goqu.L("ARRAY_AGG(DISTINCT event_type)").As("event_types"),
 		).
 		InnerJoin(goqu.T("users_acc_dashboards").As("uad"), goqu.On(
-			goqu.Ex{"uad.id": goqu.I("anh.dashboard_id"),
-			})).
+			goqu.Ex{"uad.id": goqu.I("uadnh.dashboard_id")})).
 		InnerJoin(goqu.T("users_acc_dashboards_groups").As("uadg"), goqu.On(
-			goqu.Ex{"uadg.id": goqu.I("anh.group_id"),
+			goqu.Ex{"uadg.id": goqu.I("uadnh.group_id")},
 			goqu.Ex{"uadg.dashboard_id": goqu.I("uad.id")},
-			})).
+		)).
 		Where(
 			goqu.Ex{"uad.user_id": userId},
-			goqu.L("anh.network = ANY(?)", pq.Array(chainIds)),
 		).
 		GroupBy(
-			goqu.I("anh.epoch"),
-			goqu.I("anh.network"),
+			goqu.I("uadnh.epoch"),
+			goqu.I("uadnh.network"),
 			goqu.I("uad.id"),
 			goqu.I("uadg.id"),
 			goqu.I("uadg.name"),
 		)
 
-	unionQuery := vdbQuery.Union(adbQuery)*/
-	unionQuery := goqu.From(vdbQuery)
+	if chainIds != nil {
+		adbQuery = adbQuery.Where(
+			goqu.L("uadnh.network = ANY(?)", pq.Array(chainIds)),
+		)
+	}
+
+	unionQuery := goqu.From(vdbQuery.Union(adbQuery))
 
 	// sorting
 	defaultColumns := []t.SortColumn{
@@ -415,6 +438,7 @@ func (d *DataAccessService) GetValidatorDashboardNotificationDetails(ctx context
 		ValidatorOnline:          []t.NotificationEventValidatorBackOnline{},
 		MinCollateral:            []t.Address{},
 		MaxCollateral:            []t.Address{},
+		PoolOperationNotIncluded: []t.NotificationEventPoolOperation{},
 	}
 
 	var searchIndices []uint64
@@ -643,6 +667,19 @@ func (d *DataAccessService) GetValidatorDashboardNotificationDetails(ctx context
 					continue
 				}
 				notificationDetails.Sync = append(notificationDetails.Sync, curNotification.ValidatorIndex)
+			case types.ValidatorPoolOperationNotIncludedEventName:
+				curNotification, ok := notification.(*n.ValidatorPoolOperationNotIncludedNotification)
+				if !ok {
+					return nil, fmt.Errorf("failed to cast notification to ValidatorPoolOperationNotIncludedNotification")
+				}
+				if searchEnabled && !searchIndexSet[curNotification.ValidatorIndex] {
+					continue
+				}
+				notificationDetails.PoolOperationNotIncluded = append(notificationDetails.PoolOperationNotIncluded, t.NotificationEventPoolOperation{
+					Index:         curNotification.ValidatorIndex,
+					Type:          curNotification.OperationType,
+					FirstSeenSlot: curNotification.FirstSeenSlot,
+				})
 			default:
 				log.Debugf("Unhandled notification type: %s", notification.GetEventName())
 			}
@@ -696,7 +733,125 @@ func (d *DataAccessService) GetValidatorDashboardNotificationDetails(ctx context
 }
 
 func (d *DataAccessService) GetAccountDashboardNotificationDetails(ctx context.Context, dashboardId uint64, groupId uint64, epoch uint64, search string) (*t.NotificationAccountDashboardDetail, error) {
-	return d.dummy.GetAccountDashboardNotificationDetails(ctx, dashboardId, groupId, epoch, search)
+	notificationDetails := t.NotificationAccountDashboardDetail{
+		IncomingTransactions:  []t.NotificationEventExecution{},
+		OutgoingTransactions:  []t.NotificationEventExecution{},
+		ERC20TokenTransfers:   []t.NotificationEventExecution{},
+		ERC721TokenTransfers:  []t.NotificationEventExecution{},
+		ERC1155TokenTransfers: []t.NotificationEventExecution{},
+	}
+
+	search = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(search), "0x"))
+
+	// -------------------------------------
+	// retrieve notification events
+	eventTypesEncodedList := [][]byte{}
+	query := `SELECT details FROM users_acc_dashboards_notifications_history WHERE dashboard_id = $1 AND group_id = $2 AND epoch = $3`
+	err := d.alloyReader.SelectContext(ctx, &eventTypesEncodedList, query, dashboardId, groupId, epoch)
+	if err != nil {
+		return nil, err
+	}
+	if len(eventTypesEncodedList) == 0 {
+		return &notificationDetails, nil
+	}
+
+	addressMapping := make(map[string]*t.Address)
+	for _, eventTypesEncoded := range eventTypesEncodedList {
+		buf := bytes.NewBuffer(eventTypesEncoded)
+		gz, err := gzip.NewReader(buf)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+
+		eventTypes, err := io.ReadAll(gz)
+		if err != nil {
+			return nil, err
+		}
+
+		decoder := gob.NewDecoder(bytes.NewReader(eventTypes))
+
+		notifications := []types.Notification{}
+		err = decoder.Decode(&notifications)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, notification := range notifications {
+			// ETH amounts are in WEI, erc20 amounts are scaled by the decimals of the token
+			var address []byte
+			var execution t.NotificationEventExecution
+			switch curNotification := notification.(type) {
+			case *n.AccountTransactionNotification:
+				address = curNotification.Address
+				execution = t.NotificationEventExecution{
+					Amount:          decimal.NewFromBigInt(new(big.Int).SetBytes(curNotification.Value), 0),
+					TransactionHash: t.Hash(hexutil.Encode(curNotification.TxHash)),
+					TokenName:       utils.Config.Frontend.ElCurrency,
+				}
+			case *n.AccountTokenTransferNotification:
+				address = curNotification.Address
+				execution = t.NotificationEventExecution{
+					TransactionHash: t.Hash(hexutil.Encode(curNotification.TxHash)),
+					TokenName:       curNotification.TokenSymbol,
+				}
+				switch curNotification.GetEventName() {
+				case types.ERC721TokenTransferEventName:
+					execution.Amount = decimal.NewFromInt(1)
+				case types.ERC1155TokenTransferEventName:
+					execution.Amount = decimal.NewFromBigInt(new(big.Int).SetBytes(curNotification.Value), 0)
+				default:
+					execution.Amount = decimal.NewFromBigInt(new(big.Int).SetBytes(curNotification.Value), -int32(curNotification.TokenDecimals)) //nolint:gosec
+				}
+				if execution.TokenName == "" {
+					execution.TokenName = hexutil.Encode(curNotification.TokenAddress)
+				}
+			default:
+				log.Debugf("Unhandled notification type: %s", notification.GetEventName())
+				continue
+			}
+			if search != "" && !strings.Contains(hex.EncodeToString(address), search) {
+				continue
+			}
+
+			addr := t.Address{Hash: t.Hash(hexutil.Encode(address))}
+			addressMapping[hexutil.Encode(address)] = &addr
+			execution.Address = addr
+
+			switch notification.GetEventName() {
+			case types.IncomingTransactionEventName:
+				notificationDetails.IncomingTransactions = append(notificationDetails.IncomingTransactions, execution)
+			case types.OutgoingTransactionEventName:
+				notificationDetails.OutgoingTransactions = append(notificationDetails.OutgoingTransactions, execution)
+			case types.ERC20TokenTransferEventName:
+				notificationDetails.ERC20TokenTransfers = append(notificationDetails.ERC20TokenTransfers, execution)
+			case types.ERC721TokenTransferEventName:
+				notificationDetails.ERC721TokenTransfers = append(notificationDetails.ERC721TokenTransfers, execution)
+			case types.ERC1155TokenTransferEventName:
+				notificationDetails.ERC1155TokenTransfers = append(notificationDetails.ERC1155TokenTransfers, execution)
+			}
+		}
+	}
+
+	// fill addresses
+	if err := d.GetNamesAndEnsForAddresses(ctx, addressMapping); err != nil {
+		return nil, err
+	}
+	for _, executions := range [][]t.NotificationEventExecution{
+		notificationDetails.IncomingTransactions,
+		notificationDetails.OutgoingTransactions,
+		notificationDetails.ERC20TokenTransfers,
+		notificationDetails.ERC721TokenTransfers,
+		notificationDetails.ERC1155TokenTransfers,
+	} {
+		for i := range executions {
+			if address, ok := addressMapping[string(executions[i].Address.Hash)]; ok {
+				executions[i].Address = *address
+			}
+		}
+	}
+
+	return &notificationDetails, nil
 }
 
 func (d *DataAccessService) GetMachineNotifications(ctx context.Context, userId uint64, cursor string, colSort t.Sort[enums.NotificationMachinesColumn], search string, limit uint64) ([]t.NotificationMachinesTableRow, *t.Paging, error) {
@@ -1038,6 +1193,9 @@ func (d *DataAccessService) GetNotificationSettings(ctx context.Context, userId
 			MachineStorageUsageThreshold: MachineStorageUsageThresholdDefault,
 			MachineCpuUsageThreshold:     MachineCpuUsageThresholdDefault,
 			MachineMemoryUsageThreshold:  MachineMemoryUsageThresholdDefault,
+			DeliveryMode:                 string(types.NotificationDeliveryImmediate),
+			Timezone:                     "UTC",
+			GroupDeliveryModes:           []t.NotificationGroupDeliveryMode{},
 		},
 	}
 
@@ -1073,16 +1231,46 @@ func (d *DataAccessService) GetNotificationSettings(ctx context.Context, userId
 	}
 
 	// -------------------------------------
-	// Get the "do not disturb" setting
-	var doNotDisturbTimestamp sql.NullTime
+	// Get the "do not disturb" and delivery settings
+	var userSettings struct {
+		DoNotDisturbTimestamp sql.NullTime   `db:"notifications_do_not_disturb_ts"`
+		DeliveryMode          sql.NullString `db:"notifications_delivery_mode"`
+		QuietHoursStart       sql.NullInt16  `db:"notifications_quiet_hours_start"`
+		QuietHoursEnd         sql.NullInt16  `db:"notifications_quiet_hours_end"`
+		Timezone              sql.NullString `db:"notifications_timezone"`
+	}
 	wg.Go(func() error {
-		err := d.userReader.GetContext(ctx, &doNotDisturbTimestamp, `
+		err := d.userReader.GetContext(ctx, &userSettings, `
 		SELECT
-			notifications_do_not_disturb_ts
+			notifications_do_not_disturb_ts,
+			notifications_delivery_mode,
+			notifications_quiet_hours_start,
+			notifications_quiet_hours_end,
+			notifications_timezone
 		FROM users
 		WHERE id = $1`, userId)
 		if err != nil {
-			return fmt.Errorf(`error retrieving data for notifications "do not disturb" setting: %w`, err)
+			return fmt.Errorf(`error retrieving data for notifications "do not disturb" and delivery settings: %w`, err)
+		}
+
+		return nil
+	})
+
+	// -------------------------------------
+	// Get the delivery mode overrides of the dashboard groups
+	groupDeliveryModes := []t.NotificationGroupDeliveryMode{}
+	wg.Go(func() error {
+		err := d.alloyReader.SelectContext(ctx, &groupDeliveryModes, `
+		SELECT
+			g.dashboard_id,
+			g.id AS group_id,
+			g.notifications_delivery_mode AS delivery_mode
+		FROM users_val_dashboards_groups g
+		INNER JOIN users_val_dashboards d ON d.id = g.dashboard_id
+		WHERE d.user_id = $1 AND g.notifications_delivery_mode IS NOT NULL
+		ORDER BY g.dashboard_id, g.id`, userId)
+		if err != nil {
+			return fmt.Errorf(`error retrieving data for notifications group delivery settings: %w`, err)
 		}
 
 		return nil
@@ -1178,6 +1366,15 @@ func (d *DataAccessService) GetNotificationSettings(ctx context.Context, userId
 		return nil
 	})
 
+	// -------------------------------------
+	// Get the linked telegram chat
+	isTelegramLinked := false
+	wg.Go(func() error {
+		var err error
+		isTelegramLinked, err = d.GetTelegramChatLinked(ctx, userId)
+		return err
+	})
+
 	err = wg.Wait()
 	if err != nil {
 		return nil, err
@@ -1186,9 +1383,22 @@ func (d *DataAccessService) GetNotificationSettings(ctx context.Context, userId
 	// -------------------------------------
 	// Fill the result
 	result.HasMachines = hasMachines
-	if doNotDisturbTimestamp.Valid {
-		result.GeneralSettings.DoNotDisturbTimestamp = doNotDisturbTimestamp.Time.Unix()
+	result.IsTelegramLinked = isTelegramLinked
+	if userSettings.DoNotDisturbTimestamp.Valid {
+		result.GeneralSettings.DoNotDisturbTimestamp = userSettings.DoNotDisturbTimestamp.Time.Unix()
 	}
+	if userSettings.DeliveryMode.Valid {
+		result.GeneralSettings.DeliveryMode = userSettings.DeliveryMode.String
+	}
+	if userSettings.QuietHoursStart.Valid && userSettings.QuietHoursEnd.Valid {
+		result.GeneralSettings.IsQuietHoursEnabled = true
+		result.GeneralSettings.QuietHoursStart = uint64(userSettings.QuietHoursStart.Int16)
+		result.GeneralSettings.QuietHoursEnd = uint64(userSettings.QuietHoursEnd.Int16)
+	}
+	if userSettings.Timezone.Valid {
+		result.GeneralSettings.Timezone = userSettings.Timezone.String
+	}
+	result.GeneralSettings.GroupDeliveryModes = groupDeliveryModes
 
 	for _, channel := range notificationChannels {
 		switch channel.Channel {
@@ -1288,6 +1498,7 @@ func (d *DataAccessService) GetNotificationSettingsDefaultValues(ctx context.Con
 		MaxCollateralThreshold:            MaxCollateralThresholdDefault,
 		MinCollateralThreshold:            MinCollateralThresholdDefault,
 		ERC20TokenTransfersValueThreshold: ERC20TokenTransfersValueThresholdDefault,
+		PoolOperationNotIncludedThreshold: PoolOperationNotIncludedThresholdDefault,
 
 		MachineStorageUsageThreshold: MachineStorageUsageThresholdDefault,
 		MachineCpuUsageThreshold:     MachineCpuUsageThresholdDefault,
@@ -1321,6 +1532,25 @@ func (d *DataAccessService) UpdateNotificationSettingsGeneral(ctx context.Contex
 		return err
 	}
 
+	// -------------------------------------
+	// Set the delivery settings
+	quietHoursStart, quietHoursEnd := sql.NullInt16{}, sql.NullInt16{}
+	if settings.IsQuietHoursEnabled {
+		quietHoursStart = sql.NullInt16{Int16: int16(settings.QuietHoursStart), Valid: true}
+		quietHoursEnd = sql.NullInt16{Int16: int16(settings.QuietHoursEnd), Valid: true}
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE users
+		SET
+			notifications_delivery_mode = NULLIF($1, $2),
+			notifications_quiet_hours_start = $3,
+			notifications_quiet_hours_end = $4,
+			notifications_timezone = NULLIF($5, '')
+		WHERE id = $6`, settings.DeliveryMode, types.NotificationDeliveryImmediate, quietHoursStart, quietHoursEnd, settings.Timezone, userId)
+	if err != nil {
+		return err
+	}
+
 	// -------------------------------------
 	// Set the notification channels
 	_, err = tx.ExecContext(ctx, `
@@ -1340,6 +1570,39 @@ func (d *DataAccessService) UpdateNotificationSettingsGeneral(ctx context.Contex
 	if err != nil {
 		return fmt.Errorf("error committing tx to update general notification settings: %w", err)
 	}
+
+	// -------------------------------------
+	// Replace the delivery mode overrides of the users dashboard groups
+	alloyTx, err := d.alloyWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting db transactions to update group delivery settings: %w", err)
+	}
+	defer utils.Rollback(alloyTx)
+
+	_, err = alloyTx.ExecContext(ctx, `
+		UPDATE users_val_dashboards_groups g
+		SET notifications_delivery_mode = NULL
+		FROM users_val_dashboards d
+		WHERE d.id = g.dashboard_id AND d.user_id = $1 AND g.notifications_delivery_mode IS NOT NULL`, userId)
+	if err != nil {
+		return err
+	}
+	for _, groupDeliveryMode := range settings.GroupDeliveryModes {
+		_, err = alloyTx.ExecContext(ctx, `
+			UPDATE users_val_dashboards_groups g
+			SET notifications_delivery_mode = $1
+			FROM users_val_dashboards d
+			WHERE d.id = g.dashboard_id AND d.user_id = $2 AND g.dashboard_id = $3 AND g.id = $4`,
+			groupDeliveryMode.DeliveryMode, userId, groupDeliveryMode.DashboardId, groupDeliveryMode.GroupId)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = alloyTx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing tx to update group delivery settings: %w", err)
+	}
 	return nil
 }
 
@@ -1552,7 +1815,7 @@ func (d *DataAccessService) GetNotificationSettingsDashboards(ctx context.Contex
 				event_filter,
 				event_threshold
 			FROM users_subscriptions
-			WHERE user_id = $1 AND event_name LIKE $2`, userId, networkName+"%")
+			WHERE user_id = $1 AND (event_name LIKE $2 OR event_filter LIKE $3)`, userId, networkName+"%", AccountDashboardEventPrefix+":%")
 		if err != nil {
 			return fmt.Errorf(`error retrieving data for account dashboard notifications: %w`, err)
 		}
@@ -1563,13 +1826,19 @@ func (d *DataAccessService) GetNotificationSettingsDashboards(ctx context.Contex
 	// -------------------------------------
 	// Get the validator dashboards
 	valDashboards := []struct {
-		DashboardId   uint64         `db:"dashboard_id"`
-		DashboardName string         `db:"dashboard_name"`
-		GroupId       uint64         `db:"group_id"`
-		GroupName     string         `db:"group_name"`
-		Network       uint64         `db:"network"`
-		WebhookUrl    sql.NullString `db:"webhook_target"`
-		WebhookFormat sql.NullString `db:"webhook_format"`
+		DashboardId          uint64         `db:"dashboard_id"`
+		DashboardName        string         `db:"dashboard_name"`
+		GroupId              uint64         `db:"group_id"`
+		GroupName            string         `db:"group_name"`
+		Network              uint64         `db:"network"`
+		WebhookUrl           sql.NullString `db:"webhook_target"`
+		WebhookFormat        sql.NullString `db:"webhook_format"`
+		WebhookSigningSecret string         `db:"webhook_signing_secret"`
+		TelegramEnabled      bool           `db:"telegram_enabled"`
+		SlackWebhookUrl      sql.NullString `db:"slack_webhook_url"`
+		MatrixRoomId         sql.NullString `db:"matrix_room_id"`
+		IncomeReportCurrency sql.NullString `db:"income_report_currency"`
+		IncomeReportFormat   sql.NullString `db:"income_report_format"`
 	}{}
 	wg.Go(func() error {
 		err := d.alloyReader.SelectContext(ctx, &valDashboards, `
@@ -1580,7 +1849,13 @@ func (d *DataAccessService) GetNotificationSettingsDashboards(ctx context.Contex
 				g.name AS group_name,
 				d.network,
 				g.webhook_target,
-				g.webhook_format
+				g.webhook_format,
+				g.webhook_signing_secret,
+				g.telegram_enabled,
+				g.slack_webhook_url,
+				g.matrix_room_id,
+				g.income_report_currency,
+				g.income_report_format
 			FROM users_val_dashboards d
 			INNER JOIN users_val_dashboards_groups g ON d.id = g.dashboard_id
 			WHERE d.user_id = $1`, userId)
@@ -1601,29 +1876,28 @@ func (d *DataAccessService) GetNotificationSettingsDashboards(ctx context.Contex
 		WebhookUrl                      sql.NullString `db:"webhook_target"`
 		WebhookFormat                   sql.NullString `db:"webhook_format"`
 		IsIgnoreSpamTransactionsEnabled bool           `db:"ignore_spam_transactions"`
-		SubscribedChainIds              []uint64       `db:"subscribed_chain_ids"`
+		SubscribedChainIds              pq.Int64Array  `db:"subscribed_chain_ids"`
 	}{}
-	// TODO: Account dashboard handling will be handled later
-	// wg.Go(func() error {
-	// 	err := d.alloyReader.SelectContext(ctx, &accDashboards, `
-	// 		SELECT
-	// 			d.id AS dashboard_id,
-	// 			d.name AS dashboard_name,
-	// 			g.id AS group_id,
-	// 			g.name AS group_name,
-	// 			g.webhook_target,
-	// 			g.webhook_format,
-	// 			g.ignore_spam_transactions,
-	// 			g.subscribed_chain_ids
-	// 		FROM users_acc_dashboards d
-	// 		INNER JOIN users_acc_dashboards_groups g ON d.id = g.dashboard_id
-	// 		WHERE d.user_id = $1`, userId)
-	// 	if err != nil {
-	// 		return fmt.Errorf(`error retrieving data for validator dashboard notifications: %w`, err)
-	// 	}
-
-	// 	return nil
-	// })
+	wg.Go(func() error {
+		err := d.alloyReader.SelectContext(ctx, &accDashboards, `
+			SELECT
+				d.id AS dashboard_id,
+				d.name AS dashboard_name,
+				g.id AS group_id,
+				g.name AS group_name,
+				g.webhook_target,
+				g.webhook_format,
+				g.ignore_spam_transactions,
+				g.subscribed_chain_ids
+			FROM users_acc_dashboards d
+			INNER JOIN users_acc_dashboards_groups g ON d.id = g.dashboard_id
+			WHERE d.user_id = $1`, userId)
+		if err != nil {
+			return fmt.Errorf(`error retrieving data for account dashboard notifications: %w`, err)
+		}
+
+		return nil
+	})
 
 	err = wg.Wait()
 	if err != nil {
@@ -1655,9 +1929,10 @@ func (d *DataAccessService) GetNotificationSettingsDashboards(ctx context.Contex
 			if dashboardType == ValidatorDashboardEventPrefix {
 				resultMap[event.Filter] = &t.NotificationSettingsDashboardsTableRow{
 					Settings: t.NotificationSettingsValidatorDashboard{
-						GroupEfficiencyBelowThreshold: GroupEfficiencyBelowThresholdDefault,
-						MaxCollateralThreshold:        MaxCollateralThresholdDefault,
-						MinCollateralThreshold:        MinCollateralThresholdDefault,
+						GroupEfficiencyBelowThreshold:     GroupEfficiencyBelowThresholdDefault,
+						MaxCollateralThreshold:            MaxCollateralThresholdDefault,
+						MinCollateralThreshold:            MinCollateralThresholdDefault,
+						PoolOperationNotIncludedThreshold: PoolOperationNotIncludedThresholdDefault,
 					},
 				}
 			} else if dashboardType == AccountDashboardEventPrefix {
@@ -1689,12 +1964,17 @@ func (d *DataAccessService) GetNotificationSettingsDashboards(ctx context.Contex
 				settings.IsWithdrawalProcessedSubscribed = true
 			case types.ValidatorGotSlashedEventName:
 				settings.IsSlashedSubscribed = true
+			case types.ValidatorPoolOperationNotIncludedEventName:
+				settings.IsPoolOperationNotIncludedSubscribed = true
+				settings.PoolOperationNotIncludedThreshold = uint64(event.Threshold)
 			case types.RocketpoolCollateralMinReachedEventName:
 				settings.IsMinCollateralSubscribed = true
 				settings.MinCollateralThreshold = event.Threshold
 			case types.RocketpoolCollateralMaxReachedEventName:
 				settings.IsMaxCollateralSubscribed = true
 				settings.MaxCollateralThreshold = event.Threshold
+			case types.TaxReportEventName:
+				settings.IsIncomeReportSubscribed = true
 			}
 			resultMap[event.Filter].Settings = settings
 		case t.NotificationSettingsAccountDashboard:
@@ -1722,9 +2002,10 @@ func (d *DataAccessService) GetNotificationSettingsDashboards(ctx context.Contex
 		if _, ok := resultMap[key]; !ok {
 			resultMap[key] = &t.NotificationSettingsDashboardsTableRow{
 				Settings: t.NotificationSettingsValidatorDashboard{
-					GroupEfficiencyBelowThreshold: GroupEfficiencyBelowThresholdDefault,
-					MaxCollateralThreshold:        MaxCollateralThresholdDefault,
-					MinCollateralThreshold:        MinCollateralThresholdDefault,
+					GroupEfficiencyBelowThreshold:     GroupEfficiencyBelowThresholdDefault,
+					MaxCollateralThreshold:            MaxCollateralThresholdDefault,
+					MinCollateralThreshold:            MinCollateralThresholdDefault,
+					PoolOperationNotIncludedThreshold: PoolOperationNotIncludedThresholdDefault,
 				},
 			}
 		}
@@ -1742,6 +2023,20 @@ func (d *DataAccessService) GetNotificationSettingsDashboards(ctx context.Contex
 			valSettings.WebhookUrl = valDashboard.WebhookUrl.String
 			valSettings.IsWebhookDiscordEnabled = valDashboard.WebhookFormat.Valid &&
 				types.NotificationChannel(valDashboard.WebhookFormat.String) == types.WebhookDiscordNotificationChannel
+			if valDashboard.WebhookUrl.Valid {
+				valSettings.WebhookSigningSecret = valDashboard.WebhookSigningSecret
+			}
+			valSettings.IsTelegramEnabled = valDashboard.TelegramEnabled
+			valSettings.SlackWebhookUrl = valDashboard.SlackWebhookUrl.String
+			valSettings.MatrixRoomId = valDashboard.MatrixRoomId.String
+			valSettings.IncomeReportCurrency = IncomeReportCurrencyDefault
+			if valDashboard.IncomeReportCurrency.Valid {
+				valSettings.IncomeReportCurrency = valDashboard.IncomeReportCurrency.String
+			}
+			valSettings.IncomeReportFormat = IncomeReportFormatDefault
+			if valDashboard.IncomeReportFormat.Valid {
+				valSettings.IncomeReportFormat = valDashboard.IncomeReportFormat.String
+			}
 
 			resultMap[key].Settings = valSettings
 		}
@@ -1750,6 +2045,10 @@ func (d *DataAccessService) GetNotificationSettingsDashboards(ctx context.Contex
 	// Account dashboards
 	for _, accDashboard := range accDashboards {
 		key := fmt.Sprintf("%s:%d:%d", AccountDashboardEventPrefix, accDashboard.DashboardId, accDashboard.GroupId)
+		subscribedChainIds := make([]uint64, 0, len(accDashboard.SubscribedChainIds))
+		for _, chainId := range accDashboard.SubscribedChainIds {
+			subscribedChainIds = append(subscribedChainIds, uint64(chainId))
+		}
 
 		if _, ok := resultMap[key]; !ok {
 			resultMap[key] = &t.NotificationSettingsDashboardsTableRow{
@@ -1765,7 +2064,7 @@ func (d *DataAccessService) GetNotificationSettingsDashboards(ctx context.Contex
 		resultMap[key].DashboardName = accDashboard.DashboardName
 		resultMap[key].GroupId = accDashboard.GroupId
 		resultMap[key].GroupName = accDashboard.GroupName
-		resultMap[key].ChainIds = accDashboard.SubscribedChainIds
+		resultMap[key].ChainIds = subscribedChainIds
 
 		// Set the settings
 		if accSettings, ok := resultMap[key].Settings.(t.NotificationSettingsAccountDashboard); ok {
@@ -1773,7 +2072,7 @@ func (d *DataAccessService) GetNotificationSettingsDashboards(ctx context.Contex
 			accSettings.IsWebhookDiscordEnabled = accDashboard.WebhookFormat.Valid &&
 				types.NotificationChannel(accDashboard.WebhookFormat.String) == types.WebhookDiscordNotificationChannel
 			accSettings.IsIgnoreSpamTransactionsEnabled = accDashboard.IsIgnoreSpamTransactionsEnabled
-			accSettings.SubscribedChainIds = accDashboard.SubscribedChainIds
+			accSettings.SubscribedChainIds = subscribedChainIds
 
 			resultMap[key].Settings = accSettings
 		}
@@ -1917,11 +2216,13 @@ func (d *DataAccessService) UpdateNotificationSettingsValidatorDashboard(ctx con
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsSyncSubscribed, userId, types.SyncCommitteeSoonEventName, networkName, eventFilter, epoch, 0)
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsWithdrawalProcessedSubscribed, userId, types.ValidatorReceivedWithdrawalEventName, networkName, eventFilter, epoch, 0)
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsSlashedSubscribed, userId, types.ValidatorGotSlashedEventName, networkName, eventFilter, epoch, 0)
+	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsPoolOperationNotIncludedSubscribed, userId, types.ValidatorPoolOperationNotIncludedEventName, networkName, eventFilter, epoch, float64(settings.PoolOperationNotIncludedThreshold))
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsMaxCollateralSubscribed, userId, types.RocketpoolCollateralMaxReachedEventName, networkName, eventFilter, epoch, settings.MaxCollateralThreshold)
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsMinCollateralSubscribed, userId, types.RocketpoolCollateralMinReachedEventName, networkName, eventFilter, epoch, settings.MinCollateralThreshold)
 	// Set two events for IsBlockProposalSubscribed
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsBlockProposalSubscribed, userId, types.ValidatorMissedProposalEventName, networkName, eventFilter, epoch, 0)
 	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsBlockProposalSubscribed, userId, types.ValidatorExecutedProposalEventName, networkName, eventFilter, epoch, 0)
+	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsIncomeReportSubscribed, userId, types.TaxReportEventName, networkName, eventFilter, epoch, 0)
 
 	// Insert all the events or update the threshold if they already exist
 	if len(eventsToInsert) > 0 {
@@ -1981,103 +2282,114 @@ func (d *DataAccessService) UpdateNotificationSettingsValidatorDashboard(ctx con
 		UPDATE users_val_dashboards_groups 
 		SET 
 			webhook_target = NULLIF($1, ''),
-			webhook_format = $2
-		WHERE dashboard_id = $3 AND id = $4`, settings.WebhookUrl, webhookFormat, dashboardId, groupId)
+			webhook_format = $2,
+			telegram_enabled = $3,
+			slack_webhook_url = NULLIF($4, ''),
+			matrix_room_id = NULLIF($5, ''),
+			income_report_currency = NULLIF($6, ''),
+			income_report_format = NULLIF($7, '')
+		WHERE dashboard_id = $8 AND id = $9`, settings.WebhookUrl, webhookFormat, settings.IsTelegramEnabled, settings.SlackWebhookUrl, settings.MatrixRoomId,
+		settings.IncomeReportCurrency, settings.IncomeReportFormat, dashboardId, groupId)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
-func (d *DataAccessService) UpdateNotificationSettingsAccountDashboard(ctx context.Context, userId uint64, dashboardId t.VDBIdPrimary, groupId uint64, settings t.NotificationSettingsAccountDashboard) error {
-	// TODO: Account dashboard handling will be handled later
-	// // For the given dashboardId and groupId update users_subscriptions and users_acc_dashboards_groups with the given settings
-	// epoch := utils.TimeToEpoch(time.Now())
-
-	// var eventsToInsert []goqu.Record
-	// var eventsToDelete []goqu.Expression
-
-	// tx, err := d.userWriter.BeginTxx(ctx, nil)
-	// if err != nil {
-	// 	return fmt.Errorf("error starting db transactions to update validator dashboard notification settings: %w", err)
-	// }
-	// defer utils.Rollback(tx)
-
-	// eventFilter := fmt.Sprintf("%s:%d:%d", AccountDashboardEventPrefix, dashboardId, groupId)
-
-	// d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsIncomingTransactionsSubscribed, userId, types.IncomingTransactionEventName, "", eventFilter, epoch, 0)
-	// d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsOutgoingTransactionsSubscribed, userId, types.OutgoingTransactionEventName, "", eventFilter, epoch, 0)
-	// d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsERC20TokenTransfersSubscribed, userId, types.ERC20TokenTransferEventName, "", eventFilter, epoch, settings.ERC20TokenTransfersValueThreshold)
-	// d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsERC721TokenTransfersSubscribed, userId, types.ERC721TokenTransferEventName, "", eventFilter, epoch, 0)
-	// d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsERC1155TokenTransfersSubscribed, userId, types.ERC1155TokenTransferEventName, "", eventFilter, epoch, 0)
-
-	// // Insert all the events or update the threshold if they already exist
-	// if len(eventsToInsert) > 0 {
-	// 	insertDs := goqu.Dialect("postgres").
-	// 		Insert("users_subscriptions").
-	// 		Cols("user_id", "event_name", "event_filter", "created_ts", "created_epoch", "event_threshold").
-	// 		Rows(eventsToInsert).
-	// 		OnConflict(goqu.DoUpdate(
-	// 			"user_id, event_name, event_filter",
-	// 			goqu.Record{"event_threshold": goqu.L("EXCLUDED.event_threshold")},
-	// 		))
-
-	// 	query, args, err := insertDs.Prepared(true).ToSQL()
-	// 	if err != nil {
-	// 		return fmt.Errorf("error preparing query: %w", err)
-	// 	}
-
-	// 	_, err = tx.ExecContext(ctx, query, args...)
-	// 	if err != nil {
-	// 		return err
-	// 	}
-	// }
-
-	// // Delete all the events
-	// if len(eventsToDelete) > 0 {
-	// 	deleteDs := goqu.Dialect("postgres").
-	// 		Delete("users_subscriptions").
-	// 		Where(goqu.Or(eventsToDelete...))
-
-	// 	query, args, err := deleteDs.Prepared(true).ToSQL()
-	// 	if err != nil {
-	// 		return fmt.Errorf("error preparing query: %w", err)
-	// 	}
-
-	// 	_, err = tx.ExecContext(ctx, query, args...)
-	// 	if err != nil {
-	// 		return err
-	// 	}
-	// }
-
-	// err = tx.Commit()
-	// if err != nil {
-	// 	return fmt.Errorf("error committing tx to update validator dashboard notification settings: %w", err)
-	// }
-
-	// // Set non-event settings
-	// var webhookFormat sql.NullString
-	// if settings.WebhookUrl != "" {
-	// 	webhookFormat.String = string(types.WebhookNotificationChannel)
-	// 	webhookFormat.Valid = true
-	// 	if settings.IsWebhookDiscordEnabled {
-	// 		webhookFormat.String = string(types.WebhookDiscordNotificationChannel)
-	// 	}
-	// }
-
-	// _, err = d.alloyWriter.ExecContext(ctx, `
-	// 	UPDATE users_acc_dashboards_groups
-	// 	SET
-	// 		webhook_target = NULLIF($1, ''),
-	// 		webhook_format = $2,
-	// 		ignore_spam_transactions = $3,
-	// 		subscribed_chain_ids = $4
-	// 	WHERE dashboard_id = $5 AND id = $6`, settings.WebhookUrl, webhookFormat, settings.IsIgnoreSpamTransactionsEnabled, settings.SubscribedChainIds, dashboardId, groupId)
-	// if err != nil {
-	// 	return err
-	// }
-
-	return d.dummy.UpdateNotificationSettingsAccountDashboard(ctx, userId, dashboardId, groupId, settings)
+func (d *DataAccessService) UpdateNotificationSettingsAccountDashboard(ctx context.Context, userId uint64, dashboardId uint64, groupId uint64, settings t.NotificationSettingsAccountDashboard) error {
+	// For the given dashboardId and groupId update users_subscriptions and users_acc_dashboards_groups with the given settings
+	epoch := utils.TimeToEpoch(time.Now())
+
+	var eventsToInsert []goqu.Record
+	var eventsToDelete []goqu.Expression
+
+	tx, err := d.userWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting db transactions to update account dashboard notification settings: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	eventFilter := fmt.Sprintf("%s:%d:%d", AccountDashboardEventPrefix, dashboardId, groupId)
+
+	// account dashboard events are not bound to a network, the subscribed chain ids are stored with the group instead
+	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsIncomingTransactionsSubscribed, userId, types.IncomingTransactionEventName, "", eventFilter, epoch, 0)
+	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsOutgoingTransactionsSubscribed, userId, types.OutgoingTransactionEventName, "", eventFilter, epoch, 0)
+	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsERC20TokenTransfersSubscribed, userId, types.ERC20TokenTransferEventName, "", eventFilter, epoch, settings.ERC20TokenTransfersValueThreshold)
+	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsERC721TokenTransfersSubscribed, userId, types.ERC721TokenTransferEventName, "", eventFilter, epoch, 0)
+	d.AddOrRemoveEvent(&eventsToInsert, &eventsToDelete, settings.IsERC1155TokenTransfersSubscribed, userId, types.ERC1155TokenTransferEventName, "", eventFilter, epoch, 0)
+
+	// Insert all the events or update the threshold if they already exist
+	if len(eventsToInsert) > 0 {
+		insertDs := goqu.Dialect("postgres").
+			Insert("users_subscriptions").
+			Cols("user_id", "event_name", "event_filter", "created_ts", "created_epoch", "event_threshold").
+			Rows(eventsToInsert).
+			OnConflict(goqu.DoUpdate(
+				"user_id, event_name, event_filter",
+				goqu.Record{"event_threshold": goqu.L("EXCLUDED.event_threshold")},
+			))
+
+		query, args, err := insertDs.Prepared(true).ToSQL()
+		if err != nil {
+			return fmt.Errorf("error preparing query: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Delete all the events
+	if len(eventsToDelete) > 0 {
+		deleteDs := goqu.Dialect("postgres").
+			Delete("users_subscriptions").
+			Where(goqu.Or(eventsToDelete...))
+
+		query, args, err := deleteDs.Prepared(true).ToSQL()
+		if err != nil {
+			return fmt.Errorf("error preparing query: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing tx to update account dashboard notification settings: %w", err)
+	}
+
+	// Set non-event settings
+	var webhookFormat sql.NullString
+	if settings.WebhookUrl != "" {
+		webhookFormat.String = string(types.WebhookNotificationChannel)
+		webhookFormat.Valid = true
+		if settings.IsWebhookDiscordEnabled {
+			webhookFormat.String = string(types.WebhookDiscordNotificationChannel)
+		}
+	}
+
+	subscribedChainIds := make(pq.Int64Array, 0, len(settings.SubscribedChainIds))
+	for _, chainId := range settings.SubscribedChainIds {
+		subscribedChainIds = append(subscribedChainIds, int64(chainId))
+	}
+
+	_, err = d.alloyWriter.ExecContext(ctx, `
+		UPDATE users_acc_dashboards_groups
+		SET
+			webhook_target = NULLIF($1, ''),
+			webhook_format = $2,
+			ignore_spam_transactions = $3,
+			subscribed_chain_ids = $4
+		WHERE dashboard_id = $5 AND id = $6`, settings.WebhookUrl, webhookFormat, settings.IsIgnoreSpamTransactionsEnabled, subscribedChainIds, dashboardId, groupId)
+	if err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func (d *DataAccessService) AddOrRemoveEvent(eventsToInsert *[]goqu.Record, eventsToDelete *[]goqu.Expression, isSubscribed bool, userId uint64, eventName types.EventName, network, eventFilter string, epoch int64, threshold float64) {
@@ -2103,3 +2415,197 @@ func (d *DataAccessService) QueueTestPushNotification(ctx context.Context, userI
 func (d *DataAccessService) QueueTestWebhookNotification(ctx context.Context, userId uint64, webhookUrl string, isDiscordWebhook bool) error {
 	return notification.SendTestWebhookNotification(ctx, types.UserId(userId), webhookUrl, isDiscordWebhook)
 }
+func (d *DataAccessService) QueueTestTelegramNotification(ctx context.Context, userId uint64) error {
+	err := notification.SendTestTelegramNotification(ctx, types.UserId(userId), d.userReader)
+	if errors.Is(err, notification.ErrTelegramNotLinked) {
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	return err
+}
+func (d *DataAccessService) QueueTestSlackNotification(ctx context.Context, userId uint64, webhookUrl string) error {
+	return notification.SendTestSlackNotification(ctx, types.UserId(userId), webhookUrl)
+}
+func (d *DataAccessService) QueueTestMatrixNotification(ctx context.Context, userId uint64, roomId string) error {
+	return notification.SendTestMatrixNotification(ctx, types.UserId(userId), roomId)
+}
+
+func (d *DataAccessService) CreateTelegramLinkToken(ctx context.Context, userId uint64, token string, expires time.Time) error {
+	_, err := d.userWriter.ExecContext(ctx, `
+		INSERT INTO users_telegram_chats (user_id, link_token, link_token_expires_ts)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET link_token = EXCLUDED.link_token, link_token_expires_ts = EXCLUDED.link_token_expires_ts`, userId, token, expires.UTC())
+	if err != nil {
+		return fmt.Errorf("error creating telegram link token: %w", err)
+	}
+	return nil
+}
+
+func (d *DataAccessService) GetTelegramChatLinked(ctx context.Context, userId uint64) (bool, error) {
+	var linked bool
+	err := d.userReader.GetContext(ctx, &linked, `SELECT EXISTS(SELECT 1 FROM users_telegram_chats WHERE user_id = $1 AND chat_id IS NOT NULL)`, userId)
+	if err != nil {
+		return false, fmt.Errorf("error retrieving linked telegram chat: %w", err)
+	}
+	return linked, nil
+}
+
+// DeleteTelegramChat unlinks the telegram chat of a user and disables telegram notifications for all of their dashboard groups
+func (d *DataAccessService) DeleteTelegramChat(ctx context.Context, userId uint64) error {
+	_, err := d.userWriter.ExecContext(ctx, `DELETE FROM users_telegram_chats WHERE user_id = $1`, userId)
+	if err != nil {
+		return fmt.Errorf("error deleting telegram chat: %w", err)
+	}
+	_, err = d.alloyWriter.ExecContext(ctx, `
+		UPDATE users_val_dashboards_groups SET telegram_enabled = false
+		WHERE telegram_enabled AND dashboard_id IN (SELECT id FROM users_val_dashboards WHERE user_id = $1)`, userId)
+	if err != nil {
+		return fmt.Errorf("error disabling telegram notifications: %w", err)
+	}
+	return nil
+}
+
+func (d *DataAccessService) RotateNotificationSettingsValidatorDashboardWebhookSecret(ctx context.Context, dashboardId t.VDBIdPrimary, groupId uint64) (string, error) {
+	secret, err := notification.GenerateWebhookSigningSecret()
+	if err != nil {
+		return "", fmt.Errorf("error generating webhook signing secret: %w", err)
+	}
+
+	result, err := d.alloyWriter.ExecContext(ctx, `
+		UPDATE users_val_dashboards_groups
+		SET webhook_signing_secret = $1
+		WHERE dashboard_id = $2 AND id = $3`, secret, dashboardId, groupId)
+	if err != nil {
+		return "", err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return "", err
+	}
+	if rowsAffected == 0 {
+		return "", fmt.Errorf("%w: group %d of dashboard %d", ErrNotFound, groupId, dashboardId)
+	}
+	return secret, nil
+}
+
+func (d *DataAccessService) GetWebhookDeadLetters(ctx context.Context, userId uint64, cursor string, limit uint64) ([]t.NotificationWebhookDeadLetter, *t.Paging, error) {
+	result := make([]t.NotificationWebhookDeadLetter, 0)
+	var paging t.Paging
+
+	// Initialize the cursor
+	var currentCursor t.NotificationWebhookDeadLettersCursor
+	var err error
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.NotificationWebhookDeadLettersCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as NotificationWebhookDeadLettersCursor: %w", err)
+		}
+	}
+
+	deadLetters := []struct {
+		Id            uint64         `db:"id"`
+		Channel       string         `db:"channel"`
+		WebhookUrl    string         `db:"webhook_url"`
+		DashboardId   uint64         `db:"dashboard_id"`
+		GroupId       uint64         `db:"group_id"`
+		Created       time.Time      `db:"created"`
+		FailedTs      time.Time      `db:"failed_ts"`
+		RedeliveredTs sql.NullTime   `db:"redelivered_ts"`
+		Retries       uint64         `db:"retries"`
+		LastStatus    sql.NullString `db:"last_status"`
+		LastError     sql.NullString `db:"last_error"`
+		Payload       string         `db:"payload"`
+	}{}
+
+	// newest entries first, the id can be used to uniquely identify a row
+	ds := goqu.Dialect("postgres").
+		Select(
+			goqu.C("id"),
+			goqu.C("channel"),
+			goqu.L("COALESCE(content->'Webhook'->>'url', CASE WHEN channel = 'slack' THEN content->>'target' END, '')").As("webhook_url"),
+			goqu.L("COALESCE((content->'Webhook'->>'dashboardId')::BIGINT, (content->>'dashboardId')::BIGINT, 0)").As("dashboard_id"),
+			goqu.L("COALESCE((content->'Webhook'->>'dashboardGroupId')::BIGINT, (content->>'dashboardGroupId')::BIGINT, 0)").As("group_id"),
+			goqu.C("created"),
+			goqu.C("failed_ts"),
+			goqu.C("redelivered_ts"),
+			goqu.C("retries"),
+			goqu.C("last_status"),
+			goqu.C("last_error"),
+			goqu.L("CASE WHEN channel = 'webhook_discord' THEN content->'discordRequest' ELSE content END::TEXT").As("payload")).
+		From("notification_dead_letters").
+		Where(goqu.C("user_id").Eq(userId)).
+		Limit(uint(limit + 1))
+
+	if currentCursor.IsValid() {
+		if currentCursor.IsReverse() {
+			ds = ds.Where(goqu.C("id").Gt(currentCursor.Id)).Order(goqu.C("id").Asc())
+		} else {
+			ds = ds.Where(goqu.C("id").Lt(currentCursor.Id)).Order(goqu.C("id").Desc())
+		}
+	} else {
+		ds = ds.Order(goqu.C("id").Desc())
+	}
+
+	query, args, err := ds.Prepared(true).ToSQL()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing webhook dead letters query: %w", err)
+	}
+
+	err = d.readerDb.SelectContext(ctx, &deadLetters, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving webhook dead letters: %w", err)
+	}
+
+	for _, deadLetter := range deadLetters {
+		resultEntry := t.NotificationWebhookDeadLetter{
+			Id:               deadLetter.Id,
+			Channel:          deadLetter.Channel,
+			WebhookUrl:       deadLetter.WebhookUrl,
+			DashboardId:      deadLetter.DashboardId,
+			GroupId:          deadLetter.GroupId,
+			CreatedTimestamp: deadLetter.Created.Unix(),
+			FailedTimestamp:  deadLetter.FailedTs.Unix(),
+			Attempts:         deadLetter.Retries,
+			LastStatus:       deadLetter.LastStatus.String,
+			LastError:        deadLetter.LastError.String,
+			Payload:          deadLetter.Payload,
+		}
+		if deadLetter.RedeliveredTs.Valid {
+			resultEntry.RedeliveredTimestamp = deadLetter.RedeliveredTs.Time.Unix()
+		}
+		result = append(result, resultEntry)
+	}
+
+	// -------------------------------------
+	// Paging
+
+	// Flag if above limit
+	moreDataFlag := len(result) > int(limit)
+	if !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return result, &paging, nil
+	}
+
+	// Remove the last entries from data
+	if moreDataFlag {
+		result = result[:limit]
+	}
+
+	if currentCursor.IsReverse() {
+		slices.Reverse(result)
+	}
+
+	p, err := utils.GetPagingFromData(result, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+
+	return result, p, nil
+}
+
+func (d *DataAccessService) RedeliverWebhookDeadLetter(ctx context.Context, userId uint64, deadLetterId uint64) error {
+	_, err := notification.RedeliverDeadLetter(ctx, d.writerDb, types.UserId(userId), deadLetterId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: dead letter %d does not exist or has already been redelivered", ErrNotFound, deadLetterId)
+	}
+	return err
+}
@@ -0,0 +1,366 @@
+package dataaccess
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+type OAuthRepository interface {
+	GetOAuthClient(ctx context.Context, clientId string) (*t.OAuthClient, error)
+	GetOAuthClientByRedirectUri(ctx context.Context, redirectUri string) (*t.OAuthClient, error)
+	CreateOAuthAuthorizationCode(ctx context.Context, code t.OAuthAuthorizationCode) (string, error)
+	ConsumeOAuthAuthorizationCode(ctx context.Context, appId uint64, code string) (*t.OAuthAuthorizationCode, error)
+	CreateOAuthTokens(ctx context.Context, appId uint64, userId uint64, scopes []enums.ApiKeyScope) (*t.OAuthTokenGrant, error)
+	RefreshOAuthTokens(ctx context.Context, appId uint64, refreshToken string) (*t.OAuthTokenGrant, error)
+	RevokeOAuthToken(ctx context.Context, appId uint64, token string) error
+	GetOAuthAccessTokenAccess(ctx context.Context, accessToken string) (*t.ApiKeyAccess, error)
+
+	GetUserOAuthApps(ctx context.Context, userId uint64) ([]t.OAuthApp, error)
+	GetUserOAuthAppCount(ctx context.Context, userId uint64) (uint64, error)
+	CreateUserOAuthApp(ctx context.Context, userId uint64, name string, redirectUri string, scopes []enums.ApiKeyScope, confidential bool) (*t.OAuthApp, error)
+	RemoveUserOAuthApp(ctx context.Context, userId uint64, appId uint64) error
+}
+
+const (
+	oauthAuthorizationCodeLifetime = 10 * time.Minute
+	oauthAccessTokenLifetime       = time.Hour
+	oauthRefreshTokenLifetime      = 30 * 24 * time.Hour
+)
+
+// oauthAppRow is a row of oauth_apps, NULL scopes mean that the app may request all scopes
+type oauthAppRow struct {
+	Id               uint64         `db:"id"`
+	OwnerId          uint64         `db:"owner_id"`
+	ClientId         string         `db:"client_id"`
+	Name             string         `db:"app_name"`
+	RedirectUri      string         `db:"redirect_uri"`
+	ClientSecretHash sql.NullString `db:"client_secret_hash"`
+	Scopes           pq.StringArray `db:"scopes"`
+	FirstParty       bool           `db:"first_party"`
+	CreatedAt        time.Time      `db:"created_ts"`
+}
+
+const oauthAppColumns = `id, owner_id, client_id, app_name, redirect_uri, client_secret_hash, scopes, first_party, created_ts`
+
+func (row oauthAppRow) toOAuthClient() *t.OAuthClient {
+	return &t.OAuthClient{
+		AppId:            row.Id,
+		OwnerId:          row.OwnerId,
+		ClientId:         row.ClientId,
+		Name:             row.Name,
+		RedirectUri:      row.RedirectUri,
+		ClientSecretHash: row.ClientSecretHash.String,
+		Scopes:           parseOAuthScopes(row.Scopes),
+		FirstParty:       row.FirstParty,
+	}
+}
+
+func (row oauthAppRow) toOAuthApp() t.OAuthApp {
+	scopes := parseOAuthScopes(row.Scopes)
+	if scopes == nil {
+		scopes = enums.AllApiKeyScopes
+	}
+	return t.OAuthApp{
+		Id:           row.Id,
+		Name:         row.Name,
+		ClientId:     row.ClientId,
+		RedirectUri:  row.RedirectUri,
+		Scopes:       apiKeyScopesArray(scopes),
+		Confidential: row.ClientSecretHash.Valid,
+		CreatedAt:    row.CreatedAt.Unix(),
+	}
+}
+
+func parseOAuthScopes(scopes pq.StringArray) []enums.ApiKeyScope {
+	return apiKeyRow{Scopes: scopes}.scopes()
+}
+
+func (d *DataAccessService) getOAuthClient(ctx context.Context, column string, value string) (*t.OAuthClient, error) {
+	var row oauthAppRow
+	err := d.userReader.GetContext(ctx, &row, `SELECT `+oauthAppColumns+` FROM oauth_apps WHERE active = true AND `+column+` = $1`, value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: oauth client not found", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return row.toOAuthClient(), nil
+}
+
+func (d *DataAccessService) GetOAuthClient(ctx context.Context, clientId string) (*t.OAuthClient, error) {
+	return d.getOAuthClient(ctx, "client_id", clientId)
+}
+
+func (d *DataAccessService) GetOAuthClientByRedirectUri(ctx context.Context, redirectUri string) (*t.OAuthClient, error) {
+	return d.getOAuthClient(ctx, "redirect_uri", redirectUri)
+}
+
+// CreateOAuthAuthorizationCode stores the approved grant and returns the code, only its hash is stored
+func (d *DataAccessService) CreateOAuthAuthorizationCode(ctx context.Context, code t.OAuthAuthorizationCode) (string, error) {
+	rawCode, err := utils.GenerateRandomAPIKey()
+	if err != nil {
+		return "", err
+	}
+	_, err = d.userWriter.ExecContext(ctx, `
+		INSERT INTO oauth_authorization_codes (code_hash, app_id, user_id, redirect_uri, scopes, code_challenge, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW() + $7 * INTERVAL '1 second')`,
+		utils.HashAndEncode(rawCode), code.AppId, code.UserId, code.RedirectUri, apiKeyScopesArray(code.Scopes), code.CodeChallenge, oauthAuthorizationCodeLifetime.Seconds())
+	if err != nil {
+		return "", err
+	}
+	return rawCode, nil
+}
+
+// ConsumeOAuthAuthorizationCode marks the code as used, so it can only be exchanged once even if the exchange fails later on
+func (d *DataAccessService) ConsumeOAuthAuthorizationCode(ctx context.Context, appId uint64, code string) (*t.OAuthAuthorizationCode, error) {
+	var row struct {
+		AppId         uint64         `db:"app_id"`
+		UserId        uint64         `db:"user_id"`
+		RedirectUri   string         `db:"redirect_uri"`
+		Scopes        pq.StringArray `db:"scopes"`
+		CodeChallenge string         `db:"code_challenge"`
+	}
+	err := d.userWriter.GetContext(ctx, &row, `
+		UPDATE oauth_authorization_codes
+		SET consumed_at = NOW()
+		WHERE code_hash = $1 AND app_id = $2 AND consumed_at IS NULL AND expires_at > NOW()
+		RETURNING app_id, user_id, redirect_uri, scopes, code_challenge`,
+		utils.HashAndEncode(code), appId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: authorization code not found", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	scopes := parseOAuthScopes(row.Scopes)
+	if scopes == nil {
+		scopes = []enums.ApiKeyScope{}
+	}
+	return &t.OAuthAuthorizationCode{
+		AppId:         row.AppId,
+		UserId:        row.UserId,
+		RedirectUri:   row.RedirectUri,
+		Scopes:        scopes,
+		CodeChallenge: row.CodeChallenge,
+	}, nil
+}
+
+// insertOAuthTokens creates a new access and refresh token pair, only their hashes are stored
+func insertOAuthTokens(ctx context.Context, db sqlx.ExecerContext, appId uint64, userId uint64, scopes pq.StringArray) (*t.OAuthTokenGrant, error) {
+	accessToken, err := utils.GenerateRandomAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := utils.GenerateRandomAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO oauth_tokens (app_id, user_id, access_token_hash, refresh_token_hash, scopes, access_expires_at, refresh_expires_at)
+		VALUES ($1, $2, $3, $4, $5, NOW() + $6 * INTERVAL '1 second', NOW() + $7 * INTERVAL '1 second')`,
+		appId, userId, utils.HashAndEncode(accessToken), utils.HashAndEncode(refreshToken), scopes, oauthAccessTokenLifetime.Seconds(), oauthRefreshTokenLifetime.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	grantedScopes := parseOAuthScopes(scopes)
+	if grantedScopes == nil {
+		grantedScopes = []enums.ApiKeyScope{}
+	}
+	return &t.OAuthTokenGrant{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    uint64(oauthAccessTokenLifetime.Seconds()),
+		Scopes:       grantedScopes,
+	}, nil
+}
+
+func (d *DataAccessService) CreateOAuthTokens(ctx context.Context, appId uint64, userId uint64, scopes []enums.ApiKeyScope) (*t.OAuthTokenGrant, error) {
+	return insertOAuthTokens(ctx, d.userWriter, appId, userId, apiKeyScopesArray(scopes))
+}
+
+// RefreshOAuthTokens rotates the refresh token. If an already rotated refresh token is used again it was most likely leaked,
+// so all tokens the app holds for the user are revoked.
+func (d *DataAccessService) RefreshOAuthTokens(ctx context.Context, appId uint64, refreshToken string) (*t.OAuthTokenGrant, error) {
+	tx, err := d.userWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting db transaction: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	var row struct {
+		Id             uint64         `db:"id"`
+		UserId         uint64         `db:"user_id"`
+		Scopes         pq.StringArray `db:"scopes"`
+		RefreshExpired bool           `db:"refresh_expired"`
+		RevokedAt      sql.NullTime   `db:"revoked_at"`
+	}
+	err = tx.GetContext(ctx, &row, `
+		SELECT id, user_id, scopes, refresh_expires_at <= NOW() AS refresh_expired, revoked_at
+		FROM oauth_tokens
+		WHERE refresh_token_hash = $1 AND app_id = $2
+		FOR UPDATE`,
+		utils.HashAndEncode(refreshToken), appId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: refresh token not found", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if row.RevokedAt.Valid {
+		_, err = tx.ExecContext(ctx, `UPDATE oauth_tokens SET revoked_at = NOW() WHERE user_id = $1 AND app_id = $2 AND revoked_at IS NULL`, row.UserId, appId)
+		if err != nil {
+			return nil, err
+		}
+		if err = tx.Commit(); err != nil {
+			return nil, fmt.Errorf("error committing tx: %w", err)
+		}
+		return nil, fmt.Errorf("%w: refresh token was already used", ErrNotFound)
+	}
+	if row.RefreshExpired {
+		return nil, fmt.Errorf("%w: refresh token expired", ErrNotFound)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE oauth_tokens SET revoked_at = NOW() WHERE id = $1`, row.Id)
+	if err != nil {
+		return nil, err
+	}
+	grant, err := insertOAuthTokens(ctx, tx, appId, row.UserId, row.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("error committing tx: %w", err)
+	}
+	return grant, nil
+}
+
+// RevokeOAuthToken revokes the grant the access or refresh token belongs to.
+// Unknown tokens are not an error, see RFC 7009 section 2.2.
+func (d *DataAccessService) RevokeOAuthToken(ctx context.Context, appId uint64, token string) error {
+	tokenHash := utils.HashAndEncode(token)
+	_, err := d.userWriter.ExecContext(ctx, `
+		UPDATE oauth_tokens
+		SET revoked_at = NOW()
+		WHERE app_id = $1 AND (access_token_hash = $2 OR refresh_token_hash = $2) AND revoked_at IS NULL`,
+		appId, tokenHash)
+	return err
+}
+
+func (d *DataAccessService) GetOAuthAccessTokenAccess(ctx context.Context, accessToken string) (*t.ApiKeyAccess, error) {
+	var row struct {
+		UserId uint64         `db:"user_id"`
+		Scopes pq.StringArray `db:"scopes"`
+	}
+	err := d.userReader.GetContext(ctx, &row, `
+		SELECT t.user_id, t.scopes
+		FROM oauth_tokens t
+		INNER JOIN oauth_apps a ON a.id = t.app_id
+		WHERE t.access_token_hash = $1 AND t.revoked_at IS NULL AND t.access_expires_at > NOW() AND a.active = true`,
+		utils.HashAndEncode(accessToken))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: access token not found", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// tokens are always restricted to the scopes the user consented to
+	scopes := parseOAuthScopes(row.Scopes)
+	if scopes == nil {
+		scopes = []enums.ApiKeyScope{}
+	}
+	return &t.ApiKeyAccess{
+		UserId: row.UserId,
+		Scopes: scopes,
+	}, nil
+}
+
+func (d *DataAccessService) GetUserOAuthApps(ctx context.Context, userId uint64) ([]t.OAuthApp, error) {
+	var rows []oauthAppRow
+	err := d.userReader.SelectContext(ctx, &rows, `SELECT `+oauthAppColumns+` FROM oauth_apps WHERE owner_id = $1 AND active = true ORDER BY created_ts, id`, userId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]t.OAuthApp, len(rows))
+	for i, row := range rows {
+		result[i] = row.toOAuthApp()
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) GetUserOAuthAppCount(ctx context.Context, userId uint64) (uint64, error) {
+	var count uint64
+	err := d.userReader.GetContext(ctx, &count, `SELECT COUNT(*) FROM oauth_apps WHERE owner_id = $1 AND active = true`, userId)
+	return count, err
+}
+
+// CreateUserOAuthApp registers a third party app, the client secret of confidential apps is only returned here
+func (d *DataAccessService) CreateUserOAuthApp(ctx context.Context, userId uint64, name string, redirectUri string, scopes []enums.ApiKeyScope, confidential bool) (*t.OAuthApp, error) {
+	clientId, err := utils.GenerateRandomAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	var clientSecret string
+	var clientSecretHash sql.NullString
+	if confidential {
+		clientSecret, err = utils.GenerateRandomAPIKey()
+		if err != nil {
+			return nil, err
+		}
+		clientSecretHash = sql.NullString{String: utils.HashAndEncode(clientSecret), Valid: true}
+	}
+	var row oauthAppRow
+	err = d.userWriter.GetContext(ctx, &row, `
+		INSERT INTO oauth_apps (owner_id, client_id, app_name, redirect_uri, client_secret_hash, scopes, first_party, created_ts)
+		VALUES ($1, $2, $3, $4, $5, $6, false, NOW())
+		RETURNING `+oauthAppColumns,
+		userId, clientId, name, redirectUri, clientSecretHash, apiKeyScopesArray(scopes))
+	if err != nil {
+		return nil, err
+	}
+	result := row.toOAuthApp()
+	result.ClientSecret = clientSecret
+	return &result, nil
+}
+
+// RemoveUserOAuthApp deactivates the app and revokes all tokens that were issued to it
+func (d *DataAccessService) RemoveUserOAuthApp(ctx context.Context, userId uint64, appId uint64) error {
+	tx, err := d.userWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting db transaction: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	result, err := tx.ExecContext(ctx, `UPDATE oauth_apps SET active = false WHERE owner_id = $1 AND id = $2 AND active = true AND first_party = false`, userId, appId)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: oauth app %v not found", ErrNotFound, appId)
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE oauth_tokens SET revoked_at = NOW() WHERE app_id = $1 AND revoked_at IS NULL`, appId)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing tx: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,405 @@
+package dataaccess
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/pkg/errors"
+)
+
+type OrganizationRepository interface {
+	GetUserOrganizations(ctx context.Context, userId uint64) ([]t.Organization, error)
+	GetUserOrganization(ctx context.Context, userId uint64, organizationId uint64) (*t.Organization, error)
+	CreateOrganization(ctx context.Context, userId uint64, name string) (*t.Organization, error)
+	UpdateOrganizationName(ctx context.Context, organizationId uint64, name string) error
+	RemoveOrganization(ctx context.Context, organizationId uint64) error
+
+	GetOrganizationMemberRole(ctx context.Context, organizationId uint64, userId uint64) (enums.OrganizationRole, error)
+	GetOrganizationMembers(ctx context.Context, organizationId uint64) ([]t.OrganizationMember, error)
+	UpdateOrganizationMemberRole(ctx context.Context, organizationId uint64, userId uint64, role enums.OrganizationRole) error
+	RemoveOrganizationMember(ctx context.Context, organizationId uint64, userId uint64) error
+
+	GetOrganizationInvitations(ctx context.Context, organizationId uint64) ([]t.OrganizationInvitation, error)
+	CreateOrganizationInvitation(ctx context.Context, organizationId uint64, invitedBy uint64, email string, role enums.OrganizationRole) (*t.OrganizationInvitation, string, error)
+	RemoveOrganizationInvitation(ctx context.Context, organizationId uint64, invitationId uint64) error
+	AcceptOrganizationInvitation(ctx context.Context, userId uint64, email string, token string) (uint64, error)
+
+	GetOrganizationPremiumPerks(ctx context.Context, organizationId uint64) (*t.PremiumPerks, error)
+	GetOrganizationValidatorDashboardCount(ctx context.Context, organizationId uint64, active bool) (uint64, error)
+	UpdateValidatorDashboardOwner(ctx context.Context, dashboardId t.VDBIdPrimary, userId uint64, organizationId *uint64) error
+}
+
+const organizationInvitationLifetime = 7 * 24 * time.Hour
+
+type organizationRow struct {
+	Id          uint64    `db:"id"`
+	Name        string    `db:"name"`
+	Role        string    `db:"role"`
+	MemberCount uint64    `db:"member_count"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func (row organizationRow) toOrganization() t.Organization {
+	return t.Organization{
+		Id:          row.Id,
+		Name:        row.Name,
+		Role:        row.Role,
+		MemberCount: row.MemberCount,
+		CreatedAt:   row.CreatedAt.Unix(),
+	}
+}
+
+const userOrganizationsQuery = `
+	SELECT
+		o.id,
+		o.name,
+		om.role,
+		(SELECT COUNT(*) FROM organization_members WHERE organization_id = o.id) AS member_count,
+		o.created_at
+	FROM organizations o
+	INNER JOIN organization_members om ON om.organization_id = o.id
+	WHERE om.user_id = $1`
+
+func (d *DataAccessService) GetUserOrganizations(ctx context.Context, userId uint64) ([]t.Organization, error) {
+	var rows []organizationRow
+	err := d.userReader.SelectContext(ctx, &rows, userOrganizationsQuery+` ORDER BY o.name, o.id`, userId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]t.Organization, len(rows))
+	for i, row := range rows {
+		result[i] = row.toOrganization()
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) GetUserOrganization(ctx context.Context, userId uint64, organizationId uint64) (*t.Organization, error) {
+	var row organizationRow
+	err := d.userReader.GetContext(ctx, &row, userOrganizationsQuery+` AND o.id = $2`, userId, organizationId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: organization with id %v not found", ErrNotFound, organizationId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := row.toOrganization()
+	return &result, nil
+}
+
+// CreateOrganization creates a new organization with the user as its owner
+func (d *DataAccessService) CreateOrganization(ctx context.Context, userId uint64, name string) (*t.Organization, error) {
+	tx, err := d.userWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting db transaction: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	row := organizationRow{
+		Name:        name,
+		Role:        enums.OrganizationRoles.Owner.String(),
+		MemberCount: 1,
+	}
+	err = tx.GetContext(ctx, &row, `INSERT INTO organizations (name) VALUES ($1) RETURNING id, created_at`, name)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO organization_members (organization_id, user_id, role) VALUES ($1, $2, $3)`, row.Id, userId, row.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("error committing tx: %w", err)
+	}
+	result := row.toOrganization()
+	return &result, nil
+}
+
+func (d *DataAccessService) UpdateOrganizationName(ctx context.Context, organizationId uint64, name string) error {
+	_, err := d.userWriter.ExecContext(ctx, `UPDATE organizations SET name = $2 WHERE id = $1`, organizationId, name)
+	return err
+}
+
+// RemoveOrganization deletes the organization including its members and invitations, it must not own any dashboards anymore
+func (d *DataAccessService) RemoveOrganization(ctx context.Context, organizationId uint64) error {
+	_, err := d.userWriter.ExecContext(ctx, `DELETE FROM organizations WHERE id = $1`, organizationId)
+	return err
+}
+
+// Members
+
+func (d *DataAccessService) GetOrganizationMemberRole(ctx context.Context, organizationId uint64, userId uint64) (enums.OrganizationRole, error) {
+	var role string
+	err := d.userReader.GetContext(ctx, &role, `SELECT role FROM organization_members WHERE organization_id = $1 AND user_id = $2`, organizationId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return enums.OrganizationRole(-1), fmt.Errorf("%w: user %v is not a member of organization %v", ErrNotFound, userId, organizationId)
+	}
+	if err != nil {
+		return enums.OrganizationRole(-1), err
+	}
+	return enums.OrganizationRole(0).NewFromString(role), nil
+}
+
+func (d *DataAccessService) GetOrganizationMembers(ctx context.Context, organizationId uint64) ([]t.OrganizationMember, error) {
+	var rows []struct {
+		UserId   uint64    `db:"user_id"`
+		Email    string    `db:"email"`
+		Role     string    `db:"role"`
+		JoinedAt time.Time `db:"created_at"`
+	}
+	err := d.userReader.SelectContext(ctx, &rows, `
+		SELECT om.user_id, u.email, om.role, om.created_at
+		FROM organization_members om
+		INNER JOIN users u ON u.id = om.user_id
+		WHERE om.organization_id = $1
+		ORDER BY om.created_at, om.user_id`,
+		organizationId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]t.OrganizationMember, len(rows))
+	for i, row := range rows {
+		result[i] = t.OrganizationMember{
+			UserId:   row.UserId,
+			Email:    row.Email,
+			Role:     row.Role,
+			JoinedAt: row.JoinedAt.Unix(),
+		}
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) UpdateOrganizationMemberRole(ctx context.Context, organizationId uint64, userId uint64, role enums.OrganizationRole) error {
+	result, err := d.userWriter.ExecContext(ctx, `UPDATE organization_members SET role = $3 WHERE organization_id = $1 AND user_id = $2`, organizationId, userId, role.String())
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: user %v is not a member of organization %v", ErrNotFound, userId, organizationId)
+	}
+	return nil
+}
+
+func (d *DataAccessService) RemoveOrganizationMember(ctx context.Context, organizationId uint64, userId uint64) error {
+	result, err := d.userWriter.ExecContext(ctx, `DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2`, organizationId, userId)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: user %v is not a member of organization %v", ErrNotFound, userId, organizationId)
+	}
+	return nil
+}
+
+// Invitations
+
+type organizationInvitationRow struct {
+	Id        uint64    `db:"id"`
+	Email     string    `db:"email"`
+	Role      string    `db:"role"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+func (row organizationInvitationRow) toOrganizationInvitation() t.OrganizationInvitation {
+	return t.OrganizationInvitation{
+		Id:        row.Id,
+		Email:     row.Email,
+		Role:      row.Role,
+		ExpiresAt: row.ExpiresAt.Unix(),
+	}
+}
+
+// GetOrganizationInvitations returns the pending invitations, expired ones are omitted
+func (d *DataAccessService) GetOrganizationInvitations(ctx context.Context, organizationId uint64) ([]t.OrganizationInvitation, error) {
+	var rows []organizationInvitationRow
+	err := d.userReader.SelectContext(ctx, &rows, `
+		SELECT id, email, role, expires_at
+		FROM organization_invitations
+		WHERE organization_id = $1 AND expires_at > NOW()
+		ORDER BY created_at, id`,
+		organizationId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]t.OrganizationInvitation, len(rows))
+	for i, row := range rows {
+		result[i] = row.toOrganizationInvitation()
+	}
+	return result, nil
+}
+
+// CreateOrganizationInvitation invites the email address, a previous invitation of the same address is replaced.
+// The returned token is only stored hashed and has to be sent to the invitee.
+func (d *DataAccessService) CreateOrganizationInvitation(ctx context.Context, organizationId uint64, invitedBy uint64, email string, role enums.OrganizationRole) (*t.OrganizationInvitation, string, error) {
+	token := utils.RandomString(40)
+	var row organizationInvitationRow
+	err := d.userWriter.GetContext(ctx, &row, `
+		INSERT INTO organization_invitations (organization_id, email, role, token_hash, invited_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, NOW() + $6 * INTERVAL '1 second')
+		ON CONFLICT (organization_id, email) DO UPDATE SET
+			role = EXCLUDED.role,
+			token_hash = EXCLUDED.token_hash,
+			invited_by = EXCLUDED.invited_by,
+			expires_at = EXCLUDED.expires_at,
+			created_at = NOW()
+		RETURNING id, email, role, expires_at`,
+		organizationId, strings.ToLower(email), role.String(), utils.HashAndEncode(token), invitedBy, organizationInvitationLifetime.Seconds())
+	if err != nil {
+		return nil, "", err
+	}
+	result := row.toOrganizationInvitation()
+	return &result, token, nil
+}
+
+func (d *DataAccessService) RemoveOrganizationInvitation(ctx context.Context, organizationId uint64, invitationId uint64) error {
+	result, err := d.userWriter.ExecContext(ctx, `DELETE FROM organization_invitations WHERE organization_id = $1 AND id = $2`, organizationId, invitationId)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: invitation %v not found", ErrNotFound, invitationId)
+	}
+	return nil
+}
+
+// AcceptOrganizationInvitation adds the user to the organization the token was issued for and returns the organization id.
+// Invitations are bound to the invited email address, they can't be accepted by other users.
+func (d *DataAccessService) AcceptOrganizationInvitation(ctx context.Context, userId uint64, email string, token string) (uint64, error) {
+	tx, err := d.userWriter.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error starting db transaction: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	var invitation struct {
+		Id             uint64 `db:"id"`
+		OrganizationId uint64 `db:"organization_id"`
+		Role           string `db:"role"`
+	}
+	err = tx.GetContext(ctx, &invitation, `
+		DELETE FROM organization_invitations
+		WHERE token_hash = $1 AND email = $2 AND expires_at > NOW()
+		RETURNING id, organization_id, role`,
+		utils.HashAndEncode(token), strings.ToLower(email))
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("%w: invitation not found", ErrNotFound)
+	}
+	if err != nil {
+		return 0, err
+	}
+	// existing members keep their role
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO organization_members (organization_id, user_id, role)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (organization_id, user_id) DO NOTHING`,
+		invitation.OrganizationId, userId, invitation.Role)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, fmt.Errorf("error committing tx: %w", err)
+	}
+	return invitation.OrganizationId, nil
+}
+
+// Dashboards & perks
+
+// GetOrganizationPremiumPerks pools the perks of all members, every perk is granted at the highest level any member has
+func (d *DataAccessService) GetOrganizationPremiumPerks(ctx context.Context, organizationId uint64) (*t.PremiumPerks, error) {
+	var memberIds []uint64
+	err := d.userReader.SelectContext(ctx, &memberIds, `SELECT user_id FROM organization_members WHERE organization_id = $1`, organizationId)
+	if err != nil {
+		return nil, err
+	}
+	result, err := d.GetFreeTierPerks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, memberId := range memberIds {
+		userInfo, err := d.GetUserInfo(ctx, memberId)
+		if err != nil {
+			return nil, fmt.Errorf("error getting premium perks of organization member %v: %w", memberId, err)
+		}
+		poolPremiumPerks(result, &userInfo.PremiumPerks)
+	}
+	return result, nil
+}
+
+func poolPremiumPerks(pooled *t.PremiumPerks, perks *t.PremiumPerks) {
+	pooled.AdFree = pooled.AdFree || perks.AdFree
+	pooled.ValidatorDashboards = max(pooled.ValidatorDashboards, perks.ValidatorDashboards)
+	pooled.ValidatorsPerDashboard = max(pooled.ValidatorsPerDashboard, perks.ValidatorsPerDashboard)
+	pooled.ValidatorGroupsPerDashboard = max(pooled.ValidatorGroupsPerDashboard, perks.ValidatorGroupsPerDashboard)
+	pooled.ShareCustomDashboards = pooled.ShareCustomDashboards || perks.ShareCustomDashboards
+	pooled.ManageDashboardViaApi = pooled.ManageDashboardViaApi || perks.ManageDashboardViaApi
+	pooled.BulkAdding = pooled.BulkAdding || perks.BulkAdding
+	pooled.ChartHistorySeconds.Epoch = max(pooled.ChartHistorySeconds.Epoch, perks.ChartHistorySeconds.Epoch)
+	pooled.ChartHistorySeconds.Hourly = max(pooled.ChartHistorySeconds.Hourly, perks.ChartHistorySeconds.Hourly)
+	pooled.ChartHistorySeconds.Daily = max(pooled.ChartHistorySeconds.Daily, perks.ChartHistorySeconds.Daily)
+	pooled.ChartHistorySeconds.Weekly = max(pooled.ChartHistorySeconds.Weekly, perks.ChartHistorySeconds.Weekly)
+	pooled.EmailNotificationsPerDay = max(pooled.EmailNotificationsPerDay, perks.EmailNotificationsPerDay)
+	pooled.ConfigureNotificationsViaApi = pooled.ConfigureNotificationsViaApi || perks.ConfigureNotificationsViaApi
+	pooled.ValidatorGroupNotifications = max(pooled.ValidatorGroupNotifications, perks.ValidatorGroupNotifications)
+	pooled.WebhookEndpoints = max(pooled.WebhookEndpoints, perks.WebhookEndpoints)
+	pooled.MobileAppCustomThemes = pooled.MobileAppCustomThemes || perks.MobileAppCustomThemes
+	pooled.MobileAppWidget = pooled.MobileAppWidget || perks.MobileAppWidget
+	pooled.MonitorMachines = max(pooled.MonitorMachines, perks.MonitorMachines)
+	pooled.MachineMonitoringHistorySeconds = max(pooled.MachineMonitoringHistorySeconds, perks.MachineMonitoringHistorySeconds)
+	pooled.NotificationsMachineCustomThreshold = pooled.NotificationsMachineCustomThreshold || perks.NotificationsMachineCustomThreshold
+	pooled.NotificationsValidatorDashboardGroupEfficiency = pooled.NotificationsValidatorDashboardGroupEfficiency || perks.NotificationsValidatorDashboardGroupEfficiency
+	pooled.ValidatorDashboardExports = pooled.ValidatorDashboardExports || perks.ValidatorDashboardExports
+}
+
+// return number of active / archived dashboards of all networks owned by the organization
+func (d *DataAccessService) GetOrganizationValidatorDashboardCount(ctx context.Context, organizationId uint64, active bool) (uint64, error) {
+	var total uint64
+	for _, network := range append([]*DataAccessService{d}, d.additionalNetworks()...) {
+		var count uint64
+		err := network.alloyReader.GetContext(ctx, &count, `
+			SELECT COUNT(*) FROM users_val_dashboards
+			WHERE organization_id = $1 AND (($2 AND is_archived IS NULL) OR (NOT $2 AND is_archived IS NOT NULL))
+		`, organizationId, active)
+		if err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// UpdateValidatorDashboardOwner transfers the dashboard to an organization or, if organizationId is nil, to the user personally.
+// The user stays the creator of organization dashboards and keeps receiving their notifications.
+func (d *DataAccessService) UpdateValidatorDashboardOwner(ctx context.Context, dashboardId t.VDBIdPrimary, userId uint64, organizationId *uint64) error {
+	_, err := d.alloyWriter.ExecContext(ctx, `
+		UPDATE users_val_dashboards SET user_id = $2, organization_id = $3 WHERE id = $1
+	`, dashboardId, userId, organizationId)
+	return err
+}
+
+// getUserOrganizationIds returns the ids of all organizations the user is a member of
+func (d *DataAccessService) getUserOrganizationIds(ctx context.Context, userId uint64) ([]int64, error) {
+	var organizationIds []int64
+	err := d.userReader.SelectContext(ctx, &organizationIds, `SELECT organization_id FROM organization_members WHERE user_id = $1`, userId)
+	return organizationIds, err
+}
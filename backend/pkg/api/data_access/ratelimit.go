@@ -2,12 +2,16 @@ package dataaccess
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
 	"github.com/gobitfly/beaconchain/pkg/api/types"
 )
 
 type RatelimitRepository interface {
 	GetApiWeights(ctx context.Context) ([]types.ApiWeightItem, error)
+	GetUserApiUsage(ctx context.Context, userId uint64, aggregation enums.ChartAggregation, afterTs, beforeTs uint64) ([]types.ApiKeyEndpointUsage, error)
 	// TODO @patrick: move queries from commons/ratelimit/ratelimit.go to here
 }
 
@@ -20,3 +24,70 @@ func (d *DataAccessService) GetApiWeights(ctx context.Context) ([]types.ApiWeigh
 	`)
 	return result, err
 }
+
+// GetUserApiUsage returns the requests of all (including revoked) api keys of the user per endpoint, aggregated by the given interval.
+// The weight of a request is the weight its endpoint had at the time of the request.
+func (d *DataAccessService) GetUserApiUsage(ctx context.Context, userId uint64, aggregation enums.ChartAggregation, afterTs, beforeTs uint64) ([]types.ApiKeyEndpointUsage, error) {
+	var truncation string
+	switch aggregation {
+	case enums.ChartAggregations.Hourly:
+		truncation = "hour"
+	case enums.ChartAggregations.Daily:
+		truncation = "day"
+	case enums.ChartAggregations.Weekly:
+		truncation = "week"
+	default:
+		return nil, fmt.Errorf("unsupported aggregation %v", aggregation)
+	}
+
+	var queryResult []struct {
+		ApiKeyId uint64    `db:"api_key_id"`
+		Endpoint string    `db:"endpoint"`
+		Ts       time.Time `db:"ts"`
+		Requests uint64    `db:"requests"`
+		Weight   uint64    `db:"weight"`
+	}
+	err := d.userReader.SelectContext(ctx, &queryResult, `
+		SELECT
+			k.id AS api_key_id,
+			s.endpoint,
+			DATE_TRUNC($4, s.ts) AS ts,
+			SUM(s.count) AS requests,
+			SUM(s.count * COALESCE(w.weight, 1)) AS weight
+		FROM api_statistics s
+		INNER JOIN api_keys k ON k.api_key = s.apikey
+		LEFT JOIN LATERAL (
+			SELECT weight
+			FROM api_weights
+			WHERE api_weights.endpoint = s.endpoint AND api_weights.valid_from <= s.ts
+			ORDER BY api_weights.valid_from DESC
+			LIMIT 1
+		) w ON true
+		WHERE k.user_id = $1 AND s.ts >= $2 AND s.ts < $3
+		GROUP BY 1, 2, 3
+		ORDER BY 1, 2, 3`,
+		userId, time.Unix(int64(afterTs), 0).UTC(), time.Unix(int64(beforeTs), 0).UTC(), truncation)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving api usage: %w", err)
+	}
+
+	result := []types.ApiKeyEndpointUsage{}
+	for _, row := range queryResult {
+		if len(result) == 0 || result[len(result)-1].ApiKeyId != row.ApiKeyId || result[len(result)-1].Endpoint != row.Endpoint {
+			result = append(result, types.ApiKeyEndpointUsage{
+				ApiKeyId: row.ApiKeyId,
+				Endpoint: row.Endpoint,
+				Data:     []types.ApiUsageDataPoint{},
+			})
+		}
+		usage := &result[len(result)-1]
+		usage.Requests += row.Requests
+		usage.Weight += row.Weight
+		usage.Data = append(usage.Data, types.ApiUsageDataPoint{
+			Timestamp: row.Ts.Unix(),
+			Requests:  row.Requests,
+			Weight:    row.Weight,
+		})
+	}
+	return result, nil
+}
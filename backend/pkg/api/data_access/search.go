@@ -5,7 +5,6 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	t "github.com/gobitfly/beaconchain/pkg/api/types"
-	"github.com/gobitfly/beaconchain/pkg/commons/db"
 )
 
 type SearchRepository interface {
@@ -19,8 +18,11 @@ type SearchRepository interface {
 }
 
 func (d *DataAccessService) GetSearchValidatorByIndex(ctx context.Context, chainId, index uint64) (*t.SearchValidator, error) {
-	// TODO: implement handling of chainid
-	validatorMapping, err := d.services.GetCurrentValidatorMapping()
+	network, ok := d.network(chainId)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	validatorMapping, err := network.services.GetCurrentValidatorMapping()
 	if err != nil {
 		return nil, err
 	}
@@ -36,8 +38,11 @@ func (d *DataAccessService) GetSearchValidatorByIndex(ctx context.Context, chain
 }
 
 func (d *DataAccessService) GetSearchValidatorByPublicKey(ctx context.Context, chainId uint64, publicKey []byte) (*t.SearchValidator, error) {
-	// TODO: implement handling of chainid
-	validatorMapping, err := d.services.GetCurrentValidatorMapping()
+	network, ok := d.network(chainId)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	validatorMapping, err := network.services.GetCurrentValidatorMapping()
 	if err != nil {
 		return nil, err
 	}
@@ -54,11 +59,14 @@ func (d *DataAccessService) GetSearchValidatorByPublicKey(ctx context.Context, c
 }
 
 func (d *DataAccessService) GetSearchValidatorsByDepositAddress(ctx context.Context, chainId uint64, address []byte) (*t.SearchValidatorsByDepositAddress, error) {
-	// TODO: implement handling of chainid
+	network, ok := d.network(chainId)
+	if !ok {
+		return nil, ErrNotFound
+	}
 	ret := &t.SearchValidatorsByDepositAddress{
 		DepositAddress: hexutil.Encode(address),
 	}
-	err := db.ReaderDb.GetContext(ctx, &ret.Count, `
+	err := network.readerDb.GetContext(ctx, &ret.Count, `
 		select count(validatorindex) from validators where pubkey in (select publickey from eth1_deposits where from_address = $1);`, address)
 	if err != nil {
 		return nil, err
@@ -76,11 +84,14 @@ func (d *DataAccessService) GetSearchValidatorsByDepositEnsName(ctx context.Cont
 }
 
 func (d *DataAccessService) GetSearchValidatorsByWithdrawalCredential(ctx context.Context, chainId uint64, credential []byte) (*t.SearchValidatorsByWithdrwalCredential, error) {
-	// TODO: implement handling of chainid
+	network, ok := d.network(chainId)
+	if !ok {
+		return nil, ErrNotFound
+	}
 	ret := &t.SearchValidatorsByWithdrwalCredential{
 		WithdrawalCredential: hexutil.Encode(credential),
 	}
-	err := db.ReaderDb.GetContext(ctx, &ret.Count, "select count(validatorindex) from validators where withdrawalcredentials = $1;", credential)
+	err := network.readerDb.GetContext(ctx, &ret.Count, "select count(validatorindex) from validators where withdrawalcredentials = $1;", credential)
 	if err != nil {
 		return nil, err
 	}
@@ -97,11 +108,14 @@ func (d *DataAccessService) GetSearchValidatorsByWithdrawalEnsName(ctx context.C
 }
 
 func (d *DataAccessService) GetSearchValidatorsByGraffiti(ctx context.Context, chainId uint64, graffiti string) (*t.SearchValidatorsByGraffiti, error) {
-	// TODO: implement handling of chainid
+	network, ok := d.network(chainId)
+	if !ok {
+		return nil, ErrNotFound
+	}
 	ret := &t.SearchValidatorsByGraffiti{
 		Graffiti: graffiti,
 	}
-	err := db.ReaderDb.GetContext(ctx, &ret.Count, "select count(distinct proposer) from blocks where graffiti_text = $1;", graffiti)
+	err := network.readerDb.GetContext(ctx, &ret.Count, "select count(distinct proposer) from blocks where graffiti_text = $1;", graffiti)
 	if err != nil {
 		return nil, err
 	}
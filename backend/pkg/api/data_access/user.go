@@ -9,6 +9,7 @@ import (
 	t "github.com/gobitfly/beaconchain/pkg/api/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/db"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 )
@@ -227,7 +228,7 @@ func (d *DataAccessService) GetUserCredentialInfo(ctx context.Context, userId ui
 
 func (d *DataAccessService) GetUserIdByApiKey(ctx context.Context, apiKey string) (uint64, error) {
 	var userId uint64
-	err := d.userReader.GetContext(ctx, &userId, `SELECT user_id FROM api_keys WHERE api_key = $1 LIMIT 1`, apiKey)
+	err := d.userReader.GetContext(ctx, &userId, `SELECT user_id FROM api_keys WHERE api_key = $1 AND valid_until > NOW() LIMIT 1`, apiKey)
 	if errors.Is(err, sql.ErrNoRows) {
 		return 0, fmt.Errorf("%w: user for api_key not found", ErrNotFound)
 	}
@@ -273,18 +274,52 @@ func (d *DataAccessService) GetFreeTierPerks(ctx context.Context) (*t.PremiumPer
 func (d *DataAccessService) GetUserDashboards(ctx context.Context, userId uint64) (*t.UserDashboardsData, error) {
 	result := &t.UserDashboardsData{}
 
+	// validator dashboards of the user's organizations are listed alongside the personal ones
+	organizationIds, err := d.getUserOrganizationIds(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving user organizations: %w", err)
+	}
+
+	// dashboards are stored in the alloy database of the network they were created for
+	for _, network := range append([]*DataAccessService{d}, d.additionalNetworks()...) {
+		validatorDashboards, err := network.getUserValidatorDashboards(ctx, userId, organizationIds)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving user dashboards data of chain %d: %w", network.chainId(), err)
+		}
+		result.ValidatorDashboards = append(result.ValidatorDashboards, validatorDashboards...)
+	}
+
+	// Get the account dashboards
+	err = d.alloyReader.SelectContext(ctx, &result.AccountDashboards, `
+		SELECT
+			id,
+			name
+		FROM users_acc_dashboards
+		WHERE user_id = $1
+	`, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// getUserValidatorDashboards returns the validator dashboards of the user and the organizations that are stored in the
+// alloy database of the network
+func (d *DataAccessService) getUserValidatorDashboards(ctx context.Context, userId uint64, organizationIds []int64) ([]t.ValidatorDashboard, error) {
 	wg := errgroup.Group{}
 
 	validatorDashboardMap := make(map[uint64]*t.ValidatorDashboard, 0)
 	wg.Go(func() error {
 		dbReturn := []struct {
-			Id           uint64         `db:"id"`
-			Name         string         `db:"name"`
-			Network      uint64         `db:"network"`
-			IsArchived   sql.NullString `db:"is_archived"`
-			PublicId     sql.NullString `db:"public_id"`
-			PublicName   sql.NullString `db:"public_name"`
-			SharedGroups sql.NullBool   `db:"shared_groups"`
+			Id             uint64         `db:"id"`
+			Name           string         `db:"name"`
+			Network        uint64         `db:"network"`
+			IsArchived     sql.NullString `db:"is_archived"`
+			PublicId       sql.NullString `db:"public_id"`
+			PublicName     sql.NullString `db:"public_name"`
+			SharedGroups   sql.NullBool   `db:"shared_groups"`
+			OrganizationId *uint64        `db:"organization_id"`
 		}{}
 
 		err := d.alloyReader.SelectContext(ctx, &dbReturn, `
@@ -295,11 +330,12 @@ func (d *DataAccessService) GetUserDashboards(ctx context.Context, userId uint64
 			uvd.is_archived,
 			uvds.public_id,
 			uvds.name AS public_name,
-			uvds.shared_groups
+			uvds.shared_groups,
+			uvd.organization_id
 		FROM users_val_dashboards uvd
 		LEFT JOIN users_val_dashboards_sharing uvds ON uvd.id = uvds.dashboard_id
-		WHERE uvd.user_id = $1
-	`, userId)
+		WHERE (uvd.user_id = $1 AND uvd.organization_id IS NULL) OR uvd.organization_id = ANY($2)
+	`, userId, pq.Array(organizationIds))
 		if err != nil {
 			return err
 		}
@@ -313,6 +349,7 @@ func (d *DataAccessService) GetUserDashboards(ctx context.Context, userId uint64
 					PublicIds:      []t.VDBPublicId{},
 					IsArchived:     row.IsArchived.Valid,
 					ArchivedReason: row.IsArchived.String,
+					OrganizationId: row.OrganizationId,
 				}
 			}
 			if row.PublicId.Valid {
@@ -346,9 +383,9 @@ func (d *DataAccessService) GetUserDashboards(ctx context.Context, userId uint64
 		FROM users_val_dashboards uvd
 		LEFT JOIN users_val_dashboards_groups uvdg ON uvd.id = uvdg.dashboard_id
 		LEFT JOIN users_val_dashboards_validators uvdv ON uvd.id = uvdv.dashboard_id
-		WHERE uvd.user_id = $1
+		WHERE (uvd.user_id = $1 AND uvd.organization_id IS NULL) OR uvd.organization_id = ANY($2)
 		GROUP BY uvd.id
-	`, userId)
+	`, userId, pq.Array(organizationIds))
 		if err != nil {
 			return err
 		}
@@ -362,39 +399,34 @@ func (d *DataAccessService) GetUserDashboards(ctx context.Context, userId uint64
 
 	err := wg.Wait()
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving user dashboards data: %w", err)
+		return nil, err
 	}
 
 	// Fill the result
+	result := make([]t.ValidatorDashboard, 0, len(validatorDashboardMap))
 	for _, validatorDashboard := range validatorDashboardMap {
 		validatorDashboard.GroupCount = validatorDashboardCountMap[validatorDashboard.Id].GroupCount
 		validatorDashboard.ValidatorCount = validatorDashboardCountMap[validatorDashboard.Id].ValidatorCount
 
-		result.ValidatorDashboards = append(result.ValidatorDashboards, *validatorDashboard)
-	}
-
-	// Get the account dashboards
-	err = d.alloyReader.SelectContext(ctx, &result.AccountDashboards, `
-		SELECT
-			id,
-			name
-		FROM users_acc_dashboards
-		WHERE user_id = $1
-	`, userId)
-	if err != nil {
-		return nil, err
+		result = append(result, *validatorDashboard)
 	}
 
 	return result, nil
 }
 
-// return number of active / archived dashboards
+// return number of active / archived dashboards of all networks, dashboards owned by organizations are not counted
 func (d *DataAccessService) GetUserValidatorDashboardCount(ctx context.Context, userId uint64, active bool) (uint64, error) {
-	var count uint64
-	err := d.alloyReader.GetContext(ctx, &count, `
-		SELECT COUNT(*) FROM users_val_dashboards
-		WHERE user_id = $1 AND (($2 AND is_archived IS NULL) OR (NOT $2 AND is_archived IS NOT NULL))
-	`, userId, active)
-
-	return count, err
+	var total uint64
+	for _, network := range append([]*DataAccessService{d}, d.additionalNetworks()...) {
+		var count uint64
+		err := network.alloyReader.GetContext(ctx, &count, `
+			SELECT COUNT(*) FROM users_val_dashboards
+			WHERE user_id = $1 AND organization_id IS NULL AND (($2 AND is_archived IS NULL) OR (NOT $2 AND is_archived IS NOT NULL))
+		`, userId, active)
+		if err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
 }
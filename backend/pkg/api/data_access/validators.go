@@ -0,0 +1,501 @@
+package dataaccess
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/cache"
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+)
+
+type ValidatorRepository interface {
+	GetValidators(ctx context.Context, indices []uint64) ([]t.ValidatorData, error)
+	GetValidatorDuties(ctx context.Context, index, epoch uint64) (*t.ValidatorDuties, error)
+	GetValidatorsByDepositAddress(ctx context.Context, address []byte, cursor string, limit uint64) ([]t.ValidatorData, *t.Paging, error)
+	GetValidatorsByWithdrawalCredentials(ctx context.Context, credentials [][]byte, cursor string, limit uint64) ([]t.ValidatorData, *t.Paging, error)
+	GetValidatorStatusCounts(ctx context.Context) ([]t.ValidatorStatusCount, error)
+	GetValidatorLeaderboard(ctx context.Context, cursor string, limit uint64) ([]t.ValidatorLeaderboardTableRow, *t.Paging, error)
+	GetValidatorQueue(ctx context.Context) (*t.ValidatorQueue, error)
+	GetValidatorPoolOperations(ctx context.Context, index uint64) ([]t.ValidatorPoolOperation, error)
+}
+
+// order in which validator statuses are returned
+var validatorStatuses = []constypes.ValidatorDbStatus{
+	constypes.DbActiveOnline,
+	constypes.DbActiveOffline,
+	constypes.DbPending,
+	constypes.DbDeposited,
+	constypes.DbExitingOnline,
+	constypes.DbExitingOffline,
+	constypes.DbSlashingOnline,
+	constypes.DbSlashingOffline,
+	constypes.DbExited,
+	constypes.DbSlashed,
+}
+
+func (d *DataAccessService) GetValidators(ctx context.Context, indices []uint64) ([]t.ValidatorData, error) {
+	validatorMapping, err := d.services.GetCurrentValidatorMapping()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]t.ValidatorData, 0, len(indices))
+	for _, index := range indices {
+		if index >= uint64(len(validatorMapping.ValidatorMetadata)) {
+			continue
+		}
+		result = append(result, getValidatorData(index, validatorMapping.ValidatorMetadata[index]))
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) GetValidatorDuties(ctx context.Context, index, epoch uint64) (*t.ValidatorDuties, error) {
+	result := &t.ValidatorDuties{
+		Epoch:     epoch,
+		Proposals: make([]t.ValidatorProposalDuty, 0),
+	}
+
+	proposals := []struct {
+		Slot   uint64 `db:"proposerslot"`
+		Status uint64 `db:"status"`
+	}{}
+	err := d.readerDb.SelectContext(ctx, &proposals, `
+		SELECT proposerslot, status
+		FROM proposal_assignments
+		WHERE epoch = $1 AND validatorindex = $2
+		ORDER BY proposerslot`, epoch, index)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving proposal assignments of validator %d: %w", index, err)
+	}
+	for _, proposal := range proposals {
+		duty := t.ValidatorProposalDuty{Slot: proposal.Slot}
+		switch proposal.Status {
+		case 1:
+			duty.Status = "proposed"
+		case 2:
+			duty.Status = "missed"
+		default:
+			duty.Status = "scheduled"
+		}
+		result.Proposals = append(result.Proposals, duty)
+	}
+
+	if epoch >= d.chain.ClConfig.AltairForkEpoch {
+		period := d.chain.SyncPeriodOfEpoch(epoch)
+		var isSyncCommitteeMember bool
+		err = d.readerDb.GetContext(ctx, &isSyncCommitteeMember, `
+			SELECT EXISTS(SELECT 1 FROM sync_committees WHERE period = $1 AND validatorindex = $2)`, period, index)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving sync committee membership of validator %d: %w", index, err)
+		}
+		if isSyncCommitteeMember {
+			startEpoch := d.chain.FirstEpochOfSyncPeriod(period)
+			result.SyncCommittee = &t.ValidatorSyncCommitteeDuty{
+				Period:     period,
+				StartEpoch: startEpoch,
+				EndEpoch:   startEpoch + d.chain.ClConfig.EpochsPerSyncCommitteePeriod - 1,
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (d *DataAccessService) GetValidatorsByDepositAddress(ctx context.Context, address []byte, cursor string, limit uint64) ([]t.ValidatorData, *t.Paging, error) {
+	searchResult, err := d.GetSearchValidatorsByDepositAddress(ctx, d.chainId(), address)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ds := goqu.Dialect("postgres").
+		Select(goqu.C("validatorindex")).
+		From("validators").
+		Where(goqu.L("pubkey IN (SELECT publickey FROM eth1_deposits WHERE from_address = ?)", address))
+
+	return d.getValidatorsPage(ctx, ds, searchResult.Count, cursor, limit)
+}
+
+func (d *DataAccessService) GetValidatorsByWithdrawalCredentials(ctx context.Context, credentials [][]byte, cursor string, limit uint64) ([]t.ValidatorData, *t.Paging, error) {
+	var totalCount uint64
+	err := d.readerDb.GetContext(ctx, &totalCount, "SELECT COUNT(validatorindex) FROM validators WHERE withdrawalcredentials = ANY($1)", pq.ByteaArray(credentials))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error counting validators by withdrawal credentials: %w", err)
+	}
+	if totalCount == 0 {
+		return nil, nil, ErrNotFound
+	}
+
+	ds := goqu.Dialect("postgres").
+		Select(goqu.C("validatorindex")).
+		From("validators").
+		Where(goqu.L("withdrawalcredentials = ANY(?)", pq.ByteaArray(credentials)))
+
+	return d.getValidatorsPage(ctx, ds, totalCount, cursor, limit)
+}
+
+// getValidatorsPage returns a page of validators, ordered by index, from a query selecting validator indices
+func (d *DataAccessService) getValidatorsPage(ctx context.Context, ds *goqu.SelectDataset, totalCount uint64, cursor string, limit uint64) ([]t.ValidatorData, *t.Paging, error) {
+	paging := t.Paging{TotalCount: totalCount}
+
+	// Initialize the cursor
+	var currentCursor t.ValidatorsCursor
+	var err error
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.ValidatorsCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as ValidatorsCursor: %w", err)
+		}
+	}
+
+	if currentCursor.IsValid() {
+		if currentCursor.IsReverse() {
+			ds = ds.Where(goqu.C("validatorindex").Lt(currentCursor.Index)).Order(goqu.C("validatorindex").Desc())
+		} else {
+			ds = ds.Where(goqu.C("validatorindex").Gt(currentCursor.Index)).Order(goqu.C("validatorindex").Asc())
+		}
+	} else {
+		ds = ds.Order(goqu.C("validatorindex").Asc())
+	}
+	ds = ds.Limit(uint(limit + 1))
+
+	query, args, err := ds.Prepared(true).ToSQL()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing validators query: %w", err)
+	}
+
+	var indices []uint64
+	err = d.readerDb.SelectContext(ctx, &indices, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving validator indices: %w", err)
+	}
+
+	result, err := d.GetValidators(ctx, indices)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// -------------------------------------
+	// Paging
+
+	// Flag if above limit
+	moreDataFlag := len(result) > int(limit)
+	if !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return result, &paging, nil
+	}
+
+	// Remove the last entries from data
+	if moreDataFlag {
+		result = result[:limit]
+	}
+
+	if currentCursor.IsReverse() {
+		slices.Reverse(result)
+	}
+
+	p, err := utils.GetPagingFromData(result, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+	p.TotalCount = totalCount
+
+	return result, p, nil
+}
+
+func (d *DataAccessService) GetValidatorStatusCounts(ctx context.Context) ([]t.ValidatorStatusCount, error) {
+	validatorMapping, err := d.services.GetCurrentValidatorMapping()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[constypes.ValidatorDbStatus]uint64, len(validatorStatuses))
+	for _, metadata := range validatorMapping.ValidatorMetadata {
+		counts[constypes.ValidatorDbStatus(metadata.Status)]++
+	}
+
+	result := make([]t.ValidatorStatusCount, 0, len(validatorStatuses))
+	for _, status := range validatorStatuses {
+		result = append(result, t.ValidatorStatusCount{
+			Status: string(status),
+			Count:  counts[status],
+		})
+	}
+	return result, nil
+}
+
+func (d *DataAccessService) GetValidatorLeaderboard(ctx context.Context, cursor string, limit uint64) ([]t.ValidatorLeaderboardTableRow, *t.Paging, error) {
+	result := make([]t.ValidatorLeaderboardTableRow, 0)
+	var paging t.Paging
+
+	// Initialize the cursor
+	var currentCursor t.ValidatorLeaderboardCursor
+	var err error
+	if cursor != "" {
+		currentCursor, err = utils.StringToCursor[t.ValidatorLeaderboardCursor](cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse passed cursor as ValidatorLeaderboardCursor: %w", err)
+		}
+	}
+
+	performances := []struct {
+		Index     uint64 `db:"validatorindex"`
+		Rank      uint64 `db:"rank7d"`
+		Balance   int64  `db:"balance"`
+		Cl1d      int64  `db:"cl_performance_1d"`
+		Cl7d      int64  `db:"cl_performance_7d"`
+		Cl31d     int64  `db:"cl_performance_31d"`
+		ClTotal   int64  `db:"cl_performance_total"`
+		El1d      int64  `db:"el_performance_1d"`
+		El7d      int64  `db:"el_performance_7d"`
+		El31d     int64  `db:"el_performance_31d"`
+		ElTotal   int64  `db:"el_performance_total"`
+		PublicKey []byte `db:"pubkey"`
+	}{}
+
+	ds := goqu.Dialect("postgres").
+		Select(
+			goqu.I("vp.validatorindex"),
+			goqu.I("vp.rank7d"),
+			goqu.I("vp.balance"),
+			goqu.I("vp.cl_performance_1d"),
+			goqu.I("vp.cl_performance_7d"),
+			goqu.I("vp.cl_performance_31d"),
+			goqu.I("vp.cl_performance_total"),
+			goqu.I("vp.el_performance_1d"),
+			goqu.I("vp.el_performance_7d"),
+			goqu.I("vp.el_performance_31d"),
+			goqu.I("vp.el_performance_total"),
+			goqu.I("v.pubkey")).
+		From(goqu.T("validator_performance").As("vp")).
+		InnerJoin(goqu.T("validators").As("v"), goqu.On(goqu.I("v.validatorindex").Eq(goqu.I("vp.validatorindex")))).
+		Where(goqu.I("vp.rank7d").Gt(0)).
+		Limit(uint(limit + 1))
+
+	if currentCursor.IsValid() {
+		if currentCursor.IsReverse() {
+			ds = ds.Where(goqu.I("vp.rank7d").Lt(currentCursor.Rank)).Order(goqu.I("vp.rank7d").Desc())
+		} else {
+			ds = ds.Where(goqu.I("vp.rank7d").Gt(currentCursor.Rank)).Order(goqu.I("vp.rank7d").Asc())
+		}
+	} else {
+		ds = ds.Order(goqu.I("vp.rank7d").Asc())
+	}
+
+	query, args, err := ds.Prepared(true).ToSQL()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error preparing validator leaderboard query: %w", err)
+	}
+
+	err = d.readerDb.SelectContext(ctx, &performances, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving validator leaderboard: %w", err)
+	}
+
+	gweiToWei := func(gwei int64) decimal.Decimal {
+		return utils.GWeiToWei(big.NewInt(gwei))
+	}
+	for _, performance := range performances {
+		// the 31 day performance is the closest to the last 30 days that is available
+		result = append(result, t.ValidatorLeaderboardTableRow{
+			Rank:      performance.Rank,
+			Index:     performance.Index,
+			PublicKey: t.PubKey(hexutil.Encode(performance.PublicKey)),
+			Balance:   gweiToWei(performance.Balance),
+			Income: t.PeriodicValues[t.ClElValue[decimal.Decimal]]{
+				AllTime: t.ClElValue[decimal.Decimal]{Cl: gweiToWei(performance.ClTotal), El: decimal.NewFromInt(performance.ElTotal)},
+				Last24h: t.ClElValue[decimal.Decimal]{Cl: gweiToWei(performance.Cl1d), El: decimal.NewFromInt(performance.El1d)},
+				Last7d:  t.ClElValue[decimal.Decimal]{Cl: gweiToWei(performance.Cl7d), El: decimal.NewFromInt(performance.El7d)},
+				Last30d: t.ClElValue[decimal.Decimal]{Cl: gweiToWei(performance.Cl31d), El: decimal.NewFromInt(performance.El31d)},
+			},
+		})
+	}
+
+	// -------------------------------------
+	// Paging
+
+	// Flag if above limit
+	moreDataFlag := len(result) > int(limit)
+	if !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return result, &paging, nil
+	}
+
+	// Remove the last entries from data
+	if moreDataFlag {
+		result = result[:limit]
+	}
+
+	if currentCursor.IsReverse() {
+		slices.Reverse(result)
+	}
+
+	p, err := utils.GetPagingFromData(result, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+
+	return result, p, nil
+}
+
+func (d *DataAccessService) GetValidatorQueue(ctx context.Context) (*t.ValidatorQueue, error) {
+	validatorMapping, err := d.services.GetCurrentValidatorMapping()
+	if err != nil {
+		return nil, err
+	}
+
+	latestStats := cache.LatestStats.OfChain(d.chainId()).Get()
+	activationChurnLimit := uint64(4)
+	exitChurnLimit := uint64(4)
+	if latestStats == nil || latestStats.ValidatorActivationChurnLimit == nil {
+		log.Warnf("Activation Churn rate not set in config using 4 as default")
+	} else {
+		activationChurnLimit = *latestStats.ValidatorActivationChurnLimit
+	}
+	if latestStats == nil || latestStats.ValidatorChurnLimit == nil {
+		log.Warnf("Churn rate not set in config using 4 as default")
+	} else {
+		exitChurnLimit = *latestStats.ValidatorChurnLimit
+	}
+
+	result := &t.ValidatorQueue{
+		Entering: t.ValidatorQueueData{ChurnLimit: activationChurnLimit},
+		Exiting:  t.ValidatorQueueData{ChurnLimit: exitChurnLimit},
+	}
+	var enteringBalance, exitingBalance uint64
+	for _, metadata := range validatorMapping.ValidatorMetadata {
+		switch constypes.ValidatorDbStatus(metadata.Status) {
+		case constypes.DbPending:
+			result.Entering.Count++
+			enteringBalance += metadata.EffectiveBalance
+		case constypes.DbExitingOnline, constypes.DbExitingOffline:
+			result.Exiting.Count++
+			exitingBalance += metadata.EffectiveBalance
+		}
+	}
+	result.Entering.Balance = utils.GWeiToWei(new(big.Int).SetUint64(enteringBalance))
+	result.Exiting.Balance = utils.GWeiToWei(new(big.Int).SetUint64(exitingBalance))
+
+	secondsPerEpoch := d.chain.ClConfig.SlotsPerEpoch * d.chain.ClConfig.SecondsPerSlot
+	if activationChurnLimit > 0 {
+		result.Entering.EstimatedWaitDuration = (result.Entering.Count + activationChurnLimit - 1) / activationChurnLimit * secondsPerEpoch
+	}
+	if exitChurnLimit > 0 {
+		result.Exiting.EstimatedWaitDuration = (result.Exiting.Count + exitChurnLimit - 1) / exitChurnLimit * secondsPerEpoch
+	}
+
+	return result, nil
+}
+
+func (d *DataAccessService) GetValidatorPoolOperations(ctx context.Context, index uint64) ([]t.ValidatorPoolOperation, error) {
+	operations, err := d.getPoolOperations(ctx, []t.VDBValidator{index})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]t.ValidatorPoolOperation, 0, len(operations))
+	for _, operation := range operations {
+		result = append(result, getValidatorPoolOperation(operation))
+	}
+	return result, nil
+}
+
+// getPoolOperations returns the tracked pool operations of the validators, the most recently seen first
+func (d *DataAccessService) getPoolOperations(ctx context.Context, validators []t.VDBValidator) ([]*types.PoolOperation, error) {
+	operations := []*types.PoolOperation{}
+	if len(validators) == 0 {
+		return operations, nil
+	}
+	err := d.readerDb.SelectContext(ctx, &operations, `
+		SELECT
+			type,
+			validatorindex,
+			signature,
+			exit_epoch,
+			to_execution_address,
+			first_seen_ts,
+			first_seen_slot,
+			last_seen_slot,
+			included_slot,
+			dropped_slot
+		FROM pool_operations
+		WHERE validatorindex = ANY($1)
+		ORDER BY first_seen_slot DESC, validatorindex`, pq.Array(validators))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving pool operations: %w", err)
+	}
+	return operations, nil
+}
+
+func getValidatorPoolOperation(operation *types.PoolOperation) t.ValidatorPoolOperation {
+	slotOrNil := func(slot sql.NullInt64) *uint64 {
+		if !slot.Valid {
+			return nil
+		}
+		s := uint64(slot.Int64)
+		return &s
+	}
+
+	result := t.ValidatorPoolOperation{
+		Index:         operation.ValidatorIndex,
+		Type:          operation.Type,
+		Status:        "pending",
+		Signature:     t.Hash(hexutil.Encode(operation.Signature)),
+		ExitEpoch:     slotOrNil(operation.ExitEpoch),
+		FirstSeenTs:   operation.FirstSeenTs.Unix(),
+		FirstSeenSlot: operation.FirstSeenSlot,
+		LastSeenSlot:  operation.LastSeenSlot,
+		IncludedSlot:  slotOrNil(operation.IncludedSlot),
+		DroppedSlot:   slotOrNil(operation.DroppedSlot),
+	}
+	if len(operation.ToExecutionAddress) > 0 {
+		result.ToExecutionAddress = &t.Address{Hash: t.Hash(hexutil.Encode(operation.ToExecutionAddress))}
+	}
+	switch {
+	case operation.IncludedSlot.Valid:
+		result.Status = "included"
+	case operation.DroppedSlot.Valid:
+		result.Status = "dropped"
+	}
+	return result
+}
+
+func getValidatorData(index uint64, metadata *types.CachedValidator) t.ValidatorData {
+	epochOrNil := func(epoch sql.NullInt64) *uint64 {
+		if !epoch.Valid || epoch.Int64 < 0 || uint64(epoch.Int64) >= db.MaxSqlNumber {
+			return nil
+		}
+		e := uint64(epoch.Int64)
+		return &e
+	}
+
+	data := t.ValidatorData{
+		Index:                      index,
+		PublicKey:                  t.PubKey(hexutil.Encode(metadata.PublicKey)),
+		Status:                     metadata.Status,
+		Balance:                    utils.GWeiToWei(new(big.Int).SetUint64(metadata.Balance)),
+		EffectiveBalance:           utils.GWeiToWei(new(big.Int).SetUint64(metadata.EffectiveBalance)),
+		Slashed:                    metadata.Slashed,
+		WithdrawalCredential:       t.Hash(hexutil.Encode(metadata.WithdrawalCredentials)),
+		ActivationEligibilityEpoch: epochOrNil(metadata.ActivationEligibilityEpoch),
+		ActivationEpoch:            epochOrNil(metadata.ActivationEpoch),
+		ExitEpoch:                  epochOrNil(metadata.ExitEpoch),
+		WithdrawableEpoch:          epochOrNil(metadata.WithdrawableEpoch),
+	}
+
+	if constypes.ValidatorDbStatus(metadata.Status) == constypes.DbPending && metadata.Queues.ActivationIndex.Valid {
+		activationIndex := uint64(metadata.Queues.ActivationIndex.Int64)
+		data.QueuePosition = &activationIndex
+	}
+	return data
+}
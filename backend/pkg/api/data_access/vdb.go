@@ -5,6 +5,7 @@ import (
 
 	"github.com/gobitfly/beaconchain/pkg/api/enums"
 	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/reports"
 	"github.com/shopspring/decimal"
 )
 
@@ -13,13 +14,16 @@ type ValidatorDashboardRepository interface {
 	GetValidatorDashboardIdByPublicId(ctx context.Context, publicDashboardId t.VDBIdPublic) (*t.VDBIdPrimary, error)
 	GetValidatorDashboardInfo(ctx context.Context, dashboardId t.VDBIdPrimary) (*t.ValidatorDashboard, error)
 	GetValidatorDashboardName(ctx context.Context, dashboardId t.VDBIdPrimary) (string, error)
-	CreateValidatorDashboard(ctx context.Context, userId uint64, name string, network uint64) (*t.VDBPostReturnData, error)
+	CreateValidatorDashboard(ctx context.Context, userId uint64, name string, network uint64, organizationId *uint64) (*t.VDBPostReturnData, error)
 	RemoveValidatorDashboard(ctx context.Context, dashboardId t.VDBIdPrimary) error
 
 	UpdateValidatorDashboardArchiving(ctx context.Context, dashboardId t.VDBIdPrimary, archivedReason *enums.VDBArchivedReason) (*t.VDBPostArchivingReturnData, error)
 
 	UpdateValidatorDashboardName(ctx context.Context, dashboardId t.VDBIdPrimary, name string) (*t.VDBPostReturnData, error)
 
+	UpdateValidatorDashboardOperatorShare(ctx context.Context, dashboardId t.VDBIdPrimary, protocol enums.StakingProtocol, operatorShare float64) (*t.VDBOperatorShare, error)
+	RemoveValidatorDashboardOperatorShare(ctx context.Context, dashboardId t.VDBIdPrimary, protocol enums.StakingProtocol) error
+
 	GetValidatorDashboardOverview(ctx context.Context, dashboardId t.VDBId, protocolModes t.VDBProtocolModes) (*t.VDBOverviewData, error)
 
 	CreateValidatorDashboardGroup(ctx context.Context, dashboardId t.VDBIdPrimary, name string) (*t.VDBPostCreateGroupData, error)
@@ -45,6 +49,7 @@ type ValidatorDashboardRepository interface {
 	GetValidatorDashboardPublicIdCount(ctx context.Context, dashboardId t.VDBIdPrimary) (uint64, error)
 
 	GetValidatorDashboardSlotViz(ctx context.Context, dashboardId t.VDBId, groupIds []uint64) ([]t.SlotVizEpoch, error)
+	SubscribeToValidatorDashboardSlotDuties(ctx context.Context, dashboardId t.VDBId) (<-chan t.VDBSlotDutiesEvent, error)
 
 	GetLatestExportedChartTs(ctx context.Context, aggregation enums.ChartAggregation) (uint64, error)
 
@@ -57,8 +62,10 @@ type ValidatorDashboardRepository interface {
 	GetValidatorDashboardProposalSummaryValidators(ctx context.Context, dashboardId t.VDBId, groupId int64, period enums.TimePeriod) (*t.VDBProposalSummaryValidators, error)
 
 	GetValidatorDashboardRewards(ctx context.Context, dashboardId t.VDBId, cursor string, colSort t.Sort[enums.VDBRewardsColumn], search string, limit uint64, protocolModes t.VDBProtocolModes) ([]t.VDBRewardsTableRow, *t.Paging, error)
+	GetValidatorDashboardRewardsRange(ctx context.Context, dashboardId t.VDBId, startEpoch, endEpoch uint64, protocolModes t.VDBProtocolModes) ([]t.VDBRewardsTableRow, error)
 	GetValidatorDashboardGroupRewards(ctx context.Context, dashboardId t.VDBId, groupId int64, epoch uint64, protocolModes t.VDBProtocolModes) (*t.VDBGroupRewardsData, error)
 	GetValidatorDashboardRewardsChart(ctx context.Context, dashboardId t.VDBId, protocolModes t.VDBProtocolModes) (*t.ChartData[int, decimal.Decimal], error)
+	GetValidatorDashboardIncomeReport(ctx context.Context, dashboardId t.VDBId, groupId int64, currency enums.Currency, period reports.Period) (*reports.IncomeReport, error)
 
 	GetValidatorDashboardDuties(ctx context.Context, dashboardId t.VDBId, epoch uint64, groupId int64, cursor string, colSort t.Sort[enums.VDBDutiesColumn], search string, limit uint64, protocolModes t.VDBProtocolModes) ([]t.VDBEpochDutiesTableRow, *t.Paging, error)
 
@@ -75,6 +82,8 @@ type ValidatorDashboardRepository interface {
 	GetValidatorDashboardWithdrawals(ctx context.Context, dashboardId t.VDBId, cursor string, colSort t.Sort[enums.VDBWithdrawalsColumn], search string, limit uint64, protocolModes t.VDBProtocolModes) ([]t.VDBWithdrawalsTableRow, *t.Paging, error)
 	GetValidatorDashboardTotalWithdrawals(ctx context.Context, dashboardId t.VDBId, search string, protocolModes t.VDBProtocolModes) (*t.VDBTotalWithdrawalsData, error)
 
+	GetValidatorDashboardPoolOperations(ctx context.Context, dashboardId t.VDBId) ([]t.VDBPoolOperationsTableRow, error)
+
 	GetValidatorDashboardRocketPool(ctx context.Context, dashboardId t.VDBId, cursor string, colSort t.Sort[enums.VDBRocketPoolColumn], search string, limit uint64) ([]t.VDBRocketPoolTableRow, *t.Paging, error)
 	GetValidatorDashboardTotalRocketPool(ctx context.Context, dashboardId t.VDBId, search string) (*t.VDBRocketPoolTableRow, error)
 	GetValidatorDashboardRocketPoolMinipools(ctx context.Context, dashboardId t.VDBId, node, cursor string, colSort t.Sort[enums.VDBRocketPoolMinipoolsColumn], search string, limit uint64) ([]t.VDBRocketPoolMinipoolsTableRow, *t.Paging, error)
@@ -24,8 +24,6 @@ import (
 )
 
 func (d *DataAccessService) GetValidatorDashboardBlocks(ctx context.Context, dashboardId t.VDBId, cursor string, colSort t.Sort[enums.VDBBlocksColumn], search string, limit uint64, protocolModes t.VDBProtocolModes) ([]t.VDBBlocksTableRow, *t.Paging, error) {
-	// @DATA-ACCESS incorporate protocolModes
-
 	// -------------------------------------
 	// Setup
 	var err error
@@ -35,6 +33,12 @@ func (d *DataAccessService) GetValidatorDashboardBlocks(ctx context.Context, das
 		return nil, nil, err
 	}
 
+	// only the operator share of the rewards is reported for validators of enabled protocol modes
+	shares, err := d.getOperatorShares(ctx, dashboardId, protocolModes)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// TODO @LuccaBitfly move validation to handler?
 	if cursor != "" {
 		if currentCursor, err = utils.StringToCursor[t.BlocksCursor](cursor); err != nil {
@@ -176,7 +180,7 @@ func (d *DataAccessService) GetValidatorDashboardBlocks(ctx context.Context, das
 			blocks.Col("exec_block_number"),
 			blocks.Col("graffiti_text"),
 			goqu.COALESCE(goqu.I("rb.proposer_fee_recipient"), blocks.Col("exec_fee_recipient")).As("fee_recipient"),
-			goqu.COALESCE(goqu.L("rb.value / 1e18"), goqu.I("ep.fee_recipient_reward")).As("el_reward"),
+			goqu.L(shares.postgresWeighted("COALESCE(rb.value / 1e18, ep.fee_recipient_reward)", "blocks.proposer")).As("el_reward"),
 		)
 
 	// 3. Sorting and pagination
@@ -212,7 +216,7 @@ func (d *DataAccessService) GetValidatorDashboardBlocks(ctx context.Context, das
 	blocksDs = blocksDs.Limit(uint(limit + 1))
 
 	// 5. Gather and supply scheduled blocks to let db do the sorting etc
-	latestSlot := cache.LatestSlot.Get()
+	latestSlot := cache.LatestSlot.OfChain(d.chainId()).Get()
 	onlyPrimarySort := colSort.Column == enums.VDBBlockSlot
 	if !(onlyPrimarySort || colSort.Column == enums.VDBBlockBlock) ||
 		!currentCursor.IsValid() ||
@@ -256,7 +260,7 @@ func (d *DataAccessService) GetValidatorDashboardBlocks(ctx context.Context, das
 				}
 				scheduledProposers = append(scheduledProposers, dutiesInfo.PropAssignmentsForSlot[slot])
 				scheduledGroups = append(scheduledGroups, validatorSet[vali])
-				scheduledEpochs = append(scheduledEpochs, slot/utils.Config.Chain.ClConfig.SlotsPerEpoch)
+				scheduledEpochs = append(scheduledEpochs, slot/d.chain.ClConfig.SlotsPerEpoch)
 				scheduledSlots = append(scheduledSlots, slot)
 			}
 
@@ -435,7 +439,7 @@ func (d *DataAccessService) GetValidatorDashboardBlocks(ctx context.Context, das
 			reward.El = proposal.ElReward.Decimal.Mul(decimal.NewFromInt(1e18))
 		}
 		if clReward, ok := clRewards[proposal.Slot]; ok && clReward.Valid {
-			reward.Cl = clReward.Decimal.Mul(decimal.NewFromInt(1e9))
+			reward.Cl = clReward.Decimal.Mul(decimal.NewFromInt(1e9)).Mul(shares.get(proposal.Proposer))
 		}
 		proposals[i].Reward = proposal.ElReward.Decimal.Add(proposal.ClReward.Decimal)
 		data[i].Reward = &reward
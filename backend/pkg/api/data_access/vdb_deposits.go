@@ -20,6 +20,13 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// deposit_type values of the blocks_consensus_deposits view
+const (
+	clDepositTypeDeposit        = 0
+	clDepositTypeDepositRequest = 1
+	clDepositTypeConsolidation  = 2
+)
+
 func (d *DataAccessService) GetValidatorDashboardElDeposits(ctx context.Context, dashboardId t.VDBId, cursor string, limit uint64) ([]t.VDBExecutionDepositsTableRow, *t.Paging, error) {
 	var err error
 	currentDirection := enums.DESC // TODO: expose over parameter
@@ -241,27 +248,31 @@ func (d *DataAccessService) GetValidatorDashboardClDeposits(ctx context.Context,
 		GroupId              sql.NullInt64 `db:"group_id"`
 		PublicKey            []byte        `db:"publickey"`
 		Slot                 int64         `db:"block_slot"`
+		DepositType          int64         `db:"deposit_type"`
 		SlotIndex            int64         `db:"block_index"`
 		WithdrawalCredential []byte        `db:"withdrawalcredentials"`
 		Amount               int64         `db:"amount"`
 		Signature            []byte        `db:"signature"`
+		SourcePublicKey      []byte        `db:"source_publickey"`
 	}
 
 	query := `
 			SELECT
 				bd.publickey,
 				bd.block_slot,
+				bd.deposit_type,
 				bd.block_index,
 				bd.amount,
 				bd.signature,
-				bd.withdrawalcredentials
+				bd.withdrawalcredentials,
+				bd.source_publickey
 		`
 
 	var filter interface{}
 	if dashboardId.Validators != nil {
 		query += `
 			FROM
-				blocks_deposits bd
+				blocks_consensus_deposits bd
 			WHERE
 				bd.publickey = ANY ($1)`
 		filter = byteaArray
@@ -270,7 +281,8 @@ func (d *DataAccessService) GetValidatorDashboardClDeposits(ctx context.Context,
 			, cbdl.group_id
 			FROM
 				cached_blocks_deposits_lookup cbdl
-				INNER JOIN blocks_deposits bd ON bd.block_slot = cbdl.block_slot
+				INNER JOIN blocks_consensus_deposits bd ON bd.block_slot = cbdl.block_slot
+					AND bd.deposit_type = cbdl.deposit_type
 					AND bd.block_index = cbdl.block_index
 			WHERE
 				cbdl.dashboard_id = $1`
@@ -278,10 +290,10 @@ func (d *DataAccessService) GetValidatorDashboardClDeposits(ctx context.Context,
 	}
 
 	params := []interface{}{filter}
-	filterFragment := ` ORDER BY bd.block_slot DESC, bd.block_index DESC`
+	filterFragment := ` ORDER BY bd.block_slot DESC, bd.deposit_type DESC, bd.block_index DESC`
 	if currentCursor.IsValid() {
-		filterFragment = ` AND (bd.block_slot < $2 or (bd.block_slot = $2 and bd.block_index < $3)) ` + filterFragment
-		params = append(params, currentCursor.Slot, currentCursor.SlotIndex)
+		filterFragment = ` AND (bd.block_slot, bd.deposit_type, bd.block_index) < ($2, $3, $4) ` + filterFragment
+		params = append(params, currentCursor.Slot, currentCursor.DepositType, currentCursor.SlotIndex)
 	}
 
 	if currentDirection == enums.ASC && !currentCursor.IsReverse() || currentDirection == enums.DESC && currentCursor.IsReverse() {
@@ -310,17 +322,33 @@ func (d *DataAccessService) GetValidatorDashboardClDeposits(ctx context.Context,
 		return nil, nil, fmt.Errorf("failed to recover indices after query: %w", err)
 	}
 
+	validatorMapping, err := d.services.GetCurrentValidatorMapping()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	responseData := make([]t.VDBConsensusDepositsTableRow, len(data))
 	for i, row := range data {
 		responseData[i] = t.VDBConsensusDepositsTableRow{
 			PublicKey:            t.PubKey(pubkeys[i]),
 			Index:                indices[i],
-			Epoch:                utils.EpochOfSlot(uint64(row.Slot)),
+			Epoch:                d.chain.EpochOfSlot(uint64(row.Slot)),
 			Slot:                 uint64(row.Slot),
 			WithdrawalCredential: t.Hash(hexutil.Encode(row.WithdrawalCredential)),
 			Amount:               utils.GWeiToWei(big.NewInt(row.Amount)),
 			Signature:            t.Hash(hexutil.Encode(row.Signature)),
 		}
+		switch row.DepositType {
+		case clDepositTypeDeposit:
+			responseData[i].Type = "deposit"
+		case clDepositTypeDepositRequest:
+			responseData[i].Type = "deposit_request"
+		case clDepositTypeConsolidation:
+			responseData[i].Type = "consolidation"
+			if sourceIndex, ok := validatorMapping.ValidatorIndices[hexutil.Encode(row.SourcePublicKey)]; ok {
+				responseData[i].ConsolidatedFrom = &sourceIndex
+			}
+		}
 		if row.GroupId.Valid {
 			if dashboardId.AggregateGroups {
 				responseData[i].GroupId = t.DefaultGroupId
@@ -424,7 +452,7 @@ func (d *DataAccessService) GetValidatorDashboardTotalClDeposits(ctx context.Con
 	if dashboardId.Validators != nil {
 		query += `
 			FROM
-				blocks_deposits
+				blocks_consensus_deposits
 			WHERE
 				publickey = ANY ($1)`
 		filter = byteaArray
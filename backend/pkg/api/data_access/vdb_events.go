@@ -0,0 +1,135 @@
+package dataaccess
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+)
+
+// SubscribeToValidatorDashboardSlotDuties streams the duty results of the dashboard validators for every new head slot.
+// The returned channel is closed once ctx is done.
+func (d *DataAccessService) SubscribeToValidatorDashboardSlotDuties(ctx context.Context, dashboardId t.VDBId) (<-chan t.VDBSlotDutiesEvent, error) {
+	validatorGroups, err := d.getDashboardValidatorGroups(ctx, dashboardId)
+	if err != nil {
+		return nil, err
+	}
+
+	slotDuties := d.services.SubscribeToSlotDuties(ctx)
+	result := make(chan t.VDBSlotDutiesEvent)
+	go func() {
+		defer close(result)
+		lastEpoch := uint64(0)
+		for slotEvent := range slotDuties {
+			// validators can be added or removed at any time, reload them once per epoch
+			if lastEpoch != 0 && slotEvent.Epoch > lastEpoch {
+				groups, err := d.getDashboardValidatorGroups(ctx, dashboardId)
+				if err != nil {
+					if ctx.Err() == nil {
+						log.Error(err, "error reloading dashboard validators for slot duties stream", 0, log.Fields{"dashboard": dashboardId.Id})
+					}
+				} else {
+					validatorGroups = groups
+				}
+			}
+			lastEpoch = slotEvent.Epoch
+
+			event := filterSlotDuties(slotEvent, validatorGroups)
+			if len(event.Duties) == 0 {
+				continue
+			}
+			select {
+			case result <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return result, nil
+}
+
+// getDashboardValidatorGroups maps the dashboard validators to their group, guest and aggregated dashboards only have the default group
+func (d *DataAccessService) getDashboardValidatorGroups(ctx context.Context, dashboardId t.VDBId) (map[t.VDBValidator]uint64, error) {
+	if len(dashboardId.Validators) > 0 || dashboardId.AggregateGroups {
+		validators, err := d.getDashboardValidators(ctx, dashboardId, nil)
+		if err != nil {
+			return nil, err
+		}
+		validatorGroups := make(map[t.VDBValidator]uint64, len(validators))
+		for _, validator := range validators {
+			validatorGroups[validator] = t.DefaultGroupId
+		}
+		return validatorGroups, nil
+	}
+
+	var queryResult []struct {
+		ValidatorIndex t.VDBValidator `db:"validator_index"`
+		GroupId        uint64         `db:"group_id"`
+	}
+	query, args, err := goqu.Dialect("postgres").
+		Select("validator_index", "group_id").
+		From("users_val_dashboards_validators").
+		Where(goqu.L("dashboard_id = ?", dashboardId.Id)).
+		Prepared(true).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("error preparing query: %w", err)
+	}
+	err = d.alloyReader.SelectContext(ctx, &queryResult, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving dashboard validators: %w", err)
+	}
+
+	validatorGroups := make(map[t.VDBValidator]uint64, len(queryResult))
+	for _, row := range queryResult {
+		validatorGroups[row.ValidatorIndex] = row.GroupId
+	}
+	return validatorGroups, nil
+}
+
+// filterSlotDuties returns the duties of the slot that belong to the dashboard validators
+func filterSlotDuties(slotEvent *types.SlotDutiesEvent, validatorGroups map[t.VDBValidator]uint64) t.VDBSlotDutiesEvent {
+	event := t.VDBSlotDutiesEvent{
+		Slot:   slotEvent.Slot,
+		Epoch:  slotEvent.Epoch,
+		Duties: []t.VDBSlotDutyResult{},
+	}
+	addDuty := func(validator uint64, duty string, success bool, dutySlot uint64) {
+		groupId, ok := validatorGroups[t.VDBValidator(validator)]
+		if !ok {
+			return
+		}
+		status := "success"
+		if !success {
+			status = "failed"
+		}
+		event.Duties = append(event.Duties, t.VDBSlotDutyResult{
+			Validator: validator,
+			GroupId:   groupId,
+			Duty:      duty,
+			Status:    status,
+			DutySlot:  dutySlot,
+		})
+	}
+
+	addDuty(slotEvent.Proposer, "proposal", !slotEvent.ProposalMissed, slotEvent.Slot)
+	for attestedSlot, validators := range slotEvent.Attestations {
+		for _, validator := range validators {
+			addDuty(validator, "attestation", true, attestedSlot)
+		}
+	}
+	for attestedSlot, validators := range slotEvent.MissedAttestations {
+		for _, validator := range validators {
+			addDuty(validator, "attestation", false, attestedSlot)
+		}
+	}
+	for _, validator := range slotEvent.SyncParticipated {
+		addDuty(validator, "sync", true, slotEvent.Slot)
+	}
+	for _, validator := range slotEvent.SyncMissed {
+		addDuty(validator, "sync", false, slotEvent.Slot)
+	}
+	return event
+}
@@ -17,6 +17,16 @@ import (
 
 //////////////////// 		Helper functions (must be used by more than one VDB endpoint!)
 
+// Per validator income expressions, they are summed up by the queries and scaled by the operator share if protocol modes are enabled
+const (
+	// clRewardsExpr is the cl income of a validator row in the rolling tables (aliased as r), in gwei
+	clRewardsExpr = `COALESCE(finalizeAggregation(r.balance_end), 0) + COALESCE(r.withdrawals_amount, 0) - COALESCE(r.deposits_amount, 0) - COALESCE(finalizeAggregation(r.balance_start), 0)`
+	// epochClRewardsExpr is the cl income of a validator row in validator_dashboard_data_epoch (aliased as e), in gwei
+	epochClRewardsExpr = `COALESCE(e.attestations_reward, 0) + COALESCE(e.blocks_cl_reward, 0) + COALESCE(e.sync_reward, 0)`
+	// elRewardsExpr is the el income of a proposed block (aliased as b, with the execution payload ep and the relay block rb), in wei
+	elRewardsExpr = `COALESCE(rb.value, ep.fee_recipient_reward * 1e18, 0)`
+)
+
 func (d DataAccessService) getDashboardValidators(ctx context.Context, dashboardId t.VDBId, groupIds []uint64) ([]t.VDBValidator, error) {
 	if len(dashboardId.Validators) == 0 {
 		ds := goqu.Dialect("postgres").
@@ -88,7 +98,7 @@ func (d *DataAccessService) getWithdrawableCountFromCursor(validatorindex t.VDBV
 	// the validators' balance will not be checked here as this is only a rough estimation
 	// checking the balance for hundreds of thousands of validators is too expensive
 
-	stats := cache.LatestStats.Get()
+	stats := cache.LatestStats.OfChain(d.chainId()).Get()
 	if stats == nil || stats.ActiveValidatorCount == nil || stats.TotalValidatorCount == nil {
 		return 0, errors.New("stats not available")
 	}
@@ -121,8 +131,8 @@ func (d *DataAccessService) getWithdrawableCountFromCursor(validatorindex t.VDBV
 
 // GetTimeToNextWithdrawal calculates the time it takes for the validators next withdrawal to be processed.
 func (d *DataAccessService) getTimeToNextWithdrawal(distance uint64) time.Time {
-	minTimeToWithdrawal := time.Now().Add(time.Second * time.Duration((distance/utils.Config.Chain.ClConfig.MaxValidatorsPerWithdrawalSweep)*utils.Config.Chain.ClConfig.SecondsPerSlot))
-	timeToWithdrawal := time.Now().Add(time.Second * time.Duration((float64(distance)/float64(utils.Config.Chain.ClConfig.MaxWithdrawalsPerPayload))*float64(utils.Config.Chain.ClConfig.SecondsPerSlot)))
+	minTimeToWithdrawal := time.Now().Add(time.Second * time.Duration((distance/d.chain.ClConfig.MaxValidatorsPerWithdrawalSweep)*d.chain.ClConfig.SecondsPerSlot))
+	timeToWithdrawal := time.Now().Add(time.Second * time.Duration((float64(distance)/float64(d.chain.ClConfig.MaxWithdrawalsPerPayload))*float64(d.chain.ClConfig.SecondsPerSlot)))
 
 	if timeToWithdrawal.Before(minTimeToWithdrawal) {
 		return minTimeToWithdrawal
@@ -0,0 +1,29 @@
+package dataaccess
+
+import (
+	"context"
+
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/reports"
+)
+
+func (d *DataAccessService) GetValidatorDashboardIncomeReport(ctx context.Context, dashboardId t.VDBId, groupId int64, currency enums.Currency, period reports.Period) (*reports.IncomeReport, error) {
+	source := reports.Source{
+		Name:       "Validator Dashboard",
+		GroupId:    groupId,
+		Validators: dashboardId.Validators,
+	}
+	if dashboardId.Validators == nil {
+		name, err := d.GetValidatorDashboardName(ctx, dashboardId.Id)
+		if err != nil {
+			return nil, err
+		}
+		source.Name = name
+		source.DashboardId = uint64(dashboardId.Id)
+		if dashboardId.AggregateGroups {
+			source.GroupId = reports.AllGroups
+		}
+	}
+	return reports.NewService(d.clickhouseReader, d.readerDb).GetIncomeReport(ctx, source, currency, period)
+}
@@ -31,11 +31,19 @@ func (d *DataAccessService) GetValidatorDashboardUser(ctx context.Context, dashb
 	err := d.alloyReader.GetContext(ctx, result, `
 		SELECT
 			id,
-			user_id
+			user_id,
+			organization_id,
+			network
 		FROM users_val_dashboards
 		WHERE id = $1
 	`, dashboardId)
 	if errors.Is(err, sql.ErrNoRows) {
+		// dashboards are stored in the alloy database of the network they were created for
+		for _, network := range d.additionalNetworks() {
+			if result, err := network.GetValidatorDashboardUser(ctx, dashboardId); !errors.Is(err, ErrNotFound) {
+				return result, err
+			}
+		}
 		return nil, fmt.Errorf("%w: dashboard with id %v not found", ErrNotFound, dashboardId)
 	}
 	return result, err
@@ -52,6 +60,11 @@ func (d *DataAccessService) GetValidatorDashboardIdByPublicId(ctx context.Contex
 		WHERE uvds.public_id = $1
 	`, publicDashboardId)
 	if errors.Is(err, sql.ErrNoRows) {
+		for _, network := range d.additionalNetworks() {
+			if result, err := network.GetValidatorDashboardIdByPublicId(ctx, publicDashboardId); !errors.Is(err, ErrNotFound) {
+				return result, err
+			}
+		}
 		return nil, fmt.Errorf("%w: public id %v not found", ErrNotFound, publicDashboardId)
 	}
 	return &result, err
@@ -194,7 +207,8 @@ func (d *DataAccessService) GetValidatorsFromSlices(ctx context.Context, indices
 	return result, nil
 }
 
-func (d *DataAccessService) CreateValidatorDashboard(ctx context.Context, userId uint64, name string, network uint64) (*t.VDBPostReturnData, error) {
+// CreateValidatorDashboard creates a dashboard for the user, if organizationId is set the dashboard is owned by that organization
+func (d *DataAccessService) CreateValidatorDashboard(ctx context.Context, userId uint64, name string, network uint64, organizationId *uint64) (*t.VDBPostReturnData, error) {
 	result := &t.VDBPostReturnData{}
 
 	tx, err := d.alloyWriter.BeginTxx(ctx, nil)
@@ -205,10 +219,10 @@ func (d *DataAccessService) CreateValidatorDashboard(ctx context.Context, userId
 
 	// Create validator dashboard for user
 	err = tx.GetContext(ctx, result, `
-		INSERT INTO users_val_dashboards (user_id, network, name)
-			VALUES ($1, $2, $3)
-		RETURNING id, user_id, name, network, (EXTRACT(epoch FROM created_at))::BIGINT as created_at
-	`, userId, network, name)
+		INSERT INTO users_val_dashboards (user_id, network, name, organization_id)
+			VALUES ($1, $2, $3, $4)
+		RETURNING id, user_id, name, network, (EXTRACT(epoch FROM created_at))::BIGINT as created_at, organization_id
+	`, userId, network, name, organizationId)
 	if err != nil {
 		return nil, err
 	}
@@ -284,7 +298,12 @@ func (d *DataAccessService) UpdateValidatorDashboardName(ctx context.Context, da
 func (d *DataAccessService) GetValidatorDashboardOverview(ctx context.Context, dashboardId t.VDBId, protocolModes t.VDBProtocolModes) (*t.VDBOverviewData, error) {
 	data := t.VDBOverviewData{}
 	eg := errgroup.Group{}
-	var err error
+
+	// Only the operator share of the rewards is reported for validators of enabled protocol modes
+	shares, err := d.getOperatorShares(ctx, dashboardId, protocolModes)
+	if err != nil {
+		return nil, err
+	}
 
 	// Network
 	if dashboardId.Validators == nil {
@@ -297,7 +316,7 @@ func (d *DataAccessService) GetValidatorDashboardOverview(ctx context.Context, d
 			return d.alloyReader.GetContext(ctx, &data.Network, query, dashboardId.Id)
 		})
 	} else { // load the chain id from the config in case of public dashboards
-		data.Network = utils.Config.Chain.ClConfig.DepositChainID
+		data.Network = d.chain.ClConfig.DepositChainID
 	}
 
 	// Groups
@@ -330,6 +349,13 @@ func (d *DataAccessService) GetValidatorDashboardOverview(ctx context.Context, d
 		})
 	}
 
+	// Configured operator shares
+	eg.Go(func() error {
+		var err error
+		data.OperatorShares, err = d.getValidatorDashboardOperatorShares(ctx, dashboardId)
+		return err
+	})
+
 	// Validator status and balance
 	eg.Go(func() error {
 		validatorMapping, err := d.services.GetCurrentValidatorMapping()
@@ -417,7 +443,7 @@ func (d *DataAccessService) GetValidatorDashboardOverview(ctx context.Context, d
 			effectiveBalance := utils.GWeiToWei(big.NewInt(int64(metadata.EffectiveBalance)))
 
 			if rpValidator, ok := rpValidators[validator]; ok {
-				if protocolModes.RocketPool {
+				if protocolModes.IsEnabled(enums.StakingProtocols.RocketPool) {
 					// Calculate the balance of the operator
 					fullDeposit := rpValidator.UserDepositBalance.Add(rpValidator.NodeDepositBalance)
 					operatorShare := rpValidator.NodeDepositBalance.Div(fullDeposit)
@@ -457,7 +483,7 @@ func (d *DataAccessService) GetValidatorDashboardOverview(ctx context.Context, d
 	retrieveRewardsAndEfficiency := func(table string, hours int, rewards *t.ClElValue[decimal.Decimal], apr *t.ClElValue[float64], efficiency *float64) {
 		// Rewards + APR
 		eg.Go(func() error {
-			(*rewards).El, (*apr).El, (*rewards).Cl, (*apr).Cl, err = d.internal_getElClAPR(ctx, dashboardId, -1, hours)
+			(*rewards).El, (*apr).El, (*rewards).Cl, (*apr).Cl, err = d.internal_getElClAPR(ctx, dashboardId, -1, hours, shares)
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,42 @@
+package dataaccess
+
+import (
+	"context"
+
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+)
+
+func (d *DataAccessService) GetValidatorDashboardPoolOperations(ctx context.Context, dashboardId t.VDBId) ([]t.VDBPoolOperationsTableRow, error) {
+	validatorGroups, err := d.getDashboardValidatorGroups(ctx, dashboardId)
+	if err != nil {
+		return nil, err
+	}
+	validators := make([]t.VDBValidator, 0, len(validatorGroups))
+	for validator := range validatorGroups {
+		validators = append(validators, validator)
+	}
+
+	operations, err := d.getPoolOperations(ctx, validators)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]t.VDBPoolOperationsTableRow, 0, len(operations))
+	for _, operation := range operations {
+		data := getValidatorPoolOperation(operation)
+		result = append(result, t.VDBPoolOperationsTableRow{
+			Index:              data.Index,
+			GroupId:            validatorGroups[operation.ValidatorIndex],
+			Type:               data.Type,
+			Status:             data.Status,
+			Signature:          data.Signature,
+			ExitEpoch:          data.ExitEpoch,
+			ToExecutionAddress: data.ToExecutionAddress,
+			FirstSeenTs:        data.FirstSeenTs,
+			FirstSeenSlot:      data.FirstSeenSlot,
+			LastSeenSlot:       data.LastSeenSlot,
+			IncludedSlot:       data.IncludedSlot,
+			DroppedSlot:        data.DroppedSlot,
+		})
+	}
+	return result, nil
+}
@@ -23,12 +23,8 @@ import (
 )
 
 func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, dashboardId t.VDBId, cursor string, colSort t.Sort[enums.VDBRewardsColumn], search string, limit uint64, protocolModes t.VDBProtocolModes) ([]t.VDBRewardsTableRow, *t.Paging, error) {
-	// @DATA-ACCESS incorporate protocolModes
-	result := make([]t.VDBRewardsTableRow, 0)
 	var paging t.Paging
 
-	wg := errgroup.Group{}
-
 	// Initialize the cursor
 	var currentCursor t.RewardsCursor
 	var err error
@@ -39,6 +35,70 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 		}
 	}
 
+	latestFinalizedEpoch := cache.LatestFinalizedEpoch.OfChain(d.chainId()).Get()
+	const epochLookBack = 9
+	startEpoch := uint64(0)
+	if latestFinalizedEpoch > epochLookBack {
+		startEpoch = latestFinalizedEpoch - epochLookBack
+	}
+
+	shares, err := d.getOperatorShares(ctx, dashboardId, protocolModes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := d.getValidatorDashboardRewardsRows(ctx, dashboardId, currentCursor, colSort, search, startEpoch, nil, shares)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(result) == 0 {
+		return result, &paging, nil
+	}
+
+	// Flag if above limit
+	moreDataFlag := len(result) > int(limit)
+	if !moreDataFlag && !currentCursor.IsValid() {
+		// No paging required
+		return result, &paging, nil
+	}
+
+	// Remove the last entries from data
+	if moreDataFlag {
+		if currentCursor.IsReverse() {
+			result = result[len(result)-int(limit):]
+		} else {
+			result = result[:limit]
+		}
+	}
+
+	p, err := utils.GetPagingFromData(result, currentCursor, moreDataFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
+	}
+
+	return result, p, nil
+}
+
+// GetValidatorDashboardRewardsRange returns the rewards rows of all epochs between startEpoch and endEpoch (inclusive) in ascending order.
+// Unlike GetValidatorDashboardRewards it is not limited to the latest epochs and is not paged, so callers should keep the range small.
+func (d *DataAccessService) GetValidatorDashboardRewardsRange(ctx context.Context, dashboardId t.VDBId, startEpoch, endEpoch uint64, protocolModes t.VDBProtocolModes) ([]t.VDBRewardsTableRow, error) {
+	shares, err := d.getOperatorShares(ctx, dashboardId, protocolModes)
+	if err != nil {
+		return nil, err
+	}
+
+	colSort := t.Sort[enums.VDBRewardsColumn]{Column: enums.VDBRewardsColumns.Epoch, Desc: false}
+	return d.getValidatorDashboardRewardsRows(ctx, dashboardId, t.RewardsCursor{}, colSort, "", startEpoch, &endEpoch, shares)
+}
+
+// getValidatorDashboardRewardsRows returns the unpaged rewards rows (including the "epoch total" rows) starting at startEpoch and,
+// if endEpoch is set, ending at endEpoch. The rewards are scaled by the passed operator shares.
+func (d *DataAccessService) getValidatorDashboardRewardsRows(ctx context.Context, dashboardId t.VDBId, currentCursor t.RewardsCursor, colSort t.Sort[enums.VDBRewardsColumn], search string, startEpoch uint64, endEpoch *uint64, shares operatorShares) ([]t.VDBRewardsTableRow, error) {
+	result := make([]t.VDBRewardsTableRow, 0)
+
+	wg := errgroup.Group{}
+	var err error
+
 	// Prepare the sorting
 	isReverseDirection := (colSort.Desc && !currentCursor.IsReverse()) || (!colSort.Desc && currentCursor.IsReverse())
 	sortSearchDirection := ">"
@@ -56,13 +116,13 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 			// Get the current validator state to convert pubkey to index
 			validatorMapping, err := d.services.GetCurrentValidatorMapping()
 			if err != nil {
-				return nil, nil, err
+				return nil, err
 			}
 			if index, ok := validatorMapping.ValidatorIndices[search]; ok {
 				indexSearch = int64(index)
 			} else {
 				// No validator index for pubkey found, return empty results
-				return result, &paging, nil
+				return result, nil
 			}
 		} else if number, err := strconv.ParseUint(search, 10, 64); err == nil {
 			indexSearch = int64(number)
@@ -70,13 +130,6 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 		}
 	}
 
-	latestFinalizedEpoch := cache.LatestFinalizedEpoch.Get()
-	const epochLookBack = 9
-	startEpoch := uint64(0)
-	if latestFinalizedEpoch > epochLookBack {
-		startEpoch = latestFinalizedEpoch - epochLookBack
-	}
-
 	groupIdSearchMap := make(map[uint64]bool, 0)
 
 	// ------------------------------------------------------------------------------------------------------------------
@@ -86,7 +139,7 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 		With("validators", goqu.L("(SELECT validator_index as validator_index, group_id FROM users_val_dashboards_validators WHERE dashboard_id = ?)", dashboardId.Id)).
 		Select(
 			goqu.L("e.epoch"),
-			goqu.L(`SUM(COALESCE(e.attestations_reward, 0) + COALESCE(e.blocks_cl_reward, 0) + COALESCE(e.sync_reward, 0)) AS cl_rewards`),
+			goqu.L(fmt.Sprintf("SUM(%s) AS cl_rewards", shares.clickhouseWeighted(epochClRewardsExpr, "e.validator_index"))),
 			goqu.L("SUM(COALESCE(e.attestations_scheduled, 0)) AS attestations_scheduled"),
 			goqu.L("SUM(COALESCE(e.attestations_observed, 0)) AS attestations_observed"),
 			goqu.L("SUM(COALESCE(e.blocks_scheduled, 0)) AS blocks_scheduled"),
@@ -95,12 +148,12 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 			goqu.L("SUM(COALESCE(e.sync_executed, 0)) AS sync_executed"),
 			goqu.L("SUM(CASE WHEN e.slashed THEN 1 ELSE 0 END) AS slashed_in_epoch"),
 			goqu.L("SUM(COALESCE(e.blocks_slashing_count, 0)) AS slashed_amount")).
-		Where(goqu.L("e.epoch_timestamp >= fromUnixTimestamp(?)", utils.EpochToTime(startEpoch).Unix()))
+		Where(goqu.L("e.epoch_timestamp >= fromUnixTimestamp(?)", d.chain.EpochToTime(startEpoch).Unix()))
 
 	elDs := goqu.Dialect("postgres").
 		Select(
 			goqu.L("b.epoch"),
-			goqu.L("SUM(COALESCE(rb.value, ep.fee_recipient_reward * 1e18, 0)) AS el_rewards")).
+			goqu.L(fmt.Sprintf("SUM(%s) AS el_rewards", shares.postgresWeighted(elRewardsExpr, "b.proposer")))).
 		From(goqu.L("users_val_dashboards_validators v")).
 		Where(goqu.L("b.epoch >= ?", startEpoch)).
 		LeftJoin(goqu.L("blocks b"), goqu.On(goqu.L("v.validator_index = b.proposer AND b.status = '1'"))).
@@ -116,6 +169,11 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 			goqu.On(goqu.L("rb.exec_block_hash = b.exec_block_hash")),
 		)
 
+	if endEpoch != nil {
+		rewardsDs = rewardsDs.Where(goqu.L("e.epoch_timestamp <= fromUnixTimestamp(?)", d.chain.EpochToTime(*endEpoch).Unix()))
+		elDs = elDs.Where(goqu.L("b.epoch <= ?", *endEpoch))
+	}
+
 	if dashboardId.Validators == nil {
 		rewardsDs = rewardsDs.
 			InnerJoin(goqu.L("validators v"), goqu.On(goqu.L("e.validator_index = v.validator_index"))).
@@ -126,22 +184,22 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 			if currentCursor.IsReverse() {
 				if currentCursor.GroupId == t.AllGroups {
 					// The cursor is on the total rewards so get the data for all groups excluding the cursor epoch
-					rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("e.epoch_timestamp %s fromUnixTimestamp(?)", sortSearchDirection), utils.EpochToTime(currentCursor.Epoch).Unix()))
+					rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("e.epoch_timestamp %s fromUnixTimestamp(?)", sortSearchDirection), d.chain.EpochToTime(currentCursor.Epoch).Unix()))
 					elDs = elDs.Where(goqu.L(fmt.Sprintf("b.epoch %s ?", sortSearchDirection), currentCursor.Epoch))
 				} else {
 					// The cursor is on a specific group, get the data for the whole epoch since we could need it for the total rewards
-					rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("e.epoch_timestamp %s= fromUnixTimestamp(?)", sortSearchDirection), utils.EpochToTime(currentCursor.Epoch).Unix()))
+					rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("e.epoch_timestamp %s= fromUnixTimestamp(?)", sortSearchDirection), d.chain.EpochToTime(currentCursor.Epoch).Unix()))
 					elDs = elDs.Where(goqu.L(fmt.Sprintf("b.epoch %s= ?", sortSearchDirection), currentCursor.Epoch))
 				}
 			} else {
 				if currentCursor.GroupId == t.AllGroups {
 					// The cursor is on the total rewards so get the data for all groups including the cursor epoch
-					rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("e.epoch_timestamp %s= fromUnixTimestamp(?)", sortSearchDirection), utils.EpochToTime(currentCursor.Epoch).Unix()))
+					rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("e.epoch_timestamp %s= fromUnixTimestamp(?)", sortSearchDirection), d.chain.EpochToTime(currentCursor.Epoch).Unix()))
 					elDs = elDs.Where(goqu.L(fmt.Sprintf("b.epoch %s= ?", sortSearchDirection), currentCursor.Epoch))
 				} else {
 					// The cursor is on a specific group so get the data for groups before/after it
 					rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("(e.epoch_timestamp %[1]s fromUnixTimestamp(?) OR (e.epoch_timestamp = fromUnixTimestamp(?) AND v.group_id %[1]s ?))", sortSearchDirection),
-						utils.EpochToTime(currentCursor.Epoch).Unix(), utils.EpochToTime(currentCursor.Epoch).Unix(), currentCursor.GroupId))
+						d.chain.EpochToTime(currentCursor.Epoch).Unix(), d.chain.EpochToTime(currentCursor.Epoch).Unix(), currentCursor.GroupId))
 					elDs = elDs.Where(goqu.L(fmt.Sprintf("(b.epoch %[1]s ? OR (b.epoch = ? AND v.group_id %[1]s ?))", sortSearchDirection),
 						currentCursor.Epoch, currentCursor.Epoch, currentCursor.GroupId))
 				}
@@ -152,7 +210,7 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 			if dashboardId.AggregateGroups {
 				if epochSearch == -1 && indexSearch == -1 {
 					// If we have a search term but no epoch or index search then we can return empty results
-					return result, &paging, nil
+					return result, nil
 				}
 
 				found := false
@@ -166,11 +224,11 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 							WHERE dashboard_id = $1 AND validator_index = $2)
 						`, dashboardId.Id, indexSearch)
 					if err != nil {
-						return nil, nil, err
+						return nil, err
 					}
 				}
 				if !found && epochSearch != -1 {
-					rewardsDs = rewardsDs.Where(goqu.L("e.epoch_timestamp = fromUnixTimestamp(?)", utils.EpochToTime(uint64(epochSearch)).Unix()))
+					rewardsDs = rewardsDs.Where(goqu.L("e.epoch_timestamp = fromUnixTimestamp(?)", d.chain.EpochToTime(uint64(epochSearch)).Unix()))
 					elDs = elDs.Where(goqu.L("b.epoch = ?", epochSearch))
 				}
 			} else {
@@ -192,13 +250,13 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 
 				groupIdQuery, groupIdArgs, err := groupIdDs.Prepared(true).ToSQL()
 				if err != nil {
-					return nil, nil, err
+					return nil, err
 				}
 
 				var groupIdSearch []uint64
 				err = d.readerDb.SelectContext(ctx, &groupIdSearch, groupIdQuery, groupIdArgs...)
 				if err != nil {
-					return nil, nil, err
+					return nil, err
 				}
 
 				// Convert to a map for an easy check later
@@ -209,11 +267,11 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 				if len(groupIdSearchMap) == 0 {
 					if epochSearch != -1 {
 						// If we have an epoch search but no group search then we can restrict the query to the epoch
-						rewardsDs = rewardsDs.Where(goqu.L("e.epoch_timestamp = fromUnixTimestamp(?)", utils.EpochToTime(uint64(epochSearch)).Unix()))
+						rewardsDs = rewardsDs.Where(goqu.L("e.epoch_timestamp = fromUnixTimestamp(?)", d.chain.EpochToTime(uint64(epochSearch)).Unix()))
 						elDs = elDs.Where(goqu.L("b.epoch = ?", epochSearch))
 					} else {
 						// No search for goup or epoch possible, return empty results
-						return result, &paging, nil
+						return result, nil
 					}
 				}
 			}
@@ -263,13 +321,13 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 			GroupBy(goqu.L("b.epoch"))
 
 		if currentCursor.IsValid() {
-			rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("e.epoch_timestamp %s fromUnixTimestamp(?)", sortSearchDirection), utils.EpochToTime(currentCursor.Epoch).Unix()))
+			rewardsDs = rewardsDs.Where(goqu.L(fmt.Sprintf("e.epoch_timestamp %s fromUnixTimestamp(?)", sortSearchDirection), d.chain.EpochToTime(currentCursor.Epoch).Unix()))
 			elDs = elDs.Where(goqu.L(fmt.Sprintf("b.epoch %s ?", sortSearchDirection), currentCursor.Epoch))
 		}
 		if search != "" {
 			if epochSearch == -1 && indexSearch == -1 {
 				// If we have a search term but no epoch or index search then we can return empty results
-				return result, &paging, nil
+				return result, nil
 			}
 
 			found := false
@@ -279,7 +337,7 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 				found = utils.ElementExists(dashboardId.Validators, t.VDBValidator(indexSearch))
 			}
 			if !found && epochSearch != -1 {
-				rewardsDs = rewardsDs.Where(goqu.L("e.epoch_timestamp = fromUnixTimestamp(?)", utils.EpochToTime(uint64(epochSearch)).Unix()))
+				rewardsDs = rewardsDs.Where(goqu.L("e.epoch_timestamp = fromUnixTimestamp(?)", d.chain.EpochToTime(uint64(epochSearch)).Unix()))
 				elDs = elDs.Where(goqu.L("b.epoch = ?", epochSearch))
 			}
 		}
@@ -353,7 +411,7 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 
 	err = wg.Wait()
 	if err != nil {
-		return nil, nil, fmt.Errorf("error retrieving validator dashboard rewards data: %w", err)
+		return nil, fmt.Errorf("error retrieving validator dashboard rewards data: %w", err)
 	}
 
 	// ------------------------------------------------------------------------------------------------------------------
@@ -492,32 +550,10 @@ func (d *DataAccessService) GetValidatorDashboardRewards(ctx context.Context, da
 		previousEpoch = int64(res.Epoch)
 	}
 
-	// Flag if above limit
-	moreDataFlag := len(result) > int(limit)
-	if !moreDataFlag && !currentCursor.IsValid() {
-		// No paging required
-		return result, &paging, nil
-	}
-
-	// Remove the last entries from data
-	if moreDataFlag {
-		if currentCursor.IsReverse() {
-			result = result[len(result)-int(limit):]
-		} else {
-			result = result[:limit]
-		}
-	}
-
-	p, err := utils.GetPagingFromData(result, currentCursor, moreDataFlag)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get paging: %w", err)
-	}
-
-	return result, p, nil
+	return result, nil
 }
 
 func (d *DataAccessService) GetValidatorDashboardGroupRewards(ctx context.Context, dashboardId t.VDBId, groupId int64, epoch uint64, protocolModes t.VDBProtocolModes) (*t.VDBGroupRewardsData, error) {
-	// @DATA-ACCESS incorporate protocolModes
 	ret := &t.VDBGroupRewardsData{}
 
 	wg := errgroup.Group{}
@@ -527,12 +563,18 @@ func (d *DataAccessService) GetValidatorDashboardGroupRewards(ctx context.Contex
 		groupId = t.AllGroups
 	}
 
+	shares, err := d.getOperatorShares(ctx, dashboardId, protocolModes)
+	if err != nil {
+		return nil, err
+	}
+
 	// ------------------------------------------------------------------------------------------------------------------
 	// Build the query that serves as base for both the main and EL rewards queries
 	rewardsDs := goqu.Dialect("postgres").
 		From(goqu.L("validator_dashboard_data_epoch e")).
 		With("validators", goqu.L("(SELECT validator_index as validator_index, group_id FROM users_val_dashboards_validators WHERE dashboard_id = ?)", dashboardId.Id)).
 		Select(
+			goqu.L("e.validator_index"),
 			goqu.L("COALESCE(e.attestations_source_reward, 0) AS attestations_source_reward"),
 			goqu.L("COALESCE(e.attestations_target_reward, 0) AS attestations_target_reward"),
 			goqu.L("COALESCE(e.attestations_head_reward, 0) AS attestations_head_reward"),
@@ -553,11 +595,11 @@ func (d *DataAccessService) GetValidatorDashboardGroupRewards(ctx context.Contex
 			goqu.L("COALESCE(e.blocks_cl_slasher_reward, 0) AS slasher_reward"),
 			goqu.L("COALESCE(e.blocks_cl_attestations_reward, 0) AS blocks_cl_attestations_reward"),
 			goqu.L("COALESCE(e.blocks_cl_sync_aggregate_reward, 0) AS blocks_cl_sync_aggregate_reward")).
-		Where(goqu.L("e.epoch_timestamp = fromUnixTimestamp(?)", utils.EpochToTime(epoch).Unix()))
+		Where(goqu.L("e.epoch_timestamp = fromUnixTimestamp(?)", d.chain.EpochToTime(epoch).Unix()))
 
 	elDs := goqu.Dialect("postgres").
 		Select(
-			goqu.L("COALESCE(SUM(COALESCE(rb.value, ep.fee_recipient_reward * 1e18, 0)), 0) AS blocks_el_reward")).
+			goqu.L(fmt.Sprintf("COALESCE(SUM(%s), 0) AS blocks_el_reward", shares.postgresWeighted(elRewardsExpr, "b.proposer")))).
 		From(goqu.L("users_val_dashboards_validators v")).
 		LeftJoin(goqu.L("blocks b"), goqu.On(goqu.L("v.validator_index = b.proposer AND b.status = '1'"))).
 		LeftJoin(goqu.L("execution_payloads ep"), goqu.On(goqu.L("ep.block_hash = b.exec_block_hash"))).
@@ -595,6 +637,8 @@ func (d *DataAccessService) GetValidatorDashboardGroupRewards(ctx context.Contex
 	// ------------------------------------------------------------------------------------------------------------------
 	// Build the main query and get the data
 	queryResult := []struct {
+		ValidatorIndex t.VDBValidator `db:"validator_index"`
+
 		AttestationSourceReward      decimal.Decimal `db:"attestations_source_reward"`
 		AttestationTargetReward      decimal.Decimal `db:"attestations_target_reward"`
 		AttestationHeadReward        decimal.Decimal `db:"attestations_head_reward"`
@@ -651,7 +695,7 @@ func (d *DataAccessService) GetValidatorDashboardGroupRewards(ctx context.Contex
 		return nil
 	})
 
-	err := wg.Wait()
+	err = wg.Wait()
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving validator dashboard group rewards data: %w", err)
 	}
@@ -661,42 +705,45 @@ func (d *DataAccessService) GetValidatorDashboardGroupRewards(ctx context.Contex
 	gWei := decimal.NewFromInt(1e9)
 
 	for _, entry := range queryResult {
-		ret.AttestationsHead.Income = ret.AttestationsHead.Income.Add(entry.AttestationHeadReward.Mul(gWei))
+		// only the operator share of the income is reported for validators of enabled protocol modes
+		scale := gWei.Mul(shares.get(entry.ValidatorIndex))
+
+		ret.AttestationsHead.Income = ret.AttestationsHead.Income.Add(entry.AttestationHeadReward.Mul(scale))
 		ret.AttestationsHead.StatusCount.Success += uint64(entry.AttestationsHeadExecuted)
 		ret.AttestationsHead.StatusCount.Failed += uint64(entry.AttestationsScheduled) - uint64(entry.AttestationsHeadExecuted)
 
-		ret.AttestationsSource.Income = ret.AttestationsSource.Income.Add(entry.AttestationSourceReward.Mul(gWei))
+		ret.AttestationsSource.Income = ret.AttestationsSource.Income.Add(entry.AttestationSourceReward.Mul(scale))
 		ret.AttestationsSource.StatusCount.Success += uint64(entry.AttestationsSourceExecuted)
 		ret.AttestationsSource.StatusCount.Failed += uint64(entry.AttestationsScheduled) - uint64(entry.AttestationsSourceExecuted)
 
-		ret.AttestationsTarget.Income = ret.AttestationsTarget.Income.Add(entry.AttestationTargetReward.Mul(gWei))
+		ret.AttestationsTarget.Income = ret.AttestationsTarget.Income.Add(entry.AttestationTargetReward.Mul(scale))
 		ret.AttestationsTarget.StatusCount.Success += uint64(entry.AttestationsTargetExecuted)
 		ret.AttestationsTarget.StatusCount.Failed += uint64(entry.AttestationsScheduled) - uint64(entry.AttestationsTargetExecuted)
 
-		ret.Inactivity.Income = ret.Inactivity.Income.Add(entry.AttestationInactivitytReward.Mul(gWei))
+		ret.Inactivity.Income = ret.Inactivity.Income.Add(entry.AttestationInactivitytReward.Mul(scale))
 		if entry.AttestationInactivitytReward.LessThan(decimal.Zero) {
 			ret.Inactivity.StatusCount.Failed++
 		} else {
 			ret.Inactivity.StatusCount.Success++
 		}
 
-		ret.Proposal.Income = ret.Proposal.Income.Add(entry.BlocksClReward.Mul(gWei))
+		ret.Proposal.Income = ret.Proposal.Income.Add(entry.BlocksClReward.Mul(scale))
 		ret.Proposal.StatusCount.Success += uint64(entry.BlocksProposed)
 		ret.Proposal.StatusCount.Failed += uint64(entry.BlocksScheduled) - uint64(entry.BlocksProposed)
 
-		ret.Sync.Income = ret.Sync.Income.Add(entry.SyncReward.Mul(gWei))
+		ret.Sync.Income = ret.Sync.Income.Add(entry.SyncReward.Mul(scale))
 		ret.Sync.StatusCount.Success += uint64(entry.SyncExecuted)
 		ret.Sync.StatusCount.Failed += uint64(entry.SyncScheduled) - uint64(entry.SyncExecuted)
 
-		ret.Slashing.Income = ret.Slashing.Income.Add(entry.SlasherRewards.Mul(gWei))
+		ret.Slashing.Income = ret.Slashing.Income.Add(entry.SlasherRewards.Mul(scale))
 		ret.Slashing.StatusCount.Success += uint64(entry.SlashedAmount)
 		if entry.SlashedInEpoch {
 			ret.Slashing.StatusCount.Failed++
 		}
 
-		ret.ProposalClAttIncReward = ret.ProposalClAttIncReward.Add(entry.BlocksClAttestationsReward.Mul(gWei))
-		ret.ProposalClSyncIncReward = ret.ProposalClSyncIncReward.Add(entry.BlockClSyncAggregateReward.Mul(gWei))
-		ret.ProposalClSlashingIncReward = ret.ProposalClSlashingIncReward.Add(entry.SlasherRewards.Mul(gWei))
+		ret.ProposalClAttIncReward = ret.ProposalClAttIncReward.Add(entry.BlocksClAttestationsReward.Mul(scale))
+		ret.ProposalClSyncIncReward = ret.ProposalClSyncIncReward.Add(entry.BlockClSyncAggregateReward.Mul(scale))
+		ret.ProposalClSlashingIncReward = ret.ProposalClSlashingIncReward.Add(entry.SlasherRewards.Mul(scale))
 	}
 
 	ret.Proposal.Income = ret.Proposal.Income.Add(elRewards)
@@ -706,14 +753,18 @@ func (d *DataAccessService) GetValidatorDashboardGroupRewards(ctx context.Contex
 }
 
 func (d *DataAccessService) GetValidatorDashboardRewardsChart(ctx context.Context, dashboardId t.VDBId, protocolModes t.VDBProtocolModes) (*t.ChartData[int, decimal.Decimal], error) {
-	// @DATA-ACCESS incorporate protocolModes
 	// bar chart for the CL and EL rewards for each group for each epoch.
 	// NO series for all groups combined except if AggregateGroups is true.
 	// series id is group id, series property is 'cl' or 'el'
 
 	wg := errgroup.Group{}
 
-	latestFinalizedEpoch := cache.LatestFinalizedEpoch.Get()
+	shares, err := d.getOperatorShares(ctx, dashboardId, protocolModes)
+	if err != nil {
+		return nil, err
+	}
+
+	latestFinalizedEpoch := cache.LatestFinalizedEpoch.OfChain(d.chainId()).Get()
 	const epochLookBack = 224
 	startEpoch := uint64(0)
 	if latestFinalizedEpoch > epochLookBack {
@@ -725,15 +776,15 @@ func (d *DataAccessService) GetValidatorDashboardRewardsChart(ctx context.Contex
 	rewardsDs := goqu.Dialect("postgres").
 		Select(
 			goqu.L("e.epoch"),
-			goqu.L(`SUM(COALESCE(e.attestations_reward, 0) + COALESCE(e.blocks_cl_reward, 0) + COALESCE(e.sync_reward, 0)) AS cl_rewards`)).
+			goqu.L(fmt.Sprintf("SUM(%s) AS cl_rewards", shares.clickhouseWeighted(epochClRewardsExpr, "e.validator_index")))).
 		From(goqu.L("validator_dashboard_data_epoch e")).
 		With("validators", goqu.L("(SELECT validator_index as validator_index, group_id FROM users_val_dashboards_validators WHERE dashboard_id = ?)", dashboardId.Id)).
-		Where(goqu.L("e.epoch_timestamp >= fromUnixTimestamp(?)", utils.EpochToTime(startEpoch).Unix()))
+		Where(goqu.L("e.epoch_timestamp >= fromUnixTimestamp(?)", d.chain.EpochToTime(startEpoch).Unix()))
 
 	elDs := goqu.Dialect("postgres").
 		Select(
 			goqu.L("b.epoch"),
-			goqu.L("SUM(COALESCE(rb.value, ep.fee_recipient_reward * 1e18, 0)) AS el_rewards")).
+			goqu.L(fmt.Sprintf("SUM(%s) AS el_rewards", shares.postgresWeighted(elRewardsExpr, "b.proposer")))).
 		From(goqu.L("users_val_dashboards_validators v")).
 		LeftJoin(goqu.L("blocks b"), goqu.On(goqu.L("v.validator_index = b.proposer AND b.status = '1'"))).
 		LeftJoin(goqu.L("execution_payloads ep"), goqu.On(goqu.L("ep.block_hash = b.exec_block_hash"))).
@@ -839,7 +890,7 @@ func (d *DataAccessService) GetValidatorDashboardRewardsChart(ctx context.Contex
 		return nil
 	})
 
-	err := wg.Wait()
+	err = wg.Wait()
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving validator dashboard rewards chart data: %w", err)
 	}
@@ -975,7 +1026,7 @@ func (d *DataAccessService) GetValidatorDashboardDuties(ctx context.Context, das
 			goqu.L("COALESCE(e.blocks_cl_attestations_reward, 0) AS blocks_cl_attestations_reward"),
 			goqu.L("COALESCE(e.blocks_cl_sync_aggregate_reward, 0) AS blocks_cl_sync_aggregate_reward")).
 		From(goqu.L("validator_dashboard_data_epoch e")).
-		Where(goqu.L("e.epoch_timestamp = fromUnixTimestamp(?)", utils.EpochToTime(epoch).Unix())).
+		Where(goqu.L("e.epoch_timestamp = fromUnixTimestamp(?)", d.chain.EpochToTime(epoch).Unix())).
 		Where(goqu.L(`
 			(COALESCE(e.attestations_scheduled, 0) +
 			COALESCE(e.sync_scheduled,0) +
@@ -24,9 +24,9 @@ func (d *DataAccessService) GetValidatorDashboardSlotViz(ctx context.Context, da
 	}
 
 	// Get min/max slot/epoch
-	headEpoch := utils.EpochOfSlot(dutiesInfo.LatestSlot)
+	headEpoch := d.chain.EpochOfSlot(dutiesInfo.LatestSlot)
 
-	slotsPerEpoch := utils.Config.Chain.ClConfig.SlotsPerEpoch
+	slotsPerEpoch := d.chain.ClConfig.SlotsPerEpoch
 
 	minEpoch := uint64(0)
 	if headEpoch > 2 {
@@ -189,7 +189,7 @@ func (d *DataAccessService) GetValidatorDashboardSlotViz(ctx context.Context, da
 	// Hydrate the attestation data
 	for _, validator := range validatorsArray {
 		for slot, duty := range dutiesInfo.EpochAttestationDuties[validator] {
-			epoch := utils.EpochOfSlot(uint64(slot))
+			epoch := d.chain.EpochOfSlot(uint64(slot))
 			epochIdx, ok := epochToIndexMap[epoch]
 			if !ok {
 				continue
@@ -0,0 +1,283 @@
+package dataaccess
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
+	t "github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+)
+
+// stakingProtocol is implemented by every staking protocol that can be selected as protocol mode on a validator dashboard
+type stakingProtocol interface {
+	// getOperatorShares returns the share of the income that goes to the node operator for those of the passed validators that are run with the protocol.
+	// configuredShare is the operator share that is configured on the dashboard for the protocol, it is invalid if none is configured.
+	getOperatorShares(ctx context.Context, d *DataAccessService, validators []t.VDBValidator, configuredShare decimal.NullDecimal) (operatorShares, error)
+	// isConfigurable returns whether the operator share has to be configured on the dashboard
+	isConfigurable() bool
+}
+
+var stakingProtocols = map[enums.StakingProtocol]stakingProtocol{
+	enums.StakingProtocols.RocketPool: rocketPoolProtocol{},
+	enums.StakingProtocols.LidoCsm:    taggedStakingProtocol{name: "lido_csm", tagCondition: "vt.tag = 'lido_csm'"},
+	enums.StakingProtocols.Ssv:        taggedStakingProtocol{name: "ssv", tagCondition: "vt.tag = 'ssv'"},
+	enums.StakingProtocols.Obol:       taggedStakingProtocol{name: "obol", tagCondition: "vt.tag ILIKE 'pool:obol%'"},
+}
+
+// rocketPoolProtocol calculates the operator share from the minipool deposits and the node fee
+type rocketPoolProtocol struct{}
+
+func (rocketPoolProtocol) isConfigurable() bool {
+	return false
+}
+
+func (rocketPoolProtocol) getOperatorShares(ctx context.Context, d *DataAccessService, validators []t.VDBValidator, configuredShare decimal.NullDecimal) (operatorShares, error) {
+	var queryResult []struct {
+		ValidatorIndex     t.VDBValidator  `db:"validatorindex"`
+		NodeFee            float64         `db:"node_fee"`
+		NodeDepositBalance decimal.Decimal `db:"node_deposit_balance"`
+		UserDepositBalance decimal.Decimal `db:"user_deposit_balance"`
+	}
+
+	query, args, err := goqu.Dialect("postgres").
+		Select(
+			goqu.L("v.validatorindex"),
+			goqu.L("rplm.node_fee"),
+			goqu.L("rplm.node_deposit_balance"),
+			goqu.L("rplm.user_deposit_balance")).
+		From(goqu.L("rocketpool_minipools AS rplm")).
+		InnerJoin(goqu.L("validators AS v"), goqu.On(goqu.L("rplm.pubkey = v.pubkey"))).
+		Where(goqu.L("node_deposit_balance IS NOT NULL")).
+		Where(goqu.L("user_deposit_balance IS NOT NULL")).
+		Where(goqu.L("v.validatorindex = ANY(?)", pq.Array(validators))).
+		Prepared(true).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("error preparing query: %w", err)
+	}
+
+	err = d.alloyReader.SelectContext(ctx, &queryResult, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving rocketpool minipools: %w", err)
+	}
+
+	shares := make(operatorShares, len(queryResult))
+	for _, row := range queryResult {
+		fullDeposit := row.NodeDepositBalance.Add(row.UserDepositBalance)
+		if fullDeposit.IsZero() {
+			continue
+		}
+		// the operator gets the rewards of its own deposit plus the commission on the rewards of the user deposit
+		nodeShare := row.NodeDepositBalance.Div(fullDeposit)
+		userShare := row.UserDepositBalance.Div(fullDeposit)
+		shares[row.ValidatorIndex] = nodeShare.Add(userShare.Mul(decimal.NewFromFloat(row.NodeFee)))
+	}
+	return shares, nil
+}
+
+// taggedStakingProtocol applies the operator share that is configured on the dashboard to the validators that the protocol
+// exporters tagged in validator_tags. The share of a single operator depends on its cluster (ssv, obol) or bond (lido csm)
+// which is not known to us, so validators keep their full income if no share is configured.
+type taggedStakingProtocol struct {
+	name         string
+	tagCondition string
+}
+
+func (taggedStakingProtocol) isConfigurable() bool {
+	return true
+}
+
+func (p taggedStakingProtocol) getOperatorShares(ctx context.Context, d *DataAccessService, validators []t.VDBValidator, configuredShare decimal.NullDecimal) (operatorShares, error) {
+	if !configuredShare.Valid {
+		return nil, nil
+	}
+
+	var queryResult []t.VDBValidator
+	query, args, err := goqu.Dialect("postgres").
+		Select(goqu.L("v.validatorindex")).
+		Distinct().
+		From(goqu.L("validator_tags AS vt")).
+		InnerJoin(goqu.L("validators AS v"), goqu.On(goqu.L("vt.publickey = v.pubkey"))).
+		Where(goqu.L(p.tagCondition)).
+		Where(goqu.L("v.validatorindex = ANY(?)", pq.Array(validators))).
+		Prepared(true).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("error preparing query: %w", err)
+	}
+
+	err = d.alloyReader.SelectContext(ctx, &queryResult, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s validators: %w", p.name, err)
+	}
+
+	shares := make(operatorShares, len(queryResult))
+	for _, validator := range queryResult {
+		shares[validator] = configuredShare.Decimal
+	}
+	return shares, nil
+}
+
+// operatorShares maps validators to the share of their income that goes to the node operator.
+// Validators that are not part of the map keep their full income.
+type operatorShares map[t.VDBValidator]decimal.Decimal
+
+// getOperatorShares returns the operator shares of the dashboard validators for all protocol modes that are enabled.
+// If a validator is run with several of the enabled protocols the first protocol in the list of modes wins.
+func (d *DataAccessService) getOperatorShares(ctx context.Context, dashboardId t.VDBId, protocolModes t.VDBProtocolModes) (operatorShares, error) {
+	if len(protocolModes.Protocols) == 0 {
+		return nil, nil
+	}
+
+	validators, err := d.getDashboardValidators(ctx, dashboardId, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving validators from dashboard id: %w", err)
+	}
+	if len(validators) == 0 {
+		return nil, nil
+	}
+
+	configuredShares, err := d.getConfiguredOperatorShares(ctx, dashboardId)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make(operatorShares)
+	for _, protocolMode := range protocolModes.Protocols {
+		protocol, ok := stakingProtocols[protocolMode]
+		if !ok {
+			return nil, fmt.Errorf("unsupported protocol mode %s", protocolMode)
+		}
+		configuredShare := decimal.NullDecimal{}
+		if share, ok := configuredShares[protocolMode]; ok {
+			configuredShare = decimal.NewNullDecimal(share)
+		}
+		protocolShares, err := protocol.getOperatorShares(ctx, d, validators, configuredShare)
+		if err != nil {
+			return nil, err
+		}
+		for validator, share := range protocolShares {
+			if _, ok := shares[validator]; !ok {
+				shares[validator] = share
+			}
+		}
+	}
+	return shares, nil
+}
+
+// getConfiguredOperatorShares returns the operator shares that are configured on the dashboard, guest dashboards can't configure any
+func (d *DataAccessService) getConfiguredOperatorShares(ctx context.Context, dashboardId t.VDBId) (map[enums.StakingProtocol]decimal.Decimal, error) {
+	configuredShares := make(map[enums.StakingProtocol]decimal.Decimal)
+	if dashboardId.Validators != nil {
+		return configuredShares, nil
+	}
+
+	var queryResult []struct {
+		Protocol      string          `db:"protocol"`
+		OperatorShare decimal.Decimal `db:"operator_share"`
+	}
+	err := d.alloyReader.SelectContext(ctx, &queryResult, `
+		SELECT protocol, operator_share
+		FROM users_val_dashboards_operator_shares
+		WHERE dashboard_id = $1
+	`, dashboardId.Id)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving configured operator shares: %w", err)
+	}
+	for _, row := range queryResult {
+		protocol := enums.StakingProtocol(0).NewFromString(row.Protocol)
+		if enums.IsInvalidEnum(protocol) {
+			continue
+		}
+		configuredShares[protocol] = row.OperatorShare
+	}
+	return configuredShares, nil
+}
+
+// getValidatorDashboardOperatorShares returns the operator shares that are configured on the dashboard ordered by protocol
+func (d *DataAccessService) getValidatorDashboardOperatorShares(ctx context.Context, dashboardId t.VDBId) ([]t.VDBOperatorShare, error) {
+	configuredShares, err := d.getConfiguredOperatorShares(ctx, dashboardId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]t.VDBOperatorShare, 0, len(configuredShares))
+	for protocol, share := range configuredShares {
+		result = append(result, t.VDBOperatorShare{Protocol: protocol.String(), OperatorShare: share.InexactFloat64()})
+	}
+	slices.SortFunc(result, func(a, b t.VDBOperatorShare) int {
+		return strings.Compare(a.Protocol, b.Protocol)
+	})
+	return result, nil
+}
+
+func (d *DataAccessService) UpdateValidatorDashboardOperatorShare(ctx context.Context, dashboardId t.VDBIdPrimary, protocol enums.StakingProtocol, operatorShare float64) (*t.VDBOperatorShare, error) {
+	if p, ok := stakingProtocols[protocol]; !ok || !p.isConfigurable() {
+		return nil, fmt.Errorf("operator share of protocol %s can't be configured", protocol)
+	}
+	_, err := d.alloyWriter.ExecContext(ctx, `
+		INSERT INTO users_val_dashboards_operator_shares (dashboard_id, protocol, operator_share)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (dashboard_id, protocol) DO UPDATE SET operator_share = excluded.operator_share
+	`, dashboardId, protocol.String(), operatorShare)
+	if err != nil {
+		return nil, err
+	}
+	return &t.VDBOperatorShare{Protocol: protocol.String(), OperatorShare: operatorShare}, nil
+}
+
+func (d *DataAccessService) RemoveValidatorDashboardOperatorShare(ctx context.Context, dashboardId t.VDBIdPrimary, protocol enums.StakingProtocol) error {
+	_, err := d.alloyWriter.ExecContext(ctx, `
+		DELETE FROM users_val_dashboards_operator_shares WHERE dashboard_id = $1 AND protocol = $2
+	`, dashboardId, protocol.String())
+	return err
+}
+
+// get returns the operator share of the validator
+func (s operatorShares) get(validator t.VDBValidator) decimal.Decimal {
+	if share, ok := s[validator]; ok {
+		return share
+	}
+	return decimal.NewFromInt(1)
+}
+
+// sorted returns the validators and their shares ordered by validator index so that the generated queries are stable
+func (s operatorShares) sorted() ([]string, []string) {
+	validators := make([]t.VDBValidator, 0, len(s))
+	for validator := range s {
+		validators = append(validators, validator)
+	}
+	slices.Sort(validators)
+
+	indices := make([]string, 0, len(validators))
+	shares := make([]string, 0, len(validators))
+	for _, validator := range validators {
+		indices = append(indices, fmt.Sprintf("%d", validator))
+		shares = append(shares, s[validator].String())
+	}
+	return indices, shares
+}
+
+// clickhouseWeighted returns the clickhouse expression that scales the per validator expr by the operator share
+// of the validator in validatorColumn. The result is rounded to an Int64 so it can be summed up like the plain expr.
+func (s operatorShares) clickhouseWeighted(expr string, validatorColumn string) string {
+	if len(s) == 0 {
+		return expr
+	}
+	indices, shares := s.sorted()
+	return fmt.Sprintf("toInt64((%s) * transform(toUInt64(%s), CAST([%s] AS Array(UInt64)), CAST([%s] AS Array(Float64)), toFloat64(1)))",
+		expr, validatorColumn, strings.Join(indices, ","), strings.Join(shares, ","))
+}
+
+// postgresWeighted returns the postgres expression that scales the per validator expr by the operator share
+// of the validator in validatorColumn
+func (s operatorShares) postgresWeighted(expr string, validatorColumn string) string {
+	if len(s) == 0 {
+		return expr
+	}
+	indices, shares := s.sorted()
+	return fmt.Sprintf("(%s) * COALESCE((ARRAY[%s]::numeric[])[array_position(ARRAY[%s]::bigint[], %s::bigint)], 1)",
+		expr, strings.Join(shares, ","), strings.Join(indices, ","), validatorColumn)
+}
@@ -0,0 +1,107 @@
+package dataaccess
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
+	"github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/shopspring/decimal"
+)
+
+// fakeStakingProtocol returns fixed operator shares for the validators that are part of the dashboard
+type fakeStakingProtocol struct {
+	shares operatorShares
+}
+
+func (fakeStakingProtocol) isConfigurable() bool {
+	return false
+}
+
+func (p fakeStakingProtocol) getOperatorShares(ctx context.Context, d *DataAccessService, validators []types.VDBValidator, configuredShare decimal.NullDecimal) (operatorShares, error) {
+	shares := make(operatorShares)
+	for _, validator := range validators {
+		if share, ok := p.shares[validator]; ok {
+			shares[validator] = share
+		}
+	}
+	return shares, nil
+}
+
+func TestStakingProtocolsRegistry(t *testing.T) {
+	for _, protocol := range []enums.StakingProtocol{enums.StakingProtocols.RocketPool, enums.StakingProtocols.LidoCsm, enums.StakingProtocols.Ssv, enums.StakingProtocols.Obol} {
+		p, ok := stakingProtocols[protocol]
+		if !ok {
+			t.Errorf("protocol mode %s is not registered", protocol)
+			continue
+		}
+		// only rocket pool shares can be derived from on chain data, the others depend on the operator
+		if want := protocol != enums.StakingProtocols.RocketPool; p.isConfigurable() != want {
+			t.Errorf("expected configurable of protocol mode %s to be %v", protocol, want)
+		}
+	}
+}
+
+func TestTaggedStakingProtocolWithoutConfiguredShare(t *testing.T) {
+	for _, protocol := range []enums.StakingProtocol{enums.StakingProtocols.LidoCsm, enums.StakingProtocols.Ssv, enums.StakingProtocols.Obol} {
+		// validators keep their full income, no database access is needed for that
+		shares, err := stakingProtocols[protocol].getOperatorShares(context.Background(), nil, []types.VDBValidator{1, 2}, decimal.NullDecimal{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(shares) != 0 {
+			t.Errorf("expected no operator shares for protocol mode %s without configured share, got %v", protocol, shares)
+		}
+	}
+}
+
+func TestGetOperatorSharesFirstProtocolWins(t *testing.T) {
+	registered := stakingProtocols
+	defer func() { stakingProtocols = registered }()
+	stakingProtocols = map[enums.StakingProtocol]stakingProtocol{
+		enums.StakingProtocols.RocketPool: fakeStakingProtocol{shares: operatorShares{1: decimal.RequireFromString("0.5"), 2: decimal.RequireFromString("0.6")}},
+		enums.StakingProtocols.Ssv:        fakeStakingProtocol{shares: operatorShares{2: decimal.RequireFromString("0.25"), 3: decimal.RequireFromString("0.25"), 9: decimal.RequireFromString("0.25")}},
+	}
+
+	d := &DataAccessService{}
+	dashboardId := types.VDBId{Validators: []types.VDBValidator{1, 2, 3, 4}}
+	shares, err := d.getOperatorShares(context.Background(), dashboardId, types.VDBProtocolModes{Protocols: []enums.StakingProtocol{enums.StakingProtocols.Ssv, enums.StakingProtocols.RocketPool}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[types.VDBValidator]string{1: "0.5", 2: "0.25", 3: "0.25", 4: "1"}
+	for validator, share := range want {
+		if got := shares.get(validator); !got.Equal(decimal.RequireFromString(share)) {
+			t.Errorf("expected operator share %s for validator %d, got %s", share, validator, got)
+		}
+	}
+	if _, ok := shares[9]; ok {
+		t.Errorf("expected validator 9 that is not part of the dashboard to be skipped")
+	}
+
+	shares, err = d.getOperatorShares(context.Background(), dashboardId, types.VDBProtocolModes{Protocols: []enums.StakingProtocol{enums.StakingProtocols.Obol}})
+	if err == nil {
+		t.Errorf("expected an error for an unregistered protocol mode, got %v", shares)
+	}
+}
+
+func TestOperatorSharesWeighted(t *testing.T) {
+	var none operatorShares
+	if got := none.clickhouseWeighted("cl_rewards", "validator_index"); got != "cl_rewards" {
+		t.Errorf("expected the plain clickhouse expression without shares, got %s", got)
+	}
+	if got := none.postgresWeighted("amount", "validatorindex"); got != "amount" {
+		t.Errorf("expected the plain postgres expression without shares, got %s", got)
+	}
+
+	shares := operatorShares{7: decimal.RequireFromString("0.1"), 3: decimal.RequireFromString("0.5")}
+	want := "toInt64((cl_rewards) * transform(toUInt64(validator_index), CAST([3,7] AS Array(UInt64)), CAST([0.5,0.1] AS Array(Float64)), toFloat64(1)))"
+	if got := shares.clickhouseWeighted("cl_rewards", "validator_index"); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	want = "(amount) * COALESCE((ARRAY[0.5,0.1]::numeric[])[array_position(ARRAY[3,7]::bigint[], validatorindex::bigint)], 1)"
+	if got := shares.postgresWeighted("amount", "validatorindex"); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
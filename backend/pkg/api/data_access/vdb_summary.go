@@ -29,7 +29,6 @@ import (
 )
 
 func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, dashboardId t.VDBId, period enums.TimePeriod, cursor string, colSort t.Sort[enums.VDBSummaryColumn], search string, limit uint64, protocolModes t.VDBProtocolModes) ([]t.VDBSummaryTableRow, *t.Paging, error) {
-	// @DATA-ACCESS incorporate protocolModes
 	result := make([]t.VDBSummaryTableRow, 0)
 	var paging t.Paging
 
@@ -91,6 +90,13 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 	if err != nil {
 		return nil, nil, err
 	}
+
+	// ------------------------------------------------------------------------------------------------------------------
+	// Get the operator shares of the enabled protocol modes
+	shares, err := d.getOperatorShares(ctx, dashboardId, protocolModes)
+	if err != nil {
+		return nil, nil, err
+	}
 	averageNetworkEfficiency := utils.CalculateTotalEfficiency(
 		efficiency.AttestationEfficiency[period], efficiency.ProposalEfficiency[period], efficiency.SyncEfficiency[period])
 
@@ -118,14 +124,7 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 		With("validators", goqu.L("(SELECT dashboard_id, group_id, validator_index FROM users_val_dashboards_validators WHERE dashboard_id = ?)", dashboardId.Id)).
 		Select(
 			goqu.L("ARRAY_AGG(r.validator_index) AS validator_indices"),
-			goqu.L(`
-				(
-					SUM(COALESCE(finalizeAggregation(r.balance_end), 0)) +
-					SUM(COALESCE(r.withdrawals_amount, 0)) -
-					SUM(COALESCE(r.deposits_amount, 0)) -
-					SUM(COALESCE(finalizeAggregation(r.balance_start), 0))
-				) AS cl_rewards
-			`),
+			goqu.L(fmt.Sprintf("SUM(%s) AS cl_rewards", shares.clickhouseWeighted(clRewardsExpr, "r.validator_index"))),
 			goqu.L("COALESCE(SUM(r.attestations_reward)::decimal, 0) AS attestations_reward"),
 			goqu.L("COALESCE(SUM(r.attestations_ideal_reward)::decimal, 0) AS attestations_ideal_reward"),
 			goqu.L("COALESCE(SUM(r.attestations_observed), 0) AS attestations_observed"),
@@ -195,7 +194,7 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 	elRewards := make(map[int64]decimal.Decimal)
 	ds = goqu.Dialect("postgres").
 		Select(
-			goqu.L("SUM(COALESCE(rb.value, ep.fee_recipient_reward * 1e18, 0)) AS el_rewards")).
+			goqu.L(fmt.Sprintf("SUM(%s) AS el_rewards", shares.postgresWeighted(elRewardsExpr, "b.proposer")))).
 		From(goqu.L("blocks b")).
 		LeftJoin(goqu.L("execution_payloads ep"), goqu.On(goqu.L("ep.block_hash = b.exec_block_hash"))).
 		LeftJoin(
@@ -250,7 +249,7 @@ func (d *DataAccessService) GetValidatorDashboardSummary(ctx context.Context, da
 
 	// ------------------------------------------------------------------------------------------------------------------
 	// Get the current and next sync committee validators
-	latestEpoch := cache.LatestEpoch.Get()
+	latestEpoch := cache.LatestEpoch.OfChain(d.chainId()).Get()
 	currentSyncCommitteeValidators := make(map[uint64]bool)
 	upcomingSyncCommitteeValidators := make(map[uint64]bool)
 	wg.Go(func() error {
@@ -520,7 +519,7 @@ func (d *DataAccessService) GetValidatorDashboardGroupSummary(ctx context.Contex
 	}
 
 	// Get the current and next sync committee validators
-	latestEpoch := cache.LatestEpoch.Get()
+	latestEpoch := cache.LatestEpoch.OfChain(d.chainId()).Get()
 	currentSyncCommitteeValidators, upcomingSyncCommitteeValidators, err := d.getCurrentAndUpcomingSyncCommittees(ctx, latestEpoch)
 	if err != nil {
 		return nil, err
@@ -578,7 +577,7 @@ func (d *DataAccessService) GetValidatorDashboardGroupSummary(ctx context.Contex
 			return time.Time{}, time.Time{}, nil
 		}
 
-		return utils.EpochToTime(uint64(*row.LastScheduledBlockEpoch)), utils.EpochToTime(uint64(*row.LastSyncEpoch)), nil
+		return d.chain.EpochToTime(uint64(*row.LastScheduledBlockEpoch)), d.chain.EpochToTime(uint64(*row.LastSyncEpoch)), nil
 	}
 
 	ds := goqu.Dialect("postgres").
@@ -743,7 +742,7 @@ func (d *DataAccessService) GetValidatorDashboardGroupSummary(ctx context.Contex
 		}
 	}
 
-	_, ret.Apr.El, _, ret.Apr.Cl, err = d.internal_getElClAPR(ctx, dashboardId, groupId, hours)
+	_, ret.Apr.El, _, ret.Apr.Cl, err = d.internal_getElClAPR(ctx, dashboardId, groupId, hours, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -752,8 +751,8 @@ func (d *DataAccessService) GetValidatorDashboardGroupSummary(ctx context.Contex
 		validatorArr = validators
 	}
 
-	pastSyncPeriodCutoff := utils.SyncPeriodOfEpoch(rows[0].EpochStart)
-	currentSyncPeriod := utils.SyncPeriodOfEpoch(latestEpoch)
+	pastSyncPeriodCutoff := d.chain.SyncPeriodOfEpoch(rows[0].EpochStart)
+	currentSyncPeriod := d.chain.SyncPeriodOfEpoch(latestEpoch)
 	err = d.readerDb.GetContext(ctx, &ret.SyncCommitteeCount.PastPeriods, `SELECT COUNT(*) FROM sync_committees WHERE period >= $1 AND period < $2 AND validatorindex = ANY($3)`, pastSyncPeriodCutoff, currentSyncPeriod, validatorArr)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving past sync committee count: %w", err)
@@ -766,7 +765,7 @@ func (d *DataAccessService) GetValidatorDashboardGroupSummary(ctx context.Contex
 
 	luckHours := float64(hours)
 	if hours == -1 {
-		luckHours = time.Since(time.Unix(int64(utils.Config.Chain.GenesisTimestamp), 0)).Hours()
+		luckHours = time.Since(time.Unix(int64(d.chain.GenesisTimestamp), 0)).Hours()
 		if luckHours == 0 {
 			luckHours = 24
 		}
@@ -829,7 +828,9 @@ func (d *DataAccessService) GetValidatorDashboardGroupSummary(ctx context.Contex
 	return ret, nil
 }
 
-func (d *DataAccessService) internal_getElClAPR(ctx context.Context, dashboardId t.VDBId, groupId int64, hours int) (elIncome decimal.Decimal, elAPR float64, clIncome decimal.Decimal, clAPR float64, err error) {
+// internal_getElClAPR returns the income and apr of the dashboard (group) for the given period.
+// The income is scaled by the passed operator shares while the apr is always the one of the validators.
+func (d *DataAccessService) internal_getElClAPR(ctx context.Context, dashboardId t.VDBId, groupId int64, hours int, shares operatorShares) (elIncome decimal.Decimal, elAPR float64, clIncome decimal.Decimal, clAPR float64, err error) {
 	table := ""
 
 	switch hours {
@@ -851,7 +852,9 @@ func (d *DataAccessService) internal_getElClAPR(ctx context.Context, dashboardId
 		EpochStart     uint64        `db:"epoch_start"`
 		EpochEnd       uint64        `db:"epoch_end"`
 		ValidatorCount uint64        `db:"validator_count"`
+		Stake          uint64        `db:"stake"`
 		Reward         sql.NullInt64 `db:"reward"`
+		OperatorReward sql.NullInt64 `db:"operator_reward"`
 	}
 
 	var rewardsResultTable RewardsResult
@@ -864,14 +867,10 @@ func (d *DataAccessService) internal_getElClAPR(ctx context.Context, dashboardId
 			goqu.L("MIN(epoch_start) AS epoch_start"),
 			goqu.L("MAX(epoch_end) AS epoch_end"),
 			goqu.L("COUNT(*) AS validator_count"),
-			goqu.L(`
-				(
-					SUM(COALESCE(finalizeAggregation(r.balance_end), 0)) +
-					SUM(COALESCE(r.withdrawals_amount, 0)) -
-					SUM(COALESCE(r.deposits_amount, 0)) -
-					SUM(COALESCE(finalizeAggregation(r.balance_start), 0))
-				) AS reward
-			`))
+			// compounding validators can hold more than 32 ETH, use their balance as stake for the apr
+			goqu.L(fmt.Sprintf("toUInt64(SUM(GREATEST(COALESCE(finalizeAggregation(r.balance_start), 0), %d))) AS stake", d.chain.ClConfig.MinActivationBalance)),
+			goqu.L(fmt.Sprintf("SUM(%s) AS reward", clRewardsExpr)),
+			goqu.L(fmt.Sprintf("SUM(%s) AS operator_reward", shares.clickhouseWeighted(clRewardsExpr, "r.validator_index"))))
 	if len(dashboardId.Validators) > 0 {
 		rewardsDs = rewardsDs.
 			Where(goqu.L("validator_index IN ?", dashboardId.Validators))
@@ -904,12 +903,12 @@ func (d *DataAccessService) internal_getElClAPR(ctx context.Context, dashboardId
 	if hours == -1 { // for all time APR
 		aprDivisor = 90 * 24
 	}
-	clAPR = ((float64(rewardsResultTable.Reward.Int64) / float64(aprDivisor)) / (float64(32e9) * float64(rewardsResultTable.ValidatorCount))) * 24.0 * 365.0 * 100.0
+	clAPR = ((float64(rewardsResultTable.Reward.Int64) / float64(aprDivisor)) / float64(rewardsResultTable.Stake)) * 24.0 * 365.0 * 100.0
 	if math.IsNaN(clAPR) {
 		clAPR = 0
 	}
 
-	clIncome = decimal.NewFromInt(rewardsResultTable.Reward.Int64).Mul(decimal.NewFromInt(1e9))
+	clIncome = decimal.NewFromInt(rewardsResultTable.OperatorReward.Int64).Mul(decimal.NewFromInt(1e9))
 
 	if hours == -1 {
 		rewardsDs = rewardsDs.
@@ -925,11 +924,13 @@ func (d *DataAccessService) internal_getElClAPR(ctx context.Context, dashboardId
 			return decimal.Zero, 0, decimal.Zero, 0, err
 		}
 
-		clIncome = decimal.NewFromInt(rewardsResultTotal.Reward.Int64).Mul(decimal.NewFromInt(1e9))
+		clIncome = decimal.NewFromInt(rewardsResultTotal.OperatorReward.Int64).Mul(decimal.NewFromInt(1e9))
 	}
 
 	elDs := goqu.Dialect("postgres").
-		Select(goqu.L("COALESCE(SUM(COALESCE(rb.value / 1e18, fee_recipient_reward)), 0) AS el_reward")).
+		Select(
+			goqu.L("COALESCE(SUM(COALESCE(rb.value / 1e18, fee_recipient_reward)), 0) AS el_reward"),
+			goqu.L(fmt.Sprintf("COALESCE(SUM(%s), 0) AS operator_el_reward", shares.postgresWeighted("COALESCE(rb.value / 1e18, fee_recipient_reward)", "b.proposer")))).
 		From(goqu.L("blocks AS b")).
 		LeftJoin(goqu.L("execution_payloads AS ep"), goqu.On(goqu.L("b.exec_block_hash = ep.block_hash"))).
 		LeftJoin(
@@ -966,12 +967,17 @@ func (d *DataAccessService) internal_getElClAPR(ctx context.Context, dashboardId
 		return decimal.Zero, 0, decimal.Zero, 0, fmt.Errorf("error preparing query: %w", err)
 	}
 
-	err = d.alloyReader.GetContext(ctx, &elIncome, query, args...)
+	var elRewardsResult struct {
+		ElReward         decimal.Decimal `db:"el_reward"`
+		OperatorElReward decimal.Decimal `db:"operator_el_reward"`
+	}
+	err = d.alloyReader.GetContext(ctx, &elRewardsResult, query, args...)
 	if err != nil {
 		return decimal.Zero, 0, decimal.Zero, 0, err
 	}
-	elIncomeFloat, _ := elIncome.Float64() // EL income is in ETH
-	elAPR = ((elIncomeFloat / float64(aprDivisor)) / (float64(32) * float64(rewardsResultTable.ValidatorCount))) * 24.0 * 365.0 * 100.0
+	elIncome = elRewardsResult.OperatorElReward
+	elIncomeFloat, _ := elRewardsResult.ElReward.Float64() // EL income is in ETH
+	elAPR = ((elIncomeFloat / float64(aprDivisor)) / (float64(rewardsResultTable.Stake) / 1e9)) * 24.0 * 365.0 * 100.0
 	if math.IsNaN(elAPR) {
 		elAPR = 0
 	}
@@ -985,10 +991,11 @@ func (d *DataAccessService) internal_getElClAPR(ctx context.Context, dashboardId
 			return decimal.Zero, 0, decimal.Zero, 0, fmt.Errorf("error preparing query: %w", err)
 		}
 
-		err = d.alloyReader.GetContext(ctx, &elIncome, query, args...)
+		err = d.alloyReader.GetContext(ctx, &elRewardsResult, query, args...)
 		if err != nil {
 			return decimal.Zero, 0, decimal.Zero, 0, err
 		}
+		elIncome = elRewardsResult.OperatorElReward
 	}
 	elIncome = elIncome.Mul(decimal.NewFromInt(1e18))
 
@@ -1235,8 +1242,8 @@ func (d *DataAccessService) GetValidatorDashboardSummaryValidators(ctx context.C
 		return nil, err
 	}
 
-	latestEpoch := cache.LatestFinalizedEpoch.Get()
-	latestStats := cache.LatestStats.Get()
+	latestEpoch := cache.LatestFinalizedEpoch.OfChain(d.chainId()).Get()
+	latestStats := cache.LatestStats.OfChain(d.chainId()).Get()
 	var activationChurnRate uint64
 
 	if latestStats.ValidatorActivationChurnLimit == nil {
@@ -1246,7 +1253,7 @@ func (d *DataAccessService) GetValidatorDashboardSummaryValidators(ctx context.C
 		activationChurnRate = *latestStats.ValidatorActivationChurnLimit
 	}
 
-	stats := cache.LatestStats.Get()
+	stats := cache.LatestStats.OfChain(d.chainId()).Get()
 	if stats == nil || stats.LatestValidatorWithdrawalIndex == nil {
 		return nil, errors.New("stats not available")
 	}
@@ -1269,15 +1276,15 @@ func (d *DataAccessService) GetValidatorDashboardSummaryValidators(ctx context.C
 				Index: validatorIndex,
 			}
 			if metadata.ActivationEpoch.Valid {
-				validatorInfo.Timestamp = uint64(utils.EpochToTime(uint64(metadata.ActivationEpoch.Int64)).Unix())
+				validatorInfo.Timestamp = uint64(d.chain.EpochToTime(uint64(metadata.ActivationEpoch.Int64)).Unix())
 			} else if metadata.Queues.ActivationIndex.Valid {
 				queuePosition := uint64(metadata.Queues.ActivationIndex.Int64)
 				epochsToWait := (queuePosition - 1) / activationChurnRate
 				// calculate dequeue epoch
 				estimatedActivationEpoch := latestEpoch + epochsToWait + 1
 				// add activation offset
-				estimatedActivationEpoch += utils.Config.Chain.ClConfig.MaxSeedLookahead + 1
-				validatorInfo.Timestamp = uint64(utils.EpochToTime(estimatedActivationEpoch).Unix())
+				estimatedActivationEpoch += d.chain.ClConfig.MaxSeedLookahead + 1
+				validatorInfo.Timestamp = uint64(d.chain.EpochToTime(estimatedActivationEpoch).Unix())
 			}
 			result.Pending = append(result.Pending, validatorInfo)
 		case constypes.DbActiveOnline:
@@ -1294,7 +1301,7 @@ func (d *DataAccessService) GetValidatorDashboardSummaryValidators(ctx context.C
 		case constypes.DbExitingOnline, constypes.DbExitingOffline:
 			result.Exiting = append(result.Exiting, t.IndexTimestamp{
 				Index:     validatorIndex,
-				Timestamp: uint64(utils.EpochToTime(uint64(metadata.ExitEpoch.Int64)).Unix()),
+				Timestamp: uint64(d.chain.EpochToTime(uint64(metadata.ExitEpoch.Int64)).Unix()),
 			})
 			if constypes.ValidatorDbStatus(metadata.Status) == constypes.DbExitingOffline {
 				result.Offline = append(result.Offline, validatorIndex)
@@ -1359,7 +1366,7 @@ func (d *DataAccessService) GetValidatorDashboardSyncSummaryValidators(ctx conte
 	}
 
 	// Get the current and next sync committee validators
-	latestEpoch := cache.LatestEpoch.Get()
+	latestEpoch := cache.LatestEpoch.OfChain(d.chainId()).Get()
 	wg.Go(func() error {
 		currentSyncCommitteeValidators, upcomingSyncCommitteeValidators, err := d.getCurrentAndUpcomingSyncCommittees(ctx, latestEpoch)
 		if err != nil {
@@ -1400,10 +1407,10 @@ func (d *DataAccessService) GetValidatorDashboardSyncSummaryValidators(ctx conte
 		if err != nil {
 			return fmt.Errorf("error retrieving cutoff epoch for past sync committees: %w", err)
 		}
-		pastSyncPeriodCutoff := utils.SyncPeriodOfEpoch(epochStart)
+		pastSyncPeriodCutoff := d.chain.SyncPeriodOfEpoch(epochStart)
 
 		// Get the past sync committee validators
-		currentSyncPeriod := utils.SyncPeriodOfEpoch(latestEpoch)
+		currentSyncPeriod := d.chain.SyncPeriodOfEpoch(latestEpoch)
 		ds = goqu.Dialect("postgres").
 			Select(
 				goqu.L("sc.validatorindex")).
@@ -1533,8 +1540,8 @@ func (d *DataAccessService) GetValidatorDashboardSlashingsSummaryValidators(ctx
 	attestationSlashings := make(map[uint64][]uint64)
 	attestationSlashed := make(map[uint64]uint64)
 
-	slotStart := queryResult[0].EpochStart * utils.Config.Chain.ClConfig.SlotsPerEpoch
-	slotEnd := (queryResult[0].EpochEnd+1)*utils.Config.Chain.ClConfig.SlotsPerEpoch - 1
+	slotStart := queryResult[0].EpochStart * d.chain.ClConfig.SlotsPerEpoch
+	slotEnd := (queryResult[0].EpochEnd+1)*d.chain.ClConfig.SlotsPerEpoch - 1
 
 	wg := errgroup.Group{}
 
@@ -1789,7 +1796,7 @@ func (d *DataAccessService) getCurrentAndUpcomingSyncCommittees(ctx context.Cont
 	currentSyncCommitteeValidators := make(map[uint64]bool)
 	upcomingSyncCommitteeValidators := make(map[uint64]bool)
 
-	currentSyncPeriod := utils.SyncPeriodOfEpoch(latestEpoch)
+	currentSyncPeriod := d.chain.SyncPeriodOfEpoch(latestEpoch)
 	ds := goqu.Dialect("postgres").
 		Select(
 			goqu.L("validatorindex"),
@@ -56,6 +56,12 @@ func (d *DataAccessService) GetValidatorDashboardWithdrawals(ctx context.Context
 		return result, &paging, nil
 	}
 
+	// Only the operator share of the withdrawals is reported for validators of enabled protocol modes
+	shares, err := d.getOperatorShares(ctx, dashboardId, protocolModes)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	validatorGroupMap := make(map[t.VDBValidator]uint64)
 	var validators []t.VDBValidator
 	if dashboardId.Validators == nil {
@@ -223,17 +229,28 @@ func (d *DataAccessService) GetValidatorDashboardWithdrawals(ctx context.Context
 		return nil, nil, err
 	}
 
+	validatorMapping, err := d.services.GetCurrentValidatorMapping()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Create the result
 	cursorData := make([]t.WithdrawalsCursor, 0)
 	for i, withdrawal := range queryResult {
 		address := hexutil.Encode(withdrawal.Address)
+		epoch := withdrawal.BlockSlot / d.chain.ClConfig.SlotsPerEpoch
+		var metadata *types.CachedValidator
+		if withdrawal.ValidatorIndex < uint64(len(validatorMapping.ValidatorMetadata)) {
+			metadata = validatorMapping.ValidatorMetadata[withdrawal.ValidatorIndex]
+		}
 		result = append(result, t.VDBWithdrawalsTableRow{
-			Epoch:     withdrawal.BlockSlot / utils.Config.Chain.ClConfig.SlotsPerEpoch,
+			Epoch:     epoch,
 			Slot:      withdrawal.BlockSlot,
 			Index:     withdrawal.ValidatorIndex,
 			Recipient: *addressMapping[address],
 			GroupId:   validatorGroupMap[withdrawal.ValidatorIndex],
-			Amount:    utils.GWeiToWei(big.NewInt(int64(withdrawal.Amount))),
+			Amount:    utils.GWeiToWei(big.NewInt(int64(withdrawal.Amount))).Mul(shares.get(withdrawal.ValidatorIndex)),
+			Type:      getWithdrawalType(metadata, epoch),
 		})
 		result[i].Recipient.IsContract = contractStatuses[i] == types.CONTRACT_CREATION || contractStatuses[i] == types.CONTRACT_PRESENT
 		cursorData = append(cursorData, t.WithdrawalsCursor{
@@ -307,7 +324,7 @@ func (d *DataAccessService) getNextWithdrawalRow(queryValidators []t.VDBValidato
 		return nil, nil
 	}
 
-	stats := cache.LatestStats.Get()
+	stats := cache.LatestStats.OfChain(d.chainId()).Get()
 	if stats == nil || stats.LatestValidatorWithdrawalIndex == nil {
 		return nil, errors.New("stats not available")
 	}
@@ -318,7 +335,7 @@ func (d *DataAccessService) getNextWithdrawalRow(queryValidators []t.VDBValidato
 		return nil, err
 	}
 
-	epoch := cache.LatestEpoch.Get()
+	epoch := cache.LatestEpoch.OfChain(d.chainId()).Get()
 
 	// find subscribed validators that are active and have valid withdrawal credentials
 	// order by validator index to ensure that "last withdrawal" cursor handling works
@@ -326,7 +343,7 @@ func (d *DataAccessService) getNextWithdrawalRow(queryValidators []t.VDBValidato
 		return queryValidators[i] < queryValidators[j]
 	})
 
-	latestFinalized := cache.LatestFinalizedEpoch.Get()
+	latestFinalized := cache.LatestFinalizedEpoch.OfChain(d.chainId()).Get()
 
 	var nextValidator *t.VDBValidator
 	for _, validator := range queryValidators {
@@ -345,8 +362,9 @@ func (d *DataAccessService) getNextWithdrawalRow(queryValidators []t.VDBValidato
 			continue
 		}
 
+		maxEffectiveBalance := utils.GetMaxEffectiveBalance(metadata.WithdrawalCredentials)
 		if (metadata.Balance > 0 && metadata.WithdrawableEpoch.Valid && metadata.WithdrawableEpoch.Int64 <= int64(epoch)) ||
-			(metadata.EffectiveBalance == utils.Config.Chain.ClConfig.MaxEffectiveBalance && metadata.Balance > utils.Config.Chain.ClConfig.MaxEffectiveBalance) {
+			(metadata.EffectiveBalance == maxEffectiveBalance && metadata.Balance > maxEffectiveBalance) {
 			// this validator is eligible for withdrawal, check if it is the next one
 			if nextValidator == nil || validator > *stats.LatestValidatorWithdrawalIndex {
 				distance, err := d.getWithdrawableCountFromCursor(validator, *stats.LatestValidatorWithdrawalIndex)
@@ -357,7 +375,7 @@ func (d *DataAccessService) getNextWithdrawalRow(queryValidators []t.VDBValidato
 				timeToWithdrawal := d.getTimeToNextWithdrawal(distance)
 
 				// it normally takes two epochs to finalize
-				if !timeToWithdrawal.Before(utils.EpochToTime(epoch + (epoch - latestFinalized))) {
+				if !timeToWithdrawal.Before(d.chain.EpochToTime(epoch + (epoch - latestFinalized))) {
 					// this validator has a next withdrawal
 					nextValidatorInt := validator
 					nextValidator = &nextValidatorInt
@@ -377,7 +395,7 @@ func (d *DataAccessService) getNextWithdrawalRow(queryValidators []t.VDBValidato
 
 	nextValidatorData := validatorMapping.ValidatorMetadata[*nextValidator]
 
-	lastWithdrawnEpochs, err := db.GetLastWithdrawalEpoch([]t.VDBValidator{*nextValidator})
+	lastWithdrawnEpochs, err := d.getLastWithdrawalEpoch([]t.VDBValidator{*nextValidator})
 	if err != nil {
 		return nil, err
 	}
@@ -388,27 +406,29 @@ func (d *DataAccessService) getNextWithdrawalRow(queryValidators []t.VDBValidato
 		return nil, err
 	}
 	nextTimeToWithdrawal := d.getTimeToNextWithdrawal(nextDistance)
-	nextWithdrawalSlot := utils.TimeToSlot(uint64(nextTimeToWithdrawal.Unix()))
+	nextWithdrawalSlot := d.chain.TimeToSlot(uint64(nextTimeToWithdrawal.Unix()))
 
 	address, err := utils.GetAddressOfWithdrawalCredentials(nextValidatorData.WithdrawalCredentials)
 	if err != nil {
 		return nil, err
 	}
 
+	// compounding validators only withdraw the balance above the electra max effective balance
+	maxEffectiveBalance := utils.GetMaxEffectiveBalance(nextValidatorData.WithdrawalCredentials)
+	withdrawalType := getWithdrawalType(nextValidatorData, epoch)
+
 	var withdrawalAmount uint64
-	if nextValidatorData.WithdrawableEpoch.Valid && nextValidatorData.WithdrawableEpoch.Int64 <= int64(epoch) {
-		// full withdrawal
+	if withdrawalType == "full" {
 		withdrawalAmount = nextValidatorData.Balance
-	} else {
-		// partial withdrawal
-		withdrawalAmount = nextValidatorData.Balance - utils.Config.Chain.ClConfig.MaxEffectiveBalance
+	} else if nextValidatorData.Balance > maxEffectiveBalance {
+		withdrawalAmount = nextValidatorData.Balance - maxEffectiveBalance
 	}
 
-	if lastWithdrawnEpoch == epoch || nextValidatorData.Balance < utils.Config.Chain.ClConfig.MaxEffectiveBalance {
+	if lastWithdrawnEpoch == epoch || nextValidatorData.Balance < d.chain.ClConfig.MaxEffectiveBalance {
 		withdrawalAmount = 0
 	}
 
-	ens_name, err := db.GetEnsNameForAddress(*address, utils.SlotToTime(nextWithdrawalSlot))
+	ens_name, err := db.GetEnsNameForAddress(*address, d.chain.SlotToTime(nextWithdrawalSlot))
 	if err != sql.ErrNoRows {
 		return nil, err
 	}
@@ -423,7 +443,7 @@ func (d *DataAccessService) getNextWithdrawalRow(queryValidators []t.VDBValidato
 	}
 
 	nextData := &t.VDBWithdrawalsTableRow{
-		Epoch: nextWithdrawalSlot / utils.Config.Chain.ClConfig.SlotsPerEpoch,
+		Epoch: nextWithdrawalSlot / d.chain.ClConfig.SlotsPerEpoch,
 		Slot:  nextWithdrawalSlot,
 		Index: *nextValidator,
 		Recipient: t.Address{
@@ -432,11 +452,20 @@ func (d *DataAccessService) getNextWithdrawalRow(queryValidators []t.VDBValidato
 			IsContract: contractStatus[0] == types.CONTRACT_CREATION || contractStatus[0] == types.CONTRACT_PRESENT,
 		},
 		Amount: utils.GWeiToWei(big.NewInt(int64(withdrawalAmount))),
+		Type:   withdrawalType,
 	}
 
 	return nextData, nil
 }
 
+// getWithdrawalType returns "full" if the validator was withdrawable in the given epoch and "partial" otherwise
+func getWithdrawalType(metadata *types.CachedValidator, epoch uint64) string {
+	if metadata != nil && metadata.WithdrawableEpoch.Valid && metadata.WithdrawableEpoch.Int64 <= int64(epoch) {
+		return "full"
+	}
+	return "partial"
+}
+
 func (d *DataAccessService) GetValidatorDashboardTotalWithdrawals(ctx context.Context, dashboardId t.VDBId, search string, protocolModes t.VDBProtocolModes) (*t.VDBTotalWithdrawalsData, error) {
 	result := &t.VDBTotalWithdrawalsData{
 		TotalAmount: decimal.NewFromBigInt(big.NewInt(0), 0),
@@ -452,6 +481,12 @@ func (d *DataAccessService) GetValidatorDashboardTotalWithdrawals(ctx context.Co
 		return result, nil
 	}
 
+	// Only the operator share of the withdrawals is reported for validators of enabled protocol modes
+	shares, err := d.getOperatorShares(ctx, dashboardId, protocolModes)
+	if err != nil {
+		return nil, err
+	}
+
 	queryResult := []struct {
 		ValidatorIndex t.VDBValidator `db:"validator_index"`
 		Epoch          uint64         `db:"epoch_end"`
@@ -504,34 +539,34 @@ func (d *DataAccessService) GetValidatorDashboardTotalWithdrawals(ctx context.Co
 		return result, nil
 	}
 
-	var totalAmount int64
+	totalAmount := decimal.Zero
 	var validators []t.VDBValidator
 	lastEpoch := queryResult[0].Epoch
-	lastSlot := (lastEpoch+1)*utils.Config.Chain.ClConfig.SlotsPerEpoch - 1
+	lastSlot := (lastEpoch+1)*d.chain.ClConfig.SlotsPerEpoch - 1
 
 	for _, res := range queryResult {
 		// Calculate the total amount of withdrawals
-		totalAmount += res.Amount
+		totalAmount = totalAmount.Add(decimal.NewFromInt(res.Amount).Mul(shares.get(res.ValidatorIndex)))
 
 		// Calculate the current validators
 		validators = append(validators, res.ValidatorIndex)
 	}
 
-	var latestWithdrawalsAmount int64
-	err = d.readerDb.GetContext(ctx, &latestWithdrawalsAmount, `
+	var latestWithdrawalsAmount decimal.Decimal
+	err = d.readerDb.GetContext(ctx, &latestWithdrawalsAmount, fmt.Sprintf(`
 		SELECT
-			COALESCE(SUM(w.amount), 0)
+			COALESCE(SUM(%s), 0)
 		FROM
 		    blocks_withdrawals w
 		INNER JOIN blocks b ON w.block_slot = b.slot AND w.block_root = b.blockroot AND b.status = '1'
 		WHERE w.block_slot > $1 AND w.validatorindex = ANY ($2)
-		`, lastSlot, validators)
+		`, shares.postgresWeighted("w.amount", "w.validatorindex")), lastSlot, validators)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return nil, fmt.Errorf("error getting latest withdrawals for validators: %+v: %w", dashboardId, err)
 	}
 
-	totalAmount += latestWithdrawalsAmount
-	result.TotalAmount = utils.GWeiToWei(big.NewInt(totalAmount))
+	totalAmount = totalAmount.Add(latestWithdrawalsAmount)
+	result.TotalAmount = totalAmount.Mul(decimal.NewFromInt(1e9))
 
 	return result, nil
 }
@@ -564,7 +599,10 @@ func (d *DataAccessService) getValidatorSearch(search string) ([]t.VDBValidator,
 				withdrawalCredentials := utils.GetWithdrawalCredentialsOfAddress(common.BytesToAddress(address))
 
 				for index, metadata := range validatorMapping.ValidatorMetadata {
-					if bytes.Equal(withdrawalCredentials, metadata.WithdrawalCredentials) {
+					// compounding (0x02) credentials only differ in the prefix
+					if len(metadata.WithdrawalCredentials) == len(withdrawalCredentials) &&
+						(metadata.WithdrawalCredentials[0] == 0x01 || metadata.WithdrawalCredentials[0] == 0x02) &&
+						bytes.Equal(withdrawalCredentials[1:], metadata.WithdrawalCredentials[1:]) {
 						validatorSearch = append(validatorSearch, t.VDBValidator(index))
 					}
 				}
@@ -584,3 +622,30 @@ func (d *DataAccessService) getValidatorSearch(search string) ([]t.VDBValidator,
 
 	return validatorSearch, nil
 }
+
+func (d *DataAccessService) getLastWithdrawalEpoch(validators []uint64) (map[uint64]uint64, error) {
+	var dbResponse []struct {
+		ValidatorIndex     uint64 `db:"validatorindex"`
+		LastWithdrawalSlot uint64 `db:"last_withdrawal_slot"`
+	}
+
+	res := make(map[uint64]uint64)
+	err := d.readerDb.Select(&dbResponse, `
+		SELECT w.validatorindex as validatorindex, COALESCE(max(block_slot), 0) as last_withdrawal_slot
+		FROM blocks_withdrawals w
+		INNER JOIN blocks b ON b.blockroot = w.block_root AND b.status = '1'
+		WHERE w.validatorindex = ANY($1)
+		GROUP BY w.validatorindex`, validators)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return res, nil
+		}
+		return nil, fmt.Errorf("error getting validator blocks_withdrawals count for validators: %d: %w", validators, err)
+	}
+
+	for _, row := range dbResponse {
+		res[row.ValidatorIndex] = row.LastWithdrawalSlot / d.chain.ClConfig.SlotsPerEpoch
+	}
+
+	return res, nil
+}
@@ -1,6 +1,9 @@
 package enums
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type Enum interface {
 	Int() int
@@ -261,3 +264,239 @@ func (c ChartAggregation) Duration(secondsPerEpoch uint64) time.Duration {
 		return 0
 	}
 }
+
+// ----------------
+// Fiat Currency (as stored in the historical price table)
+
+type Currency int
+
+var _ EnumFactory[Currency] = Currency(0)
+
+const (
+	CurrencyNative Currency = iota
+	CurrencyUSD
+	CurrencyEUR
+	CurrencyGBP
+	CurrencyCAD
+	CurrencyJPY
+	CurrencyCNY
+	CurrencyAUD
+	CurrencyRUB
+)
+
+func (c Currency) Int() int {
+	return int(c)
+}
+
+func (Currency) NewFromString(s string) Currency {
+	switch strings.ToUpper(s) {
+	case "":
+		return CurrencyNative
+	case "USD":
+		return CurrencyUSD
+	case "EUR":
+		return CurrencyEUR
+	case "GBP":
+		return CurrencyGBP
+	case "CAD":
+		return CurrencyCAD
+	case "JPY":
+		return CurrencyJPY
+	case "CNY":
+		return CurrencyCNY
+	case "AUD":
+		return CurrencyAUD
+	case "RUB":
+		return CurrencyRUB
+	default:
+		return Currency(-1)
+	}
+}
+
+// Code returns the lowercase currency code, which is also the column name in the price table; empty for the native currency
+func (c Currency) Code() string {
+	switch c {
+	case CurrencyUSD:
+		return "usd"
+	case CurrencyEUR:
+		return "eur"
+	case CurrencyGBP:
+		return "gbp"
+	case CurrencyCAD:
+		return "cad"
+	case CurrencyJPY:
+		return "jpy"
+	case CurrencyCNY:
+		return "cny"
+	case CurrencyAUD:
+		return "aud"
+	case CurrencyRUB:
+		return "rub"
+	default:
+		return ""
+	}
+}
+
+var Currencies = struct {
+	Native Currency
+	USD    Currency
+	EUR    Currency
+	GBP    Currency
+	CAD    Currency
+	JPY    Currency
+	CNY    Currency
+	AUD    Currency
+	RUB    Currency
+}{
+	CurrencyNative,
+	CurrencyUSD,
+	CurrencyEUR,
+	CurrencyGBP,
+	CurrencyCAD,
+	CurrencyJPY,
+	CurrencyCNY,
+	CurrencyAUD,
+	CurrencyRUB,
+}
+
+// ----------------
+// API Key Scopes
+
+type ApiKeyScope int
+
+var _ EnumFactory[ApiKeyScope] = ApiKeyScope(0)
+
+const (
+	ApiKeyScopeReadDashboards ApiKeyScope = iota
+	ApiKeyScopeManageDashboards
+	ApiKeyScopeManageNotifications
+	ApiKeyScopePushMachineMetrics
+	ApiKeyScopeManageBroadcasts
+)
+
+func (s ApiKeyScope) Int() int {
+	return int(s)
+}
+
+func (ApiKeyScope) NewFromString(s string) ApiKeyScope {
+	switch s {
+	case "read_dashboards":
+		return ApiKeyScopeReadDashboards
+	case "manage_dashboards":
+		return ApiKeyScopeManageDashboards
+	case "manage_notifications":
+		return ApiKeyScopeManageNotifications
+	case "push_machine_metrics":
+		return ApiKeyScopePushMachineMetrics
+	case "manage_broadcasts":
+		return ApiKeyScopeManageBroadcasts
+	default:
+		return ApiKeyScope(-1)
+	}
+}
+
+func (s ApiKeyScope) String() string {
+	switch s {
+	case ApiKeyScopeReadDashboards:
+		return "read_dashboards"
+	case ApiKeyScopeManageDashboards:
+		return "manage_dashboards"
+	case ApiKeyScopeManageNotifications:
+		return "manage_notifications"
+	case ApiKeyScopePushMachineMetrics:
+		return "push_machine_metrics"
+	case ApiKeyScopeManageBroadcasts:
+		return "manage_broadcasts"
+	default:
+		return ""
+	}
+}
+
+var ApiKeyScopes = struct {
+	ReadDashboards      ApiKeyScope
+	ManageDashboards    ApiKeyScope
+	ManageNotifications ApiKeyScope
+	PushMachineMetrics  ApiKeyScope
+	ManageBroadcasts    ApiKeyScope
+}{
+	ApiKeyScopeReadDashboards,
+	ApiKeyScopeManageDashboards,
+	ApiKeyScopeManageNotifications,
+	ApiKeyScopePushMachineMetrics,
+	ApiKeyScopeManageBroadcasts,
+}
+
+// AllApiKeyScopes are the scopes of keys that were created without restrictions
+var AllApiKeyScopes = []ApiKeyScope{
+	ApiKeyScopeReadDashboards,
+	ApiKeyScopeManageDashboards,
+	ApiKeyScopeManageNotifications,
+	ApiKeyScopePushMachineMetrics,
+	ApiKeyScopeManageBroadcasts,
+}
+
+// ----------------
+// Organization Roles
+
+// OrganizationRole values are ordered by privilege, each role includes the permissions of the roles below it
+type OrganizationRole int
+
+var _ EnumFactory[OrganizationRole] = OrganizationRole(0)
+
+const (
+	OrganizationRoleViewer OrganizationRole = iota
+	OrganizationRoleEditor
+	OrganizationRoleAdmin
+	OrganizationRoleOwner
+)
+
+func (r OrganizationRole) Int() int {
+	return int(r)
+}
+
+func (OrganizationRole) NewFromString(s string) OrganizationRole {
+	switch s {
+	case "viewer":
+		return OrganizationRoleViewer
+	case "editor":
+		return OrganizationRoleEditor
+	case "admin":
+		return OrganizationRoleAdmin
+	case "owner":
+		return OrganizationRoleOwner
+	default:
+		return OrganizationRole(-1)
+	}
+}
+
+func (r OrganizationRole) String() string {
+	switch r {
+	case OrganizationRoleViewer:
+		return "viewer"
+	case OrganizationRoleEditor:
+		return "editor"
+	case OrganizationRoleAdmin:
+		return "admin"
+	case OrganizationRoleOwner:
+		return "owner"
+	default:
+		return ""
+	}
+}
+
+// Includes returns true if the role grants at least the permissions of the required role
+func (r OrganizationRole) Includes(required OrganizationRole) bool {
+	return !IsInvalidEnum(r) && r >= required
+}
+
+var OrganizationRoles = struct {
+	Viewer OrganizationRole
+	Editor OrganizationRole
+	Admin  OrganizationRole
+	Owner  OrganizationRole
+}{
+	OrganizationRoleViewer,
+	OrganizationRoleEditor,
+	OrganizationRoleAdmin,
+	OrganizationRoleOwner,
+}
@@ -485,3 +485,222 @@ var VDBWRocketPoolColumns = struct {
 }{
 	VDBRocketPoolMinipoolsGroup,
 }
+
+// ----------------
+// Validator Dashboard Exports
+
+type VDBExportType int
+
+var _ EnumFactory[VDBExportType] = VDBExportType(0)
+
+const (
+	VDBExportRewards VDBExportType = iota
+	VDBExportDuties
+	VDBExportWithdrawals
+	VDBExportElDeposits
+	VDBExportClDeposits
+)
+
+func (c VDBExportType) Int() int {
+	return int(c)
+}
+
+func (VDBExportType) NewFromString(s string) VDBExportType {
+	switch s {
+	case "rewards":
+		return VDBExportRewards
+	case "duties":
+		return VDBExportDuties
+	case "withdrawals":
+		return VDBExportWithdrawals
+	case "execution-layer-deposits":
+		return VDBExportElDeposits
+	case "consensus-layer-deposits":
+		return VDBExportClDeposits
+	default:
+		return VDBExportType(-1)
+	}
+}
+
+func (c VDBExportType) String() string {
+	switch c {
+	case VDBExportRewards:
+		return "rewards"
+	case VDBExportDuties:
+		return "duties"
+	case VDBExportWithdrawals:
+		return "withdrawals"
+	case VDBExportElDeposits:
+		return "execution-layer-deposits"
+	case VDBExportClDeposits:
+		return "consensus-layer-deposits"
+	default:
+		return ""
+	}
+}
+
+var VDBExportTypes = struct {
+	Rewards     VDBExportType
+	Duties      VDBExportType
+	Withdrawals VDBExportType
+	ElDeposits  VDBExportType
+	ClDeposits  VDBExportType
+}{
+	VDBExportRewards,
+	VDBExportDuties,
+	VDBExportWithdrawals,
+	VDBExportElDeposits,
+	VDBExportClDeposits,
+}
+
+type VDBExportFormat int
+
+var _ EnumFactory[VDBExportFormat] = VDBExportFormat(0)
+
+const (
+	VDBExportFormatCsv VDBExportFormat = iota
+	VDBExportFormatNdjson
+)
+
+func (c VDBExportFormat) Int() int {
+	return int(c)
+}
+
+func (VDBExportFormat) NewFromString(s string) VDBExportFormat {
+	switch s {
+	case "", "csv":
+		return VDBExportFormatCsv
+	case "ndjson":
+		return VDBExportFormatNdjson
+	default:
+		return VDBExportFormat(-1)
+	}
+}
+
+var VDBExportFormats = struct {
+	Csv    VDBExportFormat
+	Ndjson VDBExportFormat
+}{
+	VDBExportFormatCsv,
+	VDBExportFormatNdjson,
+}
+
+// ----------------
+// Validator Dashboard Income Reports
+
+type IncomeReportFormat int
+
+var _ EnumFactory[IncomeReportFormat] = IncomeReportFormat(0)
+
+const (
+	IncomeReportFormatPdf IncomeReportFormat = iota
+	IncomeReportFormatCsv
+	IncomeReportFormatKoinly
+	IncomeReportFormatCoinTracking
+)
+
+func (c IncomeReportFormat) Int() int {
+	return int(c)
+}
+
+func (IncomeReportFormat) NewFromString(s string) IncomeReportFormat {
+	switch s {
+	case "", "pdf":
+		return IncomeReportFormatPdf
+	case "csv":
+		return IncomeReportFormatCsv
+	case "koinly":
+		return IncomeReportFormatKoinly
+	case "cointracking":
+		return IncomeReportFormatCoinTracking
+	default:
+		return IncomeReportFormat(-1)
+	}
+}
+
+func (c IncomeReportFormat) String() string {
+	switch c {
+	case IncomeReportFormatPdf:
+		return "pdf"
+	case IncomeReportFormatCsv:
+		return "csv"
+	case IncomeReportFormatKoinly:
+		return "koinly"
+	case IncomeReportFormatCoinTracking:
+		return "cointracking"
+	default:
+		return ""
+	}
+}
+
+var IncomeReportFormats = struct {
+	Pdf          IncomeReportFormat
+	Csv          IncomeReportFormat
+	Koinly       IncomeReportFormat
+	CoinTracking IncomeReportFormat
+}{
+	IncomeReportFormatPdf,
+	IncomeReportFormatCsv,
+	IncomeReportFormatKoinly,
+	IncomeReportFormatCoinTracking,
+}
+
+// ----------------
+// Validator Dashboard Staking Protocols
+
+type StakingProtocol int
+
+var _ EnumFactory[StakingProtocol] = StakingProtocol(0)
+
+const (
+	StakingProtocolRocketPool StakingProtocol = iota
+	StakingProtocolLidoCsm
+	StakingProtocolSsv
+	StakingProtocolObol
+)
+
+func (c StakingProtocol) Int() int {
+	return int(c)
+}
+
+func (StakingProtocol) NewFromString(s string) StakingProtocol {
+	switch s {
+	case "rocket_pool":
+		return StakingProtocolRocketPool
+	case "lido_csm":
+		return StakingProtocolLidoCsm
+	case "ssv":
+		return StakingProtocolSsv
+	case "obol":
+		return StakingProtocolObol
+	default:
+		return StakingProtocol(-1)
+	}
+}
+
+func (c StakingProtocol) String() string {
+	switch c {
+	case StakingProtocolRocketPool:
+		return "rocket_pool"
+	case StakingProtocolLidoCsm:
+		return "lido_csm"
+	case StakingProtocolSsv:
+		return "ssv"
+	case StakingProtocolObol:
+		return "obol"
+	default:
+		return ""
+	}
+}
+
+var StakingProtocols = struct {
+	RocketPool StakingProtocol
+	LidoCsm    StakingProtocol
+	Ssv        StakingProtocol
+	Obol       StakingProtocol
+}{
+	StakingProtocolRocketPool,
+	StakingProtocolLidoCsm,
+	StakingProtocolSsv,
+	StakingProtocolObol,
+}
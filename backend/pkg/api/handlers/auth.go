@@ -7,10 +7,12 @@ import (
 	"fmt"
 	"html"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	dataaccess "github.com/gobitfly/beaconchain/pkg/api/data_access"
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
 	"github.com/gobitfly/beaconchain/pkg/api/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
 	"github.com/gobitfly/beaconchain/pkg/commons/mail"
@@ -28,6 +30,16 @@ const (
 	subscriptionKey  = "subscription"
 	userGroupKey     = "user_group"
 	mobileAuthKey    = "mobile_auth"
+
+	sessionCreatedKey   = "created_at"
+	sessionUserAgentKey = "user_agent"
+	sessionIpKey        = "ip"
+
+	// set after the password check while the second factor is pending
+	secondFactorUserIdKey        = "second_factor_user_id"
+	secondFactorDeadlineKey      = "second_factor_deadline"
+	webAuthnChallengeKey         = "webauthn_challenge"
+	webAuthnChallengeDeadlineKey = "webauthn_challenge_deadline"
 )
 
 const authConfirmEmailRateLimit = time.Minute * 2
@@ -175,7 +187,7 @@ func (h *HandlerService) GetUserIdBySession(r *http.Request) (uint64, error) {
 
 const authHeaderPrefix = "Bearer "
 
-func (h *HandlerService) GetUserIdByApiKey(r *http.Request) (uint64, error) {
+func (h *HandlerService) GetApiKeyAccess(r *http.Request) (*types.ApiKeyAccess, error) {
 	// TODO: store user id in context during ratelimting and use it here
 	query := r.URL.Query()
 	header := r.Header
@@ -187,13 +199,68 @@ func (h *HandlerService) GetUserIdByApiKey(r *http.Request) (uint64, error) {
 		query.Get("apikey"),
 	)
 	if apiKey == "" {
-		return 0, newUnauthorizedErr("missing api key")
+		return nil, newUnauthorizedErr("missing api key")
+	}
+	access, err := h.daService.GetApiKeyAccess(r.Context(), apiKey)
+	if errors.Is(err, dataaccess.ErrNotFound) {
+		// bearer tokens can also be access tokens issued to oauth apps, they are restricted like api keys
+		access, err = h.daService.GetOAuthAccessTokenAccess(r.Context(), apiKey)
 	}
-	userId, err := h.daService.GetUserIdByApiKey(r.Context(), apiKey)
 	if errors.Is(err, dataaccess.ErrNotFound) {
 		err = newUnauthorizedErr("api key not found")
 	}
-	return userId, err
+	return access, err
+}
+
+// ApiKeyScope declares the scope an api key needs to access a public route.
+// api keys are refused on public routes that weren't registered with a declared scope.
+type ApiKeyScope struct {
+	scope    enums.ApiKeyScope
+	declared bool
+	required bool
+}
+
+// AnyApiKeyScope declares that any valid api key may access a public route
+var AnyApiKeyScope = ApiKeyScope{declared: true}
+
+// RequireApiKeyScope declares that api keys need the given scope to access a public route
+func RequireApiKeyScope(scope enums.ApiKeyScope) ApiKeyScope {
+	return ApiKeyScope{scope: scope, declared: true, required: true}
+}
+
+// HandlePublic registers a route on the public router together with the scope api keys need to access it.
+// api keys are also checked against the network the request is served by, right before the handler runs.
+func (h *HandlerService) HandlePublic(router *mux.Router, path string, handler func(w http.ResponseWriter, r *http.Request), scope ApiKeyScope) *mux.Route {
+	route := router.Handle(path, h.ApiKeyNetworkCheckMiddleware(http.HandlerFunc(handler)))
+	if scope.declared {
+		h.apiKeyScopes[route] = scope
+	}
+	return route
+}
+
+// HandlePublicPrefix is like HandlePublic for routes matching a path prefix
+func (h *HandlerService) HandlePublicPrefix(router *mux.Router, prefix string, handler http.Handler, scope ApiKeyScope) *mux.Route {
+	route := router.PathPrefix(prefix).Handler(h.ApiKeyNetworkCheckMiddleware(handler))
+	if scope.declared {
+		h.apiKeyScopes[route] = scope
+	}
+	return route
+}
+
+// checkApiKeyAccess returns a forbidden error if the route or the scopes of the api key don't allow the request
+func (h *HandlerService) checkApiKeyAccess(r *http.Request, access *types.ApiKeyAccess) error {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return newForbiddenErr("api keys are not allowed to access this route")
+	}
+	scope, ok := h.apiKeyScopes[route]
+	if !ok {
+		return newForbiddenErr("api keys are not allowed to access this route")
+	}
+	if scope.required && !access.HasScope(scope.scope) {
+		return newForbiddenErr("api key is missing the scope '%s'", scope.scope.String())
+	}
+	return nil
 }
 
 // if this is used, user ID should've been stored in context (by GetUserIdStoreMiddleware)
@@ -207,16 +274,170 @@ func GetUserIdByContext(r *http.Request) (uint64, error) {
 
 // Handlers
 
-func (h *HandlerService) InternalPostOauthAuthorize(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+// checkApiKeyRestrictions validates the scopes and networks of an api key request, duplicates are removed
+func (v *validationError) checkApiKeyRestrictions(scopes []string, networks []intOrString) ([]enums.ApiKeyScope, []uint64) {
+	var checkedScopes []enums.ApiKeyScope
+	for _, scope := range scopes {
+		checkedScope := checkEnum[enums.ApiKeyScope](v, scope, "scopes")
+		if !slices.Contains(checkedScopes, checkedScope) {
+			checkedScopes = append(checkedScopes, checkedScope)
+		}
+	}
+	var checkedNetworks []uint64
+	for _, network := range networks {
+		chainId := v.checkNetwork(network)
+		if !slices.Contains(checkedNetworks, chainId) {
+			checkedNetworks = append(checkedNetworks, chainId)
+		}
+	}
+	return checkedScopes, checkedNetworks
 }
 
-func (h *HandlerService) InternalPostOauthToken(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+func (h *HandlerService) InternalGetUserApiKeys(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	data, err := h.getDataAccessor(r).GetUserApiKeys(r.Context(), user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetUserApiKeysResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
-func (h *HandlerService) InternalPostApiKeys(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+func (h *HandlerService) InternalPostUserApiKeys(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	var v validationError
+	req := struct {
+		Name     string        `json:"name"`
+		Scopes   []string      `json:"scopes"`
+		Networks []intOrString `json:"networks"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	name := v.checkNameNotEmpty(req.Name)
+	scopes, networks := v.checkApiKeyRestrictions(req.Scopes, req.Networks)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	userInfo, err := h.daService.GetUserInfo(r.Context(), user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	apiKeyCount, err := h.daService.GetUserApiKeyCount(r.Context(), user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if apiKeyCount >= userInfo.ApiPerks.ApiKeys {
+		returnConflict(w, r, errors.New("maximum number of api keys reached"))
+		return
+	}
+
+	data, err := h.getDataAccessor(r).CreateUserApiKey(r.Context(), user.Id, name, scopes, networks)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalPostUserApiKeysResponse{
+		Data: *data,
+	}
+	returnCreated(w, r, response)
+}
+
+func (h *HandlerService) InternalPutUserApiKey(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	var v validationError
+	req := struct {
+		Name     string        `json:"name"`
+		Scopes   []string      `json:"scopes"`
+		Networks []intOrString `json:"networks"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	apiKeyId := v.checkUint(mux.Vars(r)["api_key_id"], "api_key_id")
+	name := v.checkNameNotEmpty(req.Name)
+	scopes, networks := v.checkApiKeyRestrictions(req.Scopes, req.Networks)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).UpdateUserApiKey(r.Context(), user.Id, apiKeyId, name, scopes, networks)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalPutUserApiKeyResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
+}
+
+// InternalPostUserApiKeyRotations replaces the key with a new one, the old key stops working immediately
+func (h *HandlerService) InternalPostUserApiKeyRotations(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	var v validationError
+	apiKeyId := v.checkUint(mux.Vars(r)["api_key_id"], "api_key_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).RotateUserApiKey(r.Context(), user.Id, apiKeyId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalPostUserApiKeysResponse{
+		Data: *data,
+	}
+	returnCreated(w, r, response)
+}
+
+func (h *HandlerService) InternalDeleteUserApiKey(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	var v validationError
+	apiKeyId := v.checkUint(mux.Vars(r)["api_key_id"], "api_key_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	err = h.getDataAccessor(r).RemoveUserApiKey(r.Context(), user.Id, apiKeyId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
 }
 
 func (h *HandlerService) InternalPostUsers(w http.ResponseWriter, r *http.Request) {
@@ -461,13 +682,25 @@ func (h *HandlerService) InternalPostLogin(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if err := h.checkLoginLock(r.Context(), user.Id); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
 	// validate password
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
 	if err != nil {
+		h.recordLoginFailure(r.Context(), user)
 		handleErr(w, r, errBadCredentials)
 		return
 	}
 
+	secondFactorMethods, err := h.getSecondFactorMethods(r.Context(), user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
 	// change privileges
 	err = h.scs.RenewToken(r.Context())
 	if err != nil {
@@ -475,12 +708,26 @@ func (h *HandlerService) InternalPostLogin(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	h.scs.Put(r.Context(), authenticatedKey, true)
-	h.scs.Put(r.Context(), userIdKey, user.Id)
-	h.scs.Put(r.Context(), subscriptionKey, user.ProductId)
-	h.scs.Put(r.Context(), userGroupKey, user.UserGroup)
+	if len(secondFactorMethods) > 0 {
+		// session stays unauthenticated until the second factor was provided
+		h.scs.Put(r.Context(), secondFactorUserIdKey, user.Id)
+		h.scs.Put(r.Context(), secondFactorDeadlineKey, time.Now().Add(secondFactorTimeout).Unix())
+		response := types.InternalPostLoginResponse{
+			Data: types.LoginResult{
+				SecondFactorRequired: true,
+				SecondFactorMethods:  secondFactorMethods,
+			},
+		}
+		returnOk(w, r, response)
+		return
+	}
 
-	returnOk(w, r, nil)
+	h.authenticateSession(r, user)
+
+	response := types.InternalPostLoginResponse{
+		Data: types.LoginResult{SecondFactorRequired: false},
+	}
+	returnOk(w, r, response)
 }
 
 // Can be used to login on mobile, requires an authenticated session
@@ -522,9 +769,9 @@ func (h *HandlerService) InternalPostMobileAuthorize(w http.ResponseWriter, r *h
 		return
 	}
 
-	// check if oauth app exists to validate whether redirect uri is valid
-	appInfo, err := h.daService.GetAppDataFromRedirectUri(r.Context(), req.RedirectURI)
-	if err != nil {
+	// the mobile app is a registered oauth client, only first party clients may receive sessions
+	appInfo, err := h.daService.GetOAuthClientByRedirectUri(r.Context(), req.RedirectURI)
+	if err != nil || !appInfo.FirstParty {
 		callback := req.RedirectURI + "?error=invalid_request&error_description=missing_redirect_uri" + state
 		http.Redirect(w, r, callback, http.StatusSeeOther)
 		return
@@ -540,7 +787,7 @@ func (h *HandlerService) InternalPostMobileAuthorize(w http.ResponseWriter, r *h
 	session := h.scs.Token(r.Context())
 
 	sanitizedDeviceName := html.EscapeString(clientName)
-	err = h.daService.AddUserDevice(r.Context(), userInfo.Id, utils.HashAndEncode(session+session), clientID, sanitizedDeviceName, appInfo.ID)
+	err = h.daService.AddUserDevice(r.Context(), userInfo.Id, utils.HashAndEncode(session+session), clientID, sanitizedDeviceName, appInfo.AppId)
 	if err != nil {
 		log.Warnf("Error adding user device: %v", err)
 		callback := req.RedirectURI + "?error=invalid_request&error_description=server_error" + state
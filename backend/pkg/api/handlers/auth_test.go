@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dataaccess "github.com/gobitfly/beaconchain/pkg/api/data_access"
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
+	"github.com/gobitfly/beaconchain/pkg/api/types"
+	commontypes "github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gorilla/mux"
+)
+
+// testDataAccessor serves one network, resolves every api key to the same access and every dashboard to the holesky network.
+// all other methods are unused.
+type testDataAccessor struct {
+	dataaccess.DataAccessor
+	chainId  uint64
+	networks map[uint64]*testDataAccessor
+	access   types.ApiKeyAccess
+}
+
+func (d *testDataAccessor) ForNetwork(chainId uint64) (dataaccess.DataAccessor, bool) {
+	if chainId == d.chainId {
+		return d, true
+	}
+	network, ok := d.networks[chainId]
+	return network, ok
+}
+
+func (d *testDataAccessor) GetApiKeyAccess(ctx context.Context, apiKey string) (*types.ApiKeyAccess, error) {
+	access := d.access
+	return &access, nil
+}
+
+func (d *testDataAccessor) GetValidatorDashboardUser(ctx context.Context, dashboardId types.VDBIdPrimary) (*types.DashboardUser, error) {
+	return &types.DashboardUser{Id: dashboardId, Network: 17000}, nil
+}
+
+// newTestHandlerService returns a handler service serving mainnet (chain id 1) and holesky (chain id 17000), but not sepolia
+func newTestHandlerService(access types.ApiKeyAccess) *HandlerService {
+	utils.Config = &commontypes.Config{}
+	utils.Config.Chain.ClConfig.DepositChainID = 1
+	allNetworks = []types.NetworkInfo{{ChainId: 1, Name: "mainnet"}, {ChainId: 17000, Name: "holesky"}, {ChainId: 11155111, Name: "sepolia"}}
+
+	holesky := &testDataAccessor{chainId: 17000}
+	return &HandlerService{
+		daService:    &testDataAccessor{chainId: 1, networks: map[uint64]*testDataAccessor{17000: holesky}, access: access},
+		apiKeyScopes: make(map[*mux.Route]ApiKeyScope),
+	}
+}
+
+func newApiKeyTestRouter(access types.ApiKeyAccess) *mux.Router {
+	h := newTestHandlerService(access)
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+	router := mux.NewRouter()
+	router.Use(h.StoreUserIdByApiKeyMiddleware, h.StoreNetworkMiddleware)
+	dashboardRouter := router.PathPrefix("/validator-dashboards").Subrouter()
+	dashboardRouter.Use(h.VDBNetworkMiddleware)
+	h.HandlePublic(dashboardRouter, "/{dashboard_id}", ok, RequireApiKeyScope(enums.ApiKeyScopes.ReadDashboards)).Methods(http.MethodGet)
+	h.HandlePublic(dashboardRouter, "/{dashboard_id}/name", ok, RequireApiKeyScope(enums.ApiKeyScopes.ManageDashboards)).Methods(http.MethodPut)
+	h.HandlePublic(dashboardRouter, "/{dashboard_id}", ok, RequireApiKeyScope(enums.ApiKeyScopes.ManageDashboards)).Methods(http.MethodDelete)
+	h.HandlePublic(router, "/users/me/notifications/test-email", ok, RequireApiKeyScope(enums.ApiKeyScopes.ManageNotifications)).Methods(http.MethodPost)
+	h.HandlePublic(router, "/networks/{network}/epochs", ok, AnyApiKeyScope).Methods(http.MethodGet)
+	// registered without declaring a scope
+	router.HandleFunc("/networks/{network}/undeclared", ok).Methods(http.MethodPost)
+	h.HandlePublic(router, "/networks/{network}/internal-only", ok, ApiKeyScope{}).Methods(http.MethodPost)
+	return router
+}
+
+func TestApiKeyAccess(t *testing.T) {
+	readOnly := types.ApiKeyAccess{Scopes: []enums.ApiKeyScope{enums.ApiKeyScopes.ReadDashboards}}
+	unrestricted := types.ApiKeyAccess{}
+	mainnetOnly := types.ApiKeyAccess{Networks: []uint64{1}}
+	holeskyOnly := types.ApiKeyAccess{Networks: []uint64{17000}}
+
+	tests := []struct {
+		name   string
+		access types.ApiKeyAccess
+		method string
+		path   string
+		apiKey bool
+		want   int
+	}{
+		{"read only key reads a dashboard", readOnly, http.MethodGet, "/validator-dashboards/1", true, http.StatusOK},
+		{"read only key renames a dashboard", readOnly, http.MethodPut, "/validator-dashboards/1/name", true, http.StatusForbidden},
+		{"read only key deletes a dashboard", readOnly, http.MethodDelete, "/validator-dashboards/1", true, http.StatusForbidden},
+		{"read only key sends a test email", readOnly, http.MethodPost, "/users/me/notifications/test-email", true, http.StatusForbidden},
+		{"read only key on a route open to any key", readOnly, http.MethodGet, "/networks/mainnet/epochs", true, http.StatusOK},
+		{"unrestricted key deletes a dashboard", unrestricted, http.MethodDelete, "/validator-dashboards/1", true, http.StatusOK},
+		{"unrestricted key on an undeclared route", unrestricted, http.MethodPost, "/networks/mainnet/undeclared", true, http.StatusForbidden},
+		{"unrestricted key on a route with an empty declaration", unrestricted, http.MethodPost, "/networks/mainnet/internal-only", true, http.StatusForbidden},
+		{"no key on an undeclared route", unrestricted, http.MethodPost, "/networks/mainnet/undeclared", false, http.StatusOK},
+		{"key of the requested network", holeskyOnly, http.MethodGet, "/networks/holesky/epochs", true, http.StatusOK},
+		{"key of another network", holeskyOnly, http.MethodGet, "/networks/mainnet/epochs", true, http.StatusForbidden},
+		{"key of the network of the dashboard", holeskyOnly, http.MethodGet, "/validator-dashboards/1", true, http.StatusOK},
+		{"key of another network than the one of the dashboard", mainnetOnly, http.MethodGet, "/validator-dashboards/1", true, http.StatusForbidden},
+		{"key of the main network on a route without network", mainnetOnly, http.MethodPost, "/users/me/notifications/test-email", true, http.StatusOK},
+		{"key of another network on a route without network", holeskyOnly, http.MethodPost, "/users/me/notifications/test-email", true, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newApiKeyTestRouter(tt.access)
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.apiKey {
+				req.Header.Set("Authorization", authHeaderPrefix+"key")
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("%s %s returned %d, want %d: %s", tt.method, tt.path, rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestNetworkRouting(t *testing.T) {
+	h := newTestHandlerService(types.ApiKeyAccess{})
+	var servedBy uint64
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		servedBy = h.getDataAccessor(r).(*testDataAccessor).chainId
+		w.WriteHeader(http.StatusOK)
+	}
+	router := mux.NewRouter()
+	router.Use(h.StoreNetworkMiddleware)
+	router.HandleFunc("/networks/{network}/epochs", handler)
+	router.HandleFunc("/users/me/dashboards", handler)
+	dashboardRouter := router.PathPrefix("/validator-dashboards").Subrouter()
+	dashboardRouter.Use(h.VDBNetworkMiddleware)
+	dashboardRouter.HandleFunc("/{dashboard_id}", handler)
+
+	tests := []struct {
+		path     string
+		want     int
+		servedBy uint64
+	}{
+		{"/networks/mainnet/epochs", http.StatusOK, 1},
+		{"/networks/holesky/epochs", http.StatusOK, 17000},
+		{"/networks/17000/epochs", http.StatusOK, 17000},
+		{"/networks/sepolia/epochs", http.StatusNotFound, 0},
+		{"/validator-dashboards/1", http.StatusOK, 17000},
+		{"/users/me/dashboards", http.StatusOK, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			servedBy = 0
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != tt.want || servedBy != tt.servedBy {
+				t.Errorf("GET %s returned %d served by %d, want %d served by %d", tt.path, rec.Code, servedBy, tt.want, tt.servedBy)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	dataaccess "github.com/gobitfly/beaconchain/pkg/api/data_access"
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
+	"github.com/gobitfly/beaconchain/pkg/api/types"
+	commontypes "github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gorilla/mux"
+)
+
+const maxBroadcastOfflineEpochs = 100
+
+// checkNodeJobErr turns invalid node job data into a bad request
+func checkNodeJobErr(err error) error {
+	var userErr commontypes.CreateNodeJobUserError
+	if errors.As(err, &userErr) {
+		return newBadRequestErr("%s", userErr.Message)
+	}
+	return err
+}
+
+// getBroadcast returns the broadcast with the given id. Broadcasts of a dashboard are only accessible to users with the required role on
+// it and only on the network the dashboard was created for, all others are only accessible to the user who created them.
+func (h *HandlerService) getBroadcast(r *http.Request, id string, chainId uint64, required enums.OrganizationRole) (*types.Broadcast, error) {
+	broadcast, err := h.getDataAccessor(r).GetNodeJob(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	if isMocked(r) {
+		return broadcast, nil
+	}
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		return nil, err
+	}
+	// the proper error would be 403 Forbidden, but we don't want to leak information so we return 404 Not Found
+	notFoundErr := newNotFoundErr("broadcast with id %s not found", id)
+	if broadcast.DashboardId == nil {
+		if broadcast.UserId == nil || *broadcast.UserId != userId {
+			return nil, notFoundErr
+		}
+		return broadcast, nil
+	}
+	dashboardId := types.VDBIdPrimary(*broadcast.DashboardId)
+	if _, err := h.checkValidatorDashboardAccess(r.Context(), dashboardId, userId, required); err != nil {
+		if errors.Is(err, dataaccess.ErrNotFound) {
+			err = notFoundErr
+		}
+		return nil, err
+	}
+	dashboardUser, err := h.daService.GetValidatorDashboardUser(r.Context(), dashboardId)
+	if err != nil {
+		return nil, err
+	}
+	if dashboardUser.Network != chainId {
+		return nil, notFoundErr
+	}
+	return broadcast, nil
+}
+
+// PublicPostNetworkBroadcasts godoc
+//
+//	@Description	Broadcast signed BLS to execution changes or a signed voluntary exit to the network.
+//	@Description	Pre-signed voluntary exits of a dashboard group can be uploaded together with a trigger, they are broadcast once the trigger fires:
+//	@Description	at a scheduled epoch, once one of the validators has been offline for a number of epochs, once a validator of the group got slashed or when the broadcast is triggered manually.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Broadcasts
+//	@Accept			json
+//	@Produce		json
+//	@Param			network	path		string											true	"The network name or chain id."
+//	@Param			request	body		handlers.PublicPostNetworkBroadcasts.request	true	"`data` holds the signed operations in the format of the beacon node API. Triggered broadcasts require `trigger`, `dashboard_id` and `group_id`, their `data` must be a list of signed voluntary exits of validators in that group of a dashboard created for the network."
+//	@Success		201		{object}	types.PostNetworkBroadcastsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		401		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/broadcasts [post]
+func (h *HandlerService) PublicPostNetworkBroadcasts(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	type trigger struct {
+		Type   string `json:"type" enums:"scheduled_epoch,offline,sibling_slashed,manual"`
+		Epoch  uint64 `json:"epoch,omitempty"`
+		Epochs uint64 `json:"epochs,omitempty"`
+	}
+	type request struct {
+		Data        json.RawMessage `json:"data" swaggertype:"object"`
+		Trigger     *trigger        `json:"trigger,omitempty"`
+		DashboardId uint64          `json:"dashboard_id,omitempty"`
+		GroupId     *uint64         `json:"group_id,omitempty"`
+	}
+	var req request
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	chainId := v.checkNetworkParameter(mux.Vars(r)["network"])
+	if len(req.Data) == 0 {
+		v.add("data", "must not be empty")
+	}
+	var exits []*phase0.SignedVoluntaryExit
+	if req.Trigger != nil {
+		triggerType := commontypes.NodeJobTriggerType(req.Trigger.Type)
+		switch {
+		case !slices.Contains(commontypes.NodeJobTriggerTypes, triggerType):
+			v.add("trigger.type", fmt.Sprintf("given value '%s' is not a valid trigger type", req.Trigger.Type))
+		case triggerType == commontypes.ScheduledEpochNodeJobTriggerType && req.Trigger.Epoch == 0:
+			v.add("trigger.epoch", "must be set for scheduled_epoch triggers")
+		case triggerType == commontypes.OfflineNodeJobTriggerType:
+			checkMinMax(&v, req.Trigger.Epochs, 1, maxBroadcastOfflineEpochs, "trigger.epochs")
+		}
+		if req.DashboardId == 0 {
+			v.add("dashboard_id", "must be set for triggered broadcasts")
+		}
+		if req.GroupId == nil {
+			v.add("group_id", "must be set for triggered broadcasts")
+		}
+		if err := json.Unmarshal(req.Data, &exits); err != nil || len(exits) == 0 {
+			v.add("data", "must be a list of signed voluntary exits for triggered broadcasts")
+		}
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	// broadcasts are only accessible to their creator, so they can't be created anonymously
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	data := []byte(req.Data)
+	if req.Trigger != nil {
+		if !isMocked(r) {
+			dashboardId := types.VDBIdPrimary(req.DashboardId)
+			if _, err := h.checkValidatorDashboardAccess(r.Context(), dashboardId, userId, enums.OrganizationRoles.Editor); err != nil {
+				handleErr(w, r, err)
+				return
+			}
+			dashboardUser, err := h.daService.GetValidatorDashboardUser(r.Context(), dashboardId)
+			if err != nil {
+				handleErr(w, r, err)
+				return
+			}
+			if dashboardUser.Network != chainId {
+				handleErr(w, r, newBadRequestErr("dashboard with id %v was not created for network %v", req.DashboardId, chainId))
+				return
+			}
+		}
+		data, err = json.Marshal(commontypes.ConditionalVoluntaryExitsNodeJobData{
+			DashboardId: req.DashboardId,
+			GroupId:     *req.GroupId,
+			Trigger: commontypes.NodeJobTrigger{
+				Type:   commontypes.NodeJobTriggerType(req.Trigger.Type),
+				Epoch:  req.Trigger.Epoch,
+				Epochs: req.Trigger.Epochs,
+			},
+			Exits: exits,
+		})
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+	}
+
+	broadcast, err := h.getDataAccessor(r).CreateNodeJob(r.Context(), &userId, data)
+	if err != nil {
+		handleErr(w, r, checkNodeJobErr(err))
+		return
+	}
+	response := types.PostNetworkBroadcastsResponse{
+		Data: *broadcast,
+	}
+	returnCreated(w, r, response)
+}
+
+// PublicGetNetworkBroadcast godoc
+//
+//	@Description	Get the status of a broadcast, the state of its validators and the audit log of its submissions.
+//	@Description	Broadcasts of a dashboard are accessible to all users with access to the dashboard, all others only to the user who created them.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Broadcasts
+//	@Produce		json
+//	@Param			network			path		string	true	"The network name or chain id."
+//	@Param			broadcast_id	path		string	true	"The ID of the broadcast."
+//	@Success		200				{object}	types.GetNetworkBroadcastResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Failure		401				{object}	types.ApiErrorResponse
+//	@Failure		404				{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/broadcasts/{broadcast_id} [get]
+func (h *HandlerService) PublicGetNetworkBroadcast(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	id := v.checkRegex(reUuid, vars["broadcast_id"], "broadcast_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	broadcast, err := h.getBroadcast(r, id, chainId, enums.OrganizationRoles.Viewer)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkBroadcastResponse{
+		Data: *broadcast,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicPostNetworkBroadcastTriggers godoc
+//
+//	@Description	Trigger a broadcast of pre-signed voluntary exits right away, regardless of its trigger.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Broadcasts
+//	@Produce		json
+//	@Param			network			path		string	true	"The network name or chain id."
+//	@Param			broadcast_id	path		string	true	"The ID of the broadcast."
+//	@Success		200				{object}	types.GetNetworkBroadcastResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Failure		401				{object}	types.ApiErrorResponse
+//	@Failure		404				{object}	types.ApiErrorResponse
+//	@Failure		409				{object}	types.ApiErrorResponse	"Conflict. The broadcast has already been triggered."
+//	@Router			/networks/{network}/broadcasts/{broadcast_id}/triggers [post]
+func (h *HandlerService) PublicPostNetworkBroadcastTriggers(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	id := v.checkRegex(reUuid, vars["broadcast_id"], "broadcast_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	broadcast, err := h.getBroadcast(r, id, chainId, enums.OrganizationRoles.Editor)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if broadcast.Trigger == nil {
+		handleErr(w, r, newBadRequestErr("broadcast with id %s has no trigger", id))
+		return
+	}
+	triggered, err := h.getDataAccessor(r).TriggerNodeJob(r.Context(), id, fmt.Sprintf("triggered manually by user %d", userId))
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if !triggered {
+		handleErr(w, r, newConflictErr("broadcast with id %s has already been triggered", id))
+		return
+	}
+	broadcast, err = h.getDataAccessor(r).GetNodeJob(r.Context(), id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkBroadcastResponse{
+		Data: *broadcast,
+	}
+	returnOk(w, r, response)
+}
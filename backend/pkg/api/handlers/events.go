@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	eventStreamWriteTimeout = 10 * time.Second
+	// proxies close idle connections, so a keepalive is sent if there were no duties for a while
+	eventStreamPingInterval = 30 * time.Second
+	eventNameSlotDuties     = "slot_duties"
+)
+
+// websocket connections are only accepted from the same origin (default of the upgrader), other clients have to use server-sent events
+var eventStreamUpgrader = websocket.Upgrader{}
+
+// streamSlotDuties streams the events over a websocket if the client requested an upgrade and as server-sent events otherwise
+func streamSlotDuties(w http.ResponseWriter, r *http.Request, events <-chan types.VDBSlotDutiesEvent) {
+	if websocket.IsWebSocketUpgrade(r) {
+		streamSlotDutiesWebSocket(w, r, events)
+		return
+	}
+	streamSlotDutiesSSE(w, r, events)
+}
+
+// streamSlotDutiesSSE writes the events as server-sent events until the channel is closed or the client disconnects
+func streamSlotDutiesSSE(w http.ResponseWriter, r *http.Request, events <-chan types.VDBSlotDutiesEvent) {
+	rc := http.NewResponseController(w)
+	flush := func() error {
+		if err := rc.SetWriteDeadline(time.Now().Add(eventStreamWriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		return nil
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// disables response buffering of nginx
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := flush(); err != nil {
+		return
+	}
+
+	ping := time.NewTicker(eventStreamPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ping.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				logApiError(r, fmt.Errorf("error marshalling dashboard event: %w", err), 0)
+				return
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", eventNameSlotDuties, data); err != nil {
+				return
+			}
+			ping.Reset(eventStreamPingInterval)
+		}
+		if err := flush(); err != nil {
+			return
+		}
+	}
+}
+
+// streamSlotDutiesWebSocket sends every event as json message over the upgraded connection until the channel is closed or the client disconnects
+func streamSlotDutiesWebSocket(w http.ResponseWriter, r *http.Request, events <-chan types.VDBSlotDutiesEvent) {
+	conn, err := eventStreamUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader already replied with an error
+		return
+	}
+	defer conn.Close()
+	// the hijacked connection keeps the deadlines of the server, dead clients are detected by failing pings instead
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		return
+	}
+
+	// the connection is write only, but control messages and the close of the client have to be read
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(eventStreamPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case <-r.Context().Done():
+			return
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(eventStreamWriteTimeout)); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""), time.Now().Add(eventStreamWriteTimeout))
+				return
+			}
+			if err := conn.SetWriteDeadline(time.Now().Add(eventStreamWriteTimeout)); err != nil {
+				return
+			}
+			message := struct {
+				Event string                   `json:"event"`
+				Data  types.VDBSlotDutiesEvent `json:"data"`
+			}{eventNameSlotDuties, event}
+			if err := conn.WriteJSON(message); err != nil {
+				return
+			}
+		}
+	}
+}
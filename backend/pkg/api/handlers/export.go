@@ -0,0 +1,482 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
+	"github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	exportDefaultInterval   = 30 * 24 * 60 * 60  // 30 days
+	exportMaxInterval       = 366 * 24 * 60 * 60 // a bit more than a year to cover a full tax year
+	exportMaxDutiesInterval = 7 * 24 * 60 * 60   // duties are exported per validator and epoch, a lot more rows than the other exports
+	exportWriteTimeout      = 30 * time.Second
+	exportDecimalPlaces     = 2
+)
+
+// validatorDashboardExport holds the validated parameters of a dashboard export
+type validatorDashboardExport struct {
+	dashboardId   types.VDBId
+	exportType    enums.VDBExportType
+	format        enums.VDBExportFormat
+	currency      enums.Currency
+	groupId       int64
+	protocolModes types.VDBProtocolModes
+	afterTs       uint64
+	beforeTs      uint64
+	prices        map[int64]float64
+}
+
+func (e validatorDashboardExport) includesGroup(groupId int64) bool {
+	return e.groupId == types.AllGroups || e.groupId == groupId
+}
+
+func (e validatorDashboardExport) maxInterval() uint64 {
+	if e.exportType == enums.VDBExportTypes.Duties {
+		return exportMaxDutiesInterval
+	}
+	return exportMaxInterval
+}
+
+func (e validatorDashboardExport) fileName() string {
+	return fmt.Sprintf("dashboard-%s-%s-%s",
+		e.exportType.String(),
+		time.Unix(int64(e.afterTs), 0).UTC().Format(time.DateOnly),
+		time.Unix(int64(e.beforeTs), 0).UTC().Format(time.DateOnly))
+}
+
+// currencyColumns appends the price column and a converted column for each of the given value columns if a currency was requested
+func (e validatorDashboardExport) currencyColumns(columns []string, valueColumns ...string) []string {
+	code := e.currency.Code()
+	if code == "" {
+		return columns
+	}
+	columns = append(columns, "price_"+code)
+	for _, column := range valueColumns {
+		columns = append(columns, column+"_"+code)
+	}
+	return columns
+}
+
+// currencyValues appends the price of the day of ts and the converted values, matching currencyColumns
+// if there is no price for that day the converted values are left empty
+func (e validatorDashboardExport) currencyValues(values []any, ts time.Time, amounts ...decimal.Decimal) []any {
+	if e.currency.Code() == "" {
+		return values
+	}
+	price, ok := e.prices[ts.UTC().Truncate(utils.Day).Unix()]
+	if !ok {
+		return append(values, make([]any, len(amounts)+1)...)
+	}
+	values = append(values, price)
+	priceDecimal := decimal.NewFromFloat(price)
+	for _, amount := range amounts {
+		values = append(values, amount.Mul(priceDecimal).Round(exportDecimalPlaces))
+	}
+	return values
+}
+
+// exportWriter streams rows as csv or newline-delimited json, so large exports never have to be held in memory
+type exportWriter struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	format  enums.VDBExportFormat
+	columns []string
+	csv     *csv.Writer
+	buf     bytes.Buffer
+}
+
+// newExportWriter writes the response headers (and the csv header row) and returns a writer for the rows
+func newExportWriter(w http.ResponseWriter, format enums.VDBExportFormat, fileName string, columns []string) (*exportWriter, error) {
+	ew := &exportWriter{
+		w:       w,
+		rc:      http.NewResponseController(w),
+		format:  format,
+		columns: columns,
+	}
+
+	contentType, extension := "text/csv", "csv"
+	if format == enums.VDBExportFormats.Ndjson {
+		contentType, extension = "application/x-ndjson", "ndjson"
+	}
+	w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, fileName, extension))
+	w.WriteHeader(http.StatusOK)
+
+	if format == enums.VDBExportFormats.Csv {
+		ew.csv = csv.NewWriter(w)
+		if err := ew.csv.Write(columns); err != nil {
+			return nil, err
+		}
+	}
+	return ew, ew.flush()
+}
+
+func (ew *exportWriter) writeRow(values []any) error {
+	if len(values) != len(ew.columns) {
+		return fmt.Errorf("export row has %d values but %d columns", len(values), len(ew.columns))
+	}
+
+	if ew.csv != nil {
+		record := make([]string, len(values))
+		for i, value := range values {
+			record[i] = exportValueToString(value)
+		}
+		return ew.csv.Write(record)
+	}
+
+	// build the object manually to keep the column order of the csv export
+	ew.buf.Reset()
+	ew.buf.WriteByte('{')
+	for i, value := range values {
+		if i > 0 {
+			ew.buf.WriteByte(',')
+		}
+		key, err := json.Marshal(ew.columns[i])
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		ew.buf.Write(key)
+		ew.buf.WriteByte(':')
+		ew.buf.Write(data)
+	}
+	ew.buf.WriteString("}\n")
+	_, err := ew.w.Write(ew.buf.Bytes())
+	return err
+}
+
+// flush sends the buffered rows to the client and extends the write deadline of the server as long as data keeps flowing
+func (ew *exportWriter) flush() error {
+	if ew.csv != nil {
+		ew.csv.Flush()
+		if err := ew.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if err := ew.rc.SetWriteDeadline(time.Now().Add(exportWriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	if err := ew.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+func exportValueToString(value any) string {
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() || (rv.Kind() == reflect.Pointer && rv.IsNil()) {
+		return ""
+	}
+	if rv.Kind() == reflect.Pointer {
+		value = rv.Elem().Interface()
+	}
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// abortExport logs the error and aborts the response; once the export has started streaming the status code can't be changed anymore,
+// so aborting is the only way to let the client know that the export is incomplete
+func abortExport(r *http.Request, err error) {
+	logApiError(r, fmt.Errorf("error streaming validator dashboard export: %w", err), 1)
+	panic(http.ErrAbortHandler)
+}
+
+func weiToEther(wei decimal.Decimal) decimal.Decimal {
+	return utils.WeiToEther(wei.BigInt())
+}
+
+func (h *HandlerService) exportValidatorDashboardRewards(w http.ResponseWriter, r *http.Request, export validatorDashboardExport) {
+	ctx := r.Context()
+	chain := getChain(ctx)
+	latestFinalizedEpoch, err := h.getDataAccessor(r).GetLatestFinalizedEpoch(ctx)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	startEpoch := uint64(chain.TimeToEpoch(time.Unix(int64(export.afterTs), 0)))
+	endEpoch := min(uint64(chain.TimeToEpoch(time.Unix(int64(export.beforeTs), 0))), latestFinalizedEpoch)
+
+	columns := []string{"epoch", "timestamp", "group_id", "attestation_efficiency", "proposal_efficiency", "sync_efficiency", "slashings", "cl_reward", "el_reward", "total_reward"}
+	ew, err := newExportWriter(w, export.format, export.fileName(), export.currencyColumns(columns, "cl_reward", "el_reward", "total_reward"))
+	if err != nil {
+		abortExport(r, err)
+	}
+
+	// the rewards are fetched a day at a time to keep the queries small
+	epochsPerChunk := max(chain.EpochsPerDay(), 1)
+	for chunkStart := startEpoch; chunkStart <= endEpoch; chunkStart += epochsPerChunk {
+		chunkEnd := min(chunkStart+epochsPerChunk-1, endEpoch)
+		rows, err := h.getDataAccessor(r).GetValidatorDashboardRewardsRange(ctx, export.dashboardId, chunkStart, chunkEnd, export.protocolModes)
+		if err != nil {
+			abortExport(r, err)
+		}
+		for _, row := range rows {
+			// the "epoch total" rows would count the rewards twice
+			if row.GroupId == types.AllGroups || !export.includesGroup(row.GroupId) {
+				continue
+			}
+			ts := chain.EpochToTime(row.Epoch).UTC()
+			clReward, elReward := weiToEther(row.Reward.Cl), weiToEther(row.Reward.El)
+			totalReward := clReward.Add(elReward)
+			values := []any{row.Epoch, ts, row.GroupId, row.Duty.Attestation, row.Duty.Proposal, row.Duty.Sync, row.Duty.Slashing, clReward, elReward, totalReward}
+			if err := ew.writeRow(export.currencyValues(values, ts, clReward, elReward, totalReward)); err != nil {
+				abortExport(r, err)
+			}
+		}
+		if err := ew.flush(); err != nil {
+			abortExport(r, err)
+		}
+	}
+}
+
+// dutyValues returns the status and income of a duty, both are left empty if the validator had no such duty
+func dutyValues(event *types.ValidatorHistoryEvent) (any, any) {
+	if event == nil {
+		return nil, nil
+	}
+	return event.Status, weiToEther(event.Income)
+}
+
+func (h *HandlerService) exportValidatorDashboardDuties(w http.ResponseWriter, r *http.Request, export validatorDashboardExport) {
+	ctx := r.Context()
+	chain := getChain(ctx)
+	latestFinalizedEpoch, err := h.getDataAccessor(r).GetLatestFinalizedEpoch(ctx)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	startEpoch := uint64(chain.TimeToEpoch(time.Unix(int64(export.afterTs), 0)))
+	endEpoch := min(uint64(chain.TimeToEpoch(time.Unix(int64(export.beforeTs), 0))), latestFinalizedEpoch)
+	colSort := types.Sort[enums.VDBDutiesColumn]{Column: enums.VDBDutiesColumns.Validator, Desc: false}
+
+	columns := []string{"epoch", "timestamp", "index",
+		"attestation_source", "attestation_source_income", "attestation_target", "attestation_target_income", "attestation_head", "attestation_head_income",
+		"sync", "sync_income", "sync_count", "slashing", "slashing_income", "proposal", "proposal_cl_income", "proposal_el_income", "total_income"}
+	ew, err := newExportWriter(w, export.format, export.fileName(), export.currencyColumns(columns, "total_income"))
+	if err != nil {
+		abortExport(r, err)
+	}
+
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		ts := chain.EpochToTime(epoch).UTC()
+		cursor := ""
+		for {
+			rows, paging, err := h.getDataAccessor(r).GetValidatorDashboardDuties(ctx, export.dashboardId, epoch, export.groupId, cursor, colSort, "", maxQueryLimit, export.protocolModes)
+			if err != nil {
+				abortExport(r, err)
+			}
+			for _, row := range rows {
+				values := []any{epoch, ts, row.Validator}
+				totalIncome := decimal.Zero
+				for _, event := range []*types.ValidatorHistoryEvent{row.Duties.AttestationSource, row.Duties.AttestationTarget, row.Duties.AttestationHead, row.Duties.Sync} {
+					status, income := dutyValues(event)
+					values = append(values, status, income)
+					if event != nil {
+						totalIncome = totalIncome.Add(event.Income)
+					}
+				}
+				values = append(values, row.Duties.SyncCount)
+				status, income := dutyValues(row.Duties.Slashing)
+				values = append(values, status, income)
+				if row.Duties.Slashing != nil {
+					totalIncome = totalIncome.Add(row.Duties.Slashing.Income)
+				}
+				if proposal := row.Duties.Proposal; proposal != nil {
+					clIncome := proposal.ClAttestationInclusionIncome.Add(proposal.ClSyncInclusionIncome).Add(proposal.ClSlashingInclusionIncome)
+					values = append(values, proposal.Status, weiToEther(clIncome), weiToEther(proposal.ElIncome))
+					totalIncome = totalIncome.Add(clIncome).Add(proposal.ElIncome)
+				} else {
+					values = append(values, nil, nil, nil)
+				}
+				total := weiToEther(totalIncome)
+				values = append(values, total)
+				if err := ew.writeRow(export.currencyValues(values, ts, total)); err != nil {
+					abortExport(r, err)
+				}
+			}
+			if paging == nil || paging.NextCursor == "" {
+				break
+			}
+			cursor = paging.NextCursor
+		}
+		if err := ew.flush(); err != nil {
+			abortExport(r, err)
+		}
+	}
+}
+
+func (h *HandlerService) exportValidatorDashboardWithdrawals(w http.ResponseWriter, r *http.Request, export validatorDashboardExport) {
+	ctx := r.Context()
+	chain := getChain(ctx)
+	startSlot := chain.TimeToSlot(export.afterTs)
+	endSlot := chain.TimeToSlot(export.beforeTs)
+
+	// start right before the first slot of the range; there can't be any withdrawals in the genesis slot
+	cursor, err := utils.CursorToString(types.WithdrawalsCursor{
+		Slot:            max(startSlot, 1) - 1,
+		WithdrawalIndex: math.MaxInt64,
+	})
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	colSort := types.Sort[enums.VDBWithdrawalsColumn]{Column: enums.VDBWithdrawalsColumns.Slot, Desc: false}
+
+	columns := []string{"epoch", "slot", "timestamp", "group_id", "index", "recipient", "type", "amount"}
+	ew, err := newExportWriter(w, export.format, export.fileName(), export.currencyColumns(columns, "amount"))
+	if err != nil {
+		abortExport(r, err)
+	}
+
+	for cursor != "" {
+		rows, paging, err := h.getDataAccessor(r).GetValidatorDashboardWithdrawals(ctx, export.dashboardId, cursor, colSort, "", maxQueryLimit, export.protocolModes)
+		if err != nil {
+			abortExport(r, err)
+		}
+		cursor = ""
+		if paging != nil {
+			cursor = paging.NextCursor
+		}
+		for _, row := range rows {
+			if row.IsMissingEstimate || row.Slot > endSlot {
+				cursor = ""
+				break
+			}
+			if !export.includesGroup(int64(row.GroupId)) {
+				continue
+			}
+			ts := chain.SlotToTime(row.Slot).UTC()
+			amount := weiToEther(row.Amount)
+			values := []any{row.Epoch, row.Slot, ts, row.GroupId, row.Index, row.Recipient.Hash, row.Type, amount}
+			if err := ew.writeRow(export.currencyValues(values, ts, amount)); err != nil {
+				abortExport(r, err)
+			}
+		}
+		if err := ew.flush(); err != nil {
+			abortExport(r, err)
+		}
+	}
+}
+
+func (h *HandlerService) exportValidatorDashboardElDeposits(w http.ResponseWriter, r *http.Request, export validatorDashboardExport) {
+	ctx := r.Context()
+
+	// deposits are only available newest first, collect the ones in range before writing them in chronological order
+	// like the other exports; there is at most a handful of deposits per validator so this stays small
+	deposits := make([]types.VDBExecutionDepositsTableRow, 0)
+	cursor := ""
+	for {
+		rows, paging, err := h.getDataAccessor(r).GetValidatorDashboardElDeposits(ctx, export.dashboardId, cursor, maxQueryLimit)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		reachedStart := false
+		for _, row := range rows {
+			if row.Timestamp < int64(export.afterTs) {
+				reachedStart = true
+				break
+			}
+			if row.Timestamp > int64(export.beforeTs) || !export.includesGroup(int64(row.GroupId)) {
+				continue
+			}
+			deposits = append(deposits, row)
+		}
+		if reachedStart || paging == nil || paging.NextCursor == "" {
+			break
+		}
+		cursor = paging.NextCursor
+	}
+	slices.Reverse(deposits)
+
+	columns := []string{"block", "timestamp", "group_id", "index", "public_key", "from", "depositor", "tx_hash", "withdrawal_credential", "valid", "amount"}
+	ew, err := newExportWriter(w, export.format, export.fileName(), export.currencyColumns(columns, "amount"))
+	if err != nil {
+		abortExport(r, err)
+	}
+	for _, row := range deposits {
+		ts := time.Unix(row.Timestamp, 0).UTC()
+		amount := weiToEther(row.Amount)
+		values := []any{row.Block, ts, row.GroupId, row.Index, row.PublicKey, row.From.Hash, row.Depositor.Hash, row.TxHash, row.WithdrawalCredential, row.Valid, amount}
+		if err := ew.writeRow(export.currencyValues(values, ts, amount)); err != nil {
+			abortExport(r, err)
+		}
+	}
+	if err := ew.flush(); err != nil {
+		abortExport(r, err)
+	}
+}
+
+func (h *HandlerService) exportValidatorDashboardClDeposits(w http.ResponseWriter, r *http.Request, export validatorDashboardExport) {
+	ctx := r.Context()
+	chain := getChain(ctx)
+	startSlot := chain.TimeToSlot(export.afterTs)
+	endSlot := chain.TimeToSlot(export.beforeTs)
+
+	// like the execution layer deposits these are only available newest first
+	deposits := make([]types.VDBConsensusDepositsTableRow, 0)
+	cursor := ""
+	for {
+		rows, paging, err := h.getDataAccessor(r).GetValidatorDashboardClDeposits(ctx, export.dashboardId, cursor, maxQueryLimit)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		reachedStart := false
+		for _, row := range rows {
+			if row.Slot < startSlot {
+				reachedStart = true
+				break
+			}
+			if row.Slot > endSlot || !export.includesGroup(int64(row.GroupId)) {
+				continue
+			}
+			deposits = append(deposits, row)
+		}
+		if reachedStart || paging == nil || paging.NextCursor == "" {
+			break
+		}
+		cursor = paging.NextCursor
+	}
+	slices.Reverse(deposits)
+
+	columns := []string{"epoch", "slot", "timestamp", "group_id", "index", "public_key", "type", "consolidated_from", "withdrawal_credential", "amount"}
+	ew, err := newExportWriter(w, export.format, export.fileName(), export.currencyColumns(columns, "amount"))
+	if err != nil {
+		abortExport(r, err)
+	}
+	for _, row := range deposits {
+		ts := chain.SlotToTime(row.Slot).UTC()
+		amount := weiToEther(row.Amount)
+		values := []any{row.Epoch, row.Slot, ts, row.GroupId, row.Index, row.PublicKey, row.Type, row.ConsolidatedFrom, row.WithdrawalCredential, amount}
+		if err := ew.writeRow(export.currencyValues(values, ts, amount)); err != nil {
+			abortExport(r, err)
+		}
+	}
+	if err := ew.flush(); err != nil {
+		abortExport(r, err)
+	}
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	dataaccess "github.com/gobitfly/beaconchain/pkg/api/data_access"
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
+	"github.com/gobitfly/beaconchain/pkg/api/types"
+	commontypes "github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/shopspring/decimal"
+)
+
+func TestExportWriterCsv(t *testing.T) {
+	rec := httptest.NewRecorder()
+	ew, err := newExportWriter(rec, enums.VDBExportFormats.Csv, "export", []string{"epoch", "timestamp", "recipient", "amount", "consolidated_from"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Unix(1_700_000_000, 0)
+	if err := ew.writeRow([]any{uint64(1), ts, "a,b", decimal.RequireFromString("1.5"), (*uint64)(nil)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ew.flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := rec.Header().Get("Content-Type"); got != "text/csv; charset=utf-8" {
+		t.Errorf("unexpected content type %q", got)
+	}
+	if got := rec.Header().Get("Content-Disposition"); got != `attachment; filename="export.csv"` {
+		t.Errorf("unexpected content disposition %q", got)
+	}
+	want := "epoch,timestamp,recipient,amount,consolidated_from\n1,2023-11-14T22:13:20Z,\"a,b\",1.5,\n"
+	if got := rec.Body.String(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestExportWriterNdjson(t *testing.T) {
+	rec := httptest.NewRecorder()
+	ew, err := newExportWriter(rec, enums.VDBExportFormats.Ndjson, "export", []string{"slot", "type", "amount"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range [][]any{{uint64(2), "partial", 0.25}, {uint64(1), "full", nil}} {
+		if err := ew.writeRow(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := rec.Header().Get("Content-Disposition"); got != `attachment; filename="export.ndjson"` {
+		t.Errorf("unexpected content disposition %q", got)
+	}
+	want := `{"slot":2,"type":"partial","amount":0.25}` + "\n" + `{"slot":1,"type":"full","amount":null}` + "\n"
+	if got := rec.Body.String(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestExportWriterRejectsIncompleteRows(t *testing.T) {
+	ew, err := newExportWriter(httptest.NewRecorder(), enums.VDBExportFormats.Csv, "export", []string{"epoch", "amount"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ew.writeRow([]any{uint64(1)}); err == nil {
+		t.Errorf("expected an error for a row with fewer values than columns")
+	}
+}
+
+func TestAbortExport(t *testing.T) {
+	defer func() {
+		if r := recover(); r != http.ErrAbortHandler {
+			t.Errorf("expected abortExport to panic with http.ErrAbortHandler, got %v", r)
+		}
+	}()
+	abortExport(httptest.NewRequest(http.MethodGet, "/validator-dashboards/1/exports/rewards", nil), errors.New("connection lost"))
+}
+
+// exportTestDataAccessor returns the consensus layer deposits newest first, two per page
+type exportTestDataAccessor struct {
+	dataaccess.DataAccessor
+	clDeposits []types.VDBConsensusDepositsTableRow
+}
+
+func (d *exportTestDataAccessor) ForNetwork(chainId uint64) (dataaccess.DataAccessor, bool) {
+	return d, true
+}
+
+func (d *exportTestDataAccessor) GetValidatorDashboardClDeposits(ctx context.Context, dashboardId types.VDBId, cursor string, limit uint64) ([]types.VDBConsensusDepositsTableRow, *types.Paging, error) {
+	start := 0
+	if cursor != "" {
+		start = len(cursor)
+	}
+	end := min(start+2, len(d.clDeposits))
+	paging := &types.Paging{}
+	if end < len(d.clDeposits) {
+		paging.NextCursor = strings.Repeat("x", end)
+	}
+	return d.clDeposits[start:end], paging, nil
+}
+
+func TestExportValidatorDashboardClDeposits(t *testing.T) {
+	// the dashboard is served by an additional network, the slot times of the main network must not be used
+	utils.Config = &commontypes.Config{}
+	utils.Config.Chain.ClConfig.DepositChainID = 100
+	utils.Config.Chain.ClConfig.SecondsPerSlot = 5
+	utils.Config.Chain.ClConfig.SlotsPerEpoch = 16
+	network := commontypes.NetworkConfig{}
+	network.Chain.ClConfig.DepositChainID = 17000
+	network.Chain.ClConfig.SecondsPerSlot = 12
+	network.Chain.ClConfig.SlotsPerEpoch = 32
+	utils.Config.Networks = []commontypes.NetworkConfig{network}
+
+	deposits := []types.VDBConsensusDepositsTableRow{}
+	for _, slot := range []uint64{500, 400, 300, 200, 100} {
+		deposits = append(deposits, types.VDBConsensusDepositsTableRow{Slot: slot, Epoch: slot / 32, Type: "deposit", Amount: decimal.RequireFromString("32000000000000000000")})
+	}
+	h := &HandlerService{daService: &exportTestDataAccessor{clDeposits: deposits}}
+
+	export := validatorDashboardExport{
+		exportType: enums.VDBExportTypes.ClDeposits,
+		format:     enums.VDBExportFormats.Ndjson,
+		groupId:    types.AllGroups,
+		afterTs:    150 * 12,
+		beforeTs:   450 * 12,
+	}
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r = r.WithContext(context.WithValue(r.Context(), types.CtxChainIdKey, uint64(17000)))
+	h.exportValidatorDashboardClDeposits(rec, r, export)
+
+	// deposits outside of the range are skipped, the rest is exported oldest first
+	var slots []uint64
+	for _, line := range strings.Split(strings.TrimSpace(rec.Body.String()), "\n") {
+		var row struct {
+			Slot   uint64 `json:"slot"`
+			Amount string `json:"amount"`
+		}
+		if err := json.Unmarshal([]byte(line), &row); err != nil {
+			t.Fatalf("error decoding %q: %v", line, err)
+		}
+		if row.Amount != "32" {
+			t.Errorf("expected the amount in ether, got %s", row.Amount)
+		}
+		slots = append(slots, row.Slot)
+	}
+	if !slices.Equal(slots, []uint64{200, 300, 400}) {
+		t.Errorf("expected slots 200, 300 and 400 to be exported, got %v", slots)
+	}
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,6 +21,8 @@ import (
 	"github.com/gobitfly/beaconchain/pkg/api/services"
 	types "github.com/gobitfly/beaconchain/pkg/api/types"
 	commontypes "github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gorilla/mux"
 )
 
 type HandlerService struct {
@@ -27,6 +30,7 @@ type HandlerService struct {
 	daDummy                     dataaccess.DataAccessor
 	scs                         *scs.SessionManager
 	isPostMachineMetricsEnabled bool // if more config options are needed, consider having the whole config in here
+	apiKeyScopes                map[*mux.Route]ApiKeyScope
 }
 
 func NewHandlerService(dataAccessor dataaccess.DataAccessor, dummy dataaccess.DataAccessor, sessionManager *scs.SessionManager, enablePostMachineMetrics bool) *HandlerService {
@@ -43,19 +47,53 @@ func NewHandlerService(dataAccessor dataaccess.DataAccessor, dummy dataaccess.Da
 		daDummy:                     dummy,
 		scs:                         sessionManager,
 		isPostMachineMetricsEnabled: enablePostMachineMetrics,
+		apiKeyScopes:                make(map[*mux.Route]ApiKeyScope),
 	}
 }
 
 // getDataAccessor returns the correct data accessor based on the request context.
-// if the request is mocked, the data access dummy is returned; otherwise the data access service.
+// if the request is mocked, the data access dummy is returned; otherwise the data access service of the requested network.
 // should only be used if getting mocked data for the endpoint is appropriate
 func (h *HandlerService) getDataAccessor(r *http.Request) dataaccess.DataAccessor {
 	if isMocked(r) {
 		return h.daDummy
 	}
+	return h.getNetworkDataAccessor(r.Context())
+}
+
+// getMainDataAccessor returns the data access service of the main network, or the data access dummy if the request is mocked.
+// it knows the dashboards of all networks and should be used for data spanning all networks (e.g. dashboard counts)
+func (h *HandlerService) getMainDataAccessor(r *http.Request) dataaccess.DataAccessor {
+	if isMocked(r) {
+		return h.daDummy
+	}
+	return h.daService
+}
+
+// getNetworkDataAccessor returns the data access service of the network stored in the context (by StoreNetworkMiddleware or
+// VDBNetworkMiddleware), or the one of the main network if the request isn't bound to a network
+func (h *HandlerService) getNetworkDataAccessor(ctx context.Context) dataaccess.DataAccessor {
+	if chainId, ok := ctx.Value(types.CtxChainIdKey).(uint64); ok {
+		if network, ok := h.daService.ForNetwork(chainId); ok {
+			return network
+		}
+	}
 	return h.daService
 }
 
+// getChain returns the chain config of the network stored in the context (by StoreNetworkMiddleware or VDBNetworkMiddleware),
+// or the one of the main network if the request isn't bound to a network
+func getChain(ctx context.Context) *commontypes.Chain {
+	if chainId, ok := ctx.Value(types.CtxChainIdKey).(uint64); ok {
+		for i := range utils.Config.Networks {
+			if utils.Config.Networks[i].Chain.ClConfig.DepositChainID == chainId {
+				return &utils.Config.Networks[i].Chain
+			}
+		}
+	}
+	return &utils.Config.Chain
+}
+
 // all networks available in the system, filled on startup in NewHandlerService
 var allNetworks []types.NetworkInfo
 
@@ -121,7 +159,7 @@ func (h *HandlerService) getDashboardId(ctx context.Context, dashboardIdParam in
 		}
 		return &types.VDBId{Id: types.VDBIdPrimary(dashboardInfo.DashboardId), Validators: nil, AggregateGroups: !dashboardInfo.ShareSettings.ShareGroups}, nil
 	case validatorSet:
-		validators, err := h.daService.GetValidatorsFromSlices(ctx, dashboardId.Indexes, dashboardId.PublicKeys)
+		validators, err := h.getNetworkDataAccessor(ctx).GetValidatorsFromSlices(ctx, dashboardId.Indexes, dashboardId.PublicKeys)
 		if err != nil {
 			return nil, err
 		}
@@ -158,6 +196,33 @@ func (h *HandlerService) handleDashboardId(ctx context.Context, param string) (*
 	return dashboardId, nil
 }
 
+// getValidatorIndex is a helper function to validate a validator param, which is either an index or a public key, and resolve it to the validator index.
+func (h *HandlerService) getValidatorIndex(r *http.Request, chainId uint64, param string) (uint64, error) {
+	var v validationError
+	var validator *types.SearchValidator
+	var err error
+	switch {
+	case reInteger.MatchString(param):
+		index := v.checkUint(param, "validator")
+		if v.hasErrors() {
+			return 0, v
+		}
+		validator, err = h.getDataAccessor(r).GetSearchValidatorByIndex(r.Context(), chainId, index)
+	case reValidatorPublicKey.MatchString(param):
+		publicKey, decodeErr := hex.DecodeString(strings.TrimPrefix(param, "0x"))
+		if decodeErr != nil {
+			return 0, newBadRequestErr("given value '%s' is not a valid validator public key", param)
+		}
+		validator, err = h.getDataAccessor(r).GetSearchValidatorByPublicKey(r.Context(), chainId, publicKey)
+	default:
+		return 0, newBadRequestErr("given value '%s' is neither a valid validator index nor public key", param)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return validator.Index, nil
+}
+
 const chartDatapointLimit uint64 = 200
 
 type ChartTimeDashboardLimits struct {
@@ -179,7 +244,7 @@ func (h *HandlerService) getCurrentChartTimeLimitsForDashboard(ctx context.Conte
 	if maxAge == 0 {
 		return limits, newConflictErr("requested aggregation is not available for dashboard owner's premium subscription")
 	}
-	limits.LatestExportedTs, err = h.daService.GetLatestExportedChartTs(ctx, aggregation)
+	limits.LatestExportedTs, err = h.getNetworkDataAccessor(ctx).GetLatestExportedChartTs(ctx, aggregation)
 	if err != nil {
 		return limits, err
 	}
@@ -190,7 +255,7 @@ func (h *HandlerService) getCurrentChartTimeLimitsForDashboard(ctx context.Conte
 	return limits, nil
 }
 
-// getDashboardPremiumPerks gets the premium perks of the dashboard OWNER or if it's a guest dashboard, it returns free tier premium perks
+// getDashboardPremiumPerks gets the premium perks of the dashboard OWNER (the pooled perks for organization dashboards) or if it's a guest dashboard, it returns free tier premium perks
 func (h *HandlerService) getDashboardPremiumPerks(ctx context.Context, id types.VDBId) (*types.PremiumPerks, error) {
 	// for guest dashboards, return free tier perks
 	if id.Validators != nil {
@@ -205,6 +270,9 @@ func (h *HandlerService) getDashboardPremiumPerks(ctx context.Context, id types.
 	if err != nil {
 		return nil, err
 	}
+	if dashboardUser.OrganizationId != nil {
+		return h.daService.GetOrganizationPremiumPerks(ctx, *dashboardUser.OrganizationId)
+	}
 	userInfo, err := h.daService.GetUserInfo(ctx, dashboardUser.UserId)
 	if err != nil {
 		return nil, err
@@ -513,6 +581,7 @@ var dbEventToResponse = map[string]string{
 	string(commontypes.ValidatorGotSlashedEventName):               "validator_got_slashed",
 	string(commontypes.ValidatorDidSlashEventName):                 "validator_has_slashed",
 	string(commontypes.ValidatorGroupEfficiencyEventName):          "group_efficiency_below",
+	string(commontypes.ValidatorPoolOperationNotIncludedEventName): "pool_operation_not_included",
 	string(commontypes.RocketpoolCollateralMinReachedEventName):    "min_collateral",
 	string(commontypes.RocketpoolCollateralMaxReachedEventName):    "max_collateral",
 	string(commontypes.IncomingTransactionEventName):               "incoming_tx",
@@ -3,18 +3,24 @@ package handlers
 import (
 	"bytes"
 	"cmp"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gobitfly/beaconchain/pkg/api/enums"
 	"github.com/gobitfly/beaconchain/pkg/api/types"
+	commontypes "github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/reports"
 	"github.com/gorilla/mux"
 	"github.com/invopop/jsonschema"
 	"github.com/shopspring/decimal"
@@ -32,6 +38,7 @@ var (
 	reValidatorPublicKey           = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{96}$`)
 	reValidatorList                = regexp.MustCompile(`^(0x[0-9a-fA-F]{96}|[0-9]+)(,\s*(0x[0-9a-fA-F]{96}|[0-9]+)\s*)+$`)
 	reEthereumAddress              = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{40}$`)
+	reEthereumAddressPrefix        = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{0,40}$`)
 	reWithdrawalCredential         = regexp.MustCompile(`^(0x0[01])?[0-9a-fA-F]{62}$`)
 	reEnsName                      = regexp.MustCompile(`^.+\.eth$`)
 	reGraffiti                     = regexp.MustCompile(`^.{2,}$`)          // at least 2 characters, so that queries won't time out
@@ -40,11 +47,19 @@ var (
 	rePassword                     = regexp.MustCompile(`^.{5,}$`)
 	reEmailUserToken               = regexp.MustCompile(`^[a-z0-9]{40}$`)
 	reJsonContentType              = regexp.MustCompile(`^application\/json(;.*)?$`)
+	reSlackWebhookUrl              = regexp.MustCompile(`^https://hooks\.slack\.com/services/[A-Za-z0-9/_-]+$`)
+	reMatrixRoomId                 = regexp.MustCompile(`^![A-Za-z0-9._=\-/+]+:[A-Za-z0-9.\-]+(:[0-9]+)?$`)
+	rePkceCodeChallenge            = regexp.MustCompile(`^[A-Za-z0-9_-]{43}$`) // base64url encoded sha256, see RFC 7636
+	rePkceCodeVerifier             = regexp.MustCompile(`^[A-Za-z0-9\-._~]{43,128}$`)
+	reSessionId                    = regexp.MustCompile(`^[0-9a-f]{64}$`) // hex encoded sha256 of the session token
+	reMetricName                   = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	reUuid                         = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
 )
 
 const (
 	maxNameLength                     = 50
 	maxValidatorsInList               = 20
+	maxAccountsInList                 = 100
 	maxQueryLimit              uint64 = 100
 	defaultReturnLimit         uint64 = 10
 	sortOrderAscending                = "asc"
@@ -56,6 +71,8 @@ const (
 	allowEmpty                        = true
 	forbidEmpty                       = false
 	MaxArchivedDashboardsCount        = 10
+	maxOAuthAppNameLength             = 35
+	maxOAuthRedirectUriLength         = 100
 )
 
 // All changes to common functions MUST NOT break any public handler behavior (not in effect yet)
@@ -261,6 +278,10 @@ func (v *validationError) checkPrimaryDashboardId(param string) types.VDBIdPrima
 	return types.VDBIdPrimary(v.checkUint(param, "dashboard_id"))
 }
 
+func (v *validationError) checkAccountDashboardId(param string) uint64 {
+	return v.checkUint(param, "dashboard_id")
+}
+
 // helper function to unify handling of block detail request validation
 func (h *HandlerService) validateBlockRequest(r *http.Request, paramName string) (uint64, uint64, error) {
 	var v validationError
@@ -272,9 +293,9 @@ func (h *HandlerService) validateBlockRequest(r *http.Request, paramName string)
 	case "latest":
 		ctx := r.Context()
 		if paramName == "block" {
-			value, err = h.daService.GetLatestBlock(ctx)
+			value, err = h.getDataAccessor(r).GetLatestBlock(ctx)
 		} else if paramName == "slot" {
-			value, err = h.daService.GetLatestSlot(ctx)
+			value, err = h.getDataAccessor(r).GetLatestSlot(ctx)
 		}
 		if err != nil {
 			return 0, 0, err
@@ -340,10 +361,146 @@ func checkMinMax[T cmp.Ordered](v *validationError, param T, min T, max T, param
 	return param
 }
 
+// checkNotificationDeliveryMode returns the given delivery mode, an empty mode defaults to immediate delivery
+func (v *validationError) checkNotificationDeliveryMode(mode string, paramName string) string {
+	if mode == "" {
+		return string(commontypes.NotificationDeliveryImmediate)
+	}
+	if !slices.Contains(commontypes.NotificationDeliveryModes, commontypes.NotificationDeliveryMode(mode)) {
+		v.add(paramName, fmt.Sprintf("given value '%s' is not a valid delivery mode", mode))
+	}
+	return mode
+}
+
+func (v *validationError) checkTimezone(timezone string, paramName string) string {
+	if timezone == "" {
+		return "UTC"
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		v.add(paramName, fmt.Sprintf("given value '%s' is not a valid IANA time zone", timezone))
+	}
+	return timezone
+}
+
+func (v *validationError) checkSlackWebhookUrl(webhookUrl string, allowEmpty bool) string {
+	if allowEmpty && webhookUrl == "" {
+		return ""
+	}
+	return v.checkRegex(reSlackWebhookUrl, webhookUrl, "slack_webhook_url")
+}
+
+func (v *validationError) checkMatrixRoomId(roomId string, allowEmpty bool) string {
+	if allowEmpty && roomId == "" {
+		return ""
+	}
+	return v.checkRegex(reMatrixRoomId, roomId, "matrix_room_id")
+}
+
+// checkOAuthRedirectUri only allows absolute uris without fragment. Plain http is only allowed for loopback addresses,
+// custom schemes are allowed for native apps, see RFC 8252.
+func (v *validationError) checkOAuthRedirectUri(redirectUri string) string {
+	if len(redirectUri) > maxOAuthRedirectUriLength {
+		v.add("redirect_uri", fmt.Sprintf("given value '%s' is too long, maximum length is %d", redirectUri, maxOAuthRedirectUriLength))
+		return redirectUri
+	}
+	u, err := url.Parse(redirectUri)
+	if err != nil || !u.IsAbs() || u.Fragment != "" {
+		v.add("redirect_uri", fmt.Sprintf("given value '%s' is not an absolute uri without fragment", redirectUri))
+		return redirectUri
+	}
+	if u.Scheme == "http" && u.Hostname() != "localhost" && u.Hostname() != "127.0.0.1" && u.Hostname() != "::1" {
+		v.add("redirect_uri", fmt.Sprintf("given value '%s' must use https", redirectUri))
+	}
+	return redirectUri
+}
+
+// checkOAuthScopes parses the space separated scope parameter, duplicates are removed
+func (v *validationError) checkOAuthScopes(scope string) []enums.ApiKeyScope {
+	var scopes []enums.ApiKeyScope
+	for _, s := range strings.Fields(scope) {
+		checkedScope := checkEnum[enums.ApiKeyScope](v, s, "scope")
+		if !slices.Contains(scopes, checkedScope) {
+			scopes = append(scopes, checkedScope)
+		}
+	}
+	if len(scopes) == 0 {
+		v.add("scope", "at least one scope is required")
+	}
+	return scopes
+}
+
+func (v *validationError) checkBase64Url(param, paramName string) []byte {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(param, "="))
+	if err != nil || len(decoded) == 0 {
+		v.add(paramName, fmt.Sprintf(`given value '%s' is not valid base64url`, param))
+	}
+	return decoded
+}
+
 func (v *validationError) checkAddress(publicId string) string {
 	return v.checkRegex(reEthereumAddress, publicId, "address")
 }
 
+// checkAddressBytes validates the given address and returns it decoded.
+func (v *validationError) checkAddressBytes(param string, paramName string) []byte {
+	decoded, err := hex.DecodeString(strings.TrimPrefix(param, "0x"))
+	if !reEthereumAddress.MatchString(param) || err != nil {
+		v.add(paramName, fmt.Sprintf("given value '%s' is not a valid address", param))
+		return nil
+	}
+	return decoded
+}
+
+func (v *validationError) checkAddresses(addresses []string, allowEmpty bool) [][]byte {
+	if len(addresses) == 0 && !allowEmpty {
+		v.add("addresses", "list of addresses must not be empty")
+		return nil
+	}
+	if len(addresses) > maxAccountsInList {
+		v.add("addresses", fmt.Sprintf("too many addresses in list, maximum is %d", maxAccountsInList))
+		return nil
+	}
+	var result [][]byte
+	for _, address := range addresses {
+		result = append(result, v.checkAddressBytes(address, "addresses"))
+	}
+	return result
+}
+
+// checkAddressSearch validates that the search string is a (prefix of a) hex encoded address and returns it lower cased.
+func (v *validationError) checkAddressSearch(search string) string {
+	if search == "" {
+		return ""
+	}
+	return strings.ToLower(v.checkRegex(reEthereumAddressPrefix, search, "search"))
+}
+
+// checkWithdrawalCredentialOrAddress validates the given withdrawal credential or withdrawal address and returns the matching withdrawal credentials.
+// A withdrawal address is converted to the corresponding 0x01 and compounding 0x02 withdrawal credentials.
+func (v *validationError) checkWithdrawalCredentialOrAddress(param string) [][]byte {
+	var credentialHexes []string
+	switch {
+	case reEthereumAddress.MatchString(param):
+		address := strings.TrimPrefix(param, "0x")
+		credentialHexes = []string{"010000000000000000000000" + address, "020000000000000000000000" + address}
+	case reWithdrawalCredential.MatchString(param):
+		credentialHexes = []string{strings.TrimPrefix(param, "0x")}
+	default:
+		v.add("credential", fmt.Sprintf("given value '%s' is neither a valid withdrawal credential nor address", param))
+		return nil
+	}
+	credentials := make([][]byte, 0, len(credentialHexes))
+	for _, credentialHex := range credentialHexes {
+		credential, err := hex.DecodeString(credentialHex)
+		if err != nil {
+			v.add("credential", fmt.Sprintf("given value '%s' is neither a valid withdrawal credential nor address", param))
+			return nil
+		}
+		credentials = append(credentials, credential)
+	}
+	return credentials
+}
+
 func (v *validationError) checkUintMinMax(param string, min uint64, max uint64, paramName string) uint64 {
 	return checkMinMax(v, v.checkUint(param, paramName), min, max, paramName)
 }
@@ -424,16 +581,27 @@ func (v *validationError) checkProtocolModes(protocolModes string) types.VDBProt
 	}
 	protocolsSlice := splitParameters(protocolModes, ',')
 	for _, protocolMode := range protocolsSlice {
-		switch protocolMode {
-		case "rocket_pool":
-			modes.RocketPool = true
-		default:
+		protocol := enums.StakingProtocol(0).NewFromString(protocolMode)
+		if enums.IsInvalidEnum(protocol) {
 			v.add("modes", fmt.Sprintf("given value '%s' is not a valid protocol mode", protocolMode))
+			continue
+		}
+		if !modes.IsEnabled(protocol) {
+			modes.Protocols = append(modes.Protocols, protocol)
 		}
 	}
 	return modes
 }
 
+// checkConfigurableStakingProtocol validates a staking protocol whose operator share can be configured on a dashboard
+func (v *validationError) checkConfigurableStakingProtocol(protocol string) enums.StakingProtocol {
+	stakingProtocol := checkEnum[enums.StakingProtocol](v, protocol, "protocol")
+	if stakingProtocol == enums.StakingProtocols.RocketPool {
+		v.add("protocol", "the operator share of rocket_pool validators is derived from their minipools and can't be configured")
+	}
+	return stakingProtocol
+}
+
 func (v *validationError) checkValidatorList(validators string, allowEmpty bool) ([]types.VDBValidator, []string) {
 	if validators == "" && !allowEmpty {
 		v.add("validators", "list of validators must not be empty")
@@ -523,6 +691,39 @@ func isValidNetwork(network intOrString) (uint64, bool) {
 	return 0, false
 }
 
+// checkExportTimestamps validates the optional `after_ts` and `before_ts` parameters of an export, which must not lie apart more than maxInterval seconds.
+// If they are missing the export covers the last 30 days (or maxInterval if that is shorter) up to `before_ts` or now.
+func (v *validationError) checkExportTimestamps(q url.Values, maxInterval uint64) (after uint64, before uint64) {
+	before = uint64(time.Now().Unix())
+	if beforeParam := q.Get("before_ts"); beforeParam != "" {
+		before = v.checkUint(beforeParam, "before_ts")
+	}
+	after = before - min(exportDefaultInterval, maxInterval, before)
+	if afterParam := q.Get("after_ts"); afterParam != "" {
+		after = v.checkUint(afterParam, "after_ts")
+	}
+
+	if after > before {
+		v.add("after_ts", "parameter `after_ts` must not be greater than `before_ts`")
+	} else if before-after > maxInterval {
+		v.add("before_ts", fmt.Sprintf("parameters `after_ts` and `before_ts` must not lie apart more than %d seconds", maxInterval))
+	}
+	return after, before
+}
+
+// checkFiscalPeriod parses the fiscal period of an income report, which starts at the beginning of `start_month` (defaults to January) of `year` and lasts `months` (defaults to 12) months
+func (v *validationError) checkFiscalPeriod(q url.Values) reports.Period {
+	year := v.checkUintMinMax(q.Get("year"), 2020, 9999, "year")
+	startMonth, months := uint64(1), uint64(12)
+	if param := q.Get("start_month"); param != "" {
+		startMonth = v.checkUintMinMax(param, 1, 12, "start_month")
+	}
+	if param := q.Get("months"); param != "" {
+		months = v.checkUintMinMax(param, 1, 12, "months")
+	}
+	return reports.NewFiscalPeriod(int(year), time.Month(startMonth), int(months))
+}
+
 func (v *validationError) checkTimestamps(r *http.Request, chartLimits ChartTimeDashboardLimits) (after uint64, before uint64) {
 	afterParam := r.URL.Query().Get("after_ts")
 	beforeParam := r.URL.Query().Get("before_ts")
@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gobitfly/beaconchain/pkg/api/enums"
 	types "github.com/gobitfly/beaconchain/pkg/api/types"
@@ -242,6 +244,33 @@ func (h *HandlerService) InternalDeleteAdConfiguration(w http.ResponseWriter, r
 	returnNoContent(w, r)
 }
 
+// --------------------------------------
+// Monitoring
+
+func (h *HandlerService) InternalGetMonitoringStatus(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if user.UserGroup != types.UserGroupAdmin {
+		returnForbidden(w, r, errors.New("user is not an admin"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	data, err := h.getDataAccessor(r).GetMonitoringStatus(ctx)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetMonitoringStatusResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
+}
+
 // --------------------------------------
 // User
 
@@ -270,63 +299,71 @@ func (h *HandlerService) InternalGetUserDashboards(w http.ResponseWriter, r *htt
 	h.PublicGetUserDashboards(w, r)
 }
 
+func (h *HandlerService) InternalGetUserApiUsage(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetUserApiUsage(w, r)
+}
+
 // --------------------------------------
 // Account Dashboards
 
 func (h *HandlerService) InternalPostAccountDashboards(w http.ResponseWriter, r *http.Request) {
-	returnCreated(w, r, nil)
+	h.PublicPostAccountDashboards(w, r)
 }
 
 func (h *HandlerService) InternalGetAccountDashboard(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	h.PublicGetAccountDashboard(w, r)
 }
 
 func (h *HandlerService) InternalDeleteAccountDashboard(w http.ResponseWriter, r *http.Request) {
-	returnNoContent(w, r)
+	h.PublicDeleteAccountDashboard(w, r)
+}
+
+func (h *HandlerService) InternalPutAccountDashboardName(w http.ResponseWriter, r *http.Request) {
+	h.PublicPutAccountDashboardName(w, r)
 }
 
 func (h *HandlerService) InternalPostAccountDashboardGroups(w http.ResponseWriter, r *http.Request) {
-	returnCreated(w, r, nil)
+	h.PublicPostAccountDashboardGroups(w, r)
 }
 
 func (h *HandlerService) InternalDeleteAccountDashboardGroups(w http.ResponseWriter, r *http.Request) {
-	returnNoContent(w, r)
+	h.PublicDeleteAccountDashboardGroups(w, r)
 }
 
 func (h *HandlerService) InternalPostAccountDashboardAccounts(w http.ResponseWriter, r *http.Request) {
-	returnCreated(w, r, nil)
+	h.PublicPostAccountDashboardAccounts(w, r)
 }
 
 func (h *HandlerService) InternalGetAccountDashboardAccounts(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	h.PublicGetAccountDashboardAccounts(w, r)
 }
 
 func (h *HandlerService) InternalDeleteAccountDashboardAccounts(w http.ResponseWriter, r *http.Request) {
-	returnNoContent(w, r)
+	h.PublicDeleteAccountDashboardAccounts(w, r)
 }
 
 func (h *HandlerService) InternalPutAccountDashboardAccount(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	h.PublicPutAccountDashboardAccount(w, r)
 }
 
 func (h *HandlerService) InternalPostAccountDashboardPublicIds(w http.ResponseWriter, r *http.Request) {
-	returnCreated(w, r, nil)
+	h.PublicPostAccountDashboardPublicIds(w, r)
 }
 
 func (h *HandlerService) InternalPutAccountDashboardPublicId(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	h.PublicPutAccountDashboardPublicId(w, r)
 }
 
 func (h *HandlerService) InternalDeleteAccountDashboardPublicId(w http.ResponseWriter, r *http.Request) {
-	returnNoContent(w, r)
+	h.PublicDeleteAccountDashboardPublicId(w, r)
 }
 
 func (h *HandlerService) InternalGetAccountDashboardTransactions(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	h.PublicGetAccountDashboardTransactions(w, r)
 }
 
 func (h *HandlerService) InternalPutAccountDashboardTransactionsSettings(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	h.PublicPutAccountDashboardTransactionsSettings(w, r)
 }
 
 // --------------------------------------
@@ -348,6 +385,14 @@ func (h *HandlerService) InternalPutValidatorDashboardName(w http.ResponseWriter
 	h.PublicPutValidatorDashboardName(w, r)
 }
 
+func (h *HandlerService) InternalPutValidatorDashboardOperatorShare(w http.ResponseWriter, r *http.Request) {
+	h.PublicPutValidatorDashboardOperatorShare(w, r)
+}
+
+func (h *HandlerService) InternalDeleteValidatorDashboardOperatorShare(w http.ResponseWriter, r *http.Request) {
+	h.PublicDeleteValidatorDashboardOperatorShare(w, r)
+}
+
 func (h *HandlerService) InternalPostValidatorDashboardGroups(w http.ResponseWriter, r *http.Request) {
 	h.PublicPostValidatorDashboardGroups(w, r)
 }
@@ -388,7 +433,7 @@ func (h *HandlerService) InternalGetValidatorDashboardMobileValidators(w http.Re
 		handleErr(w, r, v)
 		return
 	}
-	data, paging, err := h.daService.GetValidatorDashboardMobileValidators(r.Context(), *dashboardId, groupId, period, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit)
+	data, paging, err := h.getNetworkDataAccessor(r.Context()).GetValidatorDashboardMobileValidators(r.Context(), *dashboardId, groupId, period, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit)
 	if err != nil {
 		handleErr(w, r, err)
 		return
@@ -424,6 +469,10 @@ func (h *HandlerService) InternalGetValidatorDashboardSlotViz(w http.ResponseWri
 	h.PublicGetValidatorDashboardSlotViz(w, r)
 }
 
+func (h *HandlerService) InternalGetValidatorDashboardEvents(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetValidatorDashboardEvents(w, r)
+}
+
 func (h *HandlerService) InternalGetValidatorDashboardSummary(w http.ResponseWriter, r *http.Request) {
 	h.PublicGetValidatorDashboardSummary(w, r)
 }
@@ -488,10 +537,22 @@ func (h *HandlerService) InternalGetValidatorDashboardWithdrawals(w http.Respons
 	h.PublicGetValidatorDashboardWithdrawals(w, r)
 }
 
+func (h *HandlerService) InternalGetValidatorDashboardExport(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetValidatorDashboardExport(w, r)
+}
+
+func (h *HandlerService) InternalGetValidatorDashboardIncomeReport(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetValidatorDashboardIncomeReport(w, r)
+}
+
 func (h *HandlerService) InternalGetValidatorDashboardTotalWithdrawals(w http.ResponseWriter, r *http.Request) {
 	h.PublicGetValidatorDashboardTotalWithdrawals(w, r)
 }
 
+func (h *HandlerService) InternalGetValidatorDashboardPoolOperations(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetValidatorDashboardPoolOperations(w, r)
+}
+
 func (h *HandlerService) InternalGetValidatorDashboardRocketPool(w http.ResponseWriter, r *http.Request) {
 	h.PublicGetValidatorDashboardRocketPool(w, r)
 }
@@ -526,7 +587,7 @@ func (h *HandlerService) InternalGetValidatorDashboardMobileWidget(w http.Respon
 		returnForbidden(w, r, errors.New("user does not have access to mobile app widget"))
 		return
 	}
-	data, err := h.daService.GetValidatorDashboardMobileWidget(r.Context(), dashboardId)
+	data, err := h.getNetworkDataAccessor(r.Context()).GetValidatorDashboardMobileWidget(r.Context(), dashboardId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
@@ -662,6 +723,38 @@ func (h *HandlerService) InternalPostUserNotificationsTestWebhook(w http.Respons
 	h.PublicPostUserNotificationsTestWebhook(w, r)
 }
 
+func (h *HandlerService) InternalPostUserNotificationsTestTelegram(w http.ResponseWriter, r *http.Request) {
+	h.PublicPostUserNotificationsTestTelegram(w, r)
+}
+
+func (h *HandlerService) InternalPostUserNotificationsTestSlack(w http.ResponseWriter, r *http.Request) {
+	h.PublicPostUserNotificationsTestSlack(w, r)
+}
+
+func (h *HandlerService) InternalPostUserNotificationsTestMatrix(w http.ResponseWriter, r *http.Request) {
+	h.PublicPostUserNotificationsTestMatrix(w, r)
+}
+
+func (h *HandlerService) InternalPostUserNotificationsTelegramLink(w http.ResponseWriter, r *http.Request) {
+	h.PublicPostUserNotificationsTelegramLink(w, r)
+}
+
+func (h *HandlerService) InternalDeleteUserNotificationsTelegramLink(w http.ResponseWriter, r *http.Request) {
+	h.PublicDeleteUserNotificationsTelegramLink(w, r)
+}
+
+func (h *HandlerService) InternalPostUserNotificationSettingsValidatorDashboardWebhookSecret(w http.ResponseWriter, r *http.Request) {
+	h.PublicPostUserNotificationSettingsValidatorDashboardWebhookSecret(w, r)
+}
+
+func (h *HandlerService) InternalGetUserNotificationWebhookDeadLetters(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetUserNotificationWebhookDeadLetters(w, r)
+}
+
+func (h *HandlerService) InternalPostUserNotificationWebhookDeadLetterRedeliveries(w http.ResponseWriter, r *http.Request) {
+	h.PublicPostUserNotificationWebhookDeadLetterRedeliveries(w, r)
+}
+
 // --------------------------------------
 // Blocks
 
@@ -672,7 +765,7 @@ func (h *HandlerService) InternalGetBlock(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	data, err := h.daService.GetBlock(r.Context(), chainId, block)
+	data, err := h.getDataAccessor(r).GetBlock(r.Context(), chainId, block)
 	if err != nil {
 		handleErr(w, r, err)
 		return
@@ -685,154 +778,35 @@ func (h *HandlerService) InternalGetBlock(w http.ResponseWriter, r *http.Request
 }
 
 func (h *HandlerService) InternalGetBlockOverview(w http.ResponseWriter, r *http.Request) {
-	chainId, block, err := h.validateBlockRequest(r, "block")
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	data, err := h.daService.GetBlockOverview(r.Context(), chainId, block)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-	response := types.InternalGetBlockOverviewResponse{
-		Data: *data,
-	}
-	returnOk(w, r, response)
+	h.PublicGetNetworkBlock(w, r)
 }
 
 func (h *HandlerService) InternalGetBlockTransactions(w http.ResponseWriter, r *http.Request) {
-	chainId, block, err := h.validateBlockRequest(r, "block")
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	data, err := h.daService.GetBlockTransactions(r.Context(), chainId, block)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	response := types.InternalGetBlockTransactionsResponse{
-		Data: data,
-	}
-	returnOk(w, r, response)
+	h.PublicGetNetworkBlockTransactions(w, r)
 }
 
 func (h *HandlerService) InternalGetBlockVotes(w http.ResponseWriter, r *http.Request) {
-	chainId, block, err := h.validateBlockRequest(r, "block")
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	data, err := h.daService.GetBlockVotes(r.Context(), chainId, block)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	response := types.InternalGetBlockVotesResponse{
-		Data: data,
-	}
-	returnOk(w, r, response)
+	h.PublicGetNetworkBlockVotes(w, r)
 }
 
 func (h *HandlerService) InternalGetBlockAttestations(w http.ResponseWriter, r *http.Request) {
-	chainId, block, err := h.validateBlockRequest(r, "block")
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	data, err := h.daService.GetBlockAttestations(r.Context(), chainId, block)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	response := types.InternalGetBlockAttestationsResponse{
-		Data: data,
-	}
-	returnOk(w, r, response)
+	h.PublicGetNetworkBlockAttestations(w, r)
 }
 
 func (h *HandlerService) InternalGetBlockWithdrawals(w http.ResponseWriter, r *http.Request) {
-	chainId, block, err := h.validateBlockRequest(r, "block")
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	data, err := h.daService.GetBlockWithdrawals(r.Context(), chainId, block)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	response := types.InternalGetBlockWtihdrawalsResponse{
-		Data: data,
-	}
-	returnOk(w, r, response)
+	h.PublicGetNetworkBlockWithdrawals(w, r)
 }
 
 func (h *HandlerService) InternalGetBlockBlsChanges(w http.ResponseWriter, r *http.Request) {
-	chainId, block, err := h.validateBlockRequest(r, "block")
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	data, err := h.daService.GetBlockBlsChanges(r.Context(), chainId, block)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	response := types.InternalGetBlockBlsChangesResponse{
-		Data: data,
-	}
-	returnOk(w, r, response)
+	h.PublicGetNetworkBlockBlsChanges(w, r)
 }
 
 func (h *HandlerService) InternalGetBlockVoluntaryExits(w http.ResponseWriter, r *http.Request) {
-	chainId, block, err := h.validateBlockRequest(r, "block")
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	data, err := h.daService.GetBlockVoluntaryExits(r.Context(), chainId, block)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	response := types.InternalGetBlockVoluntaryExitsResponse{
-		Data: data,
-	}
-	returnOk(w, r, response)
+	h.PublicGetNetworkBlockVoluntaryExits(w, r)
 }
 
 func (h *HandlerService) InternalGetBlockBlobs(w http.ResponseWriter, r *http.Request) {
-	chainId, block, err := h.validateBlockRequest(r, "block")
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	data, err := h.daService.GetBlockBlobs(r.Context(), chainId, block)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	response := types.InternalGetBlockBlobsResponse{
-		Data: data,
-	}
-	returnOk(w, r, response)
+	h.PublicGetNetworkBlockBlobs(w, r)
 }
 
 // --------------------------------------
@@ -845,7 +819,7 @@ func (h *HandlerService) InternalGetSlot(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	data, err := h.daService.GetSlot(r.Context(), chainId, block)
+	data, err := h.getDataAccessor(r).GetSlot(r.Context(), chainId, block)
 	if err != nil {
 		handleErr(w, r, err)
 		return
@@ -858,154 +832,35 @@ func (h *HandlerService) InternalGetSlot(w http.ResponseWriter, r *http.Request)
 }
 
 func (h *HandlerService) InternalGetSlotOverview(w http.ResponseWriter, r *http.Request) {
-	chainId, block, err := h.validateBlockRequest(r, "slot")
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	data, err := h.daService.GetSlotOverview(r.Context(), chainId, block)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-	response := types.InternalGetBlockOverviewResponse{
-		Data: *data,
-	}
-	returnOk(w, r, response)
+	h.PublicGetNetworkSlot(w, r)
 }
 
 func (h *HandlerService) InternalGetSlotTransactions(w http.ResponseWriter, r *http.Request) {
-	chainId, block, err := h.validateBlockRequest(r, "slot")
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	data, err := h.daService.GetSlotTransactions(r.Context(), chainId, block)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	response := types.InternalGetBlockTransactionsResponse{
-		Data: data,
-	}
-	returnOk(w, r, response)
+	h.PublicGetNetworkSlotTransactions(w, r)
 }
 
 func (h *HandlerService) InternalGetSlotVotes(w http.ResponseWriter, r *http.Request) {
-	chainId, block, err := h.validateBlockRequest(r, "slot")
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	data, err := h.daService.GetSlotVotes(r.Context(), chainId, block)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	response := types.InternalGetBlockVotesResponse{
-		Data: data,
-	}
-	returnOk(w, r, response)
+	h.PublicGetNetworkSlotVotes(w, r)
 }
 
 func (h *HandlerService) InternalGetSlotAttestations(w http.ResponseWriter, r *http.Request) {
-	chainId, block, err := h.validateBlockRequest(r, "slot")
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	data, err := h.daService.GetSlotAttestations(r.Context(), chainId, block)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	response := types.InternalGetBlockAttestationsResponse{
-		Data: data,
-	}
-	returnOk(w, r, response)
+	h.PublicGetNetworkSlotAttestations(w, r)
 }
 
 func (h *HandlerService) InternalGetSlotWithdrawals(w http.ResponseWriter, r *http.Request) {
-	chainId, block, err := h.validateBlockRequest(r, "slot")
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	data, err := h.daService.GetSlotWithdrawals(r.Context(), chainId, block)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	response := types.InternalGetBlockWtihdrawalsResponse{
-		Data: data,
-	}
-	returnOk(w, r, response)
+	h.PublicGetNetworkSlotWithdrawals(w, r)
 }
 
 func (h *HandlerService) InternalGetSlotBlsChanges(w http.ResponseWriter, r *http.Request) {
-	chainId, block, err := h.validateBlockRequest(r, "slot")
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	data, err := h.daService.GetSlotBlsChanges(r.Context(), chainId, block)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	response := types.InternalGetBlockBlsChangesResponse{
-		Data: data,
-	}
-	returnOk(w, r, response)
+	h.PublicGetNetworkSlotBlsChanges(w, r)
 }
 
 func (h *HandlerService) InternalGetSlotVoluntaryExits(w http.ResponseWriter, r *http.Request) {
-	chainId, block, err := h.validateBlockRequest(r, "slot")
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	data, err := h.daService.GetSlotVoluntaryExits(r.Context(), chainId, block)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	response := types.InternalGetBlockVoluntaryExitsResponse{
-		Data: data,
-	}
-	returnOk(w, r, response)
+	h.PublicGetNetworkSlotVoluntaryExits(w, r)
 }
 
 func (h *HandlerService) InternalGetSlotBlobs(w http.ResponseWriter, r *http.Request) {
-	chainId, block, err := h.validateBlockRequest(r, "slot")
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	data, err := h.daService.GetSlotBlobs(r.Context(), chainId, block)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	response := types.InternalGetBlockBlobsResponse{
-		Data: data,
-	}
-	returnOk(w, r, response)
+	h.PublicGetNetworkSlotBlobs(w, r)
 }
 
 func (h *HandlerService) ReturnOk(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,842 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	dataaccess "github.com/gobitfly/beaconchain/pkg/api/data_access"
+	"github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/mail"
+	"github.com/gobitfly/beaconchain/pkg/commons/ratelimit"
+	commonTypes "github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gorilla/mux"
+)
+
+const (
+	loginMaxFailedAttempts        = 10
+	loginFailureWindow            = 15 * time.Minute
+	loginLockDuration             = 15 * time.Minute
+	secondFactorTimeout           = 5 * time.Minute
+	webAuthnTimeout               = 2 * time.Minute
+	webAuthnChallengeLength       = 32
+	maxWebAuthnCredentialsPerUser = 10
+
+	secondFactorTotp         = "totp"
+	secondFactorRecoveryCode = "recovery_code"
+	secondFactorWebAuthn     = "webauthn"
+
+	sessionTypeWeb    = "web"
+	sessionTypeMobile = "mobile"
+)
+
+var errLoginLocked = newTooManyRequestsErr("too many failed login attempts, try again later")
+var errInvalidSecondFactor = newUnauthorizedErr("invalid second factor")
+
+// --------------------------------------
+//   Sessions & lockout
+
+// authenticateSession grants the privileges of the user to the (already renewed) session
+func (h *HandlerService) authenticateSession(r *http.Request, user *types.UserCredentialInfo) {
+	ctx := r.Context()
+	h.scs.Put(ctx, authenticatedKey, true)
+	h.scs.Put(ctx, userIdKey, user.Id)
+	h.scs.Put(ctx, subscriptionKey, user.ProductId)
+	h.scs.Put(ctx, userGroupKey, user.UserGroup)
+	h.scs.Put(ctx, sessionCreatedKey, time.Now().Unix())
+	h.scs.Put(ctx, sessionUserAgentKey, r.UserAgent())
+	h.scs.Put(ctx, sessionIpKey, ratelimit.GetIP(r))
+
+	if err := h.daService.ResetUserLoginFailures(ctx, user.Id); err != nil {
+		log.Error(err, "error resetting failed login attempts", 0, map[string]interface{}{"user_id": user.Id})
+	}
+}
+
+// recordLoginFailure counts a failed password or second factor attempt and locks the login once the limit is reached
+func (h *HandlerService) recordLoginFailure(ctx context.Context, user *types.UserCredentialInfo) {
+	failedAttempts, err := h.daService.RecordUserLoginFailure(ctx, user.Id, loginFailureWindow)
+	if err != nil {
+		log.Error(err, "error recording failed login attempt", 0, map[string]interface{}{"user_id": user.Id})
+		return
+	}
+	if failedAttempts < loginMaxFailedAttempts {
+		return
+	}
+	if err := h.daService.LockUserLogin(ctx, user.Id, loginLockDuration); err != nil {
+		log.Error(err, "error locking user login", 0, map[string]interface{}{"user_id": user.Id})
+		return
+	}
+	if err := sendLoginLockedEmail(user.Email, failedAttempts); err != nil {
+		log.Error(err, "error sending login lockout email", 0, map[string]interface{}{"user_id": user.Id})
+	}
+}
+
+func sendLoginLockedEmail(email string, failedAttempts uint64) error {
+	subject := fmt.Sprintf("%s: Too many failed login attempts", utils.Config.Frontend.SiteDomain)
+	msg := fmt.Sprintf(`There were %[2]d failed login attempts for your account on %[1]s, logging in has been disabled for %[3]d minutes.
+
+If this wasn't you, somebody might be trying to guess your password. Consider changing your password and enabling two-factor authentication in your account settings.
+
+Best regards,
+
+%[1]s
+`, utils.Config.Frontend.SiteDomain, failedAttempts, int(loginLockDuration.Minutes()))
+	return mail.SendTextMail(email, subject, msg, []commonTypes.EmailAttachment{})
+}
+
+func (h *HandlerService) checkLoginLock(ctx context.Context, userId uint64) error {
+	locked, err := h.daService.IsUserLoginLocked(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if locked {
+		return errLoginLocked
+	}
+	return nil
+}
+
+// getSecondFactorMethods returns the second factors the user has to choose from during login, empty if 2FA is disabled
+func (h *HandlerService) getSecondFactorMethods(ctx context.Context, userId uint64) ([]string, error) {
+	var methods []string
+	totp, err := h.daService.GetUserTotp(ctx, userId)
+	if err != nil && !errors.Is(err, dataaccess.ErrNotFound) {
+		return nil, err
+	}
+	if err == nil && totp.Confirmed {
+		methods = append(methods, secondFactorTotp)
+	}
+	credentials, err := h.daService.GetUserWebAuthnCredentials(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if len(credentials) > 0 {
+		methods = append(methods, secondFactorWebAuthn)
+	}
+	if len(methods) == 0 {
+		// recovery codes only replace other factors
+		return nil, nil
+	}
+	recoveryCodes, err := h.daService.GetUserRecoveryCodeCount(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if recoveryCodes > 0 {
+		methods = append(methods, secondFactorRecoveryCode)
+	}
+	return methods, nil
+}
+
+// getPendingSecondFactorUserId returns the user that passed the password check in this session but still has to provide a second factor
+func (h *HandlerService) getPendingSecondFactorUserId(ctx context.Context) (uint64, error) {
+	userId, ok := h.scs.Get(ctx, secondFactorUserIdKey).(uint64)
+	if !ok {
+		return 0, newUnauthorizedErr("no pending login")
+	}
+	if time.Now().Unix() > h.scs.GetInt64(ctx, secondFactorDeadlineKey) {
+		h.scs.Remove(ctx, secondFactorUserIdKey)
+		h.scs.Remove(ctx, secondFactorDeadlineKey)
+		return 0, newUnauthorizedErr("login timed out, please log in again")
+	}
+	return userId, nil
+}
+
+// --------------------------------------
+//   WebAuthn helpers
+
+// the relying party is the frontend domain, passkeys are bound to it
+func getWebAuthnRelyingParty() (rpId string, origin string) {
+	siteDomain := utils.Config.Frontend.SiteDomain
+	rpId = strings.Split(siteDomain, ":")[0]
+	scheme := "https"
+	if rpId == "localhost" {
+		scheme = "http"
+	}
+	return rpId, scheme + "://" + siteDomain
+}
+
+// newWebAuthnChallenge creates a random challenge and stores it in the session, it can be used for a single ceremony only
+func (h *HandlerService) newWebAuthnChallenge(ctx context.Context) ([]byte, error) {
+	challenge := make([]byte, webAuthnChallengeLength)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, err
+	}
+	h.scs.Put(ctx, webAuthnChallengeKey, challenge)
+	h.scs.Put(ctx, webAuthnChallengeDeadlineKey, time.Now().Add(webAuthnTimeout).Unix())
+	return challenge, nil
+}
+
+func (h *HandlerService) popWebAuthnChallenge(ctx context.Context) ([]byte, error) {
+	challenge, ok := h.scs.Pop(ctx, webAuthnChallengeKey).([]byte)
+	deadline := h.scs.GetInt64(ctx, webAuthnChallengeDeadlineKey)
+	h.scs.Remove(ctx, webAuthnChallengeDeadlineKey)
+	if !ok || time.Now().Unix() > deadline {
+		return nil, newBadRequestErr("no pending webauthn challenge, request a new one")
+	}
+	return challenge, nil
+}
+
+func mapWebAuthnCredential(credential *types.WebAuthnCredential) types.UserWebAuthnCredential {
+	result := types.UserWebAuthnCredential{
+		Id:        credential.Id,
+		Name:      credential.Name,
+		CreatedAt: credential.CreatedAt.Unix(),
+	}
+	if credential.LastUsedAt != nil {
+		lastUsedAt := credential.LastUsedAt.Unix()
+		result.LastUsedAt = &lastUsedAt
+	}
+	return result
+}
+
+type webAuthnAssertion struct {
+	CredentialId      string `json:"credential_id"`
+	ClientDataJSON    string `json:"client_data_json"`
+	AuthenticatorData string `json:"authenticator_data"`
+	Signature         string `json:"signature"`
+}
+
+// verifyWebAuthnAssertion checks a navigator.credentials.get response against the credentials of the user
+func (h *HandlerService) verifyWebAuthnAssertion(ctx context.Context, userId uint64, assertion *webAuthnAssertion) error {
+	var v validationError
+	credentialId := v.checkBase64Url(assertion.CredentialId, "credential_id")
+	clientDataJSON := v.checkBase64Url(assertion.ClientDataJSON, "client_data_json")
+	authenticatorData := v.checkBase64Url(assertion.AuthenticatorData, "authenticator_data")
+	signature := v.checkBase64Url(assertion.Signature, "signature")
+	if v.hasErrors() {
+		return v
+	}
+	challenge, err := h.popWebAuthnChallenge(ctx)
+	if err != nil {
+		return err
+	}
+
+	credentials, err := h.daService.GetUserWebAuthnCredentials(ctx, userId)
+	if err != nil {
+		return err
+	}
+	var credential *types.WebAuthnCredential
+	for i := range credentials {
+		if bytes.Equal(credentials[i].CredentialId, credentialId) {
+			credential = &credentials[i]
+			break
+		}
+	}
+	if credential == nil {
+		return errInvalidSecondFactor
+	}
+
+	rpId, origin := getWebAuthnRelyingParty()
+	if err := utils.VerifyWebAuthnClientData(clientDataJSON, utils.WebAuthnTypeGet, challenge, origin); err != nil {
+		return errInvalidSecondFactor
+	}
+	data, err := utils.ParseWebAuthnAuthenticatorData(authenticatorData)
+	if err != nil {
+		return errInvalidSecondFactor
+	}
+	if err := utils.VerifyWebAuthnAuthenticatorData(data, rpId); err != nil {
+		return errInvalidSecondFactor
+	}
+	if err := utils.VerifyWebAuthnSignature(credential.PublicKey, credential.Algorithm, authenticatorData, clientDataJSON, signature); err != nil {
+		return errInvalidSecondFactor
+	}
+	// authenticators that support counters must increase them, a stale counter indicates a cloned authenticator
+	if (data.SignCount != 0 || credential.SignCount != 0) && data.SignCount <= credential.SignCount {
+		return errInvalidSecondFactor
+	}
+	return h.daService.UpdateUserWebAuthnCredentialUsage(ctx, userId, credential.Id, data.SignCount)
+}
+
+// --------------------------------------
+//   Login
+
+func (h *HandlerService) InternalPostLoginSecondFactor(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	req := struct {
+		Method   string             `json:"method"`
+		Code     string             `json:"code,omitempty"`
+		WebAuthn *webAuthnAssertion `json:"webauthn,omitempty"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	switch req.Method {
+	case secondFactorTotp, secondFactorRecoveryCode:
+		if req.Code == "" {
+			v.add("code", "code is required for this method")
+		}
+	case secondFactorWebAuthn:
+		if req.WebAuthn == nil {
+			v.add("webauthn", "assertion is required for this method")
+		}
+	default:
+		v.add("method", fmt.Sprintf("given value '%s' is not a valid second factor method", req.Method))
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	ctx := r.Context()
+	userId, err := h.getPendingSecondFactorUserId(ctx)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	user, err := h.daService.GetUserCredentialInfo(ctx, userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if err := h.checkLoginLock(ctx, userId); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	switch req.Method {
+	case secondFactorTotp:
+		err = h.verifyTotpCode(ctx, userId, req.Code)
+	case secondFactorRecoveryCode:
+		err = h.daService.ConsumeUserRecoveryCode(ctx, userId, req.Code)
+		if errors.Is(err, dataaccess.ErrNotFound) {
+			err = errInvalidSecondFactor
+		}
+	case secondFactorWebAuthn:
+		err = h.verifyWebAuthnAssertion(ctx, userId, req.WebAuthn)
+	}
+	if err != nil {
+		if errors.Is(err, errInvalidSecondFactor) {
+			h.recordLoginFailure(ctx, user)
+		}
+		handleErr(w, r, err)
+		return
+	}
+
+	// change privileges
+	err = h.scs.RenewToken(ctx)
+	if err != nil {
+		handleErr(w, r, errors.New("error creating session"))
+		return
+	}
+	h.scs.Remove(ctx, secondFactorUserIdKey)
+	h.scs.Remove(ctx, secondFactorDeadlineKey)
+	h.authenticateSession(r, user)
+
+	response := types.InternalPostLoginResponse{
+		Data: types.LoginResult{SecondFactorRequired: false},
+	}
+	returnOk(w, r, response)
+}
+
+// verifyTotpCode validates the code against the confirmed secret, each time step can only be used once
+func (h *HandlerService) verifyTotpCode(ctx context.Context, userId uint64, code string) error {
+	totp, err := h.daService.GetUserTotp(ctx, userId)
+	if err != nil {
+		if errors.Is(err, dataaccess.ErrNotFound) {
+			return errInvalidSecondFactor
+		}
+		return err
+	}
+	if !totp.Confirmed {
+		return errInvalidSecondFactor
+	}
+	step, ok := utils.ValidateTotpCode(totp.Secret, code, time.Now())
+	if !ok {
+		return errInvalidSecondFactor
+	}
+	ok, err = h.daService.UpdateUserTotpLastUsedStep(ctx, userId, step)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errInvalidSecondFactor
+	}
+	return nil
+}
+
+func (h *HandlerService) InternalPostLoginWebAuthnChallenges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, err := h.getPendingSecondFactorUserId(ctx)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	credentials, err := h.daService.GetUserWebAuthnCredentials(ctx, userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if len(credentials) == 0 {
+		handleErr(w, r, newBadRequestErr("no webauthn credentials registered"))
+		return
+	}
+	challenge, err := h.newWebAuthnChallenge(ctx)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	rpId, _ := getWebAuthnRelyingParty()
+	options := types.WebAuthnOptions{
+		Challenge:     base64.RawURLEncoding.EncodeToString(challenge),
+		RpId:          rpId,
+		CredentialIds: make([]string, len(credentials)),
+		Timeout:       uint64(webAuthnTimeout.Milliseconds()),
+	}
+	for i, credential := range credentials {
+		options.CredentialIds[i] = base64.RawURLEncoding.EncodeToString(credential.CredentialId)
+	}
+	response := types.InternalPostWebAuthnChallengesResponse{
+		Data: options,
+	}
+	returnOk(w, r, response)
+}
+
+// --------------------------------------
+//   Second factor management
+
+func (h *HandlerService) InternalGetUserSecondFactors(w http.ResponseWriter, r *http.Request) {
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	ctx := r.Context()
+	data := types.UserSecondFactors{}
+	totp, err := h.daService.GetUserTotp(ctx, userId)
+	if err != nil && !errors.Is(err, dataaccess.ErrNotFound) {
+		handleErr(w, r, err)
+		return
+	}
+	data.TotpEnabled = err == nil && totp.Confirmed
+	data.RecoveryCodesRemaining, err = h.daService.GetUserRecoveryCodeCount(ctx, userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	credentials, err := h.daService.GetUserWebAuthnCredentials(ctx, userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	data.WebAuthnCredentials = make([]types.UserWebAuthnCredential, len(credentials))
+	for i := range credentials {
+		data.WebAuthnCredentials[i] = mapWebAuthnCredential(&credentials[i])
+	}
+	response := types.InternalGetUserSecondFactorsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+// InternalPostUserTotp starts the totp setup, the secret is only enforced after it was confirmed with a valid code
+func (h *HandlerService) InternalPostUserTotp(w http.ResponseWriter, r *http.Request) {
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	ctx := r.Context()
+	totp, err := h.daService.GetUserTotp(ctx, userId)
+	if err != nil && !errors.Is(err, dataaccess.ErrNotFound) {
+		handleErr(w, r, err)
+		return
+	}
+	if err == nil && totp.Confirmed {
+		returnConflict(w, r, errors.New("totp is already enabled, disable it first to set up a new authenticator"))
+		return
+	}
+	user, err := h.daService.GetUserCredentialInfo(ctx, userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	secret, err := utils.GenerateTotpSecret()
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	err = h.daService.SetUserTotpSecret(ctx, userId, secret)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalPostUserTotpResponse{
+		Data: types.TotpSetup{
+			Secret: secret,
+			Uri:    utils.TotpUri(utils.Config.Frontend.SiteDomain, user.Email, secret),
+		},
+	}
+	returnCreated(w, r, response)
+}
+
+func (h *HandlerService) InternalPostUserTotpConfirmations(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	req := struct {
+		Code string `json:"code"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	ctx := r.Context()
+	totp, err := h.daService.GetUserTotp(ctx, userId)
+	if err != nil {
+		if errors.Is(err, dataaccess.ErrNotFound) {
+			err = newNotFoundErr("no pending totp setup")
+		}
+		handleErr(w, r, err)
+		return
+	}
+	if totp.Confirmed {
+		returnConflict(w, r, errors.New("totp is already enabled"))
+		return
+	}
+	step, ok := utils.ValidateTotpCode(totp.Secret, req.Code, time.Now())
+	if !ok {
+		handleErr(w, r, newBadRequestErr("invalid code"))
+		return
+	}
+	err = h.daService.ConfirmUserTotp(ctx, userId, step)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
+}
+
+func (h *HandlerService) InternalDeleteUserTotp(w http.ResponseWriter, r *http.Request) {
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	err = h.daService.RemoveUserTotp(r.Context(), userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if err := h.removeUnusedRecoveryCodes(r.Context(), userId); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
+}
+
+// removeUnusedRecoveryCodes drops the recovery codes once the last second factor is gone, new codes are generated when 2FA is enabled again
+func (h *HandlerService) removeUnusedRecoveryCodes(ctx context.Context, userId uint64) error {
+	methods, err := h.getSecondFactorMethods(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if len(methods) > 0 {
+		return nil
+	}
+	return h.daService.RemoveUserRecoveryCodes(ctx, userId)
+}
+
+// InternalPostUserRecoveryCodes replaces all recovery codes, the codes can't be retrieved later
+func (h *HandlerService) InternalPostUserRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	ctx := r.Context()
+	methods, err := h.getSecondFactorMethods(ctx, userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if len(methods) == 0 {
+		handleErr(w, r, newBadRequestErr("recovery codes require totp or a webauthn credential to be set up"))
+		return
+	}
+	codes, err := h.daService.CreateUserRecoveryCodes(ctx, userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalPostUserRecoveryCodesResponse{
+		Data: codes,
+	}
+	returnCreated(w, r, response)
+}
+
+func (h *HandlerService) InternalPostUserWebAuthnChallenges(w http.ResponseWriter, r *http.Request) {
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	ctx := r.Context()
+	user, err := h.daService.GetUserCredentialInfo(ctx, userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	credentials, err := h.daService.GetUserWebAuthnCredentials(ctx, userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	challenge, err := h.newWebAuthnChallenge(ctx)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	rpId, _ := getWebAuthnRelyingParty()
+	options := types.WebAuthnOptions{
+		Challenge:     base64.RawURLEncoding.EncodeToString(challenge),
+		RpId:          rpId,
+		RpName:        utils.Config.Frontend.SiteDomain,
+		UserId:        base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(userId, 10))),
+		UserName:      user.Email,
+		Algorithms:    utils.WebAuthnSupportedAlgorithms,
+		CredentialIds: make([]string, len(credentials)),
+		Timeout:       uint64(webAuthnTimeout.Milliseconds()),
+	}
+	for i, credential := range credentials {
+		options.CredentialIds[i] = base64.RawURLEncoding.EncodeToString(credential.CredentialId)
+	}
+	response := types.InternalPostWebAuthnChallengesResponse{
+		Data: options,
+	}
+	returnCreated(w, r, response)
+}
+
+func (h *HandlerService) InternalPostUserWebAuthnCredentials(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	req := struct {
+		Name               string `json:"name"`
+		CredentialId       string `json:"credential_id"`
+		ClientDataJSON     string `json:"client_data_json"`
+		AuthenticatorData  string `json:"authenticator_data"`
+		PublicKey          string `json:"public_key"`
+		PublicKeyAlgorithm int64  `json:"public_key_algorithm"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	name := v.checkNameNotEmpty(req.Name)
+	credentialId := v.checkBase64Url(req.CredentialId, "credential_id")
+	clientDataJSON := v.checkBase64Url(req.ClientDataJSON, "client_data_json")
+	authenticatorData := v.checkBase64Url(req.AuthenticatorData, "authenticator_data")
+	publicKey := v.checkBase64Url(req.PublicKey, "public_key")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	if _, err := utils.ParseWebAuthnPublicKey(publicKey, req.PublicKeyAlgorithm); err != nil {
+		v.add("public_key", err.Error())
+		handleErr(w, r, v)
+		return
+	}
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	ctx := r.Context()
+	challenge, err := h.popWebAuthnChallenge(ctx)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	rpId, origin := getWebAuthnRelyingParty()
+	if err := utils.VerifyWebAuthnClientData(clientDataJSON, utils.WebAuthnTypeCreate, challenge, origin); err != nil {
+		handleErr(w, r, newBadRequestErr("%s", err.Error()))
+		return
+	}
+	data, err := utils.ParseWebAuthnAuthenticatorData(authenticatorData)
+	if err != nil {
+		handleErr(w, r, newBadRequestErr("%s", err.Error()))
+		return
+	}
+	if err := utils.VerifyWebAuthnAuthenticatorData(data, rpId); err != nil {
+		handleErr(w, r, newBadRequestErr("%s", err.Error()))
+		return
+	}
+	if !bytes.Equal(data.CredentialId, credentialId) {
+		handleErr(w, r, newBadRequestErr("credential id does not match the authenticator data"))
+		return
+	}
+
+	credentials, err := h.daService.GetUserWebAuthnCredentials(ctx, userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if len(credentials) >= maxWebAuthnCredentialsPerUser {
+		returnConflict(w, r, fmt.Errorf("maximum number of %d webauthn credentials reached", maxWebAuthnCredentialsPerUser))
+		return
+	}
+	credential, err := h.daService.AddUserWebAuthnCredential(ctx, userId, types.WebAuthnCredential{
+		Name:         name,
+		CredentialId: credentialId,
+		PublicKey:    publicKey,
+		Algorithm:    req.PublicKeyAlgorithm,
+		SignCount:    data.SignCount,
+	})
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalPostUserWebAuthnCredentialsResponse{
+		Data: mapWebAuthnCredential(credential),
+	}
+	returnCreated(w, r, response)
+}
+
+func (h *HandlerService) InternalDeleteUserWebAuthnCredential(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	credentialId := v.checkUint(mux.Vars(r)["credential_id"], "credential_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	err = h.daService.RemoveUserWebAuthnCredential(r.Context(), userId, credentialId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if err := h.removeUnusedRecoveryCodes(r.Context(), userId); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
+}
+
+// --------------------------------------
+//   Active sessions
+
+// sessions are identified by the hash of their token, the token itself grants access and must never be exposed
+func getSessionId(token string) string {
+	return utils.HashAndEncode(token)
+}
+
+func (h *HandlerService) InternalGetUserSessions(w http.ResponseWriter, r *http.Request) {
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	devices, err := h.daService.GetUserDevices(r.Context(), userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	// mobile sessions are stored with the hash of the session token as refresh token of the device
+	deviceNames := make(map[string]string, len(devices))
+	for _, device := range devices {
+		deviceNames[device.RefreshTokenHash] = device.DeviceName
+	}
+
+	currentToken := h.scs.Token(r.Context())
+	data := []types.UserSession{}
+	err = h.scs.Iterate(r.Context(), func(ctx context.Context) error {
+		if !h.scs.GetBool(ctx, authenticatedKey) {
+			return nil
+		}
+		if sessionUserId, ok := h.scs.Get(ctx, userIdKey).(uint64); !ok || sessionUserId != userId {
+			return nil
+		}
+		token := h.scs.Token(ctx)
+		session := types.UserSession{
+			Id:        getSessionId(token),
+			Type:      sessionTypeWeb,
+			UserAgent: h.scs.GetString(ctx, sessionUserAgentKey),
+			Ip:        h.scs.GetString(ctx, sessionIpKey),
+			CreatedAt: h.scs.GetInt64(ctx, sessionCreatedKey),
+			Current:   token == currentToken,
+		}
+		if deviceName, ok := deviceNames[utils.HashAndEncode(token+token)]; ok {
+			session.Type = sessionTypeMobile
+			session.DeviceName = deviceName
+		} else if h.scs.GetBool(ctx, mobileAuthKey) {
+			session.Type = sessionTypeMobile
+		}
+		data = append(data, session)
+		return nil
+	})
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetUserSessionsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+// InternalDeleteUserSession logs out a single session, mobile devices also lose their refresh token
+func (h *HandlerService) InternalDeleteUserSession(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	sessionId := v.checkRegex(reSessionId, mux.Vars(r)["session_id"], "session_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	var token string
+	if currentToken := h.scs.Token(r.Context()); getSessionId(currentToken) == sessionId {
+		// the current session has to be destroyed via the request context, it would be saved again otherwise
+		token = currentToken
+		err = h.scs.Destroy(r.Context())
+	} else {
+		err = h.scs.Iterate(r.Context(), func(ctx context.Context) error {
+			if sessionUserId, ok := h.scs.Get(ctx, userIdKey).(uint64); !ok || sessionUserId != userId {
+				return nil
+			}
+			if getSessionId(h.scs.Token(ctx)) != sessionId {
+				return nil
+			}
+			token = h.scs.Token(ctx)
+			return h.scs.Destroy(ctx)
+		})
+	}
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if token == "" {
+		handleErr(w, r, newNotFoundErr("session %s not found", sessionId))
+		return
+	}
+	err = h.daService.DeactivateUserDevice(r.Context(), userId, utils.HashAndEncode(token+token))
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
+}
@@ -1,16 +1,19 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gobitfly/beaconchain/pkg/api/types"
 
 	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/machinemetrics"
 	commontypes "github.com/gobitfly/beaconchain/pkg/commons/types"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
@@ -63,6 +66,163 @@ func (h *HandlerService) PublicGetUserMachineMetrics(w http.ResponseWriter, r *h
 	returnOk(w, r, response)
 }
 
+func (h *HandlerService) InternalGetUserMachineMetricSeries(w http.ResponseWriter, r *http.Request) {
+	h.PublicGetUserMachineMetricSeries(w, r)
+}
+
+func (h *HandlerService) PublicGetUserMachineMetricSeries(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	q := r.URL.Query()
+	machine := v.checkRegex(reName, q.Get("machine"), "machine")
+	metric := v.checkRegex(reMetricName, q.Get("metric"), "metric")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	userInfo, err := h.daService.GetUserInfo(r.Context(), userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	since := time.Now().Add(-time.Duration(userInfo.PremiumPerks.MachineMonitoringHistorySeconds) * time.Second)
+
+	data, err := h.daService.GetUserMachineMetricSeries(r.Context(), userId, machine, metric, since)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetUserMachineMetricSeriesResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+const (
+	// remote write requests are snappy compressed, text pushes of a few targets stay well below
+	maxMachineMetricsBodySize = 10 * 1024 * 1024
+	// snappy compresses well, the decompressed size of remote write requests is limited separately
+	maxMachineMetricsDecodedSize = 32 * 1024 * 1024
+	maxMachineMetricSeries       = 20000
+)
+
+// PublicPostUserMachineMetrics ingests metrics in the prometheus remote write, OpenMetrics or prometheus text format.
+// Well-known client metrics are stored as machine metrics, all series are stored raw for custom charts.
+// Remote write streams can contain several targets, if no machine is passed they are assigned to the host of their instance label.
+func (h *HandlerService) PublicPostUserMachineMetrics(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	machine := v.checkRegex(reName, r.URL.Query().Get("machine"), "machine")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	if !h.isPostMachineMetricsEnabled {
+		returnError(w, r, http.StatusServiceUnavailable, fmt.Errorf("machine metrics pushing is temporarily disabled"))
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxMachineMetricsBodySize))
+	if err != nil {
+		returnBadRequest(w, r, fmt.Errorf("could not read request body: %w", err))
+		return
+	}
+	var samples []machinemetrics.Sample
+	contentType := r.Header.Get("Content-Type")
+	switch {
+	case strings.HasPrefix(contentType, "application/x-protobuf"):
+		samples, err = machinemetrics.ParseRemoteWrite(body, maxMachineMetricsDecodedSize, maxMachineMetricSeries)
+	case strings.HasPrefix(contentType, "application/openmetrics-text"):
+		samples, err = machinemetrics.ParseText(bytes.NewReader(body), true, maxMachineMetricSeries)
+	case strings.HasPrefix(contentType, "text/plain"), contentType == "":
+		samples, err = machinemetrics.ParseText(bytes.NewReader(body), false, maxMachineMetricSeries)
+	default:
+		returnBadRequest(w, r, fmt.Errorf("unsupported content type %s, expected application/x-protobuf, application/openmetrics-text or text/plain", contentType))
+		return
+	}
+	if err != nil {
+		returnBadRequest(w, r, err)
+		return
+	}
+
+	now := time.Now().Unix()
+	metrics := make(map[string][]machinemetrics.Metric)
+	series := make(map[string]map[string][]commontypes.MachineMetricSeriesPoint)
+	for _, target := range machinemetrics.GroupByTarget(samples) {
+		machineName := machine
+		if machineName == "" {
+			machineName = target.Host()
+		}
+		converted, err := machinemetrics.Convert(target)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		metrics[machineName] = append(metrics[machineName], converted...)
+
+		if series[machineName] == nil {
+			series[machineName] = make(map[string][]commontypes.MachineMetricSeriesPoint)
+		}
+		for _, sample := range target.Samples {
+			id := sample.Series()
+			timestamp := sample.Timestamp / 1000
+			if timestamp == 0 {
+				timestamp = now
+			}
+			series[machineName][id] = append(series[machineName][id], commontypes.MachineMetricSeriesPoint{Timestamp: timestamp, Value: sample.Value})
+		}
+	}
+	userInfo, err := h.daService.GetUserInfo(r.Context(), userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	count, err := db.BigtableClient.GetMachineMetricsMachineCount(commontypes.UserId(userId))
+	if err != nil {
+		handleErr(w, r, errors.Wrap(err, "could not get machine count"))
+		return
+	}
+	maxMachines := userInfo.PremiumPerks.MonitorMachines
+	if count > maxMachines || uint64(len(series)) > maxMachines {
+		handleErr(w, r, newForbiddenErr("user has reached max machine count"))
+		return
+	}
+
+	for machineName, machineMetrics := range metrics {
+		for _, metric := range machineMetrics {
+			data, err := proto.Marshal(metric.Data)
+			if err != nil {
+				handleErr(w, r, errors.Wrap(err, "could not encode machine metric"))
+				return
+			}
+			err = h.daService.PostUserMachineMetrics(r.Context(), userId, machineName, metric.Process, data)
+			// remote write splits the samples of a scrape into several requests, only the first one per minute is stored
+			if err != nil && !strings.HasPrefix(err.Error(), "rate limit") {
+				handleErr(w, r, err)
+				return
+			}
+		}
+	}
+	for machineName, machineSeries := range series {
+		err = h.daService.PostUserMachineMetricSeries(r.Context(), userId, machineName, machineSeries)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+	}
+
+	returnNoContent(w, r)
+}
+
 func (h *HandlerService) LegacyPostUserMachineMetrics(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
 	apiKey := q.Get("apikey")
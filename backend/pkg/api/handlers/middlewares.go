@@ -8,6 +8,7 @@ import (
 	"strconv"
 
 	"github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 	"github.com/gorilla/mux"
 )
 
@@ -42,10 +43,55 @@ func (h *HandlerService) StoreUserIdBySessionMiddleware(next http.Handler) http.
 	})
 }
 
-// middleware that stores user id in context, using the api key to get the user id
+// middleware that stores user id and api key access in context, using the api key to get the user id.
+// requests the route or scopes of the api key don't allow are rejected, networks are checked by the routes themselves.
 func (h *HandlerService) StoreUserIdByApiKeyMiddleware(next http.Handler) http.Handler {
-	return StoreUserIdMiddleware(next, func(r *http.Request) (uint64, error) {
-		return h.GetUserIdByApiKey(r)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		access, err := h.GetApiKeyAccess(r)
+		if err == nil {
+			err = h.checkApiKeyAccess(r, access)
+		}
+		if err != nil {
+			if errors.Is(err, errUnauthorized) {
+				// if next handler requires authentication, it should return 'unauthorized' itself
+				next.ServeHTTP(w, r)
+			} else {
+				handleErr(w, r, err)
+			}
+			return
+		}
+
+		// store user id and api key access in context
+		ctx := context.WithValue(r.Context(), types.CtxUserIdKey, access.UserId)
+		ctx = context.WithValue(ctx, types.CtxApiKeyAccessKey, access)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// middleware that rejects requests whose api key isn't allowed to access the network the request is served by.
+// wraps the handlers of public routes, so it runs after StoreNetworkMiddleware or VDBNetworkMiddleware stored the network.
+func (h *HandlerService) ApiKeyNetworkCheckMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		access, ok := r.Context().Value(types.CtxApiKeyAccessKey).(*types.ApiKeyAccess)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		chainId, ok := r.Context().Value(types.CtxChainIdKey).(uint64)
+		if !ok {
+			if _, ok := mux.Vars(r)["network"]; ok {
+				// invalid networks are rejected by the handler itself
+				next.ServeHTTP(w, r)
+				return
+			}
+			// requests that aren't bound to a network are served by the main network
+			chainId = utils.Config.Chain.ClConfig.DepositChainID
+		}
+		if !access.HasNetwork(chainId) {
+			handleErr(w, r, newForbiddenErr("api key is not allowed to access network %d", chainId))
+			return
+		}
+		next.ServeHTTP(w, r)
 	})
 }
 
@@ -71,15 +117,111 @@ func (h *HandlerService) VDBAuthMiddleware(next http.Handler) http.Handler {
 			handleErr(w, r, err)
 			return
 		}
-		dashboardUser, err := h.daService.GetValidatorDashboardUser(r.Context(), types.VDBIdPrimary(dashboardId))
+		ownerId, err := h.checkValidatorDashboardAccess(r.Context(), types.VDBIdPrimary(dashboardId), userId, requiredDashboardRole(r))
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+
+		// store dashboard owner in context, notifications are routed to them
+		ctx := context.WithValue(r.Context(), types.CtxDashboardOwnerIdKey, ownerId)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// middleware that stores the chain id of the network path parameter in context, requests are served by the data access
+// service of that network. networks that aren't served by this api are rejected.
+func (h *HandlerService) StoreNetworkMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		network, ok := mux.Vars(r)["network"]
+		if !ok || isMocked(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		var v validationError
+		chainId := v.checkNetworkParameter(network)
+		if v.hasErrors() {
+			// invalid networks are rejected by the handler itself
+			next.ServeHTTP(w, r)
+			return
+		}
+		if _, ok := h.daService.ForNetwork(chainId); !ok {
+			handleErr(w, r, newNotFoundErr("network %s is not served by this api", network))
+			return
+		}
+		ctx := context.WithValue(r.Context(), types.CtxChainIdKey, chainId)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// middleware that stores the network a validator dashboard was created for in context, the dashboard is served by the
+// data access service of that network. guest dashboards are served by the main network.
+func (h *HandlerService) VDBNetworkMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isMocked(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		var dashboardId types.VDBIdPrimary
+		switch id := mux.Vars(r)["dashboard_id"]; {
+		case reInteger.MatchString(id):
+			var v validationError
+			dashboardId = v.checkPrimaryDashboardId(id)
+			if v.hasErrors() {
+				next.ServeHTTP(w, r)
+				return
+			}
+		case reValidatorDashboardPublicId.MatchString(id):
+			primaryId, err := h.daService.GetValidatorDashboardIdByPublicId(r.Context(), types.VDBIdPublic(id))
+			if err != nil {
+				// unknown public ids are rejected by the handler itself
+				next.ServeHTTP(w, r)
+				return
+			}
+			dashboardId = *primaryId
+		default:
+			next.ServeHTTP(w, r)
+			return
+		}
+		dashboardUser, err := h.daService.GetValidatorDashboardUser(r.Context(), dashboardId)
+		if err != nil {
+			// unknown dashboards are rejected by the handler itself
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx := context.WithValue(r.Context(), types.CtxChainIdKey, dashboardUser.Network)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// middleware check to ensure the user has access to the account dashboard
+func (h *HandlerService) ADBAuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// if mock data is used, no need to check access
+		if isMocked, ok := r.Context().Value(types.CtxIsMockedKey).(bool); ok && isMocked {
+			next.ServeHTTP(w, r)
+			return
+		}
+		dashboardId, err := strconv.ParseUint(mux.Vars(r)["dashboard_id"], 10, 64)
+		if err != nil {
+			// invalid ids are rejected by the handler itself
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		userId, err := GetUserIdByContext(r)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		dashboardUser, err := h.daService.GetAccountDashboardUser(r.Context(), dashboardId)
 		if err != nil {
 			handleErr(w, r, err)
 			return
 		}
 
 		if dashboardUser.UserId != userId {
-			// user does not have access to dashboard
-			// the proper error would be 403 Forbidden, but we don't want to leak information so we return 404 Not Found
+			// user does not have access to dashboard, don't leak its existence
 			handleErr(w, r, newNotFoundErr("dashboard with id %v not found", dashboardId))
 			return
 		}
@@ -116,10 +258,37 @@ func (h *HandlerService) PremiumPerkCheckMiddleware(next http.Handler, hasRequir
 }
 
 // Middleware for managing dashboards via API
+// Organization dashboards are checked against the pooled perks of the organization instead of the perks of the user
 func (h *HandlerService) ManageDashboardsViaApiCheckMiddleware(next http.Handler) http.Handler {
-	return h.PremiumPerkCheckMiddleware(next, func(premiumPerks types.PremiumPerks) bool {
+	userPerkCheck := h.PremiumPerkCheckMiddleware(next, func(premiumPerks types.PremiumPerks) bool {
 		return premiumPerks.ManageDashboardViaApi
 	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dashboardId, err := strconv.ParseUint(mux.Vars(r)["dashboard_id"], 10, 64)
+		if isMocked, ok := r.Context().Value(types.CtxIsMockedKey).(bool); err != nil || (ok && isMocked) {
+			userPerkCheck.ServeHTTP(w, r)
+			return
+		}
+		dashboardUser, err := h.daService.GetValidatorDashboardUser(r.Context(), types.VDBIdPrimary(dashboardId))
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		if dashboardUser.OrganizationId == nil {
+			userPerkCheck.ServeHTTP(w, r)
+			return
+		}
+		premiumPerks, err := h.daService.GetOrganizationPremiumPerks(r.Context(), *dashboardUser.OrganizationId)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		if !premiumPerks.ManageDashboardViaApi {
+			handleErr(w, r, newForbiddenErr("organization premium perks do not allow usage of this endpoint"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
 }
 
 // Middleware for managing notifications via API
@@ -0,0 +1,486 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	dataaccess "github.com/gobitfly/beaconchain/pkg/api/data_access"
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
+	"github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gorilla/mux"
+)
+
+// OAuth 2.0 authorization code flow with PKCE (RFC 6749, RFC 7636) and token revocation (RFC 7009).
+// The consent screen is rendered by the frontend, which uses the internal authorize endpoints with the session of the user.
+// The token and revocation endpoints are called by the apps themselves and are neither session nor CSRF protected.
+
+const (
+	oauthResponseTypeCode           = "code"
+	oauthCodeChallengeMethodS256    = "S256"
+	oauthGrantTypeAuthorizationCode = "authorization_code"
+	oauthGrantTypeRefreshToken      = "refresh_token"
+	oauthTokenTypeBearer            = "Bearer"
+	maxOAuthAppsPerUser             = 10
+)
+
+// error codes of RFC 6749 section 4.1.2.1 and 5.2
+const (
+	oauthErrInvalidRequest       = "invalid_request"
+	oauthErrInvalidClient        = "invalid_client"
+	oauthErrInvalidGrant         = "invalid_grant"
+	oauthErrInvalidScope         = "invalid_scope"
+	oauthErrAccessDenied         = "access_denied"
+	oauthErrUnsupportedGrantType = "unsupported_grant_type"
+	oauthErrServerError          = "server_error"
+)
+
+type oauthAuthorizeRequest struct {
+	ResponseType        string `json:"response_type"`
+	ClientId            string `json:"client_id"`
+	RedirectUri         string `json:"redirect_uri"`
+	Scope               string `json:"scope"`
+	State               string `json:"state,omitempty"`
+	CodeChallenge       string `json:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method"`
+}
+
+// getOAuthClientForRedirect returns the client if the redirect uri matches the registered one.
+// Only after this it is safe to redirect the user back to the app, errors before have to be shown to the user directly.
+func (h *HandlerService) getOAuthClientForRedirect(r *http.Request, clientId, redirectUri string) (*types.OAuthClient, error) {
+	client, err := h.daService.GetOAuthClient(r.Context(), clientId)
+	if errors.Is(err, dataaccess.ErrNotFound) {
+		return nil, newBadRequestErr("unknown client_id '%s'", clientId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if client.RedirectUri != redirectUri {
+		return nil, newBadRequestErr("redirect_uri does not match the one registered for the client")
+	}
+	return client, nil
+}
+
+// checkOAuthAuthorizeRequest validates the parameters that are returned to the app as error if they are invalid
+func (v *validationError) checkOAuthAuthorizeRequest(req oauthAuthorizeRequest, client *types.OAuthClient) []enums.ApiKeyScope {
+	if req.ResponseType != oauthResponseTypeCode {
+		v.add("response_type", fmt.Sprintf("only '%s' is supported", oauthResponseTypeCode))
+	}
+	// PKCE is mandatory for all clients, including confidential ones
+	if req.CodeChallengeMethod != oauthCodeChallengeMethodS256 {
+		v.add("code_challenge_method", fmt.Sprintf("only '%s' is supported", oauthCodeChallengeMethodS256))
+	}
+	v.checkRegex(rePkceCodeChallenge, req.CodeChallenge, "code_challenge")
+	scopes := v.checkOAuthScopes(req.Scope)
+	for _, scope := range scopes {
+		if !client.AllowsScope(scope) {
+			v.add("scope", fmt.Sprintf("client is not allowed to request the scope '%s'", scope.String()))
+		}
+	}
+	return scopes
+}
+
+func oauthRedirectUri(redirectUri string, params url.Values) string {
+	u, err := url.Parse(redirectUri)
+	if err != nil {
+		// redirect uris are validated on registration
+		return redirectUri
+	}
+	query := u.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+func oauthErrorRedirectUri(redirectUri, errorCode, description, state string) string {
+	params := url.Values{"error": {errorCode}}
+	if description != "" {
+		params.Set("error_description", description)
+	}
+	if state != "" {
+		params.Set("state", state)
+	}
+	return oauthRedirectUri(redirectUri, params)
+}
+
+// InternalGetOauthAuthorize validates an authorization request and returns what the frontend needs to ask the user for consent.
+func (h *HandlerService) InternalGetOauthAuthorize(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.getUserBySession(r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	q := r.URL.Query()
+	req := oauthAuthorizeRequest{
+		ResponseType:        q.Get("response_type"),
+		ClientId:            q.Get("client_id"),
+		RedirectUri:         q.Get("redirect_uri"),
+		Scope:               q.Get("scope"),
+		State:               q.Get("state"),
+		CodeChallenge:       q.Get("code_challenge"),
+		CodeChallengeMethod: q.Get("code_challenge_method"),
+	}
+	client, err := h.getOAuthClientForRedirect(r, req.ClientId, req.RedirectUri)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	var v validationError
+	scopes := v.checkOAuthAuthorizeRequest(req, client)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	response := types.InternalGetOAuthAuthorizeResponse{
+		Data: types.OAuthConsentRequest{
+			ClientId:    client.ClientId,
+			AppName:     client.Name,
+			RedirectUri: client.RedirectUri,
+			Scopes:      apiKeyScopeStrings(scopes),
+			FirstParty:  client.FirstParty,
+		},
+	}
+	returnOk(w, r, response)
+}
+
+// InternalPostOauthAuthorize records the decision of the user and returns the uri the frontend has to redirect the user to.
+// The uri contains either the authorization code or the error as specified in RFC 6749 section 4.1.2.
+func (h *HandlerService) InternalPostOauthAuthorize(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	var v validationError
+	req := struct {
+		oauthAuthorizeRequest
+		Approved bool `json:"approved"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	client, err := h.getOAuthClientForRedirect(r, req.ClientId, req.RedirectUri)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	redirect := func(redirectUri string) {
+		returnOk(w, r, types.InternalPostOAuthAuthorizeResponse{
+			Data: types.OAuthAuthorizeRedirect{RedirectUri: redirectUri},
+		})
+	}
+	scopes := v.checkOAuthAuthorizeRequest(req.oauthAuthorizeRequest, client)
+	if v.hasErrors() {
+		errorCode := oauthErrInvalidRequest
+		if _, ok := v["scope"]; ok {
+			errorCode = oauthErrInvalidScope
+		}
+		redirect(oauthErrorRedirectUri(client.RedirectUri, errorCode, v.Error(), req.State))
+		return
+	}
+	if !req.Approved {
+		redirect(oauthErrorRedirectUri(client.RedirectUri, oauthErrAccessDenied, "the user denied the request", req.State))
+		return
+	}
+
+	code, err := h.daService.CreateOAuthAuthorizationCode(r.Context(), types.OAuthAuthorizationCode{
+		AppId:         client.AppId,
+		UserId:        user.Id,
+		RedirectUri:   client.RedirectUri,
+		Scopes:        scopes,
+		CodeChallenge: req.CodeChallenge,
+	})
+	if err != nil {
+		logApiError(r, err, 0)
+		redirect(oauthErrorRedirectUri(client.RedirectUri, oauthErrServerError, "", req.State))
+		return
+	}
+	params := url.Values{"code": {code}}
+	if req.State != "" {
+		params.Set("state", req.State)
+	}
+	redirect(oauthRedirectUri(client.RedirectUri, params))
+}
+
+// oauthError is returned by the token and revocation endpoints, see RFC 6749 section 5.2
+type oauthError struct {
+	StatusCode  int
+	Code        string
+	Description string
+}
+
+func (e oauthError) Error() string {
+	return e.Code + ": " + e.Description
+}
+
+func newOAuthError(statusCode int, code, description string) *oauthError {
+	return &oauthError{StatusCode: statusCode, Code: code, Description: description}
+}
+
+func returnOAuthError(w http.ResponseWriter, r *http.Request, err error) {
+	var oauthErr *oauthError
+	if !errors.As(err, &oauthErr) {
+		logApiError(r, err, 1)
+		oauthErr = newOAuthError(http.StatusInternalServerError, oauthErrServerError, "")
+	}
+	if oauthErr.Code == oauthErrInvalidClient && oauthErr.StatusCode == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Basic realm="oauth"`)
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeResponse(w, r, oauthErr.StatusCode, types.OAuthErrorResponse{
+		Error:            oauthErr.Code,
+		ErrorDescription: oauthErr.Description,
+	})
+}
+
+// authenticateOAuthClient identifies the client by http basic auth or the form parameters.
+// Confidential clients have to authenticate with their secret, public clients only send their id.
+func (h *HandlerService) authenticateOAuthClient(r *http.Request) (*types.OAuthClient, error) {
+	clientId, clientSecret, usedBasicAuth := r.BasicAuth()
+	if !usedBasicAuth {
+		clientId = r.PostForm.Get("client_id")
+		clientSecret = r.PostForm.Get("client_secret")
+	}
+	unauthorizedStatus := http.StatusBadRequest
+	if usedBasicAuth {
+		unauthorizedStatus = http.StatusUnauthorized
+	}
+	if clientId == "" {
+		return nil, newOAuthError(unauthorizedStatus, oauthErrInvalidClient, "missing client_id")
+	}
+	client, err := h.daService.GetOAuthClient(r.Context(), clientId)
+	if errors.Is(err, dataaccess.ErrNotFound) {
+		return nil, newOAuthError(unauthorizedStatus, oauthErrInvalidClient, "unknown client")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if client.IsConfidential() && subtle.ConstantTimeCompare([]byte(utils.HashAndEncode(clientSecret)), []byte(client.ClientSecretHash)) != 1 {
+		return nil, newOAuthError(unauthorizedStatus, oauthErrInvalidClient, "invalid client credentials")
+	}
+	return client, nil
+}
+
+func verifyPkceCodeVerifier(codeVerifier, codeChallenge string) bool {
+	if !rePkceCodeVerifier.MatchString(codeVerifier) {
+		return false
+	}
+	hash := sha256.Sum256([]byte(codeVerifier))
+	expected := base64.RawURLEncoding.EncodeToString(hash[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(codeChallenge)) == 1
+}
+
+func (h *HandlerService) exchangeOAuthAuthorizationCode(r *http.Request, client *types.OAuthClient) (*types.OAuthTokenGrant, error) {
+	code := r.PostForm.Get("code")
+	if code == "" {
+		return nil, newOAuthError(http.StatusBadRequest, oauthErrInvalidRequest, "missing code")
+	}
+	grant, err := h.daService.ConsumeOAuthAuthorizationCode(r.Context(), client.AppId, code)
+	if errors.Is(err, dataaccess.ErrNotFound) {
+		return nil, newOAuthError(http.StatusBadRequest, oauthErrInvalidGrant, "invalid, expired or already used code")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if r.PostForm.Get("redirect_uri") != grant.RedirectUri {
+		return nil, newOAuthError(http.StatusBadRequest, oauthErrInvalidGrant, "redirect_uri does not match the authorization request")
+	}
+	if !verifyPkceCodeVerifier(r.PostForm.Get("code_verifier"), grant.CodeChallenge) {
+		return nil, newOAuthError(http.StatusBadRequest, oauthErrInvalidGrant, "invalid code_verifier")
+	}
+	return h.daService.CreateOAuthTokens(r.Context(), client.AppId, grant.UserId, grant.Scopes)
+}
+
+func (h *HandlerService) refreshOAuthTokens(r *http.Request, client *types.OAuthClient) (*types.OAuthTokenGrant, error) {
+	refreshToken := r.PostForm.Get("refresh_token")
+	if refreshToken == "" {
+		return nil, newOAuthError(http.StatusBadRequest, oauthErrInvalidRequest, "missing refresh_token")
+	}
+	grant, err := h.daService.RefreshOAuthTokens(r.Context(), client.AppId, refreshToken)
+	if errors.Is(err, dataaccess.ErrNotFound) {
+		return nil, newOAuthError(http.StatusBadRequest, oauthErrInvalidGrant, "invalid, expired or revoked refresh_token")
+	}
+	return grant, err
+}
+
+// OAuthPostToken issues tokens for an authorization code or rotates a refresh token, see RFC 6749 section 4.1.3 and 6.
+// Requests are form encoded as required by the spec.
+func (h *HandlerService) OAuthPostToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		returnOAuthError(w, r, newOAuthError(http.StatusBadRequest, oauthErrInvalidRequest, "invalid form body"))
+		return
+	}
+	client, err := h.authenticateOAuthClient(r)
+	if err != nil {
+		returnOAuthError(w, r, err)
+		return
+	}
+
+	var grant *types.OAuthTokenGrant
+	switch grantType := r.PostForm.Get("grant_type"); grantType {
+	case oauthGrantTypeAuthorizationCode:
+		grant, err = h.exchangeOAuthAuthorizationCode(r, client)
+	case oauthGrantTypeRefreshToken:
+		grant, err = h.refreshOAuthTokens(r, client)
+	default:
+		err = newOAuthError(http.StatusBadRequest, oauthErrUnsupportedGrantType, fmt.Sprintf("grant_type '%s' is not supported", grantType))
+	}
+	if err != nil {
+		returnOAuthError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	returnOk(w, r, types.OAuthTokenResponse{
+		AccessToken:  grant.AccessToken,
+		TokenType:    oauthTokenTypeBearer,
+		ExpiresIn:    grant.ExpiresIn,
+		RefreshToken: grant.RefreshToken,
+		Scope:        strings.Join(apiKeyScopeStrings(grant.Scopes), " "),
+	})
+}
+
+// OAuthPostRevoke revokes the grant of an access or refresh token, see RFC 7009
+func (h *HandlerService) OAuthPostRevoke(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		returnOAuthError(w, r, newOAuthError(http.StatusBadRequest, oauthErrInvalidRequest, "invalid form body"))
+		return
+	}
+	client, err := h.authenticateOAuthClient(r)
+	if err != nil {
+		returnOAuthError(w, r, err)
+		return
+	}
+	token := r.PostForm.Get("token")
+	if token == "" {
+		returnOAuthError(w, r, newOAuthError(http.StatusBadRequest, oauthErrInvalidRequest, "missing token"))
+		return
+	}
+	if err := h.daService.RevokeOAuthToken(r.Context(), client.AppId, token); err != nil {
+		returnOAuthError(w, r, err)
+		return
+	}
+	returnOk(w, r, nil)
+}
+
+func apiKeyScopeStrings(scopes []enums.ApiKeyScope) []string {
+	result := make([]string, len(scopes))
+	for i, scope := range scopes {
+		result[i] = scope.String()
+	}
+	return result
+}
+
+// Client registry
+
+func (h *HandlerService) InternalGetUserOAuthApps(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	data, err := h.getDataAccessor(r).GetUserOAuthApps(r.Context(), user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetUserOAuthAppsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+// InternalPostUserOAuthApps registers an app, apps that can keep a secret (e.g. server side tools) should be confidential
+func (h *HandlerService) InternalPostUserOAuthApps(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	var v validationError
+	req := struct {
+		Name         string   `json:"name"`
+		RedirectUri  string   `json:"redirect_uri"`
+		Scopes       []string `json:"scopes"`
+		Confidential bool     `json:"confidential"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	name := v.checkNameNotEmpty(req.Name)
+	if len(name) > maxOAuthAppNameLength {
+		v.add("name", fmt.Sprintf("given value '%s' is too long, maximum length is %d", name, maxOAuthAppNameLength))
+	}
+	redirectUri := v.checkOAuthRedirectUri(req.RedirectUri)
+	scopes := v.checkOAuthScopes(strings.Join(req.Scopes, " "))
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	appCount, err := h.daService.GetUserOAuthAppCount(r.Context(), user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if appCount >= maxOAuthAppsPerUser {
+		returnConflict(w, r, errors.New("maximum number of oauth apps reached"))
+		return
+	}
+	_, err = h.daService.GetOAuthClientByRedirectUri(r.Context(), redirectUri)
+	if err == nil {
+		returnConflict(w, r, errors.New("redirect_uri is already registered for another app"))
+		return
+	}
+	if !errors.Is(err, dataaccess.ErrNotFound) {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).CreateUserOAuthApp(r.Context(), user.Id, name, redirectUri, scopes, req.Confidential)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalPostUserOAuthAppsResponse{
+		Data: *data,
+	}
+	returnCreated(w, r, response)
+}
+
+// InternalDeleteUserOAuthApp removes the app, all tokens issued to it stop working immediately
+func (h *HandlerService) InternalDeleteUserOAuthApp(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	var v validationError
+	appId := v.checkUint(mux.Vars(r)["app_id"], "app_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	err = h.getDataAccessor(r).RemoveUserOAuthApp(r.Context(), user.Id, appId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
+}
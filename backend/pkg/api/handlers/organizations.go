@@ -0,0 +1,724 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	dataaccess "github.com/gobitfly/beaconchain/pkg/api/data_access"
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
+	"github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/mail"
+	commonTypes "github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gorilla/mux"
+)
+
+const (
+	maxOrganizationsPerUser = 10
+	maxOrganizationMembers  = 25 // including pending invitations
+)
+
+// checkOrganizationRole returns the role of the user in the organization, it fails if the role doesn't include the required one.
+// Non-members get a not found error to not leak the existence of organizations.
+func (h *HandlerService) checkOrganizationRole(ctx context.Context, organizationId, userId uint64, required enums.OrganizationRole) (enums.OrganizationRole, error) {
+	role, err := h.daService.GetOrganizationMemberRole(ctx, organizationId, userId)
+	if err != nil {
+		if errors.Is(err, dataaccess.ErrNotFound) {
+			err = newNotFoundErr("organization with id %v not found", organizationId)
+		}
+		return role, err
+	}
+	if !role.Includes(required) {
+		return role, newForbiddenErr("role '%s' does not allow this action, at least '%s' is required", role, required)
+	}
+	return role, nil
+}
+
+// requiredDashboardRole returns the role an organization member needs for a request on an organization dashboard
+func requiredDashboardRole(r *http.Request) enums.OrganizationRole {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return enums.OrganizationRoles.Viewer
+	case http.MethodDelete:
+		// deleting the dashboard itself is reserved for admins, deleting parts of it (groups, public ids, ...) isn't
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil && strings.HasSuffix(template, "{dashboard_id}") {
+				return enums.OrganizationRoles.Admin
+			}
+		}
+	}
+	return enums.OrganizationRoles.Editor
+}
+
+// checkValidatorDashboardAccess fails if the user doesn't have the required role on the dashboard and returns the owner of the dashboard otherwise.
+// Personal dashboards are only accessible to their owner, organization dashboards to all members depending on their role.
+func (h *HandlerService) checkValidatorDashboardAccess(ctx context.Context, dashboardId types.VDBIdPrimary, userId uint64, required enums.OrganizationRole) (uint64, error) {
+	dashboardUser, err := h.daService.GetValidatorDashboardUser(ctx, dashboardId)
+	if err != nil {
+		return 0, err
+	}
+
+	if dashboardUser.OrganizationId != nil {
+		role, err := h.daService.GetOrganizationMemberRole(ctx, *dashboardUser.OrganizationId, userId)
+		if errors.Is(err, dataaccess.ErrNotFound) {
+			return 0, newNotFoundErr("dashboard with id %v not found", dashboardId)
+		}
+		if err != nil {
+			return 0, err
+		}
+		if !role.Includes(required) {
+			return 0, newForbiddenErr("organization role '%s' does not allow this action, at least '%s' is required", role, required)
+		}
+	} else if dashboardUser.UserId != userId {
+		// the proper error would be 403 Forbidden, but we don't want to leak information so we return 404 Not Found
+		return 0, newNotFoundErr("dashboard with id %v not found", dashboardId)
+	}
+	return dashboardUser.UserId, nil
+}
+
+// getValidatorDashboardPerks returns the perks that limit a validator dashboard and the organization owning it, if any.
+// Organization dashboards are limited by the pooled perks of the organization, personal dashboards by the perks of the user.
+func (h *HandlerService) getValidatorDashboardPerks(r *http.Request, dashboardId types.VDBIdPrimary, userInfo *types.UserInfo) (*types.PremiumPerks, *uint64, error) {
+	dashboardUser, err := h.getDataAccessor(r).GetValidatorDashboardUser(r.Context(), dashboardId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if dashboardUser.OrganizationId == nil {
+		return &userInfo.PremiumPerks, nil, nil
+	}
+	perks, err := h.getDataAccessor(r).GetOrganizationPremiumPerks(r.Context(), *dashboardUser.OrganizationId)
+	return perks, dashboardUser.OrganizationId, err
+}
+
+// getValidatorDashboardCount counts the active or archived dashboards of the organization, or the personal ones of the user if organizationId is nil
+func (h *HandlerService) getValidatorDashboardCount(r *http.Request, userId uint64, organizationId *uint64, active bool) (uint64, error) {
+	if organizationId != nil {
+		return h.getMainDataAccessor(r).GetOrganizationValidatorDashboardCount(r.Context(), *organizationId, active)
+	}
+	return h.getMainDataAccessor(r).GetUserValidatorDashboardCount(r.Context(), userId, active)
+}
+
+// getDashboardNotificationUserId returns the user receiving the notifications of the dashboard in the request.
+// Notifications of organization dashboards are routed to their creator, members manage them on the creator's behalf.
+func getDashboardNotificationUserId(r *http.Request) (uint64, error) {
+	if ownerId, ok := r.Context().Value(types.CtxDashboardOwnerIdKey).(uint64); ok {
+		return ownerId, nil
+	}
+	return GetUserIdByContext(r)
+}
+
+func sendOrganizationInvitationEmail(email, organizationName string, role enums.OrganizationRole, token string) error {
+	subject := fmt.Sprintf("%s: Invitation to join %s", utils.Config.Frontend.SiteDomain, organizationName)
+	msg := fmt.Sprintf(`You have been invited to join the organization "%[2]s" on %[1]s as %[3]s.
+
+Log in with this email address and accept the invitation by opening the following link:
+
+https://%[1]s/organizations/invitations/%[4]s
+
+The invitation expires in 7 days. If you don't want to join the organization, you can ignore this email.
+
+Best regards,
+
+%[1]s
+`, utils.Config.Frontend.SiteDomain, organizationName, role, token)
+	return mail.SendTextMail(email, subject, msg, []commonTypes.EmailAttachment{})
+}
+
+// --------------------------------------
+//   Organizations
+
+func (h *HandlerService) InternalGetUserOrganizations(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	data, err := h.getDataAccessor(r).GetUserOrganizations(r.Context(), user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetUserOrganizationsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+func (h *HandlerService) InternalPostUserOrganizations(w http.ResponseWriter, r *http.Request) {
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	var v validationError
+	req := struct {
+		Name string `json:"name"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	name := v.checkNameNotEmpty(req.Name)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	organizations, err := h.daService.GetUserOrganizations(r.Context(), user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if len(organizations) >= maxOrganizationsPerUser {
+		returnConflict(w, r, errors.New("maximum number of organizations reached"))
+		return
+	}
+
+	data, err := h.getDataAccessor(r).CreateOrganization(r.Context(), user.Id, name)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalPostUserOrganizationsResponse{
+		Data: *data,
+	}
+	returnCreated(w, r, response)
+}
+
+func (h *HandlerService) InternalGetOrganization(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	organizationId := v.checkUint(mux.Vars(r)["organization_id"], "organization_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	organization, err := h.getDataAccessor(r).GetUserOrganization(r.Context(), user.Id, organizationId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	premiumPerks, err := h.getDataAccessor(r).GetOrganizationPremiumPerks(r.Context(), organizationId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetOrganizationResponse{
+		Data: types.OrganizationDetails{
+			Organization: *organization,
+			PremiumPerks: *premiumPerks,
+		},
+	}
+	returnOk(w, r, response)
+}
+
+func (h *HandlerService) InternalPutOrganization(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	organizationId := v.checkUint(mux.Vars(r)["organization_id"], "organization_id")
+	req := struct {
+		Name string `json:"name"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	name := v.checkNameNotEmpty(req.Name)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if _, err := h.checkOrganizationRole(r.Context(), organizationId, user.Id, enums.OrganizationRoles.Admin); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	err = h.getDataAccessor(r).UpdateOrganizationName(r.Context(), organizationId, name)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	organization, err := h.getDataAccessor(r).GetUserOrganization(r.Context(), user.Id, organizationId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalPutOrganizationResponse{
+		Data: *organization,
+	}
+	returnOk(w, r, response)
+}
+
+func (h *HandlerService) InternalDeleteOrganization(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	organizationId := v.checkUint(mux.Vars(r)["organization_id"], "organization_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if _, err := h.checkOrganizationRole(r.Context(), organizationId, user.Id, enums.OrganizationRoles.Owner); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	// dashboards would lose their owner, they have to be deleted or transferred first
+	for _, active := range []bool{true, false} {
+		dashboardCount, err := h.daService.GetOrganizationValidatorDashboardCount(r.Context(), organizationId, active)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		if dashboardCount > 0 {
+			returnConflict(w, r, errors.New("organization still owns dashboards, delete or transfer them first"))
+			return
+		}
+	}
+
+	err = h.getDataAccessor(r).RemoveOrganization(r.Context(), organizationId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
+}
+
+// --------------------------------------
+//   Members
+
+func (h *HandlerService) InternalGetOrganizationMembers(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	organizationId := v.checkUint(mux.Vars(r)["organization_id"], "organization_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if _, err := h.checkOrganizationRole(r.Context(), organizationId, user.Id, enums.OrganizationRoles.Viewer); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetOrganizationMembers(r.Context(), organizationId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetOrganizationMembersResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+// getOrganizationMember returns the member and the number of owners of the organization
+func (h *HandlerService) getOrganizationMember(ctx context.Context, organizationId, userId uint64) (*types.OrganizationMember, int, error) {
+	members, err := h.daService.GetOrganizationMembers(ctx, organizationId)
+	if err != nil {
+		return nil, 0, err
+	}
+	var member *types.OrganizationMember
+	ownerCount := 0
+	for i := range members {
+		if members[i].Role == enums.OrganizationRoles.Owner.String() {
+			ownerCount++
+		}
+		if members[i].UserId == userId {
+			member = &members[i]
+		}
+	}
+	if member == nil {
+		return nil, 0, newNotFoundErr("user %v is not a member of organization %v", userId, organizationId)
+	}
+	return member, ownerCount, nil
+}
+
+// InternalPutOrganizationMember changes the role of a member, only owners can grant or revoke the owner role
+func (h *HandlerService) InternalPutOrganizationMember(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	organizationId := v.checkUint(vars["organization_id"], "organization_id")
+	memberId := v.checkUint(vars["user_id"], "user_id")
+	req := struct {
+		Role string `json:"role"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	role := checkEnum[enums.OrganizationRole](&v, req.Role, "role")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	userRole, err := h.checkOrganizationRole(r.Context(), organizationId, user.Id, enums.OrganizationRoles.Admin)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	member, ownerCount, err := h.getOrganizationMember(r.Context(), organizationId, memberId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	memberRole := enums.OrganizationRole(0).NewFromString(member.Role)
+	if (role == enums.OrganizationRoles.Owner || memberRole == enums.OrganizationRoles.Owner) && userRole != enums.OrganizationRoles.Owner {
+		handleErr(w, r, newForbiddenErr("only owners can grant or revoke the owner role"))
+		return
+	}
+	if memberRole == enums.OrganizationRoles.Owner && role != enums.OrganizationRoles.Owner && ownerCount <= 1 {
+		returnConflict(w, r, errors.New("an organization needs at least one owner"))
+		return
+	}
+
+	err = h.getDataAccessor(r).UpdateOrganizationMemberRole(r.Context(), organizationId, memberId, role)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	member.Role = role.String()
+	response := types.InternalPutOrganizationMemberResponse{
+		Data: *member,
+	}
+	returnOk(w, r, response)
+}
+
+// InternalDeleteOrganizationMember removes a member, every member can remove themselves to leave the organization
+func (h *HandlerService) InternalDeleteOrganizationMember(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	organizationId := v.checkUint(vars["organization_id"], "organization_id")
+	memberId := v.checkUint(vars["user_id"], "user_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	requiredRole := enums.OrganizationRoles.Admin
+	if memberId == user.Id {
+		requiredRole = enums.OrganizationRoles.Viewer
+	}
+	userRole, err := h.checkOrganizationRole(r.Context(), organizationId, user.Id, requiredRole)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	member, ownerCount, err := h.getOrganizationMember(r.Context(), organizationId, memberId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if member.Role == enums.OrganizationRoles.Owner.String() {
+		if userRole != enums.OrganizationRoles.Owner {
+			handleErr(w, r, newForbiddenErr("only owners can remove other owners"))
+			return
+		}
+		if ownerCount <= 1 {
+			returnConflict(w, r, errors.New("the last owner can't leave the organization, delete it instead"))
+			return
+		}
+	}
+
+	err = h.getDataAccessor(r).RemoveOrganizationMember(r.Context(), organizationId, memberId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
+}
+
+// --------------------------------------
+//   Invitations
+
+func (h *HandlerService) InternalGetOrganizationInvitations(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	organizationId := v.checkUint(mux.Vars(r)["organization_id"], "organization_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if _, err := h.checkOrganizationRole(r.Context(), organizationId, user.Id, enums.OrganizationRoles.Admin); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetOrganizationInvitations(r.Context(), organizationId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetOrganizationInvitationsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+// InternalPostOrganizationInvitations invites a user via email, inviting an address again replaces the previous invitation
+func (h *HandlerService) InternalPostOrganizationInvitations(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	organizationId := v.checkUint(mux.Vars(r)["organization_id"], "organization_id")
+	req := struct {
+		Email string `json:"email"`
+		Role  string `json:"role"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	email := v.checkEmail(req.Email)
+	role := checkEnum[enums.OrganizationRole](&v, req.Role, "role")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	ctx := r.Context()
+	userRole, err := h.checkOrganizationRole(ctx, organizationId, user.Id, enums.OrganizationRoles.Admin)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if role == enums.OrganizationRoles.Owner && userRole != enums.OrganizationRoles.Owner {
+		handleErr(w, r, newForbiddenErr("only owners can invite other owners"))
+		return
+	}
+
+	members, err := h.daService.GetOrganizationMembers(ctx, organizationId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	for _, member := range members {
+		if strings.EqualFold(member.Email, email) {
+			returnConflict(w, r, errors.New("user is already a member of the organization"))
+			return
+		}
+	}
+	invitations, err := h.daService.GetOrganizationInvitations(ctx, organizationId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if len(members)+len(invitations) >= maxOrganizationMembers {
+		returnConflict(w, r, errors.New("maximum number of organization members reached"))
+		return
+	}
+	organization, err := h.daService.GetUserOrganization(ctx, user.Id, organizationId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	invitation, token, err := h.getDataAccessor(r).CreateOrganizationInvitation(ctx, organizationId, user.Id, email, role)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	err = sendOrganizationInvitationEmail(email, organization.Name, role, token)
+	if err != nil {
+		// the invitation is useless without the token, don't keep it around
+		if removeErr := h.daService.RemoveOrganizationInvitation(ctx, organizationId, invitation.Id); removeErr != nil {
+			log.Error(removeErr, "error removing organization invitation after failed email", 0, map[string]interface{}{"organization_id": organizationId})
+		}
+		handleErr(w, r, errors.New("error sending invitation email, try again later"))
+		return
+	}
+	response := types.InternalPostOrganizationInvitationsResponse{
+		Data: *invitation,
+	}
+	returnCreated(w, r, response)
+}
+
+func (h *HandlerService) InternalDeleteOrganizationInvitation(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	organizationId := v.checkUint(vars["organization_id"], "organization_id")
+	invitationId := v.checkUint(vars["invitation_id"], "invitation_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if _, err := h.checkOrganizationRole(r.Context(), organizationId, user.Id, enums.OrganizationRoles.Admin); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	err = h.getDataAccessor(r).RemoveOrganizationInvitation(r.Context(), organizationId, invitationId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
+}
+
+// InternalPostUserOrganizationMemberships accepts an invitation, it must have been sent to the email address of the user
+func (h *HandlerService) InternalPostUserOrganizationMemberships(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	req := struct {
+		Token string `json:"token"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	token := v.checkUserEmailToken(req.Token)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	userInfo, err := h.daService.GetUserCredentialInfo(r.Context(), user.Id)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	organizationId, err := h.getDataAccessor(r).AcceptOrganizationInvitation(r.Context(), user.Id, userInfo.Email, token)
+	if err != nil {
+		if errors.Is(err, dataaccess.ErrNotFound) {
+			err = newNotFoundErr("invitation not found, it might have expired or was sent to another email address")
+		}
+		handleErr(w, r, err)
+		return
+	}
+	organization, err := h.getDataAccessor(r).GetUserOrganization(r.Context(), user.Id, organizationId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalPostUserOrganizationsResponse{
+		Data: *organization,
+	}
+	returnCreated(w, r, response)
+}
+
+// --------------------------------------
+//   Dashboard ownership
+
+// InternalPutValidatorDashboardOrganization transfers a validator dashboard to an organization or, if no organization is given, to the requesting user.
+// Organization admins can transfer dashboards away from their organization, only admins of the target organization can transfer dashboards to it.
+func (h *HandlerService) InternalPutValidatorDashboardOrganization(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	req := struct {
+		OrganizationId *uint64 `json:"organization_id,omitempty"`
+	}{}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	user, err := h.getUserBySession(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	ctx := r.Context()
+	dashboardUser, err := h.daService.GetValidatorDashboardUser(ctx, dashboardId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if dashboardUser.OrganizationId != nil {
+		if _, err := h.checkOrganizationRole(ctx, *dashboardUser.OrganizationId, user.Id, enums.OrganizationRoles.Admin); err != nil {
+			handleErr(w, r, err)
+			return
+		}
+	} else if dashboardUser.UserId != user.Id {
+		handleErr(w, r, newNotFoundErr("dashboard with id %v not found", dashboardId))
+		return
+	}
+	if req.OrganizationId != nil {
+		if _, err := h.checkOrganizationRole(ctx, *req.OrganizationId, user.Id, enums.OrganizationRoles.Admin); err != nil {
+			handleErr(w, r, err)
+			return
+		}
+	}
+
+	// the new owner must have room for the dashboard
+	dashboard, err := h.daService.GetValidatorDashboardInfo(ctx, dashboardId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if !dashboard.IsArchived {
+		var perks *types.PremiumPerks
+		if req.OrganizationId != nil {
+			perks, err = h.daService.GetOrganizationPremiumPerks(ctx, *req.OrganizationId)
+		} else {
+			var userInfo *types.UserInfo
+			userInfo, err = h.daService.GetUserInfo(ctx, user.Id)
+			if userInfo != nil {
+				perks = &userInfo.PremiumPerks
+			}
+		}
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		dashboardCount, err := h.getValidatorDashboardCount(r, user.Id, req.OrganizationId, true)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		if dashboardCount >= perks.ValidatorDashboards {
+			returnConflict(w, r, errors.New("maximum number of validator dashboards of the new owner reached"))
+			return
+		}
+	}
+
+	err = h.getDataAccessor(r).UpdateValidatorDashboardOwner(ctx, dashboardId, user.Id, req.OrganizationId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
+}
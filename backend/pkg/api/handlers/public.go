@@ -2,14 +2,19 @@ package handlers
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/gobitfly/beaconchain/pkg/api/enums"
 	"github.com/gobitfly/beaconchain/pkg/api/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gobitfly/beaconchain/pkg/reports"
 	"github.com/gorilla/mux"
 	"github.com/shopspring/decimal"
 )
@@ -85,75 +90,85 @@ func (h *HandlerService) PublicGetUserDashboards(w http.ResponseWriter, r *http.
 	returnOk(w, r, response)
 }
 
-func (h *HandlerService) PublicPostAccountDashboards(w http.ResponseWriter, r *http.Request) {
-	returnCreated(w, r, nil)
-}
-
-func (h *HandlerService) PublicGetAccountDashboard(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
-}
-
-func (h *HandlerService) PublicDeleteAccountDashboard(w http.ResponseWriter, r *http.Request) {
-	returnNoContent(w, r)
-}
-
-func (h *HandlerService) PublicPostAccountDashboardGroups(w http.ResponseWriter, r *http.Request) {
-	returnCreated(w, r, nil)
-}
-
-func (h *HandlerService) PublicDeleteAccountDashboardGroups(w http.ResponseWriter, r *http.Request) {
-	returnNoContent(w, r)
-}
-
-func (h *HandlerService) PublicPostAccountDashboardAccounts(w http.ResponseWriter, r *http.Request) {
-	returnCreated(w, r, nil)
-}
-
-func (h *HandlerService) PublicGetAccountDashboardAccounts(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
-}
-
-func (h *HandlerService) PublicDeleteAccountDashboardAccounts(w http.ResponseWriter, r *http.Request) {
-	returnNoContent(w, r)
-}
-
-func (h *HandlerService) PublicPutAccountDashboardAccount(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
-}
-
-func (h *HandlerService) PublicPostAccountDashboardPublicIds(w http.ResponseWriter, r *http.Request) {
-	returnCreated(w, r, nil)
-}
-
-func (h *HandlerService) PublicPutAccountDashboardPublicId(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
-}
+const (
+	apiUsageDefaultInterval = 7 * 24 * 60 * 60  // 7 days
+	apiUsageMaxInterval     = 90 * 24 * 60 * 60 // 90 days
+)
 
-func (h *HandlerService) PublicDeleteAccountDashboardPublicId(w http.ResponseWriter, r *http.Request) {
-	returnNoContent(w, r)
-}
+// PublicGetUserApiUsage godoc
+//
+//	@Description	Get the number of requests and their weight per API key and endpoint of the authenticated user over time. The weight is what counts towards the rate limits.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Users
+//	@Produce		json
+//	@Param			aggregation	query		string	false	"Aggregation interval of the usage, defaults to `hourly`."	Enums(hourly, daily, weekly)
+//	@Param			after_ts	query		string	false	"Return usage after this unix timestamp. Defaults to 7 days before `before_ts`."
+//	@Param			before_ts	query		string	false	"Return usage before this unix timestamp. Defaults to now. The range must not exceed 90 days."
+//	@Success		200			{object}	types.GetUserApiUsageResponse
+//	@Failure		400			{object}	types.ApiErrorResponse
+//	@Router			/users/me/api-usage [get]
+func (h *HandlerService) PublicGetUserApiUsage(w http.ResponseWriter, r *http.Request) {
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	var v validationError
+	q := r.URL.Query()
+	aggregation := checkEnum[enums.ChartAggregation](&v, q.Get("aggregation"), "aggregation")
+	if aggregation == enums.ChartAggregations.Epoch {
+		v.add("aggregation", "epoch aggregation is not supported for api usage")
+	}
+	beforeTs := uint64(time.Now().Unix())
+	if beforeParam := q.Get("before_ts"); beforeParam != "" {
+		beforeTs = v.checkUint(beforeParam, "before_ts")
+	}
+	afterTs := beforeTs - min(apiUsageDefaultInterval, beforeTs)
+	if afterParam := q.Get("after_ts"); afterParam != "" {
+		afterTs = v.checkUint(afterParam, "after_ts")
+	}
+	if afterTs >= beforeTs {
+		v.add("after_ts", "must be before `before_ts`")
+	} else if beforeTs-afterTs > apiUsageMaxInterval {
+		v.add("after_ts", "the time range must not exceed 90 days")
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
 
-func (h *HandlerService) PublicGetAccountDashboardTransactions(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	data, err := h.getDataAccessor(r).GetUserApiUsage(r.Context(), userId, aggregation, afterTs, beforeTs)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetUserApiUsageResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
-func (h *HandlerService) PublicPutAccountDashboardTransactionsSettings(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
-}
+// account dashboards are not (yet) part of the premium perks, so they share fixed limits
+const (
+	maxAccountDashboardsPerUser     uint64 = 10
+	maxAccountGroupsPerDashboard    uint64 = 20
+	maxAccountsPerAccountDashboard  uint64 = 100
+	maxAccountsPerDashboardForAdmin uint64 = math.MaxUint16
+)
 
-// PublicPostValidatorDashboards godoc
+// PublicPostAccountDashboards godoc
 //
-//	@Description	Create a new validator dashboard. **Note**: New dashboards will automatically have a default group created.
+//	@Description	Create a new account dashboard. **Note**: New dashboards will automatically have a default group created.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Validator Dashboard Management
+//	@Tags			Account Dashboard Management
 //	@Accept			json
 //	@Produce		json
-//	@Param			request	body		handlers.PublicPostValidatorDashboards.request	true	"`name`: Specify the name of the dashboard.<br>`network`: Specify the network for the dashboard. Possible options are:<ul><li>`ethereum`</li><li>`gnosis`</li></ul>"
-//	@Success		201		{object}	types.ApiDataResponse[types.VDBPostReturnData]
+//	@Param			request	body		handlers.PublicPostAccountDashboards.request	true	"`name`: Specify the name of the dashboard."
+//	@Success		201		{object}	types.ApiDataResponse[types.ADBPostReturnData]
 //	@Failure		400		{object}	types.ApiErrorResponse
 //	@Failure		409		{object}	types.ApiErrorResponse	"Conflict. The request could not be performed by the server because the authenticated user has already reached their dashboard limit."
-//	@Router			/validator-dashboards [post]
-func (h *HandlerService) PublicPostValidatorDashboards(w http.ResponseWriter, r *http.Request) {
+//	@Router			/account-dashboards [post]
+func (h *HandlerService) PublicPostAccountDashboards(w http.ResponseWriter, r *http.Request) {
 	var v validationError
 	userId, err := GetUserIdByContext(r)
 	if err != nil {
@@ -162,8 +177,7 @@ func (h *HandlerService) PublicPostValidatorDashboards(w http.ResponseWriter, r
 	}
 
 	type request struct {
-		Name    string      `json:"name"`
-		Network intOrString `json:"network" swaggertype:"string" enums:"ethereum,gnosis"`
+		Name string `json:"name"`
 	}
 	var req request
 	if err := v.checkBody(&req, r); err != nil {
@@ -171,117 +185,79 @@ func (h *HandlerService) PublicPostValidatorDashboards(w http.ResponseWriter, r
 		return
 	}
 	name := v.checkNameNotEmpty(req.Name)
-	chainId := v.checkNetwork(req.Network)
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
 
-	userInfo, err := h.getDataAccessor(r).GetUserInfo(r.Context(), userId)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-	dashboardCount, err := h.getDataAccessor(r).GetUserValidatorDashboardCount(r.Context(), userId, true)
+	dashboardCount, err := h.getDataAccessor(r).GetUserAccountDashboardCount(r.Context(), userId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	if dashboardCount >= userInfo.PremiumPerks.ValidatorDashboards {
-		returnConflict(w, r, errors.New("maximum number of validator dashboards reached"))
+	if dashboardCount >= maxAccountDashboardsPerUser {
+		returnConflict(w, r, errors.New("maximum number of account dashboards reached"))
 		return
 	}
 
-	data, err := h.getDataAccessor(r).CreateValidatorDashboard(r.Context(), userId, name, chainId)
+	data, err := h.getDataAccessor(r).CreateAccountDashboard(r.Context(), userId, name)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.ApiDataResponse[types.VDBPostReturnData]{
+	response := types.ApiDataResponse[types.ADBPostReturnData]{
 		Data: *data,
 	}
 	returnCreated(w, r, response)
 }
 
-// PublicGetValidatorDashboards godoc
+// PublicGetAccountDashboard godoc
 //
-//	@Description	Get overview information for a specified dashboard
-//	@Tags			Validator Dashboard
+//	@Description	Get overview information for a specified account dashboard.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Account Dashboard
 //	@Produce		json
-//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool``."
-//	@Success		200				{object}	types.GetValidatorDashboardResponse
+//	@Param			dashboard_id	path		integer	true	"The ID of the dashboard."
+//	@Success		200				{object}	types.GetAccountDashboardResponse
 //	@Failure		400				{object}	types.ApiErrorResponse	"Bad Request"
-//	@Router			/validator-dashboards/{dashboard_id} [get]
-func (h *HandlerService) PublicGetValidatorDashboard(w http.ResponseWriter, r *http.Request) {
+//	@Failure		404				{object}	types.ApiErrorResponse	"Not Found"
+//	@Router			/account-dashboards/{dashboard_id} [get]
+func (h *HandlerService) PublicGetAccountDashboard(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	dashboardIdParam := mux.Vars(r)["dashboard_id"]
-	dashboardId, err := h.handleDashboardId(r.Context(), dashboardIdParam)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	q := r.URL.Query()
-	protocolModes := v.checkProtocolModes(q.Get("modes"))
+	dashboardId := v.checkAccountDashboardId(mux.Vars(r)["dashboard_id"])
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-
-	// set name depending on dashboard id
-	var name string
-	if reInteger.MatchString(dashboardIdParam) {
-		name, err = h.getDataAccessor(r).GetValidatorDashboardName(r.Context(), dashboardId.Id)
-	} else if reValidatorDashboardPublicId.MatchString(dashboardIdParam) {
-		var publicIdInfo *types.VDBPublicId
-		publicIdInfo, err = h.getDataAccessor(r).GetValidatorDashboardPublicId(r.Context(), types.VDBIdPublic(dashboardIdParam))
-		name = publicIdInfo.Name
-	}
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	// add premium chart perk info for shared dashboards
-	premiumPerks, err := h.getDashboardPremiumPerks(r.Context(), *dashboardId)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-	data, err := h.getDataAccessor(r).GetValidatorDashboardOverview(r.Context(), *dashboardId, protocolModes)
+	data, err := h.getDataAccessor(r).GetAccountDashboardOverview(r.Context(), dashboardId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	data.ChartHistorySeconds = premiumPerks.ChartHistorySeconds
-	data.Name = name
-
-	response := types.GetValidatorDashboardResponse{
+	response := types.GetAccountDashboardResponse{
 		Data: *data,
 	}
-
 	returnOk(w, r, response)
 }
 
-// PublicPutValidatorDashboard godoc
+// PublicDeleteAccountDashboard godoc
 //
-//	@Description	Delete a specified validator dashboard.
+//	@Description	Delete a specified account dashboard.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Validator Dashboard Management
+//	@Tags			Account Dashboard Management
 //	@Produce		json
 //	@Param			dashboard_id	path	integer	true	"The ID of the dashboard."
 //	@Success		204				"Dashboard deleted successfully."
 //	@Failure		400				{object}	types.ApiErrorResponse	"Bad Request"
-//	@Router			/validator-dashboards/{dashboard_id} [delete]
-func (h *HandlerService) PublicDeleteValidatorDashboard(w http.ResponseWriter, r *http.Request) {
+//	@Router			/account-dashboards/{dashboard_id} [delete]
+func (h *HandlerService) PublicDeleteAccountDashboard(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	dashboardId := v.checkAccountDashboardId(mux.Vars(r)["dashboard_id"])
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	err := h.getDataAccessor(r).RemoveValidatorDashboard(r.Context(), dashboardId)
+	err := h.getDataAccessor(r).RemoveAccountDashboard(r.Context(), dashboardId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
@@ -289,21 +265,21 @@ func (h *HandlerService) PublicDeleteValidatorDashboard(w http.ResponseWriter, r
 	returnNoContent(w, r)
 }
 
-// PublicPutValidatorDashboard godoc
+// PublicPutAccountDashboardName godoc
 //
-//	@Description	Update the name of a specified validator dashboard.
+//	@Description	Update the name of a specified account dashboard.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Validator Dashboard Management
+//	@Tags			Account Dashboard Management
 //	@Accept			json
 //	@Produce		json
-//	@Param			dashboard_id	path		integer												true	"The ID of the dashboard."
-//	@Param			request			body		handlers.PublicPutValidatorDashboardName.request	true	"request"
-//	@Success		200				{object}	types.ApiDataResponse[types.VDBPostReturnData]
+//	@Param			dashboard_id	path		integer											true	"The ID of the dashboard."
+//	@Param			request			body		handlers.PublicPutAccountDashboardName.request	true	"request"
+//	@Success		200				{object}	types.ApiDataResponse[types.ADBPostReturnData]
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/name [put]
-func (h *HandlerService) PublicPutValidatorDashboardName(w http.ResponseWriter, r *http.Request) {
+//	@Router			/account-dashboards/{dashboard_id}/name [put]
+func (h *HandlerService) PublicPutAccountDashboardName(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	dashboardId := v.checkAccountDashboardId(mux.Vars(r)["dashboard_id"])
 	type request struct {
 		Name string `json:"name"`
 	}
@@ -317,33 +293,33 @@ func (h *HandlerService) PublicPutValidatorDashboardName(w http.ResponseWriter,
 		handleErr(w, r, v)
 		return
 	}
-	data, err := h.getDataAccessor(r).UpdateValidatorDashboardName(r.Context(), dashboardId, name)
+	data, err := h.getDataAccessor(r).UpdateAccountDashboardName(r.Context(), dashboardId, name)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.ApiDataResponse[types.VDBPostReturnData]{
+	response := types.ApiDataResponse[types.ADBPostReturnData]{
 		Data: *data,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicPostValidatorDashboardGroups godoc
+// PublicPostAccountDashboardGroups godoc
 //
-//	@Description	Create a new group in a specified validator dashboard.
+//	@Description	Create a new group in a specified account dashboard.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Validator Dashboard Management
+//	@Tags			Account Dashboard Management
 //	@Accept			json
 //	@Produce		json
 //	@Param			dashboard_id	path		integer												true	"The ID of the dashboard."
-//	@Param			request			body		handlers.PublicPostValidatorDashboardGroups.request	true	"request"
-//	@Success		201				{object}	types.ApiDataResponse[types.VDBPostCreateGroupData]
+//	@Param			request			body		handlers.PublicPostAccountDashboardGroups.request	true	"request"
+//	@Success		201				{object}	types.ApiDataResponse[types.ADBPostCreateGroupData]
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Failure		409				{object}	types.ApiErrorResponse	"Conflict. The request could not be performed by the server because the authenticated user has already reached their group limit."
-//	@Router			/validator-dashboards/{dashboard_id}/groups [post]
-func (h *HandlerService) PublicPostValidatorDashboardGroups(w http.ResponseWriter, r *http.Request) {
+//	@Failure		409				{object}	types.ApiErrorResponse	"Conflict. The request could not be performed by the server because the dashboard has already reached its group limit."
+//	@Router			/account-dashboards/{dashboard_id}/groups [post]
+func (h *HandlerService) PublicPostAccountDashboardGroups(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	dashboardId := v.checkAccountDashboardId(mux.Vars(r)["dashboard_id"])
 	type request struct {
 		Name string `json:"name"`
 	}
@@ -358,72 +334,53 @@ func (h *HandlerService) PublicPostValidatorDashboardGroups(w http.ResponseWrite
 		return
 	}
 	ctx := r.Context()
-	// check if user has reached the maximum number of groups
-	userId, err := GetUserIdByContext(r)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-	userInfo, err := h.getDataAccessor(r).GetUserInfo(ctx, userId)
+	groupCount, err := h.getDataAccessor(r).GetAccountDashboardGroupCount(ctx, dashboardId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	groupCount, err := h.getDataAccessor(r).GetValidatorDashboardGroupCount(ctx, dashboardId)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-	if groupCount >= userInfo.PremiumPerks.ValidatorGroupsPerDashboard {
-		returnConflict(w, r, errors.New("maximum number of validator dashboard groups reached"))
+	if groupCount >= maxAccountGroupsPerDashboard {
+		returnConflict(w, r, errors.New("maximum number of account dashboard groups reached"))
 		return
 	}
 
-	data, err := h.getDataAccessor(r).CreateValidatorDashboardGroup(ctx, dashboardId, name)
+	data, err := h.getDataAccessor(r).CreateAccountDashboardGroup(ctx, dashboardId, name)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-
-	response := types.ApiDataResponse[types.VDBPostCreateGroupData]{
+	response := types.ApiDataResponse[types.ADBPostCreateGroupData]{
 		Data: *data,
 	}
-
 	returnCreated(w, r, response)
 }
 
-// PublicGetValidatorDashboardGroups godoc
+// PublicDeleteAccountDashboardGroups godoc
 //
-//	@Description	Update a groups name in a specified validator dashboard.
+//	@Description	Delete a group in a specified account dashboard. Accounts of the group are removed from the dashboard as well.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Validator Dashboard Management
-//	@Accept			json
+//	@Tags			Account Dashboard Management
 //	@Produce		json
-//	@Param			dashboard_id	path		integer												true	"The ID of the dashboard."
-//	@Param			group_id		path		integer												true	"The ID of the group."
-//	@Param			request			body		handlers.PublicPutValidatorDashboardGroups.request	true	"request"
-//	@Success		200				{object}	types.ApiDataResponse[types.VDBPostCreateGroupData]
+//	@Param			dashboard_id	path	integer	true	"The ID of the dashboard."
+//	@Param			group_id		path	integer	true	"The ID of the group."
+//	@Success		204				"Group deleted successfully."
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/groups/{group_id} [put]
-func (h *HandlerService) PublicPutValidatorDashboardGroups(w http.ResponseWriter, r *http.Request) {
+//	@Failure		404				{object}	types.ApiErrorResponse	"Not Found"
+//	@Router			/account-dashboards/{dashboard_id}/groups/{group_id} [delete]
+func (h *HandlerService) PublicDeleteAccountDashboardGroups(w http.ResponseWriter, r *http.Request) {
 	var v validationError
 	vars := mux.Vars(r)
-	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
+	dashboardId := v.checkAccountDashboardId(vars["dashboard_id"])
 	groupId := v.checkExistingGroupId(vars["group_id"])
-	type request struct {
-		Name string `json:"name"`
-	}
-	var req request
-	if err := v.checkBody(&req, r); err != nil {
-		handleErr(w, r, err)
-		return
-	}
-	name := v.checkNameNotEmpty(req.Name)
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	groupExists, err := h.getDataAccessor(r).GetValidatorDashboardGroupExists(r.Context(), dashboardId, groupId)
+	if groupId == types.DefaultGroupId {
+		returnBadRequest(w, r, errors.New("cannot delete default group"))
+		return
+	}
+	groupExists, err := h.getDataAccessor(r).GetAccountDashboardGroupExists(r.Context(), dashboardId, groupId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
@@ -432,45 +389,49 @@ func (h *HandlerService) PublicPutValidatorDashboardGroups(w http.ResponseWriter
 		returnNotFound(w, r, errors.New("group not found"))
 		return
 	}
-	data, err := h.getDataAccessor(r).UpdateValidatorDashboardGroup(r.Context(), dashboardId, groupId, name)
+	err = h.getDataAccessor(r).RemoveAccountDashboardGroup(r.Context(), dashboardId, groupId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-
-	response := types.ApiDataResponse[types.VDBPostCreateGroupData]{
-		Data: *data,
-	}
-
-	returnOk(w, r, response)
+	returnNoContent(w, r)
 }
 
-// PublicDeleteValidatorDashboardGroup godoc
+// PublicPostAccountDashboardAccounts godoc
 //
-//	@Description	Delete a group in a specified validator dashboard.
-//	@Tags			Validator Dashboard Management
+//	@Description	Add new accounts to a specified account dashboard or move already added accounts to another group. Accounts exceeding the dashboard limit are ignored, the response contains the list of added accounts.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Account Dashboard Management
 //	@Accept			json
 //	@Produce		json
-//	@Param			dashboard_id	path	integer	true	"The ID of the dashboard."
-//	@Param			group_id		path	integer	true	"The ID of the group."
-//	@Success		204				"Group deleted successfully."
+//	@Param			dashboard_id	path		integer												true	"The ID of the dashboard."
+//	@Param			request			body		handlers.PublicPostAccountDashboardAccounts.request	true	"`addresses`: Provide a list of addresses.<br>`group_id`: (optional) Provide a single group id, to which all accounts get added to. If omitted, the default group will be used."
+//	@Success		201				{object}	types.ApiDataResponse[[]types.ADBPostAccountsData]	"Returns a list of added accounts."
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/groups/{group_id} [delete]
-func (h *HandlerService) PublicDeleteValidatorDashboardGroup(w http.ResponseWriter, r *http.Request) {
+//	@Failure		404				{object}	types.ApiErrorResponse	"Not Found"
+//	@Router			/account-dashboards/{dashboard_id}/accounts [post]
+func (h *HandlerService) PublicPostAccountDashboardAccounts(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	vars := mux.Vars(r)
-	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
-	groupId := v.checkExistingGroupId(vars["group_id"])
-	if v.hasErrors() {
-		handleErr(w, r, v)
+	dashboardId := v.checkAccountDashboardId(mux.Vars(r)["dashboard_id"])
+	type request struct {
+		GroupId   uint64   `json:"group_id,omitempty" x-nullable:"true"`
+		Addresses []string `json:"addresses"`
+	}
+	req := request{
+		GroupId: types.DefaultGroupId, // default value
+	}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
 		return
 	}
-	if groupId == types.DefaultGroupId {
-		returnBadRequest(w, r, errors.New("cannot delete default group"))
+	addresses := v.checkAddresses(req.Addresses, forbidEmpty)
+	if v.hasErrors() {
+		handleErr(w, r, v)
 		return
 	}
-	groupExists, err := h.getDataAccessor(r).GetValidatorDashboardGroupExists(r.Context(), dashboardId, groupId)
+
+	ctx := r.Context()
+	groupExists, err := h.getDataAccessor(r).GetAccountDashboardGroupExists(ctx, dashboardId, req.GroupId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
@@ -479,45 +440,102 @@ func (h *HandlerService) PublicDeleteValidatorDashboardGroup(w http.ResponseWrit
 		returnNotFound(w, r, errors.New("group not found"))
 		return
 	}
-	err = h.getDataAccessor(r).RemoveValidatorDashboardGroup(r.Context(), dashboardId, groupId)
+	userId, err := GetUserIdByContext(r)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-
-	returnNoContent(w, r)
+	userInfo, err := h.getDataAccessor(r).GetUserInfo(ctx, userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	dashboardLimit := maxAccountsPerAccountDashboard
+	if isUserAdmin(userInfo) {
+		dashboardLimit = maxAccountsPerDashboardForAdmin
+	}
+	existingAccountCount, err := h.getDataAccessor(r).GetAccountDashboardAccountCount(ctx, dashboardId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	var limit uint64
+	if dashboardLimit >= existingAccountCount {
+		limit = dashboardLimit - existingAccountCount
+	}
+	// accounts which are already part of the dashboard only change their group and don't count towards the limit
+	if uint64(len(addresses)) > limit {
+		addresses = addresses[:limit]
+	}
+
+	data, err := h.getDataAccessor(r).AddAccountDashboardAccounts(ctx, dashboardId, req.GroupId, addresses)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.ApiDataResponse[[]types.ADBPostAccountsData]{
+		Data: data,
+	}
+	returnCreated(w, r, response)
 }
 
-// PublicDeleteValidatorDashboardGroupValidators godoc
-// @Description	Delete all validators from a specified group in a specified validator dashboard.
-// @Tags			Validator Dashboard Management
-// @Security		ApiKeyInHeader || ApiKeyInQuery
-// @Accept		json
-// @Produce		json
-// @Param			dashboard_id	path	integer	true	"The ID of the dashboard."
-// @Param			group_id		path	integer	true	"The ID of the group."
-// @Success		204				"Validators removed successfully."
-// @Failure		400				{object}	types.ApiErrorResponse
-// @Router			/validator-dashboards/{dashboard_id}/groups/{group_id}/validators [delete]
-func (h *HandlerService) PublicDeleteValidatorDashboardGroupValidators(w http.ResponseWriter, r *http.Request) {
+// PublicGetAccountDashboardAccounts godoc
+//
+//	@Description	Get a list of accounts in a specified account dashboard.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Account Dashboard
+//	@Produce		json
+//	@Param			dashboard_id	path		integer	true	"The ID of the dashboard."
+//	@Param			group_id		query		integer	false	"The ID of the group."
+//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit			query		integer	false	"The maximum number of results that may be returned."
+//	@Param			search			query		string	false	"Search for (a prefix of) an address."
+//	@Success		200				{object}	types.GetAccountDashboardAccountsResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/account-dashboards/{dashboard_id}/accounts [get]
+func (h *HandlerService) PublicGetAccountDashboardAccounts(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	vars := mux.Vars(r)
-	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
-	groupId := v.checkExistingGroupId(vars["group_id"])
+	dashboardId := v.checkAccountDashboardId(mux.Vars(r)["dashboard_id"])
+	q := r.URL.Query()
+	groupId := v.checkGroupId(q.Get("group_id"), allowEmpty)
+	pagingParams := v.checkPagingParams(q)
+	search := v.checkAddressSearch(pagingParams.search)
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	groupExists, err := h.getDataAccessor(r).GetValidatorDashboardGroupExists(r.Context(), dashboardId, groupId)
+	data, paging, err := h.getDataAccessor(r).GetAccountDashboardAccounts(r.Context(), dashboardId, groupId, pagingParams.cursor, search, pagingParams.limit)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	if !groupExists {
-		returnNotFound(w, r, errors.New("group not found"))
+	response := types.GetAccountDashboardAccountsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicDeleteAccountDashboardAccounts godoc
+//
+//	@Description	Remove accounts from a specified account dashboard.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Account Dashboard Management
+//	@Produce		json
+//	@Param			dashboard_id	path	integer	true	"The ID of the dashboard."
+//	@Param			accounts		query	string	true	"Provide a comma separated list of addresses to remove from the dashboard."
+//	@Success		204				"Accounts removed successfully."
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/account-dashboards/{dashboard_id}/accounts [delete]
+func (h *HandlerService) PublicDeleteAccountDashboardAccounts(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkAccountDashboardId(mux.Vars(r)["dashboard_id"])
+	addresses := v.checkAddresses(splitParameters(r.URL.Query().Get("accounts"), ','), forbidEmpty)
+	if v.hasErrors() {
+		handleErr(w, r, v)
 		return
 	}
-	err = h.getDataAccessor(r).RemoveValidatorDashboardGroupValidators(r.Context(), dashboardId, groupId)
+	err := h.getDataAccessor(r).RemoveAccountDashboardAccounts(r.Context(), dashboardId, addresses)
 	if err != nil {
 		handleErr(w, r, err)
 		return
@@ -525,31 +543,29 @@ func (h *HandlerService) PublicDeleteValidatorDashboardGroupValidators(w http.Re
 	returnNoContent(w, r)
 }
 
-// PublicGetValidatorDashboardGroups godoc
+// PublicPutAccountDashboardAccount godoc
 //
-//	@Description	Add new validators to a specified dashboard or update the group of already-added validators. This endpoint will always add as many validators as possible, even if more validators are provided than allowed by the subscription plan. The response will contain a list of added validators.
+//	@Description	Move an account of a specified account dashboard to another group.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Validator Dashboard Management
+//	@Tags			Account Dashboard Management
 //	@Accept			json
 //	@Produce		json
-//	@Param			dashboard_id	path		integer													true	"The ID of the dashboard."
-//	@Param			request			body		handlers.PublicPostValidatorDashboardValidators.request	true	"`group_id`: (optional) Provide a single group id, to which all validators get added to. If omitted, the default group will be used.<br><br>To add validators or update their group, only one of the following fields can be set:<ul><li>`validators`: Provide a list of validator indices or public keys.</li><li>`deposit_address`: (limited to subscription tiers with 'Bulk adding') Provide a deposit address from which as many validators as possible will be added to the dashboard.</li><li>`withdrawal_credential`: (limited to subscription tiers with 'Bulk adding') Provide a withdrawal credential from which as many validators as possible will be added to the dashboard.</li><li>`graffiti`: (limited to subscription tiers with 'Bulk adding') Provide a graffiti string from which as many validators as possible will be added to the dashboard.</li></ul>"
-//	@Success		201				{object}	types.ApiDataResponse[[]types.VDBPostValidatorsData]	"Returns a list of added validators."
+//	@Param			dashboard_id	path		integer												true	"The ID of the dashboard."
+//	@Param			address			path		string												true	"The address of the account."
+//	@Param			request			body		handlers.PublicPutAccountDashboardAccount.request	true	"`group_id`: The id of the group the account gets moved to."
+//	@Success		200				{object}	types.ApiDataResponse[types.ADBPostAccountsData]
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/validators [post]
-func (h *HandlerService) PublicPostValidatorDashboardValidators(w http.ResponseWriter, r *http.Request) {
+//	@Failure		404				{object}	types.ApiErrorResponse	"Not Found"
+//	@Router			/account-dashboards/{dashboard_id}/accounts/{address} [put]
+func (h *HandlerService) PublicPutAccountDashboardAccount(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	vars := mux.Vars(r)
+	dashboardId := v.checkAccountDashboardId(vars["dashboard_id"])
+	address := v.checkAddressBytes(vars["address"], "address")
 	type request struct {
-		GroupId              uint64        `json:"group_id,omitempty" x-nullable:"true"`
-		Validators           []intOrString `json:"validators,omitempty"`
-		DepositAddress       string        `json:"deposit_address,omitempty"`
-		WithdrawalCredential string        `json:"withdrawal_credential,omitempty"`
-		Graffiti             string        `json:"graffiti,omitempty"`
-	}
-	req := request{
-		GroupId: types.DefaultGroupId, // default value
+		GroupId uint64 `json:"group_id"`
 	}
+	var req request
 	if err := v.checkBody(&req, r); err != nil {
 		handleErr(w, r, err)
 		return
@@ -558,30 +574,8 @@ func (h *HandlerService) PublicPostValidatorDashboardValidators(w http.ResponseW
 		handleErr(w, r, v)
 		return
 	}
-	groupId := req.GroupId
-	// check if exactly one of validators, deposit_address, withdrawal_credential, graffiti is set
-	nilFields := []bool{
-		req.Validators == nil,
-		req.DepositAddress == "",
-		req.WithdrawalCredential == "",
-		req.Graffiti == "",
-	}
-	var count int
-	for _, isNil := range nilFields {
-		if !isNil {
-			count++
-		}
-	}
-	if count != 1 {
-		v.add("request body", "exactly one of `validators`, `deposit_address`, `withdrawal_credential`, `graffiti` must be set. please check the API documentation for more information")
-	}
-	if v.hasErrors() {
-		handleErr(w, r, v)
-		return
-	}
-
 	ctx := r.Context()
-	groupExists, err := h.getDataAccessor(r).GetValidatorDashboardGroupExists(ctx, dashboardId, groupId)
+	groupExists, err := h.getDataAccessor(r).GetAccountDashboardGroupExists(ctx, dashboardId, req.GroupId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
@@ -590,465 +584,1155 @@ func (h *HandlerService) PublicPostValidatorDashboardValidators(w http.ResponseW
 		returnNotFound(w, r, errors.New("group not found"))
 		return
 	}
-	userId, err := GetUserIdByContext(r)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-	userInfo, err := h.getDataAccessor(r).GetUserInfo(ctx, userId)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-	if req.Validators == nil && !userInfo.PremiumPerks.BulkAdding {
-		returnForbidden(w, r, errors.New("bulk adding not allowed with current subscription plan"))
-		return
-	}
-	dashboardLimit := userInfo.PremiumPerks.ValidatorsPerDashboard
-	existingValidatorCount, err := h.getDataAccessor(r).GetValidatorDashboardValidatorsCount(ctx, dashboardId)
+	data, err := h.getDataAccessor(r).UpdateAccountDashboardAccount(ctx, dashboardId, address, req.GroupId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	var limit uint64
-	if isUserAdmin(userInfo) {
-		limit = math.MaxUint32 // no limit for admins
-	} else if dashboardLimit >= existingValidatorCount {
-		limit = dashboardLimit - existingValidatorCount
+	response := types.ApiDataResponse[types.ADBPostAccountsData]{
+		Data: *data,
 	}
+	returnOk(w, r, response)
+}
 
-	var data []types.VDBPostValidatorsData
-	var dataErr error
-	switch {
-	case req.Validators != nil:
-		indices, pubkeys := v.checkValidators(req.Validators, forbidEmpty)
-		if v.hasErrors() {
-			handleErr(w, r, v)
-			return
-		}
-		validators, err := h.getDataAccessor(r).GetValidatorsFromSlices(ctx, indices, pubkeys)
-		if err != nil {
-			handleErr(w, r, err)
-			return
-		}
-		if len(validators) > int(limit) {
-			validators = validators[:limit]
-		}
-		data, dataErr = h.getDataAccessor(r).AddValidatorDashboardValidators(ctx, dashboardId, groupId, validators)
-
-	case req.DepositAddress != "":
-		depositAddress := v.checkRegex(reEthereumAddress, req.DepositAddress, "deposit_address")
-		if v.hasErrors() {
-			handleErr(w, r, v)
-			return
-		}
-		data, dataErr = h.getDataAccessor(r).AddValidatorDashboardValidatorsByDepositAddress(ctx, dashboardId, groupId, depositAddress, limit)
-
-	case req.WithdrawalCredential != "":
-		withdrawalCredential := v.checkRegex(reWithdrawalCredential, req.WithdrawalCredential, "withdrawal_credential")
-		if v.hasErrors() {
-			handleErr(w, r, v)
-			return
-		}
-		data, dataErr = h.getDataAccessor(r).AddValidatorDashboardValidatorsByWithdrawalCredential(ctx, dashboardId, groupId, withdrawalCredential, limit)
-
-	case req.Graffiti != "":
-		graffiti := v.checkRegex(reGraffiti, req.Graffiti, "graffiti")
-		if v.hasErrors() {
-			handleErr(w, r, v)
-			return
-		}
-		data, dataErr = h.getDataAccessor(r).AddValidatorDashboardValidatorsByGraffiti(ctx, dashboardId, groupId, graffiti, limit)
-	}
+func (h *HandlerService) PublicPostAccountDashboardPublicIds(w http.ResponseWriter, r *http.Request) {
+	returnCreated(w, r, nil)
+}
 
-	if dataErr != nil {
-		handleErr(w, r, dataErr)
-		return
-	}
-	response := types.ApiDataResponse[[]types.VDBPostValidatorsData]{
-		Data: data,
-	}
+func (h *HandlerService) PublicPutAccountDashboardPublicId(w http.ResponseWriter, r *http.Request) {
+	returnOk(w, r, nil)
+}
 
-	returnCreated(w, r, response)
+func (h *HandlerService) PublicDeleteAccountDashboardPublicId(w http.ResponseWriter, r *http.Request) {
+	returnNoContent(w, r)
 }
 
-// PublicGetValidatorDashboardValidators godoc
+// PublicGetAccountDashboardTransactions godoc
 //
-//	@Description	Get a list of validators in a specified validator dashboard.
-//	@Tags			Validator Dashboard
+//	@Description	Get the transaction history of the accounts in a specified account dashboard, newest first. Transactions of all networks selected in the dashboards transaction settings are merged into one list. Only forward paging is supported.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Account Dashboard
 //	@Produce		json
-//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			dashboard_id	path		integer	true	"The ID of the dashboard."
 //	@Param			group_id		query		integer	false	"The ID of the group."
-//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
-//	@Param			sort			query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(index, public_key, balance, status, withdrawal_credentials)
-//	@Param			search			query		string	false	"Search for Address, ENS."
-//	@Success		200				{object}	types.GetValidatorDashboardValidatorsResponse
+//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate forward."
+//	@Param			limit			query		integer	false	"The maximum number of results that may be returned."
+//	@Success		200				{object}	types.GetAccountDashboardTransactionsResponse
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/validators [get]
-func (h *HandlerService) PublicGetValidatorDashboardValidators(w http.ResponseWriter, r *http.Request) {
+//	@Router			/account-dashboards/{dashboard_id}/transactions [get]
+func (h *HandlerService) PublicGetAccountDashboardTransactions(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
+	dashboardId := v.checkAccountDashboardId(mux.Vars(r)["dashboard_id"])
 	q := r.URL.Query()
 	groupId := v.checkGroupId(q.Get("group_id"), allowEmpty)
 	pagingParams := v.checkPagingParams(q)
-	sort := checkSort[enums.VDBManageValidatorsColumn](&v, q.Get("sort"))
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardValidators(r.Context(), *dashboardId, groupId, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit)
+	data, paging, err := h.getDataAccessor(r).GetAccountDashboardTransactions(r.Context(), dashboardId, groupId, pagingParams.cursor, pagingParams.limit)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.GetValidatorDashboardValidatorsResponse{
+	response := types.GetAccountDashboardTransactionsResponse{
 		Data:   data,
 		Paging: *paging,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicDeleteValidatorDashboardValidators godoc
+// PublicPutAccountDashboardTransactionsSettings godoc
 //
-//	@Description	Remove validators from a specified dashboard.
+//	@Description	Update the transaction display settings of a specified account dashboard.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Validator Dashboard Management
+//	@Tags			Account Dashboard Management
 //	@Accept			json
 //	@Produce		json
-//	@Param			dashboard_id	path	integer														true	"The ID of the dashboard."
-//	@Param			request			body	handlers.PublicDeleteValidatorDashboardValidators.request	true	"`validators`: Provide an array of validator indices or public keys that should get removed from the dashboard."
-//	@Success		204				"Validators removed successfully."
+//	@Param			dashboard_id	path		integer															true	"The ID of the dashboard."
+//	@Param			request			body		handlers.PublicPutAccountDashboardTransactionsSettings.request	true	"`networks`: The networks (names or chain ids) whose transactions are listed.<br>`is_include_token_transfers_enabled`: Whether ERC-20 token transfers are listed.<br>`is_ignore_spam_transactions_enabled`: Whether transactions classified as spam are hidden."
+//	@Success		200				{object}	types.InternalPutAccountDashboardTransactionsSettingsResponse
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/validators/bulk-deletions [post]
-func (h *HandlerService) PublicDeleteValidatorDashboardValidators(w http.ResponseWriter, r *http.Request) {
+//	@Router			/account-dashboards/{dashboard_id}/transactions/settings [put]
+func (h *HandlerService) PublicPutAccountDashboardTransactionsSettings(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	dashboardId := v.checkAccountDashboardId(mux.Vars(r)["dashboard_id"])
 	type request struct {
-		Validators []intOrString `json:"validators"`
+		Networks                        []intOrString `json:"networks"`
+		IsIncludeTokenTransfersEnabled  bool          `json:"is_include_token_transfers_enabled"`
+		IsIgnoreSpamTransactionsEnabled bool          `json:"is_ignore_spam_transactions_enabled"`
 	}
 	var req request
 	if err := v.checkBody(&req, r); err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	indices, publicKeys := v.checkValidators(req.Validators, forbidEmpty)
+	settings := types.ADBTransactionSettings{
+		Networks:                        []uint64{},
+		IsIncludeTokenTransfersEnabled:  req.IsIncludeTokenTransfersEnabled,
+		IsIgnoreSpamTransactionsEnabled: req.IsIgnoreSpamTransactionsEnabled,
+	}
+	for _, network := range req.Networks {
+		settings.Networks = append(settings.Networks, v.checkNetwork(network))
+	}
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	ctx := r.Context()
-	validators, err := h.getDataAccessor(r).GetValidatorsFromSlices(ctx, indices, publicKeys)
+	data, err := h.getDataAccessor(r).UpdateAccountDashboardTransactionSettings(r.Context(), dashboardId, settings)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	err = h.getDataAccessor(r).RemoveValidatorDashboardValidators(ctx, dashboardId, validators)
-	if err != nil {
-		handleErr(w, r, err)
-		return
+	response := types.InternalPutAccountDashboardTransactionsSettingsResponse{
+		Data: *data,
 	}
-
-	returnNoContent(w, r)
+	returnOk(w, r, response)
 }
 
-// PublicPostValidatorDashboardPublicIds godoc
+// PublicPostValidatorDashboards godoc
 //
-//	@Description	Create a new public ID for a specified dashboard. This can be used as an ID by other users for non-modyfing (i.e. GET) endpoints only. Currently limited to one per dashboard.
+//	@Description	Create a new validator dashboard. **Note**: New dashboards will automatically have a default group created.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
 //	@Tags			Validator Dashboard Management
 //	@Accept			json
 //	@Produce		json
-//	@Param			dashboard_id	path		integer													true	"The ID of the dashboard."
-//	@Param			request			body		handlers.PublicPostValidatorDashboardPublicIds.request	true	"`name`: Provide a public name for the dashboard<br>`share_settings`:<ul><li>`share_groups`: If set to `true`, accessing the dashboard through the public ID will not reveal any group information.</li></ul>"
-//	@Success		201				{object}	types.ApiDataResponse[types.VDBPublicId]
-//	@Failure		400				{object}	types.ApiErrorResponse
-//	@Failure		409				{object}	types.ApiErrorResponse	"Conflict. The request could not be performed by the server because the authenticated user has already reached their public ID limit."
-//	@Router			/validator-dashboards/{dashboard_id}/public-ids [post]
-func (h *HandlerService) PublicPostValidatorDashboardPublicIds(w http.ResponseWriter, r *http.Request) {
+//	@Param			request	body		handlers.PublicPostValidatorDashboards.request	true	"`name`: Specify the name of the dashboard.<br>`network`: Specify the network for the dashboard. Possible options are:<ul><li>`ethereum`</li><li>`gnosis`</li></ul>`organization_id`: Optional. Create the dashboard for an organization the user is at least an editor of."
+//	@Success		201		{object}	types.ApiDataResponse[types.VDBPostReturnData]
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		409		{object}	types.ApiErrorResponse	"Conflict. The request could not be performed by the server because the authenticated user has already reached their dashboard limit."
+//	@Router			/validator-dashboards [post]
+func (h *HandlerService) PublicPostValidatorDashboards(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
-	type request struct {
-		Name          string `json:"name,omitempty"`
-		ShareSettings struct {
-			ShareGroups bool `json:"share_groups"`
-		} `json:"share_settings"`
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	type request struct {
+		Name           string      `json:"name"`
+		Network        intOrString `json:"network" swaggertype:"string" enums:"ethereum,gnosis"`
+		OrganizationId *uint64     `json:"organization_id,omitempty"`
 	}
 	var req request
 	if err := v.checkBody(&req, r); err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	name := v.checkName(req.Name, 0)
+	name := v.checkNameNotEmpty(req.Name)
+	chainId := v.checkNetwork(req.Network)
+	network, ok := h.getDataAccessor(r).ForNetwork(chainId)
+	if !v.hasErrors() && !ok {
+		v.add("network", fmt.Sprintf("network %d is not served by this api", chainId))
+	}
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	publicIdCount, err := h.getDataAccessor(r).GetValidatorDashboardPublicIdCount(r.Context(), dashboardId)
+
+	var premiumPerks *types.PremiumPerks
+	if req.OrganizationId != nil {
+		if _, err := h.checkOrganizationRole(r.Context(), *req.OrganizationId, userId, enums.OrganizationRoles.Editor); err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		premiumPerks, err = h.getDataAccessor(r).GetOrganizationPremiumPerks(r.Context(), *req.OrganizationId)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+	} else {
+		userInfo, err := h.getDataAccessor(r).GetUserInfo(r.Context(), userId)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		premiumPerks = &userInfo.PremiumPerks
+	}
+	dashboardCount, err := h.getValidatorDashboardCount(r, userId, req.OrganizationId, true)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	if publicIdCount >= 1 {
-		returnConflict(w, r, errors.New("cannot create more than one public id"))
+	if dashboardCount >= premiumPerks.ValidatorDashboards {
+		returnConflict(w, r, errors.New("maximum number of validator dashboards reached"))
 		return
 	}
 
-	data, err := h.getDataAccessor(r).CreateValidatorDashboardPublicId(r.Context(), dashboardId, name, req.ShareSettings.ShareGroups)
+	// the dashboard is stored in the alloy database of its network
+	data, err := network.CreateValidatorDashboard(r.Context(), userId, name, chainId, req.OrganizationId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.ApiDataResponse[types.VDBPublicId]{
+	response := types.ApiDataResponse[types.VDBPostReturnData]{
 		Data: *data,
 	}
-
 	returnCreated(w, r, response)
 }
 
-// PublicPutValidatorDashboardPublicId godoc
+// PublicGetValidatorDashboards godoc
 //
-//	@Description	Update a specified public ID for a specified dashboard.
-//	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Validator Dashboard Management
-//	@Accept			json
+//	@Description	Get overview information for a specified dashboard
+//	@Tags			Validator Dashboard
 //	@Produce		json
-//	@Param			dashboard_id	path		integer													true	"The ID of the dashboard."
-//	@Param			public_id		path		string													true	"The ID of the public ID."
-//	@Param			request			body		handlers.PublicPutValidatorDashboardPublicId.request	true	"`name`: Provide a public name for the dashboard<br>`share_settings`:<ul><li>`share_groups`: If set to `true`, accessing the dashboard through the public ID will not reveal any group information.</li></ul>"
-//	@Success		200				{object}	types.ApiDataResponse[types.VDBPublicId]
-//	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/public-ids/{public_id} [put]
-func (h *HandlerService) PublicPutValidatorDashboardPublicId(w http.ResponseWriter, r *http.Request) {
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool`, `lido_csm`, `ssv`, `obol`. The operator share of `lido_csm`, `ssv` and `obol` validators is configured via the operator-shares endpoint of the dashboard."
+//	@Success		200				{object}	types.GetValidatorDashboardResponse
+//	@Failure		400				{object}	types.ApiErrorResponse	"Bad Request"
+//	@Router			/validator-dashboards/{dashboard_id} [get]
+func (h *HandlerService) PublicGetValidatorDashboard(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	vars := mux.Vars(r)
-	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
-	type request struct {
-		Name          string `json:"name,omitempty"`
-		ShareSettings struct {
-			ShareGroups bool `json:"share_groups"`
-		} `json:"share_settings"`
-	}
-	var req request
-	if err := v.checkBody(&req, r); err != nil {
+	dashboardIdParam := mux.Vars(r)["dashboard_id"]
+	dashboardId, err := h.handleDashboardId(r.Context(), dashboardIdParam)
+	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	name := v.checkName(req.Name, 0)
-	publicDashboardId := v.checkValidatorDashboardPublicId(vars["public_id"])
+
+	q := r.URL.Query()
+	protocolModes := v.checkProtocolModes(q.Get("modes"))
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	fetchedId, err := h.getDataAccessor(r).GetValidatorDashboardIdByPublicId(r.Context(), publicDashboardId)
+
+	// set name depending on dashboard id
+	var name string
+	if reInteger.MatchString(dashboardIdParam) {
+		name, err = h.getDataAccessor(r).GetValidatorDashboardName(r.Context(), dashboardId.Id)
+	} else if reValidatorDashboardPublicId.MatchString(dashboardIdParam) {
+		var publicIdInfo *types.VDBPublicId
+		publicIdInfo, err = h.getDataAccessor(r).GetValidatorDashboardPublicId(r.Context(), types.VDBIdPublic(dashboardIdParam))
+		name = publicIdInfo.Name
+	}
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	if *fetchedId != dashboardId {
-		handleErr(w, r, newNotFoundErr("public id %v not found", publicDashboardId))
+
+	// add premium chart perk info for shared dashboards
+	premiumPerks, err := h.getDashboardPremiumPerks(r.Context(), *dashboardId)
+	if err != nil {
+		handleErr(w, r, err)
 		return
 	}
-
-	data, err := h.getDataAccessor(r).UpdateValidatorDashboardPublicId(r.Context(), publicDashboardId, name, req.ShareSettings.ShareGroups)
+	data, err := h.getDataAccessor(r).GetValidatorDashboardOverview(r.Context(), *dashboardId, protocolModes)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.ApiDataResponse[types.VDBPublicId]{
+	data.ChartHistorySeconds = premiumPerks.ChartHistorySeconds
+	data.Name = name
+
+	response := types.GetValidatorDashboardResponse{
 		Data: *data,
 	}
 
 	returnOk(w, r, response)
 }
 
-// PublicDeleteValidatorDashboardPublicId godoc
+// PublicPutValidatorDashboard godoc
 //
-//	@Description	Delete a specified public ID for a specified dashboard.
+//	@Description	Delete a specified validator dashboard.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
 //	@Tags			Validator Dashboard Management
 //	@Produce		json
 //	@Param			dashboard_id	path	integer	true	"The ID of the dashboard."
-//	@Param			public_id		path	string	true	"The ID of the public ID."
-//	@Success		204				"Public ID deleted successfully."
-//	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/public-ids/{public_id} [delete]
-func (h *HandlerService) PublicDeleteValidatorDashboardPublicId(w http.ResponseWriter, r *http.Request) {
+//	@Success		204				"Dashboard deleted successfully."
+//	@Failure		400				{object}	types.ApiErrorResponse	"Bad Request"
+//	@Router			/validator-dashboards/{dashboard_id} [delete]
+func (h *HandlerService) PublicDeleteValidatorDashboard(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	vars := mux.Vars(r)
-	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
-	publicDashboardId := v.checkValidatorDashboardPublicId(vars["public_id"])
+	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	fetchedId, err := h.getDataAccessor(r).GetValidatorDashboardIdByPublicId(r.Context(), publicDashboardId)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-	if *fetchedId != dashboardId {
-		handleErr(w, r, newNotFoundErr("public id %v not found", publicDashboardId))
-		return
-	}
-
-	err = h.getDataAccessor(r).RemoveValidatorDashboardPublicId(r.Context(), publicDashboardId)
+	err := h.getDataAccessor(r).RemoveValidatorDashboard(r.Context(), dashboardId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-
 	returnNoContent(w, r)
 }
 
-// PublicPutValidatorDashboardArchiving godoc
+// PublicPutValidatorDashboard godoc
 //
-//	@Description	Archive or unarchive a specified validator dashboard. Archived dashboards cannot be accessed by other endpoints. Archiving happens automatically if the number of dashboards, validators, or groups exceeds the limit allowed by your subscription plan. For example, this might occur if you downgrade your subscription to a lower tier.
+//	@Description	Update the name of a specified validator dashboard.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
 //	@Tags			Validator Dashboard Management
 //	@Accept			json
 //	@Produce		json
-//	@Param			dashboard_id	path		integer													true	"The ID of the dashboard."
-//	@Param			request			body		handlers.PublicPutValidatorDashboardArchiving.request	true	"`is_archived`: Set to `true` to archive the dashboard, or `false` to unarchive it."
-//	@Success		200				{object}	types.ApiDataResponse[types.VDBPostArchivingReturnData]
+//	@Param			dashboard_id	path		integer												true	"The ID of the dashboard."
+//	@Param			request			body		handlers.PublicPutValidatorDashboardName.request	true	"request"
+//	@Success		200				{object}	types.ApiDataResponse[types.VDBPostReturnData]
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Failure		409				{object}	types.ApiErrorResponse	"Conflict. The request could not be performed by the server because the authenticated user has already reached their subscription limit."
-//	@Router			/validator-dashboards/{dashboard_id}/archiving [put]
-func (h *HandlerService) PublicPutValidatorDashboardArchiving(w http.ResponseWriter, r *http.Request) {
+//	@Router			/validator-dashboards/{dashboard_id}/name [put]
+func (h *HandlerService) PublicPutValidatorDashboardName(w http.ResponseWriter, r *http.Request) {
 	var v validationError
 	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
 	type request struct {
-		IsArchived bool `json:"is_archived"`
+		Name string `json:"name"`
 	}
 	var req request
 	if err := v.checkBody(&req, r); err != nil {
 		handleErr(w, r, err)
 		return
 	}
+	name := v.checkNameNotEmpty(req.Name)
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-
-	// check conditions for changing archival status
-	dashboardInfo, err := h.getDataAccessor(r).GetValidatorDashboardInfo(r.Context(), dashboardId)
+	data, err := h.getDataAccessor(r).UpdateValidatorDashboardName(r.Context(), dashboardId, name)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	if dashboardInfo.IsArchived == req.IsArchived {
-		// nothing to do
-		returnOk(w, r, types.ApiDataResponse[types.VDBPostArchivingReturnData]{
-			Data: types.VDBPostArchivingReturnData{Id: uint64(dashboardId), IsArchived: req.IsArchived},
-		})
-		return
+	response := types.ApiDataResponse[types.VDBPostReturnData]{
+		Data: *data,
 	}
+	returnOk(w, r, response)
+}
 
-	userId, err := GetUserIdByContext(r)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-	dashboardCount, err := h.getDataAccessor(r).GetUserValidatorDashboardCount(r.Context(), userId, !req.IsArchived)
-	if err != nil {
-		handleErr(w, r, err)
-		return
+// PublicPutValidatorDashboardOperatorShare godoc
+//
+//	@Description	Set the share of the validator income that goes to the node operator for validators of a staking protocol. It is applied if the protocol mode is enabled. The operator share of `rocket_pool` validators is derived from their minipools and can't be set.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validator Dashboard Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			dashboard_id	path		integer															true	"The ID of the dashboard."
+//	@Param			protocol		path		string															true	"The staking protocol."	Enums(lido_csm, ssv, obol)
+//	@Param			request			body		handlers.PublicPutValidatorDashboardOperatorShare.request	true	"`operator_share`: Share of the validator income between 0 (exclusive) and 1."
+//	@Success		200				{object}	types.ApiDataResponse[types.VDBOperatorShare]
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/operator-shares/{protocol} [put]
+func (h *HandlerService) PublicPutValidatorDashboardOperatorShare(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
+	protocol := v.checkConfigurableStakingProtocol(vars["protocol"])
+	type request struct {
+		OperatorShare float64 `json:"operator_share"`
 	}
-
-	userInfo, err := h.getDataAccessor(r).GetUserInfo(r.Context(), userId)
-	if err != nil {
+	var req request
+	if err := v.checkBody(&req, r); err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	if req.IsArchived {
-		if dashboardCount >= MaxArchivedDashboardsCount && !isUserAdmin(userInfo) {
-			returnConflict(w, r, errors.New("maximum number of archived validator dashboards reached"))
-			return
-		}
-	} else {
-		if dashboardCount >= userInfo.PremiumPerks.ValidatorDashboards {
-			returnConflict(w, r, errors.New("maximum number of active validator dashboards reached"))
-			return
-		}
-		if dashboardInfo.GroupCount >= userInfo.PremiumPerks.ValidatorGroupsPerDashboard {
-			returnConflict(w, r, errors.New("maximum number of groups in dashboards reached"))
-			return
-		}
-		if dashboardInfo.ValidatorCount >= userInfo.PremiumPerks.ValidatorsPerDashboard {
-			returnConflict(w, r, errors.New("maximum number of validators in dashboards reached"))
-			return
-		}
+	if req.OperatorShare <= 0 || req.OperatorShare > 1 {
+		v.add("operator_share", "operator share must be greater than 0 and at most 1")
 	}
-
-	var archivedReason *enums.VDBArchivedReason
-	if req.IsArchived {
-		archivedReason = &enums.VDBArchivedReasons.User
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
 	}
-
-	data, err := h.getDataAccessor(r).UpdateValidatorDashboardArchiving(r.Context(), dashboardId, archivedReason)
+	data, err := h.getDataAccessor(r).UpdateValidatorDashboardOperatorShare(r.Context(), dashboardId, protocol, req.OperatorShare)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.ApiDataResponse[types.VDBPostArchivingReturnData]{
+	response := types.ApiDataResponse[types.VDBOperatorShare]{
 		Data: *data,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetValidatorDashboardSlotViz godoc
+// PublicDeleteValidatorDashboardOperatorShare godoc
 //
-//	@Description	Get slot viz information for a specified dashboard
-//	@Tags			Validator Dashboard
+//	@Description	Remove the operator share of a staking protocol from a specified validator dashboard. Validators of the protocol keep their full income afterwards.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validator Dashboard Management
 //	@Produce		json
-//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Param			group_ids		query		string	false	"Provide a comma separated list of group IDs to filter the results by. If omitted, all groups will be included."
-//	@Success		200				{object}	types.GetValidatorDashboardSlotVizResponse
+//	@Param			dashboard_id	path	integer	true	"The ID of the dashboard."
+//	@Param			protocol		path	string	true	"The staking protocol."	Enums(lido_csm, ssv, obol)
+//	@Success		204				"Operator share removed successfully."
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/slot-viz [get]
-func (h *HandlerService) PublicGetValidatorDashboardSlotViz(w http.ResponseWriter, r *http.Request) {
+//	@Router			/validator-dashboards/{dashboard_id}/operator-shares/{protocol} [delete]
+func (h *HandlerService) PublicDeleteValidatorDashboardOperatorShare(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-
-	groupIds := v.checkExistingGroupIdList(r.URL.Query().Get("group_ids"))
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
+	protocol := v.checkConfigurableStakingProtocol(vars["protocol"])
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	data, err := h.getDataAccessor(r).GetValidatorDashboardSlotViz(r.Context(), *dashboardId, groupIds)
+	err := h.getDataAccessor(r).RemoveValidatorDashboardOperatorShare(r.Context(), dashboardId, protocol)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.GetValidatorDashboardSlotVizResponse{
-		Data: data,
-	}
-
-	returnOk(w, r, response)
+	returnNoContent(w, r)
 }
 
-// PublicGetValidatorDashboardSummary godoc
+// PublicPostValidatorDashboardGroups godoc
 //
-//	@Description	Get summary information for a specified dashboard
-//	@Tags			Validator Dashboard
+//	@Description	Create a new group in a specified validator dashboard.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validator Dashboard Management
+//	@Accept			json
 //	@Produce		json
-//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Param			period			query		string	true	"Time period to get data for."	Enums(all_time, last_30d, last_7d, last_24h, last_1h)
-//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
-//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
-//	@Param			sort			query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(group_id, validators, efficiency, attestations, proposals, reward)
-//	@Param			search			query		string	false	"Search for Index, Public Key, Group."
-//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool``."
-//	@Success		200				{object}	types.GetValidatorDashboardSummaryResponse
+//	@Param			dashboard_id	path		integer												true	"The ID of the dashboard."
+//	@Param			request			body		handlers.PublicPostValidatorDashboardGroups.request	true	"request"
+//	@Success		201				{object}	types.ApiDataResponse[types.VDBPostCreateGroupData]
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/summary [get]
+//	@Failure		409				{object}	types.ApiErrorResponse	"Conflict. The request could not be performed by the server because the authenticated user has already reached their group limit."
+//	@Router			/validator-dashboards/{dashboard_id}/groups [post]
+func (h *HandlerService) PublicPostValidatorDashboardGroups(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	type request struct {
+		Name string `json:"name"`
+	}
+	var req request
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	name := v.checkNameNotEmpty(req.Name)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	ctx := r.Context()
+	// check if user has reached the maximum number of groups
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	userInfo, err := h.getDataAccessor(r).GetUserInfo(ctx, userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	premiumPerks, _, err := h.getValidatorDashboardPerks(r, dashboardId, userInfo)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	groupCount, err := h.getDataAccessor(r).GetValidatorDashboardGroupCount(ctx, dashboardId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if groupCount >= premiumPerks.ValidatorGroupsPerDashboard {
+		returnConflict(w, r, errors.New("maximum number of validator dashboard groups reached"))
+		return
+	}
+
+	data, err := h.getDataAccessor(r).CreateValidatorDashboardGroup(ctx, dashboardId, name)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	response := types.ApiDataResponse[types.VDBPostCreateGroupData]{
+		Data: *data,
+	}
+
+	returnCreated(w, r, response)
+}
+
+// PublicGetValidatorDashboardGroups godoc
+//
+//	@Description	Update a groups name in a specified validator dashboard.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validator Dashboard Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			dashboard_id	path		integer												true	"The ID of the dashboard."
+//	@Param			group_id		path		integer												true	"The ID of the group."
+//	@Param			request			body		handlers.PublicPutValidatorDashboardGroups.request	true	"request"
+//	@Success		200				{object}	types.ApiDataResponse[types.VDBPostCreateGroupData]
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/groups/{group_id} [put]
+func (h *HandlerService) PublicPutValidatorDashboardGroups(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
+	type request struct {
+		Name string `json:"name"`
+	}
+	var req request
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	name := v.checkNameNotEmpty(req.Name)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	groupExists, err := h.getDataAccessor(r).GetValidatorDashboardGroupExists(r.Context(), dashboardId, groupId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if !groupExists {
+		returnNotFound(w, r, errors.New("group not found"))
+		return
+	}
+	data, err := h.getDataAccessor(r).UpdateValidatorDashboardGroup(r.Context(), dashboardId, groupId, name)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	response := types.ApiDataResponse[types.VDBPostCreateGroupData]{
+		Data: *data,
+	}
+
+	returnOk(w, r, response)
+}
+
+// PublicDeleteValidatorDashboardGroup godoc
+//
+//	@Description	Delete a group in a specified validator dashboard.
+//	@Tags			Validator Dashboard Management
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Accept			json
+//	@Produce		json
+//	@Param			dashboard_id	path	integer	true	"The ID of the dashboard."
+//	@Param			group_id		path	integer	true	"The ID of the group."
+//	@Success		204				"Group deleted successfully."
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/groups/{group_id} [delete]
+func (h *HandlerService) PublicDeleteValidatorDashboardGroup(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	if groupId == types.DefaultGroupId {
+		returnBadRequest(w, r, errors.New("cannot delete default group"))
+		return
+	}
+	groupExists, err := h.getDataAccessor(r).GetValidatorDashboardGroupExists(r.Context(), dashboardId, groupId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if !groupExists {
+		returnNotFound(w, r, errors.New("group not found"))
+		return
+	}
+	err = h.getDataAccessor(r).RemoveValidatorDashboardGroup(r.Context(), dashboardId, groupId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	returnNoContent(w, r)
+}
+
+// PublicDeleteValidatorDashboardGroupValidators godoc
+// @Description	Delete all validators from a specified group in a specified validator dashboard.
+// @Tags			Validator Dashboard Management
+// @Security		ApiKeyInHeader || ApiKeyInQuery
+// @Accept		json
+// @Produce		json
+// @Param			dashboard_id	path	integer	true	"The ID of the dashboard."
+// @Param			group_id		path	integer	true	"The ID of the group."
+// @Success		204				"Validators removed successfully."
+// @Failure		400				{object}	types.ApiErrorResponse
+// @Router			/validator-dashboards/{dashboard_id}/groups/{group_id}/validators [delete]
+func (h *HandlerService) PublicDeleteValidatorDashboardGroupValidators(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	groupExists, err := h.getDataAccessor(r).GetValidatorDashboardGroupExists(r.Context(), dashboardId, groupId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if !groupExists {
+		returnNotFound(w, r, errors.New("group not found"))
+		return
+	}
+	err = h.getDataAccessor(r).RemoveValidatorDashboardGroupValidators(r.Context(), dashboardId, groupId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
+}
+
+// PublicGetValidatorDashboardGroups godoc
+//
+//	@Description	Add new validators to a specified dashboard or update the group of already-added validators. This endpoint will always add as many validators as possible, even if more validators are provided than allowed by the subscription plan. The response will contain a list of added validators.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validator Dashboard Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			dashboard_id	path		integer													true	"The ID of the dashboard."
+//	@Param			request			body		handlers.PublicPostValidatorDashboardValidators.request	true	"`group_id`: (optional) Provide a single group id, to which all validators get added to. If omitted, the default group will be used.<br><br>To add validators or update their group, only one of the following fields can be set:<ul><li>`validators`: Provide a list of validator indices or public keys.</li><li>`deposit_address`: (limited to subscription tiers with 'Bulk adding') Provide a deposit address from which as many validators as possible will be added to the dashboard.</li><li>`withdrawal_credential`: (limited to subscription tiers with 'Bulk adding') Provide a withdrawal credential from which as many validators as possible will be added to the dashboard.</li><li>`graffiti`: (limited to subscription tiers with 'Bulk adding') Provide a graffiti string from which as many validators as possible will be added to the dashboard.</li></ul>"
+//	@Success		201				{object}	types.ApiDataResponse[[]types.VDBPostValidatorsData]	"Returns a list of added validators."
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/validators [post]
+func (h *HandlerService) PublicPostValidatorDashboardValidators(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	type request struct {
+		GroupId              uint64        `json:"group_id,omitempty" x-nullable:"true"`
+		Validators           []intOrString `json:"validators,omitempty"`
+		DepositAddress       string        `json:"deposit_address,omitempty"`
+		WithdrawalCredential string        `json:"withdrawal_credential,omitempty"`
+		Graffiti             string        `json:"graffiti,omitempty"`
+	}
+	req := request{
+		GroupId: types.DefaultGroupId, // default value
+	}
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	groupId := req.GroupId
+	// check if exactly one of validators, deposit_address, withdrawal_credential, graffiti is set
+	nilFields := []bool{
+		req.Validators == nil,
+		req.DepositAddress == "",
+		req.WithdrawalCredential == "",
+		req.Graffiti == "",
+	}
+	var count int
+	for _, isNil := range nilFields {
+		if !isNil {
+			count++
+		}
+	}
+	if count != 1 {
+		v.add("request body", "exactly one of `validators`, `deposit_address`, `withdrawal_credential`, `graffiti` must be set. please check the API documentation for more information")
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	ctx := r.Context()
+	groupExists, err := h.getDataAccessor(r).GetValidatorDashboardGroupExists(ctx, dashboardId, groupId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if !groupExists {
+		returnNotFound(w, r, errors.New("group not found"))
+		return
+	}
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	userInfo, err := h.getDataAccessor(r).GetUserInfo(ctx, userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	premiumPerks, _, err := h.getValidatorDashboardPerks(r, dashboardId, userInfo)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if req.Validators == nil && !premiumPerks.BulkAdding {
+		returnForbidden(w, r, errors.New("bulk adding not allowed with current subscription plan"))
+		return
+	}
+	dashboardLimit := premiumPerks.ValidatorsPerDashboard
+	existingValidatorCount, err := h.getDataAccessor(r).GetValidatorDashboardValidatorsCount(ctx, dashboardId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	var limit uint64
+	if isUserAdmin(userInfo) {
+		limit = math.MaxUint32 // no limit for admins
+	} else if dashboardLimit >= existingValidatorCount {
+		limit = dashboardLimit - existingValidatorCount
+	}
+
+	var data []types.VDBPostValidatorsData
+	var dataErr error
+	switch {
+	case req.Validators != nil:
+		indices, pubkeys := v.checkValidators(req.Validators, forbidEmpty)
+		if v.hasErrors() {
+			handleErr(w, r, v)
+			return
+		}
+		validators, err := h.getDataAccessor(r).GetValidatorsFromSlices(ctx, indices, pubkeys)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		if len(validators) > int(limit) {
+			validators = validators[:limit]
+		}
+		data, dataErr = h.getDataAccessor(r).AddValidatorDashboardValidators(ctx, dashboardId, groupId, validators)
+
+	case req.DepositAddress != "":
+		depositAddress := v.checkRegex(reEthereumAddress, req.DepositAddress, "deposit_address")
+		if v.hasErrors() {
+			handleErr(w, r, v)
+			return
+		}
+		data, dataErr = h.getDataAccessor(r).AddValidatorDashboardValidatorsByDepositAddress(ctx, dashboardId, groupId, depositAddress, limit)
+
+	case req.WithdrawalCredential != "":
+		withdrawalCredential := v.checkRegex(reWithdrawalCredential, req.WithdrawalCredential, "withdrawal_credential")
+		if v.hasErrors() {
+			handleErr(w, r, v)
+			return
+		}
+		data, dataErr = h.getDataAccessor(r).AddValidatorDashboardValidatorsByWithdrawalCredential(ctx, dashboardId, groupId, withdrawalCredential, limit)
+
+	case req.Graffiti != "":
+		graffiti := v.checkRegex(reGraffiti, req.Graffiti, "graffiti")
+		if v.hasErrors() {
+			handleErr(w, r, v)
+			return
+		}
+		data, dataErr = h.getDataAccessor(r).AddValidatorDashboardValidatorsByGraffiti(ctx, dashboardId, groupId, graffiti, limit)
+	}
+
+	if dataErr != nil {
+		handleErr(w, r, dataErr)
+		return
+	}
+	response := types.ApiDataResponse[[]types.VDBPostValidatorsData]{
+		Data: data,
+	}
+
+	returnCreated(w, r, response)
+}
+
+// PublicGetValidatorDashboardValidators godoc
+//
+//	@Description	Get a list of validators in a specified validator dashboard.
+//	@Tags			Validator Dashboard
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			group_id		query		integer	false	"The ID of the group."
+//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
+//	@Param			sort			query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(index, public_key, balance, status, withdrawal_credentials)
+//	@Param			search			query		string	false	"Search for Address, ENS."
+//	@Success		200				{object}	types.GetValidatorDashboardValidatorsResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/validators [get]
+func (h *HandlerService) PublicGetValidatorDashboardValidators(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	q := r.URL.Query()
+	groupId := v.checkGroupId(q.Get("group_id"), allowEmpty)
+	pagingParams := v.checkPagingParams(q)
+	sort := checkSort[enums.VDBManageValidatorsColumn](&v, q.Get("sort"))
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardValidators(r.Context(), *dashboardId, groupId, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetValidatorDashboardValidatorsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicDeleteValidatorDashboardValidators godoc
+//
+//	@Description	Remove validators from a specified dashboard.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validator Dashboard Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			dashboard_id	path	integer														true	"The ID of the dashboard."
+//	@Param			request			body	handlers.PublicDeleteValidatorDashboardValidators.request	true	"`validators`: Provide an array of validator indices or public keys that should get removed from the dashboard."
+//	@Success		204				"Validators removed successfully."
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/validators/bulk-deletions [post]
+func (h *HandlerService) PublicDeleteValidatorDashboardValidators(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	type request struct {
+		Validators []intOrString `json:"validators"`
+	}
+	var req request
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	indices, publicKeys := v.checkValidators(req.Validators, forbidEmpty)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	ctx := r.Context()
+	validators, err := h.getDataAccessor(r).GetValidatorsFromSlices(ctx, indices, publicKeys)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	err = h.getDataAccessor(r).RemoveValidatorDashboardValidators(ctx, dashboardId, validators)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	returnNoContent(w, r)
+}
+
+// PublicPostValidatorDashboardPublicIds godoc
+//
+//	@Description	Create a new public ID for a specified dashboard. This can be used as an ID by other users for non-modyfing (i.e. GET) endpoints only. Currently limited to one per dashboard.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validator Dashboard Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			dashboard_id	path		integer													true	"The ID of the dashboard."
+//	@Param			request			body		handlers.PublicPostValidatorDashboardPublicIds.request	true	"`name`: Provide a public name for the dashboard<br>`share_settings`:<ul><li>`share_groups`: If set to `true`, accessing the dashboard through the public ID will not reveal any group information.</li></ul>"
+//	@Success		201				{object}	types.ApiDataResponse[types.VDBPublicId]
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Failure		409				{object}	types.ApiErrorResponse	"Conflict. The request could not be performed by the server because the authenticated user has already reached their public ID limit."
+//	@Router			/validator-dashboards/{dashboard_id}/public-ids [post]
+func (h *HandlerService) PublicPostValidatorDashboardPublicIds(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	type request struct {
+		Name          string `json:"name,omitempty"`
+		ShareSettings struct {
+			ShareGroups bool `json:"share_groups"`
+		} `json:"share_settings"`
+	}
+	var req request
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	name := v.checkName(req.Name, 0)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	publicIdCount, err := h.getDataAccessor(r).GetValidatorDashboardPublicIdCount(r.Context(), dashboardId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if publicIdCount >= 1 {
+		returnConflict(w, r, errors.New("cannot create more than one public id"))
+		return
+	}
+
+	data, err := h.getDataAccessor(r).CreateValidatorDashboardPublicId(r.Context(), dashboardId, name, req.ShareSettings.ShareGroups)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.ApiDataResponse[types.VDBPublicId]{
+		Data: *data,
+	}
+
+	returnCreated(w, r, response)
+}
+
+// PublicPutValidatorDashboardPublicId godoc
+//
+//	@Description	Update a specified public ID for a specified dashboard.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validator Dashboard Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			dashboard_id	path		integer													true	"The ID of the dashboard."
+//	@Param			public_id		path		string													true	"The ID of the public ID."
+//	@Param			request			body		handlers.PublicPutValidatorDashboardPublicId.request	true	"`name`: Provide a public name for the dashboard<br>`share_settings`:<ul><li>`share_groups`: If set to `true`, accessing the dashboard through the public ID will not reveal any group information.</li></ul>"
+//	@Success		200				{object}	types.ApiDataResponse[types.VDBPublicId]
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/public-ids/{public_id} [put]
+func (h *HandlerService) PublicPutValidatorDashboardPublicId(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	type request struct {
+		Name          string `json:"name,omitempty"`
+		ShareSettings struct {
+			ShareGroups bool `json:"share_groups"`
+		} `json:"share_settings"`
+	}
+	var req request
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	name := v.checkName(req.Name, 0)
+	publicDashboardId := v.checkValidatorDashboardPublicId(vars["public_id"])
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	fetchedId, err := h.getDataAccessor(r).GetValidatorDashboardIdByPublicId(r.Context(), publicDashboardId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if *fetchedId != dashboardId {
+		handleErr(w, r, newNotFoundErr("public id %v not found", publicDashboardId))
+		return
+	}
+
+	data, err := h.getDataAccessor(r).UpdateValidatorDashboardPublicId(r.Context(), publicDashboardId, name, req.ShareSettings.ShareGroups)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.ApiDataResponse[types.VDBPublicId]{
+		Data: *data,
+	}
+
+	returnOk(w, r, response)
+}
+
+// PublicDeleteValidatorDashboardPublicId godoc
+//
+//	@Description	Delete a specified public ID for a specified dashboard.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validator Dashboard Management
+//	@Produce		json
+//	@Param			dashboard_id	path	integer	true	"The ID of the dashboard."
+//	@Param			public_id		path	string	true	"The ID of the public ID."
+//	@Success		204				"Public ID deleted successfully."
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/public-ids/{public_id} [delete]
+func (h *HandlerService) PublicDeleteValidatorDashboardPublicId(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
+	publicDashboardId := v.checkValidatorDashboardPublicId(vars["public_id"])
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	fetchedId, err := h.getDataAccessor(r).GetValidatorDashboardIdByPublicId(r.Context(), publicDashboardId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if *fetchedId != dashboardId {
+		handleErr(w, r, newNotFoundErr("public id %v not found", publicDashboardId))
+		return
+	}
+
+	err = h.getDataAccessor(r).RemoveValidatorDashboardPublicId(r.Context(), publicDashboardId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	returnNoContent(w, r)
+}
+
+// PublicPutValidatorDashboardArchiving godoc
+//
+//	@Description	Archive or unarchive a specified validator dashboard. Archived dashboards cannot be accessed by other endpoints. Archiving happens automatically if the number of dashboards, validators, or groups exceeds the limit allowed by your subscription plan. For example, this might occur if you downgrade your subscription to a lower tier.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validator Dashboard Management
+//	@Accept			json
+//	@Produce		json
+//	@Param			dashboard_id	path		integer													true	"The ID of the dashboard."
+//	@Param			request			body		handlers.PublicPutValidatorDashboardArchiving.request	true	"`is_archived`: Set to `true` to archive the dashboard, or `false` to unarchive it."
+//	@Success		200				{object}	types.ApiDataResponse[types.VDBPostArchivingReturnData]
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Failure		409				{object}	types.ApiErrorResponse	"Conflict. The request could not be performed by the server because the authenticated user has already reached their subscription limit."
+//	@Router			/validator-dashboards/{dashboard_id}/archiving [put]
+func (h *HandlerService) PublicPutValidatorDashboardArchiving(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId := v.checkPrimaryDashboardId(mux.Vars(r)["dashboard_id"])
+	type request struct {
+		IsArchived bool `json:"is_archived"`
+	}
+	var req request
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	// check conditions for changing archival status
+	dashboardInfo, err := h.getDataAccessor(r).GetValidatorDashboardInfo(r.Context(), dashboardId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if dashboardInfo.IsArchived == req.IsArchived {
+		// nothing to do
+		returnOk(w, r, types.ApiDataResponse[types.VDBPostArchivingReturnData]{
+			Data: types.VDBPostArchivingReturnData{Id: uint64(dashboardId), IsArchived: req.IsArchived},
+		})
+		return
+	}
+
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	userInfo, err := h.getDataAccessor(r).GetUserInfo(r.Context(), userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	// organization dashboards count against the limits of the organization
+	premiumPerks, organizationId, err := h.getValidatorDashboardPerks(r, dashboardId, userInfo)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	dashboardCount, err := h.getValidatorDashboardCount(r, userId, organizationId, !req.IsArchived)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	if req.IsArchived {
+		if dashboardCount >= MaxArchivedDashboardsCount && !isUserAdmin(userInfo) {
+			returnConflict(w, r, errors.New("maximum number of archived validator dashboards reached"))
+			return
+		}
+	} else {
+		if dashboardCount >= premiumPerks.ValidatorDashboards {
+			returnConflict(w, r, errors.New("maximum number of active validator dashboards reached"))
+			return
+		}
+		if dashboardInfo.GroupCount >= premiumPerks.ValidatorGroupsPerDashboard {
+			returnConflict(w, r, errors.New("maximum number of groups in dashboards reached"))
+			return
+		}
+		if dashboardInfo.ValidatorCount >= premiumPerks.ValidatorsPerDashboard {
+			returnConflict(w, r, errors.New("maximum number of validators in dashboards reached"))
+			return
+		}
+	}
+
+	var archivedReason *enums.VDBArchivedReason
+	if req.IsArchived {
+		archivedReason = &enums.VDBArchivedReasons.User
+	}
+
+	data, err := h.getDataAccessor(r).UpdateValidatorDashboardArchiving(r.Context(), dashboardId, archivedReason)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.ApiDataResponse[types.VDBPostArchivingReturnData]{
+		Data: *data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetValidatorDashboardSlotViz godoc
+//
+//	@Description	Get slot viz information for a specified dashboard
+//	@Tags			Validator Dashboard
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			group_ids		query		string	false	"Provide a comma separated list of group IDs to filter the results by. If omitted, all groups will be included."
+//	@Success		200				{object}	types.GetValidatorDashboardSlotVizResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/slot-viz [get]
+func (h *HandlerService) PublicGetValidatorDashboardSlotViz(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	groupIds := v.checkExistingGroupIdList(r.URL.Query().Get("group_ids"))
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	data, err := h.getDataAccessor(r).GetValidatorDashboardSlotViz(r.Context(), *dashboardId, groupIds)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetValidatorDashboardSlotVizResponse{
+		Data: data,
+	}
+
+	returnOk(w, r, response)
+}
+
+// PublicGetValidatorDashboardEvents godoc
+//
+//	@Description	Stream the duty results of the validators of a specified dashboard as they happen: proposals, attestations and sync committee participation, including missed duties.
+//	@Description	An event is sent for every new head slot that contains duties of the dashboard validators. Included attestations are reported with the slot of the including block, missed attestations once their inclusion window has passed.
+//	@Description	Events are sent as server-sent events named `slot_duties`. If the request is a WebSocket upgrade request, every event is sent as JSON message of the form `{"event": "slot_duties", "data": ...}` instead.
+//	@Tags			Validator Dashboard
+//	@Produce		text/event-stream
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Success		200				{object}	types.VDBSlotDutiesEvent	"A stream of slot duties events."
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/events [get]
+func (h *HandlerService) PublicGetValidatorDashboardEvents(w http.ResponseWriter, r *http.Request) {
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	events, err := h.getDataAccessor(r).SubscribeToValidatorDashboardSlotDuties(r.Context(), *dashboardId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	streamSlotDuties(w, r, events)
+}
+
+// PublicGetValidatorDashboardSummary godoc
+//
+//	@Description	Get summary information for a specified dashboard
+//	@Tags			Validator Dashboard
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			period			query		string	true	"Time period to get data for."	Enums(all_time, last_30d, last_7d, last_24h, last_1h)
+//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
+//	@Param			sort			query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(group_id, validators, efficiency, attestations, proposals, reward)
+//	@Param			search			query		string	false	"Search for Index, Public Key, Group."
+//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool`, `lido_csm`, `ssv`, `obol`. The operator share of `lido_csm`, `ssv` and `obol` validators is configured via the operator-shares endpoint of the dashboard."
+//	@Success		200				{object}	types.GetValidatorDashboardSummaryResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/summary [get]
 func (h *HandlerService) PublicGetValidatorDashboardSummary(w http.ResponseWriter, r *http.Request) {
 	var v validationError
 	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
@@ -1058,971 +1742,1604 @@ func (h *HandlerService) PublicGetValidatorDashboardSummary(w http.ResponseWrite
 	}
 	q := r.URL.Query()
 	pagingParams := v.checkPagingParams(q)
-	sort := checkSort[enums.VDBSummaryColumn](&v, q.Get("sort"))
+	sort := checkSort[enums.VDBSummaryColumn](&v, q.Get("sort"))
+	protocolModes := v.checkProtocolModes(q.Get("modes"))
+
+	period := checkEnum[enums.TimePeriod](&v, q.Get("period"), "period")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardSummary(r.Context(), *dashboardId, period, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit, protocolModes)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetValidatorDashboardSummaryResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetValidatorDashboardGroupSummary godoc
+//
+//	@Description	Get summary information for a specified group in a specified dashboard
+//	@Tags			Validator Dashboard
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			group_id		path		integer	true	"The ID of the group."
+//	@Param			period			query		string	true	"Time period to get data for."	Enums(all_time, last_30d, last_7d, last_24h, last_1h)
+//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool`, `lido_csm`, `ssv`, `obol`. The operator share of `lido_csm`, `ssv` and `obol` validators is configured via the operator-shares endpoint of the dashboard."
+//	@Success		200				{object}	types.GetValidatorDashboardGroupSummaryResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/groups/{group_id}/summary [get]
+func (h *HandlerService) PublicGetValidatorDashboardGroupSummary(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId, err := h.handleDashboardId(r.Context(), vars["dashboard_id"])
+	q := r.URL.Query()
+	protocolModes := v.checkProtocolModes(q.Get("modes"))
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	groupId := v.checkGroupId(vars["group_id"], forbidEmpty)
+	period := checkEnum[enums.TimePeriod](&v, r.URL.Query().Get("period"), "period")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetValidatorDashboardGroupSummary(r.Context(), *dashboardId, groupId, period, protocolModes)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetValidatorDashboardGroupSummaryResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetValidatorDashboardSummaryChart godoc
+//
+//	@Description	Get summary chart data for a specified dashboard
+//	@Tags			Validator Dashboard
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			group_ids		query		string	false	"Provide a comma separated list of group IDs to filter the results by."
+//	@Param			efficiency_type	query		string	false	"Efficiency type to get data for."	Enums(all, attestation, sync, proposal)
+//	@Param			aggregation		query		string	false	"Aggregation type to get data for."	Enums(epoch, hourly, daily, weekly)	Default(hourly)
+//	@Param			after_ts		query		string	false	"Return data after this timestamp."
+//	@Param			before_ts		query		string	false	"Return data before this timestamp."
+//	@Success		200				{object}	types.GetValidatorDashboardSummaryChartResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/summary-chart [get]
+func (h *HandlerService) PublicGetValidatorDashboardSummaryChart(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	ctx := r.Context()
+	dashboardId, err := h.handleDashboardId(ctx, mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	q := r.URL.Query()
+	groupIds := v.checkGroupIdList(q.Get("group_ids"))
+	efficiencyType := checkEnum[enums.VDBSummaryChartEfficiencyType](&v, q.Get("efficiency_type"), "efficiency_type")
+
+	aggregation := checkEnum[enums.ChartAggregation](&v, r.URL.Query().Get("aggregation"), "aggregation")
+	chartLimits, err := h.getCurrentChartTimeLimitsForDashboard(ctx, dashboardId, aggregation)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	afterTs, beforeTs := v.checkTimestamps(r, chartLimits)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	if afterTs < chartLimits.MinAllowedTs || beforeTs < chartLimits.MinAllowedTs {
+		returnConflict(w, r, fmt.Errorf("requested time range is too old, minimum timestamp for dashboard owner's premium subscription for this aggregation is %v", chartLimits.MinAllowedTs))
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetValidatorDashboardSummaryChart(ctx, *dashboardId, groupIds, efficiencyType, aggregation, afterTs, beforeTs)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetValidatorDashboardSummaryChartResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetValidatorDashboardSummaryValidators godoc
+//
+//	@Description	Get summary information for validators in a specified dashboard
+//	@Tags			Validator Dashboard
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			group_id		query		integer	false	"The ID of the group."
+//	@Param			duty			query		string	false	"Validator duty to get data for."	Enums(none, sync, slashed, proposal)	Default(none)
+//	@Param			period			query		string	true	"Time period to get data for."		Enums(all_time, last_30d, last_7d, last_24h, last_1h)
+//	@Success		200				{object}	types.GetValidatorDashboardSummaryValidatorsResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/summary/validators [get]
+func (h *HandlerService) PublicGetValidatorDashboardSummaryValidators(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	groupId := v.checkGroupId(r.URL.Query().Get("group_id"), allowEmpty)
+	q := r.URL.Query()
+	duty := checkEnum[enums.ValidatorDuty](&v, q.Get("duty"), "duty")
+	period := checkEnum[enums.TimePeriod](&v, q.Get("period"), "period")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	// get indices based on duty
+	var indices interface{}
+	duties := enums.ValidatorDuties
+	switch duty {
+	case duties.None:
+		indices, err = h.getDataAccessor(r).GetValidatorDashboardSummaryValidators(r.Context(), *dashboardId, groupId)
+	case duties.Sync:
+		indices, err = h.getDataAccessor(r).GetValidatorDashboardSyncSummaryValidators(r.Context(), *dashboardId, groupId, period)
+	case duties.Slashed:
+		indices, err = h.getDataAccessor(r).GetValidatorDashboardSlashingsSummaryValidators(r.Context(), *dashboardId, groupId, period)
+	case duties.Proposal:
+		indices, err = h.getDataAccessor(r).GetValidatorDashboardProposalSummaryValidators(r.Context(), *dashboardId, groupId, period)
+	}
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	// map indices to response format
+	data, err := mapVDBIndices(indices)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	response := types.GetValidatorDashboardSummaryValidatorsResponse{
+		Data: data,
+	}
+
+	returnOk(w, r, response)
+}
+
+// PublicGetValidatorDashboardRewards godoc
+//
+//	@Description	Get rewards information for a specified dashboard
+//	@Tags			Validator Dashboard
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
+//	@Param			sort			query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(epoch)
+//	@Param			search			query		string	false	"Search for Epoch, Index, Public Key, Group."
+//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool`, `lido_csm`, `ssv`, `obol`. The operator share of `lido_csm`, `ssv` and `obol` validators is configured via the operator-shares endpoint of the dashboard."
+//	@Success		200				{object}	types.GetValidatorDashboardRewardsResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/rewards [get]
+func (h *HandlerService) PublicGetValidatorDashboardRewards(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	q := r.URL.Query()
+	pagingParams := v.checkPagingParams(q)
+	sort := checkSort[enums.VDBRewardsColumn](&v, q.Get("sort"))
 	protocolModes := v.checkProtocolModes(q.Get("modes"))
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
 
-	period := checkEnum[enums.TimePeriod](&v, q.Get("period"), "period")
+	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardRewards(r.Context(), *dashboardId, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit, protocolModes)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetValidatorDashboardRewardsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetValidatorDashboardGroupRewards godoc
+//
+//	@Description	Get rewards information for a specified group in a specified dashboard
+//	@Tags			Validator Dashboard
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			group_id		path		integer	true	"The ID of the group."
+//	@Param			epoch			path		integer	true	"The epoch to get data for."
+//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool`, `lido_csm`, `ssv`, `obol`. The operator share of `lido_csm`, `ssv` and `obol` validators is configured via the operator-shares endpoint of the dashboard."
+//	@Success		200				{object}	types.GetValidatorDashboardGroupRewardsResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/groups/{group_id}/rewards/{epoch} [get]
+func (h *HandlerService) PublicGetValidatorDashboardGroupRewards(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	q := r.URL.Query()
+	dashboardId, err := h.handleDashboardId(r.Context(), vars["dashboard_id"])
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	groupId := v.checkGroupId(vars["group_id"], forbidEmpty)
+	epoch := v.checkUint(vars["epoch"], "epoch")
+	protocolModes := v.checkProtocolModes(q.Get("modes"))
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetValidatorDashboardGroupRewards(r.Context(), *dashboardId, groupId, epoch, protocolModes)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetValidatorDashboardGroupRewardsResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetValidatorDashboardRewardsChart godoc
+//
+//	@Description	Get rewards chart data for a specified dashboard
+//	@Tags			Validator Dashboard
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool`, `lido_csm`, `ssv`, `obol`. The operator share of `lido_csm`, `ssv` and `obol` validators is configured via the operator-shares endpoint of the dashboard."
+//	@Success		200				{object}	types.GetValidatorDashboardRewardsChartResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/rewards-chart [get]
+func (h *HandlerService) PublicGetValidatorDashboardRewardsChart(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	q := r.URL.Query()
+	dashboardId, err := h.handleDashboardId(r.Context(), vars["dashboard_id"])
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	protocolModes := v.checkProtocolModes(q.Get("modes"))
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetValidatorDashboardRewardsChart(r.Context(), *dashboardId, protocolModes)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetValidatorDashboardRewardsChartResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetValidatorDashboardDuties godoc
+//
+//	@Description	Get duties information for a specified dashboard
+//	@Tags			Validator Dashboard
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			epoch			path		integer	true	"The epoch to get data for."
+//	@Param			group_id		query		integer	false	"The ID of the group."
+//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
+//	@Param			sort			query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(validator, reward)
+//	@Param			search			query		string	false	"Search for Index, Public Key."
+//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool`, `lido_csm`, `ssv`, `obol`. The operator share of `lido_csm`, `ssv` and `obol` validators is configured via the operator-shares endpoint of the dashboard."
+//	@Success		200				{object}	types.GetValidatorDashboardDutiesResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/duties/{epoch} [get]
+func (h *HandlerService) PublicGetValidatorDashboardDuties(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId, err := h.handleDashboardId(r.Context(), vars["dashboard_id"])
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	q := r.URL.Query()
+	groupId := v.checkGroupId(q.Get("group_id"), allowEmpty)
+	epoch := v.checkUint(vars["epoch"], "epoch")
+	pagingParams := v.checkPagingParams(q)
+	sort := checkSort[enums.VDBDutiesColumn](&v, q.Get("sort"))
+	protocolModes := v.checkProtocolModes(q.Get("modes"))
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardDuties(r.Context(), *dashboardId, epoch, groupId, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit, protocolModes)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetValidatorDashboardDutiesResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetValidatorDashboardBlocks godoc
+//
+//	@Description	Get blocks information for a specified dashboard
+//	@Tags			Validator Dashboard
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
+//	@Param			sort			query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(proposer, slot, block, status, reward)
+//	@Param			search			query		string	false	"Search for Index, Public Key, Group."
+//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool`, `lido_csm`, `ssv`, `obol`. The operator share of `lido_csm`, `ssv` and `obol` validators is configured via the operator-shares endpoint of the dashboard."
+//	@Success		200				{object}	types.GetValidatorDashboardBlocksResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/blocks [get]
+func (h *HandlerService) PublicGetValidatorDashboardBlocks(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	q := r.URL.Query()
+	pagingParams := v.checkPagingParams(q)
+	sort := checkSort[enums.VDBBlocksColumn](&v, q.Get("sort"))
+	protocolModes := v.checkProtocolModes(q.Get("modes"))
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardBlocks(r.Context(), *dashboardId, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit, protocolModes)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetValidatorDashboardBlocksResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetValidatorDashboardHeatmap godoc
+//
+//	@Description	Get heatmap information for a specified dashboard
+//	@Tags			Validator Dashboard
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			aggregation		query		string	false	"Aggregation type to get data for."	Enums(epoch, hourly, daily, weekly)	Default(hourly)
+//	@Param			after_ts		query		string	false	"Return data after this timestamp."
+//	@Param			before_ts		query		string	false	"Return data before this timestamp."
+//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool`, `lido_csm`, `ssv`, `obol`. The operator share of `lido_csm`, `ssv` and `obol` validators is configured via the operator-shares endpoint of the dashboard."
+//	@Success		200				{object}	types.GetValidatorDashboardHeatmapResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/heatmap [get]
+func (h *HandlerService) PublicGetValidatorDashboardHeatmap(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	q := r.URL.Query()
+	protocolModes := v.checkProtocolModes(q.Get("modes"))
+	aggregation := checkEnum[enums.ChartAggregation](&v, r.URL.Query().Get("aggregation"), "aggregation")
+	chartLimits, err := h.getCurrentChartTimeLimitsForDashboard(r.Context(), dashboardId, aggregation)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	afterTs, beforeTs := v.checkTimestamps(r, chartLimits)
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
+	if afterTs < chartLimits.MinAllowedTs || beforeTs < chartLimits.MinAllowedTs {
+		returnConflict(w, r, fmt.Errorf("requested time range is too old, minimum timestamp for dashboard owner's premium subscription for this aggregation is %v", chartLimits.MinAllowedTs))
+		return
+	}
 
-	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardSummary(r.Context(), *dashboardId, period, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit, protocolModes)
+	data, err := h.getDataAccessor(r).GetValidatorDashboardHeatmap(r.Context(), *dashboardId, protocolModes, aggregation, afterTs, beforeTs)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.GetValidatorDashboardSummaryResponse{
-		Data:   data,
-		Paging: *paging,
+	response := types.GetValidatorDashboardHeatmapResponse{
+		Data: *data,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetValidatorDashboardGroupSummary godoc
+// PublicGetValidatorDashboardGroupHeatmap godoc
 //
-//	@Description	Get summary information for a specified group in a specified dashboard
+//	@Description	Get heatmap information for a specified group in a specified dashboard
 //	@Tags			Validator Dashboard
 //	@Produce		json
 //	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
 //	@Param			group_id		path		integer	true	"The ID of the group."
-//	@Param			period			query		string	true	"Time period to get data for."	Enums(all_time, last_30d, last_7d, last_24h, last_1h)
-//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool``."
-//	@Success		200				{object}	types.GetValidatorDashboardGroupSummaryResponse
+//	@Param			timestamp		path		integer	true	"The timestamp to get data for."
+//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool`, `lido_csm`, `ssv`, `obol`. The operator share of `lido_csm`, `ssv` and `obol` validators is configured via the operator-shares endpoint of the dashboard."
+//	@Param			aggregation		query		string	false	"Aggregation type to get data for."	Enums(epoch, hourly, daily, weekly)	Default(hourly)
+//	@Success		200				{object}	types.GetValidatorDashboardGroupHeatmapResponse
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/groups/{group_id}/summary [get]
-func (h *HandlerService) PublicGetValidatorDashboardGroupSummary(w http.ResponseWriter, r *http.Request) {
+//	@Router			/validator-dashboards/{dashboard_id}/groups/{group_id}/heatmap/{timestamp} [get]
+func (h *HandlerService) PublicGetValidatorDashboardGroupHeatmap(w http.ResponseWriter, r *http.Request) {
 	var v validationError
 	vars := mux.Vars(r)
 	dashboardId, err := h.handleDashboardId(r.Context(), vars["dashboard_id"])
-	q := r.URL.Query()
-	protocolModes := v.checkProtocolModes(q.Get("modes"))
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	groupId := v.checkExistingGroupId(vars["group_id"])
+	requestedTimestamp := v.checkUint(vars["timestamp"], "timestamp")
+	protocolModes := v.checkProtocolModes(r.URL.Query().Get("modes"))
+	aggregation := checkEnum[enums.ChartAggregation](&v, r.URL.Query().Get("aggregation"), "aggregation")
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
+	chartLimits, err := h.getCurrentChartTimeLimitsForDashboard(r.Context(), dashboardId, aggregation)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	groupId := v.checkGroupId(vars["group_id"], forbidEmpty)
-	period := checkEnum[enums.TimePeriod](&v, r.URL.Query().Get("period"), "period")
-	if v.hasErrors() {
-		handleErr(w, r, v)
+	if requestedTimestamp < chartLimits.MinAllowedTs || requestedTimestamp > chartLimits.LatestExportedTs {
+		handleErr(w, r, newConflictErr("requested timestamp is outside of allowed chart history for dashboard owner's premium subscription"))
 		return
 	}
 
-	data, err := h.getDataAccessor(r).GetValidatorDashboardGroupSummary(r.Context(), *dashboardId, groupId, period, protocolModes)
+	data, err := h.getDataAccessor(r).GetValidatorDashboardGroupHeatmap(r.Context(), *dashboardId, groupId, protocolModes, aggregation, requestedTimestamp)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.GetValidatorDashboardGroupSummaryResponse{
+	response := types.GetValidatorDashboardGroupHeatmapResponse{
 		Data: *data,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetValidatorDashboardSummaryChart godoc
+// PublicGetValidatorDashboardExecutionLayerDeposits godoc
 //
-//	@Description	Get summary chart data for a specified dashboard
+//	@Description	Get execution layer deposits information for a specified dashboard
 //	@Tags			Validator Dashboard
 //	@Produce		json
 //	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Param			group_ids		query		string	false	"Provide a comma separated list of group IDs to filter the results by."
-//	@Param			efficiency_type	query		string	false	"Efficiency type to get data for."	Enums(all, attestation, sync, proposal)
-//	@Param			aggregation		query		string	false	"Aggregation type to get data for."	Enums(epoch, hourly, daily, weekly)	Default(hourly)
-//	@Param			after_ts		query		string	false	"Return data after this timestamp."
-//	@Param			before_ts		query		string	false	"Return data before this timestamp."
-//	@Success		200				{object}	types.GetValidatorDashboardSummaryChartResponse
+//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
+//	@Success		200				{object}	types.GetValidatorDashboardExecutionLayerDepositsResponse
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/summary-chart [get]
-func (h *HandlerService) PublicGetValidatorDashboardSummaryChart(w http.ResponseWriter, r *http.Request) {
+//	@Router			/validator-dashboards/{dashboard_id}/execution-layer-deposits [get]
+func (h *HandlerService) PublicGetValidatorDashboardExecutionLayerDeposits(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	ctx := r.Context()
-	dashboardId, err := h.handleDashboardId(ctx, mux.Vars(r)["dashboard_id"])
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	q := r.URL.Query()
-	groupIds := v.checkGroupIdList(q.Get("group_ids"))
-	efficiencyType := checkEnum[enums.VDBSummaryChartEfficiencyType](&v, q.Get("efficiency_type"), "efficiency_type")
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
 
-	aggregation := checkEnum[enums.ChartAggregation](&v, r.URL.Query().Get("aggregation"), "aggregation")
-	chartLimits, err := h.getCurrentChartTimeLimitsForDashboard(ctx, dashboardId, aggregation)
+	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardElDeposits(r.Context(), *dashboardId, pagingParams.cursor, pagingParams.limit)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	afterTs, beforeTs := v.checkTimestamps(r, chartLimits)
-	if v.hasErrors() {
-		handleErr(w, r, v)
+	response := types.GetValidatorDashboardExecutionLayerDepositsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetValidatorDashboardConsensusLayerDeposits godoc
+//
+//	@Description	Get consensus layer deposits information for a specified dashboard
+//	@Tags			Validator Dashboard
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
+//	@Success		200				{object}	types.GetValidatorDashboardConsensusLayerDepositsResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/consensus-layer-deposits [get]
+func (h *HandlerService) PublicGetValidatorDashboardConsensusLayerDeposits(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, r, err)
 		return
 	}
-	if afterTs < chartLimits.MinAllowedTs || beforeTs < chartLimits.MinAllowedTs {
-		returnConflict(w, r, fmt.Errorf("requested time range is too old, minimum timestamp for dashboard owner's premium subscription for this aggregation is %v", chartLimits.MinAllowedTs))
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
 		return
 	}
 
-	data, err := h.getDataAccessor(r).GetValidatorDashboardSummaryChart(ctx, *dashboardId, groupIds, efficiencyType, aggregation, afterTs, beforeTs)
+	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardClDeposits(r.Context(), *dashboardId, pagingParams.cursor, pagingParams.limit)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.GetValidatorDashboardSummaryChartResponse{
-		Data: *data,
+
+	response := types.GetValidatorDashboardConsensusLayerDepositsResponse{
+		Data:   data,
+		Paging: *paging,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetValidatorDashboardSummaryValidators godoc
+// PublicGetValidatorDashboardTotalConsensusLayerDeposits godoc
 //
-//	@Description	Get summary information for validators in a specified dashboard
+//	@Description	Get total consensus layer deposits information for a specified dashboard
 //	@Tags			Validator Dashboard
 //	@Produce		json
 //	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Param			group_id		query		integer	false	"The ID of the group."
-//	@Param			duty			query		string	false	"Validator duty to get data for."	Enums(none, sync, slashed, proposal)	Default(none)
-//	@Param			period			query		string	true	"Time period to get data for."		Enums(all_time, last_30d, last_7d, last_24h, last_1h)
-//	@Success		200				{object}	types.GetValidatorDashboardSummaryValidatorsResponse
+//	@Success		200				{object}	types.GetValidatorDashboardTotalConsensusDepositsResponse
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/summary/validators [get]
-func (h *HandlerService) PublicGetValidatorDashboardSummaryValidators(w http.ResponseWriter, r *http.Request) {
-	var v validationError
+//	@Router			/validator-dashboards/{dashboard_id}/total-consensus-layer-deposits [get]
+func (h *HandlerService) PublicGetValidatorDashboardTotalConsensusLayerDeposits(w http.ResponseWriter, r *http.Request) {
+	var err error
 	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	groupId := v.checkGroupId(r.URL.Query().Get("group_id"), allowEmpty)
-	q := r.URL.Query()
-	duty := checkEnum[enums.ValidatorDuty](&v, q.Get("duty"), "duty")
-	period := checkEnum[enums.TimePeriod](&v, q.Get("period"), "period")
-	if v.hasErrors() {
-		handleErr(w, r, v)
+	data, err := h.getDataAccessor(r).GetValidatorDashboardTotalClDeposits(r.Context(), *dashboardId)
+	if err != nil {
+		handleErr(w, r, err)
 		return
 	}
 
-	// get indices based on duty
-	var indices interface{}
-	duties := enums.ValidatorDuties
-	switch duty {
-	case duties.None:
-		indices, err = h.getDataAccessor(r).GetValidatorDashboardSummaryValidators(r.Context(), *dashboardId, groupId)
-	case duties.Sync:
-		indices, err = h.getDataAccessor(r).GetValidatorDashboardSyncSummaryValidators(r.Context(), *dashboardId, groupId, period)
-	case duties.Slashed:
-		indices, err = h.getDataAccessor(r).GetValidatorDashboardSlashingsSummaryValidators(r.Context(), *dashboardId, groupId, period)
-	case duties.Proposal:
-		indices, err = h.getDataAccessor(r).GetValidatorDashboardProposalSummaryValidators(r.Context(), *dashboardId, groupId, period)
+	response := types.GetValidatorDashboardTotalConsensusDepositsResponse{
+		Data: *data,
 	}
+	returnOk(w, r, response)
+}
+
+// PublicGetValidatorDashboardTotalExecutionLayerDeposits godoc
+//
+//	@Description	Get total execution layer deposits information for a specified dashboard
+//	@Tags			Validator Dashboard
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Success		200				{object}	types.GetValidatorDashboardTotalExecutionDepositsResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/total-execution-layer-deposits [get]
+func (h *HandlerService) PublicGetValidatorDashboardTotalExecutionLayerDeposits(w http.ResponseWriter, r *http.Request) {
+	var err error
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	// map indices to response format
-	data, err := mapVDBIndices(indices)
+	data, err := h.getDataAccessor(r).GetValidatorDashboardTotalElDeposits(r.Context(), *dashboardId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
 
-	response := types.GetValidatorDashboardSummaryValidatorsResponse{
-		Data: data,
+	response := types.GetValidatorDashboardTotalExecutionDepositsResponse{
+		Data: *data,
 	}
-
 	returnOk(w, r, response)
 }
 
-// PublicGetValidatorDashboardRewards godoc
+// PublicGetValidatorDashboardWithdrawals godoc
 //
-//	@Description	Get rewards information for a specified dashboard
+//	@Description	Get withdrawals information for a specified dashboard
 //	@Tags			Validator Dashboard
 //	@Produce		json
 //	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
 //	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
 //	@Param			limit			query		string	false	"The maximum number of results that may be returned."
-//	@Param			sort			query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(epoch)
-//	@Param			search			query		string	false	"Search for Epoch, Index, Public Key, Group."
-//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool``."
-//	@Success		200				{object}	types.GetValidatorDashboardRewardsResponse
+//	@Param			sort			query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(epoch, slot, index, recipient, amount)
+//	@Param			search			query		string	false	"Search for Index, Public Key, Address."
+//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool`, `lido_csm`, `ssv`, `obol`. The operator share of `lido_csm`, `ssv` and `obol` validators is configured via the operator-shares endpoint of the dashboard."
+//	@Success		200				{object}	types.GetValidatorDashboardWithdrawalsResponse
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/rewards [get]
-func (h *HandlerService) PublicGetValidatorDashboardRewards(w http.ResponseWriter, r *http.Request) {
+//	@Router			/validator-dashboards/{dashboard_id}/withdrawals [get]
+func (h *HandlerService) PublicGetValidatorDashboardWithdrawals(w http.ResponseWriter, r *http.Request) {
 	var v validationError
+	q := r.URL.Query()
 	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	q := r.URL.Query()
 	pagingParams := v.checkPagingParams(q)
-	sort := checkSort[enums.VDBRewardsColumn](&v, q.Get("sort"))
+	sort := checkSort[enums.VDBWithdrawalsColumn](&v, q.Get("sort"))
 	protocolModes := v.checkProtocolModes(q.Get("modes"))
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
 
-	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardRewards(r.Context(), *dashboardId, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit, protocolModes)
+	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardWithdrawals(r.Context(), *dashboardId, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit, protocolModes)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.GetValidatorDashboardRewardsResponse{
+	response := types.GetValidatorDashboardWithdrawalsResponse{
 		Data:   data,
 		Paging: *paging,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetValidatorDashboardGroupRewards godoc
+// PublicGetValidatorDashboardExport godoc
 //
-//	@Description	Get rewards information for a specified group in a specified dashboard
+//	@Description	Export rewards (including duty efficiencies), per validator duties, withdrawals or execution and consensus layer deposits of a specified dashboard for a time range as CSV or newline-delimited JSON.
+//	@Description	The export is streamed and its amounts are given in the native currency of the network, optionally converted using the historical daily price of the requested currency.
+//	@Description	Exporting requires the dashboard owner to have a premium subscription which includes exports.
 //	@Tags			Validator Dashboard
-//	@Produce		json
+//	@Produce		text/csv,application/x-ndjson
 //	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Param			group_id		path		integer	true	"The ID of the group."
-//	@Param			epoch			path		integer	true	"The epoch to get data for."
-//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool``."
-//	@Success		200				{object}	types.GetValidatorDashboardGroupRewardsResponse
+//	@Param			export_type		path		string	true	"The data to export."	Enums(rewards, duties, withdrawals, execution-layer-deposits, consensus-layer-deposits)
+//	@Param			format			query		string	false	"The format of the export, defaults to `csv`."	Enums(csv, ndjson)
+//	@Param			after_ts		query		string	false	"Export data after this unix timestamp. Defaults to 30 days (7 days for duties) before `before_ts`."
+//	@Param			before_ts		query		string	false	"Export data before this unix timestamp. Defaults to now. The range must not exceed 366 days, or 7 days for duties."
+//	@Param			group_id		query		integer	false	"Only export data of this group."
+//	@Param			currency		query		string	false	"Add the historical price and the converted amounts in this currency."	Enums(USD, EUR, GBP, CAD, JPY, CNY, AUD, RUB)
+//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool`, `lido_csm`, `ssv`, `obol`. The operator share of `lido_csm`, `ssv` and `obol` validators is configured via the operator-shares endpoint of the dashboard."
+//	@Success		200				"The exported data, streamed row by row."
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/groups/{group_id}/rewards/{epoch} [get]
-func (h *HandlerService) PublicGetValidatorDashboardGroupRewards(w http.ResponseWriter, r *http.Request) {
+//	@Failure		403				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/exports/{export_type} [get]
+func (h *HandlerService) PublicGetValidatorDashboardExport(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	vars := mux.Vars(r)
 	q := r.URL.Query()
-	dashboardId, err := h.handleDashboardId(r.Context(), vars["dashboard_id"])
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	groupId := v.checkGroupId(vars["group_id"], forbidEmpty)
-	epoch := v.checkUint(vars["epoch"], "epoch")
-	protocolModes := v.checkProtocolModes(q.Get("modes"))
+	export := validatorDashboardExport{
+		dashboardId:   *dashboardId,
+		exportType:    checkEnum[enums.VDBExportType](&v, mux.Vars(r)["export_type"], "export_type"),
+		format:        checkEnum[enums.VDBExportFormat](&v, q.Get("format"), "format"),
+		currency:      checkEnum[enums.Currency](&v, q.Get("currency"), "currency"),
+		groupId:       v.checkGroupId(q.Get("group_id"), allowEmpty),
+		protocolModes: v.checkProtocolModes(q.Get("modes")),
+	}
+	export.afterTs, export.beforeTs = v.checkExportTimestamps(q, export.maxInterval())
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+
+	premiumPerks, err := h.getDashboardPremiumPerks(r.Context(), *dashboardId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if !premiumPerks.ValidatorDashboardExports {
+		handleErr(w, r, newForbiddenErr("exports are not available for the dashboard owner's premium subscription"))
+		return
+	}
+
+	export.prices, err = h.getDataAccessor(r).GetHistoricalExchangeRates(r.Context(), export.currency, export.afterTs, export.beforeTs)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	switch export.exportType {
+	case enums.VDBExportTypes.Rewards:
+		h.exportValidatorDashboardRewards(w, r, export)
+	case enums.VDBExportTypes.Withdrawals:
+		h.exportValidatorDashboardWithdrawals(w, r, export)
+	case enums.VDBExportTypes.Duties:
+		h.exportValidatorDashboardDuties(w, r, export)
+	case enums.VDBExportTypes.ElDeposits:
+		h.exportValidatorDashboardElDeposits(w, r, export)
+	case enums.VDBExportTypes.ClDeposits:
+		h.exportValidatorDashboardClDeposits(w, r, export)
+	}
+}
+
+// PublicGetValidatorDashboardIncomeReport godoc
+//
+//	@Description	Get a report of the daily consensus and execution layer income of a specified dashboard for a fiscal period, including the fiat value of the income at the time it was received.
+//	@Description	The report can be downloaded as PDF, CSV or in the import formats of Koinly and CoinTracking.
+//	@Description	Income reports require the dashboard owner to have a premium subscription which includes exports.
+//	@Tags			Validator Dashboard
+//	@Produce		application/pdf,text/csv
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			year			query		integer	true	"The year the fiscal period starts in."
+//	@Param			start_month		query		integer	false	"The month the fiscal period starts with, defaults to `1`."	minimum(1)	maximum(12)
+//	@Param			months			query		integer	false	"The length of the fiscal period in months, defaults to `12`."	minimum(1)	maximum(12)
+//	@Param			format			query		string	false	"The format of the report, defaults to `pdf`."	Enums(pdf, csv, koinly, cointracking)
+//	@Param			currency		query		string	false	"The fiat currency the income is valued in, defaults to `USD`."	Enums(USD, EUR, GBP, CAD, JPY, CNY, AUD, RUB)
+//	@Param			group_id		query		integer	false	"Only include the income of this group."
+//	@Success		200				"The income report."
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Failure		403				{object}	types.ApiErrorResponse
+//	@Router			/validator-dashboards/{dashboard_id}/income-report [get]
+func (h *HandlerService) PublicGetValidatorDashboardIncomeReport(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	q := r.URL.Query()
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	period := v.checkFiscalPeriod(q)
+	format := checkEnum[enums.IncomeReportFormat](&v, q.Get("format"), "format")
+	currency := enums.Currencies.USD
+	if q.Get("currency") != "" {
+		currency = checkEnum[enums.Currency](&v, q.Get("currency"), "currency")
+	}
+	groupId := v.checkGroupId(q.Get("group_id"), allowEmpty)
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-
-	data, err := h.getDataAccessor(r).GetValidatorDashboardGroupRewards(r.Context(), *dashboardId, groupId, epoch, protocolModes)
+
+	premiumPerks, err := h.getDashboardPremiumPerks(r.Context(), *dashboardId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	if !premiumPerks.ValidatorDashboardExports {
+		handleErr(w, r, newForbiddenErr("income reports are not available for the dashboard owner's premium subscription"))
+		return
+	}
+
+	report, err := h.getDataAccessor(r).GetValidatorDashboardIncomeReport(r.Context(), *dashboardId, groupId, currency, period)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	doc, err := reports.Render(report, format)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.GetValidatorDashboardGroupRewardsResponse{
-		Data: *data,
+
+	w.Header().Set("Content-Type", doc.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, doc.FileName))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(doc.Data); err != nil {
+		logApiError(r, fmt.Errorf("error writing income report: %w", err), 0)
 	}
-	returnOk(w, r, response)
 }
 
-// PublicGetValidatorDashboardRewardsChart godoc
+// PublicGetValidatorDashboardTotalWithdrawals godoc
 //
-//	@Description	Get rewards chart data for a specified dashboard
+//	@Description	Get total withdrawals information for a specified dashboard
 //	@Tags			Validator Dashboard
 //	@Produce		json
 //	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool``."
-//	@Success		200				{object}	types.GetValidatorDashboardRewardsChartResponse
+//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool`, `lido_csm`, `ssv`, `obol`. The operator share of `lido_csm`, `ssv` and `obol` validators is configured via the operator-shares endpoint of the dashboard."
+//	@Success		200				{object}	types.GetValidatorDashboardTotalWithdrawalsResponse
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/rewards-chart [get]
-func (h *HandlerService) PublicGetValidatorDashboardRewardsChart(w http.ResponseWriter, r *http.Request) {
+//	@Router			/validator-dashboards/{dashboard_id}/total-withdrawals [get]
+func (h *HandlerService) PublicGetValidatorDashboardTotalWithdrawals(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	vars := mux.Vars(r)
 	q := r.URL.Query()
-	dashboardId, err := h.handleDashboardId(r.Context(), vars["dashboard_id"])
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
+	pagingParams := v.checkPagingParams(q)
 	protocolModes := v.checkProtocolModes(q.Get("modes"))
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
 
-	data, err := h.getDataAccessor(r).GetValidatorDashboardRewardsChart(r.Context(), *dashboardId, protocolModes)
+	data, err := h.getDataAccessor(r).GetValidatorDashboardTotalWithdrawals(r.Context(), *dashboardId, pagingParams.search, protocolModes)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.GetValidatorDashboardRewardsChartResponse{
+
+	response := types.GetValidatorDashboardTotalWithdrawalsResponse{
 		Data: *data,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetValidatorDashboardDuties godoc
+// PublicGetValidatorDashboardPoolOperations godoc
 //
-//	@Description	Get duties information for a specified dashboard
+//	@Description	Get the voluntary exits and BLS to execution changes of the validators of a specified dashboard that have been seen in the op pool of the beacon node, from the moment they were first seen until they got included or dropped.
 //	@Tags			Validator Dashboard
 //	@Produce		json
 //	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Param			epoch			path		integer	true	"The epoch to get data for."
-//	@Param			group_id		query		integer	false	"The ID of the group."
-//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
-//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
-//	@Param			sort			query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(validator, reward)
-//	@Param			search			query		string	false	"Search for Index, Public Key."
-//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool``."
-//	@Success		200				{object}	types.GetValidatorDashboardDutiesResponse
+//	@Success		200				{object}	types.GetValidatorDashboardPoolOperationsResponse
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/duties/{epoch} [get]
-func (h *HandlerService) PublicGetValidatorDashboardDuties(w http.ResponseWriter, r *http.Request) {
-	var v validationError
-	vars := mux.Vars(r)
-	dashboardId, err := h.handleDashboardId(r.Context(), vars["dashboard_id"])
+//	@Router			/validator-dashboards/{dashboard_id}/pool-operations [get]
+func (h *HandlerService) PublicGetValidatorDashboardPoolOperations(w http.ResponseWriter, r *http.Request) {
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	q := r.URL.Query()
-	groupId := v.checkGroupId(q.Get("group_id"), allowEmpty)
-	epoch := v.checkUint(vars["epoch"], "epoch")
-	pagingParams := v.checkPagingParams(q)
-	sort := checkSort[enums.VDBDutiesColumn](&v, q.Get("sort"))
-	protocolModes := v.checkProtocolModes(q.Get("modes"))
-	if v.hasErrors() {
-		handleErr(w, r, v)
-		return
-	}
 
-	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardDuties(r.Context(), *dashboardId, epoch, groupId, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit, protocolModes)
+	data, err := h.getDataAccessor(r).GetValidatorDashboardPoolOperations(r.Context(), *dashboardId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.GetValidatorDashboardDutiesResponse{
-		Data:   data,
-		Paging: *paging,
+
+	response := types.GetValidatorDashboardPoolOperationsResponse{
+		Data: data,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetValidatorDashboardBlocks godoc
+// PublicGetValidatorDashboardRocketPool godoc
 //
-//	@Description	Get blocks information for a specified dashboard
+//	@Description	Get an aggregated list of the Rocket Pool nodes details associated with a specified dashboard.
 //	@Tags			Validator Dashboard
 //	@Produce		json
 //	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
 //	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
 //	@Param			limit			query		string	false	"The maximum number of results that may be returned."
-//	@Param			sort			query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(proposer, slot, block, status, reward)
-//	@Param			search			query		string	false	"Search for Index, Public Key, Group."
-//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool``."
-//	@Success		200				{object}	types.GetValidatorDashboardBlocksResponse
+//	@Param			sort			query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(node, minipools, collateral, rpl, effective_rpl, rpl_apr, smoothing_pool)
+//	@Param			search			query		string	false	"Search for Node address."
+//	@Success		200				{object}	types.GetValidatorDashboardRocketPoolResponse
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/blocks [get]
-func (h *HandlerService) PublicGetValidatorDashboardBlocks(w http.ResponseWriter, r *http.Request) {
+//	@Router			/validator-dashboards/{dashboard_id}/rocket-pool [get]
+func (h *HandlerService) PublicGetValidatorDashboardRocketPool(w http.ResponseWriter, r *http.Request) {
 	var v validationError
+	q := r.URL.Query()
 	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	q := r.URL.Query()
 	pagingParams := v.checkPagingParams(q)
-	sort := checkSort[enums.VDBBlocksColumn](&v, q.Get("sort"))
-	protocolModes := v.checkProtocolModes(q.Get("modes"))
+	sort := checkSort[enums.VDBRocketPoolColumn](&v, q.Get("sort"))
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
 
-	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardBlocks(r.Context(), *dashboardId, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit, protocolModes)
+	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardRocketPool(r.Context(), *dashboardId, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.GetValidatorDashboardBlocksResponse{
+	response := types.GetValidatorDashboardRocketPoolResponse{
 		Data:   data,
 		Paging: *paging,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetValidatorDashboardHeatmap godoc
+// PublicGetValidatorDashboardTotalRocketPool godoc
 //
-//	@Description	Get heatmap information for a specified dashboard
+//	@Description	Get a summary of all Rocket Pool nodes details associated with a specified dashboard.
 //	@Tags			Validator Dashboard
 //	@Produce		json
 //	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Param			aggregation		query		string	false	"Aggregation type to get data for."	Enums(epoch, hourly, daily, weekly)	Default(hourly)
-//	@Param			after_ts		query		string	false	"Return data after this timestamp."
-//	@Param			before_ts		query		string	false	"Return data before this timestamp."
-//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool``."
-//	@Success		200				{object}	types.GetValidatorDashboardHeatmapResponse
+//	@Success		200				{object}	types.GetValidatorDashboardTotalRocketPoolResponse
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/heatmap [get]
-func (h *HandlerService) PublicGetValidatorDashboardHeatmap(w http.ResponseWriter, r *http.Request) {
+//	@Router			/validator-dashboards/{dashboard_id}/total-rocket-pool [get]
+func (h *HandlerService) PublicGetValidatorDashboardTotalRocketPool(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
 	q := r.URL.Query()
-	protocolModes := v.checkProtocolModes(q.Get("modes"))
-	aggregation := checkEnum[enums.ChartAggregation](&v, r.URL.Query().Get("aggregation"), "aggregation")
-	chartLimits, err := h.getCurrentChartTimeLimitsForDashboard(r.Context(), dashboardId, aggregation)
+	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	afterTs, beforeTs := v.checkTimestamps(r, chartLimits)
+	pagingParams := v.checkPagingParams(q)
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	if afterTs < chartLimits.MinAllowedTs || beforeTs < chartLimits.MinAllowedTs {
-		returnConflict(w, r, fmt.Errorf("requested time range is too old, minimum timestamp for dashboard owner's premium subscription for this aggregation is %v", chartLimits.MinAllowedTs))
-		return
-	}
 
-	data, err := h.getDataAccessor(r).GetValidatorDashboardHeatmap(r.Context(), *dashboardId, protocolModes, aggregation, afterTs, beforeTs)
+	data, err := h.getDataAccessor(r).GetValidatorDashboardTotalRocketPool(r.Context(), *dashboardId, pagingParams.search)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.GetValidatorDashboardHeatmapResponse{
+	response := types.GetValidatorDashboardTotalRocketPoolResponse{
 		Data: *data,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetValidatorDashboardGroupHeatmap godoc
+// PublicGetValidatorDashboardRocketPoolMinipools godoc
 //
-//	@Description	Get heatmap information for a specified group in a specified dashboard
+//	@Description	Get minipools information for a specified Rocket Pool node associated with a specified dashboard.
 //	@Tags			Validator Dashboard
 //	@Produce		json
 //	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Param			group_id		path		integer	true	"The ID of the group."
-//	@Param			timestamp		path		integer	true	"The timestamp to get data for."
-//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool``."
-//	@Param			aggregation		query		string	false	"Aggregation type to get data for."	Enums(epoch, hourly, daily, weekly)	Default(hourly)
-//	@Success		200				{object}	types.GetValidatorDashboardGroupHeatmapResponse
+//	@Param			node_address	path		string	true	"The address of the node."
+//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
+//	@Param			sort			query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(group_id)
+//	@Param			search			query		string	false	"Search for Index, Node."
+//	@Success		200				{object}	types.GetValidatorDashboardRocketPoolMinipoolsResponse
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/groups/{group_id}/heatmap/{timestamp} [get]
-func (h *HandlerService) PublicGetValidatorDashboardGroupHeatmap(w http.ResponseWriter, r *http.Request) {
+//	@Router			/validator-dashboards/{dashboard_id}/rocket-pool/{node_address}/minipools [get]
+func (h *HandlerService) PublicGetValidatorDashboardRocketPoolMinipools(w http.ResponseWriter, r *http.Request) {
 	var v validationError
 	vars := mux.Vars(r)
+	q := r.URL.Query()
 	dashboardId, err := h.handleDashboardId(r.Context(), vars["dashboard_id"])
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	groupId := v.checkExistingGroupId(vars["group_id"])
-	requestedTimestamp := v.checkUint(vars["timestamp"], "timestamp")
-	protocolModes := v.checkProtocolModes(r.URL.Query().Get("modes"))
-	aggregation := checkEnum[enums.ChartAggregation](&v, r.URL.Query().Get("aggregation"), "aggregation")
+	// support ENS names ?
+	nodeAddress := v.checkAddress(vars["node_address"])
+	pagingParams := v.checkPagingParams(q)
+	sort := checkSort[enums.VDBRocketPoolMinipoolsColumn](&v, q.Get("sort"))
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	chartLimits, err := h.getCurrentChartTimeLimitsForDashboard(r.Context(), dashboardId, aggregation)
+
+	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardRocketPoolMinipools(r.Context(), *dashboardId, nodeAddress, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	if requestedTimestamp < chartLimits.MinAllowedTs || requestedTimestamp > chartLimits.LatestExportedTs {
-		handleErr(w, r, newConflictErr("requested timestamp is outside of allowed chart history for dashboard owner's premium subscription"))
-		return
+	response := types.GetValidatorDashboardRocketPoolMinipoolsResponse{
+		Data:   data,
+		Paging: *paging,
 	}
+	returnOk(w, r, response)
+}
 
-	data, err := h.getDataAccessor(r).GetValidatorDashboardGroupHeatmap(r.Context(), *dashboardId, groupId, protocolModes, aggregation, requestedTimestamp)
+// ----------------------------------------------
+// Notifications
+// ----------------------------------------------
+
+// PublicGetUserNotifications godoc
+//
+//	@Description	Get an overview of your recent notifications.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notifications
+//	@Produce		json
+//	@Success		200	{object}	types.InternalGetUserNotificationsResponse
+//	@Router			/users/me/notifications [get]
+func (h *HandlerService) PublicGetUserNotifications(w http.ResponseWriter, r *http.Request) {
+	userId, err := GetUserIdByContext(r)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.GetValidatorDashboardGroupHeatmapResponse{
+	data, err := h.getDataAccessor(r).GetNotificationOverview(r.Context(), userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetUserNotificationsResponse{
 		Data: *data,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetValidatorDashboardExecutionLayerDeposits godoc
+// PublicGetUserNotificationDashboards godoc
 //
-//	@Description	Get execution layer deposits information for a specified dashboard
-//	@Tags			Validator Dashboard
+//	@Description	Get a list of triggered notifications related to your dashboards.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notifications
 //	@Produce		json
-//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
-//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
-//	@Success		200				{object}	types.GetValidatorDashboardExecutionLayerDepositsResponse
-//	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/execution-layer-deposits [get]
-func (h *HandlerService) PublicGetValidatorDashboardExecutionLayerDeposits(w http.ResponseWriter, r *http.Request) {
+//	@Param			networks	query		string	false	"If set, results will be filtered to only include networks given. Provide a comma separated list."
+//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit	query		integer	false	"The maximum number of results that may be returned."
+//	@Param			sort	query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	" Enums(chain_id, timestamp, dashboard_id)
+//	@Param			search	query		string	false	"Search for Dashboard, Group"
+//	@Success		200		{object}	types.InternalGetUserNotificationDashboardsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/dashboards [get]
+func (h *HandlerService) PublicGetUserNotificationDashboards(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	userId, err := GetUserIdByContext(r)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	pagingParams := v.checkPagingParams(r.URL.Query())
+	q := r.URL.Query()
+	pagingParams := v.checkPagingParams(q)
+	sort := checkSort[enums.NotificationDashboardsColumn](&v, q.Get("sort"))
+	chainIds := v.checkNetworksParameter(q.Get("networks"))
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
 
-	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardElDeposits(r.Context(), *dashboardId, pagingParams.cursor, pagingParams.limit)
+	data, paging, err := h.getDataAccessor(r).GetDashboardNotifications(r.Context(), userId, chainIds, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.GetValidatorDashboardExecutionLayerDepositsResponse{
+	mapDashboardNotificationEvents(data)
+	response := types.InternalGetUserNotificationDashboardsResponse{
 		Data:   data,
 		Paging: *paging,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetValidatorDashboardConsensusLayerDeposits godoc
+// PublicGetUserNotificationValidators godoc
 //
-//	@Description	Get consensus layer deposits information for a specified dashboard
-//	@Tags			Validator Dashboard
+//	@Description	Get a detailed view of a triggered notification related to a validator dashboard group at a specific epoch.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notifications
 //	@Produce		json
 //	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
-//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
-//	@Success		200				{object}	types.GetValidatorDashboardConsensusLayerDepositsResponse
+//	@Param			group_id		path		integer	true	"The ID of the group."
+//	@Param			epoch			path		integer	true	"The epoch of the notification."
+//	@Param			search			query		string	false	"Search for Index"
+//	@Success		200				{object}	types.InternalGetUserNotificationsValidatorDashboardResponse
 //	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/consensus-layer-deposits [get]
-func (h *HandlerService) PublicGetValidatorDashboardConsensusLayerDeposits(w http.ResponseWriter, r *http.Request) {
+//	@Router			/users/me/notifications/validator-dashboards/{dashboard_id}/groups/{group_id}/epochs/{epoch} [get]
+func (h *HandlerService) PublicGetUserNotificationsValidatorDashboard(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
+	epoch := v.checkUint(vars["epoch"], "epoch")
+	search := r.URL.Query().Get("search")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	data, err := h.getDataAccessor(r).GetValidatorDashboardNotificationDetails(r.Context(), dashboardId, groupId, epoch, search)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	pagingParams := v.checkPagingParams(r.URL.Query())
+	response := types.InternalGetUserNotificationsValidatorDashboardResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetUserNotificationsAccountDashboard godoc
+//
+//	@Description	Get a detailed view of a triggered notification related to an account dashboard group at a specific epoch.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notifications
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			group_id		path		integer	true	"The ID of the group."
+//	@Param			epoch			path		integer	true	"The epoch of the notification."
+//	@Param			search			query		string	false	"Search for Address, ENS"
+//	@Success		200				{object}	types.InternalGetUserNotificationsAccountDashboardResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/account-dashboards/{dashboard_id}/groups/{group_id}/epochs/{epoch} [get]
+func (h *HandlerService) PublicGetUserNotificationsAccountDashboard(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkAccountDashboardId(vars["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
+	epoch := v.checkUint(vars["epoch"], "epoch")
+	search := r.URL.Query().Get("search")
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-
-	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardClDeposits(r.Context(), *dashboardId, pagingParams.cursor, pagingParams.limit)
+	data, err := h.getDataAccessor(r).GetAccountDashboardNotificationDetails(r.Context(), dashboardId, groupId, epoch, search)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-
-	response := types.GetValidatorDashboardConsensusLayerDepositsResponse{
-		Data:   data,
-		Paging: *paging,
+	response := types.InternalGetUserNotificationsAccountDashboardResponse{
+		Data: *data,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetValidatorDashboardTotalConsensusLayerDeposits godoc
+// PublicGetUserNotificationMachines godoc
 //
-//	@Description	Get total consensus layer deposits information for a specified dashboard
-//	@Tags			Validator Dashboard
+//	@Description	Get a list of triggered notifications related to your machines.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notifications
 //	@Produce		json
-//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Success		200				{object}	types.GetValidatorDashboardTotalConsensusDepositsResponse
-//	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/total-consensus-layer-deposits [get]
-func (h *HandlerService) PublicGetValidatorDashboardTotalConsensusLayerDeposits(w http.ResponseWriter, r *http.Request) {
-	var err error
-	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit	query		integer	false	"The maximum number of results that may be returned."
+//	@Param			sort	query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(machine_name, threshold, event_type, timestamp)
+//	@Param			search	query		string	false	"Search for Machine"
+//	@Success		200		{object}	types.InternalGetUserNotificationMachinesResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/machines [get]
+func (h *HandlerService) PublicGetUserNotificationMachines(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	data, err := h.getDataAccessor(r).GetValidatorDashboardTotalClDeposits(r.Context(), *dashboardId)
+	q := r.URL.Query()
+	pagingParams := v.checkPagingParams(q)
+	sort := checkSort[enums.NotificationMachinesColumn](&v, q.Get("sort"))
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	data, paging, err := h.getDataAccessor(r).GetMachineNotifications(r.Context(), userId, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-
-	response := types.GetValidatorDashboardTotalConsensusDepositsResponse{
-		Data: *data,
+	mapMachineNotificationEventNames(data)
+	response := types.InternalGetUserNotificationMachinesResponse{
+		Data:   data,
+		Paging: *paging,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetValidatorDashboardTotalExecutionLayerDeposits godoc
+// PublicGetUserNotificationClients godoc
 //
-//	@Description	Get total execution layer deposits information for a specified dashboard
-//	@Tags			Validator Dashboard
+//	@Description	Get a list of triggered notifications related to your clients.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notifications
 //	@Produce		json
-//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Success		200				{object}	types.GetValidatorDashboardTotalExecutionDepositsResponse
-//	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/total-execution-layer-deposits [get]
-func (h *HandlerService) PublicGetValidatorDashboardTotalExecutionLayerDeposits(w http.ResponseWriter, r *http.Request) {
-	var err error
-	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit	query		integer	false	"The maximum number of results that may be returned."
+//	@Param			sort	query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(client_name, timestamp)
+//	@Param			search	query		string	false	"Search for Client"
+//	@Success		200		{object}	types.InternalGetUserNotificationClientsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/clients [get]
+func (h *HandlerService) PublicGetUserNotificationClients(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	data, err := h.getDataAccessor(r).GetValidatorDashboardTotalElDeposits(r.Context(), *dashboardId)
+	q := r.URL.Query()
+	pagingParams := v.checkPagingParams(q)
+	sort := checkSort[enums.NotificationClientsColumn](&v, q.Get("sort"))
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	data, paging, err := h.getDataAccessor(r).GetClientNotifications(r.Context(), userId, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-
-	response := types.GetValidatorDashboardTotalExecutionDepositsResponse{
-		Data: *data,
+	response := types.InternalGetUserNotificationClientsResponse{
+		Data:   data,
+		Paging: *paging,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetValidatorDashboardWithdrawals godoc
+// PublicGetUserNotificationNetworks godoc
 //
-//	@Description	Get withdrawals information for a specified dashboard
-//	@Tags			Validator Dashboard
+//	@Description	Get a list of triggered notifications related to networks.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notifications
 //	@Produce		json
-//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
-//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
-//	@Param			sort			query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(epoch, slot, index, recipient, amount)
-//	@Param			search			query		string	false	"Search for Index, Public Key, Address."
-//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool``."
-//	@Success		200				{object}	types.GetValidatorDashboardWithdrawalsResponse
-//	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/withdrawals [get]
-func (h *HandlerService) PublicGetValidatorDashboardWithdrawals(w http.ResponseWriter, r *http.Request) {
+//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit	query		integer	false	"The maximum number of results that may be returned."
+//	@Param			sort	query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(timestamp, event_type)
+//	@Success		200		{object}	types.InternalGetUserNotificationNetworksResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/networks [get]
+func (h *HandlerService) PublicGetUserNotificationNetworks(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	q := r.URL.Query()
-	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	userId, err := GetUserIdByContext(r)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
+	q := r.URL.Query()
 	pagingParams := v.checkPagingParams(q)
-	sort := checkSort[enums.VDBWithdrawalsColumn](&v, q.Get("sort"))
-	protocolModes := v.checkProtocolModes(q.Get("modes"))
+	sort := checkSort[enums.NotificationNetworksColumn](&v, q.Get("sort"))
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-
-	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardWithdrawals(r.Context(), *dashboardId, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit, protocolModes)
+	data, paging, err := h.getDataAccessor(r).GetNetworkNotifications(r.Context(), userId, pagingParams.cursor, *sort, pagingParams.limit)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.GetValidatorDashboardWithdrawalsResponse{
+	mapNetworkNotificationEventNames(data)
+	response := types.InternalGetUserNotificationNetworksResponse{
 		Data:   data,
 		Paging: *paging,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetValidatorDashboardTotalWithdrawals godoc
+const diffTolerance = 0.0001
+
+// PublicGetUserNotificationPairedDevices godoc
 //
-//	@Description	Get total withdrawals information for a specified dashboard
-//	@Tags			Validator Dashboard
+//	@Description	Get notification settings for the authenticated user. Excludes dashboard notification settings.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notification Settings
 //	@Produce		json
-//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Param			modes			query		string	false	"Provide a comma separated list of protocol modes which should be respected for validator calculations. Possible values are `rocket_pool``."
-//	@Success		200				{object}	types.GetValidatorDashboardTotalWithdrawalsResponse
-//	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/total-withdrawals [get]
-func (h *HandlerService) PublicGetValidatorDashboardTotalWithdrawals(w http.ResponseWriter, r *http.Request) {
-	var v validationError
-	q := r.URL.Query()
-	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+//	@Success		200	{object}	types.InternalGetUserNotificationSettingsResponse
+//	@Router			/users/me/notifications/settings [get]
+func (h *HandlerService) PublicGetUserNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	userId, err := GetUserIdByContext(r)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	pagingParams := v.checkPagingParams(q)
-	protocolModes := v.checkProtocolModes(q.Get("modes"))
-	if v.hasErrors() {
-		handleErr(w, r, v)
+	data, err := h.getDataAccessor(r).GetNotificationSettings(r.Context(), userId)
+	if err != nil {
+		handleErr(w, r, err)
 		return
 	}
 
-	data, err := h.getDataAccessor(r).GetValidatorDashboardTotalWithdrawals(r.Context(), *dashboardId, pagingParams.search, protocolModes)
+	// check premium perks
+	userInfo, err := h.getDataAccessor(r).GetUserInfo(r.Context(), userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	defaultSettings, err := h.getDataAccessor(r).GetNotificationSettingsDefaultValues(r.Context())
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
+	userGeneralSettings := data.GeneralSettings
 
-	response := types.GetValidatorDashboardTotalWithdrawalsResponse{
+	// if users premium perks do not allow custom thresholds, set them to default in the response
+	// TODO: once stripe payments run in v2, this should be removed and the notification settings should be updated upon a tier change instead
+	if !userInfo.PremiumPerks.NotificationsMachineCustomThreshold {
+		if math.Abs(userGeneralSettings.MachineStorageUsageThreshold-defaultSettings.MachineStorageUsageThreshold) > diffTolerance {
+			userGeneralSettings.MachineStorageUsageThreshold = defaultSettings.MachineStorageUsageThreshold
+			userGeneralSettings.IsMachineStorageUsageSubscribed = false
+		}
+		if math.Abs(userGeneralSettings.MachineCpuUsageThreshold-defaultSettings.MachineCpuUsageThreshold) > diffTolerance {
+			userGeneralSettings.MachineCpuUsageThreshold = defaultSettings.MachineCpuUsageThreshold
+			userGeneralSettings.IsMachineCpuUsageSubscribed = false
+		}
+		if math.Abs(userGeneralSettings.MachineMemoryUsageThreshold-defaultSettings.MachineMemoryUsageThreshold) > diffTolerance {
+			userGeneralSettings.MachineMemoryUsageThreshold = defaultSettings.MachineMemoryUsageThreshold
+			userGeneralSettings.IsMachineMemoryUsageSubscribed = false
+		}
+		data.GeneralSettings = userGeneralSettings
+	}
+
+	response := types.InternalGetUserNotificationSettingsResponse{
 		Data: *data,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetValidatorDashboardRocketPool godoc
+// PublicPutUserNotificationSettingsGeneral godoc
 //
-//	@Description	Get an aggregated list of the Rocket Pool nodes details associated with a specified dashboard.
-//	@Tags			Validator Dashboard
+//	@Description	Update general notification settings for the authenticated user, including the delivery policy (digests and quiet hours) for email, push and chat notifications.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notification Settings
+//	@Accept			json
 //	@Produce		json
-//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
-//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
-//	@Param			sort			query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(node, minipools, collateral, rpl, effective_rpl, rpl_apr, smoothing_pool)
-//	@Param			search			query		string	false	"Search for Node address."
-//	@Success		200				{object}	types.GetValidatorDashboardRocketPoolResponse
-//	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/rocket-pool [get]
-func (h *HandlerService) PublicGetValidatorDashboardRocketPool(w http.ResponseWriter, r *http.Request) {
+//	@Param			request	body		types.NotificationSettingsGeneral	true	"Description TODO"
+//	@Success		200		{object}	types.InternalPutUserNotificationSettingsGeneralResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/settings/general [put]
+func (h *HandlerService) PublicPutUserNotificationSettingsGeneral(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	q := r.URL.Query()
-	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	userId, err := GetUserIdByContext(r)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	pagingParams := v.checkPagingParams(q)
-	sort := checkSort[enums.VDBRocketPoolColumn](&v, q.Get("sort"))
+	var req types.NotificationSettingsGeneral
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	checkMinMax(&v, req.MachineStorageUsageThreshold, 0, 1, "machine_storage_usage_threshold")
+	checkMinMax(&v, req.MachineCpuUsageThreshold, 0, 1, "machine_cpu_usage_threshold")
+	checkMinMax(&v, req.MachineMemoryUsageThreshold, 0, 1, "machine_memory_usage_threshold")
+	req.DeliveryMode = v.checkNotificationDeliveryMode(req.DeliveryMode, "delivery_mode")
+	for i := range req.GroupDeliveryModes {
+		req.GroupDeliveryModes[i].DeliveryMode = v.checkNotificationDeliveryMode(req.GroupDeliveryModes[i].DeliveryMode, fmt.Sprintf("group_delivery_modes[%d].delivery_mode", i))
+	}
+	if req.GroupDeliveryModes == nil {
+		req.GroupDeliveryModes = []types.NotificationGroupDeliveryMode{}
+	}
+	if req.IsQuietHoursEnabled {
+		checkMinMax(&v, req.QuietHoursStart, 0, 23, "quiet_hours_start")
+		checkMinMax(&v, req.QuietHoursEnd, 0, 23, "quiet_hours_end")
+	} else {
+		req.QuietHoursStart, req.QuietHoursEnd = 0, 0
+	}
+	req.Timezone = v.checkTimezone(req.Timezone, "timezone")
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
+	req.DoNotDisturbTimestamp = min(req.DoNotDisturbTimestamp, math.MaxInt32)
 
-	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardRocketPool(r.Context(), *dashboardId, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit)
+	// check premium perks
+	userInfo, err := h.getDataAccessor(r).GetUserInfo(r.Context(), userId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.GetValidatorDashboardRocketPoolResponse{
-		Data:   data,
-		Paging: *paging,
-	}
-	returnOk(w, r, response)
-}
-
-// PublicGetValidatorDashboardTotalRocketPool godoc
-//
-//	@Description	Get a summary of all Rocket Pool nodes details associated with a specified dashboard.
-//	@Tags			Validator Dashboard
-//	@Produce		json
-//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Success		200				{object}	types.GetValidatorDashboardTotalRocketPoolResponse
-//	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/total-rocket-pool [get]
-func (h *HandlerService) PublicGetValidatorDashboardTotalRocketPool(w http.ResponseWriter, r *http.Request) {
-	var v validationError
-	q := r.URL.Query()
-	dashboardId, err := h.handleDashboardId(r.Context(), mux.Vars(r)["dashboard_id"])
+	defaultSettings, err := h.getDataAccessor(r).GetNotificationSettingsDefaultValues(r.Context())
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	pagingParams := v.checkPagingParams(q)
-	if v.hasErrors() {
-		handleErr(w, r, v)
+
+	// use tolarance for float comparison
+	isCustomThresholdUsed := math.Abs(req.MachineStorageUsageThreshold-defaultSettings.MachineStorageUsageThreshold) > diffTolerance ||
+		math.Abs(req.MachineCpuUsageThreshold-defaultSettings.MachineCpuUsageThreshold) > diffTolerance ||
+		math.Abs(req.MachineMemoryUsageThreshold-defaultSettings.MachineMemoryUsageThreshold) > diffTolerance
+
+	if !userInfo.PremiumPerks.NotificationsMachineCustomThreshold && isCustomThresholdUsed {
+		returnForbidden(w, r, errors.New("user does not have premium perks to set machine settings thresholds"))
 		return
 	}
 
-	data, err := h.getDataAccessor(r).GetValidatorDashboardTotalRocketPool(r.Context(), *dashboardId, pagingParams.search)
+	err = h.getDataAccessor(r).UpdateNotificationSettingsGeneral(r.Context(), userId, req)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.GetValidatorDashboardTotalRocketPoolResponse{
-		Data: *data,
+	response := types.InternalPutUserNotificationSettingsGeneralResponse{
+		Data: req,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetValidatorDashboardRocketPoolMinipools godoc
+// PublicPutUserNotificationSettingsNetworks godoc
 //
-//	@Description	Get minipools information for a specified Rocket Pool node associated with a specified dashboard.
-//	@Tags			Validator Dashboard
+//	@Description	Update network notification settings for the authenticated user.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notification Settings
+//	@Accept			json
 //	@Produce		json
-//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Param			node_address	path		string	true	"The address of the node."
-//	@Param			cursor			query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
-//	@Param			limit			query		string	false	"The maximum number of results that may be returned."
-//	@Param			sort			query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(group_id)
-//	@Param			search			query		string	false	"Search for Index, Node."
-//	@Success		200				{object}	types.GetValidatorDashboardRocketPoolMinipoolsResponse
-//	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/validator-dashboards/{dashboard_id}/rocket-pool/{node_address}/minipools [get]
-func (h *HandlerService) PublicGetValidatorDashboardRocketPoolMinipools(w http.ResponseWriter, r *http.Request) {
+//	@Param			network	path		string								true	"The networks name or chain ID."
+//	@Param			request	body		handlers.PublicPutUserNotificationSettingsNetworks.request	true	"Description Todo"
+//	@Success		200		{object}	types.InternalPutUserNotificationSettingsNetworksResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/settings/networks/{network} [put]
+func (h *HandlerService) PublicPutUserNotificationSettingsNetworks(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	vars := mux.Vars(r)
-	q := r.URL.Query()
-	dashboardId, err := h.handleDashboardId(r.Context(), vars["dashboard_id"])
+	userId, err := GetUserIdByContext(r)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	// support ENS names ?
-	nodeAddress := v.checkAddress(vars["node_address"])
-	pagingParams := v.checkPagingParams(q)
-	sort := checkSort[enums.VDBRocketPoolMinipoolsColumn](&v, q.Get("sort"))
+	type request struct {
+		IsGasAboveSubscribed          bool    `json:"is_gas_above_subscribed"`
+		GasAboveThreshold             string  `json:"gas_above_threshold"`
+		IsGasBelowSubscribed          bool    `json:"is_gas_below_subscribed"`
+		GasBelowThreshold             string  `json:"gas_below_threshold" `
+		IsParticipationRateSubscribed bool    `json:"is_participation_rate_subscribed"`
+		ParticipationRateThreshold    float64 `json:"participation_rate_threshold"`
+		IsNewRewardRoundSubscribed    bool    `json:"is_new_reward_round_subscribed"`
+	}
+	var req request
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	checkMinMax(&v, req.ParticipationRateThreshold, 0, 1, "participation_rate_threshold")
+	chainId := v.checkNetworkParameter(mux.Vars(r)["network"])
+
+	minWei := decimal.New(1000000, 1)       // 0.001 Gwei
+	maxWei := decimal.New(1000000000000, 1) // 1000 Gwei
+	gasAboveThreshold := v.checkWeiMinMax(req.GasAboveThreshold, "gas_above_threshold", minWei, maxWei)
+	gasBelowThreshold := v.checkWeiMinMax(req.GasBelowThreshold, "gas_below_threshold", minWei, maxWei)
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
+	settings := types.NotificationSettingsNetwork{
+		IsGasAboveSubscribed:          req.IsGasAboveSubscribed,
+		GasAboveThreshold:             gasAboveThreshold,
+		IsGasBelowSubscribed:          req.IsGasBelowSubscribed,
+		GasBelowThreshold:             gasBelowThreshold,
+		IsParticipationRateSubscribed: req.IsParticipationRateSubscribed,
+		ParticipationRateThreshold:    req.ParticipationRateThreshold,
+		IsNewRewardRoundSubscribed:    req.IsNewRewardRoundSubscribed,
+	}
 
-	data, paging, err := h.getDataAccessor(r).GetValidatorDashboardRocketPoolMinipools(r.Context(), *dashboardId, nodeAddress, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit)
+	err = h.getDataAccessor(r).UpdateNotificationSettingsNetworks(r.Context(), userId, chainId, settings)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.GetValidatorDashboardRocketPoolMinipoolsResponse{
-		Data:   data,
-		Paging: *paging,
+	response := types.InternalPutUserNotificationSettingsNetworksResponse{
+		Data: types.NotificationNetwork{
+			ChainId:  chainId,
+			Settings: settings,
+		},
 	}
 	returnOk(w, r, response)
 }
 
-// ----------------------------------------------
-// Notifications
-// ----------------------------------------------
-
-// PublicGetUserNotifications godoc
+// PublicPutUserNotificationSettingsPairedDevices godoc
 //
-//	@Description	Get an overview of your recent notifications.
+//	@Description	Update paired device notification settings for the authenticated user.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Notifications
+//	@Tags			Notification Settings
+//	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	types.InternalGetUserNotificationsResponse
-//	@Router			/users/me/notifications [get]
-func (h *HandlerService) PublicGetUserNotifications(w http.ResponseWriter, r *http.Request) {
+//	@Param			paired_device_id	path		string															true	"The paired device ID."
+//	@Param			request				body		handlers.PublicPutUserNotificationSettingsPairedDevices.request	true	"Description TODO"
+//	@Success		200					{object}	types.InternalPutUserNotificationSettingsPairedDevicesResponse
+//	@Failure		400					{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/settings/paired-devices/{paired_device_id} [put]
+func (h *HandlerService) PublicPutUserNotificationSettingsPairedDevices(w http.ResponseWriter, r *http.Request) {
+	var v validationError
 	userId, err := GetUserIdByContext(r)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	data, err := h.getDataAccessor(r).GetNotificationOverview(r.Context(), userId)
-	if err != nil {
+	type request struct {
+		Name                   string `json:"name,omitempty"`
+		IsNotificationsEnabled bool   `json:"is_notifications_enabled"`
+	}
+	var req request
+	if err := v.checkBody(&req, r); err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.InternalGetUserNotificationsResponse{
-		Data: *data,
+	// TODO use a better way to validate the paired device id
+	pairedDeviceId := v.checkUint(mux.Vars(r)["paired_device_id"], "paired_device_id")
+	name := v.checkNameNotEmpty(req.Name)
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
 	}
-	returnOk(w, r, response)
-}
-
-// PublicGetUserNotificationDashboards godoc
-//
-//	@Description	Get a list of triggered notifications related to your dashboards.
-//	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Notifications
-//	@Produce		json
-//	@Param			networks	query		string	false	"If set, results will be filtered to only include networks given. Provide a comma separated list."
-//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
-//	@Param			limit	query		integer	false	"The maximum number of results that may be returned."
-//	@Param			sort	query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	" Enums(chain_id, timestamp, dashboard_id)
-//	@Param			search	query		string	false	"Search for Dashboard, Group"
-//	@Success		200		{object}	types.InternalGetUserNotificationDashboardsResponse
-//	@Failure		400		{object}	types.ApiErrorResponse
-//	@Router			/users/me/notifications/dashboards [get]
-func (h *HandlerService) PublicGetUserNotificationDashboards(w http.ResponseWriter, r *http.Request) {
-	var v validationError
-	userId, err := GetUserIdByContext(r)
+	pairedDeviceUserId, err := h.getDataAccessor(r).GetPairedDeviceUserId(r.Context(), pairedDeviceId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	q := r.URL.Query()
-	pagingParams := v.checkPagingParams(q)
-	sort := checkSort[enums.NotificationDashboardsColumn](&v, q.Get("sort"))
-	chainIds := v.checkNetworksParameter(q.Get("networks"))
-	if v.hasErrors() {
-		handleErr(w, r, v)
+	if userId != pairedDeviceUserId {
+		returnNotFound(w, r, fmt.Errorf("not found: paired device with id %d not found", pairedDeviceId)) // return 404 to not leak information
 		return
 	}
-
-	data, paging, err := h.getDataAccessor(r).GetDashboardNotifications(r.Context(), userId, chainIds, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit)
+	err = h.getDataAccessor(r).UpdateNotificationSettingsPairedDevice(r.Context(), pairedDeviceId, name, req.IsNotificationsEnabled)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	mapDashboardNotificationEvents(data)
-	response := types.InternalGetUserNotificationDashboardsResponse{
-		Data:   data,
-		Paging: *paging,
+	// TODO timestamp
+	response := types.InternalPutUserNotificationSettingsPairedDevicesResponse{
+		Data: types.NotificationPairedDevice{
+			Id:                     pairedDeviceId,
+			Name:                   req.Name,
+			IsNotificationsEnabled: req.IsNotificationsEnabled,
+		},
 	}
+
 	returnOk(w, r, response)
 }
 
-// PublicGetUserNotificationValidators godoc
+// PublicDeleteUserNotificationSettingsPairedDevices godoc
 //
-//	@Description	Get a detailed view of a triggered notification related to a validator dashboard group at a specific epoch.
+//	@Description	Delete paired device notification settings for the authenticated user.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Notifications
+//	@Tags			Notification Settings
 //	@Produce		json
-//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Param			group_id		path		integer	true	"The ID of the group."
-//	@Param			epoch			path		integer	true	"The epoch of the notification."
-//	@Param			search			query		string	false	"Search for Index"
-//	@Success		200				{object}	types.InternalGetUserNotificationsValidatorDashboardResponse
-//	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/users/me/notifications/validator-dashboards/{dashboard_id}/groups/{group_id}/epochs/{epoch} [get]
-func (h *HandlerService) PublicGetUserNotificationsValidatorDashboard(w http.ResponseWriter, r *http.Request) {
+//	@Param			paired_device_id	path	string	true	"The paired device ID."
+//	@Success		204
+//	@Failure		400	{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/settings/paired-devices/{paired_device_id} [delete]
+func (h *HandlerService) PublicDeleteUserNotificationSettingsPairedDevices(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	vars := mux.Vars(r)
-	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
-	groupId := v.checkExistingGroupId(vars["group_id"])
-	epoch := v.checkUint(vars["epoch"], "epoch")
-	search := r.URL.Query().Get("search")
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	pairedDeviceId := v.checkUint(mux.Vars(r)["paired_device_id"], "paired_device_id")
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	data, err := h.getDataAccessor(r).GetValidatorDashboardNotificationDetails(r.Context(), dashboardId, groupId, epoch, search)
+	pairedDeviceUserId, err := h.getDataAccessor(r).GetPairedDeviceUserId(r.Context(), pairedDeviceId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.InternalGetUserNotificationsValidatorDashboardResponse{
-		Data: *data,
+	if userId != pairedDeviceUserId {
+		returnNotFound(w, r, fmt.Errorf("not found: paired device with id %d not found", pairedDeviceId)) // return 404 to not leak information
+		return
 	}
-	returnOk(w, r, response)
+	err = h.getDataAccessor(r).DeleteNotificationSettingsPairedDevice(r.Context(), pairedDeviceId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	returnNoContent(w, r)
 }
 
-// PublicGetUserNotificationsAccountDashboard godoc
+// PublicPutUserNotificationSettingsClient godoc
 //
-//	@Description	Get a detailed view of a triggered notification related to an account dashboard group at a specific epoch.
+//	@Description	Update client notification settings for the authenticated user. When a client is subscribed, notifications will be sent when a new version is available.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Notifications
+//	@Tags			Notification Settings
+//	@Accept			json
 //	@Produce		json
-//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
-//	@Param			group_id		path		integer	true	"The ID of the group."
-//	@Param			epoch			path		integer	true	"The epoch of the notification."
-//	@Param			search			query		string	false	"Search for Address, ENS"
-//	@Success		200				{object}	types.InternalGetUserNotificationsAccountDashboardResponse
-//	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/users/me/notifications/account-dashboards/{dashboard_id}/groups/{group_id}/epochs/{epoch} [get]
-func (h *HandlerService) PublicGetUserNotificationsAccountDashboard(w http.ResponseWriter, r *http.Request) {
+//	@Param			client_id	path		integer														true	"The ID of the client."
+//	@Param			request		body		handlers.PublicPutUserNotificationSettingsClient.request	true	"`is_subscribed`: Set to `true` to subscribe to notifications; set to `false` to unsubscribe."
+//	@Success		200			{object}	types.InternalPutUserNotificationSettingsClientResponse
+//	@Failure		400			{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/settings/clients/{client_id} [put]
+func (h *HandlerService) PublicPutUserNotificationSettingsClient(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	vars := mux.Vars(r)
-	dashboardId := v.checkUint(vars["dashboard_id"], "dashboard_id")
-	groupId := v.checkExistingGroupId(vars["group_id"])
-	epoch := v.checkUint(vars["epoch"], "epoch")
-	search := r.URL.Query().Get("search")
+	userId, err := GetUserIdByContext(r)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	type request struct {
+		IsSubscribed bool `json:"is_subscribed"`
+	}
+	var req request
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	clientId := v.checkUint(mux.Vars(r)["client_id"], "client_id")
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	data, err := h.getDataAccessor(r).GetAccountDashboardNotificationDetails(r.Context(), dashboardId, groupId, epoch, search)
+	data, err := h.getDataAccessor(r).UpdateNotificationSettingsClients(r.Context(), userId, clientId, req.IsSubscribed)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.InternalGetUserNotificationsAccountDashboardResponse{
+	response := types.InternalPutUserNotificationSettingsClientResponse{
 		Data: *data,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetUserNotificationMachines godoc
+// PublicGetUserNotificationSettingsDashboards godoc
 //
-//	@Description	Get a list of triggered notifications related to your machines.
+//	@Description	Get a list of notification settings for the dashboards of the authenticated user.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Notifications
+//	@Tags			Notification Settings
 //	@Produce		json
 //	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
 //	@Param			limit	query		integer	false	"The maximum number of results that may be returned."
-//	@Param			sort	query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(machine_name, threshold, event_type, timestamp)
-//	@Param			search	query		string	false	"Search for Machine"
-//	@Success		200		{object}	types.InternalGetUserNotificationMachinesResponse
+//	@Param			sort	query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums	(dashboard_id, group_name)
+//	@Param			search	query		string	false	"Search for Dashboard, Group"
+//	@Success		200		{object}	types.InternalGetUserNotificationSettingsDashboardsResponse
 //	@Failure		400		{object}	types.ApiErrorResponse
-//	@Router			/users/me/notifications/machines [get]
-func (h *HandlerService) PublicGetUserNotificationMachines(w http.ResponseWriter, r *http.Request) {
+//	@Router			/users/me/notifications/settings/dashboards [get]
+func (h *HandlerService) PublicGetUserNotificationSettingsDashboards(w http.ResponseWriter, r *http.Request) {
 	var v validationError
 	userId, err := GetUserIdByContext(r)
 	if err != nil {
@@ -2031,239 +3348,324 @@ func (h *HandlerService) PublicGetUserNotificationMachines(w http.ResponseWriter
 	}
 	q := r.URL.Query()
 	pagingParams := v.checkPagingParams(q)
-	sort := checkSort[enums.NotificationMachinesColumn](&v, q.Get("sort"))
+	sort := checkSort[enums.NotificationSettingsDashboardColumn](&v, q.Get("sort"))
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	data, paging, err := h.getDataAccessor(r).GetMachineNotifications(r.Context(), userId, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit)
+	data, paging, err := h.getDataAccessor(r).GetNotificationSettingsDashboards(r.Context(), userId, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	mapMachineNotificationEventNames(data)
-	response := types.InternalGetUserNotificationMachinesResponse{
+	// if users premium perks do not allow subscriptions, set them to false in the response
+	// TODO: once stripe payments run in v2, this should be removed and the notification settings should be updated upon a tier change instead
+	userInfo, err := h.getDataAccessor(r).GetUserInfo(r.Context(), userId)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	defaultSettings, err := h.getDataAccessor(r).GetNotificationSettingsDefaultValues(r.Context())
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	for i, dashboard := range data {
+		if dashboard.IsAccountDashboard {
+			continue
+		}
+		settings, ok := dashboard.Settings.(types.NotificationSettingsValidatorDashboard)
+		if !ok {
+			handleErr(w, r, errors.New("invalid settings type"))
+			return
+		}
+		if !userInfo.PremiumPerks.NotificationsValidatorDashboardGroupEfficiency && settings.IsGroupEfficiencyBelowSubscribed {
+			settings.IsGroupEfficiencyBelowSubscribed = false
+			settings.GroupEfficiencyBelowThreshold = defaultSettings.GroupEfficiencyBelowThreshold
+		}
+		data[i].Settings = settings
+	}
+	response := types.InternalGetUserNotificationSettingsDashboardsResponse{
 		Data:   data,
 		Paging: *paging,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetUserNotificationClients godoc
+// PublicPutUserNotificationSettingsValidatorDashboard godoc
 //
-//	@Description	Get a list of triggered notifications related to your clients.
+//	@Description	Update the notification settings for a specific group of a validator dashboard for the authenticated user.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Notifications
+//	@Tags			Notification Settings
+//	@Accept			json
 //	@Produce		json
-//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
-//	@Param			limit	query		integer	false	"The maximum number of results that may be returned."
-//	@Param			sort	query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(client_name, timestamp)
-//	@Param			search	query		string	false	"Search for Client"
-//	@Success		200		{object}	types.InternalGetUserNotificationClientsResponse
-//	@Failure		400		{object}	types.ApiErrorResponse
-//	@Router			/users/me/notifications/clients [get]
-func (h *HandlerService) PublicGetUserNotificationClients(w http.ResponseWriter, r *http.Request) {
+//	@Param			dashboard_id	path		string											true	"The ID of the dashboard."
+//	@Param			group_id		path		integer											true	"The ID of the group."
+//	@Param			request			body		types.NotificationSettingsValidatorDashboard	true	"Notification settings"
+//	@Success		200				{object}	types.InternalPutUserNotificationSettingsValidatorDashboardResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/settings/validator-dashboards/{dashboard_id}/groups/{group_id} [put]
+func (h *HandlerService) PublicPutUserNotificationSettingsValidatorDashboard(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	userId, err := GetUserIdByContext(r)
+	// settings of organization dashboards belong to the dashboard creator, who receives the notifications
+	userId, err := getDashboardNotificationUserId(r)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	q := r.URL.Query()
-	pagingParams := v.checkPagingParams(q)
-	sort := checkSort[enums.NotificationClientsColumn](&v, q.Get("sort"))
+
+	var req types.NotificationSettingsValidatorDashboard
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	checkMinMax(&v, req.GroupEfficiencyBelowThreshold, 0, 1, "group_offline_threshold")
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
+
+	checkMinMax(&v, req.MaxCollateralThreshold, 0, 1, "max_collateral_threshold")
+	checkMinMax(&v, req.MinCollateralThreshold, 0, 1, "min_collateral_threshold")
+	if req.IsPoolOperationNotIncludedSubscribed {
+		checkMinMax(&v, req.PoolOperationNotIncludedThreshold, 1, 100, "pool_operation_not_included_threshold")
+	}
+	req.SlackWebhookUrl = v.checkSlackWebhookUrl(req.SlackWebhookUrl, allowEmpty)
+	req.MatrixRoomId = v.checkMatrixRoomId(req.MatrixRoomId, allowEmpty)
+	if req.IncomeReportCurrency != "" {
+		req.IncomeReportCurrency = strings.ToUpper(checkEnum[enums.Currency](&v, req.IncomeReportCurrency, "income_report_currency").Code())
+	}
+	if req.IncomeReportFormat != "" {
+		checkEnum[enums.IncomeReportFormat](&v, req.IncomeReportFormat, "income_report_format")
+	}
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	data, paging, err := h.getDataAccessor(r).GetClientNotifications(r.Context(), userId, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit)
+	// the signing secret can only be changed via the rotation endpoint
+	req.WebhookSigningSecret = ""
+	userInfo, err := h.getDataAccessor(r).GetUserInfo(r.Context(), userId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.InternalGetUserNotificationClientsResponse{
-		Data:   data,
-		Paging: *paging,
+	if !userInfo.PremiumPerks.NotificationsValidatorDashboardGroupEfficiency && req.IsGroupEfficiencyBelowSubscribed {
+		returnForbidden(w, r, errors.New("user does not have premium perks to subscribe group efficiency event"))
+		return
+	}
+	if req.IsTelegramEnabled {
+		isTelegramLinked, err := h.getDataAccessor(r).GetTelegramChatLinked(r.Context(), userId)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		if !isTelegramLinked {
+			returnConflict(w, r, errors.New("no telegram chat linked, link a chat before enabling telegram notifications"))
+			return
+		}
+	}
+
+	err = h.getDataAccessor(r).UpdateNotificationSettingsValidatorDashboard(r.Context(), userId, dashboardId, groupId, req)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalPutUserNotificationSettingsValidatorDashboardResponse{
+		Data: req,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetUserNotificationNetworks godoc
+// PublicPutUserNotificationSettingsAccountDashboard godoc
 //
-//	@Description	Get a list of triggered notifications related to networks.
+//	@Description	Update the notification settings for a specific group of an account dashboard for the authenticated user.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Notifications
+//	@Tags			Notification Settings
+//	@Accept			json
 //	@Produce		json
-//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
-//	@Param			limit	query		integer	false	"The maximum number of results that may be returned."
-//	@Param			sort	query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums(timestamp, event_type)
-//	@Success		200		{object}	types.InternalGetUserNotificationNetworksResponse
-//	@Failure		400		{object}	types.ApiErrorResponse
-//	@Router			/users/me/notifications/networks [get]
-func (h *HandlerService) PublicGetUserNotificationNetworks(w http.ResponseWriter, r *http.Request) {
+//	@Param			dashboard_id	path		string																true	"The ID of the dashboard."
+//	@Param			group_id		path		integer																true	"The ID of the group."
+//	@Param			request			body		handlers.PublicPutUserNotificationSettingsAccountDashboard.request	true	"Notification settings"
+//	@Success		200				{object}	types.InternalPutUserNotificationSettingsAccountDashboardResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/settings/account-dashboards/{dashboard_id}/groups/{group_id} [put]
+func (h *HandlerService) PublicPutUserNotificationSettingsAccountDashboard(w http.ResponseWriter, r *http.Request) {
 	var v validationError
 	userId, err := GetUserIdByContext(r)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	q := r.URL.Query()
-	pagingParams := v.checkPagingParams(q)
-	sort := checkSort[enums.NotificationNetworksColumn](&v, q.Get("sort"))
+
+	// uses a different struct due to `subscribed_chain_ids`, which is a slice of intOrString in the payload but a slice of uint64 in the response
+	type request struct {
+		WebhookUrl                      string        `json:"webhook_url"`
+		IsWebhookDiscordEnabled         bool          `json:"is_webhook_discord_enabled"`
+		IsIgnoreSpamTransactionsEnabled bool          `json:"is_ignore_spam_transactions_enabled"`
+		SubscribedChainIds              []intOrString `json:"subscribed_chain_ids"`
+
+		IsIncomingTransactionsSubscribed  bool    `json:"is_incoming_transactions_subscribed"`
+		IsOutgoingTransactionsSubscribed  bool    `json:"is_outgoing_transactions_subscribed"`
+		IsERC20TokenTransfersSubscribed   bool    `json:"is_erc20_token_transfers_subscribed"`
+		ERC20TokenTransfersValueThreshold float64 `json:"erc20_token_transfers_value_threshold"` // 0 does not disable, is_erc20_token_transfers_subscribed determines if it's enabled
+		IsERC721TokenTransfersSubscribed  bool    `json:"is_erc721_token_transfers_subscribed"`
+		IsERC1155TokenTransfersSubscribed bool    `json:"is_erc1155_token_transfers_subscribed"`
+	}
+	var req request
+	if err := v.checkBody(&req, r); err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	chainIds := v.checkNetworkSlice(req.SubscribedChainIds)
+	checkMinMax(&v, req.ERC20TokenTransfersValueThreshold, 0, math.MaxFloat64, "erc20_token_transfers_value_threshold")
+	vars := mux.Vars(r)
+	dashboardId := v.checkAccountDashboardId(vars["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	data, paging, err := h.getDataAccessor(r).GetNetworkNotifications(r.Context(), userId, pagingParams.cursor, *sort, pagingParams.limit)
+	settings := types.NotificationSettingsAccountDashboard{
+		WebhookUrl:                      req.WebhookUrl,
+		IsWebhookDiscordEnabled:         req.IsWebhookDiscordEnabled,
+		IsIgnoreSpamTransactionsEnabled: req.IsIgnoreSpamTransactionsEnabled,
+		SubscribedChainIds:              chainIds,
+
+		IsIncomingTransactionsSubscribed:  req.IsIncomingTransactionsSubscribed,
+		IsOutgoingTransactionsSubscribed:  req.IsOutgoingTransactionsSubscribed,
+		IsERC20TokenTransfersSubscribed:   req.IsERC20TokenTransfersSubscribed,
+		ERC20TokenTransfersValueThreshold: req.ERC20TokenTransfersValueThreshold,
+		IsERC721TokenTransfersSubscribed:  req.IsERC721TokenTransfersSubscribed,
+		IsERC1155TokenTransfersSubscribed: req.IsERC1155TokenTransfersSubscribed,
+	}
+	err = h.getDataAccessor(r).UpdateNotificationSettingsAccountDashboard(r.Context(), userId, dashboardId, groupId, settings)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	mapNetworkNotificationEventNames(data)
-	response := types.InternalGetUserNotificationNetworksResponse{
-		Data:   data,
-		Paging: *paging,
+	response := types.InternalPutUserNotificationSettingsAccountDashboardResponse{
+		Data: settings,
 	}
 	returnOk(w, r, response)
 }
 
-const diffTolerance = 0.0001
-
-// PublicGetUserNotificationPairedDevices godoc
+// PublicPostUserNotificationsTestEmail godoc
 //
-//	@Description	Get notification settings for the authenticated user. Excludes dashboard notification settings.
+//	@Description	Send a test email notification to the authenticated user.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
 //	@Tags			Notification Settings
 //	@Produce		json
-//	@Success		200	{object}	types.InternalGetUserNotificationSettingsResponse
-//	@Router			/users/me/notifications/settings [get]
-func (h *HandlerService) PublicGetUserNotificationSettings(w http.ResponseWriter, r *http.Request) {
+//	@Success		204
+//	@Router			/users/me/notifications/test-email [post]
+func (h *HandlerService) PublicPostUserNotificationsTestEmail(w http.ResponseWriter, r *http.Request) {
 	userId, err := GetUserIdByContext(r)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	data, err := h.getDataAccessor(r).GetNotificationSettings(r.Context(), userId)
+	err = h.getDataAccessor(r).QueueTestEmailNotification(r.Context(), userId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
+	returnNoContent(w, r)
+}
 
-	// check premium perks
-	userInfo, err := h.getDataAccessor(r).GetUserInfo(r.Context(), userId)
+// PublicPostUserNotificationsTestPush godoc
+//
+//	@Description	Send a test push notification to the authenticated user.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notification Settings
+//	@Produce		json
+//	@Success		204
+//	@Router			/users/me/notifications/test-push [post]
+func (h *HandlerService) PublicPostUserNotificationsTestPush(w http.ResponseWriter, r *http.Request) {
+	userId, err := GetUserIdByContext(r)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	defaultSettings, err := h.getDataAccessor(r).GetNotificationSettingsDefaultValues(r.Context())
+	err = h.getDataAccessor(r).QueueTestPushNotification(r.Context(), userId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	userGeneralSettings := data.GeneralSettings
-
-	// if users premium perks do not allow custom thresholds, set them to default in the response
-	// TODO: once stripe payments run in v2, this should be removed and the notification settings should be updated upon a tier change instead
-	if !userInfo.PremiumPerks.NotificationsMachineCustomThreshold {
-		if math.Abs(userGeneralSettings.MachineStorageUsageThreshold-defaultSettings.MachineStorageUsageThreshold) > diffTolerance {
-			userGeneralSettings.MachineStorageUsageThreshold = defaultSettings.MachineStorageUsageThreshold
-			userGeneralSettings.IsMachineStorageUsageSubscribed = false
-		}
-		if math.Abs(userGeneralSettings.MachineCpuUsageThreshold-defaultSettings.MachineCpuUsageThreshold) > diffTolerance {
-			userGeneralSettings.MachineCpuUsageThreshold = defaultSettings.MachineCpuUsageThreshold
-			userGeneralSettings.IsMachineCpuUsageSubscribed = false
-		}
-		if math.Abs(userGeneralSettings.MachineMemoryUsageThreshold-defaultSettings.MachineMemoryUsageThreshold) > diffTolerance {
-			userGeneralSettings.MachineMemoryUsageThreshold = defaultSettings.MachineMemoryUsageThreshold
-			userGeneralSettings.IsMachineMemoryUsageSubscribed = false
-		}
-		data.GeneralSettings = userGeneralSettings
-	}
-
-	response := types.InternalGetUserNotificationSettingsResponse{
-		Data: *data,
-	}
-	returnOk(w, r, response)
+	returnNoContent(w, r)
 }
 
-// PublicPutUserNotificationSettingsGeneral godoc
+// PublicPostUserNotificationsTestWebhook godoc
 //
-//	@Description	Update general notification settings for the authenticated user.
+//	@Description	Send a test webhook notification from the authenticated user to the given URL.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
 //	@Tags			Notification Settings
 //	@Accept			json
 //	@Produce		json
-//	@Param			request	body		types.NotificationSettingsGeneral	true	"Description TODO"
-//	@Success		200		{object}	types.InternalPutUserNotificationSettingsGeneralResponse
-//	@Failure		400		{object}	types.ApiErrorResponse
-//	@Router			/users/me/notifications/settings/general [put]
-func (h *HandlerService) PublicPutUserNotificationSettingsGeneral(w http.ResponseWriter, r *http.Request) {
+//	@Param			request	body	handlers.PublicPostUserNotificationsTestWebhook.request	true	"Request"
+//	@Success		204
+//	@Failure		400	{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/test-webhook [post]
+func (h *HandlerService) PublicPostUserNotificationsTestWebhook(w http.ResponseWriter, r *http.Request) {
 	var v validationError
 	userId, err := GetUserIdByContext(r)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	var req types.NotificationSettingsGeneral
+	type request struct {
+		WebhookUrl              string `json:"webhook_url"`
+		IsWebhookDiscordEnabled bool   `json:"is_webhook_discord_enabled,omitempty"`
+	}
+	var req request
 	if err := v.checkBody(&req, r); err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	checkMinMax(&v, req.MachineStorageUsageThreshold, 0, 1, "machine_storage_usage_threshold")
-	checkMinMax(&v, req.MachineCpuUsageThreshold, 0, 1, "machine_cpu_usage_threshold")
-	checkMinMax(&v, req.MachineMemoryUsageThreshold, 0, 1, "machine_memory_usage_threshold")
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	req.DoNotDisturbTimestamp = min(req.DoNotDisturbTimestamp, math.MaxInt32)
-
-	// check premium perks
-	userInfo, err := h.getDataAccessor(r).GetUserInfo(r.Context(), userId)
+	err = h.getDataAccessor(r).QueueTestWebhookNotification(r.Context(), userId, req.WebhookUrl, req.IsWebhookDiscordEnabled)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	defaultSettings, err := h.getDataAccessor(r).GetNotificationSettingsDefaultValues(r.Context())
+	returnNoContent(w, r)
+}
+
+// PublicPostUserNotificationsTestTelegram godoc
+//
+//	@Description	Send a test telegram notification to the telegram chat linked by the authenticated user.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notification Settings
+//	@Produce		json
+//	@Success		204
+//	@Failure		404	{object}	types.ApiErrorResponse	"No telegram chat has been linked."
+//	@Router			/users/me/notifications/test-telegram [post]
+func (h *HandlerService) PublicPostUserNotificationsTestTelegram(w http.ResponseWriter, r *http.Request) {
+	userId, err := GetUserIdByContext(r)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-
-	// use tolarance for float comparison
-	isCustomThresholdUsed := math.Abs(req.MachineStorageUsageThreshold-defaultSettings.MachineStorageUsageThreshold) > diffTolerance ||
-		math.Abs(req.MachineCpuUsageThreshold-defaultSettings.MachineCpuUsageThreshold) > diffTolerance ||
-		math.Abs(req.MachineMemoryUsageThreshold-defaultSettings.MachineMemoryUsageThreshold) > diffTolerance
-
-	if !userInfo.PremiumPerks.NotificationsMachineCustomThreshold && isCustomThresholdUsed {
-		returnForbidden(w, r, errors.New("user does not have premium perks to set machine settings thresholds"))
-		return
-	}
-
-	err = h.getDataAccessor(r).UpdateNotificationSettingsGeneral(r.Context(), userId, req)
+	err = h.getDataAccessor(r).QueueTestTelegramNotification(r.Context(), userId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.InternalPutUserNotificationSettingsGeneralResponse{
-		Data: req,
-	}
-	returnOk(w, r, response)
+	returnNoContent(w, r)
 }
 
-// PublicPutUserNotificationSettingsNetworks godoc
+// PublicPostUserNotificationsTestSlack godoc
 //
-//	@Description	Update network notification settings for the authenticated user.
+//	@Description	Send a test slack notification from the authenticated user to the given slack incoming webhook URL.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
 //	@Tags			Notification Settings
 //	@Accept			json
 //	@Produce		json
-//	@Param			network	path		string								true	"The networks name or chain ID."
-//	@Param			request	body		handlers.PublicPutUserNotificationSettingsNetworks.request	true	"Description Todo"
-//	@Success		200		{object}	types.InternalPutUserNotificationSettingsNetworksResponse
-//	@Failure		400		{object}	types.ApiErrorResponse
-//	@Router			/users/me/notifications/settings/networks/{network} [put]
-func (h *HandlerService) PublicPutUserNotificationSettingsNetworks(w http.ResponseWriter, r *http.Request) {
+//	@Param			request	body	handlers.PublicPostUserNotificationsTestSlack.request	true	"Request"
+//	@Success		204
+//	@Failure		400	{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/test-slack [post]
+func (h *HandlerService) PublicPostUserNotificationsTestSlack(w http.ResponseWriter, r *http.Request) {
 	var v validationError
 	userId, err := GetUserIdByContext(r)
 	if err != nil {
@@ -2271,67 +3673,38 @@ func (h *HandlerService) PublicPutUserNotificationSettingsNetworks(w http.Respon
 		return
 	}
 	type request struct {
-		IsGasAboveSubscribed          bool    `json:"is_gas_above_subscribed"`
-		GasAboveThreshold             string  `json:"gas_above_threshold"`
-		IsGasBelowSubscribed          bool    `json:"is_gas_below_subscribed"`
-		GasBelowThreshold             string  `json:"gas_below_threshold" `
-		IsParticipationRateSubscribed bool    `json:"is_participation_rate_subscribed"`
-		ParticipationRateThreshold    float64 `json:"participation_rate_threshold"`
-		IsNewRewardRoundSubscribed    bool    `json:"is_new_reward_round_subscribed"`
+		WebhookUrl string `json:"webhook_url"`
 	}
 	var req request
 	if err := v.checkBody(&req, r); err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	checkMinMax(&v, req.ParticipationRateThreshold, 0, 1, "participation_rate_threshold")
-	chainId := v.checkNetworkParameter(mux.Vars(r)["network"])
-
-	minWei := decimal.New(1000000, 1)       // 0.001 Gwei
-	maxWei := decimal.New(1000000000000, 1) // 1000 Gwei
-	gasAboveThreshold := v.checkWeiMinMax(req.GasAboveThreshold, "gas_above_threshold", minWei, maxWei)
-	gasBelowThreshold := v.checkWeiMinMax(req.GasBelowThreshold, "gas_below_threshold", minWei, maxWei)
+	webhookUrl := v.checkSlackWebhookUrl(req.WebhookUrl, false)
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	settings := types.NotificationSettingsNetwork{
-		IsGasAboveSubscribed:          req.IsGasAboveSubscribed,
-		GasAboveThreshold:             gasAboveThreshold,
-		IsGasBelowSubscribed:          req.IsGasBelowSubscribed,
-		GasBelowThreshold:             gasBelowThreshold,
-		IsParticipationRateSubscribed: req.IsParticipationRateSubscribed,
-		ParticipationRateThreshold:    req.ParticipationRateThreshold,
-		IsNewRewardRoundSubscribed:    req.IsNewRewardRoundSubscribed,
-	}
-
-	err = h.getDataAccessor(r).UpdateNotificationSettingsNetworks(r.Context(), userId, chainId, settings)
+	err = h.getDataAccessor(r).QueueTestSlackNotification(r.Context(), userId, webhookUrl)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.InternalPutUserNotificationSettingsNetworksResponse{
-		Data: types.NotificationNetwork{
-			ChainId:  chainId,
-			Settings: settings,
-		},
-	}
-	returnOk(w, r, response)
+	returnNoContent(w, r)
 }
 
-// PublicPutUserNotificationSettingsPairedDevices godoc
+// PublicPostUserNotificationsTestMatrix godoc
 //
-//	@Description	Update paired device notification settings for the authenticated user.
+//	@Description	Send a test matrix notification from the authenticated user to the given matrix room. The room has to invite the beaconcha.in matrix user first.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
 //	@Tags			Notification Settings
 //	@Accept			json
 //	@Produce		json
-//	@Param			paired_device_id	path		string															true	"The paired device ID."
-//	@Param			request				body		handlers.PublicPutUserNotificationSettingsPairedDevices.request	true	"Description TODO"
-//	@Success		200					{object}	types.InternalPutUserNotificationSettingsPairedDevicesResponse
-//	@Failure		400					{object}	types.ApiErrorResponse
-//	@Router			/users/me/notifications/settings/paired-devices/{paired_device_id} [put]
-func (h *HandlerService) PublicPutUserNotificationSettingsPairedDevices(w http.ResponseWriter, r *http.Request) {
+//	@Param			request	body	handlers.PublicPostUserNotificationsTestMatrix.request	true	"Request"
+//	@Success		204
+//	@Failure		400	{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/test-matrix [post]
+func (h *HandlerService) PublicPostUserNotificationsTestMatrix(w http.ResponseWriter, r *http.Request) {
 	var v validationError
 	userId, err := GetUserIdByContext(r)
 	if err != nil {
@@ -2339,143 +3712,136 @@ func (h *HandlerService) PublicPutUserNotificationSettingsPairedDevices(w http.R
 		return
 	}
 	type request struct {
-		Name                   string `json:"name,omitempty"`
-		IsNotificationsEnabled bool   `json:"is_notifications_enabled"`
+		RoomId string `json:"room_id"`
 	}
 	var req request
 	if err := v.checkBody(&req, r); err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	// TODO use a better way to validate the paired device id
-	pairedDeviceId := v.checkUint(mux.Vars(r)["paired_device_id"], "paired_device_id")
-	name := v.checkNameNotEmpty(req.Name)
+	roomId := v.checkMatrixRoomId(req.RoomId, false)
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	pairedDeviceUserId, err := h.getDataAccessor(r).GetPairedDeviceUserId(r.Context(), pairedDeviceId)
-	if err != nil {
-		handleErr(w, r, err)
-		return
-	}
-	if userId != pairedDeviceUserId {
-		returnNotFound(w, r, fmt.Errorf("not found: paired device with id %d not found", pairedDeviceId)) // return 404 to not leak information
-		return
-	}
-	err = h.getDataAccessor(r).UpdateNotificationSettingsPairedDevice(r.Context(), pairedDeviceId, name, req.IsNotificationsEnabled)
+	err = h.getDataAccessor(r).QueueTestMatrixNotification(r.Context(), userId, roomId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	// TODO timestamp
-	response := types.InternalPutUserNotificationSettingsPairedDevicesResponse{
-		Data: types.NotificationPairedDevice{
-			Id:                     pairedDeviceId,
-			Name:                   req.Name,
-			IsNotificationsEnabled: req.IsNotificationsEnabled,
-		},
-	}
-
-	returnOk(w, r, response)
+	returnNoContent(w, r)
 }
 
-// PublicDeleteUserNotificationSettingsPairedDevices godoc
+const telegramLinkTokenExpiry = time.Minute * 15
+
+// PublicPostUserNotificationsTelegramLink godoc
 //
-//	@Description	Delete paired device notification settings for the authenticated user.
+//	@Description	Create a link to the beaconcha.in telegram bot. Starting the bot with this link links the telegram chat to the authenticated user.
+//	@Description	The link expires after 15 minutes, creating a new link invalidates the previous one.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
 //	@Tags			Notification Settings
 //	@Produce		json
-//	@Param			paired_device_id	path	string	true	"The paired device ID."
-//	@Success		204
-//	@Failure		400	{object}	types.ApiErrorResponse
-//	@Router			/users/me/notifications/settings/paired-devices/{paired_device_id} [delete]
-func (h *HandlerService) PublicDeleteUserNotificationSettingsPairedDevices(w http.ResponseWriter, r *http.Request) {
-	var v validationError
+//	@Success		200	{object}	types.InternalPostUserNotificationsTelegramLinkResponse
+//	@Router			/users/me/notifications/telegram-link [post]
+func (h *HandlerService) PublicPostUserNotificationsTelegramLink(w http.ResponseWriter, r *http.Request) {
 	userId, err := GetUserIdByContext(r)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	pairedDeviceId := v.checkUint(mux.Vars(r)["paired_device_id"], "paired_device_id")
-	if v.hasErrors() {
-		handleErr(w, r, v)
+	if utils.Config.Notifications.TelegramBotName == "" {
+		handleErr(w, r, newNotFoundErr("telegram notifications are not available"))
 		return
 	}
-	pairedDeviceUserId, err := h.getDataAccessor(r).GetPairedDeviceUserId(r.Context(), pairedDeviceId)
+	token, err := utils.GenerateRandomBytesSecure(16)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	if userId != pairedDeviceUserId {
-		returnNotFound(w, r, fmt.Errorf("not found: paired device with id %d not found", pairedDeviceId)) // return 404 to not leak information
-		return
-	}
-	err = h.getDataAccessor(r).DeleteNotificationSettingsPairedDevice(r.Context(), pairedDeviceId)
+	linkToken := hex.EncodeToString(token)
+	expires := time.Now().Add(telegramLinkTokenExpiry)
+	err = h.getDataAccessor(r).CreateTelegramLinkToken(r.Context(), userId, linkToken, expires)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	returnNoContent(w, r)
+	response := types.InternalPostUserNotificationsTelegramLinkResponse{
+		Data: types.NotificationTelegramLink{
+			LinkUrl:          fmt.Sprintf("https://t.me/%s?start=%s", utils.Config.Notifications.TelegramBotName, linkToken),
+			ExpiresTimestamp: expires.Unix(),
+		},
+	}
+	returnOk(w, r, response)
 }
 
-// PublicPutUserNotificationSettingsClient godoc
+// PublicDeleteUserNotificationsTelegramLink godoc
 //
-//	@Description	Update client notification settings for the authenticated user. When a client is subscribed, notifications will be sent when a new version is available.
+//	@Description	Unlink the telegram chat of the authenticated user. Telegram notifications are disabled for all dashboard groups.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
 //	@Tags			Notification Settings
-//	@Accept			json
-//	@Produce		json
-//	@Param			client_id	path		integer														true	"The ID of the client."
-//	@Param			request		body		handlers.PublicPutUserNotificationSettingsClient.request	true	"`is_subscribed`: Set to `true` to subscribe to notifications; set to `false` to unsubscribe."
-//	@Success		200			{object}	types.InternalPutUserNotificationSettingsClientResponse
-//	@Failure		400			{object}	types.ApiErrorResponse
-//	@Router			/users/me/notifications/settings/clients/{client_id} [put]
-func (h *HandlerService) PublicPutUserNotificationSettingsClient(w http.ResponseWriter, r *http.Request) {
-	var v validationError
+//	@Success		204
+//	@Router			/users/me/notifications/telegram-link [delete]
+func (h *HandlerService) PublicDeleteUserNotificationsTelegramLink(w http.ResponseWriter, r *http.Request) {
 	userId, err := GetUserIdByContext(r)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	type request struct {
-		IsSubscribed bool `json:"is_subscribed"`
-	}
-	var req request
-	if err := v.checkBody(&req, r); err != nil {
+	err = h.getDataAccessor(r).DeleteTelegramChat(r.Context(), userId)
+	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	clientId := v.checkUint(mux.Vars(r)["client_id"], "client_id")
+	returnNoContent(w, r)
+}
+
+// PublicPostUserNotificationSettingsValidatorDashboardWebhookSecret godoc
+//
+//	@Description	Generate a new signing secret for the webhook of a specific group of a validator dashboard. The previous secret is invalidated immediately.
+//	@Description	Webhook deliveries carry a `X-Signature` header of the form `t=<unix timestamp>,v1=<signature>` where the signature is the hex encoded HMAC-SHA256 of `<unix timestamp>.<request body>` keyed with this secret, and a `X-Webhook-Id` header that stays the same across retries of a delivery.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notification Settings
+//	@Produce		json
+//	@Param			dashboard_id	path		string	true	"The ID of the dashboard."
+//	@Param			group_id		path		integer	true	"The ID of the group."
+//	@Success		200				{object}	types.InternalPostUserNotificationSettingsValidatorDashboardWebhookSecretResponse
+//	@Failure		400				{object}	types.ApiErrorResponse
+//	@Failure		404				{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/settings/validator-dashboards/{dashboard_id}/groups/{group_id}/webhook-signing-secret [post]
+func (h *HandlerService) PublicPostUserNotificationSettingsValidatorDashboardWebhookSecret(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
+	groupId := v.checkExistingGroupId(vars["group_id"])
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	data, err := h.getDataAccessor(r).UpdateNotificationSettingsClients(r.Context(), userId, clientId, req.IsSubscribed)
+	secret, err := h.getDataAccessor(r).RotateNotificationSettingsValidatorDashboardWebhookSecret(r.Context(), dashboardId, groupId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.InternalPutUserNotificationSettingsClientResponse{
-		Data: *data,
+	response := types.InternalPostUserNotificationSettingsValidatorDashboardWebhookSecretResponse{
+		Data: types.NotificationWebhookSigningSecret{
+			WebhookSigningSecret: secret,
+		},
 	}
 	returnOk(w, r, response)
 }
 
-// PublicGetUserNotificationSettingsDashboards godoc
+// PublicGetUserNotificationWebhookDeadLetters godoc
 //
-//	@Description	Get a list of notification settings for the dashboards of the authenticated user.
+//	@Description	Get a list of webhook deliveries of the authenticated user that could not be delivered after all retries.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Notification Settings
+//	@Tags			Notifications
 //	@Produce		json
 //	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
 //	@Param			limit	query		integer	false	"The maximum number of results that may be returned."
-//	@Param			sort	query		string	false	"The field you want to sort by. Append with `:desc` for descending order."	Enums	(dashboard_id, group_name)
-//	@Param			search	query		string	false	"Search for Dashboard, Group"
-//	@Success		200		{object}	types.InternalGetUserNotificationSettingsDashboardsResponse
+//	@Success		200		{object}	types.InternalGetUserNotificationWebhookDeadLettersResponse
 //	@Failure		400		{object}	types.ApiErrorResponse
-//	@Router			/users/me/notifications/settings/dashboards [get]
-func (h *HandlerService) PublicGetUserNotificationSettingsDashboards(w http.ResponseWriter, r *http.Request) {
+//	@Router			/users/me/notifications/webhook-dead-letters [get]
+func (h *HandlerService) PublicGetUserNotificationWebhookDeadLetters(w http.ResponseWriter, r *http.Request) {
 	var v validationError
 	userId, err := GetUserIdByContext(r)
 	if err != nil {
@@ -2484,294 +3850,382 @@ func (h *HandlerService) PublicGetUserNotificationSettingsDashboards(w http.Resp
 	}
 	q := r.URL.Query()
 	pagingParams := v.checkPagingParams(q)
-	sort := checkSort[enums.NotificationSettingsDashboardColumn](&v, q.Get("sort"))
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	data, paging, err := h.getDataAccessor(r).GetNotificationSettingsDashboards(r.Context(), userId, pagingParams.cursor, *sort, pagingParams.search, pagingParams.limit)
+	data, paging, err := h.getDataAccessor(r).GetWebhookDeadLetters(r.Context(), userId, pagingParams.cursor, pagingParams.limit)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	// if users premium perks do not allow subscriptions, set them to false in the response
-	// TODO: once stripe payments run in v2, this should be removed and the notification settings should be updated upon a tier change instead
-	userInfo, err := h.getDataAccessor(r).GetUserInfo(r.Context(), userId)
+	response := types.InternalGetUserNotificationWebhookDeadLettersResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicPostUserNotificationWebhookDeadLetterRedeliveries godoc
+//
+//	@Description	Queue a failed webhook delivery of the authenticated user for another delivery attempt.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Notifications
+//	@Param			dead_letter_id	path	integer	true	"The ID of the failed delivery."
+//	@Success		204
+//	@Failure		400	{object}	types.ApiErrorResponse
+//	@Failure		404	{object}	types.ApiErrorResponse
+//	@Router			/users/me/notifications/webhook-dead-letters/{dead_letter_id}/redeliveries [post]
+func (h *HandlerService) PublicPostUserNotificationWebhookDeadLetterRedeliveries(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	userId, err := GetUserIdByContext(r)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	defaultSettings, err := h.getDataAccessor(r).GetNotificationSettingsDefaultValues(r.Context())
+	deadLetterId := v.checkUint(mux.Vars(r)["dead_letter_id"], "dead_letter_id")
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	err = h.getDataAccessor(r).RedeliverWebhookDeadLetter(r.Context(), userId, deadLetterId)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	for i, dashboard := range data {
-		if dashboard.IsAccountDashboard {
-			continue
-		}
-		settings, ok := dashboard.Settings.(types.NotificationSettingsValidatorDashboard)
-		if !ok {
-			handleErr(w, r, errors.New("invalid settings type"))
-			return
-		}
-		if !userInfo.PremiumPerks.NotificationsValidatorDashboardGroupEfficiency && settings.IsGroupEfficiencyBelowSubscribed {
-			settings.IsGroupEfficiencyBelowSubscribed = false
-			settings.GroupEfficiencyBelowThreshold = defaultSettings.GroupEfficiencyBelowThreshold
-		}
-		data[i].Settings = settings
+	returnNoContent(w, r)
+}
+
+// PublicGetNetworkValidators godoc
+//
+//	@Description	Get information about a list of validators.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validators
+//	@Produce		json
+//	@Param			network		path		string	true	"The network name or chain id."
+//	@Param			validators	query		string	true	"Provide a comma separated list of validator indices or public keys (at most 20)."
+//	@Success		200			{object}	types.GetNetworkValidatorsResponse
+//	@Failure		400			{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/validators [get]
+func (h *HandlerService) PublicGetNetworkValidators(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	v.checkNetworkParameter(mux.Vars(r)["network"])
+	indices, publicKeys := v.checkValidatorList(r.URL.Query().Get("validators"), forbidEmpty)
+	if len(indices)+len(publicKeys) > maxValidatorsInList {
+		v.add("validators", fmt.Sprintf("too many validators in list, maximum is %d", maxValidatorsInList))
 	}
-	response := types.InternalGetUserNotificationSettingsDashboardsResponse{
-		Data:   data,
-		Paging: *paging,
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	ctx := r.Context()
+	validators, err := h.getDataAccessor(r).GetValidatorsFromSlices(ctx, indices, publicKeys)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	slices.Sort(validators)
+	data, err := h.getDataAccessor(r).GetValidators(ctx, validators)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.GetNetworkValidatorsResponse{
+		Data: data,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicPutUserNotificationSettingsValidatorDashboard godoc
+// PublicGetNetworkValidator godoc
 //
-//	@Description	Update the notification settings for a specific group of a validator dashboard for the authenticated user.
-//	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Notification Settings
-//	@Accept			json
-//	@Produce		json
-//	@Param			dashboard_id	path		string											true	"The ID of the dashboard."
-//	@Param			group_id		path		integer											true	"The ID of the group."
-//	@Param			request			body		types.NotificationSettingsValidatorDashboard	true	"Notification settings"
-//	@Success		200				{object}	types.InternalPutUserNotificationSettingsValidatorDashboardResponse
-//	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/users/me/notifications/settings/validator-dashboards/{dashboard_id}/groups/{group_id} [put]
-func (h *HandlerService) PublicPutUserNotificationSettingsValidatorDashboard(w http.ResponseWriter, r *http.Request) {
+//	@Description	Get information about a single validator.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validators
+//	@Produce		json
+//	@Param			network		path		string	true	"The network name or chain id."
+//	@Param			validator	path		string	true	"The index or public key of the validator."
+//	@Success		200			{object}	types.GetNetworkValidatorResponse
+//	@Failure		400			{object}	types.ApiErrorResponse
+//	@Failure		404			{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/validators/{validator} [get]
+func (h *HandlerService) PublicGetNetworkValidator(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	userId, err := GetUserIdByContext(r)
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	index, err := h.getValidatorIndex(r, chainId, vars["validator"])
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-
-	var req types.NotificationSettingsValidatorDashboard
-	if err := v.checkBody(&req, r); err != nil {
+	data, err := h.getDataAccessor(r).GetValidators(r.Context(), []uint64{index})
+	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	checkMinMax(&v, req.GroupEfficiencyBelowThreshold, 0, 1, "group_offline_threshold")
-	vars := mux.Vars(r)
-	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
-	groupId := v.checkExistingGroupId(vars["group_id"])
+	if len(data) == 0 {
+		handleErr(w, r, newNotFoundErr("validator %d not found", index))
+		return
+	}
+	response := types.GetNetworkValidatorResponse{
+		Data: data[0],
+	}
+	returnOk(w, r, response)
+}
 
-	checkMinMax(&v, req.MaxCollateralThreshold, 0, 1, "max_collateral_threshold")
-	checkMinMax(&v, req.MinCollateralThreshold, 0, 1, "min_collateral_threshold")
+// PublicGetNetworkValidatorDuties godoc
+//
+//	@Description	Get the block proposal and sync committee duties of a validator for a specific epoch.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validators
+//	@Produce		json
+//	@Param			network		path		string	true	"The network name or chain id."
+//	@Param			validator	path		string	true	"The index or public key of the validator."
+//	@Param			epoch		query		integer	false	"The epoch to get the duties for. Defaults to the current epoch."
+//	@Success		200			{object}	types.GetNetworkValidatorDutiesResponse
+//	@Failure		400			{object}	types.ApiErrorResponse
+//	@Failure		404			{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/validators/{validator}/duties [get]
+func (h *HandlerService) PublicGetNetworkValidatorDuties(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	var epoch uint64
+	epochParam := r.URL.Query().Get("epoch")
+	if epochParam != "" {
+		epoch = v.checkUint(epochParam, "epoch")
+	}
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	userInfo, err := h.getDataAccessor(r).GetUserInfo(r.Context(), userId)
+	ctx := r.Context()
+	index, err := h.getValidatorIndex(r, chainId, vars["validator"])
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	if !userInfo.PremiumPerks.NotificationsValidatorDashboardGroupEfficiency && req.IsGroupEfficiencyBelowSubscribed {
-		returnForbidden(w, r, errors.New("user does not have premium perks to subscribe group efficiency event"))
-		return
+	if epochParam == "" {
+		latestSlot, err := h.getDataAccessor(r).GetLatestSlot(ctx)
+		if err != nil {
+			handleErr(w, r, err)
+			return
+		}
+		epoch = getChain(ctx).EpochOfSlot(latestSlot)
 	}
-
-	err = h.getDataAccessor(r).UpdateNotificationSettingsValidatorDashboard(r.Context(), userId, dashboardId, groupId, req)
+	data, err := h.getDataAccessor(r).GetValidatorDuties(ctx, index, epoch)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.InternalPutUserNotificationSettingsValidatorDashboardResponse{
-		Data: req,
+	response := types.GetNetworkValidatorDutiesResponse{
+		Data: *data,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicPutUserNotificationSettingsAccountDashboard godoc
+// PublicGetNetworkValidatorPoolOperations godoc
 //
-//	@Description	Update the notification settings for a specific group of an account dashboard for the authenticated user.
+//	@Description	Get the voluntary exits and BLS to execution changes of a validator that have been seen in the op pool of the beacon node, from the moment they were first seen until they got included or dropped.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Notification Settings
-//	@Accept			json
+//	@Tags			Validators
 //	@Produce		json
-//	@Param			dashboard_id	path		string																true	"The ID of the dashboard."
-//	@Param			group_id		path		integer																true	"The ID of the group."
-//	@Param			request			body		handlers.PublicPutUserNotificationSettingsAccountDashboard.request	true	"Notification settings"
-//	@Success		200				{object}	types.InternalPutUserNotificationSettingsAccountDashboardResponse
-//	@Failure		400				{object}	types.ApiErrorResponse
-//	@Router			/users/me/notifications/settings/account-dashboards/{dashboard_id}/groups/{group_id} [put]
-func (h *HandlerService) PublicPutUserNotificationSettingsAccountDashboard(w http.ResponseWriter, r *http.Request) {
+//	@Param			network		path		string	true	"The network name or chain id."
+//	@Param			validator	path		string	true	"The index or public key of the validator."
+//	@Success		200			{object}	types.GetNetworkValidatorPoolOperationsResponse
+//	@Failure		400			{object}	types.ApiErrorResponse
+//	@Failure		404			{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/validators/{validator}/pool-operations [get]
+func (h *HandlerService) PublicGetNetworkValidatorPoolOperations(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	userId, err := GetUserIdByContext(r)
+	vars := mux.Vars(r)
+	chainId := v.checkNetworkParameter(vars["network"])
+	if v.hasErrors() {
+		handleErr(w, r, v)
+		return
+	}
+	index, err := h.getValidatorIndex(r, chainId, vars["validator"])
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-
-	// uses a different struct due to `subscribed_chain_ids`, which is a slice of intOrString in the payload but a slice of uint64 in the response
-	type request struct {
-		WebhookUrl                      string        `json:"webhook_url"`
-		IsWebhookDiscordEnabled         bool          `json:"is_webhook_discord_enabled"`
-		IsIgnoreSpamTransactionsEnabled bool          `json:"is_ignore_spam_transactions_enabled"`
-		SubscribedChainIds              []intOrString `json:"subscribed_chain_ids"`
-
-		IsIncomingTransactionsSubscribed  bool    `json:"is_incoming_transactions_subscribed"`
-		IsOutgoingTransactionsSubscribed  bool    `json:"is_outgoing_transactions_subscribed"`
-		IsERC20TokenTransfersSubscribed   bool    `json:"is_erc20_token_transfers_subscribed"`
-		ERC20TokenTransfersValueThreshold float64 `json:"erc20_token_transfers_value_threshold"` // 0 does not disable, is_erc20_token_transfers_subscribed determines if it's enabled
-		IsERC721TokenTransfersSubscribed  bool    `json:"is_erc721_token_transfers_subscribed"`
-		IsERC1155TokenTransfersSubscribed bool    `json:"is_erc1155_token_transfers_subscribed"`
-	}
-	var req request
-	if err := v.checkBody(&req, r); err != nil {
+	data, err := h.getDataAccessor(r).GetValidatorPoolOperations(r.Context(), index)
+	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	chainIds := v.checkNetworkSlice(req.SubscribedChainIds)
-	checkMinMax(&v, req.ERC20TokenTransfersValueThreshold, 0, math.MaxFloat64, "group_offline_threshold")
+	response := types.GetNetworkValidatorPoolOperationsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetNetworkAddressValidators godoc
+//
+//	@Description	Get a list of validators that were deposited by a specific address.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validators
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			address	path		string	true	"The deposit address."
+//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit	query		integer	false	"The maximum number of results that may be returned."
+//	@Success		200		{object}	types.GetNetworkAddressValidatorsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/addresses/{address}/validators [get]
+func (h *HandlerService) PublicGetNetworkAddressValidators(w http.ResponseWriter, r *http.Request) {
+	var v validationError
 	vars := mux.Vars(r)
-	dashboardId := v.checkPrimaryDashboardId(vars["dashboard_id"])
-	groupId := v.checkExistingGroupId(vars["group_id"])
+	v.checkNetworkParameter(vars["network"])
+	address := v.checkAddress(vars["address"])
+	pagingParams := v.checkPagingParams(r.URL.Query())
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	settings := types.NotificationSettingsAccountDashboard{
-		WebhookUrl:                      req.WebhookUrl,
-		IsWebhookDiscordEnabled:         req.IsWebhookDiscordEnabled,
-		IsIgnoreSpamTransactionsEnabled: req.IsIgnoreSpamTransactionsEnabled,
-		SubscribedChainIds:              chainIds,
-
-		IsIncomingTransactionsSubscribed:  req.IsIncomingTransactionsSubscribed,
-		IsOutgoingTransactionsSubscribed:  req.IsOutgoingTransactionsSubscribed,
-		IsERC20TokenTransfersSubscribed:   req.IsERC20TokenTransfersSubscribed,
-		ERC20TokenTransfersValueThreshold: req.ERC20TokenTransfersValueThreshold,
-		IsERC721TokenTransfersSubscribed:  req.IsERC721TokenTransfersSubscribed,
-		IsERC1155TokenTransfersSubscribed: req.IsERC1155TokenTransfersSubscribed,
+	addressBytes, err := hex.DecodeString(strings.TrimPrefix(address, "0x"))
+	if err != nil {
+		handleErr(w, r, newBadRequestErr("given value '%s' is not a valid address", address))
+		return
 	}
-	err = h.getDataAccessor(r).UpdateNotificationSettingsAccountDashboard(r.Context(), userId, dashboardId, groupId, settings)
+	data, paging, err := h.getDataAccessor(r).GetValidatorsByDepositAddress(r.Context(), addressBytes, pagingParams.cursor, pagingParams.limit)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	response := types.InternalPutUserNotificationSettingsAccountDashboardResponse{
-		Data: settings,
+	response := types.GetNetworkAddressValidatorsResponse{
+		Data:   data,
+		Paging: *paging,
 	}
 	returnOk(w, r, response)
 }
 
-// PublicPostUserNotificationsTestEmail godoc
+// PublicGetNetworkWithdrawalCredentialValidators godoc
 //
-//	@Description	Send a test email notification to the authenticated user.
+//	@Description	Get a list of validators with a specific withdrawal credential. Passing a withdrawal address returns the validators with the corresponding `0x01` or `0x02` withdrawal credential.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Notification Settings
+//	@Tags			Validators
 //	@Produce		json
-//	@Success		204
-//	@Router			/users/me/notifications/test-email [post]
-func (h *HandlerService) PublicPostUserNotificationsTestEmail(w http.ResponseWriter, r *http.Request) {
-	userId, err := GetUserIdByContext(r)
-	if err != nil {
-		handleErr(w, r, err)
+//	@Param			network		path		string	true	"The network name or chain id."
+//	@Param			credential	path		string	true	"The withdrawal credential or withdrawal address."
+//	@Param			cursor		query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit		query		integer	false	"The maximum number of results that may be returned."
+//	@Success		200			{object}	types.GetNetworkWithdrawalCredentialValidatorsResponse
+//	@Failure		400			{object}	types.ApiErrorResponse
+//	@Failure		404			{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/withdrawal-credentials/{credential}/validators [get]
+func (h *HandlerService) PublicGetNetworkWithdrawalCredentialValidators(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	vars := mux.Vars(r)
+	v.checkNetworkParameter(vars["network"])
+	credentials := v.checkWithdrawalCredentialOrAddress(vars["credential"])
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
 		return
 	}
-	err = h.getDataAccessor(r).QueueTestEmailNotification(r.Context(), userId)
+	data, paging, err := h.getDataAccessor(r).GetValidatorsByWithdrawalCredentials(r.Context(), credentials, pagingParams.cursor, pagingParams.limit)
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	returnNoContent(w, r)
+	response := types.GetNetworkWithdrawalCredentialValidatorsResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
 }
 
-// PublicPostUserNotificationsTestPush godoc
+// PublicGetNetworkValidatorStatuses godoc
 //
-//	@Description	Send a test push notification to the authenticated user.
+//	@Description	Get the number of validators per status.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Notification Settings
+//	@Tags			Validators
 //	@Produce		json
-//	@Success		204
-//	@Router			/users/me/notifications/test-push [post]
-func (h *HandlerService) PublicPostUserNotificationsTestPush(w http.ResponseWriter, r *http.Request) {
-	userId, err := GetUserIdByContext(r)
-	if err != nil {
-		handleErr(w, r, err)
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Success		200		{object}	types.GetNetworkValidatorStatusesResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/validator-statuses [get]
+func (h *HandlerService) PublicGetNetworkValidatorStatuses(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	v.checkNetworkParameter(mux.Vars(r)["network"])
+	if v.hasErrors() {
+		handleErr(w, r, v)
 		return
 	}
-	err = h.getDataAccessor(r).QueueTestPushNotification(r.Context(), userId)
+	data, err := h.getDataAccessor(r).GetValidatorStatusCounts(r.Context())
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	returnNoContent(w, r)
+	response := types.GetNetworkValidatorStatusesResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
-// PublicPostUserNotificationsTestWebhook godoc
+// PublicGetNetworkValidatorLeaderboard godoc
 //
-//	@Description	Send a test webhook notification from the authenticated user to the given URL.
+//	@Description	Get the validators ranked by their consensus layer income of the last 7 days.
 //	@Security		ApiKeyInHeader || ApiKeyInQuery
-//	@Tags			Notification Settings
-//	@Accept			json
+//	@Tags			Validators
 //	@Produce		json
-//	@Param			request	body	handlers.PublicPostUserNotificationsTestWebhook.request	true	"Request"
-//	@Success		204
-//	@Failure		400	{object}	types.ApiErrorResponse
-//	@Router			/users/me/notifications/test-webhook [post]
-func (h *HandlerService) PublicPostUserNotificationsTestWebhook(w http.ResponseWriter, r *http.Request) {
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			cursor	query		string	false	"Return data for the given cursor value. Pass the `paging.next_cursor`` value of the previous response to navigate to forward, or pass the `paging.prev_cursor`` value of the previous response to navigate to backward."
+//	@Param			limit	query		integer	false	"The maximum number of results that may be returned."
+//	@Success		200		{object}	types.GetNetworkValidatorLeaderboardResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/validator-leaderboard [get]
+func (h *HandlerService) PublicGetNetworkValidatorLeaderboard(w http.ResponseWriter, r *http.Request) {
 	var v validationError
-	userId, err := GetUserIdByContext(r)
-	if err != nil {
-		handleErr(w, r, err)
+	v.checkNetworkParameter(mux.Vars(r)["network"])
+	pagingParams := v.checkPagingParams(r.URL.Query())
+	if v.hasErrors() {
+		handleErr(w, r, v)
 		return
 	}
-	type request struct {
-		WebhookUrl              string `json:"webhook_url"`
-		IsWebhookDiscordEnabled bool   `json:"is_webhook_discord_enabled,omitempty"`
-	}
-	var req request
-	if err := v.checkBody(&req, r); err != nil {
+	data, paging, err := h.getDataAccessor(r).GetValidatorLeaderboard(r.Context(), pagingParams.cursor, pagingParams.limit)
+	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
+	response := types.GetNetworkValidatorLeaderboardResponse{
+		Data:   data,
+		Paging: *paging,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetNetworkValidatorQueue godoc
+//
+//	@Description	Get the current state of the validator activation and exit queues.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Validators
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Success		200		{object}	types.GetNetworkValidatorQueueResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/validator-queue [get]
+func (h *HandlerService) PublicGetNetworkValidatorQueue(w http.ResponseWriter, r *http.Request) {
+	var v validationError
+	v.checkNetworkParameter(mux.Vars(r)["network"])
 	if v.hasErrors() {
 		handleErr(w, r, v)
 		return
 	}
-	err = h.getDataAccessor(r).QueueTestWebhookNotification(r.Context(), userId, req.WebhookUrl, req.IsWebhookDiscordEnabled)
+	data, err := h.getDataAccessor(r).GetValidatorQueue(r.Context())
 	if err != nil {
 		handleErr(w, r, err)
 		return
 	}
-	returnNoContent(w, r)
-}
-
-func (h *HandlerService) PublicGetNetworkValidators(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
-}
-
-func (h *HandlerService) PublicGetNetworkValidator(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
-}
-
-func (h *HandlerService) PublicGetNetworkValidatorDuties(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
-}
-
-func (h *HandlerService) PublicGetNetworkAddressValidators(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
-}
-
-func (h *HandlerService) PublicGetNetworkWithdrawalCredentialValidators(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
-}
-
-func (h *HandlerService) PublicGetNetworkValidatorStatuses(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
-}
-
-func (h *HandlerService) PublicGetNetworkValidatorLeaderboard(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
-}
-
-func (h *HandlerService) PublicGetNetworkValidatorQueue(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	response := types.GetNetworkValidatorQueueResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
 }
 
 func (h *HandlerService) PublicGetNetworkEpochs(w http.ResponseWriter, r *http.Request) {
@@ -2786,16 +4240,68 @@ func (h *HandlerService) PublicGetNetworkBlocks(w http.ResponseWriter, r *http.R
 	returnOk(w, r, nil)
 }
 
+// PublicGetNetworkBlock godoc
+//
+//	@Description	Get the overview of an execution layer block.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Blocks
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			block	path		string	true	"The execution layer block number or \"latest\"."
+//	@Success		200		{object}	types.InternalGetBlockOverviewResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/blocks/{block}/overview [get]
 func (h *HandlerService) PublicGetNetworkBlock(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	chainId, block, err := h.validateBlockRequest(r, "block")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetBlockOverview(r.Context(), chainId, block)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetBlockOverviewResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
 }
 
 func (h *HandlerService) PublicGetNetworkSlots(w http.ResponseWriter, r *http.Request) {
 	returnOk(w, r, nil)
 }
 
+// PublicGetNetworkSlot godoc
+//
+//	@Description	Get the overview of a consensus layer slot.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Slots
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			slot	path		string	true	"The slot or \"latest\"."
+//	@Success		200		{object}	types.InternalGetBlockOverviewResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/slots/{slot}/overview [get]
 func (h *HandlerService) PublicGetNetworkSlot(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	chainId, slot, err := h.validateBlockRequest(r, "slot")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetSlotOverview(r.Context(), chainId, slot)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetBlockOverviewResponse{
+		Data: *data,
+	}
+	returnOk(w, r, response)
 }
 
 func (h *HandlerService) PublicGetNetworkValidatorBlocks(w http.ResponseWriter, r *http.Request) {
@@ -2834,20 +4340,124 @@ func (h *HandlerService) PublicGetNetworkEpochAttestations(w http.ResponseWriter
 	returnOk(w, r, nil)
 }
 
+// PublicGetNetworkSlotAttestations godoc
+//
+//	@Description	Get the attestations included in the block proposed at a slot.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Slots
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			slot	path		string	true	"The slot or \"latest\"."
+//	@Success		200		{object}	types.InternalGetBlockAttestationsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/slots/{slot}/attestations [get]
 func (h *HandlerService) PublicGetNetworkSlotAttestations(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	chainId, slot, err := h.validateBlockRequest(r, "slot")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetSlotAttestations(r.Context(), chainId, slot)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetBlockAttestationsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkSlotVotes godoc
+//
+//	@Description	Get the attestations that voted for the block proposed at a slot.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Slots
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			slot	path		string	true	"The slot or \"latest\"."
+//	@Success		200		{object}	types.InternalGetBlockVotesResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/slots/{slot}/votes [get]
 func (h *HandlerService) PublicGetNetworkSlotVotes(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	chainId, slot, err := h.validateBlockRequest(r, "slot")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetSlotVotes(r.Context(), chainId, slot)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetBlockVotesResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkBlockAttestations godoc
+//
+//	@Description	Get the attestations included in a block.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Blocks
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			block	path		string	true	"The execution layer block number or \"latest\"."
+//	@Success		200		{object}	types.InternalGetBlockAttestationsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/blocks/{block}/attestations [get]
 func (h *HandlerService) PublicGetNetworkBlockAttestations(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	chainId, block, err := h.validateBlockRequest(r, "block")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetBlockAttestations(r.Context(), chainId, block)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetBlockAttestationsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkBlockVotes godoc
+//
+//	@Description	Get the attestations that voted for a block.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Blocks
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			block	path		string	true	"The execution layer block number or \"latest\"."
+//	@Success		200		{object}	types.InternalGetBlockVotesResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/blocks/{block}/votes [get]
 func (h *HandlerService) PublicGetNetworkBlockVotes(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	chainId, block, err := h.validateBlockRequest(r, "block")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetBlockVotes(r.Context(), chainId, block)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetBlockVotesResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
 func (h *HandlerService) PublicGetNetworkAggregatedAttestations(w http.ResponseWriter, r *http.Request) {
@@ -2894,11 +4504,64 @@ func (h *HandlerService) PublicGetNetworkWithdrawals(w http.ResponseWriter, r *h
 	returnOk(w, r, nil)
 }
 
+// PublicGetNetworkSlotWithdrawals godoc
+//
+//	@Description	Get the withdrawals processed in the block proposed at a slot.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Slots
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			slot	path		string	true	"The slot or \"latest\"."
+//	@Success		200		{object}	types.InternalGetBlockWtihdrawalsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/slots/{slot}/withdrawals [get]
 func (h *HandlerService) PublicGetNetworkSlotWithdrawals(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	chainId, slot, err := h.validateBlockRequest(r, "slot")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetSlotWithdrawals(r.Context(), chainId, slot)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetBlockWtihdrawalsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
+
+// PublicGetNetworkBlockWithdrawals godoc
+//
+//	@Description	Get the withdrawals processed in a block.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Blocks
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			block	path		string	true	"The execution layer block number or \"latest\"."
+//	@Success		200		{object}	types.InternalGetBlockWtihdrawalsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/blocks/{block}/withdrawals [get]
 func (h *HandlerService) PublicGetNetworkBlockWithdrawals(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	chainId, block, err := h.validateBlockRequest(r, "block")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetBlockWithdrawals(r.Context(), chainId, block)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetBlockWtihdrawalsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
 func (h *HandlerService) PublicGetNetworkValidatorWithdrawals(w http.ResponseWriter, r *http.Request) {
@@ -2917,12 +4580,64 @@ func (h *HandlerService) PublicGetNetworkEpochVoluntaryExits(w http.ResponseWrit
 	returnOk(w, r, nil)
 }
 
+// PublicGetNetworkSlotVoluntaryExits godoc
+//
+//	@Description	Get the voluntary exits included in the block proposed at a slot.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Slots
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			slot	path		string	true	"The slot or \"latest\"."
+//	@Success		200		{object}	types.InternalGetBlockVoluntaryExitsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/slots/{slot}/voluntary-exits [get]
 func (h *HandlerService) PublicGetNetworkSlotVoluntaryExits(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	chainId, slot, err := h.validateBlockRequest(r, "slot")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetSlotVoluntaryExits(r.Context(), chainId, slot)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetBlockVoluntaryExitsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkBlockVoluntaryExits godoc
+//
+//	@Description	Get the voluntary exits included in a block.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Blocks
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			block	path		string	true	"The execution layer block number or \"latest\"."
+//	@Success		200		{object}	types.InternalGetBlockVoluntaryExitsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/blocks/{block}/voluntary-exits [get]
 func (h *HandlerService) PublicGetNetworkBlockVoluntaryExits(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	chainId, block, err := h.validateBlockRequest(r, "block")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetBlockVoluntaryExits(r.Context(), chainId, block)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetBlockVoluntaryExitsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
 func (h *HandlerService) PublicGetNetworkAddressBalanceHistory(w http.ResponseWriter, r *http.Request) {
@@ -2949,16 +4664,124 @@ func (h *HandlerService) PublicGetNetworkAddressTransactions(w http.ResponseWrit
 	returnOk(w, r, nil)
 }
 
+// PublicGetNetworkSlotTransactions godoc
+//
+//	@Description	Get the transactions included in the block proposed at a slot.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Slots
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			slot	path		string	true	"The slot or \"latest\"."
+//	@Success		200		{object}	types.InternalGetBlockTransactionsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/slots/{slot}/transactions [get]
 func (h *HandlerService) PublicGetNetworkSlotTransactions(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	chainId, slot, err := h.validateBlockRequest(r, "slot")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetSlotTransactions(r.Context(), chainId, slot)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetBlockTransactionsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkBlockTransactions godoc
+//
+//	@Description	Get the transactions included in a block.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Blocks
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			block	path		string	true	"The execution layer block number or \"latest\"."
+//	@Success		200		{object}	types.InternalGetBlockTransactionsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/blocks/{block}/transactions [get]
 func (h *HandlerService) PublicGetNetworkBlockTransactions(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	chainId, block, err := h.validateBlockRequest(r, "block")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetBlockTransactions(r.Context(), chainId, block)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetBlockTransactionsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkBlockBlobs godoc
+//
+//	@Description	Get the blobs included in a block. The blob data is only returned while it is retained by the blob indexer.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Blocks
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			block	path		string	true	"The execution layer block number or \"latest\"."
+//	@Success		200		{object}	types.InternalGetBlockBlobsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/blocks/{block}/blobs [get]
 func (h *HandlerService) PublicGetNetworkBlockBlobs(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	chainId, block, err := h.validateBlockRequest(r, "block")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetBlockBlobs(r.Context(), chainId, block)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetBlockBlobsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
+}
+
+// PublicGetNetworkSlotBlobs godoc
+//
+//	@Description	Get the blobs included in the block proposed at a slot. The blob data is only returned while it is retained by the blob indexer.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Slots
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			slot	path		string	true	"The slot or \"latest\"."
+//	@Success		200		{object}	types.InternalGetBlockBlobsResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/slots/{slot}/blobs [get]
+func (h *HandlerService) PublicGetNetworkSlotBlobs(w http.ResponseWriter, r *http.Request) {
+	chainId, slot, err := h.validateBlockRequest(r, "slot")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetSlotBlobs(r.Context(), chainId, slot)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetBlockBlobsResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
 func (h *HandlerService) PublicGetNetworkBlsChanges(w http.ResponseWriter, r *http.Request) {
@@ -2969,12 +4792,64 @@ func (h *HandlerService) PublicGetNetworkEpochBlsChanges(w http.ResponseWriter,
 	returnOk(w, r, nil)
 }
 
+// PublicGetNetworkSlotBlsChanges godoc
+//
+//	@Description	Get the BLS to execution changes included in the block proposed at a slot.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Slots
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			slot	path		string	true	"The slot or \"latest\"."
+//	@Success		200		{object}	types.InternalGetBlockBlsChangesResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/slots/{slot}/bls-changes [get]
 func (h *HandlerService) PublicGetNetworkSlotBlsChanges(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	chainId, slot, err := h.validateBlockRequest(r, "slot")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetSlotBlsChanges(r.Context(), chainId, slot)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetBlockBlsChangesResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
+// PublicGetNetworkBlockBlsChanges godoc
+//
+//	@Description	Get the BLS to execution changes included in a block.
+//	@Security		ApiKeyInHeader || ApiKeyInQuery
+//	@Tags			Blocks
+//	@Produce		json
+//	@Param			network	path		string	true	"The network name or chain id."
+//	@Param			block	path		string	true	"The execution layer block number or \"latest\"."
+//	@Success		200		{object}	types.InternalGetBlockBlsChangesResponse
+//	@Failure		400		{object}	types.ApiErrorResponse
+//	@Failure		404		{object}	types.ApiErrorResponse
+//	@Router			/networks/{network}/blocks/{block}/bls-changes [get]
 func (h *HandlerService) PublicGetNetworkBlockBlsChanges(w http.ResponseWriter, r *http.Request) {
-	returnOk(w, r, nil)
+	chainId, block, err := h.validateBlockRequest(r, "block")
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	data, err := h.getDataAccessor(r).GetBlockBlsChanges(r.Context(), chainId, block)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+	response := types.InternalGetBlockBlsChangesResponse{
+		Data: data,
+	}
+	returnOk(w, r, response)
 }
 
 func (h *HandlerService) PublicGetNetworkValidatorBlsChanges(w http.ResponseWriter, r *http.Request) {
@@ -3001,10 +4876,6 @@ func (h *HandlerService) PublicGetNetworkLayer1ToLayer2Transactions(w http.Respo
 	returnOk(w, r, nil)
 }
 
-func (h *HandlerService) PublicPostNetworkBroadcasts(w http.ResponseWriter, r *http.Request) {
-	returnCreated(w, r, nil)
-}
-
 func (h *HandlerService) PublicGetEthPriceHistory(w http.ResponseWriter, r *http.Request) {
 	returnOk(w, r, nil)
 }
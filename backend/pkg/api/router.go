@@ -6,6 +6,7 @@ import (
 
 	dataaccess "github.com/gobitfly/beaconchain/pkg/api/data_access"
 	"github.com/gobitfly/beaconchain/pkg/api/docs"
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
 	handlers "github.com/gobitfly/beaconchain/pkg/api/handlers"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
 	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
@@ -19,14 +20,28 @@ type endpoint struct {
 	Path           string
 	PublicHandler  func(w http.ResponseWriter, r *http.Request)
 	InternalHander func(w http.ResponseWriter, r *http.Request)
+	ApiKeyScope    handlers.ApiKeyScope
 }
 
+// scopes api keys need to access public routes, api keys are refused on public routes without a declared scope
+var (
+	anyScope            = handlers.AnyApiKeyScope
+	readDashboards      = handlers.RequireApiKeyScope(enums.ApiKeyScopes.ReadDashboards)
+	manageDashboards    = handlers.RequireApiKeyScope(enums.ApiKeyScopes.ManageDashboards)
+	manageNotifications = handlers.RequireApiKeyScope(enums.ApiKeyScopes.ManageNotifications)
+	pushMachineMetrics  = handlers.RequireApiKeyScope(enums.ApiKeyScopes.PushMachineMetrics)
+	manageBroadcasts    = handlers.RequireApiKeyScope(enums.ApiKeyScopes.ManageBroadcasts)
+	internalOnly        handlers.ApiKeyScope
+)
+
 func NewApiRouter(dataAccessor dataaccess.DataAccessor, dummy dataaccess.DataAccessor, cfg *types.Config) *mux.Router {
 	router := mux.NewRouter()
 	apiRouter := router.PathPrefix("/api").Subrouter()
 	publicRouter := apiRouter.PathPrefix("/v2").Subrouter()
 	legacyRouter := apiRouter.PathPrefix("/v1").Subrouter()
 	internalRouter := apiRouter.PathPrefix("/i").Subrouter()
+	// called by oauth apps directly, so neither session nor csrf protected
+	oauthRouter := apiRouter.PathPrefix("/oauth").Subrouter()
 	sessionManager := newSessionManager(cfg)
 	internalRouter.Use(sessionManager.LoadAndSave, getSlidingSessionExpirationMiddleware(sessionManager))
 
@@ -44,11 +59,16 @@ func NewApiRouter(dataAccessor dataaccess.DataAccessor, dummy dataaccess.DataAcc
 		internalRouter.Use(handlerService.StoreIsMockedFlagMiddleware)
 	}
 
+	// route requests for a network to the data access service of that network
+	publicRouter.Use(handlerService.StoreNetworkMiddleware)
+	internalRouter.Use(handlerService.StoreNetworkMiddleware)
+
 	addRoutes(handlerService, publicRouter, internalRouter, cfg)
 	addLegacyRoutes(handlerService, legacyRouter)
+	addOAuthRoutes(handlerService, oauthRouter)
 
 	// serve static files
-	publicRouter.PathPrefix("/docs/").Handler(http.StripPrefix("/api/v2/docs/", http.FileServer(http.FS(docs.Files))))
+	handlerService.HandlePublicPrefix(publicRouter, "/docs/", http.StripPrefix("/api/v2/docs/", http.FileServer(http.FS(docs.Files))), anyScope)
 	router.Use(metrics.HttpMiddleware)
 
 	return router
@@ -93,161 +113,191 @@ func GetCorsMiddleware(allowedHosts []string) func(http.Handler) http.Handler {
 
 func addRoutes(hs *handlers.HandlerService, publicRouter, internalRouter *mux.Router, cfg *types.Config) {
 	addValidatorDashboardRoutes(hs, publicRouter, internalRouter, cfg)
+	addAccountDashboardRoutes(hs, publicRouter, internalRouter, cfg)
 	addNotificationRoutes(hs, publicRouter, internalRouter, cfg.Frontend.Debug)
 	endpoints := []endpoint{
-		{http.MethodGet, "/healthz", hs.PublicGetHealthz, nil},
-		{http.MethodGet, "/healthz-loadbalancer", hs.PublicGetHealthzLoadbalancer, nil},
-
-		{http.MethodGet, "/ratelimit-weights", nil, hs.InternalGetRatelimitWeights},
-
-		{http.MethodPost, "/login", nil, hs.InternalPostLogin},
-
-		{http.MethodGet, "/mobile/authorize", nil, hs.InternalPostMobileAuthorize},
-		{http.MethodPost, "/mobile/equivalent-exchange", nil, hs.InternalPostMobileEquivalentExchange},
-		{http.MethodPost, "/mobile/purchase", nil, hs.InternalHandleMobilePurchase},
-		{http.MethodGet, "/mobile/latest-bundle", nil, hs.InternalGetMobileLatestBundle},
-		{http.MethodPost, "/mobile/bundles/{bundle_version}/deliveries", nil, hs.InternalPostMobileBundleDeliveries},
-
-		{http.MethodPost, "/logout", nil, hs.InternalPostLogout},
-
-		{http.MethodGet, "/latest-state", nil, hs.InternalGetLatestState},
-
-		{http.MethodGet, "/product-summary", nil, hs.InternalGetProductSummary},
-
-		{http.MethodPost, "/ad-configurations", nil, hs.InternalPostAdConfigurations},
-		{http.MethodGet, "/ad-configurations", nil, hs.InternalGetAdConfigurations},
-		{http.MethodPut, "/ad-configurations/{key}", nil, hs.InternalPutAdConfiguration},
-		{http.MethodDelete, "/ad-configurations/{key}", nil, hs.InternalDeleteAdConfiguration},
-
-		{http.MethodPost, "/users", nil, hs.InternalPostUsers},
-		{http.MethodPost, "/users/email-confirmations/{token}", nil, hs.InternalPostUserConfirm},
-		{http.MethodPost, "/users/password-resets", nil, hs.InternalPostUserPasswordReset},
-		{http.MethodPost, "/users/password-resets/{token}", nil, hs.InternalPostUserPasswordResetHash},
-		{http.MethodGet, "/users/me", nil, hs.InternalGetUserInfo},
-		{http.MethodDelete, "/users/me", nil, hs.InternalDeleteUser},
-		{http.MethodPost, "/users/me/email", nil, hs.InternalPostUserEmail},
-		{http.MethodPut, "/users/me/password", nil, hs.InternalPutUserPassword},
-		{http.MethodGet, "/users/me/dashboards", hs.PublicGetUserDashboards, hs.InternalGetUserDashboards},
-		{http.MethodPut, "/users/me/notifications/settings/paired-devices/{client_id}/token", nil, hs.InternalPostUsersMeNotificationSettingsPairedDevicesToken},
-
-		{http.MethodGet, "/users/me/machine-metrics", hs.PublicGetUserMachineMetrics, hs.InternalGetUserMachineMetrics},
-
-		{http.MethodPost, "/search", nil, hs.InternalPostSearch},
-
-		{http.MethodPost, "/account-dashboards", hs.PublicPostAccountDashboards, hs.InternalPostAccountDashboards},
-		{http.MethodGet, "/account-dashboards/{dashboard_id}", hs.PublicGetAccountDashboard, hs.InternalGetAccountDashboard},
-		{http.MethodDelete, "/account-dashboards/{dashboard_id}", hs.PublicDeleteAccountDashboard, hs.InternalDeleteAccountDashboard},
-		{http.MethodPost, "/account-dashboards/{dashboard_id}/groups", hs.PublicPostAccountDashboardGroups, hs.InternalPostAccountDashboardGroups},
-		{http.MethodDelete, "/account-dashboards/{dashboard_id}/groups/{group_id}", hs.PublicDeleteAccountDashboardGroups, hs.InternalDeleteAccountDashboardGroups},
-		{http.MethodPost, "/account-dashboards/{dashboard_id}/accounts", hs.PublicPostAccountDashboardAccounts, hs.InternalPostAccountDashboardAccounts},
-		{http.MethodGet, "/account-dashboards/{dashboard_id}/accounts", hs.PublicGetAccountDashboardAccounts, hs.InternalGetAccountDashboardAccounts},
-		{http.MethodDelete, "/account-dashboards/{dashboard_id}/accounts", hs.PublicDeleteAccountDashboardAccounts, hs.InternalDeleteAccountDashboardAccounts},
-		{http.MethodPut, "/account-dashboards/{dashboard_id}/accounts/{address}", hs.PublicPutAccountDashboardAccount, hs.InternalPutAccountDashboardAccount},
-		{http.MethodPost, "/account-dashboards/{dashboard_id}/public-ids", hs.PublicPostAccountDashboardPublicIds, hs.InternalPostAccountDashboardPublicIds},
-		{http.MethodPut, "/account-dashboards/{dashboard_id}/public-ids/{public_id}", hs.PublicPutAccountDashboardPublicId, hs.InternalPutAccountDashboardPublicId},
-		{http.MethodDelete, "/account-dashboards/{dashboard_id}/public-ids/{public_id}", hs.PublicDeleteAccountDashboardPublicId, hs.InternalDeleteAccountDashboardPublicId},
-		{http.MethodGet, "/account-dashboards/{dashboard_id}/transactions", hs.PublicGetAccountDashboardTransactions, hs.InternalGetAccountDashboardTransactions},
-		{http.MethodPut, "/account-dashboards/{dashboard_id}/transactions/settings", hs.PublicPutAccountDashboardTransactionsSettings, hs.InternalPutAccountDashboardTransactionsSettings},
-
-		{http.MethodGet, "/networks/{network}/validators", hs.PublicGetNetworkValidators, nil},
-		{http.MethodGet, "/networks/{network}/validators/{validator}", hs.PublicGetNetworkValidator, nil},
-		{http.MethodGet, "/networks/{network}/validators/{validator}/duties", hs.PublicGetNetworkValidatorDuties, nil},
-		{http.MethodGet, "/networks/{network}/addresses/{address}/validators", hs.PublicGetNetworkAddressValidators, nil},
-		{http.MethodGet, "/networks/{network}/withdrawal-credentials/{credential}/validators", hs.PublicGetNetworkWithdrawalCredentialValidators, nil},
-		{http.MethodGet, "/networks/{network}/validator-statuses", hs.PublicGetNetworkValidatorStatuses, nil},
-		{http.MethodGet, "/networks/{network}/validator-leaderboard", hs.PublicGetNetworkValidatorLeaderboard, nil},
-		{http.MethodGet, "/networks/{network}/validator-queue", hs.PublicGetNetworkValidatorQueue, nil},
-
-		{http.MethodGet, "/networks/{network}/epochs", hs.PublicGetNetworkEpochs, nil},
-		{http.MethodGet, "/networks/{network}/epochs/{epoch}", hs.PublicGetNetworkEpoch, nil},
-
-		{http.MethodGet, "/networks/{network}/blocks", hs.PublicGetNetworkBlocks, nil},
-		{http.MethodGet, "/networks/{network}/blocks/{block}", nil, hs.InternalGetBlock},
-		{http.MethodGet, "/networks/{network}/blocks/{block}/overview", hs.PublicGetNetworkBlock, hs.InternalGetBlockOverview},
-		{http.MethodGet, "/networks/{network}/blocks/{block}/votes", hs.PublicGetNetworkBlockVotes, hs.InternalGetBlockVotes},
-		{http.MethodGet, "/networks/{network}/slots", hs.PublicGetNetworkSlots, nil},
-		{http.MethodGet, "/networks/{network}/slots/{slot}", nil, hs.InternalGetSlot},
-		{http.MethodGet, "/networks/{network}/slots/{slot}/overview", hs.PublicGetNetworkSlot, hs.InternalGetSlotOverview},
-		{http.MethodGet, "/networks/{network}/slots/{slot}/votes", hs.PublicGetNetworkSlotVotes, hs.InternalGetSlotVotes},
-		{http.MethodGet, "/networks/{network}/validators/{validator}/blocks", hs.PublicGetNetworkValidatorBlocks, nil},
-		{http.MethodGet, "/networks/{network}/addresses/{address}/priority-fee-blocks", hs.PublicGetNetworkAddressPriorityFeeBlocks, nil},
-		{http.MethodGet, "/networks/{network}/addresses/{address}/proposer-reward-blocks", hs.PublicGetNetworkAddressProposerRewardBlocks, nil},
-		{http.MethodGet, "/networks/{network}/forked-blocks", hs.PublicGetNetworkForkedBlocks, nil},
-		{http.MethodGet, "/networks/{network}/forked-blocks/{block}", hs.PublicGetNetworkForkedBlock, nil},
-		{http.MethodGet, "/networks/{network}/forked-slots/{slot}", hs.PublicGetNetworkForkedSlot, nil},
-		{http.MethodGet, "/networks/{network}/block-sizes", hs.PublicGetNetworkBlockSizes, nil},
-
-		{http.MethodGet, "/networks/{network}/validators/{validator}/attestations", hs.PublicGetNetworkValidatorAttestations, nil},
-		{http.MethodGet, "/networks/{network}/epochs/{epoch}/attestations", hs.PublicGetNetworkEpochAttestations, nil},
-		{http.MethodGet, "/networks/{network}/slots/{slot}/attestations", hs.PublicGetNetworkSlotAttestations, hs.InternalGetSlotAttestations},
-		{http.MethodGet, "/networks/{network}/blocks/{block}/attestations", hs.PublicGetNetworkBlockAttestations, hs.InternalGetBlockAttestations},
-		{http.MethodGet, "/networks/{network}/aggregated-attestations", hs.PublicGetNetworkAggregatedAttestations, nil},
-
-		{http.MethodGet, "/networks/{network}/ethstore/{day}", hs.PublicGetNetworkEthStore, nil},
-		{http.MethodGet, "/networks/{network}/validators/{validator}/reward-history", hs.PublicGetNetworkValidatorRewardHistory, nil},
-		{http.MethodGet, "/networks/{network}/validators/{validator}/balance-history", hs.PublicGetNetworkValidatorBalanceHistory, nil},
-		{http.MethodGet, "/networks/{network}/validators/{validator}/performance-history", hs.PublicGetNetworkValidatorPerformanceHistory, nil},
-
-		{http.MethodGet, "/networks/{network}/slashings", hs.PublicGetNetworkSlashings, nil},
-		{http.MethodGet, "/networks/{network}/validators/{validator}/slashings", hs.PublicGetNetworkValidatorSlashings, nil},
-
-		{http.MethodGet, "/networks/{network}/deposits", hs.PublicGetNetworkDeposits, nil},
-		{http.MethodGet, "/networks/{network}/validators/{validator}/deposits", hs.PublicGetNetworkValidatorDeposits, nil},
-		{http.MethodGet, "/networks/{network}/transactions/{hash}/deposits", hs.PublicGetNetworkTransactionDeposits, nil},
-
-		{http.MethodGet, "/networks/{network}/withdrawals", hs.PublicGetNetworkWithdrawals, nil},
-		{http.MethodGet, "/networks/{network}/slots/{slot}/withdrawals", hs.PublicGetNetworkSlotWithdrawals, hs.InternalGetSlotWithdrawals},
-		{http.MethodGet, "/networks/{network}/blocks/{block}/withdrawals", hs.PublicGetNetworkBlockWithdrawals, hs.InternalGetBlockWithdrawals},
-		{http.MethodGet, "/networks/{network}/validators/{validator}/withdrawals", hs.PublicGetNetworkValidatorWithdrawals, nil},
-		{http.MethodGet, "/networks/{network}/withdrawal-credentials/{credential}/withdrawals", hs.PublicGetNetworkWithdrawalCredentialWithdrawals, nil},
-
-		{http.MethodGet, "/networks/{network}/voluntary-exits", hs.PublicGetNetworkVoluntaryExits, nil},
-		{http.MethodGet, "/networks/{network}/epochs/{epoch}/voluntary-exits", hs.PublicGetNetworkEpochVoluntaryExits, nil},
-		{http.MethodGet, "/networks/{network}/slots/{slot}/voluntary-exits", hs.PublicGetNetworkSlotVoluntaryExits, hs.InternalGetSlotVoluntaryExits},
-		{http.MethodGet, "/networks/{network}/blocks/{block}/voluntary-exits", hs.PublicGetNetworkBlockVoluntaryExits, hs.InternalGetBlockVoluntaryExits},
-
-		{http.MethodGet, "/networks/{network}/addresses/{address}/balance-history", hs.PublicGetNetworkAddressBalanceHistory, nil},
-		{http.MethodGet, "/networks/{network}/addresses/{address}/token-supply-history", hs.PublicGetNetworkAddressTokenSupplyHistory, nil},
-		{http.MethodGet, "/networks/{network}/addresses/{address}/event-logs", hs.PublicGetNetworkAddressEventLogs, nil},
-
-		{http.MethodGet, "/networks/{network}/transactions", hs.PublicGetNetworkTransactions, nil},
-		{http.MethodGet, "/networks/{network}/transactions/{hash}", hs.PublicGetNetworkTransaction, nil},
-		{http.MethodGet, "/networks/{network}/addresses/{address}/transactions", hs.PublicGetNetworkAddressTransactions, nil},
-		{http.MethodGet, "/networks/{network}/slots/{slot}/transactions", hs.PublicGetNetworkSlotTransactions, hs.InternalGetSlotTransactions},
-		{http.MethodGet, "/networks/{network}/blocks/{block}/transactions", hs.PublicGetNetworkBlockTransactions, hs.InternalGetBlockTransactions},
-		{http.MethodGet, "/networks/{network}/blocks/{block}/blobs", hs.PublicGetNetworkBlockBlobs, hs.InternalGetBlockBlobs},
-
-		{http.MethodGet, "/networks/{network}/handlerService-changes", hs.PublicGetNetworkBlsChanges, nil},
-		{http.MethodGet, "/networks/{network}/epochs/{epoch}/handlerService-changes", hs.PublicGetNetworkEpochBlsChanges, nil},
-		{http.MethodGet, "/networks/{network}/slots/{slot}/handlerService-changes", hs.PublicGetNetworkSlotBlsChanges, hs.InternalGetSlotBlsChanges},
-		{http.MethodGet, "/networks/{network}/blocks/{block}/handlerService-changes", hs.PublicGetNetworkBlockBlsChanges, hs.InternalGetBlockBlsChanges},
-		{http.MethodGet, "/networks/{network}/validators/{validator}/handlerService-changes", hs.PublicGetNetworkValidatorBlsChanges, nil},
-
-		{http.MethodGet, "/networks/ethereum/addresses/{address}/ens", hs.PublicGetNetworkAddressEns, nil},
-		{http.MethodGet, "/networks/ethereum/ens/{ens_name}", hs.PublicGetNetworkEns, nil},
-
-		{http.MethodGet, "/networks/{layer_2_network}/batches", hs.PublicGetNetworkBatches, nil},
-		{http.MethodGet, "/networks/{layer_2_network}/layer1-to-layer2-transactions", hs.PublicGetNetworkLayer1ToLayer2Transactions, nil},
-		{http.MethodGet, "/networks/{layer_2_network}/layer2-to-layer1-transactions", hs.PublicGetNetworkLayer2ToLayer1Transactions, nil},
-
-		{http.MethodPost, "/networks/{network}/broadcasts", hs.PublicPostNetworkBroadcasts, nil},
-		{http.MethodGet, "/eth-price-history", hs.PublicGetEthPriceHistory, nil},
-
-		{http.MethodGet, "/networks/{network}/gasnow", hs.PublicGetNetworkGasNow, nil},
-		{http.MethodGet, "/networks/{network}/average-gas-limit-history", hs.PublicGetNetworkAverageGasLimitHistory, nil},
-		{http.MethodGet, "/networks/{network}/gas-used-history", hs.PublicGetNetworkGasUsedHistory, nil},
-
-		{http.MethodGet, "/rocket-pool", hs.PublicGetRocketPool, hs.InternalGetRocketPool},
-		{http.MethodGet, "/rocket-pool/nodes", hs.PublicGetRocketPoolNodes, nil},
-		{http.MethodGet, "/rocket-pool/minipools", hs.PublicGetRocketPoolMinipools, nil},
-
-		{http.MethodGet, "/networks/{network}/sync-committee/{period}", hs.PublicGetNetworkSyncCommittee, nil},
-
-		{http.MethodGet, "/multisig-safes/{address}", hs.PublicGetMultisigSafe, nil},
-		{http.MethodGet, "/multisig-safes/{address}/transactions", hs.PublicGetMultisigSafeTransactions, nil},
-		{http.MethodGet, "/multisig-transactions/{hash}/confirmations", hs.PublicGetMultisigTransactionConfirmations, nil},
+		{http.MethodGet, "/healthz", hs.PublicGetHealthz, nil, anyScope},
+		{http.MethodGet, "/healthz-loadbalancer", hs.PublicGetHealthzLoadbalancer, nil, anyScope},
+
+		{http.MethodGet, "/ratelimit-weights", nil, hs.InternalGetRatelimitWeights, internalOnly},
+
+		{http.MethodPost, "/login", nil, hs.InternalPostLogin, internalOnly},
+		{http.MethodPost, "/login/second-factor", nil, hs.InternalPostLoginSecondFactor, internalOnly},
+		{http.MethodPost, "/login/webauthn-challenges", nil, hs.InternalPostLoginWebAuthnChallenges, internalOnly},
+
+		{http.MethodGet, "/oauth/authorize", nil, hs.InternalGetOauthAuthorize, internalOnly},
+		{http.MethodPost, "/oauth/authorize", nil, hs.InternalPostOauthAuthorize, internalOnly},
+
+		{http.MethodGet, "/mobile/authorize", nil, hs.InternalPostMobileAuthorize, internalOnly},
+		{http.MethodPost, "/mobile/equivalent-exchange", nil, hs.InternalPostMobileEquivalentExchange, internalOnly},
+		{http.MethodPost, "/mobile/purchase", nil, hs.InternalHandleMobilePurchase, internalOnly},
+		{http.MethodGet, "/mobile/latest-bundle", nil, hs.InternalGetMobileLatestBundle, internalOnly},
+		{http.MethodPost, "/mobile/bundles/{bundle_version}/deliveries", nil, hs.InternalPostMobileBundleDeliveries, internalOnly},
+
+		{http.MethodPost, "/logout", nil, hs.InternalPostLogout, internalOnly},
+
+		{http.MethodGet, "/latest-state", nil, hs.InternalGetLatestState, internalOnly},
+
+		{http.MethodGet, "/product-summary", nil, hs.InternalGetProductSummary, internalOnly},
+
+		{http.MethodPost, "/ad-configurations", nil, hs.InternalPostAdConfigurations, internalOnly},
+		{http.MethodGet, "/ad-configurations", nil, hs.InternalGetAdConfigurations, internalOnly},
+		{http.MethodPut, "/ad-configurations/{key}", nil, hs.InternalPutAdConfiguration, internalOnly},
+		{http.MethodDelete, "/ad-configurations/{key}", nil, hs.InternalDeleteAdConfiguration, internalOnly},
+
+		{http.MethodGet, "/monitoring/status", nil, hs.InternalGetMonitoringStatus, internalOnly},
+
+		{http.MethodPost, "/users", nil, hs.InternalPostUsers, internalOnly},
+		{http.MethodPost, "/users/email-confirmations/{token}", nil, hs.InternalPostUserConfirm, internalOnly},
+		{http.MethodPost, "/users/password-resets", nil, hs.InternalPostUserPasswordReset, internalOnly},
+		{http.MethodPost, "/users/password-resets/{token}", nil, hs.InternalPostUserPasswordResetHash, internalOnly},
+		{http.MethodGet, "/users/me", nil, hs.InternalGetUserInfo, internalOnly},
+		{http.MethodDelete, "/users/me", nil, hs.InternalDeleteUser, internalOnly},
+		{http.MethodPost, "/users/me/email", nil, hs.InternalPostUserEmail, internalOnly},
+		{http.MethodPut, "/users/me/password", nil, hs.InternalPutUserPassword, internalOnly},
+		{http.MethodGet, "/users/me/dashboards", hs.PublicGetUserDashboards, hs.InternalGetUserDashboards, readDashboards},
+		{http.MethodGet, "/users/me/api-keys", nil, hs.InternalGetUserApiKeys, internalOnly},
+		{http.MethodPost, "/users/me/api-keys", nil, hs.InternalPostUserApiKeys, internalOnly},
+		{http.MethodPut, "/users/me/api-keys/{api_key_id}", nil, hs.InternalPutUserApiKey, internalOnly},
+		{http.MethodDelete, "/users/me/api-keys/{api_key_id}", nil, hs.InternalDeleteUserApiKey, internalOnly},
+		{http.MethodPost, "/users/me/api-keys/{api_key_id}/rotations", nil, hs.InternalPostUserApiKeyRotations, internalOnly},
+		{http.MethodGet, "/users/me/api-usage", hs.PublicGetUserApiUsage, hs.InternalGetUserApiUsage, anyScope},
+		{http.MethodGet, "/users/me/oauth-apps", nil, hs.InternalGetUserOAuthApps, internalOnly},
+		{http.MethodPost, "/users/me/oauth-apps", nil, hs.InternalPostUserOAuthApps, internalOnly},
+		{http.MethodDelete, "/users/me/oauth-apps/{app_id}", nil, hs.InternalDeleteUserOAuthApp, internalOnly},
+		{http.MethodGet, "/users/me/second-factors", nil, hs.InternalGetUserSecondFactors, internalOnly},
+		{http.MethodPost, "/users/me/totp", nil, hs.InternalPostUserTotp, internalOnly},
+		{http.MethodDelete, "/users/me/totp", nil, hs.InternalDeleteUserTotp, internalOnly},
+		{http.MethodPost, "/users/me/totp/confirmations", nil, hs.InternalPostUserTotpConfirmations, internalOnly},
+		{http.MethodPost, "/users/me/recovery-codes", nil, hs.InternalPostUserRecoveryCodes, internalOnly},
+		{http.MethodPost, "/users/me/webauthn-challenges", nil, hs.InternalPostUserWebAuthnChallenges, internalOnly},
+		{http.MethodPost, "/users/me/webauthn-credentials", nil, hs.InternalPostUserWebAuthnCredentials, internalOnly},
+		{http.MethodDelete, "/users/me/webauthn-credentials/{credential_id}", nil, hs.InternalDeleteUserWebAuthnCredential, internalOnly},
+		{http.MethodGet, "/users/me/sessions", nil, hs.InternalGetUserSessions, internalOnly},
+		{http.MethodDelete, "/users/me/sessions/{session_id}", nil, hs.InternalDeleteUserSession, internalOnly},
+		{http.MethodGet, "/users/me/organizations", nil, hs.InternalGetUserOrganizations, internalOnly},
+		{http.MethodPost, "/users/me/organizations", nil, hs.InternalPostUserOrganizations, internalOnly},
+		{http.MethodPost, "/users/me/organization-memberships", nil, hs.InternalPostUserOrganizationMemberships, internalOnly},
+		{http.MethodGet, "/organizations/{organization_id}", nil, hs.InternalGetOrganization, internalOnly},
+		{http.MethodPut, "/organizations/{organization_id}", nil, hs.InternalPutOrganization, internalOnly},
+		{http.MethodDelete, "/organizations/{organization_id}", nil, hs.InternalDeleteOrganization, internalOnly},
+		{http.MethodGet, "/organizations/{organization_id}/members", nil, hs.InternalGetOrganizationMembers, internalOnly},
+		{http.MethodPut, "/organizations/{organization_id}/members/{user_id}", nil, hs.InternalPutOrganizationMember, internalOnly},
+		{http.MethodDelete, "/organizations/{organization_id}/members/{user_id}", nil, hs.InternalDeleteOrganizationMember, internalOnly},
+		{http.MethodGet, "/organizations/{organization_id}/invitations", nil, hs.InternalGetOrganizationInvitations, internalOnly},
+		{http.MethodPost, "/organizations/{organization_id}/invitations", nil, hs.InternalPostOrganizationInvitations, internalOnly},
+		{http.MethodDelete, "/organizations/{organization_id}/invitations/{invitation_id}", nil, hs.InternalDeleteOrganizationInvitation, internalOnly},
+		{http.MethodPut, "/users/me/notifications/settings/paired-devices/{client_id}/token", nil, hs.InternalPostUsersMeNotificationSettingsPairedDevicesToken, internalOnly},
+
+		{http.MethodGet, "/users/me/machine-metrics", hs.PublicGetUserMachineMetrics, hs.InternalGetUserMachineMetrics, anyScope},
+		{http.MethodPost, "/users/me/machine-metrics", hs.PublicPostUserMachineMetrics, nil, pushMachineMetrics},
+		{http.MethodGet, "/users/me/machine-metrics/series", hs.PublicGetUserMachineMetricSeries, hs.InternalGetUserMachineMetricSeries, anyScope},
+
+		{http.MethodPost, "/search", nil, hs.InternalPostSearch, internalOnly},
+
+		{http.MethodGet, "/networks/{network}/validators", hs.PublicGetNetworkValidators, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/validators/{validator}", hs.PublicGetNetworkValidator, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/validators/{validator}/duties", hs.PublicGetNetworkValidatorDuties, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/validators/{validator}/pool-operations", hs.PublicGetNetworkValidatorPoolOperations, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/addresses/{address}/validators", hs.PublicGetNetworkAddressValidators, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/withdrawal-credentials/{credential}/validators", hs.PublicGetNetworkWithdrawalCredentialValidators, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/validator-statuses", hs.PublicGetNetworkValidatorStatuses, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/validator-leaderboard", hs.PublicGetNetworkValidatorLeaderboard, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/validator-queue", hs.PublicGetNetworkValidatorQueue, nil, anyScope},
+
+		{http.MethodGet, "/networks/{network}/epochs", hs.PublicGetNetworkEpochs, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/epochs/{epoch}", hs.PublicGetNetworkEpoch, nil, anyScope},
+
+		{http.MethodGet, "/networks/{network}/blocks", hs.PublicGetNetworkBlocks, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/blocks/{block}", nil, hs.InternalGetBlock, internalOnly},
+		{http.MethodGet, "/networks/{network}/blocks/{block}/overview", hs.PublicGetNetworkBlock, hs.InternalGetBlockOverview, anyScope},
+		{http.MethodGet, "/networks/{network}/blocks/{block}/votes", hs.PublicGetNetworkBlockVotes, hs.InternalGetBlockVotes, anyScope},
+		{http.MethodGet, "/networks/{network}/slots", hs.PublicGetNetworkSlots, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/slots/{slot}", nil, hs.InternalGetSlot, internalOnly},
+		{http.MethodGet, "/networks/{network}/slots/{slot}/overview", hs.PublicGetNetworkSlot, hs.InternalGetSlotOverview, anyScope},
+		{http.MethodGet, "/networks/{network}/slots/{slot}/votes", hs.PublicGetNetworkSlotVotes, hs.InternalGetSlotVotes, anyScope},
+		{http.MethodGet, "/networks/{network}/validators/{validator}/blocks", hs.PublicGetNetworkValidatorBlocks, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/addresses/{address}/priority-fee-blocks", hs.PublicGetNetworkAddressPriorityFeeBlocks, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/addresses/{address}/proposer-reward-blocks", hs.PublicGetNetworkAddressProposerRewardBlocks, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/forked-blocks", hs.PublicGetNetworkForkedBlocks, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/forked-blocks/{block}", hs.PublicGetNetworkForkedBlock, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/forked-slots/{slot}", hs.PublicGetNetworkForkedSlot, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/block-sizes", hs.PublicGetNetworkBlockSizes, nil, anyScope},
+
+		{http.MethodGet, "/networks/{network}/validators/{validator}/attestations", hs.PublicGetNetworkValidatorAttestations, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/epochs/{epoch}/attestations", hs.PublicGetNetworkEpochAttestations, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/slots/{slot}/attestations", hs.PublicGetNetworkSlotAttestations, hs.InternalGetSlotAttestations, anyScope},
+		{http.MethodGet, "/networks/{network}/blocks/{block}/attestations", hs.PublicGetNetworkBlockAttestations, hs.InternalGetBlockAttestations, anyScope},
+		{http.MethodGet, "/networks/{network}/aggregated-attestations", hs.PublicGetNetworkAggregatedAttestations, nil, anyScope},
+
+		{http.MethodGet, "/networks/{network}/ethstore/{day}", hs.PublicGetNetworkEthStore, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/validators/{validator}/reward-history", hs.PublicGetNetworkValidatorRewardHistory, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/validators/{validator}/balance-history", hs.PublicGetNetworkValidatorBalanceHistory, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/validators/{validator}/performance-history", hs.PublicGetNetworkValidatorPerformanceHistory, nil, anyScope},
+
+		{http.MethodGet, "/networks/{network}/slashings", hs.PublicGetNetworkSlashings, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/validators/{validator}/slashings", hs.PublicGetNetworkValidatorSlashings, nil, anyScope},
+
+		{http.MethodGet, "/networks/{network}/deposits", hs.PublicGetNetworkDeposits, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/validators/{validator}/deposits", hs.PublicGetNetworkValidatorDeposits, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/transactions/{hash}/deposits", hs.PublicGetNetworkTransactionDeposits, nil, anyScope},
+
+		{http.MethodGet, "/networks/{network}/withdrawals", hs.PublicGetNetworkWithdrawals, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/slots/{slot}/withdrawals", hs.PublicGetNetworkSlotWithdrawals, hs.InternalGetSlotWithdrawals, anyScope},
+		{http.MethodGet, "/networks/{network}/blocks/{block}/withdrawals", hs.PublicGetNetworkBlockWithdrawals, hs.InternalGetBlockWithdrawals, anyScope},
+		{http.MethodGet, "/networks/{network}/validators/{validator}/withdrawals", hs.PublicGetNetworkValidatorWithdrawals, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/withdrawal-credentials/{credential}/withdrawals", hs.PublicGetNetworkWithdrawalCredentialWithdrawals, nil, anyScope},
+
+		{http.MethodGet, "/networks/{network}/voluntary-exits", hs.PublicGetNetworkVoluntaryExits, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/epochs/{epoch}/voluntary-exits", hs.PublicGetNetworkEpochVoluntaryExits, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/slots/{slot}/voluntary-exits", hs.PublicGetNetworkSlotVoluntaryExits, hs.InternalGetSlotVoluntaryExits, anyScope},
+		{http.MethodGet, "/networks/{network}/blocks/{block}/voluntary-exits", hs.PublicGetNetworkBlockVoluntaryExits, hs.InternalGetBlockVoluntaryExits, anyScope},
+
+		{http.MethodGet, "/networks/{network}/addresses/{address}/balance-history", hs.PublicGetNetworkAddressBalanceHistory, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/addresses/{address}/token-supply-history", hs.PublicGetNetworkAddressTokenSupplyHistory, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/addresses/{address}/event-logs", hs.PublicGetNetworkAddressEventLogs, nil, anyScope},
+
+		{http.MethodGet, "/networks/{network}/transactions", hs.PublicGetNetworkTransactions, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/transactions/{hash}", hs.PublicGetNetworkTransaction, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/addresses/{address}/transactions", hs.PublicGetNetworkAddressTransactions, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/slots/{slot}/transactions", hs.PublicGetNetworkSlotTransactions, hs.InternalGetSlotTransactions, anyScope},
+		{http.MethodGet, "/networks/{network}/blocks/{block}/transactions", hs.PublicGetNetworkBlockTransactions, hs.InternalGetBlockTransactions, anyScope},
+		{http.MethodGet, "/networks/{network}/slots/{slot}/blobs", hs.PublicGetNetworkSlotBlobs, hs.InternalGetSlotBlobs, anyScope},
+		{http.MethodGet, "/networks/{network}/blocks/{block}/blobs", hs.PublicGetNetworkBlockBlobs, hs.InternalGetBlockBlobs, anyScope},
+
+		{http.MethodGet, "/networks/{network}/bls-changes", hs.PublicGetNetworkBlsChanges, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/epochs/{epoch}/bls-changes", hs.PublicGetNetworkEpochBlsChanges, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/slots/{slot}/bls-changes", hs.PublicGetNetworkSlotBlsChanges, hs.InternalGetSlotBlsChanges, anyScope},
+		{http.MethodGet, "/networks/{network}/blocks/{block}/bls-changes", hs.PublicGetNetworkBlockBlsChanges, hs.InternalGetBlockBlsChanges, anyScope},
+		{http.MethodGet, "/networks/{network}/validators/{validator}/bls-changes", hs.PublicGetNetworkValidatorBlsChanges, nil, anyScope},
+
+		{http.MethodGet, "/networks/ethereum/addresses/{address}/ens", hs.PublicGetNetworkAddressEns, nil, anyScope},
+		{http.MethodGet, "/networks/ethereum/ens/{ens_name}", hs.PublicGetNetworkEns, nil, anyScope},
+
+		{http.MethodGet, "/networks/{layer_2_network}/batches", hs.PublicGetNetworkBatches, nil, anyScope},
+		{http.MethodGet, "/networks/{layer_2_network}/layer1-to-layer2-transactions", hs.PublicGetNetworkLayer1ToLayer2Transactions, nil, anyScope},
+		{http.MethodGet, "/networks/{layer_2_network}/layer2-to-layer1-transactions", hs.PublicGetNetworkLayer2ToLayer1Transactions, nil, anyScope},
+
+		{http.MethodPost, "/networks/{network}/broadcasts", hs.PublicPostNetworkBroadcasts, nil, manageBroadcasts},
+		{http.MethodGet, "/networks/{network}/broadcasts/{broadcast_id}", hs.PublicGetNetworkBroadcast, nil, anyScope},
+		{http.MethodPost, "/networks/{network}/broadcasts/{broadcast_id}/triggers", hs.PublicPostNetworkBroadcastTriggers, nil, manageBroadcasts},
+		{http.MethodGet, "/eth-price-history", hs.PublicGetEthPriceHistory, nil, anyScope},
+
+		{http.MethodGet, "/networks/{network}/gasnow", hs.PublicGetNetworkGasNow, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/average-gas-limit-history", hs.PublicGetNetworkAverageGasLimitHistory, nil, anyScope},
+		{http.MethodGet, "/networks/{network}/gas-used-history", hs.PublicGetNetworkGasUsedHistory, nil, anyScope},
+
+		{http.MethodGet, "/rocket-pool", hs.PublicGetRocketPool, hs.InternalGetRocketPool, anyScope},
+		{http.MethodGet, "/rocket-pool/nodes", hs.PublicGetRocketPoolNodes, nil, anyScope},
+		{http.MethodGet, "/rocket-pool/minipools", hs.PublicGetRocketPoolMinipools, nil, anyScope},
+
+		{http.MethodGet, "/networks/{network}/sync-committee/{period}", hs.PublicGetNetworkSyncCommittee, nil, anyScope},
+
+		{http.MethodGet, "/multisig-safes/{address}", hs.PublicGetMultisigSafe, nil, anyScope},
+		{http.MethodGet, "/multisig-safes/{address}/transactions", hs.PublicGetMultisigSafeTransactions, nil, anyScope},
+		{http.MethodGet, "/multisig-transactions/{hash}/confirmations", hs.PublicGetMultisigTransactionConfirmations, nil, anyScope},
 	}
-	addEndpointsToRouters(endpoints, publicRouter, internalRouter)
+	addEndpointsToRouters(hs, endpoints, publicRouter, internalRouter)
 }
 
 // Legacy routes are available behind the /v1 prefix and guarantee backwards compatibility with the old API
@@ -255,14 +305,23 @@ func addLegacyRoutes(hs *handlers.HandlerService, publicRouter *mux.Router) {
 	publicRouter.HandleFunc("/client/metrics", hs.LegacyPostUserMachineMetrics).Methods(http.MethodPost, http.MethodOptions)
 }
 
+func addOAuthRoutes(hs *handlers.HandlerService, oauthRouter *mux.Router) {
+	oauthRouter.HandleFunc("/token", hs.OAuthPostToken).Methods(http.MethodPost, http.MethodOptions)
+	oauthRouter.HandleFunc("/revoke", hs.OAuthPostRevoke).Methods(http.MethodPost, http.MethodOptions)
+}
+
 func addValidatorDashboardRoutes(hs *handlers.HandlerService, publicRouter, internalRouter *mux.Router, cfg *types.Config) {
 	vdbPath := "/validator-dashboards"
-	publicRouter.HandleFunc(vdbPath, hs.PublicPostValidatorDashboards).Methods(http.MethodPost, http.MethodOptions)
+	hs.HandlePublic(publicRouter, vdbPath, hs.PublicPostValidatorDashboards, manageDashboards).Methods(http.MethodPost, http.MethodOptions)
 	internalRouter.HandleFunc(vdbPath, hs.InternalPostValidatorDashboards).Methods(http.MethodPost, http.MethodOptions)
 
 	publicDashboardRouter := publicRouter.PathPrefix(vdbPath).Subrouter()
 	internalDashboardRouter := internalRouter.PathPrefix(vdbPath).Subrouter()
 
+	// serve dashboards by the network they were created for
+	publicDashboardRouter.Use(hs.VDBNetworkMiddleware)
+	internalDashboardRouter.Use(hs.VDBNetworkMiddleware)
+
 	// add middleware to check if user has access to dashboard
 	if !cfg.Frontend.Debug {
 		publicDashboardRouter.Use(hs.VDBAuthMiddleware, hs.ManageDashboardsViaApiCheckMiddleware)
@@ -270,11 +329,12 @@ func addValidatorDashboardRoutes(hs *handlers.HandlerService, publicRouter, inte
 	}
 
 	archivalEndpoints := []endpoint{
-		{http.MethodDelete, "/{dashboard_id}", hs.PublicDeleteValidatorDashboard, hs.InternalDeleteValidatorDashboard},
-		{http.MethodPut, "/{dashboard_id}/archiving", hs.PublicPutValidatorDashboardArchiving, hs.InternalPutValidatorDashboardArchiving},
+		{http.MethodDelete, "/{dashboard_id}", hs.PublicDeleteValidatorDashboard, hs.InternalDeleteValidatorDashboard, manageDashboards},
+		{http.MethodPut, "/{dashboard_id}/archiving", hs.PublicPutValidatorDashboardArchiving, hs.InternalPutValidatorDashboardArchiving, manageDashboards},
+		{http.MethodPut, "/{dashboard_id}/organization", nil, hs.InternalPutValidatorDashboardOrganization, internalOnly},
 	}
 
-	addEndpointsToRouters(archivalEndpoints, publicDashboardRouter, internalDashboardRouter)
+	addEndpointsToRouters(hs, archivalEndpoints, publicDashboardRouter, internalDashboardRouter)
 
 	// create new subrouters for archived check middleware, will be used for all endpoints added after this
 	if !cfg.Frontend.Debug {
@@ -285,43 +345,82 @@ func addValidatorDashboardRoutes(hs *handlers.HandlerService, publicRouter, inte
 	}
 
 	endpoints := []endpoint{
-		{http.MethodGet, "/{dashboard_id}", hs.PublicGetValidatorDashboard, hs.InternalGetValidatorDashboard},
-		{http.MethodPut, "/{dashboard_id}/name", hs.PublicPutValidatorDashboardName, hs.InternalPutValidatorDashboardName},
-		{http.MethodPost, "/{dashboard_id}/groups", hs.PublicPostValidatorDashboardGroups, hs.InternalPostValidatorDashboardGroups},
-		{http.MethodPut, "/{dashboard_id}/groups/{group_id}", hs.PublicPutValidatorDashboardGroups, hs.InternalPutValidatorDashboardGroups},
-		{http.MethodDelete, "/{dashboard_id}/groups/{group_id}", hs.PublicDeleteValidatorDashboardGroup, hs.InternalDeleteValidatorDashboardGroup},
-		{http.MethodDelete, "/{dashboard_id}/groups/{group_id}/validators", hs.PublicDeleteValidatorDashboardGroupValidators, hs.InternalDeleteValidatorDashboardGroupValidators},
-		{http.MethodPost, "/{dashboard_id}/validators", hs.PublicPostValidatorDashboardValidators, hs.InternalPostValidatorDashboardValidators},
-		{http.MethodGet, "/{dashboard_id}/validators", hs.PublicGetValidatorDashboardValidators, hs.InternalGetValidatorDashboardValidators},
-		{http.MethodPost, "/{dashboard_id}/validators/bulk-deletions", hs.PublicDeleteValidatorDashboardValidators, hs.InternalDeleteValidatorDashboardValidators},
-		{http.MethodPost, "/{dashboard_id}/public-ids", hs.PublicPostValidatorDashboardPublicIds, hs.InternalPostValidatorDashboardPublicIds},
-		{http.MethodPut, "/{dashboard_id}/public-ids/{public_id}", hs.PublicPutValidatorDashboardPublicId, hs.InternalPutValidatorDashboardPublicId},
-		{http.MethodDelete, "/{dashboard_id}/public-ids/{public_id}", hs.PublicDeleteValidatorDashboardPublicId, hs.InternalDeleteValidatorDashboardPublicId},
-		{http.MethodGet, "/{dashboard_id}/slot-viz", hs.PublicGetValidatorDashboardSlotViz, hs.InternalGetValidatorDashboardSlotViz},
-		{http.MethodGet, "/{dashboard_id}/summary", hs.PublicGetValidatorDashboardSummary, hs.InternalGetValidatorDashboardSummary},
-		{http.MethodGet, "/{dashboard_id}/summary/validators", hs.PublicGetValidatorDashboardSummaryValidators, hs.InternalGetValidatorDashboardSummaryValidators},
-		{http.MethodGet, "/{dashboard_id}/groups/{group_id}/summary", hs.PublicGetValidatorDashboardGroupSummary, hs.InternalGetValidatorDashboardGroupSummary},
-		{http.MethodGet, "/{dashboard_id}/summary-chart", hs.PublicGetValidatorDashboardSummaryChart, hs.InternalGetValidatorDashboardSummaryChart},
-		{http.MethodGet, "/{dashboard_id}/rewards", hs.PublicGetValidatorDashboardRewards, hs.InternalGetValidatorDashboardRewards},
-		{http.MethodGet, "/{dashboard_id}/groups/{group_id}/rewards/{epoch}", hs.PublicGetValidatorDashboardGroupRewards, hs.InternalGetValidatorDashboardGroupRewards},
-		{http.MethodGet, "/{dashboard_id}/rewards-chart", hs.PublicGetValidatorDashboardRewardsChart, hs.InternalGetValidatorDashboardRewardsChart},
-		{http.MethodGet, "/{dashboard_id}/duties/{epoch}", hs.PublicGetValidatorDashboardDuties, hs.InternalGetValidatorDashboardDuties},
-		{http.MethodGet, "/{dashboard_id}/blocks", hs.PublicGetValidatorDashboardBlocks, hs.InternalGetValidatorDashboardBlocks},
-		{http.MethodGet, "/{dashboard_id}/heatmap", hs.PublicGetValidatorDashboardHeatmap, hs.InternalGetValidatorDashboardHeatmap},
-		{http.MethodGet, "/{dashboard_id}/groups/{group_id}/heatmap/{timestamp}", hs.PublicGetValidatorDashboardGroupHeatmap, hs.InternalGetValidatorDashboardGroupHeatmap},
-		{http.MethodGet, "/{dashboard_id}/execution-layer-deposits", hs.PublicGetValidatorDashboardExecutionLayerDeposits, hs.InternalGetValidatorDashboardExecutionLayerDeposits},
-		{http.MethodGet, "/{dashboard_id}/consensus-layer-deposits", hs.PublicGetValidatorDashboardConsensusLayerDeposits, hs.InternalGetValidatorDashboardConsensusLayerDeposits},
-		{http.MethodGet, "/{dashboard_id}/total-execution-layer-deposits", hs.PublicGetValidatorDashboardTotalExecutionLayerDeposits, hs.InternalGetValidatorDashboardTotalExecutionLayerDeposits},
-		{http.MethodGet, "/{dashboard_id}/total-consensus-layer-deposits", hs.PublicGetValidatorDashboardTotalConsensusLayerDeposits, hs.InternalGetValidatorDashboardTotalConsensusLayerDeposits},
-		{http.MethodGet, "/{dashboard_id}/withdrawals", hs.PublicGetValidatorDashboardWithdrawals, hs.InternalGetValidatorDashboardWithdrawals},
-		{http.MethodGet, "/{dashboard_id}/total-withdrawals", hs.PublicGetValidatorDashboardTotalWithdrawals, hs.InternalGetValidatorDashboardTotalWithdrawals},
-		{http.MethodGet, "/{dashboard_id}/rocket-pool", hs.PublicGetValidatorDashboardRocketPool, hs.InternalGetValidatorDashboardRocketPool},
-		{http.MethodGet, "/{dashboard_id}/total-rocket-pool", hs.PublicGetValidatorDashboardTotalRocketPool, hs.InternalGetValidatorDashboardTotalRocketPool},
-		{http.MethodGet, "/{dashboard_id}/rocket-pool/{node_address}/minipools", hs.PublicGetValidatorDashboardRocketPoolMinipools, hs.InternalGetValidatorDashboardRocketPoolMinipools},
-		{http.MethodGet, "/{dashboard_id}/mobile/widget", nil, hs.InternalGetValidatorDashboardMobileWidget},
-		{http.MethodGet, "/{dashboard_id}/mobile/validators", nil, hs.InternalGetValidatorDashboardMobileValidators},
+		{http.MethodGet, "/{dashboard_id}", hs.PublicGetValidatorDashboard, hs.InternalGetValidatorDashboard, readDashboards},
+		{http.MethodPut, "/{dashboard_id}/name", hs.PublicPutValidatorDashboardName, hs.InternalPutValidatorDashboardName, manageDashboards},
+		{http.MethodPut, "/{dashboard_id}/operator-shares/{protocol}", hs.PublicPutValidatorDashboardOperatorShare, hs.InternalPutValidatorDashboardOperatorShare, manageDashboards},
+		{http.MethodDelete, "/{dashboard_id}/operator-shares/{protocol}", hs.PublicDeleteValidatorDashboardOperatorShare, hs.InternalDeleteValidatorDashboardOperatorShare, manageDashboards},
+		{http.MethodPost, "/{dashboard_id}/groups", hs.PublicPostValidatorDashboardGroups, hs.InternalPostValidatorDashboardGroups, manageDashboards},
+		{http.MethodPut, "/{dashboard_id}/groups/{group_id}", hs.PublicPutValidatorDashboardGroups, hs.InternalPutValidatorDashboardGroups, manageDashboards},
+		{http.MethodDelete, "/{dashboard_id}/groups/{group_id}", hs.PublicDeleteValidatorDashboardGroup, hs.InternalDeleteValidatorDashboardGroup, manageDashboards},
+		{http.MethodDelete, "/{dashboard_id}/groups/{group_id}/validators", hs.PublicDeleteValidatorDashboardGroupValidators, hs.InternalDeleteValidatorDashboardGroupValidators, manageDashboards},
+		{http.MethodPost, "/{dashboard_id}/validators", hs.PublicPostValidatorDashboardValidators, hs.InternalPostValidatorDashboardValidators, manageDashboards},
+		{http.MethodGet, "/{dashboard_id}/validators", hs.PublicGetValidatorDashboardValidators, hs.InternalGetValidatorDashboardValidators, readDashboards},
+		{http.MethodPost, "/{dashboard_id}/validators/bulk-deletions", hs.PublicDeleteValidatorDashboardValidators, hs.InternalDeleteValidatorDashboardValidators, manageDashboards},
+		{http.MethodPost, "/{dashboard_id}/public-ids", hs.PublicPostValidatorDashboardPublicIds, hs.InternalPostValidatorDashboardPublicIds, manageDashboards},
+		{http.MethodPut, "/{dashboard_id}/public-ids/{public_id}", hs.PublicPutValidatorDashboardPublicId, hs.InternalPutValidatorDashboardPublicId, manageDashboards},
+		{http.MethodDelete, "/{dashboard_id}/public-ids/{public_id}", hs.PublicDeleteValidatorDashboardPublicId, hs.InternalDeleteValidatorDashboardPublicId, manageDashboards},
+		{http.MethodGet, "/{dashboard_id}/slot-viz", hs.PublicGetValidatorDashboardSlotViz, hs.InternalGetValidatorDashboardSlotViz, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/events", hs.PublicGetValidatorDashboardEvents, hs.InternalGetValidatorDashboardEvents, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/summary", hs.PublicGetValidatorDashboardSummary, hs.InternalGetValidatorDashboardSummary, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/summary/validators", hs.PublicGetValidatorDashboardSummaryValidators, hs.InternalGetValidatorDashboardSummaryValidators, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/groups/{group_id}/summary", hs.PublicGetValidatorDashboardGroupSummary, hs.InternalGetValidatorDashboardGroupSummary, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/summary-chart", hs.PublicGetValidatorDashboardSummaryChart, hs.InternalGetValidatorDashboardSummaryChart, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/rewards", hs.PublicGetValidatorDashboardRewards, hs.InternalGetValidatorDashboardRewards, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/groups/{group_id}/rewards/{epoch}", hs.PublicGetValidatorDashboardGroupRewards, hs.InternalGetValidatorDashboardGroupRewards, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/rewards-chart", hs.PublicGetValidatorDashboardRewardsChart, hs.InternalGetValidatorDashboardRewardsChart, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/duties/{epoch}", hs.PublicGetValidatorDashboardDuties, hs.InternalGetValidatorDashboardDuties, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/blocks", hs.PublicGetValidatorDashboardBlocks, hs.InternalGetValidatorDashboardBlocks, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/heatmap", hs.PublicGetValidatorDashboardHeatmap, hs.InternalGetValidatorDashboardHeatmap, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/groups/{group_id}/heatmap/{timestamp}", hs.PublicGetValidatorDashboardGroupHeatmap, hs.InternalGetValidatorDashboardGroupHeatmap, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/execution-layer-deposits", hs.PublicGetValidatorDashboardExecutionLayerDeposits, hs.InternalGetValidatorDashboardExecutionLayerDeposits, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/consensus-layer-deposits", hs.PublicGetValidatorDashboardConsensusLayerDeposits, hs.InternalGetValidatorDashboardConsensusLayerDeposits, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/total-execution-layer-deposits", hs.PublicGetValidatorDashboardTotalExecutionLayerDeposits, hs.InternalGetValidatorDashboardTotalExecutionLayerDeposits, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/total-consensus-layer-deposits", hs.PublicGetValidatorDashboardTotalConsensusLayerDeposits, hs.InternalGetValidatorDashboardTotalConsensusLayerDeposits, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/withdrawals", hs.PublicGetValidatorDashboardWithdrawals, hs.InternalGetValidatorDashboardWithdrawals, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/total-withdrawals", hs.PublicGetValidatorDashboardTotalWithdrawals, hs.InternalGetValidatorDashboardTotalWithdrawals, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/pool-operations", hs.PublicGetValidatorDashboardPoolOperations, hs.InternalGetValidatorDashboardPoolOperations, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/exports/{export_type}", hs.PublicGetValidatorDashboardExport, hs.InternalGetValidatorDashboardExport, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/income-report", hs.PublicGetValidatorDashboardIncomeReport, hs.InternalGetValidatorDashboardIncomeReport, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/rocket-pool", hs.PublicGetValidatorDashboardRocketPool, hs.InternalGetValidatorDashboardRocketPool, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/total-rocket-pool", hs.PublicGetValidatorDashboardTotalRocketPool, hs.InternalGetValidatorDashboardTotalRocketPool, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/rocket-pool/{node_address}/minipools", hs.PublicGetValidatorDashboardRocketPoolMinipools, hs.InternalGetValidatorDashboardRocketPoolMinipools, readDashboards},
+		{http.MethodGet, "/{dashboard_id}/mobile/widget", nil, hs.InternalGetValidatorDashboardMobileWidget, internalOnly},
+		{http.MethodGet, "/{dashboard_id}/mobile/validators", nil, hs.InternalGetValidatorDashboardMobileValidators, internalOnly},
+	}
+	addEndpointsToRouters(hs, endpoints, publicDashboardRouter, internalDashboardRouter)
+}
+
+func addAccountDashboardRoutes(hs *handlers.HandlerService, publicRouter, internalRouter *mux.Router, cfg *types.Config) {
+	adbPath := "/account-dashboards"
+	hs.HandlePublic(publicRouter, adbPath, hs.PublicPostAccountDashboards, manageDashboards).Methods(http.MethodPost, http.MethodOptions)
+	internalRouter.HandleFunc(adbPath, hs.InternalPostAccountDashboards).Methods(http.MethodPost, http.MethodOptions)
+
+	publicDashboardRouter := publicRouter.PathPrefix(adbPath).Subrouter()
+	internalDashboardRouter := internalRouter.PathPrefix(adbPath).Subrouter()
+
+	// add middleware to check if user has access to dashboard
+	if !cfg.Frontend.Debug {
+		publicDashboardRouter.Use(hs.ADBAuthMiddleware, hs.ManageDashboardsViaApiCheckMiddleware)
+		internalDashboardRouter.Use(hs.ADBAuthMiddleware)
+	}
+
+	endpoints := []endpoint{
+		{http.MethodGet, "/{dashboard_id}", hs.PublicGetAccountDashboard, hs.InternalGetAccountDashboard, readDashboards},
+		{http.MethodDelete, "/{dashboard_id}", hs.PublicDeleteAccountDashboard, hs.InternalDeleteAccountDashboard, manageDashboards},
+		{http.MethodPut, "/{dashboard_id}/name", hs.PublicPutAccountDashboardName, hs.InternalPutAccountDashboardName, manageDashboards},
+		{http.MethodPost, "/{dashboard_id}/groups", hs.PublicPostAccountDashboardGroups, hs.InternalPostAccountDashboardGroups, manageDashboards},
+		{http.MethodDelete, "/{dashboard_id}/groups/{group_id}", hs.PublicDeleteAccountDashboardGroups, hs.InternalDeleteAccountDashboardGroups, manageDashboards},
+		{http.MethodPost, "/{dashboard_id}/accounts", hs.PublicPostAccountDashboardAccounts, hs.InternalPostAccountDashboardAccounts, manageDashboards},
+		{http.MethodGet, "/{dashboard_id}/accounts", hs.PublicGetAccountDashboardAccounts, hs.InternalGetAccountDashboardAccounts, readDashboards},
+		{http.MethodDelete, "/{dashboard_id}/accounts", hs.PublicDeleteAccountDashboardAccounts, hs.InternalDeleteAccountDashboardAccounts, manageDashboards},
+		{http.MethodPut, "/{dashboard_id}/accounts/{address}", hs.PublicPutAccountDashboardAccount, hs.InternalPutAccountDashboardAccount, manageDashboards},
+		{http.MethodPost, "/{dashboard_id}/public-ids", hs.PublicPostAccountDashboardPublicIds, hs.InternalPostAccountDashboardPublicIds, manageDashboards},
+		{http.MethodPut, "/{dashboard_id}/public-ids/{public_id}", hs.PublicPutAccountDashboardPublicId, hs.InternalPutAccountDashboardPublicId, manageDashboards},
+		{http.MethodDelete, "/{dashboard_id}/public-ids/{public_id}", hs.PublicDeleteAccountDashboardPublicId, hs.InternalDeleteAccountDashboardPublicId, manageDashboards},
+		{http.MethodGet, "/{dashboard_id}/transactions", hs.PublicGetAccountDashboardTransactions, hs.InternalGetAccountDashboardTransactions, readDashboards},
+		{http.MethodPut, "/{dashboard_id}/transactions/settings", hs.PublicPutAccountDashboardTransactionsSettings, hs.InternalPutAccountDashboardTransactionsSettings, manageDashboards},
 	}
-	addEndpointsToRouters(endpoints, publicDashboardRouter, internalDashboardRouter)
+	addEndpointsToRouters(hs, endpoints, publicDashboardRouter, internalDashboardRouter)
 }
 
 func addNotificationRoutes(hs *handlers.HandlerService, publicRouter, internalRouter *mux.Router, debug bool) {
@@ -333,44 +432,61 @@ func addNotificationRoutes(hs *handlers.HandlerService, publicRouter, internalRo
 		publicNotificationRouter.Use(hs.ManageNotificationsViaApiCheckMiddleware)
 	}
 	endpoints := []endpoint{
-		{http.MethodGet, "", hs.PublicGetUserNotifications, hs.InternalGetUserNotifications},
-		{http.MethodGet, "/dashboards", hs.PublicGetUserNotificationDashboards, hs.InternalGetUserNotificationDashboards},
-		{http.MethodGet, "/machines", hs.PublicGetUserNotificationMachines, hs.InternalGetUserNotificationMachines},
-		{http.MethodGet, "/clients", hs.PublicGetUserNotificationClients, hs.InternalGetUserNotificationClients},
-		{http.MethodGet, "/networks", hs.PublicGetUserNotificationNetworks, hs.InternalGetUserNotificationNetworks},
-		{http.MethodGet, "/settings", hs.PublicGetUserNotificationSettings, hs.InternalGetUserNotificationSettings},
-		{http.MethodPut, "/settings/general", hs.PublicPutUserNotificationSettingsGeneral, hs.InternalPutUserNotificationSettingsGeneral},
-		{http.MethodPut, "/settings/networks/{network}", hs.PublicPutUserNotificationSettingsNetworks, hs.InternalPutUserNotificationSettingsNetworks},
-		{http.MethodPut, "/settings/paired-devices/{paired_device_id}", hs.PublicPutUserNotificationSettingsPairedDevices, hs.InternalPutUserNotificationSettingsPairedDevices},
-		{http.MethodDelete, "/settings/paired-devices/{paired_device_id}", hs.PublicDeleteUserNotificationSettingsPairedDevices, hs.InternalDeleteUserNotificationSettingsPairedDevices},
-		{http.MethodPut, "/settings/clients/{client_id}", hs.PublicPutUserNotificationSettingsClient, hs.InternalPutUserNotificationSettingsClient},
-		{http.MethodGet, "/settings/dashboards", hs.PublicGetUserNotificationSettingsDashboards, hs.InternalGetUserNotificationSettingsDashboards},
-		{http.MethodPost, "/test-email", hs.PublicPostUserNotificationsTestEmail, hs.InternalPostUserNotificationsTestEmail},
-		{http.MethodPost, "/test-push", hs.PublicPostUserNotificationsTestPush, hs.InternalPostUserNotificationsTestPush},
-		{http.MethodPost, "/test-webhook", hs.PublicPostUserNotificationsTestWebhook, hs.InternalPostUserNotificationsTestWebhook},
+		{http.MethodGet, "", hs.PublicGetUserNotifications, hs.InternalGetUserNotifications, manageNotifications},
+		{http.MethodGet, "/dashboards", hs.PublicGetUserNotificationDashboards, hs.InternalGetUserNotificationDashboards, manageNotifications},
+		{http.MethodGet, "/machines", hs.PublicGetUserNotificationMachines, hs.InternalGetUserNotificationMachines, manageNotifications},
+		{http.MethodGet, "/clients", hs.PublicGetUserNotificationClients, hs.InternalGetUserNotificationClients, manageNotifications},
+		{http.MethodGet, "/networks", hs.PublicGetUserNotificationNetworks, hs.InternalGetUserNotificationNetworks, manageNotifications},
+		{http.MethodGet, "/settings", hs.PublicGetUserNotificationSettings, hs.InternalGetUserNotificationSettings, manageNotifications},
+		{http.MethodPut, "/settings/general", hs.PublicPutUserNotificationSettingsGeneral, hs.InternalPutUserNotificationSettingsGeneral, manageNotifications},
+		{http.MethodPut, "/settings/networks/{network}", hs.PublicPutUserNotificationSettingsNetworks, hs.InternalPutUserNotificationSettingsNetworks, manageNotifications},
+		{http.MethodPut, "/settings/paired-devices/{paired_device_id}", hs.PublicPutUserNotificationSettingsPairedDevices, hs.InternalPutUserNotificationSettingsPairedDevices, manageNotifications},
+		{http.MethodDelete, "/settings/paired-devices/{paired_device_id}", hs.PublicDeleteUserNotificationSettingsPairedDevices, hs.InternalDeleteUserNotificationSettingsPairedDevices, manageNotifications},
+		{http.MethodPut, "/settings/clients/{client_id}", hs.PublicPutUserNotificationSettingsClient, hs.InternalPutUserNotificationSettingsClient, manageNotifications},
+		{http.MethodGet, "/settings/dashboards", hs.PublicGetUserNotificationSettingsDashboards, hs.InternalGetUserNotificationSettingsDashboards, manageNotifications},
+		{http.MethodPost, "/test-email", hs.PublicPostUserNotificationsTestEmail, hs.InternalPostUserNotificationsTestEmail, manageNotifications},
+		{http.MethodPost, "/test-push", hs.PublicPostUserNotificationsTestPush, hs.InternalPostUserNotificationsTestPush, manageNotifications},
+		{http.MethodPost, "/test-webhook", hs.PublicPostUserNotificationsTestWebhook, hs.InternalPostUserNotificationsTestWebhook, manageNotifications},
+		{http.MethodPost, "/test-telegram", hs.PublicPostUserNotificationsTestTelegram, hs.InternalPostUserNotificationsTestTelegram, manageNotifications},
+		{http.MethodPost, "/test-slack", hs.PublicPostUserNotificationsTestSlack, hs.InternalPostUserNotificationsTestSlack, manageNotifications},
+		{http.MethodPost, "/test-matrix", hs.PublicPostUserNotificationsTestMatrix, hs.InternalPostUserNotificationsTestMatrix, manageNotifications},
+		{http.MethodPost, "/telegram-link", hs.PublicPostUserNotificationsTelegramLink, hs.InternalPostUserNotificationsTelegramLink, manageNotifications},
+		{http.MethodDelete, "/telegram-link", hs.PublicDeleteUserNotificationsTelegramLink, hs.InternalDeleteUserNotificationsTelegramLink, manageNotifications},
+		{http.MethodGet, "/webhook-dead-letters", hs.PublicGetUserNotificationWebhookDeadLetters, hs.InternalGetUserNotificationWebhookDeadLetters, manageNotifications},
+		{http.MethodPost, "/webhook-dead-letters/{dead_letter_id}/redeliveries", hs.PublicPostUserNotificationWebhookDeadLetterRedeliveries, hs.InternalPostUserNotificationWebhookDeadLetterRedeliveries, manageNotifications},
 	}
-	addEndpointsToRouters(endpoints, publicNotificationRouter, internalNotificationRouter)
+	addEndpointsToRouters(hs, endpoints, publicNotificationRouter, internalNotificationRouter)
 
 	publicDashboardNotificationSettingsRouter := publicNotificationRouter.NewRoute().Subrouter()
 	internalDashboardNotificationSettingsRouter := internalNotificationRouter.NewRoute().Subrouter()
-	// TODO add adb auth middleware to account dashboard endpoints once they are implemented
 	if !debug {
 		publicDashboardNotificationSettingsRouter.Use(hs.VDBAuthMiddleware)
 		internalDashboardNotificationSettingsRouter.Use(hs.VDBAuthMiddleware)
 	}
 	dashboardSettingsEndpoints := []endpoint{
-		{http.MethodGet, "/validator-dashboards/{dashboard_id}/groups/{group_id}/epochs/{epoch}", hs.PublicGetUserNotificationsValidatorDashboard, hs.InternalGetUserNotificationsValidatorDashboard},
-		{http.MethodGet, "/account-dashboards/{dashboard_id}/groups/{group_id}/epochs/{epoch}", hs.PublicGetUserNotificationsAccountDashboard, hs.InternalGetUserNotificationsAccountDashboard},
-		{http.MethodPut, "/settings/validator-dashboards/{dashboard_id}/groups/{group_id}", hs.PublicPutUserNotificationSettingsValidatorDashboard, hs.InternalPutUserNotificationSettingsValidatorDashboard},
-		{http.MethodPut, "/settings/account-dashboards/{dashboard_id}/groups/{group_id}", hs.PublicPutUserNotificationSettingsAccountDashboard, hs.InternalPutUserNotificationSettingsAccountDashboard},
+		{http.MethodGet, "/validator-dashboards/{dashboard_id}/groups/{group_id}/epochs/{epoch}", hs.PublicGetUserNotificationsValidatorDashboard, hs.InternalGetUserNotificationsValidatorDashboard, manageNotifications},
+		{http.MethodPut, "/settings/validator-dashboards/{dashboard_id}/groups/{group_id}", hs.PublicPutUserNotificationSettingsValidatorDashboard, hs.InternalPutUserNotificationSettingsValidatorDashboard, manageNotifications},
+		{http.MethodPost, "/settings/validator-dashboards/{dashboard_id}/groups/{group_id}/webhook-signing-secret", hs.PublicPostUserNotificationSettingsValidatorDashboardWebhookSecret, hs.InternalPostUserNotificationSettingsValidatorDashboardWebhookSecret, manageNotifications},
+	}
+	addEndpointsToRouters(hs, dashboardSettingsEndpoints, publicDashboardNotificationSettingsRouter, internalDashboardNotificationSettingsRouter)
+
+	publicAccountDashboardNotificationSettingsRouter := publicNotificationRouter.NewRoute().Subrouter()
+	internalAccountDashboardNotificationSettingsRouter := internalNotificationRouter.NewRoute().Subrouter()
+	if !debug {
+		publicAccountDashboardNotificationSettingsRouter.Use(hs.ADBAuthMiddleware)
+		internalAccountDashboardNotificationSettingsRouter.Use(hs.ADBAuthMiddleware)
+	}
+	accountDashboardSettingsEndpoints := []endpoint{
+		{http.MethodGet, "/account-dashboards/{dashboard_id}/groups/{group_id}/epochs/{epoch}", hs.PublicGetUserNotificationsAccountDashboard, hs.InternalGetUserNotificationsAccountDashboard, manageNotifications},
+		{http.MethodPut, "/settings/account-dashboards/{dashboard_id}/groups/{group_id}", hs.PublicPutUserNotificationSettingsAccountDashboard, hs.InternalPutUserNotificationSettingsAccountDashboard, manageNotifications},
 	}
-	addEndpointsToRouters(dashboardSettingsEndpoints, publicDashboardNotificationSettingsRouter, internalDashboardNotificationSettingsRouter)
+	addEndpointsToRouters(hs, accountDashboardSettingsEndpoints, publicAccountDashboardNotificationSettingsRouter, internalAccountDashboardNotificationSettingsRouter)
 }
 
-func addEndpointsToRouters(endpoints []endpoint, publicRouter *mux.Router, internalRouter *mux.Router) {
+func addEndpointsToRouters(hs *handlers.HandlerService, endpoints []endpoint, publicRouter *mux.Router, internalRouter *mux.Router) {
 	for _, endpoint := range endpoints {
 		if endpoint.PublicHandler != nil {
-			publicRouter.HandleFunc(endpoint.Path, endpoint.PublicHandler).Methods(endpoint.Method, http.MethodOptions)
+			hs.HandlePublic(publicRouter, endpoint.Path, endpoint.PublicHandler, endpoint.ApiKeyScope).Methods(endpoint.Method, http.MethodOptions)
 		}
 		if endpoint.InternalHander != nil {
 			internalRouter.HandleFunc(endpoint.Path, endpoint.InternalHander).Methods(endpoint.Method, http.MethodOptions)
@@ -1,12 +1,16 @@
 package services
 
 import (
+	"bytes"
+	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/gobitfly/beaconchain/pkg/commons/db"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
 	"github.com/gobitfly/beaconchain/pkg/commons/price"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
@@ -14,7 +18,10 @@ import (
 
 var ErrWaiting error = errors.New("waiting for service to be initialized")
 
+// Services holds the cached data of a single network, the services of the main network additionally send emails and update prices
 type Services struct {
+	chain                   *types.Chain
+	primary                 bool
 	readerDb                *sqlx.DB
 	writerDb                *sqlx.DB
 	alloyReader             *sqlx.DB
@@ -22,33 +29,62 @@ type Services struct {
 	clickhouseReader        *sqlx.DB
 	bigtable                *db.Bigtable
 	persistentRedisDbClient *redis.Client
+
+	currentValidatorMapping     atomic.Pointer[ValidatorMapping]
+	cachedBufferCompressed      *bytes.Buffer
+	cachedBufferDecompressed    *bytes.Buffer
+	cachedRedisValidatorMapping *types.RedisCachedValidatorsMapping
+	lastEpochUpdate             uint64
+
+	currentDutiesInfo     atomic.Pointer[SyncData]
+	currentEfficiencyInfo atomic.Pointer[EfficiencyData]
+	slotDutiesSubscribers slotDutiesSubscribers
 }
 
-func NewServices(readerDb, writerDb, alloyReader, alloyWriter, clickhouseReader *sqlx.DB, bigtable *db.Bigtable, persistentRedisDbClient *redis.Client) *Services {
+func NewServices(chain *types.Chain, primary bool, readerDb, writerDb, alloyReader, alloyWriter, clickhouseReader *sqlx.DB, bigtable *db.Bigtable, persistentRedisDbClient *redis.Client) *Services {
 	return &Services{
-		readerDb:                readerDb,
-		writerDb:                writerDb,
-		alloyReader:             alloyReader,
-		alloyWriter:             alloyWriter,
-		clickhouseReader:        clickhouseReader,
-		bigtable:                bigtable,
-		persistentRedisDbClient: persistentRedisDbClient,
+		chain:                       chain,
+		primary:                     primary,
+		readerDb:                    readerDb,
+		writerDb:                    writerDb,
+		alloyReader:                 alloyReader,
+		alloyWriter:                 alloyWriter,
+		clickhouseReader:            clickhouseReader,
+		bigtable:                    bigtable,
+		persistentRedisDbClient:     persistentRedisDbClient,
+		cachedBufferCompressed:      new(bytes.Buffer),
+		cachedBufferDecompressed:    new(bytes.Buffer),
+		cachedRedisValidatorMapping: new(types.RedisCachedValidatorsMapping),
+		slotDutiesSubscribers:       slotDutiesSubscribers{channels: make(map[chan *types.SlotDutiesEvent]struct{})},
 	}
 }
 
 func (s *Services) InitServices() {
 	wg := &sync.WaitGroup{}
-	log.Infof("initializing services...")
-	wg.Add(4)
+	log.Infof("initializing services for chain %d...", s.chain.ClConfig.DepositChainID)
+	wg.Add(3)
 	go s.startSlotVizDataService(wg)
 	go s.startIndexMappingService(wg)
 	go s.startEfficiencyDataService(wg)
-	go s.startEmailSenderService(wg)
+	go s.startSlotDutiesService()
 
-	log.Infof("initializing prices...")
-	price.Init(utils.Config.Chain.ClConfig.DepositChainID, utils.Config.Eth1ErigonEndpoint, utils.Config.Frontend.ClCurrency, utils.Config.Frontend.ElCurrency)
-	log.Infof("...prices initialized")
+	if s.primary {
+		wg.Add(1)
+		go s.startEmailSenderService(wg)
+
+		log.Infof("initializing prices...")
+		price.Init(s.chain.ClConfig.DepositChainID, utils.Config.Eth1ErigonEndpoint, utils.Config.Frontend.ClCurrency, utils.Config.Frontend.ElCurrency)
+		log.Infof("...prices initialized")
+	}
 
 	wg.Wait()
-	log.Infof("...services initialized")
+	log.Infof("...services for chain %d initialized", s.chain.ClConfig.DepositChainID)
+}
+
+// statusReportId returns the monitoring id of a service, the ids of the services of additional networks are suffixed with the chain id
+func (s *Services) statusReportId(id string) string {
+	if s.primary {
+		return id
+	}
+	return fmt.Sprintf("%s_%d", id, s.chain.ClConfig.DepositChainID)
 }
@@ -4,7 +4,6 @@ import (
 	"database/sql"
 	"fmt"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/doug-martin/goqu/v9"
@@ -20,14 +19,12 @@ import (
 // TODO: As a service this will not scale well as it is running once on every instance of the api.
 // Instead of service this should be moved to the exporter.
 
-var currentEfficiencyInfo atomic.Pointer[EfficiencyData]
-
 func (s *Services) startEfficiencyDataService(wg *sync.WaitGroup) {
 	o := sync.Once{}
 	for {
 		startTime := time.Now()
-		delay := time.Duration(utils.Config.Chain.ClConfig.SlotsPerEpoch*utils.Config.Chain.ClConfig.SecondsPerSlot) * time.Second
-		r := services.NewStatusReport("api_service_avg_efficiency", constants.Default, delay)
+		delay := time.Duration(s.chain.ClConfig.SlotsPerEpoch*s.chain.ClConfig.SecondsPerSlot) * time.Second
+		r := services.NewStatusReport(s.statusReportId("api_service_avg_efficiency"), constants.Default, delay)
 		err := s.updateEfficiencyData() // TODO: only update data if something has changed (new head epoch)
 		r(constants.Running, nil)
 		if err != nil {
@@ -132,10 +129,10 @@ func (s *Services) updateEfficiencyData() error {
 	}
 
 	// update currentEfficiencyInfo
-	if currentEfficiencyInfo.Load() == nil { // info on first iteration
+	if s.currentEfficiencyInfo.Load() == nil { // info on first iteration
 		log.Infof("== average network efficiency data updater initialized ==")
 	}
-	currentEfficiencyInfo.Store(efficiencyInfo)
+	s.currentEfficiencyInfo.Store(efficiencyInfo)
 
 	return nil
 }
@@ -143,11 +140,11 @@ func (s *Services) updateEfficiencyData() error {
 // GetCurrentEfficiencyInfo returns the current efficiency info and a function to release the lock
 // Call release lock after you are done with accessing the data, otherwise it will block the efficiency service from updating
 func (s *Services) GetCurrentEfficiencyInfo() (*EfficiencyData, error) {
-	if currentEfficiencyInfo.Load() == nil {
+	if s.currentEfficiencyInfo.Load() == nil {
 		return nil, fmt.Errorf("%w: efficiencyInfo", ErrWaiting)
 	}
 
-	return currentEfficiencyInfo.Load(), nil
+	return s.currentEfficiencyInfo.Load(), nil
 }
 
 func (s *Services) initEfficiencyInfo() *EfficiencyData {
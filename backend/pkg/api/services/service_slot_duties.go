@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+)
+
+// subscribers of the slot duties stream, every subscriber gets its own buffered channel
+type slotDutiesSubscribers struct {
+	sync.RWMutex
+	channels map[chan *types.SlotDutiesEvent]struct{}
+}
+
+// startSlotDutiesService forwards the slot duties that the exporter publishes via redis to all subscribers.
+// A single redis subscription is shared by all dashboard streams of this instance.
+func (s *Services) startSlotDutiesService() {
+	channel := types.SlotDutiesRedisChannel(s.chain.ClConfig.DepositChainID)
+	for {
+		pubsub := s.persistentRedisDbClient.Subscribe(context.Background(), channel)
+		for msg := range pubsub.Channel() {
+			event := &types.SlotDutiesEvent{}
+			err := json.Unmarshal([]byte(msg.Payload), event)
+			if err != nil {
+				log.Error(err, "error unmarshalling slot duties event", 0)
+				continue
+			}
+			s.broadcastSlotDuties(event)
+		}
+		// the channel only closes if the subscription is gone, resubscribe
+		err := pubsub.Close()
+		if err != nil {
+			log.Error(err, "error closing slot duties subscription", 0)
+		}
+		log.Warnf("slot duties subscription closed, resubscribing")
+		time.Sleep(time.Second)
+	}
+}
+
+func (s *Services) broadcastSlotDuties(event *types.SlotDutiesEvent) {
+	s.slotDutiesSubscribers.RLock()
+	defer s.slotDutiesSubscribers.RUnlock()
+	for ch := range s.slotDutiesSubscribers.channels {
+		select {
+		case ch <- event:
+		default:
+			// slow subscriber, drop the event instead of blocking all other streams
+		}
+	}
+}
+
+// SubscribeToSlotDuties returns a channel that receives the duties of every new slot until ctx is done
+func (s *Services) SubscribeToSlotDuties(ctx context.Context) <-chan *types.SlotDutiesEvent {
+	ch := make(chan *types.SlotDutiesEvent, 8)
+	s.slotDutiesSubscribers.Lock()
+	s.slotDutiesSubscribers.channels[ch] = struct{}{}
+	s.slotDutiesSubscribers.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.slotDutiesSubscribers.Lock()
+		delete(s.slotDutiesSubscribers.channels, ch)
+		s.slotDutiesSubscribers.Unlock()
+		close(ch)
+	}()
+	return ch
+}
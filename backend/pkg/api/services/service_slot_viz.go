@@ -8,11 +8,9 @@ import (
 	"strconv"
 	"strings"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/gobitfly/beaconchain/pkg/commons/cache"
-	"github.com/gobitfly/beaconchain/pkg/commons/db"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
 	"github.com/gobitfly/beaconchain/pkg/commons/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
@@ -25,14 +23,12 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-var currentDutiesInfo atomic.Pointer[SyncData]
-
 func (s *Services) startSlotVizDataService(wg *sync.WaitGroup) {
 	o := sync.Once{}
 	for {
 		startTime := time.Now()
-		delay := time.Duration(utils.Config.Chain.ClConfig.SecondsPerSlot) * time.Second
-		r := services.NewStatusReport("api_service_slot_viz", constants.Default, delay)
+		delay := time.Duration(s.chain.ClConfig.SecondsPerSlot) * time.Second
+		r := services.NewStatusReport(s.statusReportId("api_service_slot_viz"), constants.Default, delay)
 		r(constants.Running, nil)
 		err := s.updateSlotVizData() // TODO: only update data if something has changed (new head slot or new head epoch)
 		if err != nil {
@@ -50,7 +46,7 @@ func (s *Services) startSlotVizDataService(wg *sync.WaitGroup) {
 
 func (s *Services) updateSlotVizData() error {
 	var dutiesInfo *SyncData
-	if currentDutiesInfo.Load() == nil {
+	if s.currentDutiesInfo.Load() == nil {
 		dutiesInfo = s.initDutiesInfo()
 	} else {
 		dutiesInfo = s.copyAndCleanDutiesInfo()
@@ -67,7 +63,7 @@ func (s *Services) updateSlotVizData() error {
 	gOuter.Go(func() error {
 		startTime := time.Now()
 		var err error
-		validatorDutiesInfo, err = db.GetValidatorDutiesInfo(s.getMaxValidatorDutiesInfoSlot())
+		validatorDutiesInfo, err = s.getValidatorDutiesInfo(s.getMaxValidatorDutiesInfoSlot())
 		if err != nil {
 			return errors.Wrap(err, "error getting validator duties info")
 		}
@@ -80,7 +76,7 @@ func (s *Services) updateSlotVizData() error {
 	// Gather the assignments data
 	{
 		// Get min/max slot/epoch
-		headEpoch := cache.LatestEpoch.Get()
+		headEpoch := cache.LatestEpoch.OfChain(s.chain.ClConfig.DepositChainID).Get()
 
 		minEpoch := uint64(0)
 		if headEpoch > 1 {
@@ -89,7 +85,7 @@ func (s *Services) updateSlotVizData() error {
 
 		// if we have fetched epoch assignments before
 		// dont load for this epoch again
-		if v := currentDutiesInfo.Load(); v != nil {
+		if v := s.currentDutiesInfo.Load(); v != nil {
 			if v.AssignmentsFetchedForEpoch > 0 {
 				minEpoch = v.AssignmentsFetchedForEpoch + 1
 			}
@@ -110,7 +106,7 @@ func (s *Services) updateSlotVizData() error {
 					log.Debugf("getEpochAssignments: %d %s", epoch, time.Since(startTime))
 				}()
 				// Get the epoch assignments data
-				key := fmt.Sprintf("%d:%s:%d", utils.Config.Chain.ClConfig.DepositChainID, "ea", epoch)
+				key := fmt.Sprintf("%d:%s:%d", s.chain.ClConfig.DepositChainID, "ea", epoch)
 				ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 				defer cancel()
 
@@ -227,7 +223,7 @@ func (s *Services) updateSlotVizData() error {
 			if dutiesInfo.SlotSyncParticipated[duty.Slot] == nil {
 				dutiesInfo.SlotSyncParticipated[duty.Slot] = make(map[constypes.ValidatorIndex]bool, 0)
 
-				partValidators := utils.GetParticipatingSyncCommitteeValidators(duty.SyncAggregateBits, dutiesInfo.TotalSyncAssignmentsForEpoch[utils.EpochOfSlot(duty.Slot)])
+				partValidators := utils.GetParticipatingSyncCommitteeValidators(duty.SyncAggregateBits, dutiesInfo.TotalSyncAssignmentsForEpoch[s.chain.EpochOfSlot(duty.Slot)])
 				for _, validator := range partValidators {
 					dutiesInfo.SlotSyncParticipated[duty.Slot][validator] = true
 				}
@@ -278,11 +274,11 @@ func (s *Services) updateSlotVizData() error {
 	log.Debugf("process slotduties extra data: %s", time.Since(startTime))
 
 	// update currentDutiesInfo and hence frontend data
-	if currentDutiesInfo.Load() == nil { // info on first iteration
+	if s.currentDutiesInfo.Load() == nil { // info on first iteration
 		log.Infof("== slot-viz data updater initialized ==")
 	}
 
-	currentDutiesInfo.Store(dutiesInfo)
+	s.currentDutiesInfo.Store(dutiesInfo)
 
 	return nil
 }
@@ -290,10 +286,10 @@ func (s *Services) updateSlotVizData() error {
 // GetCurrentDutiesInfo returns the current duties info and a function to release the lock
 // Call release lock after you are done with accessing the data, otherwise it will block the slot viz service from updating
 func (s *Services) GetCurrentDutiesInfo() (*SyncData, error) {
-	if currentDutiesInfo.Load() == nil {
+	if s.currentDutiesInfo.Load() == nil {
 		return nil, fmt.Errorf("%w: dutiesInfo", ErrWaiting)
 	}
-	return currentDutiesInfo.Load(), nil
+	return s.currentDutiesInfo.Load(), nil
 }
 
 func (s *Services) initDutiesInfo() *SyncData {
@@ -314,10 +310,10 @@ func (s *Services) initDutiesInfo() *SyncData {
 
 func (s *Services) copyAndCleanDutiesInfo() *SyncData {
 	// deep copy & clean
-	headSlot := cache.LatestEpoch.Get() * utils.Config.Chain.ClConfig.SlotsPerEpoch
+	headSlot := cache.LatestEpoch.OfChain(s.chain.ClConfig.DepositChainID).Get() * s.chain.ClConfig.SlotsPerEpoch
 	dropBelowSlot := uint64(0)
-	if headSlot > 2*utils.Config.Chain.ClConfig.SlotsPerEpoch {
-		dropBelowSlot = headSlot - 2*utils.Config.Chain.ClConfig.SlotsPerEpoch
+	if headSlot > 2*s.chain.ClConfig.SlotsPerEpoch {
+		dropBelowSlot = headSlot - 2*s.chain.ClConfig.SlotsPerEpoch
 	}
 	p, err := s.GetCurrentDutiesInfo()
 	if err != nil {
@@ -395,7 +391,7 @@ func (s *Services) copyAndCleanDutiesInfo() *SyncData {
 
 	// copy SyncAssignmentsForEpoch
 	for epoch, v := range p.SyncAssignmentsForEpoch {
-		if epoch*utils.Config.Chain.ClConfig.SlotsPerEpoch < dropBelowSlot {
+		if epoch*s.chain.ClConfig.SlotsPerEpoch < dropBelowSlot {
 			continue
 		}
 		dutiesInfo.SyncAssignmentsForEpoch[epoch] = make(map[constypes.ValidatorIndex]bool, len(v))
@@ -407,7 +403,7 @@ func (s *Services) copyAndCleanDutiesInfo() *SyncData {
 
 	// copy TotalSyncAssignmentsForEpoch
 	for epoch, v := range p.TotalSyncAssignmentsForEpoch {
-		if epoch*utils.Config.Chain.ClConfig.SlotsPerEpoch < dropBelowSlot {
+		if epoch*s.chain.ClConfig.SlotsPerEpoch < dropBelowSlot {
 			continue
 		}
 		dutiesInfo.TotalSyncAssignmentsForEpoch[epoch] = make([]constypes.ValidatorIndex, 0, len(p.TotalSyncAssignmentsForEpoch[epoch]))
@@ -433,8 +429,8 @@ func (s *Services) copyAndCleanDutiesInfo() *SyncData {
 }
 
 func (s *Services) getMaxValidatorDutiesInfoSlot() uint64 {
-	headEpoch := cache.LatestEpoch.Get()
-	slotsPerEpoch := utils.Config.Chain.ClConfig.SlotsPerEpoch
+	headEpoch := cache.LatestEpoch.OfChain(s.chain.ClConfig.DepositChainID).Get()
+	slotsPerEpoch := s.chain.ClConfig.SlotsPerEpoch
 
 	minEpoch := uint64(0)
 	if headEpoch > 1 {
@@ -472,3 +468,24 @@ type SyncData struct {
 	EpochAttestationDuties       map[uint64]map[uint32]bool // validatorindex -> slot -> attested
 	AssignmentsFetchedForEpoch   uint64
 }
+
+func (s *Services) getValidatorDutiesInfo(startSlot uint64) ([]types.ValidatorDutyInfo, error) {
+	validatorDutyInfo := []types.ValidatorDutyInfo{}
+
+	err := s.readerDb.Select(&validatorDutyInfo, `
+		SELECT
+			blocks.slot,
+			blocks.status,
+			COALESCE(blocks.exec_block_number, 0) AS exec_block_number,
+			blocks.syncaggregate_bits,
+			blocks_attestations.validators,
+			blocks_attestations.slot AS attested_slot,
+			blocks.proposerslashingscount,
+			blocks.attesterslashingscount
+		FROM blocks
+		LEFT JOIN blocks_attestations ON blocks.slot = blocks_attestations.block_slot
+		WHERE blocks.slot >= $1
+		`, startSlot)
+
+	return validatorDutyInfo, err
+}
@@ -1,12 +1,10 @@
 package services
 
 import (
-	"bytes"
 	"context"
 	"encoding/gob"
 	"fmt"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -27,24 +25,17 @@ type ValidatorMapping struct {
 	ValidatorMetadata []*types.CachedValidator            // note: why pointers?
 }
 
-var currentValidatorMapping atomic.Pointer[ValidatorMapping]
-var _cachedBufferCompressed = new(bytes.Buffer)
-var _cachedBufferDecompressed = new(bytes.Buffer)
-var _cachedRedisValidatorMapping = new(types.RedisCachedValidatorsMapping)
-
-var lastEpochUpdate = uint64(0)
-
 func (s *Services) startIndexMappingService(wg *sync.WaitGroup) {
 	var err error
 	o := sync.Once{}
 	for {
 		startTime := time.Now()
-		delay := time.Duration(utils.Config.Chain.ClConfig.SecondsPerSlot) * time.Second
+		delay := time.Duration(s.chain.ClConfig.SecondsPerSlot) * time.Second
 		err = nil // clear error
-		r := services.NewStatusReport("api_service_validator_mapping", constants.Default, delay)
+		r := services.NewStatusReport(s.statusReportId("api_service_validator_mapping"), constants.Default, delay)
 		r(constants.Running, nil)
-		latestEpoch := cache.LatestEpoch.Get()
-		if currentValidatorMapping.Load() == nil || latestEpoch != lastEpochUpdate {
+		latestEpoch := cache.LatestEpoch.OfChain(s.chain.ClConfig.DepositChainID).Get()
+		if s.currentValidatorMapping.Load() == nil || latestEpoch != s.lastEpochUpdate {
 			err = s.updateValidatorMapping()
 		}
 		if err != nil {
@@ -53,8 +44,8 @@ func (s *Services) startIndexMappingService(wg *sync.WaitGroup) {
 			delay = 10 * time.Second
 		} else {
 			log.Infof("=== validator mapping updated in %s", time.Since(startTime))
-			r(constants.Success, map[string]string{"took": time.Since(startTime).String(), "latest_epoch": fmt.Sprintf("%d", lastEpochUpdate)})
-			lastEpochUpdate = latestEpoch
+			r(constants.Success, map[string]string{"took": time.Since(startTime).String(), "latest_epoch": fmt.Sprintf("%d", s.lastEpochUpdate)})
+			s.lastEpochUpdate = latestEpoch
 			o.Do(func() {
 				wg.Done()
 			})
@@ -65,14 +56,14 @@ func (s *Services) startIndexMappingService(wg *sync.WaitGroup) {
 
 func (s *Services) initValidatorMapping() {
 	log.Infof("initializing validator mapping")
-	lenMapping := len(_cachedRedisValidatorMapping.Mapping)
+	lenMapping := len(s.cachedRedisValidatorMapping.Mapping)
 
 	c := ValidatorMapping{}
 	c.ValidatorIndices = make(map[string]constypes.ValidatorIndex, lenMapping)
 	c.ValidatorPubkeys = make([]string, lenMapping)
-	c.ValidatorMetadata = _cachedRedisValidatorMapping.Mapping
+	c.ValidatorMetadata = s.cachedRedisValidatorMapping.Mapping
 
-	for i, v := range _cachedRedisValidatorMapping.Mapping {
+	for i, v := range s.cachedRedisValidatorMapping.Mapping {
 		if i == lenMapping {
 			break
 		}
@@ -83,14 +74,14 @@ func (s *Services) initValidatorMapping() {
 		c.ValidatorPubkeys[i] = b
 		c.ValidatorIndices[b] = j
 	}
-	currentValidatorMapping.Store(&c)
+	s.currentValidatorMapping.Store(&c)
 }
 
 func (s *Services) updateValidatorMapping() error {
 	start := time.Now()
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
-	key := fmt.Sprintf("%d:%s", utils.Config.Chain.ClConfig.DepositChainID, "vm")
+	key := fmt.Sprintf("%d:%s", s.chain.ClConfig.DepositChainID, "vm")
 	compressed, err := s.persistentRedisDbClient.Get(ctx, key).Bytes()
 	if err != nil {
 		return errors.Wrap(err, "failed to get compressed validator mapping from db")
@@ -99,15 +90,15 @@ func (s *Services) updateValidatorMapping() error {
 
 	// decompress
 	start = time.Now()
-	_cachedBufferCompressed.Write(compressed)
-	defer _cachedBufferCompressed.Reset()
-	w, err := pgzip.NewReaderN(_cachedBufferCompressed, 1_000_000, 10)
+	s.cachedBufferCompressed.Write(compressed)
+	defer s.cachedBufferCompressed.Reset()
+	w, err := pgzip.NewReaderN(s.cachedBufferCompressed, 1_000_000, 10)
 	if err != nil {
 		return errors.Wrap(err, "failed to create pgzip reader")
 	}
 	defer w.Close()
-	_, err = w.WriteTo(_cachedBufferDecompressed)
-	defer _cachedBufferDecompressed.Reset()
+	_, err = w.WriteTo(s.cachedBufferDecompressed)
+	defer s.cachedBufferDecompressed.Reset()
 	if err != nil {
 		return errors.Wrap(err, "failed to decompress validator mapping from redis")
 	}
@@ -115,8 +106,8 @@ func (s *Services) updateValidatorMapping() error {
 
 	// ungob
 	start = time.Now()
-	dec := gob.NewDecoder(_cachedBufferDecompressed)
-	err = dec.Decode(&_cachedRedisValidatorMapping)
+	dec := gob.NewDecoder(s.cachedBufferDecompressed)
+	err = dec.Decode(&s.cachedRedisValidatorMapping)
 	if err != nil {
 		return errors.Wrap(err, "error decoding assignments data")
 	}
@@ -133,10 +124,10 @@ func (s *Services) updateValidatorMapping() error {
 // Call release lock after you are done with accessing the data, otherwise it will block the validator mapping service from updating
 func (s *Services) GetCurrentValidatorMapping() (*ValidatorMapping, error) {
 	// in theory the consumer can just check if the pointer is nil, but this is more explicit
-	if currentValidatorMapping.Load() == nil {
+	if s.currentValidatorMapping.Load() == nil {
 		return nil, fmt.Errorf("%w: validator mapping", ErrWaiting)
 	}
-	return currentValidatorMapping.Load(), nil
+	return s.currentValidatorMapping.Load(), nil
 }
 
 func (s *Services) GetPubkeySliceFromIndexSlice(indices []constypes.ValidatorIndex) ([]string, error) {
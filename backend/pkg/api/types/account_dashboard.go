@@ -0,0 +1,92 @@
+package types
+
+import "github.com/shopspring/decimal"
+
+// ------------------------------------------------------------
+// Overview
+
+type ADBGroup struct {
+	Id    uint64 `json:"id"`
+	Name  string `json:"name"`
+	Count uint64 `json:"count"`
+}
+
+type ADBTransactionSettings struct {
+	// chain ids of the networks whose transactions are listed
+	Networks                        []uint64 `json:"networks" faker:"chain_ids"`
+	IsIncludeTokenTransfersEnabled  bool     `json:"is_include_token_transfers_enabled"`
+	IsIgnoreSpamTransactionsEnabled bool     `json:"is_ignore_spam_transactions_enabled"`
+}
+
+type InternalPutAccountDashboardTransactionsSettingsResponse ApiDataResponse[ADBTransactionSettings]
+
+type ADBOverviewData struct {
+	Id                  uint64                 `json:"id"`
+	Name                string                 `json:"name"`
+	Groups              []ADBGroup             `json:"groups"`
+	AccountCount        uint64                 `json:"account_count"`
+	TransactionSettings ADBTransactionSettings `json:"transaction_settings"`
+}
+
+type GetAccountDashboardResponse ApiDataResponse[ADBOverviewData]
+
+// ------------------------------------------------------------
+// Accounts
+
+type ADBAccountsTableRow struct {
+	Address Address `json:"address"`
+	GroupId uint64  `json:"group_id"`
+}
+
+type GetAccountDashboardAccountsResponse ApiPagingResponse[ADBAccountsTableRow]
+
+type ADBPostAccountsData struct {
+	Address Hash   `json:"address"`
+	GroupId uint64 `json:"group_id"`
+}
+
+// ------------------------------------------------------------
+// Transactions
+
+type ADBToken struct {
+	Address  Hash   `json:"address"`
+	Symbol   string `json:"symbol,omitempty"`
+	Decimals uint64 `json:"decimals"`
+}
+
+type ADBTransactionsTableRow struct {
+	Network   uint64 `json:"network"`
+	Hash      Hash   `json:"hash"`
+	Block     uint64 `json:"block"`
+	Timestamp int64  `json:"timestamp"`
+	Type      string `json:"type" tstype:"'transaction' | 'erc20_transfer'" faker:"oneof: transaction, erc20_transfer"`
+	Method    string `json:"method,omitempty"`
+	// direction from the point of view of the dashboard accounts
+	Direction string  `json:"direction" tstype:"'in' | 'out' | 'self'" faker:"oneof: in, out, self"`
+	From      Address `json:"from"`
+	To        Address `json:"to"`
+	GroupId   uint64  `json:"group_id"`
+	// in wei for transactions, in the smallest unit of the token for token transfers
+	Value   decimal.Decimal `json:"value"`
+	Token   *ADBToken       `json:"token,omitempty"`
+	Fee     decimal.Decimal `json:"fee"`
+	Success bool            `json:"success"`
+	IsSpam  bool            `json:"is_spam"`
+}
+
+type GetAccountDashboardTransactionsResponse ApiPagingResponse[ADBTransactionsTableRow]
+
+// ------------------------------------------------------------
+// Management
+
+type ADBPostReturnData struct {
+	Id        uint64 `db:"id" json:"id"`
+	UserID    uint64 `db:"user_id" json:"user_id"`
+	Name      string `db:"name" json:"name"`
+	CreatedAt int64  `db:"created_at" json:"created_at"`
+}
+
+type ADBPostCreateGroupData struct {
+	Id   uint64 `db:"id" json:"id"`
+	Name string `db:"name" json:"name"`
+}
@@ -65,20 +65,38 @@ type BlobInfo struct {
 	BurnedFees *decimal.Decimal `json:"burned_fees"`
 }
 
+type BlockGasLimit struct {
+	Value   uint64  `json:"value"`
+	Percent float64 `json:"percent"` // share of the gas limit that was used
+}
+
+type BlockMevTag struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type BlockStatus struct {
+	Proposal  string `json:"proposal" tstype:"'proposed' | 'orphaned' | 'missed' | 'scheduled'" faker:"oneof: proposed, orphaned, missed, scheduled"`
+	Finalized string `json:"finalized" tstype:"'finalized' | 'justified' | 'not_finalized'" faker:"oneof: finalized, justified, not_finalized"`
+}
+
+type BlockTransactionCounts struct {
+	General  uint64 `json:"general"`
+	Internal uint64 `json:"internal"`
+	Blob     uint64 `json:"blob,omitempty"`
+}
+
 type BlockOverview struct {
 	// General
 	Block uint64 `json:"block"`
 	Time  int64  `json:"time"`
 
 	// Old blocks only
-	Miner    *Address         `json:"miner,omitempty"`
-	Rewards  *decimal.Decimal `json:"rewards,omitempty"`
-	TxFees   *decimal.Decimal `json:"tx_fees,omitempty"`
-	GasUsage *decimal.Decimal `json:"gas_usage,omitempty"`
-	GasLimit *struct {
-		Value   uint64  `json:"value"`
-		Percent float64 `json:"percent"`
-	} `json:"gas_limit,omitempty"`
+	Miner          *Address         `json:"miner,omitempty"`
+	Rewards        *decimal.Decimal `json:"rewards,omitempty"`
+	TxFees         *decimal.Decimal `json:"tx_fees,omitempty"`
+	GasUsage       *decimal.Decimal `json:"gas_usage,omitempty"`
+	GasLimit       *BlockGasLimit   `json:"gas_limit,omitempty"`
 	LowestGasPrice *decimal.Decimal `json:"lowest_gas_price,omitempty"`
 	Difficulty     *decimal.Decimal `json:"difficulty,omitempty"`
 	// base + burned fee only present post EIP-1559
@@ -89,27 +107,17 @@ type BlockOverview struct {
 	ParentHash Hash             `json:"parent_hash,omitempty"`
 
 	// New blocks only
-	MevTags []struct {
-		Name  string `json:"name"`
-		Color string `json:"color"`
-	} `json:"mev_tags,omitempty"`
+	MevTags                 []BlockMevTag               `json:"mev_tags,omitempty"`
 	Epoch                   uint64                      `json:"epoch,omitempty"`
 	Slot                    uint64                      `json:"slot,omitempty"`
 	Proposer                uint64                      `json:"proposer,omitempty"`
 	ProposerReward          *ClElValue[decimal.Decimal] `json:"proposer_reward,omitempty"`
 	ProposerRewardRecipient *Address                    `json:"proposer_reward_recipient,omitempty"`
-	Status                  *struct {
-		Proposal  string `json:"proposal" tstype:"'proposed' | 'orphaned' | 'missed' | 'scheduled'" faker:"oneof: proposed, orphaned, missed, scheduled"`
-		Finalized string `json:"finalized" tstype:"'finalized' | 'justified' | 'not_finalized'" faker:"oneof: finalized, justified, not_finalized"`
-	} `json:"status,omitempty"`
-	PriorityFees *decimal.Decimal `json:"priority_fees,omitempty"`
-	Transactions *struct {
-		General  uint64 `json:"general"`
-		Internal uint64 `json:"internal"`
-		Blob     uint64 `json:"blob,omitempty"`
-	} `json:"transactions,omitempty"`
-	BlockRoot  Hash `json:"block_root,omitempty"`
-	ParentRoot Hash `json:"parent_root,omitempty"`
+	Status                  *BlockStatus                `json:"status,omitempty"`
+	PriorityFees            *decimal.Decimal            `json:"priority_fees,omitempty"`
+	Transactions            *BlockTransactionCounts     `json:"transactions,omitempty"`
+	BlockRoot               Hash                        `json:"block_root,omitempty"`
+	ParentRoot              Hash                        `json:"parent_root,omitempty"`
 
 	ExecutionPayload *BlockExecutionPayload `json:"execution_payload,omitempty"`
 	ConsensusLayer   *BlockConsensusLayer   `json:"consensus_layer,omitempty"`
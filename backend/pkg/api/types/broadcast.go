@@ -0,0 +1,41 @@
+package types
+
+// ------------------------------------------------------------
+// Broadcasts
+type BroadcastTrigger struct {
+	Type   string `json:"type" tstype:"'scheduled_epoch' | 'offline' | 'sibling_slashed' | 'manual'" faker:"oneof: scheduled_epoch, offline, sibling_slashed, manual"`
+	Epoch  uint64 `json:"epoch,omitempty"`  // scheduled_epoch only
+	Epochs uint64 `json:"epochs,omitempty"` // offline only
+}
+
+type BroadcastValidator struct {
+	Index     uint64 `json:"index"`
+	PublicKey PubKey `json:"public_key"`
+	Status    string `json:"status" tstype:"'slashed' | 'exited' | 'deposited' | 'pending' | 'slashing_offline' | 'slashing_online' | 'exiting_offline' | 'exiting_online' | 'active_offline' | 'active_online'" faker:"oneof: slashed, exited, deposited, pending, slashing_offline, slashing_online, exiting_offline, exiting_online, active_offline, active_online"`
+}
+
+type BroadcastAuditLogEntry struct {
+	Timestamp int64   `json:"timestamp"`
+	Action    string  `json:"action" tstype:"'created' | 'triggered' | 'submitted' | 'submission_failed' | 'completed'" faker:"oneof: created, triggered, submitted, submission_failed, completed"`
+	Index     *uint64 `json:"index,omitempty"` // only set if the entry concerns a single validator
+	Details   string  `json:"details,omitempty"`
+}
+
+type Broadcast struct {
+	Id          string                   `json:"id"`
+	Type        string                   `json:"type" tstype:"'bls_to_execution_changes' | 'voluntary_exits' | 'conditional_voluntary_exits'" faker:"oneof: bls_to_execution_changes, voluntary_exits, conditional_voluntary_exits"`
+	Status      string                   `json:"status" tstype:"'waiting_for_trigger' | 'pending' | 'submitted_to_node' | 'completed' | 'failed'" faker:"oneof: waiting_for_trigger, pending, submitted_to_node, completed, failed"`
+	DashboardId *uint64                  `json:"dashboard_id,omitempty"` // conditional_voluntary_exits only
+	GroupId     *uint64                  `json:"group_id,omitempty"`     // conditional_voluntary_exits only
+	Trigger     *BroadcastTrigger        `json:"trigger,omitempty"`      // conditional_voluntary_exits only
+	CreatedTs   int64                    `json:"created_ts"`
+	SubmittedTs *int64                   `json:"submitted_ts,omitempty"`
+	CompletedTs *int64                   `json:"completed_ts,omitempty"`
+	Validators  []BroadcastValidator     `json:"validators"`
+	AuditLog    []BroadcastAuditLogEntry `json:"audit_log"`
+	UserId      *uint64                  `json:"-"` // creator, only set for broadcasts of logged in users
+}
+
+type PostNetworkBroadcastsResponse ApiDataResponse[Broadcast]
+
+type GetNetworkBroadcastResponse ApiDataResponse[Broadcast]
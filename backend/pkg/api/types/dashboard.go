@@ -13,6 +13,7 @@ type ValidatorDashboard struct {
 	ArchivedReason string        `json:"archived_reason,omitempty" tstype:"'user' | 'dashboard_limit' | 'validator_limit' | 'group_limit'" extensions:"x-order=6"`
 	ValidatorCount uint64        `json:"validator_count" extensions:"x-order=7"`
 	GroupCount     uint64        `json:"group_count" extensions:"x-order=8"`
+	OrganizationId *uint64       `json:"organization_id,omitempty" extensions:"x-order=9"` // set if the dashboard is owned by an organization
 }
 
 type UserDashboardsData struct {
@@ -2,6 +2,7 @@ package types
 
 import (
 	"database/sql"
+	"slices"
 	"time"
 
 	"github.com/gobitfly/beaconchain/pkg/api/enums"
@@ -47,6 +48,9 @@ type VDBValidator = types.ValidatorIndex
 type DashboardUser struct {
 	Id     VDBIdPrimary `db:"id"` // this must be the bigint id
 	UserId uint64       `db:"user_id"`
+	// organization owning the dashboard, members access it according to their role
+	OrganizationId *uint64 `db:"organization_id"`
+	Network        uint64  `db:"network"` // chain id the dashboard was created for
 }
 
 type CursorLike interface {
@@ -75,8 +79,9 @@ func (c GenericCursor) IsReverse() bool {
 
 type CLDepositsCursor struct {
 	GenericCursor
-	Slot      int64
-	SlotIndex int64
+	Slot        int64
+	DepositType int64
+	SlotIndex   int64
 }
 
 type ELDepositsCursor struct {
@@ -91,6 +96,12 @@ type ValidatorsCursor struct {
 	Index uint64 `json:"vi"`
 }
 
+type ValidatorLeaderboardCursor struct {
+	GenericCursor
+
+	Rank uint64
+}
+
 type RewardsCursor struct {
 	GenericCursor
 
@@ -143,6 +154,12 @@ type RocketPoolCursor struct {
 	SmoothingpoolOptIn bool
 }
 
+type NotificationWebhookDeadLettersCursor struct {
+	GenericCursor
+
+	Id uint64
+}
+
 type NotificationClientsCursor struct {
 	GenericCursor
 
@@ -188,6 +205,26 @@ type NotificationsDashboardsCursor struct {
 	GroupId       uint64
 }
 
+type ADBAccountsCursor struct {
+	GenericCursor
+
+	Address []byte
+}
+
+// ADBTransactionsCursor stores the read position within every bigtable index the transaction history is merged from,
+// keyed by chain id, index type and account
+type ADBTransactionsCursor struct {
+	GenericCursor
+
+	Positions map[string]ADBTransactionsIndexPosition `json:"p"`
+}
+
+type ADBTransactionsIndexPosition struct {
+	Key  string `json:"k"`           // index key after which reading continues, empty to start at the newest entry
+	Skip uint64 `json:"s,omitempty"` // entries after Key that have already been returned
+	Done bool   `json:"d,omitempty"` // the index has been read completely
+}
+
 type NetworkInfo struct {
 	ChainId           uint64
 	Name              string
@@ -254,8 +291,13 @@ type VDBProposalSummaryValidators struct {
 	Missed   []IndexSlots
 }
 
+// VDBProtocolModes holds the staking protocols for which only the operator share of the validator income should be reported
 type VDBProtocolModes struct {
-	RocketPool bool
+	Protocols []enums.StakingProtocol
+}
+
+func (m VDBProtocolModes) IsEnabled(protocol enums.StakingProtocol) bool {
+	return slices.Contains(m.Protocols, protocol)
 }
 
 type MobileSubscription struct {
@@ -316,6 +358,7 @@ type NotificationSettingsDefaultValues struct {
 	MaxCollateralThreshold            float64
 	MinCollateralThreshold            float64
 	ERC20TokenTransfersValueThreshold float64
+	PoolOperationNotIncludedThreshold uint64
 
 	MachineStorageUsageThreshold float64
 	MachineCpuUsageThreshold     float64
@@ -328,9 +371,94 @@ type NotificationSettingsDefaultValues struct {
 
 // ------------------------------
 
+// ApiKeyAccess describes what an api key may be used for, nil scopes or networks mean that the key is unrestricted
+type ApiKeyAccess struct {
+	UserId   uint64
+	Scopes   []enums.ApiKeyScope
+	Networks []uint64
+}
+
+func (a ApiKeyAccess) HasScope(scope enums.ApiKeyScope) bool {
+	if a.Scopes == nil || slices.Contains(a.Scopes, scope) {
+		return true
+	}
+	// managing dashboards includes reading them
+	return scope == enums.ApiKeyScopes.ReadDashboards && slices.Contains(a.Scopes, enums.ApiKeyScopes.ManageDashboards)
+}
+
+func (a ApiKeyAccess) HasNetwork(chainId uint64) bool {
+	return a.Networks == nil || slices.Contains(a.Networks, chainId)
+}
+
+// OAuthClient is an app registered at the oauth authorization server, nil scopes mean that the client may request all scopes
+type OAuthClient struct {
+	AppId       uint64
+	OwnerId     uint64
+	ClientId    string
+	Name        string
+	RedirectUri string
+	// empty for public clients, which can't keep a secret and rely on PKCE alone
+	ClientSecretHash string
+	Scopes           []enums.ApiKeyScope
+	FirstParty       bool
+}
+
+func (c OAuthClient) IsConfidential() bool {
+	return c.ClientSecretHash != ""
+}
+
+func (c OAuthClient) AllowsScope(scope enums.ApiKeyScope) bool {
+	return c.Scopes == nil || slices.Contains(c.Scopes, scope)
+}
+
+// OAuthAuthorizationCode is the grant a user approved, it can be exchanged once for tokens
+type OAuthAuthorizationCode struct {
+	AppId         uint64
+	UserId        uint64
+	RedirectUri   string
+	Scopes        []enums.ApiKeyScope
+	CodeChallenge string
+}
+
+type OAuthTokenGrant struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    uint64 // seconds until the access token expires
+	Scopes       []enums.ApiKeyScope
+}
+
+// ------------------------------
+
+type UserTotp struct {
+	Secret       string
+	Confirmed    bool
+	LastUsedStep uint64
+}
+
+type WebAuthnCredential struct {
+	Id           uint64
+	Name         string
+	CredentialId []byte
+	PublicKey    []byte // DER encoded SubjectPublicKeyInfo
+	Algorithm    int64
+	SignCount    uint32
+	CreatedAt    time.Time
+	LastUsedAt   *time.Time
+}
+
+// UserDevice is a mobile app login, the refresh token hash is derived from the session token of the app
+type UserDevice struct {
+	Id               uint64
+	DeviceName       string
+	RefreshTokenHash string
+}
+
 type CtxKey string
 
 const CtxUserIdKey CtxKey = "user_id"
 const CtxIsMockedKey CtxKey = "is_mocked"
 const CtxMockSeedKey CtxKey = "mock_seed"
 const CtxDashboardIdKey CtxKey = "dashboard_id"
+const CtxDashboardOwnerIdKey CtxKey = "dashboard_owner_id"
+const CtxChainIdKey CtxKey = "chain_id"
+const CtxApiKeyAccessKey CtxKey = "api_key_access"
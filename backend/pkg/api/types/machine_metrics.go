@@ -3,9 +3,18 @@ package types
 import "github.com/gobitfly/beaconchain/pkg/commons/types"
 
 type MachineMetricsData struct {
-	SystemMetrics    []*types.MachineMetricSystem    `json:"system_metrics" faker:"slice_len=30"`
-	ValidatorMetrics []*types.MachineMetricValidator `json:"validator_metrics" faker:"slice_len=30"`
-	NodeMetrics      []*types.MachineMetricNode      `json:"node_metrics" faker:"slice_len=30"`
+	SystemMetrics        []*types.MachineMetricSystem    `json:"system_metrics" faker:"slice_len=30"`
+	ValidatorMetrics     []*types.MachineMetricValidator `json:"validator_metrics" faker:"slice_len=30"`
+	NodeMetrics          []*types.MachineMetricNode      `json:"node_metrics" faker:"slice_len=30"`
+	ExecutionNodeMetrics []*types.MachineMetricNode      `json:"execution_node_metrics" faker:"slice_len=30"`
 }
 
 type GetUserMachineMetricsRespone ApiDataResponse[MachineMetricsData]
+
+// MachineMetricSeries is a raw series a machine pushed in one of the prometheus formats
+type MachineMetricSeries struct {
+	Series string                           `json:"series"` // metric name followed by the sorted labels
+	Points []types.MachineMetricSeriesPoint `json:"points" faker:"slice_len=30"`
+}
+
+type GetUserMachineMetricSeriesResponse ApiDataResponse[[]MachineMetricSeries]
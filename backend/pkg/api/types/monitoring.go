@@ -0,0 +1,31 @@
+package types
+
+type MonitoringServiceReport struct {
+	Emitter   string            `json:"emitter"`
+	Status    string            `json:"status" tstype:"'running' | 'success' | 'failure'" faker:"oneof: running, success, failure"`
+	Timestamp int64             `json:"timestamp"`
+	Metadata  map[string]string `json:"metadata"`
+}
+
+type MonitoringServiceAlert struct {
+	Rule    string `json:"rule" tstype:"'service_down' | 'running_too_long' | 'rolling_lag'" faker:"oneof: service_down, running_too_long, rolling_lag"`
+	Emitter string `json:"emitter,omitempty"`
+	Summary string `json:"summary"`
+	StartTs int64  `json:"start_ts"`
+}
+
+type MonitoringServiceStatus struct {
+	Id      string                    `json:"id"`
+	Healthy bool                      `json:"healthy"`
+	Alerts  []MonitoringServiceAlert  `json:"alerts"`
+	Latest  []MonitoringServiceReport `json:"latest"`  // latest report of every emitter
+	History []MonitoringServiceReport `json:"history"` // latest report of the most recent runs, newest first
+}
+
+type MonitoringStatusData struct {
+	DeploymentType string                    `json:"deployment_type"`
+	Healthy        bool                      `json:"healthy"`
+	Services       []MonitoringServiceStatus `json:"services"`
+}
+
+type InternalGetMonitoringStatusResponse ApiDataResponse[MonitoringStatusData]
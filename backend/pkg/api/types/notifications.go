@@ -41,7 +41,7 @@ type NotificationDashboardsTableRow struct {
 	GroupId            uint64         `db:"group_id" json:"group_id"`
 	GroupName          string         `db:"group_name" json:"group_name"`
 	EntityCount        uint64         `db:"entity_count" json:"entity_count"`
-	EventTypes         pq.StringArray `db:"event_types" json:"event_types" tstype:"('validator_online' | 'validator_offline' | 'group_efficiency_below' | 'attestation_missed' | 'proposal_success' | 'proposal_missed' | 'proposal_upcoming' | 'max_collateral' | 'min_collateral' | 'sync' | 'withdrawal' | 'validator_got_slashed' | 'validator_has_slashed' | 'incoming_tx' | 'outgoing_tx' | 'transfer_erc20' | 'transfer_erc721' | 'transfer_erc1155')[]" faker:"slice_len=2, oneof: validator_online, validator_offline, group_efficiency_below, attestation_missed, proposal_success, proposal_missed, proposal_upcoming, max_collateral, min_collateral, sync, withdrawal, validator_got_slashed, validator_has_slashed, incoming_tx, outgoing_tx, transfer_erc20, transfer_erc721, transfer_erc1155"`
+	EventTypes         pq.StringArray `db:"event_types" json:"event_types" tstype:"('validator_online' | 'validator_offline' | 'group_efficiency_below' | 'attestation_missed' | 'proposal_success' | 'proposal_missed' | 'proposal_upcoming' | 'max_collateral' | 'min_collateral' | 'sync' | 'withdrawal' | 'validator_got_slashed' | 'validator_has_slashed' | 'pool_operation_not_included' | 'incoming_tx' | 'outgoing_tx' | 'transfer_erc20' | 'transfer_erc721' | 'transfer_erc1155')[]" faker:"slice_len=2, oneof: validator_online, validator_offline, group_efficiency_below, attestation_missed, proposal_success, proposal_missed, proposal_upcoming, max_collateral, min_collateral, sync, withdrawal, validator_got_slashed, validator_has_slashed, pool_operation_not_included, incoming_tx, outgoing_tx, transfer_erc20, transfer_erc721, transfer_erc1155"`
 }
 
 type InternalGetUserNotificationDashboardsResponse ApiPagingResponse[NotificationDashboardsTableRow]
@@ -60,6 +60,12 @@ type NotificationEventWithdrawal struct {
 	Address Address         `json:"address"`
 }
 
+type NotificationEventPoolOperation struct {
+	Index         uint64 `json:"index"`
+	Type          string `json:"type" tstype:"'voluntary_exit' | 'bls_to_execution_change'" faker:"oneof: voluntary_exit, bls_to_execution_change"`
+	FirstSeenSlot uint64 `json:"first_seen_slot"`
+}
+
 type NotificationValidatorDashboardDetail struct {
 	DashboardName            string                                 `db:"dashboard_name" json:"dashboard_name"`
 	GroupName                string                                 `db:"group_name" json:"group_name"`
@@ -76,6 +82,7 @@ type NotificationValidatorDashboardDetail struct {
 	Withdrawal               []NotificationEventWithdrawal          `json:"withdrawal"`
 	MinCollateral            []Address                              `json:"min_collateral"` // node addresses
 	MaxCollateral            []Address                              `json:"max_collateral"` // node addresses
+	PoolOperationNotIncluded []NotificationEventPoolOperation       `json:"pool_operation_not_included"`
 }
 
 type InternalGetUserNotificationsValidatorDashboardResponse ApiDataResponse[NotificationValidatorDashboardDetail]
@@ -177,20 +184,38 @@ type NotificationSettingsGeneral struct {
 	MachineCpuUsageThreshold        float64 `json:"machine_cpu_usage_threshold" faker:"boundary_start=0, boundary_end=1"`
 	IsMachineMemoryUsageSubscribed  bool    `json:"is_machine_memory_usage_subscribed"`
 	MachineMemoryUsageThreshold     float64 `json:"machine_memory_usage_threshold" faker:"boundary_start=0, boundary_end=1"`
+
+	// delivery policy for email, push and chat notifications, critical events (e.g. slashings) are always delivered immediately
+	DeliveryMode        string                          `json:"delivery_mode" tstype:"'immediate' | 'hourly_digest' | 'daily_digest'" faker:"oneof: immediate, hourly_digest, daily_digest"`
+	IsQuietHoursEnabled bool                            `json:"is_quiet_hours_enabled"`
+	QuietHoursStart     uint64                          `json:"quiet_hours_start" faker:"boundary_start=0, boundary_end=23"`  // hour of the day in the given timezone
+	QuietHoursEnd       uint64                          `json:"quiet_hours_end" faker:"boundary_start=0, boundary_end=23"`    // hour of the day in the given timezone
+	Timezone            string                          `json:"timezone" faker:"oneof: UTC, Europe/Berlin, America/New_York"` // IANA time zone name
+	GroupDeliveryModes  []NotificationGroupDeliveryMode `json:"group_delivery_modes"`                                         // overrides DeliveryMode for single dashboard groups
+}
+type NotificationGroupDeliveryMode struct {
+	DashboardId  uint64 `db:"dashboard_id" json:"dashboard_id"`
+	GroupId      uint64 `db:"group_id" json:"group_id"`
+	DeliveryMode string `db:"delivery_mode" json:"delivery_mode" tstype:"'immediate' | 'hourly_digest' | 'daily_digest'" faker:"oneof: immediate, hourly_digest, daily_digest"`
 }
 type InternalPutUserNotificationSettingsGeneralResponse ApiDataResponse[NotificationSettingsGeneral]
 type NotificationSettings struct {
-	GeneralSettings NotificationSettingsGeneral  `json:"general_settings"`
-	HasMachines     bool                         `json:"has_machines"`
-	Networks        []NotificationNetwork        `json:"networks"`
-	PairedDevices   []NotificationPairedDevice   `json:"paired_devices"`
-	Clients         []NotificationSettingsClient `json:"clients" faker:"slice_len=10"`
+	GeneralSettings  NotificationSettingsGeneral  `json:"general_settings"`
+	HasMachines      bool                         `json:"has_machines"`
+	IsTelegramLinked bool                         `json:"is_telegram_linked"`
+	Networks         []NotificationNetwork        `json:"networks"`
+	PairedDevices    []NotificationPairedDevice   `json:"paired_devices"`
+	Clients          []NotificationSettingsClient `json:"clients" faker:"slice_len=10"`
 }
 type InternalGetUserNotificationSettingsResponse ApiDataResponse[NotificationSettings]
 
 type NotificationSettingsValidatorDashboard struct {
 	WebhookUrl              string `json:"webhook_url" faker:"url"`
 	IsWebhookDiscordEnabled bool   `json:"is_webhook_discord_enabled"`
+	WebhookSigningSecret    string `json:"webhook_signing_secret,omitempty"` // read-only, used to verify the `X-Signature` header of webhook deliveries
+	IsTelegramEnabled       bool   `json:"is_telegram_enabled"`
+	SlackWebhookUrl         string `json:"slack_webhook_url" faker:"url"`
+	MatrixRoomId            string `json:"matrix_room_id"`
 
 	IsValidatorOfflineSubscribed      bool    `json:"is_validator_offline_subscribed"`
 	IsGroupEfficiencyBelowSubscribed  bool    `json:"is_group_efficiency_below_subscribed"`
@@ -202,10 +227,17 @@ type NotificationSettingsValidatorDashboard struct {
 	IsWithdrawalProcessedSubscribed   bool    `json:"is_withdrawal_processed_subscribed"`
 	IsSlashedSubscribed               bool    `json:"is_slashed_subscribed"`
 
+	IsPoolOperationNotIncludedSubscribed bool   `json:"is_pool_operation_not_included_subscribed"`
+	PoolOperationNotIncludedThreshold    uint64 `json:"pool_operation_not_included_threshold" faker:"boundary_start=1, boundary_end=100"` // epochs
+
 	IsMaxCollateralSubscribed bool    `json:"is_max_collateral_subscribed"`
 	MaxCollateralThreshold    float64 `json:"max_collateral_threshold" faker:"boundary_start=0, boundary_end=1"`
 	IsMinCollateralSubscribed bool    `json:"is_min_collateral_subscribed"`
 	MinCollateralThreshold    float64 `json:"min_collateral_threshold" faker:"boundary_start=0, boundary_end=1"`
+
+	IsIncomeReportSubscribed bool   `json:"is_income_report_subscribed"`
+	IncomeReportCurrency     string `json:"income_report_currency" tstype:"'USD' | 'EUR' | 'GBP' | 'CAD' | 'JPY' | 'CNY' | 'AUD' | 'RUB'" faker:"oneof: USD, EUR, GBP, CAD, JPY, CNY, AUD, RUB"`
+	IncomeReportFormat       string `json:"income_report_format" tstype:"'pdf' | 'csv' | 'koinly' | 'cointracking'" faker:"oneof: pdf, csv, koinly, cointracking"`
 }
 
 type InternalPutUserNotificationSettingsValidatorDashboardResponse ApiDataResponse[NotificationSettingsValidatorDashboard]
@@ -237,3 +269,35 @@ type NotificationSettingsDashboardsTableRow struct {
 }
 
 type InternalGetUserNotificationSettingsDashboardsResponse ApiPagingResponse[NotificationSettingsDashboardsTableRow]
+
+type NotificationWebhookSigningSecret struct {
+	WebhookSigningSecret string `json:"webhook_signing_secret"`
+}
+
+type InternalPostUserNotificationSettingsValidatorDashboardWebhookSecretResponse ApiDataResponse[NotificationWebhookSigningSecret]
+
+type NotificationTelegramLink struct {
+	LinkUrl          string `json:"link_url"` // opens a chat with the telegram bot, starting the chat links it to the account
+	ExpiresTimestamp int64  `json:"expires_timestamp"`
+}
+
+type InternalPostUserNotificationsTelegramLinkResponse ApiDataResponse[NotificationTelegramLink]
+
+// ------------------------------------------------------------
+// Webhook Dead Letters
+type NotificationWebhookDeadLetter struct {
+	Id                   uint64 `db:"id" json:"id"`
+	Channel              string `db:"channel" json:"channel" tstype:"'webhook' | 'webhook_discord' | 'telegram' | 'slack' | 'matrix'" faker:"oneof: webhook, webhook_discord, telegram, slack, matrix"`
+	WebhookUrl           string `db:"webhook_url" json:"webhook_url" faker:"url"`
+	DashboardId          uint64 `db:"dashboard_id" json:"dashboard_id,omitempty"`
+	GroupId              uint64 `db:"group_id" json:"group_id,omitempty"`
+	CreatedTimestamp     int64  `json:"created_timestamp"`
+	FailedTimestamp      int64  `json:"failed_timestamp"`
+	RedeliveredTimestamp int64  `json:"redelivered_timestamp,omitempty"`
+	Attempts             uint64 `db:"retries" json:"attempts"`
+	LastStatus           string `json:"last_status,omitempty"`
+	LastError            string `json:"last_error,omitempty"`
+	Payload              string `json:"payload"` // json encoded request body of the failed delivery
+}
+
+type InternalGetUserNotificationWebhookDeadLettersResponse ApiPagingResponse[NotificationWebhookDeadLetter]
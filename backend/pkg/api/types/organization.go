@@ -0,0 +1,45 @@
+package types
+
+type Organization struct {
+	Id          uint64 `json:"id"`
+	Name        string `json:"name"`
+	Role        string `json:"role" tstype:"'owner' | 'admin' | 'editor' | 'viewer'" faker:"oneof: owner, admin, editor, viewer"` // role of the requesting user
+	MemberCount uint64 `json:"member_count"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
+type InternalGetUserOrganizationsResponse ApiDataResponse[[]Organization]
+
+type InternalPostUserOrganizationsResponse ApiDataResponse[Organization]
+
+type OrganizationDetails struct {
+	Organization
+	// best perks of all members, they apply to all dashboards owned by the organization
+	PremiumPerks PremiumPerks `json:"premium_perks"`
+}
+
+type InternalGetOrganizationResponse ApiDataResponse[OrganizationDetails]
+
+type InternalPutOrganizationResponse ApiDataResponse[Organization]
+
+type OrganizationMember struct {
+	UserId   uint64 `json:"user_id"`
+	Email    string `json:"email"`
+	Role     string `json:"role" tstype:"'owner' | 'admin' | 'editor' | 'viewer'" faker:"oneof: owner, admin, editor, viewer"`
+	JoinedAt int64  `json:"joined_at"`
+}
+
+type InternalGetOrganizationMembersResponse ApiDataResponse[[]OrganizationMember]
+
+type InternalPutOrganizationMemberResponse ApiDataResponse[OrganizationMember]
+
+type OrganizationInvitation struct {
+	Id        uint64 `json:"id"`
+	Email     string `json:"email"`
+	Role      string `json:"role" tstype:"'owner' | 'admin' | 'editor' | 'viewer'" faker:"oneof: owner, admin, editor, viewer"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+type InternalGetOrganizationInvitationsResponse ApiDataResponse[[]OrganizationInvitation]
+
+type InternalPostOrganizationInvitationsResponse ApiDataResponse[OrganizationInvitation]
@@ -43,3 +43,20 @@ type SlotVizEpoch struct {
 }
 
 type GetValidatorDashboardSlotVizResponse ApiDataResponse[[]SlotVizEpoch]
+
+// ------------------------------------------------------------
+// Slot Duties Stream
+type VDBSlotDutyResult struct {
+	Validator uint64 `json:"validator"`
+	GroupId   uint64 `json:"group_id"`
+	Duty      string `json:"duty" tstype:"'proposal' | 'attestation' | 'sync'" faker:"oneof: proposal, attestation, sync"`
+	Status    string `json:"status" tstype:"'success' | 'failed'" faker:"oneof: success, failed"`
+	DutySlot  uint64 `json:"duty_slot"` // slot the duty was assigned to, differs from the event slot for attestations
+}
+
+// VDBSlotDutiesEvent is pushed to the dashboard event stream for every new head slot that contains duties of the dashboard validators
+type VDBSlotDutiesEvent struct {
+	Slot   uint64              `json:"slot"`
+	Epoch  uint64              `json:"epoch"`
+	Duties []VDBSlotDutyResult `json:"duties"`
+}
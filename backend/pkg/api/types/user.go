@@ -32,6 +32,37 @@ type EmailUpdate struct {
 
 type InternalPostUserEmailResponse ApiDataResponse[EmailUpdate]
 
+type ApiKey struct {
+	Id        uint64   `json:"id"`
+	Name      string   `json:"name"`
+	Key       string   `json:"key"` // the full key is only returned when it is created or rotated
+	Scopes    []string `json:"scopes" tstype:"('read_dashboards' | 'manage_dashboards' | 'manage_notifications' | 'push_machine_metrics' | 'manage_broadcasts')[]" faker:"slice_len=2"`
+	Networks  []uint64 `json:"networks"` // chain ids the key is restricted to, empty if the key can be used on all networks
+	CreatedAt int64    `json:"created_at" faker:"unix_time"`
+}
+
+type InternalGetUserApiKeysResponse ApiDataResponse[[]ApiKey]
+
+type InternalPostUserApiKeysResponse ApiDataResponse[ApiKey]
+
+type InternalPutUserApiKeyResponse ApiDataResponse[ApiKey]
+
+type ApiUsageDataPoint struct {
+	Timestamp int64  `json:"timestamp" faker:"unix_time"` // start of the aggregation interval
+	Requests  uint64 `json:"requests"`
+	Weight    uint64 `json:"weight"` // requests multiplied with the weight of the endpoint, this is what counts towards the rate limits
+}
+
+type ApiKeyEndpointUsage struct {
+	ApiKeyId uint64              `json:"api_key_id"`
+	Endpoint string              `json:"endpoint"`
+	Requests uint64              `json:"requests"`
+	Weight   uint64              `json:"weight"`
+	Data     []ApiUsageDataPoint `json:"data"`
+}
+
+type GetUserApiUsageResponse ApiDataResponse[[]ApiKeyEndpointUsage]
+
 type AdConfigurationUpdateData struct {
 	JQuerySelector  string `json:"jquery_selector"`
 	InsertMode      string `json:"insert_mode"`
@@ -135,6 +166,7 @@ type PremiumPerks struct {
 	MachineMonitoringHistorySeconds                uint64              `json:"machine_monitoring_history_seconds"`
 	NotificationsMachineCustomThreshold            bool                `json:"notifications_machine_custom_threshold"`
 	NotificationsValidatorDashboardGroupEfficiency bool                `json:"notifications_validator_dashboard_group_efficiency"`
+	ValidatorDashboardExports                      bool                `json:"validator_dashboard_exports"`
 }
 
 // TODO @patrick post-beta StripeCreateCheckoutSession and StripeCustomerPortal are currently served from v1 (loadbalanced), Once V1 is not affected by this anymore, consider wrapping this with ApiDataResponse
@@ -148,10 +180,108 @@ type StripeCustomerPortal struct {
 	Url string `json:"url"`
 }
 
-type OAuthAppData struct {
-	ID          uint64 `db:"id"`
-	Owner       uint64 `db:"owner_id"`
-	AppName     string `db:"app_name"`
-	RedirectURI string `db:"redirect_uri"`
-	Active      bool   `db:"active"`
+type OAuthApp struct {
+	Id           uint64   `json:"id"`
+	Name         string   `json:"name"`
+	ClientId     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret,omitempty"` // only returned once after the app was created
+	RedirectUri  string   `json:"redirect_uri"`
+	Scopes       []string `json:"scopes"`
+	Confidential bool     `json:"confidential"`
+	CreatedAt    int64    `json:"created_at"`
 }
+
+type InternalGetUserOAuthAppsResponse ApiDataResponse[[]OAuthApp]
+
+type InternalPostUserOAuthAppsResponse ApiDataResponse[OAuthApp]
+
+// OAuthConsentRequest is shown to the user before an app gets access to their account
+type OAuthConsentRequest struct {
+	ClientId    string   `json:"client_id"`
+	AppName     string   `json:"app_name"`
+	RedirectUri string   `json:"redirect_uri"`
+	Scopes      []string `json:"scopes"`
+	FirstParty  bool     `json:"first_party"`
+}
+
+type InternalGetOAuthAuthorizeResponse ApiDataResponse[OAuthConsentRequest]
+
+type OAuthAuthorizeRedirect struct {
+	RedirectUri string `json:"redirect_uri"`
+}
+
+type InternalPostOAuthAuthorizeResponse ApiDataResponse[OAuthAuthorizeRedirect]
+
+// OAuthTokenResponse and OAuthErrorResponse are defined by RFC 6749 and not wrapped like other responses
+type OAuthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    uint64 `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	Scope        string `json:"scope"`
+}
+
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+type LoginResult struct {
+	SecondFactorRequired bool     `json:"second_factor_required"`
+	SecondFactorMethods  []string `json:"second_factor_methods,omitempty" tstype:"('totp' | 'recovery_code' | 'webauthn')[]"`
+}
+
+type InternalPostLoginResponse ApiDataResponse[LoginResult]
+
+type TotpSetup struct {
+	Secret string `json:"secret"`
+	Uri    string `json:"uri"` // otpauth uri, usually shown as qr code
+}
+
+type InternalPostUserTotpResponse ApiDataResponse[TotpSetup]
+
+type InternalPostUserRecoveryCodesResponse ApiDataResponse[[]string]
+
+type UserWebAuthnCredential struct {
+	Id         uint64 `json:"id"`
+	Name       string `json:"name"`
+	CreatedAt  int64  `json:"created_at"`
+	LastUsedAt *int64 `json:"last_used_at,omitempty"`
+}
+
+type InternalPostUserWebAuthnCredentialsResponse ApiDataResponse[UserWebAuthnCredential]
+
+type UserSecondFactors struct {
+	TotpEnabled            bool                     `json:"totp_enabled"`
+	RecoveryCodesRemaining uint64                   `json:"recovery_codes_remaining"`
+	WebAuthnCredentials    []UserWebAuthnCredential `json:"webauthn_credentials"`
+}
+
+type InternalGetUserSecondFactorsResponse ApiDataResponse[UserSecondFactors]
+
+// WebAuthnOptions contains the parameters for navigator.credentials.create (registration) and navigator.credentials.get (login).
+// Binary values are base64url encoded.
+type WebAuthnOptions struct {
+	Challenge     string   `json:"challenge"`
+	RpId          string   `json:"rp_id"`
+	RpName        string   `json:"rp_name,omitempty"`
+	UserId        string   `json:"user_id,omitempty"`
+	UserName      string   `json:"user_name,omitempty"`
+	Algorithms    []int64  `json:"algorithms,omitempty"`
+	CredentialIds []string `json:"credential_ids"` // credentials to exclude during registration or to allow during login
+	Timeout       uint64   `json:"timeout"`        // milliseconds
+}
+
+type InternalPostWebAuthnChallengesResponse ApiDataResponse[WebAuthnOptions]
+
+type UserSession struct {
+	Id         string `json:"id"`
+	Type       string `json:"type" tstype:"'web' | 'mobile'" faker:"oneof: web, mobile"`
+	DeviceName string `json:"device_name,omitempty"`
+	UserAgent  string `json:"user_agent,omitempty"`
+	Ip         string `json:"ip,omitempty"`
+	CreatedAt  int64  `json:"created_at,omitempty"`
+	Current    bool   `json:"current"`
+}
+
+type InternalGetUserSessionsResponse ApiDataResponse[[]UserSession]
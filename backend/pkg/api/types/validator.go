@@ -0,0 +1,104 @@
+package types
+
+import "github.com/shopspring/decimal"
+
+// ------------------------------------------------------------
+// Validators
+type ValidatorData struct {
+	Index                      uint64          `json:"index"`
+	PublicKey                  PubKey          `json:"public_key"`
+	Status                     string          `json:"status" tstype:"'slashed' | 'exited' | 'deposited' | 'pending' | 'slashing_offline' | 'slashing_online' | 'exiting_offline' | 'exiting_online' | 'active_offline' | 'active_online'" faker:"oneof: slashed, exited, deposited, pending, slashing_offline, slashing_online, exiting_offline, exiting_online, active_offline, active_online"`
+	Balance                    decimal.Decimal `json:"balance" faker:"eth"`
+	EffectiveBalance           decimal.Decimal `json:"effective_balance" faker:"eth"`
+	Slashed                    bool            `json:"slashed"`
+	WithdrawalCredential       Hash            `json:"withdrawal_credential"`
+	ActivationEligibilityEpoch *uint64         `json:"activation_eligibility_epoch,omitempty"`
+	ActivationEpoch            *uint64         `json:"activation_epoch,omitempty"`
+	ExitEpoch                  *uint64         `json:"exit_epoch,omitempty"`
+	WithdrawableEpoch          *uint64         `json:"withdrawable_epoch,omitempty"`
+	QueuePosition              *uint64         `json:"queue_position,omitempty"`
+}
+
+type GetNetworkValidatorsResponse ApiDataResponse[[]ValidatorData]
+
+type GetNetworkValidatorResponse ApiDataResponse[ValidatorData]
+
+type GetNetworkAddressValidatorsResponse ApiPagingResponse[ValidatorData]
+
+type GetNetworkWithdrawalCredentialValidatorsResponse ApiPagingResponse[ValidatorData]
+
+// ------------------------------------------------------------
+// Duties
+type ValidatorProposalDuty struct {
+	Slot   uint64 `json:"slot"`
+	Status string `json:"status" tstype:"'scheduled' | 'proposed' | 'missed'" faker:"oneof: scheduled, proposed, missed"`
+}
+
+type ValidatorSyncCommitteeDuty struct {
+	Period     uint64 `json:"period"`
+	StartEpoch uint64 `json:"start_epoch"`
+	EndEpoch   uint64 `json:"end_epoch"`
+}
+
+type ValidatorDuties struct {
+	Epoch         uint64                      `json:"epoch"`
+	Proposals     []ValidatorProposalDuty     `json:"proposals"`
+	SyncCommittee *ValidatorSyncCommitteeDuty `json:"sync_committee,omitempty"`
+}
+
+type GetNetworkValidatorDutiesResponse ApiDataResponse[ValidatorDuties]
+
+// ------------------------------------------------------------
+// Pool Operations
+type ValidatorPoolOperation struct {
+	Index              uint64   `json:"index"`
+	Type               string   `json:"type" tstype:"'voluntary_exit' | 'bls_to_execution_change'" faker:"oneof: voluntary_exit, bls_to_execution_change"`
+	Status             string   `json:"status" tstype:"'pending' | 'included' | 'dropped'" faker:"oneof: pending, included, dropped"`
+	Signature          Hash     `json:"signature"`
+	ExitEpoch          *uint64  `json:"exit_epoch,omitempty"`
+	ToExecutionAddress *Address `json:"to_execution_address,omitempty"`
+	FirstSeenTs        int64    `json:"first_seen_ts"`
+	FirstSeenSlot      uint64   `json:"first_seen_slot"`
+	LastSeenSlot       uint64   `json:"last_seen_slot"`
+	IncludedSlot       *uint64  `json:"included_slot,omitempty"`
+	DroppedSlot        *uint64  `json:"dropped_slot,omitempty"`
+}
+
+type GetNetworkValidatorPoolOperationsResponse ApiDataResponse[[]ValidatorPoolOperation]
+
+// ------------------------------------------------------------
+// Statuses
+type ValidatorStatusCount struct {
+	Status string `json:"status" tstype:"'slashed' | 'exited' | 'deposited' | 'pending' | 'slashing_offline' | 'slashing_online' | 'exiting_offline' | 'exiting_online' | 'active_offline' | 'active_online'" faker:"oneof: slashed, exited, deposited, pending, slashing_offline, slashing_online, exiting_offline, exiting_online, active_offline, active_online"`
+	Count  uint64 `json:"count"`
+}
+
+type GetNetworkValidatorStatusesResponse ApiDataResponse[[]ValidatorStatusCount]
+
+// ------------------------------------------------------------
+// Leaderboard
+type ValidatorLeaderboardTableRow struct {
+	Rank      uint64                                     `json:"rank"` // based on the consensus layer income of the last 7 days
+	Index     uint64                                     `json:"index"`
+	PublicKey PubKey                                     `json:"public_key"`
+	Balance   decimal.Decimal                            `json:"balance" faker:"eth"`
+	Income    PeriodicValues[ClElValue[decimal.Decimal]] `json:"income"`
+}
+
+type GetNetworkValidatorLeaderboardResponse ApiPagingResponse[ValidatorLeaderboardTableRow]
+
+// ------------------------------------------------------------
+// Queue
+type ValidatorQueueData struct {
+	Count                 uint64          `json:"count"`
+	Balance               decimal.Decimal `json:"balance" faker:"eth"`     // sum of the effective balances
+	ChurnLimit            uint64          `json:"churn_limit"`             // validators per epoch
+	EstimatedWaitDuration uint64          `json:"estimated_wait_duration"` // in seconds
+}
+
+type ValidatorQueue struct {
+	Entering ValidatorQueueData `json:"entering"`
+	Exiting  ValidatorQueueData `json:"exiting"`
+}
+
+type GetNetworkValidatorQueueResponse ApiDataResponse[ValidatorQueue]
@@ -19,6 +19,12 @@ type VDBOverviewBalances struct {
 	StakedEth decimal.Decimal `json:"staked_eth"`
 }
 
+// VDBOperatorShare is the share of the validator income that goes to the node operator for validators of a staking protocol
+type VDBOperatorShare struct {
+	Protocol      string  `json:"protocol"` // lido_csm, ssv or obol
+	OperatorShare float64 `json:"operator_share"`
+}
+
 type VDBOverviewData struct {
 	Name                string                                     `json:"name,omitempty" extensions:"x-order=1"`
 	Network             uint64                                     `json:"network"`
@@ -29,6 +35,7 @@ type VDBOverviewData struct {
 	Apr                 PeriodicValues[ClElValue[float64]]         `json:"apr"`
 	ChartHistorySeconds ChartHistorySeconds                        `json:"chart_history_seconds"`
 	Balances            VDBOverviewBalances                        `json:"balances"`
+	OperatorShares      []VDBOperatorShare                         `json:"operator_shares"`
 }
 
 type GetValidatorDashboardResponse ApiDataResponse[VDBOverviewData]
@@ -246,8 +253,11 @@ type VDBConsensusDepositsTableRow struct {
 	Epoch                uint64          `json:"epoch"`
 	Slot                 uint64          `json:"slot"`
 	WithdrawalCredential Hash            `json:"withdrawal_credential"`
-	Amount               decimal.Decimal `json:"amount"`
+	Amount               decimal.Decimal `json:"amount"` // zero for consolidations, the request does not contain the moved balance
 	Signature            Hash            `json:"signature"`
+	Type                 string          `json:"type" tstype:"'deposit' | 'deposit_request' | 'consolidation'" faker:"oneof: deposit, deposit_request, consolidation"`
+	// only set for consolidations, the validator whose balance is moved into this one
+	ConsolidatedFrom *uint64 `json:"consolidated_from,omitempty"`
 }
 type GetValidatorDashboardConsensusLayerDepositsResponse ApiPagingResponse[VDBConsensusDepositsTableRow]
 
@@ -272,6 +282,7 @@ type VDBWithdrawalsTableRow struct {
 	GroupId           uint64          `json:"group_id"`
 	Recipient         Address         `json:"recipient"`
 	Amount            decimal.Decimal `json:"amount"`
+	Type              string          `json:"type" tstype:"'full' | 'partial'" faker:"oneof: full, partial"`
 	IsMissingEstimate bool            `json:"is_missing_estimate"`
 }
 type GetValidatorDashboardWithdrawalsResponse ApiPagingResponse[VDBWithdrawalsTableRow]
@@ -282,6 +293,24 @@ type VDBTotalWithdrawalsData struct {
 
 type GetValidatorDashboardTotalWithdrawalsResponse ApiDataResponse[VDBTotalWithdrawalsData]
 
+// ------------------------------------------------------------
+// Pool Operations Tab
+type VDBPoolOperationsTableRow struct {
+	Index              uint64   `json:"index"`
+	GroupId            uint64   `json:"group_id"`
+	Type               string   `json:"type" tstype:"'voluntary_exit' | 'bls_to_execution_change'" faker:"oneof: voluntary_exit, bls_to_execution_change"`
+	Status             string   `json:"status" tstype:"'pending' | 'included' | 'dropped'" faker:"oneof: pending, included, dropped"`
+	Signature          Hash     `json:"signature"`
+	ExitEpoch          *uint64  `json:"exit_epoch,omitempty"`
+	ToExecutionAddress *Address `json:"to_execution_address,omitempty"`
+	FirstSeenTs        int64    `json:"first_seen_ts"`
+	FirstSeenSlot      uint64   `json:"first_seen_slot"`
+	LastSeenSlot       uint64   `json:"last_seen_slot"`
+	IncludedSlot       *uint64  `json:"included_slot,omitempty"`
+	DroppedSlot        *uint64  `json:"dropped_slot,omitempty"`
+}
+type GetValidatorDashboardPoolOperationsResponse ApiDataResponse[[]VDBPoolOperationsTableRow]
+
 // ------------------------------------------------------------
 // Rocket Pool Tab
 type VDBRocketPoolTableRow struct {
@@ -350,6 +379,8 @@ type VDBPostReturnData struct {
 	Name      string `db:"name" json:"name"`
 	Network   uint64 `db:"network" json:"network"`
 	CreatedAt int64  `db:"created_at" json:"created_at"`
+
+	OrganizationId *uint64 `db:"organization_id" json:"organization_id,omitempty"`
 }
 
 type VDBPostCreateGroupData struct {
@@ -39,7 +39,7 @@ type BlobIndexer struct {
 	S3Client          *s3.Client
 	running           bool
 	runningMu         *sync.Mutex
-	clEndpoint        string
+	clEndpoints       []string
 	cl                consapi.Client
 	id                string
 	networkID         string
@@ -48,21 +48,10 @@ type BlobIndexer struct {
 
 func NewBlobIndexer() (*BlobIndexer, error) {
 	initDB()
-	cfg, err := config.LoadDefaultConfig(context.TODO(),
-		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
-			utils.Config.BlobIndexer.S3.AccessKeyId,
-			utils.Config.BlobIndexer.S3.AccessKeySecret,
-			"",
-		)),
-		config.WithRegion("auto"),
-	)
+	s3Client, err := newS3Client()
 	if err != nil {
 		return nil, err
 	}
-	s3Client := s3.NewFromConfig(cfg, func(o *s3.Options) {
-		o.UsePathStyle = true
-		o.BaseEndpoint = aws.String(utils.Config.BlobIndexer.S3.Endpoint)
-	})
 
 	writtenBlobsCache, err := lru.New[string, bool](1000)
 	if err != nil {
@@ -70,17 +59,36 @@ func NewBlobIndexer() (*BlobIndexer, error) {
 	}
 
 	id := utils.GetUUID()
+	clEndpoints := utils.GetConsensusNodeEndpoints()
 	bi := &BlobIndexer{
 		S3Client:          s3Client,
 		runningMu:         &sync.Mutex{},
-		clEndpoint:        "http://" + utils.Config.Indexer.Node.Host + ":" + utils.Config.Indexer.Node.Port,
-		cl:                consapi.NewClient("http://" + utils.Config.Indexer.Node.Host + ":" + utils.Config.Indexer.Node.Port),
+		clEndpoints:       clEndpoints,
+		cl:                consapi.NewMultiNodeClient(context.Background(), clEndpoints, nil),
 		id:                id,
 		writtenBlobsCache: writtenBlobsCache,
 	}
 	return bi, nil
 }
 
+func newS3Client() (*s3.Client, error) {
+	cfg, err := config.LoadDefaultConfig(context.TODO(),
+		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
+			utils.Config.BlobIndexer.S3.AccessKeyId,
+			utils.Config.BlobIndexer.S3.AccessKeySecret,
+			"",
+		)),
+		config.WithRegion("auto"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = true
+		o.BaseEndpoint = aws.String(utils.Config.BlobIndexer.S3.Endpoint)
+	}), nil
+}
+
 func initDB() {
 	if utils.Config.BlobIndexer.DisableStatusReports {
 		return
@@ -118,7 +126,7 @@ func (bi *BlobIndexer) Start() {
 	bi.running = true
 	bi.runningMu.Unlock()
 
-	log.InfoWithFields(log.Fields{"version": version.Version, "clEndpoint": bi.clEndpoint, "s3Endpoint": utils.Config.BlobIndexer.S3.Endpoint, "id": bi.id}, "starting blobindexer")
+	log.InfoWithFields(log.Fields{"version": version.Version, "clEndpoints": bi.clEndpoints, "s3Endpoint": utils.Config.BlobIndexer.S3.Endpoint, "id": bi.id}, "starting blobindexer")
 	for {
 		err := bi.index()
 		if err != nil {
@@ -140,7 +148,7 @@ func (bi *BlobIndexer) index() error {
 	g.SetLimit(3)
 	g.Go(func() error {
 		var err error
-		spec, err = bi.cl.GetSpec()
+		spec, err = bi.cl.GetSpec(gCtx)
 		if err != nil {
 			return fmt.Errorf("error bi.cl.GetSpec: %w", err)
 		}
@@ -148,7 +156,7 @@ func (bi *BlobIndexer) index() error {
 	})
 	g.Go(func() error {
 		var err error
-		headHeader, err = bi.cl.GetBlockHeader("head")
+		headHeader, err = bi.cl.GetBlockHeader(gCtx, "head")
 		if err != nil {
 			return fmt.Errorf("error bi.cl.GetBlockHeader(head): %w", err)
 		}
@@ -156,7 +164,7 @@ func (bi *BlobIndexer) index() error {
 	})
 	g.Go(func() error {
 		var err error
-		finalizedHeader, err = bi.cl.GetBlockHeader("finalized")
+		finalizedHeader, err = bi.cl.GetBlockHeader(gCtx, "finalized")
 		if err != nil {
 			return fmt.Errorf("error bi.cl.GetBlockHeader(finalized): %w", err)
 		}
@@ -202,7 +210,7 @@ func (bi *BlobIndexer) index() error {
 		minBlobSlot = minBlobSlot - pruneMarginSlotRange
 	}
 	if status.LastIndexedFinalizedSlot < minBlobSlot && status.LastIndexedFinalizedBlobSlot > 0 {
-		bs, err := bi.cl.GetBlobSidecars(status.LastIndexedFinalizedBlobSlot)
+		bs, err := bi.cl.GetBlobSidecars(context.Background(), status.LastIndexedFinalizedBlobSlot)
 		if err != nil {
 			return err
 		}
@@ -259,7 +267,7 @@ func (bi *BlobIndexer) index() error {
 					return gCtx.Err()
 				default:
 				}
-				numBlobs, err := bi.indexBlobsAtSlot(slot)
+				numBlobs, err := bi.indexBlobsAtSlot(gCtx, slot)
 				if err != nil {
 					return fmt.Errorf("error bi.IndexBlobsAtSlot(%v): %w", slot, err)
 				}
@@ -315,10 +323,10 @@ func (bi *BlobIndexer) index() error {
 	return nil
 }
 
-func (bi *BlobIndexer) indexBlobsAtSlot(slot uint64) (int, error) {
+func (bi *BlobIndexer) indexBlobsAtSlot(ctx context.Context, slot uint64) (int, error) {
 	tGetBlobSidcar := time.Now()
 
-	blobSidecar, err := bi.cl.GetBlobSidecars(slot)
+	blobSidecar, err := bi.cl.GetBlobSidecars(ctx, slot)
 	if err != nil {
 		httpErr := network.SpecificError(err)
 		if httpErr != nil && httpErr.StatusCode == http.StatusNotFound {
@@ -333,7 +341,7 @@ func (bi *BlobIndexer) indexBlobsAtSlot(slot uint64) (int, error) {
 		return 0, nil
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*20)
+	ctx, cancel := context.WithTimeout(ctx, time.Second*20)
 	defer cancel()
 
 	g, gCtx := errgroup.WithContext(ctx)
@@ -0,0 +1,55 @@
+package blobindexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore provides read access to the blobs written by the BlobIndexer
+type BlobStore struct {
+	s3Client  *s3.Client
+	networkID string
+}
+
+func NewBlobStore() (*BlobStore, error) {
+	s3Client, err := newS3Client()
+	if err != nil {
+		return nil, err
+	}
+	return &BlobStore{
+		s3Client:  s3Client,
+		networkID: fmt.Sprintf("%d", utils.Config.Chain.ClConfig.DepositNetworkID),
+	}, nil
+}
+
+// GetBlob returns the blob with the given versioned hash together with the metadata the indexer stored alongside it.
+// ErrBlobNotFound is returned if the blob has not been indexed (yet) or has already been pruned.
+func (bs *BlobStore) GetBlob(ctx context.Context, versionedHash []byte) ([]byte, map[string]string, error) {
+	key := fmt.Sprintf("%s/blobs/%#x", bs.networkID, versionedHash)
+	obj, err := bs.s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &utils.Config.BlobIndexer.S3.Bucket,
+		Key:    &key,
+	})
+	if err != nil {
+		// see GetIndexerStatus for why 403 is treated as not found
+		var httpResponseErr *awshttp.ResponseError
+		if errors.As(err, &httpResponseErr) && (httpResponseErr.HTTPStatusCode() == 404 || httpResponseErr.HTTPStatusCode() == 403) {
+			return nil, nil, ErrBlobNotFound
+		}
+		return nil, nil, fmt.Errorf("error getting blob %s: %w", key, err)
+	}
+	defer obj.Body.Close()
+	data, err := io.ReadAll(obj.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading blob %s: %w", key, err)
+	}
+	return data, obj.Metadata, nil
+}
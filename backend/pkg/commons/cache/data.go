@@ -11,53 +11,53 @@ import (
 
 // LatestEpoch will return the latest epoch
 var LatestEpoch UInt64Cached = UInt64Cached{
-	cacheKey: func() string {
-		return fmt.Sprintf("%d:frontend:latestEpoch", utils.Config.Chain.ClConfig.DepositChainID)
+	cacheKey: func(chainId uint64) string {
+		return fmt.Sprintf("%d:frontend:latestEpoch", chainId)
 	},
 }
 
 var LatestNodeEpoch UInt64Cached = UInt64Cached{
-	cacheKey: func() string {
-		return fmt.Sprintf("%d:frontend:latestNodeEpoch", utils.Config.Chain.ClConfig.DepositChainID)
+	cacheKey: func(chainId uint64) string {
+		return fmt.Sprintf("%d:frontend:latestNodeEpoch", chainId)
 	},
 }
 
 var LatestNodeFinalizedEpoch UInt64Cached = UInt64Cached{
-	cacheKey: func() string {
-		return fmt.Sprintf("%d:frontend:latestNodeFinalizedEpoch", utils.Config.Chain.ClConfig.DepositChainID)
+	cacheKey: func(chainId uint64) string {
+		return fmt.Sprintf("%d:frontend:latestNodeFinalizedEpoch", chainId)
 	},
 }
 
 // LatestFinalizedEpoch will return the most recent epoch that has been finalized.
 var LatestFinalizedEpoch UInt64Cached = UInt64Cached{
-	cacheKey: func() string {
-		return fmt.Sprintf("%d:frontend:latestFinalized", utils.Config.Chain.ClConfig.DepositChainID)
+	cacheKey: func(chainId uint64) string {
+		return fmt.Sprintf("%d:frontend:latestFinalized", chainId)
 	},
 }
 
 // LatestSlot will return the latest slot
 var LatestSlot UInt64Cached = UInt64Cached{
-	cacheKey: func() string {
-		return fmt.Sprintf("%d:frontend:slot", utils.Config.Chain.ClConfig.DepositChainID)
+	cacheKey: func(chainId uint64) string {
+		return fmt.Sprintf("%d:frontend:slot", chainId)
 	},
 }
 
 // LatestProposedSlot will return the latest proposed slot
 var LatestProposedSlot UInt64Cached = UInt64Cached{
-	cacheKey: func() string {
-		return fmt.Sprintf("%d:frontend:latestProposedSlot", utils.Config.Chain.ClConfig.DepositChainID)
+	cacheKey: func(chainId uint64) string {
+		return fmt.Sprintf("%d:frontend:latestProposedSlot", chainId)
 	},
 }
 
 var LatestExportedStatisticDay UInt64Cached = UInt64Cached{
-	cacheKey: func() string {
-		return fmt.Sprintf("%d:frontend:lastExportedStatisticDay", utils.Config.Chain.ClConfig.DepositChainID)
+	cacheKey: func(chainId uint64) string {
+		return fmt.Sprintf("%d:frontend:lastExportedStatisticDay", chainId)
 	},
 }
 
 var LatestStats Cached[types.Stats] = Cached[types.Stats]{
-	cacheKey: func() string {
-		return fmt.Sprintf("%d:frontend:latestStats", utils.Config.Chain.ClConfig.DepositChainID)
+	cacheKey: func(chainId uint64) string {
+		return fmt.Sprintf("%d:frontend:latestStats", chainId)
 	},
 }
 
@@ -67,51 +67,79 @@ func FinalizationDelay() uint64 {
 }
 
 type UInt64Cached struct {
-	cacheKey func() string
+	cacheKey func(chainId uint64) string
+	chainId  uint64
+}
+
+// OfChain returns the cached value of the given chain instead of the configured one
+func (cfg UInt64Cached) OfChain(chainId uint64) UInt64Cached {
+	cfg.chainId = chainId
+	return cfg
+}
+
+func (cfg UInt64Cached) key() string {
+	if cfg.chainId != 0 {
+		return cfg.cacheKey(cfg.chainId)
+	}
+	return cfg.cacheKey(utils.Config.Chain.ClConfig.DepositChainID)
 }
 
 func (cfg UInt64Cached) Get() uint64 {
-	if wanted, err := TieredCache.GetUint64WithLocalTimeout(cfg.cacheKey(), time.Second*5); err == nil {
+	if wanted, err := TieredCache.GetUint64WithLocalTimeout(cfg.key(), time.Second*5); err == nil {
 		return wanted
 	} else {
-		log.Error(err, "error retrieving uint64 for key", 0, map[string]interface{}{"cacheKey": cfg.cacheKey(), "err": err})
+		log.Error(err, "error retrieving uint64 for key", 0, map[string]interface{}{"cacheKey": cfg.key(), "err": err})
 	}
 	return 0
 }
 
 func (cfg UInt64Cached) GetOrDefault(provideDefault func() (uint64, error)) (uint64, error) {
-	if wanted, err := TieredCache.GetUint64WithLocalTimeout(cfg.cacheKey(), time.Second*5); err == nil {
+	if wanted, err := TieredCache.GetUint64WithLocalTimeout(cfg.key(), time.Second*5); err == nil {
 		return wanted, nil
 	}
 	return provideDefault()
 }
 
 func (cfg UInt64Cached) Set(epoch uint64) error {
-	return TieredCache.SetUint64(cfg.cacheKey(), epoch, utils.Day)
+	return TieredCache.SetUint64(cfg.key(), epoch, utils.Day)
 }
 
 type Cached[T any] struct {
-	cacheKey func() string
+	cacheKey func(chainId uint64) string
+	chainId  uint64
+}
+
+// OfChain returns the cached value of the given chain instead of the configured one
+func (cfg Cached[T]) OfChain(chainId uint64) Cached[T] {
+	cfg.chainId = chainId
+	return cfg
+}
+
+func (cfg Cached[T]) key() string {
+	if cfg.chainId != 0 {
+		return cfg.cacheKey(cfg.chainId)
+	}
+	return cfg.cacheKey(utils.Config.Chain.ClConfig.DepositChainID)
 }
 
 func (cfg Cached[T]) Get() *T {
 	var wanted T
-	if wanted, err := TieredCache.GetWithLocalTimeout(cfg.cacheKey(), time.Second*5, &wanted); err == nil {
+	if wanted, err := TieredCache.GetWithLocalTimeout(cfg.key(), time.Second*5, &wanted); err == nil {
 		return wanted.(*T)
 	} else {
-		log.Error(err, "error retrieving values for key", 0, map[string]interface{}{"cacheKey": cfg.cacheKey(), "err": err})
+		log.Error(err, "error retrieving values for key", 0, map[string]interface{}{"cacheKey": cfg.key(), "err": err})
 	}
 	return nil
 }
 
 func (cfg Cached[T]) GetOrDefault(provideDefault func() (*T, error)) (*T, error) {
 	var wanted T
-	if wanted, err := TieredCache.GetWithLocalTimeout(cfg.cacheKey(), time.Second*5, &wanted); err == nil {
+	if wanted, err := TieredCache.GetWithLocalTimeout(cfg.key(), time.Second*5, &wanted); err == nil {
 		return wanted.(*T), nil
 	}
 	return provideDefault()
 }
 
 func (cfg Cached[T]) Set(value *T) error {
-	return TieredCache.Set(cfg.cacheKey(), value, utils.Day)
+	return TieredCache.Set(cfg.key(), value, utils.Day)
 }
@@ -6,15 +6,16 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	gcp_bigtable "cloud.google.com/go/bigtable"
 	"github.com/go-redis/redis/v8"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/storage"
 	"github.com/gobitfly/beaconchain/pkg/commons/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 	itypes "github.com/gobitfly/eth-rewards/types"
@@ -54,18 +55,18 @@ const (
 )
 
 type Bigtable struct {
-	client *gcp_bigtable.Client
+	store storage.Store
 
-	tableBeaconchain       *gcp_bigtable.Table
-	tableValidators        *gcp_bigtable.Table
-	tableValidatorsHistory *gcp_bigtable.Table
+	tableBeaconchain       storage.Table
+	tableValidators        storage.Table
+	tableValidatorsHistory storage.Table
 
-	tableData            *gcp_bigtable.Table
-	tableBlocks          *gcp_bigtable.Table
-	tableMetadataUpdates *gcp_bigtable.Table
-	tableMetadata        *gcp_bigtable.Table
+	tableData            storage.Table
+	tableBlocks          storage.Table
+	tableMetadataUpdates storage.Table
+	tableMetadata        storage.Table
 
-	tableMachineMetrics *gcp_bigtable.Table
+	tableMachineMetrics storage.Table
 
 	redisCache *redis.Client
 
@@ -80,27 +81,26 @@ type Bigtable struct {
 }
 
 func InitBigtable(project, instance, chainId, redisAddress string) (*Bigtable, error) {
-	if utils.Config.Bigtable.Emulator {
-		if utils.Config.Bigtable.EmulatorHost == "" {
-			utils.Config.Bigtable.EmulatorHost = "127.0.0.1"
-		}
-		log.Infof("using emulated local bigtable environment, setting BIGTABLE_EMULATOR_HOST env variable to %s:%d", utils.Config.Bigtable.EmulatorHost, utils.Config.Bigtable.EmulatorPort)
-		err := os.Setenv("BIGTABLE_EMULATOR_HOST", fmt.Sprintf("%s:%d", utils.Config.Bigtable.EmulatorHost, utils.Config.Bigtable.EmulatorPort))
-
-		if err != nil {
-			log.Fatal(err, "unable to set bigtable emulator environment variable", 0)
-		}
-	}
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 	defer cancel()
 
-	poolSize := 50
-	btClient, err := gcp_bigtable.NewClient(ctx, project, instance, option.WithGRPCConnectionPool(poolSize))
-	// btClient, err := gcp_bigtable.NewClient(context.Background(), project, instance)
-
+	store, err := newStorageStore(ctx, project, instance)
 	if err != nil {
 		return nil, err
 	}
+	if utils.Config.Bigtable.Embedded {
+		// there is no separate setup step for the embedded store, create the schema on first use
+		tables, err := store.Tables(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(tables) == 0 {
+			err = createBigtableSchema(ctx, store)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
 
 	rdc := redis.NewClient(&redis.Options{
 		Addr:        redisAddress,
@@ -111,22 +111,7 @@ func InitBigtable(project, instance, chainId, redisAddress string) (*Bigtable, e
 		return nil, err
 	}
 
-	bt := &Bigtable{
-		client:                         btClient,
-		tableData:                      btClient.Open("data"),
-		tableBlocks:                    btClient.Open("blocks"),
-		tableMetadataUpdates:           btClient.Open("metadata_updates"),
-		tableMetadata:                  btClient.Open("metadata"),
-		tableBeaconchain:               btClient.Open("beaconchain"),
-		tableMachineMetrics:            btClient.Open("machine_metrics"),
-		tableValidators:                btClient.Open("beaconchain_validators"),
-		tableValidatorsHistory:         btClient.Open("beaconchain_validators_history"),
-		chainId:                        chainId,
-		redisCache:                     rdc,
-		LastAttestationCacheMux:        &sync.Mutex{},
-		v2SchemaCutOffEpoch:            utils.Config.Bigtable.V2SchemaCutOffEpoch,
-		machineMetricsQueuedWritesChan: make(chan types.BulkMutation, MAX_BATCH_MUTATIONS),
-	}
+	bt := newBigtable(store, chainId, rdc)
 
 	if utils.Config.Frontend.Enabled { // Only activate machine metrics inserts on frontend / api instances
 		go bt.commitQueuedMachineMetricWrites()
@@ -136,6 +121,52 @@ func InitBigtable(project, instance, chainId, redisAddress string) (*Bigtable, e
 	return bt, nil
 }
 
+// newStorageStore opens the configured storage backend
+func newStorageStore(ctx context.Context, project, instance string) (storage.Store, error) {
+	if utils.Config.Bigtable.Embedded {
+		path := utils.Config.Bigtable.EmbeddedPath
+		if path == "" {
+			path = filepath.Join(os.TempDir(), "beaconchain-storage")
+		}
+		log.Infof("using embedded storage at %s instead of bigtable", path)
+		return storage.NewPebbleStore(path)
+	}
+
+	if utils.Config.Bigtable.Emulator {
+		if utils.Config.Bigtable.EmulatorHost == "" {
+			utils.Config.Bigtable.EmulatorHost = "127.0.0.1"
+		}
+		log.Infof("using emulated local bigtable environment, setting BIGTABLE_EMULATOR_HOST env variable to %s:%d", utils.Config.Bigtable.EmulatorHost, utils.Config.Bigtable.EmulatorPort)
+		err := os.Setenv("BIGTABLE_EMULATOR_HOST", fmt.Sprintf("%s:%d", utils.Config.Bigtable.EmulatorHost, utils.Config.Bigtable.EmulatorPort))
+
+		if err != nil {
+			log.Fatal(err, "unable to set bigtable emulator environment variable", 0)
+		}
+	}
+
+	poolSize := 50
+	return storage.NewBigtableStore(ctx, project, instance, option.WithGRPCConnectionPool(poolSize))
+}
+
+func newBigtable(store storage.Store, chainId string, redisCache *redis.Client) *Bigtable {
+	return &Bigtable{
+		store:                          store,
+		tableData:                      store.Table("data"),
+		tableBlocks:                    store.Table("blocks"),
+		tableMetadataUpdates:           store.Table("metadata_updates"),
+		tableMetadata:                  store.Table("metadata"),
+		tableBeaconchain:               store.Table("beaconchain"),
+		tableMachineMetrics:            store.Table("machine_metrics"),
+		tableValidators:                store.Table("beaconchain_validators"),
+		tableValidatorsHistory:         store.Table("beaconchain_validators_history"),
+		chainId:                        chainId,
+		redisCache:                     redisCache,
+		LastAttestationCacheMux:        &sync.Mutex{},
+		v2SchemaCutOffEpoch:            utils.Config.Bigtable.V2SchemaCutOffEpoch,
+		machineMetricsQueuedWritesChan: make(chan types.BulkMutation, MAX_BATCH_MUTATIONS),
+	}
+}
+
 func (bigtable *Bigtable) commitQueuedMachineMetricWrites() {
 	// copy the pending mutations over and commit them
 	batchSize := 10000
@@ -187,11 +218,9 @@ func (bigtable *Bigtable) commitQueuedMachineMetricWrites() {
 func (bigtable *Bigtable) Close() {
 	close(bigtable.machineMetricsQueuedWritesChan)
 	time.Sleep(time.Second * 5)
-	bigtable.client.Close()
-}
-
-func (bigtable *Bigtable) GetClient() *gcp_bigtable.Client {
-	return bigtable.client
+	if err := bigtable.store.Close(); err != nil {
+		log.Error(err, "error closing storage", 0)
+	}
 }
 
 func (bigtable *Bigtable) SaveMachineMetric(process string, userID types.UserId, machine string, data []byte) error {
@@ -200,7 +229,7 @@ func (bigtable *Bigtable) SaveMachineMetric(process string, userID types.UserId,
 
 	rowKeyData := fmt.Sprintf("u:%s:p:%s:m:%v", bigtable.reversePaddedUserID(userID), process, machine)
 
-	ts := gcp_bigtable.Now()
+	ts := storage.Now()
 	rateLimitKey := fmt.Sprintf("%s:%d", rowKeyData, ts.Time().Minute())
 	keySet, err := bigtable.redisCache.SetNX(ctx, rateLimitKey, "1", time.Minute).Result()
 	if err != nil {
@@ -221,7 +250,7 @@ func (bigtable *Bigtable) SaveMachineMetric(process string, userID types.UserId,
 		return err
 	}
 
-	dataMut := gcp_bigtable.NewMutation()
+	dataMut := storage.NewMutation()
 	dataMut.Set(MACHINE_METRICS_COLUMN_FAMILY, "v1", ts, data)
 
 	bulkMut := types.BulkMutation{ // schedule the mutation for writing
@@ -240,16 +269,16 @@ func (bigtable Bigtable) getMachineMetricNamesMap(userID types.UserId, searchDep
 
 	rangePrefix := fmt.Sprintf("u:%s:p:", bigtable.reversePaddedUserID(userID))
 
-	filter := gcp_bigtable.ChainFilters(
-		gcp_bigtable.FamilyFilter(MACHINE_METRICS_COLUMN_FAMILY),
-		gcp_bigtable.LatestNFilter(searchDepth),
-		gcp_bigtable.TimestampRangeFilter(time.Now().Add(time.Duration(searchDepth*-1)*time.Minute), time.Now()),
-		gcp_bigtable.StripValueFilter(),
+	filter := storage.ChainFilters(
+		storage.FamilyFilter(MACHINE_METRICS_COLUMN_FAMILY),
+		storage.LatestNFilter(searchDepth),
+		storage.TimestampRangeFilter(time.Now().Add(time.Duration(searchDepth*-1)*time.Minute), time.Now()),
+		storage.StripValueFilter(),
 	)
 
 	machineNames := make(map[string]bool)
 
-	err := bigtable.tableMachineMetrics.ReadRows(ctx, gcp_bigtable.PrefixRange(rangePrefix), func(r gcp_bigtable.Row) bool {
+	err := bigtable.tableMachineMetrics.ReadRows(ctx, storage.PrefixRange(rangePrefix), func(r storage.Row) bool {
 		success, _, machine, _ := machineMetricRowParts(r.Key())
 		if !success {
 			return false
@@ -257,7 +286,7 @@ func (bigtable Bigtable) getMachineMetricNamesMap(userID types.UserId, searchDep
 		machineNames[machine] = true
 
 		return true
-	}, gcp_bigtable.RowFilter(filter))
+	}, storage.RowFilter(filter))
 	if err != nil {
 		return machineNames, err
 	}
@@ -335,6 +364,31 @@ func (bigtable Bigtable) GetMachineMetricsNode(userID types.UserId, limit, offse
 	)
 }
 
+func (bigtable Bigtable) GetMachineMetricsExecutionNode(userID types.UserId, limit, offset int) ([]*types.MachineMetricNode, error) {
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		log.WarnWithFields(log.Fields{
+			"userId":   userID,
+			"limit":    limit,
+			"offset":   offset,
+			"func":     utils.GetCurrentFuncName(),
+			"duration": REPORT_TIMEOUT,
+		}, "call took longer than expected")
+	})
+	defer tmr.Stop()
+
+	return getMachineMetrics(bigtable, "executionnode", userID, limit, offset,
+		func(data []byte, machine string) *types.MachineMetricNode {
+			obj := &types.MachineMetricNode{}
+			err := proto.Unmarshal(data, obj)
+			if err != nil {
+				return nil
+			}
+			obj.Machine = &machine
+			return obj
+		},
+	)
+}
+
 func (bigtable Bigtable) GetMachineMetricsValidator(userID types.UserId, limit, offset int) ([]*types.MachineMetricValidator, error) {
 	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
 		log.WarnWithFields(log.Fields{
@@ -395,13 +449,13 @@ func getMachineMetrics[T types.MachineMetricSystem | types.MachineMetricNode | t
 		offset = 1
 	}
 
-	filter := gcp_bigtable.ChainFilters(
-		gcp_bigtable.FamilyFilter(MACHINE_METRICS_COLUMN_FAMILY),
-		gcp_bigtable.LatestNFilter(limit),
-		gcp_bigtable.CellsPerRowOffsetFilter(offset),
+	filter := storage.ChainFilters(
+		storage.FamilyFilter(MACHINE_METRICS_COLUMN_FAMILY),
+		storage.LatestNFilter(limit),
+		storage.CellsPerRowOffsetFilter(offset),
 	)
 	gapSize := utils.GetMachineStatsGap(uint64(limit))
-	err := bigtable.tableMachineMetrics.ReadRows(ctx, gcp_bigtable.PrefixRange(rangePrefix), func(r gcp_bigtable.Row) bool {
+	err := bigtable.tableMachineMetrics.ReadRows(ctx, storage.PrefixRange(rangePrefix), func(r storage.Row) bool {
 		success, _, machine, _ := machineMetricRowParts(r.Key())
 		if !success {
 			return false
@@ -421,7 +475,7 @@ func getMachineMetrics[T types.MachineMetricSystem | types.MachineMetricNode | t
 			res = append(res, obj)
 		}
 		return true
-	}, gcp_bigtable.RowFilter(filter))
+	}, storage.RowFilter(filter))
 	if err != nil {
 		return nil, err
 	}
@@ -429,6 +483,74 @@ func getMachineMetrics[T types.MachineMetricSystem | types.MachineMetricNode | t
 	return res, nil
 }
 
+// SaveMachineMetricSeries stores the raw series a machine pushed, series are keyed by their name and labels.
+// Points are truncated to the minute, so a series keeps at most one point per minute.
+func (bigtable *Bigtable) SaveMachineMetricSeries(userID types.UserId, machine string, series map[string][]types.MachineMetricSeriesPoint) error {
+	muts := types.NewBulkMutations(len(series))
+	for id, points := range series {
+		mut := storage.NewMutation()
+		for _, p := range points {
+			value := make([]byte, 8)
+			binary.LittleEndian.PutUint64(value, math.Float64bits(p.Value))
+			mut.Set(MACHINE_METRICS_COLUMN_FAMILY, "v", storage.Time(time.Unix(p.Timestamp, 0).Truncate(time.Minute)), value)
+		}
+		muts.Add(bigtable.getMachineSeriesRowKey(userID, machine, id), mut)
+	}
+	return bigtable.WriteBulk(muts, bigtable.tableMachineMetrics, MAX_BATCH_MUTATIONS)
+}
+
+// GetMachineMetricSeries returns the points since the given time of all series of the metric a machine pushed, oldest first
+func (bigtable *Bigtable) GetMachineMetricSeries(userID types.UserId, machine, metric string, since time.Time) (map[string][]types.MachineMetricSeriesPoint, error) {
+	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
+		log.WarnWithFields(log.Fields{
+			"userId":   userID,
+			"machine":  machine,
+			"metric":   metric,
+			"func":     utils.GetCurrentFuncName(),
+			"duration": REPORT_TIMEOUT,
+		}, "call took longer than expected")
+	})
+	defer tmr.Stop()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
+	defer cancel()
+
+	prefix := bigtable.getMachineSeriesRowKey(userID, machine, "")
+	filter := storage.ChainFilters(
+		storage.FamilyFilter(MACHINE_METRICS_COLUMN_FAMILY),
+		storage.TimestampRangeFilter(since, time.Time{}),
+	)
+
+	res := make(map[string][]types.MachineMetricSeriesPoint)
+	err := bigtable.tableMachineMetrics.ReadRows(ctx, storage.PrefixRange(prefix+metric), func(r storage.Row) bool {
+		series := strings.TrimPrefix(r.Key(), prefix)
+		// the prefix also matches metrics that start with the requested name
+		if series != metric && !strings.HasPrefix(series, metric+"{") {
+			return true
+		}
+		items := r[MACHINE_METRICS_COLUMN_FAMILY]
+		points := make([]types.MachineMetricSeriesPoint, 0, len(items))
+		// cells are sorted by descending timestamp
+		for i := len(items) - 1; i >= 0; i-- {
+			points = append(points, types.MachineMetricSeriesPoint{
+				Timestamp: items[i].Timestamp.Time().Unix(),
+				Value:     math.Float64frombits(binary.LittleEndian.Uint64(items[i].Value)),
+			})
+		}
+		res[series] = points
+		return true
+	}, storage.RowFilter(filter))
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// the raw series use their own row prefix, so that they are not read as machine metrics
+func (bigtable *Bigtable) getMachineSeriesRowKey(userID types.UserId, machine, series string) string {
+	return fmt.Sprintf("r:%s:m:%s:s:%s", bigtable.reversePaddedUserID(userID), machine, series)
+}
+
 func (bigtable Bigtable) GetMachineRowKey(userID types.UserId, process string, machine string) string {
 	return fmt.Sprintf("u:%s:p:%s:m:%s", bigtable.reversePaddedUserID(userID), process, machine)
 }
@@ -437,7 +559,7 @@ func (bigtable Bigtable) GetMachineRowKey(userID types.UserId, process string, m
 // machineData contains the latest machine data in CurrentData
 // and 5 minute old data in fiveMinuteOldData (defined in limit)
 // as well as the insert timestamps of both
-func (bigtable Bigtable) GetMachineMetricsForNotifications(rowKeys gcp_bigtable.RowList) (map[types.UserId]map[string]*types.MachineMetricSystemUser, error) {
+func (bigtable Bigtable) GetMachineMetricsForNotifications(rowKeys storage.RowList) (map[types.UserId]map[string]*types.MachineMetricSystemUser, error) {
 	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
 		log.WarnWithFields(log.Fields{
 			"rowKeys":  rowKeys,
@@ -453,12 +575,12 @@ func (bigtable Bigtable) GetMachineMetricsForNotifications(rowKeys gcp_bigtable.
 
 	limit := 5
 
-	filter := gcp_bigtable.ChainFilters(
-		gcp_bigtable.FamilyFilter(MACHINE_METRICS_COLUMN_FAMILY),
-		gcp_bigtable.LatestNFilter(limit),
+	filter := storage.ChainFilters(
+		storage.FamilyFilter(MACHINE_METRICS_COLUMN_FAMILY),
+		storage.LatestNFilter(limit),
 	)
 
-	err := bigtable.tableMachineMetrics.ReadRows(ctx, rowKeys, func(r gcp_bigtable.Row) bool {
+	err := bigtable.tableMachineMetrics.ReadRows(ctx, rowKeys, func(r storage.Row) bool {
 		success, userID, machine, _ := machineMetricRowParts(r.Key())
 		if !success {
 			return false
@@ -500,7 +622,7 @@ func (bigtable Bigtable) GetMachineMetricsForNotifications(rowKeys gcp_bigtable.
 			count++
 		}
 		return true
-	}, gcp_bigtable.RowFilter(filter))
+	}, storage.RowFilter(filter))
 	if err != nil {
 		return nil, err
 	}
@@ -534,7 +656,7 @@ func (bigtable *Bigtable) SaveValidatorBalances(epoch uint64, validators []*type
 	defer cancel()
 
 	// start := time.Now()
-	ts := gcp_bigtable.Timestamp(0)
+	ts := storage.Timestamp(0)
 
 	muts := types.NewBulkMutations(len(validators))
 
@@ -551,7 +673,7 @@ func (bigtable *Bigtable) SaveValidatorBalances(epoch uint64, validators []*type
 		effectiveBalanceEncoded := uint8(validator.EffectiveBalance / 1e9) // we can encode the effective balance in 1 byte as it is capped at 32ETH and only decrements in 1 ETH steps
 
 		combined := append(balanceEncoded, effectiveBalanceEncoded)
-		mut := &gcp_bigtable.Mutation{}
+		mut := &storage.Mutation{}
 		mut.Set(VALIDATOR_BALANCES_FAMILY, "b", ts, combined)
 		key := fmt.Sprintf("%s:%s:%s:%s", bigtable.chainId, bigtable.validatorIndexToKey(validator.Index), VALIDATOR_BALANCES_FAMILY, epochKey)
 
@@ -567,7 +689,7 @@ func (bigtable *Bigtable) SaveValidatorBalances(epoch uint64, validators []*type
 	highestActiveIndexEncoded := make([]byte, 8)
 	binary.LittleEndian.PutUint64(highestActiveIndexEncoded, highestActiveIndex)
 
-	mut := &gcp_bigtable.Mutation{}
+	mut := &storage.Mutation{}
 	mut.Set(VALIDATOR_HIGHEST_ACTIVE_INDEX_FAMILY, VALIDATOR_HIGHEST_ACTIVE_INDEX_FAMILY, ts, highestActiveIndexEncoded)
 	key := fmt.Sprintf("%s:%s:%s", bigtable.chainId, VALIDATOR_HIGHEST_ACTIVE_INDEX_FAMILY, epochKey)
 	err = bigtable.tableValidatorsHistory.Apply(ctx, key, mut)
@@ -579,12 +701,12 @@ func (bigtable *Bigtable) SaveValidatorBalances(epoch uint64, validators []*type
 
 func (bigtable *Bigtable) SaveProposalAssignments(epoch uint64, assignments map[uint64]uint64) error {
 	start := time.Now()
-	ts := gcp_bigtable.Timestamp(0)
+	ts := storage.Timestamp(0)
 
 	muts := types.NewBulkMutations(len(assignments))
 
 	for slot, validator := range assignments {
-		mut := gcp_bigtable.NewMutation()
+		mut := storage.NewMutation()
 		mut.Set(PROPOSALS_FAMILY, "p", ts, []byte{})
 
 		key := fmt.Sprintf("%s:%s:%s:%s:%s", bigtable.chainId, bigtable.validatorIndexToKey(validator), PROPOSALS_FAMILY, bigtable.reversedPaddedEpoch(epoch), bigtable.reversedPaddedSlot(slot))
@@ -625,7 +747,7 @@ func (bigtable *Bigtable) SaveAttestationDuties(duties map[types.Slot]map[types.
 
 	mutsInclusionSlot := types.NewBulkMutations(MAX_BATCH_MUTATIONS)
 
-	mutLastAttestationSlot := gcp_bigtable.NewMutation()
+	mutLastAttestationSlot := storage.NewMutation()
 	mutLastAttestationSlotCount := 0
 
 	for attestedSlot, validators := range duties {
@@ -638,13 +760,13 @@ func (bigtable *Bigtable) SaveAttestationDuties(duties map[types.Slot]map[types.
 			for _, inclusionSlot := range inclusions {
 				key := fmt.Sprintf("%s:%s:%s:%s", bigtable.chainId, bigtable.validatorIndexToKey(uint64(validator)), ATTESTATIONS_FAMILY, bigtable.reversedPaddedEpoch(epoch))
 
-				mutInclusionSlot := gcp_bigtable.NewMutation()
-				mutInclusionSlot.Set(ATTESTATIONS_FAMILY, fmt.Sprintf("%d", attestedSlot), gcp_bigtable.Timestamp((MAX_CL_BLOCK_NUMBER-inclusionSlot)*1000), []byte{})
+				mutInclusionSlot := storage.NewMutation()
+				mutInclusionSlot.Set(ATTESTATIONS_FAMILY, fmt.Sprintf("%d", attestedSlot), storage.Timestamp((MAX_CL_BLOCK_NUMBER-inclusionSlot)*1000), []byte{})
 
 				mutsInclusionSlot.Add(key, mutInclusionSlot)
 
 				if inclusionSlot != MAX_CL_BLOCK_NUMBER && uint64(attestedSlot) > bigtable.LastAttestationCache[uint64(validator)] {
-					mutLastAttestationSlot.Set(ATTESTATIONS_FAMILY, fmt.Sprintf("%d", validator), gcp_bigtable.Timestamp((attestedSlot)*1000), []byte{})
+					mutLastAttestationSlot.Set(ATTESTATIONS_FAMILY, fmt.Sprintf("%d", validator), storage.Timestamp((attestedSlot)*1000), []byte{})
 					bigtable.LastAttestationCache[uint64(validator)] = uint64(attestedSlot)
 					mutLastAttestationSlotCount++
 
@@ -655,7 +777,7 @@ func (bigtable *Bigtable) SaveAttestationDuties(duties map[types.Slot]map[types.
 							bigtable.LastAttestationCacheMux.Unlock()
 							return fmt.Errorf("error applying last attestation slot mutations: %v", err)
 						}
-						mutLastAttestationSlot = gcp_bigtable.NewMutation()
+						mutLastAttestationSlot = storage.NewMutation()
 						mutLastAttestationSlotCount = 0
 						log.Infof("applyied last attestation slot mutations in %v", time.Since(mutStart))
 					}
@@ -687,8 +809,8 @@ func (bigtable *Bigtable) SetLastAttestationSlot(validator uint64, lastAttestati
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 	defer cancel()
 
-	mutLastAttestationSlot := gcp_bigtable.NewMutation()
-	mutLastAttestationSlot.Set(ATTESTATIONS_FAMILY, fmt.Sprintf("%d", validator), gcp_bigtable.Timestamp(lastAttestationSlot*1000), []byte{})
+	mutLastAttestationSlot := storage.NewMutation()
+	mutLastAttestationSlot.Set(ATTESTATIONS_FAMILY, fmt.Sprintf("%d", validator), storage.Timestamp(lastAttestationSlot*1000), []byte{})
 	err := bigtable.tableValidators.Apply(ctx, fmt.Sprintf("%s:lastAttestationSlot", bigtable.chainId), mutLastAttestationSlot)
 	if err != nil {
 		return err
@@ -706,8 +828,8 @@ func (bigtable *Bigtable) SaveProposal(block *types.Block) error {
 	if len(block.BlockRoot) != 32 { // skip dummy blocks
 		return nil
 	}
-	mut := gcp_bigtable.NewMutation()
-	mut.Set(PROPOSALS_FAMILY, "b", gcp_bigtable.Timestamp((MAX_CL_BLOCK_NUMBER-block.Slot)*1000), []byte{})
+	mut := storage.NewMutation()
+	mut.Set(PROPOSALS_FAMILY, "b", storage.Timestamp((MAX_CL_BLOCK_NUMBER-block.Slot)*1000), []byte{})
 	key := fmt.Sprintf("%s:%s:%s:%s:%s", bigtable.chainId, bigtable.validatorIndexToKey(block.Proposer), PROPOSALS_FAMILY, bigtable.reversedPaddedEpoch(utils.EpochOfSlot(block.Slot)), bigtable.reversedPaddedSlot(block.Slot))
 
 	err := bigtable.tableValidatorsHistory.Apply(ctx, key, mut)
@@ -720,6 +842,18 @@ func (bigtable *Bigtable) SaveProposal(block *types.Block) error {
 	return nil
 }
 
+// DeleteProposal removes the proposal of an orphaned block, the proposal assignment of the slot is kept so the slot counts as missed
+func (bigtable *Bigtable) DeleteProposal(slot, proposer uint64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	mut := storage.NewMutation()
+	mut.DeleteCellsInColumn(PROPOSALS_FAMILY, "b")
+	key := fmt.Sprintf("%s:%s:%s:%s:%s", bigtable.chainId, bigtable.validatorIndexToKey(proposer), PROPOSALS_FAMILY, bigtable.reversedPaddedEpoch(utils.EpochOfSlot(slot)), bigtable.reversedPaddedSlot(slot))
+
+	return bigtable.tableValidatorsHistory.Apply(ctx, key, mut)
+}
+
 func (bigtable *Bigtable) SaveSyncComitteeDuties(duties map[types.Slot]map[types.ValidatorIndex]bool) error {
 	start := time.Now()
 
@@ -732,11 +866,11 @@ func (bigtable *Bigtable) SaveSyncComitteeDuties(duties map[types.Slot]map[types
 
 	for slot, validators := range duties {
 		for validator, participated := range validators {
-			mut := gcp_bigtable.NewMutation()
+			mut := storage.NewMutation()
 			if participated {
-				mut.Set(SYNC_COMMITTEES_FAMILY, "s", gcp_bigtable.Timestamp((MAX_CL_BLOCK_NUMBER-slot)*1000), []byte{})
+				mut.Set(SYNC_COMMITTEES_FAMILY, "s", storage.Timestamp((MAX_CL_BLOCK_NUMBER-slot)*1000), []byte{})
 			} else {
-				mut.Set(SYNC_COMMITTEES_FAMILY, "s", gcp_bigtable.Timestamp(0), []byte{})
+				mut.Set(SYNC_COMMITTEES_FAMILY, "s", storage.Timestamp(0), []byte{})
 			}
 			key := fmt.Sprintf("%s:%s:%s:%s:%s", bigtable.chainId, bigtable.validatorIndexToKey(uint64(validator)), SYNC_COMMITTEES_FAMILY, bigtable.reversedPaddedEpoch(utils.EpochOfSlot(uint64(slot))), bigtable.reversedPaddedSlot(uint64(slot)))
 
@@ -835,9 +969,9 @@ func (bigtable *Bigtable) getValidatorBalanceHistoryV2(validators []uint64, star
 			default:
 			}
 			ranges := bigtable.getValidatorsEpochRanges(vals, VALIDATOR_BALANCES_FAMILY, startEpoch, endEpoch)
-			ro := gcp_bigtable.LimitRows(int64(endEpoch-startEpoch+1) * int64(len(vals)))
+			ro := storage.LimitRows(int64(endEpoch-startEpoch+1) * int64(len(vals)))
 
-			handleRow := func(r gcp_bigtable.Row) bool {
+			handleRow := func(r storage.Row) bool {
 				// logger.Info(r.Key())
 				keySplit := strings.Split(r.Key(), ":")
 
@@ -915,30 +1049,30 @@ func (bigtable *Bigtable) getValidatorBalanceHistoryV1(validators []uint64, star
 	ranges := bigtable.getEpochRangesV1(startEpoch, endEpoch)
 	res := make(map[uint64][]*types.ValidatorBalance, valLen)
 
-	columnFilters := []gcp_bigtable.Filter{}
+	columnFilters := []storage.Filter{}
 	if valLen < getAllThreshold {
-		columnFilters = make([]gcp_bigtable.Filter, 0, valLen)
+		columnFilters = make([]storage.Filter, 0, valLen)
 		for _, validator := range validators {
-			columnFilters = append(columnFilters, gcp_bigtable.ColumnFilter(fmt.Sprintf("%d", validator)))
+			columnFilters = append(columnFilters, storage.ColumnFilter(fmt.Sprintf("%d", validator)))
 		}
 	}
 
-	filter := gcp_bigtable.ChainFilters(
-		gcp_bigtable.FamilyFilter(VALIDATOR_BALANCES_FAMILY),
-		gcp_bigtable.InterleaveFilters(columnFilters...),
+	filter := storage.ChainFilters(
+		storage.FamilyFilter(VALIDATOR_BALANCES_FAMILY),
+		storage.InterleaveFilters(columnFilters...),
 	)
 
 	if len(columnFilters) == 1 { // special case to retrieve data for one validators
-		filter = gcp_bigtable.ChainFilters(
-			gcp_bigtable.FamilyFilter(VALIDATOR_BALANCES_FAMILY),
+		filter = storage.ChainFilters(
+			storage.FamilyFilter(VALIDATOR_BALANCES_FAMILY),
 			columnFilters[0],
 		)
 	}
 	if len(columnFilters) == 0 { // special case to retrieve data for all validators
-		filter = gcp_bigtable.FamilyFilter(VALIDATOR_BALANCES_FAMILY)
+		filter = storage.FamilyFilter(VALIDATOR_BALANCES_FAMILY)
 	}
 
-	handleRow := func(r gcp_bigtable.Row) bool {
+	handleRow := func(r storage.Row) bool {
 		keySplit := strings.Split(r.Key(), ":")
 
 		epoch, err := strconv.ParseUint(keySplit[3], 10, 64)
@@ -983,7 +1117,7 @@ func (bigtable *Bigtable) getValidatorBalanceHistoryV1(validators []uint64, star
 		return true
 	}
 
-	err := bigtable.tableBeaconchain.ReadRows(ctx, ranges, handleRow, gcp_bigtable.RowFilter(filter))
+	err := bigtable.tableBeaconchain.ReadRows(ctx, ranges, handleRow, storage.RowFilter(filter))
 	if err != nil {
 		return nil, err
 	}
@@ -1043,8 +1177,8 @@ func (bigtable *Bigtable) getValidatorAttestationHistoryV2(validators []uint64,
 			default:
 			}
 			ranges := bigtable.getValidatorsEpochRanges(vals, ATTESTATIONS_FAMILY, startEpoch, endEpoch)
-			filter := gcp_bigtable.LimitRows(int64(endEpoch-startEpoch+1) * int64(len(vals))) // max is one row per epoch
-			err := bigtable.tableValidatorsHistory.ReadRows(ctx, ranges, func(r gcp_bigtable.Row) bool {
+			filter := storage.LimitRows(int64(endEpoch-startEpoch+1) * int64(len(vals))) // max is one row per epoch
+			err := bigtable.tableValidatorsHistory.ReadRows(ctx, ranges, func(r storage.Row) bool {
 				keySplit := strings.Split(r.Key(), ":")
 
 				validator, err := bigtable.validatorKeyToIndex(keySplit[1])
@@ -1177,27 +1311,27 @@ func (bigtable *Bigtable) getValidatorAttestationHistoryV1(validators []uint64,
 	ranges := bigtable.getSlotRangesForEpochV1(startEpoch, endEpoch)
 	res := make(map[uint64][]*types.ValidatorAttestation, len(validators))
 
-	columnFilters := []gcp_bigtable.Filter{}
+	columnFilters := []storage.Filter{}
 	if valLen < 1000 {
-		columnFilters = make([]gcp_bigtable.Filter, 0, len(validators))
+		columnFilters = make([]storage.Filter, 0, len(validators))
 		for _, validator := range validators {
-			columnFilters = append(columnFilters, gcp_bigtable.ColumnFilter(fmt.Sprintf("%d", validator)))
+			columnFilters = append(columnFilters, storage.ColumnFilter(fmt.Sprintf("%d", validator)))
 		}
 	}
 
-	filter := gcp_bigtable.ChainFilters(
-		gcp_bigtable.FamilyFilter(ATTESTATIONS_FAMILY),
-		gcp_bigtable.InterleaveFilters(columnFilters...),
+	filter := storage.ChainFilters(
+		storage.FamilyFilter(ATTESTATIONS_FAMILY),
+		storage.InterleaveFilters(columnFilters...),
 	)
 
 	if len(columnFilters) == 1 { // special case to retrieve data for one validators
-		filter = gcp_bigtable.ChainFilters(
-			gcp_bigtable.FamilyFilter(ATTESTATIONS_FAMILY),
+		filter = storage.ChainFilters(
+			storage.FamilyFilter(ATTESTATIONS_FAMILY),
 			columnFilters[0],
 		)
 	}
 	if len(columnFilters) == 0 { // special case to retrieve data for all validators
-		filter = gcp_bigtable.FamilyFilter(ATTESTATIONS_FAMILY)
+		filter = storage.FamilyFilter(ATTESTATIONS_FAMILY)
 	}
 
 	maxSlot := (endEpoch + 1) * utils.Config.Chain.ClConfig.SlotsPerEpoch
@@ -1206,7 +1340,7 @@ func (bigtable *Bigtable) getValidatorAttestationHistoryV1(validators []uint64,
 
 	// Save info for all inclusionSlot for attestations in attestationsMap
 	// Set the maxSlot to the highest inclusionSlot
-	err := bigtable.tableBeaconchain.ReadRows(ctx, ranges, func(r gcp_bigtable.Row) bool {
+	err := bigtable.tableBeaconchain.ReadRows(ctx, ranges, func(r storage.Row) bool {
 		keySplit := strings.Split(r.Key(), ":")
 
 		attesterSlot, err := strconv.ParseUint(keySplit[4], 10, 64)
@@ -1248,7 +1382,7 @@ func (bigtable *Bigtable) getValidatorAttestationHistoryV1(validators []uint64,
 			})
 		}
 		return true
-	}, gcp_bigtable.RowFilter(filter))
+	}, storage.RowFilter(filter))
 	if err != nil {
 		return nil, err
 	}
@@ -1341,36 +1475,36 @@ func (bigtable *Bigtable) GetLastAttestationSlots(validators []uint64) (map[uint
 
 	res := make(map[uint64]uint64, len(validators))
 
-	columnFilters := []gcp_bigtable.Filter{}
+	columnFilters := []storage.Filter{}
 	if valLen < 1000 {
-		columnFilters = make([]gcp_bigtable.Filter, 0, len(validators))
+		columnFilters = make([]storage.Filter, 0, len(validators))
 		for _, validator := range validators {
-			columnFilters = append(columnFilters, gcp_bigtable.ColumnFilter(fmt.Sprintf("%d", validator)))
+			columnFilters = append(columnFilters, storage.ColumnFilter(fmt.Sprintf("%d", validator)))
 		}
 	}
 
-	filter := gcp_bigtable.ChainFilters(
-		gcp_bigtable.FamilyFilter(ATTESTATIONS_FAMILY),
-		gcp_bigtable.InterleaveFilters(columnFilters...),
-		gcp_bigtable.LatestNFilter(1),
+	filter := storage.ChainFilters(
+		storage.FamilyFilter(ATTESTATIONS_FAMILY),
+		storage.InterleaveFilters(columnFilters...),
+		storage.LatestNFilter(1),
 	)
 
 	if len(columnFilters) == 1 { // special case to retrieve data for one validators
-		filter = gcp_bigtable.ChainFilters(
-			gcp_bigtable.FamilyFilter(ATTESTATIONS_FAMILY),
+		filter = storage.ChainFilters(
+			storage.FamilyFilter(ATTESTATIONS_FAMILY),
 			columnFilters[0],
-			gcp_bigtable.LatestNFilter(1),
+			storage.LatestNFilter(1),
 		)
 	} else if len(columnFilters) == 0 { // special case to retrieve data for all validators
-		filter = gcp_bigtable.ChainFilters(
-			gcp_bigtable.FamilyFilter(ATTESTATIONS_FAMILY),
-			gcp_bigtable.LatestNFilter(1),
+		filter = storage.ChainFilters(
+			storage.FamilyFilter(ATTESTATIONS_FAMILY),
+			storage.LatestNFilter(1),
 		)
 	}
 
 	key := fmt.Sprintf("%s:lastAttestationSlot", bigtable.chainId)
 
-	row, err := bigtable.tableValidators.ReadRow(ctx, key, gcp_bigtable.RowFilter(filter))
+	row, err := bigtable.tableValidators.ReadRow(ctx, key, storage.RowFilter(filter))
 	if err != nil {
 		return nil, err
 	}
@@ -1452,9 +1586,9 @@ func (bigtable *Bigtable) getValidatorMissedAttestationHistoryV2(validators []ui
 			}
 			ranges := bigtable.getValidatorsEpochRanges(vals, ATTESTATIONS_FAMILY, startEpoch, endEpoch)
 
-			filter := gcp_bigtable.LimitRows(int64(endEpoch-startEpoch+1) * int64(len(vals))) // max is one row per epoch
+			filter := storage.LimitRows(int64(endEpoch-startEpoch+1) * int64(len(vals))) // max is one row per epoch
 
-			err = bigtable.tableValidatorsHistory.ReadRows(ctx, ranges, func(r gcp_bigtable.Row) bool {
+			err = bigtable.tableValidatorsHistory.ReadRows(ctx, ranges, func(r storage.Row) bool {
 				keySplit := strings.Split(r.Key(), ":")
 
 				validator, err := bigtable.validatorKeyToIndex(keySplit[1])
@@ -1531,30 +1665,30 @@ func (bigtable *Bigtable) getValidatorMissedAttestationHistoryV1(validators []ui
 	res := make(map[uint64]map[uint64]bool)
 	foundValid := make(map[uint64]map[uint64]bool)
 
-	columnFilters := []gcp_bigtable.Filter{}
+	columnFilters := []storage.Filter{}
 	if valLen < 1000 {
-		columnFilters = make([]gcp_bigtable.Filter, 0, len(validators))
+		columnFilters = make([]storage.Filter, 0, len(validators))
 		for _, validator := range validators {
-			columnFilters = append(columnFilters, gcp_bigtable.ColumnFilter(fmt.Sprintf("%d", validator)))
+			columnFilters = append(columnFilters, storage.ColumnFilter(fmt.Sprintf("%d", validator)))
 		}
 	}
 
-	filter := gcp_bigtable.ChainFilters(
-		gcp_bigtable.FamilyFilter(ATTESTATIONS_FAMILY),
-		gcp_bigtable.InterleaveFilters(columnFilters...),
+	filter := storage.ChainFilters(
+		storage.FamilyFilter(ATTESTATIONS_FAMILY),
+		storage.InterleaveFilters(columnFilters...),
 	)
 
 	if len(columnFilters) == 1 { // special case to retrieve data for one validators
-		filter = gcp_bigtable.ChainFilters(
-			gcp_bigtable.FamilyFilter(ATTESTATIONS_FAMILY),
+		filter = storage.ChainFilters(
+			storage.FamilyFilter(ATTESTATIONS_FAMILY),
 			columnFilters[0],
 		)
 	}
 	if len(columnFilters) == 0 { // special case to retrieve data for all validators
-		filter = gcp_bigtable.FamilyFilter(ATTESTATIONS_FAMILY)
+		filter = storage.FamilyFilter(ATTESTATIONS_FAMILY)
 	}
 
-	err = bigtable.tableBeaconchain.ReadRows(ctx, ranges, func(r gcp_bigtable.Row) bool {
+	err = bigtable.tableBeaconchain.ReadRows(ctx, ranges, func(r storage.Row) bool {
 		keySplit := strings.Split(r.Key(), ":")
 
 		attesterSlot, err := strconv.ParseUint(keySplit[4], 10, 64)
@@ -1595,7 +1729,7 @@ func (bigtable *Bigtable) getValidatorMissedAttestationHistoryV1(validators []ui
 			}
 		}
 		return true
-	}, gcp_bigtable.RowFilter(filter))
+	}, storage.RowFilter(filter))
 	if err != nil {
 		return nil, err
 	}
@@ -1644,7 +1778,7 @@ func (bigtable *Bigtable) getValidatorSyncDutiesHistoryV2(validators []uint64, s
 	res := make(map[uint64]map[uint64]*types.ValidatorSyncParticipation, len(validators))
 	resMux := &sync.Mutex{}
 
-	filter := gcp_bigtable.LatestNFilter(1)
+	filter := storage.LatestNFilter(1)
 
 	g, gCtx := errgroup.WithContext(ctx)
 	g.SetLimit(concurrency)
@@ -1665,7 +1799,7 @@ func (bigtable *Bigtable) getValidatorSyncDutiesHistoryV2(validators []uint64, s
 			}
 			ranges := bigtable.getValidatorSlotRanges(vals, SYNC_COMMITTEES_FAMILY, startSlot, endSlot)
 
-			err := bigtable.tableValidatorsHistory.ReadRows(ctx, ranges, func(r gcp_bigtable.Row) bool {
+			err := bigtable.tableValidatorsHistory.ReadRows(ctx, ranges, func(r storage.Row) bool {
 				keySplit := strings.Split(r.Key(), ":")
 
 				validator, err := bigtable.validatorKeyToIndex(keySplit[1])
@@ -1704,7 +1838,7 @@ func (bigtable *Bigtable) getValidatorSyncDutiesHistoryV2(validators []uint64, s
 					resMux.Unlock()
 				}
 				return true
-			}, gcp_bigtable.RowFilter(filter))
+			}, storage.RowFilter(filter))
 			return err
 		})
 	}
@@ -1723,32 +1857,32 @@ func (bigtable *Bigtable) getValidatorSyncDutiesHistoryV1(validators []uint64, s
 	ranges := bigtable.getSlotRangesV1(startSlot, endSlot)
 	res := make(map[uint64]map[uint64]*types.ValidatorSyncParticipation, len(validators))
 
-	columnFilters := make([]gcp_bigtable.Filter, 0, len(validators))
+	columnFilters := make([]storage.Filter, 0, len(validators))
 	for _, validator := range validators {
-		columnFilters = append(columnFilters, gcp_bigtable.ColumnFilter(fmt.Sprintf("%d", validator)))
+		columnFilters = append(columnFilters, storage.ColumnFilter(fmt.Sprintf("%d", validator)))
 	}
 
-	filter := gcp_bigtable.ChainFilters(
-		gcp_bigtable.FamilyFilter(SYNC_COMMITTEES_FAMILY),
-		gcp_bigtable.InterleaveFilters(columnFilters...),
-		gcp_bigtable.LatestNFilter(1),
+	filter := storage.ChainFilters(
+		storage.FamilyFilter(SYNC_COMMITTEES_FAMILY),
+		storage.InterleaveFilters(columnFilters...),
+		storage.LatestNFilter(1),
 	)
 
 	if len(columnFilters) == 1 { // special case to retrieve data for one validators
-		filter = gcp_bigtable.ChainFilters(
-			gcp_bigtable.FamilyFilter(SYNC_COMMITTEES_FAMILY),
+		filter = storage.ChainFilters(
+			storage.FamilyFilter(SYNC_COMMITTEES_FAMILY),
 			columnFilters[0],
-			gcp_bigtable.LatestNFilter(1),
+			storage.LatestNFilter(1),
 		)
 	}
 	if len(columnFilters) == 0 { // special case to retrieve data for all validators
-		filter = gcp_bigtable.ChainFilters(
-			gcp_bigtable.FamilyFilter(SYNC_COMMITTEES_FAMILY),
-			gcp_bigtable.LatestNFilter(1),
+		filter = storage.ChainFilters(
+			storage.FamilyFilter(SYNC_COMMITTEES_FAMILY),
+			storage.LatestNFilter(1),
 		)
 	}
 
-	err := bigtable.tableBeaconchain.ReadRows(ctx, ranges, func(r gcp_bigtable.Row) bool {
+	err := bigtable.tableBeaconchain.ReadRows(ctx, ranges, func(r storage.Row) bool {
 		for _, ri := range r[SYNC_COMMITTEES_FAMILY] {
 			keySplit := strings.Split(r.Key(), ":")
 
@@ -1785,7 +1919,7 @@ func (bigtable *Bigtable) getValidatorSyncDutiesHistoryV1(validators []uint64, s
 			}
 		}
 		return true
-	}, gcp_bigtable.RowFilter(filter))
+	}, storage.RowFilter(filter))
 	if err != nil {
 		return nil, err
 	}
@@ -2046,7 +2180,7 @@ func (bigtable *Bigtable) getValidatorProposalHistoryV2(validators []uint64, sta
 	res := make(map[uint64][]*types.ValidatorProposal, len(validators))
 	resMux := &sync.Mutex{}
 
-	filter := gcp_bigtable.LatestNFilter(1)
+	filter := storage.LatestNFilter(1)
 
 	g, gCtx := errgroup.WithContext(ctx)
 	g.SetLimit(concurrency)
@@ -2065,7 +2199,7 @@ func (bigtable *Bigtable) getValidatorProposalHistoryV2(validators []uint64, sta
 			default:
 			}
 			ranges := bigtable.getValidatorsEpochSlotRanges(vals, PROPOSALS_FAMILY, startEpoch, endEpoch)
-			err := bigtable.tableValidatorsHistory.ReadRows(ctx, ranges, func(r gcp_bigtable.Row) bool {
+			err := bigtable.tableValidatorsHistory.ReadRows(ctx, ranges, func(r storage.Row) bool {
 				for _, ri := range r[PROPOSALS_FAMILY] {
 					keySplit := strings.Split(r.Key(), ":")
 
@@ -2106,7 +2240,7 @@ func (bigtable *Bigtable) getValidatorProposalHistoryV2(validators []uint64, sta
 					resMux.Unlock()
 				}
 				return true
-			}, gcp_bigtable.RowFilter(filter))
+			}, storage.RowFilter(filter))
 
 			return err
 		})
@@ -2126,32 +2260,32 @@ func (bigtable *Bigtable) getValidatorProposalHistoryV1(validators []uint64, sta
 	ranges := bigtable.getSlotRangesForEpochV1(startEpoch, endEpoch)
 	res := make(map[uint64][]*types.ValidatorProposal, len(validators))
 
-	columnFilters := make([]gcp_bigtable.Filter, 0, len(validators))
+	columnFilters := make([]storage.Filter, 0, len(validators))
 	for _, validator := range validators {
-		columnFilters = append(columnFilters, gcp_bigtable.ColumnFilter(fmt.Sprintf("%d", validator)))
+		columnFilters = append(columnFilters, storage.ColumnFilter(fmt.Sprintf("%d", validator)))
 	}
 
-	filter := gcp_bigtable.ChainFilters(
-		gcp_bigtable.FamilyFilter(PROPOSALS_FAMILY),
-		gcp_bigtable.InterleaveFilters(columnFilters...),
-		gcp_bigtable.LatestNFilter(1),
+	filter := storage.ChainFilters(
+		storage.FamilyFilter(PROPOSALS_FAMILY),
+		storage.InterleaveFilters(columnFilters...),
+		storage.LatestNFilter(1),
 	)
 
 	if len(columnFilters) == 1 { // special case to retrieve data for one validators
-		filter = gcp_bigtable.ChainFilters(
-			gcp_bigtable.FamilyFilter(PROPOSALS_FAMILY),
+		filter = storage.ChainFilters(
+			storage.FamilyFilter(PROPOSALS_FAMILY),
 			columnFilters[0],
-			gcp_bigtable.LatestNFilter(1),
+			storage.LatestNFilter(1),
 		)
 	}
 	if len(columnFilters) == 0 { // special case to retrieve data for all validators
-		filter = gcp_bigtable.ChainFilters(
-			gcp_bigtable.FamilyFilter(PROPOSALS_FAMILY),
-			gcp_bigtable.LatestNFilter(1),
+		filter = storage.ChainFilters(
+			storage.FamilyFilter(PROPOSALS_FAMILY),
+			storage.LatestNFilter(1),
 		)
 	}
 
-	err := bigtable.tableBeaconchain.ReadRows(ctx, ranges, func(r gcp_bigtable.Row) bool {
+	err := bigtable.tableBeaconchain.ReadRows(ctx, ranges, func(r storage.Row) bool {
 		for _, ri := range r[PROPOSALS_FAMILY] {
 			keySplit := strings.Split(r.Key(), ":")
 
@@ -2190,7 +2324,7 @@ func (bigtable *Bigtable) getValidatorProposalHistoryV1(validators []uint64, sta
 			}
 		}
 		return true
-	}, gcp_bigtable.RowFilter(filter))
+	}, storage.RowFilter(filter))
 	if err != nil {
 		return nil, err
 	}
@@ -2200,7 +2334,7 @@ func (bigtable *Bigtable) getValidatorProposalHistoryV1(validators []uint64, sta
 
 func (bigtable *Bigtable) SaveValidatorIncomeDetails(epoch uint64, rewards map[uint64]*itypes.ValidatorEpochIncome) error {
 	start := time.Now()
-	ts := gcp_bigtable.Timestamp(utils.EpochToTime(epoch).UnixMicro())
+	ts := storage.Timestamp(utils.EpochToTime(epoch).UnixMicro())
 
 	total := &itypes.ValidatorEpochIncome{}
 
@@ -2213,7 +2347,7 @@ func (bigtable *Bigtable) SaveValidatorIncomeDetails(epoch uint64, rewards map[u
 			return err
 		}
 
-		mut := &gcp_bigtable.Mutation{}
+		mut := &storage.Mutation{}
 		mut.Set(INCOME_DETAILS_COLUMN_FAMILY, "i", ts, data)
 		key := fmt.Sprintf("%s:%s:%s:%s", bigtable.chainId, bigtable.validatorIndexToKey(i), INCOME_DETAILS_COLUMN_FAMILY, bigtable.reversedPaddedEpoch(epoch))
 
@@ -2240,7 +2374,7 @@ func (bigtable *Bigtable) SaveValidatorIncomeDetails(epoch uint64, rewards map[u
 		return err
 	}
 
-	mut := &gcp_bigtable.Mutation{}
+	mut := &storage.Mutation{}
 	mut.Set(STATS_COLUMN_FAMILY, SUM_COLUMN, ts, sum)
 
 	muts.Add(fmt.Sprintf("%s:%s:%s", bigtable.chainId, SUM_COLUMN, bigtable.reversedPaddedEpoch(epoch)), mut)
@@ -2294,7 +2428,7 @@ func (bigtable *Bigtable) getValidatorIncomeDetailsHistoryV2(validators []uint64
 	res := make(map[uint64]map[uint64]*itypes.ValidatorEpochIncome, len(validators))
 	resMux := &sync.Mutex{}
 
-	filter := gcp_bigtable.LatestNFilter(1)
+	filter := storage.LatestNFilter(1)
 
 	g, gCtx := errgroup.WithContext(ctx)
 	g.SetLimit(concurrency)
@@ -2313,7 +2447,7 @@ func (bigtable *Bigtable) getValidatorIncomeDetailsHistoryV2(validators []uint64
 			default:
 			}
 			ranges := bigtable.getValidatorsEpochRanges(vals, INCOME_DETAILS_COLUMN_FAMILY, startEpoch, endEpoch)
-			err := bigtable.tableValidatorsHistory.ReadRows(ctx, ranges, func(r gcp_bigtable.Row) bool {
+			err := bigtable.tableValidatorsHistory.ReadRows(ctx, ranges, func(r storage.Row) bool {
 				keySplit := strings.Split(r.Key(), ":")
 
 				validator, err := bigtable.validatorKeyToIndex(keySplit[1])
@@ -2345,7 +2479,7 @@ func (bigtable *Bigtable) getValidatorIncomeDetailsHistoryV2(validators []uint64
 					resMux.Unlock()
 				}
 				return true
-			}, gcp_bigtable.RowFilter(filter))
+			}, storage.RowFilter(filter))
 
 			return err
 		})
@@ -2372,35 +2506,35 @@ func (bigtable *Bigtable) getValidatorIncomeDetailsHistoryV1(validators []uint64
 	valLen := len(validators)
 
 	// read entire row if you require more than 1000 validators
-	var columnFilters []gcp_bigtable.Filter
+	var columnFilters []storage.Filter
 	if valLen < 1000 {
-		columnFilters = make([]gcp_bigtable.Filter, 0, valLen)
+		columnFilters = make([]storage.Filter, 0, valLen)
 		for _, validator := range validators {
-			columnFilters = append(columnFilters, gcp_bigtable.ColumnFilter(fmt.Sprintf("%d", validator)))
+			columnFilters = append(columnFilters, storage.ColumnFilter(fmt.Sprintf("%d", validator)))
 		}
 	}
 
-	filter := gcp_bigtable.ChainFilters(
-		gcp_bigtable.FamilyFilter(INCOME_DETAILS_COLUMN_FAMILY),
-		gcp_bigtable.InterleaveFilters(columnFilters...),
-		gcp_bigtable.LatestNFilter(1),
+	filter := storage.ChainFilters(
+		storage.FamilyFilter(INCOME_DETAILS_COLUMN_FAMILY),
+		storage.InterleaveFilters(columnFilters...),
+		storage.LatestNFilter(1),
 	)
 
 	if len(columnFilters) == 1 { // special case to retrieve data for one validator
-		filter = gcp_bigtable.ChainFilters(
-			gcp_bigtable.FamilyFilter(INCOME_DETAILS_COLUMN_FAMILY),
+		filter = storage.ChainFilters(
+			storage.FamilyFilter(INCOME_DETAILS_COLUMN_FAMILY),
 			columnFilters[0],
-			gcp_bigtable.LatestNFilter(1),
+			storage.LatestNFilter(1),
 		)
 	}
 	if len(columnFilters) == 0 { // special case to retrieve data for all validators
-		filter = gcp_bigtable.ChainFilters(
-			gcp_bigtable.FamilyFilter(INCOME_DETAILS_COLUMN_FAMILY),
-			gcp_bigtable.LatestNFilter(1),
+		filter = storage.ChainFilters(
+			storage.FamilyFilter(INCOME_DETAILS_COLUMN_FAMILY),
+			storage.LatestNFilter(1),
 		)
 	}
 
-	err := bigtable.tableBeaconchain.ReadRows(ctx, ranges, func(r gcp_bigtable.Row) bool {
+	err := bigtable.tableBeaconchain.ReadRows(ctx, ranges, func(r storage.Row) bool {
 		keySplit := strings.Split(r.Key(), ":")
 
 		epoch, err := strconv.ParseUint(keySplit[3], 10, 64)
@@ -2431,7 +2565,7 @@ func (bigtable *Bigtable) getValidatorIncomeDetailsHistoryV1(validators []uint64
 			res[validator][max_epoch_v1-epoch] = incomeDetails
 		}
 		return true
-	}, gcp_bigtable.RowFilter(filter))
+	}, storage.RowFilter(filter))
 	if err != nil {
 		return nil, err
 	}
@@ -2520,10 +2654,10 @@ func (bigtable *Bigtable) GetTotalValidatorIncomeDetailsHistory(startEpoch uint6
 
 	res := make(map[uint64]*itypes.ValidatorEpochIncome, endEpoch-startEpoch+1)
 
-	filter := gcp_bigtable.LimitRows(int64(endEpoch - startEpoch + 1))
+	filter := storage.LimitRows(int64(endEpoch - startEpoch + 1))
 
 	rowRange := bigtable.getTotalIncomeEpochRanges(startEpoch, endEpoch)
-	err := bigtable.tableValidatorsHistory.ReadRows(ctx, rowRange, func(r gcp_bigtable.Row) bool {
+	err := bigtable.tableValidatorsHistory.ReadRows(ctx, rowRange, func(r storage.Row) bool {
 		keySplit := strings.Split(r.Key(), ":")
 
 		epoch, err := strconv.ParseUint(keySplit[2], 10, 64)
@@ -2557,7 +2691,7 @@ func (bigtable *Bigtable) DeleteEpoch(epoch uint64) error {
 	return fmt.Errorf("NOT IMPLEMENTED")
 }
 
-func (bigtable *Bigtable) getValidatorsEpochRanges(validatorIndices []uint64, prefix string, startEpoch uint64, endEpoch uint64) gcp_bigtable.RowRangeList {
+func (bigtable *Bigtable) getValidatorsEpochRanges(validatorIndices []uint64, prefix string, startEpoch uint64, endEpoch uint64) storage.RowRangeList {
 	if endEpoch > math.MaxInt64 {
 		endEpoch = 0
 	}
@@ -2565,7 +2699,7 @@ func (bigtable *Bigtable) getValidatorsEpochRanges(validatorIndices []uint64, pr
 		startEpoch = 0
 	}
 
-	ranges := make(gcp_bigtable.RowRangeList, 0, int((endEpoch-startEpoch+1))*len(validatorIndices))
+	ranges := make(storage.RowRangeList, 0, int((endEpoch-startEpoch+1))*len(validatorIndices))
 
 	for _, validatorIndex := range validatorIndices {
 		validatorKey := bigtable.validatorIndexToKey(validatorIndex)
@@ -2574,12 +2708,12 @@ func (bigtable *Bigtable) getValidatorsEpochRanges(validatorIndices []uint64, pr
 		// add \x00 to make the range inclusive
 		rangeEnd := fmt.Sprintf("%s:%s:%s:%s%s", bigtable.chainId, validatorKey, prefix, bigtable.reversedPaddedEpoch(startEpoch), "\x00")
 		rangeStart := fmt.Sprintf("%s:%s:%s:%s", bigtable.chainId, validatorKey, prefix, bigtable.reversedPaddedEpoch(endEpoch))
-		ranges = append(ranges, gcp_bigtable.NewRange(rangeStart, rangeEnd))
+		ranges = append(ranges, storage.NewRange(rangeStart, rangeEnd))
 	}
 	return ranges
 }
 
-func (bigtable *Bigtable) getTotalIncomeEpochRanges(startEpoch uint64, endEpoch uint64) gcp_bigtable.RowRange {
+func (bigtable *Bigtable) getTotalIncomeEpochRanges(startEpoch uint64, endEpoch uint64) storage.RowRange {
 	if endEpoch > math.MaxInt64 {
 		endEpoch = 0
 	}
@@ -2590,10 +2724,10 @@ func (bigtable *Bigtable) getTotalIncomeEpochRanges(startEpoch uint64, endEpoch
 	rangeEnd := fmt.Sprintf("%s:%s:%s%s", bigtable.chainId, SUM_COLUMN, bigtable.reversedPaddedEpoch(startEpoch), "\x00")
 	rangeStart := fmt.Sprintf("%s:%s:%s", bigtable.chainId, SUM_COLUMN, bigtable.reversedPaddedEpoch(endEpoch))
 
-	return gcp_bigtable.NewRange(rangeStart, rangeEnd)
+	return storage.NewRange(rangeStart, rangeEnd)
 }
 
-func (bigtable *Bigtable) getValidatorsEpochSlotRanges(validatorIndices []uint64, prefix string, startEpoch uint64, endEpoch uint64) gcp_bigtable.RowRangeList {
+func (bigtable *Bigtable) getValidatorsEpochSlotRanges(validatorIndices []uint64, prefix string, startEpoch uint64, endEpoch uint64) storage.RowRangeList {
 	if endEpoch > math.MaxInt64 {
 		endEpoch = 0
 	}
@@ -2601,19 +2735,19 @@ func (bigtable *Bigtable) getValidatorsEpochSlotRanges(validatorIndices []uint64
 		startEpoch = 0
 	}
 
-	ranges := make(gcp_bigtable.RowRangeList, 0, int((endEpoch-startEpoch+1))*len(validatorIndices))
+	ranges := make(storage.RowRangeList, 0, int((endEpoch-startEpoch+1))*len(validatorIndices))
 
 	for _, validatorIndex := range validatorIndices {
 		validatorKey := bigtable.validatorIndexToKey(validatorIndex)
 
 		rangeEnd := fmt.Sprintf("%s:%s:%s:%s:%s%s", bigtable.chainId, validatorKey, prefix, bigtable.reversedPaddedEpoch(startEpoch), bigtable.reversedPaddedSlot(startEpoch*utils.Config.Chain.ClConfig.SlotsPerEpoch), "\x00")
 		rangeStart := fmt.Sprintf("%s:%s:%s:%s:%s", bigtable.chainId, validatorKey, prefix, bigtable.reversedPaddedEpoch(endEpoch), bigtable.reversedPaddedSlot(endEpoch*utils.Config.Chain.ClConfig.SlotsPerEpoch+utils.Config.Chain.ClConfig.SlotsPerEpoch-1))
-		ranges = append(ranges, gcp_bigtable.NewRange(rangeStart, rangeEnd))
+		ranges = append(ranges, storage.NewRange(rangeStart, rangeEnd))
 	}
 	return ranges
 }
 
-func (bigtable *Bigtable) getValidatorSlotRanges(validatorIndices []uint64, prefix string, startSlot uint64, endSlot uint64) gcp_bigtable.RowRangeList {
+func (bigtable *Bigtable) getValidatorSlotRanges(validatorIndices []uint64, prefix string, startSlot uint64, endSlot uint64) storage.RowRangeList {
 	if endSlot > math.MaxInt64 {
 		endSlot = 0
 	}
@@ -2624,14 +2758,14 @@ func (bigtable *Bigtable) getValidatorSlotRanges(validatorIndices []uint64, pref
 	startEpoch := utils.EpochOfSlot(startSlot)
 	endEpoch := utils.EpochOfSlot(endSlot)
 
-	ranges := make(gcp_bigtable.RowRangeList, 0, len(validatorIndices))
+	ranges := make(storage.RowRangeList, 0, len(validatorIndices))
 
 	for _, validatorIndex := range validatorIndices {
 		validatorKey := bigtable.validatorIndexToKey(validatorIndex)
 
 		rangeEnd := fmt.Sprintf("%s:%s:%s:%s:%s%s", bigtable.chainId, validatorKey, prefix, bigtable.reversedPaddedEpoch(startEpoch), bigtable.reversedPaddedSlot(startSlot), "\x00")
 		rangeStart := fmt.Sprintf("%s:%s:%s:%s:%s", bigtable.chainId, validatorKey, prefix, bigtable.reversedPaddedEpoch(endEpoch), bigtable.reversedPaddedSlot(endSlot))
-		ranges = append(ranges, gcp_bigtable.NewRange(rangeStart, rangeEnd))
+		ranges = append(ranges, storage.NewRange(rangeStart, rangeEnd))
 	}
 	return ranges
 }
@@ -2697,12 +2831,12 @@ func (bigtable *Bigtable) MigrateIncomeDataV1V2Schema(epoch uint64) error {
 	}
 
 	epochData := make(map[uint64]*validatorEpochData)
-	filter := gcp_bigtable.ChainFilters(gcp_bigtable.FamilyFilter(INCOME_DETAILS_COLUMN_FAMILY), gcp_bigtable.LatestNFilter(1))
+	filter := storage.ChainFilters(storage.FamilyFilter(INCOME_DETAILS_COLUMN_FAMILY), storage.LatestNFilter(1))
 	ctx := context.Background()
 
-	prefixEpochRange := gcp_bigtable.PrefixRange(fmt.Sprintf("%s:e:b:%s", bigtable.chainId, fmt.Sprintf("%09d", (MAX_EPOCH)-epoch)))
+	prefixEpochRange := storage.PrefixRange(fmt.Sprintf("%s:e:b:%s", bigtable.chainId, fmt.Sprintf("%09d", (MAX_EPOCH)-epoch)))
 
-	err := bigtable.tableBeaconchain.ReadRows(ctx, prefixEpochRange, func(r gcp_bigtable.Row) bool {
+	err := bigtable.tableBeaconchain.ReadRows(ctx, prefixEpochRange, func(r storage.Row) bool {
 		// log.LogInfo("processing row %v", r.Key())
 
 		keySplit := strings.Split(r.Key(), ":")
@@ -2755,7 +2889,7 @@ func (bigtable *Bigtable) MigrateIncomeDataV1V2Schema(epoch uint64) error {
 			}
 		}
 		return true
-	}, gcp_bigtable.RowFilter(filter))
+	}, storage.RowFilter(filter))
 
 	if err != nil {
 		return err
@@ -2777,86 +2911,86 @@ func (bigtable *Bigtable) MigrateIncomeDataV1V2Schema(epoch uint64) error {
 	return nil
 }
 
-func (bigtable *Bigtable) getSlotRangesForEpochV1(startEpoch uint64, endEpoch uint64) gcp_bigtable.RowRangeList {
+func (bigtable *Bigtable) getSlotRangesForEpochV1(startEpoch uint64, endEpoch uint64) storage.RowRangeList {
 	if endEpoch < startEpoch { // handle overflows
 		startEpoch = 0
 	}
 
-	ranges := gcp_bigtable.RowRangeList{}
+	ranges := storage.RowRangeList{}
 	if startEpoch == 0 { // special case when the 0 epoch is included
 		rangeEnd := fmt.Sprintf("%s:e:%s:s:%s", bigtable.chainId, reversedPaddedEpochV1(0), ":")
 		rangeStart := fmt.Sprintf("%s:e:%s:s:", bigtable.chainId, reversedPaddedEpochV1(0))
-		ranges = append(ranges, gcp_bigtable.NewRange(rangeStart, rangeEnd))
+		ranges = append(ranges, storage.NewRange(rangeStart, rangeEnd))
 
 		// epochs are sorted descending, so start with the larges epoch and end with the smallest
 		// add ':', a character lexicographically after digits, to make the range inclusive
 		if startEpoch < endEpoch {
 			rangeEnd = fmt.Sprintf("%s:e:%s:s:%s", bigtable.chainId, reversedPaddedEpochV1(startEpoch+1), ":")
 			rangeStart = fmt.Sprintf("%s:e:%s:s:", bigtable.chainId, reversedPaddedEpochV1(endEpoch))
-			ranges = append(ranges, gcp_bigtable.NewRange(rangeStart, rangeEnd))
+			ranges = append(ranges, storage.NewRange(rangeStart, rangeEnd))
 		}
 	} else {
 		// epochs are sorted descending, so start with the larges epoch and end with the smallest
 		// add ':', a character lexicographically after digits, to make the range inclusive
 		rangeEnd := fmt.Sprintf("%s:e:%s:s:%s", bigtable.chainId, reversedPaddedEpochV1(startEpoch), ":")
 		rangeStart := fmt.Sprintf("%s:e:%s:s:", bigtable.chainId, reversedPaddedEpochV1(endEpoch))
-		ranges = append(ranges, gcp_bigtable.NewRange(rangeStart, rangeEnd))
+		ranges = append(ranges, storage.NewRange(rangeStart, rangeEnd))
 	}
 	return ranges
 }
 
-func (bigtable *Bigtable) getSlotRangesV1(startSlot uint64, endSlot uint64) gcp_bigtable.RowRangeList {
+func (bigtable *Bigtable) getSlotRangesV1(startSlot uint64, endSlot uint64) storage.RowRangeList {
 	if endSlot < startSlot { // handle overflows
 		startSlot = 0
 	}
 
-	ranges := gcp_bigtable.RowRangeList{}
+	ranges := storage.RowRangeList{}
 	if startSlot == 0 { // special case when the 0 slot is included
 		rangeEnd := fmt.Sprintf("%s:e:%s:s:%s\x00", bigtable.chainId, reversedPaddedEpochV1(0), reversedPaddedSlotV1(0))
 		rangeStart := fmt.Sprintf("%s:e:%s:s:%s", bigtable.chainId, reversedPaddedEpochV1(0), reversedPaddedSlotV1(0))
-		ranges = append(ranges, gcp_bigtable.NewRange(rangeStart, rangeEnd))
+		ranges = append(ranges, storage.NewRange(rangeStart, rangeEnd))
 
 		// epochs are sorted descending, so start with the larges epoch and end with the smallest
 		// add ':', a character lexicographically after digits, to make the range inclusive
 		if startSlot < endSlot {
 			rangeEnd = fmt.Sprintf("%s:e:%s:s:%s\x00", bigtable.chainId, reversedPaddedEpochV1(utils.EpochOfSlot(startSlot)), reversedPaddedSlotV1(startSlot))
 			rangeStart = fmt.Sprintf("%s:e:%s:s:%s", bigtable.chainId, reversedPaddedEpochV1(utils.EpochOfSlot(endSlot)), reversedPaddedSlotV1(endSlot))
-			ranges = append(ranges, gcp_bigtable.NewRange(rangeStart, rangeEnd))
+			ranges = append(ranges, storage.NewRange(rangeStart, rangeEnd))
 		}
 	} else {
 		// epochs are sorted descending, so start with the larges epoch and end with the smallest
 		// add ':', a character lexicographically after digits, to make the range inclusive
 		rangeEnd := fmt.Sprintf("%s:e:%s:s:%s\x00", bigtable.chainId, reversedPaddedEpochV1(utils.EpochOfSlot(startSlot)), reversedPaddedSlotV1(startSlot))
 		rangeStart := fmt.Sprintf("%s:e:%s:s:%s", bigtable.chainId, reversedPaddedEpochV1(utils.EpochOfSlot(endSlot)), reversedPaddedSlotV1(endSlot))
-		ranges = append(ranges, gcp_bigtable.NewRange(rangeStart, rangeEnd))
+		ranges = append(ranges, storage.NewRange(rangeStart, rangeEnd))
 	}
 	return ranges
 }
 
-func (bigtable *Bigtable) getEpochRangesV1(startEpoch uint64, endEpoch uint64) gcp_bigtable.RowRangeList {
+func (bigtable *Bigtable) getEpochRangesV1(startEpoch uint64, endEpoch uint64) storage.RowRangeList {
 	if endEpoch < startEpoch { // handle overflows
 		startEpoch = 0
 	}
 
-	ranges := gcp_bigtable.RowRangeList{}
+	ranges := storage.RowRangeList{}
 	if startEpoch == 0 { // special case when the 0 epoch is included
 		rangeEnd := fmt.Sprintf("%s:e:b:%s%s", bigtable.chainId, reversedPaddedEpochV1(0), "\x00")
 		rangeStart := fmt.Sprintf("%s:e:b:%s", bigtable.chainId, reversedPaddedEpochV1(0))
-		ranges = append(ranges, gcp_bigtable.NewRange(rangeStart, rangeEnd))
+		ranges = append(ranges, storage.NewRange(rangeStart, rangeEnd))
 
 		// epochs are sorted descending, so start with the largest epoch and end with the smallest
 		// add \x00 to make the range inclusive
 		if startEpoch < endEpoch {
 			rangeEnd = fmt.Sprintf("%s:e:b:%s%s", bigtable.chainId, reversedPaddedEpochV1(startEpoch+1), "\x00")
 			rangeStart = fmt.Sprintf("%s:e:b:%s", bigtable.chainId, reversedPaddedEpochV1(endEpoch))
-			ranges = append(ranges, gcp_bigtable.NewRange(rangeStart, rangeEnd))
+			ranges = append(ranges, storage.NewRange(rangeStart, rangeEnd))
 		}
 	} else {
 		// epochs are sorted descending, so start with the largest epoch and end with the smallest
 		// add \x00 to make the range inclusive
 		rangeEnd := fmt.Sprintf("%s:e:b:%s%s", bigtable.chainId, reversedPaddedEpochV1(startEpoch), "\x00")
 		rangeStart := fmt.Sprintf("%s:e:b:%s", bigtable.chainId, reversedPaddedEpochV1(endEpoch))
-		ranges = append(ranges, gcp_bigtable.NewRange(rangeStart, rangeEnd))
+		ranges = append(ranges, storage.NewRange(rangeStart, rangeEnd))
 	}
 	return ranges
 }
@@ -8,13 +8,13 @@ import (
 	"sort"
 	"time"
 
-	gcp_bigtable "cloud.google.com/go/bigtable"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/storage"
 	"github.com/gobitfly/beaconchain/pkg/commons/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 )
 
-func (bigtable *Bigtable) WriteBulk(mutations *types.BulkMutations, table *gcp_bigtable.Table, batchSize int) error {
+func (bigtable *Bigtable) WriteBulk(mutations *types.BulkMutations, table storage.Table, batchSize int) error {
 	callingFunctionName := utils.GetParentFuncName()
 
 	ctx, done := context.WithTimeout(context.Background(), time.Minute*5)
@@ -79,9 +79,9 @@ func (bigtable *Bigtable) ClearByPrefix(table string, family, columns, prefix st
 		return fmt.Errorf("please provide family [%v], columns [%v] and prefix [%v]", family, columns, prefix)
 	}
 
-	rowRange := gcp_bigtable.PrefixRange(prefix)
+	rowRange := storage.PrefixRange(prefix)
 
-	var btTable *gcp_bigtable.Table
+	var btTable storage.Table
 
 	switch table {
 	case "data":
@@ -106,20 +106,20 @@ func (bigtable *Bigtable) ClearByPrefix(table string, family, columns, prefix st
 
 	mutsDelete := types.NewBulkMutations(MAX_BATCH_MUTATIONS)
 
-	var filter gcp_bigtable.Filter
+	var filter storage.Filter
 	columnsSlice := strings.Split(columns, ",")
 	if len(columnsSlice) > 1 {
-		columnNames := make([]gcp_bigtable.Filter, len(columnsSlice))
+		columnNames := make([]storage.Filter, len(columnsSlice))
 		for i, f := range columnsSlice {
-			columnNames[i] = gcp_bigtable.ColumnFilter(f)
+			columnNames[i] = storage.ColumnFilter(f)
 		}
-		filter = gcp_bigtable.InterleaveFilters(columnNames...)
+		filter = storage.InterleaveFilters(columnNames...)
 	} else {
-		filter = gcp_bigtable.ColumnFilter(columnsSlice[0])
+		filter = storage.ColumnFilter(columnsSlice[0])
 	}
 
 	keysCount := 0
-	deleteFunc := func(row gcp_bigtable.Row) bool {
+	deleteFunc := func(row storage.Row) bool {
 		var row_ string
 
 		if family == "*" {
@@ -131,7 +131,7 @@ func (bigtable *Bigtable) ClearByPrefix(table string, family, columns, prefix st
 			log.Infof("would delete key %v", row_)
 		}
 
-		mutDelete := gcp_bigtable.NewMutation()
+		mutDelete := storage.NewMutation()
 		if columns == "*" {
 			mutDelete.DeleteRow()
 		} else {
@@ -163,7 +163,7 @@ func (bigtable *Bigtable) ClearByPrefix(table string, family, columns, prefix st
 	if columns == "*" {
 		err = btTable.ReadRows(context.Background(), rowRange, deleteFunc)
 	} else {
-		err = btTable.ReadRows(context.Background(), rowRange, deleteFunc, gcp_bigtable.RowFilter(filter))
+		err = btTable.ReadRows(context.Background(), rowRange, deleteFunc, storage.RowFilter(filter))
 	}
 	if err != nil {
 		return err
@@ -25,7 +25,7 @@ import (
 
 	"strconv"
 
-	gcp_bigtable "cloud.google.com/go/bigtable"
+	"github.com/gobitfly/beaconchain/pkg/commons/storage"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -34,7 +34,6 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
-	gethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/go-redis/redis/v8"
 
 	"google.golang.org/protobuf/proto"
@@ -128,9 +127,9 @@ func (bigtable *Bigtable) SaveBlock(block *types.Eth1Block) error {
 	if err != nil {
 		return err
 	}
-	ts := gcp_bigtable.Timestamp(0)
+	ts := storage.Timestamp(0)
 
-	mut := gcp_bigtable.NewMutation()
+	mut := storage.NewMutation()
 	mut.Set(DEFAULT_FAMILY_BLOCKS, "data", ts, encodedBc)
 
 	err = bigtable.tableBlocks.Apply(ctx, fmt.Sprintf("%s:%s", bigtable.chainId, reversedPaddedBlockNumber(block.Number)), mut)
@@ -184,7 +183,7 @@ func (bigtable *Bigtable) CheckForGapsInBlocksTable(lookback int) (gapFound bool
 	prefix := bigtable.chainId + ":"
 	previous := 0
 	i := 0
-	err = bigtable.tableBlocks.ReadRows(ctx, gcp_bigtable.PrefixRange(prefix), func(r gcp_bigtable.Row) bool {
+	err = bigtable.tableBlocks.ReadRows(ctx, storage.PrefixRange(prefix), func(r storage.Row) bool {
 		c, err := strconv.Atoi(strings.Replace(r.Key(), prefix, "", 1))
 
 		if err != nil {
@@ -209,7 +208,7 @@ func (bigtable *Bigtable) CheckForGapsInBlocksTable(lookback int) (gapFound bool
 		i++
 
 		return i < lookback
-	}, gcp_bigtable.RowFilter(gcp_bigtable.StripValueFilter()))
+	}, storage.RowFilter(storage.StripValueFilter()))
 
 	return gapFound, start, end, err
 }
@@ -265,7 +264,7 @@ func (bigtable *Bigtable) CheckForGapsInDataTable(lookback int) error {
 	prefix := bigtable.chainId + ":B:"
 	previous := 0
 	i := 0
-	err := bigtable.tableData.ReadRows(ctx, gcp_bigtable.PrefixRange(prefix), func(r gcp_bigtable.Row) bool {
+	err := bigtable.tableData.ReadRows(ctx, storage.PrefixRange(prefix), func(r storage.Row) bool {
 		c, err := strconv.Atoi(strings.Replace(r.Key(), prefix, "", 1))
 
 		if err != nil {
@@ -286,7 +285,7 @@ func (bigtable *Bigtable) CheckForGapsInDataTable(lookback int) error {
 		i++
 
 		return i < lookback
-	}, gcp_bigtable.RowFilter(gcp_bigtable.StripValueFilter()))
+	}, storage.RowFilter(storage.StripValueFilter()))
 
 	if err != nil {
 		return err
@@ -337,7 +336,7 @@ func (bigtable *Bigtable) getLastBlockInDataTableFromBigtable() (int, error) {
 
 	prefix := bigtable.chainId + ":B:"
 	lastBlock := 0
-	err := bigtable.tableData.ReadRows(ctx, gcp_bigtable.PrefixRange(prefix), func(r gcp_bigtable.Row) bool {
+	err := bigtable.tableData.ReadRows(ctx, storage.PrefixRange(prefix), func(r storage.Row) bool {
 		c, err := strconv.Atoi(strings.Replace(r.Key(), prefix, "", 1))
 
 		if err != nil {
@@ -348,7 +347,7 @@ func (bigtable *Bigtable) getLastBlockInDataTableFromBigtable() (int, error) {
 
 		lastBlock = c
 		return c == 0 // required as the block with number 0 will be returned as first block before the most recent one
-	}, gcp_bigtable.LimitRows(2), gcp_bigtable.RowFilter(gcp_bigtable.StripValueFilter()))
+	}, storage.LimitRows(2), storage.RowFilter(storage.StripValueFilter()))
 
 	if err != nil {
 		return 0, err
@@ -363,7 +362,7 @@ func (bigtable *Bigtable) getLastBlockInBlocksTableFromBigtable() (int, error) {
 
 	prefix := bigtable.chainId + ":"
 	lastBlock := 0
-	err := bigtable.tableBlocks.ReadRows(ctx, gcp_bigtable.PrefixRange(prefix), func(r gcp_bigtable.Row) bool {
+	err := bigtable.tableBlocks.ReadRows(ctx, storage.PrefixRange(prefix), func(r storage.Row) bool {
 		c, err := strconv.Atoi(strings.Replace(r.Key(), prefix, "", 1))
 
 		if err != nil {
@@ -374,7 +373,7 @@ func (bigtable *Bigtable) getLastBlockInBlocksTableFromBigtable() (int, error) {
 
 		lastBlock = c
 		return c == 0 // required as the block with number 0 will be returned as first block before the most recent one
-	}, gcp_bigtable.LimitRows(2), gcp_bigtable.RowFilter(gcp_bigtable.StripValueFilter()))
+	}, storage.LimitRows(2), storage.RowFilter(storage.StripValueFilter()))
 
 	if err != nil {
 		return 0, err
@@ -405,10 +404,10 @@ func (bigtable *Bigtable) GetMostRecentBlockFromDataTable() (*types.Eth1BlockInd
 
 	prefix := fmt.Sprintf("%s:B:", bigtable.chainId)
 
-	rowRange := gcp_bigtable.PrefixRange(prefix)
+	rowRange := storage.PrefixRange(prefix)
 	block := types.Eth1BlockIndexed{}
 
-	rowHandler := func(row gcp_bigtable.Row) bool {
+	rowHandler := func(row storage.Row) bool {
 		c, err := strconv.Atoi(strings.Replace(row.Key(), prefix, "", 1))
 		if err != nil {
 			log.Error(err, "error parsing block number from key", 0, map[string]interface{}{"key": row.Key()})
@@ -425,7 +424,7 @@ func (bigtable *Bigtable) GetMostRecentBlockFromDataTable() (*types.Eth1BlockInd
 		return c == 0
 	}
 
-	err := bigtable.tableData.ReadRows(ctx, rowRange, rowHandler, gcp_bigtable.LimitRows(2), gcp_bigtable.RowFilter(gcp_bigtable.ColumnFilter("d")))
+	err := bigtable.tableData.ReadRows(ctx, rowRange, rowHandler, storage.LimitRows(2), storage.RowFilter(storage.ColumnFilter("d")))
 	if err != nil {
 		return nil, err
 	}
@@ -433,8 +432,8 @@ func (bigtable *Bigtable) GetMostRecentBlockFromDataTable() (*types.Eth1BlockInd
 	return &block, nil
 }
 
-func getBlockHandler(blocks *[]*types.Eth1BlockIndexed) func(gcp_bigtable.Row) bool {
-	return func(row gcp_bigtable.Row) bool {
+func getBlockHandler(blocks *[]*types.Eth1BlockIndexed) func(storage.Row) bool {
+	return func(row storage.Row) bool {
 		if row == nil {
 			return false
 		}
@@ -492,9 +491,9 @@ func (bigtable *Bigtable) GetFullBlocksDescending(stream chan<- *types.Eth1Block
 
 		limit := high - limitedLow + 1
 
-		rowRange := gcp_bigtable.NewRange(highKey, lowKey)
-		rowFilter := gcp_bigtable.RowFilter(gcp_bigtable.ColumnFilter("data"))
-		rowHandler := func(row gcp_bigtable.Row) bool {
+		rowRange := storage.NewRange(highKey, lowKey)
+		rowFilter := storage.RowFilter(storage.ColumnFilter("data"))
+		rowHandler := func(row storage.Row) bool {
 			block := types.Eth1Block{}
 			err := proto.Unmarshal(row[DEFAULT_FAMILY_BLOCKS][0].Value, &block)
 			if err != nil {
@@ -505,7 +504,7 @@ func (bigtable *Bigtable) GetFullBlocksDescending(stream chan<- *types.Eth1Block
 			return true
 		}
 
-		err := bigtable.tableBlocks.ReadRows(ctx, rowRange, rowHandler, rowFilter, gcp_bigtable.LimitRows(int64(limit)))
+		err := bigtable.tableBlocks.ReadRows(ctx, rowRange, rowHandler, rowFilter, storage.LimitRows(int64(limit)))
 		if err != nil {
 			return err
 		}
@@ -554,9 +553,9 @@ func (bigtable *Bigtable) StreamBlocksIndexedDescending(stream chan<- *types.Eth
 
 		limit := high - limitedLow + 1
 
-		rowRange := gcp_bigtable.NewRange(highKey, lowKey)
-		rowFilter := gcp_bigtable.RowFilter(gcp_bigtable.ColumnFilter("d"))
-		rowHandler := func(row gcp_bigtable.Row) bool {
+		rowRange := storage.NewRange(highKey, lowKey)
+		rowFilter := storage.RowFilter(storage.ColumnFilter("d"))
+		rowHandler := func(row storage.Row) bool {
 			block := types.Eth1BlockIndexed{}
 			err := proto.Unmarshal(row["f"][0].Value, &block)
 			if err != nil {
@@ -567,7 +566,7 @@ func (bigtable *Bigtable) StreamBlocksIndexedDescending(stream chan<- *types.Eth
 			return true
 		}
 
-		err := bigtable.tableData.ReadRows(ctx, rowRange, rowHandler, rowFilter, gcp_bigtable.LimitRows(int64(limit)))
+		err := bigtable.tableData.ReadRows(ctx, rowRange, rowHandler, rowFilter, storage.LimitRows(int64(limit)))
 		if err != nil {
 			return err
 		}
@@ -596,7 +595,7 @@ func (bigtable *Bigtable) GetBlocksIndexedMultiple(blockNumbers []uint64, limit
 	})
 	defer tmr.Stop()
 
-	rowList := gcp_bigtable.RowList{}
+	rowList := storage.RowList{}
 	for _, block := range blockNumbers {
 		rowList = append(rowList, fmt.Sprintf("%s:B:%s", bigtable.chainId, reversedPaddedBlockNumber(block)))
 	}
@@ -604,13 +603,13 @@ func (bigtable *Bigtable) GetBlocksIndexedMultiple(blockNumbers []uint64, limit
 	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
 	defer cancel()
 
-	rowFilter := gcp_bigtable.RowFilter(gcp_bigtable.ColumnFilter("d"))
+	rowFilter := storage.RowFilter(storage.ColumnFilter("d"))
 
 	blocks := make([]*types.Eth1BlockIndexed, 0, 100)
 
 	rowHandler := getBlockHandler(&blocks)
 
-	err := bigtable.tableData.ReadRows(ctx, rowList, rowHandler, rowFilter, gcp_bigtable.LimitRows(int64(limit)))
+	err := bigtable.tableData.ReadRows(ctx, rowList, rowHandler, rowFilter, storage.LimitRows(int64(limit)))
 	if err != nil {
 		return nil, err
 	}
@@ -651,7 +650,7 @@ func (bigtable *Bigtable) GetBlocksDescending(start, limit uint64) ([]*types.Eth
 	blocks := make([]*types.Eth1BlockIndexed, 0, limit)
 
 	rowHandler := getBlockHandler(&blocks)
-	rowFilter := gcp_bigtable.RowFilter(gcp_bigtable.ColumnFilter("d"))
+	rowFilter := storage.RowFilter(storage.ColumnFilter("d"))
 
 	if start > 0 {
 		// block 0 cannot be included in the range as it is padded incorrectly (will be fetched last, see below)
@@ -663,9 +662,9 @@ func (bigtable *Bigtable) GetBlocksDescending(start, limit uint64) ([]*types.Eth
 		startKey := fmt.Sprintf("%s:B:%s", bigtable.chainId, reversedPaddedBlockNumber(start))
 		endKey := fmt.Sprintf("%s:B:%s\x00", bigtable.chainId, reversedPaddedBlockNumber(limitedEnd)) // add \x00 to make the range inclusive
 
-		rowRange := gcp_bigtable.NewRange(startKey, endKey)
+		rowRange := storage.NewRange(startKey, endKey)
 
-		err := bigtable.tableData.ReadRows(ctx, rowRange, rowHandler, rowFilter, gcp_bigtable.LimitRows(int64(limit)))
+		err := bigtable.tableData.ReadRows(ctx, rowRange, rowHandler, rowFilter, storage.LimitRows(int64(limit)))
 		if err != nil {
 			return nil, err
 		}
@@ -941,14 +940,14 @@ func (bigtable *Bigtable) TransformBlock(block *types.Eth1Block, cache *freecach
 
 	// <chainID>:b:<reverse number>
 	key := fmt.Sprintf("%s:B:%s", bigtable.chainId, reversedPaddedBlockNumber(block.GetNumber()))
-	mut := gcp_bigtable.NewMutation()
+	mut := storage.NewMutation()
 
 	b, err := proto.Marshal(&idx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error marshalling proto object err: %w", err)
 	}
 
-	mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), b)
+	mut.Set(DEFAULT_FAMILY, DATA_COLUMN, storage.Timestamp(0), b)
 
 	bulkData.Keys = append(bulkData.Keys, key)
 	bulkData.Muts = append(bulkData.Muts, mut)
@@ -959,8 +958,8 @@ func (bigtable *Bigtable) TransformBlock(block *types.Eth1Block, cache *freecach
 	}
 
 	for _, idx := range indexes {
-		mut := gcp_bigtable.NewMutation()
-		mut.Set(DEFAULT_FAMILY, key, gcp_bigtable.Timestamp(0), nil)
+		mut := storage.NewMutation()
+		mut.Set(DEFAULT_FAMILY, key, storage.Timestamp(0), nil)
 
 		bulkData.Keys = append(bulkData.Keys, idx)
 		bulkData.Muts = append(bulkData.Muts, mut)
@@ -1019,37 +1018,10 @@ func (bigtable *Bigtable) TransformTx(blk *types.Eth1Block, cache *freecache.Cac
 			return nil, nil, fmt.Errorf("unexpected number of transactions in block expected at most %d but got: %v, tx: %x", TX_PER_BLOCK_LIMIT-1, i, tx.GetHash())
 		}
 		iReverse := reversePaddedIndex(i, TX_PER_BLOCK_LIMIT)
-		// log.LogInfo("address to: %x address: contract: %x, len(to): %v, len(contract): %v, contranct zero: %v", tx.GetTo(), tx.GetContractAddress(), len(tx.GetTo()), len(tx.GetContractAddress()), bytes.Equal(tx.GetContractAddress(), ZERO_ADDRESS))
-		to := tx.GetTo()
-		isContract := false
-		if !bytes.Equal(tx.GetContractAddress(), ZERO_ADDRESS) {
-			to = tx.GetContractAddress()
-			isContract = true
-		}
-		// log.LogInfo("sending to: %x", to)
-		method := make([]byte, 0)
-		if len(tx.GetData()) > 3 {
-			method = tx.GetData()[:4]
-		}
-
 		key := fmt.Sprintf("%s:TX:%x", bigtable.chainId, tx.GetHash())
-		fee := new(big.Int).Mul(new(big.Int).SetBytes(tx.GetGasPrice()), big.NewInt(int64(tx.GetGasUsed()))).Bytes()
-		blobFee := new(big.Int).Mul(new(big.Int).SetBytes(tx.GetBlobGasPrice()), big.NewInt(int64(tx.GetBlobGasUsed()))).Bytes()
-		indexedTx := &types.Eth1TransactionIndexed{
-			Hash:               tx.GetHash(),
-			BlockNumber:        blk.GetNumber(),
-			Time:               blk.GetTime(),
-			MethodId:           method,
-			From:               tx.GetFrom(),
-			To:                 to,
-			Value:              tx.GetValue(),
-			TxFee:              fee,
-			GasPrice:           tx.GetGasPrice(),
-			BlobTxFee:          blobFee,
-			BlobGasPrice:       tx.GetBlobGasPrice(),
-			IsContractCreation: isContract,
-			ErrorMsg:           tx.GetErrorMsg(),
-		}
+		indexedTx := newIndexedTransaction(blk, tx)
+		to := indexedTx.To
+		method := indexedTx.MethodId
 		// Mark Sender and Recipient for balance update
 		bigtable.markBalanceUpdate(indexedTx.From, []byte{0x0}, bulkMetadataUpdates, cache)
 		bigtable.markBalanceUpdate(indexedTx.To, []byte{0x0}, bulkMetadataUpdates, cache)
@@ -1063,8 +1035,8 @@ func (bigtable *Bigtable) TransformTx(blk *types.Eth1Block, cache *freecache.Cac
 			return nil, nil, err
 		}
 
-		mut := gcp_bigtable.NewMutation()
-		mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), b)
+		mut := storage.NewMutation()
+		mut.Set(DEFAULT_FAMILY, DATA_COLUMN, storage.Timestamp(0), b)
 
 		bulkData.Keys = append(bulkData.Keys, key)
 		bulkData.Muts = append(bulkData.Muts, mut)
@@ -1091,8 +1063,8 @@ func (bigtable *Bigtable) TransformTx(blk *types.Eth1Block, cache *freecache.Cac
 		}
 
 		for _, idx := range indexes {
-			mut := gcp_bigtable.NewMutation()
-			mut.Set(DEFAULT_FAMILY, key, gcp_bigtable.Timestamp(0), nil)
+			mut := storage.NewMutation()
+			mut.Set(DEFAULT_FAMILY, key, storage.Timestamp(0), nil)
 
 			bulkData.Keys = append(bulkData.Keys, idx)
 			bulkData.Muts = append(bulkData.Muts, mut)
@@ -1151,8 +1123,8 @@ func (bigtable *Bigtable) TransformBlobTx(blk *types.Eth1Block, cache *freecache
 			return nil, nil, err
 		}
 
-		mut := gcp_bigtable.NewMutation()
-		mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), b)
+		mut := storage.NewMutation()
+		mut.Set(DEFAULT_FAMILY, DATA_COLUMN, storage.Timestamp(0), b)
 
 		bulkData.Keys = append(bulkData.Keys, key)
 		bulkData.Muts = append(bulkData.Muts, mut)
@@ -1172,8 +1144,8 @@ func (bigtable *Bigtable) TransformBlobTx(blk *types.Eth1Block, cache *freecache
 		}
 
 		for _, idx := range indexes {
-			mut := gcp_bigtable.NewMutation()
-			mut.Set(DEFAULT_FAMILY, key, gcp_bigtable.Timestamp(0), nil)
+			mut := storage.NewMutation()
+			mut.Set(DEFAULT_FAMILY, key, storage.Timestamp(0), nil)
 
 			bulkData.Keys = append(bulkData.Keys, idx)
 			bulkData.Muts = append(bulkData.Muts, mut)
@@ -1184,7 +1156,7 @@ func (bigtable *Bigtable) TransformBlobTx(blk *types.Eth1Block, cache *freecache
 }
 
 // custom timestamp
-func encodeIsContractUpdateTs(block_number, tx_idx, trace_idx uint64) (gcp_bigtable.Timestamp, error) {
+func encodeIsContractUpdateTs(block_number, tx_idx, trace_idx uint64) (storage.Timestamp, error) {
 	var res uint64
 
 	if block_number >= TIMESTAMP_BLOCK_SCALE {
@@ -1204,10 +1176,10 @@ func encodeIsContractUpdateTs(block_number, tx_idx, trace_idx uint64) (gcp_bigta
 	res *= TIMESTAMP_TRACE_SCALE
 	res += trace_idx
 
-	return gcp_bigtable.Timestamp(res * TIMESTAMP_GBT_SCALE), nil
+	return storage.Timestamp(res * TIMESTAMP_GBT_SCALE), nil
 }
 
-func decodeIsContractUpdateTs(ts gcp_bigtable.Timestamp) (block_number, tx_idx, trace_idx uint64) {
+func decodeIsContractUpdateTs(ts storage.Timestamp) (block_number, tx_idx, trace_idx uint64) {
 	n := uint64(ts)
 	n /= TIMESTAMP_GBT_SCALE
 
@@ -1251,7 +1223,7 @@ func (bigtable *Bigtable) TransformContract(blk *types.Eth1Block, cache *freecac
 					address = itx.GetFrom()
 				}
 
-				mutWrite := gcp_bigtable.NewMutation()
+				mutWrite := storage.NewMutation()
 				ts, err := encodeIsContractUpdateTs(blk.GetNumber(), uint64(i), uint64(j))
 				if err != nil {
 					log.Error(err, "error generating bigtable isContract timestamp", 0)
@@ -1337,14 +1309,14 @@ func (bigtable *Bigtable) TransformItx(blk *types.Eth1Block, cache *freecache.Ca
 
 			// Delete existing delegatecall data or add/update other data
 			if itx.GetType() == "delegatecall" {
-				mut := gcp_bigtable.NewMutation()
+				mut := storage.NewMutation()
 				mut.DeleteCellsInColumn(DEFAULT_FAMILY, DATA_COLUMN)
 
 				bulkData.Keys = append(bulkData.Keys, key)
 				bulkData.Muts = append(bulkData.Muts, mut)
 
 				for _, idx := range indexes {
-					mut := gcp_bigtable.NewMutation()
+					mut := storage.NewMutation()
 					mut.DeleteCellsInColumn(DEFAULT_FAMILY, key)
 
 					bulkData.Keys = append(bulkData.Keys, idx)
@@ -1356,15 +1328,15 @@ func (bigtable *Bigtable) TransformItx(blk *types.Eth1Block, cache *freecache.Ca
 					return nil, nil, err
 				}
 
-				mut := gcp_bigtable.NewMutation()
-				mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), b)
+				mut := storage.NewMutation()
+				mut.Set(DEFAULT_FAMILY, DATA_COLUMN, storage.Timestamp(0), b)
 
 				bulkData.Keys = append(bulkData.Keys, key)
 				bulkData.Muts = append(bulkData.Muts, mut)
 
 				for _, idx := range indexes {
-					mut := gcp_bigtable.NewMutation()
-					mut.Set(DEFAULT_FAMILY, key, gcp_bigtable.Timestamp(0), nil)
+					mut := storage.NewMutation()
+					mut.Set(DEFAULT_FAMILY, key, storage.Timestamp(0), nil)
 
 					bulkData.Keys = append(bulkData.Keys, idx)
 					bulkData.Muts = append(bulkData.Muts, mut)
@@ -1440,48 +1412,12 @@ func (bigtable *Bigtable) TransformERC20(blk *types.Eth1Block, cache *freecache.
 				return nil, nil, fmt.Errorf("unexpected number of logs in block expected at most %d but got: %v tx: %x", ITX_PER_TX_LIMIT-1, j, tx.GetHash())
 			}
 			jReversed := reversePaddedIndex(j, ITX_PER_TX_LIMIT)
-			if len(log.GetTopics()) != 3 || !bytes.Equal(log.GetTopics()[0], erc20.TransferTopic) {
-				continue
-			}
-
-			topics := make([]common.Hash, 0, len(log.GetTopics()))
-
-			for _, lTopic := range log.GetTopics() {
-				topics = append(topics, common.BytesToHash(lTopic))
-			}
-
-			ethLog := gethtypes.Log{
-				Address:     common.BytesToAddress(log.GetAddress()),
-				Data:        log.Data,
-				Topics:      topics,
-				BlockNumber: blk.GetNumber(),
-				TxHash:      common.BytesToHash(tx.GetHash()),
-				TxIndex:     uint(i),
-				BlockHash:   common.BytesToHash(blk.GetHash()),
-				Index:       uint(j),
-				Removed:     log.GetRemoved(),
-			}
-
-			transfer, _ := filterer.ParseTransfer(ethLog)
-			if transfer == nil {
+			indexedLog := parseERC20Transfer(filterer, blk, i, tx, j, log)
+			if indexedLog == nil {
 				continue
 			}
 
-			value := []byte{}
-			if transfer != nil && transfer.Value != nil {
-				value = transfer.Value.Bytes()
-			}
-
 			key := fmt.Sprintf("%s:ERC20:%x:%s", bigtable.chainId, tx.GetHash(), jReversed)
-			indexedLog := &types.Eth1ERC20Indexed{
-				ParentHash:   tx.GetHash(),
-				BlockNumber:  blk.GetNumber(),
-				Time:         blk.GetTime(),
-				TokenAddress: log.Address,
-				From:         transfer.From.Bytes(),
-				To:           transfer.To.Bytes(),
-				Value:        value,
-			}
 			bigtable.markBalanceUpdate(indexedLog.From, indexedLog.TokenAddress, bulkMetadataUpdates, cache)
 			bigtable.markBalanceUpdate(indexedLog.To, indexedLog.TokenAddress, bulkMetadataUpdates, cache)
 
@@ -1490,8 +1426,8 @@ func (bigtable *Bigtable) TransformERC20(blk *types.Eth1Block, cache *freecache.
 				return nil, nil, err
 			}
 
-			mut := gcp_bigtable.NewMutation()
-			mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), b)
+			mut := storage.NewMutation()
+			mut.Set(DEFAULT_FAMILY, DATA_COLUMN, storage.Timestamp(0), b)
 
 			bulkData.Keys = append(bulkData.Keys, key)
 			bulkData.Muts = append(bulkData.Muts, mut)
@@ -1511,8 +1447,8 @@ func (bigtable *Bigtable) TransformERC20(blk *types.Eth1Block, cache *freecache.
 			}
 
 			for _, idx := range indexes {
-				mut := gcp_bigtable.NewMutation()
-				mut.Set(DEFAULT_FAMILY, key, gcp_bigtable.Timestamp(0), nil)
+				mut := storage.NewMutation()
+				mut.Set(DEFAULT_FAMILY, key, storage.Timestamp(0), nil)
 
 				// if i == 3 || i == 4 {
 				// 	mut.DeleteRow()
@@ -1590,57 +1526,21 @@ func (bigtable *Bigtable) TransformERC721(blk *types.Eth1Block, cache *freecache
 			if j >= ITX_PER_TX_LIMIT {
 				return nil, nil, fmt.Errorf("unexpected number of logs in block expected at most %d but got: %v tx: %x", ITX_PER_TX_LIMIT-1, j, tx.GetHash())
 			}
-			if len(log.GetTopics()) != 4 || !bytes.Equal(log.GetTopics()[0], erc721.TransferTopic) {
+			indexedLog := parseERC721Transfer(filterer, blk, i, tx, j, log)
+			if indexedLog == nil {
 				continue
 			}
 			jReversed := reversePaddedIndex(j, ITX_PER_TX_LIMIT)
 
-			topics := make([]common.Hash, 0, len(log.GetTopics()))
-
-			for _, lTopic := range log.GetTopics() {
-				topics = append(topics, common.BytesToHash(lTopic))
-			}
-
-			ethLog := gethtypes.Log{
-				Address:     common.BytesToAddress(log.GetAddress()),
-				Data:        log.Data,
-				Topics:      topics,
-				BlockNumber: blk.GetNumber(),
-				TxHash:      common.BytesToHash(tx.GetHash()),
-				TxIndex:     uint(i),
-				BlockHash:   common.BytesToHash(blk.GetHash()),
-				Index:       uint(j),
-				Removed:     log.GetRemoved(),
-			}
-
-			transfer, _ := filterer.ParseTransfer(ethLog)
-			if transfer == nil {
-				continue
-			}
-
-			tokenId := new(big.Int)
-			if transfer != nil && transfer.TokenId != nil {
-				tokenId = transfer.TokenId
-			}
-
 			key := fmt.Sprintf("%s:ERC721:%x:%s", bigtable.chainId, tx.GetHash(), jReversed)
-			indexedLog := &types.Eth1ERC721Indexed{
-				ParentHash:   tx.GetHash(),
-				BlockNumber:  blk.GetNumber(),
-				Time:         blk.GetTime(),
-				TokenAddress: log.Address,
-				From:         transfer.From.Bytes(),
-				To:           transfer.To.Bytes(),
-				TokenId:      tokenId.Bytes(),
-			}
 
 			b, err := proto.Marshal(indexedLog)
 			if err != nil {
 				return nil, nil, err
 			}
 
-			mut := gcp_bigtable.NewMutation()
-			mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), b)
+			mut := storage.NewMutation()
+			mut.Set(DEFAULT_FAMILY, DATA_COLUMN, storage.Timestamp(0), b)
 
 			bulkData.Keys = append(bulkData.Keys, key)
 			bulkData.Muts = append(bulkData.Muts, mut)
@@ -1661,8 +1561,8 @@ func (bigtable *Bigtable) TransformERC721(blk *types.Eth1Block, cache *freecache
 			}
 
 			for _, idx := range indexes {
-				mut := gcp_bigtable.NewMutation()
-				mut.Set(DEFAULT_FAMILY, key, gcp_bigtable.Timestamp(0), nil)
+				mut := storage.NewMutation()
+				mut.Set(DEFAULT_FAMILY, key, storage.Timestamp(0), nil)
 
 				// if i == 3 || i == 4 {
 				// 	mut.DeleteRow()
@@ -1744,82 +1644,18 @@ func (bigtable *Bigtable) TransformERC1155(blk *types.Eth1Block, cache *freecach
 
 			key := fmt.Sprintf("%s:ERC1155:%x:%s", bigtable.chainId, tx.GetHash(), jReversed)
 
-			// no events emitted continue
-			if len(txLog.GetTopics()) != 4 || (!bytes.Equal(txLog.GetTopics()[0], erc1155.TransferBulkTopic) && !bytes.Equal(txLog.GetTopics()[0], erc1155.TransferSingleTopic)) {
+			indexedLog := parseERC1155Transfer(filterer, blk, i, tx, j, txLog)
+			if indexedLog == nil {
 				continue
 			}
 
-			topics := make([]common.Hash, 0, len(txLog.GetTopics()))
-
-			for _, lTopic := range txLog.GetTopics() {
-				topics = append(topics, common.BytesToHash(lTopic))
-			}
-
-			ethLog := gethtypes.Log{
-				Address:     common.BytesToAddress(txLog.GetAddress()),
-				Data:        txLog.Data,
-				Topics:      topics,
-				BlockNumber: blk.GetNumber(),
-				TxHash:      common.BytesToHash(tx.GetHash()),
-				TxIndex:     uint(i),
-				BlockHash:   common.BytesToHash(blk.GetHash()),
-				Index:       uint(j),
-				Removed:     txLog.GetRemoved(),
-			}
-
-			indexedLog := &types.ETh1ERC1155Indexed{}
-			transferBatch, _ := filterer.ParseTransferBatch(ethLog)
-			transferSingle, _ := filterer.ParseTransferSingle(ethLog)
-			if transferBatch == nil && transferSingle == nil {
-				continue
-			}
-
-			// && len(transferBatch.Operator) == 20 && len(transferBatch.From) == 20 && len(transferBatch.To) == 20 && len(transferBatch.Ids) > 0 && len(transferBatch.Values) > 0
-			if transferBatch != nil {
-				ids := make([][]byte, 0, len(transferBatch.Ids))
-				for _, id := range transferBatch.Ids {
-					ids = append(ids, id.Bytes())
-				}
-
-				values := make([][]byte, 0, len(transferBatch.Values))
-				for _, val := range transferBatch.Values {
-					values = append(values, val.Bytes())
-				}
-
-				if len(ids) != len(values) {
-					log.Error(fmt.Errorf("error parsing erc1155 batch transfer logs. Expected len(ids): %v len(values): %v to be the same", len(ids), len(values)), "", 0)
-					continue
-				}
-				for ti := range ids {
-					indexedLog.BlockNumber = blk.GetNumber()
-					indexedLog.Time = blk.GetTime()
-					indexedLog.ParentHash = tx.GetHash()
-					indexedLog.From = transferBatch.From.Bytes()
-					indexedLog.To = transferBatch.To.Bytes()
-					indexedLog.Operator = transferBatch.Operator.Bytes()
-					indexedLog.TokenId = ids[ti]
-					indexedLog.Value = values[ti]
-					indexedLog.TokenAddress = txLog.GetAddress()
-				}
-			} else if transferSingle != nil {
-				indexedLog.BlockNumber = blk.GetNumber()
-				indexedLog.Time = blk.GetTime()
-				indexedLog.ParentHash = tx.GetHash()
-				indexedLog.From = transferSingle.From.Bytes()
-				indexedLog.To = transferSingle.To.Bytes()
-				indexedLog.Operator = transferSingle.Operator.Bytes()
-				indexedLog.TokenId = transferSingle.Id.Bytes()
-				indexedLog.Value = transferSingle.Value.Bytes()
-				indexedLog.TokenAddress = txLog.GetAddress()
-			}
-
 			b, err := proto.Marshal(indexedLog)
 			if err != nil {
 				return nil, nil, err
 			}
 
-			mut := gcp_bigtable.NewMutation()
-			mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), b)
+			mut := storage.NewMutation()
+			mut.Set(DEFAULT_FAMILY, DATA_COLUMN, storage.Timestamp(0), b)
 
 			bulkData.Keys = append(bulkData.Keys, key)
 			bulkData.Muts = append(bulkData.Muts, mut)
@@ -1840,8 +1676,8 @@ func (bigtable *Bigtable) TransformERC1155(blk *types.Eth1Block, cache *freecach
 			}
 
 			for _, idx := range indexes {
-				mut := gcp_bigtable.NewMutation()
-				mut.Set(DEFAULT_FAMILY, key, gcp_bigtable.Timestamp(0), nil)
+				mut := storage.NewMutation()
+				mut.Set(DEFAULT_FAMILY, key, storage.Timestamp(0), nil)
 
 				// if i == 3 || i == 4 {
 				// 	mut.DeleteRow()
@@ -1907,14 +1743,14 @@ func (bigtable *Bigtable) TransformUncle(block *types.Eth1Block, cache *freecach
 
 		// store uncles in with the key <chainid>:U:<reversePaddedBlockNumber>:<reversePaddedUncleIndex>
 		key := fmt.Sprintf("%s:U:%s:%s", bigtable.chainId, reversedPaddedBlockNumber(block.GetNumber()), iReversed)
-		mut := gcp_bigtable.NewMutation()
+		mut := storage.NewMutation()
 
 		b, err := proto.Marshal(&uncleIndexed)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error marshalling proto object err: %w", err)
 		}
 
-		mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), b)
+		mut.Set(DEFAULT_FAMILY, DATA_COLUMN, storage.Timestamp(0), b)
 
 		bulkData.Keys = append(bulkData.Keys, key)
 		bulkData.Muts = append(bulkData.Muts, mut)
@@ -1925,8 +1761,8 @@ func (bigtable *Bigtable) TransformUncle(block *types.Eth1Block, cache *freecach
 		}
 
 		for _, idx := range indexes {
-			mut := gcp_bigtable.NewMutation()
-			mut.Set(DEFAULT_FAMILY, key, gcp_bigtable.Timestamp(0), nil)
+			mut := storage.NewMutation()
+			mut.Set(DEFAULT_FAMILY, key, storage.Timestamp(0), nil)
 
 			bulkData.Keys = append(bulkData.Keys, idx)
 			bulkData.Muts = append(bulkData.Muts, mut)
@@ -1976,14 +1812,14 @@ func (bigtable *Bigtable) TransformWithdrawals(block *types.Eth1Block, cache *fr
 
 		// store withdrawals with the key <chainid>:W:<reversePaddedBlockNumber>:<reversePaddedWithdrawalIndex>
 		key := fmt.Sprintf("%s:W:%s:%s", bigtable.chainId, reversedPaddedBlockNumber(block.GetNumber()), iReversed)
-		mut := gcp_bigtable.NewMutation()
+		mut := storage.NewMutation()
 
 		b, err := proto.Marshal(&withdrawalIndexed)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error marshalling proto object err: %w", err)
 		}
 
-		mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), b)
+		mut.Set(DEFAULT_FAMILY, DATA_COLUMN, storage.Timestamp(0), b)
 
 		bulkData.Keys = append(bulkData.Keys, key)
 		bulkData.Muts = append(bulkData.Muts, mut)
@@ -1994,8 +1830,8 @@ func (bigtable *Bigtable) TransformWithdrawals(block *types.Eth1Block, cache *fr
 		}
 
 		for _, idx := range indexes {
-			mut := gcp_bigtable.NewMutation()
-			mut.Set(DEFAULT_FAMILY, key, gcp_bigtable.Timestamp(0), nil)
+			mut := storage.NewMutation()
+			mut.Set(DEFAULT_FAMILY, key, storage.Timestamp(0), nil)
 
 			bulkData.Keys = append(bulkData.Keys, idx)
 			bulkData.Muts = append(bulkData.Muts, mut)
@@ -2089,8 +1925,8 @@ func (bigtable *Bigtable) rearrangeReversePaddedIndexZero(ctx context.Context, i
 				continue
 			}
 			splits[5] = fmt.Sprintf("%d", i+1)
-			rowRange := gcp_bigtable.NewRange(indexes[len(indexes)-1]+"\x00", strings.Join(splits[:6], ":"))
-			err = bigtable.tableData.ReadRows(ctx, rowRange, func(row gcp_bigtable.Row) bool {
+			rowRange := storage.NewRange(indexes[len(indexes)-1]+"\x00", strings.Join(splits[:6], ":"))
+			err = bigtable.tableData.ReadRows(ctx, rowRange, func(row storage.Row) bool {
 				keys = append(keys, strings.TrimPrefix(row[DEFAULT_FAMILY][0].Column, "f:"))
 				indexes = append(indexes, row.Key())
 				return true
@@ -2152,17 +1988,17 @@ func (bigtable *Bigtable) GetEth1TxsForAddress(prefix string, limit int64) ([]*t
 	defer cancel()
 
 	// add \x00 to the row range such that we skip the previous value
-	rowRange := gcp_bigtable.NewRange(prefix+"\x00", prefixSuccessor(prefix, 5))
+	rowRange := storage.NewRange(prefix+"\x00", prefixSuccessor(prefix, 5))
 	data := make([]*types.Eth1TransactionIndexed, 0, limit)
 	keys := make([]string, 0, limit)
 	indexes := make([]string, 0, limit)
 	keysMap := make(map[string]*types.Eth1TransactionIndexed, limit)
 
-	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row gcp_bigtable.Row) bool {
+	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row storage.Row) bool {
 		keys = append(keys, strings.TrimPrefix(row[DEFAULT_FAMILY][0].Column, "f:"))
 		indexes = append(indexes, row.Key())
 		return true
-	}, gcp_bigtable.LimitRows(limit))
+	}, storage.LimitRows(limit))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2173,7 +2009,7 @@ func (bigtable *Bigtable) GetEth1TxsForAddress(prefix string, limit int64) ([]*t
 
 	indexes, keys = bigtable.rearrangeReversePaddedIndexZero(ctx, indexes, keys)
 
-	err = bigtable.tableData.ReadRows(ctx, gcp_bigtable.RowList(keys), func(row gcp_bigtable.Row) bool {
+	err = bigtable.tableData.ReadRows(ctx, storage.RowList(keys), func(row storage.Row) bool {
 		b := &types.Eth1TransactionIndexed{}
 		err := proto.Unmarshal(row[DEFAULT_FAMILY][0].Value, b)
 
@@ -2295,17 +2131,17 @@ func (bigtable *Bigtable) GetEth1BlocksForAddress(prefix string, limit int64) ([
 	defer cancel()
 
 	// add \x00 to the row range such that we skip the previous value
-	rowRange := gcp_bigtable.NewRange(prefix+"\x00", prefixSuccessor(prefix, 4))
+	rowRange := storage.NewRange(prefix+"\x00", prefixSuccessor(prefix, 4))
 	data := make([]*types.Eth1BlockIndexed, 0, limit)
 	keys := make([]string, 0, limit)
 	indexes := make([]string, 0, limit)
 	keysMap := make(map[string]*types.Eth1BlockIndexed, limit)
 
-	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row gcp_bigtable.Row) bool {
+	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row storage.Row) bool {
 		keys = append(keys, strings.TrimPrefix(row[DEFAULT_FAMILY][0].Column, "f:"))
 		indexes = append(indexes, row.Key())
 		return true
-	}, gcp_bigtable.LimitRows(limit))
+	}, storage.LimitRows(limit))
 	if err != nil {
 		return nil, "", err
 	}
@@ -2314,7 +2150,7 @@ func (bigtable *Bigtable) GetEth1BlocksForAddress(prefix string, limit int64) ([
 		return data, "", nil
 	}
 
-	err = bigtable.tableData.ReadRows(ctx, gcp_bigtable.RowList(keys), func(row gcp_bigtable.Row) bool {
+	err = bigtable.tableData.ReadRows(ctx, storage.RowList(keys), func(row storage.Row) bool {
 		b := &types.Eth1BlockIndexed{}
 		err := proto.Unmarshal(row[DEFAULT_FAMILY][0].Value, b)
 
@@ -2394,17 +2230,17 @@ func (bigtable *Bigtable) GetEth1UnclesForAddress(prefix string, limit int64) ([
 	defer cancel()
 
 	// add \x00 to the row range such that we skip the previous value
-	rowRange := gcp_bigtable.NewRange(prefix+"\x00", prefixSuccessor(prefix, 4))
+	rowRange := storage.NewRange(prefix+"\x00", prefixSuccessor(prefix, 4))
 	data := make([]*types.Eth1UncleIndexed, 0, limit)
 	keys := make([]string, 0, limit)
 	indexes := make([]string, 0, limit)
 	keysMap := make(map[string]*types.Eth1UncleIndexed, limit)
 
-	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row gcp_bigtable.Row) bool {
+	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row storage.Row) bool {
 		keys = append(keys, strings.TrimPrefix(row[DEFAULT_FAMILY][0].Column, "f:"))
 		indexes = append(indexes, row.Key())
 		return true
-	}, gcp_bigtable.LimitRows(limit))
+	}, storage.LimitRows(limit))
 	if err != nil {
 		return nil, "", err
 	}
@@ -2413,7 +2249,7 @@ func (bigtable *Bigtable) GetEth1UnclesForAddress(prefix string, limit int64) ([
 		return data, "", nil
 	}
 
-	err = bigtable.tableData.ReadRows(ctx, gcp_bigtable.RowList(keys), func(row gcp_bigtable.Row) bool {
+	err = bigtable.tableData.ReadRows(ctx, storage.RowList(keys), func(row storage.Row) bool {
 		b := &types.Eth1UncleIndexed{}
 		err := proto.Unmarshal(row[DEFAULT_FAMILY][0].Value, b)
 
@@ -2453,17 +2289,17 @@ func (bigtable *Bigtable) GetEth1BtxForAddress(prefix string, limit int64) ([]*t
 	defer cancel()
 
 	// add \x00 to the row range such that we skip the previous value
-	rowRange := gcp_bigtable.NewRange(prefix+"\x00", prefixSuccessor(prefix, 5))
+	rowRange := storage.NewRange(prefix+"\x00", prefixSuccessor(prefix, 5))
 	data := make([]*types.Eth1BlobTransactionIndexed, 0, limit)
 	keys := make([]string, 0, limit)
 	indexes := make([]string, 0, limit)
 	keysMap := make(map[string]*types.Eth1BlobTransactionIndexed, limit)
 
-	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row gcp_bigtable.Row) bool {
+	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row storage.Row) bool {
 		keys = append(keys, strings.TrimPrefix(row[DEFAULT_FAMILY][0].Column, "f:"))
 		indexes = append(indexes, row.Key())
 		return true
-	}, gcp_bigtable.LimitRows(limit))
+	}, storage.LimitRows(limit))
 	if err != nil {
 		return nil, "", err
 	}
@@ -2473,7 +2309,7 @@ func (bigtable *Bigtable) GetEth1BtxForAddress(prefix string, limit int64) ([]*t
 
 	indexes, keys = bigtable.rearrangeReversePaddedIndexZero(ctx, indexes, keys)
 
-	err = bigtable.tableData.ReadRows(ctx, gcp_bigtable.RowList(keys), func(row gcp_bigtable.Row) bool {
+	err = bigtable.tableData.ReadRows(ctx, storage.RowList(keys), func(row storage.Row) bool {
 		b := &types.Eth1BlobTransactionIndexed{}
 		err := proto.Unmarshal(row[DEFAULT_FAMILY][0].Value, b)
 		if err != nil {
@@ -2511,17 +2347,17 @@ func (bigtable *Bigtable) GetEth1ItxsForAddress(prefix string, limit int64) ([]*
 	defer cancel()
 
 	// add \x00 to the row range such that we skip the previous value
-	rowRange := gcp_bigtable.NewRange(prefix+"\x00", prefixSuccessor(prefix, 5))
+	rowRange := storage.NewRange(prefix+"\x00", prefixSuccessor(prefix, 5))
 	data := make([]*types.Eth1InternalTransactionIndexed, 0, limit)
 	keys := make([]string, 0, limit)
 	indexes := make([]string, 0, limit)
 
 	keysMap := make(map[string]*types.Eth1InternalTransactionIndexed, limit)
-	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row gcp_bigtable.Row) bool {
+	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row storage.Row) bool {
 		keys = append(keys, strings.TrimPrefix(row[DEFAULT_FAMILY][0].Column, "f:"))
 		indexes = append(indexes, row.Key())
 		return true
-	}, gcp_bigtable.LimitRows(limit))
+	}, storage.LimitRows(limit))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2531,7 +2367,7 @@ func (bigtable *Bigtable) GetEth1ItxsForAddress(prefix string, limit int64) ([]*
 
 	indexes, keys = bigtable.rearrangeReversePaddedIndexZero(ctx, indexes, keys)
 
-	err = bigtable.tableData.ReadRows(ctx, gcp_bigtable.RowList(keys), func(row gcp_bigtable.Row) bool {
+	err = bigtable.tableData.ReadRows(ctx, storage.RowList(keys), func(row storage.Row) bool {
 		b := &types.Eth1InternalTransactionIndexed{}
 		err := proto.Unmarshal(row[DEFAULT_FAMILY][0].Value, b)
 
@@ -2575,17 +2411,17 @@ func (bigtable *Bigtable) GetEth1ERC20ForAddress(prefix string, limit int64) ([]
 	defer cancel()
 
 	// add \x00 to the row range such that we skip the previous value
-	rowRange := gcp_bigtable.NewRange(prefix+"\x00", prefixSuccessor(prefix, 5))
+	rowRange := storage.NewRange(prefix+"\x00", prefixSuccessor(prefix, 5))
 	data := make([]*types.Eth1ERC20Indexed, 0, limit)
 	keys := make([]string, 0, limit)
 	indexes := make([]string, 0, limit)
 
 	keysMap := make(map[string]*types.Eth1ERC20Indexed, limit)
-	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row gcp_bigtable.Row) bool {
+	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row storage.Row) bool {
 		keys = append(keys, strings.TrimPrefix(row[DEFAULT_FAMILY][0].Column, "f:"))
 		indexes = append(indexes, row.Key())
 		return true
-	}, gcp_bigtable.LimitRows(limit))
+	}, storage.LimitRows(limit))
 	if err != nil {
 		return nil, "", err
 	}
@@ -2595,7 +2431,7 @@ func (bigtable *Bigtable) GetEth1ERC20ForAddress(prefix string, limit int64) ([]
 
 	indexes, keys = bigtable.rearrangeReversePaddedIndexZero(ctx, indexes, keys)
 
-	err = bigtable.tableData.ReadRows(ctx, gcp_bigtable.RowList(keys), func(row gcp_bigtable.Row) bool {
+	err = bigtable.tableData.ReadRows(ctx, storage.RowList(keys), func(row storage.Row) bool {
 		b := &types.Eth1ERC20Indexed{}
 		err := proto.Unmarshal(row[DEFAULT_FAMILY][0].Value, b)
 
@@ -2635,7 +2471,7 @@ func (bigtable *Bigtable) GetEth1ERC721ForAddress(prefix string, limit int64) ([
 
 	// add \x00 to the row range such that we don't include the prefix itself in the response. Converts range to open interval (start, end).
 	// "1:I:ERC721:81d98c8fda0410ee3e9d7586cb949cd19fa4cf38:TIME;"
-	rowRange := gcp_bigtable.NewRange(prefix+"\x00", prefixSuccessor(prefix, 5))
+	rowRange := storage.NewRange(prefix+"\x00", prefixSuccessor(prefix, 5))
 
 	data := make([]*types.Eth1ERC721Indexed, 0, limit)
 
@@ -2644,11 +2480,11 @@ func (bigtable *Bigtable) GetEth1ERC721ForAddress(prefix string, limit int64) ([
 	indexes := make([]string, 0, limit)
 
 	//  1:I:ERC721:81d98c8fda0410ee3e9d7586cb949cd19fa4cf38:TIME:9223372035220135322:0052:00000
-	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row gcp_bigtable.Row) bool {
+	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row storage.Row) bool {
 		keys = append(keys, strings.TrimPrefix(row[DEFAULT_FAMILY][0].Column, "f:"))
 		indexes = append(indexes, row.Key())
 		return true
-	}, gcp_bigtable.LimitRows(limit))
+	}, storage.LimitRows(limit))
 	if err != nil {
 		return nil, "", err
 	}
@@ -2659,7 +2495,7 @@ func (bigtable *Bigtable) GetEth1ERC721ForAddress(prefix string, limit int64) ([
 
 	indexes, keys = bigtable.rearrangeReversePaddedIndexZero(ctx, indexes, keys)
 
-	err = bigtable.tableData.ReadRows(ctx, gcp_bigtable.RowList(keys), func(row gcp_bigtable.Row) bool {
+	err = bigtable.tableData.ReadRows(ctx, storage.RowList(keys), func(row storage.Row) bool {
 		b := &types.Eth1ERC721Indexed{}
 		err := proto.Unmarshal(row[DEFAULT_FAMILY][0].Value, b)
 
@@ -2696,7 +2532,7 @@ func (bigtable *Bigtable) GetEth1ERC1155ForAddress(prefix string, limit int64) (
 	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
 	defer cancel()
 
-	rowRange := gcp_bigtable.NewRange(prefix+"\x00", prefixSuccessor(prefix, 5))
+	rowRange := storage.NewRange(prefix+"\x00", prefixSuccessor(prefix, 5))
 
 	data := make([]*types.ETh1ERC1155Indexed, 0, limit)
 
@@ -2704,11 +2540,11 @@ func (bigtable *Bigtable) GetEth1ERC1155ForAddress(prefix string, limit int64) (
 	keysMap := make(map[string]*types.ETh1ERC1155Indexed, limit)
 	indexes := make([]string, 0, limit)
 
-	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row gcp_bigtable.Row) bool {
+	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row storage.Row) bool {
 		keys = append(keys, strings.TrimPrefix(row[DEFAULT_FAMILY][0].Column, "f:"))
 		indexes = append(indexes, row.Key())
 		return true
-	}, gcp_bigtable.LimitRows(limit))
+	}, storage.LimitRows(limit))
 	if err != nil {
 		return nil, "", err
 	}
@@ -2719,7 +2555,7 @@ func (bigtable *Bigtable) GetEth1ERC1155ForAddress(prefix string, limit int64) (
 
 	indexes, keys = bigtable.rearrangeReversePaddedIndexZero(ctx, indexes, keys)
 
-	err = bigtable.tableData.ReadRows(ctx, gcp_bigtable.RowList(keys), func(row gcp_bigtable.Row) bool {
+	err = bigtable.tableData.ReadRows(ctx, storage.RowList(keys), func(row storage.Row) bool {
 		b := &types.ETh1ERC1155Indexed{}
 		err := proto.Unmarshal(row[DEFAULT_FAMILY][0].Value, b)
 
@@ -2760,7 +2596,7 @@ func (bigtable *Bigtable) GetMetadataUpdates(prefix string, startToken string, l
 	keys := make([]string, 0, limit)
 	pairs := make([]*types.Eth1AddressBalance, 0, limit)
 
-	err := bigtable.tableMetadataUpdates.ReadRows(ctx, gcp_bigtable.NewRange(startToken, ""), func(row gcp_bigtable.Row) bool {
+	err := bigtable.tableMetadataUpdates.ReadRows(ctx, storage.NewRange(startToken, ""), func(row storage.Row) bool {
 		if !strings.Contains(row.Key(), prefix) {
 			return false
 		}
@@ -2772,7 +2608,7 @@ func (bigtable *Bigtable) GetMetadataUpdates(prefix string, startToken string, l
 			}
 		}
 		return true
-	}, gcp_bigtable.LimitRows(int64(limit)))
+	}, storage.LimitRows(int64(limit)))
 
 	if err == context.DeadlineExceeded && len(keys) > 0 {
 		return keys, pairs, nil
@@ -2797,7 +2633,7 @@ func (bigtable *Bigtable) GetMetadata(startToken string, limit int) ([]string, [
 	keys := make([]string, 0, limit)
 	pairs := make([]*types.Eth1AddressBalance, 0, limit)
 
-	err := bigtable.tableMetadata.ReadRows(ctx, gcp_bigtable.NewRange(startToken, ""), func(row gcp_bigtable.Row) bool {
+	err := bigtable.tableMetadata.ReadRows(ctx, storage.NewRange(startToken, ""), func(row storage.Row) bool {
 		if !strings.HasPrefix(row.Key(), bigtable.chainId+":") {
 			return false
 		}
@@ -2811,7 +2647,7 @@ func (bigtable *Bigtable) GetMetadata(startToken string, limit int) ([]string, [
 			}
 		}
 		return true
-	}, gcp_bigtable.LimitRows(int64(limit)))
+	}, storage.LimitRows(int64(limit)))
 
 	if err == context.DeadlineExceeded && len(keys) > 0 {
 		return keys, pairs, nil
@@ -2834,8 +2670,8 @@ func (bigtable *Bigtable) GetMetadataForAddress(address []byte, offset uint64, l
 	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
 	defer cancel()
 
-	filter := gcp_bigtable.FamilyFilter(ACCOUNT_METADATA_FAMILY)
-	row, err := bigtable.tableMetadata.ReadRow(ctx, fmt.Sprintf("%s:%x", bigtable.chainId, address), gcp_bigtable.RowFilter(filter))
+	filter := storage.FamilyFilter(ACCOUNT_METADATA_FAMILY)
+	row, err := bigtable.tableMetadata.ReadRow(ctx, fmt.Sprintf("%s:%x", bigtable.chainId, address), storage.RowFilter(filter))
 
 	if err != nil {
 		return nil, err
@@ -2952,8 +2788,8 @@ func (bigtable *Bigtable) GetBalanceForAddress(address []byte, token []byte) (*t
 	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
 	defer cancel()
 
-	filter := gcp_bigtable.ChainFilters(gcp_bigtable.FamilyFilter(ACCOUNT_METADATA_FAMILY), gcp_bigtable.ColumnFilter(fmt.Sprintf("B:%x", token)))
-	row, err := bigtable.tableMetadata.ReadRow(ctx, fmt.Sprintf("%s:%x", bigtable.chainId, address), gcp_bigtable.RowFilter(filter))
+	filter := storage.ChainFilters(storage.FamilyFilter(ACCOUNT_METADATA_FAMILY), storage.ColumnFilter(fmt.Sprintf("B:%x", token)))
+	row, err := bigtable.tableMetadata.ReadRow(ctx, fmt.Sprintf("%s:%x", bigtable.chainId, address), storage.RowFilter(filter))
 
 	if err != nil {
 		return nil, err
@@ -3010,14 +2846,14 @@ func (bigtable *Bigtable) GetERC20MetadataForAddress(address []byte) (*types.ERC
 
 	// this function actually does not use bigtable right now, but it will in the future (see BIDS-1846, BIDS-1234)
 
-	var row gcp_bigtable.Row
+	var row storage.Row
 	var err error
 
 	// ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 	// defer cancel()
 	// rowKey := fmt.Sprintf("%s:%x", bigtable.chainId, address)
-	// filter := gcp_bigtable.FamilyFilter(ERC20_METADATA_FAMILY)
-	// row, err = bigtable.tableMetadata.ReadRow(ctx, rowKey, gcp_bigtable.RowFilter(filter))
+	// filter := storage.FamilyFilter(ERC20_METADATA_FAMILY)
+	// row, err = bigtable.tableMetadata.ReadRow(ctx, rowKey, storage.RowFilter(filter))
 	// if err != nil {
 	// 	 return nil, err
 	// }
@@ -3090,34 +2926,34 @@ func (bigtable *Bigtable) SaveERC20Metadata(address []byte, metadata *types.ERC2
 	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
 	defer cancel()
 
-	mut := gcp_bigtable.NewMutation()
+	mut := storage.NewMutation()
 	if len(metadata.Decimals) > 0 {
-		mut.Set(ERC20_METADATA_FAMILY, ERC20_COLUMN_DECIMALS, gcp_bigtable.Timestamp(0), metadata.Decimals)
+		mut.Set(ERC20_METADATA_FAMILY, ERC20_COLUMN_DECIMALS, storage.Timestamp(0), metadata.Decimals)
 	}
 
 	if len(metadata.TotalSupply) > 0 {
-		mut.Set(ERC20_METADATA_FAMILY, ERC20_COLUMN_TOTALSUPPLY, gcp_bigtable.Timestamp(0), metadata.TotalSupply)
+		mut.Set(ERC20_METADATA_FAMILY, ERC20_COLUMN_TOTALSUPPLY, storage.Timestamp(0), metadata.TotalSupply)
 	}
 
 	if len(metadata.Symbol) > 0 {
-		mut.Set(ERC20_METADATA_FAMILY, ERC20_COLUMN_SYMBOL, gcp_bigtable.Timestamp(0), []byte(metadata.Symbol))
+		mut.Set(ERC20_METADATA_FAMILY, ERC20_COLUMN_SYMBOL, storage.Timestamp(0), []byte(metadata.Symbol))
 	}
 
 	if len(metadata.Name) > 0 {
-		mut.Set(ERC20_METADATA_FAMILY, ERC20_COLUMN_NAME, gcp_bigtable.Timestamp(0), []byte(metadata.Name))
+		mut.Set(ERC20_METADATA_FAMILY, ERC20_COLUMN_NAME, storage.Timestamp(0), []byte(metadata.Name))
 	}
 
 	if len(metadata.Description) > 0 {
-		mut.Set(ERC20_METADATA_FAMILY, ERC20_COLUMN_DESCRIPTION, gcp_bigtable.Timestamp(0), []byte(metadata.Description))
+		mut.Set(ERC20_METADATA_FAMILY, ERC20_COLUMN_DESCRIPTION, storage.Timestamp(0), []byte(metadata.Description))
 	}
 
 	if len(metadata.Price) > 0 {
-		mut.Set(ERC20_METADATA_FAMILY, ERC20_COLUMN_PRICE, gcp_bigtable.Timestamp(0), metadata.Price)
+		mut.Set(ERC20_METADATA_FAMILY, ERC20_COLUMN_PRICE, storage.Timestamp(0), metadata.Price)
 	}
 
 	if len(metadata.Logo) > 0 && len(metadata.LogoFormat) > 0 {
-		mut.Set(ERC20_METADATA_FAMILY, ERC20_COLUMN_LOGO, gcp_bigtable.Timestamp(0), metadata.Logo)
-		mut.Set(ERC20_METADATA_FAMILY, ERC20_COLUMN_LOGO_FORMAT, gcp_bigtable.Timestamp(0), []byte(metadata.LogoFormat))
+		mut.Set(ERC20_METADATA_FAMILY, ERC20_COLUMN_LOGO, storage.Timestamp(0), metadata.Logo)
+		mut.Set(ERC20_METADATA_FAMILY, ERC20_COLUMN_LOGO_FORMAT, storage.Timestamp(0), []byte(metadata.LogoFormat))
 	}
 
 	return bigtable.tableMetadata.Apply(ctx, rowKey, mut)
@@ -3154,9 +2990,9 @@ func (bigtable *Bigtable) GetAddressName(address []byte) (string, error) {
 		return wanted, nil
 	}
 
-	filter := gcp_bigtable.ChainFilters(gcp_bigtable.FamilyFilter(ACCOUNT_METADATA_FAMILY), gcp_bigtable.ColumnFilter(ACCOUNT_COLUMN_NAME))
+	filter := storage.ChainFilters(storage.FamilyFilter(ACCOUNT_METADATA_FAMILY), storage.ColumnFilter(ACCOUNT_COLUMN_NAME))
 
-	row, err := bigtable.tableMetadata.ReadRow(ctx, rowKey, gcp_bigtable.RowFilter(filter))
+	row, err := bigtable.tableMetadata.ReadRow(ctx, rowKey, storage.RowFilter(filter))
 
 	if err != nil || row == nil {
 		err = cache.TieredCache.SetString(cacheKey, "", time.Hour)
@@ -3196,23 +3032,23 @@ func (bigtable *Bigtable) GetAddressNames(addresses map[string]string) error {
 		}
 	}
 
-	filter := gcp_bigtable.ChainFilters(gcp_bigtable.FamilyFilter(ACCOUNT_METADATA_FAMILY), gcp_bigtable.ColumnFilter(ACCOUNT_COLUMN_NAME))
+	filter := storage.ChainFilters(storage.FamilyFilter(ACCOUNT_METADATA_FAMILY), storage.ColumnFilter(ACCOUNT_COLUMN_NAME))
 
 	keyPrefix := fmt.Sprintf("%s:", bigtable.chainId)
-	err := bigtable.tableMetadata.ReadRows(ctx, gcp_bigtable.RowList(keys), func(r gcp_bigtable.Row) bool {
+	err := bigtable.tableMetadata.ReadRows(ctx, storage.RowList(keys), func(r storage.Row) bool {
 		address := strings.TrimPrefix(r.Key(), keyPrefix)
 		addressBytes, _ := hex.DecodeString(address)
 		addresses[hexutil.Encode(addressBytes)] = string(r[ACCOUNT_METADATA_FAMILY][0].Value)
 
 		return true
-	}, gcp_bigtable.RowFilter(filter))
+	}, storage.RowFilter(filter))
 
 	return err
 }
 
 type isContractInfo struct {
 	update *types.IsContractUpdate
-	ts     gcp_bigtable.Timestamp
+	ts     storage.Timestamp
 }
 
 type ContractInteractionAtRequest struct {
@@ -3243,10 +3079,10 @@ func (bigtable *Bigtable) getAddressIsContractHistories(histories map[string][]i
 	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
 	defer cancel()
 
-	filter := gcp_bigtable.ChainFilters(gcp_bigtable.FamilyFilter(ACCOUNT_METADATA_FAMILY), gcp_bigtable.ColumnFilter(ACCOUNT_IS_CONTRACT))
+	filter := storage.ChainFilters(storage.FamilyFilter(ACCOUNT_METADATA_FAMILY), storage.ColumnFilter(ACCOUNT_IS_CONTRACT))
 
 	keyPrefix := fmt.Sprintf("%s:S:", bigtable.chainId)
-	err := bigtable.tableMetadata.ReadRows(ctx, gcp_bigtable.RowList(keys), func(row gcp_bigtable.Row) bool {
+	err := bigtable.tableMetadata.ReadRows(ctx, storage.RowList(keys), func(row storage.Row) bool {
 		// results are returned in reverse order, so highest ts is first
 		address := strings.TrimPrefix(row.Key(), keyPrefix)
 		for _, v := range row[ACCOUNT_METADATA_FAMILY] {
@@ -3259,7 +3095,7 @@ func (bigtable *Bigtable) getAddressIsContractHistories(histories map[string][]i
 		}
 
 		return true
-	}, gcp_bigtable.RowFilter(filter))
+	}, storage.RowFilter(filter))
 
 	if err != nil {
 		return fmt.Errorf("error reading isContract histories from bigtable: %w", err)
@@ -3438,8 +3274,8 @@ func (bigtable *Bigtable) SaveAddressName(address []byte, name string) error {
 	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
 	defer cancel()
 
-	mut := gcp_bigtable.NewMutation()
-	mut.Set(ACCOUNT_METADATA_FAMILY, ACCOUNT_COLUMN_NAME, gcp_bigtable.Timestamp(0), []byte(name))
+	mut := storage.NewMutation()
+	mut.Set(ACCOUNT_METADATA_FAMILY, ACCOUNT_COLUMN_NAME, storage.Timestamp(0), []byte(name))
 
 	return bigtable.tableMetadata.Apply(ctx, fmt.Sprintf("%s:%x", bigtable.chainId, address), mut)
 }
@@ -3466,7 +3302,7 @@ func (bigtable *Bigtable) GetContractMetadata(address []byte) (*types.ContractMe
 		return ret, err
 	}
 
-	row, err := bigtable.tableMetadata.ReadRow(ctx, rowKey, gcp_bigtable.RowFilter(gcp_bigtable.FamilyFilter(CONTRACT_METADATA_FAMILY)))
+	row, err := bigtable.tableMetadata.ReadRow(ctx, rowKey, storage.RowFilter(storage.FamilyFilter(CONTRACT_METADATA_FAMILY)))
 
 	ret := &types.ContractMetadata{}
 
@@ -3537,9 +3373,9 @@ func (bigtable *Bigtable) SaveContractMetadata(address []byte, metadata *types.C
 	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
 	defer cancel()
 
-	mut := gcp_bigtable.NewMutation()
-	mut.Set(CONTRACT_METADATA_FAMILY, CONTRACT_NAME, gcp_bigtable.Timestamp(0), []byte(metadata.Name))
-	mut.Set(CONTRACT_METADATA_FAMILY, CONTRACT_ABI, gcp_bigtable.Timestamp(0), metadata.ABIJson)
+	mut := storage.NewMutation()
+	mut.Set(CONTRACT_METADATA_FAMILY, CONTRACT_NAME, storage.Timestamp(0), []byte(metadata.Name))
+	mut.Set(CONTRACT_METADATA_FAMILY, CONTRACT_ABI, storage.Timestamp(0), metadata.ABIJson)
 
 	return bigtable.tableMetadata.Apply(ctx, fmt.Sprintf("%s:%x", bigtable.chainId, address), mut)
 }
@@ -3551,13 +3387,13 @@ func (bigtable *Bigtable) SaveBalances(balances []*types.Eth1AddressBalance, del
 
 	mutsWrite := &types.BulkMutations{
 		Keys: make([]string, 0, len(balances)),
-		Muts: make([]*gcp_bigtable.Mutation, 0, len(balances)),
+		Muts: make([]*storage.Mutation, 0, len(balances)),
 	}
 
 	for _, balance := range balances {
-		mutWrite := gcp_bigtable.NewMutation()
+		mutWrite := storage.NewMutation()
 
-		mutWrite.Set(ACCOUNT_METADATA_FAMILY, fmt.Sprintf("B:%x", balance.Token), gcp_bigtable.Timestamp(0), balance.Balance)
+		mutWrite.Set(ACCOUNT_METADATA_FAMILY, fmt.Sprintf("B:%x", balance.Token), storage.Timestamp(0), balance.Balance)
 		mutsWrite.Keys = append(mutsWrite.Keys, fmt.Sprintf("%s:%x", bigtable.chainId, balance.Address))
 		mutsWrite.Muts = append(mutsWrite.Muts, mutWrite)
 	}
@@ -3573,10 +3409,10 @@ func (bigtable *Bigtable) SaveBalances(balances []*types.Eth1AddressBalance, del
 	}
 	mutsDelete := &types.BulkMutations{
 		Keys: make([]string, 0, len(balances)),
-		Muts: make([]*gcp_bigtable.Mutation, 0, len(balances)),
+		Muts: make([]*storage.Mutation, 0, len(balances)),
 	}
 	for _, key := range deleteKeys {
-		mutDelete := gcp_bigtable.NewMutation()
+		mutDelete := storage.NewMutation()
 		mutDelete.DeleteRow()
 		mutsDelete.Keys = append(mutsDelete.Keys, key)
 		mutsDelete.Muts = append(mutsDelete.Muts, mutDelete)
@@ -3598,14 +3434,14 @@ func (bigtable *Bigtable) SaveERC20TokenPrices(prices []*types.ERC20TokenPrice)
 
 	mutsWrite := &types.BulkMutations{
 		Keys: make([]string, 0, len(prices)),
-		Muts: make([]*gcp_bigtable.Mutation, 0, len(prices)),
+		Muts: make([]*storage.Mutation, 0, len(prices)),
 	}
 
 	for _, price := range prices {
 		rowKey := fmt.Sprintf("%s:%x", bigtable.chainId, price.Token)
-		mut := gcp_bigtable.NewMutation()
-		mut.Set(ERC20_METADATA_FAMILY, ERC20_COLUMN_PRICE, gcp_bigtable.Timestamp(0), price.Price)
-		mut.Set(ERC20_METADATA_FAMILY, ERC20_COLUMN_TOTALSUPPLY, gcp_bigtable.Timestamp(0), price.TotalSupply)
+		mut := storage.NewMutation()
+		mut.Set(ERC20_METADATA_FAMILY, ERC20_COLUMN_PRICE, storage.Timestamp(0), price.Price)
+		mut.Set(ERC20_METADATA_FAMILY, ERC20_COLUMN_TOTALSUPPLY, storage.Timestamp(0), price.TotalSupply)
 		mutsWrite.Keys = append(mutsWrite.Keys, rowKey)
 		mutsWrite.Muts = append(mutsWrite.Muts, mut)
 	}
@@ -3623,8 +3459,8 @@ func (bigtable *Bigtable) SaveBlockKeys(blockNumber uint64, blockHash []byte, ke
 	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
 	defer cancel()
 
-	mut := gcp_bigtable.NewMutation()
-	mut.Set(METADATA_UPDATES_FAMILY_BLOCKS, "keys", gcp_bigtable.Now(), []byte(keys))
+	mut := storage.NewMutation()
+	mut.Set(METADATA_UPDATES_FAMILY_BLOCKS, "keys", storage.Now(), []byte(keys))
 
 	key := fmt.Sprintf("%s:BLOCK:%s:%x", bigtable.chainId, reversedPaddedBlockNumber(blockNumber), blockHash)
 	err := bigtable.tableMetadataUpdates.Apply(ctx, key, mut)
@@ -3673,15 +3509,15 @@ func (bigtable *Bigtable) DeleteBlock(blockNumber uint64, blockHash []byte) erro
 		return err
 	}
 
-	filter := gcp_bigtable.ChainFilters(
-		gcp_bigtable.FamilyFilter(ACCOUNT_METADATA_FAMILY),
-		gcp_bigtable.ColumnFilter(ACCOUNT_IS_CONTRACT),
-		gcp_bigtable.TimestampRangeFilterMicros(starttime, endtime-1),
+	filter := storage.ChainFilters(
+		storage.FamilyFilter(ACCOUNT_METADATA_FAMILY),
+		storage.ColumnFilter(ACCOUNT_IS_CONTRACT),
+		storage.TimestampRangeFilterMicros(starttime, endtime-1),
 	)
 
 	mutsDelete := &types.BulkMutations{
 		Keys: make([]string, 0),
-		Muts: make([]*gcp_bigtable.Mutation, 0),
+		Muts: make([]*storage.Mutation, 0),
 	}
 
 	tmr := time.AfterFunc(REPORT_TIMEOUT, func() {
@@ -3697,14 +3533,14 @@ func (bigtable *Bigtable) DeleteBlock(blockNumber uint64, blockHash []byte) erro
 	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(time.Second*30))
 	defer cancel()
 
-	err = bigtable.tableMetadata.ReadRows(ctx, gcp_bigtable.PrefixRange(fmt.Sprintf("%s:S:", bigtable.chainId)), func(row gcp_bigtable.Row) bool {
-		mutDelete := gcp_bigtable.NewMutation()
+	err = bigtable.tableMetadata.ReadRows(ctx, storage.PrefixRange(fmt.Sprintf("%s:S:", bigtable.chainId)), func(row storage.Row) bool {
+		mutDelete := storage.NewMutation()
 		mutDelete.DeleteTimestampRange(ACCOUNT_METADATA_FAMILY, ACCOUNT_IS_CONTRACT, starttime, endtime)
 
 		mutsDelete.Keys = append(mutsDelete.Keys, row.Key())
 		mutsDelete.Muts = append(mutsDelete.Muts, mutDelete)
 		return true
-	}, gcp_bigtable.RowFilter(filter))
+	}, storage.RowFilter(filter))
 	if err != nil {
 		return err
 	}
@@ -3725,10 +3561,10 @@ func (bigtable *Bigtable) DeleteBlock(blockNumber uint64, blockHash []byte) erro
 	// Delete all of those keys
 	mutsDelete = &types.BulkMutations{
 		Keys: make([]string, 0, len(keys)),
-		Muts: make([]*gcp_bigtable.Mutation, 0, len(keys)),
+		Muts: make([]*storage.Mutation, 0, len(keys)),
 	}
 	for _, key := range keys {
-		mutDelete := gcp_bigtable.NewMutation()
+		mutDelete := storage.NewMutation()
 		mutDelete.DeleteRow()
 		mutsDelete.Keys = append(mutsDelete.Keys, key)
 		mutsDelete.Muts = append(mutsDelete.Muts, mutDelete)
@@ -3741,9 +3577,9 @@ func (bigtable *Bigtable) DeleteBlock(blockNumber uint64, blockHash []byte) erro
 
 	mutsDelete = &types.BulkMutations{
 		Keys: make([]string, 0, len(keys)),
-		Muts: make([]*gcp_bigtable.Mutation, 0, len(keys)),
+		Muts: make([]*storage.Mutation, 0, len(keys)),
 	}
-	mutDelete := gcp_bigtable.NewMutation()
+	mutDelete := storage.NewMutation()
 	mutDelete.DeleteRow()
 	mutsDelete.Keys = append(mutsDelete.Keys, fmt.Sprintf("%s:%s", bigtable.chainId, reversedPaddedBlockNumber(blockNumber)))
 	mutsDelete.Muts = append(mutsDelete.Muts, mutDelete)
@@ -3770,17 +3606,17 @@ func (bigtable *Bigtable) GetEth1TxForToken(prefix string, limit int64) ([]*type
 	defer cancel()
 
 	// add \x00 to the row range such that we skip the previous value
-	rowRange := gcp_bigtable.NewRange(prefix+"\x00", prefixSuccessor(prefix, 5))
+	rowRange := storage.NewRange(prefix+"\x00", prefixSuccessor(prefix, 5))
 	data := make([]*types.Eth1ERC20Indexed, 0, limit)
 	keys := make([]string, 0, limit)
 	indexes := make([]string, 0, limit)
 	keysMap := make(map[string]*types.Eth1ERC20Indexed, limit)
 
-	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row gcp_bigtable.Row) bool {
+	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row storage.Row) bool {
 		keys = append(keys, strings.TrimPrefix(row[DEFAULT_FAMILY][0].Column, "f:"))
 		indexes = append(indexes, row.Key())
 		return true
-	}, gcp_bigtable.LimitRows(limit))
+	}, storage.LimitRows(limit))
 	if err != nil {
 		return nil, "", err
 	}
@@ -3789,7 +3625,7 @@ func (bigtable *Bigtable) GetEth1TxForToken(prefix string, limit int64) ([]*type
 		return data, "", nil
 	}
 
-	err = bigtable.tableData.ReadRows(ctx, gcp_bigtable.RowList(keys), func(row gcp_bigtable.Row) bool {
+	err = bigtable.tableData.ReadRows(ctx, storage.RowList(keys), func(row storage.Row) bool {
 		b := &types.Eth1ERC20Indexed{}
 		err := proto.Unmarshal(row[DEFAULT_FAMILY][0].Value, b)
 
@@ -3832,7 +3668,7 @@ func (bigtable *Bigtable) SearchForAddress(addressPrefix []byte, limit int) ([]*
 
 	prefix := fmt.Sprintf("%s:%x", bigtable.chainId, addressPrefix)
 
-	err := bigtable.tableMetadata.ReadRows(ctx, gcp_bigtable.PrefixRange(prefix), func(row gcp_bigtable.Row) bool {
+	err := bigtable.tableMetadata.ReadRows(ctx, storage.PrefixRange(prefix), func(row storage.Row) bool {
 		si := &types.Eth1AddressSearchItem{
 			Address: strings.TrimPrefix(row.Key(), bigtable.chainId+":"),
 			Name:    "",
@@ -3851,7 +3687,7 @@ func (bigtable *Bigtable) SearchForAddress(addressPrefix []byte, limit int) ([]*
 		}
 		data = append(data, si)
 		return true
-	}, gcp_bigtable.LimitRows(int64(limit)))
+	}, storage.LimitRows(int64(limit)))
 
 	if err != nil {
 		return nil, err
@@ -3904,7 +3740,7 @@ func (bigtable *Bigtable) GetSignatureImportStatus(st types.SignatureType) (*typ
 func (bigtable *Bigtable) SaveSignatureImportStatus(status types.SignatureImportStatus, st types.SignatureType) error {
 	mutsWrite := &types.BulkMutations{
 		Keys: make([]string, 0, 1),
-		Muts: make([]*gcp_bigtable.Mutation, 0, 1),
+		Muts: make([]*storage.Mutation, 0, 1),
 	}
 
 	s, err := json.Marshal(status)
@@ -3912,8 +3748,8 @@ func (bigtable *Bigtable) SaveSignatureImportStatus(status types.SignatureImport
 		return err
 	}
 
-	mut := gcp_bigtable.NewMutation()
-	mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), s)
+	mut := storage.NewMutation()
+	mut.Set(DEFAULT_FAMILY, DATA_COLUMN, storage.Timestamp(0), s)
 
 	key := fmt.Sprintf("1:%v_SIGNATURE_IMPORT_STATUS", getSignaturePrefix(st))
 
@@ -3933,12 +3769,12 @@ func (bigtable *Bigtable) SaveSignatureImportStatus(status types.SignatureImport
 func (bigtable *Bigtable) SaveSignatures(signatures []types.Signature, st types.SignatureType) error {
 	mutsWrite := &types.BulkMutations{
 		Keys: make([]string, 0, 1),
-		Muts: make([]*gcp_bigtable.Mutation, 0, 1),
+		Muts: make([]*storage.Mutation, 0, 1),
 	}
 
 	for _, sig := range signatures {
-		mut := gcp_bigtable.NewMutation()
-		mut.Set(DEFAULT_FAMILY, DATA_COLUMN, gcp_bigtable.Timestamp(0), []byte(sig.Text))
+		mut := storage.NewMutation()
+		mut.Set(DEFAULT_FAMILY, DATA_COLUMN, storage.Timestamp(0), []byte(sig.Text))
 
 		key := fmt.Sprintf("1:%v_SIGNATURE:%v", getSignaturePrefix(st), sig.Hex)
 
@@ -4073,8 +3909,8 @@ func (bigtable *Bigtable) markBalanceUpdate(address []byte, token []byte, mutati
 	balanceUpdateKey := fmt.Sprintf("%s:B:%x", bigtable.chainId, address)                        // format is B: for balance update as chainid:prefix:address (token id will be encoded as column name)
 	balanceUpdateCacheKey := []byte(fmt.Sprintf("%s:B:%x:%x", bigtable.chainId, address, token)) // format is B: for balance update as chainid:prefix:address (token id will be encoded as column name)
 	if _, err := cache.Get(balanceUpdateCacheKey); err != nil {
-		mut := gcp_bigtable.NewMutation()
-		mut.Set(DEFAULT_FAMILY, fmt.Sprintf("%x", token), gcp_bigtable.Timestamp(0), []byte{})
+		mut := storage.NewMutation()
+		mut.Set(DEFAULT_FAMILY, fmt.Sprintf("%x", token), storage.Timestamp(0), []byte{})
 
 		mutations.Keys = append(mutations.Keys, balanceUpdateKey)
 		mutations.Muts = append(mutations.Muts, mut)
@@ -4097,9 +3933,9 @@ func (bigtable *Bigtable) SaveGasNowHistory(slow, standard, rapid, fast *big.Int
 	ts := time.Now().Truncate(time.Minute)
 	row := fmt.Sprintf("%s:GASNOW:%s", bigtable.chainId, reversePaddedBigtableTimestamp(timestamppb.New(ts)))
 
-	gcpTs := gcp_bigtable.Time(ts)
+	gcpTs := storage.Time(ts)
 
-	mut := gcp_bigtable.NewMutation()
+	mut := storage.NewMutation()
 	mut.Set(SERIES_FAMILY, GASNOW_SLOW_COLUMN, gcpTs, slow.Bytes())
 	mut.Set(SERIES_FAMILY, GASNOW_STANDARD_COLUMN, gcpTs, standard.Bytes())
 	mut.Set(SERIES_FAMILY, GASNOW_FAST_COLUMN, gcpTs, fast.Bytes())
@@ -4129,13 +3965,13 @@ func (bigtable *Bigtable) GetGasNowHistory(ts, pastTs time.Time) ([]types.GasNow
 	start := fmt.Sprintf("%s:GASNOW:%s", bigtable.chainId, reversePaddedBigtableTimestamp(timestamppb.New(ts)))
 	end := fmt.Sprintf("%s:GASNOW:%s", bigtable.chainId, reversePaddedBigtableTimestamp(timestamppb.New(pastTs)))
 
-	rowRange := gcp_bigtable.NewRange(start, end)
-	famFilter := gcp_bigtable.FamilyFilter(SERIES_FAMILY)
-	filter := gcp_bigtable.RowFilter(famFilter)
+	rowRange := storage.NewRange(start, end)
+	famFilter := storage.FamilyFilter(SERIES_FAMILY)
+	filter := storage.RowFilter(famFilter)
 
 	history := make([]types.GasNowHistory, 0)
 
-	scanner := func(row gcp_bigtable.Row) bool {
+	scanner := func(row storage.Row) bool {
 		if len(row[SERIES_FAMILY]) < 4 {
 			log.Error(fmt.Errorf("error reading row: %+v", row), "", 0)
 			return false
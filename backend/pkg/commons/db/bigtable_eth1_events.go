@@ -0,0 +1,228 @@
+package db
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/erc1155"
+	"github.com/gobitfly/beaconchain/pkg/commons/erc20"
+	"github.com/gobitfly/beaconchain/pkg/commons/erc721"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+)
+
+// Eth1BlockEvents contains the indexed transactions and token transfers of a single execution block,
+// exactly as they are written to bigtable by the TransformTx and TransformERC20/721/1155 transformers
+type Eth1BlockEvents struct {
+	Transactions     []*types.Eth1TransactionIndexed
+	ERC20Transfers   []*types.Eth1ERC20Indexed
+	ERC721Transfers  []*types.Eth1ERC721Indexed
+	ERC1155Transfers []*types.ETh1ERC1155Indexed
+}
+
+// GetEth1BlockEvents extracts the indexed transactions and token transfers of a block
+func GetEth1BlockEvents(blk *types.Eth1Block) (*Eth1BlockEvents, error) {
+	erc20Filterer, err := erc20.NewErc20Filterer(common.Address{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating erc20 filterer: %w", err)
+	}
+	erc721Filterer, err := erc721.NewErc721Filterer(common.Address{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating erc721 filterer: %w", err)
+	}
+	erc1155Filterer, err := erc1155.NewErc1155Filterer(common.Address{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating erc1155 filterer: %w", err)
+	}
+
+	events := &Eth1BlockEvents{}
+	for i, tx := range blk.GetTransactions() {
+		if i >= TX_PER_BLOCK_LIMIT {
+			return nil, fmt.Errorf("unexpected number of transactions in block expected at most %d but got: %v, tx: %x", TX_PER_BLOCK_LIMIT-1, i, tx.GetHash())
+		}
+		events.Transactions = append(events.Transactions, newIndexedTransaction(blk, tx))
+
+		for j, txLog := range tx.GetLogs() {
+			if j >= ITX_PER_TX_LIMIT {
+				return nil, fmt.Errorf("unexpected number of logs in block expected at most %d but got: %v tx: %x", ITX_PER_TX_LIMIT-1, j, tx.GetHash())
+			}
+			if transfer := parseERC20Transfer(erc20Filterer, blk, i, tx, j, txLog); transfer != nil {
+				events.ERC20Transfers = append(events.ERC20Transfers, transfer)
+			}
+			if transfer := parseERC721Transfer(erc721Filterer, blk, i, tx, j, txLog); transfer != nil {
+				events.ERC721Transfers = append(events.ERC721Transfers, transfer)
+			}
+			if transfer := parseERC1155Transfer(erc1155Filterer, blk, i, tx, j, txLog); transfer != nil {
+				events.ERC1155Transfers = append(events.ERC1155Transfers, transfer)
+			}
+		}
+	}
+	return events, nil
+}
+
+// newIndexedTransaction creates the indexed representation of a transaction of the block
+func newIndexedTransaction(blk *types.Eth1Block, tx *types.Eth1Transaction) *types.Eth1TransactionIndexed {
+	to := tx.GetTo()
+	isContract := false
+	if !bytes.Equal(tx.GetContractAddress(), ZERO_ADDRESS) {
+		to = tx.GetContractAddress()
+		isContract = true
+	}
+	method := make([]byte, 0)
+	if len(tx.GetData()) > 3 {
+		method = tx.GetData()[:4]
+	}
+
+	fee := new(big.Int).Mul(new(big.Int).SetBytes(tx.GetGasPrice()), big.NewInt(int64(tx.GetGasUsed()))).Bytes()
+	blobFee := new(big.Int).Mul(new(big.Int).SetBytes(tx.GetBlobGasPrice()), big.NewInt(int64(tx.GetBlobGasUsed()))).Bytes()
+	return &types.Eth1TransactionIndexed{
+		Hash:               tx.GetHash(),
+		BlockNumber:        blk.GetNumber(),
+		Time:               blk.GetTime(),
+		MethodId:           method,
+		From:               tx.GetFrom(),
+		To:                 to,
+		Value:              tx.GetValue(),
+		TxFee:              fee,
+		GasPrice:           tx.GetGasPrice(),
+		BlobTxFee:          blobFee,
+		BlobGasPrice:       tx.GetBlobGasPrice(),
+		IsContractCreation: isContract,
+		ErrorMsg:           tx.GetErrorMsg(),
+	}
+}
+
+// toGethLog converts log j of tx i of the block into a go-ethereum log that can be parsed by the contract filterers
+func toGethLog(blk *types.Eth1Block, i int, tx *types.Eth1Transaction, j int, txLog *types.Eth1Log) gethtypes.Log {
+	topics := make([]common.Hash, 0, len(txLog.GetTopics()))
+	for _, lTopic := range txLog.GetTopics() {
+		topics = append(topics, common.BytesToHash(lTopic))
+	}
+
+	return gethtypes.Log{
+		Address:     common.BytesToAddress(txLog.GetAddress()),
+		Data:        txLog.Data,
+		Topics:      topics,
+		BlockNumber: blk.GetNumber(),
+		TxHash:      common.BytesToHash(tx.GetHash()),
+		TxIndex:     uint(i),
+		BlockHash:   common.BytesToHash(blk.GetHash()),
+		Index:       uint(j),
+		Removed:     txLog.GetRemoved(),
+	}
+}
+
+// parseERC20Transfer returns the indexed erc20 transfer contained in the log or nil if the log is not an erc20 transfer
+func parseERC20Transfer(filterer *erc20.Erc20Filterer, blk *types.Eth1Block, i int, tx *types.Eth1Transaction, j int, txLog *types.Eth1Log) *types.Eth1ERC20Indexed {
+	if len(txLog.GetTopics()) != 3 || !bytes.Equal(txLog.GetTopics()[0], erc20.TransferTopic) {
+		return nil
+	}
+
+	transfer, _ := filterer.ParseTransfer(toGethLog(blk, i, tx, j, txLog))
+	if transfer == nil {
+		return nil
+	}
+
+	value := []byte{}
+	if transfer.Value != nil {
+		value = transfer.Value.Bytes()
+	}
+
+	return &types.Eth1ERC20Indexed{
+		ParentHash:   tx.GetHash(),
+		BlockNumber:  blk.GetNumber(),
+		Time:         blk.GetTime(),
+		TokenAddress: txLog.Address,
+		From:         transfer.From.Bytes(),
+		To:           transfer.To.Bytes(),
+		Value:        value,
+	}
+}
+
+// parseERC721Transfer returns the indexed erc721 transfer contained in the log or nil if the log is not an erc721 transfer
+func parseERC721Transfer(filterer *erc721.Erc721Filterer, blk *types.Eth1Block, i int, tx *types.Eth1Transaction, j int, txLog *types.Eth1Log) *types.Eth1ERC721Indexed {
+	if len(txLog.GetTopics()) != 4 || !bytes.Equal(txLog.GetTopics()[0], erc721.TransferTopic) {
+		return nil
+	}
+
+	transfer, _ := filterer.ParseTransfer(toGethLog(blk, i, tx, j, txLog))
+	if transfer == nil {
+		return nil
+	}
+
+	tokenId := new(big.Int)
+	if transfer.TokenId != nil {
+		tokenId = transfer.TokenId
+	}
+
+	return &types.Eth1ERC721Indexed{
+		ParentHash:   tx.GetHash(),
+		BlockNumber:  blk.GetNumber(),
+		Time:         blk.GetTime(),
+		TokenAddress: txLog.Address,
+		From:         transfer.From.Bytes(),
+		To:           transfer.To.Bytes(),
+		TokenId:      tokenId.Bytes(),
+	}
+}
+
+// parseERC1155Transfer returns the indexed erc1155 transfer contained in the log or nil if the log is not an erc1155 transfer.
+// For batch transfers only the last id of the batch is kept as the log is stored as a single row
+func parseERC1155Transfer(filterer *erc1155.Erc1155Filterer, blk *types.Eth1Block, i int, tx *types.Eth1Transaction, j int, txLog *types.Eth1Log) *types.ETh1ERC1155Indexed {
+	// no events emitted continue
+	if len(txLog.GetTopics()) != 4 || (!bytes.Equal(txLog.GetTopics()[0], erc1155.TransferBulkTopic) && !bytes.Equal(txLog.GetTopics()[0], erc1155.TransferSingleTopic)) {
+		return nil
+	}
+
+	ethLog := toGethLog(blk, i, tx, j, txLog)
+	indexedLog := &types.ETh1ERC1155Indexed{}
+	transferBatch, _ := filterer.ParseTransferBatch(ethLog)
+	transferSingle, _ := filterer.ParseTransferSingle(ethLog)
+	if transferBatch == nil && transferSingle == nil {
+		return nil
+	}
+
+	// && len(transferBatch.Operator) == 20 && len(transferBatch.From) == 20 && len(transferBatch.To) == 20 && len(transferBatch.Ids) > 0 && len(transferBatch.Values) > 0
+	if transferBatch != nil {
+		ids := make([][]byte, 0, len(transferBatch.Ids))
+		for _, id := range transferBatch.Ids {
+			ids = append(ids, id.Bytes())
+		}
+
+		values := make([][]byte, 0, len(transferBatch.Values))
+		for _, val := range transferBatch.Values {
+			values = append(values, val.Bytes())
+		}
+
+		if len(ids) != len(values) {
+			log.Error(fmt.Errorf("error parsing erc1155 batch transfer logs. Expected len(ids): %v len(values): %v to be the same", len(ids), len(values)), "", 0)
+			return nil
+		}
+		for ti := range ids {
+			indexedLog.BlockNumber = blk.GetNumber()
+			indexedLog.Time = blk.GetTime()
+			indexedLog.ParentHash = tx.GetHash()
+			indexedLog.From = transferBatch.From.Bytes()
+			indexedLog.To = transferBatch.To.Bytes()
+			indexedLog.Operator = transferBatch.Operator.Bytes()
+			indexedLog.TokenId = ids[ti]
+			indexedLog.Value = values[ti]
+			indexedLog.TokenAddress = txLog.GetAddress()
+		}
+	} else if transferSingle != nil {
+		indexedLog.BlockNumber = blk.GetNumber()
+		indexedLog.Time = blk.GetTime()
+		indexedLog.ParentHash = tx.GetHash()
+		indexedLog.From = transferSingle.From.Bytes()
+		indexedLog.To = transferSingle.To.Bytes()
+		indexedLog.Operator = transferSingle.Operator.Bytes()
+		indexedLog.TokenId = transferSingle.Id.Bytes()
+		indexedLog.Value = transferSingle.Value.Bytes()
+		indexedLog.TokenAddress = txLog.GetAddress()
+	}
+	return indexedLog
+}
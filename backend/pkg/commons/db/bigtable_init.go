@@ -5,18 +5,38 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/gobitfly/beaconchain/pkg/commons/storage"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
-
-	gcp_bigtable "cloud.google.com/go/bigtable"
 )
 
 func InitBigtableSchema() error {
-	tables := make(map[string]map[string]gcp_bigtable.GCPolicy)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	store, err := newStorageStore(ctx, utils.Config.Bigtable.Project, utils.Config.Bigtable.Instance)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	existingTables, err := store.Tables(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(existingTables) > 0 {
+		return fmt.Errorf("aborting bigtable schema init as tables are already present")
+	}
+
+	return createBigtableSchema(ctx, store)
+}
 
-	tables["beaconchain_validators"] = map[string]gcp_bigtable.GCPolicy{
-		ATTESTATIONS_FAMILY: gcp_bigtable.MaxVersionsGCPolicy(1),
+func createBigtableSchema(ctx context.Context, store storage.Store) error {
+	tables := make(map[string]map[string]storage.GCPolicy)
+	tables["beaconchain_validators"] = map[string]storage.GCPolicy{
+		ATTESTATIONS_FAMILY: storage.MaxVersionsGCPolicy(1),
 	}
-	tables["beaconchain_validators_history"] = map[string]gcp_bigtable.GCPolicy{
+	tables["beaconchain_validators_history"] = map[string]storage.GCPolicy{
 		VALIDATOR_BALANCES_FAMILY:             nil,
 		VALIDATOR_HIGHEST_ACTIVE_INDEX_FAMILY: nil,
 		ATTESTATIONS_FAMILY:                   nil,
@@ -25,64 +45,34 @@ func InitBigtableSchema() error {
 		INCOME_DETAILS_COLUMN_FAMILY:          nil,
 		STATS_COLUMN_FAMILY:                   nil,
 	}
-	tables["blocks"] = map[string]gcp_bigtable.GCPolicy{
-		DEFAULT_FAMILY_BLOCKS: gcp_bigtable.MaxVersionsGCPolicy(1),
+	tables["blocks"] = map[string]storage.GCPolicy{
+		DEFAULT_FAMILY_BLOCKS: storage.MaxVersionsGCPolicy(1),
 	}
-	tables["data"] = map[string]gcp_bigtable.GCPolicy{
-		CONTRACT_METADATA_FAMILY: gcp_bigtable.MaxAgeGCPolicy(utils.Day),
+	tables["data"] = map[string]storage.GCPolicy{
+		CONTRACT_METADATA_FAMILY: storage.MaxAgeGCPolicy(utils.Day),
 		DEFAULT_FAMILY:           nil,
 	}
-	tables["machine_metrics"] = map[string]gcp_bigtable.GCPolicy{
-		MACHINE_METRICS_COLUMN_FAMILY: gcp_bigtable.MaxAgeGCPolicy(utils.Day * 31),
+	tables["machine_metrics"] = map[string]storage.GCPolicy{
+		MACHINE_METRICS_COLUMN_FAMILY: storage.MaxAgeGCPolicy(utils.Day * 31),
 	}
-	tables["metadata"] = map[string]gcp_bigtable.GCPolicy{
+	tables["metadata"] = map[string]storage.GCPolicy{
 		ACCOUNT_METADATA_FAMILY:  nil,
 		CONTRACT_METADATA_FAMILY: nil,
 		ERC20_METADATA_FAMILY:    nil,
 		ERC721_METADATA_FAMILY:   nil,
 		ERC1155_METADATA_FAMILY:  nil,
-		SERIES_FAMILY:            gcp_bigtable.MaxVersionsGCPolicy(1),
+		SERIES_FAMILY:            storage.MaxVersionsGCPolicy(1),
 	}
-	tables["metadata_updates"] = map[string]gcp_bigtable.GCPolicy{
-		METADATA_UPDATES_FAMILY_BLOCKS: gcp_bigtable.MaxAgeGCPolicy(utils.Day),
+	tables["metadata_updates"] = map[string]storage.GCPolicy{
+		METADATA_UPDATES_FAMILY_BLOCKS: storage.MaxAgeGCPolicy(utils.Day),
 		DEFAULT_FAMILY:                 nil,
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
-	defer cancel()
 
-	admin, err := gcp_bigtable.NewAdminClient(ctx, utils.Config.Bigtable.Project, utils.Config.Bigtable.Instance)
-	if err != nil {
-		return err
-	}
-
-	existingTables, err := admin.Tables(ctx)
-	if err != nil {
-		return err
-	}
-
-	if len(existingTables) > 0 {
-		return fmt.Errorf("aborting bigtable schema init as tables are already present")
-	}
-
-	for name, definition := range tables {
-		err := admin.CreateTable(ctx, name)
+	for name, families := range tables {
+		err := store.CreateTable(ctx, name, families)
 		if err != nil {
 			return err
 		}
-
-		for columnFamily, gcPolicy := range definition {
-			err := admin.CreateColumnFamily(ctx, name, columnFamily)
-			if err != nil {
-				return err
-			}
-
-			if gcPolicy != nil {
-				err := admin.SetGCPolicy(ctx, name, columnFamily, gcPolicy)
-				if err != nil {
-					return err
-				}
-			}
-		}
 	}
 
 	return nil
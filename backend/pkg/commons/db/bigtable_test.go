@@ -0,0 +1,182 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/storage"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+// newTestBigtable returns a Bigtable backed by an embedded store in a temporary directory
+func newTestBigtable(t *testing.T) *Bigtable {
+	config := &types.Config{}
+	config.Chain.ClConfig.SlotsPerEpoch = 32
+	config.Chain.ClConfig.SyncCommitteeSize = 512
+	utils.Config = config
+
+	store, err := storage.NewPebbleStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() {
+		store.Close()
+	})
+	err = createBigtableSchema(context.Background(), store)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return newBigtable(store, "1", nil)
+}
+
+func TestBigtableValidatorBalances(t *testing.T) {
+	bt := newTestBigtable(t)
+	for epoch := uint64(10); epoch < 13; epoch++ {
+		err := bt.SaveValidatorBalances(epoch, []*types.Validator{
+			{Index: 1, Balance: 32e9 + epoch, EffectiveBalance: 32e9},
+			{Index: 2, Balance: 31e9 + epoch, EffectiveBalance: 31e9},
+			{Index: 3},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	balances, err := bt.getValidatorBalanceHistoryV2([]uint64{1, 2}, 11, 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(balances) != 2 || len(balances[1]) != 2 || len(balances[2]) != 2 {
+		t.Fatalf("expected two epochs for two validators, got %v", balances)
+	}
+	// epochs are stored reversed, so the newest one comes first
+	b := balances[2][0]
+	if b.Epoch != 12 || b.Balance != 31e9+12 || b.EffectiveBalance != 31e9 || b.Index != 2 {
+		t.Errorf("unexpected balance %+v", b)
+	}
+	if balances[1][1].Epoch != 11 || balances[1][1].Balance != 32e9+11 {
+		t.Errorf("unexpected balance %+v", balances[1][1])
+	}
+
+	maxIndex, err := bt.getMaxValidatorindexForEpochV2(11)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if maxIndex != 2 {
+		t.Errorf("expected max validator index 2, got %d", maxIndex)
+	}
+	maxIndex, err = bt.getMaxValidatorindexForEpochV2(20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if maxIndex != 0 {
+		t.Errorf("expected max validator index 0 for a missing epoch, got %d", maxIndex)
+	}
+}
+
+func TestBigtableProposals(t *testing.T) {
+	bt := newTestBigtable(t)
+	err := bt.SaveProposalAssignments(1, map[uint64]uint64{33: 5, 34: 6})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = bt.SaveProposal(&types.Block{Slot: 33, Proposer: 5, BlockRoot: make([]byte, 32)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	proposals, err := bt.getValidatorProposalHistoryV2([]uint64{5, 6, 7}, 0, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(proposals) != 2 || len(proposals[5]) != 1 || len(proposals[6]) != 1 {
+		t.Fatalf("expected one proposal for two validators, got %v", proposals)
+	}
+	if p := proposals[5][0]; p.Slot != 33 || p.Status != 1 {
+		t.Errorf("expected proposed slot 33, got %+v", p)
+	}
+	if p := proposals[6][0]; p.Slot != 34 || p.Status != 2 {
+		t.Errorf("expected missed slot 34, got %+v", p)
+	}
+
+	// the proposal of an orphaned block turns back into a missed slot
+	err = bt.DeleteProposal(33, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	proposals, err = bt.getValidatorProposalHistoryV2([]uint64{5}, 0, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(proposals[5]) != 1 || proposals[5][0].Status != 2 {
+		t.Errorf("expected missed slot 33 after deleting the proposal, got %v", proposals[5])
+	}
+}
+
+func TestBigtableSyncDuties(t *testing.T) {
+	bt := newTestBigtable(t)
+	err := bt.SaveSyncComitteeDuties(map[types.Slot]map[types.ValidatorIndex]bool{
+		64: {1: true, 2: false},
+		65: {1: false, 2: true},
+		96: {1: true, 2: true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	duties, err := bt.getValidatorSyncDutiesHistoryV2([]uint64{1, 2}, 64, 65)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[uint64]map[uint64]uint64{
+		1: {64: 1, 65: 0},
+		2: {64: 0, 65: 1},
+	}
+	for validator, slots := range want {
+		if len(duties[validator]) != len(slots) {
+			t.Errorf("validator %d: expected %d duties, got %d", validator, len(slots), len(duties[validator]))
+			continue
+		}
+		for slot, status := range slots {
+			if d := duties[validator][slot]; d == nil || d.Status != status {
+				t.Errorf("validator %d slot %d: expected status %d, got %+v", validator, slot, status, d)
+			}
+		}
+	}
+}
+
+func TestBigtableMachineMetricSeries(t *testing.T) {
+	bt := newTestBigtable(t)
+	now := time.Now().Truncate(time.Minute)
+	err := bt.SaveMachineMetricSeries(1, "node", map[string][]types.MachineMetricSeriesPoint{
+		`libp2p_peers{job="lighthouse"}`: {
+			{Timestamp: now.Add(-2 * time.Minute).Unix(), Value: 10},
+			// points of the same minute replace each other
+			{Timestamp: now.Add(-time.Minute).Unix(), Value: 11},
+			{Timestamp: now.Add(-time.Minute).Unix() + 30, Value: 12},
+		},
+		"libp2p_peers_total": {{Timestamp: now.Unix(), Value: 1}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	series, err := bt.GetMachineMetricSeries(1, "node", "libp2p_peers", now.Add(-90*time.Second))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	points := series[`libp2p_peers{job="lighthouse"}`]
+	if len(series) != 1 || len(points) != 1 || points[0].Value != 12 || points[0].Timestamp != now.Add(-time.Minute).Unix() {
+		t.Errorf("unexpected series %v", series)
+	}
+
+	series, err = bt.GetMachineMetricSeries(2, "node", "libp2p_peers", time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(series) != 0 {
+		t.Errorf("expected no series of another user, got %v", series)
+	}
+}
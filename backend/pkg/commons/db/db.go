@@ -591,6 +591,65 @@ func GetAllNonFinalizedSlots() ([]*GetAllNonFinalizedSlotsRow, error) {
 	return slots, nil
 }
 
+// GetNonFinalizedSlotsSince returns all blocks of the non finalized slots starting at slot, including the orphaned ones
+func GetNonFinalizedSlotsSince(slot uint64, tx *sqlx.Tx) ([]*GetAllNonFinalizedSlotsRow, error) {
+	var slots []*GetAllNonFinalizedSlotsRow
+	err := tx.Select(&slots, "SELECT slot, blockroot, finalized, status FROM blocks WHERE slot >= $1 AND NOT finalized ORDER BY slot", slot)
+
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving non finalized slots since slot %v from the DB: %w", slot, err)
+	}
+
+	return slots, nil
+}
+
+// SetBlockOrphaned marks a single block of a slot as orphaned and returns its proposer
+func SetBlockOrphaned(slot uint64, blockRoot []byte, tx *sqlx.Tx) (uint64, error) {
+	var proposer uint64
+	err := tx.Get(&proposer, "UPDATE blocks SET status = '3' WHERE slot = $1 AND blockroot = $2 RETURNING proposer", slot, blockRoot)
+
+	if err != nil {
+		return 0, fmt.Errorf("error setting block %x of slot %v as orphaned: %w", blockRoot, slot, err)
+	}
+
+	return proposer, nil
+}
+
+// SetBlockCanonical marks a single orphaned block of a slot as proposed again
+func SetBlockCanonical(slot uint64, blockRoot []byte, tx *sqlx.Tx) error {
+	_, err := tx.Exec("UPDATE blocks SET status = '1' WHERE slot = $1 AND blockroot = $2", slot, blockRoot)
+
+	if err != nil {
+		return fmt.Errorf("error setting block %x of slot %v as canonical: %w", blockRoot, slot, err)
+	}
+
+	return nil
+}
+
+// HasCanonicalProposal returns whether the proposer has a canonical block in the slot
+func HasCanonicalProposal(slot, proposer uint64) (bool, error) {
+	var exists bool
+	err := WriterDb.Get(&exists, "SELECT EXISTS (SELECT 1 FROM blocks WHERE slot = $1 AND proposer = $2 AND status = '1')", slot, proposer)
+
+	if err != nil {
+		return false, fmt.Errorf("error checking for a canonical proposal of slot %v: %w", slot, err)
+	}
+
+	return exists, nil
+}
+
+// GetLatestProposedSlot returns the slot of the latest canonical block
+func GetLatestProposedSlot() (uint64, error) {
+	var slot uint64
+	err := WriterDb.Get(&slot, "SELECT COALESCE(MAX(slot), 0) FROM blocks WHERE status = '1'")
+
+	if err != nil {
+		return 0, fmt.Errorf("error retrieving latest proposed slot from the DB: %w", err)
+	}
+
+	return slot, nil
+}
+
 // Get latest finalized epoch
 func GetLatestFinalizedEpoch() (uint64, error) {
 	var latestFinalized uint64
@@ -1401,6 +1460,130 @@ func GetEpochWithdrawals(epoch uint64) ([]*types.WithdrawalsNotification, error)
 	return withdrawals, nil
 }
 
+// SavePoolOperationsSeen stores operations that are currently in the op pool of the node, operations that have been
+// dropped before become pending again
+func SavePoolOperationsSeen(operations []*types.PoolOperation, slot uint64, ts time.Time) error {
+	if len(operations) == 0 {
+		return nil
+	}
+	tx, err := WriterDb.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting db transaction: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	for _, op := range operations {
+		_, err = tx.Exec(`
+			INSERT INTO pool_operations (type, validatorindex, signature, exit_epoch, to_execution_address, first_seen_ts, first_seen_slot, last_seen_slot)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+			ON CONFLICT (type, validatorindex, signature) DO UPDATE SET
+				last_seen_slot = GREATEST(pool_operations.last_seen_slot, excluded.last_seen_slot),
+				dropped_slot = NULL
+			WHERE pool_operations.included_slot IS NULL`,
+			op.Type, op.ValidatorIndex, op.Signature, op.ExitEpoch, op.ToExecutionAddress, ts, slot)
+		if err != nil {
+			return fmt.Errorf("error saving %v pool operation of validator %v: %w", op.Type, op.ValidatorIndex, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// SetPoolOperationsIncluded marks operations as included in the block of slot. Operations that haven't been seen in the
+// pool are inserted as first seen in that block, other pending operations of the same type and validator can't be
+// included anymore and are dropped.
+func SetPoolOperationsIncluded(operations []*types.PoolOperation, slot uint64) error {
+	if len(operations) == 0 {
+		return nil
+	}
+	tx, err := WriterDb.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting db transaction: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	for _, op := range operations {
+		_, err = tx.Exec(`
+			INSERT INTO pool_operations (type, validatorindex, signature, exit_epoch, to_execution_address, first_seen_ts, first_seen_slot, last_seen_slot, included_slot)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $7, $7)
+			ON CONFLICT (type, validatorindex, signature) DO UPDATE SET
+				included_slot = excluded.included_slot,
+				dropped_slot = NULL`,
+			op.Type, op.ValidatorIndex, op.Signature, op.ExitEpoch, op.ToExecutionAddress, utils.SlotToTime(slot), slot)
+		if err != nil {
+			return fmt.Errorf("error saving included %v pool operation of validator %v: %w", op.Type, op.ValidatorIndex, err)
+		}
+		_, err = tx.Exec(`
+			UPDATE pool_operations SET dropped_slot = $4
+			WHERE type = $1 AND validatorindex = $2 AND signature != $3 AND included_slot IS NULL AND dropped_slot IS NULL`,
+			op.Type, op.ValidatorIndex, op.Signature, slot)
+		if err != nil {
+			return fmt.Errorf("error dropping superseded %v pool operations of validator %v: %w", op.Type, op.ValidatorIndex, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// SetPoolOperationsDropped marks pending operations that have last been seen in the pool at or after fromLastSeenSlot
+// and before toLastSeenSlot as dropped
+func SetPoolOperationsDropped(fromLastSeenSlot, toLastSeenSlot, slot uint64) error {
+	_, err := WriterDb.Exec(`
+		UPDATE pool_operations SET dropped_slot = $3
+		WHERE included_slot IS NULL AND dropped_slot IS NULL AND last_seen_slot >= $1 AND last_seen_slot < $2`, fromLastSeenSlot, toLastSeenSlot, slot)
+	if err != nil {
+		return fmt.Errorf("error dropping pool operations last seen before slot %v: %w", toLastSeenSlot, err)
+	}
+	return nil
+}
+
+// GetOldestPendingPoolOperationSlot returns the slot the longest pending operation has last been seen in the pool, ok is false if none is pending
+func GetOldestPendingPoolOperationSlot() (slot uint64, ok bool, err error) {
+	var lastSeenSlot sql.NullInt64
+	err = WriterDb.Get(&lastSeenSlot, `SELECT MIN(last_seen_slot) FROM pool_operations WHERE included_slot IS NULL AND dropped_slot IS NULL`)
+	if err != nil {
+		return 0, false, fmt.Errorf("error retrieving the oldest pending pool operation: %w", err)
+	}
+	return uint64(lastSeenSlot.Int64), lastSeenSlot.Valid, nil
+}
+
+// ResetPoolOperationsIncluded reverts the inclusions and drops at or after slot, e.g. because the blocks got orphaned
+func ResetPoolOperationsIncluded(slot uint64) error {
+	_, err := WriterDb.Exec(`
+		UPDATE pool_operations SET
+			included_slot = CASE WHEN included_slot >= $1 THEN NULL ELSE included_slot END,
+			dropped_slot = CASE WHEN dropped_slot >= $1 THEN NULL ELSE dropped_slot END
+		WHERE included_slot >= $1 OR dropped_slot >= $1`, slot)
+	if err != nil {
+		return fmt.Errorf("error resetting pool operations included after slot %v: %w", slot, err)
+	}
+	return nil
+}
+
+// GetPoolOperationsFirstSeenSince returns the operations that have been seen for the first time at or after slot
+func GetPoolOperationsFirstSeenSince(slot uint64) ([]*types.PoolOperation, error) {
+	var operations []*types.PoolOperation
+	err := ReaderDb.Select(&operations, `
+		SELECT
+			po.type,
+			po.validatorindex,
+			po.signature,
+			po.exit_epoch,
+			po.to_execution_address,
+			po.first_seen_ts,
+			po.first_seen_slot,
+			po.last_seen_slot,
+			po.included_slot,
+			po.dropped_slot,
+			v.pubkey
+		FROM pool_operations po
+		INNER JOIN validators v ON v.validatorindex = po.validatorindex
+		WHERE po.first_seen_slot >= $1
+		ORDER BY po.first_seen_slot`, slot)
+	if err != nil {
+		return nil, fmt.Errorf("error getting pool operations first seen since slot %v: %w", slot, err)
+	}
+	return operations, nil
+}
+
 func GetValidatorWithdrawals(validator uint64, limit uint64, offset uint64, orderBy string, orderDir string) ([]*types.Withdrawals, error) {
 	var withdrawals []*types.Withdrawals
 	if limit == 0 {
@@ -2034,7 +2217,8 @@ func GetWithdrawableValidatorCount(epoch uint64) (uint64, error) {
         WHERE DAY = (SELECT COALESCE(MAX(day), 0) FROM validator_stats_status)) as stats
 	ON stats.validatorindex = validators.validatorindex
 	WHERE
-		validators.withdrawalcredentials LIKE '\x01' || '%'::bytea AND ((stats.end_effective_balance = $1 AND stats.end_balance > $1) OR (validators.withdrawableepoch <= $2 AND stats.end_balance > 0));`, utils.Config.Chain.ClConfig.MaxEffectiveBalance, epoch)
+		(validators.withdrawalcredentials LIKE '\x01' || '%'::bytea AND ((stats.end_effective_balance = $1 AND stats.end_balance > $1) OR (validators.withdrawableepoch <= $2 AND stats.end_balance > 0))) OR
+		(validators.withdrawalcredentials LIKE '\x02' || '%'::bytea AND ((stats.end_effective_balance = $3 AND stats.end_balance > $3) OR (validators.withdrawableepoch <= $2 AND stats.end_balance > 0)));`, utils.Config.Chain.ClConfig.MaxEffectiveBalance, epoch, utils.Config.Chain.ClConfig.MaxEffectiveBalanceElectra)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return 0, nil
@@ -16,13 +16,13 @@ import (
 	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
 	"github.com/gobitfly/beaconchain/pkg/commons/types"
 
-	gcp_bigtable "cloud.google.com/go/bigtable"
 	"github.com/coocood/freecache"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/gobitfly/beaconchain/pkg/commons/storage"
 	go_ens "github.com/wealdtech/go-ens/v3"
 	"golang.org/x/sync/errgroup"
 )
@@ -262,8 +262,8 @@ func (bigtable *Bigtable) TransformEnsNameRegistered(blk *types.Eth1Block, cache
 		}
 	}
 	for key := range keys {
-		mut := gcp_bigtable.NewMutation()
-		mut.Set(DEFAULT_FAMILY, key, gcp_bigtable.Timestamp(0), nil)
+		mut := storage.NewMutation()
+		mut.Set(DEFAULT_FAMILY, key, storage.Timestamp(0), nil)
 
 		bulkData.Keys = append(bulkData.Keys, key)
 		bulkData.Muts = append(bulkData.Muts, mut)
@@ -292,14 +292,14 @@ func (bigtable *Bigtable) ImportEnsUpdates(client *ethclient.Client, readBatchSi
 	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
 	defer done()
 
-	rowRange := gcp_bigtable.PrefixRange(key)
+	rowRange := storage.PrefixRange(key)
 	keys := []string{}
 
-	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row gcp_bigtable.Row) bool {
+	err := bigtable.tableData.ReadRows(ctx, rowRange, func(row storage.Row) bool {
 		row_ := row[DEFAULT_FAMILY][0]
 		keys = append(keys, row_.Row)
 		return true
-	}, gcp_bigtable.LimitRows(readBatchSize)) // limit to max 1000 entries to avoid blocking the import of new blocks
+	}, storage.LimitRows(readBatchSize)) // limit to max 1000 entries to avoid blocking the import of new blocks
 	if err != nil {
 		return err
 	}
@@ -315,7 +315,7 @@ func (bigtable *Bigtable) ImportEnsUpdates(client *ethclient.Client, readBatchSi
 		name:    make(map[string]bool),
 	}
 
-	mutDelete := gcp_bigtable.NewMutation()
+	mutDelete := storage.NewMutation()
 	mutDelete.DeleteRow()
 
 	batchSize := 100
@@ -332,7 +332,7 @@ func (bigtable *Bigtable) ImportEnsUpdates(client *ethclient.Client, readBatchSi
 		g.SetLimit(10) // limit load on the node
 		mutsDelete := &types.BulkMutations{
 			Keys: make([]string, 0, 1),
-			Muts: make([]*gcp_bigtable.Mutation, 0, 1),
+			Muts: make([]*storage.Mutation, 0, 1),
 		}
 
 		for _, k := range batch {
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'add signing secret columns to webhook tables';
+ALTER TABLE users_webhooks ADD COLUMN IF NOT EXISTS signing_secret TEXT NOT NULL DEFAULT encode(sha256(gen_random_uuid()::TEXT::bytea || gen_random_uuid()::TEXT::bytea), 'hex');
+ALTER TABLE users_val_dashboards_groups ADD COLUMN IF NOT EXISTS webhook_signing_secret TEXT NOT NULL DEFAULT encode(sha256(gen_random_uuid()::TEXT::bytea || gen_random_uuid()::TEXT::bytea), 'hex');
+
+SELECT 'add retry scheduling columns to notification_queue';
+ALTER TABLE notification_queue ADD COLUMN IF NOT EXISTS retries INT NOT NULL DEFAULT 0;
+ALTER TABLE notification_queue ADD COLUMN IF NOT EXISTS next_attempt_ts TIMESTAMP WITHOUT TIME ZONE;
+
+SELECT 'create notification_dead_letters table';
+CREATE TABLE IF NOT EXISTS notification_dead_letters (
+    id SERIAL NOT NULL,
+    user_id INT NOT NULL,
+    queue_id INT NOT NULL,
+    created TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    failed_ts TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    channel notification_channels NOT NULL,
+    content jsonb NOT NULL,
+    retries INT NOT NULL DEFAULT 0,
+    last_status TEXT,
+    last_error TEXT,
+    redelivered_ts TIMESTAMP WITHOUT TIME ZONE,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_notification_dead_letters_user_id ON notification_dead_letters (user_id, id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'drop notification_dead_letters table';
+DROP TABLE IF EXISTS notification_dead_letters;
+
+SELECT 'drop retry scheduling columns from notification_queue';
+ALTER TABLE notification_queue DROP COLUMN IF EXISTS retries;
+ALTER TABLE notification_queue DROP COLUMN IF EXISTS next_attempt_ts;
+
+SELECT 'drop signing secret columns from webhook tables';
+ALTER TABLE users_webhooks DROP COLUMN IF EXISTS signing_secret;
+ALTER TABLE users_val_dashboards_groups DROP COLUMN IF EXISTS webhook_signing_secret;
+-- +goose StatementEnd
@@ -0,0 +1,111 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'add committeebits column to blocks_attestations';
+ALTER TABLE blocks_attestations ADD COLUMN IF NOT EXISTS committeebits bytea;
+
+SELECT 'add execution request count columns to blocks';
+ALTER TABLE blocks ADD COLUMN IF NOT EXISTS depositrequestscount INT;
+ALTER TABLE blocks ADD COLUMN IF NOT EXISTS withdrawalrequestscount INT;
+ALTER TABLE blocks ADD COLUMN IF NOT EXISTS consolidationrequestscount INT;
+
+SELECT 'create blocks_deposit_requests table';
+CREATE TABLE IF NOT EXISTS blocks_deposit_requests (
+    block_slot INT NOT NULL,
+    block_root bytea NOT NULL,
+    block_index INT NOT NULL,
+    pubkey bytea NOT NULL,
+    withdrawal_credentials bytea NOT NULL,
+    amount BIGINT NOT NULL,
+    -- in GWei
+    signature bytea NOT NULL,
+    valid_signature BOOLEAN NOT NULL DEFAULT TRUE,
+    deposit_index BIGINT NOT NULL,
+    PRIMARY KEY (block_slot, block_root, block_index)
+);
+CREATE INDEX IF NOT EXISTS idx_blocks_deposit_requests_pubkey ON blocks_deposit_requests (pubkey);
+
+SELECT 'create blocks_withdrawal_requests table';
+CREATE TABLE IF NOT EXISTS blocks_withdrawal_requests (
+    block_slot INT NOT NULL,
+    block_root bytea NOT NULL,
+    block_index INT NOT NULL,
+    source_address bytea NOT NULL,
+    validator_pubkey bytea NOT NULL,
+    amount BIGINT NOT NULL,
+    -- in GWei, 0 requests a full exit
+    PRIMARY KEY (block_slot, block_root, block_index)
+);
+CREATE INDEX IF NOT EXISTS idx_blocks_withdrawal_requests_validator_pubkey ON blocks_withdrawal_requests (validator_pubkey);
+
+SELECT 'create blocks_consolidation_requests table';
+CREATE TABLE IF NOT EXISTS blocks_consolidation_requests (
+    block_slot INT NOT NULL,
+    block_root bytea NOT NULL,
+    block_index INT NOT NULL,
+    source_address bytea NOT NULL,
+    source_pubkey bytea NOT NULL,
+    target_pubkey bytea NOT NULL,
+    PRIMARY KEY (block_slot, block_root, block_index)
+);
+CREATE INDEX IF NOT EXISTS idx_blocks_consolidation_requests_source_pubkey ON blocks_consolidation_requests (source_pubkey);
+CREATE INDEX IF NOT EXISTS idx_blocks_consolidation_requests_target_pubkey ON blocks_consolidation_requests (target_pubkey);
+
+SELECT 'create blocks_consensus_deposits view';
+-- deposit_type: 0 = deposit, 1 = deposit request, 2 = consolidation into the target validator
+-- consolidations that only switch a validator to compounding credentials (source = target) are not included
+CREATE OR REPLACE VIEW blocks_consensus_deposits AS
+    SELECT
+        bd.block_slot,
+        0::SMALLINT AS deposit_type,
+        bd.block_index,
+        bd.publickey,
+        bd.withdrawalcredentials,
+        bd.amount,
+        bd.signature,
+        NULL::bytea AS source_publickey
+    FROM blocks_deposits bd
+    UNION ALL
+    SELECT
+        bdr.block_slot,
+        1::SMALLINT AS deposit_type,
+        bdr.block_index,
+        bdr.pubkey,
+        bdr.withdrawal_credentials,
+        bdr.amount,
+        bdr.signature,
+        NULL::bytea AS source_publickey
+    FROM blocks_deposit_requests bdr
+    INNER JOIN blocks b ON b.slot = bdr.block_slot AND b.blockroot = bdr.block_root AND b.status = '1'
+    UNION ALL
+    SELECT
+        bcr.block_slot,
+        2::SMALLINT AS deposit_type,
+        bcr.block_index,
+        bcr.target_pubkey,
+        NULL::bytea,
+        0::BIGINT,
+        NULL::bytea,
+        bcr.source_pubkey AS source_publickey
+    FROM blocks_consolidation_requests bcr
+    INNER JOIN blocks b ON b.slot = bcr.block_slot AND b.blockroot = bcr.block_root AND b.status = '1'
+    WHERE bcr.source_pubkey <> bcr.target_pubkey;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'drop blocks_consensus_deposits view';
+DROP VIEW IF EXISTS blocks_consensus_deposits;
+
+SELECT 'drop execution request tables';
+DROP TABLE IF EXISTS blocks_consolidation_requests;
+DROP TABLE IF EXISTS blocks_withdrawal_requests;
+DROP TABLE IF EXISTS blocks_deposit_requests;
+
+SELECT 'drop execution request count columns from blocks';
+ALTER TABLE blocks DROP COLUMN IF EXISTS depositrequestscount;
+ALTER TABLE blocks DROP COLUMN IF EXISTS withdrawalrequestscount;
+ALTER TABLE blocks DROP COLUMN IF EXISTS consolidationrequestscount;
+
+SELECT 'drop committeebits column from blocks_attestations';
+ALTER TABLE blocks_attestations DROP COLUMN IF EXISTS committeebits;
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+/* On the users db */
+SELECT 'add notification delivery policy columns to users';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS notifications_delivery_mode TEXT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS notifications_quiet_hours_start SMALLINT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS notifications_quiet_hours_end SMALLINT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS notifications_timezone TEXT;
+
+SELECT 'add notification delivery mode override to users_val_dashboards_groups';
+ALTER TABLE users_val_dashboards_groups ADD COLUMN IF NOT EXISTS notifications_delivery_mode TEXT;
+
+SELECT 'create notification_digest_queue table';
+CREATE TABLE IF NOT EXISTS notification_digest_queue (
+    id SERIAL NOT NULL,
+    user_id INT NOT NULL,
+    dashboard_id INT NOT NULL,
+    group_id INT NOT NULL,
+    event_name TEXT NOT NULL,
+    epoch INT NOT NULL,
+    created TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    release_ts TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    details bytea NOT NULL,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_notification_digest_queue_release_ts ON notification_digest_queue (release_ts);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'drop notification_digest_queue table';
+DROP TABLE IF EXISTS notification_digest_queue;
+
+SELECT 'drop notification delivery mode override from users_val_dashboards_groups';
+ALTER TABLE users_val_dashboards_groups DROP COLUMN IF EXISTS notifications_delivery_mode;
+
+SELECT 'drop notification delivery policy columns from users';
+ALTER TABLE users DROP COLUMN IF EXISTS notifications_delivery_mode;
+ALTER TABLE users DROP COLUMN IF EXISTS notifications_quiet_hours_start;
+ALTER TABLE users DROP COLUMN IF EXISTS notifications_quiet_hours_end;
+ALTER TABLE users DROP COLUMN IF EXISTS notifications_timezone;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'add telegram, slack and matrix notification channels';
+ALTER TYPE notification_channels ADD VALUE IF NOT EXISTS 'telegram';
+ALTER TYPE notification_channels ADD VALUE IF NOT EXISTS 'slack';
+ALTER TYPE notification_channels ADD VALUE IF NOT EXISTS 'matrix';
+
+/* On the users db */
+SELECT 'create users_telegram_chats table';
+CREATE TABLE IF NOT EXISTS users_telegram_chats (
+    user_id INT NOT NULL,
+    chat_id BIGINT,
+    link_token TEXT,
+    link_token_expires_ts TIMESTAMP WITHOUT TIME ZONE,
+    linked_ts TIMESTAMP WITHOUT TIME ZONE,
+    PRIMARY KEY (user_id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_telegram_chats_link_token ON users_telegram_chats (link_token);
+
+SELECT 'add chat channel columns to users_val_dashboards_groups';
+ALTER TABLE users_val_dashboards_groups ADD COLUMN IF NOT EXISTS telegram_enabled BOOL NOT NULL DEFAULT false;
+ALTER TABLE users_val_dashboards_groups ADD COLUMN IF NOT EXISTS slack_webhook_url TEXT;
+ALTER TABLE users_val_dashboards_groups ADD COLUMN IF NOT EXISTS matrix_room_id TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'drop chat channel columns from users_val_dashboards_groups';
+ALTER TABLE users_val_dashboards_groups DROP COLUMN IF EXISTS telegram_enabled;
+ALTER TABLE users_val_dashboards_groups DROP COLUMN IF EXISTS slack_webhook_url;
+ALTER TABLE users_val_dashboards_groups DROP COLUMN IF EXISTS matrix_room_id;
+
+SELECT 'drop users_telegram_chats table';
+DROP TABLE IF EXISTS users_telegram_chats;
+
+-- values can not be removed from an enum, queued telegram, slack and matrix notifications are dropped instead
+DELETE FROM notification_queue WHERE channel IN ('telegram', 'slack', 'matrix');
+DELETE FROM notification_dead_letters WHERE channel IN ('telegram', 'slack', 'matrix');
+-- +goose StatementEnd
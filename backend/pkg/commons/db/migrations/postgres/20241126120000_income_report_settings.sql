@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+/* On the users db */
+SELECT 'add income report columns to users_val_dashboards_groups';
+ALTER TABLE users_val_dashboards_groups ADD COLUMN IF NOT EXISTS income_report_currency TEXT;
+ALTER TABLE users_val_dashboards_groups ADD COLUMN IF NOT EXISTS income_report_format TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'drop income report columns from users_val_dashboards_groups';
+ALTER TABLE users_val_dashboards_groups DROP COLUMN IF EXISTS income_report_currency;
+ALTER TABLE users_val_dashboards_groups DROP COLUMN IF EXISTS income_report_format;
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'add notification columns to users_acc_dashboards_groups';
+ALTER TABLE users_acc_dashboards_groups ADD COLUMN IF NOT EXISTS webhook_target TEXT;
+ALTER TABLE users_acc_dashboards_groups ADD COLUMN IF NOT EXISTS webhook_format TEXT;
+ALTER TABLE users_acc_dashboards_groups ADD COLUMN IF NOT EXISTS webhook_last_sent TIMESTAMP WITHOUT TIME ZONE;
+ALTER TABLE users_acc_dashboards_groups ADD COLUMN IF NOT EXISTS webhook_retries INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users_acc_dashboards_groups ADD COLUMN IF NOT EXISTS webhook_signing_secret TEXT NOT NULL DEFAULT encode(sha256(gen_random_uuid()::TEXT::bytea || gen_random_uuid()::TEXT::bytea), 'hex');
+ALTER TABLE users_acc_dashboards_groups ADD COLUMN IF NOT EXISTS ignore_spam_transactions BOOL NOT NULL DEFAULT true;
+ALTER TABLE users_acc_dashboards_groups ADD COLUMN IF NOT EXISTS subscribed_chain_ids BIGINT[] NOT NULL DEFAULT '{}';
+
+SELECT 'create users_acc_dashboards_notifications_history table';
+CREATE TABLE IF NOT EXISTS users_acc_dashboards_notifications_history (
+    user_id      INT      NOT NULL,
+    dashboard_id INT      NOT NULL,
+    group_id     INT      NOT NULL,
+    epoch        INT      NOT NULL,
+    network      SMALLINT NOT NULL,
+    event_type   TEXT     NOT NULL,
+    event_count  INT      NOT NULL,
+    details      bytea    NOT NULL,
+    ts           TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, epoch, network, dashboard_id, group_id, event_type)
+);
+CREATE INDEX IF NOT EXISTS idx_acc_user_id_ts_dashboard_id_group_id_event_type ON users_acc_dashboards_notifications_history (user_id, ts, dashboard_id, group_id, event_type);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'drop users_acc_dashboards_notifications_history table';
+DROP TABLE IF EXISTS users_acc_dashboards_notifications_history;
+
+SELECT 'drop notification columns from users_acc_dashboards_groups';
+ALTER TABLE users_acc_dashboards_groups DROP COLUMN IF EXISTS webhook_target;
+ALTER TABLE users_acc_dashboards_groups DROP COLUMN IF EXISTS webhook_format;
+ALTER TABLE users_acc_dashboards_groups DROP COLUMN IF EXISTS webhook_last_sent;
+ALTER TABLE users_acc_dashboards_groups DROP COLUMN IF EXISTS webhook_retries;
+ALTER TABLE users_acc_dashboards_groups DROP COLUMN IF EXISTS webhook_signing_secret;
+ALTER TABLE users_acc_dashboards_groups DROP COLUMN IF EXISTS ignore_spam_transactions;
+ALTER TABLE users_acc_dashboards_groups DROP COLUMN IF EXISTS subscribed_chain_ids;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'create validator_operator_shares table';
+CREATE TABLE IF NOT EXISTS
+    validator_operator_shares (
+        publickey bytea NOT NULL,
+        protocol CHARACTER VARYING(100) NOT NULL,
+        -- share of the validator income that goes to the node operator, between 0 and 1
+        operator_share NUMERIC NOT NULL,
+        PRIMARY KEY (publickey, protocol)
+    );
+CREATE INDEX IF NOT EXISTS idx_validator_operator_shares_protocol ON validator_operator_shares (protocol);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'drop validator_operator_shares table';
+DROP TABLE IF EXISTS validator_operator_shares;
+-- +goose StatementEnd
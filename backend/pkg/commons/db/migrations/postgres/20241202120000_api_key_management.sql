@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add api key management columns to api_keys';
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS id BIGSERIAL UNIQUE;
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS name VARCHAR(50) NOT NULL DEFAULT 'Default';
+-- scopes and networks the key is restricted to, NULL means unrestricted (keys created before scopes existed)
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS scopes TEXT[];
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS networks BIGINT[];
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys (user_id);
+
+SELECT 'up SQL query - add api_statistics index for the usage of a key';
+CREATE INDEX IF NOT EXISTS idx_api_statistics_apikey_ts ON api_statistics (apikey, ts);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop api_statistics index for the usage of a key';
+DROP INDEX IF EXISTS idx_api_statistics_apikey_ts;
+
+SELECT 'down SQL query - drop api key management columns from api_keys';
+DROP INDEX IF EXISTS idx_api_keys_user_id;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS created_at;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS networks;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS scopes;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS name;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS id;
+-- +goose StatementEnd
@@ -0,0 +1,66 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - turn oauth_apps into a client registry';
+ALTER TABLE oauth_apps ADD COLUMN IF NOT EXISTS client_id VARCHAR(64);
+UPDATE oauth_apps SET client_id = md5(random()::text || id::text) WHERE client_id IS NULL;
+ALTER TABLE oauth_apps ALTER COLUMN client_id SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_oauth_apps_client_id ON oauth_apps (client_id);
+-- sha256 of the secret of confidential clients, public clients (mobile or browser apps) have none and rely on PKCE alone
+ALTER TABLE oauth_apps ADD COLUMN IF NOT EXISTS client_secret_hash VARCHAR(64);
+-- scopes the client may request, NULL means all scopes
+ALTER TABLE oauth_apps ADD COLUMN IF NOT EXISTS scopes TEXT[];
+-- first party clients are operated by us, they skip the consent screen and may use the mobile session flow
+ALTER TABLE oauth_apps ADD COLUMN IF NOT EXISTS first_party BOOLEAN NOT NULL DEFAULT false;
+-- all apps registered so far are our own mobile apps
+UPDATE oauth_apps SET first_party = true;
+
+SELECT 'up SQL query - create oauth_authorization_codes table';
+CREATE TABLE IF NOT EXISTS
+    oauth_authorization_codes (
+        code_hash VARCHAR(64) NOT NULL,
+        app_id INT NOT NULL,
+        user_id BIGINT NOT NULL,
+        redirect_uri VARCHAR(100) NOT NULL,
+        scopes TEXT[] NOT NULL,
+        -- base64url encoded sha256 of the PKCE code verifier
+        code_challenge VARCHAR(128) NOT NULL,
+        expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+        consumed_at TIMESTAMP WITHOUT TIME ZONE,
+        PRIMARY KEY (code_hash),
+        FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+    );
+
+SELECT 'up SQL query - create oauth_tokens table';
+CREATE TABLE IF NOT EXISTS
+    oauth_tokens (
+        id BIGSERIAL PRIMARY KEY,
+        app_id INT NOT NULL,
+        user_id BIGINT NOT NULL,
+        access_token_hash VARCHAR(64) NOT NULL UNIQUE,
+        refresh_token_hash VARCHAR(64) NOT NULL UNIQUE,
+        scopes TEXT[] NOT NULL,
+        access_expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+        refresh_expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+        -- set when the refresh token was rotated or the grant was revoked
+        revoked_at TIMESTAMP WITHOUT TIME ZONE,
+        created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+    );
+CREATE INDEX IF NOT EXISTS idx_oauth_tokens_user_id_app_id ON oauth_tokens (user_id, app_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop oauth_tokens table';
+DROP TABLE IF EXISTS oauth_tokens;
+
+SELECT 'down SQL query - drop oauth_authorization_codes table';
+DROP TABLE IF EXISTS oauth_authorization_codes;
+
+SELECT 'down SQL query - drop client registry columns from oauth_apps';
+ALTER TABLE oauth_apps DROP COLUMN IF EXISTS first_party;
+ALTER TABLE oauth_apps DROP COLUMN IF EXISTS scopes;
+ALTER TABLE oauth_apps DROP COLUMN IF EXISTS client_secret_hash;
+DROP INDEX IF EXISTS idx_oauth_apps_client_id;
+ALTER TABLE oauth_apps DROP COLUMN IF EXISTS client_id;
+-- +goose StatementEnd
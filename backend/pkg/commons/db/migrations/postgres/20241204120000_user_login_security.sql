@@ -0,0 +1,72 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - create users_totp table';
+CREATE TABLE IF NOT EXISTS
+    users_totp (
+        user_id INT NOT NULL,
+        -- base32 encoded secret shared with the authenticator app
+        secret VARCHAR(64) NOT NULL,
+        -- the second factor is only enforced once the user entered a valid code after the setup
+        confirmed_at TIMESTAMP WITHOUT TIME ZONE,
+        -- last accepted time step, codes of the same or earlier steps are rejected to prevent replays
+        last_used_step BIGINT NOT NULL DEFAULT 0,
+        created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        PRIMARY KEY (user_id),
+        FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+    );
+
+SELECT 'up SQL query - create users_recovery_codes table';
+CREATE TABLE IF NOT EXISTS
+    users_recovery_codes (
+        id BIGSERIAL PRIMARY KEY,
+        user_id INT NOT NULL,
+        code_hash VARCHAR(64) NOT NULL,
+        used_at TIMESTAMP WITHOUT TIME ZONE,
+        FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+    );
+CREATE INDEX IF NOT EXISTS idx_users_recovery_codes_user_id ON users_recovery_codes (user_id);
+
+SELECT 'up SQL query - create users_webauthn_credentials table';
+CREATE TABLE IF NOT EXISTS
+    users_webauthn_credentials (
+        id BIGSERIAL PRIMARY KEY,
+        user_id INT NOT NULL,
+        name VARCHAR(50) NOT NULL,
+        credential_id BYTEA NOT NULL UNIQUE,
+        -- DER encoded SubjectPublicKeyInfo and COSE algorithm of the key
+        public_key BYTEA NOT NULL,
+        algorithm INT NOT NULL,
+        sign_count BIGINT NOT NULL DEFAULT 0,
+        created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        last_used_at TIMESTAMP WITHOUT TIME ZONE,
+        FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+    );
+CREATE INDEX IF NOT EXISTS idx_users_webauthn_credentials_user_id ON users_webauthn_credentials (user_id);
+
+SELECT 'up SQL query - create users_login_lockouts table';
+CREATE TABLE IF NOT EXISTS
+    users_login_lockouts (
+        user_id INT NOT NULL,
+        -- failed attempts since the first failure of the current window
+        failed_attempts INT NOT NULL DEFAULT 0,
+        first_failed_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+        locked_until TIMESTAMP WITHOUT TIME ZONE,
+        PRIMARY KEY (user_id),
+        FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+    );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop users_login_lockouts table';
+DROP TABLE IF EXISTS users_login_lockouts;
+
+SELECT 'down SQL query - drop users_webauthn_credentials table';
+DROP TABLE IF EXISTS users_webauthn_credentials;
+
+SELECT 'down SQL query - drop users_recovery_codes table';
+DROP TABLE IF EXISTS users_recovery_codes;
+
+SELECT 'down SQL query - drop users_totp table';
+DROP TABLE IF EXISTS users_totp;
+-- +goose StatementEnd
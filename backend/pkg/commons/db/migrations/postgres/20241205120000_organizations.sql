@@ -0,0 +1,62 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - create organizations table';
+CREATE TABLE IF NOT EXISTS
+    organizations (
+        id BIGSERIAL PRIMARY KEY,
+        name VARCHAR(50) NOT NULL,
+        created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+    );
+
+SELECT 'up SQL query - create organization_members table';
+CREATE TABLE IF NOT EXISTS
+    organization_members (
+        organization_id BIGINT NOT NULL,
+        user_id INT NOT NULL,
+        -- one of owner, admin, editor, viewer
+        role VARCHAR(10) NOT NULL,
+        created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        PRIMARY KEY (organization_id, user_id),
+        FOREIGN KEY (organization_id) REFERENCES organizations (id) ON DELETE CASCADE,
+        FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+    );
+CREATE INDEX IF NOT EXISTS idx_organization_members_user_id ON organization_members (user_id);
+
+SELECT 'up SQL query - create organization_invitations table';
+CREATE TABLE IF NOT EXISTS
+    organization_invitations (
+        id BIGSERIAL PRIMARY KEY,
+        organization_id BIGINT NOT NULL,
+        email VARCHAR(100) NOT NULL,
+        role VARCHAR(10) NOT NULL,
+        -- the token is only sent to the invited email address and stored hashed
+        token_hash VARCHAR(64) NOT NULL UNIQUE,
+        invited_by INT,
+        expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+        created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (organization_id) REFERENCES organizations (id) ON DELETE CASCADE,
+        FOREIGN KEY (invited_by) REFERENCES users (id) ON DELETE SET NULL
+    );
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_invitations_organization_id_email ON organization_invitations (organization_id, email);
+
+SELECT 'up SQL query - add organization_id column to users_val_dashboards';
+-- dashboards owned by an organization keep the user_id of their creator, notifications are still routed to that user
+ALTER TABLE users_val_dashboards ADD COLUMN IF NOT EXISTS organization_id BIGINT;
+CREATE INDEX IF NOT EXISTS idx_users_val_dashboards_organization_id ON users_val_dashboards (organization_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop organization_id column from users_val_dashboards';
+DROP INDEX IF EXISTS idx_users_val_dashboards_organization_id;
+ALTER TABLE users_val_dashboards DROP COLUMN IF EXISTS organization_id;
+
+SELECT 'down SQL query - drop organization_invitations table';
+DROP TABLE IF EXISTS organization_invitations;
+
+SELECT 'down SQL query - drop organization_members table';
+DROP TABLE IF EXISTS organization_members;
+
+SELECT 'down SQL query - drop organizations table';
+DROP TABLE IF EXISTS organizations;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - create pool_operations table';
+CREATE TABLE IF NOT EXISTS
+    pool_operations (
+        -- one of voluntary_exit, bls_to_execution_change
+        type VARCHAR(30) NOT NULL,
+        validatorindex INT NOT NULL,
+        signature BYTEA NOT NULL,
+        -- voluntary exits only
+        exit_epoch INT,
+        -- bls to execution changes only
+        to_execution_address BYTEA,
+        first_seen_ts TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+        first_seen_slot INT NOT NULL,
+        last_seen_slot INT NOT NULL,
+        included_slot INT,
+        -- set once the operation vanished from the pool of the node without being included
+        dropped_slot INT,
+        PRIMARY KEY (type, validatorindex, signature)
+    );
+CREATE INDEX IF NOT EXISTS idx_pool_operations_validatorindex ON pool_operations (validatorindex);
+CREATE INDEX IF NOT EXISTS idx_pool_operations_pending ON pool_operations (first_seen_slot) WHERE included_slot IS NULL AND dropped_slot IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop pool_operations table';
+DROP TABLE IF EXISTS pool_operations;
+-- +goose StatementEnd
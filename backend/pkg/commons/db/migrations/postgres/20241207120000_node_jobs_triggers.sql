@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - add user_id column to node_jobs';
+-- jobs created by a logged in user, only the user can see and trigger conditional jobs
+ALTER TABLE node_jobs ADD COLUMN IF NOT EXISTS user_id INT;
+CREATE INDEX IF NOT EXISTS idx_node_jobs_type_status ON node_jobs (type, status);
+
+SELECT 'up SQL query - create node_jobs_audit_log table';
+CREATE TABLE IF NOT EXISTS
+    node_jobs_audit_log (
+        id BIGSERIAL PRIMARY KEY,
+        node_job_id VARCHAR(40) NOT NULL,
+        ts TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+        -- one of CREATED, TRIGGERED, SUBMITTED, SUBMISSION_FAILED, COMPLETED
+        action VARCHAR(40) NOT NULL,
+        -- set if the entry only concerns a single validator of the job
+        validatorindex INT,
+        details TEXT NOT NULL DEFAULT '',
+        FOREIGN KEY (node_job_id) REFERENCES node_jobs (id) ON DELETE CASCADE
+    );
+CREATE INDEX IF NOT EXISTS idx_node_jobs_audit_log_node_job_id ON node_jobs_audit_log (node_job_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - drop node_jobs_audit_log table';
+DROP TABLE IF EXISTS node_jobs_audit_log;
+
+SELECT 'down SQL query - drop user_id column from node_jobs';
+DROP INDEX IF EXISTS idx_node_jobs_type_status;
+ALTER TABLE node_jobs DROP COLUMN IF EXISTS user_id;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query - create users_val_dashboards_operator_shares table';
+-- the operator share of validators run with ssv, obol or lido csm depends on the cluster / bond of the operator,
+-- it is configured per dashboard instead of being derived by the exporters
+CREATE TABLE IF NOT EXISTS
+    users_val_dashboards_operator_shares (
+        dashboard_id BIGINT NOT NULL,
+        -- one of lido_csm, ssv, obol
+        protocol VARCHAR(100) NOT NULL,
+        -- share of the validator income that goes to the node operator, between 0 and 1
+        operator_share NUMERIC NOT NULL CHECK (operator_share > 0 AND operator_share <= 1),
+        FOREIGN KEY (dashboard_id) REFERENCES users_val_dashboards (id) ON DELETE CASCADE,
+        PRIMARY KEY (dashboard_id, protocol)
+    );
+
+SELECT 'up SQL query - drop validator_operator_shares table';
+DROP TABLE IF EXISTS validator_operator_shares;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query - create validator_operator_shares table';
+CREATE TABLE IF NOT EXISTS
+    validator_operator_shares (
+        publickey bytea NOT NULL,
+        protocol CHARACTER VARYING(100) NOT NULL,
+        operator_share NUMERIC NOT NULL,
+        PRIMARY KEY (publickey, protocol)
+    );
+CREATE INDEX IF NOT EXISTS idx_validator_operator_shares_protocol ON validator_operator_shares (protocol);
+
+SELECT 'down SQL query - drop users_val_dashboards_operator_shares table';
+DROP TABLE IF EXISTS users_val_dashboards_operator_shares;
+-- +goose StatementEnd
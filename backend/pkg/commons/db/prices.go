@@ -0,0 +1,37 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/jmoiron/sqlx"
+)
+
+// GetDailyPrices returns the daily exchange rates of the native currency to the fiat currency with the given code between startTs and endTs,
+// keyed by the unix timestamp of the start of the (UTC) day. The code is used as column name, so it must never be user input.
+func GetDailyPrices(ctx context.Context, readerDb *sqlx.DB, currencyCode string, startTs, endTs uint64) (map[int64]float64, error) {
+	// prices are stored once per day, so include the day the range starts in
+	oneDay := uint64(utils.Day.Seconds())
+	startTs -= startTs % oneDay
+
+	prices := []struct {
+		Ts    time.Time `db:"ts"`
+		Price float64   `db:"price"`
+	}{}
+	err := readerDb.SelectContext(ctx, &prices, fmt.Sprintf(`
+		SELECT ts, %s AS price
+		FROM price
+		WHERE ts >= TO_TIMESTAMP($1) AND ts <= TO_TIMESTAMP($2)
+		ORDER BY ts`, currencyCode), startTs, endTs)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving historical %s prices: %w", currencyCode, err)
+	}
+
+	result := make(map[int64]float64, len(prices))
+	for _, p := range prices {
+		result[p.Ts.Truncate(utils.Day).Unix()] = p.Price
+	}
+	return result, nil
+}
@@ -47,6 +47,7 @@ var freeTierProduct t.PremiumProduct = t.PremiumProduct{
 		MachineMonitoringHistorySeconds:                3600 * 3,
 		NotificationsMachineCustomThreshold:            false,
 		NotificationsValidatorDashboardGroupEfficiency: false,
+		ValidatorDashboardExports:                      false,
 	},
 	PricePerMonthEur: 0,
 	PricePerYearEur:  0,
@@ -78,6 +79,7 @@ var adminPerks = t.PremiumPerks{
 	MachineMonitoringHistorySeconds:                maxJsInt,
 	NotificationsMachineCustomThreshold:            true,
 	NotificationsValidatorDashboardGroupEfficiency: true,
+	ValidatorDashboardExports:                      true,
 }
 
 func GetUserInfo(ctx context.Context, userId uint64, userDbReader *sqlx.DB) (*t.UserInfo, error) {
@@ -119,7 +121,7 @@ func GetUserInfo(ctx context.Context, userId uint64, userDbReader *sqlx.DB) (*t.
 
 	userInfo.Email = utils.CensorEmail(userInfo.Email)
 
-	err = userDbReader.SelectContext(ctx, &userInfo.ApiKeys, `SELECT api_key FROM api_keys WHERE user_id = $1`, userId)
+	err = userDbReader.SelectContext(ctx, &userInfo.ApiKeys, `SELECT api_key FROM api_keys WHERE user_id = $1 AND valid_until > NOW() ORDER BY created_at`, userId)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, fmt.Errorf("error getting userApiKeys for user %v: %w", userId, err)
 	}
@@ -358,6 +360,7 @@ func GetProductSummary(ctx context.Context) (*t.ProductSummary, error) { // TODO
 					MachineMonitoringHistorySeconds:                3600 * 24 * 30,
 					NotificationsMachineCustomThreshold:            true,
 					NotificationsValidatorDashboardGroupEfficiency: true,
+					ValidatorDashboardExports:                      false,
 				},
 				PricePerMonthEur:     9.99,
 				PricePerYearEur:      107.88,
@@ -392,6 +395,7 @@ func GetProductSummary(ctx context.Context) (*t.ProductSummary, error) { // TODO
 					MachineMonitoringHistorySeconds:                3600 * 24 * 30,
 					NotificationsMachineCustomThreshold:            true,
 					NotificationsValidatorDashboardGroupEfficiency: true,
+					ValidatorDashboardExports:                      true,
 				},
 				PricePerMonthEur:     29.99,
 				PricePerYearEur:      311.88,
@@ -426,6 +430,7 @@ func GetProductSummary(ctx context.Context) (*t.ProductSummary, error) { // TODO
 					MachineMonitoringHistorySeconds:                3600 * 24 * 30,
 					NotificationsMachineCustomThreshold:            true,
 					NotificationsValidatorDashboardGroupEfficiency: true,
+					ValidatorDashboardExports:                      true,
 				},
 				PricePerMonthEur:     49.99,
 				PricePerYearEur:      479.88,
@@ -0,0 +1,264 @@
+package machinemetrics
+
+import (
+	"slices"
+	"strings"
+)
+
+type aggregation int
+
+const (
+	// aggregateSum adds up the values of the matching series, e.g. the cpu time of all cores
+	aggregateSum aggregation = iota
+	// aggregateCount counts the matching series, e.g. the number of cores
+	aggregateCount
+	// aggregateLabel reads the value of a label of the first matching series, e.g. the version of an info metric
+	aggregateLabel
+)
+
+// source selects the series a machine metric field is read from
+type source struct {
+	name string
+	// label values the series must have, a value starting with ! excludes the series with that value
+	labels map[string]string
+	// values the series must have, any if empty
+	values      []float64
+	aggregation aggregation
+	// the label that is read by aggregateLabel
+	label string
+}
+
+func (s source) matches(sample Sample) bool {
+	for name, value := range s.labels {
+		if strings.HasPrefix(value, "!") {
+			if sample.Labels[name] == value[1:] {
+				return false
+			}
+		} else if sample.Labels[name] != value {
+			return false
+		}
+	}
+	return len(s.values) == 0 || slices.Contains(s.values, sample.Value)
+}
+
+// read returns the value of the source, a float64 for sums and counts or a string for labels
+func (s source) read(series map[string][]Sample) (interface{}, bool) {
+	sum := float64(0)
+	count := 0
+	for _, sample := range series[s.name] {
+		if !s.matches(sample) {
+			continue
+		}
+		if s.aggregation == aggregateLabel {
+			value, ok := sample.Labels[s.label]
+			return value, ok
+		}
+		sum += sample.Value
+		count++
+	}
+	if s.aggregation == aggregateCount {
+		return float64(count), count > 0
+	}
+	return sum, count > 0
+}
+
+// mapping describes how the metrics of a client process are mapped onto a machine metric
+type mapping struct {
+	client  string
+	process string
+	// metrics that the process exposes, all of them have to be present to detect it
+	detect []string
+	// protobuf field name -> source
+	fields map[string]source
+}
+
+func (m mapping) detected(series map[string][]Sample) bool {
+	for _, name := range m.detect {
+		if len(series[name]) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// processFields are exposed by the default prometheus collectors of the go, rust, java and node.js clients
+var processFields = map[string]source{
+	"cpu_process_seconds_total": {name: "process_cpu_seconds_total"},
+	"memory_process_bytes":      {name: "process_resident_memory_bytes"},
+}
+
+func withProcessFields(fields map[string]source) map[string]source {
+	for field, src := range processFields {
+		if _, ok := fields[field]; !ok {
+			fields[field] = src
+		}
+	}
+	return fields
+}
+
+func versionLabel(name string) source {
+	return source{name: name, aggregation: aggregateLabel, label: "version"}
+}
+
+// mappings are checked in order, only the first detected mapping of each process is used
+var mappings = []mapping{
+	// node_exporter
+	{
+		process: ProcessSystem,
+		detect:  []string{"node_cpu_seconds_total"},
+		fields: map[string]source{
+			"cpu_cores":                         {name: "node_cpu_seconds_total", labels: map[string]string{"mode": "idle"}, aggregation: aggregateCount},
+			"cpu_threads":                       {name: "node_cpu_seconds_total", labels: map[string]string{"mode": "idle"}, aggregation: aggregateCount},
+			"cpu_node_system_seconds_total":     {name: "node_cpu_seconds_total", labels: map[string]string{"mode": "system"}},
+			"cpu_node_user_seconds_total":       {name: "node_cpu_seconds_total", labels: map[string]string{"mode": "user"}},
+			"cpu_node_iowait_seconds_total":     {name: "node_cpu_seconds_total", labels: map[string]string{"mode": "iowait"}},
+			"cpu_node_idle_seconds_total":       {name: "node_cpu_seconds_total", labels: map[string]string{"mode": "idle"}},
+			"memory_node_bytes_total":           {name: "node_memory_MemTotal_bytes"},
+			"memory_node_bytes_free":            {name: "node_memory_MemFree_bytes"},
+			"memory_node_bytes_cached":          {name: "node_memory_Cached_bytes"},
+			"memory_node_bytes_buffers":         {name: "node_memory_Buffers_bytes"},
+			"disk_node_bytes_total":             {name: "node_filesystem_size_bytes", labels: map[string]string{"mountpoint": "/"}},
+			"disk_node_bytes_free":              {name: "node_filesystem_avail_bytes", labels: map[string]string{"mountpoint": "/"}},
+			"disk_node_io_seconds":              {name: "node_disk_io_time_seconds_total"},
+			"disk_node_reads_total":             {name: "node_disk_reads_completed_total"},
+			"disk_node_writes_total":            {name: "node_disk_writes_completed_total"},
+			"network_node_bytes_total_receive":  {name: "node_network_receive_bytes_total", labels: map[string]string{"device": "!lo"}},
+			"network_node_bytes_total_transmit": {name: "node_network_transmit_bytes_total", labels: map[string]string{"device": "!lo"}},
+			"misc_node_boot_ts_seconds":         {name: "node_boot_time_seconds"},
+			"misc_os":                           {name: "node_uname_info", aggregation: aggregateLabel, label: "sysname"},
+		},
+	},
+
+	// consensus clients
+	{
+		client:  "lighthouse",
+		process: ProcessBeaconNode,
+		detect:  []string{"store_disk_db_size"},
+		fields: withProcessFields(map[string]source{
+			"disk_beaconchain_bytes_total":        {name: "store_disk_db_size"},
+			"network_libp2p_bytes_total_receive":  {name: "libp2p_inbound_bytes"},
+			"network_libp2p_bytes_total_transmit": {name: "libp2p_outbound_bytes"},
+			"network_peers_connected":             {name: "libp2p_peers"},
+			"sync_eth1_connected":                 {name: "sync_eth1_connected"},
+			"sync_eth2_synced":                    {name: "sync_eth2_synced"},
+			"sync_beacon_head_slot":               {name: "beacon_head_state_slot"},
+			"sync_eth1_fallback_configured":       {name: "sync_eth1_fallback_configured"},
+			"sync_eth1_fallback_connected":        {name: "sync_eth1_fallback_connected"},
+		}),
+	},
+	{
+		client:  "lighthouse",
+		process: ProcessValidator,
+		detect:  []string{"vc_validators_total_count"},
+		fields: withProcessFields(map[string]source{
+			"validator_total":               {name: "vc_validators_total_count"},
+			"validator_active":              {name: "vc_validators_enabled_count"},
+			"sync_eth2_fallback_configured": {name: "sync_eth2_fallback_configured"},
+			"sync_eth2_fallback_connected":  {name: "sync_eth2_fallback_connected"},
+		}),
+	},
+	{
+		client:  "teku",
+		process: ProcessBeaconNode,
+		detect:  []string{"beacon_peer_count", "jvm_memory_bytes_used"},
+		fields: withProcessFields(map[string]source{
+			"network_peers_connected": {name: "beacon_peer_count"},
+			"sync_beacon_head_slot":   {name: "beacon_head_slot"},
+		}),
+	},
+	{
+		client:  "teku",
+		process: ProcessValidator,
+		detect:  []string{"validator_local_validator_counts"},
+		fields: withProcessFields(map[string]source{
+			"validator_total":  {name: "validator_local_validator_counts"},
+			"validator_active": {name: "validator_local_validator_counts", labels: map[string]string{"state": "active_ongoing"}},
+		}),
+	},
+	{
+		client:  "prysm",
+		process: ProcessBeaconNode,
+		detect:  []string{"p2p_peer_count", "beacon_head_slot"},
+		fields: withProcessFields(map[string]source{
+			"client_version":          versionLabel("prysm_version"),
+			"network_peers_connected": {name: "p2p_peer_count", labels: map[string]string{"state": "Connected"}},
+			"sync_beacon_head_slot":   {name: "beacon_head_slot"},
+		}),
+	},
+	{
+		client:  "prysm",
+		process: ProcessValidator,
+		detect:  []string{"validator_statuses"},
+		fields: withProcessFields(map[string]source{
+			"client_version":  versionLabel("prysm_version"),
+			"validator_total": {name: "validator_statuses", aggregation: aggregateCount},
+			// 3 is the ACTIVE status of the prysm validator status enum
+			"validator_active": {name: "validator_statuses", values: []float64{3}, aggregation: aggregateCount},
+		}),
+	},
+	{
+		client:  "nimbus",
+		process: ProcessBeaconNode,
+		detect:  []string{"nbc_peers"},
+		fields: withProcessFields(map[string]source{
+			"network_peers_connected": {name: "nbc_peers"},
+			"sync_beacon_head_slot":   {name: "beacon_head_slot"},
+		}),
+	},
+	{
+		client:  "lodestar",
+		process: ProcessBeaconNode,
+		detect:  []string{"lodestar_version", "beacon_head_slot"},
+		fields: withProcessFields(map[string]source{
+			"client_version":          versionLabel("lodestar_version"),
+			"network_peers_connected": {name: "libp2p_peers"},
+			"sync_beacon_head_slot":   {name: "beacon_head_slot"},
+		}),
+	},
+	{
+		client:  "lodestar",
+		process: ProcessValidator,
+		detect:  []string{"vc_indices_count"},
+		fields: withProcessFields(map[string]source{
+			"client_version":  versionLabel("lodestar_version"),
+			"validator_total": {name: "vc_indices_count"},
+		}),
+	},
+
+	// execution clients
+	{
+		client:  "geth",
+		process: ProcessExecutionNode,
+		detect:  []string{"chain_head_block"},
+		fields: withProcessFields(map[string]source{
+			"network_libp2p_bytes_total_receive":  {name: "p2p_ingress"},
+			"network_libp2p_bytes_total_transmit": {name: "p2p_egress"},
+			"network_peers_connected":             {name: "p2p_peers"},
+		}),
+	},
+	{
+		client:  "nethermind",
+		process: ProcessExecutionNode,
+		detect:  []string{"nethermind_blocks"},
+		fields: withProcessFields(map[string]source{
+			"network_peers_connected": {name: "nethermind_sync_peers"},
+		}),
+	},
+	{
+		client:  "besu",
+		process: ProcessExecutionNode,
+		detect:  []string{"ethereum_blockchain_height"},
+		fields: withProcessFields(map[string]source{
+			"network_peers_connected": {name: "ethereum_peer_count"},
+		}),
+	},
+	{
+		client:  "reth",
+		process: ProcessExecutionNode,
+		detect:  []string{"reth_network_connected_peers"},
+		fields: withProcessFields(map[string]source{
+			"client_version":          versionLabel("reth_info"),
+			"network_peers_connected": {name: "reth_network_connected_peers"},
+		}),
+	},
+}
@@ -0,0 +1,207 @@
+// Package machinemetrics converts metrics that nodes expose in the prometheus formats to the machine metrics of the
+// machine monitoring. Well-known metrics of the clients are mapped onto the MachineMetricSystem, MachineMetricNode and
+// MachineMetricValidator protobufs, the raw series are kept as they are.
+package machinemetrics
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+)
+
+const (
+	ProcessSystem        = "system"
+	ProcessBeaconNode    = "beaconnode"
+	ProcessValidator     = "validator"
+	ProcessExecutionNode = "executionnode"
+)
+
+// exporterVersion is stored as exporter version of the converted metrics
+const exporterVersion = "prometheus"
+
+// Sample is a single value of a series
+type Sample struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+	// unix milliseconds, 0 if the sample has no timestamp
+	Timestamp int64
+}
+
+// Series returns the identifier of the series of the sample, the metric name followed by the sorted labels
+func (s Sample) Series() string {
+	if len(s.Labels) == 0 {
+		return s.Name
+	}
+	names := make([]string, 0, len(s.Labels))
+	for name := range s.Labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	b.WriteString(s.Name)
+	b.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, "%s=%q", name, s.Labels[name])
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// Target are the samples of a single scrape target. Remote write requests contain the samples of all targets a
+// prometheus scrapes, they are told apart by their job and instance labels.
+type Target struct {
+	Job      string
+	Instance string
+	Samples  []Sample
+}
+
+// Host returns the host of the instance label, which is the machine the target runs on
+func (t *Target) Host() string {
+	host, _, err := net.SplitHostPort(t.Instance)
+	if err != nil {
+		return t.Instance
+	}
+	return host
+}
+
+// GroupByTarget groups the samples by their job and instance labels, in the order of their first sample
+func GroupByTarget(samples []Sample) []*Target {
+	var targets []*Target
+	byKey := make(map[[2]string]*Target)
+	for _, s := range samples {
+		key := [2]string{s.Labels["job"], s.Labels["instance"]}
+		target, ok := byKey[key]
+		if !ok {
+			target = &Target{Job: key[0], Instance: key[1]}
+			byKey[key] = target
+			targets = append(targets, target)
+		}
+		target.Samples = append(target.Samples, s)
+	}
+	return targets
+}
+
+// Metric is a machine metric converted from the samples of a target
+type Metric struct {
+	Process string
+	// empty for system metrics
+	Client string
+	Data   proto.Message
+}
+
+// Convert maps the samples of the target onto the machine metrics of the processes that are detected. A target can
+// result in several metrics, e.g. if a beacon node runs its validators in the same process.
+func Convert(target *Target) ([]Metric, error) {
+	series := latestSamples(target.Samples)
+	timestamp := int64(0)
+	for _, s := range series {
+		timestamp = max(timestamp, s[0].Timestamp)
+	}
+	if timestamp == 0 {
+		timestamp = time.Now().UnixMilli()
+	}
+
+	var metrics []Metric
+	for _, m := range mappings {
+		if !m.detected(series) || slices.ContainsFunc(metrics, func(metric Metric) bool { return metric.Process == m.process }) {
+			continue
+		}
+		var msg proto.Message
+		switch m.process {
+		case ProcessSystem:
+			msg = &types.MachineMetricSystem{}
+		case ProcessValidator:
+			msg = &types.MachineMetricValidator{}
+		default:
+			msg = &types.MachineMetricNode{}
+		}
+		data := msg.ProtoReflect()
+		err := setField(data, "timestamp", uint64(timestamp))
+		if err != nil {
+			return nil, err
+		}
+		err = setField(data, "exporter_version", exporterVersion)
+		if err != nil {
+			return nil, err
+		}
+		if m.client != "" {
+			err = setField(data, "client_name", m.client)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for field, src := range m.fields {
+			value, ok := src.read(series)
+			if !ok {
+				continue
+			}
+			err = setField(data, field, value)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", m.client, m.process, err)
+			}
+		}
+		metrics = append(metrics, Metric{Process: m.process, Client: m.client, Data: msg})
+	}
+	return metrics, nil
+}
+
+// latestSamples returns the samples by metric name, only the latest sample of each series is kept
+func latestSamples(samples []Sample) map[string][]Sample {
+	latest := make(map[string]Sample)
+	for _, s := range samples {
+		id := s.Series()
+		if prev, ok := latest[id]; !ok || s.Timestamp >= prev.Timestamp {
+			latest[id] = s
+		}
+	}
+	byName := make(map[string][]Sample)
+	for _, s := range latest {
+		byName[s.Name] = append(byName[s.Name], s)
+	}
+	return byName
+}
+
+// setField sets the protobuf field with the given name, numbers are converted to the type of the field
+func setField(msg protoreflect.Message, name string, value interface{}) error {
+	fd := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if fd == nil {
+		return fmt.Errorf("unknown field %s of %s", name, msg.Descriptor().Name())
+	}
+	switch v := value.(type) {
+	case string:
+		if fd.Kind() != protoreflect.StringKind {
+			return fmt.Errorf("field %s is no string", name)
+		}
+		msg.Set(fd, protoreflect.ValueOfString(v))
+	case uint64:
+		if fd.Kind() != protoreflect.Uint64Kind {
+			return fmt.Errorf("field %s is no integer", name)
+		}
+		msg.Set(fd, protoreflect.ValueOfUint64(v))
+	case float64:
+		switch fd.Kind() {
+		case protoreflect.Uint64Kind:
+			msg.Set(fd, protoreflect.ValueOfUint64(uint64(math.Max(v, 0))))
+		case protoreflect.BoolKind:
+			msg.Set(fd, protoreflect.ValueOfBool(v != 0))
+		default:
+			return fmt.Errorf("field %s is neither integer nor boolean", name)
+		}
+	default:
+		return fmt.Errorf("unsupported value %v for field %s", value, name)
+	}
+	return nil
+}
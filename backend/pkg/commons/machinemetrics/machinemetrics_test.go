@@ -0,0 +1,270 @@
+package machinemetrics
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+)
+
+func TestParseText(t *testing.T) {
+	text := `# HELP node_cpu_seconds_total Seconds the CPUs spent in each mode.
+# TYPE node_cpu_seconds_total counter
+node_cpu_seconds_total{cpu="0",mode="idle"} 100.5
+node_cpu_seconds_total{cpu="0", mode="user",} 2e1 1700000000000
+escaped{path="C:\\data",quote="\"q\"",nl="a\nb"} 1
+stale NaN
+no_labels 3
+`
+	samples, err := ParseText(strings.NewReader(text), false, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(samples) != 4 {
+		t.Fatalf("expected 4 samples, got %d", len(samples))
+	}
+	if s := samples[1]; s.Name != "node_cpu_seconds_total" || s.Labels["mode"] != "user" || s.Value != 20 || s.Timestamp != 1700000000000 {
+		t.Errorf("unexpected sample %+v", s)
+	}
+	if s := samples[2]; s.Labels["path"] != `C:\data` || s.Labels["quote"] != `"q"` || s.Labels["nl"] != "a\nb" {
+		t.Errorf("unexpected labels %v", s.Labels)
+	}
+	if got := samples[1].Series(); got != `node_cpu_seconds_total{cpu="0",mode="user"}` {
+		t.Errorf("unexpected series %s", got)
+	}
+	if got := samples[3].Series(); got != "no_labels" {
+		t.Errorf("unexpected series %s", got)
+	}
+
+	openMetrics := `# TYPE foo counter
+# UNIT foo seconds
+foo_total{a="b"} 1.5 1700000000.25 # {trace_id="x"} 1 1700000000
+# EOF
+`
+	samples, err = ParseText(strings.NewReader(openMetrics), true, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(samples) != 1 || samples[0].Value != 1.5 || samples[0].Timestamp != 1700000000250 {
+		t.Errorf("unexpected samples %+v", samples)
+	}
+
+	for _, invalid := range []string{"foo", `foo{a="b" 1`, `foo{a=b} 1`, "foo bar", "foo 1 2 3"} {
+		_, err := ParseText(strings.NewReader(invalid), false, 100)
+		if err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+}
+
+func appendLabel(b []byte, name, value string) []byte {
+	var label []byte
+	label = protowire.AppendTag(label, 1, protowire.BytesType)
+	label = protowire.AppendString(label, name)
+	label = protowire.AppendTag(label, 2, protowire.BytesType)
+	label = protowire.AppendString(label, value)
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	return protowire.AppendBytes(b, label)
+}
+
+func appendSample(b []byte, value float64, timestamp int64) []byte {
+	var sample []byte
+	sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+	sample = protowire.AppendFixed64(sample, math.Float64bits(value))
+	sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+	sample = protowire.AppendVarint(sample, uint64(timestamp))
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	return protowire.AppendBytes(b, sample)
+}
+
+func TestParseRemoteWrite(t *testing.T) {
+	var series []byte
+	series = appendLabel(series, "__name__", "libp2p_peers")
+	series = appendLabel(series, "instance", "node:5054")
+	series = appendSample(series, 50, 1000)
+	series = appendSample(series, math.NaN(), 2000)
+	series = appendSample(series, 52, 3000)
+
+	var request []byte
+	request = protowire.AppendTag(request, 1, protowire.BytesType)
+	request = protowire.AppendBytes(request, series)
+	// metadata is skipped
+	request = protowire.AppendTag(request, 3, protowire.BytesType)
+	request = protowire.AppendBytes(request, []byte{})
+
+	samples, err := ParseRemoteWrite(snappy.Encode(nil, request), 1024, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(samples) != 2 {
+		t.Fatalf("expected 2 samples, got %d", len(samples))
+	}
+	if s := samples[1]; s.Name != "libp2p_peers" || s.Labels["instance"] != "node:5054" || s.Value != 52 || s.Timestamp != 3000 {
+		t.Errorf("unexpected sample %+v", s)
+	}
+
+	_, err = ParseRemoteWrite(request, 1024, 100)
+	if err == nil {
+		t.Errorf("expected an error for an uncompressed request")
+	}
+	_, err = ParseRemoteWrite(snappy.Encode(nil, request[:len(request)-5]), 1024, 100)
+	if err == nil {
+		t.Errorf("expected an error for a truncated request")
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	// the same series with different timestamps only counts once
+	text := "a 1 1000\na 2 2000\nb{x=\"1\"} 1\nb{x=\"2\"} 1\n"
+	if _, err := ParseText(strings.NewReader(text), false, 3); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := ParseText(strings.NewReader(text), false, 2); !errors.Is(err, ErrTooManySeries) {
+		t.Errorf("expected ErrTooManySeries, got %v", err)
+	}
+
+	var request []byte
+	for _, name := range []string{"a", "b", "c"} {
+		var series []byte
+		series = appendLabel(series, "__name__", name)
+		series = appendSample(series, 1, 1000)
+		request = protowire.AppendTag(request, 1, protowire.BytesType)
+		request = protowire.AppendBytes(request, series)
+	}
+	if _, err := ParseRemoteWrite(snappy.Encode(nil, request), 1024, 3); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := ParseRemoteWrite(snappy.Encode(nil, request), 1024, 2); !errors.Is(err, ErrTooManySeries) {
+		t.Errorf("expected ErrTooManySeries, got %v", err)
+	}
+
+	// the decoded size is checked before decompressing, a small body can claim a huge size
+	if _, err := ParseRemoteWrite(snappy.Encode(nil, request), len(request)-1, 3); err == nil {
+		t.Errorf("expected an error for a request that decompresses to more than the max size")
+	}
+	bomb := binary.AppendUvarint(nil, 1<<31)
+	if _, err := ParseRemoteWrite(bomb, 1024, 3); err == nil || !strings.Contains(err.Error(), "too large") {
+		t.Errorf("expected the claimed decoded size to be rejected, got %v", err)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	text := `
+node_cpu_seconds_total{cpu="0",mode="idle",instance="host:9100",job="node"} 100
+node_cpu_seconds_total{cpu="1",mode="idle",instance="host:9100",job="node"} 50
+node_cpu_seconds_total{cpu="0",mode="user",instance="host:9100",job="node"} 10
+node_network_receive_bytes_total{device="eth0",instance="host:9100",job="node"} 1000
+node_network_receive_bytes_total{device="lo",instance="host:9100",job="node"} 99
+node_uname_info{sysname="Linux",instance="host:9100",job="node"} 1
+beacon_peer_count{instance="host:5051",job="teku"} 80 2000
+beacon_peer_count{instance="host:5051",job="teku"} 81 3000
+jvm_memory_bytes_used{area="heap",instance="host:5051",job="teku"} 1
+beacon_head_slot{instance="host:5051",job="teku"} 123
+process_resident_memory_bytes{instance="host:5051",job="teku"} 4096
+validator_local_validator_counts{state="active_ongoing",instance="host:5051",job="teku"} 3
+validator_local_validator_counts{state="pending_queued",instance="host:5051",job="teku"} 2
+reth_network_connected_peers{instance="host:9001",job="reth"} 12
+reth_info{version="1.1.0",instance="host:9001",job="reth"} 1
+unknown_metric{instance="host:1234",job="other"} 1
+`
+	samples, err := ParseText(strings.NewReader(text), false, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	targets := GroupByTarget(samples)
+	if len(targets) != 4 {
+		t.Fatalf("expected 4 targets, got %d", len(targets))
+	}
+	if targets[0].Host() != "host" || targets[1].Job != "teku" {
+		t.Errorf("unexpected targets %+v %+v", targets[0], targets[1])
+	}
+
+	metrics, err := Convert(targets[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(metrics) != 1 || metrics[0].Process != ProcessSystem {
+		t.Fatalf("expected system metrics, got %+v", metrics)
+	}
+	system := metrics[0].Data.(*types.MachineMetricSystem)
+	if system.CpuCores != 2 || system.CpuNodeIdleSecondsTotal != 150 || system.CpuNodeUserSecondsTotal != 10 ||
+		system.NetworkNodeBytesTotalReceive != 1000 || system.MiscOs != "Linux" || system.ExporterVersion != exporterVersion || system.Timestamp == 0 {
+		t.Errorf("unexpected system metrics %+v", system)
+	}
+
+	metrics, err = Convert(targets[1])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(metrics) != 2 || metrics[0].Process != ProcessBeaconNode || metrics[1].Process != ProcessValidator {
+		t.Fatalf("expected beacon node and validator metrics, got %+v", metrics)
+	}
+	node := metrics[0].Data.(*types.MachineMetricNode)
+	if node.ClientName != "teku" || node.NetworkPeersConnected != 81 || node.SyncBeaconHeadSlot != 123 || node.MemoryProcessBytes != 4096 || node.Timestamp != 3000 {
+		t.Errorf("unexpected node metrics %+v", node)
+	}
+	validator := metrics[1].Data.(*types.MachineMetricValidator)
+	if validator.ValidatorTotal != 5 || validator.ValidatorActive != 3 {
+		t.Errorf("unexpected validator metrics %+v", validator)
+	}
+
+	metrics, err = Convert(targets[2])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(metrics) != 1 || metrics[0].Process != ProcessExecutionNode {
+		t.Fatalf("expected execution node metrics, got %+v", metrics)
+	}
+	if node := metrics[0].Data.(*types.MachineMetricNode); node.ClientName != "reth" || node.ClientVersion != "1.1.0" || node.NetworkPeersConnected != 12 {
+		t.Errorf("unexpected execution node metrics %+v", node)
+	}
+
+	metrics, err = Convert(targets[3])
+	if err != nil || len(metrics) != 0 {
+		t.Errorf("expected no metrics for an unknown target, got %+v, %v", metrics, err)
+	}
+}
+
+// TestMappingFields makes sure that all mapped fields exist in the protobufs and have a matching type
+func TestMappingFields(t *testing.T) {
+	for _, m := range mappings {
+		var samples []Sample
+		for _, name := range m.detect {
+			samples = append(samples, Sample{Name: name, Value: 1})
+		}
+		for _, src := range m.fields {
+			labels := map[string]string{}
+			for name, value := range src.labels {
+				if !strings.HasPrefix(value, "!") {
+					labels[name] = value
+				}
+			}
+			if src.label != "" {
+				labels[src.label] = "v"
+			}
+			value := float64(1)
+			if len(src.values) > 0 {
+				value = src.values[0]
+			}
+			samples = append(samples, Sample{Name: src.name, Labels: labels, Value: value})
+		}
+		metrics, err := Convert(&Target{Samples: samples})
+		if err != nil {
+			t.Errorf("%s %s: %v", m.client, m.process, err)
+			continue
+		}
+		found := false
+		for _, metric := range metrics {
+			found = found || (metric.Client == m.client && metric.Process == m.process)
+		}
+		if !found {
+			t.Errorf("%s %s: mapping is not detected by its own metrics", m.client, m.process)
+		}
+	}
+}
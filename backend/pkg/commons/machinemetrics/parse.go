@@ -0,0 +1,322 @@
+package machinemetrics
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ErrTooManySeries is returned if a request contains more distinct series than allowed
+var ErrTooManySeries = errors.New("too many series")
+
+// seriesLimiter fails as soon as more than max distinct series are added
+type seriesLimiter struct {
+	max  int
+	seen map[string]struct{}
+}
+
+func newSeriesLimiter(max int) *seriesLimiter {
+	return &seriesLimiter{max: max, seen: make(map[string]struct{})}
+}
+
+func (l *seriesLimiter) add(sample Sample) error {
+	l.seen[sample.Series()] = struct{}{}
+	if len(l.seen) > l.max {
+		return fmt.Errorf("%w, max allowed are %d per request", ErrTooManySeries, l.max)
+	}
+	return nil
+}
+
+// ParseRemoteWrite parses the snappy compressed protobuf WriteRequest of the prometheus remote write protocol.
+// Only float samples are supported, native histograms, exemplars and metadata are skipped.
+// Requests that decompress to more than maxDecodedSize bytes or contain more than maxSeries series are rejected.
+func ParseRemoteWrite(body []byte, maxDecodedSize, maxSeries int) ([]Sample, error) {
+	// the decoded length is part of the snappy header, check it before allocating the buffer
+	decodedSize, err := snappy.DecodedLen(body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snappy encoding: %w", err)
+	}
+	if decodedSize > maxDecodedSize {
+		return nil, fmt.Errorf("decompressed request too large, max allowed are %d bytes", maxDecodedSize)
+	}
+	data, err := snappy.Decode(nil, body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snappy encoding: %w", err)
+	}
+
+	var samples []Sample
+	limiter := newSeriesLimiter(maxSeries)
+	// message WriteRequest { repeated TimeSeries timeseries = 1; ... }
+	err = readMessage(data, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		if num != 1 || typ != protowire.BytesType {
+			return nil
+		}
+		series, err := parseTimeSeries(value)
+		if err != nil {
+			return err
+		}
+		if len(series) > 0 {
+			if err := limiter.add(series[0]); err != nil {
+				return err
+			}
+		}
+		samples = append(samples, series...)
+		return nil
+	})
+	if errors.Is(err, ErrTooManySeries) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote write request: %w", err)
+	}
+	return samples, nil
+}
+
+// parseTimeSeries parses a TimeSeries message: { repeated Label labels = 1; repeated Sample samples = 2; ... }
+func parseTimeSeries(data []byte) ([]Sample, error) {
+	var name string
+	labels := make(map[string]string)
+	type point struct {
+		value     float64
+		timestamp int64
+	}
+	var points []point
+
+	err := readMessage(data, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case 1:
+			// message Label { string name = 1; string value = 2; }
+			var labelName, labelValue string
+			err := readMessage(value, func(num protowire.Number, typ protowire.Type, value []byte) error {
+				if typ != protowire.BytesType {
+					return nil
+				}
+				switch num {
+				case 1:
+					labelName = string(value)
+				case 2:
+					labelValue = string(value)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if labelName == "__name__" {
+				name = labelValue
+			} else {
+				labels[labelName] = labelValue
+			}
+		case 2:
+			// message Sample { double value = 1; int64 timestamp = 2; }
+			var p point
+			err := readFields(value, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+				switch {
+				case num == 1 && typ == protowire.Fixed64Type:
+					v, n := protowire.ConsumeFixed64(b)
+					p.value = math.Float64frombits(v)
+					return n, protowire.ParseError(n)
+				case num == 2 && typ == protowire.VarintType:
+					v, n := protowire.ConsumeVarint(b)
+					p.timestamp = int64(v)
+					return n, protowire.ParseError(n)
+				}
+				n := protowire.ConsumeFieldValue(num, typ, b)
+				return n, protowire.ParseError(n)
+			})
+			if err != nil {
+				return err
+			}
+			points = append(points, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, fmt.Errorf("time series without metric name")
+	}
+
+	samples := make([]Sample, 0, len(points))
+	for _, p := range points {
+		// remote write uses a special NaN as staleness marker
+		if math.IsNaN(p.value) || math.IsInf(p.value, 0) {
+			continue
+		}
+		samples = append(samples, Sample{Name: name, Labels: labels, Value: p.value, Timestamp: p.timestamp})
+	}
+	return samples, nil
+}
+
+// readMessage calls f for every length delimited or skipped field of the message, f gets the field content
+func readMessage(data []byte, f func(num protowire.Number, typ protowire.Type, value []byte) error) error {
+	return readFields(data, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if typ == protowire.BytesType {
+			value, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, protowire.ParseError(n)
+			}
+			return n, f(num, typ, value)
+		}
+		n := protowire.ConsumeFieldValue(num, typ, b)
+		return n, protowire.ParseError(n)
+	})
+}
+
+// readFields calls f for every field of the message, f has to consume the field value and return its length
+func readFields(data []byte, f func(num protowire.Number, typ protowire.Type, b []byte) (int, error)) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+		n, err := f(num, typ, data)
+		if err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+// ParseText parses metrics in the prometheus text exposition format or, if openMetrics is set, in the OpenMetrics text format.
+// The formats only differ in the unit of the optional timestamps, which are seconds in OpenMetrics and milliseconds otherwise.
+// Requests with more than maxSeries series are rejected.
+func ParseText(r io.Reader, openMetrics bool, maxSeries int) ([]Sample, error) {
+	var samples []Sample
+	limiter := newSeriesLimiter(maxSeries)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			// HELP, TYPE, UNIT and EOF lines aren't needed, the client mappings know the metric types
+			continue
+		}
+		sample, err := parseSampleLine(line, openMetrics)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		if math.IsNaN(sample.Value) || math.IsInf(sample.Value, 0) {
+			continue
+		}
+		if err := limiter.add(sample); err != nil {
+			return nil, err
+		}
+		samples = append(samples, sample)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
+
+func parseSampleLine(line string, openMetrics bool) (Sample, error) {
+	sample := Sample{Labels: make(map[string]string)}
+	end := strings.IndexAny(line, "{ \t")
+	if end <= 0 {
+		return sample, fmt.Errorf("invalid sample %q", line)
+	}
+	sample.Name = line[:end]
+	rest := line[end:]
+
+	if rest[0] == '{' {
+		var err error
+		rest, err = parseLabels(rest[1:], sample.Labels)
+		if err != nil {
+			return sample, err
+		}
+	}
+
+	if openMetrics {
+		// exemplars are separated by a hash
+		if i := strings.Index(rest, "#"); i >= 0 {
+			rest = rest[:i]
+		}
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return sample, fmt.Errorf("invalid value of sample %s", sample.Name)
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return sample, fmt.Errorf("invalid value of sample %s: %w", sample.Name, err)
+	}
+	sample.Value = value
+	if len(fields) == 2 {
+		if openMetrics {
+			ts, err := strconv.ParseFloat(fields[1], 64)
+			if err != nil {
+				return sample, fmt.Errorf("invalid timestamp of sample %s: %w", sample.Name, err)
+			}
+			sample.Timestamp = int64(ts * 1000)
+		} else {
+			sample.Timestamp, err = strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return sample, fmt.Errorf("invalid timestamp of sample %s: %w", sample.Name, err)
+			}
+		}
+	}
+	return sample, nil
+}
+
+// parseLabels parses the labels following the opening brace into labels and returns the rest of the line after the closing brace
+func parseLabels(s string, labels map[string]string) (string, error) {
+	for {
+		s = strings.TrimLeft(s, " \t")
+		if s == "" {
+			return "", fmt.Errorf("unterminated label set")
+		}
+		if s[0] == '}' {
+			return s[1:], nil
+		}
+		eq := strings.IndexByte(s, '=')
+		if eq <= 0 {
+			return "", fmt.Errorf("invalid label in %q", s)
+		}
+		name := strings.TrimSpace(s[:eq])
+		s = strings.TrimLeft(s[eq+1:], " \t")
+		if s == "" || s[0] != '"' {
+			return "", fmt.Errorf("label %s has no quoted value", name)
+		}
+
+		var value strings.Builder
+		i := 1
+		for ; i < len(s) && s[i] != '"'; i++ {
+			if s[i] != '\\' || i+1 == len(s) {
+				value.WriteByte(s[i])
+				continue
+			}
+			i++
+			switch s[i] {
+			case 'n':
+				value.WriteByte('\n')
+			default:
+				// \\ and \"
+				value.WriteByte(s[i])
+			}
+		}
+		if i == len(s) {
+			return "", fmt.Errorf("unterminated value of label %s", name)
+		}
+		labels[name] = value.String()
+		s = strings.TrimLeft(s[i+1:], " \t")
+		if strings.HasPrefix(s, ",") {
+			s = s[1:]
+		}
+	}
+}
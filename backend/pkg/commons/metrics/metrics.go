@@ -78,6 +78,23 @@ var (
 		Name: "counter",
 		Help: "Generic counter of events with name in labels",
 	}, []string{"name"})
+	ConsensusNodeRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "consensus_node_requests_total",
+		Help: "Total number of requests to consensus nodes by endpoint, method and status.",
+	}, []string{"endpoint", "method", "status"})
+	ConsensusNodeRequestsDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "consensus_node_requests_duration",
+		Help:    "Duration of requests to consensus nodes in seconds by endpoint and method.",
+		Buckets: []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 120, 300},
+	}, []string{"endpoint", "method"})
+	ConsensusNodeHealthy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "consensus_node_healthy",
+		Help: "Gauge that is 1 if the consensus node at endpoint passed its last health check, 0 otherwise",
+	}, []string{"endpoint"})
+	ConsensusNodeSyncDistance = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "consensus_node_sync_distance",
+		Help: "Sync distance in slots as reported by the consensus node at endpoint",
+	}, []string{"endpoint"})
 )
 
 func init() {
@@ -128,6 +145,11 @@ func (r *responseWriterDelegator) Write(b []byte) (int, error) {
 	return n, err
 }
 
+// Unwrap allows http.ResponseController to flush streamed responses through the delegator
+func (r *responseWriterDelegator) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 // Serve serves prometheus metrics on the given address under /metrics
 func Serve(addr string, servePprof bool, enableExtraPprof bool) error {
 	router := http.NewServeMux()
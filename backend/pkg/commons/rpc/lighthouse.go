@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"bytes"
+	"context"
 	"net/http"
 
 	"fmt"
@@ -26,14 +27,12 @@ import (
 	"github.com/prysmaticlabs/go-bitfield"
 )
 
-// TODO replace most lc.cl.Endpoint with lc.cl and use the interface
-
 // LighthouseLatestHeadEpoch is used to cache the latest head epoch for participation requests
 var LighthouseLatestHeadEpoch uint64 = 0
 
 // LighthouseClient holds the Lighthouse client info
 type LighthouseClient struct {
-	cl                  *consapi.NodeClient
+	cl                  consapi.Client
 	assignmentsCache    *lru.Cache
 	assignmentsCacheMux *sync.Mutex
 	slotsCache          *lru.Cache
@@ -41,8 +40,9 @@ type LighthouseClient struct {
 	signer              gethtypes.Signer
 }
 
-// NewLighthouseClient is used to create a new Lighthouse client
-func NewLighthouseClient(cl *consapi.NodeClient, chainID *big.Int) (*LighthouseClient, error) {
+// NewLighthouseClient is used to create a new Lighthouse client.
+// Standard beacon api requests fail over to other nodes if cl is a multi node client.
+func NewLighthouseClient(cl consapi.Client, chainID *big.Int) (*LighthouseClient, error) {
 	signer := gethtypes.NewCancunSigner(chainID)
 	client := &LighthouseClient{
 		cl:                  cl,
@@ -59,7 +59,7 @@ func NewLighthouseClient(cl *consapi.NodeClient, chainID *big.Int) (*LighthouseC
 func (lc *LighthouseClient) GetNewBlockChan() chan *types.Block {
 	blkCh := make(chan *types.Block, 10)
 	go func() {
-		res := lc.cl.GetEvents([]constypes.EventTopic{constypes.EventHead})
+		res := lc.cl.GetEvents(context.Background(), []constypes.EventTopic{constypes.EventHead})
 
 		for event := range res {
 			if event.Error != nil {
@@ -92,7 +92,7 @@ func (lc *LighthouseClient) GetNewBlockChan() chan *types.Block {
 // GetChainHead gets the chain head from Lighthouse
 // Deprecated: Use retriever.GetChainHead() instead
 func (lc *LighthouseClient) GetChainHead() (*types.ChainHead, error) {
-	parsedHead, err := lc.cl.GetBlockHeader("head")
+	parsedHead, err := lc.cl.GetBlockHeader(context.Background(), "head")
 	if err != nil {
 		return &types.ChainHead{}, err
 	}
@@ -102,7 +102,7 @@ func (lc *LighthouseClient) GetChainHead() (*types.ChainHead, error) {
 		id = "genesis"
 	}
 
-	parsedFinality, err := lc.cl.GetFinalityCheckpoints(id)
+	parsedFinality, err := lc.cl.GetFinalityCheckpoints(context.Background(), id)
 	if err != nil {
 		return &types.ChainHead{}, err
 	}
@@ -135,7 +135,7 @@ func (lc *LighthouseClient) GetChainHead() (*types.ChainHead, error) {
 
 func (lc *LighthouseClient) GetValidatorQueue() (*types.ValidatorQueue, error) {
 	// pre-filter the status, to return much less validators, thus much faster!
-	parsedValidators, err := lc.cl.GetValidators("head", nil, []constypes.ValidatorStatus{constypes.PendingQueued, constypes.ActiveExiting, constypes.ActiveSlashed})
+	parsedValidators, err := lc.cl.GetValidators(context.Background(), "head", nil, []constypes.ValidatorStatus{constypes.PendingQueued, constypes.ActiveExiting, constypes.ActiveSlashed})
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving validator for head valiqdator queue check: %w", err)
 	}
@@ -164,19 +164,19 @@ func (lc *LighthouseClient) GetEpochAssignments(epoch uint64) (*types.EpochAssig
 	}
 	lc.assignmentsCacheMux.Unlock()
 
-	parsedProposerResponse, err := lc.cl.GetPropoalAssignments(epoch)
+	parsedProposerResponse, err := lc.cl.GetPropoalAssignments(context.Background(), epoch)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving proposer duties for epoch %v: %w", epoch, err)
 	}
 
 	// fetch the block root that the proposer data is dependent on
-	parsedHeader, err := lc.cl.GetBlockHeader(parsedProposerResponse.DependentRoot)
+	parsedHeader, err := lc.cl.GetBlockHeader(context.Background(), parsedProposerResponse.DependentRoot)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving proposer duties dependent header for epoch %v: %w", epoch, err)
 	}
 	depStateRoot := parsedHeader.Data.Header.Message.StateRoot.String()
 
-	parsedCommittees, err := lc.cl.GetCommittees(depStateRoot, &epoch, nil, nil)
+	parsedCommittees, err := lc.cl.GetCommittees(context.Background(), depStateRoot, &epoch, nil, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving committees data: %w", err)
 	}
@@ -228,13 +228,13 @@ func (lc *LighthouseClient) GetEpochAssignments(epoch uint64) (*types.EpochAssig
 // GetEpochProposerAssignments will get the epoch proposer assignments from Lighthouse RPC api
 // Deprecated: use cl retriever GetPropoalAssignments
 func (lc *LighthouseClient) GetEpochProposerAssignments(epoch uint64) (*constypes.StandardProposerAssignmentsResponse, error) {
-	return lc.cl.GetPropoalAssignments(epoch)
+	return lc.cl.GetPropoalAssignments(context.Background(), epoch)
 }
 
 func (lc *LighthouseClient) GetValidatorState(epoch uint64) (*constypes.StandardValidatorsResponse, error) {
-	parsedValidators, err := lc.cl.GetValidators(epoch*utils.Config.Chain.ClConfig.SlotsPerEpoch, nil, nil)
+	parsedValidators, err := lc.cl.GetValidators(context.Background(), epoch*utils.Config.Chain.ClConfig.SlotsPerEpoch, nil, nil)
 	if err != nil && epoch == 0 {
-		parsedValidators, err = lc.cl.GetValidators("genesis", nil, nil)
+		parsedValidators, err = lc.cl.GetValidators(context.Background(), "genesis", nil, nil)
 		if err != nil {
 			return nil, fmt.Errorf("error retrieving validators for genesis: %w", err)
 		}
@@ -507,9 +507,9 @@ func (lc *LighthouseClient) GetBalancesForEpoch(epoch int64) (map[uint64]uint64,
 
 	validatorBalances := make(map[uint64]uint64)
 
-	parsedResponse, err := lc.cl.GetValidatorBalances(epoch * int64(utils.Config.Chain.ClConfig.SlotsPerEpoch))
+	parsedResponse, err := lc.cl.GetValidatorBalances(context.Background(), epoch*int64(utils.Config.Chain.ClConfig.SlotsPerEpoch))
 	if err != nil && epoch == 0 {
-		parsedResponse, err = lc.cl.GetValidatorBalances("genesis")
+		parsedResponse, err = lc.cl.GetValidatorBalances(context.Background(), "genesis")
 		if err != nil {
 			return validatorBalances, err
 		}
@@ -525,7 +525,7 @@ func (lc *LighthouseClient) GetBalancesForEpoch(epoch int64) (map[uint64]uint64,
 }
 
 func (lc *LighthouseClient) GetBlockByBlockroot(blockroot []byte) (*types.Block, error) {
-	parsedHeaders, err := lc.cl.GetBlockHeader(fmt.Sprintf("0x%x", blockroot))
+	parsedHeaders, err := lc.cl.GetBlockHeader(context.Background(), fmt.Sprintf("0x%x", blockroot))
 	if err != nil {
 		httpErr := network.SpecificError(err)
 		if httpErr != nil && httpErr.StatusCode == http.StatusNotFound {
@@ -537,7 +537,7 @@ func (lc *LighthouseClient) GetBlockByBlockroot(blockroot []byte) (*types.Block,
 
 	slot := parsedHeaders.Data.Header.Message.Slot
 
-	parsedResponse, err := lc.cl.GetSlot(parsedHeaders.Data.Root.String())
+	parsedResponse, err := lc.cl.GetSlot(context.Background(), parsedHeaders.Data.Root.String())
 	if err != nil {
 		log.Error(err, "error parsing block data for slot", 0, map[string]interface{}{"slot": parsedHeaders.Data.Header.Message.Slot})
 		return nil, fmt.Errorf("error retrieving block data at slot %v: %w", slot, err)
@@ -548,10 +548,10 @@ func (lc *LighthouseClient) GetBlockByBlockroot(blockroot []byte) (*types.Block,
 
 // GetBlockHeader will get the block header by slot from Lighthouse RPC api
 func (lc *LighthouseClient) GetBlockHeader(slot uint64) (*constypes.StandardBeaconHeaderResponse, error) {
-	parsedHeaders, err := lc.cl.GetBlockHeader(slot)
+	parsedHeaders, err := lc.cl.GetBlockHeader(context.Background(), slot)
 
 	if err != nil && slot == 0 {
-		parsedHeader, err := lc.cl.GetBlockHeaders(nil, nil)
+		parsedHeader, err := lc.cl.GetBlockHeaders(context.Background(), nil, nil)
 		if err != nil {
 			return nil, fmt.Errorf("error retrieving chain head for slot %v: %w", slot, err)
 		}
@@ -666,7 +666,7 @@ func (lc *LighthouseClient) GetBlockBySlot(slot uint64) (*types.Block, error) {
 	}
 	lc.slotsCacheMux.Unlock()
 
-	parsedResponse, err := lc.cl.GetSlot(parsedHeaders.Data.Root.String())
+	parsedResponse, err := lc.cl.GetSlot(context.Background(), parsedHeaders.Data.Root.String())
 	if err != nil && slot == 0 {
 		log.Error(err, "error parsing block data for slot", 0, map[string]interface{}{"slot": parsedHeaders.Data.Header.Message.Slot})
 
@@ -930,9 +930,12 @@ func (lc *LighthouseClient) blockFromResponse(parsedHeaders *constypes.StandardB
 		}
 	}
 
+	// committee sizes are only needed to split the aggregation bits of electra attestations
+	committeeSizes := make(map[[2]uint64]uint64)
 	for i, attestation := range parsedBlock.Message.Body.Attestations {
 		a := &types.Attestation{
 			AggregationBits: attestation.AggregationBits,
+			CommitteeBits:   attestation.CommitteeBits,
 			Attesters:       []uint64{},
 			Data: &types.AttestationData{
 				Slot:            attestation.Data.Slot,
@@ -956,21 +959,38 @@ func (lc *LighthouseClient) blockFromResponse(parsedHeaders *constypes.StandardB
 			return nil, fmt.Errorf("error receiving epoch assignment for epoch %v: %w", a.Data.Slot/utils.Config.Chain.ClConfig.SlotsPerEpoch, err)
 		}
 
-		for i := uint64(0); i < aggregationBits.Len(); i++ {
-			if aggregationBits.BitAt(i) {
-				validator, found := assignments.AttestorAssignments[utils.FormatAttestorAssignmentKey(a.Data.Slot, uint64(a.Data.CommitteeIndex), i)]
-				if !found { // This should never happen!
-					validator = 0
-					log.Fatal(fmt.Errorf("error retrieving assigned validator for attestation %v of block %v for slot %v committee index %v member index %v", i, block.Slot, a.Data.Slot, a.Data.CommitteeIndex, i), "", 0)
-				}
-				a.Attesters = append(a.Attesters, validator)
+		// pre electra an attestation covers exactly one committee, after electra the committee bits select the
+		// covered committees and the aggregation bits of all of them are concatenated
+		committees := []uint64{uint64(a.Data.CommitteeIndex)}
+		if len(a.CommitteeBits) > 0 {
+			committees = committees[:0]
+			for _, committeeIndex := range bitfield.Bitvector64(a.CommitteeBits).BitIndices() {
+				committees = append(committees, uint64(committeeIndex))
+			}
+		}
 
-				if block.AttestationDuties[types.ValidatorIndex(validator)] == nil {
-					block.AttestationDuties[types.ValidatorIndex(validator)] = []types.Slot{types.Slot(a.Data.Slot)}
-				} else {
-					block.AttestationDuties[types.ValidatorIndex(validator)] = append(block.AttestationDuties[types.ValidatorIndex(validator)], types.Slot(a.Data.Slot))
+		offset := uint64(0)
+		for _, committeeIndex := range committees {
+			committeeSize := aggregationBits.Len()
+			if len(a.CommitteeBits) > 0 {
+				committeeSize = getCommitteeSize(assignments, committeeSizes, a.Data.Slot, committeeIndex)
+			}
+			for j := uint64(0); j < committeeSize && offset+j < aggregationBits.Len(); j++ {
+				if aggregationBits.BitAt(offset + j) {
+					validator, found := assignments.AttestorAssignments[utils.FormatAttestorAssignmentKey(a.Data.Slot, committeeIndex, j)]
+					if !found { // This should never happen!
+						return nil, fmt.Errorf("error retrieving assigned validator for attestation %v of block %v for slot %v committee index %v member index %v", i, block.Slot, a.Data.Slot, committeeIndex, j)
+					}
+					a.Attesters = append(a.Attesters, validator)
+
+					if block.AttestationDuties[types.ValidatorIndex(validator)] == nil {
+						block.AttestationDuties[types.ValidatorIndex(validator)] = []types.Slot{types.Slot(a.Data.Slot)}
+					} else {
+						block.AttestationDuties[types.ValidatorIndex(validator)] = append(block.AttestationDuties[types.ValidatorIndex(validator)], types.Slot(a.Data.Slot))
+					}
 				}
 			}
+			offset += committeeSize
 		}
 
 		block.Attestations[i] = a
@@ -1007,9 +1027,55 @@ func (lc *LighthouseClient) blockFromResponse(parsedHeaders *constypes.StandardB
 		}
 	}
 
+	if requests := parsedBlock.Message.Body.ExecutionRequests; requests != nil {
+		block.DepositRequests = make([]*types.DepositRequest, len(requests.Deposits))
+		for i, deposit := range requests.Deposits {
+			block.DepositRequests[i] = &types.DepositRequest{
+				PublicKey:             deposit.Pubkey,
+				WithdrawalCredentials: deposit.WithdrawalCredentials,
+				Amount:                deposit.Amount,
+				Signature:             deposit.Signature,
+				Index:                 deposit.Index,
+			}
+		}
+		block.WithdrawalRequests = make([]*types.WithdrawalRequest, len(requests.Withdrawals))
+		for i, withdrawal := range requests.Withdrawals {
+			block.WithdrawalRequests[i] = &types.WithdrawalRequest{
+				SourceAddress:   withdrawal.SourceAddress,
+				ValidatorPubkey: withdrawal.ValidatorPubkey,
+				Amount:          withdrawal.Amount,
+			}
+		}
+		block.ConsolidationRequests = make([]*types.ConsolidationRequest, len(requests.Consolidations))
+		for i, consolidation := range requests.Consolidations {
+			block.ConsolidationRequests[i] = &types.ConsolidationRequest{
+				SourceAddress: consolidation.SourceAddress,
+				SourcePubkey:  consolidation.SourcePubkey,
+				TargetPubkey:  consolidation.TargetPubkey,
+			}
+		}
+	}
+
 	return block, nil
 }
 
+// getCommitteeSize returns the number of members of a committee by probing the attestor assignments, sizes are cached per (slot, committee)
+func getCommitteeSize(assignments *types.EpochAssignments, cache map[[2]uint64]uint64, slot, committeeIndex uint64) uint64 {
+	key := [2]uint64{slot, committeeIndex}
+	if size, ok := cache[key]; ok {
+		return size
+	}
+	size := uint64(0)
+	for {
+		if _, found := assignments.AttestorAssignments[utils.FormatAttestorAssignmentKey(slot, committeeIndex, size)]; !found {
+			break
+		}
+		size++
+	}
+	cache[key] = size
+	return size
+}
+
 func syncCommitteeParticipation(bits []byte) float64 {
 	participating := 0
 	for i := 0; i < int(utils.Config.Chain.ClConfig.SyncCommitteeSize); i++ {
@@ -1020,6 +1086,34 @@ func syncCommitteeParticipation(bits []byte) float64 {
 	return float64(participating) / float64(utils.Config.Chain.ClConfig.SyncCommitteeSize)
 }
 
+// lighthouseEndpoints returns the endpoints of the nodes lighthouse specific requests are sent to, in the configured order
+func (lc *LighthouseClient) lighthouseEndpoints() []string {
+	switch cl := lc.cl.ClientInt.(type) {
+	case *consapi.NodeClient:
+		return []string{cl.Endpoint}
+	case *consapi.MultiNodeClient:
+		endpoints := make([]string, 0, len(cl.Nodes()))
+		for _, node := range cl.Nodes() {
+			endpoints = append(endpoints, node.Endpoint)
+		}
+		return endpoints
+	}
+	return nil
+}
+
+// getValidatorInclusion gets the global validator inclusion data of an epoch, falling back to the next node on errors
+func (lc *LighthouseClient) getValidatorInclusion(epoch uint64) (*LighthouseValidatorParticipationResponse, error) {
+	err := fmt.Errorf("no lighthouse node available")
+	for _, endpoint := range lc.lighthouseEndpoints() {
+		var res *LighthouseValidatorParticipationResponse
+		res, err = network.Get[LighthouseValidatorParticipationResponse](context.Background(), nil, fmt.Sprintf("%s/lighthouse/validator_inclusion/%d/global", endpoint, epoch))
+		if err == nil {
+			return res, nil
+		}
+	}
+	return nil, err
+}
+
 // GetValidatorParticipation will get the validator participation from the Lighthouse RPC api
 func (lc *LighthouseClient) GetValidatorParticipation(epoch uint64) (*types.ValidatorParticipation, error) {
 	head, err := lc.GetChainHead()
@@ -1044,7 +1138,7 @@ func (lc *LighthouseClient) GetValidatorParticipation(epoch uint64) (*types.Vali
 
 	log.Infof("requesting validator inclusion data for epoch %v", request_epoch)
 
-	parsedResponse, err := network.Get[LighthouseValidatorParticipationResponse](nil, fmt.Sprintf("%s/lighthouse/validator_inclusion/%d/global", lc.cl.Endpoint, request_epoch))
+	parsedResponse, err := lc.getValidatorInclusion(request_epoch)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving validator participation data for epoch %v: %w", request_epoch, err)
 	}
@@ -1056,7 +1150,7 @@ func (lc *LighthouseClient) GetValidatorParticipation(epoch uint64) (*types.Vali
 		prevEpochActiveGwei := parsedResponse.Data.PreviousEpochActiveGwei
 		if prevEpochActiveGwei == 0 {
 			// lh@5.2.0+ has no previous_epoch_active_gwei field anymore, see https://github.com/sigp/lighthouse/pull/5279
-			parsedPrevResponse, err := network.Get[LighthouseValidatorParticipationResponse](nil, fmt.Sprintf("%s/lighthouse/validator_inclusion/%d/global", lc.cl.Endpoint, request_epoch-1))
+			parsedPrevResponse, err := lc.getValidatorInclusion(request_epoch - 1)
 			if err != nil {
 				return nil, fmt.Errorf("error retrieving validator participation data for prevEpoch %v: %w", request_epoch-1, err)
 			}
@@ -1083,7 +1177,7 @@ func (lc *LighthouseClient) GetValidatorParticipation(epoch uint64) (*types.Vali
 }
 
 func (lc *LighthouseClient) GetSyncCommittee(stateID string, epoch uint64) (*constypes.StandardSyncCommittee, error) {
-	parsedSyncCommittees, err := lc.cl.GetSyncCommitteesAssignments(&epoch, stateID)
+	parsedSyncCommittees, err := lc.cl.GetSyncCommitteesAssignments(context.Background(), &epoch, stateID)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving sync_committees for epoch %v (state: %v): %w", epoch, stateID, err)
 	}
@@ -1092,7 +1186,7 @@ func (lc *LighthouseClient) GetSyncCommittee(stateID string, epoch uint64) (*con
 }
 
 func (lc *LighthouseClient) GetBlobSidecars(stateID string) (*constypes.StandardBlobSidecarsResponse, error) {
-	return lc.cl.GetBlobSidecars(stateID)
+	return lc.cl.GetBlobSidecars(context.Background(), stateID)
 }
 
 type LighthouseValidatorParticipationResponse struct {
@@ -0,0 +1,230 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gcp_bigtable "cloud.google.com/go/bigtable"
+	"google.golang.org/api/option"
+)
+
+type bigtableStore struct {
+	client   *gcp_bigtable.Client
+	project  string
+	instance string
+}
+
+// NewBigtableStore connects to the Bigtable instance, set BIGTABLE_EMULATOR_HOST to use the emulator
+func NewBigtableStore(ctx context.Context, project, instance string, opts ...option.ClientOption) (Store, error) {
+	client, err := gcp_bigtable.NewClient(ctx, project, instance, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &bigtableStore{
+		client:   client,
+		project:  project,
+		instance: instance,
+	}, nil
+}
+
+func (s *bigtableStore) Table(name string) Table {
+	return &bigtableTable{table: s.client.Open(name)}
+}
+
+func (s *bigtableStore) Tables(ctx context.Context) ([]string, error) {
+	admin, err := gcp_bigtable.NewAdminClient(ctx, s.project, s.instance)
+	if err != nil {
+		return nil, err
+	}
+	defer admin.Close()
+	return admin.Tables(ctx)
+}
+
+func (s *bigtableStore) CreateTable(ctx context.Context, name string, families map[string]GCPolicy) error {
+	admin, err := gcp_bigtable.NewAdminClient(ctx, s.project, s.instance)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	err = admin.CreateTable(ctx, name)
+	if err != nil {
+		return err
+	}
+	for family, policy := range families {
+		err := admin.CreateColumnFamily(ctx, name, family)
+		if err != nil {
+			return err
+		}
+		if policy == nil {
+			continue
+		}
+		gcPolicy, err := toBigtableGCPolicy(policy)
+		if err != nil {
+			return err
+		}
+		err = admin.SetGCPolicy(ctx, name, family, gcPolicy)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *bigtableStore) Close() error {
+	return s.client.Close()
+}
+
+type bigtableTable struct {
+	table *gcp_bigtable.Table
+}
+
+func (t *bigtableTable) ReadRow(ctx context.Context, row string, opts ...ReadOption) (Row, error) {
+	var result Row
+	err := t.ReadRows(ctx, RowList{row}, func(r Row) bool {
+		result = r
+		return true
+	}, opts...)
+	return result, err
+}
+
+func (t *bigtableTable) ReadRows(ctx context.Context, arg RowSet, f func(Row) bool, opts ...ReadOption) error {
+	settings := newReadSettings(opts)
+	var readOpts []gcp_bigtable.ReadOption
+	if settings.filter != nil {
+		filter, err := toBigtableFilter(settings.filter)
+		if err != nil {
+			return err
+		}
+		readOpts = append(readOpts, gcp_bigtable.RowFilter(filter))
+	}
+	if settings.limit > 0 {
+		readOpts = append(readOpts, gcp_bigtable.LimitRows(settings.limit))
+	}
+	return t.table.ReadRows(ctx, toBigtableRowSet(arg), func(r gcp_bigtable.Row) bool {
+		return f(fromBigtableRow(r))
+	}, readOpts...)
+}
+
+func (t *bigtableTable) Apply(ctx context.Context, row string, m *Mutation) error {
+	return t.table.Apply(ctx, row, toBigtableMutation(m))
+}
+
+func (t *bigtableTable) ApplyBulk(ctx context.Context, rowKeys []string, muts []*Mutation) ([]error, error) {
+	bigtableMuts := make([]*gcp_bigtable.Mutation, len(muts))
+	for i, m := range muts {
+		bigtableMuts[i] = toBigtableMutation(m)
+	}
+	return t.table.ApplyBulk(ctx, rowKeys, bigtableMuts)
+}
+
+func toBigtableRowSet(arg RowSet) gcp_bigtable.RowSet {
+	switch arg := arg.(type) {
+	case RowList:
+		return gcp_bigtable.RowList(arg)
+	case RowRange:
+		return toBigtableRowRange(arg)
+	case RowRangeList:
+		ranges := make(gcp_bigtable.RowRangeList, len(arg))
+		for i, r := range arg {
+			ranges[i] = toBigtableRowRange(r)
+		}
+		return ranges
+	}
+	// all row sets are defined in this package
+	panic(fmt.Sprintf("unsupported row set %T", arg))
+}
+
+func toBigtableRowRange(r RowRange) gcp_bigtable.RowRange {
+	if r.Unbounded() {
+		return gcp_bigtable.InfiniteRange(r.start)
+	}
+	return gcp_bigtable.NewRange(r.start, r.end)
+}
+
+func toBigtableFilter(f Filter) (gcp_bigtable.Filter, error) {
+	switch f := f.(type) {
+	case chainFilter:
+		sub, err := toBigtableFilters(f.sub)
+		return gcp_bigtable.ChainFilters(sub...), err
+	case interleaveFilter:
+		sub, err := toBigtableFilters(f.sub)
+		return gcp_bigtable.InterleaveFilters(sub...), err
+	case regexpFilter:
+		if f.err != nil {
+			return nil, f.err
+		}
+		if f.name == "family" {
+			return gcp_bigtable.FamilyFilter(f.pattern), nil
+		}
+		return gcp_bigtable.ColumnFilter(f.pattern), nil
+	case latestNFilter:
+		return gcp_bigtable.LatestNFilter(int(f)), nil
+	case stripValueFilter:
+		return gcp_bigtable.StripValueFilter(), nil
+	case timestampRangeFilter:
+		return gcp_bigtable.TimestampRangeFilterMicros(gcp_bigtable.Timestamp(f.start), gcp_bigtable.Timestamp(f.end)), nil
+	case cellsPerRowOffsetFilter:
+		return gcp_bigtable.CellsPerRowOffsetFilter(int(f)), nil
+	}
+	return nil, fmt.Errorf("unsupported filter %v", f)
+}
+
+func toBigtableFilters(filters []Filter) ([]gcp_bigtable.Filter, error) {
+	result := make([]gcp_bigtable.Filter, len(filters))
+	for i, f := range filters {
+		var err error
+		result[i], err = toBigtableFilter(f)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func toBigtableMutation(m *Mutation) *gcp_bigtable.Mutation {
+	mut := gcp_bigtable.NewMutation()
+	for _, op := range m.ops {
+		switch op.kind {
+		case mutationSet:
+			mut.Set(op.family, op.column, gcp_bigtable.Timestamp(op.ts), op.value)
+		case mutationDeleteColumn:
+			mut.DeleteCellsInColumn(op.family, op.column)
+		case mutationDeleteTimestampRange:
+			mut.DeleteTimestampRange(op.family, op.column, gcp_bigtable.Timestamp(op.ts), gcp_bigtable.Timestamp(op.end))
+		case mutationDeleteFamily:
+			mut.DeleteCellsInFamily(op.family)
+		case mutationDeleteRow:
+			mut.DeleteRow()
+		}
+	}
+	return mut
+}
+
+func fromBigtableRow(r gcp_bigtable.Row) Row {
+	row := make(Row, len(r))
+	for family, items := range r {
+		readItems := make([]ReadItem, len(items))
+		for i, item := range items {
+			readItems[i] = ReadItem{
+				Row:       item.Row,
+				Column:    item.Column,
+				Timestamp: Timestamp(item.Timestamp),
+				Value:     item.Value,
+			}
+		}
+		row[family] = readItems
+	}
+	return row
+}
+
+func toBigtableGCPolicy(policy GCPolicy) (gcp_bigtable.GCPolicy, error) {
+	switch policy := policy.(type) {
+	case maxVersionsPolicy:
+		return gcp_bigtable.MaxVersionsGCPolicy(int(policy)), nil
+	case maxAgePolicy:
+		return gcp_bigtable.MaxAgeGCPolicy(time.Duration(policy)), nil
+	}
+	return nil, fmt.Errorf("unsupported gc policy %v", policy)
+}
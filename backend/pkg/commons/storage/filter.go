@@ -0,0 +1,282 @@
+package storage
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// cell is a single versioned value of a row, the unit filters operate on
+type cell struct {
+	family string
+	column string
+	ts     Timestamp
+	value  []byte
+}
+
+// Filter selects the cells of a row that are returned by a read
+type Filter interface {
+	String() string
+	// filterCells applies the filter to the cells of a row, which are sorted by family, column and descending timestamp
+	filterCells(cells []cell) ([]cell, error)
+}
+
+type chainFilter struct {
+	sub []Filter
+}
+
+// ChainFilters applies the filters in sequence, each one to the output of the previous one
+func ChainFilters(sub ...Filter) Filter {
+	return chainFilter{sub: sub}
+}
+
+func (f chainFilter) String() string {
+	return "(" + joinFilters(f.sub, " | ") + ")"
+}
+
+func (f chainFilter) filterCells(cells []cell) ([]cell, error) {
+	var err error
+	for _, sub := range f.sub {
+		cells, err = sub.filterCells(cells)
+		if err != nil || len(cells) == 0 {
+			return cells, err
+		}
+	}
+	return cells, nil
+}
+
+type interleaveFilter struct {
+	sub []Filter
+}
+
+// InterleaveFilters applies the filters to the row independently and returns the union of their output.
+// Like in Bigtable, a cell passing several filters is returned several times.
+func InterleaveFilters(sub ...Filter) Filter {
+	return interleaveFilter{sub: sub}
+}
+
+func (f interleaveFilter) String() string {
+	return "(" + joinFilters(f.sub, " + ") + ")"
+}
+
+func (f interleaveFilter) filterCells(cells []cell) ([]cell, error) {
+	var result []cell
+	for _, sub := range f.sub {
+		// filters may modify the cells they get, e.g. by stripping values
+		input := make([]cell, len(cells))
+		copy(input, cells)
+		output, err := sub.filterCells(input)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, output...)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return lessCell(result[i], result[j])
+	})
+	return result, nil
+}
+
+type regexpFilter struct {
+	name    string
+	pattern string
+	re      *regexp.Regexp
+	err     error
+	value   func(c cell) string
+}
+
+func newRegexpFilter(name, pattern string, value func(c cell) string) regexpFilter {
+	// patterns have to match the whole value, like in Bigtable
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		err = fmt.Errorf("invalid %s pattern %q: %w", name, pattern, err)
+	}
+	return regexpFilter{name: name, pattern: pattern, re: re, err: err, value: value}
+}
+
+func (f regexpFilter) String() string {
+	return fmt.Sprintf("%s(%s)", f.name, f.pattern)
+}
+
+func (f regexpFilter) filterCells(cells []cell) ([]cell, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	result := cells[:0]
+	for _, c := range cells {
+		if f.re.MatchString(f.value(c)) {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+// FamilyFilter keeps the cells of the column families matching the regular expression
+func FamilyFilter(pattern string) Filter {
+	return newRegexpFilter("family", pattern, func(c cell) string { return c.family })
+}
+
+// ColumnFilter keeps the cells of the columns whose qualifier matches the regular expression
+func ColumnFilter(pattern string) Filter {
+	return newRegexpFilter("col", pattern, func(c cell) string { return c.column })
+}
+
+type latestNFilter int
+
+// LatestNFilter keeps the n newest cells of each column
+func LatestNFilter(n int) Filter {
+	return latestNFilter(n)
+}
+
+func (f latestNFilter) String() string {
+	return fmt.Sprintf("col(*,%d)", int(f))
+}
+
+func (f latestNFilter) filterCells(cells []cell) ([]cell, error) {
+	result := cells[:0]
+	count := 0
+	for i, c := range cells {
+		if i == 0 || c.family != cells[i-1].family || c.column != cells[i-1].column {
+			count = 0
+		}
+		if count < int(f) {
+			result = append(result, c)
+		}
+		count++
+	}
+	return result, nil
+}
+
+type stripValueFilter struct{}
+
+// StripValueFilter replaces the values of all cells with empty values
+func StripValueFilter() Filter {
+	return stripValueFilter{}
+}
+
+func (stripValueFilter) String() string {
+	return "strip_value()"
+}
+
+func (stripValueFilter) filterCells(cells []cell) ([]cell, error) {
+	for i := range cells {
+		cells[i].value = nil
+	}
+	return cells, nil
+}
+
+type timestampRangeFilter struct {
+	start Timestamp
+	end   Timestamp
+}
+
+// TimestampRangeFilterMicros keeps the cells with start <= timestamp < end, an end of 0 means no upper bound
+func TimestampRangeFilterMicros(start Timestamp, end Timestamp) Filter {
+	return timestampRangeFilter{start: start, end: end}
+}
+
+// TimestampRangeFilter keeps the cells written in [start, end), a zero end means no upper bound
+func TimestampRangeFilter(start time.Time, end time.Time) Filter {
+	f := timestampRangeFilter{}
+	if !start.IsZero() {
+		f.start = Time(start)
+	}
+	if !end.IsZero() {
+		f.end = Time(end)
+	}
+	return f
+}
+
+func (f timestampRangeFilter) String() string {
+	return fmt.Sprintf("timestamp_range(%d,%d)", f.start, f.end)
+}
+
+func (f timestampRangeFilter) filterCells(cells []cell) ([]cell, error) {
+	result := cells[:0]
+	for _, c := range cells {
+		if c.ts >= f.start && (f.end == 0 || c.ts < f.end) {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+type cellsPerRowOffsetFilter int
+
+// CellsPerRowOffsetFilter skips the first n cells of each row
+func CellsPerRowOffsetFilter(n int) Filter {
+	return cellsPerRowOffsetFilter(n)
+}
+
+func (f cellsPerRowOffsetFilter) String() string {
+	return fmt.Sprintf("cells_per_row_offset(%d)", int(f))
+}
+
+func (f cellsPerRowOffsetFilter) filterCells(cells []cell) ([]cell, error) {
+	if int(f) >= len(cells) {
+		return nil, nil
+	}
+	return cells[f:], nil
+}
+
+func joinFilters(filters []Filter, sep string) string {
+	s := make([]string, len(filters))
+	for i, f := range filters {
+		s[i] = f.String()
+	}
+	return strings.Join(s, sep)
+}
+
+func lessCell(a, b cell) bool {
+	if a.family != b.family {
+		return a.family < b.family
+	}
+	if a.column != b.column {
+		return a.column < b.column
+	}
+	return a.ts > b.ts
+}
+
+// ReadOption configures a read
+type ReadOption interface {
+	set(settings *readSettings)
+}
+
+type readSettings struct {
+	filter Filter
+	limit  int64
+}
+
+type rowFilter struct {
+	f Filter
+}
+
+func (o rowFilter) set(settings *readSettings) {
+	settings.filter = o.f
+}
+
+// RowFilter applies the filter to every row read
+func RowFilter(f Filter) ReadOption {
+	return rowFilter{f: f}
+}
+
+type limitRows int64
+
+func (o limitRows) set(settings *readSettings) {
+	settings.limit = int64(o)
+}
+
+// LimitRows stops the read after limit rows
+func LimitRows(limit int64) ReadOption {
+	return limitRows(limit)
+}
+
+func newReadSettings(opts []ReadOption) readSettings {
+	var settings readSettings
+	for _, opt := range opts {
+		opt.set(&settings)
+	}
+	return settings
+}
@@ -0,0 +1,55 @@
+package storage
+
+type mutationKind uint8
+
+const (
+	mutationSet mutationKind = iota
+	mutationDeleteColumn
+	mutationDeleteTimestampRange
+	mutationDeleteFamily
+	mutationDeleteRow
+)
+
+type mutationOp struct {
+	kind   mutationKind
+	family string
+	column string
+	ts     Timestamp
+	end    Timestamp
+	value  []byte
+}
+
+// Mutation is a list of changes applied atomically to a single row, in the order they were added
+type Mutation struct {
+	ops []mutationOp
+}
+
+// NewMutation returns an empty mutation
+func NewMutation() *Mutation {
+	return &Mutation{}
+}
+
+// Set writes value to the cell of the column with the given timestamp, replacing an existing cell with the same timestamp
+func (m *Mutation) Set(family, column string, ts Timestamp, value []byte) {
+	m.ops = append(m.ops, mutationOp{kind: mutationSet, family: family, column: column, ts: ts, value: value})
+}
+
+// DeleteCellsInColumn deletes all cells of the column
+func (m *Mutation) DeleteCellsInColumn(family, column string) {
+	m.ops = append(m.ops, mutationOp{kind: mutationDeleteColumn, family: family, column: column})
+}
+
+// DeleteTimestampRange deletes the cells of the column with start <= timestamp < end, an end of 0 means no upper bound
+func (m *Mutation) DeleteTimestampRange(family, column string, start, end Timestamp) {
+	m.ops = append(m.ops, mutationOp{kind: mutationDeleteTimestampRange, family: family, column: column, ts: start, end: end})
+}
+
+// DeleteCellsInFamily deletes all cells of the column family
+func (m *Mutation) DeleteCellsInFamily(family string) {
+	m.ops = append(m.ops, mutationOp{kind: mutationDeleteFamily, family: family})
+}
+
+// DeleteRow deletes the whole row
+func (m *Mutation) DeleteRow() {
+	m.ops = append(m.ops, mutationOp{kind: mutationDeleteRow})
+}
@@ -0,0 +1,480 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+)
+
+// Keys of the embedded store:
+//
+//	schema: 's' | table
+//	cells:  'd' | table | row | family | column | ^timestamp (8 bytes big endian)
+//
+// Each string component is escaped (0x00 -> 0x00 0xff) and terminated with 0x00 0x01, which keeps the lexicographic
+// order of the components, so rows are sorted by key and cells by family, column and descending timestamp.
+const (
+	pebbleSchemaPrefix = 's'
+	pebbleDataPrefix   = 'd'
+)
+
+type pebbleStore struct {
+	db *pebble.DB
+
+	// guards the schema and serializes writes, so garbage collection can't race with concurrent mutations
+	mu     sync.RWMutex
+	tables map[string]map[string]pebbleGCPolicy
+}
+
+// pebbleGCPolicy is the persisted form of a GCPolicy
+type pebbleGCPolicy struct {
+	MaxVersions int           `json:"max_versions,omitempty"`
+	MaxAge      time.Duration `json:"max_age,omitempty"`
+}
+
+// NewPebbleStore opens or creates an embedded store in the directory.
+// Only one process can open the directory at a time.
+func NewPebbleStore(path string) (Store, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("error opening pebble store at %s: %w", path, err)
+	}
+	s := &pebbleStore{
+		db:     db,
+		tables: make(map[string]map[string]pebbleGCPolicy),
+	}
+	err = s.loadSchema()
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *pebbleStore) loadSchema() error {
+	iter, err := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: []byte{pebbleSchemaPrefix},
+		UpperBound: []byte{pebbleSchemaPrefix + 1},
+	})
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+	for iter.First(); iter.Valid(); iter.Next() {
+		name, _, err := decodeComponent(iter.Key()[1:])
+		if err != nil {
+			return err
+		}
+		families := make(map[string]pebbleGCPolicy)
+		err = json.Unmarshal(iter.Value(), &families)
+		if err != nil {
+			return fmt.Errorf("error decoding schema of table %s: %w", name, err)
+		}
+		s.tables[name] = families
+	}
+	return iter.Error()
+}
+
+func (s *pebbleStore) Table(name string) Table {
+	return &pebbleTable{store: s, name: name}
+}
+
+func (s *pebbleStore) Tables(ctx context.Context) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tables := make([]string, 0, len(s.tables))
+	for name := range s.tables {
+		tables = append(tables, name)
+	}
+	sort.Strings(tables)
+	return tables, nil
+}
+
+func (s *pebbleStore) CreateTable(ctx context.Context, name string, families map[string]GCPolicy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.tables[name]; ok {
+		return fmt.Errorf("table %s already exists", name)
+	}
+	schema := make(map[string]pebbleGCPolicy, len(families))
+	for family, policy := range families {
+		switch policy := policy.(type) {
+		case nil:
+			schema[family] = pebbleGCPolicy{}
+		case maxVersionsPolicy:
+			schema[family] = pebbleGCPolicy{MaxVersions: int(policy)}
+		case maxAgePolicy:
+			schema[family] = pebbleGCPolicy{MaxAge: time.Duration(policy)}
+		default:
+			return fmt.Errorf("unsupported gc policy %v", policy)
+		}
+	}
+	value, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+	err = s.db.Set(appendComponent([]byte{pebbleSchemaPrefix}, name), value, pebble.Sync)
+	if err != nil {
+		return err
+	}
+	s.tables[name] = schema
+	return nil
+}
+
+func (s *pebbleStore) Close() error {
+	return s.db.Close()
+}
+
+type pebbleTable struct {
+	store *pebbleStore
+	name  string
+}
+
+func (t *pebbleTable) families() (map[string]pebbleGCPolicy, error) {
+	families, ok := t.store.tables[t.name]
+	if !ok {
+		return nil, fmt.Errorf("table %s does not exist", t.name)
+	}
+	return families, nil
+}
+
+func (t *pebbleTable) tablePrefix() []byte {
+	return appendComponent([]byte{pebbleDataPrefix}, t.name)
+}
+
+func (t *pebbleTable) rowPrefix(row string) []byte {
+	return appendComponent(t.tablePrefix(), row)
+}
+
+func (t *pebbleTable) ReadRow(ctx context.Context, row string, opts ...ReadOption) (Row, error) {
+	var result Row
+	err := t.ReadRows(ctx, RowList{row}, func(r Row) bool {
+		result = r
+		return true
+	}, opts...)
+	return result, err
+}
+
+func (t *pebbleTable) ReadRows(ctx context.Context, arg RowSet, f func(Row) bool, opts ...ReadOption) error {
+	t.store.mu.RLock()
+	_, err := t.families()
+	t.store.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	settings := newReadSettings(opts)
+	tablePrefix := t.tablePrefix()
+
+	var rowsRead int64
+	for _, r := range arg.keyRanges() {
+		upperBound := appendPrefix(tablePrefix, r.end)
+		if r.Unbounded() {
+			upperBound = prefixEnd(tablePrefix)
+		}
+		iter, err := t.store.db.NewIterWithContext(ctx, &pebble.IterOptions{
+			LowerBound: appendPrefix(tablePrefix, r.start),
+			UpperBound: upperBound,
+		})
+		if err != nil {
+			return err
+		}
+		done, err := t.readRange(ctx, iter, len(tablePrefix), settings, &rowsRead, f)
+		closeErr := iter.Close()
+		if err != nil {
+			return err
+		}
+		if closeErr != nil {
+			return closeErr
+		}
+		if done {
+			return nil
+		}
+	}
+	return nil
+}
+
+// readRange groups the cells of the iterator into rows and passes them to f, it returns true if the read is done
+func (t *pebbleTable) readRange(ctx context.Context, iter *pebble.Iterator, prefixLen int, settings readSettings, rowsRead *int64, f func(Row) bool) (bool, error) {
+	var rowKey string
+	var cells []cell
+	// emit returns true if reading has to stop
+	emit := func() (bool, error) {
+		if len(cells) == 0 {
+			return false, nil
+		}
+		var err error
+		if settings.filter != nil {
+			cells, err = settings.filter.filterCells(cells)
+			if err != nil {
+				return true, err
+			}
+		}
+		if len(cells) == 0 {
+			// like Bigtable, rows without cells are not returned
+			return false, nil
+		}
+		*rowsRead++
+		if !f(toRow(rowKey, cells)) {
+			return true, nil
+		}
+		return settings.limit > 0 && *rowsRead >= settings.limit, nil
+	}
+
+	for iter.First(); iter.Valid(); iter.Next() {
+		row, c, err := decodeCellKey(iter.Key()[prefixLen:])
+		if err != nil {
+			return true, err
+		}
+		if row != rowKey {
+			if done, err := emit(); done || err != nil {
+				return true, err
+			}
+			if err := ctx.Err(); err != nil {
+				return true, err
+			}
+			rowKey = row
+			cells = cells[:0]
+		}
+		c.value = bytes.Clone(iter.Value())
+		cells = append(cells, c)
+	}
+	if err := iter.Error(); err != nil {
+		return true, err
+	}
+	return emit()
+}
+
+func toRow(key string, cells []cell) Row {
+	row := make(Row)
+	for _, c := range cells {
+		row[c.family] = append(row[c.family], ReadItem{
+			Row:       key,
+			Column:    c.family + ":" + c.column,
+			Timestamp: c.ts,
+			Value:     c.value,
+		})
+	}
+	return row
+}
+
+func (t *pebbleTable) Apply(ctx context.Context, row string, m *Mutation) error {
+	errs, err := t.ApplyBulk(ctx, []string{row}, []*Mutation{m})
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+func (t *pebbleTable) ApplyBulk(ctx context.Context, rowKeys []string, muts []*Mutation) ([]error, error) {
+	if len(rowKeys) != len(muts) {
+		return nil, fmt.Errorf("mismatched rowKeys and mutation array lengths: %d, %d", len(rowKeys), len(muts))
+	}
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+	families, err := t.families()
+	if err != nil {
+		return nil, err
+	}
+
+	batch := t.store.db.NewBatch()
+	defer batch.Close()
+	var errs []error
+	type column struct {
+		row, family, column string
+	}
+	var touched []column
+	for i, m := range muts {
+		rowErr := t.addMutation(batch, families, rowKeys[i], m)
+		if rowErr != nil {
+			if errs == nil {
+				errs = make([]error, len(muts))
+			}
+			errs[i] = rowErr
+			continue
+		}
+		for _, op := range m.ops {
+			if op.kind == mutationSet && families[op.family] != (pebbleGCPolicy{}) {
+				touched = append(touched, column{row: rowKeys[i], family: op.family, column: op.column})
+			}
+		}
+	}
+	err = batch.Commit(pebble.Sync)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range touched {
+		err := t.collectGarbage(c.row, c.family, c.column, families[c.family])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return errs, nil
+}
+
+// addMutation adds the operations of the mutation to the batch, a mutation with an invalid operation isn't added at all
+func (t *pebbleTable) addMutation(batch *pebble.Batch, families map[string]pebbleGCPolicy, row string, m *Mutation) error {
+	for _, op := range m.ops {
+		if op.kind == mutationDeleteRow {
+			continue
+		}
+		if _, ok := families[op.family]; !ok {
+			return fmt.Errorf("column family %s does not exist in table %s", op.family, t.name)
+		}
+	}
+
+	rowPrefix := t.rowPrefix(row)
+	for _, op := range m.ops {
+		var err error
+		switch op.kind {
+		case mutationSet:
+			ts := op.ts
+			if ts < 0 {
+				ts = Now()
+			}
+			err = batch.Set(cellKey(rowPrefix, op.family, op.column, ts), op.value, nil)
+		case mutationDeleteColumn:
+			columnPrefix := appendComponent(appendComponent(rowPrefix, op.family), op.column)
+			err = batch.DeleteRange(columnPrefix, prefixEnd(columnPrefix), nil)
+		case mutationDeleteTimestampRange:
+			// timestamps are stored inverted, so the newest cell (end - 1) has the smallest key
+			start := cellKey(rowPrefix, op.family, op.column, op.end-1)
+			if op.end == 0 {
+				start = appendComponent(appendComponent(rowPrefix, op.family), op.column)
+			}
+			end := cellKey(rowPrefix, op.family, op.column, op.ts-1)
+			if op.ts <= 0 {
+				end = prefixEnd(appendComponent(appendComponent(rowPrefix, op.family), op.column))
+			}
+			err = batch.DeleteRange(start, end, nil)
+		case mutationDeleteFamily:
+			familyPrefix := appendComponent(rowPrefix, op.family)
+			err = batch.DeleteRange(familyPrefix, prefixEnd(familyPrefix), nil)
+		case mutationDeleteRow:
+			err = batch.DeleteRange(rowPrefix, prefixEnd(rowPrefix), nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectGarbage applies the gc policy of the family to a column, Bigtable does this eventually in the background
+func (t *pebbleTable) collectGarbage(row, family, column string, policy pebbleGCPolicy) error {
+	columnPrefix := appendComponent(appendComponent(t.rowPrefix(row), family), column)
+	iter, err := t.store.db.NewIter(&pebble.IterOptions{
+		LowerBound: columnPrefix,
+		UpperBound: prefixEnd(columnPrefix),
+	})
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	minTimestamp := Timestamp(0)
+	if policy.MaxAge > 0 {
+		minTimestamp = Time(time.Now().Add(-policy.MaxAge))
+	}
+	batch := t.store.db.NewBatch()
+	defer batch.Close()
+	versions := 0
+	for iter.First(); iter.Valid(); iter.Next() {
+		versions++
+		key := iter.Key()
+		ts := Timestamp(^binary.BigEndian.Uint64(key[len(key)-8:]))
+		if (policy.MaxVersions > 0 && versions > policy.MaxVersions) || ts < minTimestamp {
+			err := batch.Delete(key, nil)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if batch.Empty() {
+		return nil
+	}
+	return batch.Commit(pebble.NoSync)
+}
+
+func cellKey(rowPrefix []byte, family, column string, ts Timestamp) []byte {
+	key := appendComponent(appendComponent(bytes.Clone(rowPrefix), family), column)
+	return binary.BigEndian.AppendUint64(key, ^uint64(ts))
+}
+
+func decodeCellKey(key []byte) (string, cell, error) {
+	row, key, err := decodeComponent(key)
+	if err != nil {
+		return "", cell{}, err
+	}
+	family, key, err := decodeComponent(key)
+	if err != nil {
+		return "", cell{}, err
+	}
+	column, key, err := decodeComponent(key)
+	if err != nil {
+		return "", cell{}, err
+	}
+	if len(key) != 8 {
+		return "", cell{}, errors.New("invalid cell key, missing timestamp")
+	}
+	return row, cell{family: family, column: column, ts: Timestamp(^binary.BigEndian.Uint64(key))}, nil
+}
+
+// appendComponent appends the escaped and terminated component to the key
+func appendComponent(key []byte, component string) []byte {
+	return append(appendPrefix(key, component), 0x00, 0x01)
+}
+
+// appendPrefix appends the escaped component without terminator, the result is a prefix of the keys of all
+// components starting with the given one
+func appendPrefix(key []byte, component string) []byte {
+	key = bytes.Clone(key)
+	for i := 0; i < len(component); i++ {
+		key = append(key, component[i])
+		if component[i] == 0x00 {
+			key = append(key, 0xff)
+		}
+	}
+	return key
+}
+
+func decodeComponent(key []byte) (string, []byte, error) {
+	var component []byte
+	for i := 0; i < len(key); i++ {
+		if key[i] != 0x00 {
+			component = append(component, key[i])
+			continue
+		}
+		if i+1 >= len(key) {
+			break
+		}
+		if key[i+1] == 0x01 {
+			return string(component), key[i+2:], nil
+		}
+		if key[i+1] != 0xff {
+			break
+		}
+		component = append(component, 0x00)
+		i++
+	}
+	return "", nil, errors.New("invalid key component")
+}
+
+// prefixEnd returns the smallest key larger than all keys starting with prefix
+func prefixEnd(prefix []byte) []byte {
+	return []byte(prefixSuccessor(string(prefix)))
+}
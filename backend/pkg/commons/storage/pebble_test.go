@@ -0,0 +1,293 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func newTestTable(t *testing.T, families map[string]GCPolicy) Table {
+	store, err := NewPebbleStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() {
+		store.Close()
+	})
+	err = store.CreateTable(context.Background(), "test", families)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return store.Table("test")
+}
+
+func apply(t *testing.T, table Table, row string, m *Mutation) {
+	err := table.Apply(context.Background(), row, m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func readKeys(t *testing.T, table Table, rows RowSet, opts ...ReadOption) []string {
+	var keys []string
+	err := table.ReadRows(context.Background(), rows, func(r Row) bool {
+		keys = append(keys, r.Key())
+		return true
+	}, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return keys
+}
+
+func formatRow(r Row, families ...string) string {
+	s := ""
+	for _, family := range families {
+		for _, item := range r[family] {
+			s += fmt.Sprintf("%s@%d=%s ", item.Column, item.Timestamp, item.Value)
+		}
+	}
+	return s
+}
+
+func TestPebbleRowSets(t *testing.T) {
+	table := newTestTable(t, map[string]GCPolicy{"f": nil})
+	for _, row := range []string{"a", "a\x00", "ab", "b", "b:1", "b:2", "c", "\xff"} {
+		m := NewMutation()
+		m.Set("f", "c", 1, []byte(row))
+		apply(t, table, row, m)
+	}
+
+	tests := []struct {
+		name string
+		rows RowSet
+		opts []ReadOption
+		want string
+	}{
+		{"list", RowList{"c", "a", "x", "a"}, nil, "[a c]"},
+		{"list with zero byte", RowList{"a\x00"}, nil, "[a\x00]"},
+		{"range", NewRange("a\x00", "b:2"), nil, "[a\x00 ab b b:1]"},
+		{"empty range", NewRange("b", "b"), nil, "[]"},
+		{"infinite range", InfiniteRange("b:"), nil, "[b:1 b:2 c \xff]"},
+		{"prefix range", PrefixRange("b:"), nil, "[b:1 b:2]"},
+		{"prefix range of 0xff", PrefixRange("\xff"), nil, "[\xff]"},
+		{"range list", RowRangeList{NewRange("b", "c"), PrefixRange("a"), NewRange("ab", "b:1")}, nil, "[a a\x00 ab b b:1 b:2]"},
+		{"limit", InfiniteRange(""), []ReadOption{LimitRows(3)}, "[a a\x00 ab]"},
+		{"limit over ranges", RowRangeList{PrefixRange("a"), PrefixRange("b")}, []ReadOption{LimitRows(4)}, "[a a\x00 ab b]"},
+	}
+	for _, tt := range tests {
+		got := fmt.Sprintf("%v", readKeys(t, table, tt.rows, tt.opts...))
+		if got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestPebbleFilters(t *testing.T) {
+	table := newTestTable(t, map[string]GCPolicy{"a": nil, "b": nil})
+	m := NewMutation()
+	for ts := Timestamp(1); ts <= 3; ts++ {
+		m.Set("a", "x", ts, []byte(fmt.Sprint(ts)))
+		m.Set("a", "y", ts*10, []byte(fmt.Sprint(ts*10)))
+	}
+	m.Set("b", "x", 5, []byte("5"))
+	apply(t, table, "row", m)
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   string
+	}{
+		{"none", nil, "a:x@3=3 a:x@2=2 a:x@1=1 a:y@30=30 a:y@20=20 a:y@10=10 b:x@5=5 "},
+		{"family", FamilyFilter("b"), "b:x@5=5 "},
+		{"family full match", FamilyFilter("a|c"), "a:x@3=3 a:x@2=2 a:x@1=1 a:y@30=30 a:y@20=20 a:y@10=10 "},
+		{"column", ColumnFilter("x"), "a:x@3=3 a:x@2=2 a:x@1=1 b:x@5=5 "},
+		{"latest", LatestNFilter(1), "a:x@3=3 a:y@30=30 b:x@5=5 "},
+		{"strip value", ChainFilters(FamilyFilter("b"), StripValueFilter()), "b:x@5= "},
+		{"timestamp range", TimestampRangeFilterMicros(2, 20), "a:x@3=3 a:x@2=2 a:y@10=10 b:x@5=5 "},
+		{"open timestamp range", TimestampRangeFilterMicros(20, 0), "a:y@30=30 a:y@20=20 "},
+		{"offset", CellsPerRowOffsetFilter(5), "a:y@10=10 b:x@5=5 "},
+		{"chain", ChainFilters(FamilyFilter("a"), ColumnFilter("y"), LatestNFilter(2)), "a:y@30=30 a:y@20=20 "},
+		{"interleave", InterleaveFilters(ColumnFilter("y"), ChainFilters(LatestNFilter(1), StripValueFilter())), "a:x@3= a:y@30=30 a:y@30= a:y@20=20 a:y@10=10 b:x@5= "},
+	}
+	for _, tt := range tests {
+		var opts []ReadOption
+		if tt.filter != nil {
+			opts = append(opts, RowFilter(tt.filter))
+		}
+		r, err := table.ReadRow(context.Background(), "row", opts...)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		got := formatRow(r, "a", "b")
+		if got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+	}
+
+	keys := readKeys(t, table, RowList{"row"}, RowFilter(FamilyFilter("c")))
+	if len(keys) != 0 {
+		t.Errorf("expected rows without matching cells to be skipped, got %v", keys)
+	}
+	_, err := table.ReadRow(context.Background(), "row", RowFilter(ColumnFilter("(")))
+	if err == nil {
+		t.Errorf("expected an error for an invalid pattern")
+	}
+}
+
+func TestPebbleMutations(t *testing.T) {
+	table := newTestTable(t, map[string]GCPolicy{"a": nil, "b": nil})
+	reset := func() {
+		m := NewMutation()
+		m.DeleteRow()
+		for ts := Timestamp(1); ts <= 4; ts++ {
+			m.Set("a", "x", ts, []byte(fmt.Sprint(ts)))
+		}
+		m.Set("a", "y", 1, []byte("1"))
+		m.Set("b", "x", 1, []byte("1"))
+		apply(t, table, "row", m)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(m *Mutation)
+		want   string
+	}{
+		{"overwrite", func(m *Mutation) { m.Set("a", "x", 4, []byte("new")) }, "a:x@4=new a:x@3=3 a:x@2=2 a:x@1=1 a:y@1=1 b:x@1=1 "},
+		{"delete column", func(m *Mutation) { m.DeleteCellsInColumn("a", "x") }, "a:y@1=1 b:x@1=1 "},
+		{"delete timestamp range", func(m *Mutation) { m.DeleteTimestampRange("a", "x", 2, 4) }, "a:x@4=4 a:x@1=1 a:y@1=1 b:x@1=1 "},
+		{"delete open timestamp range", func(m *Mutation) { m.DeleteTimestampRange("a", "x", 3, 0) }, "a:x@2=2 a:x@1=1 a:y@1=1 b:x@1=1 "},
+		{"delete family", func(m *Mutation) { m.DeleteCellsInFamily("a") }, "b:x@1=1 "},
+		{"delete row", func(m *Mutation) { m.DeleteRow() }, ""},
+		{"delete and set", func(m *Mutation) { m.DeleteRow(); m.Set("b", "z", 1, []byte("1")) }, "b:z@1=1 "},
+	}
+	for _, tt := range tests {
+		reset()
+		m := NewMutation()
+		tt.mutate(m)
+		apply(t, table, "row", m)
+		r, err := table.ReadRow(context.Background(), "row")
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		got := formatRow(r, "a", "b")
+		if got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestPebbleApplyBulk(t *testing.T) {
+	table := newTestTable(t, map[string]GCPolicy{"f": nil})
+	valid := NewMutation()
+	valid.Set("f", "c", 1, []byte("v"))
+	invalid := NewMutation()
+	invalid.Set("f", "c", 1, []byte("v"))
+	invalid.Set("missing", "c", 1, []byte("v"))
+
+	errs, err := table.ApplyBulk(context.Background(), []string{"a", "b", "c"}, []*Mutation{valid, invalid, valid})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 3 || errs[0] != nil || errs[1] == nil || errs[2] != nil {
+		t.Fatalf("expected an error for the second row only, got %v", errs)
+	}
+	got := fmt.Sprintf("%v", readKeys(t, table, InfiniteRange("")))
+	if got != "[a c]" {
+		t.Errorf("expected the invalid mutation not to be applied, got %s", got)
+	}
+
+	errs, err = table.ApplyBulk(context.Background(), []string{"a"}, []*Mutation{valid})
+	if err != nil || errs != nil {
+		t.Errorf("expected no errors, got %v, %v", errs, err)
+	}
+	_, err = table.ApplyBulk(context.Background(), []string{"a", "b"}, []*Mutation{valid})
+	if err == nil {
+		t.Errorf("expected an error for mismatched lengths")
+	}
+}
+
+func TestPebbleGCPolicies(t *testing.T) {
+	table := newTestTable(t, map[string]GCPolicy{
+		"versions": MaxVersionsGCPolicy(2),
+		"age":      MaxAgeGCPolicy(time.Hour),
+	})
+	now := Now()
+	m := NewMutation()
+	for ts := Timestamp(1); ts <= 3; ts++ {
+		m.Set("versions", "c", ts, []byte(fmt.Sprint(ts)))
+	}
+	m.Set("age", "c", now, []byte("new"))
+	m.Set("age", "c", Time(time.Now().Add(-2*time.Hour)), []byte("old"))
+	apply(t, table, "row", m)
+
+	r, err := table.ReadRow(context.Background(), "row", RowFilter(StripValueFilter()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := fmt.Sprintf("age:c@%d= versions:c@3= versions:c@2= ", now)
+	got := formatRow(r, "age", "versions")
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestPebbleReopen(t *testing.T) {
+	path := t.TempDir()
+	store, err := NewPebbleStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := context.Background()
+	err = store.CreateTable(ctx, "test", map[string]GCPolicy{"f": MaxVersionsGCPolicy(1)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = store.CreateTable(ctx, "test", nil)
+	if err == nil {
+		t.Errorf("expected an error when creating an existing table")
+	}
+	m := NewMutation()
+	m.Set("f", "c", 1, []byte("v"))
+	err = store.Table("test").Apply(ctx, "row", m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = store.Close()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	store, err = NewPebbleStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer store.Close()
+	tables, err := store.Tables(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tables) != 1 || tables[0] != "test" {
+		t.Errorf("expected table test, got %v", tables)
+	}
+	// the gc policy has to survive the restart as well
+	m = NewMutation()
+	m.Set("f", "c", 2, []byte("v2"))
+	err = store.Table("test").Apply(ctx, "row", m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, err := store.Table("test").ReadRow(ctx, "row")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := formatRow(r, "f"); got != "f:c@2=v2 " {
+		t.Errorf("expected a single version, got %q", got)
+	}
+
+	_, err = store.Table("missing").ReadRow(ctx, "row")
+	if err == nil {
+		t.Errorf("expected an error when reading a missing table")
+	}
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+)
+
+// RowSet is a set of rows to read
+type RowSet interface {
+	// keyRanges returns the sorted, non-overlapping key ranges covered by the set
+	keyRanges() []RowRange
+}
+
+// RowList is a list of row keys
+type RowList []string
+
+func (r RowList) keyRanges() []RowRange {
+	keys := make([]string, len(r))
+	copy(keys, r)
+	sort.Strings(keys)
+	ranges := make([]RowRange, 0, len(keys))
+	for i, key := range keys {
+		if i > 0 && key == keys[i-1] {
+			continue
+		}
+		ranges = append(ranges, RowRange{start: key, end: key + "\x00"})
+	}
+	return ranges
+}
+
+// RowRange is the half-open key range [start, end), an empty end means the range is unbounded
+type RowRange struct {
+	start string
+	end   string
+}
+
+// NewRange returns the range [begin, end)
+func NewRange(begin, end string) RowRange {
+	return RowRange{start: begin, end: end}
+}
+
+// InfiniteRange returns the range [start, ∞)
+func InfiniteRange(start string) RowRange {
+	return RowRange{start: start}
+}
+
+// PrefixRange returns the range of all keys starting with prefix
+func PrefixRange(prefix string) RowRange {
+	return RowRange{start: prefix, end: prefixSuccessor(prefix)}
+}
+
+// Unbounded returns whether the range has no end
+func (r RowRange) Unbounded() bool {
+	return r.end == ""
+}
+
+// Contains returns whether the row key is part of the range
+func (r RowRange) Contains(row string) bool {
+	return r.start <= row && (r.Unbounded() || row < r.end)
+}
+
+func (r RowRange) String() string {
+	end := r.end
+	if r.Unbounded() {
+		end = "∞"
+	}
+	return fmt.Sprintf("[%q,%q)", r.start, end)
+}
+
+func (r RowRange) keyRanges() []RowRange {
+	if !r.Unbounded() && r.start >= r.end {
+		return nil
+	}
+	return []RowRange{r}
+}
+
+// RowRangeList is a list of row ranges, they may overlap
+type RowRangeList []RowRange
+
+func (r RowRangeList) keyRanges() []RowRange {
+	ranges := make([]RowRange, 0, len(r))
+	for _, rr := range r {
+		ranges = append(ranges, rr.keyRanges()...)
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start < ranges[j].start
+	})
+	// merge overlapping ranges so that every row is returned only once
+	merged := ranges[:0]
+	for _, rr := range ranges {
+		last := len(merged) - 1
+		if last >= 0 && (merged[last].Unbounded() || rr.start <= merged[last].end) {
+			if merged[last].Unbounded() || rr.Unbounded() {
+				merged[last].end = ""
+			} else if rr.end > merged[last].end {
+				merged[last].end = rr.end
+			}
+			continue
+		}
+		merged = append(merged, rr)
+	}
+	return merged
+}
+
+// prefixSuccessor returns the smallest key that is larger than all keys starting with prefix, or "" if there is none
+func prefixSuccessor(prefix string) string {
+	n := len(prefix)
+	for n > 0 && prefix[n-1] == 0xff {
+		n--
+	}
+	if n == 0 {
+		return ""
+	}
+	return prefix[:n-1] + string([]byte{prefix[n-1] + 1})
+}
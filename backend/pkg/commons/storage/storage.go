@@ -0,0 +1,103 @@
+// Package storage provides the row-range key-value storage used for validator history, eth1 indexes and machine metrics.
+//
+// The API mirrors the subset of the Bigtable data API the db package relies on (row reads, prefix and range scans,
+// filters, bulk mutations and column families), so the same code can run against Bigtable in production and
+// against an embedded Pebble database on a single machine or in tests.
+package storage
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// Store is a storage backend holding a set of tables
+type Store interface {
+	// Table returns a handle to the table with the given name, it doesn't check if the table exists
+	Table(name string) Table
+	// Tables returns the names of all existing tables
+	Tables(ctx context.Context) ([]string, error)
+	// CreateTable creates a table with the given column families and their garbage collection policies (nil for none)
+	CreateTable(ctx context.Context, name string, families map[string]GCPolicy) error
+	Close() error
+}
+
+// Table is a sparse, sorted map of row keys to rows
+type Table interface {
+	// ReadRow returns the row with the given key, or a nil row if it doesn't exist
+	ReadRow(ctx context.Context, row string, opts ...ReadOption) (Row, error)
+	// ReadRows calls f for each row in arg in row key order, until f returns false
+	ReadRows(ctx context.Context, arg RowSet, f func(Row) bool, opts ...ReadOption) error
+	// Apply applies the mutation to the row atomically
+	Apply(ctx context.Context, row string, m *Mutation) error
+	// ApplyBulk applies the mutations to the rows, each row is mutated atomically but not the whole batch.
+	// The returned slice holds the error of each row and is nil if all mutations were applied.
+	ApplyBulk(ctx context.Context, rowKeys []string, muts []*Mutation) ([]error, error)
+}
+
+// Row maps column families to the cells read from them
+type Row map[string][]ReadItem
+
+// Key returns the key of the row
+func (r Row) Key() string {
+	for _, items := range r {
+		if len(items) > 0 {
+			return items[0].Row
+		}
+	}
+	return ""
+}
+
+// ReadItem is a single cell, cells of a column are ordered from newest to oldest
+type ReadItem struct {
+	Row string
+	// Column has the form "family:qualifier"
+	Column    string
+	Timestamp Timestamp
+	Value     []byte
+}
+
+// Timestamp is the version of a cell in microseconds since the unix epoch
+type Timestamp int64
+
+// Now returns the current time as Timestamp
+func Now() Timestamp {
+	return Time(time.Now())
+}
+
+// Time converts a time.Time to a Timestamp
+func Time(t time.Time) Timestamp {
+	return Timestamp(t.UnixNano() / 1e3)
+}
+
+// Time converts the Timestamp to a time.Time
+func (ts Timestamp) Time() time.Time {
+	return time.Unix(int64(ts)/1e6, int64(ts)%1e6*1e3)
+}
+
+// GCPolicy defines when old cells of a column family are deleted
+type GCPolicy interface {
+	String() string
+}
+
+type maxVersionsPolicy int
+
+func (p maxVersionsPolicy) String() string {
+	return "versions() > " + strconv.Itoa(int(p))
+}
+
+// MaxVersionsGCPolicy keeps only the n newest cells of each column
+func MaxVersionsGCPolicy(n int) GCPolicy {
+	return maxVersionsPolicy(n)
+}
+
+type maxAgePolicy time.Duration
+
+func (p maxAgePolicy) String() string {
+	return "age() > " + time.Duration(p).String()
+}
+
+// MaxAgeGCPolicy deletes cells with a timestamp older than d
+func MaxAgeGCPolicy(d time.Duration) GCPolicy {
+	return maxAgePolicy(d)
+}
@@ -4,7 +4,7 @@ import (
 	"math/big"
 	"time"
 
-	gcp_bigtable "cloud.google.com/go/bigtable"
+	"github.com/gobitfly/beaconchain/pkg/commons/storage"
 )
 
 type ValidatorBalanceStatistic struct {
@@ -59,17 +59,17 @@ type GasNowHistory struct {
 
 type BulkMutations struct {
 	Keys []string
-	Muts []*gcp_bigtable.Mutation
+	Muts []*storage.Mutation
 }
 
 func NewBulkMutations(length int) *BulkMutations {
 	return &BulkMutations{
 		Keys: make([]string, 0, length),
-		Muts: make([]*gcp_bigtable.Mutation, 0, length),
+		Muts: make([]*storage.Mutation, 0, length),
 	}
 }
 
-func (bulkMutations *BulkMutations) Add(key string, mut *gcp_bigtable.Mutation) {
+func (bulkMutations *BulkMutations) Add(key string, mut *storage.Mutation) {
 	bulkMutations.Keys = append(bulkMutations.Keys, key)
 	bulkMutations.Muts = append(bulkMutations.Muts, mut)
 }
@@ -89,5 +89,5 @@ func (bulkMutations *BulkMutations) Swap(i, j int) {
 
 type BulkMutation struct {
 	Key string
-	Mut *gcp_bigtable.Mutation
+	Mut *storage.Mutation
 }
@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 type ForkVersion struct {
 	Epoch           uint64
 	CurrentVersion  []byte
@@ -28,6 +30,8 @@ type ClChainConfig struct {
 	CappellaForkEpoch    uint64 `yaml:"CAPELLA_FORK_EPOCH"`
 	DenebForkVersion     string `yaml:"DENEB_FORK_VERSION"`
 	DenebForkEpoch       uint64 `yaml:"DENEB_FORK_EPOCH"`
+	ElectraForkVersion   string `yaml:"ELECTRA_FORK_VERSION"`
+	ElectraForkEpoch     uint64 `yaml:"ELECTRA_FORK_EPOCH"`
 	Eip6110ForkVersion   string `yaml:"EIP6110_FORK_VERSION"`
 	Eip6110ForkEpoch     uint64 `yaml:"EIP6110_FORK_EPOCH"`
 	Eip7002ForkVersion   string `yaml:"EIP7002_FORK_VERSION"`
@@ -139,4 +143,58 @@ type ClChainConfig struct {
 	FieldElementsPerBlob       uint64 `yaml:"FIELD_ELEMENTS_PER_BLOB"`
 	MaxBlobCommitmentsPerBlock uint64 `yaml:"MAX_BLOB_COMMITMENTS_PER_BLOCK"`
 	MaxBlobsPerBlock           uint64 `yaml:"MAX_BLOBS_PER_BLOCK"`
+
+	// electra
+	// https://github.com/ethereum/consensus-specs/blob/dev/presets/mainnet/electra.yaml
+	MinActivationBalance                  uint64 `yaml:"MIN_ACTIVATION_BALANCE"`
+	MaxEffectiveBalanceElectra            uint64 `yaml:"MAX_EFFECTIVE_BALANCE_ELECTRA"`
+	MaxPendingPartialsPerWithdrawalsSweep uint64 `yaml:"MAX_PENDING_PARTIALS_PER_WITHDRAWALS_SWEEP"`
+}
+
+// SlotToTime returns the start time of the slot
+func (c *Chain) SlotToTime(slot uint64) time.Time {
+	return time.Unix(int64(c.GenesisTimestamp+slot*c.ClConfig.SecondsPerSlot), 0)
+}
+
+// TimeToSlot returns the slot of the given unix timestamp
+func (c *Chain) TimeToSlot(timestamp uint64) uint64 {
+	if c.GenesisTimestamp > timestamp {
+		return 0
+	}
+	return (timestamp - c.GenesisTimestamp) / c.ClConfig.SecondsPerSlot
+}
+
+// EpochToTime returns the start time of the epoch
+func (c *Chain) EpochToTime(epoch uint64) time.Time {
+	return time.Unix(int64(c.GenesisTimestamp+epoch*c.ClConfig.SecondsPerSlot*c.ClConfig.SlotsPerEpoch), 0)
+}
+
+// TimeToEpoch returns the epoch of the given time
+func (c *Chain) TimeToEpoch(ts time.Time) int64 {
+	if int64(c.GenesisTimestamp) > ts.Unix() {
+		return 0
+	}
+	return (ts.Unix() - int64(c.GenesisTimestamp)) / int64(c.ClConfig.SecondsPerSlot) / int64(c.ClConfig.SlotsPerEpoch)
+}
+
+func (c *Chain) EpochOfSlot(slot uint64) uint64 {
+	return slot / c.ClConfig.SlotsPerEpoch
+}
+
+// EpochsPerDay returns the number of epochs that fit in a day
+func (c *Chain) EpochsPerDay() uint64 {
+	return (uint64((24 * time.Hour).Seconds()) / c.ClConfig.SlotsPerEpoch) / c.ClConfig.SecondsPerSlot
+}
+
+// SyncPeriodOfEpoch returns the sync committee period of the epoch, epochs before altair belong to period 0
+func (c *Chain) SyncPeriodOfEpoch(epoch uint64) uint64 {
+	if epoch < c.ClConfig.AltairForkEpoch {
+		return 0
+	}
+	return epoch / c.ClConfig.EpochsPerSyncCommitteePeriod
+}
+
+// FirstEpochOfSyncPeriod returns the first epoch of the sync committee period
+func (c *Chain) FirstEpochOfSyncPeriod(syncPeriod uint64) uint64 {
+	return syncPeriod * c.ClConfig.EpochsPerSyncCommitteePeriod
 }
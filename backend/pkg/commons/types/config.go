@@ -58,6 +58,8 @@ type Config struct {
 		EmulatorPort        int    `yaml:"emulatorPort" envconfig:"BIGTABLE_EMULATOR_PORT"`
 		EmulatorHost        string `yaml:"emulatorHost" envconfig:"BIGTABLE_EMULATOR_HOST"`
 		V2SchemaCutOffEpoch uint64 `yaml:"v2SchemaCutOffEpoch" envconfig:"BIGTABLE_V2_SCHEMA_CUTT_OFF_EPOCH"`
+		Embedded            bool   `yaml:"embedded" envconfig:"BIGTABLE_EMBEDDED"`          // use an embedded pebble store instead of bigtable, it can only be opened by one process at a time
+		EmbeddedPath        string `yaml:"embeddedPath" envconfig:"BIGTABLE_EMBEDDED_PATH"` // directory of the embedded store, defaults to a directory in the temp dir
 	} `yaml:"bigtable"`
 	BlobIndexer struct {
 		S3 struct {
@@ -105,6 +107,12 @@ type Config struct {
 			Host     string `yaml:"host" envconfig:"INDEXER_NODE_HOST"`
 			Type     string `yaml:"type" envconfig:"INDEXER_NODE_TYPE"`
 			PageSize int32  `yaml:"pageSize" envconfig:"INDEXER_NODE_PAGE_SIZE"`
+			// additional beacon node endpoints (e.g. http://host:port) that are used if the primary node is unhealthy
+			FailoverEndpoints []string `yaml:"failoverEndpoints" envconfig:"INDEXER_NODE_FAILOVER_ENDPOINTS"`
+			// request heavy responses (states, blocks) ssz encoded, falls back to json if unsupported by the node
+			SSZ bool `yaml:"ssz" envconfig:"INDEXER_NODE_SSZ"`
+			// timeout of a single request to a beacon node in seconds before failing over to the next node, defaults to 120
+			RequestTimeout uint64 `yaml:"requestTimeout" envconfig:"INDEXER_NODE_REQUEST_TIMEOUT"`
 		} `yaml:"node"`
 		ELDepositContractFirstBlock uint64 `yaml:"eth1DepositContractFirstBlock" envconfig:"INDEXER_ETH1_DEPOSIT_CONTRACT_FIRST_BLOCK"`
 		DoNotTraceDeposits          bool   `yaml:"doNotTraceDeposits" envconfig:"INDEXER_DO_NOT_TRACE_DEPOSITS"`
@@ -270,20 +278,33 @@ type Config struct {
 		PprofExtra bool   `yaml:"pprofExtra" envconfig:"METRICS_PPROF_EXTRA"`
 	} `yaml:"metrics"`
 	Notifications struct {
-		UserDBNotifications                           bool    `yaml:"userDbNotifications" envconfig:"USERDB_NOTIFICATIONS_ENABLED"`
-		FirebaseCredentialsPath                       string  `yaml:"firebaseCredentialsPath" envconfig:"NOTIFICATIONS_FIREBASE_CRED_PATH"`
-		ValidatorBalanceDecreasedNotificationsEnabled bool    `yaml:"validatorBalanceDecreasedNotificationsEnabled" envconfig:"VALIDATOR_BALANCE_DECREASED_NOTIFICATIONS_ENABLED"`
-		PubkeyCachePath                               string  `yaml:"pubkeyCachePath" envconfig:"NOTIFICATIONS_PUBKEY_CACHE_PATH"`
-		OnlineDetectionLimit                          int     `yaml:"onlineDetectionLimit" envconfig:"ONLINE_DETECTION_LIMIT"`
-		OfflineDetectionLimit                         int     `yaml:"offlineDetectionLimit" envconfig:"OFFLINE_DETECTION_LIMIT"`
-		MachineEventThreshold                         uint64  `yaml:"machineEventThreshold" envconfig:"MACHINE_EVENT_THRESHOLD"`
-		MachineEventFirstRatioThreshold               float64 `yaml:"machineEventFirstRatioThreshold" envconfig:"MACHINE_EVENT_FIRST_RATIO_THRESHOLD"`
-		MachineEventSecondRatioThreshold              float64 `yaml:"machineEventSecondRatioThreshold" envconfig:"MACHINE_EVENT_SECOND_RATIO_THRESHOLD"`
+		UserDBNotifications                           bool          `yaml:"userDbNotifications" envconfig:"USERDB_NOTIFICATIONS_ENABLED"`
+		FirebaseCredentialsPath                       string        `yaml:"firebaseCredentialsPath" envconfig:"NOTIFICATIONS_FIREBASE_CRED_PATH"`
+		ValidatorBalanceDecreasedNotificationsEnabled bool          `yaml:"validatorBalanceDecreasedNotificationsEnabled" envconfig:"VALIDATOR_BALANCE_DECREASED_NOTIFICATIONS_ENABLED"`
+		PubkeyCachePath                               string        `yaml:"pubkeyCachePath" envconfig:"NOTIFICATIONS_PUBKEY_CACHE_PATH"`
+		OnlineDetectionLimit                          int           `yaml:"onlineDetectionLimit" envconfig:"ONLINE_DETECTION_LIMIT"`
+		OfflineDetectionLimit                         int           `yaml:"offlineDetectionLimit" envconfig:"OFFLINE_DETECTION_LIMIT"`
+		MachineEventThreshold                         uint64        `yaml:"machineEventThreshold" envconfig:"MACHINE_EVENT_THRESHOLD"`
+		MachineEventFirstRatioThreshold               float64       `yaml:"machineEventFirstRatioThreshold" envconfig:"MACHINE_EVENT_FIRST_RATIO_THRESHOLD"`
+		MachineEventSecondRatioThreshold              float64       `yaml:"machineEventSecondRatioThreshold" envconfig:"MACHINE_EVENT_SECOND_RATIO_THRESHOLD"`
+		WebhookTimeout                                time.Duration `yaml:"webhookTimeout" envconfig:"NOTIFICATIONS_WEBHOOK_TIMEOUT"`
+		TelegramBotToken                              string        `yaml:"telegramBotToken" envconfig:"NOTIFICATIONS_TELEGRAM_BOT_TOKEN"`
+		TelegramBotName                               string        `yaml:"telegramBotName" envconfig:"NOTIFICATIONS_TELEGRAM_BOT_NAME"`
+		MatrixHomeserverUrl                           string        `yaml:"matrixHomeserverUrl" envconfig:"NOTIFICATIONS_MATRIX_HOMESERVER_URL"`
+		MatrixAccessToken                             string        `yaml:"matrixAccessToken" envconfig:"NOTIFICATIONS_MATRIX_ACCESS_TOKEN"`
 	} `yaml:"notifications"`
 	SSVExporter struct {
 		Enabled bool   `yaml:"enabled" envconfig:"SSV_EXPORTER_ENABLED"`
 		Address string `yaml:"address" envconfig:"SSV_EXPORTER_ADDRESS"`
 	} `yaml:"SSVExporter"`
+	LidoCsmExporter struct {
+		Enabled    bool   `yaml:"enabled" envconfig:"LIDO_CSM_EXPORTER_ENABLED"`
+		KeysApiUrl string `yaml:"keysApiUrl" envconfig:"LIDO_CSM_EXPORTER_KEYS_API_URL"`
+		ModuleId   uint64 `yaml:"moduleId" envconfig:"LIDO_CSM_EXPORTER_MODULE_ID"`
+	} `yaml:"lidoCsmExporter"`
+	SlotDutiesPublisher struct {
+		Enabled bool `yaml:"enabled" envconfig:"SLOT_DUTIES_PUBLISHER_ENABLED"`
+	} `yaml:"slotDutiesPublisher"`
 	RocketpoolExporter struct {
 		Enabled bool `yaml:"enabled" envconfig:"ROCKETPOOL_EXPORTER_ENABLED"`
 	} `yaml:"rocketpoolExporter"`
@@ -301,6 +322,13 @@ type Config struct {
 	Monitoring struct {
 		ApiKey                          string                           `yaml:"apiKey" envconfig:"MONITORING_API_KEY"`
 		ServiceMonitoringConfigurations []ServiceMonitoringConfiguration `yaml:"serviceMonitoringConfigurations" envconfig:"SERVICE_MONITORING_CONFIGURATIONS"`
+		Alerting                        struct {
+			RepeatInterval      time.Duration `yaml:"repeatInterval" envconfig:"MONITORING_ALERTING_REPEAT_INTERVAL"`
+			MaxRollingLagEpochs uint64        `yaml:"maxRollingLagEpochs" envconfig:"MONITORING_ALERTING_MAX_ROLLING_LAG_EPOCHS"`
+			AlertmanagerUrl     string        `yaml:"alertmanagerUrl" envconfig:"MONITORING_ALERTING_ALERTMANAGER_URL"`
+			PagerDutyRoutingKey string        `yaml:"pagerDutyRoutingKey" envconfig:"MONITORING_ALERTING_PAGERDUTY_ROUTING_KEY"`
+			WebhookUrl          string        `yaml:"webhookUrl" envconfig:"MONITORING_ALERTING_WEBHOOK_URL"`
+		} `yaml:"alerting"`
 	} `yaml:"monitoring"`
 	InternalAlerts InternalAlertDiscord `yaml:"internalAlerts"`
 
@@ -308,6 +336,8 @@ type Config struct {
 	CorsAllowedHosts []string `yaml:"corsAllowedHosts" envconfig:"CORS_ALLOWED_HOSTS"`
 
 	SkipDataAccessServiceInitWait bool `yaml:"skipDataAccessServiceInitWait" envconfig:"SKIP_DATA_ACCESS_SERVICE_INIT_WAIT"`
+
+	Networks []NetworkConfig `yaml:"networks"` // additional networks served by the api
 }
 
 type Chain struct {
@@ -330,14 +360,25 @@ type InternalAlertDiscord struct {
 }
 
 type DatabaseConfig struct {
-	Username     string
-	Password     string
-	Name         string
-	Host         string
-	Port         string
-	MaxOpenConns int
-	MaxIdleConns int
-	SSL          bool
+	Username     string `yaml:"user"`
+	Password     string `yaml:"password"`
+	Name         string `yaml:"name"`
+	Host         string `yaml:"host"`
+	Port         string `yaml:"port"`
+	MaxOpenConns int    `yaml:"maxOpenConns"`
+	MaxIdleConns int    `yaml:"maxIdleConns"`
+	SSL          bool   `yaml:"ssl"`
+}
+
+// NetworkConfig is a network that the api serves in addition to Chain. Users, dashboards and sessions are shared with the
+// main network, the chain data is read from the databases of the network.
+type NetworkConfig struct {
+	Chain            Chain          `yaml:"chain"`
+	ReaderDatabase   DatabaseConfig `yaml:"readerDatabase"`
+	WriterDatabase   DatabaseConfig `yaml:"writerDatabase"`
+	ClickHouseReader DatabaseConfig `yaml:"clickhouseReader"`
+	AlloyReader      DatabaseConfig `yaml:"alloyReader"`
+	AlloyWriter      DatabaseConfig `yaml:"alloyWriter"`
 }
 
 type ServiceMonitoringConfiguration struct {
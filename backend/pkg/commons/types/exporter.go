@@ -130,6 +130,9 @@ type Block struct {
 	ExcessBlobGas              uint64
 	BlobKZGCommitments         [][]byte
 	BlobKZGProofs              [][]byte
+	DepositRequests            []*DepositRequest       // electra
+	WithdrawalRequests         []*WithdrawalRequest    // electra
+	ConsolidationRequests      []*ConsolidationRequest // electra
 	AttestationDuties          map[ValidatorIndex][]Slot
 	SyncDuties                 map[ValidatorIndex]bool
 	Finalized                  bool
@@ -213,6 +216,27 @@ type WithdrawalsNotification struct {
 	Pubkey         []byte `json:"pubkey"`
 }
 
+const (
+	PoolOperationVoluntaryExit        = "voluntary_exit"
+	PoolOperationBlsToExecutionChange = "bls_to_execution_change"
+)
+
+// PoolOperation is a voluntary exit or bls to execution change that has been seen in the op pool of the node
+type PoolOperation struct {
+	Type               string        `db:"type"`
+	ValidatorIndex     uint64        `db:"validatorindex"`
+	Signature          []byte        `db:"signature"`
+	ExitEpoch          sql.NullInt64 `db:"exit_epoch"`
+	ToExecutionAddress []byte        `db:"to_execution_address"`
+	FirstSeenTs        time.Time     `db:"first_seen_ts"`
+	FirstSeenSlot      uint64        `db:"first_seen_slot"`
+	LastSeenSlot       uint64        `db:"last_seen_slot"`
+	IncludedSlot       sql.NullInt64 `db:"included_slot"`
+	DroppedSlot        sql.NullInt64 `db:"dropped_slot"`
+	// only set when the operations are retrieved for notifications
+	Pubkey []byte `db:"pubkey"`
+}
+
 // Eth1Data is a struct to hold the ETH1 data
 type Eth1Data struct {
 	DepositRoot  []byte
@@ -243,6 +267,7 @@ type IndexedAttestation struct {
 // Attestation is a struct to hold attestation header data
 type Attestation struct {
 	AggregationBits []byte
+	CommitteeBits   []byte // electra, nil for earlier forks
 	Attesters       []uint64
 	Data            *AttestationData
 	Signature       []byte
@@ -272,6 +297,31 @@ type Deposit struct {
 	Signature             []byte
 }
 
+// DepositRequest is a struct to hold an execution layer deposit request (EIP-6110)
+type DepositRequest struct {
+	PublicKey             []byte
+	WithdrawalCredentials []byte
+	Amount                uint64
+	Signature             []byte
+	Index                 uint64
+}
+
+// WithdrawalRequest is a struct to hold an execution layer triggered withdrawal request (EIP-7002).
+// An amount of 0 requests a full exit, any other amount a partial withdrawal.
+type WithdrawalRequest struct {
+	SourceAddress   []byte
+	ValidatorPubkey []byte
+	Amount          uint64
+}
+
+// ConsolidationRequest is a struct to hold a consolidation request (EIP-7251).
+// If source and target pubkey are equal the request switches the validator to compounding credentials.
+type ConsolidationRequest struct {
+	SourceAddress []byte
+	SourcePubkey  []byte
+	TargetPubkey  []byte
+}
+
 // VoluntaryExit is a struct to hold voluntary exit data
 type VoluntaryExit struct {
 	Epoch          uint64
@@ -683,6 +733,27 @@ type RedisCachedEpochAssignments struct {
 	Assignments *EpochAssignments
 }
 
+// SlotDutiesEvent is published via redis pub/sub by the exporter for every processed head slot,
+// it holds the duty results of all validators that became known with the slot
+type SlotDutiesEvent struct {
+	Slot  uint64 `json:"slot"`
+	Epoch uint64 `json:"epoch"`
+	// the proposer of the slot, ProposalMissed is set if no block was proposed
+	Proposer       uint64 `json:"proposer"`
+	ProposalMissed bool   `json:"proposal_missed"`
+	// validators whose attestations were included in the block of the slot, by attested slot
+	Attestations map[uint64][]uint64 `json:"attestations,omitempty"`
+	// validators whose attestations were not included until the end of their inclusion window, by attested slot
+	MissedAttestations map[uint64][]uint64 `json:"missed_attestations,omitempty"`
+	SyncParticipated   []uint64            `json:"sync_participated,omitempty"`
+	SyncMissed         []uint64            `json:"sync_missed,omitempty"`
+}
+
+// SlotDutiesRedisChannel returns the redis pub/sub channel the slot duties events of a chain are published on
+func SlotDutiesRedisChannel(chainId uint64) string {
+	return fmt.Sprintf("%d:slotDuties", chainId)
+}
+
 type RedisCachedEpochRewards struct {
 	Epoch   Epoch
 	Rewards map[uint64]*eth_rewards_types.ValidatorEpochIncome
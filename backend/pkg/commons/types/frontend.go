@@ -79,18 +79,19 @@ const (
 	RocketpoolCommissionThresholdEventName EventName = "rocketpool_commision_threshold"
 
 	// Validator dashboard events
-	ValidatorIsOfflineEventName             EventName = "validator_is_offline"
-	ValidatorIsOnlineEventName              EventName = "validator_is_online"
-	ValidatorMissedAttestationEventName     EventName = "validator_attestation_missed"
-	ValidatorMissedProposalEventName        EventName = "validator_proposal_missed"
-	ValidatorExecutedProposalEventName      EventName = "validator_proposal_submitted"
-	ValidatorUpcomingProposalEventName      EventName = "validator_proposal_upcoming"
-	SyncCommitteeSoonEventName              EventName = "validator_synccommittee_soon"
-	ValidatorReceivedWithdrawalEventName    EventName = "validator_withdrawal"
-	ValidatorGotSlashedEventName            EventName = "validator_got_slashed"
-	ValidatorGroupEfficiencyEventName       EventName = "validator_group_efficiency"
-	RocketpoolCollateralMinReachedEventName EventName = "rocketpool_colleteral_min" //nolint:misspell
-	RocketpoolCollateralMaxReachedEventName EventName = "rocketpool_colleteral_max" //nolint:misspell
+	ValidatorIsOfflineEventName                EventName = "validator_is_offline"
+	ValidatorIsOnlineEventName                 EventName = "validator_is_online"
+	ValidatorMissedAttestationEventName        EventName = "validator_attestation_missed"
+	ValidatorMissedProposalEventName           EventName = "validator_proposal_missed"
+	ValidatorExecutedProposalEventName         EventName = "validator_proposal_submitted"
+	ValidatorUpcomingProposalEventName         EventName = "validator_proposal_upcoming"
+	SyncCommitteeSoonEventName                 EventName = "validator_synccommittee_soon"
+	ValidatorReceivedWithdrawalEventName       EventName = "validator_withdrawal"
+	ValidatorGotSlashedEventName               EventName = "validator_got_slashed"
+	ValidatorGroupEfficiencyEventName          EventName = "validator_group_efficiency"
+	ValidatorPoolOperationNotIncludedEventName EventName = "validator_pool_operation_not_included"
+	RocketpoolCollateralMinReachedEventName    EventName = "rocketpool_colleteral_min" //nolint:misspell
+	RocketpoolCollateralMaxReachedEventName    EventName = "rocketpool_colleteral_max" //nolint:misspell
 
 	// Account dashboard events
 	IncomingTransactionEventName  EventName = "incoming_transaction"
@@ -130,6 +131,7 @@ var EventSortOrder = []EventName{
 	ValidatorIsOnlineEventName,
 	ValidatorGroupEfficiencyEventName,
 	ValidatorReceivedWithdrawalEventName,
+	ValidatorPoolOperationNotIncludedEventName,
 	NetworkLivenessIncreasedEventName,
 	EthClientUpdateEventName,
 	TaxReportEventName,
@@ -140,6 +142,11 @@ var EventSortOrder = []EventName{
 	ValidatorMissedAttestationEventName,
 	NetworkGasAboveThresholdEventName,
 	NetworkGasBelowThresholdEventName,
+	IncomingTransactionEventName,
+	OutgoingTransactionEventName,
+	ERC20TokenTransferEventName,
+	ERC721TokenTransferEventName,
+	ERC1155TokenTransferEventName,
 }
 
 var MachineEvents = []EventName{
@@ -172,58 +179,87 @@ var MachineEventsMap = map[EventName]struct{}{
 	MonitoringMachineMemoryUsageEventName:    {},
 }
 
+// AccountDashboardEventsMap contains the events of account dashboards, their subscriptions are not bound to a network
+var AccountDashboardEventsMap = map[EventName]struct{}{
+	IncomingTransactionEventName:  {},
+	OutgoingTransactionEventName:  {},
+	ERC20TokenTransferEventName:   {},
+	ERC721TokenTransferEventName:  {},
+	ERC1155TokenTransferEventName: {},
+}
+
 var LegacyEventLabel map[EventName]string = map[EventName]string{
-	ValidatorUpcomingProposalEventName:       "Your validator(s) will soon propose a block",
-	ValidatorGroupEfficiencyEventName:        "Your validator group efficiency is low",
-	ValidatorMissedProposalEventName:         "Your validator(s) missed a proposal",
-	ValidatorExecutedProposalEventName:       "Your validator(s) submitted a proposal",
-	ValidatorMissedAttestationEventName:      "Your validator(s) missed an attestation",
-	ValidatorGotSlashedEventName:             "Your validator(s) got slashed",
-	ValidatorDidSlashEventName:               "Your validator(s) slashed another validator",
-	ValidatorIsOfflineEventName:              "Your validator(s) went offline",
-	ValidatorIsOnlineEventName:               "Your validator(s) came back online",
-	ValidatorReceivedWithdrawalEventName:     "A withdrawal was initiated for your validators",
-	NetworkLivenessIncreasedEventName:        "The network is experiencing liveness issues",
-	EthClientUpdateEventName:                 "An Ethereum client has a new update available",
-	MonitoringMachineOfflineEventName:        "Your machine(s) might be offline",
-	MonitoringMachineDiskAlmostFullEventName: "Your machine(s) disk space is running low",
-	MonitoringMachineCpuLoadEventName:        "Your machine(s) has a high CPU load",
-	MonitoringMachineMemoryUsageEventName:    "Your machine(s) has a high memory load",
-	TaxReportEventName:                       "You have an available tax report",
-	RocketpoolCommissionThresholdEventName:   "Your configured Rocket Pool commission threshold is reached",
-	RocketpoolNewClaimRoundStartedEventName:  "Your Rocket Pool claim from last round is available",
-	RocketpoolCollateralMinReachedEventName:  "You reached the Rocket Pool min RPL collateral",
-	RocketpoolCollateralMaxReachedEventName:  "You reached the Rocket Pool max RPL collateral",
-	SyncCommitteeSoonEventName:               "Your validator(s) will soon be part of the sync committee",
-	NetworkGasAboveThresholdEventName:        "Gas price is above threshold",
-	NetworkGasBelowThresholdEventName:        "Gas price is below threshold",
+	ValidatorUpcomingProposalEventName:         "Your validator(s) will soon propose a block",
+	ValidatorGroupEfficiencyEventName:          "Your validator group efficiency is low",
+	ValidatorMissedProposalEventName:           "Your validator(s) missed a proposal",
+	ValidatorExecutedProposalEventName:         "Your validator(s) submitted a proposal",
+	ValidatorMissedAttestationEventName:        "Your validator(s) missed an attestation",
+	ValidatorGotSlashedEventName:               "Your validator(s) got slashed",
+	ValidatorDidSlashEventName:                 "Your validator(s) slashed another validator",
+	ValidatorIsOfflineEventName:                "Your validator(s) went offline",
+	ValidatorIsOnlineEventName:                 "Your validator(s) came back online",
+	ValidatorReceivedWithdrawalEventName:       "A withdrawal was initiated for your validators",
+	ValidatorPoolOperationNotIncludedEventName: "An exit or credential change of your validator(s) is not included",
+	NetworkLivenessIncreasedEventName:          "The network is experiencing liveness issues",
+	EthClientUpdateEventName:                   "An Ethereum client has a new update available",
+	MonitoringMachineOfflineEventName:          "Your machine(s) might be offline",
+	MonitoringMachineDiskAlmostFullEventName:   "Your machine(s) disk space is running low",
+	MonitoringMachineCpuLoadEventName:          "Your machine(s) has a high CPU load",
+	MonitoringMachineMemoryUsageEventName:      "Your machine(s) has a high memory load",
+	TaxReportEventName:                         "You have an available tax report",
+	RocketpoolCommissionThresholdEventName:     "Your configured Rocket Pool commission threshold is reached",
+	RocketpoolNewClaimRoundStartedEventName:    "Your Rocket Pool claim from last round is available",
+	RocketpoolCollateralMinReachedEventName:    "You reached the Rocket Pool min RPL collateral",
+	RocketpoolCollateralMaxReachedEventName:    "You reached the Rocket Pool max RPL collateral",
+	SyncCommitteeSoonEventName:                 "Your validator(s) will soon be part of the sync committee",
+	NetworkGasAboveThresholdEventName:          "Gas price is above threshold",
+	NetworkGasBelowThresholdEventName:          "Gas price is below threshold",
+	IncomingTransactionEventName:               "Your account(s) received a transaction",
+	OutgoingTransactionEventName:               "Your account(s) sent a transaction",
+	ERC20TokenTransferEventName:                "Your account(s) transferred ERC20 tokens",
+	ERC721TokenTransferEventName:               "Your account(s) transferred ERC721 tokens",
+	ERC1155TokenTransferEventName:              "Your account(s) transferred ERC1155 tokens",
 }
 
 var EventLabel map[EventName]string = map[EventName]string{
-	ValidatorUpcomingProposalEventName:       "Upcoming block proposal",
-	ValidatorGroupEfficiencyEventName:        "Low validator group efficiency",
-	ValidatorMissedProposalEventName:         "Block proposal missed",
-	ValidatorExecutedProposalEventName:       "Block proposal submitted",
-	ValidatorMissedAttestationEventName:      "Attestation missed",
-	ValidatorGotSlashedEventName:             "Validator slashed",
-	ValidatorDidSlashEventName:               "Validator has slashed",
-	ValidatorIsOfflineEventName:              "Validator offline",
-	ValidatorIsOnlineEventName:               "Validator back online",
-	ValidatorReceivedWithdrawalEventName:     "Withdrawal processed",
-	NetworkLivenessIncreasedEventName:        "The network is experiencing liveness issues",
-	EthClientUpdateEventName:                 "An Ethereum client has a new update available",
-	MonitoringMachineOfflineEventName:        "Machine offline",
-	MonitoringMachineDiskAlmostFullEventName: "Machine low disk space",
-	MonitoringMachineCpuLoadEventName:        "Machine high CPU load",
-	MonitoringMachineMemoryUsageEventName:    "Machine high memory load",
-	TaxReportEventName:                       "Tax report available",
-	RocketpoolCommissionThresholdEventName:   "Rocket pool commission threshold is reached",
-	RocketpoolNewClaimRoundStartedEventName:  "Rocket pool claim from last round is available",
-	RocketpoolCollateralMinReachedEventName:  "Rocket pool node min RPL collateral reached",
-	RocketpoolCollateralMaxReachedEventName:  "Rocket pool node max RPL collateral reached",
-	SyncCommitteeSoonEventName:               "Upcoming sync committee",
-	NetworkGasAboveThresholdEventName:        "Gas price is above threshold",
-	NetworkGasBelowThresholdEventName:        "Gas price is below threshold",
+	ValidatorUpcomingProposalEventName:         "Upcoming block proposal",
+	ValidatorGroupEfficiencyEventName:          "Low validator group efficiency",
+	ValidatorMissedProposalEventName:           "Block proposal missed",
+	ValidatorExecutedProposalEventName:         "Block proposal submitted",
+	ValidatorMissedAttestationEventName:        "Attestation missed",
+	ValidatorGotSlashedEventName:               "Validator slashed",
+	ValidatorDidSlashEventName:                 "Validator has slashed",
+	ValidatorIsOfflineEventName:                "Validator offline",
+	ValidatorIsOnlineEventName:                 "Validator back online",
+	ValidatorReceivedWithdrawalEventName:       "Withdrawal processed",
+	ValidatorPoolOperationNotIncludedEventName: "Exit or credential change not included",
+	NetworkLivenessIncreasedEventName:          "The network is experiencing liveness issues",
+	EthClientUpdateEventName:                   "An Ethereum client has a new update available",
+	MonitoringMachineOfflineEventName:          "Machine offline",
+	MonitoringMachineDiskAlmostFullEventName:   "Machine low disk space",
+	MonitoringMachineCpuLoadEventName:          "Machine high CPU load",
+	MonitoringMachineMemoryUsageEventName:      "Machine high memory load",
+	TaxReportEventName:                         "Tax report available",
+	RocketpoolCommissionThresholdEventName:     "Rocket pool commission threshold is reached",
+	RocketpoolNewClaimRoundStartedEventName:    "Rocket pool claim from last round is available",
+	RocketpoolCollateralMinReachedEventName:    "Rocket pool node min RPL collateral reached",
+	RocketpoolCollateralMaxReachedEventName:    "Rocket pool node max RPL collateral reached",
+	SyncCommitteeSoonEventName:                 "Upcoming sync committee",
+	NetworkGasAboveThresholdEventName:          "Gas price is above threshold",
+	NetworkGasBelowThresholdEventName:          "Gas price is below threshold",
+	IncomingTransactionEventName:               "Incoming transaction",
+	OutgoingTransactionEventName:               "Outgoing transaction",
+	ERC20TokenTransferEventName:                "ERC20 token transfer",
+	ERC721TokenTransferEventName:               "ERC721 token transfer",
+	ERC1155TokenTransferEventName:              "ERC1155 token transfer",
+}
+
+// CriticalEventsMap contains the events that are always delivered immediately, regardless of digest or quiet hours settings
+var CriticalEventsMap = map[EventName]struct{}{
+	ValidatorGotSlashedEventName:             {},
+	MonitoringMachineOfflineEventName:        {},
+	MonitoringMachineDiskAlmostFullEventName: {},
+	RocketpoolCollateralMinReachedEventName:  {},
 }
 
 func IsUserIndexed(event EventName) bool {
@@ -236,6 +272,16 @@ func IsMachineNotification(event EventName) bool {
 	return ok
 }
 
+func IsCriticalEvent(event EventName) bool {
+	_, ok := CriticalEventsMap[event]
+	return ok
+}
+
+func IsAccountDashboardEvent(event EventName) bool {
+	_, ok := AccountDashboardEventsMap[event]
+	return ok
+}
+
 var EventNames = []EventName{
 	ValidatorExecutedProposalEventName,
 	ValidatorGroupEfficiencyEventName,
@@ -246,6 +292,7 @@ var EventNames = []EventName{
 	ValidatorIsOfflineEventName,
 	ValidatorIsOnlineEventName,
 	ValidatorReceivedWithdrawalEventName,
+	ValidatorPoolOperationNotIncludedEventName,
 	NetworkLivenessIncreasedEventName,
 	EthClientUpdateEventName,
 	MonitoringMachineOfflineEventName,
@@ -260,6 +307,11 @@ var EventNames = []EventName{
 	SyncCommitteeSoonEventName,
 	NetworkGasAboveThresholdEventName,
 	NetworkGasBelowThresholdEventName,
+	IncomingTransactionEventName,
+	OutgoingTransactionEventName,
+	ERC20TokenTransferEventName,
+	ERC721TokenTransferEventName,
+	ERC1155TokenTransferEventName,
 }
 
 type EventNameDesc struct {
@@ -278,6 +330,12 @@ type MachineMetricSystemUser struct {
 	FiveMinuteOldDataInsertTs int64
 }
 
+// MachineMetricSeriesPoint is a sample of a raw series that a machine pushed in one of the prometheus formats
+type MachineMetricSeriesPoint struct {
+	Timestamp int64   `json:"timestamp"` // unix seconds
+	Value     float64 `json:"value"`
+}
+
 // this is the source of truth for the validator events that are supported by the user/notification page
 var AddWatchlistEvents = []EventNameDesc{
 	{
@@ -547,6 +605,7 @@ type TransitWebhook struct {
 	Id      uint64       `db:"id,omitempty"`
 	Created sql.NullTime `db:"created"`
 	Sent    sql.NullTime `db:"sent"`
+	Retries uint64       `db:"retries"`
 	// Delivered sql.NullTime          `db:"delivered"`
 	Channel string                `db:"channel"`
 	Content TransitWebhookContent `db:"content"`
@@ -585,6 +644,7 @@ type TransitDiscord struct {
 	Id      uint64       `db:"id,omitempty"`
 	Created sql.NullTime `db:"created"`
 	Sent    sql.NullTime `db:"sent"`
+	Retries uint64       `db:"retries"`
 	// Delivered sql.NullTime          `db:"delivered"`
 	Channel string                `db:"channel"`
 	Content TransitDiscordContent `db:"content"`
@@ -611,6 +671,44 @@ func (a TransitDiscordContent) Value() (driver.Value, error) {
 	return json.Marshal(a)
 }
 
+type TransitChat struct {
+	Id      uint64             `db:"id,omitempty"`
+	Created sql.NullTime       `db:"created"`
+	Sent    sql.NullTime       `db:"sent"`
+	Retries uint64             `db:"retries"`
+	Channel string             `db:"channel"`
+	Content TransitChatContent `db:"content"`
+}
+
+// TransitChatContent is a channel independent chat message, it is formatted for telegram, slack or matrix when it is sent
+type TransitChatContent struct {
+	Target           string               `json:"target"` // telegram chat id, slack webhook url or matrix room id
+	Title            string               `json:"title"`
+	Sections         []ChatMessageSection `json:"sections"`
+	Epoch            uint64               `json:"epoch"`
+	UserId           UserId               `json:"userId"`
+	DashboardId      uint64               `json:"dashboardId"`
+	DashboardGroupId uint64               `json:"dashboardGroupId"`
+}
+
+type ChatMessageSection struct {
+	Summary string `json:"summary"`
+	Details string `json:"details"` // markdown formatted
+}
+
+func (e *TransitChatContent) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &e)
+}
+
+func (a TransitChatContent) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
+
 type TransitPush struct {
 	Id      uint64       `db:"id,omitempty"`
 	Created sql.NullTime `db:"created"`
@@ -643,6 +741,19 @@ type EmailAttachment struct {
 	Name       string `json:"name"`
 }
 
+// IncomeReportRequest describes the income report attached to a tax report notification, either of a dashboard group or of a list of validators.
+// Currency and Format are the names used by the api, unknown values fall back to the defaults of the renderer.
+type IncomeReportRequest struct {
+	Name        string
+	DashboardId uint64
+	GroupId     int64
+	Validators  []uint64
+	Currency    string
+	Format      string
+	Start       time.Time // inclusive
+	End         time.Time // exclusive
+}
+
 type Email struct {
 	Title                 string
 	Body                  template.HTML
@@ -650,17 +761,18 @@ type Email struct {
 }
 
 type UserWebhook struct {
-	ID               uint64         `db:"id" json:"id"`
-	UserID           uint64         `db:"user_id" json:"-"`
-	Url              string         `db:"url" json:"url"`
-	Retries          uint64         `db:"retries" json:"retries"`
-	LastSent         sql.NullTime   `db:"last_sent" json:"lastRetry"`
-	Response         sql.NullString `db:"response" json:"response"`
-	Request          sql.NullString `db:"request" json:"request"`
-	Destination      sql.NullString `db:"destination" json:"destination"`
-	EventNames       pq.StringArray `db:"event_names" json:"-"`
-	DashboardId      uint64         `db:"dashboard_id" json:"dashboardId"`
-	DashboardGroupId uint64         `db:"dashboard_group_id" json:"dashboardGroupId"`
+	ID                 uint64         `db:"id" json:"id"`
+	UserID             uint64         `db:"user_id" json:"-"`
+	Url                string         `db:"url" json:"url"`
+	Retries            uint64         `db:"retries" json:"retries"`
+	LastSent           sql.NullTime   `db:"last_sent" json:"lastRetry"`
+	Response           sql.NullString `db:"response" json:"response"`
+	Request            sql.NullString `db:"request" json:"request"`
+	Destination        sql.NullString `db:"destination" json:"destination"`
+	EventNames         pq.StringArray `db:"event_names" json:"-"`
+	DashboardId        uint64         `db:"dashboard_id" json:"dashboardId"`
+	DashboardGroupId   uint64         `db:"dashboard_group_id" json:"dashboardGroupId"`
+	IsAccountDashboard bool           `db:"is_account_dashboard" json:"isAccountDashboard,omitempty"`
 }
 
 type UserWebhookSubscriptions struct {
@@ -677,6 +789,9 @@ var NotificationChannelLabels map[NotificationChannel]template.HTML = map[Notifi
 	PushNotificationChannel:           "Push Notification",
 	WebhookNotificationChannel:        `Webhook Notification (<a href="/user/webhooks">configure</a>)`,
 	WebhookDiscordNotificationChannel: "Discord Notification",
+	TelegramNotificationChannel:       "Telegram Notification",
+	SlackNotificationChannel:          "Slack Notification",
+	MatrixNotificationChannel:         "Matrix Notification",
 }
 
 const (
@@ -684,6 +799,9 @@ const (
 	PushNotificationChannel           NotificationChannel = "push"
 	WebhookNotificationChannel        NotificationChannel = "webhook"
 	WebhookDiscordNotificationChannel NotificationChannel = "webhook_discord"
+	TelegramNotificationChannel       NotificationChannel = "telegram"
+	SlackNotificationChannel          NotificationChannel = "slack"
+	MatrixNotificationChannel         NotificationChannel = "matrix"
 )
 
 var NotificationChannels = []NotificationChannel{
@@ -691,6 +809,23 @@ var NotificationChannels = []NotificationChannel{
 	PushNotificationChannel,
 	WebhookNotificationChannel,
 	WebhookDiscordNotificationChannel,
+	TelegramNotificationChannel,
+	SlackNotificationChannel,
+	MatrixNotificationChannel,
+}
+
+type NotificationDeliveryMode string
+
+const (
+	NotificationDeliveryImmediate    NotificationDeliveryMode = "immediate"
+	NotificationDeliveryHourlyDigest NotificationDeliveryMode = "hourly_digest"
+	NotificationDeliveryDailyDigest  NotificationDeliveryMode = "daily_digest"
+)
+
+var NotificationDeliveryModes = []NotificationDeliveryMode{
+	NotificationDeliveryImmediate,
+	NotificationDeliveryHourlyDigest,
+	NotificationDeliveryDailyDigest,
 }
 
 func GetNotificationChannel(channel string) (NotificationChannel, error) {
@@ -13,20 +13,69 @@ import (
 
 type NodeJobStatus string
 
-const PendingNodeJobStatus NodeJobStatus = "PENDING"                   // job is waiting to be submitted
-const SubmittedToNodeNodeJobStatus NodeJobStatus = "SUBMITTED_TO_NODE" // job has been submitted successfully
-const CompletedNodeJobStatus NodeJobStatus = "COMPLETED"               // job has been submitted successfully and result is visible on chain
-const FailedNodeJobStatus NodeJobStatus = "FAILED"                     // job has been submitted successfully but something went wrong
+const WaitingForTriggerNodeJobStatus NodeJobStatus = "WAITING_FOR_TRIGGER" // job is waiting for its trigger before it can be submitted
+const PendingNodeJobStatus NodeJobStatus = "PENDING"                       // job is waiting to be submitted
+const SubmittedToNodeNodeJobStatus NodeJobStatus = "SUBMITTED_TO_NODE"     // job has been submitted successfully
+const CompletedNodeJobStatus NodeJobStatus = "COMPLETED"                   // job has been submitted successfully and result is visible on chain
+const FailedNodeJobStatus NodeJobStatus = "FAILED"                         // job has been submitted successfully but something went wrong
 
 type NodeJobType string
 
 const BLSToExecutionChangesNodeJobType NodeJobType = "BLS_TO_EXECUTION_CHANGES"
 const VoluntaryExitsNodeJobType NodeJobType = "VOLUNTARY_EXITS"
+const ConditionalVoluntaryExitsNodeJobType NodeJobType = "CONDITIONAL_VOLUNTARY_EXITS"
 const UnknownNodeJobType NodeJobType = "UNKNOWN"
 
 var NodeJobTypes = []NodeJobType{
 	BLSToExecutionChangesNodeJobType,
 	VoluntaryExitsNodeJobType,
+	ConditionalVoluntaryExitsNodeJobType,
+}
+
+type NodeJobTriggerType string
+
+const ScheduledEpochNodeJobTriggerType NodeJobTriggerType = "scheduled_epoch" // submit once the epoch has been reached
+const OfflineNodeJobTriggerType NodeJobTriggerType = "offline"                // submit once a validator of the job has been offline for a number of epochs
+const SiblingSlashedNodeJobTriggerType NodeJobTriggerType = "sibling_slashed" // submit once a validator of the dashboard group got slashed
+const ManualNodeJobTriggerType NodeJobTriggerType = "manual"                  // only submit when the owner triggers the job
+
+var NodeJobTriggerTypes = []NodeJobTriggerType{
+	ScheduledEpochNodeJobTriggerType,
+	OfflineNodeJobTriggerType,
+	SiblingSlashedNodeJobTriggerType,
+	ManualNodeJobTriggerType,
+}
+
+type NodeJobTrigger struct {
+	Type   NodeJobTriggerType `json:"type"`
+	Epoch  uint64             `json:"epoch,omitempty"`  // scheduled_epoch only
+	Epochs uint64             `json:"epochs,omitempty"` // offline only
+}
+
+// ConditionalVoluntaryExitsNodeJobData holds pre-signed voluntary exits of a dashboard group that are submitted together once the trigger fires.
+// Every job can also be triggered manually by its owner.
+type ConditionalVoluntaryExitsNodeJobData struct {
+	DashboardId uint64                        `json:"dashboard_id"`
+	GroupId     uint64                        `json:"group_id"`
+	Trigger     NodeJobTrigger                `json:"trigger"`
+	Exits       []*phase0.SignedVoluntaryExit `json:"exits"`
+}
+
+type NodeJobAuditAction string
+
+const CreatedNodeJobAuditAction NodeJobAuditAction = "CREATED"
+const TriggeredNodeJobAuditAction NodeJobAuditAction = "TRIGGERED"
+const SubmittedNodeJobAuditAction NodeJobAuditAction = "SUBMITTED"
+const SubmissionFailedNodeJobAuditAction NodeJobAuditAction = "SUBMISSION_FAILED"
+const CompletedNodeJobAuditAction NodeJobAuditAction = "COMPLETED"
+
+type NodeJobAuditLogEntry struct {
+	ID             uint64             `db:"id"`
+	NodeJobID      string             `db:"node_job_id"`
+	Ts             time.Time          `db:"ts"`
+	Action         NodeJobAuditAction `db:"action"`
+	ValidatorIndex sql.NullInt64      `db:"validatorindex"`
+	Details        string             `db:"details"`
 }
 
 func NewNodeJob(data []byte) (*NodeJob, error) {
@@ -46,6 +95,7 @@ type NodeJob struct {
 	CompletedTime       sql.NullTime  `db:"completed_time"`
 	Type                NodeJobType   `db:"type"`
 	Status              NodeJobStatus `db:"status"`
+	UserID              sql.NullInt64 `db:"user_id"` // only set for jobs created by a logged in user
 	RawData             []byte        `db:"data"`
 	Data                interface{}   `db:"-"`
 }
@@ -86,6 +136,26 @@ func (nj *NodeJob) ParseData() error {
 			return nj.SanitizeRawData()
 		}
 	}
+	{
+		var d *ConditionalVoluntaryExitsNodeJobData
+		err := json.Unmarshal(nj.RawData, &d)
+		if err == nil && d != nil && d.Trigger.Type != "" && len(d.Exits) > 0 {
+			if nj.Type != "" && nj.Type != UnknownNodeJobType && nj.Type != ConditionalVoluntaryExitsNodeJobType {
+				return fmt.Errorf("nodejob.RawData mismatches nodejob.Type (%v)", nj.Type)
+			}
+			for _, exit := range d.Exits {
+				if exit == nil || exit.Message == nil {
+					return CreateNodeJobUserError{Message: "can not unmarshal data: invalid exit"}
+				}
+			}
+			sort.Slice(d.Exits, func(i, j int) bool {
+				return d.Exits[i].Message.ValidatorIndex < d.Exits[j].Message.ValidatorIndex
+			})
+			nj.Type = ConditionalVoluntaryExitsNodeJobType
+			nj.Data = d
+			return nj.SanitizeRawData()
+		}
+	}
 	{
 		//var d *VoluntaryExitsNodeJobData
 		var d *phase0.SignedVoluntaryExit
@@ -120,3 +190,8 @@ func (nj NodeJob) GetVoluntaryExitsNodeJobData() (*phase0.SignedVoluntaryExit, b
 	d, ok := nj.Data.(*phase0.SignedVoluntaryExit)
 	return d, ok
 }
+
+func (nj NodeJob) GetConditionalVoluntaryExitsNodeJobData() (*ConditionalVoluntaryExitsNodeJobData, bool) {
+	d, ok := nj.Data.(*ConditionalVoluntaryExitsNodeJobData)
+	return d, ok
+}
@@ -1,13 +1,16 @@
 package utils
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"math/big"
 	"net"
 	"os"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/gobitfly/beaconchain/pkg/commons/config"
@@ -93,18 +96,19 @@ func ReadConfig(cfg *types.Config, path string) error {
 		cfg.Frontend.SiteBrand = "beaconcha.in"
 	}
 
-	err = setCLConfig(cfg)
+	err = setChainConfig(cfg)
 	if err != nil {
 		return err
 	}
-
-	err = setELConfig(cfg)
-	if err != nil {
-		return err
+	for i := range cfg.Networks {
+		networkCfg := &types.Config{Chain: cfg.Networks[i].Chain}
+		err = setChainConfig(networkCfg)
+		if err != nil {
+			return fmt.Errorf("error setting chain config of network %v: %w", cfg.Networks[i].Chain.Name, err)
+		}
+		cfg.Networks[i].Chain = networkCfg.Chain
 	}
 
-	cfg.Chain.Name = cfg.Chain.ClConfig.ConfigName
-
 	// match DeploymentType to development, staging, production. if its empty fallback to development
 	validTypes := []string{"development", "development_noisy", "staging", "production"}
 	if cfg.DeploymentType == "" {
@@ -115,51 +119,6 @@ func ReadConfig(cfg *types.Config, path string) error {
 		log.Fatal(fmt.Errorf("invalid DeploymentType: %v (valid types: %v)", cfg.DeploymentType, validTypes), "", 0)
 	}
 
-	if cfg.Chain.GenesisTimestamp == 0 {
-		switch cfg.Chain.Name {
-		case "mainnet":
-			cfg.Chain.GenesisTimestamp = 1606824023
-		case "prater":
-			cfg.Chain.GenesisTimestamp = 1616508000
-		case "sepolia":
-			cfg.Chain.GenesisTimestamp = 1655733600
-		case "zhejiang":
-			cfg.Chain.GenesisTimestamp = 1675263600
-		case "gnosis":
-			cfg.Chain.GenesisTimestamp = 1638993340
-		case "holesky":
-			cfg.Chain.GenesisTimestamp = 1695902400
-		default:
-			return fmt.Errorf("tried to set known genesis-timestamp, but unknown chain-name")
-		}
-	}
-
-	if cfg.Chain.GenesisValidatorsRoot == "" {
-		switch cfg.Chain.Name {
-		case "mainnet":
-			cfg.Chain.GenesisValidatorsRoot = "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"
-		case "prater":
-			cfg.Chain.GenesisValidatorsRoot = "0x043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb"
-		case "sepolia":
-			cfg.Chain.GenesisValidatorsRoot = "0xd8ea171f3c94aea21ebc42a1ed61052acf3f9209c00e4efbaaddac09ed9b8078"
-		case "zhejiang":
-			cfg.Chain.GenesisValidatorsRoot = "0x53a92d8f2bb1d85f62d16a156e6ebcd1bcaba652d0900b2c2f387826f3481f6f"
-		case "gnosis":
-			cfg.Chain.GenesisValidatorsRoot = "0xf5dcb5564e829aab27264b9becd5dfaa017085611224cb3036f573368dbb9d47"
-		case "holesky":
-			cfg.Chain.GenesisValidatorsRoot = "0x9143aa7c615a7f7115e2b6aac319c03529df8242ae705fba9df39b79c59fa8b1"
-		default:
-			return fmt.Errorf("tried to set known genesis-validators-root, but unknown chain-name")
-		}
-	}
-
-	if cfg.Chain.DomainBLSToExecutionChange == "" {
-		cfg.Chain.DomainBLSToExecutionChange = "0x0A000000"
-	}
-	if cfg.Chain.DomainVoluntaryExit == "" {
-		cfg.Chain.DomainVoluntaryExit = "0x04000000"
-	}
-
 	if cfg.Frontend.ClCurrency == "" {
 		switch cfg.Chain.Name {
 		case "gnosis":
@@ -221,6 +180,88 @@ func ReadConfig(cfg *types.Config, path string) error {
 		cfg.Frontend.Ratelimits.DiamondMonth = 6000000
 	}
 
+	if cfg.RedisSessionStoreEndpoint == "" && cfg.RedisCacheEndpoint != "" {
+		log.Warnf("using RedisCacheEndpoint %s as RedisSessionStoreEndpoint as no dedicated RedisSessionStoreEndpoint was provided", cfg.RedisCacheEndpoint)
+		cfg.RedisSessionStoreEndpoint = cfg.RedisCacheEndpoint
+	}
+
+	confSanityCheck(cfg)
+
+	log.InfoWithFields(log.Fields{
+		"genesisTimestamp":       cfg.Chain.GenesisTimestamp,
+		"genesisValidatorsRoot":  cfg.Chain.GenesisValidatorsRoot,
+		"configName":             cfg.Chain.ClConfig.ConfigName,
+		"depositChainID":         cfg.Chain.ClConfig.DepositChainID,
+		"depositNetworkID":       cfg.Chain.ClConfig.DepositNetworkID,
+		"depositContractAddress": cfg.Chain.ClConfig.DepositContractAddress,
+		"clCurrency":             cfg.Frontend.ClCurrency,
+		"elCurrency":             cfg.Frontend.ElCurrency,
+		"mainCurrency":           cfg.Frontend.MainCurrency,
+	}, "did init config")
+
+	Config = cfg
+	return nil
+}
+
+// setChainConfig loads the consensus and execution layer config of cfg.Chain and fills in the defaults of known chains
+func setChainConfig(cfg *types.Config) error {
+	err := setCLConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	err = setELConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	cfg.Chain.Name = cfg.Chain.ClConfig.ConfigName
+
+	if cfg.Chain.GenesisTimestamp == 0 {
+		switch cfg.Chain.Name {
+		case "mainnet":
+			cfg.Chain.GenesisTimestamp = 1606824023
+		case "prater":
+			cfg.Chain.GenesisTimestamp = 1616508000
+		case "sepolia":
+			cfg.Chain.GenesisTimestamp = 1655733600
+		case "zhejiang":
+			cfg.Chain.GenesisTimestamp = 1675263600
+		case "gnosis":
+			cfg.Chain.GenesisTimestamp = 1638993340
+		case "holesky":
+			cfg.Chain.GenesisTimestamp = 1695902400
+		default:
+			return fmt.Errorf("tried to set known genesis-timestamp, but unknown chain-name")
+		}
+	}
+
+	if cfg.Chain.GenesisValidatorsRoot == "" {
+		switch cfg.Chain.Name {
+		case "mainnet":
+			cfg.Chain.GenesisValidatorsRoot = "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"
+		case "prater":
+			cfg.Chain.GenesisValidatorsRoot = "0x043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb"
+		case "sepolia":
+			cfg.Chain.GenesisValidatorsRoot = "0xd8ea171f3c94aea21ebc42a1ed61052acf3f9209c00e4efbaaddac09ed9b8078"
+		case "zhejiang":
+			cfg.Chain.GenesisValidatorsRoot = "0x53a92d8f2bb1d85f62d16a156e6ebcd1bcaba652d0900b2c2f387826f3481f6f"
+		case "gnosis":
+			cfg.Chain.GenesisValidatorsRoot = "0xf5dcb5564e829aab27264b9becd5dfaa017085611224cb3036f573368dbb9d47"
+		case "holesky":
+			cfg.Chain.GenesisValidatorsRoot = "0x9143aa7c615a7f7115e2b6aac319c03529df8242ae705fba9df39b79c59fa8b1"
+		default:
+			return fmt.Errorf("tried to set known genesis-validators-root, but unknown chain-name")
+		}
+	}
+
+	if cfg.Chain.DomainBLSToExecutionChange == "" {
+		cfg.Chain.DomainBLSToExecutionChange = "0x0A000000"
+	}
+	if cfg.Chain.DomainVoluntaryExit == "" {
+		cfg.Chain.DomainVoluntaryExit = "0x04000000"
+	}
+
 	if cfg.Chain.Id != 0 {
 		switch cfg.Chain.Name {
 		case "mainnet", "ethereum":
@@ -243,26 +284,6 @@ func ReadConfig(cfg *types.Config, path string) error {
 
 	cfg.Chain.Id = cfg.Chain.ClConfig.DepositChainID
 
-	if cfg.RedisSessionStoreEndpoint == "" && cfg.RedisCacheEndpoint != "" {
-		log.Warnf("using RedisCacheEndpoint %s as RedisSessionStoreEndpoint as no dedicated RedisSessionStoreEndpoint was provided", cfg.RedisCacheEndpoint)
-		cfg.RedisSessionStoreEndpoint = cfg.RedisCacheEndpoint
-	}
-
-	confSanityCheck(cfg)
-
-	log.InfoWithFields(log.Fields{
-		"genesisTimestamp":       cfg.Chain.GenesisTimestamp,
-		"genesisValidatorsRoot":  cfg.Chain.GenesisValidatorsRoot,
-		"configName":             cfg.Chain.ClConfig.ConfigName,
-		"depositChainID":         cfg.Chain.ClConfig.DepositChainID,
-		"depositNetworkID":       cfg.Chain.ClConfig.DepositNetworkID,
-		"depositContractAddress": cfg.Chain.ClConfig.DepositContractAddress,
-		"clCurrency":             cfg.Frontend.ClCurrency,
-		"elCurrency":             cfg.Frontend.ElCurrency,
-		"mainCurrency":           cfg.Frontend.MainCurrency,
-	}, "did init config")
-
-	Config = cfg
 	return nil
 }
 
@@ -351,7 +372,7 @@ func setCLConfig(cfg *types.Config) error {
 		nodeEndpoint := fmt.Sprintf("http://%s", net.JoinHostPort(cfg.Indexer.Node.Host, cfg.Indexer.Node.Port))
 		client := consapi.NewClient(nodeEndpoint)
 
-		jr, err := client.GetSpec()
+		jr, err := client.GetSpec(context.Background())
 		if err != nil {
 			return err
 		}
@@ -374,6 +395,10 @@ func setCLConfig(cfg *types.Config) error {
 			log.Warnf("DenebForkEpoch not set, defaulting to maxForkEpoch")
 			jr.Data.DenebForkEpoch = &maxForkEpoch
 		}
+		if jr.Data.ElectraForkEpoch == nil {
+			log.Warnf("ElectraForkEpoch not set, defaulting to maxForkEpoch")
+			jr.Data.ElectraForkEpoch = &maxForkEpoch
+		}
 
 		chainCfg := types.ClChainConfig{
 			PresetBase:                              jr.Data.PresetBase,
@@ -393,6 +418,8 @@ func setCLConfig(cfg *types.Config) error {
 			CappellaForkEpoch:                       *jr.Data.CapellaForkEpoch,
 			DenebForkVersion:                        jr.Data.DenebForkVersion,
 			DenebForkEpoch:                          *jr.Data.DenebForkEpoch,
+			ElectraForkVersion:                      jr.Data.ElectraForkVersion,
+			ElectraForkEpoch:                        *jr.Data.ElectraForkEpoch,
 			SecondsPerSlot:                          uint64(jr.Data.SecondsPerSlot),
 			SecondsPerEth1Block:                     uint64(jr.Data.SecondsPerEth1Block),
 			MinValidatorWithdrawabilityDelay:        uint64(jr.Data.MinValidatorWithdrawabilityDelay),
@@ -457,10 +484,19 @@ func setCLConfig(cfg *types.Config) error {
 			MaxValidatorsPerWithdrawalSweep:         uint64(jr.Data.MaxValidatorsPerWithdrawalsSweep),
 			MaxBlsToExecutionChange:                 uint64(jr.Data.MaxBlsToExecutionChanges),
 		}
+		if jr.Data.MinActivationBalance != nil {
+			chainCfg.MinActivationBalance = *jr.Data.MinActivationBalance
+		}
+		if jr.Data.MaxEffectiveBalanceElectra != nil {
+			chainCfg.MaxEffectiveBalanceElectra = *jr.Data.MaxEffectiveBalanceElectra
+		}
+		if jr.Data.MaxPendingPartialsPerWithdrawalsSweep != nil {
+			chainCfg.MaxPendingPartialsPerWithdrawalsSweep = *jr.Data.MaxPendingPartialsPerWithdrawalsSweep
+		}
 
 		cfg.Chain.ClConfig = chainCfg
 
-		gtr, err := client.GetGenesis()
+		gtr, err := client.GetGenesis(context.Background())
 		if err != nil {
 			return err
 		}
@@ -482,6 +518,7 @@ func setCLConfig(cfg *types.Config) error {
 		}
 		cfg.Chain.ClConfig = *chainConfig
 	}
+	setElectraDefaults(&cfg.Chain.ClConfig)
 
 	// rewrite to match to allow trace as well
 	switch strings.ToLower(os.Getenv("LOG_LEVEL")) {
@@ -503,3 +540,51 @@ func setCLConfig(cfg *types.Config) error {
 
 	return nil
 }
+
+// setElectraDefaults fills in the electra parameters for chain configs that predate the fork
+func setElectraDefaults(clConfig *types.ClChainConfig) {
+	if clConfig.ElectraForkEpoch == 0 && clConfig.ElectraForkVersion == "" {
+		clConfig.ElectraForkEpoch = math.MaxUint64
+	}
+	if clConfig.MinActivationBalance == 0 {
+		clConfig.MinActivationBalance = clConfig.MaxEffectiveBalance
+	}
+	if clConfig.MaxEffectiveBalanceElectra == 0 {
+		// 2048 ETH on mainnet
+		clConfig.MaxEffectiveBalanceElectra = clConfig.MaxEffectiveBalance * 64
+	}
+	if clConfig.MaxPendingPartialsPerWithdrawalsSweep == 0 {
+		clConfig.MaxPendingPartialsPerWithdrawalsSweep = 8
+	}
+}
+
+// GetMaxEffectiveBalance returns the max effective balance of a validator with the given withdrawal credentials,
+// validators with compounding (0x02) credentials can have an effective balance above 32 ETH after electra
+func GetMaxEffectiveBalance(withdrawalCredentials []byte) uint64 {
+	if HasCompoundingWithdrawalCredentials(withdrawalCredentials) {
+		return Config.Chain.ClConfig.MaxEffectiveBalanceElectra
+	}
+	return Config.Chain.ClConfig.MaxEffectiveBalance
+}
+
+// GetConsensusNodeEndpoints returns the endpoints of all configured beacon nodes, the primary node comes first
+func GetConsensusNodeEndpoints() []string {
+	endpoints := []string{fmt.Sprintf("http://%s", net.JoinHostPort(Config.Indexer.Node.Host, Config.Indexer.Node.Port))}
+	return append(endpoints, Config.Indexer.Node.FailoverEndpoints...)
+}
+
+// used if no request timeout is configured, large enough for full state requests on mainnet
+const defaultConsensusNodeRequestTimeout = 120 * time.Second
+
+// GetConsensusClientOptions returns the consapi client options for the configured beacon nodes
+func GetConsensusClientOptions() []consapi.ClientOption {
+	requestTimeout := defaultConsensusNodeRequestTimeout
+	if Config.Indexer.Node.RequestTimeout > 0 {
+		requestTimeout = time.Duration(Config.Indexer.Node.RequestTimeout) * time.Second
+	}
+	opts := []consapi.ClientOption{consapi.WithRequestTimeout(requestTimeout)}
+	if Config.Indexer.Node.SSZ {
+		opts = append(opts, consapi.WithSSZ())
+	}
+	return opts
+}
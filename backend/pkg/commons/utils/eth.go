@@ -225,14 +225,11 @@ func GetSigningDomain() ([]byte, error) {
 }
 
 func SyncPeriodOfEpoch(epoch uint64) uint64 {
-	if epoch < Config.Chain.ClConfig.AltairForkEpoch {
-		return 0
-	}
-	return epoch / Config.Chain.ClConfig.EpochsPerSyncCommitteePeriod
+	return Config.Chain.SyncPeriodOfEpoch(epoch)
 }
 
 func FirstEpochOfSyncPeriod(syncPeriod uint64) uint64 {
-	return syncPeriod * Config.Chain.ClConfig.EpochsPerSyncCommitteePeriod
+	return Config.Chain.FirstEpochOfSyncPeriod(syncPeriod)
 }
 
 func SlotsPerSyncCommittee() uint64 {
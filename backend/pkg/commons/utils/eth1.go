@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"bytes"
 	"math/big"
+	"regexp"
 	"strings"
 
 	"github.com/gobitfly/beaconchain/pkg/commons/types"
@@ -12,6 +14,9 @@ import (
 var Erc20TransferEventHash = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
 var Erc1155TransferSingleEventHash = common.HexToHash("0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62")
 
+// scam airdrops usually advertise a website in the name or symbol of the token
+var reSpamTokenName = regexp.MustCompile(`(?i)(https?://|www\.|t\.me/|claim|\.(com|io|org|net|xyz|site|app|live|gift|vip|top)\b)`)
+
 func Eth1BlockReward(blockNumber uint64, difficulty []byte) *big.Int {
 	// no block rewards for PoS blocks
 	// holesky genesis block has difficulty 1 and zero block reward (launched with pos)
@@ -46,3 +51,24 @@ func StripPrefix(hexStr string) string {
 func EthBytesToFloat(b []byte) float64 {
 	return WeiBytesToEther(b).InexactFloat64()
 }
+
+// IsSpamEth1Transaction reports whether a transaction of account is likely spam.
+// Zero value transactions without calldata sent by third parties are used for address poisoning.
+func IsSpamEth1Transaction(tx *types.Eth1TransactionIndexed, account []byte) bool {
+	return !bytes.Equal(tx.GetFrom(), account) &&
+		!tx.GetIsContractCreation() &&
+		len(tx.GetMethodId()) == 0 &&
+		new(big.Int).SetBytes(tx.GetValue()).Sign() == 0
+}
+
+// IsSpamERC20Transfer reports whether a token transfer is likely spam, metadata may be nil if it is not known.
+// Zero value transfers are used for address poisoning, tokens without retrievable metadata or advertising a website are typical for scam airdrops.
+func IsSpamERC20Transfer(transfer *types.Eth1ERC20Indexed, metadata *types.ERC20Metadata) bool {
+	if new(big.Int).SetBytes(transfer.GetValue()).Sign() == 0 {
+		return true
+	}
+	if metadata == nil {
+		return false
+	}
+	return metadata.Symbol == "" || metadata.Symbol == "UNKNOWN" || reSpamTokenName.MatchString(metadata.Symbol) || reSpamTokenName.MatchString(metadata.Name)
+}
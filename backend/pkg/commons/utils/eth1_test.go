@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+)
+
+func TestIsSpamEth1Transaction(t *testing.T) {
+	account := []byte{0x01}
+	tests := []struct {
+		name string
+		tx   *types.Eth1TransactionIndexed
+		want bool
+	}{
+		{"incoming zero value", &types.Eth1TransactionIndexed{From: []byte{0x02}, To: account}, true},
+		{"incoming with value", &types.Eth1TransactionIndexed{From: []byte{0x02}, To: account, Value: []byte{0x01}}, false},
+		{"incoming contract call", &types.Eth1TransactionIndexed{From: []byte{0x02}, To: account, MethodId: []byte{0x6a, 0x76, 0x12, 0x02}}, false},
+		{"outgoing zero value", &types.Eth1TransactionIndexed{From: account, To: []byte{0x02}}, false},
+	}
+	for _, tt := range tests {
+		if got := IsSpamEth1Transaction(tt.tx, account); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestIsSpamERC20Transfer(t *testing.T) {
+	transfer := &types.Eth1ERC20Indexed{Value: []byte{0x01}}
+	tests := []struct {
+		name     string
+		transfer *types.Eth1ERC20Indexed
+		metadata *types.ERC20Metadata
+		want     bool
+	}{
+		{"zero value", &types.Eth1ERC20Indexed{}, &types.ERC20Metadata{Symbol: "USDC"}, true},
+		{"unknown metadata", transfer, nil, false},
+		{"regular token", transfer, &types.ERC20Metadata{Symbol: "USDC", Name: "USD Coin"}, false},
+		{"metadata unavailable", transfer, &types.ERC20Metadata{Symbol: "UNKNOWN"}, true},
+		{"advertising name", transfer, &types.ERC20Metadata{Symbol: "ETH", Name: "Visit eth-rewards.xyz to claim"}, true},
+	}
+	for _, tt := range tests {
+		if got := IsSpamERC20Transfer(tt.transfer, tt.metadata); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
@@ -12,15 +12,12 @@ const (
 
 // SlotToTime returns a time.Time to slot
 func SlotToTime(slot uint64) time.Time {
-	return time.Unix(int64(Config.Chain.GenesisTimestamp+slot*Config.Chain.ClConfig.SecondsPerSlot), 0)
+	return Config.Chain.SlotToTime(slot)
 }
 
 // TimeToSlot returns time to slot in seconds
 func TimeToSlot(timestamp uint64) uint64 {
-	if Config.Chain.GenesisTimestamp > timestamp {
-		return 0
-	}
-	return (timestamp - Config.Chain.GenesisTimestamp) / Config.Chain.ClConfig.SecondsPerSlot
+	return Config.Chain.TimeToSlot(timestamp)
 }
 
 func TimeToFirstSlotOfEpoch(timestamp uint64) uint64 {
@@ -32,7 +29,7 @@ func TimeToFirstSlotOfEpoch(timestamp uint64) uint64 {
 
 // EpochToTime will return a time.Time for an epoch
 func EpochToTime(epoch uint64) time.Time {
-	return time.Unix(int64(Config.Chain.GenesisTimestamp+epoch*Config.Chain.ClConfig.SecondsPerSlot*Config.Chain.ClConfig.SlotsPerEpoch), 0)
+	return Config.Chain.EpochToTime(epoch)
 }
 
 // TimeToDay will return a days since genesis for an timestamp
@@ -47,10 +44,7 @@ func DayToTime(day int64) time.Time {
 
 // TimeToEpoch will return an epoch for a given time
 func TimeToEpoch(ts time.Time) int64 {
-	if int64(Config.Chain.GenesisTimestamp) > ts.Unix() {
-		return 0
-	}
-	return (ts.Unix() - int64(Config.Chain.GenesisTimestamp)) / int64(Config.Chain.ClConfig.SecondsPerSlot) / int64(Config.Chain.ClConfig.SlotsPerEpoch)
+	return Config.Chain.TimeToEpoch(ts)
 }
 
 func EpochsPerDay() uint64 {
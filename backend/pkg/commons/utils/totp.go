@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // RFC 6238 default, authenticator apps only support SHA1 reliably
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP as specified in RFC 6238 with the parameters all authenticator apps support: SHA1, 6 digits and 30 second steps
+
+const (
+	totpDigits       = 6
+	totpPeriod       = 30
+	totpSecretLength = 20
+	// number of steps a code may be off to tolerate clock drift of the device
+	totpAllowedDrift = 1
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTotpSecret returns a random base32 encoded secret
+func GenerateTotpSecret() (string, error) {
+	secret := make([]byte, totpSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// TotpUri returns the otpauth uri authenticator apps read from a qr code
+func TotpUri(issuer, account, secret string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(totpDigits))
+	params.Set("period", fmt.Sprint(totpPeriod))
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+func TotpStep(t time.Time) uint64 {
+	return uint64(t.Unix()) / totpPeriod
+}
+
+// TotpCode returns the code of the given step
+func TotpCode(secret string, step uint64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", fmt.Errorf("invalid totp secret: %w", err)
+	}
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], step)
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	// dynamic truncation, see RFC 4226 section 5.3
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	modulo := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%modulo), nil
+}
+
+// ValidateTotpCode returns the step the code belongs to if it is valid at the given time.
+// Callers have to remember the step and reject codes of the same or earlier steps to prevent replays.
+func ValidateTotpCode(secret, code string, t time.Time) (uint64, bool) {
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	current := TotpStep(t)
+	for drift := -totpAllowedDrift; drift <= totpAllowedDrift; drift++ {
+		step := current + uint64(drift) //nolint:gosec // wraps only for times close to the unix epoch
+		expected, err := TotpCode(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTotpCode(t *testing.T) {
+	// test vectors of RFC 6238 appendix B for SHA1, truncated to 6 digits
+	secret := totpEncoding.EncodeToString([]byte("12345678901234567890"))
+	tests := []struct {
+		ts   int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, tt := range tests {
+		got, err := TotpCode(secret, TotpStep(time.Unix(tt.ts, 0)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("ts %d: expected %s, got %s", tt.ts, tt.want, got)
+		}
+	}
+}
+
+func TestValidateTotpCode(t *testing.T) {
+	secret, err := GenerateTotpSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	now := time.Unix(1700000000, 0)
+	step := TotpStep(now)
+	previous, err := TotpCode(secret, step-1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, ok := ValidateTotpCode(secret, previous, now); !ok || got != step-1 {
+		t.Errorf("expected code of the previous step to be valid, got step %d, valid %v", got, ok)
+	}
+	old, err := TotpCode(secret, step-2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := ValidateTotpCode(secret, old, now); ok {
+		t.Error("expected code two steps ago to be invalid")
+	}
+	if _, ok := ValidateTotpCode(secret, "12345", now); ok {
+		t.Error("expected code with wrong length to be invalid")
+	}
+}
@@ -72,7 +72,7 @@ func ElementExists[T comparable](arr []T, el T) bool {
 }
 
 func EpochOfSlot(slot uint64) uint64 {
-	return slot / Config.Chain.ClConfig.SlotsPerEpoch
+	return Config.Chain.EpochOfSlot(slot)
 }
 
 func GetCurrentFuncName() string {
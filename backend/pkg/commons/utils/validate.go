@@ -4,7 +4,7 @@ import "regexp"
 
 var eth1AddressRE = regexp.MustCompile("^(0x)?[0-9a-fA-F]{40}$")
 var withdrawalCredentialsRE = regexp.MustCompile("^(0x)?00[0-9a-fA-F]{62}$")
-var withdrawalCredentialsAddressRE = regexp.MustCompile("^(0x)?010000000000000000000000[0-9a-fA-F]{40}$")
+var withdrawalCredentialsAddressRE = regexp.MustCompile("^(0x)?0[12]0000000000000000000000[0-9a-fA-F]{40}$")
 var eth1TxRE = regexp.MustCompile("^(0x)?[0-9a-fA-F]{64}$")
 var zeroHashRE = regexp.MustCompile("^(0x)?0+$")
 var hashRE = regexp.MustCompile("^(0x)?[0-9a-fA-F]{96}$")
@@ -43,6 +43,12 @@ func IsValidWithdrawalCredentials(s string) bool {
 }
 
 // IsValidWithdrawalCredentialsAddress verifies whether a string represents valid withdrawal credential with address.
+// This includes compounding (0x02) credentials.
 func IsValidWithdrawalCredentialsAddress(s string) bool {
 	return withdrawalCredentialsAddressRE.MatchString(s)
 }
+
+// HasCompoundingWithdrawalCredentials verifies whether the withdrawal credentials are compounding (0x02) credentials.
+func HasCompoundingWithdrawalCredentials(withCred []byte) bool {
+	return len(withCred) == 32 && withCred[0] == 0x02
+}
@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Minimal WebAuthn relying party verification for passkeys registered without attestation ("none" conveyance).
+// Browsers expose the credential public key as DER SubjectPublicKeyInfo (AuthenticatorAttestationResponse.getPublicKey),
+// so neither CBOR nor COSE keys have to be parsed here.
+
+const (
+	WebAuthnTypeCreate = "webauthn.create"
+	WebAuthnTypeGet    = "webauthn.get"
+
+	// COSE algorithm identifiers, see https://www.iana.org/assignments/cose/cose.xhtml#algorithms
+	WebAuthnAlgES256 int64 = -7
+	WebAuthnAlgEdDSA int64 = -8
+	WebAuthnAlgRS256 int64 = -257
+
+	webAuthnFlagUserPresent          = 0x01
+	webAuthnFlagUserVerified         = 0x04
+	webAuthnFlagAttestedCredentials  = 0x40
+	webAuthnAuthenticatorDataMinSize = 37
+)
+
+var WebAuthnSupportedAlgorithms = []int64{WebAuthnAlgES256, WebAuthnAlgEdDSA, WebAuthnAlgRS256}
+
+type WebAuthnAuthenticatorData struct {
+	RpIdHash     []byte
+	Flags        byte
+	SignCount    uint32
+	CredentialId []byte // only present during registration
+}
+
+func (d WebAuthnAuthenticatorData) UserVerified() bool {
+	return d.Flags&webAuthnFlagUserVerified != 0
+}
+
+// ParseWebAuthnAuthenticatorData parses the binary authenticator data, see https://www.w3.org/TR/webauthn-2/#sctn-authenticator-data
+func ParseWebAuthnAuthenticatorData(data []byte) (*WebAuthnAuthenticatorData, error) {
+	if len(data) < webAuthnAuthenticatorDataMinSize {
+		return nil, errors.New("authenticator data too short")
+	}
+	result := &WebAuthnAuthenticatorData{
+		RpIdHash:  data[:32],
+		Flags:     data[32],
+		SignCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+	if result.Flags&webAuthnFlagAttestedCredentials != 0 {
+		// aaguid (16 bytes), credential id length (2 bytes), credential id, credential public key
+		rest := data[webAuthnAuthenticatorDataMinSize:]
+		if len(rest) < 18 {
+			return nil, errors.New("attested credential data too short")
+		}
+		length := int(binary.BigEndian.Uint16(rest[16:18]))
+		if len(rest) < 18+length {
+			return nil, errors.New("credential id exceeds authenticator data")
+		}
+		result.CredentialId = rest[18 : 18+length]
+	}
+	return result, nil
+}
+
+// VerifyWebAuthnAuthenticatorData checks that the data was created for the relying party and the user was present
+func VerifyWebAuthnAuthenticatorData(data *WebAuthnAuthenticatorData, rpId string) error {
+	rpIdHash := sha256.Sum256([]byte(rpId))
+	if !bytes.Equal(data.RpIdHash, rpIdHash[:]) {
+		return errors.New("authenticator data belongs to another relying party")
+	}
+	if data.Flags&webAuthnFlagUserPresent == 0 {
+		return errors.New("user was not present")
+	}
+	return nil
+}
+
+// VerifyWebAuthnClientData checks the collected client data of a ceremony against the expected values
+func VerifyWebAuthnClientData(clientDataJSON []byte, expectedType string, challenge []byte, origin string) error {
+	var clientData struct {
+		Type      string `json:"type"`
+		Challenge string `json:"challenge"`
+		Origin    string `json:"origin"`
+	}
+	if err := json.Unmarshal(clientDataJSON, &clientData); err != nil {
+		return fmt.Errorf("invalid client data: %w", err)
+	}
+	if clientData.Type != expectedType {
+		return fmt.Errorf("unexpected client data type '%s'", clientData.Type)
+	}
+	if clientData.Challenge != base64.RawURLEncoding.EncodeToString(challenge) {
+		return errors.New("challenge mismatch")
+	}
+	if clientData.Origin != origin {
+		return fmt.Errorf("unexpected origin '%s'", clientData.Origin)
+	}
+	return nil
+}
+
+// ParseWebAuthnPublicKey parses the DER encoded public key and checks that it matches the algorithm
+func ParseWebAuthnPublicKey(publicKey []byte, alg int64) (crypto.PublicKey, error) {
+	key, err := x509.ParsePKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		if alg == WebAuthnAlgES256 && k.Curve.Params().Name == "P-256" {
+			return k, nil
+		}
+	case ed25519.PublicKey:
+		if alg == WebAuthnAlgEdDSA {
+			return k, nil
+		}
+	case *rsa.PublicKey:
+		if alg == WebAuthnAlgRS256 {
+			return k, nil
+		}
+	}
+	return nil, fmt.Errorf("public key does not match algorithm %d", alg)
+}
+
+// VerifyWebAuthnSignature verifies an assertion signature over the authenticator data and the hash of the client data
+func VerifyWebAuthnSignature(publicKey []byte, alg int64, authenticatorData, clientDataJSON, signature []byte) error {
+	key, err := ParseWebAuthnPublicKey(publicKey, alg)
+	if err != nil {
+		return err
+	}
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(bytes.Clone(authenticatorData), clientDataHash[:]...)
+	digest := sha256.Sum256(signed)
+
+	valid := false
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		valid = ecdsa.VerifyASN1(k, digest[:], signature)
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(k, signed, signature)
+	case *rsa.PublicKey:
+		valid = rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], signature) == nil
+	}
+	if !valid {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"testing"
+)
+
+func TestVerifyWebAuthnAssertion(t *testing.T) {
+	const rpId = "beaconcha.in"
+	const origin = "https://beaconcha.in"
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	challenge := []byte("some random challenge")
+	clientDataJSON := []byte(fmt.Sprintf(`{"type":"%s","challenge":"%s","origin":"%s"}`, WebAuthnTypeGet, base64.RawURLEncoding.EncodeToString(challenge), origin))
+	rpIdHash := sha256.Sum256([]byte(rpId))
+	authenticatorData := append(rpIdHash[:], webAuthnFlagUserPresent|webAuthnFlagUserVerified)
+	authenticatorData = binary.BigEndian.AppendUint32(authenticatorData, 42)
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(append([]byte{}, authenticatorData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := VerifyWebAuthnClientData(clientDataJSON, WebAuthnTypeGet, challenge, origin); err != nil {
+		t.Errorf("expected client data to be valid: %v", err)
+	}
+	if err := VerifyWebAuthnClientData(clientDataJSON, WebAuthnTypeGet, []byte("other challenge"), origin); err == nil {
+		t.Error("expected challenge mismatch")
+	}
+	data, err := ParseWebAuthnAuthenticatorData(authenticatorData)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data.SignCount != 42 || !data.UserVerified() {
+		t.Errorf("unexpected authenticator data %+v", data)
+	}
+	if err := VerifyWebAuthnAuthenticatorData(data, rpId); err != nil {
+		t.Errorf("expected authenticator data to be valid: %v", err)
+	}
+	if err := VerifyWebAuthnAuthenticatorData(data, "example.com"); err == nil {
+		t.Error("expected relying party mismatch")
+	}
+	if err := VerifyWebAuthnSignature(publicKey, WebAuthnAlgES256, authenticatorData, clientDataJSON, signature); err != nil {
+		t.Errorf("expected signature to be valid: %v", err)
+	}
+	if err := VerifyWebAuthnSignature(publicKey, WebAuthnAlgES256, authenticatorData, []byte(`{}`), signature); err == nil {
+		t.Error("expected signature over other client data to be invalid")
+	}
+	if err := VerifyWebAuthnSignature(publicKey, WebAuthnAlgRS256, authenticatorData, clientDataJSON, signature); err == nil {
+		t.Error("expected algorithm mismatch")
+	}
+}
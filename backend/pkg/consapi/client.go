@@ -1,7 +1,11 @@
 package consapi
 
 import (
+	"context"
 	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gobitfly/beaconchain/pkg/consapi/types"
 )
@@ -11,59 +15,112 @@ type Client struct {
 }
 type ClientInt interface {
 	// /eth/v2/beacon/blocks/{block_id}
-	GetSlot(blockID any) (*types.StandardBeaconSlotResponse, error)
+	GetSlot(ctx context.Context, blockID any) (*types.StandardBeaconSlotResponse, error)
 
 	// Optional params ids and status to filter the response.
 	// eth/v1/beacon/states/{state_id}/validators
-	GetValidators(state any, ids []string, status []types.ValidatorStatus) (*types.StandardValidatorsResponse, error)
+	GetValidators(ctx context.Context, state any, ids []string, status []types.ValidatorStatus) (*types.StandardValidatorsResponse, error)
 
 	// eth/v1/beacon/states/{state_id}/validators/{validator_id}
-	GetValidator(validatorID, stateID any) (*types.StandardSingleValidatorsResponse, error)
+	GetValidator(ctx context.Context, validatorID, stateID any) (*types.StandardSingleValidatorsResponse, error)
 
 	// /eth/v1/validator/duties/proposer/{epoch}
-	GetPropoalAssignments(epoch uint64) (*types.StandardProposerAssignmentsResponse, error)
+	GetPropoalAssignments(ctx context.Context, epoch uint64) (*types.StandardProposerAssignmentsResponse, error)
 
 	// /eth/v1/beacon/rewards/blocks/{block_id}
-	GetPropoalRewards(blockID any) (*types.StandardBlockRewardsResponse, error)
+	GetPropoalRewards(ctx context.Context, blockID any) (*types.StandardBlockRewardsResponse, error)
 
 	// /eth/v1/beacon/rewards/sync_committee/{block_id}
-	GetSyncRewards(blockID any) (*types.StandardSyncCommitteeRewardsResponse, error)
+	GetSyncRewards(ctx context.Context, blockID any) (*types.StandardSyncCommitteeRewardsResponse, error)
 
 	// /eth/v1/beacon/rewards/attestations/{epoch}
-	GetAttestationRewards(epoch uint64) (*types.StandardAttestationRewardsResponse, error)
+	GetAttestationRewards(ctx context.Context, epoch uint64) (*types.StandardAttestationRewardsResponse, error)
 
 	// /eth/v1/beacon/states/{state_id}/sync_committees
-	GetSyncCommitteesAssignments(epoch *uint64, stateID any) (*types.StandardSyncCommitteesResponse, error)
+	GetSyncCommitteesAssignments(ctx context.Context, epoch *uint64, stateID any) (*types.StandardSyncCommitteesResponse, error)
 
 	// /eth/v1/config/spec
-	GetSpec() (*types.StandardSpecResponse, error)
+	GetSpec(ctx context.Context) (*types.StandardSpecResponse, error)
 
 	// /eth/v1/beacon/headers/{block_id}
-	GetBlockHeader(blockID any) (*types.StandardBeaconHeaderResponse, error)
+	GetBlockHeader(ctx context.Context, blockID any) (*types.StandardBeaconHeaderResponse, error)
 
 	// /eth/v1/beacon/headers
-	GetBlockHeaders(slot *uint64, parentRoot *any) (*types.StandardBeaconHeadersResponse, error)
+	GetBlockHeaders(ctx context.Context, slot *uint64, parentRoot *any) (*types.StandardBeaconHeadersResponse, error)
 
 	// /eth/v1/beacon/states/{state_id}/finality_checkpoints
-	GetFinalityCheckpoints(stateID any) (*types.StandardFinalityCheckpointsResponse, error)
+	GetFinalityCheckpoints(ctx context.Context, stateID any) (*types.StandardFinalityCheckpointsResponse, error)
 
 	// /eth/v1/beacon/states/{state_id}/validator_balances
-	GetValidatorBalances(stateID any) (*types.StandardValidatorBalancesResponse, error)
+	GetValidatorBalances(ctx context.Context, stateID any) (*types.StandardValidatorBalancesResponse, error)
+
+	// /eth/v1/beacon/states/{state_id}/pending_deposits, only available after electra
+	GetPendingDeposits(ctx context.Context, stateID any) (*types.StandardPendingDepositsResponse, error)
+
+	// /eth/v1/beacon/states/{state_id}/pending_partial_withdrawals, only available after electra
+	GetPendingPartialWithdrawals(ctx context.Context, stateID any) (*types.StandardPendingPartialWithdrawalsResponse, error)
+
+	// /eth/v1/beacon/states/{state_id}/pending_consolidations, only available after electra
+	GetPendingConsolidations(ctx context.Context, stateID any) (*types.StandardPendingConsolidationsResponse, error)
+
+	// /eth/v1/beacon/pool/voluntary_exits
+	GetPoolVoluntaryExits(ctx context.Context) (*types.StandardPoolVoluntaryExitsResponse, error)
+
+	// /eth/v1/beacon/pool/bls_to_execution_changes
+	GetPoolBLSToExecutionChanges(ctx context.Context) (*types.StandardPoolBLSToExecutionChangesResponse, error)
 
 	// /eth/v1/beacon/blob_sidecars/{block_id}
-	GetBlobSidecars(blockID any) (*types.StandardBlobSidecarsResponse, error)
+	GetBlobSidecars(ctx context.Context, blockID any) (*types.StandardBlobSidecarsResponse, error)
 
 	// Optional params epoch, index and slot
 	// /eth/v1/beacon/states/%v/committees
-	GetCommittees(stateID any, epoch, index, slot *uint64) (*types.StandardCommitteesResponse, error)
+	GetCommittees(ctx context.Context, stateID any, epoch, index, slot *uint64) (*types.StandardCommitteesResponse, error)
 
 	// /eth/v1/beacon/genesis
-	GetGenesis() (*types.StandardGenesisResponse, error)
+	GetGenesis(ctx context.Context) (*types.StandardGenesisResponse, error)
+
+	// /eth/v1/node/syncing
+	GetSyncing(ctx context.Context) (*types.StandardSyncingResponse, error)
 
 	// /eth/v1/events
-	GetEvents(topics []types.EventTopic) chan *types.EventResponse
+	// The subscription is closed once ctx is done
+	GetEvents(ctx context.Context, topics []types.EventTopic) chan *types.EventResponse
 }
 type NodeClient struct {
 	Endpoint   string
 	httpClient *http.Client
+
+	// requestTimeout bounds a single request made by the multi node client, 0 means no timeout
+	requestTimeout time.Duration
+
+	// ssz enables ssz encoded responses for heavy endpoints, see client_node_ssz.go
+	ssz            bool
+	sszUnsupported sync.Map // routes the node failed to serve as ssz or serves with a fork we can't decode
+	slotsPerEpoch  atomic.Uint64
+}
+
+type ClientOption func(*NodeClient)
+
+// WithSSZ makes the client request validators, balances and blocks ssz encoded.
+// It falls back to json if the node doesn't support ssz for a request.
+func WithSSZ() ClientOption {
+	return func(r *NodeClient) {
+		r.ssz = true
+	}
+}
+
+// WithRequestTimeout bounds every request the multi node client sends to the node, so it can fail over to another node
+// instead of waiting for a hanging one.
+func WithRequestTimeout(timeout time.Duration) ClientOption {
+	return func(r *NodeClient) {
+		r.requestTimeout = timeout
+	}
+}
+
+// requestContext returns the context for a single request to the node
+func (r *NodeClient) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.requestTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, r.requestTimeout)
 }
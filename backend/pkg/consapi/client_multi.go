@@ -0,0 +1,436 @@
+package consapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/consapi/network"
+	"github.com/gobitfly/beaconchain/pkg/consapi/types"
+)
+
+const (
+	nodeHealthCheckInterval = 12 * time.Second
+	nodeHealthCheckTimeout  = 5 * time.Second
+	// nodes reporting a higher sync distance are considered unhealthy
+	maxHealthySyncDistance = 4
+	// nodes are considered unhealthy after this many consecutive failed requests until their next successful health check
+	maxConsecutiveNodeFailures = 3
+)
+
+var ErrNoConsensusNodes = errors.New("no consensus nodes configured")
+
+// MultiNodeClient implements ClientInt on top of multiple beacon nodes.
+// Nodes are health checked periodically and every request is routed to the healthiest node,
+// falling back to the remaining nodes on 5xx responses, timeouts and connection errors.
+type MultiNodeClient struct {
+	nodes []*nodeState
+}
+
+type nodeState struct {
+	client *NodeClient
+	index  int    // position in the configured endpoint list, used as tie breaker
+	label  string // endpoint without credentials, used as metrics label
+
+	mu           sync.RWMutex
+	healthy      bool
+	syncDistance uint64
+	failures     int
+}
+
+// NewMultiNodeClient creates a client that routes requests to the healthiest of the given endpoints.
+// The first endpoint is preferred if all nodes are equally healthy. Nodes are health checked until ctx is done.
+func NewMultiNodeClient(ctx context.Context, endpoints []string, httpClient *http.Client, opts ...ClientOption) Client {
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: 500 * time.Second,
+		}
+	}
+
+	m := &MultiNodeClient{}
+	for i, endpoint := range endpoints {
+		m.nodes = append(m.nodes, &nodeState{
+			client:  newNodeClient(endpoint, httpClient, opts...),
+			index:   i,
+			label:   endpointLabel(endpoint),
+			healthy: true,
+		})
+	}
+
+	m.checkNodes()
+	go m.monitorNodes(ctx)
+
+	return Client{ClientInt: m}
+}
+
+// Nodes returns the underlying node clients in their configured order
+func (m *MultiNodeClient) Nodes() []*NodeClient {
+	nodes := make([]*NodeClient, 0, len(m.nodes))
+	for _, n := range m.nodes {
+		nodes = append(nodes, n.client)
+	}
+	return nodes
+}
+
+func (m *MultiNodeClient) monitorNodes(ctx context.Context) {
+	ticker := time.NewTicker(nodeHealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.checkNodes()
+		}
+	}
+}
+
+func (m *MultiNodeClient) checkNodes() {
+	wg := sync.WaitGroup{}
+	for _, n := range m.nodes {
+		wg.Add(1)
+		go func(n *nodeState) {
+			defer wg.Done()
+			n.check()
+		}(n)
+	}
+	wg.Wait()
+}
+
+func (n *nodeState) check() {
+	ctx, cancel := context.WithTimeout(context.Background(), nodeHealthCheckTimeout)
+	defer cancel()
+
+	res, err := n.client.GetSyncing(ctx)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	wasHealthy := n.healthy
+	if err != nil {
+		n.healthy = false
+	} else {
+		n.syncDistance = uint64(res.Data.SyncDistance)
+		n.healthy = !res.Data.ElOffline && n.syncDistance <= maxHealthySyncDistance
+		n.failures = 0
+	}
+
+	if wasHealthy && !n.healthy {
+		log.WarnWithFields(log.Fields{"endpoint": n.label, "sync_distance": n.syncDistance, "error": err}, "consensus node became unhealthy")
+	} else if !wasHealthy && n.healthy {
+		log.InfoWithFields(log.Fields{"endpoint": n.label}, "consensus node is healthy again")
+	}
+
+	healthy := 0.0
+	if n.healthy {
+		healthy = 1
+	}
+	metrics.ConsensusNodeHealthy.WithLabelValues(n.label).Set(healthy)
+	metrics.ConsensusNodeSyncDistance.WithLabelValues(n.label).Set(float64(n.syncDistance))
+}
+
+func (n *nodeState) isHealthy() bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.healthy
+}
+
+func (n *nodeState) reportResult(err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if err == nil {
+		n.failures = 0
+		return
+	}
+	n.failures++
+	if n.failures >= maxConsecutiveNodeFailures && n.healthy {
+		n.healthy = false
+		metrics.ConsensusNodeHealthy.WithLabelValues(n.label).Set(0)
+		log.WarnWithFields(log.Fields{"endpoint": n.label, "error": err}, "consensus node marked unhealthy after consecutive request failures")
+	}
+}
+
+// orderedNodes returns all nodes sorted by preference: healthy nodes first, then by sync distance and configured order
+func (m *MultiNodeClient) orderedNodes() []*nodeState {
+	type snapshot struct {
+		node         *nodeState
+		healthy      bool
+		syncDistance uint64
+	}
+	snapshots := make([]snapshot, 0, len(m.nodes))
+	for _, n := range m.nodes {
+		n.mu.RLock()
+		snapshots = append(snapshots, snapshot{node: n, healthy: n.healthy, syncDistance: n.syncDistance})
+		n.mu.RUnlock()
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		if snapshots[i].healthy != snapshots[j].healthy {
+			return snapshots[i].healthy
+		}
+		if snapshots[i].syncDistance != snapshots[j].syncDistance {
+			return snapshots[i].syncDistance < snapshots[j].syncDistance
+		}
+		return snapshots[i].node.index < snapshots[j].node.index
+	})
+	nodes := make([]*nodeState, 0, len(snapshots))
+	for _, s := range snapshots {
+		nodes = append(nodes, s.node)
+	}
+	return nodes
+}
+
+// withFailover executes fn against the nodes in order of preference until it succeeds,
+// the error is not retryable or the context is done. Every attempt is bound by the request timeout of the node,
+// so a hanging node doesn't block the failover.
+func withFailover[T any](ctx context.Context, m *MultiNodeClient, method string, fn func(ctx context.Context, c *NodeClient) (*T, error)) (*T, error) {
+	var res *T
+	err := ErrNoConsensusNodes
+	for _, n := range m.orderedNodes() {
+		start := time.Now()
+		attemptCtx, cancel := n.client.requestContext(ctx)
+		res, err = fn(attemptCtx, n.client)
+		cancel()
+		metrics.ConsensusNodeRequestsDuration.WithLabelValues(n.label, method).Observe(time.Since(start).Seconds())
+		metrics.ConsensusNodeRequestsTotal.WithLabelValues(n.label, method, requestStatus(err)).Inc()
+
+		if err == nil || ctx.Err() != nil || !isRetryable(err) {
+			n.reportResult(nil)
+			return res, err
+		}
+		n.reportResult(err)
+	}
+	return res, err
+}
+
+// isRetryable reports whether a request that failed with err should be retried on another node.
+// Client errors (4xx) are returned as is, since other nodes would answer the same.
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	if httpErr := network.SpecificError(err); httpErr != nil {
+		return httpErr.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
+func requestStatus(err error) string {
+	if err == nil {
+		return strconv.Itoa(http.StatusOK)
+	}
+	if httpErr := network.SpecificError(err); httpErr != nil {
+		return strconv.Itoa(httpErr.StatusCode)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+	return "error"
+}
+
+// endpointLabel strips credentials, paths and query params from an endpoint so it can safely be used as metrics label
+func endpointLabel(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return endpoint
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+func (m *MultiNodeClient) GetSlot(ctx context.Context, blockID any) (*types.StandardBeaconSlotResponse, error) {
+	return withFailover(ctx, m, "GetSlot", func(ctx context.Context, c *NodeClient) (*types.StandardBeaconSlotResponse, error) {
+		return c.GetSlot(ctx, blockID)
+	})
+}
+
+func (m *MultiNodeClient) GetValidators(ctx context.Context, state any, ids []string, status []types.ValidatorStatus) (*types.StandardValidatorsResponse, error) {
+	return withFailover(ctx, m, "GetValidators", func(ctx context.Context, c *NodeClient) (*types.StandardValidatorsResponse, error) {
+		return c.GetValidators(ctx, state, ids, status)
+	})
+}
+
+func (m *MultiNodeClient) GetValidator(ctx context.Context, validatorID, stateID any) (*types.StandardSingleValidatorsResponse, error) {
+	return withFailover(ctx, m, "GetValidator", func(ctx context.Context, c *NodeClient) (*types.StandardSingleValidatorsResponse, error) {
+		return c.GetValidator(ctx, validatorID, stateID)
+	})
+}
+
+func (m *MultiNodeClient) GetPropoalAssignments(ctx context.Context, epoch uint64) (*types.StandardProposerAssignmentsResponse, error) {
+	return withFailover(ctx, m, "GetPropoalAssignments", func(ctx context.Context, c *NodeClient) (*types.StandardProposerAssignmentsResponse, error) {
+		return c.GetPropoalAssignments(ctx, epoch)
+	})
+}
+
+func (m *MultiNodeClient) GetPropoalRewards(ctx context.Context, blockID any) (*types.StandardBlockRewardsResponse, error) {
+	return withFailover(ctx, m, "GetPropoalRewards", func(ctx context.Context, c *NodeClient) (*types.StandardBlockRewardsResponse, error) {
+		return c.GetPropoalRewards(ctx, blockID)
+	})
+}
+
+func (m *MultiNodeClient) GetSyncRewards(ctx context.Context, blockID any) (*types.StandardSyncCommitteeRewardsResponse, error) {
+	return withFailover(ctx, m, "GetSyncRewards", func(ctx context.Context, c *NodeClient) (*types.StandardSyncCommitteeRewardsResponse, error) {
+		return c.GetSyncRewards(ctx, blockID)
+	})
+}
+
+func (m *MultiNodeClient) GetAttestationRewards(ctx context.Context, epoch uint64) (*types.StandardAttestationRewardsResponse, error) {
+	return withFailover(ctx, m, "GetAttestationRewards", func(ctx context.Context, c *NodeClient) (*types.StandardAttestationRewardsResponse, error) {
+		return c.GetAttestationRewards(ctx, epoch)
+	})
+}
+
+func (m *MultiNodeClient) GetSyncCommitteesAssignments(ctx context.Context, epoch *uint64, stateID any) (*types.StandardSyncCommitteesResponse, error) {
+	return withFailover(ctx, m, "GetSyncCommitteesAssignments", func(ctx context.Context, c *NodeClient) (*types.StandardSyncCommitteesResponse, error) {
+		return c.GetSyncCommitteesAssignments(ctx, epoch, stateID)
+	})
+}
+
+func (m *MultiNodeClient) GetSpec(ctx context.Context) (*types.StandardSpecResponse, error) {
+	return withFailover(ctx, m, "GetSpec", func(ctx context.Context, c *NodeClient) (*types.StandardSpecResponse, error) {
+		return c.GetSpec(ctx)
+	})
+}
+
+func (m *MultiNodeClient) GetBlockHeader(ctx context.Context, blockID any) (*types.StandardBeaconHeaderResponse, error) {
+	return withFailover(ctx, m, "GetBlockHeader", func(ctx context.Context, c *NodeClient) (*types.StandardBeaconHeaderResponse, error) {
+		return c.GetBlockHeader(ctx, blockID)
+	})
+}
+
+func (m *MultiNodeClient) GetBlockHeaders(ctx context.Context, slot *uint64, parentRoot *any) (*types.StandardBeaconHeadersResponse, error) {
+	return withFailover(ctx, m, "GetBlockHeaders", func(ctx context.Context, c *NodeClient) (*types.StandardBeaconHeadersResponse, error) {
+		return c.GetBlockHeaders(ctx, slot, parentRoot)
+	})
+}
+
+func (m *MultiNodeClient) GetFinalityCheckpoints(ctx context.Context, stateID any) (*types.StandardFinalityCheckpointsResponse, error) {
+	return withFailover(ctx, m, "GetFinalityCheckpoints", func(ctx context.Context, c *NodeClient) (*types.StandardFinalityCheckpointsResponse, error) {
+		return c.GetFinalityCheckpoints(ctx, stateID)
+	})
+}
+
+func (m *MultiNodeClient) GetValidatorBalances(ctx context.Context, stateID any) (*types.StandardValidatorBalancesResponse, error) {
+	return withFailover(ctx, m, "GetValidatorBalances", func(ctx context.Context, c *NodeClient) (*types.StandardValidatorBalancesResponse, error) {
+		return c.GetValidatorBalances(ctx, stateID)
+	})
+}
+
+func (m *MultiNodeClient) GetPendingDeposits(ctx context.Context, stateID any) (*types.StandardPendingDepositsResponse, error) {
+	return withFailover(ctx, m, "GetPendingDeposits", func(ctx context.Context, c *NodeClient) (*types.StandardPendingDepositsResponse, error) {
+		return c.GetPendingDeposits(ctx, stateID)
+	})
+}
+
+func (m *MultiNodeClient) GetPendingPartialWithdrawals(ctx context.Context, stateID any) (*types.StandardPendingPartialWithdrawalsResponse, error) {
+	return withFailover(ctx, m, "GetPendingPartialWithdrawals", func(ctx context.Context, c *NodeClient) (*types.StandardPendingPartialWithdrawalsResponse, error) {
+		return c.GetPendingPartialWithdrawals(ctx, stateID)
+	})
+}
+
+func (m *MultiNodeClient) GetPendingConsolidations(ctx context.Context, stateID any) (*types.StandardPendingConsolidationsResponse, error) {
+	return withFailover(ctx, m, "GetPendingConsolidations", func(ctx context.Context, c *NodeClient) (*types.StandardPendingConsolidationsResponse, error) {
+		return c.GetPendingConsolidations(ctx, stateID)
+	})
+}
+
+func (m *MultiNodeClient) GetPoolVoluntaryExits(ctx context.Context) (*types.StandardPoolVoluntaryExitsResponse, error) {
+	return withFailover(ctx, m, "GetPoolVoluntaryExits", func(ctx context.Context, c *NodeClient) (*types.StandardPoolVoluntaryExitsResponse, error) {
+		return c.GetPoolVoluntaryExits(ctx)
+	})
+}
+
+func (m *MultiNodeClient) GetPoolBLSToExecutionChanges(ctx context.Context) (*types.StandardPoolBLSToExecutionChangesResponse, error) {
+	return withFailover(ctx, m, "GetPoolBLSToExecutionChanges", func(ctx context.Context, c *NodeClient) (*types.StandardPoolBLSToExecutionChangesResponse, error) {
+		return c.GetPoolBLSToExecutionChanges(ctx)
+	})
+}
+
+func (m *MultiNodeClient) GetBlobSidecars(ctx context.Context, blockID any) (*types.StandardBlobSidecarsResponse, error) {
+	return withFailover(ctx, m, "GetBlobSidecars", func(ctx context.Context, c *NodeClient) (*types.StandardBlobSidecarsResponse, error) {
+		return c.GetBlobSidecars(ctx, blockID)
+	})
+}
+
+func (m *MultiNodeClient) GetCommittees(ctx context.Context, stateID any, epoch, index, slot *uint64) (*types.StandardCommitteesResponse, error) {
+	return withFailover(ctx, m, "GetCommittees", func(ctx context.Context, c *NodeClient) (*types.StandardCommitteesResponse, error) {
+		return c.GetCommittees(ctx, stateID, epoch, index, slot)
+	})
+}
+
+func (m *MultiNodeClient) GetGenesis(ctx context.Context) (*types.StandardGenesisResponse, error) {
+	return withFailover(ctx, m, "GetGenesis", func(ctx context.Context, c *NodeClient) (*types.StandardGenesisResponse, error) {
+		return c.GetGenesis(ctx)
+	})
+}
+
+func (m *MultiNodeClient) GetSyncing(ctx context.Context) (*types.StandardSyncingResponse, error) {
+	return withFailover(ctx, m, "GetSyncing", func(ctx context.Context, c *NodeClient) (*types.StandardSyncingResponse, error) {
+		return c.GetSyncing(ctx)
+	})
+}
+
+// GetEvents subscribes to the healthiest node and moves the subscription to another node
+// as soon as the subscribed node becomes unhealthy and a better one is available
+func (m *MultiNodeClient) GetEvents(ctx context.Context, topics []types.EventTopic) chan *types.EventResponse {
+	responseCh := make(chan *types.EventResponse, 32)
+	if len(m.nodes) == 0 {
+		responseCh <- &types.EventResponse{Error: ErrNoConsensusNodes}
+		return responseCh
+	}
+
+	go func() {
+		for ctx.Err() == nil {
+			n := m.orderedNodes()[0]
+			subCtx, cancel := context.WithCancel(ctx)
+			m.forwardEvents(subCtx, n, n.client.GetEvents(subCtx, topics), responseCh)
+			cancel()
+		}
+	}()
+	return responseCh
+}
+
+// forwardEvents forwards events from the subscription on n until ctx is done or n should be replaced
+func (m *MultiNodeClient) forwardEvents(ctx context.Context, n *nodeState, events chan *types.EventResponse, responseCh chan *types.EventResponse) {
+	ticker := time.NewTicker(nodeHealthCheckInterval)
+	defer ticker.Stop()
+
+	shouldSwitch := func() bool {
+		if n.isHealthy() {
+			return false
+		}
+		best := m.orderedNodes()[0]
+		if best == n {
+			return false
+		}
+		log.WarnWithFields(log.Fields{"from": n.label, "to": best.label}, "moving consensus node event subscription")
+		return true
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if shouldSwitch() {
+				return
+			}
+		case e := <-events:
+			if e.Error != nil {
+				metrics.ConsensusNodeRequestsTotal.WithLabelValues(n.label, "GetEvents", requestStatus(e.Error)).Inc()
+			}
+			select {
+			case responseCh <- e:
+			case <-ctx.Done():
+				return
+			}
+			if e.Error != nil && shouldSwitch() {
+				return
+			}
+		}
+	}
+}
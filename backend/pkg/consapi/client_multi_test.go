@@ -0,0 +1,126 @@
+package consapi_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/consapi"
+	"github.com/gobitfly/beaconchain/pkg/consapi/network"
+)
+
+func newTestNode(syncDistance uint64, genesisStatus int, genesisCalls *atomic.Int64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/node/syncing":
+			fmt.Fprintf(w, `{"data":{"head_slot":"100","sync_distance":"%d","is_syncing":false}}`, syncDistance)
+		case "/eth/v1/beacon/genesis":
+			genesisCalls.Add(1)
+			if genesisStatus != http.StatusOK {
+				w.WriteHeader(genesisStatus)
+				return
+			}
+			fmt.Fprint(w, `{"data":{"genesis_time":"1606824023"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestMultiNodeClientFailover(t *testing.T) {
+	var failingCalls, healthyCalls atomic.Int64
+	failing := newTestNode(0, http.StatusInternalServerError, &failingCalls)
+	defer failing.Close()
+	healthy := newTestNode(0, http.StatusOK, &healthyCalls)
+	defer healthy.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mc := consapi.NewMultiNodeClient(ctx, []string{failing.URL, healthy.URL}, nil)
+	res, err := mc.GetGenesis(ctx)
+	if err != nil {
+		t.Fatalf("expected failover to succeed, got: %v", err)
+	}
+	if res.Data.GenesisTime != "1606824023" {
+		t.Errorf("unexpected genesis time: %v", res.Data.GenesisTime)
+	}
+	if failingCalls.Load() != 1 || healthyCalls.Load() != 1 {
+		t.Errorf("expected one call per node, got %d (failing) and %d (healthy)", failingCalls.Load(), healthyCalls.Load())
+	}
+}
+
+func TestMultiNodeClientPrefersSyncedNode(t *testing.T) {
+	var laggingCalls, syncedCalls atomic.Int64
+	lagging := newTestNode(64, http.StatusOK, &laggingCalls)
+	defer lagging.Close()
+	synced := newTestNode(0, http.StatusOK, &syncedCalls)
+	defer synced.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mc := consapi.NewMultiNodeClient(ctx, []string{lagging.URL, synced.URL}, nil)
+	if _, err := mc.GetGenesis(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if laggingCalls.Load() != 0 || syncedCalls.Load() != 1 {
+		t.Errorf("expected the synced node to be used, got %d (lagging) and %d (synced) calls", laggingCalls.Load(), syncedCalls.Load())
+	}
+}
+
+func TestMultiNodeClientNoRetryOnClientError(t *testing.T) {
+	var firstCalls, secondCalls atomic.Int64
+	first := newTestNode(0, http.StatusNotFound, &firstCalls)
+	defer first.Close()
+	second := newTestNode(0, http.StatusOK, &secondCalls)
+	defer second.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mc := consapi.NewMultiNodeClient(ctx, []string{first.URL, second.URL}, nil)
+	_, err := mc.GetGenesis(ctx)
+	httpErr := network.SpecificError(err)
+	if httpErr == nil || httpErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 error, got: %v", err)
+	}
+	if secondCalls.Load() != 0 {
+		t.Errorf("expected no retry on 4xx, got %d calls to second node", secondCalls.Load())
+	}
+}
+
+func TestMultiNodeClientFailoverOnRequestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	hanging := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/node/syncing":
+			fmt.Fprint(w, `{"data":{"head_slot":"100","sync_distance":"0","is_syncing":false}}`)
+		default:
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}
+	}))
+	defer hanging.Close()
+	defer close(release)
+	var healthyCalls atomic.Int64
+	healthy := newTestNode(0, http.StatusOK, &healthyCalls)
+	defer healthy.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mc := consapi.NewMultiNodeClient(ctx, []string{hanging.URL, healthy.URL}, nil, consapi.WithRequestTimeout(100*time.Millisecond))
+	start := time.Now()
+	if _, err := mc.GetGenesis(ctx); err != nil {
+		t.Fatalf("expected failover after the request timeout, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("failover took %v, expected it to be bound by the request timeout", elapsed)
+	}
+	if healthyCalls.Load() != 1 {
+		t.Errorf("expected one call to the healthy node, got %d", healthyCalls.Load())
+	}
+}
@@ -1,6 +1,7 @@
 package consapi
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
@@ -12,11 +13,13 @@ import (
 	"github.com/gobitfly/beaconchain/pkg/consapi/utils"
 )
 
-func NewClient(endpoint string) Client {
-	return NewClientWithConfig(endpoint, nil)
+const eventsResubscribeInterval = 5 * time.Second
+
+func NewClient(endpoint string, opts ...ClientOption) Client {
+	return NewClientWithConfig(endpoint, nil, opts...)
 }
 
-func NewClientWithConfig(endpoint string, httpClient *http.Client) Client {
+func NewClientWithConfig(endpoint string, httpClient *http.Client, opts ...ClientOption) Client {
 	if httpClient == nil {
 		httpClient = &http.Client{
 			Timeout: 500 * time.Second,
@@ -24,60 +27,88 @@ func NewClientWithConfig(endpoint string, httpClient *http.Client) Client {
 	}
 
 	retriever := Client{
-		ClientInt: &NodeClient{
-			Endpoint:   endpoint,
-			httpClient: httpClient,
-		},
+		ClientInt: newNodeClient(endpoint, httpClient, opts...),
 	}
 	return retriever
 }
 
-func (r *NodeClient) GetValidatorBalances(stateID any) (*types.StandardValidatorBalancesResponse, error) {
-	requestURL := fmt.Sprintf("%s/eth/v1/beacon/states/%v/validator_balances", r.Endpoint, stateID)
-	return network.Get[types.StandardValidatorBalancesResponse](r.httpClient, requestURL)
+func newNodeClient(endpoint string, httpClient *http.Client, opts ...ClientOption) *NodeClient {
+	r := &NodeClient{
+		Endpoint:   endpoint,
+		httpClient: httpClient,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func (r *NodeClient) GetValidatorBalances(ctx context.Context, stateID any) (*types.StandardValidatorBalancesResponse, error) {
+	return withSSZFallback(r, sszRouteState, func() (*types.StandardValidatorBalancesResponse, error) {
+		return r.getValidatorBalancesSSZ(ctx, stateID)
+	}, func() (*types.StandardValidatorBalancesResponse, error) {
+		requestURL := fmt.Sprintf("%s/eth/v1/beacon/states/%v/validator_balances", r.Endpoint, stateID)
+		return network.Get[types.StandardValidatorBalancesResponse](ctx, r.httpClient, requestURL)
+	})
 }
 
-func (r *NodeClient) GetFinalityCheckpoints(stateID any) (*types.StandardFinalityCheckpointsResponse, error) {
+func (r *NodeClient) GetFinalityCheckpoints(ctx context.Context, stateID any) (*types.StandardFinalityCheckpointsResponse, error) {
 	requestURL := fmt.Sprintf("%s/eth/v1/beacon/states/%s/finality_checkpoints", r.Endpoint, stateID)
-	return network.Get[types.StandardFinalityCheckpointsResponse](r.httpClient, requestURL)
+	return network.Get[types.StandardFinalityCheckpointsResponse](ctx, r.httpClient, requestURL)
 }
 
-func (r *NodeClient) GetBlockHeader(blockID any) (*types.StandardBeaconHeaderResponse, error) {
+func (r *NodeClient) GetBlockHeader(ctx context.Context, blockID any) (*types.StandardBeaconHeaderResponse, error) {
 	requestURL := fmt.Sprintf("%s/eth/v1/beacon/headers/%v", r.Endpoint, blockID)
-	return network.Get[types.StandardBeaconHeaderResponse](r.httpClient, requestURL)
+	return network.Get[types.StandardBeaconHeaderResponse](ctx, r.httpClient, requestURL)
 }
 
-func (r *NodeClient) GetBlockHeaders(slot *uint64, parentRoot *any) (*types.StandardBeaconHeadersResponse, error) {
+func (r *NodeClient) GetBlockHeaders(ctx context.Context, slot *uint64, parentRoot *any) (*types.StandardBeaconHeadersResponse, error) {
 	requestURL := fmt.Sprintf("%s/eth/v1/beacon/headers", r.Endpoint)
 	if slot != nil {
 		requestURL += fmt.Sprintf("?slot=%d", *slot)
 	} else if parentRoot != nil {
 		requestURL += fmt.Sprintf("?parent_root=%v", *parentRoot)
 	}
-	return network.Get[types.StandardBeaconHeadersResponse](r.httpClient, requestURL)
+	return network.Get[types.StandardBeaconHeadersResponse](ctx, r.httpClient, requestURL)
 }
 
-func (r *NodeClient) GetSyncCommitteesAssignments(epoch *uint64, stateID any) (*types.StandardSyncCommitteesResponse, error) {
+func (r *NodeClient) GetSyncCommitteesAssignments(ctx context.Context, epoch *uint64, stateID any) (*types.StandardSyncCommitteesResponse, error) {
 	var requestURL string
 	if epoch == nil {
 		requestURL = fmt.Sprintf("%s/eth/v1/beacon/states/%v/sync_committees", r.Endpoint, stateID)
 	} else {
 		requestURL = fmt.Sprintf("%s/eth/v1/beacon/states/%v/sync_committees?epoch=%d", r.Endpoint, stateID, *epoch)
 	}
-	return network.Get[types.StandardSyncCommitteesResponse](r.httpClient, requestURL)
+	return network.Get[types.StandardSyncCommitteesResponse](ctx, r.httpClient, requestURL)
 }
 
-func (r *NodeClient) GetSpec() (*types.StandardSpecResponse, error) {
+func (r *NodeClient) GetSpec(ctx context.Context) (*types.StandardSpecResponse, error) {
 	requestURL := fmt.Sprintf("%s/eth/v1/config/spec", r.Endpoint)
-	return network.Get[types.StandardSpecResponse](r.httpClient, requestURL)
+	return network.Get[types.StandardSpecResponse](ctx, r.httpClient, requestURL)
+}
+
+func (r *NodeClient) GetSlot(ctx context.Context, blockID any) (*types.StandardBeaconSlotResponse, error) {
+	return withSSZFallback(r, sszRouteBlock, func() (*types.StandardBeaconSlotResponse, error) {
+		return r.getSlotSSZ(ctx, blockID)
+	}, func() (*types.StandardBeaconSlotResponse, error) {
+		requestURL := fmt.Sprintf("%s/eth/v2/beacon/blocks/%v", r.Endpoint, blockID)
+		return network.Get[types.StandardBeaconSlotResponse](ctx, r.httpClient, requestURL)
+	})
 }
 
-func (r *NodeClient) GetSlot(blockID any) (*types.StandardBeaconSlotResponse, error) {
-	requestURL := fmt.Sprintf("%s/eth/v2/beacon/blocks/%v", r.Endpoint, blockID)
-	return network.Get[types.StandardBeaconSlotResponse](r.httpClient, requestURL)
+func (r *NodeClient) GetValidators(ctx context.Context, state any, ids []string, status []types.ValidatorStatus) (*types.StandardValidatorsResponse, error) {
+	// fetching the whole state only pays off if all validators are requested
+	if len(ids) == 0 {
+		return withSSZFallback(r, sszRouteState, func() (*types.StandardValidatorsResponse, error) {
+			return r.getValidatorsSSZ(ctx, state, status)
+		}, func() (*types.StandardValidatorsResponse, error) {
+			return r.getValidatorsJSON(ctx, state, ids, status)
+		})
+	}
+	return r.getValidatorsJSON(ctx, state, ids, status)
 }
 
-func (r *NodeClient) GetValidators(state any, ids []string, status []types.ValidatorStatus) (*types.StandardValidatorsResponse, error) {
+func (r *NodeClient) getValidatorsJSON(ctx context.Context, state any, ids []string, status []types.ValidatorStatus) (*types.StandardValidatorsResponse, error) {
 	requestURL := fmt.Sprintf("%s/eth/v1/beacon/states/%v/validators", r.Endpoint, state)
 	if len(ids) > 0 {
 		idStr := strings.Join(ids, ",")
@@ -94,40 +125,65 @@ func (r *NodeClient) GetValidators(state any, ids []string, status []types.Valid
 		requestURL += fmt.Sprintf("status=%s", statusStr)
 	}
 
-	return network.Get[types.StandardValidatorsResponse](r.httpClient, requestURL)
+	return network.Get[types.StandardValidatorsResponse](ctx, r.httpClient, requestURL)
 }
 
-func (r *NodeClient) GetValidator(validatorID, state any) (*types.StandardSingleValidatorsResponse, error) {
+func (r *NodeClient) GetValidator(ctx context.Context, validatorID, state any) (*types.StandardSingleValidatorsResponse, error) {
 	requestURL := fmt.Sprintf("%s/eth/v1/beacon/states/%s/validators/%v", r.Endpoint, state, validatorID)
-	return network.Get[types.StandardSingleValidatorsResponse](r.httpClient, requestURL)
+	return network.Get[types.StandardSingleValidatorsResponse](ctx, r.httpClient, requestURL)
 }
 
-func (r *NodeClient) GetPropoalAssignments(epoch uint64) (*types.StandardProposerAssignmentsResponse, error) {
+func (r *NodeClient) GetPropoalAssignments(ctx context.Context, epoch uint64) (*types.StandardProposerAssignmentsResponse, error) {
 	requestURL := fmt.Sprintf("%s/eth/v1/validator/duties/proposer/%d", r.Endpoint, epoch)
-	return network.Get[types.StandardProposerAssignmentsResponse](r.httpClient, requestURL)
+	return network.Get[types.StandardProposerAssignmentsResponse](ctx, r.httpClient, requestURL)
 }
 
-func (r *NodeClient) GetPropoalRewards(blockID any) (*types.StandardBlockRewardsResponse, error) {
+func (r *NodeClient) GetPropoalRewards(ctx context.Context, blockID any) (*types.StandardBlockRewardsResponse, error) {
 	requestURL := fmt.Sprintf("%s/eth/v1/beacon/rewards/blocks/%v", r.Endpoint, blockID)
-	return network.Get[types.StandardBlockRewardsResponse](r.httpClient, requestURL)
+	return network.Get[types.StandardBlockRewardsResponse](ctx, r.httpClient, requestURL)
 }
 
-func (r *NodeClient) GetSyncRewards(blockID any) (*types.StandardSyncCommitteeRewardsResponse, error) {
+func (r *NodeClient) GetSyncRewards(ctx context.Context, blockID any) (*types.StandardSyncCommitteeRewardsResponse, error) {
 	requestURL := fmt.Sprintf("%s/eth/v1/beacon/rewards/sync_committee/%v", r.Endpoint, blockID)
-	return network.Post[types.StandardSyncCommitteeRewardsResponse](r.httpClient, requestURL)
+	return network.Post[types.StandardSyncCommitteeRewardsResponse](ctx, r.httpClient, requestURL)
 }
 
-func (r *NodeClient) GetAttestationRewards(epoch uint64) (*types.StandardAttestationRewardsResponse, error) {
+func (r *NodeClient) GetAttestationRewards(ctx context.Context, epoch uint64) (*types.StandardAttestationRewardsResponse, error) {
 	requestURL := fmt.Sprintf("%s/eth/v1/beacon/rewards/attestations/%v", r.Endpoint, epoch)
-	return network.Post[types.StandardAttestationRewardsResponse](r.httpClient, requestURL)
+	return network.Post[types.StandardAttestationRewardsResponse](ctx, r.httpClient, requestURL)
+}
+
+func (r *NodeClient) GetPendingDeposits(ctx context.Context, stateID any) (*types.StandardPendingDepositsResponse, error) {
+	requestURL := fmt.Sprintf("%s/eth/v1/beacon/states/%v/pending_deposits", r.Endpoint, stateID)
+	return network.Get[types.StandardPendingDepositsResponse](ctx, r.httpClient, requestURL)
+}
+
+func (r *NodeClient) GetPendingPartialWithdrawals(ctx context.Context, stateID any) (*types.StandardPendingPartialWithdrawalsResponse, error) {
+	requestURL := fmt.Sprintf("%s/eth/v1/beacon/states/%v/pending_partial_withdrawals", r.Endpoint, stateID)
+	return network.Get[types.StandardPendingPartialWithdrawalsResponse](ctx, r.httpClient, requestURL)
+}
+
+func (r *NodeClient) GetPendingConsolidations(ctx context.Context, stateID any) (*types.StandardPendingConsolidationsResponse, error) {
+	requestURL := fmt.Sprintf("%s/eth/v1/beacon/states/%v/pending_consolidations", r.Endpoint, stateID)
+	return network.Get[types.StandardPendingConsolidationsResponse](ctx, r.httpClient, requestURL)
+}
+
+func (r *NodeClient) GetPoolVoluntaryExits(ctx context.Context) (*types.StandardPoolVoluntaryExitsResponse, error) {
+	requestURL := fmt.Sprintf("%s/eth/v1/beacon/pool/voluntary_exits", r.Endpoint)
+	return network.Get[types.StandardPoolVoluntaryExitsResponse](ctx, r.httpClient, requestURL)
+}
+
+func (r *NodeClient) GetPoolBLSToExecutionChanges(ctx context.Context) (*types.StandardPoolBLSToExecutionChangesResponse, error) {
+	requestURL := fmt.Sprintf("%s/eth/v1/beacon/pool/bls_to_execution_changes", r.Endpoint)
+	return network.Get[types.StandardPoolBLSToExecutionChangesResponse](ctx, r.httpClient, requestURL)
 }
 
-func (r *NodeClient) GetBlobSidecars(blockID any) (*types.StandardBlobSidecarsResponse, error) {
+func (r *NodeClient) GetBlobSidecars(ctx context.Context, blockID any) (*types.StandardBlobSidecarsResponse, error) {
 	requestURL := fmt.Sprintf("%s/eth/v1/beacon/blob_sidecars/%v", r.Endpoint, blockID)
-	return network.Get[types.StandardBlobSidecarsResponse](r.httpClient, requestURL)
+	return network.Get[types.StandardBlobSidecarsResponse](ctx, r.httpClient, requestURL)
 }
 
-func (r *NodeClient) GetCommittees(stateID any, epoch, index, slot *uint64) (*types.StandardCommitteesResponse, error) {
+func (r *NodeClient) GetCommittees(ctx context.Context, stateID any, epoch, index, slot *uint64) (*types.StandardCommitteesResponse, error) {
 	requestURL := fmt.Sprintf("%s/eth/v1/beacon/states/%v/committees", r.Endpoint, stateID)
 	if epoch != nil {
 		requestURL += fmt.Sprintf("?epoch=%d", *epoch)
@@ -136,43 +192,68 @@ func (r *NodeClient) GetCommittees(stateID any, epoch, index, slot *uint64) (*ty
 	} else if slot != nil {
 		requestURL += fmt.Sprintf("?slot=%d", *slot)
 	}
-	return network.Get[types.StandardCommitteesResponse](r.httpClient, requestURL)
+	return network.Get[types.StandardCommitteesResponse](ctx, r.httpClient, requestURL)
 }
 
-func (r *NodeClient) GetGenesis() (*types.StandardGenesisResponse, error) {
+func (r *NodeClient) GetGenesis(ctx context.Context) (*types.StandardGenesisResponse, error) {
 	requestURL := fmt.Sprintf("%s/eth/v1/beacon/genesis", r.Endpoint)
-	return network.Get[types.StandardGenesisResponse](r.httpClient, requestURL)
+	return network.Get[types.StandardGenesisResponse](ctx, r.httpClient, requestURL)
+}
+
+func (r *NodeClient) GetSyncing(ctx context.Context) (*types.StandardSyncingResponse, error) {
+	requestURL := fmt.Sprintf("%s/eth/v1/node/syncing", r.Endpoint)
+	return network.Get[types.StandardSyncingResponse](ctx, r.httpClient, requestURL)
 }
 
-func (r *NodeClient) GetEvents(topics []types.EventTopic) chan *types.EventResponse {
+func (r *NodeClient) GetEvents(ctx context.Context, topics []types.EventTopic) chan *types.EventResponse {
 	joinedTopics := strings.Join(utils.ConvertToStringSlice(topics), ",")
 	requestURL := fmt.Sprintf("%s/eth/v1/events?topics=%v", r.Endpoint, joinedTopics)
 	responseCh := make(chan *types.EventResponse, 32)
 
-	req, _ := http.NewRequest(http.MethodGet, requestURL, nil)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
 	// disable gzip compression for sse
 	req.Header.Set("accept-encoding", "identity")
 
 	go func() {
-		stream, err := eventsource.SubscribeWithRequest("", req)
-
-		if err != nil {
-			responseCh <- &types.EventResponse{Error: err}
-			return
+		// keep trying to subscribe, the node might not be reachable yet (e.g. while restarting)
+		var stream *eventsource.Stream
+		for {
+			var err error
+			stream, err = eventsource.SubscribeWithRequest("", req)
+			if err == nil {
+				break
+			}
+			select {
+			case responseCh <- &types.EventResponse{Error: err}:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case <-time.After(eventsResubscribeInterval):
+			case <-ctx.Done():
+				return
+			}
 		}
 		defer stream.Close()
 
 		for {
+			var response *types.EventResponse
 			select {
+			case <-ctx.Done():
+				return
 			// It is important to register to Errors, otherwise the stream does not reconnect if the connection was lost
 			case err := <-stream.Errors:
-				responseCh <- &types.EventResponse{Error: err}
+				response = &types.EventResponse{Error: err}
 			case e := <-stream.Events:
-				var response types.EventResponse
-				response.Data = []byte(e.Data())
-				response.Event = types.EventTopic(e.Event())
-
-				responseCh <- &response
+				response = &types.EventResponse{
+					Data:  []byte(e.Data()),
+					Event: types.EventTopic(e.Event()),
+				}
+			}
+			select {
+			case responseCh <- response:
+			case <-ctx.Done():
+				return
 			}
 		}
 	}()
@@ -0,0 +1,88 @@
+package consapi_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/consapi"
+)
+
+const electraBlockJSON = `{
+	"version": "electra",
+	"execution_optimistic": false,
+	"finalized": true,
+	"data": {
+		"message": {
+			"slot": "100",
+			"proposer_index": "7",
+			"parent_root": "0x01",
+			"state_root": "0x02",
+			"body": {
+				"randao_reveal": "0x",
+				"eth1_data": {"deposit_root": "0x", "deposit_count": "0", "block_hash": "0x"},
+				"graffiti": "0x",
+				"proposer_slashings": [],
+				"attester_slashings": [],
+				"attestations": [{
+					"aggregation_bits": "0x0f",
+					"signature": "0x",
+					"committee_bits": "0x0500000000000000",
+					"data": {
+						"slot": "99",
+						"index": "0",
+						"beacon_block_root": "0x",
+						"source": {"epoch": "2", "root": "0x"},
+						"target": {"epoch": "3", "root": "0x"}
+					}
+				}],
+				"deposits": [],
+				"voluntary_exits": [],
+				"execution_requests": {
+					"deposits": [{"pubkey": "0xaa", "withdrawal_credentials": "0x02", "amount": "1000000000", "signature": "0xbb", "index": "12"}],
+					"withdrawals": [{"source_address": "0xcc", "validator_pubkey": "0xdd", "amount": "0"}],
+					"consolidations": [{"source_address": "0xcc", "source_pubkey": "0xdd", "target_pubkey": "0xee"}]
+				}
+			}
+		},
+		"signature": "0x"
+	}
+}`
+
+func TestGetSlotElectra(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v2/beacon/blocks/100" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, electraBlockJSON)
+	}))
+	defer server.Close()
+
+	client := consapi.NewClient(server.URL)
+	res, err := client.GetSlot(context.Background(), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := res.Data.Message.Body
+	if len(body.Attestations) != 1 || len(body.Attestations[0].CommitteeBits) != 8 || body.Attestations[0].CommitteeBits[0] != 0x05 {
+		t.Errorf("unexpected attestation committee bits: %+v", body.Attestations)
+	}
+	requests := body.ExecutionRequests
+	if requests == nil {
+		t.Fatal("expected execution requests to be parsed")
+	}
+	if len(requests.Deposits) != 1 || requests.Deposits[0].Amount != 1e9 || requests.Deposits[0].Index != 12 {
+		t.Errorf("unexpected deposit requests: %+v", requests.Deposits)
+	}
+	if len(requests.Withdrawals) != 1 || requests.Withdrawals[0].Amount != 0 {
+		t.Errorf("unexpected withdrawal requests: %+v", requests.Withdrawals)
+	}
+	if len(requests.Consolidations) != 1 || requests.Consolidations[0].TargetPubkey[0] != 0xee {
+		t.Errorf("unexpected consolidation requests: %+v", requests.Consolidations)
+	}
+}
@@ -0,0 +1,399 @@
+package consapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gobitfly/beaconchain/pkg/consapi/network"
+	"github.com/gobitfly/beaconchain/pkg/consapi/types"
+)
+
+// Heavy endpoints can be requested ssz encoded, which is a lot cheaper to decode than json.
+// The beacon api only offers ssz for blocks and states, so validators and balances are derived from the full state.
+// Only forks known to the ssz library are decoded, everything else as well as nodes without ssz support fall back to json.
+// The vendored go-eth2-client has no electra types yet, so once a node answers with a newer fork the route stays on json.
+
+const (
+	sszRouteState = "state"
+	sszRouteBlock = "block"
+
+	farFutureEpoch = math.MaxUint64
+)
+
+// errSSZForkNotSupported is returned if the node responded with a fork we can't decode, the json endpoint is used instead
+var errSSZForkNotSupported = errors.New("ssz decoding not supported for fork")
+
+var sszSupportedForks = []string{"deneb"}
+
+func (r *NodeClient) sszEnabled(route string) bool {
+	if !r.ssz {
+		return false
+	}
+	_, unsupported := r.sszUnsupported.Load(route)
+	return !unsupported
+}
+
+// withSSZFallback tries the ssz variant of a request first and falls back to json if the node can't serve it as ssz
+func withSSZFallback[T any](r *NodeClient, route string, ssz func() (*T, error), json func() (*T, error)) (*T, error) {
+	if !r.sszEnabled(route) {
+		return json()
+	}
+	res, err := ssz()
+	if err == nil {
+		return res, nil
+	}
+	if errors.Is(err, errSSZForkNotSupported) {
+		// forks only move forward, so every following ssz request for this route would be a wasted round trip
+		r.sszUnsupported.Store(route, true)
+		return json()
+	}
+	httpErr := network.SpecificError(err)
+	if !errors.Is(err, network.ErrSSZNotSupported) && (httpErr == nil || httpErr.StatusCode >= 500) {
+		// connection errors and server errors are not caused by the encoding
+		return res, err
+	}
+	res, err = json()
+	if err == nil {
+		// the node can serve the request, just not as ssz (e.g. debug endpoints are disabled)
+		r.sszUnsupported.Store(route, true)
+	}
+	return res, err
+}
+
+// getSSZ fetches requestURL ssz encoded, fork is the consensus version the node responded with
+func (r *NodeClient) getSSZ(ctx context.Context, requestURL string) (data []byte, fork string, err error) {
+	body, fork, err := network.HTTPReqSSZ(ctx, requestURL, r.httpClient)
+	if err != nil {
+		return nil, "", err
+	}
+	defer body.Close()
+
+	found := false
+	for _, f := range sszSupportedForks {
+		if f == fork {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, "", fmt.Errorf("%w: %s", errSSZForkNotSupported, fork)
+	}
+
+	data, err = io.ReadAll(body)
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading ssz response: %w", err)
+	}
+	return data, fork, nil
+}
+
+func (r *NodeClient) getStateSSZ(ctx context.Context, stateID any) (*deneb.BeaconState, error) {
+	requestURL := fmt.Sprintf("%s/eth/v2/debug/beacon/states/%v", r.Endpoint, stateID)
+	data, _, err := r.getSSZ(ctx, requestURL)
+	if err != nil {
+		return nil, err
+	}
+	state := &deneb.BeaconState{}
+	if err := state.UnmarshalSSZ(data); err != nil {
+		return nil, fmt.Errorf("error decoding ssz state: %w", err)
+	}
+	return state, nil
+}
+
+func (r *NodeClient) getSlotsPerEpoch(ctx context.Context) (uint64, error) {
+	if slotsPerEpoch := r.slotsPerEpoch.Load(); slotsPerEpoch != 0 {
+		return slotsPerEpoch, nil
+	}
+	spec, err := r.GetSpec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if spec.Data.SlotsPerEpoch == 0 {
+		return 0, errors.New("SLOTS_PER_EPOCH not set in spec")
+	}
+	r.slotsPerEpoch.Store(uint64(spec.Data.SlotsPerEpoch))
+	return uint64(spec.Data.SlotsPerEpoch), nil
+}
+
+func (r *NodeClient) getValidatorsSSZ(ctx context.Context, stateID any, status []types.ValidatorStatus) (*types.StandardValidatorsResponse, error) {
+	slotsPerEpoch, err := r.getSlotsPerEpoch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	state, err := r.getStateSSZ(ctx, stateID)
+	if err != nil {
+		return nil, err
+	}
+	return validatorsFromState(state, slotsPerEpoch, status), nil
+}
+
+func (r *NodeClient) getValidatorBalancesSSZ(ctx context.Context, stateID any) (*types.StandardValidatorBalancesResponse, error) {
+	state, err := r.getStateSSZ(ctx, stateID)
+	if err != nil {
+		return nil, err
+	}
+	res := &types.StandardValidatorBalancesResponse{
+		Data: make([]types.StandardValidatorBalance, len(state.Balances)),
+	}
+	for i, balance := range state.Balances {
+		res.Data[i] = types.StandardValidatorBalance{
+			Index:   uint64(i),
+			Balance: uint64(balance),
+		}
+	}
+	return res, nil
+}
+
+func validatorsFromState(state *deneb.BeaconState, slotsPerEpoch uint64, status []types.ValidatorStatus) *types.StandardValidatorsResponse {
+	epoch := uint64(state.Slot) / slotsPerEpoch
+	res := &types.StandardValidatorsResponse{
+		Data: make([]types.StandardValidator, 0, len(state.Validators)),
+	}
+	for i, v := range state.Validators {
+		var balance uint64
+		if i < len(state.Balances) {
+			balance = uint64(state.Balances[i])
+		}
+		validatorStatus := getValidatorStatus(v, balance, epoch)
+		if !matchesValidatorStatus(validatorStatus, status) {
+			continue
+		}
+
+		validator := types.StandardValidator{
+			Index:   uint64(i),
+			Balance: balance,
+			Status:  validatorStatus,
+		}
+		validator.Validator.Pubkey = v.PublicKey[:]
+		validator.Validator.WithdrawalCredentials = v.WithdrawalCredentials
+		validator.Validator.EffectiveBalance = uint64(v.EffectiveBalance)
+		validator.Validator.Slashed = v.Slashed
+		validator.Validator.ActivationEligibilityEpoch = uint64(v.ActivationEligibilityEpoch)
+		validator.Validator.ActivationEpoch = uint64(v.ActivationEpoch)
+		validator.Validator.ExitEpoch = uint64(v.ExitEpoch)
+		validator.Validator.WithdrawableEpoch = uint64(v.WithdrawableEpoch)
+		res.Data = append(res.Data, validator)
+	}
+	return res
+}
+
+// getValidatorStatus derives the status of a validator at epoch the same way beacon nodes do for the validators endpoint
+func getValidatorStatus(v *phase0.Validator, balance, epoch uint64) types.ValidatorStatus {
+	switch {
+	case uint64(v.ActivationEpoch) > epoch:
+		if uint64(v.ActivationEligibilityEpoch) == farFutureEpoch {
+			return types.PendingInitialized
+		}
+		return types.PendingQueued
+	case uint64(v.ExitEpoch) > epoch:
+		if uint64(v.ExitEpoch) == farFutureEpoch {
+			return types.ActiveOngoing
+		}
+		if v.Slashed {
+			return types.ActiveSlashed
+		}
+		return types.ActiveExiting
+	case uint64(v.WithdrawableEpoch) > epoch:
+		if v.Slashed {
+			return types.ExitedSlashed
+		}
+		return types.ExitedUnslashed
+	case balance != 0:
+		return types.WithdrawalPossible
+	default:
+		return types.WithdrawalDone
+	}
+}
+
+// matchesValidatorStatus applies a status filter like the beacon api does, so "active" matches all active_* statuses
+func matchesValidatorStatus(status types.ValidatorStatus, filter []types.ValidatorStatus) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, f := range filter {
+		if status == f || strings.HasPrefix(string(status), string(f)+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *NodeClient) getSlotSSZ(ctx context.Context, blockID any) (*types.StandardBeaconSlotResponse, error) {
+	requestURL := fmt.Sprintf("%s/eth/v2/beacon/blocks/%v", r.Endpoint, blockID)
+	data, fork, err := r.getSSZ(ctx, requestURL)
+	if err != nil {
+		return nil, err
+	}
+	block := &deneb.SignedBeaconBlock{}
+	if err := block.UnmarshalSSZ(data); err != nil {
+		return nil, fmt.Errorf("error decoding ssz block: %w", err)
+	}
+	return &types.StandardBeaconSlotResponse{
+		Version: fork,
+		Data:    denebBlockToAnySignedBlock(block),
+	}, nil
+}
+
+func denebBlockToAnySignedBlock(block *deneb.SignedBeaconBlock) types.AnySignedBlock {
+	var res types.AnySignedBlock
+	res.Signature = block.Signature[:]
+
+	msg := block.Message
+	res.Message.Slot = uint64(msg.Slot)
+	res.Message.ProposerIndex = uint64(msg.ProposerIndex)
+	res.Message.ParentRoot = msg.ParentRoot[:]
+	res.Message.StateRoot = msg.StateRoot[:]
+
+	body := msg.Body
+	res.Message.Body.RandaoReveal = body.RANDAOReveal[:]
+	res.Message.Body.Eth1Data = types.Eth1Data{
+		DepositRoot:  body.ETH1Data.DepositRoot[:],
+		DepositCount: body.ETH1Data.DepositCount,
+		BlockHash:    body.ETH1Data.BlockHash,
+	}
+	res.Message.Body.Graffiti = body.Graffiti[:]
+
+	res.Message.Body.ProposerSlashings = make([]types.ProposerSlashing, len(body.ProposerSlashings))
+	for i, s := range body.ProposerSlashings {
+		res.Message.Body.ProposerSlashings[i].SignedHeader1 = convertSignedHeader(s.SignedHeader1).SignedHeader1
+		res.Message.Body.ProposerSlashings[i].SignedHeader2 = convertSignedHeader(s.SignedHeader2).SignedHeader1
+	}
+
+	res.Message.Body.AttesterSlashings = make([]types.AttesterSlashing, len(body.AttesterSlashings))
+	for i, s := range body.AttesterSlashings {
+		slashing := &res.Message.Body.AttesterSlashings[i]
+		slashing.Attestation1.AttestingIndices = toUint64Str(s.Attestation1.AttestingIndices)
+		slashing.Attestation1.Signature = s.Attestation1.Signature[:]
+		slashing.Attestation1.Data = convertAttestationData(s.Attestation1.Data).Data
+		slashing.Attestation2.AttestingIndices = toUint64Str(s.Attestation2.AttestingIndices)
+		slashing.Attestation2.Signature = s.Attestation2.Signature[:]
+		slashing.Attestation2.Data = convertAttestationData(s.Attestation2.Data).Data
+	}
+
+	res.Message.Body.Attestations = make([]types.Attestation, len(body.Attestations))
+	for i, a := range body.Attestations {
+		attestation := convertAttestationData(a.Data)
+		attestation.AggregationBits = hexutil.Bytes(a.AggregationBits)
+		attestation.Signature = a.Signature[:]
+		res.Message.Body.Attestations[i] = attestation
+	}
+
+	res.Message.Body.Deposits = make([]types.Deposit, len(body.Deposits))
+	for i, d := range body.Deposits {
+		deposit := &res.Message.Body.Deposits[i]
+		deposit.Proof = make([]hexutil.Bytes, len(d.Proof))
+		for j, p := range d.Proof {
+			deposit.Proof[j] = p
+		}
+		deposit.Data.Pubkey = d.Data.PublicKey[:]
+		deposit.Data.WithdrawalCredentials = d.Data.WithdrawalCredentials
+		deposit.Data.Amount = uint64(d.Data.Amount)
+		deposit.Data.Signature = d.Data.Signature[:]
+	}
+
+	res.Message.Body.VoluntaryExits = make([]types.VoluntaryExit, len(body.VoluntaryExits))
+	for i, e := range body.VoluntaryExits {
+		res.Message.Body.VoluntaryExits[i].Message.Epoch = uint64(e.Message.Epoch)
+		res.Message.Body.VoluntaryExits[i].Message.ValidatorIndex = uint64(e.Message.ValidatorIndex)
+		res.Message.Body.VoluntaryExits[i].Signature = e.Signature[:]
+	}
+
+	if body.SyncAggregate != nil {
+		res.Message.Body.SyncAggregate = &types.SyncAggregate{
+			SyncCommitteeBits:      hexutil.Bytes(body.SyncAggregate.SyncCommitteeBits),
+			SyncCommitteeSignature: body.SyncAggregate.SyncCommitteeSignature[:],
+		}
+	}
+
+	if p := body.ExecutionPayload; p != nil {
+		payload := &types.ExecutionPayload{
+			ParentHash:    p.ParentHash[:],
+			FeeRecipient:  p.FeeRecipient[:],
+			StateRoot:     p.StateRoot[:],
+			ReceiptsRoot:  p.ReceiptsRoot[:],
+			LogsBloom:     p.LogsBloom[:],
+			PrevRandao:    p.PrevRandao[:],
+			BlockNumber:   p.BlockNumber,
+			GasLimit:      p.GasLimit,
+			GasUsed:       p.GasUsed,
+			Timestamp:     p.Timestamp,
+			ExtraData:     p.ExtraData,
+			BlockHash:     p.BlockHash[:],
+			Transactions:  make([]hexutil.Bytes, len(p.Transactions)),
+			Withdrawals:   make([]types.WithdrawalPayload, len(p.Withdrawals)),
+			BlobGasUsed:   p.BlobGasUsed,
+			ExcessBlobGas: p.ExcessBlobGas,
+		}
+		if p.BaseFeePerGas != nil {
+			payload.BaseFeePerGas = p.BaseFeePerGas.Uint64()
+		}
+		for i, tx := range p.Transactions {
+			payload.Transactions[i] = hexutil.Bytes(tx)
+		}
+		for i, w := range p.Withdrawals {
+			payload.Withdrawals[i] = types.WithdrawalPayload{
+				Index:          uint64(w.Index),
+				ValidatorIndex: uint64(w.ValidatorIndex),
+				Address:        w.Address[:],
+				Amount:         uint64(w.Amount),
+			}
+		}
+		res.Message.Body.ExecutionPayload = payload
+	}
+
+	res.Message.Body.SignedBLSToExecutionChange = make([]*types.SignedBLSToExecutionChange, len(body.BLSToExecutionChanges))
+	for i, c := range body.BLSToExecutionChanges {
+		change := &types.SignedBLSToExecutionChange{}
+		change.Message.ValidatorIndex = uint64(c.Message.ValidatorIndex)
+		change.Message.FromBlsPubkey = c.Message.FromBLSPubkey[:]
+		change.Message.ToExecutionAddress = c.Message.ToExecutionAddress[:]
+		change.Signature = c.Signature[:]
+		res.Message.Body.SignedBLSToExecutionChange[i] = change
+	}
+
+	res.Message.Body.BlobKZGCommitments = make([]hexutil.Bytes, len(body.BlobKZGCommitments))
+	for i, c := range body.BlobKZGCommitments {
+		res.Message.Body.BlobKZGCommitments[i] = c[:]
+	}
+
+	return res
+}
+
+// convertAttestationData returns an attestation with only its data set, the data can also be assigned to attester slashings
+func convertAttestationData(d *phase0.AttestationData) types.Attestation {
+	var res types.Attestation
+	res.Data.Slot = uint64(d.Slot)
+	res.Data.Index = uint16(d.Index)
+	res.Data.BeaconBlockRoot = d.BeaconBlockRoot[:]
+	res.Data.Source.Epoch = uint64(d.Source.Epoch)
+	res.Data.Source.Root = d.Source.Root[:]
+	res.Data.Target.Epoch = uint64(d.Target.Epoch)
+	res.Data.Target.Root = d.Target.Root[:]
+	return res
+}
+
+// convertSignedHeader returns a proposer slashing with only its first header set, the header can also be assigned to the second one
+func convertSignedHeader(h *phase0.SignedBeaconBlockHeader) types.ProposerSlashing {
+	var res types.ProposerSlashing
+	res.SignedHeader1.Message.Slot = uint64(h.Message.Slot)
+	res.SignedHeader1.Message.ProposerIndex = uint64(h.Message.ProposerIndex)
+	res.SignedHeader1.Message.ParentRoot = h.Message.ParentRoot[:]
+	res.SignedHeader1.Message.StateRoot = h.Message.StateRoot[:]
+	res.SignedHeader1.Message.BodyRoot = h.Message.BodyRoot[:]
+	res.SignedHeader1.Signature = h.Signature[:]
+	return res
+}
+
+func toUint64Str(values []uint64) []types.Uint64Str {
+	res := make([]types.Uint64Str, len(values))
+	for i, v := range values {
+		res[i] = types.Uint64Str(v)
+	}
+	return res
+}
@@ -0,0 +1,207 @@
+package consapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/gobitfly/beaconchain/pkg/consapi"
+	"github.com/gobitfly/beaconchain/pkg/consapi/types"
+	"github.com/holiman/uint256"
+)
+
+const farFutureEpoch = phase0.Epoch(^uint64(0))
+
+// newTestState returns a minimal deneb state with n active validators at slot 320 (epoch 10)
+func newTestState(n int) *deneb.BeaconState {
+	syncCommittee := &altair.SyncCommittee{Pubkeys: make([]phase0.BLSPubKey, 512)}
+	state := &deneb.BeaconState{
+		Slot:                         320,
+		Fork:                         &phase0.Fork{},
+		LatestBlockHeader:            &phase0.BeaconBlockHeader{},
+		BlockRoots:                   make([]phase0.Root, 8192),
+		StateRoots:                   make([]phase0.Root, 8192),
+		ETH1Data:                     &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+		RANDAOMixes:                  make([]phase0.Root, 65536),
+		Slashings:                    make([]phase0.Gwei, 8192),
+		JustificationBits:            []byte{0},
+		PreviousJustifiedCheckpoint:  &phase0.Checkpoint{},
+		CurrentJustifiedCheckpoint:   &phase0.Checkpoint{},
+		FinalizedCheckpoint:          &phase0.Checkpoint{},
+		CurrentSyncCommittee:         syncCommittee,
+		NextSyncCommittee:            syncCommittee,
+		LatestExecutionPayloadHeader: &deneb.ExecutionPayloadHeader{BaseFeePerGas: uint256.NewInt(7)},
+		HistoricalSummaries:          []*capella.HistoricalSummary{},
+	}
+	for i := 0; i < n; i++ {
+		state.Validators = append(state.Validators, &phase0.Validator{
+			PublicKey:             phase0.BLSPubKey{byte(i), byte(i >> 8), byte(i >> 16)},
+			WithdrawalCredentials: make([]byte, 32),
+			EffectiveBalance:      32e9,
+			ExitEpoch:             farFutureEpoch,
+			WithdrawableEpoch:     farFutureEpoch,
+		})
+		state.Balances = append(state.Balances, phase0.Gwei(32e9+i))
+		state.PreviousEpochParticipation = append(state.PreviousEpochParticipation, 0)
+		state.CurrentEpochParticipation = append(state.CurrentEpochParticipation, 0)
+		state.InactivityScores = append(state.InactivityScores, 0)
+	}
+	// one validator that exited in epoch 5 and can be withdrawn from epoch 8 on
+	state.Validators[0].ExitEpoch = 5
+	state.Validators[0].WithdrawableEpoch = 8
+	return state
+}
+
+// newTestStateServer serves state as ssz from the debug endpoint and as json from the validators endpoint
+func newTestStateServer(tb testing.TB, state *deneb.BeaconState, sszSupported bool) *httptest.Server {
+	sszData, err := state.MarshalSSZ()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	jsonValidators := types.StandardValidatorsResponse{}
+	for i, v := range state.Validators {
+		validator := types.StandardValidator{Index: uint64(i), Balance: uint64(state.Balances[i]), Status: types.ActiveOngoing}
+		if i == 0 {
+			validator.Status = types.WithdrawalPossible
+		}
+		validator.Validator.Pubkey = v.PublicKey[:]
+		validator.Validator.WithdrawalCredentials = v.WithdrawalCredentials
+		validator.Validator.EffectiveBalance = uint64(v.EffectiveBalance)
+		validator.Validator.ExitEpoch = uint64(v.ExitEpoch)
+		validator.Validator.WithdrawableEpoch = uint64(v.WithdrawableEpoch)
+		jsonValidators.Data = append(jsonValidators.Data, validator)
+	}
+	jsonData, err := json.Marshal(jsonValidators)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/config/spec":
+			fmt.Fprint(w, `{"data":{"SLOTS_PER_EPOCH":"32"}}`)
+		case "/eth/v2/debug/beacon/states/head":
+			if !sszSupported {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"version":"deneb","data":{}}`)
+				return
+			}
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Header().Set("Eth-Consensus-Version", "deneb")
+			_, _ = w.Write(sszData)
+		case "/eth/v1/beacon/states/head/validators":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(jsonData)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestGetValidatorsSSZ(t *testing.T) {
+	state := newTestState(64)
+	for _, sszSupported := range []bool{true, false} {
+		t.Run(fmt.Sprintf("sszSupported=%v", sszSupported), func(t *testing.T) {
+			server := newTestStateServer(t, state, sszSupported)
+			defer server.Close()
+
+			client := consapi.NewClient(server.URL, consapi.WithSSZ())
+			res, err := client.GetValidators(context.Background(), "head", nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(res.Data) != len(state.Validators) {
+				t.Fatalf("expected %d validators, got %d", len(state.Validators), len(res.Data))
+			}
+			if res.Data[0].Status != types.WithdrawalPossible {
+				t.Errorf("expected first validator to be %v, got %v", types.WithdrawalPossible, res.Data[0].Status)
+			}
+			if res.Data[1].Index != 1 || res.Data[1].Balance != 32e9+1 || res.Data[1].Status != types.ActiveOngoing {
+				t.Errorf("unexpected validator: %+v", res.Data[1])
+			}
+		})
+	}
+}
+
+func TestGetValidatorsSSZStatusFilter(t *testing.T) {
+	state := newTestState(64)
+	server := newTestStateServer(t, state, true)
+	defer server.Close()
+
+	client := consapi.NewClient(server.URL, consapi.WithSSZ())
+	res, err := client.GetValidators(context.Background(), "head", nil, []types.ValidatorStatus{types.Active})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Data) != len(state.Validators)-1 {
+		t.Fatalf("expected %d active validators, got %d", len(state.Validators)-1, len(res.Data))
+	}
+}
+
+func TestGetValidatorsSSZUnsupportedForkIsCached(t *testing.T) {
+	state := newTestState(4)
+	stateServer := newTestStateServer(t, state, true)
+	defer stateServer.Close()
+
+	sszRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/eth/v2/debug/beacon/states/head" {
+			sszRequests++
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Header().Set("Eth-Consensus-Version", "electra")
+			return
+		}
+		resp, err := http.Get(stateServer.URL + r.URL.Path)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		defer resp.Body.Close()
+		w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
+		_, _ = io.Copy(w, resp.Body)
+	}))
+	defer server.Close()
+
+	client := consapi.NewClient(server.URL, consapi.WithSSZ())
+	for i := 0; i < 3; i++ {
+		res, err := client.GetValidators(context.Background(), "head", nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Data) != len(state.Validators) {
+			t.Fatalf("expected %d validators, got %d", len(state.Validators), len(res.Data))
+		}
+	}
+	if sszRequests != 1 {
+		t.Errorf("expected the unsupported fork to be requested as ssz once, got %d requests", sszRequests)
+	}
+}
+
+func benchmarkGetValidators(b *testing.B, opts ...consapi.ClientOption) {
+	server := newTestStateServer(b, newTestState(100_000), true)
+	defer server.Close()
+
+	client := consapi.NewClient(server.URL, opts...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.GetValidators(context.Background(), "head", nil, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetValidatorsJSON(b *testing.B) {
+	benchmarkGetValidators(b)
+}
+
+func BenchmarkGetValidatorsSSZ(b *testing.B) {
+	benchmarkGetValidators(b, consapi.WithSSZ())
+}
@@ -1,6 +1,7 @@
 package consapi_test
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -24,7 +25,7 @@ func setup() {
 }
 
 func TestGetBlockHeader(t *testing.T) {
-	res, err := cl.GetBlockHeader("head")
+	res, err := cl.GetBlockHeader(context.Background(), "head")
 	if err != nil {
 		t.Errorf("Error getting block header: %v", err)
 	}
@@ -32,7 +33,7 @@ func TestGetBlockHeader(t *testing.T) {
 }
 
 func TestGetSlot(t *testing.T) {
-	res, err := cl.GetSlot(0)
+	res, err := cl.GetSlot(context.Background(), 0)
 	if err != nil {
 		t.Errorf("Error getting slot: %v", err)
 	}
@@ -40,7 +41,7 @@ func TestGetSlot(t *testing.T) {
 }
 
 func TestGetValidators(t *testing.T) {
-	res, err := cl.GetValidators("head", nil, nil)
+	res, err := cl.GetValidators(context.Background(), "head", nil, nil)
 	if err != nil {
 		t.Errorf("Error getting validators: %v", err)
 	}
@@ -49,7 +50,7 @@ func TestGetValidators(t *testing.T) {
 
 func TestGetValidatorsFilter(t *testing.T) {
 	filter := types.ActiveSlashed
-	res, err := cl.GetValidators("head", nil, []types.ValidatorStatus{filter})
+	res, err := cl.GetValidators(context.Background(), "head", nil, []types.ValidatorStatus{filter})
 	if err != nil {
 		t.Errorf("Error getting validators: %v", err)
 	}
@@ -62,7 +63,7 @@ func TestGetValidatorsFilter(t *testing.T) {
 
 func TestGetValidatorsFilterIndex(t *testing.T) {
 	filter := []string{"4", "5", "6"}
-	res, err := cl.GetValidators("head", filter, nil)
+	res, err := cl.GetValidators(context.Background(), "head", filter, nil)
 	if err != nil {
 		t.Errorf("Error getting validators: %v", err)
 	}
@@ -76,7 +77,7 @@ func TestGetValidatorsFilterIndex(t *testing.T) {
 func TestGetValidatorsFilterBoth(t *testing.T) {
 	filter := []string{"4", "5", "6"}
 	filterStatus := types.ActiveOngoing
-	res, err := cl.GetValidators("head", filter, []types.ValidatorStatus{filterStatus})
+	res, err := cl.GetValidators(context.Background(), "head", filter, []types.ValidatorStatus{filterStatus})
 	if err != nil {
 		t.Errorf("Error getting validators: %v", err)
 	}
@@ -88,7 +89,7 @@ func TestGetValidatorsFilterBoth(t *testing.T) {
 }
 
 func TestGetPropoalAssignments(t *testing.T) {
-	res, err := cl.GetPropoalAssignments(0)
+	res, err := cl.GetPropoalAssignments(context.Background(), 0)
 	if err != nil {
 		t.Errorf("Error getting proposal assignments: %v", err)
 	}
@@ -96,7 +97,7 @@ func TestGetPropoalAssignments(t *testing.T) {
 }
 
 func TestGetPropoalRewards(t *testing.T) {
-	res, err := cl.GetPropoalRewards("head")
+	res, err := cl.GetPropoalRewards(context.Background(), "head")
 	if err != nil {
 		t.Errorf("Error getting proposal rewards: %v", err)
 	}
@@ -104,7 +105,7 @@ func TestGetPropoalRewards(t *testing.T) {
 }
 
 func TestGetSyncRewards(t *testing.T) {
-	res, err := cl.GetSyncRewards("head")
+	res, err := cl.GetSyncRewards(context.Background(), "head")
 	if err != nil {
 		t.Errorf("Error getting sync rewards: %v", err)
 	}
@@ -112,7 +113,7 @@ func TestGetSyncRewards(t *testing.T) {
 }
 
 func TestGetAttestationRewards(t *testing.T) {
-	res, err := cl.GetAttestationRewards(0)
+	res, err := cl.GetAttestationRewards(context.Background(), 0)
 	if err != nil {
 		t.Errorf("Error getting attestation rewards: %v", err)
 	}
@@ -120,7 +121,7 @@ func TestGetAttestationRewards(t *testing.T) {
 }
 
 func TestGetSyncCommitteesAssignments(t *testing.T) {
-	res, err := cl.GetSyncCommitteesAssignments(nil, "head")
+	res, err := cl.GetSyncCommitteesAssignments(context.Background(), nil, "head")
 	if err != nil {
 		t.Errorf("Error getting sync committees assignments: %v", err)
 	}
@@ -128,7 +129,7 @@ func TestGetSyncCommitteesAssignments(t *testing.T) {
 }
 
 func TestGetSpec(t *testing.T) {
-	res, err := cl.GetSpec()
+	res, err := cl.GetSpec(context.Background())
 	if err != nil {
 		httpErr := network.SpecificError(err)
 		if httpErr != nil {
@@ -141,7 +142,7 @@ func TestGetSpec(t *testing.T) {
 }
 
 func TestGetBlockHeaders(t *testing.T) {
-	res, err := cl.GetBlockHeaders(nil, nil)
+	res, err := cl.GetBlockHeaders(context.Background(), nil, nil)
 	if err != nil {
 		t.Errorf("Error getting block headers: %v", err)
 	}
@@ -150,7 +151,7 @@ func TestGetBlockHeaders(t *testing.T) {
 
 func TestGetBlockHeadersSlot(t *testing.T) {
 	slot := uint64(3)
-	res, err := cl.GetBlockHeaders(&slot, nil)
+	res, err := cl.GetBlockHeaders(context.Background(), &slot, nil)
 	if err != nil {
 		t.Errorf("Error getting block headers: %v", err)
 	}
@@ -158,7 +159,7 @@ func TestGetBlockHeadersSlot(t *testing.T) {
 }
 
 func TestGetFinalityCheckpoints(t *testing.T) {
-	res, err := cl.GetFinalityCheckpoints("head")
+	res, err := cl.GetFinalityCheckpoints(context.Background(), "head")
 	if err != nil {
 		t.Errorf("Error getting finality checkpoints: %v", err)
 	}
@@ -166,7 +167,7 @@ func TestGetFinalityCheckpoints(t *testing.T) {
 }
 
 func TestGetValidatorBalances(t *testing.T) {
-	res, err := cl.GetValidatorBalances("head")
+	res, err := cl.GetValidatorBalances(context.Background(), "head")
 	if err != nil {
 		t.Errorf("Error getting validator balances: %v", err)
 	}
@@ -174,15 +175,31 @@ func TestGetValidatorBalances(t *testing.T) {
 }
 
 func TestGetBlobSidecars(t *testing.T) {
-	res, err := cl.GetBlobSidecars("head")
+	res, err := cl.GetBlobSidecars(context.Background(), "head")
 	if err != nil {
 		t.Errorf("Error getting blob sidecars: %v", err)
 	}
 	log.Printf("Blob sidecars: %v\n", res)
 }
 
+func TestGetPoolVoluntaryExits(t *testing.T) {
+	res, err := cl.GetPoolVoluntaryExits(context.Background())
+	if err != nil {
+		t.Errorf("Error getting pool voluntary exits: %v", err)
+	}
+	log.Printf("Pool voluntary exits: %v\n", res)
+}
+
+func TestGetPoolBLSToExecutionChanges(t *testing.T) {
+	res, err := cl.GetPoolBLSToExecutionChanges(context.Background())
+	if err != nil {
+		t.Errorf("Error getting pool bls to execution changes: %v", err)
+	}
+	log.Printf("Pool bls to execution changes: %v\n", res)
+}
+
 func TestGetCommittees(t *testing.T) {
-	res, err := cl.GetCommittees("head", nil, nil, nil)
+	res, err := cl.GetCommittees(context.Background(), "head", nil, nil, nil)
 	if err != nil {
 		t.Errorf("Error getting committees: %v", err)
 	}
@@ -190,7 +207,7 @@ func TestGetCommittees(t *testing.T) {
 }
 
 func TestGetGenesis(t *testing.T) {
-	res, err := cl.GetGenesis()
+	res, err := cl.GetGenesis(context.Background())
 	if err != nil {
 		t.Errorf("Error getting genesis: %v", err)
 	}
@@ -198,7 +215,7 @@ func TestGetGenesis(t *testing.T) {
 }
 
 func TestGetEvents(t *testing.T) {
-	res := cl.GetEvents([]types.EventTopic{types.EventHead, types.EventBlock, types.EventChainReorg, types.EventFinalizedCheckpoint})
+	res := cl.GetEvents(context.Background(), []types.EventTopic{types.EventHead, types.EventBlock, types.EventChainReorg, types.EventFinalizedCheckpoint})
 
 	for event := range res {
 		if event.Error != nil {
@@ -2,18 +2,23 @@ package network
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gobitfly/beaconchain/pkg/consapi/utils"
 )
 
+// defaultHttpClient is used for requests that don't specify their own client, so connections are reused across calls
+var defaultHttpClient = &http.Client{Timeout: 20 * time.Second}
+
 // Helper for get and unmarshal
-func Get[T any](r *http.Client, url string) (*T, error) {
-	result, err := HTTPReq("GET", url, r)
+func Get[T any](ctx context.Context, r *http.Client, url string) (*T, error) {
+	result, err := HTTPReq(ctx, "GET", url, r)
 	if err != nil || result == nil {
 		var target T
 		return &target, err
@@ -22,8 +27,8 @@ func Get[T any](r *http.Client, url string) (*T, error) {
 }
 
 // Helper for post and unmarshal
-func Post[T any](r *http.Client, url string) (*T, error) {
-	result, err := HTTPReq("POST", url, r)
+func Post[T any](ctx context.Context, r *http.Client, url string) (*T, error) {
+	result, err := HTTPReq(ctx, "POST", url, r)
 	if err != nil || result == nil {
 		var target T
 		return &target, err
@@ -31,18 +36,18 @@ func Post[T any](r *http.Client, url string) (*T, error) {
 	return utils.Unmarshal[T](result, err)
 }
 
-func HTTPReq(method string, requestURL string, httpClient *http.Client) (io.ReadCloser, error) {
+func HTTPReq(ctx context.Context, method string, requestURL string, httpClient *http.Client) (io.ReadCloser, error) {
 	data := []byte{}
 	if method == "POST" {
 		data = []byte("[]")
 	}
-	r, err := http.NewRequest(method, requestURL, bytes.NewBuffer(data))
+	r, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewBuffer(data))
 	if err != nil {
 		return nil, fmt.Errorf("error creating request: %v", err)
 	}
 
 	if httpClient == nil {
-		httpClient = &http.Client{Timeout: 20 * time.Second}
+		httpClient = defaultHttpClient
 	}
 
 	r.Header.Add("Content-Type", "application/json")
@@ -54,6 +59,7 @@ func HTTPReq(method string, requestURL string, httpClient *http.Client) (io.Read
 
 	if res.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(res.Body)
+		res.Body.Close()
 		return nil, &HttpReqHttpError{
 			StatusCode: res.StatusCode,
 			Url:        requestURL,
@@ -64,6 +70,57 @@ func HTTPReq(method string, requestURL string, httpClient *http.Client) (io.Read
 	return res.Body, nil
 }
 
+// ErrSSZNotSupported is returned by HTTPReqSSZ if the node can not serve the requested resource as ssz
+var ErrSSZNotSupported = errors.New("ssz encoding not supported")
+
+const (
+	sszContentType = "application/octet-stream"
+	// prefer ssz but allow nodes that don't support it to respond with json
+	sszAcceptHeader = "application/octet-stream;q=1.0,application/json;q=0.9"
+)
+
+// HTTPReqSSZ requests an ssz encoded response. The consensus version of the response is taken from the Eth-Consensus-Version header.
+// The caller has to close the returned body.
+func HTTPReqSSZ(ctx context.Context, requestURL string, httpClient *http.Client) (io.ReadCloser, string, error) {
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("error creating request: %v", err)
+	}
+
+	if httpClient == nil {
+		httpClient = defaultHttpClient
+	}
+
+	r.Header.Add("Accept", sszAcceptHeader)
+
+	res, err := httpClient.Do(r)
+	if err != nil {
+		return nil, "", fmt.Errorf("error executing request: %v", err)
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotAcceptable, http.StatusUnsupportedMediaType, http.StatusNotImplemented:
+		res.Body.Close()
+		return nil, "", fmt.Errorf("%w: url: %s, status: %d", ErrSSZNotSupported, requestURL, res.StatusCode)
+	default:
+		body, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+		return nil, "", &HttpReqHttpError{
+			StatusCode: res.StatusCode,
+			Url:        requestURL,
+			Body:       body,
+		}
+	}
+
+	if !strings.HasPrefix(res.Header.Get("Content-Type"), sszContentType) {
+		res.Body.Close()
+		return nil, "", fmt.Errorf("%w: url: %s, content-type: %s", ErrSSZNotSupported, requestURL, res.Header.Get("Content-Type"))
+	}
+
+	return res.Body, strings.ToLower(res.Header.Get("Eth-Consensus-Version")), nil
+}
+
 type RPCErrorMessage struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
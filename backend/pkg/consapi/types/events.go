@@ -8,13 +8,13 @@ import (
 type EventTopic string
 
 const (
-	EventHead  EventTopic = "head"
-	EventBlock EventTopic = "block"
-	// EventAttestation                 EventTopic = "attestation"
-	// EventVoluntaryExit               EventTopic = "voluntary_exit"
-	// EventBlsToExecutionChange        EventTopic = "bls_to_execution_change"
-	EventFinalizedCheckpoint EventTopic = "finalized_checkpoint"
-	EventChainReorg          EventTopic = "chain_reorg"
+	EventHead                 EventTopic = "head"
+	EventBlock                EventTopic = "block"
+	EventAttestation          EventTopic = "attestation"
+	EventVoluntaryExit        EventTopic = "voluntary_exit"
+	EventBlsToExecutionChange EventTopic = "bls_to_execution_change"
+	EventFinalizedCheckpoint  EventTopic = "finalized_checkpoint"
+	EventChainReorg           EventTopic = "chain_reorg"
 	// EventContributionAndProof        EventTopic = "contribution_and_proof"
 	// EventLightClientFinalityUpdate   EventTopic = "light_client_finality_update"
 	// EventLightClientOptimisticUpdate EventTopic = "light_client_optimistic_update"
@@ -51,6 +51,30 @@ func (e EventResponse) ChainReorg() (*StandardEventChainReorg, error) {
 	return utils.UnmarshalOld[StandardEventChainReorg](e.Data, e.Error)
 }
 
+// Helper to get Attestation response type, returns nil if it is not an attestation event
+func (e EventResponse) Attestation() (*Attestation, error) {
+	if e.Event != EventAttestation {
+		return nil, nil
+	}
+	return utils.UnmarshalOld[Attestation](e.Data, e.Error)
+}
+
+// Helper to get VoluntaryExit response type, returns nil if it is not a voluntary exit event
+func (e EventResponse) VoluntaryExit() (*VoluntaryExit, error) {
+	if e.Event != EventVoluntaryExit {
+		return nil, nil
+	}
+	return utils.UnmarshalOld[VoluntaryExit](e.Data, e.Error)
+}
+
+// Helper to get BlsToExecutionChange response type, returns nil if it is not a bls to execution change event
+func (e EventResponse) BlsToExecutionChange() (*SignedBLSToExecutionChange, error) {
+	if e.Event != EventBlsToExecutionChange {
+		return nil, nil
+	}
+	return utils.UnmarshalOld[SignedBLSToExecutionChange](e.Data, e.Error)
+}
+
 // Helper to get FinalizedCheckpoint response type, returns nil if it is not a finalized checkpoint event
 func (e EventResponse) FinalizedCheckpoint() (*StandardFinalizedCheckpointResponse, error) {
 	if e.Event != EventFinalizedCheckpoint {
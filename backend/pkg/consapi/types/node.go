@@ -0,0 +1,12 @@
+package types
+
+// /eth/v1/node/syncing
+type StandardSyncingResponse struct {
+	Data struct {
+		HeadSlot     Uint64Str `json:"head_slot"`
+		SyncDistance Uint64Str `json:"sync_distance"`
+		IsSyncing    bool      `json:"is_syncing"`
+		IsOptimistic bool      `json:"is_optimistic"`
+		ElOffline    bool      `json:"el_offline"`
+	} `json:"data"`
+}
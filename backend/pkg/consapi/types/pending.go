@@ -0,0 +1,39 @@
+package types
+
+import "github.com/ethereum/go-ethereum/common/hexutil"
+
+// electra state queues, see https://github.com/ethereum/consensus-specs/blob/dev/specs/electra/beacon-chain.md#beaconstate
+
+// /eth/v1/beacon/states/{state_id}/pending_deposits
+type StandardPendingDepositsResponse struct {
+	ExecutionOptimistic bool `json:"execution_optimistic"`
+	Finalized           bool `json:"finalized"`
+	Data                []struct {
+		Pubkey                hexutil.Bytes `json:"pubkey"`
+		WithdrawalCredentials hexutil.Bytes `json:"withdrawal_credentials"`
+		Amount                uint64        `json:"amount,string"`
+		Signature             hexutil.Bytes `json:"signature"`
+		Slot                  uint64        `json:"slot,string"`
+	} `json:"data"`
+}
+
+// /eth/v1/beacon/states/{state_id}/pending_partial_withdrawals
+type StandardPendingPartialWithdrawalsResponse struct {
+	ExecutionOptimistic bool `json:"execution_optimistic"`
+	Finalized           bool `json:"finalized"`
+	Data                []struct {
+		ValidatorIndex    uint64 `json:"validator_index,string"`
+		Amount            uint64 `json:"amount,string"`
+		WithdrawableEpoch uint64 `json:"withdrawable_epoch,string"`
+	} `json:"data"`
+}
+
+// /eth/v1/beacon/states/{state_id}/pending_consolidations
+type StandardPendingConsolidationsResponse struct {
+	ExecutionOptimistic bool `json:"execution_optimistic"`
+	Finalized           bool `json:"finalized"`
+	Data                []struct {
+		SourceIndex uint64 `json:"source_index,string"`
+		TargetIndex uint64 `json:"target_index,string"`
+	} `json:"data"`
+}
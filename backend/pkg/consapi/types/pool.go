@@ -0,0 +1,13 @@
+package types
+
+// operations the node received but that haven't been included in a block yet
+
+// /eth/v1/beacon/pool/voluntary_exits
+type StandardPoolVoluntaryExitsResponse struct {
+	Data []VoluntaryExit `json:"data"`
+}
+
+// /eth/v1/beacon/pool/bls_to_execution_changes
+type StandardPoolBLSToExecutionChangesResponse struct {
+	Data []SignedBLSToExecutionChange `json:"data"`
+}
@@ -37,6 +37,9 @@ type AnySignedBlock struct {
 
 			// present only after deneb
 			BlobKZGCommitments []hexutil.Bytes `json:"blob_kzg_commitments"`
+
+			// present only after electra
+			ExecutionRequests *ExecutionRequests `json:"execution_requests,omitempty"`
 		} `json:"body"`
 	} `json:"message"`
 	Signature hexutil.Bytes `json:"signature"`
@@ -121,7 +124,9 @@ func (a *AttesterSlashing) GetSlashedIndices() []uint64 {
 type Attestation struct {
 	AggregationBits hexutil.Bytes `json:"aggregation_bits"`
 	Signature       hexutil.Bytes `json:"signature"`
-	Data            struct {
+	// present only after electra, data.index is always 0 then
+	CommitteeBits hexutil.Bytes `json:"committee_bits,omitempty"`
+	Data          struct {
 		Slot            uint64        `json:"slot,string"`
 		Index           uint16        `json:"index,string"`
 		BeaconBlockRoot hexutil.Bytes `json:"beacon_block_root"`
@@ -204,3 +209,33 @@ type SignedBLSToExecutionChange struct {
 	} `json:"message"`
 	Signature hexutil.Bytes `json:"signature"`
 }
+
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/electra/beacon-chain.md#executionrequests
+type ExecutionRequests struct {
+	Deposits       []DepositRequest       `json:"deposits"`
+	Withdrawals    []WithdrawalRequest    `json:"withdrawals"`
+	Consolidations []ConsolidationRequest `json:"consolidations"`
+}
+
+// EIP-6110
+type DepositRequest struct {
+	Pubkey                hexutil.Bytes `json:"pubkey"`
+	WithdrawalCredentials hexutil.Bytes `json:"withdrawal_credentials"`
+	Amount                uint64        `json:"amount,string"`
+	Signature             hexutil.Bytes `json:"signature"`
+	Index                 uint64        `json:"index,string"`
+}
+
+// EIP-7002, an amount of 0 requests a full exit
+type WithdrawalRequest struct {
+	SourceAddress   hexutil.Bytes `json:"source_address"`
+	ValidatorPubkey hexutil.Bytes `json:"validator_pubkey"`
+	Amount          uint64        `json:"amount,string"`
+}
+
+// EIP-7251, source and target pubkey are equal for a switch to compounding credentials
+type ConsolidationRequest struct {
+	SourceAddress hexutil.Bytes `json:"source_address"`
+	SourcePubkey  hexutil.Bytes `json:"source_pubkey"`
+	TargetPubkey  hexutil.Bytes `json:"target_pubkey"`
+}
@@ -64,8 +64,8 @@ func saveBlocks(blocks map[uint64]map[string]*types.Block, tx *sqlx.Tx, forceSlo
 	defer stmtExecutionPayload.Close()
 
 	stmtBlock, err := tx.Prepare(`
-		INSERT INTO blocks (epoch, slot, blockroot, parentroot, stateroot, signature, randaoreveal, graffiti, graffiti_text, eth1data_depositroot, eth1data_depositcount, eth1data_blockhash, syncaggregate_bits, syncaggregate_signature, proposerslashingscount, attesterslashingscount, attestationscount, depositscount, withdrawalcount, voluntaryexitscount, syncaggregate_participation, proposer, status, exec_parent_hash, exec_fee_recipient, exec_state_root, exec_receipts_root, exec_logs_bloom, exec_random, exec_block_number, exec_gas_limit, exec_gas_used, exec_timestamp, exec_extra_data, exec_base_fee_per_gas, exec_block_hash, exec_transactions_count, exec_blob_gas_used, exec_excess_blob_gas, exec_blob_transactions_count)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36, $37, $38, $39, $40)
+		INSERT INTO blocks (epoch, slot, blockroot, parentroot, stateroot, signature, randaoreveal, graffiti, graffiti_text, eth1data_depositroot, eth1data_depositcount, eth1data_blockhash, syncaggregate_bits, syncaggregate_signature, proposerslashingscount, attesterslashingscount, attestationscount, depositscount, withdrawalcount, voluntaryexitscount, syncaggregate_participation, proposer, status, exec_parent_hash, exec_fee_recipient, exec_state_root, exec_receipts_root, exec_logs_bloom, exec_random, exec_block_number, exec_gas_limit, exec_gas_used, exec_timestamp, exec_extra_data, exec_base_fee_per_gas, exec_block_hash, exec_transactions_count, exec_blob_gas_used, exec_excess_blob_gas, exec_blob_transactions_count, depositrequestscount, withdrawalrequestscount, consolidationrequestscount)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36, $37, $38, $39, $40, $41, $42, $43)
 		ON CONFLICT (slot, blockroot) DO NOTHING`)
 	if err != nil {
 		return err
@@ -109,8 +109,8 @@ func saveBlocks(blocks map[uint64]map[string]*types.Block, tx *sqlx.Tx, forceSlo
 	defer stmtAttesterSlashing.Close()
 
 	stmtAttestations, err := tx.Prepare(`
-		INSERT INTO blocks_attestations (block_slot, block_index, block_root, aggregationbits, validators, signature, slot, committeeindex, beaconblockroot, source_epoch, source_root, target_epoch, target_root)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		INSERT INTO blocks_attestations (block_slot, block_index, block_root, aggregationbits, validators, signature, slot, committeeindex, beaconblockroot, source_epoch, source_root, target_epoch, target_root, committeebits)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 		ON CONFLICT (block_slot, block_index) DO NOTHING`)
 	if err != nil {
 		return err
@@ -144,6 +144,33 @@ func saveBlocks(blocks map[uint64]map[string]*types.Block, tx *sqlx.Tx, forceSlo
 	}
 	defer stmtVoluntaryExits.Close()
 
+	stmtDepositRequests, err := tx.Prepare(`
+		INSERT INTO blocks_deposit_requests (block_slot, block_root, block_index, pubkey, withdrawal_credentials, amount, signature, valid_signature, deposit_index)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (block_slot, block_root, block_index) DO NOTHING`)
+	if err != nil {
+		return err
+	}
+	defer stmtDepositRequests.Close()
+
+	stmtWithdrawalRequests, err := tx.Prepare(`
+		INSERT INTO blocks_withdrawal_requests (block_slot, block_root, block_index, source_address, validator_pubkey, amount)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (block_slot, block_root, block_index) DO NOTHING`)
+	if err != nil {
+		return err
+	}
+	defer stmtWithdrawalRequests.Close()
+
+	stmtConsolidationRequests, err := tx.Prepare(`
+		INSERT INTO blocks_consolidation_requests (block_slot, block_root, block_index, source_address, source_pubkey, target_pubkey)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (block_slot, block_root, block_index) DO NOTHING`)
+	if err != nil {
+		return err
+	}
+	defer stmtConsolidationRequests.Close()
+
 	stmtProposalAssignments, err := tx.Prepare(`
 		INSERT INTO proposal_assignments (epoch, validatorindex, proposerslot, status)
 		VALUES ($1, $2, $3, $4)
@@ -249,6 +276,14 @@ func saveBlocks(blocks map[uint64]map[string]*types.Block, tx *sqlx.Tx, forceSlo
 					return fmt.Errorf("error executing stmtExecutionPayload for block %v: %w", b.Slot, err)
 				}
 			}
+
+			// execution requests are only present after electra, keep the counts null for earlier blocks
+			var depositRequestsCount, withdrawalRequestsCount, consolidationRequestsCount sql.NullInt32
+			if b.Slot/utils.Config.Chain.ClConfig.SlotsPerEpoch >= utils.Config.Chain.ClConfig.ElectraForkEpoch {
+				depositRequestsCount = sql.NullInt32{Int32: int32(len(b.DepositRequests)), Valid: true}
+				withdrawalRequestsCount = sql.NullInt32{Int32: int32(len(b.WithdrawalRequests)), Valid: true}
+				consolidationRequestsCount = sql.NullInt32{Int32: int32(len(b.ConsolidationRequests)), Valid: true}
+			}
 			_, err = stmtBlock.Exec(
 				b.Slot/utils.Config.Chain.ClConfig.SlotsPerEpoch,
 				b.Slot,
@@ -290,6 +325,9 @@ func saveBlocks(blocks map[uint64]map[string]*types.Block, tx *sqlx.Tx, forceSlo
 				execData.BlobGasUsed,
 				execData.ExcessBlobGas,
 				execData.BlobTxCount,
+				depositRequestsCount,
+				withdrawalRequestsCount,
+				consolidationRequestsCount,
 			)
 			if err != nil {
 				return fmt.Errorf("error executing stmtBlocks for block %v: %w", b.Slot, err)
@@ -329,7 +367,7 @@ func saveBlocks(blocks map[uint64]map[string]*types.Block, tx *sqlx.Tx, forceSlo
 				}
 			}
 			for i, a := range b.Attestations {
-				_, err = stmtAttestations.Exec(b.Slot, i, b.BlockRoot, a.AggregationBits, pq.Array(a.Attesters), a.Signature, a.Data.Slot, a.Data.CommitteeIndex, a.Data.BeaconBlockRoot, a.Data.Source.Epoch, a.Data.Source.Root, a.Data.Target.Epoch, a.Data.Target.Root)
+				_, err = stmtAttestations.Exec(b.Slot, i, b.BlockRoot, a.AggregationBits, pq.Array(a.Attesters), a.Signature, a.Data.Slot, a.Data.CommitteeIndex, a.Data.BeaconBlockRoot, a.Data.Source.Epoch, a.Data.Source.Root, a.Data.Target.Epoch, a.Data.Target.Root, a.CommitteeBits)
 				if err != nil {
 					return fmt.Errorf("error executing stmtAttestations for block %v index %v: %w", b.Slot, i, err)
 				}
@@ -351,6 +389,36 @@ func saveBlocks(blocks map[uint64]map[string]*types.Block, tx *sqlx.Tx, forceSlo
 				}
 			}
 
+			for i, d := range b.DepositRequests {
+				err := utils.VerifyDepositSignature(&phase0.DepositData{
+					PublicKey:             phase0.BLSPubKey(d.PublicKey),
+					WithdrawalCredentials: d.WithdrawalCredentials,
+					Amount:                phase0.Gwei(d.Amount),
+					Signature:             phase0.BLSSignature(d.Signature),
+				}, domain)
+
+				signatureValid := err == nil
+
+				_, err = stmtDepositRequests.Exec(b.Slot, b.BlockRoot, i, d.PublicKey, d.WithdrawalCredentials, d.Amount, d.Signature, signatureValid, d.Index)
+				if err != nil {
+					return fmt.Errorf("error executing stmtDepositRequests for block %v index %v: %w", b.Slot, i, err)
+				}
+			}
+
+			for i, w := range b.WithdrawalRequests {
+				_, err := stmtWithdrawalRequests.Exec(b.Slot, b.BlockRoot, i, w.SourceAddress, w.ValidatorPubkey, w.Amount)
+				if err != nil {
+					return fmt.Errorf("error executing stmtWithdrawalRequests for block %v index %v: %w", b.Slot, i, err)
+				}
+			}
+
+			for i, c := range b.ConsolidationRequests {
+				_, err := stmtConsolidationRequests.Exec(b.Slot, b.BlockRoot, i, c.SourceAddress, c.SourcePubkey, c.TargetPubkey)
+				if err != nil {
+					return fmt.Errorf("error executing stmtConsolidationRequests for block %v index %v: %w", b.Slot, i, err)
+				}
+			}
+
 			for i, ve := range b.VoluntaryExits {
 				_, err := stmtVoluntaryExits.Exec(b.Slot, i, b.BlockRoot, ve.Epoch, ve.ValidatorIndex, ve.Signature)
 				if err != nil {
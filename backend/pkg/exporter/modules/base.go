@@ -1,6 +1,7 @@
 package modules
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"time"
@@ -11,7 +12,6 @@ import (
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 	"github.com/gobitfly/beaconchain/pkg/consapi"
 	"github.com/gobitfly/beaconchain/pkg/consapi/types"
-	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -41,6 +41,9 @@ func StartAll(context ModuleContext, modules []ModuleInterface, justV2 bool) {
 		if utils.Config.SSVExporter.Enabled {
 			go ssvExporter()
 		}
+		if utils.Config.LidoCsmExporter.Enabled {
+			go lidoCsmExporter()
+		}
 		if utils.Config.RocketpoolExporter.Enabled {
 			go rocketpoolExporter()
 		}
@@ -68,7 +71,7 @@ func StartAll(context ModuleContext, modules []ModuleInterface, justV2 bool) {
 	startSubscriptionModules(&context, modules)
 }
 
-func startSubscriptionModules(context *ModuleContext, modules []ModuleInterface) {
+func startSubscriptionModules(moduleContext *ModuleContext, modules []ModuleInterface) {
 	goPool := &errgroup.Group{}
 
 	log.Infof("initialising exporter modules")
@@ -90,7 +93,7 @@ func startSubscriptionModules(context *ModuleContext, modules []ModuleInterface)
 	log.Infof("subscribing to node events")
 
 	// subscribe to node events and notify modules
-	events := context.CL.GetEvents([]types.EventTopic{
+	events := moduleContext.CL.GetEvents(context.Background(), []types.EventTopic{
 		types.EventHead,
 		types.EventFinalizedCheckpoint,
 		types.EventChainReorg,
@@ -155,24 +158,23 @@ func notifyAllModules(goPool *errgroup.Group, modules []ModuleInterface, f func(
 	}
 }
 
-func GetModuleContext() (ModuleContext, error) {
-	cl := consapi.NewClient("http://" + utils.Config.Indexer.Node.Host + ":" + utils.Config.Indexer.Node.Port)
+// GetModuleContext creates the consensus clients of the exporter modules, the health of the nodes is monitored until ctx is done
+func GetModuleContext(ctx context.Context) (ModuleContext, error) {
+	endpoints := utils.GetConsensusNodeEndpoints()
+	opts := utils.GetConsensusClientOptions()
+	cl := consapi.NewMultiNodeClient(ctx, endpoints, nil, opts...)
 
-	spec, err := cl.GetSpec()
+	spec, err := cl.GetSpec(ctx)
 	if err != nil {
 		log.Fatal(err, "error getting spec", 0)
 	}
 
 	config.ClConfig = &spec.Data
 
-	nodeImpl, ok := cl.ClientInt.(*consapi.NodeClient)
-	if !ok {
-		return ModuleContext{}, errors.New("lighthouse client can only be used with real node impl")
-	}
-
 	chainID := new(big.Int).SetUint64(utils.Config.Chain.ClConfig.DepositChainID)
 
-	clClient, err := rpc.NewLighthouseClient(nodeImpl, chainID)
+	// the lighthouse client shares the multi node client, so standard requests fail over like the ones of the modules
+	clClient, err := rpc.NewLighthouseClient(cl, chainID)
 	if err != nil {
 		log.Fatal(err, "error creating lighthouse client", 0)
 	}
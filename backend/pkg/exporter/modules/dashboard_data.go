@@ -1,6 +1,7 @@
 package modules
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"math"
@@ -182,7 +183,7 @@ func (d *dashboardData) processHeadQueue() {
 		stage := 0
 		doRollingAggregate := false
 		for { // retry this epoch until no errors occur
-			currentFinalizedEpoch, err := d.CL.GetFinalityCheckpoints("head")
+			currentFinalizedEpoch, err := d.CL.GetFinalityCheckpoints(context.Background(), "head")
 			if err != nil {
 				d.log.Error(err, "failed to get finalized checkpoint", 0)
 				metrics.Errors.WithLabelValues("exporter_v2dash_node_get_finalize_fail").Inc()
@@ -462,7 +463,7 @@ func (d *dashboardData) getSyncCommitteesData(errGroup *errgroup.Group, syncComm
 				errGroup.Go(func() error {
 					for {
 						start := time.Now()
-						data, err := d.CL.GetSyncCommitteesAssignments(nil, utils.FirstEpochOfSyncPeriod(syncPeriod)*utils.Config.Chain.ClConfig.SlotsPerEpoch)
+						data, err := d.CL.GetSyncCommitteesAssignments(context.Background(), nil, utils.FirstEpochOfSyncPeriod(syncPeriod)*utils.Config.Chain.ClConfig.SlotsPerEpoch)
 						if err != nil {
 							d.log.Error(err, "cannot get sync committee assignments", 0, map[string]interface{}{"syncPeriod": syncPeriod})
 							metrics.Errors.WithLabelValues("exporter_v2dash_node_committee_fail").Inc()
@@ -571,7 +572,7 @@ func (d *dashboardData) backfillHeadEpochData(upToEpoch *uint64) (backfillResult
 	var result = backfillResult{}
 	backfillToChainFinalizedHead := upToEpoch == nil
 	if upToEpoch == nil {
-		res, err := d.CL.GetFinalityCheckpoints("head")
+		res, err := d.CL.GetFinalityCheckpoints(context.Background(), "head")
 		if err != nil {
 			return result, errors.Wrap(err, "failed to get finalized checkpoint")
 		}
@@ -752,7 +753,7 @@ func (d *dashboardData) backfillHeadEpochData(upToEpoch *uint64) (backfillResult
 
 	// Return with "complete" only if task was to sync to chain finalized head and we finished
 	if backfillToChainFinalizedHead {
-		res, err := d.CL.GetFinalityCheckpoints("head")
+		res, err := d.CL.GetFinalityCheckpoints(context.Background(), "head")
 		if err != nil {
 			return result, errors.Wrap(err, "failed to get finalized checkpoint")
 		}
@@ -964,7 +965,7 @@ func (d *dashboardData) OnFinalizedCheckpoint(_ *constypes.StandardFinalizedChec
 	// An epoch becomes finalized once the next epoch gets justified
 	// Hence we just listen for new justified epochs here and fetch the latest finalized one from the node
 	// Do not assume event.Epoch -1 is finalized by default as it could be that it is not justified
-	res, err := d.CL.GetFinalityCheckpoints("head")
+	res, err := d.CL.GetFinalityCheckpoints(context.Background(), "head")
 	if err != nil {
 		return err
 	}
@@ -1110,7 +1111,7 @@ func (d *dashboardData) getData(epoch, slotsPerEpoch uint64, skipSerialCalls boo
 		// retrieve proposer assignments for the epoch in order to attribute missed slots
 		start := time.Now()
 		var err error
-		result.proposerAssignments, err = cl.GetPropoalAssignments(epoch)
+		result.proposerAssignments, err = cl.GetPropoalAssignments(context.Background(), epoch)
 		if err != nil {
 			d.log.Error(err, "can not get proposer assignments", 0, map[string]interface{}{"epoch": epoch})
 			return err
@@ -1130,7 +1131,7 @@ func (d *dashboardData) getData(epoch, slotsPerEpoch uint64, skipSerialCalls boo
 	for slot := lastSlotOfEpoch; slot >= min; slot -= utils.Config.Chain.ClConfig.SlotsPerEpoch {
 		slot := slot
 		errGroup.Go(func() error {
-			data, err := cl.GetCommittees(slot, nil, nil, nil)
+			data, err := cl.GetCommittees(context.Background(), slot, nil, nil, nil)
 			if err != nil {
 				d.log.Error(err, "can not get attestation assignments", 0, map[string]interface{}{"slot": slot})
 				return err
@@ -1154,7 +1155,7 @@ func (d *dashboardData) getData(epoch, slotsPerEpoch uint64, skipSerialCalls boo
 	errGroup.Go(func() error {
 		// attestation rewards
 		start := time.Now()
-		data, err := cl.GetAttestationRewards(epoch)
+		data, err := cl.GetAttestationRewards(context.Background(), epoch)
 		if err != nil {
 			d.log.Error(err, "can not get attestation rewards", 0, map[string]interface{}{"epoch": epoch})
 			return err
@@ -1176,7 +1177,7 @@ func (d *dashboardData) getData(epoch, slotsPerEpoch uint64, skipSerialCalls boo
 		// retrieve the validator balances at the end of the epoch
 		start := time.Now()
 		var err error
-		result.currentEpochStateEnd, err = cl.GetValidators(lastSlotOfEpoch, nil, nil)
+		result.currentEpochStateEnd, err = cl.GetValidators(context.Background(), lastSlotOfEpoch, nil, nil)
 		if err != nil {
 			d.log.Error(err, "can not get validators balances", 0, map[string]interface{}{"lastSlotOfEpoch": lastSlotOfEpoch})
 			return err
@@ -1193,10 +1194,10 @@ func (d *dashboardData) getData(epoch, slotsPerEpoch uint64, skipSerialCalls boo
 			start := time.Now()
 			var err error
 			if lastSlotOfPreviousEpoch < 0 {
-				result.lastEpochStateEnd, err = d.CL.GetValidators("genesis", nil, nil)
+				result.lastEpochStateEnd, err = d.CL.GetValidators(context.Background(), "genesis", nil, nil)
 				result.genesis = true
 			} else {
-				result.lastEpochStateEnd, err = d.CL.GetValidators(lastSlotOfPreviousEpoch, nil, nil)
+				result.lastEpochStateEnd, err = d.CL.GetValidators(context.Background(), lastSlotOfPreviousEpoch, nil, nil)
 			}
 			if err != nil {
 				d.log.Error(err, "can not get validators balances", 0, map[string]interface{}{"lastSlotOfPreviousEpoch": lastSlotOfPreviousEpoch})
@@ -1212,7 +1213,7 @@ func (d *dashboardData) getData(epoch, slotsPerEpoch uint64, skipSerialCalls boo
 			if firstSlotOfEpoch > slotsPerEpoch { // handle case for first epoch
 				// get missed slots of last epoch for optimal inclusion distance
 				for slot := firstSlotOfEpoch - slotsPerEpoch; slot <= lastSlotOfEpoch-slotsPerEpoch; slot++ {
-					_, err := cl.GetBlockHeader(slot)
+					_, err := cl.GetBlockHeader(context.Background(), slot)
 					if err != nil {
 						httpErr := network.SpecificError(err)
 						if httpErr != nil && httpErr.StatusCode == http.StatusNotFound {
@@ -1234,7 +1235,7 @@ func (d *dashboardData) getData(epoch, slotsPerEpoch uint64, skipSerialCalls boo
 		slot := slot
 		errGroup.Go(func() error {
 			// retrieve the data for all blocks that were proposed in this epoch
-			block, err := cl.GetSlot(slot)
+			block, err := cl.GetSlot(context.Background(), slot)
 			if err != nil {
 				httpErr := network.SpecificError(err)
 				if httpErr != nil && httpErr.StatusCode == http.StatusNotFound {
@@ -1251,7 +1252,7 @@ func (d *dashboardData) getData(epoch, slotsPerEpoch uint64, skipSerialCalls boo
 			result.beaconBlockData[slot] = block
 			mutex.Unlock()
 
-			blockReward, err := cl.GetPropoalRewards(slot)
+			blockReward, err := cl.GetPropoalRewards(context.Background(), slot)
 			if err != nil {
 				d.log.Error(err, "can not get block reward data", 0, map[string]interface{}{"slot": slot})
 				return err
@@ -1260,7 +1261,7 @@ func (d *dashboardData) getData(epoch, slotsPerEpoch uint64, skipSerialCalls boo
 			result.beaconBlockRewardData[slot] = blockReward
 			mutex.Unlock()
 
-			syncRewards, err := cl.GetSyncRewards(slot)
+			syncRewards, err := cl.GetSyncRewards(context.Background(), slot)
 			if err != nil {
 				d.log.Error(err, "can not get sync committee reward data", 0, map[string]interface{}{"slot": slot})
 				return err
@@ -1280,7 +1281,7 @@ func (d *dashboardData) getData(epoch, slotsPerEpoch uint64, skipSerialCalls boo
 		errGroup.Go(func() error {
 			start := time.Now()
 			var err error
-			result.syncCommitteeElectedState, err = d.CL.GetValidators(syncCommitteeElectedInSlot, nil, []constypes.ValidatorStatus{constypes.Active})
+			result.syncCommitteeElectedState, err = d.CL.GetValidators(context.Background(), syncCommitteeElectedInSlot, nil, []constypes.ValidatorStatus{constypes.Active})
 			if err != nil {
 				d.log.Error(err, "can not get sync committee election state", 0, map[string]interface{}{"slot": syncCommitteeElectedInSlot})
 				return err
@@ -1498,6 +1499,30 @@ func (d *dashboardData) process(data *Data, domain []byte) ([]*validatorDashboar
 			validatorsData[validator_index].DepositsCount.Valid = true
 		}
 
+		// post electra deposits are included as execution requests, their signature is only verified once they are
+		// processed from the pending deposits queue. Requests for pubkeys that are not yet part of the state are skipped.
+		if requests := block.Data.Message.Body.ExecutionRequests; requests != nil {
+			for _, depositRequest := range requests.Deposits {
+				validator_index, ok := pubkeyToIndexMapNewlyActivatedValidators[string(depositRequest.Pubkey)]
+				if !ok {
+					validator_index, ok = pubkeyToIndexMapOldValidators[string(depositRequest.Pubkey)]
+					if !ok {
+						d.log.Infof("deposit request with index %d in slot %v is for a validator not yet in the state, skipping", depositRequest.Index, block.Data.Message.Slot)
+						continue
+					}
+				}
+				if validator_index >= sizeInt {
+					return nil, errors.New("proposer index out of range")
+				}
+
+				validatorsData[validator_index].DepositsAmount.Int64 += int64(depositRequest.Amount)
+				validatorsData[validator_index].DepositsAmount.Valid = true
+
+				validatorsData[validator_index].DepositsCount.Int16++
+				validatorsData[validator_index].DepositsCount.Valid = true
+			}
+		}
+
 		for _, withdrawal := range block.Data.Message.Body.ExecutionPayload.Withdrawals {
 			validator_index := withdrawal.ValidatorIndex
 
@@ -1514,11 +1539,34 @@ func (d *dashboardData) process(data *Data, domain []byte) ([]*validatorDashboar
 		for _, attestation := range block.Data.Message.Body.Attestations {
 			aggregationBits := bitfield.Bitlist(attestation.AggregationBits)
 
-			for i := uint64(0); i < aggregationBits.Len(); i++ {
-				if aggregationBits.BitAt(i) {
-					validator_index, found := data.attestationAssignments[utils.FormatAttestorAssignmentKeyLowMem(attestation.Data.Slot, attestation.Data.Index, uint32(i))]
+			// post electra the aggregation bits span all committees selected by the committee bits
+			committees := []uint16{attestation.Data.Index}
+			if len(attestation.CommitteeBits) > 0 {
+				committees = committees[:0]
+				for _, committeeIndex := range bitfield.Bitvector64(attestation.CommitteeBits).BitIndices() {
+					committees = append(committees, uint16(committeeIndex))
+				}
+			}
+
+			offset := uint64(0)
+			for _, committeeIndex := range committees {
+				committeeSize := aggregationBits.Len()
+				if len(attestation.CommitteeBits) > 0 {
+					committeeSize = 0
+					for {
+						if _, found := data.attestationAssignments[utils.FormatAttestorAssignmentKeyLowMem(attestation.Data.Slot, committeeIndex, uint32(committeeSize))]; !found {
+							break
+						}
+						committeeSize++
+					}
+				}
+				for i := uint64(0); i < committeeSize && offset+i < aggregationBits.Len(); i++ {
+					if !aggregationBits.BitAt(offset + i) {
+						continue
+					}
+					validator_index, found := data.attestationAssignments[utils.FormatAttestorAssignmentKeyLowMem(attestation.Data.Slot, committeeIndex, uint32(i))]
 					if !found { // This should never happen!
-						d.log.Error(fmt.Errorf("validator not found in attestation assignments"), "validator not found in attestation assignments", 0, map[string]interface{}{"slot": attestation.Data.Slot, "index": attestation.Data.Index, "i": i})
+						d.log.Error(fmt.Errorf("validator not found in attestation assignments"), "validator not found in attestation assignments", 0, map[string]interface{}{"slot": attestation.Data.Slot, "index": committeeIndex, "i": i})
 						return nil, fmt.Errorf("validator not found in attestation assignments")
 					}
 					if validator_index >= size32 {
@@ -1540,6 +1588,7 @@ func (d *dashboardData) process(data *Data, domain []byte) ([]*validatorDashboar
 
 					validatorsData[validator_index].OptimalInclusionDelay = utils.NullInt16(int16(optimalInclusionDistance))
 				}
+				offset += committeeSize
 			}
 		}
 
@@ -186,7 +186,7 @@ func (d *executionDepositsExporter) export() (err error) {
 	var headBlock, finBlock uint64
 	var g errgroup.Group
 	g.Go(func() error {
-		headSlot, err := d.CL.GetSlot("head")
+		headSlot, err := d.CL.GetSlot(context.Background(), "head")
 		if err != nil {
 			return fmt.Errorf("error getting head-slot: %w", err)
 		}
@@ -194,7 +194,7 @@ func (d *executionDepositsExporter) export() (err error) {
 		return nil
 	})
 	g.Go(func() error {
-		finSlot, err := d.CL.GetSlot("finalized")
+		finSlot, err := d.CL.GetSlot(context.Background(), "finalized")
 		if err != nil {
 			return fmt.Errorf("error getting finalized-slot: %w", err)
 		}
@@ -54,7 +54,33 @@ func (d *executionPayloadsExporter) GetName() string {
 }
 
 func (d *executionPayloadsExporter) OnChainReorg(event *constypes.StandardEventChainReorg) (err error) {
-	return nil // nop
+	// the rewards depend on the status of the blocks, so wait until the slot exporter has reconciled them
+	result, ok := reorgResults.wait(event, time.Minute)
+	if !ok || result == nil {
+		log.Warnf("slot exporter didn't reconcile reorg at slot %v, execution payloads will be updated on the next head", event.Slot)
+		return nil
+	}
+	if len(result.Orphaned) == 0 {
+		return nil
+	}
+
+	// fill the rewards of the blocks that replaced the orphaned ones
+	d.ExportMutex.Lock()
+	err = d.maintainTable()
+	d.ExportMutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("error maintaining table: %w", err)
+	}
+
+	// the cached view still contains the rewards of the orphaned blocks
+	d.CachedViewMutex.Lock()
+	defer d.CachedViewMutex.Unlock()
+	err = d.updateCachedView()
+	if err != nil {
+		return fmt.Errorf("error updating cached view: %w", err)
+	}
+	log.Infof("updated execution payloads after reorg at slot %v", event.Slot)
+	return nil
 }
 
 // can take however long it wants to run, is run in a separate goroutine, so no need to worry about blocking
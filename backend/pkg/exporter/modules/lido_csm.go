@@ -0,0 +1,112 @@
+package modules
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+type LidoKeysApiKeysResponse struct {
+	Data struct {
+		Keys []struct {
+			Key           string `json:"key"`
+			OperatorIndex uint64 `json:"operatorIndex"`
+			Used          bool   `json:"used"`
+		} `json:"keys"`
+	} `json:"data"`
+}
+
+// lidoCsmExporter tags the deposited validators of the lido community staking module
+func lidoCsmExporter() {
+	for {
+		start := time.Now()
+		err := exportLidoCsm()
+		if err != nil {
+			log.Error(err, "error exporting lido csm validators", 0)
+		} else {
+			metrics.TaskDuration.WithLabelValues("lido_csm_exporter").Observe(time.Since(start).Seconds())
+		}
+		time.Sleep(time.Minute * 10)
+	}
+}
+
+func exportLidoCsm() error {
+	t0 := time.Now()
+	baseUrl := strings.TrimSuffix(utils.Config.LidoCsmExporter.KeysApiUrl, "/")
+	moduleId := utils.Config.LidoCsmExporter.ModuleId
+
+	keys := LidoKeysApiKeysResponse{}
+	err := getLidoKeysApi(fmt.Sprintf("%s/v1/modules/%d/keys?used=true", baseUrl, moduleId), &keys)
+	if err != nil {
+		return err
+	}
+
+	pubkeys, err := lidoCsmPubkeys(&keys)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return err
+	}
+	defer utils.Rollback(tx)
+
+	err = saveStakingProtocolValidators(tx, "lido_csm", pubkeys)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	log.InfoWithFields(log.Fields{"moduleId": moduleId, "number": len(pubkeys), "duration": time.Since(t0)}, "tagged lido csm validators")
+	return nil
+}
+
+// lidoCsmPubkeys decodes the publickeys of the keys that have been deposited
+func lidoCsmPubkeys(keys *LidoKeysApiKeysResponse) ([][]byte, error) {
+	pubkeys := make([][]byte, 0, len(keys.Data.Keys))
+	for _, key := range keys.Data.Keys {
+		if !key.Used {
+			continue
+		}
+		pubkey, err := hex.DecodeString(strings.Replace(key.Key, "0x", "", -1))
+		if err != nil {
+			return nil, err
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+}
+
+func getLidoKeysApi(url string, target interface{}) error {
+	client := &http.Client{
+		Timeout: time.Minute,
+	}
+	resp, err := client.Get(url)
+	if err != nil {
+		return fmt.Errorf("error retrieving %v from lido keys api: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error retrieving %v from lido keys api: unexpected status %s", url, resp.Status)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(target)
+	if err != nil {
+		return fmt.Errorf("error decoding json from lido keys api %v: %w", url, err)
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+package modules
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gobitfly/beaconchain/pkg/consapi/network"
+	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
+)
+
+// poolOperationsStore is the storage of the lifecycle of the tracked pool operations
+type poolOperationsStore interface {
+	// SetSeen stores operations that are currently in the pool, dropped operations become pending again
+	SetSeen(operations []*types.PoolOperation, slot uint64, ts time.Time) error
+	// SetIncluded marks operations as included in the block of slot and drops the operations they supersede
+	SetIncluded(operations []*types.PoolOperation, slot uint64) error
+	// SetDropped marks pending operations that have last been seen at or after fromLastSeenSlot and before toLastSeenSlot as dropped
+	SetDropped(fromLastSeenSlot, toLastSeenSlot, slot uint64) error
+	// GetOldestPendingSlot returns the slot the longest pending operation has last been seen in, ok is false if none is pending
+	GetOldestPendingSlot() (slot uint64, ok bool, err error)
+	// ResetIncluded reverts the inclusions and drops at or after slot
+	ResetIncluded(slot uint64) error
+}
+
+// poolOperationsTracker tracks the voluntary exits and bls to execution changes from the moment they are seen in the op
+// pool of the node until they are included in a block or vanish from the pool. Attestations are not tracked here, their
+// inclusion is already covered by the slot duties.
+type poolOperationsTracker struct {
+	ModuleContext
+	store    poolOperationsStore
+	mutex    sync.Mutex
+	lastSlot uint64
+	// the blocks of all slots since processedFromSlot have been processed, operations last seen before might have been
+	// included in a slot that has been skipped and are never dropped
+	processedFromSlot uint64
+}
+
+// maxPoolOperationsBackfillSlots limits the number of blocks that are processed at once after a restart or if the tracker fell behind
+const maxPoolOperationsBackfillSlots = 1024
+
+func NewPoolOperationsTracker(moduleContext ModuleContext) ModuleInterface {
+	return &poolOperationsTracker{
+		ModuleContext: moduleContext,
+		store:         dbPoolOperationsStore{},
+	}
+}
+
+func (d *poolOperationsTracker) Init() error {
+	go d.subscribe(context.Background())
+	return nil
+}
+
+func (d *poolOperationsTracker) GetName() string {
+	return "PoolOperations-Tracker"
+}
+
+// subscribe records operations as soon as the node receives them, the head polling only sees them once per slot
+func (d *poolOperationsTracker) subscribe(ctx context.Context) {
+	for {
+		d.receiveEvents(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warnf("pool operation event stream closed, reconnecting")
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(poolOperationsResubscribeDelay):
+		}
+	}
+}
+
+// poolOperationsResubscribeDelay is the time to wait before subscribing again after the event stream closed
+var poolOperationsResubscribeDelay = time.Second * 10
+
+// receiveEvents saves the operations of the event stream until it is closed
+func (d *poolOperationsTracker) receiveEvents(ctx context.Context) {
+	events := d.CL.GetEvents(ctx, []constypes.EventTopic{
+		constypes.EventVoluntaryExit,
+		constypes.EventBlsToExecutionChange,
+	})
+	for event := range events {
+		if event.Error != nil {
+			log.Error(event.Error, "error getting pool operation event", 0)
+			continue
+		}
+		operation, err := poolOperationFromEvent(event)
+		if err != nil {
+			log.Error(err, "error parsing pool operation event", 0, log.Fields{"event": event.Event})
+			continue
+		}
+		if operation == nil {
+			continue
+		}
+		now := time.Now()
+		err = d.store.SetSeen([]*types.PoolOperation{operation}, utils.TimeToSlot(uint64(now.Unix())), now)
+		if err != nil {
+			log.Error(err, "error saving pool operation event", 0, log.Fields{"event": event.Event, "validator": operation.ValidatorIndex})
+		}
+	}
+}
+
+func (d *poolOperationsTracker) OnHead(event *constypes.StandardEventHeadResponse) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.processHead(context.Background(), uint64(event.Slot))
+}
+
+func (d *poolOperationsTracker) processHead(ctx context.Context, headSlot uint64) error {
+	if headSlot <= d.lastSlot {
+		return nil
+	}
+
+	fromSlot := d.lastSlot + 1
+	if d.lastSlot == 0 {
+		// backfill the inclusions since the pending operations have last been seen, e.g. before a restart
+		fromSlot = headSlot
+		lastSeenSlot, ok, err := d.store.GetOldestPendingSlot()
+		if err != nil {
+			return fmt.Errorf("error retrieving pending pool operations: %w", err)
+		}
+		if ok && lastSeenSlot < headSlot {
+			fromSlot = lastSeenSlot + 1
+		}
+	}
+	if headSlot-fromSlot > maxPoolOperationsBackfillSlots {
+		fromSlot = headSlot - maxPoolOperationsBackfillSlots
+	}
+	if d.lastSlot == 0 || fromSlot > d.lastSlot+1 {
+		d.processedFromSlot = fromSlot
+	}
+
+	for slot := fromSlot; slot <= headSlot; slot++ {
+		operations, err := d.getIncludedOperations(ctx, slot)
+		if err != nil {
+			return err
+		}
+		err = d.store.SetIncluded(operations, slot)
+		if err != nil {
+			return fmt.Errorf("error saving operations included in slot %v: %w", slot, err)
+		}
+		d.lastSlot = slot
+	}
+
+	operations, err := d.getPoolOperations(ctx)
+	if err != nil {
+		return err
+	}
+	err = d.store.SetSeen(operations, headSlot, time.Now())
+	if err != nil {
+		return fmt.Errorf("error saving pool operations: %w", err)
+	}
+
+	// operations usually leave the pool because they got included, give the inclusion some time to be processed.
+	// An operation last seen in slot x can be included from slot x+1 on, so only those seen since the slot before the
+	// processed slots can be dropped.
+	if headSlot > utils.Config.Chain.ClConfig.SlotsPerEpoch {
+		fromLastSeenSlot := d.processedFromSlot
+		if fromLastSeenSlot > 0 {
+			fromLastSeenSlot--
+		}
+		err = d.store.SetDropped(fromLastSeenSlot, headSlot-utils.Config.Chain.ClConfig.SlotsPerEpoch, headSlot)
+		if err != nil {
+			return fmt.Errorf("error dropping pool operations: %w", err)
+		}
+	}
+	return nil
+}
+
+// getIncludedOperations returns the operations included in the block of slot, none if the slot has been missed
+func (d *poolOperationsTracker) getIncludedOperations(ctx context.Context, slot uint64) ([]*types.PoolOperation, error) {
+	block, err := d.CL.GetSlot(ctx, slot)
+	if err != nil {
+		httpErr := network.SpecificError(err)
+		if httpErr != nil && httpErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error retrieving block of slot %v: %w", slot, err)
+	}
+
+	body := block.Data.Message.Body
+	operations := make([]*types.PoolOperation, 0, len(body.VoluntaryExits)+len(body.SignedBLSToExecutionChange))
+	for i := range body.VoluntaryExits {
+		operations = append(operations, voluntaryExitOperation(&body.VoluntaryExits[i]))
+	}
+	for _, change := range body.SignedBLSToExecutionChange {
+		operations = append(operations, blsToExecutionChangeOperation(change))
+	}
+	return operations, nil
+}
+
+// getPoolOperations returns the operations that are currently in the pool of the node
+func (d *poolOperationsTracker) getPoolOperations(ctx context.Context) ([]*types.PoolOperation, error) {
+	exits, err := d.CL.GetPoolVoluntaryExits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving pool voluntary exits: %w", err)
+	}
+	changes, err := d.CL.GetPoolBLSToExecutionChanges(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving pool bls to execution changes: %w", err)
+	}
+
+	operations := make([]*types.PoolOperation, 0, len(exits.Data)+len(changes.Data))
+	for i := range exits.Data {
+		operations = append(operations, voluntaryExitOperation(&exits.Data[i]))
+	}
+	for i := range changes.Data {
+		operations = append(operations, blsToExecutionChangeOperation(&changes.Data[i]))
+	}
+	return operations, nil
+}
+
+func (d *poolOperationsTracker) OnFinalizedCheckpoint(event *constypes.StandardFinalizedCheckpointResponse) error {
+	return nil // nop
+}
+
+func (d *poolOperationsTracker) OnChainReorg(event *constypes.StandardEventChainReorg) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	fromSlot := uint64(0)
+	if event.Slot > event.Depth {
+		fromSlot = event.Slot - event.Depth
+	}
+	// the operations of the orphaned blocks are either back in the pool or included by the new blocks
+	err := d.store.ResetIncluded(fromSlot)
+	if err != nil {
+		return err
+	}
+	if fromSlot > 0 && d.lastSlot >= fromSlot {
+		d.lastSlot = fromSlot - 1
+		d.processedFromSlot = min(d.processedFromSlot, fromSlot)
+	}
+	return nil
+}
+
+func poolOperationFromEvent(event *constypes.EventResponse) (*types.PoolOperation, error) {
+	switch event.Event {
+	case constypes.EventVoluntaryExit:
+		exit, err := event.VoluntaryExit()
+		if err != nil {
+			return nil, err
+		}
+		return voluntaryExitOperation(exit), nil
+	case constypes.EventBlsToExecutionChange:
+		change, err := event.BlsToExecutionChange()
+		if err != nil {
+			return nil, err
+		}
+		return blsToExecutionChangeOperation(change), nil
+	}
+	return nil, nil
+}
+
+func voluntaryExitOperation(exit *constypes.VoluntaryExit) *types.PoolOperation {
+	return &types.PoolOperation{
+		Type:           types.PoolOperationVoluntaryExit,
+		ValidatorIndex: exit.Message.ValidatorIndex,
+		Signature:      exit.Signature,
+		ExitEpoch:      sql.NullInt64{Int64: int64(exit.Message.Epoch), Valid: true},
+	}
+}
+
+func blsToExecutionChangeOperation(change *constypes.SignedBLSToExecutionChange) *types.PoolOperation {
+	return &types.PoolOperation{
+		Type:               types.PoolOperationBlsToExecutionChange,
+		ValidatorIndex:     change.Message.ValidatorIndex,
+		Signature:          change.Signature,
+		ToExecutionAddress: change.Message.ToExecutionAddress,
+	}
+}
+
+type dbPoolOperationsStore struct{}
+
+func (dbPoolOperationsStore) SetSeen(operations []*types.PoolOperation, slot uint64, ts time.Time) error {
+	return db.SavePoolOperationsSeen(operations, slot, ts)
+}
+
+func (dbPoolOperationsStore) SetIncluded(operations []*types.PoolOperation, slot uint64) error {
+	return db.SetPoolOperationsIncluded(operations, slot)
+}
+
+func (dbPoolOperationsStore) SetDropped(fromLastSeenSlot, toLastSeenSlot, slot uint64) error {
+	return db.SetPoolOperationsDropped(fromLastSeenSlot, toLastSeenSlot, slot)
+}
+
+func (dbPoolOperationsStore) GetOldestPendingSlot() (uint64, bool, error) {
+	return db.GetOldestPendingPoolOperationSlot()
+}
+
+func (dbPoolOperationsStore) ResetIncluded(slot uint64) error {
+	return db.ResetPoolOperationsIncluded(slot)
+}
@@ -0,0 +1,311 @@
+package modules
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gobitfly/beaconchain/pkg/consapi"
+	"github.com/gobitfly/beaconchain/pkg/consapi/network"
+	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
+)
+
+// fakePoolNode serves the blocks and the op pool of a node, other calls panic
+type fakePoolNode struct {
+	consapi.ClientInt
+	blocks  map[uint64]*constypes.StandardBeaconSlotResponse
+	exits   []constypes.VoluntaryExit
+	changes []constypes.SignedBLSToExecutionChange
+	// every subscription sends the events and closes the stream afterwards
+	events        []*constypes.EventResponse
+	subscriptions int
+}
+
+func (n *fakePoolNode) GetEvents(ctx context.Context, topics []constypes.EventTopic) chan *constypes.EventResponse {
+	n.subscriptions++
+	ch := make(chan *constypes.EventResponse, len(n.events))
+	for _, event := range n.events {
+		ch <- event
+	}
+	close(ch)
+	return ch
+}
+
+func (n *fakePoolNode) GetSlot(ctx context.Context, blockID any) (*constypes.StandardBeaconSlotResponse, error) {
+	slot, ok := blockID.(uint64)
+	if !ok {
+		return nil, fmt.Errorf("unsupported block id %v", blockID)
+	}
+	block := n.blocks[slot]
+	if block == nil {
+		return nil, &network.HttpReqHttpError{StatusCode: http.StatusNotFound, Url: fmt.Sprintf("/eth/v2/beacon/blocks/%d", slot)}
+	}
+	return block, nil
+}
+
+func (n *fakePoolNode) GetPoolVoluntaryExits(ctx context.Context) (*constypes.StandardPoolVoluntaryExitsResponse, error) {
+	return &constypes.StandardPoolVoluntaryExitsResponse{Data: n.exits}, nil
+}
+
+func (n *fakePoolNode) GetPoolBLSToExecutionChanges(ctx context.Context) (*constypes.StandardPoolBLSToExecutionChangesResponse, error) {
+	return &constypes.StandardPoolBLSToExecutionChangesResponse{Data: n.changes}, nil
+}
+
+// fakePoolStore behaves like the pool_operations table
+type fakePoolStore struct {
+	operations map[string]*types.PoolOperation
+}
+
+func poolOperationKey(op *types.PoolOperation) string {
+	return fmt.Sprintf("%s:%d:%x", op.Type, op.ValidatorIndex, op.Signature)
+}
+
+func (s *fakePoolStore) SetSeen(operations []*types.PoolOperation, slot uint64, ts time.Time) error {
+	for _, op := range operations {
+		existing, ok := s.operations[poolOperationKey(op)]
+		if !ok {
+			op.FirstSeenSlot = slot
+			op.FirstSeenTs = ts
+			op.LastSeenSlot = slot
+			s.operations[poolOperationKey(op)] = op
+			continue
+		}
+		if existing.IncludedSlot.Valid {
+			continue
+		}
+		existing.LastSeenSlot = max(existing.LastSeenSlot, slot)
+		existing.DroppedSlot = sql.NullInt64{}
+	}
+	return nil
+}
+
+func (s *fakePoolStore) SetIncluded(operations []*types.PoolOperation, slot uint64) error {
+	for _, op := range operations {
+		existing, ok := s.operations[poolOperationKey(op)]
+		if !ok {
+			op.FirstSeenSlot = slot
+			op.LastSeenSlot = slot
+			s.operations[poolOperationKey(op)] = op
+			existing = op
+		}
+		existing.IncludedSlot = sql.NullInt64{Int64: int64(slot), Valid: true}
+		existing.DroppedSlot = sql.NullInt64{}
+		for _, other := range s.operations {
+			if other != existing && other.Type == op.Type && other.ValidatorIndex == op.ValidatorIndex && !other.IncludedSlot.Valid && !other.DroppedSlot.Valid {
+				other.DroppedSlot = sql.NullInt64{Int64: int64(slot), Valid: true}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *fakePoolStore) SetDropped(fromLastSeenSlot, toLastSeenSlot, slot uint64) error {
+	for _, op := range s.operations {
+		if !op.IncludedSlot.Valid && !op.DroppedSlot.Valid && op.LastSeenSlot >= fromLastSeenSlot && op.LastSeenSlot < toLastSeenSlot {
+			op.DroppedSlot = sql.NullInt64{Int64: int64(slot), Valid: true}
+		}
+	}
+	return nil
+}
+
+func (s *fakePoolStore) GetOldestPendingSlot() (uint64, bool, error) {
+	slot, ok := uint64(0), false
+	for _, op := range s.operations {
+		if !op.IncludedSlot.Valid && !op.DroppedSlot.Valid && (!ok || op.LastSeenSlot < slot) {
+			slot, ok = op.LastSeenSlot, true
+		}
+	}
+	return slot, ok, nil
+}
+
+func (s *fakePoolStore) ResetIncluded(slot uint64) error {
+	for _, op := range s.operations {
+		if op.IncludedSlot.Valid && uint64(op.IncludedSlot.Int64) >= slot {
+			op.IncludedSlot = sql.NullInt64{}
+		}
+		if op.DroppedSlot.Valid && uint64(op.DroppedSlot.Int64) >= slot {
+			op.DroppedSlot = sql.NullInt64{}
+		}
+	}
+	return nil
+}
+
+// status returns the lifecycle state of the operation, e.g. included:<slot>
+func (s *fakePoolStore) status(op *types.PoolOperation) string {
+	existing, ok := s.operations[poolOperationKey(op)]
+	switch {
+	case !ok:
+		return "unknown"
+	case existing.IncludedSlot.Valid:
+		return fmt.Sprintf("included:%d", existing.IncludedSlot.Int64)
+	case existing.DroppedSlot.Valid:
+		return fmt.Sprintf("dropped:%d", existing.DroppedSlot.Int64)
+	}
+	return "pending"
+}
+
+func testExit(validator uint64, signature byte) constypes.VoluntaryExit {
+	exit := constypes.VoluntaryExit{Signature: []byte{signature}}
+	exit.Message.ValidatorIndex = validator
+	return exit
+}
+
+func testBlsChange(validator uint64, signature byte) constypes.SignedBLSToExecutionChange {
+	change := constypes.SignedBLSToExecutionChange{Signature: []byte{signature}}
+	change.Message.ValidatorIndex = validator
+	change.Message.ToExecutionAddress = []byte{0xaa}
+	return change
+}
+
+func TestPoolOperationsLifecycle(t *testing.T) {
+	config := &types.Config{}
+	config.Chain.ClConfig.SlotsPerEpoch = 32
+	utils.Config = config
+
+	node := &fakePoolNode{blocks: make(map[uint64]*constypes.StandardBeaconSlotResponse)}
+	store := &fakePoolStore{operations: make(map[string]*types.PoolOperation)}
+	tracker := &poolOperationsTracker{ModuleContext: ModuleContext{CL: consapi.Client{ClientInt: node}}, store: store}
+
+	head := func(slot uint64) {
+		err := tracker.processHead(context.Background(), slot)
+		if err != nil {
+			t.Fatalf("unexpected error at head %d: %v", slot, err)
+		}
+	}
+	expect := func(op *types.PoolOperation, expected string) {
+		if got := store.status(op); got != expected {
+			t.Errorf("expected %s of validator %d to be %s, got %s", op.Type, op.ValidatorIndex, expected, got)
+		}
+	}
+
+	exit := testExit(1, 0x01)
+	supersededExit := testExit(1, 0x02)
+	change := testBlsChange(2, 0x03)
+	node.exits = []constypes.VoluntaryExit{exit, supersededExit}
+	node.changes = []constypes.SignedBLSToExecutionChange{change}
+	head(100)
+	expect(voluntaryExitOperation(&exit), "pending")
+	expect(voluntaryExitOperation(&supersededExit), "pending")
+	expect(blsToExecutionChangeOperation(&change), "pending")
+
+	// the exit is included in a block, the other exit of the validator can't be included anymore
+	block := &constypes.StandardBeaconSlotResponse{}
+	block.Data.Message.Body.VoluntaryExits = []constypes.VoluntaryExit{exit}
+	node.blocks[102] = block
+	node.exits = nil
+	head(102)
+	expect(voluntaryExitOperation(&exit), "included:102")
+	expect(voluntaryExitOperation(&supersededExit), "dropped:102")
+	expect(blsToExecutionChangeOperation(&change), "pending")
+
+	// the change vanishes from the pool without being included
+	node.changes = nil
+	head(130)
+	expect(blsToExecutionChangeOperation(&change), "pending")
+	head(135)
+	expect(blsToExecutionChangeOperation(&change), "dropped:135")
+
+	// a dropped operation that shows up again is pending again
+	node.changes = []constypes.SignedBLSToExecutionChange{change}
+	head(136)
+	expect(blsToExecutionChangeOperation(&change), "pending")
+
+	// the block with the exit got orphaned, the exit is back in the pool until the new chain includes it again
+	delete(node.blocks, 102)
+	node.exits = []constypes.VoluntaryExit{exit}
+	err := tracker.OnChainReorg(&constypes.StandardEventChainReorg{Slot: 136, Depth: 35})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expect(voluntaryExitOperation(&exit), "pending")
+	node.blocks[137] = block
+	head(137)
+	expect(voluntaryExitOperation(&exit), "included:137")
+}
+
+func TestPoolOperationsBackfill(t *testing.T) {
+	config := &types.Config{}
+	config.Chain.ClConfig.SlotsPerEpoch = 32
+	utils.Config = config
+
+	node := &fakePoolNode{blocks: make(map[uint64]*constypes.StandardBeaconSlotResponse)}
+	store := &fakePoolStore{operations: make(map[string]*types.PoolOperation)}
+	exit := testExit(1, 0x01)
+	change := testBlsChange(2, 0x02)
+	err := store.SetSeen([]*types.PoolOperation{voluntaryExitOperation(&exit), blsToExecutionChangeOperation(&change)}, 100, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the exit has been included while the tracker was not running, the change has left the pool
+	block := &constypes.StandardBeaconSlotResponse{}
+	block.Data.Message.Body.VoluntaryExits = []constypes.VoluntaryExit{exit}
+	node.blocks[105] = block
+	tracker := &poolOperationsTracker{ModuleContext: ModuleContext{CL: consapi.Client{ClientInt: node}}, store: store}
+	err = tracker.processHead(context.Background(), 150)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := store.status(voluntaryExitOperation(&exit)); got != "included:105" {
+		t.Errorf("expected the exit to be included in the backfilled slot 105, got %s", got)
+	}
+	if got := store.status(blsToExecutionChangeOperation(&change)); got != "dropped:150" {
+		t.Errorf("expected the change to be dropped, got %s", got)
+	}
+
+	// the tracker fell behind further than it backfills, operations that might have been included in a skipped slot stay pending
+	change = testBlsChange(3, 0x03)
+	node.changes = []constypes.SignedBLSToExecutionChange{change}
+	err = tracker.processHead(context.Background(), 151)
+	if err != nil {
+		t.Fatal(err)
+	}
+	node.changes = nil
+	head := 151 + maxPoolOperationsBackfillSlots + 50
+	err = tracker.processHead(context.Background(), uint64(head))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := store.status(blsToExecutionChangeOperation(&change)); got != "pending" {
+		t.Errorf("expected the change last seen before the processed slots to stay pending, got %s", got)
+	}
+	if tracker.lastSlot != uint64(head) {
+		t.Errorf("expected the tracker to be at slot %d, got %d", head, tracker.lastSlot)
+	}
+}
+
+func TestPoolOperationsResubscribe(t *testing.T) {
+	config := &types.Config{}
+	config.Chain.ClConfig.SlotsPerEpoch = 32
+	config.Chain.ClConfig.SecondsPerSlot = 12
+	utils.Config = config
+
+	delay := poolOperationsResubscribeDelay
+	defer func() { poolOperationsResubscribeDelay = delay }()
+	poolOperationsResubscribeDelay = 20 * time.Millisecond
+
+	exit := testExit(1, 0x01)
+	data, err := json.Marshal(exit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := &fakePoolNode{events: []*constypes.EventResponse{{Event: constypes.EventVoluntaryExit, Data: data}}}
+	store := &fakePoolStore{operations: make(map[string]*types.PoolOperation)}
+	tracker := &poolOperationsTracker{ModuleContext: ModuleContext{CL: consapi.Client{ClientInt: node}}, store: store}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	tracker.subscribe(ctx)
+	if node.subscriptions < 2 {
+		t.Errorf("expected the tracker to subscribe again after the stream closed, got %d subscriptions", node.subscriptions)
+	}
+	if got := store.status(voluntaryExitOperation(&exit)); got != "pending" {
+		t.Errorf("expected the exit of the event to be saved, got %s", got)
+	}
+}
@@ -0,0 +1,184 @@
+package modules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
+)
+
+// slotDutiesSource provides the duties of a slot and the attestation assignments of an epoch
+type slotDutiesSource interface {
+	GetBlockBySlot(slot uint64) (*types.Block, error)
+	GetEpochAssignments(epoch uint64) (*types.EpochAssignments, error)
+}
+
+// slotDutiesPublisher publishes the duty results of every new head slot via redis pub/sub so the api can stream them to the validator dashboards
+type slotDutiesPublisher struct {
+	ModuleContext
+	source slotDutiesSource
+	// publish sends the encoded event of a slot to the subscribers
+	publish  func(data []byte) error
+	mutex    sync.Mutex
+	lastSlot uint64
+	// first epoch whose attestations are tracked from the start of their inclusion window, missed attestations are only reported from here on
+	trackedFromEpoch uint64
+	// validators whose attestations of an epoch have already been included
+	includedAttestations map[uint64]*validatorBitset
+}
+
+func NewSlotDutiesPublisher(moduleContext ModuleContext) ModuleInterface {
+	return &slotDutiesPublisher{
+		ModuleContext: moduleContext,
+		source:        moduleContext.ConsClient,
+		publish: func(data []byte) error {
+			return db.PersistentRedisDbClient.Publish(context.Background(), types.SlotDutiesRedisChannel(utils.Config.Chain.ClConfig.DepositChainID), data).Err()
+		},
+		includedAttestations: make(map[uint64]*validatorBitset),
+	}
+}
+
+func (d *slotDutiesPublisher) Init() error {
+	return nil // nop
+}
+
+func (d *slotDutiesPublisher) GetName() string {
+	return "SlotDuties-Publisher"
+}
+
+func (d *slotDutiesPublisher) OnHead(event *constypes.StandardEventHeadResponse) error {
+	// slots have to be published in order, so heads are processed one after another
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	headSlot := uint64(event.Slot)
+	if headSlot <= d.lastSlot {
+		return nil
+	}
+
+	fromSlot := d.lastSlot + 1
+	if d.lastSlot == 0 || headSlot-d.lastSlot > utils.Config.Chain.ClConfig.SlotsPerEpoch {
+		// first head or the exporter fell behind, start tracking again from the head
+		fromSlot = headSlot
+		d.trackedFromEpoch = utils.EpochOfSlot(headSlot) + 1
+		d.includedAttestations = make(map[uint64]*validatorBitset)
+	}
+
+	for slot := fromSlot; slot <= headSlot; slot++ {
+		slotEvent, err := d.getSlotDuties(slot)
+		if err != nil {
+			return fmt.Errorf("error retrieving duties of slot %v: %w", slot, err)
+		}
+
+		data, err := json.Marshal(slotEvent)
+		if err != nil {
+			return fmt.Errorf("error marshalling duties of slot %v: %w", slot, err)
+		}
+		err = d.publish(data)
+		if err != nil {
+			return fmt.Errorf("error publishing duties of slot %v: %w", slot, err)
+		}
+		d.lastSlot = slot
+	}
+	return nil
+}
+
+func (d *slotDutiesPublisher) getSlotDuties(slot uint64) (*types.SlotDutiesEvent, error) {
+	epoch := utils.EpochOfSlot(slot)
+	block, err := d.source.GetBlockBySlot(slot)
+	if err != nil {
+		return nil, err
+	}
+
+	slotEvent := &types.SlotDutiesEvent{
+		Slot:           slot,
+		Epoch:          epoch,
+		Proposer:       block.Proposer,
+		ProposalMissed: block.Status == 0,
+		Attestations:   make(map[uint64][]uint64),
+	}
+
+	if epoch >= d.trackedFromEpoch && d.includedAttestations[epoch] == nil {
+		d.includedAttestations[epoch] = &validatorBitset{}
+	}
+
+	for validator, participated := range block.SyncDuties {
+		if participated {
+			slotEvent.SyncParticipated = append(slotEvent.SyncParticipated, uint64(validator))
+		} else {
+			slotEvent.SyncMissed = append(slotEvent.SyncMissed, uint64(validator))
+		}
+	}
+
+	for validator, attestedSlots := range block.AttestationDuties {
+		for _, attestedSlot := range attestedSlots {
+			included, ok := d.includedAttestations[utils.EpochOfSlot(uint64(attestedSlot))]
+			if ok {
+				// only the first inclusion of an attestation is reported
+				if included.isSet(uint64(validator)) {
+					continue
+				}
+				included.set(uint64(validator))
+			}
+			slotEvent.Attestations[uint64(attestedSlot)] = append(slotEvent.Attestations[uint64(attestedSlot)], uint64(validator))
+		}
+	}
+
+	// attestations of an epoch can be included until the end of the next epoch, everything not included by then is missed
+	slotEvent.MissedAttestations = make(map[uint64][]uint64)
+	for attestedEpoch, included := range d.includedAttestations {
+		if attestedEpoch+1 >= epoch {
+			continue
+		}
+		assignments, err := d.source.GetEpochAssignments(attestedEpoch)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving assignments of epoch %v: %w", attestedEpoch, err)
+		}
+		for key, validator := range assignments.AttestorAssignments {
+			if included.isSet(validator) {
+				continue
+			}
+			attestedSlot, err := strconv.ParseUint(strings.Split(key, "-")[0], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing attested slot from attestation key: %w", err)
+			}
+			slotEvent.MissedAttestations[attestedSlot] = append(slotEvent.MissedAttestations[attestedSlot], validator)
+		}
+		delete(d.includedAttestations, attestedEpoch)
+		log.Infof("reporting missed attestations of epoch %v with slot %v", attestedEpoch, slot)
+	}
+
+	return slotEvent, nil
+}
+
+func (d *slotDutiesPublisher) OnFinalizedCheckpoint(event *constypes.StandardFinalizedCheckpointResponse) error {
+	return nil // nop
+}
+
+func (d *slotDutiesPublisher) OnChainReorg(event *constypes.StandardEventChainReorg) error {
+	return nil // nop, the published events are not corrected after a reorg
+}
+
+// validatorBitset is a compact set of validator indices
+type validatorBitset []uint64
+
+func (b validatorBitset) isSet(validator uint64) bool {
+	i := validator / 64
+	return i < uint64(len(b)) && b[i]&(1<<(validator%64)) != 0
+}
+
+func (b *validatorBitset) set(validator uint64) {
+	i := validator / 64
+	for uint64(len(*b)) <= i {
+		*b = append(*b, 0)
+	}
+	(*b)[i] |= 1 << (validator % 64)
+}
@@ -0,0 +1,159 @@
+package modules
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
+)
+
+// fakeSlotDutiesSource serves proposed empty blocks for every slot unless a block is set explicitly
+type fakeSlotDutiesSource struct {
+	blocks      map[uint64]*types.Block
+	assignments map[uint64]*types.EpochAssignments
+}
+
+func (s *fakeSlotDutiesSource) GetBlockBySlot(slot uint64) (*types.Block, error) {
+	if block, ok := s.blocks[slot]; ok {
+		return block, nil
+	}
+	return &types.Block{Slot: slot, Status: 1}, nil
+}
+
+func (s *fakeSlotDutiesSource) GetEpochAssignments(epoch uint64) (*types.EpochAssignments, error) {
+	assignments, ok := s.assignments[epoch]
+	if !ok {
+		return nil, fmt.Errorf("no assignments for epoch %v", epoch)
+	}
+	return assignments, nil
+}
+
+func attestationsBlock(slot uint64, duties map[types.ValidatorIndex][]types.Slot) *types.Block {
+	return &types.Block{Slot: slot, Status: 1, Proposer: slot, AttestationDuties: duties}
+}
+
+func newTestSlotDutiesPublisher(source slotDutiesSource) (*slotDutiesPublisher, *[]types.SlotDutiesEvent) {
+	published := []types.SlotDutiesEvent{}
+	return &slotDutiesPublisher{
+		source: source,
+		publish: func(data []byte) error {
+			event := types.SlotDutiesEvent{}
+			if err := json.Unmarshal(data, &event); err != nil {
+				return err
+			}
+			published = append(published, event)
+			return nil
+		},
+		includedAttestations: make(map[uint64]*validatorBitset),
+	}, &published
+}
+
+func TestSlotDutiesPublisherReportsAttestationsOnce(t *testing.T) {
+	config := &types.Config{}
+	config.Chain.ClConfig.SlotsPerEpoch = 4
+	utils.Config = config
+
+	source := &fakeSlotDutiesSource{
+		blocks: map[uint64]*types.Block{
+			// validator 1 attests slot 4, its attestation is included twice
+			5: attestationsBlock(5, map[types.ValidatorIndex][]types.Slot{1: {4}}),
+			6: attestationsBlock(6, map[types.ValidatorIndex][]types.Slot{1: {4}}),
+			// validator 2 attests slot 5, it is included in the next epoch
+			9: attestationsBlock(9, map[types.ValidatorIndex][]types.Slot{2: {5}}),
+			// no block is proposed in slot 10
+			10: {Slot: 10, Status: 0, Proposer: 10},
+		},
+		assignments: map[uint64]*types.EpochAssignments{
+			// validator 3 is assigned to slot 6 but never included
+			1: {AttestorAssignments: map[string]uint64{
+				utils.FormatAttestorAssignmentKey(4, 0, 0): 1,
+				utils.FormatAttestorAssignmentKey(5, 0, 0): 2,
+				utils.FormatAttestorAssignmentKey(6, 0, 0): 3,
+			}},
+		},
+	}
+	publisher, published := newTestSlotDutiesPublisher(source)
+
+	// the first head starts the tracking, epoch 1 is the first epoch that is tracked from its start
+	if err := publisher.OnHead(&constypes.StandardEventHeadResponse{Slot: 3}); err != nil {
+		t.Fatal(err)
+	}
+	// the publisher catches up on the slots in between heads
+	for _, head := range []uint64{6, 9, 12} {
+		if err := publisher.OnHead(&constypes.StandardEventHeadResponse{Slot: head}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var slots []uint64
+	for _, event := range *published {
+		slots = append(slots, event.Slot)
+	}
+	if !slices.Equal(slots, []uint64{3, 4, 5, 6, 7, 8, 9, 10, 11, 12}) {
+		t.Fatalf("expected every slot to be published once in order, got %v", slots)
+	}
+
+	events := make(map[uint64]types.SlotDutiesEvent)
+	for _, event := range *published {
+		events[event.Slot] = event
+	}
+	if got := events[5].Attestations[4]; !slices.Equal(got, []uint64{1}) {
+		t.Errorf("expected the attestation of validator 1 to be reported with slot 5, got %v", got)
+	}
+	if got := events[6].Attestations; len(got) != 0 {
+		t.Errorf("expected the second inclusion of the attestation of validator 1 not to be reported, got %v", got)
+	}
+	if got := events[9].Attestations[5]; !slices.Equal(got, []uint64{2}) {
+		t.Errorf("expected the attestation of validator 2 to be reported with slot 9, got %v", got)
+	}
+	if !events[10].ProposalMissed {
+		t.Errorf("expected the proposal of slot 10 to be missed")
+	}
+	for slot, event := range events {
+		if slot != 12 && len(event.MissedAttestations) != 0 {
+			t.Errorf("expected no missed attestations before the inclusion window of epoch 1 closed, got %v with slot %v", event.MissedAttestations, slot)
+		}
+	}
+	if got := events[12].MissedAttestations; len(got) != 1 || !slices.Equal(got[6], []uint64{3}) {
+		t.Errorf("expected the attestation of validator 3 to be reported missed with slot 12, got %v", got)
+	}
+}
+
+func TestSlotDutiesPublisherReportsMissedAttestationsOfAllEpochs(t *testing.T) {
+	config := &types.Config{}
+	config.Chain.ClConfig.SlotsPerEpoch = 4
+	utils.Config = config
+
+	source := &fakeSlotDutiesSource{
+		assignments: map[uint64]*types.EpochAssignments{
+			1: {AttestorAssignments: map[string]uint64{
+				utils.FormatAttestorAssignmentKey(4, 0, 0): 1,
+				utils.FormatAttestorAssignmentKey(4, 0, 1): 2,
+			}},
+			2: {AttestorAssignments: map[string]uint64{
+				utils.FormatAttestorAssignmentKey(9, 0, 0): 1,
+			}},
+		},
+	}
+	publisher, _ := newTestSlotDutiesPublisher(source)
+	included := &validatorBitset{}
+	included.set(2)
+	publisher.includedAttestations[1] = included
+	publisher.includedAttestations[2] = &validatorBitset{}
+
+	// the inclusion windows of epoch 1 and 2 are both closed at slot 16
+	event, err := publisher.getSlotDuties(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(event.MissedAttestations) != 2 || !slices.Equal(event.MissedAttestations[4], []uint64{1}) || !slices.Equal(event.MissedAttestations[9], []uint64{1}) {
+		t.Errorf("expected the missed attestations of both epochs to be reported, got %v", event.MissedAttestations)
+	}
+	if _, ok := publisher.includedAttestations[1]; ok {
+		t.Errorf("expected epoch 1 to not be tracked anymore")
+	}
+}
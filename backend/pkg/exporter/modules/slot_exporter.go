@@ -612,19 +612,21 @@ func ExportSlot(client rpc.Client, slot uint64, isHeadEpoch bool, tx *sqlx.Tx) e
 						uvdv.dashboard_id,
 						uvdv.group_id,
 						bd.block_slot,
+						bd.deposit_type,
 						bd.block_index,
 						bd.amount
 					FROM
-						blocks_deposits bd
+						blocks_consensus_deposits bd
 						INNER JOIN validators v ON bd.publickey = v.pubkey
 						INNER JOIN users_val_dashboards_validators uvdv ON v.validatorindex = uvdv.validator_index
 					ORDER BY
 						uvdv.dashboard_id DESC,
 						bd.block_slot DESC,
+						bd.deposit_type DESC,
 						bd.block_index DESC;
 					
 					`, "cached_blocks_deposits_lookup",
-					[]string{"dashboard_id", "block_slot", "block_index"},
+					[]string{"dashboard_id", "block_slot", "deposit_type", "block_index"},
 					[]string{"dashboard_id", "amount"})
 				if err != nil {
 					return fmt.Errorf("error updating cached view of consensus deposits: %w", err)
@@ -689,7 +691,58 @@ func (d *slotExporterData) GetName() string {
 }
 
 func (d *slotExporterData) OnChainReorg(event *constypes.StandardEventChainReorg) (err error) {
-	return nil // nop
+	processSlotMutex.Lock() // don't interfere with the head export
+	defer processSlotMutex.Unlock()
+
+	var result *reorgResult
+	defer func() {
+		reorgResults.publish(event, result)
+	}()
+
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting tx: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	store := &slotReorgStore{client: d.Client, tx: tx, headEpoch: utils.EpochOfSlot(event.Slot)}
+	reconciled, err := reconcileReorg(context.Background(), d.CL, store, event)
+	if err != nil {
+		return fmt.Errorf("error reconciling reorg at slot %v: %w", event.Slot, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing tx: %w", err)
+	}
+	result = reconciled
+
+	err = store.deleteOrphanedProposals()
+	if err != nil {
+		return err
+	}
+
+	if len(result.Orphaned) == 0 {
+		return nil
+	}
+	log.Infof("reorg at slot %v orphaned %v blocks and exported %v slots", event.Slot, len(result.Orphaned), len(result.Exported))
+
+	// the latest proposed slot only ever moves forward on head, reset it if its block has been orphaned
+	for _, block := range result.Orphaned {
+		if block.Slot != cache.LatestProposedSlot.Get() {
+			continue
+		}
+		latest, err := db.GetLatestProposedSlot()
+		if err != nil {
+			return err
+		}
+		err = cache.LatestProposedSlot.Set(latest)
+		if err != nil {
+			log.Error(err, "error setting latestProposedSlot in cache", 0)
+		}
+		break
+	}
+	return nil
 }
 
 func (d *slotExporterData) OnFinalizedCheckpoint(event *constypes.StandardFinalizedCheckpointResponse) (err error) {
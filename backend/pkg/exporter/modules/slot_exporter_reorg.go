@@ -0,0 +1,248 @@
+package modules
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/rpc"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gobitfly/beaconchain/pkg/consapi"
+	"github.com/gobitfly/beaconchain/pkg/consapi/network"
+	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
+	"github.com/jmoiron/sqlx"
+)
+
+// reorgStore is the storage of the exported blocks the reorg reconciliation works on
+type reorgStore interface {
+	// GetSlots returns the blocks of all non finalized slots starting at slot, ordered by slot
+	GetSlots(slot uint64) ([]*db.GetAllNonFinalizedSlotsRow, error)
+	// SetOrphaned marks a single block of a slot as orphaned
+	SetOrphaned(slot uint64, blockRoot []byte) error
+	// SetCanonical marks an orphaned block as proposed again, it is exported again afterwards
+	SetCanonical(slot uint64, blockRoot []byte) error
+	// ExportSlot exports the block the node currently has for the slot, or a missed slot if it has none
+	ExportSlot(slot uint64) error
+}
+
+type orphanedBlock struct {
+	Slot      uint64
+	BlockRoot []byte
+}
+
+type reorgResult struct {
+	Orphaned []orphanedBlock
+	// slots that have been exported again after their blocks have been orphaned or restored, or a late block appeared
+	Exported []uint64
+}
+
+// reconcileReorg walks back the slots affected by a chain reorg and compares the exported blocks with the block roots of
+// the node. Blocks that are no longer part of the canonical chain are marked as orphaned and the canonical blocks are
+// exported in their place. A block that has been orphaned by an earlier reorg becomes canonical again if the chain flips
+// back to it. Slots after the reorg that aren't exported yet are left to the head export.
+func reconcileReorg(ctx context.Context, cl consapi.Client, store reorgStore, event *constypes.StandardEventChainReorg) (*reorgResult, error) {
+	from := uint64(0)
+	if event.Slot > event.Depth {
+		from = event.Slot - event.Depth
+	}
+	rows, err := store.GetSlots(from)
+	if err != nil {
+		return nil, err
+	}
+
+	bySlot := make(map[uint64][]*db.GetAllNonFinalizedSlotsRow)
+	slots := make([]uint64, 0, len(rows))
+	for _, row := range rows {
+		if len(bySlot[row.Slot]) == 0 {
+			slots = append(slots, row.Slot)
+		}
+		bySlot[row.Slot] = append(bySlot[row.Slot], row)
+	}
+
+	result := &reorgResult{}
+	for _, slot := range slots {
+		canonicalRoot, err := getCanonicalBlockRoot(ctx, cl, slot)
+		if err != nil {
+			return nil, err
+		}
+
+		hasCanonical := false
+		hasMissed := false
+		for _, row := range bySlot[slot] {
+			if len(row.BlockRoot) < 32 {
+				hasMissed = true
+				continue
+			}
+			if canonicalRoot != nil && bytes.Equal(row.BlockRoot, canonicalRoot) {
+				if row.Status != "3" {
+					hasCanonical = true
+					continue
+				}
+				log.Infof("setting orphaned block %x of slot %v as canonical again (reorg at slot %v with depth %v)", row.BlockRoot, slot, event.Slot, event.Depth)
+				err := store.SetCanonical(slot, row.BlockRoot)
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if row.Status != "1" {
+				continue
+			}
+			log.Infof("setting block %x of slot %v as orphaned (reorg at slot %v with depth %v)", row.BlockRoot, slot, event.Slot, event.Depth)
+			err := store.SetOrphaned(slot, row.BlockRoot)
+			if err != nil {
+				return nil, err
+			}
+			result.Orphaned = append(result.Orphaned, orphanedBlock{Slot: slot, BlockRoot: row.BlockRoot})
+		}
+
+		if (canonicalRoot != nil && !hasCanonical) || (canonicalRoot == nil && !hasMissed) {
+			err := store.ExportSlot(slot)
+			if err != nil {
+				return nil, err
+			}
+			result.Exported = append(result.Exported, slot)
+		}
+	}
+	return result, nil
+}
+
+// getCanonicalBlockRoot returns the root of the block the node has for the slot, nil if the slot has been missed
+func getCanonicalBlockRoot(ctx context.Context, cl consapi.Client, slot uint64) ([]byte, error) {
+	header, err := cl.GetBlockHeader(ctx, slot)
+	if err != nil {
+		httpErr := network.SpecificError(err)
+		if httpErr != nil && httpErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error retrieving block header of slot %v: %w", slot, err)
+	}
+	return header.Data.Root, nil
+}
+
+// slotReorgStore reconciles reorgs in the blocks table within a single tx. The proposals of orphaned blocks are only
+// deleted from bigtable by deleteOrphanedProposals once the tx has been committed.
+type slotReorgStore struct {
+	client    rpc.Client
+	tx        *sqlx.Tx
+	headEpoch uint64
+	// proposer by slot of the blocks that have been orphaned within the tx
+	orphanedProposals map[uint64]uint64
+}
+
+func (s *slotReorgStore) GetSlots(slot uint64) ([]*db.GetAllNonFinalizedSlotsRow, error) {
+	return db.GetNonFinalizedSlotsSince(slot, s.tx)
+}
+
+func (s *slotReorgStore) SetOrphaned(slot uint64, blockRoot []byte) error {
+	proposer, err := db.SetBlockOrphaned(slot, blockRoot, s.tx)
+	if err != nil {
+		return err
+	}
+	if s.orphanedProposals == nil {
+		s.orphanedProposals = make(map[uint64]uint64)
+	}
+	s.orphanedProposals[slot] = proposer
+	return nil
+}
+
+// deleteOrphanedProposals deletes the proposals of the orphaned blocks from bigtable, it has to be called after the tx
+// has been committed. Proposals are kept if the proposer got a canonical block of the slot again, e.g. because the
+// reorg exported a block of the same proposer, as they share the cell in bigtable.
+func (s *slotReorgStore) deleteOrphanedProposals() error {
+	for slot, proposer := range s.orphanedProposals {
+		proposed, err := db.HasCanonicalProposal(slot, proposer)
+		if err != nil {
+			return err
+		}
+		if proposed {
+			continue
+		}
+		err = db.BigtableClient.DeleteProposal(slot, proposer)
+		if err != nil {
+			return fmt.Errorf("error deleting orphaned proposal of slot %v from bigtable: %w", slot, err)
+		}
+	}
+	return nil
+}
+
+func (s *slotReorgStore) SetCanonical(slot uint64, blockRoot []byte) error {
+	// the proposal in bigtable is written again by the export
+	return db.SetBlockCanonical(slot, blockRoot, s.tx)
+}
+
+func (s *slotReorgStore) ExportSlot(slot uint64) error {
+	err := ExportSlot(s.client, slot, utils.EpochOfSlot(slot) == s.headEpoch, s.tx)
+	if err != nil {
+		return fmt.Errorf("error exporting slot %v: %w", slot, err)
+	}
+	return nil
+}
+
+// reorgResults passes the reconciled reorgs from the slot exporter to the modules that depend on the exported blocks.
+// All modules are notified about a reorg concurrently, so they have to wait until the slot exporter is done.
+var reorgResults = &reorgBroadcast{results: make(map[string]*pendingReorgResult)}
+
+// reorgResultsRetention is the number of slots a reorg result is kept, results nobody waits for (duplicates, results
+// published after the waiter timed out or without any waiting module) are dropped once a later reorg is published
+const reorgResultsRetention = 64
+
+type reorgBroadcast struct {
+	mu      sync.Mutex
+	results map[string]*pendingReorgResult
+}
+
+type pendingReorgResult struct {
+	slot uint64
+	ch   chan *reorgResult
+}
+
+func reorgKey(event *constypes.StandardEventChainReorg) string {
+	return fmt.Sprintf("%d:%x", event.Slot, event.NewHeadBlock)
+}
+
+func (b *reorgBroadcast) channel(event *constypes.StandardEventChainReorg) chan *reorgResult {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for key, pending := range b.results {
+		if pending.slot+reorgResultsRetention < event.Slot {
+			delete(b.results, key)
+		}
+	}
+	pending, ok := b.results[reorgKey(event)]
+	if !ok {
+		pending = &pendingReorgResult{slot: event.Slot, ch: make(chan *reorgResult, 1)}
+		b.results[reorgKey(event)] = pending
+	}
+	return pending.ch
+}
+
+// publish hands the result of a reorg to its waiter, result is nil if the reconciliation failed
+func (b *reorgBroadcast) publish(event *constypes.StandardEventChainReorg, result *reorgResult) {
+	select {
+	case b.channel(event) <- result:
+	default:
+		// the same reorg has been published already, e.g. because several nodes reported it
+	}
+}
+
+// wait returns the result of a reorg once it has been published, only a single module may wait for each reorg
+func (b *reorgBroadcast) wait(event *constypes.StandardEventChainReorg, timeout time.Duration) (*reorgResult, bool) {
+	ch := b.channel(event)
+	defer func() {
+		b.mu.Lock()
+		delete(b.results, reorgKey(event))
+		b.mu.Unlock()
+	}()
+	select {
+	case result := <-ch:
+		return result, true
+	case <-time.After(timeout):
+		return nil, false
+	}
+}
@@ -0,0 +1,267 @@
+package modules
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/consapi"
+	"github.com/gobitfly/beaconchain/pkg/consapi/network"
+	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
+)
+
+// reorgSequence is a recorded sequence of node events, see testdata/reorgs
+type reorgSequence struct {
+	Description string `json:"description"`
+	// blocks of the database before the first step, a block without root is a missed slot
+	Exported []struct {
+		Slot      uint64        `json:"slot"`
+		BlockRoot hexutil.Bytes `json:"block_root"`
+	} `json:"exported"`
+	Steps []struct {
+		// canonical chain of the node while the events of the step are received, slot -> block root, missed slots are left out
+		Node   map[uint64]hexutil.Bytes `json:"node"`
+		Events []struct {
+			Event constypes.EventTopic `json:"event"`
+			Data  json.RawMessage      `json:"data"`
+		} `json:"events"`
+		// slots with orphaned and exported blocks after all events of the step
+		Orphaned []uint64 `json:"orphaned"`
+		Exported []uint64 `json:"exported"`
+	} `json:"steps"`
+}
+
+// fakeNode serves the block headers of its chain and the recorded events, other calls panic
+type fakeNode struct {
+	consapi.ClientInt
+	chain  map[uint64]hexutil.Bytes
+	events []*constypes.EventResponse
+}
+
+func (n *fakeNode) GetBlockHeader(ctx context.Context, blockID any) (*constypes.StandardBeaconHeaderResponse, error) {
+	slot, ok := blockID.(uint64)
+	if !ok {
+		return nil, fmt.Errorf("unsupported block id %v", blockID)
+	}
+	root := n.chain[slot]
+	if root == nil {
+		return nil, &network.HttpReqHttpError{StatusCode: http.StatusNotFound, Url: fmt.Sprintf("/eth/v1/beacon/headers/%d", slot)}
+	}
+	header := &constypes.StandardBeaconHeaderResponse{}
+	header.Data.Root = root
+	header.Data.Header.Message.Slot = slot
+	return header, nil
+}
+
+func (n *fakeNode) GetEvents(ctx context.Context, topics []constypes.EventTopic) chan *constypes.EventResponse {
+	ch := make(chan *constypes.EventResponse, len(n.events))
+	for _, event := range n.events {
+		if slices.Contains(topics, event.Event) {
+			ch <- event
+		}
+	}
+	close(ch)
+	return ch
+}
+
+// fakeReorgStore behaves like the blocks table, exports insert the block the node has unless it exists already
+type fakeReorgStore struct {
+	node *fakeNode
+	rows []*db.GetAllNonFinalizedSlotsRow
+}
+
+func (s *fakeReorgStore) GetSlots(slot uint64) ([]*db.GetAllNonFinalizedSlotsRow, error) {
+	var rows []*db.GetAllNonFinalizedSlotsRow
+	for _, row := range s.rows {
+		if row.Slot >= slot {
+			rows = append(rows, row)
+		}
+	}
+	slices.SortStableFunc(rows, func(a, b *db.GetAllNonFinalizedSlotsRow) int {
+		return int(a.Slot) - int(b.Slot)
+	})
+	return rows, nil
+}
+
+func (s *fakeReorgStore) find(slot uint64, blockRoot []byte) *db.GetAllNonFinalizedSlotsRow {
+	for _, row := range s.rows {
+		if row.Slot == slot && bytes.Equal(row.BlockRoot, blockRoot) {
+			return row
+		}
+	}
+	return nil
+}
+
+func (s *fakeReorgStore) SetOrphaned(slot uint64, blockRoot []byte) error {
+	row := s.find(slot, blockRoot)
+	if row == nil {
+		return fmt.Errorf("block %x of slot %v doesn't exist", blockRoot, slot)
+	}
+	row.Status = "3"
+	return nil
+}
+
+func (s *fakeReorgStore) SetCanonical(slot uint64, blockRoot []byte) error {
+	row := s.find(slot, blockRoot)
+	if row == nil {
+		return fmt.Errorf("block %x of slot %v doesn't exist", blockRoot, slot)
+	}
+	row.Status = "1"
+	return nil
+}
+
+func (s *fakeReorgStore) ExportSlot(slot uint64) error {
+	s.insert(slot, s.node.chain[slot])
+	return nil
+}
+
+func (s *fakeReorgStore) insert(slot uint64, blockRoot []byte) {
+	row := &db.GetAllNonFinalizedSlotsRow{Slot: slot, BlockRoot: blockRoot, Status: "1"}
+	if blockRoot == nil {
+		// missed slots are exported with a dummy block root
+		row.BlockRoot = []byte{0x0}
+		row.Status = "2"
+	}
+	if s.find(slot, row.BlockRoot) == nil {
+		s.rows = append(s.rows, row)
+	}
+}
+
+// replayReorgs replays the events of the sequence against the fake node and checks the reconciled blocks after each step
+func replayReorgs(t *testing.T, sequence *reorgSequence) {
+	node := &fakeNode{}
+	cl := consapi.Client{ClientInt: node}
+	store := &fakeReorgStore{node: node}
+	for _, block := range sequence.Exported {
+		store.insert(block.Slot, block.BlockRoot)
+	}
+
+	for i, step := range sequence.Steps {
+		node.chain = step.Node
+		node.events = nil
+		for _, event := range step.Events {
+			node.events = append(node.events, &constypes.EventResponse{Event: event.Event, Data: event.Data})
+		}
+
+		orphaned := []uint64{}
+		exported := []uint64{}
+		for event := range cl.GetEvents(context.Background(), []constypes.EventTopic{constypes.EventChainReorg}) {
+			reorg, err := event.ChainReorg()
+			if err != nil {
+				t.Fatalf("step %d: unexpected error: %v", i, err)
+			}
+			result, err := reconcileReorg(context.Background(), cl, store, reorg)
+			if err != nil {
+				t.Fatalf("step %d: unexpected error: %v", i, err)
+			}
+			for _, block := range result.Orphaned {
+				orphaned = append(orphaned, block.Slot)
+			}
+			exported = append(exported, result.Exported...)
+		}
+
+		if !slices.Equal(orphaned, step.Orphaned) {
+			t.Errorf("step %d: expected orphaned slots %v, got %v", i, step.Orphaned, orphaned)
+		}
+		if !slices.Equal(exported, step.Exported) {
+			t.Errorf("step %d: expected exported slots %v, got %v", i, step.Exported, exported)
+		}
+
+		// every exported slot must have the canonical block of the node as its only proposed block
+		for _, row := range store.rows {
+			if len(row.BlockRoot) < 32 {
+				continue
+			}
+			canonical := bytes.Equal(row.BlockRoot, node.chain[row.Slot])
+			if canonical != (row.Status == "1") || (!canonical && row.Status != "3") {
+				t.Errorf("step %d: block %x of slot %d has status %s", i, row.BlockRoot, row.Slot, row.Status)
+			}
+		}
+		for slot, root := range node.chain {
+			if slices.ContainsFunc(store.rows, func(row *db.GetAllNonFinalizedSlotsRow) bool { return row.Slot == slot }) && store.find(slot, root) == nil {
+				t.Errorf("step %d: canonical block of slot %d hasn't been exported", i, slot)
+			}
+		}
+	}
+}
+
+func TestReorgReplay(t *testing.T) {
+	files, err := filepath.Glob("testdata/reorgs/*.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("no recorded reorg sequences found")
+	}
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			sequence := &reorgSequence{}
+			err = json.Unmarshal(data, sequence)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			replayReorgs(t, sequence)
+		})
+	}
+}
+
+func TestReorgResults(t *testing.T) {
+	event := &constypes.StandardEventChainReorg{Slot: 10, NewHeadBlock: []byte{1}}
+	results := &reorgBroadcast{results: make(map[string]*pendingReorgResult)}
+
+	// a result that is published before the module waits for it is kept, duplicates are dropped
+	results.publish(event, &reorgResult{Exported: []uint64{10}})
+	results.publish(event, &reorgResult{})
+	result, ok := results.wait(event, time.Second)
+	if !ok || len(result.Exported) != 1 {
+		t.Errorf("expected the first published result, got %+v", result)
+	}
+
+	_, ok = results.wait(&constypes.StandardEventChainReorg{Slot: 11}, 10*time.Millisecond)
+	if ok {
+		t.Errorf("expected a timeout for a reorg that is never published")
+	}
+	if len(results.results) != 0 {
+		t.Errorf("expected no pending results, got %d", len(results.results))
+	}
+}
+
+func TestReorgResultsAreDropped(t *testing.T) {
+	results := &reorgBroadcast{results: make(map[string]*pendingReorgResult)}
+
+	// results of a reorg nobody waits for and duplicates published after the waiter got its result
+	results.publish(&constypes.StandardEventChainReorg{Slot: 10, NewHeadBlock: []byte{1}}, &reorgResult{})
+	event := &constypes.StandardEventChainReorg{Slot: 20, NewHeadBlock: []byte{2}}
+	results.publish(event, &reorgResult{})
+	if _, ok := results.wait(event, time.Second); !ok {
+		t.Fatalf("expected the published result")
+	}
+	results.publish(event, &reorgResult{})
+	if len(results.results) != 2 {
+		t.Fatalf("expected the unclaimed results to be kept for a while, got %d", len(results.results))
+	}
+
+	// they are dropped once they are older than the retention
+	results.publish(&constypes.StandardEventChainReorg{Slot: 20 + reorgResultsRetention, NewHeadBlock: []byte{3}}, &reorgResult{})
+	if _, ok := results.results[reorgKey(event)]; !ok {
+		t.Errorf("expected the result of slot 20 to be kept within the retention")
+	}
+	results.publish(&constypes.StandardEventChainReorg{Slot: 21 + reorgResultsRetention, NewHeadBlock: []byte{4}}, &reorgResult{})
+	if len(results.results) != 2 {
+		t.Errorf("expected only the results of the last two reorgs to be kept, got %d", len(results.results))
+	}
+}
@@ -3,7 +3,6 @@ package modules
 import (
 	"encoding/hex"
 	"encoding/json"
-	"fmt"
 	"strings"
 	"time"
 
@@ -101,44 +100,13 @@ func saveSSV(res *SSVExporterResponse) error {
 	}
 	defer utils.Rollback(tx)
 
-	// for now make sure to correct wrongly marked validators
-	for {
-		res, err := tx.Exec(`delete from validator_tags where publickey in (select publickey from validator_tags where tag = 'ssv' limit 1000)`)
-		if err != nil {
-			return err
-		}
-		rows, err := res.RowsAffected()
-		if err != nil {
-			return err
-		}
-		if rows == 0 {
-			break
-		}
-		time.Sleep(time.Millisecond * 100)
+	pubkeys, err := ssvPubkeys(res)
+	if err != nil {
+		return err
 	}
-
-	batchSize := 5000
-	for b := 0; b < len(res.Data); b += batchSize {
-		start := b
-		end := b + batchSize
-		if len(res.Data) < end {
-			end = len(res.Data)
-		}
-		n := 1
-		valueStrings := make([]string, 0, batchSize)
-		valueArgs := make([]interface{}, 0, batchSize*n)
-		for i, d := range res.Data[start:end] {
-			valueStrings = append(valueStrings, fmt.Sprintf("($%d, 'ssv')", i*n+1))
-			pubkey, err := hex.DecodeString(strings.Replace(d.Publickey, "0x", "", -1))
-			if err != nil {
-				return err
-			}
-			valueArgs = append(valueArgs, pubkey)
-		}
-		_, err := tx.Exec(fmt.Sprintf(`insert into validator_tags (publickey, tag) values %s on conflict (publickey, tag) do nothing`, strings.Join(valueStrings, ",")), valueArgs...)
-		if err != nil {
-			return err
-		}
+	err = saveStakingProtocolValidators(tx, "ssv", pubkeys)
+	if err != nil {
+		return err
 	}
 
 	// currently the ssv-exporter also exports publickeys that are not actually part of the network
@@ -164,3 +132,16 @@ func saveSSV(res *SSVExporterResponse) error {
 
 	return nil
 }
+
+// ssvPubkeys decodes the publickeys of the validators that are registered with ssv
+func ssvPubkeys(res *SSVExporterResponse) ([][]byte, error) {
+	pubkeys := make([][]byte, 0, len(res.Data))
+	for _, d := range res.Data {
+		pubkey, err := hex.DecodeString(strings.Replace(d.Publickey, "0x", "", -1))
+		if err != nil {
+			return nil, err
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+}
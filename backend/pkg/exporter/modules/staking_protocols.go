@@ -0,0 +1,51 @@
+package modules
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// saveStakingProtocolValidators replaces the validators that are tagged with the protocol (ssv, lido_csm, ...).
+// The validator dashboard applies the operator share that is configured for the dashboard to the tagged validators
+// if the protocol mode is enabled.
+func saveStakingProtocolValidators(tx *sqlx.Tx, protocol string, pubkeys [][]byte) error {
+	// for now make sure to correct wrongly marked validators
+	for {
+		res, err := tx.Exec(`delete from validator_tags where tag = $1 and publickey in (select publickey from validator_tags where tag = $1 limit 1000)`, protocol)
+		if err != nil {
+			return err
+		}
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			break
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+
+	batchSize := 5000
+	for b := 0; b < len(pubkeys); b += batchSize {
+		start := b
+		end := b + batchSize
+		if len(pubkeys) < end {
+			end = len(pubkeys)
+		}
+		valueStrings := make([]string, 0, batchSize)
+		valueArgs := make([]interface{}, 0, batchSize*2)
+		for i, pubkey := range pubkeys[start:end] {
+			valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d)", i*2+1, i*2+2))
+			valueArgs = append(valueArgs, pubkey, protocol)
+		}
+		_, err := tx.Exec(fmt.Sprintf(`insert into validator_tags (publickey, tag) values %s on conflict (publickey, tag) do nothing`, strings.Join(valueStrings, ",")), valueArgs...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
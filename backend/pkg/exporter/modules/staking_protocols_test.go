@@ -0,0 +1,52 @@
+package modules
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+)
+
+func TestSSVPubkeys(t *testing.T) {
+	res := &SSVExporterResponse{}
+	err := json.Unmarshal([]byte(`{"type":"validator","data":[
+		{"index":1,"publicKey":"0xaa01","operators":[{"nodeId":1},{"nodeId":2},{"nodeId":3},{"nodeId":4}]},
+		{"index":2,"publicKey":"bb02","operators":[]}
+	]}`), res)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pubkeys, err := ssvPubkeys(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pubkeys) != 2 || hex.EncodeToString(pubkeys[0]) != "aa01" || hex.EncodeToString(pubkeys[1]) != "bb02" {
+		t.Errorf("unexpected pubkeys %x", pubkeys)
+	}
+
+	res.Data[1].Publickey = "0xnothex"
+	if _, err := ssvPubkeys(res); err == nil {
+		t.Errorf("expected an error for an invalid publickey")
+	}
+}
+
+func TestLidoCsmPubkeys(t *testing.T) {
+	keys := &LidoKeysApiKeysResponse{}
+	err := json.Unmarshal([]byte(`{"data":{"keys":[
+		{"key":"0xaa01","operatorIndex":1,"used":true},
+		{"key":"0xbb02","operatorIndex":1,"used":false},
+		{"key":"0xcc03","operatorIndex":2,"used":true}
+	]}}`), keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// keys that have not been deposited yet are not tagged
+	pubkeys, err := lidoCsmPubkeys(keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pubkeys) != 2 || hex.EncodeToString(pubkeys[0]) != "aa01" || hex.EncodeToString(pubkeys[1]) != "cc03" {
+		t.Errorf("unexpected pubkeys %x", pubkeys)
+	}
+}
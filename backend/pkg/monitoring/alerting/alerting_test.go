@@ -0,0 +1,121 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gobitfly/beaconchain/pkg/monitoring/constants"
+)
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2024, 12, 1, 12, 0, 0, 0, time.UTC)
+	rules := Rules{ExpectedServices: []string{"ch_rolling_1h", "ch_dashboard_epoch", "ch_rolling_total"}, MaxRollingLagEpochs: 10}
+	statuses := []ServiceStatus{
+		// healthy
+		{EventId: "ch_dashboard_epoch", Emitter: "a", Status: constants.Success, InsertedAt: now.Add(-10 * time.Second), TimeoutsAt: now.Add(50 * time.Second), ExpiresAt: now.Add(2 * time.Minute)},
+		// lagging rolling table that still reports
+		{EventId: "ch_rolling_1h", Emitter: "a", Status: constants.Failure, InsertedAt: now.Add(-10 * time.Second), TimeoutsAt: now.Add(50 * time.Second), ExpiresAt: now.Add(2 * time.Minute), Metadata: map[string]string{constants.LagEpochsMetadataKey: "11"}},
+		{EventId: "ch_rolling_1h", Emitter: "b", Status: constants.Success, InsertedAt: now, TimeoutsAt: now.Add(time.Minute), ExpiresAt: now.Add(2 * time.Minute)},
+		// rolling table within the threshold
+		{EventId: "ch_rolling_24h", Emitter: "a", Status: constants.Success, InsertedAt: now.Add(-10 * time.Second), TimeoutsAt: now.Add(50 * time.Second), ExpiresAt: now.Add(2 * time.Minute), Metadata: map[string]string{constants.LagEpochsMetadataKey: "10"}},
+		// stuck run
+		{EventId: "monitoring_timeouts", Emitter: "a", Status: constants.Running, InsertedAt: now.Add(-90 * time.Second), TimeoutsAt: now.Add(-30 * time.Second), ExpiresAt: now.Add(30 * time.Second)},
+		// stopped reporting, the stuck run is covered by the service being down
+		{EventId: "api_service_slot_viz", Emitter: "b", Status: constants.Running, InsertedAt: now.Add(-time.Hour), TimeoutsAt: now.Add(-59 * time.Minute), ExpiresAt: now.Add(-58 * time.Minute)},
+	}
+
+	alerts := rules.Evaluate(statuses, now)
+	expected := map[string]bool{
+		"rolling_lag/ch_rolling_1h/a":            true,
+		"running_too_long/monitoring_timeouts/a": true,
+		"service_down/api_service_slot_viz/b":    true,
+		"service_down/ch_rolling_total/":         true,
+	}
+	if len(alerts) != len(expected) {
+		t.Errorf("expected %d alerts, got %d: %+v", len(expected), len(alerts), alerts)
+	}
+	for _, alert := range alerts {
+		if !expected[alert.Fingerprint()] {
+			t.Errorf("unexpected alert %s", alert.Fingerprint())
+		}
+	}
+}
+
+func TestTrackerUpdate(t *testing.T) {
+	now := time.Date(2024, 12, 1, 12, 0, 0, 0, time.UTC)
+	tracker := Tracker{RepeatInterval: 10 * time.Minute}
+	down := Alert{Rule: ServiceDownRule, Service: "ch_dashboard_epoch", StartsAt: now}
+	lag := Alert{Rule: RollingLagRule, Service: "ch_rolling_1h", Emitter: "a", StartsAt: now}
+
+	routed := tracker.Update([]Alert{down}, now)
+	if len(routed) != 1 || routed[0].Resolved {
+		t.Fatalf("expected the new alert to be routed, got %+v", routed)
+	}
+	if !routed[0].EndsAt.Equal(now.Add(20 * time.Minute)) {
+		t.Errorf("expected firing alert to end after two repeat intervals, got %v", routed[0].EndsAt)
+	}
+
+	// still firing, not due for a repeat
+	later := down
+	later.StartsAt = now.Add(time.Minute)
+	routed = tracker.Update([]Alert{later, lag}, now.Add(time.Minute))
+	if len(routed) != 1 || routed[0].Fingerprint() != lag.Fingerprint() {
+		t.Fatalf("expected only the new alert to be routed, got %+v", routed)
+	}
+
+	// repeat keeps the original start
+	routed = tracker.Update([]Alert{later, lag}, now.Add(10*time.Minute))
+	if len(routed) != 1 || routed[0].Fingerprint() != down.Fingerprint() || !routed[0].StartsAt.Equal(now) {
+		t.Fatalf("expected the repeat of the first alert, got %+v", routed)
+	}
+
+	// both stop firing
+	routed = tracker.Update(nil, now.Add(12*time.Minute))
+	if len(routed) != 2 {
+		t.Fatalf("expected two resolved alerts, got %+v", routed)
+	}
+	for _, alert := range routed {
+		if !alert.Resolved || !alert.EndsAt.Equal(now.Add(12*time.Minute)) {
+			t.Errorf("expected resolved alert, got %+v", alert)
+		}
+	}
+	if routed = tracker.Update(nil, now.Add(13*time.Minute)); len(routed) != 0 {
+		t.Errorf("expected nothing to route, got %+v", routed)
+	}
+}
+
+func TestAlertmanagerRoute(t *testing.T) {
+	utils.Config = &types.Config{DeploymentType: "staging"}
+	var received []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/alerts" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("error decoding alerts: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	route := &AlertmanagerRoute{Url: server.URL + "/", Client: server.Client()}
+	err := route.Send(context.Background(), []Alert{{Rule: ServiceDownRule, Service: "ch_rolling_1h", Summary: "down"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(received) != 1 {
+		t.Fatalf("expected one alert, got %+v", received)
+	}
+	labels, _ := received[0]["labels"].(map[string]any)
+	if labels["alertname"] != "service_down" || labels["service"] != "ch_rolling_1h" || labels["deployment_type"] != "staging" {
+		t.Errorf("unexpected labels %+v", labels)
+	}
+	if _, ok := labels["emitter"]; ok {
+		t.Errorf("expected no emitter label for services that never reported")
+	}
+}
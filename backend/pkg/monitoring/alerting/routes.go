@@ -0,0 +1,157 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+const pagerDutyEventsUrl = "https://events.pagerduty.com/v2/enqueue"
+
+// Route delivers alerts to an external system
+type Route interface {
+	Name() string
+	Send(ctx context.Context, alerts []Alert) error
+}
+
+func postJson(ctx context.Context, client *http.Client, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+	}
+	return nil
+}
+
+// AlertmanagerRoute pushes alerts to the v2 api of a prometheus alertmanager
+type AlertmanagerRoute struct {
+	Url    string
+	Client *http.Client
+}
+
+func (r *AlertmanagerRoute) Name() string {
+	return "alertmanager"
+}
+
+func (r *AlertmanagerRoute) Send(ctx context.Context, alerts []Alert) error {
+	type alertmanagerAlert struct {
+		Labels      map[string]string `json:"labels"`
+		Annotations map[string]string `json:"annotations"`
+		StartsAt    time.Time         `json:"startsAt"`
+		EndsAt      time.Time         `json:"endsAt"`
+	}
+	payload := make([]alertmanagerAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		labels := map[string]string{
+			"alertname":       string(alert.Rule),
+			"service":         alert.Service,
+			"deployment_type": utils.Config.DeploymentType,
+		}
+		if alert.Emitter != "" {
+			labels["emitter"] = alert.Emitter
+		}
+		payload = append(payload, alertmanagerAlert{
+			Labels:      labels,
+			Annotations: map[string]string{"summary": alert.Summary},
+			StartsAt:    alert.StartsAt,
+			// alertmanager resolves alerts once they end, firing alerts end unless they are repeated in time
+			EndsAt: alert.EndsAt,
+		})
+	}
+	return postJson(ctx, r.Client, strings.TrimSuffix(r.Url, "/")+"/api/v2/alerts", payload)
+}
+
+// PagerDutyRoute triggers and resolves incidents via the pagerduty events api, repeats are deduplicated by pagerduty
+type PagerDutyRoute struct {
+	RoutingKey string
+	Client     *http.Client
+}
+
+func (r *PagerDutyRoute) Name() string {
+	return "pagerduty"
+}
+
+func (r *PagerDutyRoute) Send(ctx context.Context, alerts []Alert) error {
+	type pagerDutyPayload struct {
+		Summary   string    `json:"summary"`
+		Source    string    `json:"source"`
+		Severity  string    `json:"severity"`
+		Timestamp time.Time `json:"timestamp"`
+		Component string    `json:"component"`
+		Class     string    `json:"class"`
+	}
+	type pagerDutyEvent struct {
+		RoutingKey  string            `json:"routing_key"`
+		EventAction string            `json:"event_action"`
+		DedupKey    string            `json:"dedup_key"`
+		Payload     *pagerDutyPayload `json:"payload,omitempty"`
+	}
+	var errs []error
+	for _, alert := range alerts {
+		event := pagerDutyEvent{
+			RoutingKey:  r.RoutingKey,
+			EventAction: "trigger",
+			DedupKey:    fmt.Sprintf("%s/%s", utils.Config.DeploymentType, alert.Fingerprint()),
+		}
+		if alert.Resolved {
+			event.EventAction = "resolve"
+		} else {
+			source := alert.Emitter
+			if source == "" {
+				source = utils.Config.DeploymentType
+			}
+			event.Payload = &pagerDutyPayload{
+				Summary:   alert.Summary,
+				Source:    source,
+				Severity:  "critical",
+				Timestamp: alert.StartsAt,
+				Component: alert.Service,
+				Class:     string(alert.Rule),
+			}
+		}
+		if err := postJson(ctx, r.Client, pagerDutyEventsUrl, event); err != nil {
+			errs = append(errs, fmt.Errorf("error sending %s: %w", alert.Fingerprint(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WebhookRoute posts the alerts as json to a generic webhook
+type WebhookRoute struct {
+	Url    string
+	Client *http.Client
+}
+
+func (r *WebhookRoute) Name() string {
+	return "webhook"
+}
+
+func (r *WebhookRoute) Send(ctx context.Context, alerts []Alert) error {
+	payload := struct {
+		DeploymentType string  `json:"deployment_type"`
+		Alerts         []Alert `json:"alerts"`
+	}{
+		DeploymentType: utils.Config.DeploymentType,
+		Alerts:         alerts,
+	}
+	return postJson(ctx, r.Client, r.Url, payload)
+}
@@ -0,0 +1,141 @@
+package alerting
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/monitoring/constants"
+)
+
+type RuleType string
+
+const (
+	ServiceDownRule    RuleType = "service_down"     // the service stopped reporting or never reported
+	RunningTooLongRule RuleType = "running_too_long" // a run of the service exceeded its timeout
+	RollingLagRule     RuleType = "rolling_lag"      // a rolling table lags too many epochs behind the epoch table
+)
+
+const defaultMaxRollingLagEpochs = 10
+
+type Alert struct {
+	Rule     RuleType  `json:"rule"`
+	Service  string    `json:"service"`
+	Emitter  string    `json:"emitter,omitempty"` // empty if the service never reported
+	Summary  string    `json:"summary"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at,omitempty"` // resolve time of resolved alerts, otherwise the time the alert expires if it isn't repeated
+	Resolved bool      `json:"resolved"`
+}
+
+// Fingerprint identifies an alert across evaluations
+func (a *Alert) Fingerprint() string {
+	return fmt.Sprintf("%s/%s/%s", a.Rule, a.Service, a.Emitter)
+}
+
+type Rules struct {
+	ExpectedServices    []string
+	MaxRollingLagEpochs uint64
+}
+
+// Evaluate returns the alerts that fire for the latest status reports of the services at the given time
+func (r *Rules) Evaluate(statuses []ServiceStatus, now time.Time) []Alert {
+	maxLag := r.MaxRollingLagEpochs
+	if maxLag == 0 {
+		maxLag = defaultMaxRollingLagEpochs
+	}
+	var alerts []Alert
+	for _, status := range statuses {
+		switch {
+		case status.ExpiresAt.Before(now):
+			alerts = append(alerts, Alert{
+				Rule:     ServiceDownRule,
+				Service:  status.EventId,
+				Emitter:  status.Emitter,
+				Summary:  fmt.Sprintf("%s has not reported since %s", status.EventId, status.InsertedAt.UTC().Format(time.RFC3339)),
+				StartsAt: status.ExpiresAt,
+			})
+		case status.Status == constants.Running && status.TimeoutsAt.Before(now):
+			alerts = append(alerts, Alert{
+				Rule:     RunningTooLongRule,
+				Service:  status.EventId,
+				Emitter:  status.Emitter,
+				Summary:  fmt.Sprintf("%s has been running since %s", status.EventId, status.InsertedAt.UTC().Format(time.RFC3339)),
+				StartsAt: status.TimeoutsAt,
+			})
+		}
+		if lag, err := strconv.ParseUint(status.Metadata[constants.LagEpochsMetadataKey], 10, 64); err == nil && lag > maxLag {
+			alerts = append(alerts, Alert{
+				Rule:     RollingLagRule,
+				Service:  status.EventId,
+				Emitter:  status.Emitter,
+				Summary:  fmt.Sprintf("%s lags %d epochs behind, the threshold is %d epochs", status.EventId, lag, maxLag),
+				StartsAt: status.InsertedAt,
+			})
+		}
+	}
+	for _, service := range r.ExpectedServices {
+		if slices.ContainsFunc(statuses, func(s ServiceStatus) bool { return s.EventId == service }) {
+			continue
+		}
+		alerts = append(alerts, Alert{
+			Rule:     ServiceDownRule,
+			Service:  service,
+			Summary:  fmt.Sprintf("%s has not reported within the last day", service),
+			StartsAt: now,
+		})
+	}
+	return alerts
+}
+
+// Tracker keeps track of the firing alerts to only route changes and periodic repeats of firing alerts
+type Tracker struct {
+	RepeatInterval time.Duration
+	firing         map[string]*trackedAlert
+}
+
+type trackedAlert struct {
+	alert    Alert
+	lastSent time.Time
+}
+
+// Update returns the alerts that have to be routed: alerts that started firing, firing alerts that are due for a repeat and
+// alerts that stopped firing
+func (t *Tracker) Update(alerts []Alert, now time.Time) []Alert {
+	if t.firing == nil {
+		t.firing = make(map[string]*trackedAlert)
+	}
+	var result []Alert
+	seen := make(map[string]bool, len(alerts))
+	for _, alert := range alerts {
+		fingerprint := alert.Fingerprint()
+		seen[fingerprint] = true
+		tracked, ok := t.firing[fingerprint]
+		if !ok {
+			tracked = &trackedAlert{alert: alert}
+			t.firing[fingerprint] = tracked
+		} else {
+			// keep the start of the alert but pick up the latest summary
+			alert.StartsAt = tracked.alert.StartsAt
+			tracked.alert = alert
+			if now.Sub(tracked.lastSent) < t.RepeatInterval {
+				continue
+			}
+		}
+		tracked.lastSent = now
+		alert.EndsAt = now.Add(2 * t.RepeatInterval)
+		result = append(result, alert)
+	}
+	for fingerprint, tracked := range t.firing {
+		if seen[fingerprint] {
+			continue
+		}
+		delete(t.firing, fingerprint)
+		alert := tracked.alert
+		alert.Resolved = true
+		alert.EndsAt = now
+		result = append(result, alert)
+	}
+	return result
+}
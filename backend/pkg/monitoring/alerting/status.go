@@ -0,0 +1,83 @@
+package alerting
+
+import (
+	"context"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gobitfly/beaconchain/pkg/monitoring/constants"
+	"github.com/jmoiron/sqlx"
+)
+
+// ServiceStatus is a status report of a monitored service. The event id identifies the service, the emitter the process that runs it.
+type ServiceStatus struct {
+	EventId    string               `db:"event_id"`
+	Emitter    string               `db:"emitter"`
+	Status     constants.StatusType `db:"status"`
+	InsertedAt time.Time            `db:"inserted_at"`
+	ExpiresAt  time.Time            `db:"expires_at"`
+	TimeoutsAt time.Time            `db:"timeouts_at"`
+	Metadata   map[string]string    `db:"metadata"`
+}
+
+// GetServiceStatuses returns the latest status report of every service and emitter of the last day.
+// Emitters that shut down cleanly after their latest report are left out.
+func GetServiceStatuses(ctx context.Context, reader *sqlx.DB) ([]ServiceStatus, error) {
+	query := `
+		with clean_shutdowns as (
+			SELECT
+				emitter,
+				toNullable(max(inserted_at)) as inserted_at
+			FROM status_reports
+			WHERE deployment_type = ? AND event_id = ? AND inserted_at > now() - interval 1 days
+			GROUP BY emitter
+		), latest_reports as (
+			SELECT
+				event_id,
+				emitter,
+				max(inserted_at) as inserted_at,
+				argMax(expires_at, insert_id) as expires_at,
+				argMax(timeouts_at, insert_id) as timeouts_at,
+				argMax(status, insert_id) as status,
+				argMax(metadata, insert_id) as metadata
+			FROM status_reports
+			WHERE deployment_type = ? AND event_id != ? AND inserted_at > now() - interval 1 days
+			GROUP BY event_id, emitter
+		)
+		SELECT
+			latest_reports.event_id as event_id,
+			latest_reports.emitter as emitter,
+			latest_reports.status as status,
+			latest_reports.inserted_at as inserted_at,
+			latest_reports.expires_at as expires_at,
+			latest_reports.timeouts_at as timeouts_at,
+			latest_reports.metadata as metadata
+		FROM latest_reports
+		LEFT JOIN clean_shutdowns ON latest_reports.emitter = clean_shutdowns.emitter
+		WHERE clean_shutdowns.inserted_at is null OR latest_reports.inserted_at > clean_shutdowns.inserted_at
+		ORDER BY event_id ASC, emitter ASC`
+	var statuses []ServiceStatus
+	err := reader.SelectContext(ctx, &statuses, query, utils.Config.DeploymentType, constants.CleanShutdownEvent, utils.Config.DeploymentType, constants.CleanShutdownEvent)
+	return statuses, err
+}
+
+// GetServiceStatusHistory returns the latest report of the most recent runs of every service since the given time, newest first
+func GetServiceStatusHistory(ctx context.Context, reader *sqlx.DB, since time.Time, runsPerService uint64) ([]ServiceStatus, error) {
+	query := `
+		SELECT
+			event_id,
+			any(emitter) as emitter,
+			max(inserted_at) as inserted_at,
+			argMax(expires_at, insert_id) as expires_at,
+			argMax(timeouts_at, insert_id) as timeouts_at,
+			argMax(status, insert_id) as status,
+			argMax(metadata, insert_id) as metadata
+		FROM status_reports
+		WHERE deployment_type = ? AND event_id != ? AND inserted_at > ?
+		GROUP BY event_id, run_id
+		ORDER BY event_id ASC, inserted_at DESC
+		LIMIT ? BY event_id`
+	var statuses []ServiceStatus
+	err := reader.SelectContext(ctx, &statuses, query, utils.Config.DeploymentType, constants.CleanShutdownEvent, since, runsPerService)
+	return statuses, err
+}
@@ -13,3 +13,21 @@ const (
 )
 
 const CleanShutdownEvent = "clean_shutdown"
+
+// metadata key of the number of epochs a rolling table is behind the epoch table
+const LagEpochsMetadataKey = "lag_epochs"
+
+// services that must report their status, a service without any report is considered down
+var ExpectedServices = []string{
+	"ch_rolling_1h",
+	"ch_rolling_24h",
+	"ch_rolling_7d",
+	"ch_rolling_30d",
+	"ch_rolling_90d",
+	"ch_rolling_total",
+	"ch_dashboard_epoch",
+	"api_service_avg_efficiency",
+	"api_service_validator_mapping",
+	"api_service_slot_viz",
+	"monitoring_timeouts",
+}
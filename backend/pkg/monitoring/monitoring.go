@@ -45,6 +45,7 @@ func Init(full bool) {
 			&services.ServiceClickhouseEpoch{},
 			&services.ServiceTimeoutDetector{},
 			&services.CleanShutdownSpamDetector{},
+			&services.ServiceAlerting{},
 		)
 	}
 
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/gobitfly/beaconchain/pkg/monitoring/alerting"
+	"github.com/gobitfly/beaconchain/pkg/monitoring/constants"
+)
+
+const defaultAlertRepeatInterval = 1 * time.Hour
+
+// evaluate the alert rules over the status reports and route the alerts that fire
+type ServiceAlerting struct {
+	ServiceBase
+	rules   alerting.Rules
+	tracker alerting.Tracker
+	routes  []alerting.Route
+}
+
+func (s *ServiceAlerting) InitServices() {
+	s.ServiceBase.InitServices()
+	cfg := &utils.Config.Monitoring.Alerting
+	s.rules = alerting.Rules{
+		ExpectedServices:    constants.ExpectedServices,
+		MaxRollingLagEpochs: cfg.MaxRollingLagEpochs,
+	}
+	s.tracker = alerting.Tracker{RepeatInterval: cfg.RepeatInterval}
+	if s.tracker.RepeatInterval <= 0 {
+		s.tracker.RepeatInterval = defaultAlertRepeatInterval
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	s.routes = nil
+	if cfg.AlertmanagerUrl != "" {
+		s.routes = append(s.routes, &alerting.AlertmanagerRoute{Url: cfg.AlertmanagerUrl, Client: client})
+	}
+	if cfg.PagerDutyRoutingKey != "" {
+		s.routes = append(s.routes, &alerting.PagerDutyRoute{RoutingKey: cfg.PagerDutyRoutingKey, Client: client})
+	}
+	if cfg.WebhookUrl != "" {
+		s.routes = append(s.routes, &alerting.WebhookRoute{Url: cfg.WebhookUrl, Client: client})
+	}
+	if len(s.routes) == 0 {
+		log.Warnf("no alert routes configured, alerts will only be logged")
+	}
+}
+
+func (s *ServiceAlerting) Start() {
+	if !s.running.CompareAndSwap(false, true) {
+		// already running, return error
+		return
+	}
+	s.wg.Add(1)
+	go s.internalProcess()
+}
+
+func (s *ServiceAlerting) internalProcess() {
+	defer s.wg.Done()
+	s.runChecks()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(30 * time.Second):
+			s.runChecks()
+		}
+	}
+}
+
+func (s *ServiceAlerting) runChecks() {
+	id := "monitoring_alerting"
+	r := NewStatusReport(id, constants.Default, 30*time.Second)
+	r(constants.Running, nil)
+	if db.ClickHouseReader == nil {
+		r(constants.Failure, map[string]string{"error": "clickhouse reader is nil"})
+		// ignore
+		return
+	}
+	log.Tracef("evaluating alert rules")
+	// context with deadline
+	ctx, cancel := context.WithTimeout(s.ctx, 25*time.Second)
+	defer cancel()
+	statuses, err := alerting.GetServiceStatuses(ctx, db.ClickHouseReader)
+	if err != nil {
+		r(constants.Failure, map[string]string{"error": err.Error()})
+		return
+	}
+	now := time.Now()
+	alerts := s.tracker.Update(s.rules.Evaluate(statuses, now), now)
+	md := map[string]string{"routed_alerts": strconv.Itoa(len(alerts))}
+	if len(alerts) == 0 {
+		r(constants.Success, md)
+		return
+	}
+	for _, alert := range alerts {
+		log.InfoWithFields(log.Fields{"rule": alert.Rule, "service": alert.Service, "emitter": alert.Emitter, "resolved": alert.Resolved}, alert.Summary)
+	}
+	// failed deliveries of firing alerts are retried with the next repeat, resolutions are only sent once
+	var failedRoutes []string
+	for _, route := range s.routes {
+		if err := route.Send(ctx, alerts); err != nil {
+			log.Error(err, "error routing alerts", 0, log.Fields{"route": route.Name()})
+			failedRoutes = append(failedRoutes, route.Name())
+		}
+	}
+	if len(failedRoutes) > 0 {
+		md["error"] = "error routing alerts to " + strings.Join(failedRoutes, ", ")
+		r(constants.Failure, md)
+		return
+	}
+	r(constants.Success, md)
+}
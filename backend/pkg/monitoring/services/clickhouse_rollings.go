@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -93,8 +94,12 @@ func (s *ServiceClickhouseRollings) runChecks() {
 			tsRollingTable := utils.EpochToTime(epochRollingTable)
 			threshold := 30 * time.Minute
 			delta := tsEpochTable.Sub(tsRollingTable)
+			var lagEpochs uint64
+			if epochTable := uint64(utils.TimeToEpoch(tsEpochTable)); epochTable > epochRollingTable {
+				lagEpochs = epochTable - epochRollingTable
+			}
 			// check if delta is out of bounds
-			md := map[string]string{"delta": delta.String(), "threshold": threshold.String()}
+			md := map[string]string{"delta": delta.String(), "threshold": threshold.String(), constants.LagEpochsMetadataKey: strconv.FormatUint(lagEpochs, 10)}
 			if delta > threshold {
 				md["error"] = fmt.Sprintf("delta is over threshold %d", threshold)
 				r(constants.Failure, md)
@@ -3,7 +3,7 @@ package nodejobs
 import (
 	"bytes"
 	"database/sql"
-
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,12 +11,14 @@ import (
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/gobitfly/beaconchain/pkg/commons/db"
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
 	"github.com/gobitfly/beaconchain/pkg/commons/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 	ethutil "github.com/wealdtech/go-eth2-util"
 )
@@ -26,7 +28,7 @@ func GetNodeJob(id string) (*types.NodeJob, error) {
 		return nil, fmt.Errorf("invalid id")
 	}
 	job := types.NodeJob{}
-	err := db.WriterDb.Get(&job, `select id, type, status, created_time, submitted_to_node_time, completed_time, data from node_jobs where id = $1`, id)
+	err := db.WriterDb.Get(&job, `select id, type, status, created_time, submitted_to_node_time, completed_time, user_id, data from node_jobs where id = $1`, id)
 	if err != nil {
 		return nil, err
 	}
@@ -34,9 +36,11 @@ func GetNodeJob(id string) (*types.NodeJob, error) {
 	return &job, err
 }
 
-func GetNodeJobValidatorInfos(job *types.NodeJob) ([]types.NodeJobValidatorInfo, error) {
+// GetNodeJobValidatorIndices returns the indices of the validators the job is about
+func GetNodeJobValidatorIndices(job *types.NodeJob) ([]uint64, error) {
 	indicesArr := []uint64{}
-	if job.Type == types.BLSToExecutionChangesNodeJobType {
+	switch job.Type {
+	case types.BLSToExecutionChangesNodeJobType:
 		jobData, ok := job.GetBLSToExecutionChangesNodeJobData()
 		if !ok {
 			return nil, fmt.Errorf("invalid bls to execution job-data")
@@ -44,24 +48,42 @@ func GetNodeJobValidatorInfos(job *types.NodeJob) ([]types.NodeJobValidatorInfo,
 		for _, op := range jobData {
 			indicesArr = append(indicesArr, uint64(op.Message.ValidatorIndex))
 		}
-	} else if job.Type == types.VoluntaryExitsNodeJobType {
+	case types.VoluntaryExitsNodeJobType:
 		jobData, ok := job.GetVoluntaryExitsNodeJobData()
 		if !ok {
 			return nil, fmt.Errorf("invalid voluntary exit job-data")
 		}
-
 		indicesArr = append(indicesArr, uint64(jobData.Message.ValidatorIndex))
-	} else {
+	case types.ConditionalVoluntaryExitsNodeJobType:
+		jobData, ok := job.GetConditionalVoluntaryExitsNodeJobData()
+		if !ok {
+			return nil, fmt.Errorf("invalid conditional voluntary exits job-data")
+		}
+		for _, exit := range jobData.Exits {
+			indicesArr = append(indicesArr, uint64(exit.Message.ValidatorIndex))
+		}
+	}
+	return indicesArr, nil
+}
+
+func GetNodeJobValidatorInfos(job *types.NodeJob) ([]types.NodeJobValidatorInfo, error) {
+	indicesArr, err := GetNodeJobValidatorIndices(job)
+	if err != nil {
+		return nil, err
+	}
+	if len(indicesArr) == 0 {
 		return []types.NodeJobValidatorInfo{}, nil
 	}
 
 	dbValis := []types.NodeJobValidatorInfo{}
-	err := db.WriterDb.Select(&dbValis, `select validatorindex, pubkey, withdrawalcredentials, exitepoch, status from validators where validatorindex = any($1)`, pq.Array(indicesArr))
+	err = db.WriterDb.Select(&dbValis, `select validatorindex, pubkey, withdrawalcredentials, exitepoch, status from validators where validatorindex = any($1)`, pq.Array(indicesArr))
 	if err != nil {
 		return nil, err
 	}
 	jobStatus := "Pending"
 	switch job.Status {
+	case types.WaitingForTriggerNodeJobStatus:
+		jobStatus = "Waiting for trigger"
 	case types.SubmittedToNodeNodeJobStatus:
 		jobStatus = "Submitted to node"
 	case types.CompletedNodeJobStatus:
@@ -84,6 +106,11 @@ func CreateNodeJob(data []byte) (*types.NodeJob, error) {
 	if err != nil {
 		return nil, err
 	}
+	return CreateParsedNodeJob(j)
+}
+
+// CreateParsedNodeJob creates a job that has already been parsed, e.g. to set its owner beforehand
+func CreateParsedNodeJob(j *types.NodeJob) (*types.NodeJob, error) {
 	switch j.Type {
 	default:
 		return nil, fmt.Errorf("unknown job-type %v", j.Type)
@@ -91,9 +118,49 @@ func CreateNodeJob(data []byte) (*types.NodeJob, error) {
 		return CreateBLSToExecutionChangesNodeJob(j)
 	case types.VoluntaryExitsNodeJobType:
 		return CreateVoluntaryExitNodeJob(j)
+	case types.ConditionalVoluntaryExitsNodeJobType:
+		return CreateConditionalVoluntaryExitsNodeJob(j)
 	}
 }
 
+// GetNodeJobAuditLog returns the audit log of a job, oldest entries first
+func GetNodeJobAuditLog(id string) ([]types.NodeJobAuditLogEntry, error) {
+	entries := []types.NodeJobAuditLogEntry{}
+	err := db.WriterDb.Select(&entries, `select id, node_job_id, ts, action, validatorindex, details from node_jobs_audit_log where node_job_id = $1 order by id`, id)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// addNodeJobAuditLogEntry records an action of a job, validatorIndex is only set if the action concerns a single validator
+func addNodeJobAuditLogEntry(q sqlx.Execer, id string, action types.NodeJobAuditAction, validatorIndex sql.NullInt64, details string) error {
+	_, err := q.Exec(`insert into node_jobs_audit_log (node_job_id, action, validatorindex, details) values ($1, $2, $3, $4)`, id, action, validatorIndex, details)
+	if err != nil {
+		return fmt.Errorf("error inserting into node_jobs_audit_log: %w", err)
+	}
+	return nil
+}
+
+// submitToNode posts data to a beacon pool endpoint of the node, the returned message explains why the node rejected the data
+func submitToNode(path string, data []byte) (bool, string, error) {
+	client := &http.Client{Timeout: time.Second * 10}
+	url := fmt.Sprintf("%s%s", utils.Config.NodeJobsProcessor.ClEndpoint, path)
+	resp, err := client.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return false, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return true, "", nil
+	}
+	d, _ := io.ReadAll(resp.Body)
+	if len(d) > 1000 {
+		d = d[:1000]
+	}
+	return false, fmt.Sprintf("%s: %s", resp.Status, d), nil
+}
+
 func UpdateNodeJobs() error {
 	var err error
 	err = UpdateBLSToExecutionChangesNodeJobs()
@@ -104,6 +171,10 @@ func UpdateNodeJobs() error {
 	if err != nil {
 		return fmt.Errorf("error updating voluntary-exit-job: %w", err)
 	}
+	err = UpdateConditionalVoluntaryExitsNodeJobs()
+	if err != nil {
+		return fmt.Errorf("error updating conditional-voluntary-exits-job: %w", err)
+	}
 	return nil
 }
 
@@ -117,6 +188,10 @@ func SubmitNodeJobs() error {
 	if err != nil {
 		return err
 	}
+	err = SubmitConditionalVoluntaryExitsNodeJobs()
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -215,10 +290,14 @@ func CreateBLSToExecutionChangesNodeJob(nj *types.NodeJob) (*types.NodeJob, erro
 		}
 	}
 
-	_, err = tx.Exec(`insert into node_jobs (id, type, status, data, created_time) values ($1, $2, $3, $4, now())`, nj.ID, nj.Type, nj.Status, nj.RawData)
+	_, err = tx.Exec(`insert into node_jobs (id, type, status, data, user_id, created_time) values ($1, $2, $3, $4, $5, now())`, nj.ID, nj.Type, nj.Status, nj.RawData, nj.UserID)
 	if err != nil {
 		return nil, fmt.Errorf("error inserting into node_jobs: %w", err)
 	}
+	err = addNodeJobAuditLogEntry(tx, nj.ID, types.CreatedNodeJobAuditAction, sql.NullInt64{}, "")
+	if err != nil {
+		return nil, err
+	}
 
 	err = tx.Commit()
 	if err != nil {
@@ -284,6 +363,10 @@ func UpdateBLSToExecutionChangesNodeJob(job *types.NodeJob) error {
 			if err != nil {
 				return err
 			}
+			err = addNodeJobAuditLogEntry(db.WriterDb, job.ID, types.CompletedNodeJobAuditAction, sql.NullInt64{}, "")
+			if err != nil {
+				return err
+			}
 			log.InfoWithFields(log.Fields{"id": job.ID, "type": job.Type, "status": types.CompletedNodeJobStatus, "validators": len(indicesArr)}, "updated node_job")
 		}
 	}
@@ -311,21 +394,16 @@ func SubmitBLSToExecutionChangesNodeJobs() error {
 }
 
 func SubmitBLSToExecutionChangesNodeJob(job *types.NodeJob) error {
-	client := &http.Client{Timeout: time.Second * 10}
-	url := fmt.Sprintf("%s/eth/v1/beacon/pool/bls_to_execution_changes", utils.Config.NodeJobsProcessor.ClEndpoint)
-	resp, err := client.Post(url, "application/json", bytes.NewReader(job.RawData))
+	ok, msg, err := submitToNode("/eth/v1/beacon/pool/bls_to_execution_changes", job.RawData)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 	jobStatus := types.SubmittedToNodeNodeJobStatus
-	if resp.StatusCode != http.StatusOK {
-		d, _ := io.ReadAll(resp.Body)
-		if len(d) > 1000 {
-			d = d[:1000]
-		}
+	auditAction := types.SubmittedNodeJobAuditAction
+	if !ok {
 		jobStatus = types.FailedNodeJobStatus
-		log.WarnWithFields(log.Fields{"data": string(d), "status": resp.Status, "jobID": job.ID}, "failed submitting a job")
+		auditAction = types.SubmissionFailedNodeJobAuditAction
+		log.WarnWithFields(log.Fields{"data": msg, "jobID": job.ID}, "failed submitting a job")
 	}
 	job.Status = jobStatus
 	job.SubmittedToNodeTime.Time = time.Now()
@@ -334,51 +412,81 @@ func SubmitBLSToExecutionChangesNodeJob(job *types.NodeJob) error {
 	if err != nil {
 		return err
 	}
+	err = addNodeJobAuditLogEntry(db.WriterDb, job.ID, auditAction, sql.NullInt64{}, msg)
+	if err != nil {
+		return err
+	}
 	log.InfoWithFields(log.Fields{"id": job.ID, "type": job.Type, "status": jobStatus}, "submitted node_job")
 	return nil
 }
 
 func CreateVoluntaryExitNodeJob(nj *types.NodeJob) (*types.NodeJob, error) {
 	if len(nj.RawData) > 5e3 {
-		return nil, fmt.Errorf("data size exceeds maximum")
+		return nil, types.CreateNodeJobUserError{Message: "data-size exceeds maximum of 5KB"}
 	}
 	nj.ID = uuid.New().String()
 	nj.Status = types.PendingNodeJobStatus
 
 	njd, ok := nj.GetVoluntaryExitsNodeJobData()
 	if !ok {
-		return nil, fmt.Errorf("invalid job")
+		return nil, types.CreateNodeJobUserError{Message: "invalid data"}
+	}
+
+	err := checkVoluntaryExit(njd)
+	if err != nil {
+		return nil, err
 	}
 
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("error starting db transactions: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	_, err = tx.Exec(`insert into node_jobs (id, type, status, data, user_id, created_time) values ($1, $2, $3, $4, $5, now())`, nj.ID, nj.Type, nj.Status, nj.RawData, nj.UserID)
+	if err != nil {
+		return nil, err
+	}
+	err = addNodeJobAuditLogEntry(tx, nj.ID, types.CreatedNodeJobAuditAction, sql.NullInt64{}, "")
+	if err != nil {
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("error committing db-tx: %w", err)
+	}
+	log.InfoWithFields(log.Fields{"id": nj.ID, "type": nj.Type}, "created node_job")
+	return nj, nil
+}
+
+// checkVoluntaryExit verifies the signature of the exit and that the validator can still exit
+func checkVoluntaryExit(exit *phase0.SignedVoluntaryExit) error {
 	vali := struct {
 		Pubkey []byte `db:"pubkey"`
 		Status string `db:"status"`
 	}{}
-	err := db.WriterDb.Get(&vali, `select pubkey, status from validators where validatorindex = $1`, njd.Message.ValidatorIndex)
+	err := db.WriterDb.Get(&vali, `select pubkey, status from validators where validatorindex = $1`, exit.Message.ValidatorIndex)
+	if err == sql.ErrNoRows {
+		return types.CreateNodeJobUserError{Message: fmt.Sprintf("validator with index %v not found", exit.Message.ValidatorIndex)}
+	}
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	switch constypes.ValidatorDbStatus(vali.Status) {
 	case constypes.DbExited, constypes.DbExitingOffline, constypes.DbExitingOnline:
-		return nil, fmt.Errorf("validator has exited")
+		return types.CreateNodeJobUserError{Message: fmt.Sprintf("validator with index %v has exited", exit.Message.ValidatorIndex)}
 	case constypes.DbSlashed, constypes.DbSlashingOffline, constypes.DbSlashingOnline:
-		return nil, fmt.Errorf("validator has been slashed")
+		return types.CreateNodeJobUserError{Message: fmt.Sprintf("validator with index %v has been slashed", exit.Message.ValidatorIndex)}
 	default:
 	}
 
-	forkVersion := utils.ForkVersionAtEpoch(uint64(njd.Message.Epoch))
-	err = utils.VerifyVoluntaryExitSignature(njd, forkVersion.CurrentVersion, vali.Pubkey)
-	if err != nil {
-		return nil, err
-	}
-
-	_, err = db.WriterDb.Exec(`insert into node_jobs (id, type, status, data, created_time) values ($1, $2, $3, $4, now())`, nj.ID, nj.Type, nj.Status, nj.RawData)
+	forkVersion := utils.ForkVersionAtEpoch(uint64(exit.Message.Epoch))
+	err = utils.VerifyVoluntaryExitSignature(exit, forkVersion.CurrentVersion, vali.Pubkey)
 	if err != nil {
-		return nil, err
+		return types.CreateNodeJobUserError{Message: fmt.Sprintf("can not verify signature of validator with index %v: %v", exit.Message.ValidatorIndex, err)}
 	}
-	log.InfoWithFields(log.Fields{"id": nj.ID, "type": nj.Type}, "created node_job")
-	return nj, nil
+	return nil
 }
 
 func UpdateVoluntaryExitNodeJobs() error {
@@ -423,6 +531,10 @@ func UpdateVoluntaryExitNodeJob(job *types.NodeJob) error {
 		if err != nil {
 			return err
 		}
+		err = addNodeJobAuditLogEntry(db.WriterDb, job.ID, types.CompletedNodeJobAuditAction, sql.NullInt64{}, "")
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -448,21 +560,16 @@ func SubmitVoluntaryExitNodeJobs() error {
 }
 
 func SubmitVoluntaryExitNodeJob(job *types.NodeJob) error {
-	client := &http.Client{Timeout: time.Second * 10}
-	url := fmt.Sprintf("%s/eth/v1/beacon/pool/voluntary_exits", utils.Config.NodeJobsProcessor.ClEndpoint)
-	resp, err := client.Post(url, "application/json", bytes.NewReader(job.RawData))
+	ok, msg, err := submitToNode("/eth/v1/beacon/pool/voluntary_exits", job.RawData)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 	jobStatus := types.SubmittedToNodeNodeJobStatus
-	if resp.StatusCode != http.StatusOK {
-		d, _ := io.ReadAll(resp.Body)
-		if len(d) > 1000 {
-			d = d[:1000]
-		}
+	auditAction := types.SubmittedNodeJobAuditAction
+	if !ok {
 		jobStatus = types.FailedNodeJobStatus
-		log.WarnWithFields(log.Fields{"res": string(d), "status": resp.Status, "jobID": job.ID, "jobType": job.Type}, "failed submitting a job")
+		auditAction = types.SubmissionFailedNodeJobAuditAction
+		log.WarnWithFields(log.Fields{"res": msg, "jobID": job.ID, "jobType": job.Type}, "failed submitting a job")
 	}
 	job.Status = jobStatus
 	job.SubmittedToNodeTime.Time = time.Now()
@@ -471,6 +578,362 @@ func SubmitVoluntaryExitNodeJob(job *types.NodeJob) error {
 	if err != nil {
 		return err
 	}
+	err = addNodeJobAuditLogEntry(db.WriterDb, job.ID, auditAction, sql.NullInt64{}, msg)
+	if err != nil {
+		return err
+	}
 	log.InfoWithFields(log.Fields{"id": job.ID, "type": job.Type, "status": jobStatus}, "submitted node_job")
 	return nil
 }
+
+func CreateConditionalVoluntaryExitsNodeJob(nj *types.NodeJob) (*types.NodeJob, error) {
+	if len(nj.RawData) > 1e6 {
+		return nil, types.CreateNodeJobUserError{Message: "data-size exceeds maximum of 1MB"}
+	}
+	if !nj.UserID.Valid {
+		return nil, types.CreateNodeJobUserError{Message: "conditional jobs can only be created by logged in users"}
+	}
+	nj.ID = uuid.New().String()
+	nj.Status = types.WaitingForTriggerNodeJobStatus
+
+	njd, ok := nj.GetConditionalVoluntaryExitsNodeJobData()
+	if !ok {
+		return nil, types.CreateNodeJobUserError{Message: "invalid data"}
+	}
+	switch njd.Trigger.Type {
+	case types.ScheduledEpochNodeJobTriggerType:
+		if njd.Trigger.Epoch == 0 {
+			return nil, types.CreateNodeJobUserError{Message: "scheduled_epoch trigger requires an epoch"}
+		}
+	case types.OfflineNodeJobTriggerType:
+		if njd.Trigger.Epochs == 0 {
+			return nil, types.CreateNodeJobUserError{Message: "offline trigger requires a number of epochs"}
+		}
+	case types.SiblingSlashedNodeJobTriggerType, types.ManualNodeJobTriggerType:
+	default:
+		return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("unknown trigger type %v", njd.Trigger.Type)}
+	}
+
+	seen := map[uint64]bool{}
+	for _, exit := range njd.Exits {
+		if seen[uint64(exit.Message.ValidatorIndex)] {
+			return nil, types.CreateNodeJobUserError{Message: fmt.Sprintf("multiple entries for the same validator: %v", uint64(exit.Message.ValidatorIndex))}
+		}
+		seen[uint64(exit.Message.ValidatorIndex)] = true
+		err := checkVoluntaryExit(exit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("error starting db transactions: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	_, err = tx.Exec(`insert into node_jobs (id, type, status, data, user_id, created_time) values ($1, $2, $3, $4, $5, now())`, nj.ID, nj.Type, nj.Status, nj.RawData, nj.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("error inserting into node_jobs: %w", err)
+	}
+	err = addNodeJobAuditLogEntry(tx, nj.ID, types.CreatedNodeJobAuditAction, sql.NullInt64{}, fmt.Sprintf("waiting for trigger %v", njd.Trigger.Type))
+	if err != nil {
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("error committing db-tx: %w", err)
+	}
+	log.InfoWithFields(log.Fields{"id": nj.ID, "type": nj.Type, "trigger": njd.Trigger.Type, "validators": len(njd.Exits)}, "created node_job")
+	return nj, nil
+}
+
+// TriggerNodeJob releases a job that is waiting for its trigger to the processor, it returns false if the job wasn't waiting
+func TriggerNodeJob(id string, reason string) (bool, error) {
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return false, fmt.Errorf("error starting db transactions: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	res, err := tx.Exec(`update node_jobs set status = $1 where id = $2 and status = $3`, types.PendingNodeJobStatus, id, types.WaitingForTriggerNodeJobStatus)
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error getting rowsAffected: %w", err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+	err = addNodeJobAuditLogEntry(tx, id, types.TriggeredNodeJobAuditAction, sql.NullInt64{}, reason)
+	if err != nil {
+		return false, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return false, fmt.Errorf("error committing db-tx: %w", err)
+	}
+	log.InfoWithFields(log.Fields{"id": id, "reason": reason}, "triggered node_job")
+	return true, nil
+}
+
+// TriggerConditionalVoluntaryExitsNodeJobs evaluates the triggers of the conditional jobs that are waiting for them at the given epoch
+func TriggerConditionalVoluntaryExitsNodeJobs(epoch uint64) error {
+	jobs := []*types.NodeJob{}
+	err := db.WriterDb.Select(&jobs, `select id, type, status, created_time, submitted_to_node_time, completed_time, data from node_jobs where type = $1 and status = $2`, types.ConditionalVoluntaryExitsNodeJobType, types.WaitingForTriggerNodeJobStatus)
+	if err != nil {
+		return err
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	offlineIndices := []uint64{}
+	siblingDashboards := []uint64{}
+	for _, job := range jobs {
+		err := job.ParseData()
+		if err != nil {
+			return err
+		}
+		jobData, ok := job.GetConditionalVoluntaryExitsNodeJobData()
+		if !ok {
+			return fmt.Errorf("invalid job-data for job %v", job.ID)
+		}
+		switch jobData.Trigger.Type {
+		case types.OfflineNodeJobTriggerType:
+			for _, exit := range jobData.Exits {
+				offlineIndices = append(offlineIndices, uint64(exit.Message.ValidatorIndex))
+			}
+		case types.SiblingSlashedNodeJobTriggerType:
+			siblingDashboards = append(siblingDashboards, jobData.DashboardId)
+		}
+	}
+
+	lastActiveEpochs, err := getLastActiveEpochs(offlineIndices)
+	if err != nil {
+		return err
+	}
+	slashedByGroup, err := getSlashedValidatorsByDashboardGroup(siblingDashboards, epoch)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		jobData, _ := job.GetConditionalVoluntaryExitsNodeJobData()
+		reason := evaluateNodeJobTrigger(jobData, epoch, lastActiveEpochs, slashedByGroup[[2]uint64{jobData.DashboardId, jobData.GroupId}])
+		if reason == "" {
+			continue
+		}
+		_, err = TriggerNodeJob(job.ID, reason)
+		if err != nil {
+			return fmt.Errorf("error triggering job %v: %w", job.ID, err)
+		}
+	}
+	return nil
+}
+
+// evaluateNodeJobTrigger returns why the trigger of the job fired at the given epoch, an empty string if it didn't.
+// lastActiveEpochs holds the epoch of the last attestation of the active validators, slashedSiblings the validators of the
+// dashboard group of the job that got slashed in the epoch.
+func evaluateNodeJobTrigger(data *types.ConditionalVoluntaryExitsNodeJobData, epoch uint64, lastActiveEpochs map[uint64]uint64, slashedSiblings []uint64) string {
+	switch data.Trigger.Type {
+	case types.ScheduledEpochNodeJobTriggerType:
+		if epoch >= data.Trigger.Epoch {
+			return fmt.Sprintf("scheduled epoch %v has been reached", data.Trigger.Epoch)
+		}
+	case types.OfflineNodeJobTriggerType:
+		for _, exit := range data.Exits {
+			lastActiveEpoch, ok := lastActiveEpochs[uint64(exit.Message.ValidatorIndex)]
+			if ok && epoch >= lastActiveEpoch+data.Trigger.Epochs {
+				return fmt.Sprintf("validator %v has been offline for %v epochs", uint64(exit.Message.ValidatorIndex), epoch-lastActiveEpoch)
+			}
+		}
+	case types.SiblingSlashedNodeJobTriggerType:
+		if len(slashedSiblings) > 0 {
+			return fmt.Sprintf("validator %v of the dashboard group got slashed", slashedSiblings[0])
+		}
+	}
+	return ""
+}
+
+// getLastActiveEpochs returns the epoch of the last attestation of the given validators that are active.
+// the last attestations are kept in bigtable only, validators without a known attestation are left out.
+func getLastActiveEpochs(indices []uint64) (map[uint64]uint64, error) {
+	if len(indices) == 0 {
+		return map[uint64]uint64{}, nil
+	}
+	activeIndices := []uint64{}
+	err := db.WriterDb.Select(&activeIndices, `select validatorindex from validators where validatorindex = any($1) and status like 'active%'`, pq.Array(indices))
+	if err != nil {
+		return nil, fmt.Errorf("error getting active validators: %w", err)
+	}
+	if len(activeIndices) == 0 {
+		return map[uint64]uint64{}, nil
+	}
+	lastAttestationSlots, err := db.BigtableClient.GetLastAttestationSlots(activeIndices)
+	if err != nil {
+		return nil, fmt.Errorf("error getting last attestation slots of validators: %w", err)
+	}
+	return lastActiveEpochsOf(activeIndices, lastAttestationSlots), nil
+}
+
+// lastActiveEpochsOf maps the active validators to the epoch of their last attestation. validators that never attested
+// (or whose attestations are unknown) are left out, missing data must never make the offline trigger fire.
+func lastActiveEpochsOf(activeIndices []uint64, lastAttestationSlots map[uint64]uint64) map[uint64]uint64 {
+	lastActiveEpochs := map[uint64]uint64{}
+	for _, index := range activeIndices {
+		slot, ok := lastAttestationSlots[index]
+		if !ok || slot == 0 {
+			continue
+		}
+		lastActiveEpochs[index] = utils.EpochOfSlot(slot)
+	}
+	return lastActiveEpochs
+}
+
+// getSlashedValidatorsByDashboardGroup returns the validators of the given dashboards that got slashed in the epoch, keyed by dashboard and group id
+func getSlashedValidatorsByDashboardGroup(dashboardIds []uint64, epoch uint64) (map[[2]uint64][]uint64, error) {
+	slashedByGroup := map[[2]uint64][]uint64{}
+	if len(dashboardIds) == 0 {
+		return slashedByGroup, nil
+	}
+	slashings, err := db.GetValidatorsGotSlashed(epoch)
+	if err != nil {
+		return nil, fmt.Errorf("error getting slashed validators: %w", err)
+	}
+	if len(slashings) == 0 {
+		return slashedByGroup, nil
+	}
+	slashedIndices := make([]uint64, 0, len(slashings))
+	for _, slashing := range slashings {
+		slashedIndices = append(slashedIndices, slashing.SlashedValidatorIndex)
+	}
+
+	rows := []struct {
+		DashboardId    uint64 `db:"dashboard_id"`
+		GroupId        uint64 `db:"group_id"`
+		ValidatorIndex uint64 `db:"validator_index"`
+	}{}
+	err = db.AlloyReader.Select(&rows, `select dashboard_id, group_id, validator_index from users_val_dashboards_validators where dashboard_id = any($1) and validator_index = any($2)`, pq.Array(dashboardIds), pq.Array(slashedIndices))
+	if err != nil {
+		return nil, fmt.Errorf("error getting dashboard groups of slashed validators: %w", err)
+	}
+	for _, row := range rows {
+		key := [2]uint64{row.DashboardId, row.GroupId}
+		slashedByGroup[key] = append(slashedByGroup[key], row.ValidatorIndex)
+	}
+	return slashedByGroup, nil
+}
+
+func SubmitConditionalVoluntaryExitsNodeJobs() error {
+	maxSubmittedJobs := 100
+	jobs := []*types.NodeJob{}
+	err := db.WriterDb.Select(&jobs, `select id, type, status, created_time, submitted_to_node_time, completed_time, data from node_jobs where type = $1 and status = $2 order by created_time limit $4-(select count(*) from node_jobs where type = $1 and status = $3)`, types.ConditionalVoluntaryExitsNodeJobType, types.PendingNodeJobStatus, types.SubmittedToNodeNodeJobStatus, maxSubmittedJobs)
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		err = job.ParseData()
+		if err != nil {
+			return err
+		}
+		err = SubmitConditionalVoluntaryExitsNodeJob(job)
+		if err != nil {
+			return fmt.Errorf("error calling SubmitConditionalVoluntaryExitsNodeJob for job %v: %w", job.ID, err)
+		}
+	}
+	return nil
+}
+
+// SubmitConditionalVoluntaryExitsNodeJob submits every exit of the job on its own so a rejected exit doesn't hold back the others,
+// the job only fails if the node rejected all of them
+func SubmitConditionalVoluntaryExitsNodeJob(job *types.NodeJob) error {
+	jobData, ok := job.GetConditionalVoluntaryExitsNodeJobData()
+	if !ok {
+		return fmt.Errorf("invalid job-data")
+	}
+	jobStatus := types.FailedNodeJobStatus
+	for _, exit := range jobData.Exits {
+		data, err := json.Marshal(exit)
+		if err != nil {
+			return err
+		}
+		ok, msg, err := submitToNode("/eth/v1/beacon/pool/voluntary_exits", data)
+		if err != nil {
+			return err
+		}
+		auditAction := types.SubmittedNodeJobAuditAction
+		if ok {
+			jobStatus = types.SubmittedToNodeNodeJobStatus
+		} else {
+			auditAction = types.SubmissionFailedNodeJobAuditAction
+			log.WarnWithFields(log.Fields{"res": msg, "jobID": job.ID, "jobType": job.Type, "validator": exit.Message.ValidatorIndex}, "failed submitting a voluntary exit of a job")
+		}
+		err = addNodeJobAuditLogEntry(db.WriterDb, job.ID, auditAction, sql.NullInt64{Int64: int64(exit.Message.ValidatorIndex), Valid: true}, msg)
+		if err != nil {
+			return err
+		}
+	}
+	job.Status = jobStatus
+	job.SubmittedToNodeTime.Time = time.Now()
+	job.SubmittedToNodeTime.Valid = true
+	_, err := db.WriterDb.Exec(`update node_jobs set status = $1, submitted_to_node_time = $2 where id = $3`, job.Status, job.SubmittedToNodeTime.Time, job.ID)
+	if err != nil {
+		return err
+	}
+	log.InfoWithFields(log.Fields{"id": job.ID, "type": job.Type, "status": jobStatus, "validators": len(jobData.Exits)}, "submitted node_job")
+	return nil
+}
+
+func UpdateConditionalVoluntaryExitsNodeJobs() error {
+	jobs := []*types.NodeJob{}
+	err := db.WriterDb.Select(&jobs, `select id, type, status, created_time, submitted_to_node_time, completed_time, data from node_jobs where type = $1 and status = $2`, types.ConditionalVoluntaryExitsNodeJobType, types.SubmittedToNodeNodeJobStatus)
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		err := job.ParseData()
+		if err != nil {
+			return err
+		}
+		err = UpdateConditionalVoluntaryExitsNodeJob(job)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateConditionalVoluntaryExitsNodeJob completes the job once none of its validators can exit anymore
+func UpdateConditionalVoluntaryExitsNodeJob(job *types.NodeJob) error {
+	indicesArr, err := GetNodeJobValidatorIndices(job)
+	if err != nil {
+		return err
+	}
+	statuses := []string{}
+	err = db.WriterDb.Select(&statuses, `select status from validators where validatorindex = any($1)`, pq.Array(indicesArr))
+	if err != nil {
+		return err
+	}
+	for _, status := range statuses {
+		if !strings.HasPrefix(status, "exit") && !strings.HasPrefix(status, "slash") {
+			// not all valis have exited yet
+			return nil
+		}
+	}
+	job.Status = types.CompletedNodeJobStatus
+	job.CompletedTime.Time = time.Now()
+	job.CompletedTime.Valid = true
+	_, err = db.WriterDb.Exec(`update node_jobs set status = $1, completed_time = $2 where id = $3`, job.Status, job.CompletedTime.Time, job.ID)
+	if err != nil {
+		return err
+	}
+	err = addNodeJobAuditLogEntry(db.WriterDb, job.ID, types.CompletedNodeJobAuditAction, sql.NullInt64{}, "")
+	if err != nil {
+		return err
+	}
+	log.InfoWithFields(log.Fields{"id": job.ID, "type": job.Type, "status": job.Status, "validators": len(indicesArr)}, "updated node_job")
+	return nil
+}
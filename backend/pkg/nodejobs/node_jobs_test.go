@@ -0,0 +1,105 @@
+package nodejobs
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+func testConditionalExitsData(trigger types.NodeJobTrigger, validators ...phase0.ValidatorIndex) *types.ConditionalVoluntaryExitsNodeJobData {
+	data := &types.ConditionalVoluntaryExitsNodeJobData{Trigger: trigger}
+	for _, validator := range validators {
+		data.Exits = append(data.Exits, &phase0.SignedVoluntaryExit{Message: &phase0.VoluntaryExit{ValidatorIndex: validator}})
+	}
+	return data
+}
+
+func TestEvaluateNodeJobTrigger(t *testing.T) {
+	tests := []struct {
+		name             string
+		data             *types.ConditionalVoluntaryExitsNodeJobData
+		epoch            uint64
+		lastActiveEpochs map[uint64]uint64
+		slashedSiblings  []uint64
+		fires            bool
+	}{
+		{
+			name:  "scheduled epoch not reached",
+			data:  testConditionalExitsData(types.NodeJobTrigger{Type: types.ScheduledEpochNodeJobTriggerType, Epoch: 100}, 1),
+			epoch: 99,
+		},
+		{
+			name:  "scheduled epoch reached",
+			data:  testConditionalExitsData(types.NodeJobTrigger{Type: types.ScheduledEpochNodeJobTriggerType, Epoch: 100}, 1),
+			epoch: 100,
+			fires: true,
+		},
+		{
+			name:             "validator offline for less epochs",
+			data:             testConditionalExitsData(types.NodeJobTrigger{Type: types.OfflineNodeJobTriggerType, Epochs: 10}, 1, 2),
+			epoch:            100,
+			lastActiveEpochs: map[uint64]uint64{1: 100, 2: 91},
+		},
+		{
+			name:             "validator offline for enough epochs",
+			data:             testConditionalExitsData(types.NodeJobTrigger{Type: types.OfflineNodeJobTriggerType, Epochs: 10}, 1, 2),
+			epoch:            100,
+			lastActiveEpochs: map[uint64]uint64{1: 100, 2: 90},
+			fires:            true,
+		},
+		{
+			name:  "inactive validators are never offline",
+			data:  testConditionalExitsData(types.NodeJobTrigger{Type: types.OfflineNodeJobTriggerType, Epochs: 10}, 1),
+			epoch: 100,
+		},
+		{
+			name:  "no sibling slashed",
+			data:  testConditionalExitsData(types.NodeJobTrigger{Type: types.SiblingSlashedNodeJobTriggerType}, 1),
+			epoch: 100,
+		},
+		{
+			name:            "sibling slashed",
+			data:            testConditionalExitsData(types.NodeJobTrigger{Type: types.SiblingSlashedNodeJobTriggerType}, 1),
+			epoch:           100,
+			slashedSiblings: []uint64{3},
+			fires:           true,
+		},
+		{
+			name:            "manual triggers never fire on their own",
+			data:            testConditionalExitsData(types.NodeJobTrigger{Type: types.ManualNodeJobTriggerType}, 1),
+			epoch:           100,
+			slashedSiblings: []uint64{3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := evaluateNodeJobTrigger(tt.data, tt.epoch, tt.lastActiveEpochs, tt.slashedSiblings)
+			if fired := reason != ""; fired != tt.fires {
+				t.Errorf("expected trigger to fire: %v, got reason %q", tt.fires, reason)
+			}
+		})
+	}
+}
+
+func TestLastActiveEpochsOf(t *testing.T) {
+	utils.Config = &types.Config{}
+	utils.Config.Chain.ClConfig.SlotsPerEpoch = 32
+
+	// validator 1 attested in epoch 100, validator 2 never attested, validator 3 has no known attestation
+	lastActiveEpochs := lastActiveEpochsOf([]uint64{1, 2, 3}, map[uint64]uint64{1: 100*32 + 5, 2: 0})
+	if len(lastActiveEpochs) != 1 || lastActiveEpochs[1] != 100 {
+		t.Fatalf("expected only validator 1 to be active since epoch 100, got %v", lastActiveEpochs)
+	}
+
+	trigger := types.NodeJobTrigger{Type: types.OfflineNodeJobTriggerType, Epochs: 10}
+	for _, validator := range []phase0.ValidatorIndex{2, 3} {
+		if reason := evaluateNodeJobTrigger(testConditionalExitsData(trigger, validator), 1000, lastActiveEpochs, nil); reason != "" {
+			t.Errorf("expected the trigger not to fire for validator %v without attestation data, got reason %q", validator, reason)
+		}
+	}
+	if reason := evaluateNodeJobTrigger(testConditionalExitsData(trigger, 1), 110, lastActiveEpochs, nil); reason == "" {
+		t.Errorf("expected the trigger to fire for validator 1")
+	}
+}
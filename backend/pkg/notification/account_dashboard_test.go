@@ -0,0 +1,61 @@
+package notification
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/shopspring/decimal"
+)
+
+func TestSplitAccountDashboardNotifications(t *testing.T) {
+	notificationsPerGroup := types.NotificationsPerEventName{
+		types.ValidatorIsOfflineEventName:      {},
+		types.IncomingTransactionEventName:     {},
+		types.ERC20TokenTransferEventName:      {},
+		types.ValidatorMissedProposalEventName: {},
+	}
+
+	validator, account := splitAccountDashboardNotifications(notificationsPerGroup)
+	if len(validator) != 2 || len(account) != 2 {
+		t.Fatalf("expected 2 validator and 2 account dashboard events, got %d and %d", len(validator), len(account))
+	}
+	for event := range account {
+		if !types.IsAccountDashboardEvent(event) {
+			t.Errorf("validator dashboard event %s was classified as account dashboard event", event)
+		}
+	}
+}
+
+func TestErc20TransferValueUsd(t *testing.T) {
+	// 1.5 tokens with 6 decimals at 2 USD
+	value := big.NewInt(1_500_000).Bytes()
+	metadata := &types.ERC20Metadata{
+		Decimals: big.NewInt(6).Bytes(),
+		Price:    []byte("2"),
+	}
+	if got := erc20TransferValueUsd(value, metadata); !got.Equal(decimal.NewFromInt(3)) {
+		t.Errorf("expected a value of 3 USD, got %s", got)
+	}
+	if got := erc20TransferValueUsd(value, nil); !got.IsZero() {
+		t.Errorf("expected tokens without metadata to be valued at zero, got %s", got)
+	}
+}
+
+func TestAccountTokenTransferNotificationGetInfo(t *testing.T) {
+	account := []byte{0x01, 0x02}
+	n := &AccountTokenTransferNotification{
+		NotificationBaseImpl: types.NotificationBaseImpl{EventName: types.ERC20TokenTransferEventName},
+		Address:              account,
+		From:                 []byte{0x03},
+		To:                   account,
+		TxHash:               []byte{0x04},
+		TokenSymbol:          "USDC",
+		TokenDecimals:        6,
+		Value:                big.NewInt(1_500_000).Bytes(),
+	}
+	want := "Account 0x0102 received 1.5 USDC from 0x03 (tx 0x04)."
+	if got := n.GetInfo(types.NotifciationFormatText); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
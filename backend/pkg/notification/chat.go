@@ -0,0 +1,738 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// chatNotificationsPerDay is the maximum number of messages that are sent per user, day and chat channel
+	chatNotificationsPerDay = 1000
+
+	telegramApiUrl           = "https://api.telegram.org"
+	telegramMaxMessageLength = 4096
+
+	slackMaxBlocks        = 50
+	slackMaxHeaderLength  = 150
+	slackMaxSectionLength = 3000
+)
+
+// ErrTelegramNotLinked is returned if a user has not linked a telegram chat to their account
+var ErrTelegramNotLinked = errors.New("no telegram chat linked")
+
+var (
+	markdownLinkRegex = regexp.MustCompile(`\[([^\]]*)\]\(([^)\s]+)\)`)
+	markdownBoldRegex = regexp.MustCompile(`\*\*(.+?)\*\*`)
+)
+
+// QueueChatNotifications queues one telegram, slack and matrix message per dashboard group with notifications.
+// Like emails and push messages, chat messages are subject to the digest and quiet hours settings of the users,
+// so only notifications that are due are passed in.
+func QueueChatNotifications(notificationsByUserID types.NotificationsPerUserId, tx *sqlx.Tx) error {
+	userIds := slices.Collect(maps.Keys(notificationsByUserID))
+
+	// users can disable a chat channel for all of their dashboards
+	var disabledChannels []struct {
+		UserId  types.UserId              `db:"user_id"`
+		Channel types.NotificationChannel `db:"channel"`
+	}
+	err := db.FrontendWriterDB.Select(&disabledChannels, `SELECT user_id, channel FROM users_notification_channels WHERE active = false AND channel::text = ANY($1) AND user_id = ANY($2)`,
+		pq.StringArray{string(types.TelegramNotificationChannel), string(types.SlackNotificationChannel), string(types.MatrixNotificationChannel)}, pq.Array(userIds))
+	if err != nil {
+		return fmt.Errorf("error quering users_notification_channels, err: %w", err)
+	}
+	disabled := make(map[types.UserId]map[types.NotificationChannel]bool)
+	for _, c := range disabledChannels {
+		if _, exists := disabled[c.UserId]; !exists {
+			disabled[c.UserId] = make(map[types.NotificationChannel]bool)
+		}
+		disabled[c.UserId][c.Channel] = true
+	}
+
+	var telegramChats []struct {
+		UserId types.UserId `db:"user_id"`
+		ChatId int64        `db:"chat_id"`
+	}
+	err = db.FrontendWriterDB.Select(&telegramChats, `SELECT user_id, chat_id FROM users_telegram_chats WHERE user_id = ANY($1) AND chat_id IS NOT NULL`, pq.Array(userIds))
+	if err != nil {
+		return fmt.Errorf("error quering users_telegram_chats, err: %w", err)
+	}
+	telegramChatMap := make(map[types.UserId]int64, len(telegramChats))
+	for _, c := range telegramChats {
+		telegramChatMap[c.UserId] = c.ChatId
+	}
+
+	var groups []struct {
+		UserId           types.UserId           `db:"user_id"`
+		DashboardId      types.DashboardId      `db:"dashboard_id"`
+		DashboardGroupId types.DashboardGroupId `db:"dashboard_group_id"`
+		TelegramEnabled  bool                   `db:"telegram_enabled"`
+		SlackWebhookUrl  sql.NullString         `db:"slack_webhook_url"`
+		MatrixRoomId     sql.NullString         `db:"matrix_room_id"`
+	}
+	err = db.ReaderDb.Select(&groups, `
+	SELECT
+		users_val_dashboards.user_id AS user_id,
+		users_val_dashboards_groups.dashboard_id AS dashboard_id,
+		users_val_dashboards_groups.id AS dashboard_group_id,
+		users_val_dashboards_groups.telegram_enabled AS telegram_enabled,
+		users_val_dashboards_groups.slack_webhook_url AS slack_webhook_url,
+		users_val_dashboards_groups.matrix_room_id AS matrix_room_id
+	FROM users_val_dashboards_groups
+	INNER JOIN users_val_dashboards ON users_val_dashboards_groups.dashboard_id = users_val_dashboards.id
+	WHERE users_val_dashboards.user_id = ANY($1)
+	AND (users_val_dashboards_groups.telegram_enabled OR users_val_dashboards_groups.slack_webhook_url IS NOT NULL OR users_val_dashboards_groups.matrix_room_id IS NOT NULL);
+	`, pq.Array(userIds))
+	if err != nil {
+		return fmt.Errorf("error quering users_val_dashboards_groups, err: %w", err)
+	}
+
+	chats := make([]types.TransitChat, 0)
+	for _, g := range groups {
+		notificationsPerGroup, exists := notificationsByUserID[g.UserId][g.DashboardId][g.DashboardGroupId]
+		if !exists {
+			continue
+		}
+		// chat channels are only configured for validator dashboard groups
+		notificationsPerGroup, _ = splitAccountDashboardNotifications(notificationsPerGroup)
+		if len(notificationsPerGroup) == 0 {
+			continue
+		}
+		content := renderChatMessage(notificationsPerGroup)
+		content.UserId = g.UserId
+		content.DashboardId = uint64(g.DashboardId)
+		content.DashboardGroupId = uint64(g.DashboardGroupId)
+
+		if chatId, linked := telegramChatMap[g.UserId]; g.TelegramEnabled && linked && !disabled[g.UserId][types.TelegramNotificationChannel] {
+			content.Target = strconv.FormatInt(chatId, 10)
+			chats = append(chats, types.TransitChat{Channel: string(types.TelegramNotificationChannel), Content: content})
+		}
+		if g.SlackWebhookUrl.Valid && !disabled[g.UserId][types.SlackNotificationChannel] {
+			content.Target = g.SlackWebhookUrl.String
+			chats = append(chats, types.TransitChat{Channel: string(types.SlackNotificationChannel), Content: content})
+		}
+		if g.MatrixRoomId.Valid && !disabled[g.UserId][types.MatrixNotificationChannel] {
+			content.Target = g.MatrixRoomId.String
+			chats = append(chats, types.TransitChat{Channel: string(types.MatrixNotificationChannel), Content: content})
+		}
+	}
+
+	log.Infof("queueing %v chat notifications", len(chats))
+	if len(chats) > 0 {
+		type insertData struct {
+			Channel string                   `db:"channel"`
+			Content types.TransitChatContent `db:"content"`
+		}
+		insertRows := make([]insertData, 0, len(chats))
+		for _, c := range chats {
+			insertRows = append(insertRows, insertData{
+				Channel: c.Channel,
+				Content: c.Content,
+			})
+			metrics.NotificationsQueued.WithLabelValues(c.Channel, "multi").Inc()
+		}
+		_, err = tx.NamedExec(`INSERT INTO notification_queue (created, channel, content) VALUES (NOW(), :channel, :content)`, insertRows)
+		if err != nil {
+			return fmt.Errorf("error writing transit chat to db: %w", err)
+		}
+	}
+	return nil
+}
+
+// renderChatMessage renders the notifications of a dashboard group as a channel independent chat message with one section per event
+func renderChatMessage(notificationsPerGroup types.NotificationsPerEventName) types.TransitChatContent {
+	content := types.TransitChatContent{}
+	for _, event := range types.EventSortOrder {
+		notifications, exists := notificationsPerGroup[event]
+		if !exists || len(notifications) == 0 {
+			continue
+		}
+		if content.Title == "" {
+			for _, n := range notifications {
+				content.Title = fmt.Sprintf("%s: %s / %s", utils.Config.Frontend.SiteDomain, n.GetDashboardName(), n.GetDashboardGroupName())
+				break
+			}
+		}
+		details, totalBlockReward, epoch := getEventDetails(event, notifications, types.NotifciationFormatMarkdown)
+		if content.Epoch == 0 {
+			content.Epoch = epoch
+		}
+		content.Sections = append(content.Sections, types.ChatMessageSection{
+			Summary: getEventSummary(event, len(notifications), totalBlockReward),
+			Details: strings.TrimSuffix(details, "\n"),
+		})
+	}
+	return content
+}
+
+func getChatEpochUrl(epoch uint64) string {
+	return fmt.Sprintf("https://%s/epoch/%d", utils.Config.Frontend.SiteDomain, epoch)
+}
+
+// markdownToHtml converts the markdown subset used by the notification formatting (links and bold text) to html
+func markdownToHtml(s string) string {
+	s = html.EscapeString(s)
+	s = markdownLinkRegex.ReplaceAllString(s, `<a href="$2">$1</a>`)
+	s = markdownBoldRegex.ReplaceAllString(s, "<b>$1</b>")
+	return s
+}
+
+// markdownToSlackMrkdwn converts the markdown subset used by the notification formatting to the slack mrkdwn syntax
+func markdownToSlackMrkdwn(s string) string {
+	s = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+	s = markdownLinkRegex.ReplaceAllString(s, "<$2|$1>")
+	s = markdownBoldRegex.ReplaceAllString(s, "*$1*")
+	return s
+}
+
+// truncateText cuts s to at most maxLength characters, preferably at a line break
+func truncateText(s string, maxLength int) string {
+	if utf8.RuneCountInString(s) <= maxLength {
+		return s
+	}
+	runes := []rune(s)[:maxLength-1]
+	if i := strings.LastIndex(string(runes), "\n"); i > 0 {
+		return string(runes)[:i] + "\n…"
+	}
+	return string(runes) + "…"
+}
+
+// formatTelegramMessage renders a chat message as telegram html, sections that exceed the message size limit are omitted
+func formatTelegramMessage(content types.TransitChatContent) string {
+	msg := fmt.Sprintf("<b>%s</b>\n", html.EscapeString(content.Title))
+	footer := ""
+	if content.Epoch > 0 {
+		footer = fmt.Sprintf("\nEpoch: <a href=\"%s\">%d</a>", getChatEpochUrl(content.Epoch), content.Epoch)
+	}
+	for i, s := range content.Sections {
+		section := fmt.Sprintf("\n<b>%s</b>\n%s\n", html.EscapeString(s.Summary), markdownToHtml(s.Details))
+		if utf8.RuneCountInString(msg)+utf8.RuneCountInString(section)+utf8.RuneCountInString(footer) > telegramMaxMessageLength-100 {
+			msg += fmt.Sprintf("\n... and %d more events\n", len(content.Sections)-i)
+			break
+		}
+		msg += section
+	}
+	return msg + footer
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks,omitempty"`
+}
+
+// formatSlackMessage renders a chat message using slack block kit, text is used as fallback for notifications
+func formatSlackMessage(content types.TransitChatContent) slackMessage {
+	msg := slackMessage{
+		Text: content.Title,
+		Blocks: []slackBlock{
+			{Type: "header", Text: &slackText{Type: "plain_text", Text: truncateText(content.Title, slackMaxHeaderLength)}},
+		},
+	}
+	for i, s := range content.Sections {
+		// leave room for the overflow section and the context block
+		if len(msg.Blocks) >= slackMaxBlocks-2 {
+			msg.Blocks = append(msg.Blocks, slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: fmt.Sprintf("... and %d more events", len(content.Sections)-i)}})
+			break
+		}
+		text := fmt.Sprintf("*%s*\n%s", markdownToSlackMrkdwn(s.Summary), markdownToSlackMrkdwn(s.Details))
+		msg.Blocks = append(msg.Blocks, slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: truncateText(text, slackMaxSectionLength)}})
+	}
+	if content.Epoch > 0 {
+		msg.Blocks = append(msg.Blocks, slackBlock{
+			Type:     "context",
+			Elements: []slackText{{Type: "mrkdwn", Text: fmt.Sprintf("Epoch: <%s|%d>", getChatEpochUrl(content.Epoch), content.Epoch)}},
+		})
+	}
+	return msg
+}
+
+type matrixMessage struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format,omitempty"`
+	FormattedBody string `json:"formatted_body,omitempty"`
+}
+
+// formatMatrixMessage renders a chat message as matrix m.text event, the body contains the markdown representation
+func formatMatrixMessage(content types.TransitChatContent) matrixMessage {
+	body := fmt.Sprintf("**%s**\n", content.Title)
+	formattedBody := fmt.Sprintf("<h4>%s</h4>", html.EscapeString(content.Title))
+	for _, s := range content.Sections {
+		body += fmt.Sprintf("\n**%s**\n%s\n", s.Summary, s.Details)
+		formattedBody += fmt.Sprintf("<p><b>%s</b><br>%s</p>", html.EscapeString(s.Summary), strings.ReplaceAll(markdownToHtml(s.Details), "\n", "<br>"))
+	}
+	if content.Epoch > 0 {
+		body += fmt.Sprintf("\nEpoch: [%d](%s)", content.Epoch, getChatEpochUrl(content.Epoch))
+		formattedBody += fmt.Sprintf("<p>Epoch: <a href=\"%s\">%d</a></p>", getChatEpochUrl(content.Epoch), content.Epoch)
+	}
+	return matrixMessage{
+		MsgType:       "m.text",
+		Body:          body,
+		Format:        "org.matrix.custom.html",
+		FormattedBody: formattedBody,
+	}
+}
+
+// chatSendFunc delivers a chat message and returns the http status code and body of the response
+type chatSendFunc func(ctx context.Context, client *http.Client, id uint64, content types.TransitChatContent) (int, string, error)
+
+func sendTelegramNotifications() error {
+	if utils.Config.Notifications.TelegramBotToken == "" {
+		return nil
+	}
+	return sendChatNotifications(types.TelegramNotificationChannel, NOTIFICAION_TELEGRAM_RATE_LIMIT_BUCKET, func(ctx context.Context, client *http.Client, id uint64, content types.TransitChatContent) (int, string, error) {
+		return sendTelegramMessage(ctx, client, content.Target, formatTelegramMessage(content))
+	})
+}
+
+func sendSlackNotifications() error {
+	return sendChatNotifications(types.SlackNotificationChannel, NOTIFICAION_SLACK_RATE_LIMIT_BUCKET, func(ctx context.Context, client *http.Client, id uint64, content types.TransitChatContent) (int, string, error) {
+		return sendSlackMessage(ctx, client, content.Target, formatSlackMessage(content))
+	})
+}
+
+func sendMatrixNotifications() error {
+	if utils.Config.Notifications.MatrixHomeserverUrl == "" || utils.Config.Notifications.MatrixAccessToken == "" {
+		return nil
+	}
+	return sendChatNotifications(types.MatrixNotificationChannel, NOTIFICAION_MATRIX_RATE_LIMIT_BUCKET, func(ctx context.Context, client *http.Client, id uint64, content types.TransitChatContent) (int, string, error) {
+		// the queue id is used as transaction id so that retries of the same message are deduplicated by the homeserver
+		return sendMatrixMessage(ctx, client, content.Target, fmt.Sprintf("n%d", id), formatMatrixMessage(content))
+	})
+}
+
+// sendChatNotifications delivers all pending queue entries of the given chat channel.
+// Failed deliveries are retried with the same backoff as webhooks, client errors move the entry to the dead letters.
+func sendChatNotifications(channel types.NotificationChannel, rateLimitBucket string, send chatSendFunc) error {
+	var notificationQueueItem []types.TransitChat
+
+	err := db.WriterDb.Select(&notificationQueueItem, `SELECT
+		id,
+		created,
+		sent,
+		retries,
+		channel,
+		content
+	FROM notification_queue WHERE sent IS null AND channel = $1 AND (next_attempt_ts IS NULL OR next_attempt_ts <= NOW()) ORDER BY created ASC`, channel)
+	if err != nil {
+		return fmt.Errorf("error querying notification queue, err: %w", err)
+	}
+
+	client := &http.Client{Timeout: getWebhookTimeout()}
+
+	log.Infof("processing %v %s notifications", len(notificationQueueItem), channel)
+
+	// use an error group to throttle chat requests
+	g := &errgroup.Group{}
+	g.SetLimit(20) // issue at most 20 requests at a time
+	for _, n := range notificationQueueItem {
+		n := n
+		if exceedsChatDailyCap(channel, rateLimitBucket, n.Content.UserId) {
+			metrics.NotificationsSent.WithLabelValues(string(channel), "429").Inc()
+			_, err = db.WriterDb.Exec(`UPDATE notification_queue SET sent = now() WHERE id = $1`, n.Id)
+			if err != nil {
+				return fmt.Errorf("error updating sent status for %s notification with id: %v, err: %w", channel, n.Id, err)
+			}
+			continue
+		}
+
+		g.Go(func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), getWebhookTimeout())
+			defer cancel()
+
+			status, body, err := send(ctx, client, n.Id, n.Content)
+			if err != nil {
+				log.Warnf("error sending %s message: %v", channel, err)
+				metrics.NotificationsSent.WithLabelValues(string(channel), "error").Inc()
+				err = handleFailedWebhookDelivery(n.Id, n.Retries, n.Content.UserId, "", err.Error())
+				if err != nil {
+					log.Error(err, fmt.Sprintf("error handling failed %s delivery", channel), 0)
+				}
+				return nil
+			}
+			metrics.NotificationsSent.WithLabelValues(string(channel), strconv.Itoa(status)).Inc()
+
+			switch {
+			case status < 300:
+				_, err = db.WriterDb.Exec(`UPDATE notification_queue SET sent = now() WHERE id = $1`, n.Id)
+				if err != nil {
+					log.Error(err, "error updating notification_queue table", 0)
+				}
+			case status >= 400 && status < 500 && status != http.StatusTooManyRequests:
+				// the target is invalid or does not accept messages from us anymore, retrying will not help
+				err = moveToDeadLetters(n.Id, n.Content.UserId, true, strconv.Itoa(status), body)
+				if err != nil {
+					log.Error(err, fmt.Sprintf("error moving failed %s delivery to dead letters", channel), 0)
+				}
+			default:
+				err = handleFailedWebhookDelivery(n.Id, n.Retries, n.Content.UserId, strconv.Itoa(status), body)
+				if err != nil {
+					log.Error(err, fmt.Sprintf("error handling failed %s delivery", channel), 0)
+				}
+			}
+			return nil
+		})
+	}
+
+	err = g.Wait()
+	if err != nil {
+		log.Error(err, "error waiting for errgroup", 0)
+	}
+	return nil
+}
+
+// countSentChatMessage increments and returns the number of messages sent to a user today, it is replaced in tests
+var countSentChatMessage = db.CountSentMessage
+
+// exceedsChatDailyCap counts a message to the user and reports whether it exceeds the daily cap of the chat channel.
+// Messages are sent if the counter is unavailable.
+func exceedsChatDailyCap(channel types.NotificationChannel, rateLimitBucket string, userId types.UserId) bool {
+	count, err := countSentChatMessage(rateLimitBucket, userId)
+	if err != nil {
+		log.Error(err, fmt.Sprintf("error counting sent %s message", channel), 0)
+		return false
+	}
+	return count > chatNotificationsPerDay
+}
+
+// doChatRequest executes a json request and returns the status code and body of the response
+func doChatRequest(ctx context.Context, client *http.Client, method, url string, headers map[string]string, payload interface{}) (int, string, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return 0, "", fmt.Errorf("error marshalling request: %w", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return 0, "", fmt.Errorf("error creating request: %w", err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	if err != nil {
+		log.Error(err, "error reading body", 0)
+	}
+	return resp.StatusCode, string(b), nil
+}
+
+func getTelegramApiUrl(method string) string {
+	return fmt.Sprintf("%s/bot%s/%s", telegramApiUrl, utils.Config.Notifications.TelegramBotToken, method)
+}
+
+func sendTelegramMessage(ctx context.Context, client *http.Client, chatId, text string) (int, string, error) {
+	return doChatRequest(ctx, client, http.MethodPost, getTelegramApiUrl("sendMessage"), nil, map[string]interface{}{
+		"chat_id":                  chatId,
+		"text":                     text,
+		"parse_mode":               "HTML",
+		"disable_web_page_preview": true,
+	})
+}
+
+func sendSlackMessage(ctx context.Context, client *http.Client, webhookUrl string, msg slackMessage) (int, string, error) {
+	return doChatRequest(ctx, client, http.MethodPost, webhookUrl, nil, msg)
+}
+
+func getMatrixApiUrl(path ...string) string {
+	for i, p := range path {
+		path[i] = url.PathEscape(p)
+	}
+	return fmt.Sprintf("%s/_matrix/client/v3/%s", strings.TrimSuffix(utils.Config.Notifications.MatrixHomeserverUrl, "/"), strings.Join(path, "/"))
+}
+
+// sendMatrixMessage sends a message to a matrix room. Users invite our matrix user to their room,
+// if we are not a member of the room yet a pending invite is accepted and the message is sent again.
+// Rooms we have not been invited to are never joined, even if they are public.
+func sendMatrixMessage(ctx context.Context, client *http.Client, roomId, txnId string, msg matrixMessage) (int, string, error) {
+	headers := map[string]string{"Authorization": "Bearer " + utils.Config.Notifications.MatrixAccessToken}
+	status, body, err := doChatRequest(ctx, client, http.MethodPut, getMatrixApiUrl("rooms", roomId, "send", "m.room.message", txnId), headers, msg)
+	if err != nil || status != http.StatusForbidden {
+		return status, body, err
+	}
+
+	invited, err := hasMatrixInvite(ctx, client, headers, roomId)
+	if err != nil {
+		return 0, "", err
+	}
+	if !invited {
+		return status, body, nil
+	}
+
+	joinStatus, joinBody, err := doChatRequest(ctx, client, http.MethodPost, getMatrixApiUrl("join", roomId), headers, struct{}{})
+	if err != nil {
+		return 0, "", fmt.Errorf("error joining matrix room: %w", err)
+	}
+	if joinStatus >= 300 {
+		return joinStatus, joinBody, nil
+	}
+	return doChatRequest(ctx, client, http.MethodPut, getMatrixApiUrl("rooms", roomId, "send", "m.room.message", txnId), headers, msg)
+}
+
+// hasMatrixInvite reports whether our matrix user has a pending invite to the room
+func hasMatrixInvite(ctx context.Context, client *http.Client, headers map[string]string, roomId string) (bool, error) {
+	filter, err := json.Marshal(map[string]interface{}{
+		"presence":     map[string]interface{}{"not_types": []string{"*"}},
+		"account_data": map[string]interface{}{"not_types": []string{"*"}},
+		"room": map[string]interface{}{
+			"rooms":    []string{roomId},
+			"timeline": map[string]interface{}{"limit": 0},
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("error marshalling matrix sync filter: %w", err)
+	}
+	syncUrl := fmt.Sprintf("%s?timeout=0&filter=%s", getMatrixApiUrl("sync"), url.QueryEscape(string(filter)))
+	status, body, err := doChatRequest(ctx, client, http.MethodGet, syncUrl, headers, nil)
+	if err != nil {
+		return false, fmt.Errorf("error retrieving matrix invites: %w", err)
+	}
+	if status != http.StatusOK {
+		return false, fmt.Errorf("error retrieving matrix invites, status: %d, body: %s", status, body)
+	}
+
+	var resp struct {
+		Rooms struct {
+			Invite map[string]json.RawMessage `json:"invite"`
+		} `json:"rooms"`
+	}
+	err = json.Unmarshal([]byte(body), &resp)
+	if err != nil {
+		return false, fmt.Errorf("error decoding matrix sync response: %w", err)
+	}
+	_, invited := resp.Rooms.Invite[roomId]
+	return invited, nil
+}
+
+// telegramUpdateOffset is the id of the next telegram bot update that has not been processed yet
+var telegramUpdateOffset int64
+
+type telegramUpdate struct {
+	UpdateId int64 `json:"update_id"`
+	Message  *struct {
+		Text string `json:"text"`
+		Chat struct {
+			Id int64 `json:"id"`
+		} `json:"chat"`
+	} `json:"message"`
+}
+
+// processTelegramUpdates handles the commands that have been sent to the telegram bot.
+// `/start <token>` links the chat to the user that requested the token, `/stop` unlinks the chat.
+func processTelegramUpdates() error {
+	if utils.Config.Notifications.TelegramBotToken == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	client := &http.Client{Timeout: getWebhookTimeout()}
+	status, body, err := doChatRequest(ctx, client, http.MethodPost, getTelegramApiUrl("getUpdates"), nil, map[string]interface{}{
+		"offset":          telegramUpdateOffset,
+		"allowed_updates": []string{"message"},
+	})
+	if err != nil {
+		return fmt.Errorf("error retrieving telegram updates: %w", err)
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("error retrieving telegram updates, status: %d, body: %s", status, body)
+	}
+
+	var resp struct {
+		Result []telegramUpdate `json:"result"`
+	}
+	err = json.Unmarshal([]byte(body), &resp)
+	if err != nil {
+		return fmt.Errorf("error decoding telegram updates: %w", err)
+	}
+
+	for _, u := range resp.Result {
+		if u.UpdateId >= telegramUpdateOffset {
+			telegramUpdateOffset = u.UpdateId + 1
+		}
+		if u.Message == nil {
+			continue
+		}
+		reply, err := handleTelegramCommand(ctx, u.Message.Chat.Id, u.Message.Text)
+		if err != nil {
+			log.Error(err, "error handling telegram command", 0, log.Fields{"chat_id": u.Message.Chat.Id})
+			continue
+		}
+		if reply == "" {
+			continue
+		}
+		status, body, err := sendTelegramMessage(ctx, client, strconv.FormatInt(u.Message.Chat.Id, 10), reply)
+		if err != nil || status != http.StatusOK {
+			log.WarnWithFields(log.Fields{"chat_id": u.Message.Chat.Id, "status": status, "body": body, "error": err}, "error replying to telegram command")
+		}
+	}
+	return nil
+}
+
+// handleTelegramCommand executes a bot command and returns the reply for the chat
+func handleTelegramCommand(ctx context.Context, chatId int64, text string) (string, error) {
+	command, linkToken := parseTelegramCommand(text)
+
+	switch command {
+	case "/start":
+		if linkToken == "" {
+			return fmt.Sprintf("To receive notifications please link this chat in the notification settings on https://%s/notifications", utils.Config.Frontend.SiteDomain), nil
+		}
+		res, err := db.FrontendWriterDB.ExecContext(ctx, `
+			UPDATE users_telegram_chats SET chat_id = $1, linked_ts = NOW(), link_token = NULL, link_token_expires_ts = NULL
+			WHERE link_token = $2 AND link_token_expires_ts > NOW()`, chatId, linkToken)
+		if err != nil {
+			return "", fmt.Errorf("error linking telegram chat: %w", err)
+		}
+		if rows, err := res.RowsAffected(); err != nil || rows == 0 {
+			return "This link is invalid or has expired, please request a new one in the notification settings.", nil
+		}
+		return fmt.Sprintf("This chat is now linked to your %s account. Enable telegram notifications for your dashboard groups to receive them here, send /stop to unlink the chat.", utils.Config.Frontend.SiteDomain), nil
+	case "/stop":
+		_, err := db.FrontendWriterDB.ExecContext(ctx, `UPDATE users_telegram_chats SET chat_id = NULL, linked_ts = NULL WHERE chat_id = $1`, chatId)
+		if err != nil {
+			return "", fmt.Errorf("error unlinking telegram chat: %w", err)
+		}
+		return "This chat has been unlinked, you will not receive any further notifications here.", nil
+	}
+	return "", nil
+}
+
+// parseTelegramCommand returns the bot command of a message and its first argument
+func parseTelegramCommand(text string) (command, argument string) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return "", ""
+	}
+	// commands in group chats are suffixed with the name of the bot
+	command, _, _ = strings.Cut(fields[0], "@")
+	if len(fields) > 1 {
+		argument = fields[1]
+	}
+	return command, argument
+}
+
+func getTestChatMessage() types.TransitChatContent {
+	return types.TransitChatContent{
+		Title: fmt.Sprintf("%s: Test Notification", utils.Config.Frontend.SiteDomain),
+		Sections: []types.ChatMessageSection{
+			{Summary: "Test Notification", Details: "This is a test notification from beaconcha.in"},
+		},
+	}
+}
+
+func checkTestChatRateLimit(userId types.UserId) error {
+	count, err := db.CountSentMessage(NOTIFICATION_TEST_CHAT_RATE_LIMIT_BUCKET, userId)
+	if err != nil {
+		return err
+	}
+	if count > 100 {
+		return fmt.Errorf("rate limit has been exceeded")
+	}
+	return nil
+}
+
+func checkTestChatResponse(channel types.NotificationChannel, status int, body string, err error) error {
+	if err != nil {
+		return fmt.Errorf("error sending %s test message: %w", channel, err)
+	}
+	if status >= 300 {
+		return fmt.Errorf("error sending %s test message, status: %d, body: %s", channel, status, body)
+	}
+	return nil
+}
+
+// SendTestTelegramNotification sends a test message to the telegram chat linked by the user
+func SendTestTelegramNotification(ctx context.Context, userId types.UserId, dbConn *sqlx.DB) error {
+	if utils.Config.Notifications.TelegramBotToken == "" {
+		return fmt.Errorf("telegram notifications are not configured")
+	}
+	var chatId sql.NullInt64
+	err := dbConn.GetContext(ctx, &chatId, `SELECT chat_id FROM users_telegram_chats WHERE user_id = $1`, userId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if !chatId.Valid {
+		return ErrTelegramNotLinked
+	}
+	err = checkTestChatRateLimit(userId)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: time.Second * 5}
+	status, body, err := sendTelegramMessage(ctx, client, strconv.FormatInt(chatId.Int64, 10), formatTelegramMessage(getTestChatMessage()))
+	return checkTestChatResponse(types.TelegramNotificationChannel, status, body, err)
+}
+
+// SendTestSlackNotification sends a test message to the given slack incoming webhook
+func SendTestSlackNotification(ctx context.Context, userId types.UserId, webhookUrl string) error {
+	err := checkTestChatRateLimit(userId)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: time.Second * 5}
+	status, body, err := sendSlackMessage(ctx, client, webhookUrl, formatSlackMessage(getTestChatMessage()))
+	return checkTestChatResponse(types.SlackNotificationChannel, status, body, err)
+}
+
+// SendTestMatrixNotification sends a test message to the given matrix room
+func SendTestMatrixNotification(ctx context.Context, userId types.UserId, roomId string) error {
+	if utils.Config.Notifications.MatrixHomeserverUrl == "" || utils.Config.Notifications.MatrixAccessToken == "" {
+		return fmt.Errorf("matrix notifications are not configured")
+	}
+	err := checkTestChatRateLimit(userId)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: time.Second * 5}
+	txnId := fmt.Sprintf("test%d", time.Now().UnixNano())
+	status, body, err := sendMatrixMessage(ctx, client, roomId, txnId, formatMatrixMessage(getTestChatMessage()))
+	return checkTestChatResponse(types.MatrixNotificationChannel, status, body, err)
+}
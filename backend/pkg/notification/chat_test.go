@@ -0,0 +1,136 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+)
+
+func TestChatMarkdownConversion(t *testing.T) {
+	input := "Validator [5](https://beaconcha.in/validator/5) of **Group <A & B>** missed an attestation"
+
+	wantHtml := `Validator <a href="https://beaconcha.in/validator/5">5</a> of <b>Group &lt;A &amp; B&gt;</b> missed an attestation`
+	if got := markdownToHtml(input); got != wantHtml {
+		t.Errorf("markdownToHtml: expected %q, got %q", wantHtml, got)
+	}
+
+	wantSlack := "Validator <https://beaconcha.in/validator/5|5> of *Group &lt;A &amp; B&gt;* missed an attestation"
+	if got := markdownToSlackMrkdwn(input); got != wantSlack {
+		t.Errorf("markdownToSlackMrkdwn: expected %q, got %q", wantSlack, got)
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	if got := truncateText("short", 10); got != "short" {
+		t.Errorf("expected text to be unchanged, got %q", got)
+	}
+	if got := truncateText("line one\nline two", 12); got != "line one\n…" {
+		t.Errorf("expected text to be cut at the line break, got %q", got)
+	}
+}
+
+func TestParseTelegramCommand(t *testing.T) {
+	tests := []struct {
+		text         string
+		wantCommand  string
+		wantArgument string
+	}{
+		{text: "", wantCommand: "", wantArgument: ""},
+		{text: "/start", wantCommand: "/start", wantArgument: ""},
+		{text: "/start token", wantCommand: "/start", wantArgument: "token"},
+		{text: "/start@beaconchain_bot  token extra", wantCommand: "/start", wantArgument: "token"},
+		{text: "/stop@beaconchain_bot", wantCommand: "/stop", wantArgument: ""},
+	}
+	for _, tt := range tests {
+		command, argument := parseTelegramCommand(tt.text)
+		if command != tt.wantCommand || argument != tt.wantArgument {
+			t.Errorf("parseTelegramCommand(%q) = (%q, %q), expected (%q, %q)", tt.text, command, argument, tt.wantCommand, tt.wantArgument)
+		}
+	}
+}
+
+func TestExceedsChatDailyCap(t *testing.T) {
+	counts := map[types.UserId]int64{}
+	countSent := countSentChatMessage
+	defer func() { countSentChatMessage = countSent }()
+	countSentChatMessage = func(bucket string, userId types.UserId) (int64, error) {
+		if userId == 0 {
+			return 0, fmt.Errorf("counter unavailable")
+		}
+		counts[userId]++
+		return counts[userId], nil
+	}
+
+	for i := 0; i < chatNotificationsPerDay; i++ {
+		if exceedsChatDailyCap(types.TelegramNotificationChannel, NOTIFICAION_TELEGRAM_RATE_LIMIT_BUCKET, 1) {
+			t.Fatalf("expected message %d to be within the daily cap", i+1)
+		}
+	}
+	if !exceedsChatDailyCap(types.TelegramNotificationChannel, NOTIFICAION_TELEGRAM_RATE_LIMIT_BUCKET, 1) {
+		t.Errorf("expected message %d to exceed the daily cap", chatNotificationsPerDay+1)
+	}
+	if exceedsChatDailyCap(types.TelegramNotificationChannel, NOTIFICAION_TELEGRAM_RATE_LIMIT_BUCKET, 2) {
+		t.Errorf("expected the cap to be tracked per user")
+	}
+	if exceedsChatDailyCap(types.TelegramNotificationChannel, NOTIFICAION_TELEGRAM_RATE_LIMIT_BUCKET, 0) {
+		t.Errorf("expected messages to be sent if the counter is unavailable")
+	}
+}
+
+func TestSendMatrixMessageJoinsOnlyInvitedRooms(t *testing.T) {
+	const roomId = "!room:example.org"
+	for _, invited := range []bool{true, false} {
+		t.Run(fmt.Sprintf("invited=%v", invited), func(t *testing.T) {
+			joined := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case strings.HasPrefix(r.URL.Path, "/_matrix/client/v3/rooms/"):
+					if !joined {
+						w.WriteHeader(http.StatusForbidden)
+						fmt.Fprint(w, `{"errcode":"M_FORBIDDEN"}`)
+						return
+					}
+					fmt.Fprint(w, `{"event_id":"$event"}`)
+				case r.URL.Path == "/_matrix/client/v3/sync":
+					invites := "{}"
+					if invited {
+						invites = fmt.Sprintf(`{%q:{}}`, roomId)
+					}
+					fmt.Fprintf(w, `{"rooms":{"invite":%s}}`, invites)
+				case r.URL.Path == "/_matrix/client/v3/join/"+roomId:
+					joined = true
+					fmt.Fprintf(w, `{"room_id":%q}`, roomId)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			config := utils.Config
+			utils.Config = &types.Config{}
+			utils.Config.Notifications.MatrixHomeserverUrl = server.URL
+			defer func() { utils.Config = config }()
+
+			status, _, err := sendMatrixMessage(context.Background(), server.Client(), roomId, "txn", formatMatrixMessage(getTestChatMessage()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if joined != invited {
+				t.Errorf("expected the room to be joined: %v, joined: %v", invited, joined)
+			}
+			wantStatus := http.StatusForbidden
+			if invited {
+				wantStatus = http.StatusOK
+			}
+			if status != wantStatus {
+				t.Errorf("expected status %d, got %d", wantStatus, status)
+			}
+		})
+	}
+}
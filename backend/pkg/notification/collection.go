@@ -1,17 +1,19 @@
 package notification
 
 import (
+	"bytes"
+	"context"
 	"database/sql"
 	"encoding/gob"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	gcp_bigtable "cloud.google.com/go/bigtable"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/gobitfly/beaconchain/pkg/commons/cache"
@@ -20,10 +22,12 @@ import (
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
 	"github.com/gobitfly/beaconchain/pkg/commons/metrics"
 	"github.com/gobitfly/beaconchain/pkg/commons/services"
+	"github.com/gobitfly/beaconchain/pkg/commons/storage"
 	"github.com/gobitfly/beaconchain/pkg/commons/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
 	constypes "github.com/gobitfly/beaconchain/pkg/consapi/types"
 	"github.com/gobitfly/beaconchain/pkg/exporter/modules"
+	"github.com/gobitfly/beaconchain/pkg/nodejobs"
 	"github.com/lib/pq"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/shopspring/decimal"
@@ -40,14 +44,11 @@ func InitNotificationCollector(pubkeyCachePath string) {
 	go notificationCollector()
 }
 
-// the notificationCollector is responsible for collecting & queuing notifications
-// it is epoch based and will only collect notification for a given epoch once
-// notifications are collected in ascending epoch order
-// the epochs_notified sql table is used to keep track of already notified epochs
-// before collecting notifications several db consistency checks are done
-func notificationCollector() {
-	var once sync.Once
-	once.Do(func() {
+var registerNotificationTypesOnce sync.Once
+
+// registerNotificationTypes registers all notification types with gob so they can be encoded as interface values
+func registerNotificationTypes() {
+	registerNotificationTypesOnce.Do(func() {
 		gob.Register(&ValidatorProposalNotification{})
 		gob.Register(&ValidatorUpcomingProposalNotification{})
 		gob.Register(&ValidatorGroupEfficiencyNotification{})
@@ -56,6 +57,7 @@ func notificationCollector() {
 		gob.Register(&ValidatorIsOnlineNotification{})
 		gob.Register(&ValidatorGotSlashedNotification{})
 		gob.Register(&ValidatorWithdrawalNotification{})
+		gob.Register(&ValidatorPoolOperationNotIncludedNotification{})
 		gob.Register(&NetworkNotification{})
 		gob.Register(&RocketpoolNotification{})
 		gob.Register(&MonitorMachineNotification{})
@@ -64,9 +66,20 @@ func notificationCollector() {
 		gob.Register(&SyncCommitteeSoonNotification{})
 		gob.Register(&GasAboveThresholdNotification{})
 		gob.Register(&GasBelowThresholdNotification{})
+		gob.Register(&AccountTransactionNotification{})
+		gob.Register(&AccountTokenTransferNotification{})
 	})
+}
+
+// the notificationCollector is responsible for collecting & queuing notifications
+// it is epoch based and will only collect notification for a given epoch once
+// notifications are collected in ascending epoch order
+// the epochs_notified sql table is used to keep track of already notified epochs
+// before collecting notifications several db consistency checks are done
+func notificationCollector() {
+	registerNotificationTypes()
 
-	mc, err := modules.GetModuleContext()
+	mc, err := modules.GetModuleContext(context.Background())
 	if err != nil {
 		log.Fatal(err, "error getting module context", 0)
 	}
@@ -238,7 +251,7 @@ func collectUpcomingBlockProposalNotifications(notificationsByUserID types.Notif
 		return nil
 	}
 
-	assignments, err := mc.CL.GetPropoalAssignments(nextEpoch)
+	assignments, err := mc.CL.GetPropoalAssignments(context.Background(), nextEpoch)
 	if err != nil {
 		return fmt.Errorf("error getting proposal assignments: %w", err)
 	}
@@ -370,6 +383,29 @@ func collectNotifications(mc modules.ModuleContext, epoch uint64) (types.Notific
 	}
 	log.Infof("collecting withdrawal notifications took: %v", time.Since(start))
 
+	err = collectPoolOperationNotIncludedNotifications(notificationsByUserID, epoch)
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_collect_pool_operation_not_included").Inc()
+		return nil, fmt.Errorf("error collecting pool operation not included notifications: %v", err)
+	}
+	log.Infof("collecting pool operation not included notifications took: %v", time.Since(start))
+
+	// not a notification, but the triggers of conditional node jobs depend on the same epoch data
+	err = nodejobs.TriggerConditionalVoluntaryExitsNodeJobs(epoch)
+	if err != nil {
+		// a failing trigger must not hold back the notifications, the jobs are evaluated again in the next epoch
+		metrics.Errors.WithLabelValues("notifications_collect_node_job_triggers").Inc()
+		log.Error(err, "error evaluating node job triggers", 0, log.Fields{"epoch": epoch})
+	}
+	log.Infof("evaluating node job triggers took: %v", time.Since(start))
+
+	err = collectAccountDashboardTransactionNotifications(notificationsByUserID, epoch)
+	if err != nil {
+		metrics.Errors.WithLabelValues("notifications_collect_account_dashboard_transactions").Inc()
+		return nil, fmt.Errorf("error collecting account dashboard transaction notifications: %v", err)
+	}
+	log.Infof("collecting account dashboard transaction notifications took: %v", time.Since(start))
+
 	err = collectNetworkNotifications(notificationsByUserID)
 	if err != nil {
 		metrics.Errors.WithLabelValues("notifications_collect_network").Inc()
@@ -581,7 +617,7 @@ func collectGroupEfficiencyNotifications(notificationsByUserID types.Notificatio
 
 	// retrieve rewards for the epoch
 	log.Info("retrieving validator metadata")
-	validators, err := mc.CL.GetValidators(epoch*utils.Config.Chain.ClConfig.SlotsPerEpoch, nil, []constypes.ValidatorStatus{constypes.Active})
+	validators, err := mc.CL.GetValidators(context.Background(), epoch*utils.Config.Chain.ClConfig.SlotsPerEpoch, nil, []constypes.ValidatorStatus{constypes.Active})
 	if err != nil {
 		return fmt.Errorf("error getting validators: %w", err)
 	}
@@ -592,7 +628,7 @@ func collectGroupEfficiencyNotifications(notificationsByUserID types.Notificatio
 		activeValidatorsMap[validator.Index] = struct{}{}
 	}
 	log.Info("retrieving attestation reward data")
-	attestationRewards, err := mc.CL.GetAttestationRewards(epoch)
+	attestationRewards, err := mc.CL.GetAttestationRewards(context.Background(), epoch)
 	if err != nil {
 		return fmt.Errorf("error getting attestation rewards: %w", err)
 	}
@@ -612,7 +648,7 @@ func collectGroupEfficiencyNotifications(notificationsByUserID types.Notificatio
 	}
 
 	log.Info("retrieving block proposal data")
-	proposalAssignments, err := mc.CL.GetPropoalAssignments(epoch)
+	proposalAssignments, err := mc.CL.GetPropoalAssignments(context.Background(), epoch)
 	if err != nil {
 		return fmt.Errorf("error getting proposal assignments: %w", err)
 	}
@@ -627,14 +663,14 @@ func collectGroupEfficiencyNotifications(notificationsByUserID types.Notificatio
 		efficiencyMap[types.ValidatorIndex(assignment.ValidatorIndex)].BlocksScheduled++
 	}
 
-	syncAssignments, err := mc.CL.GetSyncCommitteesAssignments(nil, epoch*utils.Config.Chain.ClConfig.SlotsPerEpoch)
+	syncAssignments, err := mc.CL.GetSyncCommitteesAssignments(context.Background(), nil, epoch*utils.Config.Chain.ClConfig.SlotsPerEpoch)
 	if err != nil {
 		return fmt.Errorf("error getting sync committee assignments: %w", err)
 	}
 
 	for slot := epoch * utils.Config.Chain.ClConfig.SlotsPerEpoch; slot < (epoch+1)*utils.Config.Chain.ClConfig.SlotsPerEpoch; slot++ {
 		log.Infof("retrieving data for slot %v", slot)
-		s, err := mc.CL.GetSlot(slot)
+		s, err := mc.CL.GetSlot(context.Background(), slot)
 		if err != nil && strings.Contains(err.Error(), "NOT_FOUND") {
 			continue
 		} else if err != nil {
@@ -1364,6 +1400,279 @@ func collectWithdrawalNotifications(notificationsByUserID types.NotificationsPer
 	return nil
 }
 
+const (
+	// poolOperationNotIncludedDefaultEpochs is used for subscriptions without a threshold
+	poolOperationNotIncludedDefaultEpochs = 4
+	// poolOperationNotIncludedMaxEpochs is the highest threshold a subscription can configure
+	poolOperationNotIncludedMaxEpochs = 100
+)
+
+// collectPoolOperationNotIncludedNotifications notifies about voluntary exits and bls to execution changes that have been
+// seen in the op pool but haven't been included in a block within the epoch threshold of the subscription
+func collectPoolOperationNotIncludedNotifications(notificationsByUserID types.NotificationsPerUserId, epoch uint64) error {
+	subMap, err := GetSubsForEventFilter(types.ValidatorPoolOperationNotIncludedEventName, "", nil, nil)
+	if err != nil {
+		return fmt.Errorf("error getting subscriptions for pool operations not included %w", err)
+	}
+	if len(subMap) == 0 {
+		return nil
+	}
+
+	slotsPerEpoch := utils.Config.Chain.ClConfig.SlotsPerEpoch
+	fromEpoch := uint64(0)
+	if epoch > poolOperationNotIncludedMaxEpochs {
+		fromEpoch = epoch - poolOperationNotIncludedMaxEpochs
+	}
+	operations, err := db.GetPoolOperationsFirstSeenSince(fromEpoch * slotsPerEpoch)
+	if err != nil {
+		return fmt.Errorf("error getting pool operations from database, err: %w", err)
+	}
+
+	for _, operation := range operations {
+		// operations included by the end of the epoch made it in time
+		if operation.IncludedSlot.Valid && uint64(operation.IncludedSlot.Int64) < (epoch+1)*slotsPerEpoch {
+			continue
+		}
+		subscribers, ok := subMap[hex.EncodeToString(operation.Pubkey)]
+		if !ok {
+			continue
+		}
+		for _, sub := range subscribers {
+			if sub.UserID == nil || sub.ID == nil {
+				return fmt.Errorf("error expected userId and subId to be defined but got user: %v, sub: %v", sub.UserID, sub.ID)
+			}
+			threshold := uint64(sub.EventThreshold)
+			if threshold == 0 {
+				threshold = poolOperationNotIncludedDefaultEpochs
+			}
+			// notify exactly once, in the epoch in which the threshold has been exceeded
+			if utils.EpochOfSlot(operation.FirstSeenSlot)+threshold != epoch || epoch < sub.CreatedEpoch {
+				continue
+			}
+			log.Infof("creating %v notification for validator %v in epoch %v", types.ValidatorPoolOperationNotIncludedEventName, operation.ValidatorIndex, epoch)
+			n := &ValidatorPoolOperationNotIncludedNotification{
+				NotificationBaseImpl: types.NotificationBaseImpl{
+					SubscriptionID:     *sub.ID,
+					UserID:             *sub.UserID,
+					EventFilter:        hex.EncodeToString(operation.Pubkey),
+					EventName:          sub.EventName,
+					DashboardId:        sub.DashboardId,
+					DashboardName:      sub.DashboardName,
+					DashboardGroupId:   sub.DashboardGroupId,
+					DashboardGroupName: sub.DashboardGroupName,
+					Epoch:              epoch,
+				},
+				ValidatorIndex: operation.ValidatorIndex,
+				OperationType:  operation.Type,
+				FirstSeenSlot:  operation.FirstSeenSlot,
+				Epochs:         threshold,
+			}
+			notificationsByUserID.AddNotification(n)
+			metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+		}
+	}
+
+	return nil
+}
+
+func collectAccountDashboardTransactionNotifications(notificationsByUserID types.NotificationsPerUserId, epoch uint64) error {
+	// get all account dashboard subscriptions keyed by the address of the subscribed accounts
+	subMap, err := getSubsForAccountDashboardEvents([]types.EventName{
+		types.IncomingTransactionEventName,
+		types.OutgoingTransactionEventName,
+		types.ERC20TokenTransferEventName,
+		types.ERC721TokenTransferEventName,
+		types.ERC1155TokenTransferEventName,
+	})
+	if err != nil {
+		return fmt.Errorf("error getting subscriptions for account dashboard events: %w", err)
+	}
+	if len(subMap) == 0 {
+		return nil
+	}
+
+	var blockNumbers []uint64
+	err = db.WriterDb.Select(&blockNumbers, `SELECT exec_block_number FROM blocks WHERE epoch = $1 AND status = '1' AND exec_block_number > 0 ORDER BY exec_block_number`, epoch)
+	if err != nil {
+		return fmt.Errorf("error getting execution blocks of epoch %v: %w", epoch, err)
+	}
+
+	chainId := utils.Config.Chain.ClConfig.DepositChainID
+	erc20MetadataByToken := make(map[string]*types.ERC20Metadata)
+	getERC20Metadata := func(tokenAddress []byte) *types.ERC20Metadata {
+		key := hex.EncodeToString(tokenAddress)
+		if metadata, ok := erc20MetadataByToken[key]; ok {
+			return metadata
+		}
+		metadata, err := db.BigtableClient.GetERC20MetadataForAddress(tokenAddress)
+		if err != nil {
+			log.Warnf("error retrieving erc20 metadata for token %#x: %v", tokenAddress, err)
+			metadata = nil
+		}
+		erc20MetadataByToken[key] = metadata
+		return metadata
+	}
+
+	// getSubs returns the subscriptions of an address for the given event that have not been notified for the epoch yet
+	getSubs := func(address []byte, eventName types.EventName) []*accountDashboardSubscription {
+		var subs []*accountDashboardSubscription
+		for _, sub := range subMap[hex.EncodeToString(address)] {
+			if sub.EventName != eventName || !sub.isSubscribedToChain(chainId) {
+				continue
+			}
+			if sub.LastEpoch != nil && (*sub.LastEpoch >= epoch || epoch < sub.CreatedEpoch) {
+				continue
+			}
+			subs = append(subs, sub)
+		}
+		return subs
+	}
+
+	newNotificationBase := func(sub *accountDashboardSubscription, eventFilter string) types.NotificationBaseImpl {
+		return types.NotificationBaseImpl{
+			SubscriptionID:     *sub.ID,
+			UserID:             *sub.UserID,
+			EventFilter:        eventFilter,
+			EventName:          sub.EventName,
+			DashboardId:        sub.DashboardId,
+			DashboardName:      sub.DashboardName,
+			DashboardGroupId:   sub.DashboardGroupId,
+			DashboardGroupName: sub.DashboardGroupName,
+			Epoch:              epoch,
+		}
+	}
+
+	for _, blockNumber := range blockNumbers {
+		block, err := db.BigtableClient.GetBlockFromBlocksTable(blockNumber)
+		if err != nil {
+			return fmt.Errorf("error getting block %v from bigtable: %w", blockNumber, err)
+		}
+		events, err := db.GetEth1BlockEvents(block)
+		if err != nil {
+			return fmt.Errorf("error getting events of block %v: %w", blockNumber, err)
+		}
+
+		for _, tx := range events.Transactions {
+			for _, direction := range []struct {
+				address   []byte
+				eventName types.EventName
+			}{
+				{tx.GetFrom(), types.OutgoingTransactionEventName},
+				{tx.GetTo(), types.IncomingTransactionEventName},
+			} {
+				for _, sub := range getSubs(direction.address, direction.eventName) {
+					if sub.IgnoreSpamTransactions && utils.IsSpamEth1Transaction(tx, sub.Address) {
+						continue
+					}
+					n := &AccountTransactionNotification{
+						NotificationBaseImpl: newNotificationBase(sub, fmt.Sprintf("%x:%x", sub.Address, tx.GetHash())),
+						Address:              sub.Address,
+						TxHash:               tx.GetHash(),
+						BlockNumber:          tx.GetBlockNumber(),
+						From:                 tx.GetFrom(),
+						To:                   tx.GetTo(),
+						Value:                tx.GetValue(),
+					}
+					notificationsByUserID.AddNotification(n)
+					metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+				}
+			}
+		}
+
+		for i, transfer := range events.ERC20Transfers {
+			metadata := getERC20Metadata(transfer.GetTokenAddress())
+			for _, address := range transferParticipants(transfer.GetFrom(), transfer.GetTo()) {
+				for _, sub := range getSubs(address, types.ERC20TokenTransferEventName) {
+					if sub.IgnoreSpamTransactions && utils.IsSpamERC20Transfer(transfer, metadata) {
+						continue
+					}
+					if sub.EventThreshold > 0 && erc20TransferValueUsd(transfer.GetValue(), metadata).LessThan(decimal.NewFromFloat(sub.EventThreshold)) {
+						continue
+					}
+					n := &AccountTokenTransferNotification{
+						NotificationBaseImpl: newNotificationBase(sub, fmt.Sprintf("%x:%x:%d", sub.Address, transfer.GetParentHash(), i)),
+						Address:              sub.Address,
+						TxHash:               transfer.GetParentHash(),
+						BlockNumber:          transfer.GetBlockNumber(),
+						From:                 transfer.GetFrom(),
+						To:                   transfer.GetTo(),
+						TokenAddress:         transfer.GetTokenAddress(),
+						Value:                transfer.GetValue(),
+					}
+					if metadata != nil {
+						n.TokenSymbol = metadata.Symbol
+						n.TokenDecimals = new(big.Int).SetBytes(metadata.Decimals).Uint64()
+					}
+					notificationsByUserID.AddNotification(n)
+					metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+				}
+			}
+		}
+
+		for i, transfer := range events.ERC721Transfers {
+			for _, address := range transferParticipants(transfer.GetFrom(), transfer.GetTo()) {
+				for _, sub := range getSubs(address, types.ERC721TokenTransferEventName) {
+					n := &AccountTokenTransferNotification{
+						NotificationBaseImpl: newNotificationBase(sub, fmt.Sprintf("%x:%x:%d", sub.Address, transfer.GetParentHash(), i)),
+						Address:              sub.Address,
+						TxHash:               transfer.GetParentHash(),
+						BlockNumber:          transfer.GetBlockNumber(),
+						From:                 transfer.GetFrom(),
+						To:                   transfer.GetTo(),
+						TokenAddress:         transfer.GetTokenAddress(),
+						TokenId:              transfer.GetTokenId(),
+					}
+					notificationsByUserID.AddNotification(n)
+					metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+				}
+			}
+		}
+
+		for i, transfer := range events.ERC1155Transfers {
+			for _, address := range transferParticipants(transfer.GetFrom(), transfer.GetTo()) {
+				for _, sub := range getSubs(address, types.ERC1155TokenTransferEventName) {
+					n := &AccountTokenTransferNotification{
+						NotificationBaseImpl: newNotificationBase(sub, fmt.Sprintf("%x:%x:%d", sub.Address, transfer.GetParentHash(), i)),
+						Address:              sub.Address,
+						TxHash:               transfer.GetParentHash(),
+						BlockNumber:          transfer.GetBlockNumber(),
+						From:                 transfer.GetFrom(),
+						To:                   transfer.GetTo(),
+						TokenAddress:         transfer.GetTokenAddress(),
+						Value:                transfer.GetValue(),
+						TokenId:              transfer.GetTokenId(),
+					}
+					notificationsByUserID.AddNotification(n)
+					metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// transferParticipants returns the distinct addresses involved in a token transfer
+func transferParticipants(from, to []byte) [][]byte {
+	if bytes.Equal(from, to) {
+		return [][]byte{from}
+	}
+	return [][]byte{from, to}
+}
+
+// erc20TransferValueUsd returns the usd value of an erc20 transfer, transfers of tokens without a known price are valued at zero
+func erc20TransferValueUsd(value []byte, metadata *types.ERC20Metadata) decimal.Decimal {
+	if metadata == nil || len(metadata.Price) == 0 {
+		return decimal.Zero
+	}
+	price, err := decimal.NewFromString(string(metadata.Price))
+	if err != nil {
+		return decimal.Zero
+	}
+	decimals := new(big.Int).SetBytes(metadata.Decimals).Int64()
+	return decimal.NewFromBigInt(new(big.Int).SetBytes(value), -int32(decimals)).Mul(price) //nolint:gosec
+}
+
 func collectEthClientNotifications(notificationsByUserID types.NotificationsPerUserId) error {
 	updatedClients := ethclients.GetUpdatedClients() //only check if there are new updates
 	for _, client := range updatedClients {
@@ -1523,7 +1832,7 @@ func collectMonitoringMachine(
 		return err
 	}
 
-	rowKeys := gcp_bigtable.RowList{}
+	rowKeys := storage.RowList{}
 	totalSubscribed := 0
 	for _, data := range dbResult {
 		for _, sub := range data {
@@ -1656,8 +1965,45 @@ func collectTaxReportNotificationNotifications(notificationsByUserID types.Notif
 		return err
 	}
 
+	// the report settings of dashboard subscriptions are stored with the group
+	type incomeReportSettings struct {
+		DashboardId int64          `db:"dashboard_id"`
+		GroupId     int64          `db:"id"`
+		Currency    sql.NullString `db:"income_report_currency"`
+		Format      sql.NullString `db:"income_report_format"`
+	}
+	settingsByGroup := make(map[[2]int64]incomeReportSettings)
+	dashboardIds := make([]int64, 0)
 	for _, subs := range dbResults {
 		for _, sub := range subs {
+			if sub.DashboardId != nil {
+				dashboardIds = append(dashboardIds, *sub.DashboardId)
+			}
+		}
+	}
+	if len(dashboardIds) > 0 {
+		var settings []incomeReportSettings
+		err = db.AlloyReader.Select(&settings, `
+			SELECT dashboard_id, id, income_report_currency, income_report_format
+			FROM users_val_dashboards_groups
+			WHERE dashboard_id = ANY($1)`, pq.Array(dashboardIds))
+		if err != nil {
+			return fmt.Errorf("error getting income report settings: %w", err)
+		}
+		for _, s := range settings {
+			settingsByGroup[[2]int64{s.DashboardId, s.GroupId}] = s
+		}
+	}
+
+	// dashboard subscriptions are hydrated into one subscription per validator, but only one report per subscription must be sent
+	seenSubscriptions := make(map[uint64]bool)
+	for _, subs := range dbResults {
+		for _, sub := range subs {
+			if seenSubscriptions[*sub.ID] {
+				continue
+			}
+			seenSubscriptions[*sub.ID] = true
+
 			n := &TaxReportNotification{
 				NotificationBaseImpl: types.NotificationBaseImpl{
 					SubscriptionID:     *sub.ID,
@@ -1671,6 +2017,11 @@ func collectTaxReportNotificationNotifications(notificationsByUserID types.Notif
 					DashboardGroupName: sub.DashboardGroupName,
 				},
 			}
+			if sub.DashboardId != nil && sub.DashboardGroupId != nil {
+				settings := settingsByGroup[[2]int64{*sub.DashboardId, *sub.DashboardGroupId}]
+				n.Currency = settings.Currency.String
+				n.Format = settings.Format.String
+			}
 			notificationsByUserID.AddNotification(n)
 			metrics.NotificationsCollected.WithLabelValues(string(n.GetEventName())).Inc()
 		}
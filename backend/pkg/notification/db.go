@@ -283,6 +283,161 @@ func GetSubsForEventFilter(eventName types.EventName, lastSentFilter string, las
 	return subMap, nil
 }
 
+// accountDashboardSubscription is a subscription to an account dashboard event hydrated with the settings of the dashboard group
+type accountDashboardSubscription struct {
+	types.Subscription
+
+	Address                []byte
+	IgnoreSpamTransactions bool
+	SubscribedChainIds     []uint64
+}
+
+// isSubscribedToChain reports whether the subscription should receive events of the given chain, an empty list subscribes to all chains
+func (sub *accountDashboardSubscription) isSubscribedToChain(chainId uint64) bool {
+	if len(sub.SubscribedChainIds) == 0 {
+		return true
+	}
+	for _, id := range sub.SubscribedChainIds {
+		if id == chainId {
+			return true
+		}
+	}
+	return false
+}
+
+// getSubsForAccountDashboardEvents retrieves all account dashboard subscriptions for the given events
+// Map key corresponds to the hex encoded address of an account of the subscribed dashboard group
+func getSubsForAccountDashboardEvents(eventNames []types.EventName) (map[string][]*accountDashboardSubscription, error) {
+	eventNamesForQuery := make(pq.StringArray, 0, len(eventNames))
+	for _, eventName := range eventNames {
+		eventNamesForQuery = append(eventNamesForQuery, string(eventName))
+	}
+
+	// account dashboard events are not bound to a network so they are stored without the network prefix
+	var subs []*types.Subscription
+	err := db.FrontendWriterDB.Select(&subs, `
+		SELECT
+			users_subscriptions.id,
+			user_id,
+			event_filter,
+			last_sent_epoch,
+			created_epoch,
+			event_threshold,
+			event_name
+		FROM users_subscriptions
+		INNER JOIN users ON users.id = users_subscriptions.user_id
+		WHERE event_name = ANY($1) AND event_filter LIKE 'adb:%' AND user_id <> 0
+			AND (users.notifications_do_not_disturb_ts IS NULL OR users.notifications_do_not_disturb_ts < NOW())
+			-- filter out users that have all notification channels disabled (but have an entry in the table)
+			AND (select coalesce(bool_or(active), true) from users_notification_channels where users_notification_channels.user_id = users_subscriptions.user_id)`, eventNamesForQuery)
+	if err != nil {
+		return nil, fmt.Errorf("error getting account dashboard subscriptions: %w", err)
+	}
+	log.Infof("found %d account dashboard subscriptions", len(subs))
+
+	subMap := make(map[string][]*accountDashboardSubscription)
+	if len(subs) == 0 {
+		return subMap, nil
+	}
+
+	dashboardIds := make([]int64, 0, len(subs))
+	for _, sub := range subs {
+		dashboardData := strings.Split(sub.EventFilter, ":")
+		if len(dashboardData) != 3 {
+			log.Error(fmt.Errorf("invalid dashboard subscription: %s", sub.EventFilter), "invalid dashboard subscription", 0)
+			continue
+		}
+		dashboardId, err := strconv.ParseInt(dashboardData[1], 10, 64)
+		if err != nil {
+			log.Error(err, "Invalid dashboard subscription", 0)
+			continue
+		}
+		sub.DashboardId = &dashboardId
+
+		dashboardGroupId, err := strconv.ParseInt(dashboardData[2], 10, 64)
+		if err != nil {
+			log.Error(err, "Invalid dashboard subscription", 0)
+			continue
+		}
+		sub.DashboardGroupId = &dashboardGroupId
+
+		dashboardIds = append(dashboardIds, dashboardId)
+	}
+
+	type accountDashboardRow struct {
+		DashboardId            int64         `db:"dashboard_id"`
+		DashboardName          string        `db:"dashboard_name"`
+		UserId                 types.UserId  `db:"user_id"`
+		GroupId                int64         `db:"group_id"`
+		GroupName              string        `db:"group_name"`
+		Address                []byte        `db:"address"`
+		IgnoreSpamTransactions bool          `db:"ignore_spam_transactions"`
+		SubscribedChainIds     pq.Int64Array `db:"subscribed_chain_ids"`
+	}
+	var rows []accountDashboardRow
+	err = db.AlloyWriter.Select(&rows, `
+		SELECT
+			users_acc_dashboards.id AS dashboard_id,
+			users_acc_dashboards.name AS dashboard_name,
+			users_acc_dashboards.user_id,
+			users_acc_dashboards_groups.id AS group_id,
+			users_acc_dashboards_groups.name AS group_name,
+			users_acc_dashboards_accounts.address,
+			users_acc_dashboards_groups.ignore_spam_transactions,
+			users_acc_dashboards_groups.subscribed_chain_ids
+		FROM users_acc_dashboards
+		INNER JOIN users_acc_dashboards_groups ON users_acc_dashboards_groups.dashboard_id = users_acc_dashboards.id
+		INNER JOIN users_acc_dashboards_accounts ON users_acc_dashboards_accounts.dashboard_id = users_acc_dashboards_groups.dashboard_id AND users_acc_dashboards_accounts.group_id = users_acc_dashboards_groups.id
+		WHERE users_acc_dashboards.id = ANY($1)`, pq.Array(dashboardIds))
+	if err != nil {
+		return nil, fmt.Errorf("error getting account dashboard definitions: %w", err)
+	}
+	log.Infof("retrieved %d account dashboard accounts", len(rows))
+
+	type groupKey struct {
+		dashboardId int64
+		groupId     int64
+	}
+	rowsByGroup := make(map[groupKey][]accountDashboardRow)
+	for _, row := range rows {
+		key := groupKey{row.DashboardId, row.GroupId}
+		rowsByGroup[key] = append(rowsByGroup[key], row)
+	}
+
+	for _, sub := range subs {
+		if sub.DashboardId == nil || sub.DashboardGroupId == nil {
+			continue
+		}
+		for _, row := range rowsByGroup[groupKey{*sub.DashboardId, *sub.DashboardGroupId}] {
+			if sub.UserID == nil || *sub.UserID != row.UserId {
+				// the dashboard does not belong to the subscriber (anymore)
+				continue
+			}
+			hydratedSub := &accountDashboardSubscription{
+				Subscription:           *sub,
+				Address:                row.Address,
+				IgnoreSpamTransactions: row.IgnoreSpamTransactions,
+				SubscribedChainIds:     make([]uint64, 0, len(row.SubscribedChainIds)),
+			}
+			hydratedSub.DashboardName = row.DashboardName
+			if hydratedSub.DashboardName == "" {
+				hydratedSub.DashboardName = fmt.Sprintf("Dashboard %d", row.DashboardId)
+			}
+			hydratedSub.DashboardGroupName = row.GroupName
+			if hydratedSub.DashboardGroupName == "" {
+				hydratedSub.DashboardGroupName = "default"
+			}
+			for _, chainId := range row.SubscribedChainIds {
+				hydratedSub.SubscribedChainIds = append(hydratedSub.SubscribedChainIds, uint64(chainId))
+			}
+			address := hex.EncodeToString(row.Address)
+			subMap[address] = append(subMap[address], hydratedSub)
+		}
+	}
+
+	return subMap, nil
+}
+
 func GetUserPushTokenByIds(ids []types.UserId, userDbConn *sqlx.DB) (map[types.UserId][]string, error) {
 	pushByID := map[types.UserId][]string{}
 	if len(ids) == 0 {
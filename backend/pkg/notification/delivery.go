@@ -0,0 +1,391 @@
+package notification
+
+import (
+	"bytes"
+	"compress/gzip"
+	"database/sql"
+	"encoding/gob"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/log"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// local hour at which daily digests are released
+const dailyDigestReleaseHour = 8
+
+// notificationDeliveryPolicy describes when email, push and chat notifications of a user are delivered
+type notificationDeliveryPolicy struct {
+	Mode            types.NotificationDeliveryMode
+	QuietHoursStart sql.NullInt16
+	QuietHoursEnd   sql.NullInt16
+	Location        *time.Location
+	GroupModes      map[types.DashboardId]map[types.DashboardGroupId]types.NotificationDeliveryMode
+}
+
+var defaultNotificationDeliveryPolicy = notificationDeliveryPolicy{
+	Mode:     types.NotificationDeliveryImmediate,
+	Location: time.UTC,
+}
+
+// heldNotifications are the notifications of a single user, dashboard group and event that are held back until ReleaseTs
+type heldNotifications struct {
+	UserId        types.UserId
+	DashboardId   types.DashboardId
+	GroupId       types.DashboardGroupId
+	EventName     types.EventName
+	ReleaseTs     time.Time
+	Notifications types.NotificationsPerEventFilter
+}
+
+func getNotificationDeliveryPolicies(userIds []types.UserId) (map[types.UserId]*notificationDeliveryPolicy, error) {
+	policies := make(map[types.UserId]*notificationDeliveryPolicy, len(userIds))
+	if len(userIds) == 0 {
+		return policies, nil
+	}
+	getPolicy := func(userId types.UserId) *notificationDeliveryPolicy {
+		if _, ok := policies[userId]; !ok {
+			policy := defaultNotificationDeliveryPolicy
+			policies[userId] = &policy
+		}
+		return policies[userId]
+	}
+
+	var userRows []struct {
+		UserId          types.UserId   `db:"id"`
+		DeliveryMode    sql.NullString `db:"notifications_delivery_mode"`
+		QuietHoursStart sql.NullInt16  `db:"notifications_quiet_hours_start"`
+		QuietHoursEnd   sql.NullInt16  `db:"notifications_quiet_hours_end"`
+		Timezone        sql.NullString `db:"notifications_timezone"`
+	}
+	err := db.FrontendReaderDB.Select(&userRows, `
+		SELECT
+			id,
+			notifications_delivery_mode,
+			notifications_quiet_hours_start,
+			notifications_quiet_hours_end,
+			notifications_timezone
+		FROM users
+		WHERE id = ANY($1) AND (notifications_delivery_mode IS NOT NULL OR notifications_quiet_hours_start IS NOT NULL)`, pq.Array(userIds))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving notification delivery settings of users: %w", err)
+	}
+	for _, row := range userRows {
+		policy := getPolicy(row.UserId)
+		if row.DeliveryMode.Valid {
+			policy.Mode = types.NotificationDeliveryMode(row.DeliveryMode.String)
+		}
+		policy.QuietHoursStart = row.QuietHoursStart
+		policy.QuietHoursEnd = row.QuietHoursEnd
+		if row.Timezone.Valid {
+			location, err := time.LoadLocation(row.Timezone.String)
+			if err != nil {
+				log.WarnWithFields(log.Fields{"user_id": row.UserId, "timezone": row.Timezone.String}, "invalid notification timezone, falling back to UTC")
+			} else {
+				policy.Location = location
+			}
+		}
+	}
+
+	var groupRows []struct {
+		UserId       types.UserId           `db:"user_id"`
+		DashboardId  types.DashboardId      `db:"dashboard_id"`
+		GroupId      types.DashboardGroupId `db:"group_id"`
+		DeliveryMode string                 `db:"notifications_delivery_mode"`
+	}
+	err = db.ReaderDb.Select(&groupRows, `
+		SELECT
+			users_val_dashboards.user_id AS user_id,
+			users_val_dashboards_groups.dashboard_id AS dashboard_id,
+			users_val_dashboards_groups.id AS group_id,
+			users_val_dashboards_groups.notifications_delivery_mode AS notifications_delivery_mode
+		FROM users_val_dashboards_groups
+		INNER JOIN users_val_dashboards ON users_val_dashboards_groups.dashboard_id = users_val_dashboards.id
+		WHERE users_val_dashboards.user_id = ANY($1) AND users_val_dashboards_groups.notifications_delivery_mode IS NOT NULL`, pq.Array(userIds))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving notification delivery settings of dashboard groups: %w", err)
+	}
+	for _, row := range groupRows {
+		policy := getPolicy(row.UserId)
+		if policy.GroupModes == nil {
+			policy.GroupModes = make(map[types.DashboardId]map[types.DashboardGroupId]types.NotificationDeliveryMode)
+		}
+		if _, ok := policy.GroupModes[row.DashboardId]; !ok {
+			policy.GroupModes[row.DashboardId] = make(map[types.DashboardGroupId]types.NotificationDeliveryMode)
+		}
+		policy.GroupModes[row.DashboardId][row.GroupId] = types.NotificationDeliveryMode(row.DeliveryMode)
+	}
+
+	return policies, nil
+}
+
+// quietHoursEnd returns the end of the quiet hours window if t lies within it
+func (p *notificationDeliveryPolicy) quietHoursEnd(t time.Time) (time.Time, bool) {
+	if !p.QuietHoursStart.Valid || !p.QuietHoursEnd.Valid || p.QuietHoursStart.Int16 == p.QuietHoursEnd.Int16 {
+		return time.Time{}, false
+	}
+	start, end := int(p.QuietHoursStart.Int16), int(p.QuietHoursEnd.Int16)
+
+	t = t.In(p.Location)
+	hour := t.Hour()
+	if start < end && (hour < start || hour >= end) {
+		return time.Time{}, false
+	}
+	// window wraps around midnight
+	if start > end && hour < start && hour >= end {
+		return time.Time{}, false
+	}
+
+	endTs := time.Date(t.Year(), t.Month(), t.Day(), end, 0, 0, 0, p.Location)
+	if !endTs.After(t) {
+		endTs = endTs.AddDate(0, 0, 1)
+	}
+	return endTs, true
+}
+
+// releaseTime returns the time at which notifications of the given event should be delivered.
+// A zero time means that the notifications should be delivered immediately.
+func (p *notificationDeliveryPolicy) releaseTime(now time.Time, dashboardId types.DashboardId, groupId types.DashboardGroupId, event types.EventName) time.Time {
+	if types.IsCriticalEvent(event) {
+		return time.Time{}
+	}
+
+	mode := p.Mode
+	// group modes are configured for validator dashboard groups only
+	if groupMode, ok := p.GroupModes[dashboardId][groupId]; ok && !types.IsAccountDashboardEvent(event) {
+		mode = groupMode
+	}
+
+	now = now.In(p.Location)
+	release := now
+	switch mode {
+	case types.NotificationDeliveryHourlyDigest:
+		release = now.Truncate(time.Hour).Add(time.Hour)
+	case types.NotificationDeliveryDailyDigest:
+		release = time.Date(now.Year(), now.Month(), now.Day(), dailyDigestReleaseHour, 0, 0, 0, p.Location)
+		if !release.After(now) {
+			release = release.AddDate(0, 0, 1)
+		}
+	}
+	if quietHoursEnd, ok := p.quietHoursEnd(release); ok {
+		release = quietHoursEnd
+	}
+
+	if !release.After(now) {
+		return time.Time{}
+	}
+	return release
+}
+
+// applyDeliveryPolicies splits the notifications into the ones that should be delivered immediately and the ones that are held back
+// because of a digest or quiet hours setting of the user
+func applyDeliveryPolicies(now time.Time, notificationsByUserID types.NotificationsPerUserId) (types.NotificationsPerUserId, []heldNotifications, error) {
+	policies, err := getNotificationDeliveryPolicies(slices.Collect(maps.Keys(notificationsByUserID)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	immediate := make(types.NotificationsPerUserId, len(notificationsByUserID))
+	held := []heldNotifications{}
+	for userId, notificationsPerDashboard := range notificationsByUserID {
+		policy, ok := policies[userId]
+		if !ok {
+			immediate[userId] = notificationsPerDashboard
+			continue
+		}
+		for dashboardId, notificationsPerGroup := range notificationsPerDashboard {
+			for groupId, notificationsPerEvent := range notificationsPerGroup {
+				for event, notifications := range notificationsPerEvent {
+					releaseTs := policy.releaseTime(now, dashboardId, groupId, event)
+					if !releaseTs.IsZero() {
+						held = append(held, heldNotifications{
+							UserId:        userId,
+							DashboardId:   dashboardId,
+							GroupId:       groupId,
+							EventName:     event,
+							ReleaseTs:     releaseTs,
+							Notifications: notifications,
+						})
+						continue
+					}
+					if _, ok := immediate[userId]; !ok {
+						immediate[userId] = make(types.NotificationsPerDashboard)
+					}
+					if _, ok := immediate[userId][dashboardId]; !ok {
+						immediate[userId][dashboardId] = make(types.NotificationsPerDashboardGroup)
+					}
+					if _, ok := immediate[userId][dashboardId][groupId]; !ok {
+						immediate[userId][dashboardId][groupId] = make(types.NotificationsPerEventName)
+					}
+					immediate[userId][dashboardId][groupId][event] = notifications
+				}
+			}
+		}
+	}
+	return immediate, held, nil
+}
+
+// queueHeldNotifications stores the held notifications in the digest queue, they are released by the notification sender
+func queueHeldNotifications(epoch uint64, held []heldNotifications, tx *sqlx.Tx) error {
+	log.Infof("holding back %v email, push and chat notification batches", len(held))
+	if len(held) == 0 {
+		return nil
+	}
+	type insertData struct {
+		UserId      types.UserId           `db:"user_id"`
+		DashboardId types.DashboardId      `db:"dashboard_id"`
+		GroupId     types.DashboardGroupId `db:"group_id"`
+		EventName   types.EventName        `db:"event_name"`
+		Epoch       uint64                 `db:"epoch"`
+		ReleaseTs   time.Time              `db:"release_ts"`
+		Details     []byte                 `db:"details"`
+	}
+
+	insertRows := make([]insertData, 0, len(held))
+	for _, h := range held {
+		details, err := encodeNotifications(slices.Collect(maps.Values(h.Notifications)))
+		if err != nil {
+			return fmt.Errorf("error encoding held notifications: %w", err)
+		}
+		insertRows = append(insertRows, insertData{
+			UserId:      h.UserId,
+			DashboardId: h.DashboardId,
+			GroupId:     h.GroupId,
+			EventName:   h.EventName,
+			Epoch:       epoch,
+			ReleaseTs:   h.ReleaseTs.UTC(),
+			Details:     details,
+		})
+	}
+
+	_, err := tx.NamedExec(`
+		INSERT INTO notification_digest_queue (user_id, dashboard_id, group_id, event_name, epoch, release_ts, details)
+		VALUES (:user_id, :dashboard_id, :group_id, :event_name, :epoch, :release_ts, :details)`, insertRows)
+	if err != nil {
+		return fmt.Errorf("error writing held notifications to db: %w", err)
+	}
+	return nil
+}
+
+func decodeNotifications(data []byte) ([]types.Notification, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing notifications: %w", err)
+	}
+	defer gz.Close()
+
+	var notifications []types.Notification
+	err = gob.NewDecoder(gz).Decode(&notifications)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding notifications: %w", err)
+	}
+	return notifications, nil
+}
+
+// releaseHeldNotifications renders all held notifications that are due into one digest email and push message per user
+// and one chat message per dashboard group
+func releaseHeldNotifications() error {
+	registerNotificationTypes()
+
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	var rows []struct {
+		Id      uint64 `db:"id"`
+		Details []byte `db:"details"`
+	}
+	err = tx.Select(&rows, `SELECT id, details FROM notification_digest_queue WHERE release_ts <= NOW() ORDER BY id FOR UPDATE SKIP LOCKED`)
+	if err != nil {
+		return fmt.Errorf("error retrieving held notifications: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, 0, len(rows))
+	notificationsByUserID := make(types.NotificationsPerUserId)
+	for _, row := range rows {
+		ids = append(ids, row.Id)
+		notifications, err := decodeNotifications(row.Details)
+		if err != nil {
+			log.Error(err, "error decoding held notifications", 0, log.Fields{"id": row.Id})
+			continue
+		}
+		for _, n := range notifications {
+			addDigestNotification(notificationsByUserID, n)
+		}
+	}
+
+	emails, err := renderEmailsForUserEvents("Notification digest", notificationsByUserID)
+	if err != nil {
+		return fmt.Errorf("error rendering digest emails: %w", err)
+	}
+	for i := range emails {
+		emails[i].Subject = fmt.Sprintf("%s (digest)", emails[i].Subject)
+	}
+	err = insertEmailNotifications(emails, tx)
+	if err != nil {
+		return err
+	}
+
+	pushMessages, err := renderPushMessagesForUserEvents(fmt.Sprintf("%sNotification digest", getNetwork()), map[string]string{"digest": "true"}, notificationsByUserID)
+	if err != nil {
+		return fmt.Errorf("error rendering digest push messages: %w", err)
+	}
+	err = insertPushNotifications(pushMessages, tx)
+	if err != nil {
+		return err
+	}
+
+	err = QueueChatNotifications(notificationsByUserID, tx)
+	if err != nil {
+		return fmt.Errorf("error queuing digest chat notifications: %w", err)
+	}
+
+	_, err = tx.Exec(`DELETE FROM notification_digest_queue WHERE id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("error deleting released notifications: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	log.Infof("released %v held notification batches", len(rows))
+	return nil
+}
+
+// addDigestNotification adds n to the digest, the same event filter can occur in several epochs so the epoch is part of the key
+func addDigestNotification(notificationsByUserID types.NotificationsPerUserId, n types.Notification) {
+	dashboardId := types.DashboardId(0)
+	groupId := types.DashboardGroupId(0)
+	if n.GetDashboardId() != nil && n.GetDashboardGroupId() != nil {
+		dashboardId = types.DashboardId(*n.GetDashboardId())
+		groupId = types.DashboardGroupId(*n.GetDashboardGroupId())
+	}
+	userId := n.GetUserId()
+	if _, ok := notificationsByUserID[userId]; !ok {
+		notificationsByUserID[userId] = make(types.NotificationsPerDashboard)
+	}
+	if _, ok := notificationsByUserID[userId][dashboardId]; !ok {
+		notificationsByUserID[userId][dashboardId] = make(types.NotificationsPerDashboardGroup)
+	}
+	if _, ok := notificationsByUserID[userId][dashboardId][groupId]; !ok {
+		notificationsByUserID[userId][dashboardId][groupId] = make(types.NotificationsPerEventName)
+	}
+	if _, ok := notificationsByUserID[userId][dashboardId][groupId][n.GetEventName()]; !ok {
+		notificationsByUserID[userId][dashboardId][groupId][n.GetEventName()] = make(types.NotificationsPerEventFilter)
+	}
+	key := types.EventFilter(fmt.Sprintf("%s:%d", n.GetEventFilter(), n.GetEpoch()))
+	notificationsByUserID[userId][dashboardId][groupId][n.GetEventName()][key] = n
+}
@@ -0,0 +1,111 @@
+package notification
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+)
+
+func TestNotificationDeliveryPolicyReleaseTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+	// 23:30 in Berlin
+	now := time.Date(2024, 11, 25, 23, 30, 0, 0, berlin)
+	quietHours := func(start, end int16) (sql.NullInt16, sql.NullInt16) {
+		return sql.NullInt16{Int16: start, Valid: true}, sql.NullInt16{Int16: end, Valid: true}
+	}
+
+	tests := []struct {
+		name        string
+		policy      notificationDeliveryPolicy
+		event       types.EventName
+		wantZero    bool
+		wantHour    int
+		wantNextDay bool
+	}{
+		{
+			name:     "immediate",
+			policy:   notificationDeliveryPolicy{Mode: types.NotificationDeliveryImmediate, Location: berlin},
+			event:    types.ValidatorMissedAttestationEventName,
+			wantZero: true,
+		},
+		{
+			name:        "hourly digest",
+			policy:      notificationDeliveryPolicy{Mode: types.NotificationDeliveryHourlyDigest, Location: berlin},
+			event:       types.ValidatorMissedAttestationEventName,
+			wantHour:    0,
+			wantNextDay: true,
+		},
+		{
+			name:        "daily digest",
+			policy:      notificationDeliveryPolicy{Mode: types.NotificationDeliveryDailyDigest, Location: berlin},
+			event:       types.ValidatorIsOfflineEventName,
+			wantHour:    dailyDigestReleaseHour,
+			wantNextDay: true,
+		},
+		{
+			name:        "quiet hours wrapping midnight",
+			policy:      notificationDeliveryPolicy{Mode: types.NotificationDeliveryImmediate, Location: berlin},
+			event:       types.ValidatorIsOfflineEventName,
+			wantHour:    7,
+			wantNextDay: true,
+		},
+		{
+			name:        "daily digest within quiet hours",
+			policy:      notificationDeliveryPolicy{Mode: types.NotificationDeliveryDailyDigest, Location: berlin},
+			event:       types.ValidatorIsOfflineEventName,
+			wantHour:    9,
+			wantNextDay: true,
+		},
+		{
+			name:     "critical event bypasses quiet hours",
+			policy:   notificationDeliveryPolicy{Mode: types.NotificationDeliveryDailyDigest, Location: berlin},
+			event:    types.ValidatorGotSlashedEventName,
+			wantZero: true,
+		},
+	}
+	tests[3].policy.QuietHoursStart, tests[3].policy.QuietHoursEnd = quietHours(22, 7)
+	tests[4].policy.QuietHoursStart, tests[4].policy.QuietHoursEnd = quietHours(0, 9)
+	tests[5].policy.QuietHoursStart, tests[5].policy.QuietHoursEnd = quietHours(22, 7)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := tt.policy.releaseTime(now, 0, 0, tt.event)
+			if tt.wantZero {
+				if !release.IsZero() {
+					t.Fatalf("expected immediate delivery, got release at %v", release)
+				}
+				return
+			}
+			release = release.In(berlin)
+			wantDay := now.Day()
+			if tt.wantNextDay {
+				wantDay = now.AddDate(0, 0, 1).Day()
+			}
+			if release.Day() != wantDay || release.Hour() != tt.wantHour || release.Minute() != 0 {
+				t.Errorf("expected release on day %d at %d:00, got %v", wantDay, tt.wantHour, release)
+			}
+		})
+	}
+}
+
+func TestNotificationDeliveryPolicyGroupOverride(t *testing.T) {
+	now := time.Date(2024, 11, 25, 12, 30, 0, 0, time.UTC)
+	policy := notificationDeliveryPolicy{
+		Mode:     types.NotificationDeliveryImmediate,
+		Location: time.UTC,
+		GroupModes: map[types.DashboardId]map[types.DashboardGroupId]types.NotificationDeliveryMode{
+			1: {2: types.NotificationDeliveryHourlyDigest},
+		},
+	}
+	if release := policy.releaseTime(now, 1, 3, types.ValidatorIsOfflineEventName); !release.IsZero() {
+		t.Errorf("expected immediate delivery for group without override, got %v", release)
+	}
+	if release := policy.releaseTime(now, 1, 2, types.ValidatorIsOfflineEventName); !release.Equal(time.Date(2024, 11, 25, 13, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected hourly digest for group with override, got %v", release)
+	}
+}
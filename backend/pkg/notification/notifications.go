@@ -1,6 +1,8 @@
 package notification
 
 import (
+	"context"
+
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
 	"github.com/gobitfly/beaconchain/pkg/commons/types"
 	"github.com/gobitfly/beaconchain/pkg/exporter/modules"
@@ -8,7 +10,9 @@ import (
 
 // Used for isolated testing
 func GetNotificationsForEpoch(pubkeyCachePath string, epoch uint64) (types.NotificationsPerUserId, error) {
-	mc, err := modules.GetModuleContext()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mc, err := modules.GetModuleContext(ctx)
 	if err != nil {
 		log.Fatal(err, "error getting module context", 0)
 	}
@@ -21,7 +25,9 @@ func GetNotificationsForEpoch(pubkeyCachePath string, epoch uint64) (types.Notif
 }
 
 func GetHeadNotificationsForEpoch(pubkeyCachePath string, epoch uint64) (types.NotificationsPerUserId, error) {
-	mc, err := modules.GetModuleContext()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mc, err := modules.GetModuleContext(ctx)
 	if err != nil {
 		log.Fatal(err, "error getting module context", 0)
 	}
@@ -31,21 +31,39 @@ func queueNotifications(epoch uint64, notificationsByUserID types.NotificationsP
 	}
 	defer utils.Rollback(tx)
 
-	err = QueueEmailNotifications(epoch, notificationsByUserID, tx)
+	// email, push and chat notifications are subject to the digest and quiet hours settings of the users,
+	// webhooks, the notification history and the subscription state are always updated immediately
+	immediateNotifications, heldNotifications, err := applyDeliveryPolicies(time.Now(), notificationsByUserID)
+	if err != nil {
+		log.Error(err, "error applying notification delivery policies, delivering all notifications immediately", 0)
+		immediateNotifications, heldNotifications = notificationsByUserID, nil
+	}
+
+	err = QueueEmailNotifications(epoch, immediateNotifications, tx)
 	if err != nil {
 		return fmt.Errorf("error queuing email notifications: %w", err)
 	}
 
-	err = QueuePushNotification(epoch, notificationsByUserID, tx)
+	err = QueuePushNotification(epoch, immediateNotifications, tx)
 	if err != nil {
 		return fmt.Errorf("error queuing push notifications: %w", err)
 	}
 
+	err = queueHeldNotifications(epoch, heldNotifications, tx)
+	if err != nil {
+		return fmt.Errorf("error queuing held notifications: %w", err)
+	}
+
 	err = QueueWebhookNotifications(notificationsByUserID, tx)
 	if err != nil {
 		return fmt.Errorf("error queuing webhook notifications: %w", err)
 	}
 
+	err = QueueChatNotifications(immediateNotifications, tx)
+	if err != nil {
+		return fmt.Errorf("error queuing chat notifications: %w", err)
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return fmt.Errorf("error committing transaction: %w", err)
@@ -130,6 +148,16 @@ func ExportNotificationHistory(epoch uint64, notificationsByUserID types.Notific
 	}
 	defer utils.ClosePreparedStatement(dashboardNotificationHistoryInsertStmt)
 
+	accountDashboardNotificationHistoryInsertStmt, err := db.WriterDb.Preparex(`
+		INSERT INTO users_acc_dashboards_notifications_history
+		(user_id, dashboard_id, group_id, epoch, network, event_type, event_count, details, ts)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`)
+	if err != nil {
+		return fmt.Errorf("error preparing insert statement for account dashboard notifications history: %w", err)
+	}
+	defer utils.ClosePreparedStatement(accountDashboardNotificationHistoryInsertStmt)
+
 	machineNotificationHistoryInsertStmt, err := db.FrontendWriterDB.Preparex(`
 		INSERT INTO machine_notifications_history 
 		(user_id, epoch, machine_id, machine_name, event_type, event_threshold, ts)
@@ -179,6 +207,26 @@ func ExportNotificationHistory(epoch uint64, notificationsByUserID types.Notific
 								log.Error(err, "error inserting into network notifications history", 0)
 							}
 						}
+					} else if types.IsAccountDashboardEvent(eventName) { // handle account dashboard related events
+						details, err := GetNotificationDetails(notifications)
+						if err != nil {
+							log.Error(err, "error getting notification details", 0)
+							continue
+						}
+						_, err = accountDashboardNotificationHistoryInsertStmt.Exec(
+							userID,
+							dashboardID,
+							group,
+							epoch,
+							utils.Config.Chain.ClConfig.DepositChainID,
+							eventName,
+							len(notifications),
+							details,
+							epochTs,
+						)
+						if err != nil {
+							log.Error(err, "error inserting into account dashboard notifications history", 0)
+						}
 					} else if eventName != types.NetworkLivenessIncreasedEventName && !types.IsUserIndexed(eventName) && !types.IsMachineNotification(eventName) {
 						details, err := GetNotificationDetails(notifications)
 						if err != nil {
@@ -252,7 +300,11 @@ func GetNotificationDetails(notificationsPerEventFilter types.NotificationsPerEv
 		ns.SetEventFilter("") // zero out the event filter as it is not needed in the details
 		notifications = append(notifications, ns)
 	}
-	// gob encode and gzip compress the notifications
+	return encodeNotifications(notifications)
+}
+
+// encodeNotifications gob encodes and gzip compresses the notifications
+func encodeNotifications(notifications []types.Notification) ([]byte, error) {
 	var buf bytes.Buffer
 	gz := gzip.NewWriter(&buf)
 	enc := gob.NewEncoder(gz)
@@ -268,6 +320,11 @@ func GetNotificationDetails(notificationsPerEventFilter types.NotificationsPerEv
 }
 
 func RenderEmailsForUserEvents(epoch uint64, notificationsByUserID types.NotificationsPerUserId) (emails []types.TransitEmailContent, err error) {
+	return renderEmailsForUserEvents(fmt.Sprintf("Summary for epoch %d", epoch), notificationsByUserID)
+}
+
+// renderEmailsForUserEvents renders one email per user, summaryTitle is used as heading of the summary section
+func renderEmailsForUserEvents(summaryTitle string, notificationsByUserID types.NotificationsPerUserId) (emails []types.TransitEmailContent, err error) {
 	emails = make([]types.TransitEmailContent, 0, 50)
 
 	createdTs := time.Now()
@@ -364,7 +421,7 @@ func RenderEmailsForUserEvents(epoch uint64, notificationsByUserID types.Notific
 		}
 
 		//nolint:gosec // this is a static string
-		bodySummary := template.HTML(fmt.Sprintf("<h2 style='margin-bottom: 0px;'>Summary for epoch %d:</h2>", epoch))
+		bodySummary := template.HTML(fmt.Sprintf("<h2 style='margin-bottom: 0px;'>%s:</h2>", template.HTMLEscapeString(summaryTitle)))
 		for _, event := range types.EventSortOrder {
 			count, ok := notificationTypesMap[event]
 			if !ok {
@@ -390,6 +447,12 @@ func RenderEmailsForUserEvents(epoch uint64, notificationsByUserID types.Notific
 			case types.ValidatorGroupEfficiencyEventName:
 				//nolint:gosec // this is a static string
 				bodySummary += template.HTML(fmt.Sprintf("%s: %d Group%s", types.EventLabel[event], count, plural))
+			case types.IncomingTransactionEventName, types.OutgoingTransactionEventName:
+				//nolint:gosec // this is a static string
+				bodySummary += template.HTML(fmt.Sprintf("%s: %d Transaction%s", types.EventLabel[event], count, plural))
+			case types.ERC20TokenTransferEventName, types.ERC721TokenTransferEventName, types.ERC1155TokenTransferEventName:
+				//nolint:gosec // this is a static string
+				bodySummary += template.HTML(fmt.Sprintf("%s: %d Transfer%s", types.EventLabel[event], count, plural))
 			default:
 				//nolint:gosec // this is a static string
 				bodySummary += template.HTML(fmt.Sprintf("%s: %d Validator%s", types.EventLabel[event], count, plural))
@@ -428,8 +491,11 @@ func QueueEmailNotifications(epoch uint64, notificationsByUserID types.Notificat
 	if err != nil {
 		return fmt.Errorf("error rendering emails: %w", err)
 	}
+	return insertEmailNotifications(emails, tx)
+}
 
-	// now batch insert the emails in one go
+func insertEmailNotifications(emails []types.TransitEmailContent, tx *sqlx.Tx) error {
+	// batch insert the emails in one go
 	log.Infof("queueing %v email notifications", len(emails))
 	if len(emails) == 0 {
 		return nil
@@ -445,7 +511,7 @@ func QueueEmailNotifications(epoch uint64, notificationsByUserID types.Notificat
 		})
 	}
 
-	_, err = tx.NamedExec(`INSERT INTO notification_queue (created, channel, content) VALUES (NOW(), 'email', :content)`, insertRows)
+	_, err := tx.NamedExec(`INSERT INTO notification_queue (created, channel, content) VALUES (NOW(), 'email', :content)`, insertRows)
 	if err != nil {
 		log.Error(err, "error writing transit email to db", 0)
 	}
@@ -453,6 +519,11 @@ func QueueEmailNotifications(epoch uint64, notificationsByUserID types.Notificat
 }
 
 func RenderPushMessagesForUserEvents(epoch uint64, notificationsByUserID types.NotificationsPerUserId) ([]types.TransitPushContent, error) {
+	return renderPushMessagesForUserEvents(fmt.Sprintf("%sInfo for epoch %d", getNetwork(), epoch), map[string]string{"epoch": fmt.Sprintf("%d", epoch)}, notificationsByUserID)
+}
+
+// renderPushMessagesForUserEvents renders one push message per user, dashboard group and device using the given title and data payload
+func renderPushMessagesForUserEvents(title string, data map[string]string, notificationsByUserID types.NotificationsPerUserId) ([]types.TransitPushContent, error) {
 	pushMessages := make([]types.TransitPushContent, 0, 50)
 
 	userIDs := slices.Collect(maps.Keys(notificationsByUserID))
@@ -508,26 +579,7 @@ func RenderPushMessagesForUserEvents(epoch uint64, notificationsByUserID types.N
 					if len(bodySummary) > 0 {
 						bodySummary += "\n"
 					}
-					plural := ""
-					if count > 1 {
-						plural = "s"
-					}
-					switch event {
-					case types.RocketpoolCollateralMaxReachedEventName, types.RocketpoolCollateralMinReachedEventName:
-						bodySummary += fmt.Sprintf("%s: %d node%s", types.EventLabel[event], count, plural)
-					case types.TaxReportEventName, types.NetworkLivenessIncreasedEventName, types.NetworkGasAboveThresholdEventName, types.NetworkGasBelowThresholdEventName:
-						bodySummary += fmt.Sprintf("%s: %d event%s", types.EventLabel[event], count, plural)
-					case types.EthClientUpdateEventName:
-						bodySummary += fmt.Sprintf("%s: %d client%s", types.EventLabel[event], count, plural)
-					case types.MonitoringMachineCpuLoadEventName, types.MonitoringMachineMemoryUsageEventName, types.MonitoringMachineDiskAlmostFullEventName, types.MonitoringMachineOfflineEventName:
-						bodySummary += fmt.Sprintf("%s: %d machine%s", types.EventLabel[event], count, plural)
-					case types.ValidatorExecutedProposalEventName:
-						bodySummary += fmt.Sprintf("%s: %d validator%s, Reward: %.3f ETH", types.EventLabel[event], count, plural, totalBlockReward)
-					case types.ValidatorGroupEfficiencyEventName:
-						bodySummary += fmt.Sprintf("%s: %d group%s", types.EventLabel[event], count, plural)
-					default:
-						bodySummary += fmt.Sprintf("%s: %d validator%s", types.EventLabel[event], count, plural)
-					}
+					bodySummary += getEventSummary(event, count, totalBlockReward)
 					if len(events) < 3 {
 						bodySummary += fmt.Sprintf(" (%s)", strings.Join(events, ","))
 					}
@@ -545,12 +597,10 @@ func RenderPushMessagesForUserEvents(epoch uint64, notificationsByUserID types.N
 					message.APNS.Payload.Aps.Sound = "default"
 
 					notification := new(messaging.Notification)
-					notification.Title = fmt.Sprintf("%sInfo for epoch %d", getNetwork(), epoch)
+					notification.Title = title
 					notification.Body = bodySummary
 					message.Notification = notification
-					message.Data = map[string]string{
-						"epoch": fmt.Sprintf("%d", epoch),
-					}
+					message.Data = maps.Clone(data)
 					transitPushContent := types.TransitPushContent{
 						Messages: []*messaging.Message{message},
 						UserId:   userID,
@@ -570,8 +620,11 @@ func QueuePushNotification(epoch uint64, notificationsByUserID types.Notificatio
 	if err != nil {
 		return fmt.Errorf("error rendering push messages: %w", err)
 	}
+	return insertPushNotifications(pushMessages, tx)
+}
 
-	// now batch insert the push messages in one go
+func insertPushNotifications(pushMessages []types.TransitPushContent, tx *sqlx.Tx) error {
+	// batch insert the push messages in one go
 	log.Infof("queueing %v push notifications", len(pushMessages))
 	if len(pushMessages) == 0 {
 		return nil
@@ -587,7 +640,7 @@ func QueuePushNotification(epoch uint64, notificationsByUserID types.Notificatio
 		})
 	}
 
-	_, err = tx.NamedExec(`INSERT INTO notification_queue (created, channel, content) VALUES (NOW(), 'push', :content)`, insertRows)
+	_, err := tx.NamedExec(`INSERT INTO notification_queue (created, channel, content) VALUES (NOW(), 'push', :content)`, insertRows)
 	if err != nil {
 		return fmt.Errorf("error writing transit push to db: %w", err)
 	}
@@ -691,20 +744,30 @@ func QueueWebhookNotifications(notificationsByUserID types.NotificationsPerUserI
 	if err != nil {
 		return fmt.Errorf("error quering users_val_dashboards_groups, err: %w", err)
 	}
-	dashboardWebhookMap := make(map[types.UserId]map[types.DashboardId]map[types.DashboardGroupId]types.UserWebhook)
-	for _, w := range webhooks {
-		if w.Destination.Valid && w.Destination.String == "discord" {
-			w.Destination.String = "webhook_discord"
-		}
-		if _, exists := dashboardWebhookMap[types.UserId(w.UserID)]; !exists {
-			dashboardWebhookMap[types.UserId(w.UserID)] = make(map[types.DashboardId]map[types.DashboardGroupId]types.UserWebhook)
-		}
-		if _, exists := dashboardWebhookMap[types.UserId(w.UserID)][types.DashboardId(w.DashboardId)]; !exists {
-			dashboardWebhookMap[types.UserId(w.UserID)][types.DashboardId(w.DashboardId)] = make(map[types.DashboardGroupId]types.UserWebhook)
-		}
+	dashboardWebhookMap := newDashboardWebhookMap(webhooks)
 
-		dashboardWebhookMap[types.UserId(w.UserID)][types.DashboardId(w.DashboardId)][types.DashboardGroupId(w.DashboardGroupId)] = w
+	// and the webhooks of the account dashboard groups
+	webhooks = nil
+	err = db.ReaderDb.Select(&webhooks, `
+	SELECT
+		users_acc_dashboards.user_id AS user_id,
+		users_acc_dashboards_groups.id AS dashboard_group_id,
+		dashboard_id AS dashboard_id,
+		webhook_target AS url,
+		COALESCE(webhook_format, 'webhook') AS destination,
+		webhook_retries AS retries,
+		webhook_last_sent AS last_sent,
+		true AS is_account_dashboard
+	FROM users_acc_dashboards_groups
+	LEFT JOIN users_acc_dashboards ON users_acc_dashboards_groups.dashboard_id = users_acc_dashboards.id
+	WHERE users_acc_dashboards.user_id = ANY($1) AND NOT (users_acc_dashboards.user_id = ANY($2))
+	AND webhook_target IS NOT NULL
+	AND webhook_format IS NOT NULL;
+	`, pq.Array(userIds), pq.Array(disabledUsers))
+	if err != nil {
+		return fmt.Errorf("error quering users_acc_dashboards_groups, err: %w", err)
 	}
+	accountDashboardWebhookMap := newDashboardWebhookMap(webhooks)
 
 	discordNotifMap := make(map[uint64][]types.TransitDiscordContent)
 	notifs := make([]types.TransitWebhook, 0)
@@ -822,133 +885,94 @@ func QueueWebhookNotifications(notificationsByUserID types.NotificationsPerUserI
 				continue
 			}
 			for dashboardGroupId, notificationsPerGroup := range notificationsPerDashboard {
-				// retrieve the associated webhook config from the map
-				if _, exists := dashboardWebhookMap[userID]; !exists {
-					continue
-				}
-				if _, exists := dashboardWebhookMap[userID][dashboardId]; !exists {
-					continue
-				}
-				if _, exists := dashboardWebhookMap[userID][dashboardId][dashboardGroupId]; !exists {
-					continue
-				}
-				w := dashboardWebhookMap[userID][dashboardId][dashboardGroupId]
-
-				// reset Retries
-				if w.Retries > 5 && w.LastSent.Valid && w.LastSent.Time.Add(time.Hour).Before(time.Now()) {
-					_, err = db.WriterDb.Exec(`UPDATE users_val_dashboards_groups SET webhook_retries = 0 WHERE id = $1 AND dashboard_id = $2;`, dashboardGroupId, dashboardId)
-					if err != nil {
-						log.Error(err, "error updating users_webhooks table; setting retries to zero", 0)
+				// validator and account dashboard ids are not distinct, the event decides which dashboard the notifications belong to
+				validatorNotifications, accountNotifications := splitAccountDashboardNotifications(notificationsPerGroup)
+				for _, partition := range []struct {
+					webhooks      map[types.UserId]map[types.DashboardId]map[types.DashboardGroupId]types.UserWebhook
+					notifications types.NotificationsPerEventName
+				}{
+					{dashboardWebhookMap, validatorNotifications},
+					{accountDashboardWebhookMap, accountNotifications},
+				} {
+					if len(partition.notifications) == 0 {
+						continue
+					}
+					// retrieve the associated webhook config from the map
+					w, exists := partition.webhooks[userID][dashboardId][dashboardGroupId]
+					if !exists {
 						continue
 					}
-				} else if w.Retries > 5 && !w.LastSent.Valid {
-					log.Warnf("webhook '%v' for dashboard %d and group %d has more than 5 retries and does not have a valid last_sent timestamp", w.Url, dashboardId, dashboardGroupId)
-					continue
-				}
-
-				if w.Retries >= 5 {
-					// early return
-					continue
-				}
 
-				for event, notifications := range notificationsPerGroup {
-					if w.Destination.Valid && w.Destination.String == "webhook_discord" {
-						content := types.TransitDiscordContent{
-							Webhook: w,
-							UserId:  userID,
-							DiscordRequest: types.DiscordReq{
-								Username: utils.Config.Frontend.SiteDomain,
-							},
+					// reset Retries
+					if w.Retries > 5 && w.LastSent.Valid && w.LastSent.Time.Add(time.Hour).Before(time.Now()) {
+						_, err = db.WriterDb.Exec(fmt.Sprintf(`UPDATE %s SET webhook_retries = 0 WHERE id = $1 AND dashboard_id = $2;`, dashboardWebhookGroupsTable(w)), dashboardGroupId, dashboardId)
+						if err != nil {
+							log.Error(err, "error updating users_webhooks table; setting retries to zero", 0)
+							continue
 						}
+					} else if w.Retries > 5 && !w.LastSent.Valid {
+						log.Warnf("webhook '%v' for dashboard %d and group %d has more than 5 retries and does not have a valid last_sent timestamp", w.Url, dashboardId, dashboardGroupId)
+						continue
+					}
 
-						totalBlockReward := float64(0)
-						epoch := uint64(0)
-						details := ""
-						i := 0
-						for _, n := range notifications {
-							if event == types.ValidatorExecutedProposalEventName {
-								proposalNotification, ok := n.(*ValidatorProposalNotification)
-								if !ok {
-									log.Error(fmt.Errorf("error casting proposal notification"), "", 0)
-									continue
-								}
-								totalBlockReward += proposalNotification.Reward
-							}
-							if i <= 10 {
-								details += fmt.Sprintf("%s\n", n.GetInfo(types.NotifciationFormatMarkdown))
-							}
-							i++
-							if i == 11 {
-								details += fmt.Sprintf("... and %d more notifications\n", len(notifications)-i)
-								continue
-							}
-							if epoch == 0 {
-								epoch = n.GetEpoch()
-							}
-						}
+					if w.Retries >= 5 {
+						// early return
+						continue
+					}
 
-						count := len(notifications)
-						summary := ""
-						plural := ""
-						if count > 1 {
-							plural = "s"
-						}
-						switch event {
-						case types.RocketpoolCollateralMaxReachedEventName, types.RocketpoolCollateralMinReachedEventName:
-							summary += fmt.Sprintf("%s: %d node%s", types.EventLabel[event], count, plural)
-						case types.TaxReportEventName, types.NetworkLivenessIncreasedEventName, types.NetworkGasAboveThresholdEventName, types.NetworkGasBelowThresholdEventName:
-							summary += fmt.Sprintf("%s: %d event%s", types.EventLabel[event], count, plural)
-						case types.EthClientUpdateEventName:
-							summary += fmt.Sprintf("%s: %d client%s", types.EventLabel[event], count, plural)
-						case types.MonitoringMachineCpuLoadEventName, types.MonitoringMachineMemoryUsageEventName, types.MonitoringMachineDiskAlmostFullEventName, types.MonitoringMachineOfflineEventName:
-							summary += fmt.Sprintf("%s: %d machine%s", types.EventLabel[event], count, plural)
-						case types.ValidatorExecutedProposalEventName:
-							summary += fmt.Sprintf("%s: %d validator%s, Reward: %.3f ETH", types.EventLabel[event], count, plural, totalBlockReward)
-						case types.ValidatorGroupEfficiencyEventName:
-							summary += fmt.Sprintf("%s: %d group%s", types.EventLabel[event], count, plural)
-						default:
-							summary += fmt.Sprintf("%s: %d validator%s", types.EventLabel[event], count, plural)
-						}
-						content.DiscordRequest.Embeds = append(content.DiscordRequest.Embeds, types.DiscordEmbed{
-							Type:        "rich",
-							Color:       "16745472",
-							Description: details,
-							Title:       summary,
-							Fields: []types.DiscordEmbedField{
-								{
-									Name:   "Epoch",
-									Value:  fmt.Sprintf("[%[1]v](https://%[2]s/epoch/%[1]v)", epoch, utils.Config.Frontend.SiteDomain),
-									Inline: false,
+					for event, notifications := range partition.notifications {
+						if w.Destination.Valid && w.Destination.String == "webhook_discord" {
+							content := types.TransitDiscordContent{
+								Webhook: w,
+								UserId:  userID,
+								DiscordRequest: types.DiscordReq{
+									Username: utils.Config.Frontend.SiteDomain,
 								},
-							},
-						})
+							}
 
-						if _, exists := discordNotifMap[w.ID]; !exists {
-							discordNotifMap[w.ID] = make([]types.TransitDiscordContent, 0)
-						}
-						log.Infof("adding discord notification for user %d, dashboard %d, group %d and type %s", userID, dashboardId, dashboardGroupId, event)
+							details, totalBlockReward, epoch := getEventDetails(event, notifications, types.NotifciationFormatMarkdown)
+							summary := getEventSummary(event, len(notifications), totalBlockReward)
+							content.DiscordRequest.Embeds = append(content.DiscordRequest.Embeds, types.DiscordEmbed{
+								Type:        "rich",
+								Color:       "16745472",
+								Description: details,
+								Title:       summary,
+								Fields: []types.DiscordEmbedField{
+									{
+										Name:   "Epoch",
+										Value:  fmt.Sprintf("[%[1]v](https://%[2]s/epoch/%[1]v)", epoch, utils.Config.Frontend.SiteDomain),
+										Inline: false,
+									},
+								},
+							})
 
-						discordNotifMap[w.ID] = append(discordNotifMap[w.ID], content)
-					} else if w.Destination.Valid && w.Destination.String == "webhook" {
-						events := []*types.WebhookEvent{}
-						for _, n := range notifications {
-							events = append(events, &types.WebhookEvent{
-								Network:     utils.GetNetwork(),
-								Name:        string(n.GetEventName()),
-								Title:       n.GetTitle(),
-								Description: n.GetInfo(types.NotifciationFormatText),
-								Epoch:       n.GetEpoch(),
-								Target:      n.GetEventFilter(),
+							if _, exists := discordNotifMap[w.ID]; !exists {
+								discordNotifMap[w.ID] = make([]types.TransitDiscordContent, 0)
+							}
+							log.Infof("adding discord notification for user %d, dashboard %d, group %d and type %s", userID, dashboardId, dashboardGroupId, event)
+
+							discordNotifMap[w.ID] = append(discordNotifMap[w.ID], content)
+						} else if w.Destination.Valid && w.Destination.String == "webhook" {
+							events := []*types.WebhookEvent{}
+							for _, n := range notifications {
+								events = append(events, &types.WebhookEvent{
+									Network:     utils.GetNetwork(),
+									Name:        string(n.GetEventName()),
+									Title:       n.GetTitle(),
+									Description: n.GetInfo(types.NotifciationFormatText),
+									Epoch:       n.GetEpoch(),
+									Target:      n.GetEventFilter(),
+								})
+							}
+							notifs = append(notifs, types.TransitWebhook{
+								Channel: w.Destination.String,
+								Content: types.TransitWebhookContent{
+									Webhook: w,
+									Events:  events,
+									UserId:  userID,
+								},
 							})
 						}
-						notifs = append(notifs, types.TransitWebhook{
-							Channel: w.Destination.String,
-							Content: types.TransitWebhookContent{
-								Webhook: w,
-								Events:  events,
-								UserId:  userID,
-							},
-						})
 					}
 				}
 			}
@@ -1007,6 +1031,97 @@ func QueueWebhookNotifications(notificationsByUserID types.NotificationsPerUserI
 	return nil
 }
 
+// newDashboardWebhookMap indexes the given dashboard group webhooks by user, dashboard and group
+func newDashboardWebhookMap(webhooks []types.UserWebhook) map[types.UserId]map[types.DashboardId]map[types.DashboardGroupId]types.UserWebhook {
+	dashboardWebhookMap := make(map[types.UserId]map[types.DashboardId]map[types.DashboardGroupId]types.UserWebhook)
+	for _, w := range webhooks {
+		if w.Destination.Valid && w.Destination.String == "discord" {
+			w.Destination.String = "webhook_discord"
+		}
+		if _, exists := dashboardWebhookMap[types.UserId(w.UserID)]; !exists {
+			dashboardWebhookMap[types.UserId(w.UserID)] = make(map[types.DashboardId]map[types.DashboardGroupId]types.UserWebhook)
+		}
+		if _, exists := dashboardWebhookMap[types.UserId(w.UserID)][types.DashboardId(w.DashboardId)]; !exists {
+			dashboardWebhookMap[types.UserId(w.UserID)][types.DashboardId(w.DashboardId)] = make(map[types.DashboardGroupId]types.UserWebhook)
+		}
+
+		dashboardWebhookMap[types.UserId(w.UserID)][types.DashboardId(w.DashboardId)][types.DashboardGroupId(w.DashboardGroupId)] = w
+	}
+	return dashboardWebhookMap
+}
+
+// splitAccountDashboardNotifications splits the notifications of a dashboard group into the ones of validator dashboards and the ones of account dashboards
+func splitAccountDashboardNotifications(notificationsPerGroup types.NotificationsPerEventName) (validator, account types.NotificationsPerEventName) {
+	validator = make(types.NotificationsPerEventName, len(notificationsPerGroup))
+	account = make(types.NotificationsPerEventName)
+	for event, notifications := range notificationsPerGroup {
+		if types.IsAccountDashboardEvent(event) {
+			account[event] = notifications
+		} else {
+			validator[event] = notifications
+		}
+	}
+	return validator, account
+}
+
+// maxEventDetails is the maximum number of notifications per event that are listed in summary messages
+const maxEventDetails = 10
+
+// getEventDetails renders one line per notification in the given format, at most maxEventDetails notifications are listed.
+// It also returns the total reward of proposal notifications and the epoch of the notifications.
+func getEventDetails(event types.EventName, notifications types.NotificationsPerEventFilter, format types.NotificationFormat) (details string, totalBlockReward float64, epoch uint64) {
+	i := 0
+	for _, n := range notifications {
+		if event == types.ValidatorExecutedProposalEventName {
+			proposalNotification, ok := n.(*ValidatorProposalNotification)
+			if !ok {
+				log.Error(fmt.Errorf("error casting proposal notification"), "", 0)
+				continue
+			}
+			totalBlockReward += proposalNotification.Reward
+		}
+		if epoch == 0 {
+			epoch = n.GetEpoch()
+		}
+		if i < maxEventDetails {
+			details += fmt.Sprintf("%s\n", n.GetInfo(format))
+		}
+		i++
+	}
+	if i > maxEventDetails {
+		details += fmt.Sprintf("... and %d more notifications\n", i-maxEventDetails)
+	}
+	return details, totalBlockReward, epoch
+}
+
+// getEventSummary returns a one line summary of count notifications of the given event
+func getEventSummary(event types.EventName, count int, totalBlockReward float64) string {
+	plural := ""
+	if count > 1 {
+		plural = "s"
+	}
+	switch event {
+	case types.RocketpoolCollateralMaxReachedEventName, types.RocketpoolCollateralMinReachedEventName:
+		return fmt.Sprintf("%s: %d node%s", types.EventLabel[event], count, plural)
+	case types.TaxReportEventName, types.NetworkLivenessIncreasedEventName, types.NetworkGasAboveThresholdEventName, types.NetworkGasBelowThresholdEventName:
+		return fmt.Sprintf("%s: %d event%s", types.EventLabel[event], count, plural)
+	case types.EthClientUpdateEventName:
+		return fmt.Sprintf("%s: %d client%s", types.EventLabel[event], count, plural)
+	case types.MonitoringMachineCpuLoadEventName, types.MonitoringMachineMemoryUsageEventName, types.MonitoringMachineDiskAlmostFullEventName, types.MonitoringMachineOfflineEventName:
+		return fmt.Sprintf("%s: %d machine%s", types.EventLabel[event], count, plural)
+	case types.ValidatorExecutedProposalEventName:
+		return fmt.Sprintf("%s: %d validator%s, Reward: %.3f ETH", types.EventLabel[event], count, plural, totalBlockReward)
+	case types.ValidatorGroupEfficiencyEventName:
+		return fmt.Sprintf("%s: %d group%s", types.EventLabel[event], count, plural)
+	case types.IncomingTransactionEventName, types.OutgoingTransactionEventName:
+		return fmt.Sprintf("%s: %d transaction%s", types.EventLabel[event], count, plural)
+	case types.ERC20TokenTransferEventName, types.ERC721TokenTransferEventName, types.ERC1155TokenTransferEventName:
+		return fmt.Sprintf("%s: %d transfer%s", types.EventLabel[event], count, plural)
+	default:
+		return fmt.Sprintf("%s: %d validator%s", types.EventLabel[event], count, plural)
+	}
+}
+
 func getNetwork() string {
 	domainParts := strings.Split(utils.Config.Frontend.SiteDomain, ".")
 	if len(domainParts) >= 3 {
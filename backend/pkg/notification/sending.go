@@ -24,8 +24,12 @@ import (
 const NOTIFICAION_EMAIL_RATE_LIMIT_BUCKET = "n_mails"
 const NOTIFICAION_PUSH_RATE_LIMIT_BUCKET = "n_push"
 const NOTIFICAION_WEBHOOK_RATE_LIMIT_BUCKET = "n_webhooks"
+const NOTIFICAION_TELEGRAM_RATE_LIMIT_BUCKET = "n_telegram"
+const NOTIFICAION_SLACK_RATE_LIMIT_BUCKET = "n_slack"
+const NOTIFICAION_MATRIX_RATE_LIMIT_BUCKET = "n_matrix"
 
 const NOTIFICATION_TEST_EMAIL_RATE_LIMIT_BUCKET = "n_test_mails"
+const NOTIFICATION_TEST_CHAT_RATE_LIMIT_BUCKET = "n_test_chat"
 
 func InitNotificationSender() {
 	log.Infof("starting notifications-sender")
@@ -57,6 +61,16 @@ func notificationSender() {
 		}
 
 		log.Infof("lock obtained")
+		err = processTelegramUpdates()
+		if err != nil {
+			log.Error(err, "error processing telegram updates", 0)
+		}
+
+		err = releaseHeldNotifications()
+		if err != nil {
+			log.Error(err, "error releasing held notifications", 0)
+		}
+
 		err = dispatchNotifications()
 		if err != nil {
 			log.Error(err, "error dispatching notifications", 0)
@@ -140,6 +154,21 @@ func dispatchNotifications() error {
 		return fmt.Errorf("error sending webhook discord notifications, err: %w", err)
 	}
 
+	err = sendTelegramNotifications()
+	if err != nil {
+		return fmt.Errorf("error sending telegram notifications, err: %w", err)
+	}
+
+	err = sendSlackNotifications()
+	if err != nil {
+		return fmt.Errorf("error sending slack notifications, err: %w", err)
+	}
+
+	err = sendMatrixNotifications()
+	if err != nil {
+		return fmt.Errorf("error sending matrix notifications, err: %w", err)
+	}
+
 	return nil
 }
 
@@ -235,18 +264,27 @@ func sendWebhookNotifications() error {
 		id,
 		created,
 		sent,
+		retries,
 		channel,
 		content
-	FROM notification_queue WHERE sent IS null AND channel = 'webhook' ORDER BY created ASC`)
+	FROM notification_queue WHERE sent IS null AND channel = 'webhook' AND (next_attempt_ts IS NULL OR next_attempt_ts <= NOW()) ORDER BY created ASC`)
 	if err != nil {
 		return fmt.Errorf("error querying notification queue, err: %w", err)
 	}
 
-	// webhooks have 5 seconds to respond
-	client := &http.Client{Timeout: time.Second * 5}
+	client := &http.Client{Timeout: getWebhookTimeout()}
 
 	log.Infof("processing %v webhook notifications", len(notificationQueueItem))
 
+	webhooks := make([]types.UserWebhook, 0, len(notificationQueueItem))
+	for _, n := range notificationQueueItem {
+		webhooks = append(webhooks, n.Content.Webhook)
+	}
+	secrets, err := getWebhookSigningSecrets(webhooks)
+	if err != nil {
+		return err
+	}
+
 	// use an error group to throttle webhook requests
 	g := &errgroup.Group{}
 	g.SetLimit(50) // issue at most 50 requests at a time
@@ -257,57 +295,66 @@ func sendWebhookNotifications() error {
 			log.Error(err, "error counting sent webhook", 0)
 		}
 
-		// do not retry after 5 attempts
+		// the webhook has been failing consistently, do not attempt to deliver the event
 		if n.Content.Webhook.Retries > 5 {
-			_, err := db.WriterDb.Exec(`DELETE FROM notification_queue WHERE id = $1`, n.Id)
+			err := moveToDeadLetters(n.Id, n.Content.UserId, false, "", "webhook disabled after too many failed deliveries")
 			if err != nil {
-				return fmt.Errorf("error deleting from notification queue: %w", err)
+				return err
 			}
 			continue
 		}
 
-		reqBody := new(bytes.Buffer)
-
-		err = json.NewEncoder(reqBody).Encode(n.Content)
+		reqBody, err := json.Marshal(n.Content)
 		if err != nil {
 			log.Error(err, "error marshalling webhook event", 0)
 		}
 
 		_, err = url.Parse(n.Content.Webhook.Url)
 		if err != nil {
-			_, err := db.WriterDb.Exec(`DELETE FROM notification_queue WHERE id = $1`, n.Id)
+			err := moveToDeadLetters(n.Id, n.Content.UserId, false, "", fmt.Sprintf("invalid webhook url: %v", err))
 			if err != nil {
-				return fmt.Errorf("error deleting from notification queue: %w", err)
+				return err
 			}
 			continue
 		}
 
 		g.Go(func() error {
-			if n.Content.Webhook.Retries > 0 {
-				time.Sleep(time.Duration(n.Content.Webhook.Retries) * time.Second)
+			req, err := http.NewRequest(http.MethodPost, n.Content.Webhook.Url, bytes.NewReader(reqBody))
+			if err != nil {
+				log.Warnf("error creating webhook request: %v", err)
+				return nil
 			}
-			resp, err := client.Post(n.Content.Webhook.Url, "application/json", reqBody)
+			req.Header.Set("Content-Type", "application/json")
+			if secret := secrets.get(n.Content.Webhook); secret != "" {
+				SignWebhookRequest(req, secret, n.Id, time.Now(), reqBody)
+			}
+
+			resp, err := client.Do(req)
 			if err != nil {
 				log.Warnf("error sending webhook request: %v", err)
 				metrics.NotificationsSent.WithLabelValues("webhook", "error").Inc()
+				err = handleFailedWebhookDelivery(n.Id, n.Retries, n.Content.UserId, "", err.Error())
+				if err != nil {
+					log.Error(err, "error handling failed webhook delivery", 0)
+				}
 				return nil
 			} else {
 				metrics.NotificationsSent.WithLabelValues("webhook", resp.Status).Inc()
 			}
 			defer resp.Body.Close()
 
-			_, err = db.WriterDb.Exec(`UPDATE notification_queue SET sent = now() WHERE id = $1`, n.Id)
-			if err != nil {
-				log.Error(err, "error updating notification_queue table", 0)
-				return nil
-			}
+			if resp.StatusCode < 400 {
+				_, err = db.WriterDb.Exec(`UPDATE notification_queue SET sent = now() WHERE id = $1`, n.Id)
+				if err != nil {
+					log.Error(err, "error updating notification_queue table", 0)
+					return nil
+				}
 
-			if resp != nil && resp.StatusCode < 400 {
 				// update retries counters in db based on end result
 				if n.Content.Webhook.DashboardId == 0 && n.Content.Webhook.DashboardGroupId == 0 {
 					_, err = db.FrontendWriterDB.Exec(`UPDATE users_webhooks SET retries = $1, last_sent = now() WHERE id = $2;`, n.Content.Webhook.Retries, n.Content.Webhook.ID)
 				} else {
-					_, err = db.WriterDb.Exec(`UPDATE users_val_dashboards_groups SET webhook_retries = $1, webhook_last_sent = now() WHERE id = $2 AND dashboard_id = $3;`, n.Content.Webhook.Retries, n.Content.Webhook.DashboardGroupId, n.Content.Webhook.DashboardId)
+					_, err = db.WriterDb.Exec(fmt.Sprintf(`UPDATE %s SET webhook_retries = $1, webhook_last_sent = now() WHERE id = $2 AND dashboard_id = $3;`, dashboardWebhookGroupsTable(n.Content.Webhook)), n.Content.Webhook.Retries, n.Content.Webhook.DashboardGroupId, n.Content.Webhook.DashboardId)
 				}
 				if err != nil {
 					log.Warnf("failed to update retries counter to %v for webhook %v: %v", n.Content.Webhook.Retries, n.Content.Webhook.ID, err)
@@ -315,20 +362,23 @@ func sendWebhookNotifications() error {
 			} else {
 				var errResp types.ErrorResponse
 
-				if resp != nil {
-					b, err := io.ReadAll(resp.Body)
-					if err != nil {
-						log.Error(err, "error reading body", 0)
-					}
+				b, err := io.ReadAll(resp.Body)
+				if err != nil {
+					log.Error(err, "error reading body", 0)
+				}
+
+				errResp.Status = resp.Status
+				errResp.Body = string(b)
 
-					errResp.Status = resp.Status
-					errResp.Body = string(b)
+				err = handleFailedWebhookDelivery(n.Id, n.Retries, n.Content.UserId, errResp.Status, errResp.Body)
+				if err != nil {
+					log.Error(err, "error handling failed webhook delivery", 0)
 				}
 
 				if n.Content.Webhook.DashboardId == 0 && n.Content.Webhook.DashboardGroupId == 0 {
 					_, err = db.FrontendWriterDB.Exec(`UPDATE users_webhooks SET retries = retries + 1, last_sent = now(), request = $2, response = $3 WHERE id = $1;`, n.Content.Webhook.ID, n.Content, errResp)
 				} else {
-					_, err = db.WriterDb.Exec(`UPDATE users_val_dashboards_groups SET webhook_retries = webhook_retries + 1, webhook_last_sent = now() WHERE id = $1 AND dashboard_id = $2;`, n.Content.Webhook.DashboardGroupId, n.Content.Webhook.DashboardId)
+					_, err = db.WriterDb.Exec(fmt.Sprintf(`UPDATE %s SET webhook_retries = webhook_retries + 1, webhook_last_sent = now() WHERE id = $1 AND dashboard_id = $2;`, dashboardWebhookGroupsTable(n.Content.Webhook)), n.Content.Webhook.DashboardGroupId, n.Content.Webhook.DashboardId)
 				}
 				if err != nil {
 					log.Error(err, "error updating users_webhooks table", 0)
@@ -353,14 +403,14 @@ func sendDiscordNotifications() error {
 		id,
 		created,
 		sent,
+		retries,
 		channel,
 		content
-	FROM notification_queue WHERE sent IS null AND channel = 'webhook_discord' ORDER BY created ASC`)
+	FROM notification_queue WHERE sent IS null AND channel = 'webhook_discord' AND (next_attempt_ts IS NULL OR next_attempt_ts <= NOW()) ORDER BY created ASC`)
 	if err != nil {
 		return fmt.Errorf("error querying notification queue, err: %w", err)
 	}
-	// webhooks have 5 seconds to respond
-	client := &http.Client{Timeout: time.Second * 5}
+	client := &http.Client{Timeout: getWebhookTimeout()}
 
 	log.Infof("processing %v discord webhook notifications", len(notificationQueueItem))
 
@@ -374,57 +424,56 @@ func sendDiscordNotifications() error {
 			log.Error(err, "error counting sent webhook", 0)
 		}
 
-		// do not retry after 5 attempts
+		// the webhook has been failing consistently, do not attempt to deliver the event
 		if n.Content.Webhook.Retries > 5 {
-			_, err := db.WriterDb.Exec(`DELETE FROM notification_queue WHERE id = $1`, n.Id)
+			err := moveToDeadLetters(n.Id, n.Content.UserId, false, "", "webhook disabled after too many failed deliveries")
 			if err != nil {
-				return fmt.Errorf("error deleting from notification queue: %w", err)
+				return err
 			}
 			continue
 		}
 
-		reqBody := new(bytes.Buffer)
-
-		err = json.NewEncoder(reqBody).Encode(n.Content.DiscordRequest)
+		reqBody, err := json.Marshal(n.Content.DiscordRequest)
 		if err != nil {
 			log.Error(err, "error marshalling webhook event", 0)
 		}
 
 		_, err = url.Parse(n.Content.Webhook.Url)
 		if err != nil {
-			_, err := db.WriterDb.Exec(`DELETE FROM notification_queue WHERE id = $1`, n.Id)
+			err := moveToDeadLetters(n.Id, n.Content.UserId, false, "", fmt.Sprintf("invalid webhook url: %v", err))
 			if err != nil {
-				return fmt.Errorf("error deleting from notification queue: %w", err)
+				return err
 			}
 			continue
 		}
 
 		g.Go(func() error {
-			if n.Content.Webhook.Retries > 0 {
-				time.Sleep(time.Duration(n.Content.Webhook.Retries) * time.Second)
-			}
-			resp, err := client.Post(n.Content.Webhook.Url, "application/json", reqBody)
+			resp, err := client.Post(n.Content.Webhook.Url, "application/json", bytes.NewReader(reqBody))
 			if err != nil {
 				log.Warnf("error sending discord webhook request: %v", err)
 				metrics.NotificationsSent.WithLabelValues("webhook_discord", "error").Inc()
+				err = handleFailedWebhookDelivery(n.Id, n.Retries, n.Content.UserId, "", err.Error())
+				if err != nil {
+					log.Error(err, "error handling failed discord webhook delivery", 0)
+				}
 				return nil
 			} else {
 				metrics.NotificationsSent.WithLabelValues("webhook_discord", resp.Status).Inc()
 			}
 			defer resp.Body.Close()
 
-			_, err = db.WriterDb.Exec(`UPDATE notification_queue SET sent = now() WHERE id = $1`, n.Id)
-			if err != nil {
-				log.Error(err, "error updating notification_queue table", 0)
-				return nil
-			}
+			if resp.StatusCode < 400 {
+				_, err = db.WriterDb.Exec(`UPDATE notification_queue SET sent = now() WHERE id = $1`, n.Id)
+				if err != nil {
+					log.Error(err, "error updating notification_queue table", 0)
+					return nil
+				}
 
-			if resp != nil && resp.StatusCode < 400 {
 				// update retries counters in db based on end result
 				if n.Content.Webhook.DashboardId == 0 && n.Content.Webhook.DashboardGroupId == 0 {
 					_, err = db.FrontendWriterDB.Exec(`UPDATE users_webhooks SET retries = $1, last_sent = now() WHERE id = $2;`, n.Content.Webhook.Retries, n.Content.Webhook.ID)
 				} else {
-					_, err = db.WriterDb.Exec(`UPDATE users_val_dashboards_groups SET webhook_retries = $1, webhook_last_sent = now() WHERE id = $2 AND dashboard_id = $3;`, n.Content.Webhook.Retries, n.Content.Webhook.DashboardGroupId, n.Content.Webhook.DashboardId)
+					_, err = db.WriterDb.Exec(fmt.Sprintf(`UPDATE %s SET webhook_retries = $1, webhook_last_sent = now() WHERE id = $2 AND dashboard_id = $3;`, dashboardWebhookGroupsTable(n.Content.Webhook)), n.Content.Webhook.Retries, n.Content.Webhook.DashboardGroupId, n.Content.Webhook.DashboardId)
 				}
 				if err != nil {
 					log.Warnf("failed to update retries counter to %v for webhook %v: %v", n.Content.Webhook.Retries, n.Content.Webhook.ID, err)
@@ -432,20 +481,23 @@ func sendDiscordNotifications() error {
 			} else {
 				var errResp types.ErrorResponse
 
-				if resp != nil {
-					b, err := io.ReadAll(resp.Body)
-					if err != nil {
-						log.Error(err, "error reading body", 0)
-					}
+				b, err := io.ReadAll(resp.Body)
+				if err != nil {
+					log.Error(err, "error reading body", 0)
+				}
+
+				errResp.Status = resp.Status
+				errResp.Body = string(b)
 
-					errResp.Status = resp.Status
-					errResp.Body = string(b)
+				err = handleFailedWebhookDelivery(n.Id, n.Retries, n.Content.UserId, errResp.Status, errResp.Body)
+				if err != nil {
+					log.Error(err, "error handling failed discord webhook delivery", 0)
 				}
 
 				if n.Content.Webhook.DashboardId == 0 && n.Content.Webhook.DashboardGroupId == 0 {
 					_, err = db.FrontendWriterDB.Exec(`UPDATE users_webhooks SET retries = retries + 1, last_sent = now(), request = $2, response = $3 WHERE id = $1;`, n.Content.Webhook.ID, n.Content, errResp)
 				} else {
-					_, err = db.WriterDb.Exec(`UPDATE users_val_dashboards_groups SET webhook_retries = webhook_retries + 1, webhook_last_sent = now() WHERE id = $1 AND dashboard_id = $2;`, n.Content.Webhook.DashboardGroupId, n.Content.Webhook.DashboardId)
+					_, err = db.WriterDb.Exec(fmt.Sprintf(`UPDATE %s SET webhook_retries = webhook_retries + 1, webhook_last_sent = now() WHERE id = $1 AND dashboard_id = $2;`, dashboardWebhookGroupsTable(n.Content.Webhook)), n.Content.Webhook.DashboardGroupId, n.Content.Webhook.DashboardId)
 				}
 				if err != nil {
 					log.Error(err, "error updating users_webhooks table", 0)
@@ -1,6 +1,8 @@
 package notification
 
 import (
+	"bytes"
+	"context"
 	"database/sql"
 	"database/sql/driver"
 	"errors"
@@ -12,9 +14,9 @@ import (
 	"time"
 
 	"github.com/gobitfly/beaconchain/pkg/commons/log"
-	"github.com/gobitfly/beaconchain/pkg/commons/services"
 	"github.com/gobitfly/beaconchain/pkg/commons/types"
 	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/shopspring/decimal"
 )
 
 func formatValidatorLink(format types.NotificationFormat, validatorIndex interface{}) string {
@@ -419,6 +421,46 @@ func (n *ValidatorWithdrawalNotification) GetLegacyTitle() string {
 	return "Withdrawal Processed"
 }
 
+type ValidatorPoolOperationNotIncludedNotification struct {
+	types.NotificationBaseImpl
+
+	ValidatorIndex uint64
+	OperationType  string
+	FirstSeenSlot  uint64
+	Epochs         uint64
+}
+
+func (n *ValidatorPoolOperationNotIncludedNotification) GetEntitiyId() string {
+	return fmt.Sprintf("%v", n.ValidatorIndex)
+}
+
+func (n *ValidatorPoolOperationNotIncludedNotification) operationName() string {
+	if n.OperationType == types.PoolOperationBlsToExecutionChange {
+		return "withdrawal credential change"
+	}
+	return "voluntary exit"
+}
+
+func (n *ValidatorPoolOperationNotIncludedNotification) GetInfo(format types.NotificationFormat) string {
+	dashboardAndGroupInfo := formatValidatorPrefixedDashboardAndGroupLink(format, n)
+	vali := formatValidatorLink(format, n.ValidatorIndex)
+	slot := formatSlotLink(format, n.FirstSeenSlot)
+
+	return fmt.Sprintf(`The %s of validator %v%v first seen in the op pool at slot %v has not been included in a block within %d epochs.`, n.operationName(), vali, dashboardAndGroupInfo, slot, n.Epochs)
+}
+
+func (n *ValidatorPoolOperationNotIncludedNotification) GetTitle() string {
+	return n.GetLegacyTitle()
+}
+
+func (n *ValidatorPoolOperationNotIncludedNotification) GetLegacyInfo() string {
+	return fmt.Sprintf(`The %s of validator %v first seen in the op pool at slot %v has not been included in a block within %d epochs.`, n.operationName(), n.ValidatorIndex, n.FirstSeenSlot, n.Epochs)
+}
+
+func (n *ValidatorPoolOperationNotIncludedNotification) GetLegacyTitle() string {
+	return "Operation not Included"
+}
+
 type EthClientNotification struct {
 	types.NotificationBaseImpl
 
@@ -520,44 +562,73 @@ func (n *MonitorMachineNotification) GetEventFilter() string {
 
 type TaxReportNotification struct {
 	types.NotificationBaseImpl
+
+	// Currency and Format configure the income report of dashboard subscriptions, legacy subscriptions carry them in the event filter
+	Currency string
+	Format   string
 }
 
 func (n *TaxReportNotification) GetEntitiyId() string {
 	return ""
 }
 
-func (n *TaxReportNotification) GetEmailAttachment() *types.EmailAttachment {
-	tNow := time.Now()
-	lastDay := time.Date(tNow.Year(), tNow.Month(), 1, 0, 0, 0, 0, time.UTC)
-	firstDay := lastDay.AddDate(0, -1, 0)
+// IncomeReportRenderer renders the income report that is attached to tax report notifications
+type IncomeReportRenderer func(ctx context.Context, req types.IncomeReportRequest) (*types.EmailAttachment, error)
 
-	q, err := url.ParseQuery(n.EventFilter)
+var incomeReportRenderer IncomeReportRenderer
 
-	if err != nil {
-		log.Warnf("Failed to parse rewards report eventfilter: %v", err)
+// SetIncomeReportRenderer sets the renderer of the income reports attached to tax report notifications,
+// without a renderer tax report notifications are sent without a report
+func SetIncomeReportRenderer(renderer IncomeReportRenderer) {
+	incomeReportRenderer = renderer
+}
+
+// GetEmailAttachment renders the income report of the previous calendar month
+func (n *TaxReportNotification) GetEmailAttachment() *types.EmailAttachment {
+	if incomeReportRenderer == nil {
 		return nil
 	}
+	now := time.Now().UTC()
+	end := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	req := types.IncomeReportRequest{
+		Currency: n.Currency,
+		Format:   n.Format,
+		Start:    end.AddDate(0, -1, 0),
+		End:      end,
+	}
 
-	currency := q.Get("currency")
+	if n.DashboardId != nil && n.DashboardGroupId != nil {
+		req.Name = fmt.Sprintf("%s (%s)", n.DashboardName, n.DashboardGroupName)
+		req.DashboardId = uint64(*n.DashboardId)
+		req.GroupId = *n.DashboardGroupId
+	} else {
+		q, err := url.ParseQuery(n.EventFilter)
+		if err != nil {
+			log.Warnf("Failed to parse rewards report eventfilter: %v", err)
+			return nil
+		}
 
-	validators := []uint64{}
-	valSlice := strings.Split(q.Get("validators"), ",")
-	if len(valSlice) > 0 {
-		for _, val := range valSlice {
+		for _, val := range strings.Split(q.Get("validators"), ",") {
 			v, err := strconv.ParseUint(val, 10, 64)
 			if err != nil {
 				continue
 			}
-			validators = append(validators, v)
+			req.Validators = append(req.Validators, v)
 		}
-	} else {
-		log.Warnf("Validators Not found in rewards report eventfilter")
-		return nil
+		if len(req.Validators) == 0 {
+			log.Warnf("Validators Not found in rewards report eventfilter")
+			return nil
+		}
+		req.Name = "Validators"
+		req.Currency = q.Get("currency")
 	}
 
-	pdf := services.GetPdfReport(validators, currency, uint64(firstDay.Unix()), uint64(lastDay.Unix()))
-
-	return &types.EmailAttachment{Attachment: pdf, Name: fmt.Sprintf("income_history_%v_%v.pdf", firstDay.Format("20060102"), lastDay.Format("20060102"))}
+	attachment, err := incomeReportRenderer(context.Background(), req)
+	if err != nil {
+		log.Error(err, "error rendering income report", 0, log.Fields{"subscription": n.SubscriptionID})
+		return nil
+	}
+	return attachment
 }
 
 func (n *TaxReportNotification) GetInfo(format types.NotificationFormat) string {
@@ -570,6 +641,9 @@ func (n *TaxReportNotification) GetTitle() string {
 
 func (n *TaxReportNotification) GetLegacyInfo() string {
 	generalPart := `Please find attached the income history of your selected validators.`
+	if n.DashboardId != nil {
+		generalPart = fmt.Sprintf(`Please find attached the income report of the group %s of your dashboard %s.`, n.DashboardGroupName, n.DashboardName)
+	}
 	return generalPart
 }
 
@@ -800,6 +874,150 @@ func getSyncCommitteeSoonLegacyInfo(ns map[types.EventFilter]types.Notification)
 	return ""
 }
 
+func formatAccountDashboardAndGroupLink(format types.NotificationFormat, n types.Notification) string {
+	dashboardAndGroupInfo := ""
+	if n.GetDashboardId() != nil {
+		switch format {
+		case types.NotifciationFormatHtml:
+			dashboardAndGroupInfo = fmt.Sprintf(` of Group <b>%[2]v</b> in Account Dashboard <a href="https://%[1]v/account-dashboard/%[4]v">%[3]v</a>`, utils.Config.Frontend.SiteDomain, n.GetDashboardGroupName(), n.GetDashboardName(), *n.GetDashboardId())
+		case types.NotifciationFormatText:
+			dashboardAndGroupInfo = fmt.Sprintf(` of Group %[1]v in Account Dashboard %[2]v`, n.GetDashboardGroupName(), n.GetDashboardName())
+		case types.NotifciationFormatMarkdown:
+			dashboardAndGroupInfo = fmt.Sprintf(` of Group **%[1]v** in Account Dashboard [%[2]v](https://%[3]v/account-dashboard/%[4]v)`, n.GetDashboardGroupName(), n.GetDashboardName(), utils.Config.Frontend.SiteDomain, *n.GetDashboardId())
+		}
+	}
+	return dashboardAndGroupInfo
+}
+
+func formatAddressLink(format types.NotificationFormat, address []byte) string {
+	switch format {
+	case types.NotifciationFormatHtml:
+		return fmt.Sprintf(`<a href="https://%s/address/%#x">%s</a>`, utils.Config.Frontend.SiteDomain, address, utils.FormatHashRaw(address))
+	case types.NotifciationFormatText:
+		return utils.FormatHashRaw(address, false)
+	case types.NotifciationFormatMarkdown:
+		return fmt.Sprintf(`[%s](https://%s/address/%#x)`, utils.FormatHashRaw(address), utils.Config.Frontend.SiteDomain, address)
+	}
+	return ""
+}
+
+func formatTxLink(format types.NotificationFormat, hash []byte) string {
+	switch format {
+	case types.NotifciationFormatHtml:
+		return fmt.Sprintf(`<a href="https://%s/tx/%#x">%s</a>`, utils.Config.Frontend.SiteDomain, hash, utils.FormatHashRaw(hash))
+	case types.NotifciationFormatText:
+		return utils.FormatHashRaw(hash, false)
+	case types.NotifciationFormatMarkdown:
+		return fmt.Sprintf(`[%s](https://%s/tx/%#x)`, utils.FormatHashRaw(hash), utils.Config.Frontend.SiteDomain, hash)
+	}
+	return ""
+}
+
+// AccountTransactionNotification is sent for incoming and outgoing transactions of an account of an account dashboard
+type AccountTransactionNotification struct {
+	types.NotificationBaseImpl
+
+	Address     []byte
+	TxHash      []byte
+	BlockNumber uint64
+	From        []byte
+	To          []byte
+	Value       []byte
+}
+
+func (n *AccountTransactionNotification) GetEntitiyId() string {
+	return fmt.Sprintf("%#x", n.Address)
+}
+
+func (n *AccountTransactionNotification) GetInfo(format types.NotificationFormat) string {
+	dashboardAndGroupInfo := formatAccountDashboardAndGroupLink(format, n)
+	amount := utils.FormatElCurrencyString(n.Value, utils.Config.Frontend.ElCurrency, 6, true, false, false)
+	if n.EventName == types.OutgoingTransactionEventName {
+		return fmt.Sprintf(`Account %s%s sent a transaction of %s to %s (tx %s).`, formatAddressLink(format, n.Address), dashboardAndGroupInfo, amount, formatAddressLink(format, n.To), formatTxLink(format, n.TxHash))
+	}
+	return fmt.Sprintf(`Account %s%s received a transaction of %s from %s (tx %s).`, formatAddressLink(format, n.Address), dashboardAndGroupInfo, amount, formatAddressLink(format, n.From), formatTxLink(format, n.TxHash))
+}
+
+func (n *AccountTransactionNotification) GetTitle() string {
+	return n.GetLegacyTitle()
+}
+
+func (n *AccountTransactionNotification) GetLegacyInfo() string {
+	return n.GetInfo(types.NotifciationFormatText)
+}
+
+func (n *AccountTransactionNotification) GetLegacyTitle() string {
+	if n.EventName == types.OutgoingTransactionEventName {
+		return "Outgoing Transaction"
+	}
+	return "Incoming Transaction"
+}
+
+// AccountTokenTransferNotification is sent for erc20, erc721 and erc1155 token transfers of an account of an account dashboard
+type AccountTokenTransferNotification struct {
+	types.NotificationBaseImpl
+
+	Address       []byte
+	TxHash        []byte
+	BlockNumber   uint64
+	From          []byte
+	To            []byte
+	TokenAddress  []byte
+	TokenSymbol   string
+	TokenDecimals uint64
+	Value         []byte
+	TokenId       []byte
+}
+
+func (n *AccountTokenTransferNotification) GetEntitiyId() string {
+	return fmt.Sprintf("%#x", n.Address)
+}
+
+// formatAmount returns the transferred amount including the token, erc20 values are scaled by the decimals of the token
+func (n *AccountTokenTransferNotification) formatAmount(format types.NotificationFormat) string {
+	token := n.TokenSymbol
+	if token == "" {
+		token = formatAddressLink(format, n.TokenAddress)
+	}
+	switch n.EventName {
+	case types.ERC721TokenTransferEventName:
+		return fmt.Sprintf(`%s #%s`, token, new(big.Int).SetBytes(n.TokenId))
+	case types.ERC1155TokenTransferEventName:
+		return fmt.Sprintf(`%s %s #%s`, new(big.Int).SetBytes(n.Value), token, new(big.Int).SetBytes(n.TokenId))
+	default:
+		value := decimal.NewFromBigInt(new(big.Int).SetBytes(n.Value), -int32(n.TokenDecimals)) //nolint:gosec
+		return fmt.Sprintf(`%s %s`, value, token)
+	}
+}
+
+func (n *AccountTokenTransferNotification) GetInfo(format types.NotificationFormat) string {
+	dashboardAndGroupInfo := formatAccountDashboardAndGroupLink(format, n)
+	amount := n.formatAmount(format)
+	if bytes.Equal(n.From, n.Address) {
+		return fmt.Sprintf(`Account %s%s transferred %s to %s (tx %s).`, formatAddressLink(format, n.Address), dashboardAndGroupInfo, amount, formatAddressLink(format, n.To), formatTxLink(format, n.TxHash))
+	}
+	return fmt.Sprintf(`Account %s%s received %s from %s (tx %s).`, formatAddressLink(format, n.Address), dashboardAndGroupInfo, amount, formatAddressLink(format, n.From), formatTxLink(format, n.TxHash))
+}
+
+func (n *AccountTokenTransferNotification) GetTitle() string {
+	return n.GetLegacyTitle()
+}
+
+func (n *AccountTokenTransferNotification) GetLegacyInfo() string {
+	return n.GetInfo(types.NotifciationFormatText)
+}
+
+func (n *AccountTokenTransferNotification) GetLegacyTitle() string {
+	switch n.EventName {
+	case types.ERC721TokenTransferEventName:
+		return "ERC721 Token Transfer"
+	case types.ERC1155TokenTransferEventName:
+		return "ERC1155 Token Transfer"
+	default:
+		return "ERC20 Token Transfer"
+	}
+}
+
 type BigFloat big.Float
 
 func (b *BigFloat) Value() (driver.Value, error) {
@@ -0,0 +1,273 @@
+package notification
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	// WebhookSignatureHeader carries the timestamp and the HMAC-SHA256 signature of a webhook delivery
+	// in the format `t=<unix timestamp>,v1=<hex encoded signature>`
+	WebhookSignatureHeader = "X-Signature"
+	// WebhookIdHeader carries an id that is stable across retries of the same delivery and can be used to detect replays
+	WebhookIdHeader = "X-Webhook-Id"
+
+	// WebhookSignatureTolerance is the recommended maximum age of a signature timestamp that receivers should accept
+	WebhookSignatureTolerance = time.Minute * 5
+
+	webhookMaxDeliveryAttempts = 5
+	webhookRetryBaseDelay      = time.Second * 30
+	webhookRetryMaxDelay       = time.Minute * 15
+	webhookDefaultTimeout      = time.Second * 5
+)
+
+// computeWebhookSignature returns the hex encoded HMAC-SHA256 of `<timestamp>.<body>` using the given secret
+func computeWebhookSignature(secret string, ts int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(ts, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignWebhookRequest sets the signature and id headers for a webhook delivery
+func SignWebhookRequest(req *http.Request, secret string, deliveryId uint64, ts time.Time, body []byte) {
+	unixTs := ts.Unix()
+	req.Header.Set(WebhookIdHeader, strconv.FormatUint(deliveryId, 10))
+	req.Header.Set(WebhookSignatureHeader, fmt.Sprintf("t=%d,v1=%s", unixTs, computeWebhookSignature(secret, unixTs, body)))
+}
+
+// VerifyWebhookSignature checks the value of a signature header against the given body and secret.
+// Signatures with a timestamp older than the given tolerance are rejected to prevent replays.
+func VerifyWebhookSignature(secret, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var ts int64
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "t":
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid signature timestamp: %w", err)
+			}
+			ts = parsed
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if ts == 0 || len(signatures) == 0 {
+		return fmt.Errorf("malformed signature header")
+	}
+	if age := now.Sub(time.Unix(ts, 0)); age > tolerance || age < -tolerance {
+		return fmt.Errorf("signature timestamp is outside of the tolerance window")
+	}
+
+	expected := []byte(computeWebhookSignature(secret, ts, body))
+	for _, s := range signatures {
+		if hmac.Equal(expected, []byte(s)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("signature mismatch")
+}
+
+// GenerateWebhookSigningSecret returns a new random secret used to sign webhook deliveries
+func GenerateWebhookSigningSecret() (string, error) {
+	b, err := utils.GenerateRandomBytesSecure(32)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// webhookRetryDelay returns the exponential backoff delay for the given number of failed attempts
+func webhookRetryDelay(retries uint64) time.Duration {
+	delay := webhookRetryBaseDelay
+	for i := uint64(0); i < retries; i++ {
+		delay *= 2
+		if delay >= webhookRetryMaxDelay {
+			return webhookRetryMaxDelay
+		}
+	}
+	return delay
+}
+
+func getWebhookTimeout() time.Duration {
+	if utils.Config.Notifications.WebhookTimeout > 0 {
+		return utils.Config.Notifications.WebhookTimeout
+	}
+	return webhookDefaultTimeout
+}
+
+// dashboardWebhookGroupsTable returns the table that stores the configuration of the given dashboard webhook
+func dashboardWebhookGroupsTable(w types.UserWebhook) string {
+	if w.IsAccountDashboard {
+		return "users_acc_dashboards_groups"
+	}
+	return "users_val_dashboards_groups"
+}
+
+type webhookSecrets struct {
+	user             map[uint64]string
+	dashboard        map[types.DashboardId]map[types.DashboardGroupId]string
+	accountDashboard map[types.DashboardId]map[types.DashboardGroupId]string
+}
+
+func (s *webhookSecrets) get(w types.UserWebhook) string {
+	if w.DashboardId == 0 && w.DashboardGroupId == 0 {
+		return s.user[w.ID]
+	}
+	if w.IsAccountDashboard {
+		return s.accountDashboard[types.DashboardId(w.DashboardId)][types.DashboardGroupId(w.DashboardGroupId)]
+	}
+	return s.dashboard[types.DashboardId(w.DashboardId)][types.DashboardGroupId(w.DashboardGroupId)]
+}
+
+// getWebhookSigningSecrets fetches the current signing secrets of the given webhooks.
+// Secrets are looked up at send time so they are never persisted as part of the queue content and a rotation takes effect immediately.
+func getWebhookSigningSecrets(webhooks []types.UserWebhook) (*webhookSecrets, error) {
+	secrets := &webhookSecrets{
+		user:             make(map[uint64]string),
+		dashboard:        make(map[types.DashboardId]map[types.DashboardGroupId]string),
+		accountDashboard: make(map[types.DashboardId]map[types.DashboardGroupId]string),
+	}
+
+	userWebhookIds := make([]uint64, 0)
+	dashboardIds := make([]uint64, 0)
+	accountDashboardIds := make([]uint64, 0)
+	for _, w := range webhooks {
+		if w.DashboardId == 0 && w.DashboardGroupId == 0 {
+			userWebhookIds = append(userWebhookIds, w.ID)
+		} else if w.IsAccountDashboard {
+			accountDashboardIds = append(accountDashboardIds, w.DashboardId)
+		} else {
+			dashboardIds = append(dashboardIds, w.DashboardId)
+		}
+	}
+
+	if len(userWebhookIds) > 0 {
+		var rows []struct {
+			ID            uint64 `db:"id"`
+			SigningSecret string `db:"signing_secret"`
+		}
+		err := db.FrontendWriterDB.Select(&rows, `SELECT id, signing_secret FROM users_webhooks WHERE id = ANY($1)`, pq.Array(userWebhookIds))
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving signing secrets of user webhooks: %w", err)
+		}
+		for _, row := range rows {
+			secrets.user[row.ID] = row.SigningSecret
+		}
+	}
+
+	if len(dashboardIds) > 0 {
+		err := getDashboardWebhookSigningSecrets(secrets.dashboard, "users_val_dashboards_groups", dashboardIds)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving signing secrets of dashboard webhooks: %w", err)
+		}
+	}
+
+	if len(accountDashboardIds) > 0 {
+		err := getDashboardWebhookSigningSecrets(secrets.accountDashboard, "users_acc_dashboards_groups", accountDashboardIds)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving signing secrets of account dashboard webhooks: %w", err)
+		}
+	}
+
+	return secrets, nil
+}
+
+func getDashboardWebhookSigningSecrets(secrets map[types.DashboardId]map[types.DashboardGroupId]string, table string, dashboardIds []uint64) error {
+	var rows []struct {
+		DashboardId   types.DashboardId      `db:"dashboard_id"`
+		GroupId       types.DashboardGroupId `db:"id"`
+		SigningSecret string                 `db:"webhook_signing_secret"`
+	}
+	err := db.WriterDb.Select(&rows, fmt.Sprintf(`SELECT dashboard_id, id, webhook_signing_secret FROM %s WHERE dashboard_id = ANY($1)`, table), pq.Array(dashboardIds))
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if _, ok := secrets[row.DashboardId]; !ok {
+			secrets[row.DashboardId] = make(map[types.DashboardGroupId]string)
+		}
+		secrets[row.DashboardId][row.GroupId] = row.SigningSecret
+	}
+	return nil
+}
+
+// handleFailedWebhookDelivery reschedules a failed delivery with exponential backoff.
+// Once the maximum number of attempts has been reached the entry is moved from the queue to the dead-letter table.
+func handleFailedWebhookDelivery(queueId uint64, retries uint64, userId types.UserId, status, lastError string) error {
+	if retries+1 >= webhookMaxDeliveryAttempts {
+		return moveToDeadLetters(queueId, userId, true, status, lastError)
+	}
+
+	_, err := db.WriterDb.Exec(`UPDATE notification_queue SET retries = retries + 1, next_attempt_ts = NOW() + make_interval(secs => $2) WHERE id = $1`, queueId, webhookRetryDelay(retries).Seconds())
+	if err != nil {
+		return fmt.Errorf("error scheduling retry for notification with id %v: %w", queueId, err)
+	}
+	return nil
+}
+
+// moveToDeadLetters atomically removes an entry from the notification queue and stores it in the dead-letter table.
+// attempted is false if the entry is dropped without a delivery attempt, e.g. because the webhook is disabled.
+func moveToDeadLetters(queueId uint64, userId types.UserId, attempted bool, status, lastError string) error {
+	_, err := db.WriterDb.Exec(`
+		WITH moved AS (
+			DELETE FROM notification_queue WHERE id = $1 RETURNING id, created, channel, content, retries
+		)
+		INSERT INTO notification_dead_letters (user_id, queue_id, created, channel, content, retries, last_status, last_error)
+		SELECT $2, id, created, channel, content, retries + CASE WHEN $5 THEN 1 ELSE 0 END, NULLIF($3, ''), NULLIF($4, '') FROM moved`,
+		queueId, userId, status, lastError, attempted)
+	if err != nil {
+		return fmt.Errorf("error moving notification with id %v to dead letters: %w", queueId, err)
+	}
+	return nil
+}
+
+// RedeliverDeadLetter puts a dead-lettered webhook event back into the notification queue.
+// Returns the id of the new queue entry.
+func RedeliverDeadLetter(ctx context.Context, dbConn *sqlx.DB, userId types.UserId, deadLetterId uint64) (uint64, error) {
+	tx, err := dbConn.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error starting db transaction to redeliver dead letter: %w", err)
+	}
+	defer utils.Rollback(tx)
+
+	var queueId uint64
+	err = tx.GetContext(ctx, &queueId, `
+		INSERT INTO notification_queue (created, channel, content)
+		SELECT NOW(), channel, content FROM notification_dead_letters
+		WHERE id = $1 AND user_id = $2 AND redelivered_ts IS NULL
+		RETURNING id`, deadLetterId, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE notification_dead_letters SET redelivered_ts = NOW() WHERE id = $1`, deadLetterId)
+	if err != nil {
+		return 0, fmt.Errorf("error marking dead letter %v as redelivered: %w", deadLetterId, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, fmt.Errorf("error committing tx to redeliver dead letter: %w", err)
+	}
+	return queueId, nil
+}
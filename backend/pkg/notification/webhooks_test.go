@@ -0,0 +1,77 @@
+package notification
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	secret := "secret"
+	body := []byte(`{"event":"validator_is_offline"}`)
+	now := time.Unix(1_700_000_000, 0)
+	header := func(secret string, ts time.Time, body []byte) string {
+		return fmt.Sprintf("t=%d,v1=%s", ts.Unix(), computeWebhookSignature(secret, ts.Unix(), body))
+	}
+
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		body    []byte
+		wantErr bool
+	}{
+		{name: "valid", secret: secret, header: header(secret, now, body), body: body},
+		{name: "at the end of the tolerance window", secret: secret, header: header(secret, now.Add(-WebhookSignatureTolerance), body), body: body},
+		{name: "at the start of the tolerance window", secret: secret, header: header(secret, now.Add(WebhookSignatureTolerance), body), body: body},
+		{name: "too old", secret: secret, header: header(secret, now.Add(-WebhookSignatureTolerance-time.Second), body), body: body, wantErr: true},
+		{name: "too far in the future", secret: secret, header: header(secret, now.Add(WebhookSignatureTolerance+time.Second), body), body: body, wantErr: true},
+		{name: "tampered body", secret: secret, header: header(secret, now, body), body: []byte(`{"event":"validator_got_slashed"}`), wantErr: true},
+		{name: "wrong secret", secret: "other", header: header(secret, now, body), body: body, wantErr: true},
+		{name: "one of multiple signatures matches", secret: secret, header: header("old", now, body) + ",v1=" + computeWebhookSignature(secret, now.Unix(), body), body: body},
+		{name: "missing signature", secret: secret, header: fmt.Sprintf("t=%d", now.Unix()), body: body, wantErr: true},
+		{name: "missing timestamp", secret: secret, header: "v1=" + computeWebhookSignature(secret, now.Unix(), body), body: body, wantErr: true},
+		{name: "invalid timestamp", secret: secret, header: "t=abc,v1=" + computeWebhookSignature(secret, now.Unix(), body), body: body, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyWebhookSignature(tt.secret, tt.header, tt.body, now, WebhookSignatureTolerance)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyWebhookSignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestComputeWebhookSignature(t *testing.T) {
+	body := []byte("body")
+	signature := computeWebhookSignature("secret", 1, body)
+	if signature != computeWebhookSignature("secret", 1, body) {
+		t.Errorf("expected the signature to be deterministic")
+	}
+	if signature == computeWebhookSignature("secret", 2, body) {
+		t.Errorf("expected the timestamp to be part of the signature")
+	}
+	if signature == computeWebhookSignature("other", 1, body) {
+		t.Errorf("expected the secret to be part of the signature")
+	}
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	tests := []struct {
+		retries uint64
+		want    time.Duration
+	}{
+		{retries: 0, want: 30 * time.Second},
+		{retries: 1, want: time.Minute},
+		{retries: 2, want: 2 * time.Minute},
+		{retries: 4, want: 8 * time.Minute},
+		{retries: 5, want: 15 * time.Minute},
+		{retries: 100, want: 15 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := webhookRetryDelay(tt.retries); got != tt.want {
+			t.Errorf("webhookRetryDelay(%d) = %v, want %v", tt.retries, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
+	"github.com/gobitfly/beaconchain/pkg/commons/db"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+	"golang.org/x/sync/errgroup"
+)
+
+// AllGroups selects every group of a dashboard
+const AllGroups int64 = -1
+
+// Period is the fiscal period a report covers, Start is inclusive and End is exclusive
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// NewFiscalPeriod returns the period of the given amount of months starting at the beginning of startMonth in year (UTC)
+func NewFiscalPeriod(year int, startMonth time.Month, months int) Period {
+	start := time.Date(year, startMonth, 1, 0, 0, 0, 0, time.UTC)
+	return Period{Start: start, End: start.AddDate(0, months, 0)}
+}
+
+func (p Period) String() string {
+	return fmt.Sprintf("%s - %s", p.Start.Format(time.DateOnly), p.End.Add(-utils.Day).Format(time.DateOnly))
+}
+
+// Source selects the validators a report is generated for, either a validator dashboard (optionally restricted to a single group) or a plain list of validators
+type Source struct {
+	Name        string
+	DashboardId uint64
+	GroupId     int64
+	Validators  []uint64
+}
+
+// DailyIncome is the income of a single (UTC) day, amounts are denominated in the native currencies of the network
+type DailyIncome struct {
+	Day      time.Time
+	ClIncome decimal.Decimal
+	ElIncome decimal.Decimal
+	// Price is the fiat value of one unit of the native currency on that day, zero if unknown
+	Price decimal.Decimal
+}
+
+func (d DailyIncome) Total() decimal.Decimal {
+	return d.ClIncome.Add(d.ElIncome)
+}
+
+// Value returns the fiat value of amount at the price of the day
+func (d DailyIncome) Value(amount decimal.Decimal) decimal.Decimal {
+	return amount.Mul(d.Price)
+}
+
+type IncomeReport struct {
+	Name       string
+	Period     Period
+	Currency   enums.Currency
+	ClCurrency string
+	ElCurrency string
+	Days       []DailyIncome
+}
+
+// Totals returns the summed CL and EL income as well as their summed fiat value at the time of receipt
+func (r *IncomeReport) Totals() (cl, el, value decimal.Decimal) {
+	for _, day := range r.Days {
+		cl = cl.Add(day.ClIncome)
+		el = el.Add(day.ElIncome)
+		value = value.Add(day.Value(day.Total()))
+	}
+	return cl, el, value
+}
+
+// Service computes income reports, CL rewards are read from clickhouse while EL rewards and prices are read from the postgres reader db
+type Service struct {
+	clickhouseReader *sqlx.DB
+	readerDb         *sqlx.DB
+}
+
+func NewService(clickhouseReader, readerDb *sqlx.DB) *Service {
+	return &Service{
+		clickhouseReader: clickhouseReader,
+		readerDb:         readerDb,
+	}
+}
+
+// GetIncomeReport computes the per-day CL and EL income of the source within the period, days that lie in the future are omitted
+func (s *Service) GetIncomeReport(ctx context.Context, source Source, currency enums.Currency, period Period) (*IncomeReport, error) {
+	if currency.Code() == "" {
+		return nil, fmt.Errorf("income reports require a fiat currency")
+	}
+	report := &IncomeReport{
+		Name:       source.Name,
+		Period:     period,
+		Currency:   currency,
+		ClCurrency: utils.Config.Frontend.ClCurrency,
+		ElCurrency: utils.Config.Frontend.ElCurrency,
+	}
+	if report.ClCurrency == "" {
+		report.ClCurrency = "ETH"
+	}
+	if report.ElCurrency == "" {
+		report.ElCurrency = "ETH"
+	}
+
+	end := period.End
+	if today := time.Now().UTC().Truncate(utils.Day).Add(utils.Day); end.After(today) {
+		end = today
+	}
+	if !period.Start.Before(end) || (source.DashboardId == 0 && len(source.Validators) == 0) {
+		return report, nil
+	}
+
+	wg := errgroup.Group{}
+	var clIncome, elIncome map[int64]decimal.Decimal
+	var prices map[int64]float64
+	wg.Go(func() error {
+		var err error
+		clIncome, err = s.getDailyClIncome(ctx, source, period.Start, end)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		elIncome, err = s.getDailyElIncome(ctx, source, period.Start, end)
+		return err
+	})
+	if currency != enums.Currencies.Native {
+		wg.Go(func() error {
+			var err error
+			// the column name is taken from the currency enum and not from user input
+			prices, err = db.GetDailyPrices(ctx, s.readerDb, currency.Code(), uint64(period.Start.Unix()), uint64(end.Unix()))
+			return err
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	for day := period.Start; day.Before(end); day = day.Add(utils.Day) {
+		ts := day.Unix()
+		report.Days = append(report.Days, DailyIncome{
+			Day:      day,
+			ClIncome: clIncome[ts],
+			ElIncome: elIncome[ts],
+			Price:    decimal.NewFromFloat(prices[ts]),
+		})
+	}
+	return report, nil
+}
+
+func (s *Service) getDailyClIncome(ctx context.Context, source Source, start, end time.Time) (map[int64]decimal.Decimal, error) {
+	ds := goqu.Dialect("postgres").
+		From(goqu.L("validator_dashboard_data_daily d")).
+		Select(
+			goqu.L("d.t AS day"),
+			goqu.L("SUM(COALESCE(d.attestations_reward, 0) + COALESCE(d.blocks_cl_reward, 0) + COALESCE(d.sync_reward, 0)) AS cl_income")).
+		Where(goqu.L("d.t >= fromUnixTimestamp(?) AND d.t < fromUnixTimestamp(?)", start.Unix(), end.Unix())).
+		GroupBy(goqu.L("day"))
+
+	if source.Validators == nil {
+		validators := goqu.Dialect("postgres").
+			From("users_val_dashboards_validators").
+			Select(goqu.L("validator_index")).
+			Where(goqu.L("dashboard_id = ?", source.DashboardId))
+		if source.GroupId != AllGroups {
+			validators = validators.Where(goqu.L("group_id = ?", source.GroupId))
+		}
+		ds = ds.
+			With("validators", validators).
+			Where(goqu.L("d.validator_index IN (SELECT validator_index FROM validators)"))
+	} else {
+		ds = ds.Where(goqu.L("d.validator_index IN ?", source.Validators))
+	}
+
+	query, args, err := ds.Prepared(true).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("error preparing query: %w", err)
+	}
+
+	queryResult := []struct {
+		Day      time.Time `db:"day"`
+		ClIncome int64     `db:"cl_income"`
+	}{}
+	err = s.clickhouseReader.SelectContext(ctx, &queryResult, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving daily cl income: %w", err)
+	}
+
+	result := make(map[int64]decimal.Decimal, len(queryResult))
+	for _, entry := range queryResult {
+		// cl rewards are stored in gwei
+		result[entry.Day.UTC().Truncate(utils.Day).Unix()] = decimal.NewFromInt(entry.ClIncome).Shift(-9)
+	}
+	return result, nil
+}
+
+func (s *Service) getDailyElIncome(ctx context.Context, source Source, start, end time.Time) (map[int64]decimal.Decimal, error) {
+	// blocks are attributed to the day their epoch started in
+	ds := goqu.Dialect("postgres").
+		Select(
+			goqu.L("b.epoch"),
+			goqu.L("SUM(COALESCE(rb.value, ep.fee_recipient_reward * 1e18, 0)) AS el_income")).
+		From(goqu.L("blocks b")).
+		LeftJoin(goqu.L("execution_payloads ep"), goqu.On(goqu.L("ep.block_hash = b.exec_block_hash"))).
+		LeftJoin(
+			goqu.Lateral(goqu.Dialect("postgres").
+				From("relays_blocks").
+				Select(
+					goqu.L("exec_block_hash"),
+					goqu.MAX("value").As("value")).
+				Where(goqu.L("relays_blocks.exec_block_hash = b.exec_block_hash")).
+				GroupBy("exec_block_hash")).As("rb"),
+			goqu.On(goqu.L("rb.exec_block_hash = b.exec_block_hash")),
+		).
+		Where(goqu.L("b.status = '1' AND b.epoch >= ? AND b.epoch < ?", utils.TimeToEpoch(start), utils.TimeToEpoch(end))).
+		GroupBy(goqu.L("b.epoch"))
+
+	if source.Validators == nil {
+		ds = ds.
+			InnerJoin(goqu.L("users_val_dashboards_validators v"), goqu.On(goqu.L("v.validator_index = b.proposer"))).
+			Where(goqu.L("v.dashboard_id = ?", source.DashboardId))
+		if source.GroupId != AllGroups {
+			ds = ds.Where(goqu.L("v.group_id = ?", source.GroupId))
+		}
+	} else {
+		ds = ds.Where(goqu.L("b.proposer = ANY(?)", pq.Array(source.Validators)))
+	}
+
+	query, args, err := ds.Prepared(true).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("error preparing query: %w", err)
+	}
+
+	queryResult := []struct {
+		Epoch    uint64          `db:"epoch"`
+		ElIncome decimal.Decimal `db:"el_income"`
+	}{}
+	err = s.readerDb.SelectContext(ctx, &queryResult, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving daily el income: %w", err)
+	}
+
+	result := make(map[int64]decimal.Decimal)
+	for _, entry := range queryResult {
+		day := utils.EpochToTime(entry.Epoch).UTC().Truncate(utils.Day).Unix()
+		// el rewards are stored in wei
+		result[day] = result[day].Add(entry.ElIncome.Shift(-18))
+	}
+	return result, nil
+}
@@ -0,0 +1,238 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
+	"github.com/gobitfly/beaconchain/pkg/commons/types"
+	"github.com/gobitfly/beaconchain/pkg/commons/utils"
+	"github.com/jung-kurt/gofpdf"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	amountDecimals = 9
+	fiatDecimals   = 2
+)
+
+// Document is a rendered income report
+type Document struct {
+	Data        []byte
+	ContentType string
+	FileName    string
+}
+
+// Render renders the report in the given format
+func Render(report *IncomeReport, format enums.IncomeReportFormat) (*Document, error) {
+	var data []byte
+	var err error
+	contentType := "text/csv"
+	extension := "csv"
+	switch format {
+	case enums.IncomeReportFormats.Pdf:
+		data, err = renderPdf(report)
+		contentType = "application/pdf"
+		extension = "pdf"
+	case enums.IncomeReportFormats.Csv:
+		data, err = renderCsv(report)
+	case enums.IncomeReportFormats.Koinly:
+		data, err = renderKoinly(report)
+	case enums.IncomeReportFormats.CoinTracking:
+		data, err = renderCoinTracking(report)
+	default:
+		return nil, fmt.Errorf("unknown income report format %d", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error rendering %s income report: %w", format.String(), err)
+	}
+
+	fileName := fmt.Sprintf("income_report_%s_%s", report.Period.Start.Format(time.DateOnly), report.Period.End.Add(-utils.Day).Format(time.DateOnly))
+	if format == enums.IncomeReportFormats.Koinly || format == enums.IncomeReportFormats.CoinTracking {
+		fileName += "_" + format.String()
+	}
+	return &Document{
+		Data:        data,
+		ContentType: contentType,
+		FileName:    fileName + "." + extension,
+	}, nil
+}
+
+func writeCsv(records [][]string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func renderCsv(report *IncomeReport) ([]byte, error) {
+	fiat := strings.ToUpper(report.Currency.Code())
+	records := [][]string{{
+		"Date",
+		fmt.Sprintf("Consensus Layer Income (%s)", report.ClCurrency),
+		fmt.Sprintf("Execution Layer Income (%s)", report.ElCurrency),
+		fmt.Sprintf("Price (%s)", fiat),
+		fmt.Sprintf("Consensus Layer Income (%s)", fiat),
+		fmt.Sprintf("Execution Layer Income (%s)", fiat),
+		fmt.Sprintf("Total Income (%s)", fiat),
+	}}
+	for _, day := range report.Days {
+		records = append(records, []string{
+			day.Day.Format(time.DateOnly),
+			day.ClIncome.StringFixed(amountDecimals),
+			day.ElIncome.StringFixed(amountDecimals),
+			day.Price.StringFixed(fiatDecimals),
+			day.Value(day.ClIncome).StringFixed(fiatDecimals),
+			day.Value(day.ElIncome).StringFixed(fiatDecimals),
+			day.Value(day.Total()).StringFixed(fiatDecimals),
+		})
+	}
+	return writeCsv(records)
+}
+
+// incomeTransactions splits the days of the report into one transaction per layer and day, skipping days without income
+func incomeTransactions(report *IncomeReport, fn func(day DailyIncome, amount decimal.Decimal, currency, description string)) {
+	for _, day := range report.Days {
+		if !day.ClIncome.IsZero() {
+			fn(day, day.ClIncome, report.ClCurrency, "Consensus layer rewards")
+		}
+		if !day.ElIncome.IsZero() {
+			fn(day, day.ElIncome, report.ElCurrency, "Execution layer rewards")
+		}
+	}
+}
+
+// renderKoinly renders the report in the Koinly universal import format
+func renderKoinly(report *IncomeReport) ([]byte, error) {
+	fiat := strings.ToUpper(report.Currency.Code())
+	records := [][]string{{"Date", "Sent Amount", "Sent Currency", "Received Amount", "Received Currency", "Fee Amount", "Fee Currency", "Net Worth Amount", "Net Worth Currency", "Label", "Description", "TxHash"}}
+	incomeTransactions(report, func(day DailyIncome, amount decimal.Decimal, currency, description string) {
+		// the income of a day is booked at its end
+		date := day.Day.Add(utils.Day - time.Second).Format("2006-01-02 15:04:05 UTC")
+		description = fmt.Sprintf("%s (%s)", description, report.Name)
+		netWorth, netWorthCurrency := "", ""
+		if !day.Price.IsZero() {
+			netWorth, netWorthCurrency = day.Value(amount).Abs().StringFixed(fiatDecimals), fiat
+		}
+		if amount.IsNegative() {
+			// penalties exceeding the rewards of the day
+			records = append(records, []string{date, amount.Abs().StringFixed(amountDecimals), currency, "", "", "", "", netWorth, netWorthCurrency, "cost", description, ""})
+			return
+		}
+		records = append(records, []string{date, "", "", amount.StringFixed(amountDecimals), currency, "", "", netWorth, netWorthCurrency, "staking", description, ""})
+	})
+	return writeCsv(records)
+}
+
+// renderCoinTracking renders the report in the CoinTracking CSV import format
+func renderCoinTracking(report *IncomeReport) ([]byte, error) {
+	records := [][]string{{"Type", "Buy Amount", "Buy Currency", "Sell Amount", "Sell Currency", "Fee", "Fee Currency", "Exchange", "Trade-Group", "Comment", "Date"}}
+	incomeTransactions(report, func(day DailyIncome, amount decimal.Decimal, currency, description string) {
+		date := day.Day.Add(utils.Day - time.Second).Format(time.DateTime)
+		if amount.IsNegative() {
+			records = append(records, []string{"Other Fee", "", "", amount.Abs().StringFixed(amountDecimals), currency, "", "", "beaconcha.in", report.Name, description, date})
+			return
+		}
+		records = append(records, []string{"Staking", amount.StringFixed(amountDecimals), currency, "", "", "", "", "beaconcha.in", report.Name, description, date})
+	})
+	return writeCsv(records)
+}
+
+func renderPdf(report *IncomeReport) ([]byte, error) {
+	fiat := strings.ToUpper(report.Currency.Code())
+	totalCl, totalEl, totalValue := report.Totals()
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTopMargin(15)
+	pdf.SetHeaderFuncMode(func() {
+		pdf.SetY(5)
+		pdf.SetFont("Arial", "B", 12)
+		pdf.CellFormat(0, 10, fmt.Sprintf("Beaconcha.in Income Report %s (%s)", report.Name, report.Period), "", 0, "C", false, 0, "")
+		pdf.Ln(12)
+	}, true)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont("Arial", "I", 8)
+		pdf.CellFormat(0, 10, fmt.Sprintf("Page %d/{nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	const rowHeight = 6.0
+	pdf.SetFont("Times", "", 10)
+	pdf.SetTextColor(24, 24, 24)
+	pdf.CellFormat(0, rowHeight, fmt.Sprintf("Consensus Layer Income: %s %s | Execution Layer Income: %s %s | Total: %s %s",
+		totalCl.StringFixed(6), report.ClCurrency, totalEl.StringFixed(6), report.ElCurrency, totalValue.StringFixed(fiatDecimals), fiat), "", 1, "C", false, 0, "")
+	pdf.Ln(4)
+
+	header := []string{"Date", "CL Income (" + report.ClCurrency + ")", "EL Income (" + report.ElCurrency + ")", "Price (" + fiat + ")", "Income (" + fiat + ")"}
+	colWidth := 190.0 / float64(len(header))
+	pdf.SetFont("Times", "", 9)
+	pdf.SetTextColor(224, 224, 224)
+	pdf.SetFillColor(64, 64, 64)
+	for _, col := range header {
+		pdf.CellFormat(colWidth, rowHeight, col, "1", 0, "CM", true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetTextColor(24, 24, 24)
+	for i, day := range report.Days {
+		if i%2 == 0 {
+			pdf.SetFillColor(255, 255, 255)
+		} else {
+			pdf.SetFillColor(235, 235, 235)
+		}
+		row := []string{
+			day.Day.Format(time.DateOnly),
+			day.ClIncome.StringFixed(6),
+			day.ElIncome.StringFixed(6),
+			day.Price.StringFixed(fiatDecimals),
+			day.Value(day.Total()).StringFixed(fiatDecimals),
+		}
+		for _, col := range row {
+			pdf.CellFormat(colWidth, rowHeight, col, "1", 0, "CM", true, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := pdf.Output(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderAttachment generates and renders the income report of a tax report notification.
+// Unknown currencies fall back to USD and unknown formats to PDF.
+func (s *Service) RenderAttachment(ctx context.Context, req types.IncomeReportRequest) (*types.EmailAttachment, error) {
+	currency := enums.Currency(0).NewFromString(req.Currency)
+	if currency.Code() == "" {
+		currency = enums.Currencies.USD
+	}
+	format := enums.IncomeReportFormat(0).NewFromString(req.Format)
+	if format == enums.IncomeReportFormat(-1) {
+		format = enums.IncomeReportFormats.Pdf
+	}
+	source := Source{
+		Name:        req.Name,
+		DashboardId: req.DashboardId,
+		GroupId:     req.GroupId,
+		Validators:  req.Validators,
+	}
+
+	report, err := s.GetIncomeReport(ctx, source, currency, Period{Start: req.Start, End: req.End})
+	if err != nil {
+		return nil, fmt.Errorf("error generating income report: %w", err)
+	}
+	doc, err := Render(report, format)
+	if err != nil {
+		return nil, fmt.Errorf("error rendering income report: %w", err)
+	}
+	return &types.EmailAttachment{Attachment: doc.Data, Name: doc.FileName}, nil
+}
@@ -0,0 +1,68 @@
+package reports
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gobitfly/beaconchain/pkg/api/enums"
+	"github.com/shopspring/decimal"
+)
+
+func testIncomeReport() *IncomeReport {
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &IncomeReport{
+		Name:       "My Dashboard",
+		Period:     NewFiscalPeriod(2024, time.January, 1),
+		Currency:   enums.Currencies.USD,
+		ClCurrency: "ETH",
+		ElCurrency: "ETH",
+		Days: []DailyIncome{
+			{Day: day, ClIncome: decimal.RequireFromString("0.0025"), ElIncome: decimal.RequireFromString("0.05"), Price: decimal.NewFromInt(2000)},
+			{Day: day.Add(24 * time.Hour), ClIncome: decimal.RequireFromString("-0.0001"), Price: decimal.NewFromInt(2100)},
+			{Day: day.Add(48 * time.Hour)},
+		},
+	}
+}
+
+func TestRenderIncomeReport(t *testing.T) {
+	report := testIncomeReport()
+
+	_, _, value := report.Totals()
+	if !value.Equal(decimal.RequireFromString("104.79")) {
+		t.Errorf("expected a total value of 104.79, got %s", value)
+	}
+
+	doc, err := Render(report, enums.IncomeReportFormats.Koinly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.FileName != "income_report_2024-01-01_2024-01-31_koinly.csv" {
+		t.Errorf("unexpected file name %s", doc.FileName)
+	}
+	lines := strings.Split(strings.TrimSpace(string(doc.Data)), "\n")
+	want := []string{
+		"Date,Sent Amount,Sent Currency,Received Amount,Received Currency,Fee Amount,Fee Currency,Net Worth Amount,Net Worth Currency,Label,Description,TxHash",
+		"2024-01-01 23:59:59 UTC,,,0.002500000,ETH,,,5.00,USD,staking,Consensus layer rewards (My Dashboard),",
+		"2024-01-01 23:59:59 UTC,,,0.050000000,ETH,,,100.00,USD,staking,Execution layer rewards (My Dashboard),",
+		"2024-01-02 23:59:59 UTC,0.000100000,ETH,,,,,0.21,USD,cost,Consensus layer rewards (My Dashboard),",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got %d:\n%s", len(want), len(lines), doc.Data)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d: expected %q, got %q", i, want[i], lines[i])
+		}
+	}
+
+	for _, format := range []enums.IncomeReportFormat{enums.IncomeReportFormats.Pdf, enums.IncomeReportFormats.Csv, enums.IncomeReportFormats.CoinTracking} {
+		doc, err := Render(report, format)
+		if err != nil {
+			t.Fatalf("%s: %v", format.String(), err)
+		}
+		if len(doc.Data) == 0 {
+			t.Errorf("%s: expected a non-empty document", format.String())
+		}
+	}
+}
@@ -14,19 +14,26 @@ export const useNotificationsManagementStore = defineStore('notifications-manage
     {
       clients: [],
       general_settings: {
+        delivery_mode: 'immediate',
         do_not_disturb_timestamp: 0,
+        group_delivery_modes: [],
         is_email_notifications_enabled: false,
         is_machine_cpu_usage_subscribed: false,
         is_machine_memory_usage_subscribed: false,
         is_machine_offline_subscribed: false,
         is_machine_storage_usage_subscribed: false,
         is_push_notifications_enabled: false,
+        is_quiet_hours_enabled: false,
         is_webhook_notifications_enabled: false,
         machine_cpu_usage_threshold: 0.0,
         machine_memory_usage_threshold: 0.0,
         machine_storage_usage_threshold: 0.0,
+        quiet_hours_end: 0,
+        quiet_hours_start: 0,
+        timezone: 'UTC',
       },
       has_machines: true,
+      is_telegram_linked: false,
       networks: [],
       paired_devices: [],
     },
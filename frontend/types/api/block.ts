@@ -61,6 +61,23 @@ export interface BlobInfo {
   excess_gas: number /* uint64 */;
   burned_fees?: string /* decimal.Decimal */;
 }
+export interface BlockGasLimit {
+  value: number /* uint64 */;
+  percent: number /* float64 */; // share of the gas limit that was used
+}
+export interface BlockMevTag {
+  name: string;
+  color: string;
+}
+export interface BlockStatus {
+  proposal: 'proposed' | 'orphaned' | 'missed' | 'scheduled';
+  finalized: 'finalized' | 'justified' | 'not_finalized';
+}
+export interface BlockTransactionCounts {
+  general: number /* uint64 */;
+  internal: number /* uint64 */;
+  blob?: number /* uint64 */;
+}
 export interface BlockOverview {
   /**
    * General
@@ -74,10 +91,7 @@ export interface BlockOverview {
   rewards?: string /* decimal.Decimal */;
   tx_fees?: string /* decimal.Decimal */;
   gas_usage?: string /* decimal.Decimal */;
-  gas_limit?: {
-    value: number /* uint64 */;
-    percent: number /* float64 */;
-  };
+  gas_limit?: BlockGasLimit;
   lowest_gas_price?: string /* decimal.Decimal */;
   difficulty?: string /* decimal.Decimal */;
   /**
@@ -91,25 +105,15 @@ export interface BlockOverview {
   /**
    * New blocks only
    */
-  mev_tags?: {
-    name: string;
-    color: string;
-  }[];
+  mev_tags?: BlockMevTag[];
   epoch?: number /* uint64 */;
   slot?: number /* uint64 */;
   proposer?: number /* uint64 */;
   proposer_reward?: ClElValue<string /* decimal.Decimal */>;
   proposer_reward_recipient?: Address;
-  status?: {
-    proposal: 'proposed' | 'orphaned' | 'missed' | 'scheduled';
-    finalized: 'finalized' | 'justified' | 'not_finalized';
-  };
+  status?: BlockStatus;
   priority_fees?: string /* decimal.Decimal */;
-  transactions?: {
-    general: number /* uint64 */;
-    internal: number /* uint64 */;
-    blob?: number /* uint64 */;
-  };
+  transactions?: BlockTransactionCounts;
   block_root?: Hash;
   parent_root?: Hash;
   execution_payload?: BlockExecutionPayload;
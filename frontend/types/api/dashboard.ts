@@ -18,6 +18,7 @@ export interface ValidatorDashboard {
   archived_reason?: 'user' | 'dashboard_limit' | 'validator_limit' | 'group_limit';
   validator_count: number /* uint64 */;
   group_count: number /* uint64 */;
+  organization_id?: number /* uint64 */; // set if the dashboard is owned by an organization
 }
 export interface UserDashboardsData {
   validator_dashboards: ValidatorDashboard[];
@@ -9,5 +9,14 @@ export interface MachineMetricsData {
   system_metrics: (any /* types.MachineMetricSystem */ | undefined)[];
   validator_metrics: (any /* types.MachineMetricValidator */ | undefined)[];
   node_metrics: (any /* types.MachineMetricNode */ | undefined)[];
+  execution_node_metrics: (any /* types.MachineMetricNode */ | undefined)[];
 }
 export type GetUserMachineMetricsRespone = ApiDataResponse<MachineMetricsData>;
+/**
+ * MachineMetricSeries is a raw series a machine pushed in one of the prometheus formats
+ */
+export interface MachineMetricSeries {
+  series: string; // metric name followed by the sorted labels
+  points: any /* types.MachineMetricSeriesPoint */[];
+}
+export type GetUserMachineMetricSeriesResponse = ApiDataResponse<MachineMetricSeries[]>;
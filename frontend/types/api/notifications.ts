@@ -44,7 +44,7 @@ export interface NotificationDashboardsTableRow {
   group_id: number /* uint64 */;
   group_name: string;
   entity_count: number /* uint64 */;
-  event_types: ('validator_online' | 'validator_offline' | 'group_efficiency_below' | 'attestation_missed' | 'proposal_success' | 'proposal_missed' | 'proposal_upcoming' | 'max_collateral' | 'min_collateral' | 'sync' | 'withdrawal' | 'validator_got_slashed' | 'validator_has_slashed' | 'incoming_tx' | 'outgoing_tx' | 'transfer_erc20' | 'transfer_erc721' | 'transfer_erc1155')[];
+  event_types: ('validator_online' | 'validator_offline' | 'group_efficiency_below' | 'attestation_missed' | 'proposal_success' | 'proposal_missed' | 'proposal_upcoming' | 'max_collateral' | 'min_collateral' | 'sync' | 'withdrawal' | 'validator_got_slashed' | 'validator_has_slashed' | 'pool_operation_not_included' | 'incoming_tx' | 'outgoing_tx' | 'transfer_erc20' | 'transfer_erc721' | 'transfer_erc1155')[];
 }
 export type InternalGetUserNotificationDashboardsResponse = ApiPagingResponse<NotificationDashboardsTableRow>;
 export interface NotificationEventValidatorBackOnline {
@@ -56,6 +56,11 @@ export interface NotificationEventWithdrawal {
   amount: string /* decimal.Decimal */;
   address: Address;
 }
+export interface NotificationEventPoolOperation {
+  index: number /* uint64 */;
+  type: 'voluntary_exit' | 'bls_to_execution_change';
+  first_seen_slot: number /* uint64 */;
+}
 export interface NotificationValidatorDashboardDetail {
   dashboard_name: string;
   group_name: string;
@@ -72,6 +77,7 @@ export interface NotificationValidatorDashboardDetail {
   withdrawal: NotificationEventWithdrawal[];
   min_collateral: Address[]; // node addresses
   max_collateral: Address[]; // node addresses
+  pool_operation_not_included: NotificationEventPoolOperation[];
 }
 export type InternalGetUserNotificationsValidatorDashboardResponse = ApiDataResponse<NotificationValidatorDashboardDetail>;
 export interface NotificationEventExecution {
@@ -165,11 +171,26 @@ export interface NotificationSettingsGeneral {
   machine_cpu_usage_threshold: number /* float64 */;
   is_machine_memory_usage_subscribed: boolean;
   machine_memory_usage_threshold: number /* float64 */;
+  /**
+   * delivery policy for email, push and chat notifications, critical events (e.g. slashings) are always delivered immediately
+   */
+  delivery_mode: 'immediate' | 'hourly_digest' | 'daily_digest';
+  is_quiet_hours_enabled: boolean;
+  quiet_hours_start: number /* uint64 */; // hour of the day in the given timezone
+  quiet_hours_end: number /* uint64 */; // hour of the day in the given timezone
+  timezone: string; // IANA time zone name
+  group_delivery_modes: NotificationGroupDeliveryMode[]; // overrides DeliveryMode for single dashboard groups
+}
+export interface NotificationGroupDeliveryMode {
+  dashboard_id: number /* uint64 */;
+  group_id: number /* uint64 */;
+  delivery_mode: 'immediate' | 'hourly_digest' | 'daily_digest';
 }
 export type InternalPutUserNotificationSettingsGeneralResponse = ApiDataResponse<NotificationSettingsGeneral>;
 export interface NotificationSettings {
   general_settings: NotificationSettingsGeneral;
   has_machines: boolean;
+  is_telegram_linked: boolean;
   networks: NotificationNetwork[];
   paired_devices: NotificationPairedDevice[];
   clients: NotificationSettingsClient[];
@@ -178,6 +199,10 @@ export type InternalGetUserNotificationSettingsResponse = ApiDataResponse<Notifi
 export interface NotificationSettingsValidatorDashboard {
   webhook_url: string;
   is_webhook_discord_enabled: boolean;
+  webhook_signing_secret?: string; // read-only, used to verify the `X-Signature` header of webhook deliveries
+  is_telegram_enabled: boolean;
+  slack_webhook_url: string;
+  matrix_room_id: string;
   is_validator_offline_subscribed: boolean;
   is_group_efficiency_below_subscribed: boolean;
   group_efficiency_below_threshold: number /* float64 */;
@@ -187,10 +212,15 @@ export interface NotificationSettingsValidatorDashboard {
   is_sync_subscribed: boolean;
   is_withdrawal_processed_subscribed: boolean;
   is_slashed_subscribed: boolean;
+  is_pool_operation_not_included_subscribed: boolean;
+  pool_operation_not_included_threshold: number /* uint64 */; // epochs
   is_max_collateral_subscribed: boolean;
   max_collateral_threshold: number /* float64 */;
   is_min_collateral_subscribed: boolean;
   min_collateral_threshold: number /* float64 */;
+  is_income_report_subscribed: boolean;
+  income_report_currency: 'USD' | 'EUR' | 'GBP' | 'CAD' | 'JPY' | 'CNY' | 'AUD' | 'RUB';
+  income_report_format: 'pdf' | 'csv' | 'koinly' | 'cointracking';
 }
 export type InternalPutUserNotificationSettingsValidatorDashboardResponse = ApiDataResponse<NotificationSettingsValidatorDashboard>;
 export interface NotificationSettingsAccountDashboard {
@@ -219,3 +249,31 @@ export interface NotificationSettingsDashboardsTableRow {
   chain_ids: number /* uint64 */[];
 }
 export type InternalGetUserNotificationSettingsDashboardsResponse = ApiPagingResponse<NotificationSettingsDashboardsTableRow>;
+export interface NotificationWebhookSigningSecret {
+  webhook_signing_secret: string;
+}
+export type InternalPostUserNotificationSettingsValidatorDashboardWebhookSecretResponse = ApiDataResponse<NotificationWebhookSigningSecret>;
+export interface NotificationTelegramLink {
+  link_url: string; // opens a chat with the telegram bot, starting the chat links it to the account
+  expires_timestamp: number /* int64 */;
+}
+export type InternalPostUserNotificationsTelegramLinkResponse = ApiDataResponse<NotificationTelegramLink>;
+/**
+ * ------------------------------------------------------------
+ * Webhook Dead Letters
+ */
+export interface NotificationWebhookDeadLetter {
+  id: number /* uint64 */;
+  channel: 'webhook' | 'webhook_discord' | 'telegram' | 'slack' | 'matrix';
+  webhook_url: string;
+  dashboard_id?: number /* uint64 */;
+  group_id?: number /* uint64 */;
+  created_timestamp: number /* int64 */;
+  failed_timestamp: number /* int64 */;
+  redelivered_timestamp?: number /* int64 */;
+  attempts: number /* uint64 */;
+  last_status?: string;
+  last_error?: string;
+  payload: string; // json encoded request body of the failed delivery
+}
+export type InternalGetUserNotificationWebhookDeadLettersResponse = ApiPagingResponse<NotificationWebhookDeadLetter>;
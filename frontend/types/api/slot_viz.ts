@@ -47,3 +47,22 @@ export interface SlotVizEpoch {
   slots?: VDBSlotVizSlot[]; // only on dashboard page
 }
 export type GetValidatorDashboardSlotVizResponse = ApiDataResponse<SlotVizEpoch[]>;
+/**
+ * ------------------------------------------------------------
+ * Slot Duties Stream
+ */
+export interface VDBSlotDutyResult {
+  validator: number /* uint64 */;
+  group_id: number /* uint64 */;
+  duty: 'proposal' | 'attestation' | 'sync';
+  status: 'success' | 'failed';
+  duty_slot: number /* uint64 */; // slot the duty was assigned to, differs from the event slot for attestations
+}
+/**
+ * VDBSlotDutiesEvent is pushed to the dashboard event stream for every new head slot that contains duties of the dashboard validators
+ */
+export interface VDBSlotDutiesEvent {
+  slot: number /* uint64 */;
+  epoch: number /* uint64 */;
+  duties: VDBSlotDutyResult[];
+}
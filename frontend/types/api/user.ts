@@ -30,6 +30,30 @@ export interface EmailUpdate {
   pending_email: string;
 }
 export type InternalPostUserEmailResponse = ApiDataResponse<EmailUpdate>;
+export interface ApiKey {
+  id: number /* uint64 */;
+  name: string;
+  key: string; // the full key is only returned when it is created or rotated
+  scopes: ('read_dashboards' | 'manage_dashboards' | 'manage_notifications' | 'push_machine_metrics' | 'manage_broadcasts')[];
+  networks: number /* uint64 */[]; // chain ids the key is restricted to, empty if the key can be used on all networks
+  created_at: number /* int64 */;
+}
+export type InternalGetUserApiKeysResponse = ApiDataResponse<ApiKey[]>;
+export type InternalPostUserApiKeysResponse = ApiDataResponse<ApiKey>;
+export type InternalPutUserApiKeyResponse = ApiDataResponse<ApiKey>;
+export interface ApiUsageDataPoint {
+  timestamp: number /* int64 */; // start of the aggregation interval
+  requests: number /* uint64 */;
+  weight: number /* uint64 */; // requests multiplied with the weight of the endpoint, this is what counts towards the rate limits
+}
+export interface ApiKeyEndpointUsage {
+  api_key_id: number /* uint64 */;
+  endpoint: string;
+  requests: number /* uint64 */;
+  weight: number /* uint64 */;
+  data: ApiUsageDataPoint[];
+}
+export type GetUserApiUsageResponse = ApiDataResponse<ApiKeyEndpointUsage[]>;
 export interface AdConfigurationUpdateData {
   jquery_selector: string;
   insert_mode: string;
@@ -121,6 +145,7 @@ export interface PremiumPerks {
   machine_monitoring_history_seconds: number /* uint64 */;
   notifications_machine_custom_threshold: boolean;
   notifications_validator_dashboard_group_efficiency: boolean;
+  validator_dashboard_exports: boolean;
 }
 export interface StripeCreateCheckoutSession {
   sessionId?: string;
@@ -129,10 +154,93 @@ export interface StripeCreateCheckoutSession {
 export interface StripeCustomerPortal {
   url: string;
 }
-export interface OAuthAppData {
-  ID: number /* uint64 */;
-  Owner: number /* uint64 */;
-  AppName: string;
-  RedirectURI: string;
-  Active: boolean;
+export interface OAuthApp {
+  id: number /* uint64 */;
+  name: string;
+  client_id: string;
+  client_secret?: string; // only returned once after the app was created
+  redirect_uri: string;
+  scopes: string[];
+  confidential: boolean;
+  created_at: number /* int64 */;
+}
+export type InternalGetUserOAuthAppsResponse = ApiDataResponse<OAuthApp[]>;
+export type InternalPostUserOAuthAppsResponse = ApiDataResponse<OAuthApp>;
+/**
+ * OAuthConsentRequest is shown to the user before an app gets access to their account
+ */
+export interface OAuthConsentRequest {
+  client_id: string;
+  app_name: string;
+  redirect_uri: string;
+  scopes: string[];
+  first_party: boolean;
+}
+export type InternalGetOAuthAuthorizeResponse = ApiDataResponse<OAuthConsentRequest>;
+export interface OAuthAuthorizeRedirect {
+  redirect_uri: string;
+}
+export type InternalPostOAuthAuthorizeResponse = ApiDataResponse<OAuthAuthorizeRedirect>;
+/**
+ * OAuthTokenResponse and OAuthErrorResponse are defined by RFC 6749 and not wrapped like other responses
+ */
+export interface OAuthTokenResponse {
+  access_token: string;
+  token_type: string;
+  expires_in: number /* uint64 */;
+  refresh_token: string;
+  scope: string;
+}
+export interface OAuthErrorResponse {
+  error: string;
+  error_description?: string;
+}
+export interface LoginResult {
+  second_factor_required: boolean;
+  second_factor_methods?: ('totp' | 'recovery_code' | 'webauthn')[];
+}
+export type InternalPostLoginResponse = ApiDataResponse<LoginResult>;
+export interface TotpSetup {
+  secret: string;
+  uri: string; // otpauth uri, usually shown as qr code
+}
+export type InternalPostUserTotpResponse = ApiDataResponse<TotpSetup>;
+export type InternalPostUserRecoveryCodesResponse = ApiDataResponse<string[]>;
+export interface UserWebAuthnCredential {
+  id: number /* uint64 */;
+  name: string;
+  created_at: number /* int64 */;
+  last_used_at?: number /* int64 */;
+}
+export type InternalPostUserWebAuthnCredentialsResponse = ApiDataResponse<UserWebAuthnCredential>;
+export interface UserSecondFactors {
+  totp_enabled: boolean;
+  recovery_codes_remaining: number /* uint64 */;
+  webauthn_credentials: UserWebAuthnCredential[];
+}
+export type InternalGetUserSecondFactorsResponse = ApiDataResponse<UserSecondFactors>;
+/**
+ * WebAuthnOptions contains the parameters for navigator.credentials.create (registration) and navigator.credentials.get (login).
+ * Binary values are base64url encoded.
+ */
+export interface WebAuthnOptions {
+  challenge: string;
+  rp_id: string;
+  rp_name?: string;
+  user_id?: string;
+  user_name?: string;
+  algorithms?: number /* int64 */[];
+  credential_ids: string[]; // credentials to exclude during registration or to allow during login
+  timeout: number /* uint64 */; // milliseconds
+}
+export type InternalPostWebAuthnChallengesResponse = ApiDataResponse<WebAuthnOptions>;
+export interface UserSession {
+  id: string;
+  type: 'web' | 'mobile';
+  device_name?: string;
+  user_agent?: string;
+  ip?: string;
+  created_at?: number /* int64 */;
+  current: boolean;
 }
+export type InternalGetUserSessionsResponse = ApiDataResponse<UserSession[]>;
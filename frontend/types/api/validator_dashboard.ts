@@ -15,6 +15,13 @@ export interface VDBOverviewBalances {
   effective: string /* decimal.Decimal */;
   staked_eth: string /* decimal.Decimal */;
 }
+/**
+ * VDBOperatorShare is the share of the validator income that goes to the node operator for validators of a staking protocol
+ */
+export interface VDBOperatorShare {
+  protocol: string; // lido_csm, ssv or obol
+  operator_share: number /* float64 */;
+}
 export interface VDBOverviewData {
   name?: string;
   network: number /* uint64 */;
@@ -25,6 +32,7 @@ export interface VDBOverviewData {
   apr: PeriodicValues<ClElValue<number /* float64 */>>;
   chart_history_seconds: ChartHistorySeconds;
   balances: VDBOverviewBalances;
+  operator_shares: VDBOperatorShare[];
 }
 export type GetValidatorDashboardResponse = ApiDataResponse<VDBOverviewData>;
 export interface VDBPostArchivingReturnData {
@@ -213,8 +221,13 @@ export interface VDBConsensusDepositsTableRow {
   epoch: number /* uint64 */;
   slot: number /* uint64 */;
   withdrawal_credential: Hash;
-  amount: string /* decimal.Decimal */;
+  amount: string /* decimal.Decimal */; // zero for consolidations, the request does not contain the moved balance
   signature: Hash;
+  type: 'deposit' | 'deposit_request' | 'consolidation';
+  /**
+   * only set for consolidations, the validator whose balance is moved into this one
+   */
+  consolidated_from?: number /* uint64 */;
 }
 export type GetValidatorDashboardConsensusLayerDepositsResponse = ApiPagingResponse<VDBConsensusDepositsTableRow>;
 export interface VDBTotalExecutionDepositsData {
@@ -236,6 +249,7 @@ export interface VDBWithdrawalsTableRow {
   group_id: number /* uint64 */;
   recipient: Address;
   amount: string /* decimal.Decimal */;
+  type: 'full' | 'partial';
   is_missing_estimate: boolean;
 }
 export type GetValidatorDashboardWithdrawalsResponse = ApiPagingResponse<VDBWithdrawalsTableRow>;
@@ -243,6 +257,25 @@ export interface VDBTotalWithdrawalsData {
   total_amount: string /* decimal.Decimal */;
 }
 export type GetValidatorDashboardTotalWithdrawalsResponse = ApiDataResponse<VDBTotalWithdrawalsData>;
+/**
+ * ------------------------------------------------------------
+ * Pool Operations Tab
+ */
+export interface VDBPoolOperationsTableRow {
+  index: number /* uint64 */;
+  group_id: number /* uint64 */;
+  type: 'voluntary_exit' | 'bls_to_execution_change';
+  status: 'pending' | 'included' | 'dropped';
+  signature: Hash;
+  exit_epoch?: number /* uint64 */;
+  to_execution_address?: Address;
+  first_seen_ts: number /* int64 */;
+  first_seen_slot: number /* uint64 */;
+  last_seen_slot: number /* uint64 */;
+  included_slot?: number /* uint64 */;
+  dropped_slot?: number /* uint64 */;
+}
+export type GetValidatorDashboardPoolOperationsResponse = ApiDataResponse<VDBPoolOperationsTableRow[]>;
 /**
  * ------------------------------------------------------------
  * Rocket Pool Tab
@@ -309,6 +342,7 @@ export interface VDBPostReturnData {
   name: string;
   network: number /* uint64 */;
   created_at: number /* int64 */;
+  organization_id?: number /* uint64 */;
 }
 export interface VDBPostCreateGroupData {
   id: number /* uint64 */;